@@ -0,0 +1,198 @@
+package bolt
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/charmbracelet/log"
+	"github.com/mkolibaba/gophkeeper/client"
+	"go.etcd.io/bbolt"
+	"time"
+)
+
+const accountKey = "account"
+
+// account - данные для входа без связи с сервером.
+type account struct {
+	// VaultKey - ключ хранилища, зашифрованный ключом из мастер-пароля,
+	// в том виде, в каком его хранит сервер.
+	VaultKey []byte
+	// LastLogin - время последнего входа на сервере.
+	LastLogin time.Time
+}
+
+// AuthorizationService запоминает в кэше зашифрованный ключ хранилища
+// после каждого входа на сервере. Если сервер недоступен, хранилище
+// открывается этим ключом, и клиент работает с кэшем без сессии: изменения
+// копятся в очереди до следующего входа на сервере.
+type AuthorizationService struct {
+	remote client.AuthorizationService
+	cipher client.Cipher
+	db     *DB
+	logger *log.Logger
+	now    func() time.Time
+}
+
+func NewAuthorizationService(
+	remote client.AuthorizationService,
+	cipher client.Cipher,
+	db *DB,
+	logger *log.Logger,
+) *AuthorizationService {
+	return &AuthorizationService{
+		remote: remote,
+		cipher: cipher,
+		db:     db,
+		logger: logger,
+		now:    time.Now,
+	}
+}
+
+func (s *AuthorizationService) Authorize(ctx context.Context, login string, password string) (client.Tokens, error) {
+	tokens, err := s.remote.Authorize(ctx, login, password)
+	if isUnavailable(err) {
+		return s.unlockOffline(login, password, err)
+	}
+	if err != nil {
+		return client.Tokens{}, err
+	}
+
+	s.saveAccount(login, tokens.VaultKey)
+	return tokens, nil
+}
+
+func (s *AuthorizationService) Register(ctx context.Context, login string, password string) (client.Tokens, error) {
+	tokens, err := s.remote.Register(ctx, login, password)
+	if err != nil {
+		return client.Tokens{}, err
+	}
+
+	s.saveAccount(login, tokens.VaultKey)
+	return tokens, nil
+}
+
+func (s *AuthorizationService) VerifyMFA(
+	ctx context.Context,
+	challenge string,
+	login string,
+	password string,
+	code string,
+) (client.Tokens, error) {
+	tokens, err := s.remote.VerifyMFA(ctx, challenge, login, password, code)
+	if err != nil {
+		return client.Tokens{}, err
+	}
+
+	s.saveAccount(login, tokens.VaultKey)
+	return tokens, nil
+}
+
+// unlockOffline открывает хранилище ключом, сохраненным при последнем входе
+// на сервере. Если ключа нет, возвращает remoteErr. Токены сессии при этом
+// пустые.
+func (s *AuthorizationService) unlockOffline(login string, password string, remoteErr error) (client.Tokens, error) {
+	var acc account
+	var found bool
+	err := s.db.viewUser(login, func(b *bbolt.Bucket) error {
+		v := b.Get([]byte(accountKey))
+		if v == nil {
+			return nil
+		}
+		found = true
+		return json.Unmarshal(v, &acc)
+	})
+	if err != nil {
+		return client.Tokens{}, fmt.Errorf("unlock offline: %w", err)
+	}
+	if !found {
+		return client.Tokens{}, remoteErr
+	}
+
+	key, err := s.cipher.DeriveKeys(login, password)
+	if err != nil {
+		return client.Tokens{}, err
+	}
+	if err := s.cipher.Unlock(key, acc.VaultKey); err != nil {
+		return client.Tokens{}, fmt.Errorf("unlock vault: %w", err)
+	}
+
+	s.logger.Warn("server is unavailable, vault unlocked from cache", "login", login, "last_login", acc.LastLogin)
+	return client.Tokens{}, nil
+}
+
+// saveAccount запоминает ключ хранилища пользователя. Вход на сервере уже
+// выполнен, поэтому ошибка только записывается в лог: без сохраненного
+// ключа не получится лишь войти без связи с сервером.
+func (s *AuthorizationService) saveAccount(login string, vaultKey []byte) {
+	v, err := json.Marshal(account{VaultKey: vaultKey, LastLogin: s.now()})
+	if err == nil {
+		err = s.db.updateUser(login, func(b *bbolt.Bucket) error {
+			return b.Put([]byte(accountKey), v)
+		})
+	}
+	if err != nil {
+		s.logger.Error("failed to save vault key to cache", "login", login, "err", err)
+	}
+}
+
+func (s *AuthorizationService) Logout(ctx context.Context) error {
+	return s.remote.Logout(ctx)
+}
+
+func (s *AuthorizationService) ListSessions(ctx context.Context) ([]client.Session, error) {
+	return s.remote.ListSessions(ctx)
+}
+
+func (s *AuthorizationService) RevokeSession(ctx context.Context, id string) error {
+	return s.remote.RevokeSession(ctx, id)
+}
+
+func (s *AuthorizationService) SetupMFA(ctx context.Context) (client.MFASetup, error) {
+	return s.remote.SetupMFA(ctx)
+}
+
+func (s *AuthorizationService) EnableMFA(ctx context.Context, code string) ([]string, error) {
+	return s.remote.EnableMFA(ctx, code)
+}
+
+func (s *AuthorizationService) DisableMFA(ctx context.Context, code string) error {
+	return s.remote.DisableMFA(ctx, code)
+}
+
+// ChangePassword меняет мастер-пароль на сервере и заново шифрует новым
+// паролем сохраненный ключ хранилища, чтобы прежний пароль больше не
+// открывал кэш.
+func (s *AuthorizationService) ChangePassword(
+	ctx context.Context,
+	login string,
+	currentPassword string,
+	newPassword string,
+) error {
+	if err := s.remote.ChangePassword(ctx, login, currentPassword, newPassword); err != nil {
+		return err
+	}
+
+	key, err := s.cipher.DeriveKeys(login, newPassword)
+	if err != nil {
+		return err
+	}
+	vaultKey, err := s.cipher.WrapVaultKey(key)
+	if err != nil {
+		return err
+	}
+
+	s.saveAccount(login, vaultKey)
+	return nil
+}
+
+// DeleteAccount удаляет учетную запись на сервере и ее кэш.
+func (s *AuthorizationService) DeleteAccount(ctx context.Context, login string, password string) error {
+	if err := s.remote.DeleteAccount(ctx, login, password); err != nil {
+		return err
+	}
+
+	if err := s.db.removeUser(login); err != nil {
+		return fmt.Errorf("delete account: %w", err)
+	}
+	return nil
+}
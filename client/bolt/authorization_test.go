@@ -0,0 +1,137 @@
+package bolt
+
+import (
+	"context"
+	"errors"
+	"github.com/charmbracelet/log"
+	"github.com/mkolibaba/gophkeeper/client"
+	"github.com/mkolibaba/gophkeeper/client/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"io"
+	"path/filepath"
+	"testing"
+)
+
+var errInvalidPassword = errors.New("invalid master password")
+
+// newTestCipher возвращает шифр, который открывает ключ хранилища, только
+// если он зашифрован ключом из того же пароля.
+func newTestCipher() *mock.CipherMock {
+	return &mock.CipherMock{
+		DeriveKeysFunc: func(login string, password string) (client.MasterKey, error) {
+			return client.MasterKey{AuthKey: "auth " + password, EncryptionKey: []byte(password)}, nil
+		},
+		UnlockFunc: func(key client.MasterKey, vaultKey []byte) error {
+			if string(vaultKey) != "vault key for "+string(key.EncryptionKey) {
+				return errInvalidPassword
+			}
+			return nil
+		},
+		WrapVaultKeyFunc: func(key client.MasterKey) ([]byte, error) {
+			return []byte("vault key for " + string(key.EncryptionKey)), nil
+		},
+	}
+}
+
+func TestAuthorizationOffline(t *testing.T) {
+	offline := false
+	password := "123"
+	remote := &mock.AuthorizationServiceMock{
+		AuthorizeFunc: func(ctx context.Context, login string, p string) (client.Tokens, error) {
+			if offline {
+				return client.Tokens{}, errUnavailable
+			}
+			if p != password {
+				return client.Tokens{}, status.Error(codes.InvalidArgument, "invalid login or password")
+			}
+			return client.Tokens{AccessToken: "token", VaultKey: []byte("vault key for " + password)}, nil
+		},
+		ChangePasswordFunc: func(ctx context.Context, login string, currentPassword string, newPassword string) error {
+			password = newPassword
+			return nil
+		},
+		DeleteAccountFunc: func(ctx context.Context, login string, password string) error {
+			return nil
+		},
+	}
+	cipher := newTestCipher()
+	srv := NewAuthorizationService(remote, cipher, newTestDB(t), log.New(io.Discard))
+
+	t.Run("no_cached_key", func(t *testing.T) {
+		offline = true
+		_, err := srv.Authorize(t.Context(), "alice", "123")
+		require.Equal(t, codes.Unavailable, status.Code(err))
+	})
+	t.Run("online", func(t *testing.T) {
+		offline = false
+		tokens, err := srv.Authorize(t.Context(), "alice", "123")
+		require.NoError(t, err)
+		require.Equal(t, "token", tokens.AccessToken)
+	})
+	t.Run("unlock_offline", func(t *testing.T) {
+		offline = true
+		tokens, err := srv.Authorize(t.Context(), "alice", "123")
+		require.NoError(t, err)
+		require.Empty(t, tokens.AccessToken)
+
+		calls := cipher.UnlockCalls()
+		require.Equal(t, []byte("vault key for 123"), calls[len(calls)-1].VaultKey)
+
+		_, err = srv.Authorize(t.Context(), "alice", "wrong")
+		require.ErrorIs(t, err, errInvalidPassword)
+
+		// Ключ другого пользователя не подходит.
+		_, err = srv.Authorize(t.Context(), "bob", "123")
+		require.Equal(t, codes.Unavailable, status.Code(err))
+	})
+	t.Run("change_password", func(t *testing.T) {
+		offline = false
+		_, err := srv.Authorize(t.Context(), "alice", "123")
+		require.NoError(t, err)
+		require.NoError(t, srv.ChangePassword(t.Context(), "alice", "123", "456"))
+
+		// Прежний пароль больше не открывает кэш.
+		offline = true
+		_, err = srv.Authorize(t.Context(), "alice", "123")
+		require.ErrorIs(t, err, errInvalidPassword)
+		_, err = srv.Authorize(t.Context(), "alice", "456")
+		require.NoError(t, err)
+	})
+	t.Run("delete_account", func(t *testing.T) {
+		require.NoError(t, srv.DeleteAccount(t.Context(), "alice", "456"))
+
+		_, err := srv.Authorize(t.Context(), "alice", "456")
+		require.Equal(t, codes.Unavailable, status.Code(err))
+	})
+}
+
+func TestLoginWithoutSession(t *testing.T) {
+	// Хранилище открыто ключом из кэша: токена нет, и изменения копятся
+	// в очереди, даже если сервер уже доступен.
+	db, err := Open(filepath.Join(t.TempDir(), "vault.db"), &mock.UserServiceMock{
+		GetUserLoginFunc: func() string {
+			return "alice"
+		},
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		db.Close()
+	})
+
+	server := newFakeLoginServer()
+	sync := NewSynchronizer(db, server.syncMock(), log.New(io.Discard))
+	srv := NewLoginService(server.mock(), db, sync)
+
+	require.NoError(t, srv.Save(t.Context(), client.LoginData{Name: "github", Login: "alice"}))
+	require.Empty(t, server.logins)
+
+	logins, err := srv.GetAll(t.Context())
+	require.NoError(t, err)
+	require.Len(t, logins, 1)
+
+	pending, err := sync.Pending()
+	require.NoError(t, err)
+	require.Equal(t, 1, pending)
+}
@@ -0,0 +1,69 @@
+package bolt
+
+import (
+	"context"
+	"github.com/mkolibaba/gophkeeper/client"
+)
+
+// BinaryService кэширует метаданные бинарных данных. Загрузка и скачивание
+// файлов требуют связи с сервером, остальные изменения могут быть отложены.
+type BinaryService struct {
+	cache  *cache[client.BinaryData, client.BinaryDataUpdate]
+	remote client.BinaryService
+}
+
+func NewBinaryService(remote client.BinaryService, db *DB, sync *Synchronizer) *BinaryService {
+	c := &cache[client.BinaryData, client.BinaryDataUpdate]{
+		db:           db,
+		sync:         sync,
+		kind:         client.DataKindBinary,
+		remoteSave:   remote.Save,
+		remoteUpdate: remote.Update,
+		remoteRemove: remote.Remove,
+		withID: func(data client.BinaryData, id int64) client.BinaryData {
+			data.ID = id
+			return data
+		},
+		updateKey: func(data client.BinaryDataUpdate) (int64, int64) {
+			return data.ID, data.Version
+		},
+		apply: func(data *client.BinaryData, update client.BinaryDataUpdate) {
+			setIfNotNil(&data.Name, update.Name)
+			setIfNotNil(&data.Notes, update.Notes)
+		},
+	}
+	c.register()
+
+	return &BinaryService{
+		cache:  c,
+		remote: remote,
+	}
+}
+
+func (s *BinaryService) Save(ctx context.Context, data client.BinaryData) error {
+	return s.cache.sync.online(ctx, func() error {
+		return s.remote.Save(ctx, data)
+	})
+}
+
+func (s *BinaryService) GetAll(ctx context.Context) ([]client.BinaryData, error) {
+	return s.cache.getAll(ctx)
+}
+
+func (s *BinaryService) Update(ctx context.Context, data client.BinaryDataUpdate) error {
+	return s.cache.update(ctx, data)
+}
+
+func (s *BinaryService) Remove(ctx context.Context, id int64, version int64) error {
+	return s.cache.remove(ctx, id, version)
+}
+
+func (s *BinaryService) Download(ctx context.Context, id int64) (string, error) {
+	var path string
+	err := s.cache.sync.online(ctx, func() error {
+		var err error
+		path, err = s.remote.Download(ctx, id)
+		return err
+	})
+	return path, err
+}
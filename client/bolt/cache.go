@@ -0,0 +1,222 @@
+package bolt
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/mkolibaba/gophkeeper/client"
+	"go.etcd.io/bbolt"
+)
+
+var errNotCached = errors.New("data is not available offline")
+
+// cache реализует общую логику кэширующих сервисов: чтение из кэша,
+// синхронизированного с сервером, и запись с постановкой в очередь,
+// если сервер недоступен. T - тип данных, U - тип обновления данных.
+type cache[T client.Data, U any] struct {
+	db   *DB
+	sync *Synchronizer
+	kind client.DataKind
+	coll collection[T]
+
+	remoteSave   func(ctx context.Context, data T) error
+	remoteUpdate func(ctx context.Context, data U) error
+	remoteRemove func(ctx context.Context, id int64, version int64) error
+
+	// withID возвращает копию данных с переданным идентификатором.
+	withID func(data T, id int64) T
+	// updateKey возвращает идентификатор и версию обновляемых данных.
+	updateKey func(data U) (int64, int64)
+	// apply применяет обновление к данным.
+	apply func(data *T, update U)
+}
+
+func (c *cache[T, U]) register() {
+	c.coll = collection[T]{name: string(c.kind)}
+	c.sync.register(c.kind, c)
+}
+
+func (c *cache[T, U]) save(ctx context.Context, data T) error {
+	err := c.sync.online(ctx, func() error {
+		return c.remoteSave(ctx, data)
+	})
+	if !isUnavailable(err) {
+		// Новые данные попадут в кэш при следующей синхронизации.
+		return err
+	}
+
+	return c.db.update(func(b *bbolt.Bucket) error {
+		id, err := localID(b)
+		if err != nil {
+			return err
+		}
+
+		data = c.withID(data, id)
+		if err := c.coll.put(b, data); err != nil {
+			return err
+		}
+		return c.enqueue(b, actionSave, id, 0, data)
+	})
+}
+
+func (c *cache[T, U]) getAll(ctx context.Context) ([]T, error) {
+	if err := c.sync.Pull(ctx); err != nil && !isUnavailable(err) {
+		return nil, err
+	}
+
+	var items []T
+	err := c.db.view(func(b *bbolt.Bucket) error {
+		var err error
+		items, err = c.coll.all(b)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get all: %w", err)
+	}
+	return items, nil
+}
+
+func (c *cache[T, U]) update(ctx context.Context, data U) error {
+	id, version := c.updateKey(data)
+
+	var err error
+	if id > 0 {
+		err = c.sync.online(ctx, func() error {
+			return c.remoteUpdate(ctx, data)
+		})
+		if err != nil && !isUnavailable(err) {
+			return err
+		}
+	}
+	online := err == nil && id > 0
+
+	return c.db.update(func(b *bbolt.Bucket) error {
+		item, ok, err := c.coll.get(b, id)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			if online {
+				return nil
+			}
+			return errNotCached
+		}
+
+		c.apply(&item, data)
+		if err := c.coll.put(b, item); err != nil {
+			return err
+		}
+
+		key, op, queued, err := findQueued(b, c.kind, id)
+		if err != nil {
+			return err
+		}
+		if online {
+			if queued {
+				// Отклоненное изменение записи заменено новым.
+				return dequeue(b, key)
+			}
+			return nil
+		}
+		if queued && (op.Conflict == nil || op.Action == actionSave) {
+			// Запись уже ждет отправки: достаточно обновить отложенную
+			// операцию, сохранив исходную версию. Отклоненное создание
+			// записи отправляется повторно.
+			op.Conflict = nil
+			return rewriteQueued(b, key, op, item)
+		}
+		if queued {
+			// Отклоненное изменение заменяется новым, сделанным
+			// на основе версии, которую видит пользователь.
+			if err := dequeue(b, key); err != nil {
+				return err
+			}
+		}
+		return c.enqueue(b, actionUpdate, id, version, item)
+	})
+}
+
+func (c *cache[T, U]) remove(ctx context.Context, id int64, version int64) error {
+	var err error
+	if id > 0 {
+		err = c.sync.online(ctx, func() error {
+			return c.remoteRemove(ctx, id, version)
+		})
+		if err != nil && !isUnavailable(err) {
+			return err
+		}
+	}
+	online := err == nil && id > 0
+
+	return c.db.update(func(b *bbolt.Bucket) error {
+		if err := c.coll.delete(b, id); err != nil {
+			return err
+		}
+
+		key, op, queued, err := findQueued(b, c.kind, id)
+		if err != nil {
+			return err
+		}
+		if queued {
+			if err := dequeue(b, key); err != nil {
+				return err
+			}
+			if online || op.Action == actionSave {
+				// Данные так и не попали на сервер либо отклоненное
+				// изменение заменено удалением.
+				return nil
+			}
+			if op.Conflict == nil {
+				version = op.Version
+			}
+		}
+		if online {
+			return nil
+		}
+		if id < 0 {
+			return nil
+		}
+		return c.enqueue(b, actionRemove, id, version, nil)
+	})
+}
+
+func (c *cache[T, U]) enqueue(b *bbolt.Bucket, action action, id int64, version int64, data any) error {
+	op := operation{
+		Kind:    c.kind,
+		Action:  action,
+		ID:      id,
+		Version: version,
+	}
+	if data != nil {
+		var err error
+		if op.Data, err = json.Marshal(data); err != nil {
+			return err
+		}
+	}
+	return enqueue(b, op)
+}
+
+func (c *cache[T, U]) decode(data []byte) (client.Data, error) {
+	var item T
+	if err := json.Unmarshal(data, &item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+func (c *cache[T, U]) put(b *bbolt.Bucket, data client.Data) error {
+	item, ok := data.(T)
+	if !ok {
+		return fmt.Errorf("unexpected %s data type %T", c.kind, data)
+	}
+	return c.coll.put(b, item)
+}
+
+func (c *cache[T, U]) delete(b *bbolt.Bucket, id int64) error {
+	return c.coll.delete(b, id)
+}
+
+func (c *cache[T, U]) clear(b *bbolt.Bucket) error {
+	return c.coll.clear(b)
+}
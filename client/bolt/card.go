@@ -0,0 +1,57 @@
+package bolt
+
+import (
+	"context"
+	"github.com/mkolibaba/gophkeeper/client"
+)
+
+// CardService кэширует данные карт локально и позволяет работать с ними
+// без связи с сервером.
+type CardService struct {
+	cache *cache[client.CardData, client.CardDataUpdate]
+}
+
+func NewCardService(remote client.CardService, db *DB, sync *Synchronizer) *CardService {
+	c := &cache[client.CardData, client.CardDataUpdate]{
+		db:           db,
+		sync:         sync,
+		kind:         client.DataKindCard,
+		remoteSave:   remote.Save,
+		remoteUpdate: remote.Update,
+		remoteRemove: remote.Remove,
+		withID: func(data client.CardData, id int64) client.CardData {
+			data.ID = id
+			return data
+		},
+		updateKey: func(data client.CardDataUpdate) (int64, int64) {
+			return data.ID, data.Version
+		},
+		apply: func(data *client.CardData, update client.CardDataUpdate) {
+			setIfNotNil(&data.Name, update.Name)
+			setIfNotNil(&data.Number, update.Number)
+			setIfNotNil(&data.ExpDate, update.ExpDate)
+			setIfNotNil(&data.CVV, update.CVV)
+			setIfNotNil(&data.Cardholder, update.Cardholder)
+			setIfNotNil(&data.Notes, update.Notes)
+		},
+	}
+	c.register()
+
+	return &CardService{cache: c}
+}
+
+func (s *CardService) Save(ctx context.Context, data client.CardData) error {
+	return s.cache.save(ctx, data)
+}
+
+func (s *CardService) GetAll(ctx context.Context) ([]client.CardData, error) {
+	return s.cache.getAll(ctx)
+}
+
+func (s *CardService) Update(ctx context.Context, data client.CardDataUpdate) error {
+	return s.cache.update(ctx, data)
+}
+
+func (s *CardService) Remove(ctx context.Context, id int64, version int64) error {
+	return s.cache.remove(ctx, id, version)
+}
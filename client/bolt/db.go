@@ -0,0 +1,178 @@
+package bolt
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/mkolibaba/gophkeeper/client"
+	"go.etcd.io/bbolt"
+	bbolterrors "go.etcd.io/bbolt/errors"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// DB - локальный кэш хранилища. Данные каждого пользователя лежат
+// в отдельном бакете, названном по логину. Секретные поля попадают в кэш
+// уже зашифрованными, так как шифрование выполняется уровнем выше.
+type DB struct {
+	db          *bbolt.DB
+	userService client.UserService
+}
+
+func Open(path string, userService client.UserService) (*DB, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, fmt.Errorf("open: %w", err)
+	}
+
+	db, err := bbolt.Open(path, 0600, &bbolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("open: %w", err)
+	}
+
+	return &DB{
+		db:          db,
+		userService: userService,
+	}, nil
+}
+
+func (db *DB) Close() error {
+	return db.db.Close()
+}
+
+// view выполняет fn в транзакции чтения над бакетом текущего пользователя.
+// Если бакета еще нет, fn не вызывается.
+func (db *DB) view(fn func(b *bbolt.Bucket) error) error {
+	return db.viewUser(db.userService.GetUserLogin(), fn)
+}
+
+// viewUser - аналог view для бакета пользователя login.
+func (db *DB) viewUser(login string, fn func(b *bbolt.Bucket) error) error {
+	return db.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(userBucketName(login))
+		if b == nil {
+			return nil
+		}
+		return fn(b)
+	})
+}
+
+// update выполняет fn в транзакции записи над бакетом текущего пользователя.
+func (db *DB) update(fn func(b *bbolt.Bucket) error) error {
+	return db.updateUser(db.userService.GetUserLogin(), fn)
+}
+
+// updateUser - аналог update для бакета пользователя login.
+func (db *DB) updateUser(login string, fn func(b *bbolt.Bucket) error) error {
+	return db.db.Update(func(tx *bbolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists(userBucketName(login))
+		if err != nil {
+			return err
+		}
+		return fn(b)
+	})
+}
+
+// removeUser удаляет бакет пользователя login со всеми данными.
+func (db *DB) removeUser(login string) error {
+	return db.db.Update(func(tx *bbolt.Tx) error {
+		err := tx.DeleteBucket(userBucketName(login))
+		if errors.Is(err, bbolterrors.ErrBucketNotFound) {
+			return nil
+		}
+		return err
+	})
+}
+
+func userBucketName(login string) []byte {
+	return []byte("user:" + login)
+}
+
+// collection - набор записей одного типа внутри бакета пользователя.
+type collection[T client.Data] struct {
+	name string
+}
+
+func (c collection[T]) all(b *bbolt.Bucket) ([]T, error) {
+	bucket := b.Bucket([]byte(c.name))
+	if bucket == nil {
+		return nil, nil
+	}
+
+	var items []T
+	err := bucket.ForEach(func(_, v []byte) error {
+		var item T
+		if err := json.Unmarshal(v, &item); err != nil {
+			return err
+		}
+		items = append(items, item)
+		return nil
+	})
+	return items, err
+}
+
+func (c collection[T]) get(b *bbolt.Bucket, id int64) (T, bool, error) {
+	var item T
+
+	bucket := b.Bucket([]byte(c.name))
+	if bucket == nil {
+		return item, false, nil
+	}
+
+	v := bucket.Get(itob(id))
+	if v == nil {
+		return item, false, nil
+	}
+
+	err := json.Unmarshal(v, &item)
+	return item, err == nil, err
+}
+
+func (c collection[T]) put(b *bbolt.Bucket, item T) error {
+	bucket, err := b.CreateBucketIfNotExists([]byte(c.name))
+	if err != nil {
+		return err
+	}
+
+	v, err := json.Marshal(item)
+	if err != nil {
+		return err
+	}
+	return bucket.Put(itob(item.GetID()), v)
+}
+
+func (c collection[T]) delete(b *bbolt.Bucket, id int64) error {
+	bucket := b.Bucket([]byte(c.name))
+	if bucket == nil {
+		return nil
+	}
+	return bucket.Delete(itob(id))
+}
+
+func (c collection[T]) clear(b *bbolt.Bucket) error {
+	if b.Bucket([]byte(c.name)) == nil {
+		return nil
+	}
+	return b.DeleteBucket([]byte(c.name))
+}
+
+// localID возвращает временный отрицательный идентификатор для данных,
+// созданных без связи с сервером.
+func localID(b *bbolt.Bucket) (int64, error) {
+	seq, err := b.NextSequence()
+	if err != nil {
+		return 0, err
+	}
+	return -int64(seq), nil
+}
+
+func itob(v int64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, uint64(v))
+	return b
+}
+
+func btoi(b []byte) int64 {
+	return int64(binary.BigEndian.Uint64(b))
+}
@@ -0,0 +1,62 @@
+package bolt
+
+import (
+	"context"
+	"github.com/mkolibaba/gophkeeper/client"
+)
+
+// LoginService кэширует логины локально и позволяет работать с ними
+// без связи с сервером.
+type LoginService struct {
+	cache *cache[client.LoginData, client.LoginDataUpdate]
+}
+
+func NewLoginService(remote client.LoginService, db *DB, sync *Synchronizer) *LoginService {
+	c := &cache[client.LoginData, client.LoginDataUpdate]{
+		db:           db,
+		sync:         sync,
+		kind:         client.DataKindLogin,
+		remoteSave:   remote.Save,
+		remoteUpdate: remote.Update,
+		remoteRemove: remote.Remove,
+		withID: func(data client.LoginData, id int64) client.LoginData {
+			data.ID = id
+			return data
+		},
+		updateKey: func(data client.LoginDataUpdate) (int64, int64) {
+			return data.ID, data.Version
+		},
+		apply: func(data *client.LoginData, update client.LoginDataUpdate) {
+			setIfNotNil(&data.Name, update.Name)
+			setIfNotNil(&data.Login, update.Login)
+			setIfNotNil(&data.Password, update.Password)
+			setIfNotNil(&data.Website, update.Website)
+			setIfNotNil(&data.Notes, update.Notes)
+		},
+	}
+	c.register()
+
+	return &LoginService{cache: c}
+}
+
+func (s *LoginService) Save(ctx context.Context, data client.LoginData) error {
+	return s.cache.save(ctx, data)
+}
+
+func (s *LoginService) GetAll(ctx context.Context) ([]client.LoginData, error) {
+	return s.cache.getAll(ctx)
+}
+
+func (s *LoginService) Update(ctx context.Context, data client.LoginDataUpdate) error {
+	return s.cache.update(ctx, data)
+}
+
+func (s *LoginService) Remove(ctx context.Context, id int64, version int64) error {
+	return s.cache.remove(ctx, id, version)
+}
+
+func setIfNotNil[T any](dst *T, src *T) {
+	if src != nil {
+		*dst = *src
+	}
+}
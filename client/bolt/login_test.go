@@ -0,0 +1,418 @@
+package bolt
+
+import (
+	"context"
+	"errors"
+	"github.com/charmbracelet/log"
+	"github.com/mkolibaba/gophkeeper/client"
+	"github.com/mkolibaba/gophkeeper/client/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"io"
+	"path/filepath"
+	"testing"
+)
+
+var errUnavailable = status.Error(codes.Unavailable, "connection refused")
+
+func newTestDB(t *testing.T) *DB {
+	t.Helper()
+
+	db, err := Open(filepath.Join(t.TempDir(), "vault.db"), &mock.UserServiceMock{
+		GetUserLoginFunc: func() string {
+			return "alice"
+		},
+		GetBearerTokenFunc: func() string {
+			return "token"
+		},
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		db.Close()
+	})
+	return db
+}
+
+// fakeLoginServer - сервер с логинами, который можно "выключать".
+type fakeLoginServer struct {
+	offline   bool
+	nextID    int64
+	revision  int64
+	logins    []client.LoginData
+	changed   map[int64]int64 // ревизии изменений по id
+	removed   map[int64]int64 // ревизии удалений по id
+	pullSince []int64
+}
+
+func newFakeLoginServer() *fakeLoginServer {
+	return &fakeLoginServer{
+		changed: make(map[int64]int64),
+		removed: make(map[int64]int64),
+	}
+}
+
+func (f *fakeLoginServer) save(data client.LoginData) {
+	f.nextID++
+	f.revision++
+	data.ID = f.nextID
+	data.Version = f.revision
+	f.logins = append(f.logins, data)
+	f.changed[data.ID] = f.revision
+}
+
+func (f *fakeLoginServer) update(id int64, version int64, fn func(data *client.LoginData)) error {
+	for i := range f.logins {
+		if f.logins[i].ID == id {
+			if f.logins[i].Version != version {
+				return &client.VersionConflictError{Version: f.logins[i].Version}
+			}
+			fn(&f.logins[i])
+			f.revision++
+			f.logins[i].Version = f.revision
+			f.changed[id] = f.revision
+			return nil
+		}
+	}
+	return status.Error(codes.NotFound, "data not found")
+}
+
+func (f *fakeLoginServer) remove(id int64, version int64) error {
+	for i := range f.logins {
+		if f.logins[i].ID == id {
+			if f.logins[i].Version != version {
+				return &client.VersionConflictError{Version: f.logins[i].Version}
+			}
+			f.logins = append(f.logins[:i], f.logins[i+1:]...)
+			f.revision++
+			delete(f.changed, id)
+			f.removed[id] = f.revision
+			return nil
+		}
+	}
+	return status.Error(codes.NotFound, "data not found")
+}
+
+func (f *fakeLoginServer) mock() *mock.LoginServiceMock {
+	return &mock.LoginServiceMock{
+		SaveFunc: func(ctx context.Context, data client.LoginData) error {
+			if f.offline {
+				return errUnavailable
+			}
+			f.save(data)
+			return nil
+		},
+		UpdateFunc: func(ctx context.Context, data client.LoginDataUpdate) error {
+			if f.offline {
+				return errUnavailable
+			}
+			return f.update(data.ID, data.Version, func(login *client.LoginData) {
+				setIfNotNil(&login.Name, data.Name)
+			})
+		},
+		RemoveFunc: func(ctx context.Context, id int64, version int64) error {
+			if f.offline {
+				return errUnavailable
+			}
+			return f.remove(id, version)
+		},
+	}
+}
+
+func (f *fakeLoginServer) syncMock() *mock.SyncServiceMock {
+	return &mock.SyncServiceMock{
+		PullFunc: func(ctx context.Context, since int64) (int64, []client.Change, error) {
+			if f.offline {
+				return 0, nil, errUnavailable
+			}
+			f.pullSince = append(f.pullSince, since)
+
+			var changes []client.Change
+			for _, login := range f.logins {
+				if f.changed[login.ID] > since {
+					changes = append(changes, client.Change{Kind: client.DataKindLogin, ID: login.ID, Data: login})
+				}
+			}
+			for id, revision := range f.removed {
+				if revision > since {
+					changes = append(changes, client.Change{Kind: client.DataKindLogin, ID: id})
+				}
+			}
+			return f.revision, changes, nil
+		},
+		PushFunc: func(ctx context.Context, changes []client.Change) ([]error, error) {
+			if f.offline {
+				return nil, errUnavailable
+			}
+
+			var results []error
+			for _, change := range changes {
+				var err error
+				switch {
+				case change.Data == nil:
+					err = f.remove(change.ID, change.Version)
+				case change.ID == 0:
+					f.save(change.Data.(client.LoginData))
+				default:
+					err = f.update(change.ID, change.Version, func(login *client.LoginData) {
+						*login = change.Data.(client.LoginData)
+						login.ID = change.ID
+					})
+				}
+				results = append(results, err)
+			}
+			return results, nil
+		},
+	}
+}
+
+func newTestLoginService(t *testing.T, server *fakeLoginServer) (*LoginService, *Synchronizer) {
+	t.Helper()
+
+	db := newTestDB(t)
+	sync := NewSynchronizer(db, server.syncMock(), log.New(io.Discard))
+	return NewLoginService(server.mock(), db, sync), sync
+}
+
+func TestLoginOffline(t *testing.T) {
+	server := newFakeLoginServer()
+	srv, sync := newTestLoginService(t, server)
+
+	err := srv.Save(t.Context(), client.LoginData{Name: "github", Login: "alice"})
+	require.NoError(t, err)
+	err = srv.Save(t.Context(), client.LoginData{Name: "gitlab", Login: "alice"})
+	require.NoError(t, err)
+
+	// Кэш заполняется при чтении с сервера.
+	logins, err := srv.GetAll(t.Context())
+	require.NoError(t, err)
+	require.Len(t, logins, 2)
+
+	server.offline = true
+
+	t.Run("read_from_cache", func(t *testing.T) {
+		logins, err := srv.GetAll(t.Context())
+		require.NoError(t, err)
+		require.Len(t, logins, 2)
+	})
+	t.Run("write_offline", func(t *testing.T) {
+		err := srv.Save(t.Context(), client.LoginData{Name: "bitbucket", Login: "alice"})
+		require.NoError(t, err)
+
+		logins, err := srv.GetAll(t.Context())
+		require.NoError(t, err)
+		require.Len(t, logins, 3)
+
+		var local client.LoginData
+		for _, l := range logins {
+			if l.ID < 0 {
+				local = l
+			}
+		}
+		require.Equal(t, "bitbucket", local.Name)
+
+		// Обновление и удаление данных, созданных офлайн, а также повторные
+		// изменения одной записи не порождают новых операций.
+		name := "bitbucket.org"
+		err = srv.Update(t.Context(), client.LoginDataUpdate{ID: local.ID, Name: &name})
+		require.NoError(t, err)
+
+		name = "github.io"
+		err = srv.Update(t.Context(), client.LoginDataUpdate{ID: 1, Version: 1, Name: &name})
+		require.NoError(t, err)
+		name = "github.com"
+		err = srv.Update(t.Context(), client.LoginDataUpdate{ID: 1, Version: 1, Name: &name})
+		require.NoError(t, err)
+
+		err = srv.Remove(t.Context(), 2, 2)
+		require.NoError(t, err)
+
+		pending, err := sync.Pending()
+		require.NoError(t, err)
+		require.Equal(t, 3, pending)
+
+		logins, err = srv.GetAll(t.Context())
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{"github.com", "bitbucket.org"}, names(logins))
+	})
+	t.Run("sync_when_online", func(t *testing.T) {
+		server.offline = false
+
+		logins, err := srv.GetAll(t.Context())
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{"github.com", "bitbucket.org"}, names(logins))
+		require.ElementsMatch(t, []string{"github.com", "bitbucket.org"}, names(server.logins))
+
+		for _, l := range logins {
+			require.Positive(t, l.ID)
+		}
+
+		pending, err := sync.Pending()
+		require.NoError(t, err)
+		require.Zero(t, pending)
+	})
+}
+
+func TestLoginRemoveOfflineCreated(t *testing.T) {
+	server := newFakeLoginServer()
+	server.offline = true
+	srv, sync := newTestLoginService(t, server)
+
+	err := srv.Save(t.Context(), client.LoginData{Name: "github", Login: "alice"})
+	require.NoError(t, err)
+
+	logins, err := srv.GetAll(t.Context())
+	require.NoError(t, err)
+	require.Len(t, logins, 1)
+
+	err = srv.Remove(t.Context(), logins[0].ID, logins[0].Version)
+	require.NoError(t, err)
+
+	pending, err := sync.Pending()
+	require.NoError(t, err)
+	require.Zero(t, pending)
+}
+
+func TestLoginRejectedChange(t *testing.T) {
+	server := newFakeLoginServer()
+	server.offline = true
+	srv, sync := newTestLoginService(t, server)
+
+	// Данных с таким id на сервере нет, изменение будет отклонено.
+	err := srv.Remove(t.Context(), 42, 1)
+	require.NoError(t, err)
+
+	server.offline = false
+	err = sync.Flush(t.Context())
+	var conflict *client.VersionConflictError
+	require.ErrorAs(t, err, &conflict)
+
+	// Отклоненное изменение остается в очереди, но повторно
+	// не отправляется.
+	require.NoError(t, sync.Flush(t.Context()))
+	pending, err := sync.Pending()
+	require.NoError(t, err)
+	require.Equal(t, 1, pending)
+}
+
+func TestLoginOfflineConflict(t *testing.T) {
+	server := newFakeLoginServer()
+	srv, sync := newTestLoginService(t, server)
+
+	server.save(client.LoginData{Name: "github", Login: "alice"})
+	_, err := srv.GetAll(t.Context())
+	require.NoError(t, err)
+
+	server.offline = true
+	name := "github.io"
+	err = srv.Update(t.Context(), client.LoginDataUpdate{ID: 1, Version: 1, Name: &name})
+	require.NoError(t, err)
+
+	// Запись изменили с другого клиента, пока этот был офлайн.
+	server.offline = false
+	require.NoError(t, server.update(1, 1, func(login *client.LoginData) {
+		login.Name = "github.com"
+	}))
+
+	_, err = srv.GetAll(t.Context())
+	var conflict *client.VersionConflictError
+	require.ErrorAs(t, err, &conflict)
+	require.Equal(t, server.logins[0].Version, conflict.Version)
+
+	// Кэш показывает данные сервера, изменение ждет разрешения конфликта.
+	logins, err := srv.GetAll(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, []string{"github.com"}, names(logins))
+	pending, err := sync.Pending()
+	require.NoError(t, err)
+	require.Equal(t, 1, pending)
+
+	// Новое изменение записи заменяет отклоненное.
+	err = srv.Update(t.Context(), client.LoginDataUpdate{ID: 1, Version: conflict.Version, Name: &name})
+	require.NoError(t, err)
+	require.Equal(t, []string{"github.io"}, names(server.logins))
+	pending, err = sync.Pending()
+	require.NoError(t, err)
+	require.Zero(t, pending)
+}
+
+func TestLoginServerError(t *testing.T) {
+	db := newTestDB(t)
+	sync := NewSynchronizer(db, &mock.SyncServiceMock{
+		PullFunc: func(ctx context.Context, since int64) (int64, []client.Change, error) {
+			return 0, nil, errors.New("some error")
+		},
+	}, log.New(io.Discard))
+	srv := NewLoginService(&mock.LoginServiceMock{
+		SaveFunc: func(ctx context.Context, data client.LoginData) error {
+			return status.Error(codes.InvalidArgument, "invalid data")
+		},
+	}, db, sync)
+
+	err := srv.Save(t.Context(), client.LoginData{Name: "github"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = srv.GetAll(t.Context())
+	require.Error(t, err)
+}
+
+func TestLoginPullChanges(t *testing.T) {
+	server := newFakeLoginServer()
+	srv, _ := newTestLoginService(t, server)
+
+	server.save(client.LoginData{Name: "github", Login: "alice"})
+	server.save(client.LoginData{Name: "gitlab", Login: "alice"})
+
+	logins, err := srv.GetAll(t.Context())
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"github", "gitlab"}, names(logins))
+
+	// Изменения, сделанные другим клиентом.
+	require.NoError(t, server.remove(1, 1))
+	require.NoError(t, server.update(2, 2, func(login *client.LoginData) {
+		login.Name = "gitlab.com"
+	}))
+
+	logins, err = srv.GetAll(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, []string{"gitlab.com"}, names(logins))
+
+	// Запрашиваются только изменения после последней полученной ревизии.
+	require.Equal(t, []int64{0, 2}, server.pullSince)
+}
+
+func TestLoginVersionConflict(t *testing.T) {
+	server := newFakeLoginServer()
+	srv, _ := newTestLoginService(t, server)
+
+	server.save(client.LoginData{Name: "github", Login: "alice"})
+
+	logins, err := srv.GetAll(t.Context())
+	require.NoError(t, err)
+	require.Len(t, logins, 1)
+	version := logins[0].Version
+
+	// Запись изменили с другого клиента.
+	require.NoError(t, server.update(1, version, func(login *client.LoginData) {
+		login.Name = "github.com"
+	}))
+
+	name := "github.io"
+	err = srv.Update(t.Context(), client.LoginDataUpdate{ID: 1, Version: version, Name: &name})
+	var conflict *client.VersionConflictError
+	require.ErrorAs(t, err, &conflict)
+	require.Equal(t, server.logins[0].Version, conflict.Version)
+
+	// Повтор с актуальной версией перезаписывает данные на сервере.
+	err = srv.Update(t.Context(), client.LoginDataUpdate{ID: 1, Version: conflict.Version, Name: &name})
+	require.NoError(t, err)
+	require.Equal(t, []string{"github.io"}, names(server.logins))
+}
+
+func names(logins []client.LoginData) []string {
+	var result []string
+	for _, l := range logins {
+		result = append(result, l.Name)
+	}
+	return result
+}
@@ -0,0 +1,38 @@
+package bolt
+
+import (
+	"context"
+	"github.com/mkolibaba/gophkeeper/client"
+	"go.uber.org/fx"
+)
+
+// Module подключает локальный кэш. Сервисы кэша оборачивают сервисы,
+// помеченные как `name:"remote"`.
+var Module = fx.Module(
+	"bolt",
+	fx.Provide(
+		NewDB,
+		NewSynchronizer,
+		fx.Annotate(NewAuthorizationService, fx.ParamTags(`name:"remote"`), fx.As(new(client.AuthorizationService))),
+		fx.Annotate(NewLoginService, fx.ParamTags(`name:"remote"`), fx.As(new(client.LoginService))),
+		fx.Annotate(NewNoteService, fx.ParamTags(`name:"remote"`), fx.As(new(client.NoteService))),
+		fx.Annotate(NewBinaryService, fx.ParamTags(`name:"remote"`), fx.As(new(client.BinaryService))),
+		fx.Annotate(NewCardService, fx.ParamTags(`name:"remote"`), fx.As(new(client.CardService))),
+		fx.Annotate(NewOTPService, fx.ParamTags(`name:"remote"`), fx.As(new(client.OTPService))),
+	),
+)
+
+func NewDB(lc fx.Lifecycle, config *client.Config, userService client.UserService) (*DB, error) {
+	db, err := Open(config.Cache.Path, userService)
+	if err != nil {
+		return nil, err
+	}
+
+	lc.Append(fx.Hook{
+		OnStop: func(context.Context) error {
+			return db.Close()
+		},
+	})
+
+	return db, nil
+}
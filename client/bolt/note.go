@@ -0,0 +1,53 @@
+package bolt
+
+import (
+	"context"
+	"github.com/mkolibaba/gophkeeper/client"
+)
+
+// NoteService кэширует заметки локально и позволяет работать с ними
+// без связи с сервером.
+type NoteService struct {
+	cache *cache[client.NoteData, client.NoteDataUpdate]
+}
+
+func NewNoteService(remote client.NoteService, db *DB, sync *Synchronizer) *NoteService {
+	c := &cache[client.NoteData, client.NoteDataUpdate]{
+		db:           db,
+		sync:         sync,
+		kind:         client.DataKindNote,
+		remoteSave:   remote.Save,
+		remoteUpdate: remote.Update,
+		remoteRemove: remote.Remove,
+		withID: func(data client.NoteData, id int64) client.NoteData {
+			data.ID = id
+			return data
+		},
+		updateKey: func(data client.NoteDataUpdate) (int64, int64) {
+			return data.ID, data.Version
+		},
+		apply: func(data *client.NoteData, update client.NoteDataUpdate) {
+			setIfNotNil(&data.Name, update.Name)
+			setIfNotNil(&data.Text, update.Text)
+		},
+	}
+	c.register()
+
+	return &NoteService{cache: c}
+}
+
+func (s *NoteService) Save(ctx context.Context, data client.NoteData) error {
+	return s.cache.save(ctx, data)
+}
+
+func (s *NoteService) GetAll(ctx context.Context) ([]client.NoteData, error) {
+	return s.cache.getAll(ctx)
+}
+
+func (s *NoteService) Update(ctx context.Context, data client.NoteDataUpdate) error {
+	return s.cache.update(ctx, data)
+}
+
+func (s *NoteService) Remove(ctx context.Context, id int64, version int64) error {
+	return s.cache.remove(ctx, id, version)
+}
@@ -0,0 +1,59 @@
+package bolt
+
+import (
+	"context"
+	"github.com/mkolibaba/gophkeeper/client"
+)
+
+// OTPService кэширует данные одноразовых паролей локально, так что коды
+// можно получать и без связи с сервером.
+type OTPService struct {
+	cache *cache[client.OTPData, client.OTPDataUpdate]
+}
+
+func NewOTPService(remote client.OTPService, db *DB, sync *Synchronizer) *OTPService {
+	c := &cache[client.OTPData, client.OTPDataUpdate]{
+		db:           db,
+		sync:         sync,
+		kind:         client.DataKindOTP,
+		remoteSave:   remote.Save,
+		remoteUpdate: remote.Update,
+		remoteRemove: remote.Remove,
+		withID: func(data client.OTPData, id int64) client.OTPData {
+			data.ID = id
+			return data
+		},
+		updateKey: func(data client.OTPDataUpdate) (int64, int64) {
+			return data.ID, data.Version
+		},
+		apply: func(data *client.OTPData, update client.OTPDataUpdate) {
+			setIfNotNil(&data.Name, update.Name)
+			setIfNotNil(&data.Type, update.Type)
+			setIfNotNil(&data.Secret, update.Secret)
+			setIfNotNil(&data.Algorithm, update.Algorithm)
+			setIfNotNil(&data.Digits, update.Digits)
+			setIfNotNil(&data.Period, update.Period)
+			setIfNotNil(&data.Counter, update.Counter)
+			setIfNotNil(&data.Issuer, update.Issuer)
+		},
+	}
+	c.register()
+
+	return &OTPService{cache: c}
+}
+
+func (s *OTPService) Save(ctx context.Context, data client.OTPData) error {
+	return s.cache.save(ctx, data)
+}
+
+func (s *OTPService) GetAll(ctx context.Context) ([]client.OTPData, error) {
+	return s.cache.getAll(ctx)
+}
+
+func (s *OTPService) Update(ctx context.Context, data client.OTPDataUpdate) error {
+	return s.cache.update(ctx, data)
+}
+
+func (s *OTPService) Remove(ctx context.Context, id int64, version int64) error {
+	return s.cache.remove(ctx, id, version)
+}
@@ -0,0 +1,368 @@
+package bolt
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/charmbracelet/log"
+	"github.com/mkolibaba/gophkeeper/client"
+	"go.etcd.io/bbolt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"sync"
+)
+
+const (
+	queueBucket = "queue"
+	revisionKey = "revision"
+)
+
+type action string
+
+const (
+	actionSave   action = "save"
+	actionUpdate action = "update"
+	actionRemove action = "remove"
+)
+
+var errOffline = errors.New("server is unavailable")
+
+// operation - изменение, сделанное без связи с сервером и ожидающее отправки.
+// Для сохранения и обновления Data содержит запись целиком. Version - версия
+// записи на сервере, на основе которой сделано изменение.
+type operation struct {
+	Kind    client.DataKind
+	Action  action
+	ID      int64
+	Version int64
+	Data    json.RawMessage
+	// Conflict - причина, по которой сервер отклонил изменение. Отклоненное
+	// изменение остается в очереди, но не отправляется, пока пользователь
+	// не изменит или не удалит запись заново.
+	Conflict *client.VersionConflictError `json:",omitempty"`
+}
+
+// store - коллекция кэша, в которую синхронизатор применяет изменения.
+type store interface {
+	decode(data []byte) (client.Data, error)
+	put(b *bbolt.Bucket, data client.Data) error
+	delete(b *bbolt.Bucket, id int64) error
+	clear(b *bbolt.Bucket) error
+}
+
+// Synchronizer хранит очередь изменений, сделанных офлайн, и отправляет
+// их на сервер одним пакетом, как только он становится доступен. Кэш
+// обновляется инкрементально: с сервера запрашиваются только изменения
+// после последней полученной ревизии.
+type Synchronizer struct {
+	db     *DB
+	remote client.SyncService
+	logger *log.Logger
+	mu     sync.Mutex
+	stores map[client.DataKind]store
+}
+
+func NewSynchronizer(db *DB, remote client.SyncService, logger *log.Logger) *Synchronizer {
+	return &Synchronizer{
+		db:     db,
+		remote: remote,
+		logger: logger,
+		stores: make(map[client.DataKind]store),
+	}
+}
+
+func (s *Synchronizer) register(kind client.DataKind, store store) {
+	s.stores[kind] = store
+}
+
+// Flush отправляет на сервер накопленные изменения. Если сервер недоступен,
+// изменения остаются в очереди и возвращается errOffline.
+// Изменения, отклоненные сервером, остаются в очереди, а Flush возвращает
+// *client.VersionConflictError.
+func (s *Synchronizer) Flush(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.push(ctx)
+}
+
+// Pull отправляет накопленные изменения и применяет к кэшу изменения,
+// сделанные на сервере после последней синхронизации.
+func (s *Synchronizer) Pull(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Отклоненные изменения не мешают получить данные сервера: по ним
+	// пользователь разрешает конфликт.
+	var conflict *client.VersionConflictError
+	pushErr := s.push(ctx)
+	if pushErr != nil && !errors.As(pushErr, &conflict) {
+		return pushErr
+	}
+
+	var since int64
+	err := s.db.view(func(b *bbolt.Bucket) error {
+		if v := b.Get([]byte(revisionKey)); v != nil {
+			since = btoi(v)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("pull: %w", err)
+	}
+
+	revision, changes, err := s.remote.Pull(ctx, since)
+	if err != nil {
+		if isUnavailable(err) {
+			return errOffline
+		}
+		return err
+	}
+
+	err = s.db.update(func(b *bbolt.Bucket) error {
+		for _, change := range changes {
+			store, ok := s.stores[change.Kind]
+			if !ok {
+				return fmt.Errorf("unknown data kind %q", change.Kind)
+			}
+
+			if change.Data == nil {
+				err = store.delete(b, change.ID)
+			} else {
+				err = store.put(b, change.Data)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return b.Put([]byte(revisionKey), itob(revision))
+	})
+	if err != nil {
+		return fmt.Errorf("pull: %w", err)
+	}
+
+	return pushErr
+}
+
+// Pending возвращает количество изменений, ожидающих отправки на сервер,
+// в том числе отклоненных.
+func (s *Synchronizer) Pending() (int, error) {
+	var n int
+	err := s.db.view(func(b *bbolt.Bucket) error {
+		if queue := b.Bucket([]byte(queueBucket)); queue != nil {
+			n = queue.Stats().KeyN
+		}
+		return nil
+	})
+	return n, err
+}
+
+// online выполняет запрос к серверу fn после отправки накопленных изменений,
+// чтобы сохранить порядок операций.
+func (s *Synchronizer) online(ctx context.Context, fn func() error) error {
+	// Отклоненные изменения не отправляются, поэтому не нарушают порядок
+	// операций и не мешают новым.
+	var conflict *client.VersionConflictError
+	if err := s.Flush(ctx); err != nil && !errors.As(err, &conflict) {
+		return err
+	}
+	if err := fn(); err != nil {
+		if isUnavailable(err) {
+			return errOffline
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *Synchronizer) push(ctx context.Context) error {
+	// Без сессии хранилище открыто ключом из кэша, и запросы к серверу
+	// не выполняются до следующего входа.
+	if s.db.userService.GetBearerToken() == "" {
+		return errOffline
+	}
+
+	keys, ops, err := s.pending()
+	if err != nil {
+		return fmt.Errorf("push: %w", err)
+	}
+
+	// Отклоненное изменение было бы отклонено снова, поэтому отправляется
+	// только после того, как пользователь изменит запись заново.
+	var sent []int
+	var changes []client.Change
+	for i, op := range ops {
+		if op.Conflict != nil {
+			continue
+		}
+
+		store, ok := s.stores[op.Kind]
+		if !ok {
+			return fmt.Errorf("push: unknown data kind %q", op.Kind)
+		}
+
+		change := client.Change{Kind: op.Kind, ID: op.ID, Version: op.Version}
+		if op.Action == actionSave {
+			// Временный идентификатор серверу не нужен: запись будет создана.
+			change.ID = 0
+		}
+		if op.Action != actionRemove {
+			if change.Data, err = store.decode(op.Data); err != nil {
+				return fmt.Errorf("push: %w", err)
+			}
+		}
+		sent = append(sent, i)
+		changes = append(changes, change)
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+
+	// При ошибке сервера результаты есть только у изменений, примененных
+	// до нее. Остальные будут отправлены повторно.
+	results, pushErr := s.remote.Push(ctx, changes)
+	if pushErr != nil && isUnavailable(pushErr) {
+		return errOffline
+	}
+
+	var rejected error
+	err = s.db.update(func(b *bbolt.Bucket) error {
+		for j, result := range results {
+			key, op := keys[sent[j]], ops[sent[j]]
+
+			if result != nil {
+				s.logger.Error("pending change rejected by server", "kind", op.Kind, "action", op.Action, "id", op.ID, "err", result)
+
+				if !errors.As(result, &op.Conflict) {
+					// Запись удалена на сервере или изменение не прошло
+					// проверку: версии, с которой можно повторить
+					// изменение, нет.
+					op.Conflict = &client.VersionConflictError{}
+				}
+				if rejected == nil {
+					rejected = fmt.Errorf("%s %d: %w", op.Kind, op.ID, op.Conflict)
+				}
+				if err := putQueued(b, key, op); err != nil {
+					return err
+				}
+				continue
+			}
+
+			if err := dequeue(b, key); err != nil {
+				return err
+			}
+			// Данные, созданные офлайн, вернутся с сервера уже
+			// с постоянным идентификатором.
+			if op.Action == actionSave {
+				if err := s.stores[op.Kind].delete(b, op.ID); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("push: %w", err)
+	}
+
+	if pushErr != nil {
+		return pushErr
+	}
+	return rejected
+}
+
+// pending возвращает ключи и операции очереди в порядке их добавления.
+func (s *Synchronizer) pending() ([][]byte, []operation, error) {
+	var keys [][]byte
+	var ops []operation
+
+	err := s.db.view(func(b *bbolt.Bucket) error {
+		queue := b.Bucket([]byte(queueBucket))
+		if queue == nil {
+			return nil
+		}
+
+		return queue.ForEach(func(k, v []byte) error {
+			var op operation
+			if err := json.Unmarshal(v, &op); err != nil {
+				return err
+			}
+			keys = append(keys, append([]byte(nil), k...))
+			ops = append(ops, op)
+			return nil
+		})
+	})
+
+	return keys, ops, err
+}
+
+func enqueue(b *bbolt.Bucket, op operation) error {
+	queue, err := b.CreateBucketIfNotExists([]byte(queueBucket))
+	if err != nil {
+		return err
+	}
+
+	seq, err := queue.NextSequence()
+	if err != nil {
+		return err
+	}
+
+	v, err := json.Marshal(op)
+	if err != nil {
+		return err
+	}
+	return queue.Put(itob(int64(seq)), v)
+}
+
+// findQueued возвращает отложенную операцию над записью. Очередь содержит
+// не больше одной операции для каждой записи: повторные изменения
+// объединяются с уже поставленной в очередь операцией.
+func findQueued(b *bbolt.Bucket, kind client.DataKind, id int64) ([]byte, operation, bool, error) {
+	queue := b.Bucket([]byte(queueBucket))
+	if queue == nil {
+		return nil, operation{}, false, nil
+	}
+
+	c := queue.Cursor()
+	for k, v := c.First(); k != nil; k, v = c.Next() {
+		var op operation
+		if err := json.Unmarshal(v, &op); err != nil {
+			return nil, operation{}, false, err
+		}
+		if op.Kind == kind && op.ID == id {
+			return append([]byte(nil), k...), op, true, nil
+		}
+	}
+	return nil, operation{}, false, nil
+}
+
+// rewriteQueued заменяет данные отложенной операции.
+func rewriteQueued(b *bbolt.Bucket, key []byte, op operation, data any) error {
+	var err error
+	if op.Data, err = json.Marshal(data); err != nil {
+		return err
+	}
+	return putQueued(b, key, op)
+}
+
+// putQueued сохраняет отложенную операцию под ключом key.
+func putQueued(b *bbolt.Bucket, key []byte, op operation) error {
+	v, err := json.Marshal(op)
+	if err != nil {
+		return err
+	}
+	return b.Bucket([]byte(queueBucket)).Put(key, v)
+}
+
+func dequeue(b *bbolt.Bucket, key []byte) error {
+	return b.Bucket([]byte(queueBucket)).Delete(key)
+}
+
+func isUnavailable(err error) bool {
+	if errors.Is(err, errOffline) {
+		return true
+	}
+	code := status.Code(err)
+	return code == codes.Unavailable || code == codes.DeadlineExceeded
+}
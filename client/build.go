@@ -0,0 +1,38 @@
+package client
+
+import (
+	"context"
+	"fmt"
+)
+
+// ProtocolVersion - версия протокола клиент-серверного взаимодействия,
+// которую поддерживает клиент.
+const ProtocolVersion = 4
+
+// BuildInfo - сведения о сборке. Задаются при сборке через -ldflags.
+type BuildInfo struct {
+	Version string
+	Commit  string
+	Date    string
+}
+
+func (b BuildInfo) String() string {
+	return fmt.Sprintf("%s (commit %s, built %s)", b.Version, b.Commit, b.Date)
+}
+
+// ServerInfo - сведения о сервере.
+type ServerInfo struct {
+	Build           BuildInfo
+	ProtocolVersion int64
+}
+
+// Compatible сообщает, совпадает ли версия протокола сервера с версией клиента.
+func (i ServerInfo) Compatible() bool {
+	return i.ProtocolVersion == ProtocolVersion
+}
+
+type InfoService interface {
+	ServerInfo(ctx context.Context) (ServerInfo, error)
+	// Usage возвращает данные, которые занимает текущий пользователь.
+	Usage(ctx context.Context) (Usage, error)
+}
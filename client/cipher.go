@@ -0,0 +1,75 @@
+package client
+
+import (
+	"errors"
+	"io"
+)
+
+var ErrVaultLocked = errors.New("vault is locked")
+
+// MasterKey - ключи, выведенные из мастер-пароля.
+type MasterKey struct {
+	// AuthKey - ключ аутентификации. Передается на сервер вместо
+	// мастер-пароля, по нему нельзя восстановить EncryptionKey.
+	AuthKey string
+	// EncryptionKey - ключ, которым зашифрован ключ хранилища.
+	// Не покидает клиент.
+	EncryptionKey []byte
+}
+
+// Field - поле записи, к которому привязан шифротекст.
+type Field struct {
+	// Kind - тип записи.
+	Kind DataKind
+	// Item - случайный идентификатор записи, который клиент выбирает при ее
+	// создании. Идентификаторы сервера для этого не подходят: сервер
+	// назначает их уже после шифрования, а записи, созданные без связи
+	// с сервером, до синхронизации имеют временные идентификаторы.
+	Item []byte
+	// Name - название поля.
+	Name string
+}
+
+// Cipher - клиентский слой шифрования. Все секретные данные шифруются
+// до отправки на сервер ключом хранилища (vault key), который, в свою
+// очередь, хранится на сервере только в зашифрованном виде.
+type Cipher interface {
+	// DeriveKeys выводит ключи из мастер-пароля пользователя login.
+	DeriveKeys(login string, password string) (MasterKey, error)
+
+	// NewVaultKey генерирует новый ключ хранилища и возвращает его
+	// зашифрованным ключом key.
+	NewVaultKey(key MasterKey) ([]byte, error)
+
+	// Unlock расшифровывает ключ хранилища ключом key и запоминает его
+	// для последующих операций.
+	Unlock(key MasterKey, vaultKey []byte) error
+
+	// WrapVaultKey возвращает открытый ключ хранилища, зашифрованный
+	// ключом key. Используется при смене мастер-пароля.
+	WrapVaultKey(key MasterKey) ([]byte, error)
+
+	// Encrypt шифрует строку и привязывает шифротекст к полю field: его
+	// нельзя выдать за другое поле той же или другой записи.
+	Encrypt(plaintext string, field Field) (string, error)
+
+	// Decrypt расшифровывает строку, полученную через Encrypt для того же
+	// поля.
+	Decrypt(ciphertext string, field Field) (string, error)
+
+	// ItemID возвращает идентификатор записи, к которой привязан шифротекст,
+	// полученный через Encrypt. Подлинность привязки проверяет Decrypt.
+	ItemID(ciphertext string) ([]byte, error)
+
+	// EncryptStream возвращает reader, отдающий зашифрованное содержимое r.
+	EncryptStream(r io.Reader) (io.Reader, error)
+
+	// DecryptStream возвращает writer, который расшифровывает записанные в него
+	// данные и пишет результат в w. Close проверяет, что поток получен полностью.
+	DecryptStream(w io.Writer) (io.WriteCloser, error)
+
+	// ContentKey возвращает ключ дедупликации содержимого r: одинаковое
+	// содержимое получает одинаковый ключ, но без ключа хранилища его
+	// нельзя вычислить и проверить догадку о содержимом.
+	ContentKey(r io.Reader) ([]byte, error)
+}
@@ -0,0 +1,318 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/mkolibaba/gophkeeper/client"
+	"github.com/spf13/cobra"
+	"go.uber.org/fx"
+	"golang.org/x/term"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+)
+
+// Коды завершения команд.
+const (
+	ExitOK              = 0
+	ExitError           = 1
+	ExitUsage           = 2
+	ExitUnauthenticated = 3
+	ExitNotFound        = 4
+	ExitConflict        = 5
+)
+
+const (
+	envLogin       = "GOPHKEEPER_LOGIN"
+	envPassword    = "GOPHKEEPER_PASSWORD"
+	envNewPassword = "GOPHKEEPER_NEW_PASSWORD"
+	envMFACode     = "GOPHKEEPER_MFA_CODE"
+)
+
+var (
+	errUnauthenticated = errors.New("unauthenticated")
+	errNotFound        = errors.New("not found")
+)
+
+// usageError - ошибка в аргументах или флагах команды.
+type usageError struct {
+	err error
+}
+
+func (e *usageError) Error() string {
+	return e.err.Error()
+}
+
+func (e *usageError) Unwrap() error {
+	return e.err
+}
+
+// Services - сервисы, с которыми работают команды.
+type Services struct {
+	fx.In
+
+	Authorization client.AuthorizationService
+	User          client.UserService
+	Login         client.LoginService
+	Note          client.NoteService
+	Binary        client.BinaryService
+	Card          client.CardService
+	OTP           client.OTPService
+}
+
+type cli struct {
+	// modules - модули приложения, из которых собираются сервисы
+	// для каждой команды.
+	modules fx.Option
+	user    string
+	output  string
+}
+
+// NewRootCommand создает корневую команду клиента. Без подкоманды запускается
+// TUI, подкоманды выполняются без интерактивного интерфейса на сервисах,
+// собранных из modules.
+func NewRootCommand(build client.BuildInfo, tui func() error, modules fx.Option) *cobra.Command {
+	c := &cli{modules: modules}
+
+	root := &cobra.Command{
+		Use:     "gophkeeper-client",
+		Short:   "GophKeeper client",
+		Version: build.String(),
+		Long: "GophKeeper client. Without a subcommand the terminal UI is started.\n\n" +
+			"Subcommands authenticate on every run: the login is taken from --user or " + envLogin +
+			", the master password from " + envPassword + ". If they are not set and the client " +
+			"is run in a terminal, they are requested interactively.",
+		Args:          usageArgs(cobra.NoArgs),
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if c.output != outputPlain && c.output != outputJSON {
+				return &usageError{fmt.Errorf("unknown output format %q", c.output)}
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return tui()
+		},
+	}
+	root.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return &usageError{err}
+	})
+
+	flags := root.PersistentFlags()
+	flags.StringVarP(&c.user, "user", "u", "", "user login (default $"+envLogin+")")
+	flags.StringVarP(&c.output, "output", "o", outputPlain, "output format: plain or json")
+
+	root.AddCommand(
+		c.newLoginCommand(),
+		c.newRegisterCommand(),
+		c.newListCommand(),
+		c.newGetCommand(),
+		c.newAddCommand(),
+		c.newEditCommand(),
+		c.newRemoveCommand(),
+		c.newDownloadCommand(),
+		c.newSessionsCommand(),
+		c.newRevokeCommand(),
+		c.newMFACommand(),
+		c.newPasswdCommand(),
+		c.newDeleteAccountCommand(),
+	)
+
+	return root
+}
+
+// Execute выполняет команду и возвращает код завершения.
+func Execute(cmd *cobra.Command) int {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	err := cmd.ExecuteContext(ctx)
+	if err == nil {
+		return ExitOK
+	}
+
+	fmt.Fprintln(cmd.ErrOrStderr(), "error:", err)
+
+	var usageErr *usageError
+	if errors.As(err, &usageErr) {
+		fmt.Fprintln(cmd.ErrOrStderr(), "run with --help for usage")
+	}
+
+	return exitCode(err)
+}
+
+func exitCode(err error) int {
+	var usageErr *usageError
+	var conflictErr *client.VersionConflictError
+
+	switch {
+	case errors.As(err, &usageErr):
+		return ExitUsage
+	case errors.Is(err, errUnauthenticated), status.Code(err) == codes.Unauthenticated:
+		return ExitUnauthenticated
+	case errors.Is(err, errNotFound), errors.Is(err, client.ErrDataNotFound), status.Code(err) == codes.NotFound:
+		return ExitNotFound
+	case errors.As(err, &conflictErr):
+		return ExitConflict
+	default:
+		return ExitError
+	}
+}
+
+// usageArgs помечает ошибки проверки аргументов как ошибки использования.
+func usageArgs(args cobra.PositionalArgs) cobra.PositionalArgs {
+	return func(cmd *cobra.Command, a []string) error {
+		if err := args(cmd, a); err != nil {
+			return &usageError{err}
+		}
+		return nil
+	}
+}
+
+// run собирает сервисы приложения, авторизуется и выполняет fn.
+func (c *cli) run(cmd *cobra.Command, fn func(ctx context.Context, s Services) error) error {
+	return c.runWithPassword(cmd, func(ctx context.Context, s Services, _ string) error {
+		return fn(ctx, s)
+	})
+}
+
+// runWithPassword - аналог run для команд, которым нужен мастер-пароль,
+// например для подтверждения изменений учетной записи.
+func (c *cli) runWithPassword(cmd *cobra.Command, fn func(ctx context.Context, s Services, password string) error) error {
+	return c.start(cmd, func(ctx context.Context, s Services, login, password string) error {
+		tokens, err := s.Authorization.Authorize(ctx, login, password)
+		var mfaErr *client.MFARequiredError
+		if errors.As(err, &mfaErr) {
+			tokens, err = c.verifyMFA(cmd, s, mfaErr.Challenge, login, password)
+		}
+		if err != nil {
+			return fmt.Errorf("%w: %w", errUnauthenticated, err)
+		}
+		s.User.SetInfo(login, tokens)
+		// Каждый запуск создает сессию на сервере, завершаем ее по окончании.
+		defer s.Authorization.Logout(context.WithoutCancel(ctx))
+
+		return fn(ctx, s, password)
+	})
+}
+
+func (c *cli) start(
+	cmd *cobra.Command,
+	fn func(ctx context.Context, s Services, login, password string) error,
+) error {
+	login, password, err := c.credentials(cmd)
+	if err != nil {
+		return err
+	}
+
+	var s Services
+	app := fx.New(c.modules, fx.Populate(&s))
+	if err := app.Err(); err != nil {
+		return err
+	}
+
+	ctx := cmd.Context()
+	if err := app.Start(ctx); err != nil {
+		return err
+	}
+	defer app.Stop(context.Background())
+
+	return fn(ctx, s, login, password)
+}
+
+// verifyMFA завершает вход кодом второго фактора из переменной окружения
+// или, если клиент запущен в терминале, запрашивает код.
+func (c *cli) verifyMFA(cmd *cobra.Command, s Services, challenge, login, password string) (client.Tokens, error) {
+	code := os.Getenv(envMFACode)
+
+	stdin, ok := cmd.InOrStdin().(*os.File)
+	if code == "" && ok && term.IsTerminal(int(stdin.Fd())) {
+		fmt.Fprint(cmd.ErrOrStderr(), "One-time or recovery code: ")
+		line, err := bufio.NewReader(stdin).ReadString('\n')
+		if err != nil && err != io.EOF {
+			return client.Tokens{}, err
+		}
+		code = strings.TrimSpace(line)
+	}
+
+	if code == "" {
+		return client.Tokens{}, fmt.Errorf("second factor is required, set %s", envMFACode)
+	}
+
+	return s.Authorization.VerifyMFA(cmd.Context(), challenge, login, password, code)
+}
+
+// credentials возвращает логин и мастер-пароль из флагов, переменных
+// окружения или, если клиент запущен в терминале, запрашивает их.
+func (c *cli) credentials(cmd *cobra.Command) (string, string, error) {
+	login := c.user
+	if login == "" {
+		login = os.Getenv(envLogin)
+	}
+	password := os.Getenv(envPassword)
+
+	stdin, ok := cmd.InOrStdin().(*os.File)
+	interactive := ok && term.IsTerminal(int(stdin.Fd()))
+
+	if login == "" && interactive {
+		fmt.Fprint(cmd.ErrOrStderr(), "Login: ")
+		line, err := bufio.NewReader(stdin).ReadString('\n')
+		if err != nil && err != io.EOF {
+			return "", "", err
+		}
+		login = strings.TrimSpace(line)
+	}
+	if password == "" && interactive {
+		fmt.Fprint(cmd.ErrOrStderr(), "Master password: ")
+		b, err := term.ReadPassword(int(stdin.Fd()))
+		fmt.Fprintln(cmd.ErrOrStderr())
+		if err != nil {
+			return "", "", err
+		}
+		password = string(b)
+	}
+
+	if login == "" || password == "" {
+		return "", "", fmt.Errorf("%w: login and master password are required", errUnauthenticated)
+	}
+
+	return login, password, nil
+}
+
+// newPassword возвращает новый мастер-пароль из переменной окружения или,
+// если клиент запущен в терминале, запрашивает его дважды.
+func (c *cli) newPassword(cmd *cobra.Command) (string, error) {
+	if password := os.Getenv(envNewPassword); password != "" {
+		return password, nil
+	}
+
+	stdin, ok := cmd.InOrStdin().(*os.File)
+	if !ok || !term.IsTerminal(int(stdin.Fd())) {
+		return "", &usageError{fmt.Errorf("new master password is required, set %s", envNewPassword)}
+	}
+
+	var passwords [2]string
+	for i, prompt := range []string{"New master password: ", "Repeat new master password: "} {
+		fmt.Fprint(cmd.ErrOrStderr(), prompt)
+		b, err := term.ReadPassword(int(stdin.Fd()))
+		fmt.Fprintln(cmd.ErrOrStderr())
+		if err != nil {
+			return "", err
+		}
+		passwords[i] = string(b)
+	}
+
+	if passwords[0] == "" {
+		return "", &usageError{fmt.Errorf("new master password is empty")}
+	}
+	if passwords[0] != passwords[1] {
+		return "", &usageError{fmt.Errorf("passwords do not match")}
+	}
+	return passwords[0], nil
+}
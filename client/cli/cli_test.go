@@ -0,0 +1,303 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/charmbracelet/log"
+	"github.com/mkolibaba/gophkeeper/client"
+	"github.com/mkolibaba/gophkeeper/client/inmem"
+	"github.com/mkolibaba/gophkeeper/client/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+type mocks struct {
+	authorization *mock.AuthorizationServiceMock
+	login         *mock.LoginServiceMock
+	note          *mock.NoteServiceMock
+	binary        *mock.BinaryServiceMock
+	card          *mock.CardServiceMock
+	otp           *mock.OTPServiceMock
+}
+
+func newMocks() *mocks {
+	return &mocks{
+		authorization: &mock.AuthorizationServiceMock{
+			AuthorizeFunc: func(ctx context.Context, login string, password string) (client.Tokens, error) {
+				return client.Tokens{AccessToken: "token", RefreshToken: "refresh token"}, nil
+			},
+			LogoutFunc: func(ctx context.Context) error {
+				return nil
+			},
+		},
+		login: &mock.LoginServiceMock{
+			GetAllFunc: func(ctx context.Context) ([]client.LoginData, error) {
+				return []client.LoginData{
+					{ID: 1, Name: "github", Login: "octocat", Password: "secret", Version: 3},
+				}, nil
+			},
+		},
+		note:   &mock.NoteServiceMock{},
+		binary: &mock.BinaryServiceMock{},
+		card:   &mock.CardServiceMock{},
+		otp:    &mock.OTPServiceMock{},
+	}
+}
+
+func (m *mocks) modules() fx.Option {
+	return fx.Options(
+		fx.NopLogger,
+		fx.Provide(
+			func() client.AuthorizationService { return m.authorization },
+			func() client.UserService { return inmem.NewUserService(log.New(io.Discard)) },
+			func() client.LoginService { return m.login },
+			func() client.NoteService { return m.note },
+			func() client.BinaryService { return m.binary },
+			func() client.CardService { return m.card },
+			func() client.OTPService { return m.otp },
+		),
+	)
+}
+
+func execute(t *testing.T, m *mocks, stdin string, args ...string) (string, int) {
+	t.Setenv(envLogin, "user")
+	t.Setenv(envPassword, "password")
+
+	root := NewRootCommand(client.BuildInfo{Version: "v1.0.0"}, func() error {
+		t.Fatal("tui must not be started")
+		return nil
+	}, m.modules())
+
+	var out bytes.Buffer
+	root.SetArgs(args)
+	root.SetIn(strings.NewReader(stdin))
+	root.SetOut(&out)
+	root.SetErr(io.Discard)
+
+	code := Execute(root)
+	return out.String(), code
+}
+
+func TestList(t *testing.T) {
+	m := newMocks()
+
+	out, code := execute(t, m, "", "ls")
+	require.Equal(t, ExitOK, code)
+	require.Contains(t, out, "KIND")
+	require.Contains(t, out, "login  1   github  3")
+
+	out, code = execute(t, m, "", "ls", "-o", "json", "--kind", "login")
+	require.Equal(t, ExitOK, code)
+	require.JSONEq(t, `[{"kind":"login","id":1,"name":"github","version":3}]`, out)
+	require.Len(t, m.note.GetAllCalls(), 1)
+
+	authCalls := m.authorization.AuthorizeCalls()
+	require.Len(t, authCalls, 2)
+	require.Equal(t, "user", authCalls[0].Login)
+	require.Equal(t, "password", authCalls[0].Password)
+	require.Len(t, m.authorization.LogoutCalls(), 2)
+}
+
+func TestGet(t *testing.T) {
+	m := newMocks()
+
+	out, code := execute(t, m, "", "get", "github", "--field", "password")
+	require.Equal(t, ExitOK, code)
+	require.Equal(t, "secret\n", out)
+
+	out, code = execute(t, m, "", "get", "github", "-o", "json")
+	require.Equal(t, ExitOK, code)
+	require.JSONEq(t, `{
+		"kind": "login", "id": 1, "version": 3, "name": "github", "login": "octocat",
+		"password": "secret", "website": "", "notes": ""
+	}`, out)
+
+	_, code = execute(t, m, "", "get", "gitlab")
+	require.Equal(t, ExitNotFound, code)
+
+	_, code = execute(t, m, "", "get", "github", "--field", "cvv")
+	require.Equal(t, ExitUsage, code)
+}
+
+func TestAdd(t *testing.T) {
+	m := newMocks()
+
+	_, code := execute(t, m, "top secret\n",
+		"add", "login", "--name", "gitlab", "--login", "octocat", "--password", "-")
+	require.Equal(t, ExitOK, code)
+
+	calls := m.login.SaveCalls()
+	require.Len(t, calls, 1)
+	require.Equal(t, client.LoginData{
+		Name:     "gitlab",
+		Login:    "octocat",
+		Password: "top secret",
+	}, calls[0].Data)
+
+	_, code = execute(t, m, "", "add", "otp", "--name", "mail", "--secret", "JBSWY3DPEHPK3PXP", "--digits", "8")
+	require.Equal(t, ExitOK, code)
+
+	otpCalls := m.otp.SaveCalls()
+	require.Len(t, otpCalls, 1)
+	require.Equal(t, client.OTPData{
+		Name:      "mail",
+		Type:      client.OTPTypeTOTP,
+		Secret:    "JBSWY3DPEHPK3PXP",
+		Algorithm: "SHA1",
+		Digits:    8,
+		Period:    30,
+	}, otpCalls[0].Data)
+}
+
+func TestEdit(t *testing.T) {
+	m := newMocks()
+
+	_, code := execute(t, m, "", "edit", "login", "github", "--password", "new secret")
+	require.Equal(t, ExitOK, code)
+
+	calls := m.login.UpdateCalls()
+	require.Len(t, calls, 1)
+	update := calls[0].Data
+	require.Equal(t, int64(1), update.ID)
+	require.Equal(t, int64(3), update.Version)
+	require.Equal(t, "new secret", *update.Password)
+	require.Nil(t, update.Name)
+
+	m.login.UpdateFunc = func(ctx context.Context, data client.LoginDataUpdate) error {
+		return &client.VersionConflictError{Version: 4}
+	}
+	_, code = execute(t, m, "", "edit", "login", "github", "--notes", "x")
+	require.Equal(t, ExitConflict, code)
+
+	_, code = execute(t, m, "", "edit", "login", "github")
+	require.Equal(t, ExitUsage, code)
+}
+
+func TestRemove(t *testing.T) {
+	m := newMocks()
+
+	_, code := execute(t, m, "", "rm", "github")
+	require.Equal(t, ExitOK, code)
+
+	calls := m.login.RemoveCalls()
+	require.Len(t, calls, 1)
+	require.Equal(t, int64(1), calls[0].ID)
+	require.Equal(t, int64(3), calls[0].Version)
+}
+
+func TestSessions(t *testing.T) {
+	m := newMocks()
+	created := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	m.authorization.ListSessionsFunc = func(ctx context.Context) ([]client.Session, error) {
+		return []client.Session{
+			{ID: "abc", CreatedAt: created, LastUsedAt: created, ExpiresAt: created, Current: true},
+		}, nil
+	}
+	m.authorization.RevokeSessionFunc = func(ctx context.Context, id string) error {
+		if id != "abc" {
+			return status.Error(codes.NotFound, "session not found")
+		}
+		return nil
+	}
+
+	out, code := execute(t, m, "", "sessions", "-o", "json")
+	require.Equal(t, ExitOK, code)
+	require.JSONEq(t, `[{
+		"id": "abc", "created_at": "2025-01-01T12:00:00Z", "last_used_at": "2025-01-01T12:00:00Z",
+		"expires_at": "2025-01-01T12:00:00Z", "current": true
+	}]`, out)
+
+	_, code = execute(t, m, "", "revoke", "abc")
+	require.Equal(t, ExitOK, code)
+
+	_, code = execute(t, m, "", "revoke", "unknown")
+	require.Equal(t, ExitNotFound, code)
+}
+
+func TestPasswd(t *testing.T) {
+	m := newMocks()
+	m.authorization.ChangePasswordFunc = func(ctx context.Context, login string, currentPassword string, newPassword string) error {
+		return nil
+	}
+
+	// Без терминала новый пароль берется только из переменной окружения.
+	_, code := execute(t, m, "", "passwd")
+	require.Equal(t, ExitUsage, code)
+
+	t.Setenv(envNewPassword, "new password")
+	_, code = execute(t, m, "", "passwd")
+	require.Equal(t, ExitOK, code)
+
+	calls := m.authorization.ChangePasswordCalls()
+	require.Len(t, calls, 1)
+	require.Equal(t, "user", calls[0].Login)
+	require.Equal(t, "password", calls[0].CurrentPassword)
+	require.Equal(t, "new password", calls[0].NewPassword)
+}
+
+func TestDeleteAccount(t *testing.T) {
+	m := newMocks()
+	m.authorization.DeleteAccountFunc = func(ctx context.Context, login string, password string) error {
+		return nil
+	}
+
+	_, code := execute(t, m, "", "delete-account")
+	require.Equal(t, ExitUsage, code)
+	require.Empty(t, m.authorization.DeleteAccountCalls())
+
+	path := filepath.Join(t.TempDir(), "export.json")
+	_, code = execute(t, m, "", "delete-account", "--yes", "--export", path)
+	require.Equal(t, ExitOK, code)
+
+	calls := m.authorization.DeleteAccountCalls()
+	require.Len(t, calls, 1)
+	require.Equal(t, "user", calls[0].Login)
+	require.Equal(t, "password", calls[0].Password)
+
+	exported, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.JSONEq(t, `[{
+		"kind": "login", "id": 1, "version": 3, "name": "github", "login": "octocat",
+		"password": "secret", "website": "", "notes": ""
+	}]`, string(exported))
+
+	// Существующий файл не перезаписывается, и учетная запись не удаляется.
+	_, code = execute(t, m, "", "delete-account", "--yes", "--export", path)
+	require.Equal(t, ExitError, code)
+	require.Len(t, m.authorization.DeleteAccountCalls(), 1)
+}
+
+func TestVersion(t *testing.T) {
+	out, code := execute(t, newMocks(), "", "--version")
+	require.Equal(t, ExitOK, code)
+	require.Contains(t, out, "v1.0.0")
+}
+
+func TestExitCodes(t *testing.T) {
+	m := newMocks()
+	m.authorization.AuthorizeFunc = func(ctx context.Context, login string, password string) (client.Tokens, error) {
+		return client.Tokens{}, fmt.Errorf("invalid credentials")
+	}
+
+	_, code := execute(t, m, "", "ls")
+	require.Equal(t, ExitUnauthenticated, code)
+	require.Empty(t, m.login.GetAllCalls())
+
+	_, code = execute(t, m, "", "ls", "--kind", "unknown")
+	require.Equal(t, ExitUsage, code)
+
+	_, code = execute(t, m, "", "ls", "-o", "yaml")
+	require.Equal(t, ExitUsage, code)
+
+	_, code = execute(t, m, "", "unknown")
+	require.Equal(t, ExitUsage, code)
+}
@@ -0,0 +1,508 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"github.com/mkolibaba/gophkeeper/client"
+	"github.com/spf13/cobra"
+	"io"
+	"os"
+	"strings"
+)
+
+func (c *cli) newLoginCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "login",
+		Short: "Check credentials and fill the local cache",
+		Args:  usageArgs(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return c.run(cmd, func(ctx context.Context, s Services) error {
+				// Получение данных синхронизирует локальный кэш, после чего
+				// записи доступны без связи с сервером.
+				for _, k := range kinds {
+					if _, err := k.all(ctx, s); err != nil {
+						return err
+					}
+				}
+				return c.printMessage(cmd.OutOrStdout(), "logged in as %s", s.User.GetUserLogin())
+			})
+		},
+	}
+}
+
+func (c *cli) newRegisterCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "register",
+		Short: "Register a new user",
+		Args:  usageArgs(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return c.start(cmd, func(ctx context.Context, s Services, login, password string) error {
+				tokens, err := s.Authorization.Register(ctx, login, password)
+				if err != nil {
+					return err
+				}
+				s.User.SetInfo(login, tokens)
+				defer s.Authorization.Logout(context.WithoutCancel(ctx))
+
+				return c.printMessage(cmd.OutOrStdout(), "registered %s", login)
+			})
+		},
+	}
+}
+
+func (c *cli) newListCommand() *cobra.Command {
+	var kindName string
+
+	cmd := &cobra.Command{
+		Use:     "ls",
+		Aliases: []string{"list"},
+		Short:   "List records",
+		Args:    usageArgs(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			selected, err := selectKinds(kindName)
+			if err != nil {
+				return err
+			}
+
+			return c.run(cmd, func(ctx context.Context, s Services) error {
+				var entries []entry
+				for _, k := range selected {
+					items, err := k.all(ctx, s)
+					if err != nil {
+						return err
+					}
+					for _, item := range items {
+						entries = append(entries, entry{
+							Kind:    k.name,
+							ID:      item.GetID(),
+							Name:    item.GetName(),
+							Version: item.GetVersion(),
+						})
+					}
+				}
+				return c.printEntries(cmd.OutOrStdout(), entries)
+			})
+		},
+	}
+	cmd.Flags().StringVarP(&kindName, "kind", "k", "", "list only records of the kind")
+
+	return cmd
+}
+
+func (c *cli) newGetCommand() *cobra.Command {
+	var kindName, fieldName string
+
+	cmd := &cobra.Command{
+		Use:   "get <name>",
+		Short: "Show a record or one of its fields",
+		Args:  usageArgs(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return c.run(cmd, func(ctx context.Context, s Services) error {
+				r, err := find(ctx, s, kindName, args[0])
+				if err != nil {
+					return err
+				}
+
+				if fieldName == "" {
+					return c.printRecord(cmd.OutOrStdout(), r)
+				}
+
+				var names []string
+				for _, v := range r.values() {
+					if v.name == fieldName {
+						return c.printValue(cmd.OutOrStdout(), v.value)
+					}
+					names = append(names, v.name)
+				}
+				return &usageError{fmt.Errorf("%s has no field %q, available fields: %s",
+					r.kind.name, fieldName, strings.Join(names, ", "))}
+			})
+		},
+	}
+	cmd.Flags().StringVarP(&kindName, "kind", "k", "", "kind of the record")
+	cmd.Flags().StringVarP(&fieldName, "field", "f", "", "print only the field value")
+
+	return cmd
+}
+
+func (c *cli) newAddCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "add <kind>",
+		Short: "Add a record",
+		Long:  "Add a record. A flag value \"-\" is read from stdin.",
+		Args:  usageArgs(cobra.NoArgs),
+	}
+
+	for _, k := range kinds {
+		sub := &cobra.Command{
+			Use:   k.name,
+			Short: fmt.Sprintf("Add %s", k.name),
+			Args:  usageArgs(cobra.NoArgs),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				values, err := readFlagValues(cmd, k.fields)
+				if err != nil {
+					return err
+				}
+
+				return c.run(cmd, func(ctx context.Context, s Services) error {
+					if err := k.save(ctx, s, values); err != nil {
+						return err
+					}
+					return c.printMessage(cmd.OutOrStdout(), "%s %s saved", k.name, values["name"])
+				})
+			},
+		}
+		for _, f := range k.fields {
+			sub.Flags().String(f.name, "", f.usage)
+		}
+		cmd.AddCommand(sub)
+	}
+
+	return cmd
+}
+
+func (c *cli) newEditCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "edit <kind>",
+		Short: "Change fields of a record",
+		Long:  "Change fields of a record. Only the passed flags are changed, a flag value \"-\" is read from stdin.",
+		Args:  usageArgs(cobra.NoArgs),
+	}
+
+	for _, k := range kinds {
+		var fields []field
+		for _, f := range k.fields {
+			if !f.createOnly {
+				fields = append(fields, f)
+			}
+		}
+
+		sub := &cobra.Command{
+			Use:   k.name + " <name>",
+			Short: fmt.Sprintf("Change %s", k.name),
+			Args:  usageArgs(cobra.ExactArgs(1)),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				values, err := readFlagValues(cmd, fields)
+				if err != nil {
+					return err
+				}
+				if len(values) == 0 {
+					return &usageError{fmt.Errorf("nothing to change")}
+				}
+
+				return c.run(cmd, func(ctx context.Context, s Services) error {
+					r, err := find(ctx, s, k.name, args[0])
+					if err != nil {
+						return err
+					}
+					if err := k.update(ctx, s, r.data, values); err != nil {
+						return err
+					}
+					return c.printMessage(cmd.OutOrStdout(), "%s %s changed", k.name, args[0])
+				})
+			},
+		}
+		for _, f := range fields {
+			sub.Flags().String(f.name, "", f.usage)
+		}
+		cmd.AddCommand(sub)
+	}
+
+	return cmd
+}
+
+func (c *cli) newRemoveCommand() *cobra.Command {
+	var kindName string
+
+	cmd := &cobra.Command{
+		Use:   "rm <name>",
+		Short: "Remove a record",
+		Args:  usageArgs(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return c.run(cmd, func(ctx context.Context, s Services) error {
+				r, err := find(ctx, s, kindName, args[0])
+				if err != nil {
+					return err
+				}
+				if err := r.kind.remove(ctx, s, r.data); err != nil {
+					return err
+				}
+				return c.printMessage(cmd.OutOrStdout(), "%s %s removed", r.kind.name, args[0])
+			})
+		},
+	}
+	cmd.Flags().StringVarP(&kindName, "kind", "k", "", "kind of the record")
+
+	return cmd
+}
+
+func (c *cli) newDownloadCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "download <name>",
+		Short: "Download a file to the download directory",
+		Args:  usageArgs(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return c.run(cmd, func(ctx context.Context, s Services) error {
+				r, err := find(ctx, s, "file", args[0])
+				if err != nil {
+					return err
+				}
+				path, err := s.Binary.Download(ctx, r.data.GetID())
+				if err != nil {
+					return err
+				}
+				return c.printMessage(cmd.OutOrStdout(), "%s downloaded to %s", r.data.(client.BinaryData).Filename, path)
+			})
+		},
+	}
+}
+
+func (c *cli) newSessionsCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "sessions",
+		Short: "List active sessions",
+		Args:  usageArgs(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return c.run(cmd, func(ctx context.Context, s Services) error {
+				sessions, err := s.Authorization.ListSessions(ctx)
+				if err != nil {
+					return err
+				}
+				return c.printSessions(cmd.OutOrStdout(), sessions)
+			})
+		},
+	}
+}
+
+func (c *cli) newRevokeCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "revoke <session id>",
+		Short: "Revoke a session",
+		Args:  usageArgs(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return c.run(cmd, func(ctx context.Context, s Services) error {
+				if err := s.Authorization.RevokeSession(ctx, args[0]); err != nil {
+					return err
+				}
+				return c.printMessage(cmd.OutOrStdout(), "session %s revoked", args[0])
+			})
+		},
+	}
+}
+
+func (c *cli) newMFACommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "mfa",
+		Short: "Manage two-factor authentication",
+		Long: "Manage two-factor authentication. When it is enabled, commands ask for a one-time " +
+			"or recovery code, or take it from " + envMFACode + ".",
+		Args: usageArgs(cobra.NoArgs),
+	}
+
+	cmd.AddCommand(
+		&cobra.Command{
+			Use:   "setup",
+			Short: "Create a new TOTP secret for an authenticator app",
+			Args:  usageArgs(cobra.NoArgs),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				return c.run(cmd, func(ctx context.Context, s Services) error {
+					setup, err := s.Authorization.SetupMFA(ctx)
+					if err != nil {
+						return err
+					}
+					return c.printMFASetup(cmd.OutOrStdout(), setup)
+				})
+			},
+		},
+		&cobra.Command{
+			Use:   "enable <code>",
+			Short: "Confirm the secret with a code and print recovery codes",
+			Args:  usageArgs(cobra.ExactArgs(1)),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				return c.run(cmd, func(ctx context.Context, s Services) error {
+					codes, err := s.Authorization.EnableMFA(ctx, args[0])
+					if err != nil {
+						return err
+					}
+					return c.printValues(cmd.OutOrStdout(), codes)
+				})
+			},
+		},
+		&cobra.Command{
+			Use:   "disable <code>",
+			Short: "Disable two-factor authentication",
+			Args:  usageArgs(cobra.ExactArgs(1)),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				return c.run(cmd, func(ctx context.Context, s Services) error {
+					if err := s.Authorization.DisableMFA(ctx, args[0]); err != nil {
+						return err
+					}
+					return c.printMessage(cmd.OutOrStdout(), "two-factor authentication disabled")
+				})
+			},
+		},
+	)
+
+	return cmd
+}
+
+func (c *cli) newPasswdCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "passwd",
+		Short: "Change the master password",
+		Long: "Change the master password. The new password is taken from " + envNewPassword +
+			" or requested interactively. Other sessions are revoked.",
+		Args: usageArgs(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return c.runWithPassword(cmd, func(ctx context.Context, s Services, password string) error {
+				newPassword, err := c.newPassword(cmd)
+				if err != nil {
+					return err
+				}
+				if err := s.Authorization.ChangePassword(ctx, s.User.GetUserLogin(), password, newPassword); err != nil {
+					return err
+				}
+				return c.printMessage(cmd.OutOrStdout(), "master password changed")
+			})
+		},
+	}
+}
+
+func (c *cli) newDeleteAccountCommand() *cobra.Command {
+	var exportPath string
+	var confirmed bool
+
+	cmd := &cobra.Command{
+		Use:   "delete-account",
+		Short: "Delete the account and all its records",
+		Long: "Delete the account and all its records on the server. With --export the records " +
+			"are first saved decrypted to a JSON file. File contents are not exported, " +
+			"use download to keep them.",
+		Args: usageArgs(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !confirmed {
+				return &usageError{fmt.Errorf("the account can not be restored, pass --yes to confirm")}
+			}
+
+			return c.runWithPassword(cmd, func(ctx context.Context, s Services, password string) error {
+				if exportPath != "" {
+					if err := export(ctx, s, exportPath); err != nil {
+						return fmt.Errorf("export: %w", err)
+					}
+				}
+
+				login := s.User.GetUserLogin()
+				if err := s.Authorization.DeleteAccount(ctx, login, password); err != nil {
+					return err
+				}
+				return c.printMessage(cmd.OutOrStdout(), "account %s deleted", login)
+			})
+		},
+	}
+	cmd.Flags().StringVar(&exportPath, "export", "", "save all records to the JSON file before deletion")
+	cmd.Flags().BoolVar(&confirmed, "yes", false, "confirm the deletion")
+
+	return cmd
+}
+
+// selectKinds возвращает тип с именем name или все типы, если имя не задано.
+func selectKinds(name string) ([]*kind, error) {
+	if name == "" {
+		return kinds, nil
+	}
+	k, err := findKind(name)
+	if err != nil {
+		return nil, err
+	}
+	return []*kind{k}, nil
+}
+
+// find ищет запись по имени среди записей типа kindName или всех типов.
+func find(ctx context.Context, s Services, kindName string, name string) (record, error) {
+	selected, err := selectKinds(kindName)
+	if err != nil {
+		return record{}, err
+	}
+
+	var found []record
+	for _, k := range selected {
+		items, err := k.all(ctx, s)
+		if err != nil {
+			return record{}, err
+		}
+		for _, item := range items {
+			if item.GetName() == name {
+				found = append(found, record{kind: k, data: item})
+			}
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return record{}, fmt.Errorf("record %q: %w", name, errNotFound)
+	case 1:
+		return found[0], nil
+	default:
+		return record{}, fmt.Errorf("there are %d records named %q, specify the kind", len(found), name)
+	}
+}
+
+// export сохраняет все записи пользователя в расшифрованном виде
+// в JSON-файл path.
+func export(ctx context.Context, s Services, path string) error {
+	var records []record
+	for _, k := range kinds {
+		items, err := k.all(ctx, s)
+		if err != nil {
+			return err
+		}
+		for _, item := range items {
+			records = append(records, record{kind: k, data: item})
+		}
+	}
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	objects := make([]map[string]any, 0, len(records))
+	for _, r := range records {
+		objects = append(objects, r.object())
+	}
+	if err := printJSON(file, objects); err != nil {
+		return err
+	}
+	return file.Close()
+}
+
+// readFlagValues собирает явно заданные флаги полей. Значение "-" читается
+// из stdin, так секреты не попадают в список процессов.
+func readFlagValues(cmd *cobra.Command, fields []field) (flagValues, error) {
+	values := flagValues{}
+	stdinRead := false
+
+	for _, f := range fields {
+		flag := cmd.Flags().Lookup(f.name)
+		if flag == nil || !flag.Changed {
+			continue
+		}
+
+		value := flag.Value.String()
+		if value == "-" {
+			if stdinRead {
+				return nil, &usageError{fmt.Errorf("only one flag can be read from stdin")}
+			}
+			b, err := io.ReadAll(cmd.InOrStdin())
+			if err != nil {
+				return nil, err
+			}
+			value = strings.TrimSuffix(string(b), "\n")
+			stdinRead = true
+		}
+		values[f.name] = value
+	}
+
+	return values, nil
+}
@@ -0,0 +1,380 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"github.com/mkolibaba/gophkeeper/client"
+	"strconv"
+	"time"
+)
+
+// field - поле записи, задаваемое флагом команд add и edit.
+type field struct {
+	name  string
+	usage string
+	// createOnly - поле можно задать только при создании записи.
+	createOnly bool
+}
+
+// value - значение поля записи для вывода.
+type value struct {
+	name  string
+	value string
+}
+
+// kind описывает тип данных для команд: как получить, сохранить, изменить
+// и удалить записи этого типа.
+type kind struct {
+	name   string
+	fields []field
+
+	all    func(ctx context.Context, s Services) ([]client.Data, error)
+	save   func(ctx context.Context, s Services, values flagValues) error
+	update func(ctx context.Context, s Services, data client.Data, values flagValues) error
+	remove func(ctx context.Context, s Services, data client.Data) error
+	show   func(data client.Data) []value
+}
+
+// record - запись вместе с ее типом.
+type record struct {
+	kind *kind
+	data client.Data
+}
+
+func (r record) values() []value {
+	return r.kind.show(r.data)
+}
+
+// object возвращает все поля записи для вывода в JSON.
+func (r record) object() map[string]any {
+	object := map[string]any{
+		"kind":    r.kind.name,
+		"id":      r.data.GetID(),
+		"version": r.data.GetVersion(),
+	}
+	for _, v := range r.values() {
+		object[v.name] = v.value
+	}
+	return object
+}
+
+var kinds = []*kind{
+	{
+		name: "login",
+		fields: []field{
+			{name: "name", usage: "record name"},
+			{name: "login", usage: "login"},
+			{name: "password", usage: "password"},
+			{name: "website", usage: "website"},
+			{name: "notes", usage: "notes"},
+		},
+		all: func(ctx context.Context, s Services) ([]client.Data, error) {
+			return collect(s.Login.GetAll(ctx))
+		},
+		save: func(ctx context.Context, s Services, v flagValues) error {
+			return s.Login.Save(ctx, client.LoginData{
+				Name:     v["name"],
+				Login:    v["login"],
+				Password: v["password"],
+				Website:  v["website"],
+				Notes:    v["notes"],
+			})
+		},
+		update: func(ctx context.Context, s Services, data client.Data, v flagValues) error {
+			return s.Login.Update(ctx, client.LoginDataUpdate{
+				ID:       data.GetID(),
+				Version:  data.GetVersion(),
+				Name:     v.ptr("name"),
+				Login:    v.ptr("login"),
+				Password: v.ptr("password"),
+				Website:  v.ptr("website"),
+				Notes:    v.ptr("notes"),
+			})
+		},
+		remove: func(ctx context.Context, s Services, data client.Data) error {
+			return s.Login.Remove(ctx, data.GetID(), data.GetVersion())
+		},
+		show: func(data client.Data) []value {
+			d := data.(client.LoginData)
+			return []value{
+				{"name", d.Name},
+				{"login", d.Login},
+				{"password", d.Password},
+				{"website", d.Website},
+				{"notes", d.Notes},
+			}
+		},
+	},
+	{
+		name: "note",
+		fields: []field{
+			{name: "name", usage: "record name"},
+			{name: "text", usage: "note text"},
+		},
+		all: func(ctx context.Context, s Services) ([]client.Data, error) {
+			return collect(s.Note.GetAll(ctx))
+		},
+		save: func(ctx context.Context, s Services, v flagValues) error {
+			return s.Note.Save(ctx, client.NoteData{
+				Name: v["name"],
+				Text: v["text"],
+			})
+		},
+		update: func(ctx context.Context, s Services, data client.Data, v flagValues) error {
+			return s.Note.Update(ctx, client.NoteDataUpdate{
+				ID:      data.GetID(),
+				Version: data.GetVersion(),
+				Name:    v.ptr("name"),
+				Text:    v.ptr("text"),
+			})
+		},
+		remove: func(ctx context.Context, s Services, data client.Data) error {
+			return s.Note.Remove(ctx, data.GetID(), data.GetVersion())
+		},
+		show: func(data client.Data) []value {
+			d := data.(client.NoteData)
+			return []value{
+				{"name", d.Name},
+				{"text", d.Text},
+			}
+		},
+	},
+	{
+		name: "card",
+		fields: []field{
+			{name: "name", usage: "record name"},
+			{name: "number", usage: "card number"},
+			{name: "exp-date", usage: "expiration date (MM/YY)"},
+			{name: "cvv", usage: "CVV"},
+			{name: "cardholder", usage: "cardholder"},
+			{name: "notes", usage: "notes"},
+		},
+		all: func(ctx context.Context, s Services) ([]client.Data, error) {
+			return collect(s.Card.GetAll(ctx))
+		},
+		save: func(ctx context.Context, s Services, v flagValues) error {
+			return s.Card.Save(ctx, client.CardData{
+				Name:       v["name"],
+				Number:     v["number"],
+				ExpDate:    v["exp-date"],
+				CVV:        v["cvv"],
+				Cardholder: v["cardholder"],
+				Notes:      v["notes"],
+			})
+		},
+		update: func(ctx context.Context, s Services, data client.Data, v flagValues) error {
+			return s.Card.Update(ctx, client.CardDataUpdate{
+				ID:         data.GetID(),
+				Version:    data.GetVersion(),
+				Name:       v.ptr("name"),
+				Number:     v.ptr("number"),
+				ExpDate:    v.ptr("exp-date"),
+				CVV:        v.ptr("cvv"),
+				Cardholder: v.ptr("cardholder"),
+				Notes:      v.ptr("notes"),
+			})
+		},
+		remove: func(ctx context.Context, s Services, data client.Data) error {
+			return s.Card.Remove(ctx, data.GetID(), data.GetVersion())
+		},
+		show: func(data client.Data) []value {
+			d := data.(client.CardData)
+			return []value{
+				{"name", d.Name},
+				{"number", d.Number},
+				{"exp-date", d.ExpDate},
+				{"cvv", d.CVV},
+				{"cardholder", d.Cardholder},
+				{"notes", d.Notes},
+			}
+		},
+	},
+	{
+		name: "file",
+		fields: []field{
+			{name: "name", usage: "record name"},
+			{name: "path", usage: "path to the file to upload", createOnly: true},
+			{name: "notes", usage: "notes"},
+		},
+		all: func(ctx context.Context, s Services) ([]client.Data, error) {
+			return collect(s.Binary.GetAll(ctx))
+		},
+		save: func(ctx context.Context, s Services, v flagValues) error {
+			return s.Binary.Save(ctx, client.BinaryData{
+				Name:     v["name"],
+				Filename: v["path"],
+				Notes:    v["notes"],
+			})
+		},
+		update: func(ctx context.Context, s Services, data client.Data, v flagValues) error {
+			return s.Binary.Update(ctx, client.BinaryDataUpdate{
+				ID:      data.GetID(),
+				Version: data.GetVersion(),
+				Name:    v.ptr("name"),
+				Notes:   v.ptr("notes"),
+			})
+		},
+		remove: func(ctx context.Context, s Services, data client.Data) error {
+			return s.Binary.Remove(ctx, data.GetID(), data.GetVersion())
+		},
+		show: func(data client.Data) []value {
+			d := data.(client.BinaryData)
+			return []value{
+				{"name", d.Name},
+				{"filename", d.Filename},
+				{"size", strconv.FormatInt(d.Size, 10)},
+				{"notes", d.Notes},
+			}
+		},
+	},
+	{
+		name: "otp",
+		fields: []field{
+			{name: "uri", usage: "otpauth:// URI, other fields override its parameters", createOnly: true},
+			{name: "name", usage: "record name"},
+			{name: "secret", usage: "base32 secret"},
+			{name: "issuer", usage: "issuer"},
+			{name: "type", usage: "totp or hotp (default totp)"},
+			{name: "algorithm", usage: "SHA1, SHA256 or SHA512 (default SHA1)"},
+			{name: "digits", usage: "code length (default 6)"},
+			{name: "period", usage: "TOTP period in seconds (default 30)"},
+			{name: "counter", usage: "HOTP counter"},
+		},
+		all: func(ctx context.Context, s Services) ([]client.Data, error) {
+			return collect(s.OTP.GetAll(ctx))
+		},
+		save: func(ctx context.Context, s Services, v flagValues) error {
+			data := client.OTPData{
+				Type:      client.OTPTypeTOTP,
+				Algorithm: "SHA1",
+				Digits:    6,
+				Period:    30,
+			}
+			if uri, ok := v["uri"]; ok {
+				var err error
+				if data, err = client.ParseOTPURI(uri); err != nil {
+					return err
+				}
+			}
+
+			update, err := newOTPDataUpdate(v)
+			if err != nil {
+				return err
+			}
+			set(&data.Name, update.Name)
+			set(&data.Type, update.Type)
+			set(&data.Secret, update.Secret)
+			set(&data.Algorithm, update.Algorithm)
+			set(&data.Digits, update.Digits)
+			set(&data.Period, update.Period)
+			set(&data.Counter, update.Counter)
+			set(&data.Issuer, update.Issuer)
+
+			return s.OTP.Save(ctx, data)
+		},
+		update: func(ctx context.Context, s Services, data client.Data, v flagValues) error {
+			update, err := newOTPDataUpdate(v)
+			if err != nil {
+				return err
+			}
+			update.ID = data.GetID()
+			update.Version = data.GetVersion()
+			return s.OTP.Update(ctx, update)
+		},
+		remove: func(ctx context.Context, s Services, data client.Data) error {
+			return s.OTP.Remove(ctx, data.GetID(), data.GetVersion())
+		},
+		show: func(data client.Data) []value {
+			d := data.(client.OTPData)
+			code, err := d.Code(time.Now())
+			if err != nil {
+				code = err.Error()
+			}
+			return []value{
+				{"name", d.Name},
+				{"type", d.Type},
+				{"secret", d.Secret},
+				{"algorithm", d.Algorithm},
+				{"digits", strconv.FormatInt(d.Digits, 10)},
+				{"period", strconv.FormatInt(d.Period, 10)},
+				{"counter", strconv.FormatInt(d.Counter, 10)},
+				{"issuer", d.Issuer},
+				{"code", code},
+			}
+		},
+	},
+}
+
+func findKind(name string) (*kind, error) {
+	for _, k := range kinds {
+		if k.name == name {
+			return k, nil
+		}
+	}
+	return nil, &usageError{fmt.Errorf("unknown data kind %q", name)}
+}
+
+func newOTPDataUpdate(v flagValues) (client.OTPDataUpdate, error) {
+	update := client.OTPDataUpdate{
+		Name:      v.ptr("name"),
+		Type:      v.ptr("type"),
+		Secret:    v.ptr("secret"),
+		Algorithm: v.ptr("algorithm"),
+		Issuer:    v.ptr("issuer"),
+	}
+
+	var err error
+	if update.Digits, err = v.int("digits"); err != nil {
+		return client.OTPDataUpdate{}, err
+	}
+	if update.Period, err = v.int("period"); err != nil {
+		return client.OTPDataUpdate{}, err
+	}
+	if update.Counter, err = v.int("counter"); err != nil {
+		return client.OTPDataUpdate{}, err
+	}
+
+	return update, nil
+}
+
+// flagValues - значения полей, переданные флагами. Содержит только
+// явно заданные флаги.
+type flagValues map[string]string
+
+func (v flagValues) ptr(name string) *string {
+	value, ok := v[name]
+	if !ok {
+		return nil
+	}
+	return &value
+}
+
+func (v flagValues) int(name string) (*int64, error) {
+	value, ok := v[name]
+	if !ok {
+		return nil, nil
+	}
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return nil, &usageError{fmt.Errorf("--%s must be a number", name)}
+	}
+	return &n, nil
+}
+
+// collect приводит записи конкретного типа к client.Data.
+func collect[T client.Data](items []T, err error) ([]client.Data, error) {
+	if err != nil {
+		return nil, err
+	}
+	data := make([]client.Data, 0, len(items))
+	for _, item := range items {
+		data = append(data, item)
+	}
+	return data, nil
+}
+
+func set[T any](dst *T, src *T) {
+	if src != nil {
+		*dst = *src
+	}
+}
@@ -0,0 +1,145 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/mkolibaba/gophkeeper/client"
+	"io"
+	"text/tabwriter"
+	"time"
+)
+
+const (
+	outputPlain = "plain"
+	outputJSON  = "json"
+)
+
+// entry - краткое описание записи для списка.
+type entry struct {
+	Kind    string `json:"kind"`
+	ID      int64  `json:"id"`
+	Name    string `json:"name"`
+	Version int64  `json:"version"`
+}
+
+// printEntries выводит список записей таблицей или массивом JSON.
+func (c *cli) printEntries(w io.Writer, entries []entry) error {
+	if c.output == outputJSON {
+		if entries == nil {
+			entries = []entry{}
+		}
+		return printJSON(w, entries)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "KIND\tID\tNAME\tVERSION")
+	for _, e := range entries {
+		fmt.Fprintf(tw, "%s\t%d\t%s\t%d\n", e.Kind, e.ID, e.Name, e.Version)
+	}
+	return tw.Flush()
+}
+
+// printSessions выводит список сессий таблицей или массивом JSON.
+func (c *cli) printSessions(w io.Writer, sessions []client.Session) error {
+	if c.output == outputJSON {
+		type session struct {
+			ID         string    `json:"id"`
+			CreatedAt  time.Time `json:"created_at"`
+			LastUsedAt time.Time `json:"last_used_at"`
+			ExpiresAt  time.Time `json:"expires_at"`
+			Current    bool      `json:"current"`
+		}
+		result := make([]session, 0, len(sessions))
+		for _, s := range sessions {
+			result = append(result, session(s))
+		}
+		return printJSON(w, result)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tCREATED\tLAST USED\tEXPIRES\tCURRENT")
+	for _, s := range sessions {
+		current := ""
+		if s.Current {
+			current = "*"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", s.ID,
+			s.CreatedAt.Local().Format(time.DateTime),
+			s.LastUsedAt.Local().Format(time.DateTime),
+			s.ExpiresAt.Local().Format(time.DateTime),
+			current,
+		)
+	}
+	return tw.Flush()
+}
+
+// printRecord выводит все поля записи: в текстовом режиме по полю на строку,
+// в режиме JSON - объектом.
+func (c *cli) printRecord(w io.Writer, r record) error {
+	if c.output == outputJSON {
+		return printJSON(w, r.object())
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 1, ' ', 0)
+	fmt.Fprintf(tw, "kind:\t%s\n", r.kind.name)
+	fmt.Fprintf(tw, "id:\t%d\n", r.data.GetID())
+	fmt.Fprintf(tw, "version:\t%d\n", r.data.GetVersion())
+	for _, v := range r.values() {
+		fmt.Fprintf(tw, "%s:\t%s\n", v.name, v.value)
+	}
+	return tw.Flush()
+}
+
+// printValue выводит одно значение. В текстовом режиме значение выводится
+// как есть, чтобы его было удобно использовать в скриптах.
+func (c *cli) printValue(w io.Writer, value string) error {
+	if c.output == outputJSON {
+		return printJSON(w, value)
+	}
+	_, err := fmt.Fprintln(w, value)
+	return err
+}
+
+// printValues выводит значения по одному на строку или массивом JSON.
+func (c *cli) printValues(w io.Writer, values []string) error {
+	if c.output == outputJSON {
+		if values == nil {
+			values = []string{}
+		}
+		return printJSON(w, values)
+	}
+	for _, v := range values {
+		if _, err := fmt.Fprintln(w, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// printMFASetup выводит секрет второго фактора.
+func (c *cli) printMFASetup(w io.Writer, setup client.MFASetup) error {
+	if c.output == outputJSON {
+		return printJSON(w, map[string]string{"secret": setup.Secret, "uri": setup.URI})
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 1, ' ', 0)
+	fmt.Fprintf(tw, "secret:\t%s\n", setup.Secret)
+	fmt.Fprintf(tw, "uri:\t%s\n", setup.URI)
+	return tw.Flush()
+}
+
+// printMessage выводит сообщение о результате команды.
+func (c *cli) printMessage(w io.Writer, format string, args ...any) error {
+	message := fmt.Sprintf(format, args...)
+	if c.output == outputJSON {
+		return printJSON(w, map[string]string{"message": message})
+	}
+	_, err := fmt.Fprintln(w, message)
+	return err
+}
+
+func printJSON(w io.Writer, v any) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
@@ -3,26 +3,61 @@ package main
 import (
 	"github.com/charmbracelet/log"
 	"github.com/mkolibaba/gophkeeper/client"
+	"github.com/mkolibaba/gophkeeper/client/bolt"
+	"github.com/mkolibaba/gophkeeper/client/cli"
+	"github.com/mkolibaba/gophkeeper/client/crypto"
 	"github.com/mkolibaba/gophkeeper/client/grpc"
 	"github.com/mkolibaba/gophkeeper/client/inmem"
 	"github.com/mkolibaba/gophkeeper/client/tui"
 	"go.uber.org/fx"
 	"go.uber.org/fx/fxevent"
 	"log/slog"
+	"os"
+)
+
+// Сведения о сборке, задаются через -ldflags "-X main.version=...".
+var (
+	version = "dev"
+	commit  = "none"
+	date    = "unknown"
 )
 
 func main() {
+	root := cli.NewRootCommand(buildInfo(), runTUI, createModules())
+	os.Exit(cli.Execute(root))
+}
+
+func buildInfo() client.BuildInfo {
+	return client.BuildInfo{
+		Version: version,
+		Commit:  commit,
+		Date:    date,
+	}
+}
+
+func runTUI() error {
 	fx.New(createApp()).Run()
+	return nil
 }
 
 func createApp() fx.Option {
+	return fx.Options(
+		createModules(),
+		tui.Module,
+	)
+}
+
+// createModules возвращает модули, общие для TUI и команд без интерфейса.
+func createModules() fx.Option {
 	return fx.Options(
 		fx.WithLogger(func(logger *log.Logger) fxevent.Logger {
 			return &fxevent.SlogLogger{Logger: slog.New(logger)}
 		}),
+		fx.Supply(buildInfo()),
 		client.Module,
 		grpc.Module,
+		bolt.Module,
+		crypto.Module,
 		inmem.Module,
-		tui.Module,
 	)
 }
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"github.com/mkolibaba/gophkeeper/client/cli"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/fx"
 	"testing"
@@ -10,3 +11,8 @@ func TestValidateApp(t *testing.T) {
 	err := fx.ValidateApp(createApp())
 	require.NoError(t, err)
 }
+
+func TestValidateCLI(t *testing.T) {
+	err := fx.ValidateApp(createModules(), fx.Invoke(func(cli.Services) {}))
+	require.NoError(t, err)
+}
@@ -9,6 +9,28 @@ import (
 type Config struct {
 	GRPC struct {
 		ServerAddress string `mapstructure:"server_address"`
+		TLS           struct {
+			Enabled bool
+			// CAFile - сертификаты CA для проверки сервера. Если не задан,
+			// используются системные сертификаты.
+			CAFile string `mapstructure:"ca_file"`
+			// CertFile и KeyFile - клиентский сертификат для mTLS.
+			CertFile   string `mapstructure:"cert_file"`
+			KeyFile    string `mapstructure:"key_file"`
+			ServerName string `mapstructure:"server_name"`
+		}
+	}
+	Cache struct {
+		// Path - путь к файлу локального кэша хранилища.
+		Path string
+	}
+	Binary struct {
+		// Compression - сжатие файлов перед шифрованием: zstd или none.
+		// Файлы сжимаются, только если сервер поддерживает способ сжатия.
+		Compression string
+		// DownloadDir - каталог скачанных файлов. Если не задан,
+		// используется XDG_DOWNLOAD_DIR или ~/Downloads.
+		DownloadDir string `mapstructure:"download_dir"`
 	}
 	Log struct {
 		Output   string
@@ -0,0 +1,88 @@
+package crypto
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/mkolibaba/gophkeeper/client"
+)
+
+// BinaryService шифрует заметки к бинарным данным. Содержимое файлов
+// шифруется потоково в grpc.BinaryService.
+type BinaryService struct {
+	next     client.BinaryService
+	cipher   client.Cipher
+	validate *validator.Validate
+}
+
+func NewBinaryService(next client.BinaryService, cipher client.Cipher, validate *validator.Validate) *BinaryService {
+	return &BinaryService{
+		next:     next,
+		cipher:   cipher,
+		validate: validate,
+	}
+}
+
+func (s *BinaryService) Save(ctx context.Context, data client.BinaryData) error {
+	if err := s.validate.Struct(data); err != nil {
+		return err
+	}
+
+	fields := newFieldCipher(s.cipher, client.DataKindBinary)
+	fields.newItem()
+	fields.encrypt("notes", &data.Notes)
+	if fields.err != nil {
+		return fmt.Errorf("save: %w", fields.err)
+	}
+
+	return s.next.Save(ctx, data)
+}
+
+func (s *BinaryService) GetAll(ctx context.Context) ([]client.BinaryData, error) {
+	binaries, err := s.next.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := newFieldCipher(s.cipher, client.DataKindBinary)
+	for i := range binaries {
+		decryptBinary(fields, &binaries[i])
+	}
+	if fields.err != nil {
+		return nil, fmt.Errorf("get all: %w", fields.err)
+	}
+
+	return binaries, nil
+}
+
+func (s *BinaryService) Update(ctx context.Context, data client.BinaryDataUpdate) error {
+	fields := newFieldCipher(s.cipher, client.DataKindBinary)
+	if anySet(data.Notes) {
+		err := bindStored(ctx, fields, s.next.GetAll, data.ID, func(data client.BinaryData) string {
+			return data.Notes
+		})
+		if err != nil {
+			return fmt.Errorf("update: %w", err)
+		}
+	}
+	fields.encryptOptional("notes", &data.Notes)
+	if fields.err != nil {
+		return fmt.Errorf("update: %w", fields.err)
+	}
+
+	return s.next.Update(ctx, data)
+}
+
+func (s *BinaryService) Remove(ctx context.Context, id int64, version int64) error {
+	return s.next.Remove(ctx, id, version)
+}
+
+func (s *BinaryService) Download(ctx context.Context, id int64) (string, error) {
+	return s.next.Download(ctx, id)
+}
+
+// decryptBinary расшифровывает секретные поля записи data.
+func decryptBinary(f *fieldCipher, data *client.BinaryData) {
+	f.bind(data.Notes)
+	f.decrypt("notes", &data.Notes)
+}
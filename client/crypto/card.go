@@ -0,0 +1,121 @@
+package crypto
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/mkolibaba/gophkeeper/client"
+)
+
+// CardService шифрует данные карт перед передачей в next
+// и расшифровывает их при получении. Так как сервер получает только
+// шифротекст, формат полей карты проверяется здесь.
+type CardService struct {
+	next     client.CardService
+	cipher   client.Cipher
+	validate *validator.Validate
+}
+
+func NewCardService(next client.CardService, cipher client.Cipher, validate *validator.Validate) *CardService {
+	return &CardService{
+		next:     next,
+		cipher:   cipher,
+		validate: validate,
+	}
+}
+
+func (s *CardService) Save(ctx context.Context, data client.CardData) error {
+	if err := s.validate.Struct(data); err != nil {
+		return err
+	}
+
+	fields := newFieldCipher(s.cipher, client.DataKindCard)
+	fields.newItem()
+	fields.encrypt("number", &data.Number)
+	fields.encrypt("exp_date", &data.ExpDate)
+	fields.encrypt("cvv", &data.CVV)
+	fields.encrypt("cardholder", &data.Cardholder)
+	fields.encrypt("notes", &data.Notes)
+	if fields.err != nil {
+		return fmt.Errorf("save: %w", fields.err)
+	}
+
+	return s.next.Save(ctx, data)
+}
+
+func (s *CardService) GetAll(ctx context.Context) ([]client.CardData, error) {
+	cards, err := s.next.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := newFieldCipher(s.cipher, client.DataKindCard)
+	for i := range cards {
+		decryptCard(fields, &cards[i])
+	}
+	if fields.err != nil {
+		return nil, fmt.Errorf("get all: %w", fields.err)
+	}
+
+	return cards, nil
+}
+
+func (s *CardService) Update(ctx context.Context, data client.CardDataUpdate) error {
+	if err := s.validateUpdate(data); err != nil {
+		return err
+	}
+
+	fields := newFieldCipher(s.cipher, client.DataKindCard)
+	if anySet(data.Number, data.ExpDate, data.CVV, data.Cardholder, data.Notes) {
+		err := bindStored(ctx, fields, s.next.GetAll, data.ID, func(data client.CardData) string {
+			return data.Number
+		})
+		if err != nil {
+			return fmt.Errorf("update: %w", err)
+		}
+	}
+	fields.encryptOptional("number", &data.Number)
+	fields.encryptOptional("exp_date", &data.ExpDate)
+	fields.encryptOptional("cvv", &data.CVV)
+	fields.encryptOptional("cardholder", &data.Cardholder)
+	fields.encryptOptional("notes", &data.Notes)
+	if fields.err != nil {
+		return fmt.Errorf("update: %w", fields.err)
+	}
+
+	return s.next.Update(ctx, data)
+}
+
+func (s *CardService) Remove(ctx context.Context, id int64, version int64) error {
+	return s.next.Remove(ctx, id, version)
+}
+
+func (s *CardService) validateUpdate(data client.CardDataUpdate) error {
+	rules := []struct {
+		value *string
+		tag   string
+	}{
+		{data.Number, "credit_card"},
+		{data.ExpDate, "exp_date"},
+		{data.CVV, "len=3"},
+	}
+	for _, rule := range rules {
+		if rule.value == nil {
+			continue
+		}
+		if err := s.validate.Var(*rule.value, rule.tag); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decryptCard расшифровывает секретные поля записи data.
+func decryptCard(f *fieldCipher, data *client.CardData) {
+	f.bind(data.Number)
+	f.decrypt("number", &data.Number)
+	f.decrypt("exp_date", &data.ExpDate)
+	f.decrypt("cvv", &data.CVV)
+	f.decrypt("cardholder", &data.Cardholder)
+	f.decrypt("notes", &data.Notes)
+}
@@ -0,0 +1,172 @@
+package crypto
+
+import (
+	"bytes"
+	"context"
+	"github.com/go-playground/validator/v10"
+	"github.com/mkolibaba/gophkeeper/client"
+	"github.com/mkolibaba/gophkeeper/client/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func newTestValidator(t *testing.T) *validator.Validate {
+	t.Helper()
+	v, err := client.NewDataValidator()
+	require.NoError(t, err)
+	return v
+}
+
+func TestCardSave(t *testing.T) {
+	cipher := newUnlockedCipher(t)
+
+	t.Run("success", func(t *testing.T) {
+		var saved client.CardData
+		srv := NewCardService(&mock.CardServiceMock{
+			SaveFunc: func(ctx context.Context, data client.CardData) error {
+				saved = data
+				return nil
+			},
+		}, cipher, newTestValidator(t))
+
+		card := client.CardData{
+			Name:       "my card",
+			Number:     "4111111111111111",
+			ExpDate:    "12/30",
+			CVV:        "123",
+			Cardholder: "ALICE",
+		}
+		err := srv.Save(t.Context(), card)
+		require.NoError(t, err)
+
+		require.Equal(t, "my card", saved.Name)
+		require.NotEqual(t, card.Number, saved.Number)
+		require.NotEqual(t, card.CVV, saved.CVV)
+
+		require.Equal(t, card.Number, decryptField(t, cipher, client.DataKindCard, "number", saved.Number))
+
+		// Все поля записи привязаны к одному идентификатору.
+		item, err := cipher.ItemID(saved.Number)
+		require.NoError(t, err)
+		cvvItem, err := cipher.ItemID(saved.CVV)
+		require.NoError(t, err)
+		require.Equal(t, item, cvvItem)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		next := &mock.CardServiceMock{}
+		srv := NewCardService(next, cipher, newTestValidator(t))
+
+		err := srv.Save(t.Context(), client.CardData{
+			Name:       "my card",
+			Number:     "4111111111111111",
+			ExpDate:    "1230",
+			CVV:        "123",
+			Cardholder: "ALICE",
+		})
+		require.Error(t, err)
+		require.Empty(t, next.SaveCalls())
+	})
+}
+
+func TestCardGetAll(t *testing.T) {
+	cipher := newUnlockedCipher(t)
+	encrypt := func(item byte, name, plaintext string) string {
+		return encryptField(t, cipher, client.DataKindCard, item, name, plaintext)
+	}
+	card := client.CardData{
+		ID:         1,
+		Name:       "my card",
+		Number:     encrypt(1, "number", "4111111111111111"),
+		ExpDate:    encrypt(1, "exp_date", "12/30"),
+		CVV:        encrypt(1, "cvv", "123"),
+		Cardholder: encrypt(1, "cardholder", "ALICE"),
+		Notes:      encrypt(1, "notes", ""),
+	}
+	newService := func(cards ...client.CardData) *CardService {
+		return NewCardService(&mock.CardServiceMock{
+			GetAllFunc: func(ctx context.Context) ([]client.CardData, error) {
+				return cards, nil
+			},
+		}, cipher, newTestValidator(t))
+	}
+
+	t.Run("success", func(t *testing.T) {
+		cards, err := newService(card).GetAll(t.Context())
+		require.NoError(t, err)
+		require.Len(t, cards, 1)
+		require.Equal(t, "my card", cards[0].Name)
+		require.Equal(t, "4111111111111111", cards[0].Number)
+		require.Equal(t, "123", cards[0].CVV)
+	})
+	t.Run("swapped_fields", func(t *testing.T) {
+		other := card
+		other.CVV = card.ExpDate
+		_, err := newService(other).GetAll(t.Context())
+		require.ErrorIs(t, err, ErrMalformedData)
+
+		other = card
+		other.CVV = encrypt(2, "cvv", "456")
+		_, err = newService(other).GetAll(t.Context())
+		require.ErrorIs(t, err, ErrMalformedData)
+	})
+}
+
+func TestCardUpdate(t *testing.T) {
+	cipher := newUnlockedCipher(t)
+
+	t.Run("success", func(t *testing.T) {
+		var updated client.CardDataUpdate
+		srv := NewCardService(&mock.CardServiceMock{
+			GetAllFunc: func(ctx context.Context) ([]client.CardData, error) {
+				return []client.CardData{{
+					ID:     1,
+					Number: encryptField(t, cipher, client.DataKindCard, 1, "number", "4111111111111111"),
+				}}, nil
+			},
+			UpdateFunc: func(ctx context.Context, data client.CardDataUpdate) error {
+				updated = data
+				return nil
+			},
+		}, cipher, newTestValidator(t))
+
+		name := "new name"
+		cvv := "321"
+		err := srv.Update(t.Context(), client.CardDataUpdate{ID: 1, Name: &name, CVV: &cvv})
+		require.NoError(t, err)
+
+		require.Equal(t, "321", cvv, "caller data should not be modified")
+		require.Equal(t, "new name", *updated.Name)
+		require.Nil(t, updated.Number)
+
+		// Новое значение привязано к той же записи.
+		got, err := cipher.Decrypt(*updated.CVV, client.Field{
+			Kind: client.DataKindCard,
+			Item: bytes.Repeat([]byte{1}, itemIDSize),
+			Name: "cvv",
+		})
+		require.NoError(t, err)
+		require.Equal(t, "321", got)
+	})
+	t.Run("not_found", func(t *testing.T) {
+		next := &mock.CardServiceMock{
+			GetAllFunc: func(ctx context.Context) ([]client.CardData, error) {
+				return nil, nil
+			},
+		}
+		srv := NewCardService(next, cipher, newTestValidator(t))
+
+		cvv := "321"
+		err := srv.Update(t.Context(), client.CardDataUpdate{ID: 1, CVV: &cvv})
+		require.ErrorIs(t, err, client.ErrDataNotFound)
+		require.Empty(t, next.UpdateCalls())
+	})
+	t.Run("invalid", func(t *testing.T) {
+		next := &mock.CardServiceMock{}
+		srv := NewCardService(next, cipher, newTestValidator(t))
+
+		cvv := "12"
+		err := srv.Update(t.Context(), client.CardDataUpdate{ID: 1, CVV: &cvv})
+		require.Error(t, err)
+		require.Empty(t, next.UpdateCalls())
+	})
+}
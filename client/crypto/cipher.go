@@ -0,0 +1,471 @@
+package crypto
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"github.com/mkolibaba/gophkeeper/client"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/hkdf"
+	"io"
+	"sync"
+)
+
+const (
+	formatVersion byte = 1
+
+	keySize = chacha20poly1305.KeySize
+	// itemIDSize - размер идентификатора записи, к которой привязаны
+	// шифротексты ее полей.
+	itemIDSize = 16
+
+	argonTime    = 3
+	argonMemory  = 64 * 1024
+	argonThreads = 4
+
+	// saltPrefix отделяет соль Argon2id этого приложения от солей других
+	// приложений с тем же логином.
+	saltPrefix = "gophkeeper:"
+	// Параметры info HKDF для ключей, выводимых из мастер-пароля.
+	authKeyInfo       = "gophkeeper auth key"
+	encryptionKeyInfo = "gophkeeper vault key encryption key"
+	// contentKeyInfo - параметр info HKDF для ключа HMAC, которым из ключа
+	// хранилища вычисляются ключи дедупликации файлов.
+	contentKeyInfo = "gophkeeper content key"
+
+	// streamChunkSize - размер открытого текста в одном фрейме потока.
+	streamChunkSize = 64 * 1024
+	// streamPrefixSize - размер случайной части nonce потока. Оставшиеся
+	// 8 байт nonce занимает счетчик фреймов.
+	streamPrefixSize = chacha20poly1305.NonceSizeX - 8
+)
+
+var (
+	ErrInvalidPassword   = errors.New("invalid master password")
+	ErrMalformedData     = errors.New("malformed encrypted data")
+	ErrUnexpectedVersion = errors.New("unexpected encryption format version")
+	ErrTruncatedStream   = errors.New("encrypted stream is truncated")
+)
+
+// Cipher шифрует данные ключом хранилища с помощью XChaCha20-Poly1305.
+// Из мастер-пароля через Argon2id выводится мастер-ключ, а из него через
+// HKDF - ключ аутентификации для сервера и ключ, которым зашифрован ключ
+// хранилища.
+type Cipher struct {
+	mu         sync.RWMutex
+	key        []byte
+	aead       cipher.AEAD
+	contentKey []byte
+}
+
+func NewCipher() *Cipher {
+	return &Cipher{}
+}
+
+// DeriveKeys выводит ключи из мастер-пароля. Соль Argon2id получается из
+// логина: ключ аутентификации нужен до входа, когда сервер еще ничего
+// не передал клиенту.
+func (c *Cipher) DeriveKeys(login string, password string) (client.MasterKey, error) {
+	salt := sha256.Sum256([]byte(saltPrefix + login))
+	master := argon2.IDKey([]byte(password), salt[:], argonTime, argonMemory, argonThreads, keySize)
+
+	authKey, err := expandKey(master, authKeyInfo)
+	if err != nil {
+		return client.MasterKey{}, fmt.Errorf("derive keys: %w", err)
+	}
+	encryptionKey, err := expandKey(master, encryptionKeyInfo)
+	if err != nil {
+		return client.MasterKey{}, fmt.Errorf("derive keys: %w", err)
+	}
+
+	return client.MasterKey{
+		AuthKey:       base64.StdEncoding.EncodeToString(authKey),
+		EncryptionKey: encryptionKey,
+	}, nil
+}
+
+func (c *Cipher) NewVaultKey(key client.MasterKey) ([]byte, error) {
+	vaultKey := make([]byte, keySize)
+	if _, err := rand.Read(vaultKey); err != nil {
+		return nil, fmt.Errorf("new vault key: %w", err)
+	}
+
+	sealed, err := wrapKey(key.EncryptionKey, vaultKey)
+	if err != nil {
+		return nil, fmt.Errorf("new vault key: %w", err)
+	}
+
+	return sealed, nil
+}
+
+func (c *Cipher) WrapVaultKey(key client.MasterKey) ([]byte, error) {
+	c.mu.RLock()
+	vaultKey := c.key
+	c.mu.RUnlock()
+
+	if vaultKey == nil {
+		return nil, client.ErrVaultLocked
+	}
+
+	sealed, err := wrapKey(key.EncryptionKey, vaultKey)
+	if err != nil {
+		return nil, fmt.Errorf("wrap vault key: %w", err)
+	}
+
+	return sealed, nil
+}
+
+func (c *Cipher) Unlock(key client.MasterKey, vaultKey []byte) error {
+	if len(vaultKey) < 1 {
+		return ErrMalformedData
+	}
+	if vaultKey[0] != formatVersion {
+		return ErrUnexpectedVersion
+	}
+
+	kek, err := chacha20poly1305.NewX(key.EncryptionKey)
+	if err != nil {
+		return fmt.Errorf("unlock: %w", err)
+	}
+
+	opened, err := open(kek, vaultKey[1:], vaultKey[:1])
+	if errors.Is(err, ErrMalformedData) {
+		return err
+	}
+	if err != nil {
+		return ErrInvalidPassword
+	}
+
+	aead, err := chacha20poly1305.NewX(opened)
+	if err != nil {
+		return fmt.Errorf("unlock: %w", err)
+	}
+	contentKey, err := expandKey(opened, contentKeyInfo)
+	if err != nil {
+		return fmt.Errorf("unlock: %w", err)
+	}
+
+	c.mu.Lock()
+	c.key = opened
+	c.aead = aead
+	c.contentKey = contentKey
+	c.mu.Unlock()
+
+	return nil
+}
+
+// Encrypt возвращает version|item|nonce|ciphertext. В additional data
+// передаются заголовок, тип записи и название поля.
+func (c *Cipher) Encrypt(plaintext string, field client.Field) (string, error) {
+	aead, err := c.getAEAD()
+	if err != nil {
+		return "", err
+	}
+	if len(field.Item) != itemIDSize {
+		return "", fmt.Errorf("encrypt: item id must be %d bytes long", itemIDSize)
+	}
+
+	header := append([]byte{formatVersion}, field.Item...)
+	sealed, err := seal(aead, header, []byte(plaintext), fieldAdditionalData(header, field))
+	if err != nil {
+		return "", fmt.Errorf("encrypt: %w", err)
+	}
+
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+func (c *Cipher) Decrypt(ciphertext string, field client.Field) (string, error) {
+	aead, err := c.getAEAD()
+	if err != nil {
+		return "", err
+	}
+
+	data, err := decodeField(ciphertext)
+	if err != nil {
+		return "", err
+	}
+	header := data[:1+itemIDSize]
+	if !bytes.Equal(header[1:], field.Item) {
+		return "", ErrMalformedData
+	}
+
+	plaintext, err := open(aead, data[len(header):], fieldAdditionalData(header, field))
+	if err != nil {
+		return "", ErrMalformedData
+	}
+
+	return string(plaintext), nil
+}
+
+func (c *Cipher) ItemID(ciphertext string) ([]byte, error) {
+	data, err := decodeField(ciphertext)
+	if err != nil {
+		return nil, err
+	}
+	return bytes.Clone(data[1 : 1+itemIDSize]), nil
+}
+
+func (c *Cipher) EncryptStream(r io.Reader) (io.Reader, error) {
+	aead, err := c.getAEAD()
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := make([]byte, streamPrefixSize)
+	if _, err := rand.Read(prefix); err != nil {
+		return nil, fmt.Errorf("encrypt stream: %w", err)
+	}
+
+	return &encryptReader{
+		src:    r,
+		aead:   aead,
+		prefix: prefix,
+		buf:    append([]byte{formatVersion}, prefix...),
+		chunk:  make([]byte, streamChunkSize),
+	}, nil
+}
+
+func (c *Cipher) DecryptStream(w io.Writer) (io.WriteCloser, error) {
+	aead, err := c.getAEAD()
+	if err != nil {
+		return nil, err
+	}
+
+	return &decryptWriter{
+		dst:  w,
+		aead: aead,
+	}, nil
+}
+
+// ContentKey возвращает HMAC-SHA256 содержимого r на ключе, выведенном из
+// ключа хранилища. Сервер по нему узнает только, что у пользователя есть
+// одинаковые файлы.
+func (c *Cipher) ContentKey(r io.Reader) ([]byte, error) {
+	c.mu.RLock()
+	key := c.contentKey
+	c.mu.RUnlock()
+
+	if key == nil {
+		return nil, client.ErrVaultLocked
+	}
+
+	mac := hmac.New(sha256.New, key)
+	if _, err := io.Copy(mac, r); err != nil {
+		return nil, fmt.Errorf("content key: %w", err)
+	}
+	return mac.Sum(nil), nil
+}
+
+func (c *Cipher) getAEAD() (cipher.AEAD, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.aead == nil {
+		return nil, client.ErrVaultLocked
+	}
+	return c.aead, nil
+}
+
+// wrapKey шифрует ключ хранилища ключом encryptionKey и возвращает
+// version|nonce|ciphertext.
+func wrapKey(encryptionKey []byte, key []byte) ([]byte, error) {
+	kek, err := chacha20poly1305.NewX(encryptionKey)
+	if err != nil {
+		return nil, err
+	}
+
+	header := []byte{formatVersion}
+	return seal(kek, header, key, header)
+}
+
+// decodeField декодирует шифротекст поля и проверяет его заголовок.
+func decodeField(ciphertext string) ([]byte, error) {
+	data, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil || len(data) < 1+itemIDSize {
+		return nil, ErrMalformedData
+	}
+	if data[0] != formatVersion {
+		return nil, ErrUnexpectedVersion
+	}
+	return data, nil
+}
+
+// fieldAdditionalData возвращает additional data шифротекста поля field
+// с заголовком header.
+func fieldAdditionalData(header []byte, field client.Field) []byte {
+	ad := bytes.Clone(header)
+	ad = append(ad, field.Kind...)
+	ad = append(ad, 0)
+	return append(ad, field.Name...)
+}
+
+// expandKey выводит из мастер-ключа независимый ключ для назначения info.
+func expandKey(master []byte, info string) ([]byte, error) {
+	key := make([]byte, keySize)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, master, nil, []byte(info)), key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// seal шифрует plaintext со случайным nonce и возвращает prefix|nonce|ciphertext.
+func seal(aead cipher.AEAD, prefix, plaintext, additionalData []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, 0, len(prefix)+len(nonce)+len(plaintext)+aead.Overhead())
+	out = append(out, prefix...)
+	out = append(out, nonce...)
+	return aead.Seal(out, nonce, plaintext, additionalData), nil
+}
+
+// open расшифровывает данные в формате nonce|ciphertext.
+func open(aead cipher.AEAD, data, additionalData []byte) ([]byte, error) {
+	if len(data) < aead.NonceSize() {
+		return nil, ErrMalformedData
+	}
+	nonce, ciphertext := data[:aead.NonceSize()], data[aead.NonceSize():]
+	return aead.Open(nil, nonce, ciphertext, additionalData)
+}
+
+// Поток шифруется фреймами: version|prefix, затем последовательность
+// length|ciphertext. Nonce фрейма - prefix|counter, в additional data
+// передается признак последнего фрейма, что защищает поток от усечения
+// и перестановки фреймов.
+
+func streamNonce(prefix []byte, counter uint64) []byte {
+	nonce := make([]byte, chacha20poly1305.NonceSizeX)
+	copy(nonce, prefix)
+	binary.BigEndian.PutUint64(nonce[streamPrefixSize:], counter)
+	return nonce
+}
+
+func frameAdditionalData(final bool) []byte {
+	if final {
+		return []byte{1}
+	}
+	return []byte{0}
+}
+
+type encryptReader struct {
+	src     io.Reader
+	aead    cipher.AEAD
+	prefix  []byte
+	counter uint64
+	buf     []byte
+	chunk   []byte
+	done    bool
+}
+
+func (r *encryptReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		if r.done {
+			return 0, io.EOF
+		}
+		if err := r.nextFrame(); err != nil {
+			return 0, err
+		}
+	}
+
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+func (r *encryptReader) nextFrame() error {
+	n, err := io.ReadFull(r.src, r.chunk)
+	final := false
+	switch {
+	case errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		final = true
+	case err != nil:
+		return err
+	}
+
+	sealed := r.aead.Seal(nil, streamNonce(r.prefix, r.counter), r.chunk[:n], frameAdditionalData(final))
+	r.counter++
+
+	frame := make([]byte, 4, 4+len(sealed))
+	binary.BigEndian.PutUint32(frame, uint32(len(sealed)))
+	r.buf = append(frame, sealed...)
+	r.done = final
+	return nil
+}
+
+type decryptWriter struct {
+	dst     io.Writer
+	aead    cipher.AEAD
+	prefix  []byte
+	counter uint64
+	buf     bytes.Buffer
+	done    bool
+}
+
+func (w *decryptWriter) Write(p []byte) (int, error) {
+	w.buf.Write(p)
+
+	if w.prefix == nil {
+		if w.buf.Len() < 1+streamPrefixSize {
+			return len(p), nil
+		}
+		header := w.buf.Next(1 + streamPrefixSize)
+		if header[0] != formatVersion {
+			return 0, ErrUnexpectedVersion
+		}
+		w.prefix = bytes.Clone(header[1:])
+	}
+
+	for w.buf.Len() >= 4 {
+		size := int(binary.BigEndian.Uint32(w.buf.Bytes()[:4]))
+		if size > streamChunkSize+w.aead.Overhead() {
+			return 0, ErrMalformedData
+		}
+		if w.buf.Len() < 4+size {
+			break
+		}
+		if w.done {
+			return 0, ErrMalformedData
+		}
+
+		w.buf.Next(4)
+		sealed := w.buf.Next(size)
+		if err := w.openFrame(sealed); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(p), nil
+}
+
+func (w *decryptWriter) openFrame(sealed []byte) error {
+	nonce := streamNonce(w.prefix, w.counter)
+
+	plaintext, err := w.aead.Open(nil, nonce, sealed, frameAdditionalData(false))
+	if err != nil {
+		plaintext, err = w.aead.Open(nil, nonce, sealed, frameAdditionalData(true))
+		if err != nil {
+			return ErrMalformedData
+		}
+		w.done = true
+	}
+	w.counter++
+
+	_, err = w.dst.Write(plaintext)
+	return err
+}
+
+func (w *decryptWriter) Close() error {
+	if !w.done || w.buf.Len() > 0 {
+		return ErrTruncatedStream
+	}
+	return nil
+}
@@ -0,0 +1,284 @@
+package crypto
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"github.com/mkolibaba/gophkeeper/client"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/chacha20poly1305"
+	"io"
+	"testing"
+)
+
+// testField - поле, к которому тесты привязывают шифротексты.
+var testField = client.Field{
+	Kind: client.DataKindLogin,
+	Item: bytes.Repeat([]byte{1}, itemIDSize),
+	Name: "password",
+}
+
+func mustDeriveKeys(t *testing.T, login, password string) client.MasterKey {
+	t.Helper()
+
+	key, err := NewCipher().DeriveKeys(login, password)
+	require.NoError(t, err)
+	return key
+}
+
+func newUnlockedCipher(t *testing.T) *Cipher {
+	t.Helper()
+
+	c := NewCipher()
+	key := mustDeriveKeys(t, "alice", "master")
+	vaultKey, err := c.NewVaultKey(key)
+	require.NoError(t, err)
+	require.NoError(t, c.Unlock(key, vaultKey))
+	return c
+}
+
+// encryptField шифрует plaintext как поле name записи item типа kind.
+func encryptField(t *testing.T, c *Cipher, kind client.DataKind, item byte, name, plaintext string) string {
+	t.Helper()
+
+	field := client.Field{Kind: kind, Item: bytes.Repeat([]byte{item}, itemIDSize), Name: name}
+	ciphertext, err := c.Encrypt(plaintext, field)
+	require.NoError(t, err)
+	return ciphertext
+}
+
+// decryptField расшифровывает поле name записи типа kind, к которой привязан
+// ciphertext.
+func decryptField(t *testing.T, c *Cipher, kind client.DataKind, name, ciphertext string) string {
+	t.Helper()
+
+	item, err := c.ItemID(ciphertext)
+	require.NoError(t, err)
+	plaintext, err := c.Decrypt(ciphertext, client.Field{Kind: kind, Item: item, Name: name})
+	require.NoError(t, err)
+	return plaintext
+}
+
+func TestCipherDeriveKeys(t *testing.T) {
+	key := mustDeriveKeys(t, "alice", "master")
+	require.Len(t, key.EncryptionKey, chacha20poly1305.KeySize)
+	require.NotEqual(t, "master", key.AuthKey)
+
+	// Ключ аутентификации не совпадает с ключом шифрования.
+	authKey, err := base64.StdEncoding.DecodeString(key.AuthKey)
+	require.NoError(t, err)
+	require.NotEqual(t, key.EncryptionKey, authKey)
+
+	// Ключи воспроизводятся и зависят от логина и пароля.
+	require.Equal(t, key, mustDeriveKeys(t, "alice", "master"))
+	require.NotEqual(t, key, mustDeriveKeys(t, "bob", "master"))
+	require.NotEqual(t, key, mustDeriveKeys(t, "alice", "other"))
+}
+
+func TestCipherUnlock(t *testing.T) {
+	c := NewCipher()
+	key := mustDeriveKeys(t, "alice", "master")
+	vaultKey, err := c.NewVaultKey(key)
+	require.NoError(t, err)
+
+	t.Run("locked", func(t *testing.T) {
+		_, err := NewCipher().Encrypt("secret", testField)
+		require.ErrorIs(t, err, client.ErrVaultLocked)
+	})
+	t.Run("wrong_password", func(t *testing.T) {
+		err := c.Unlock(mustDeriveKeys(t, "alice", "wrong"), vaultKey)
+		require.ErrorIs(t, err, ErrInvalidPassword)
+	})
+	t.Run("malformed", func(t *testing.T) {
+		err := c.Unlock(key, vaultKey[:5])
+		require.ErrorIs(t, err, ErrMalformedData)
+	})
+	t.Run("success", func(t *testing.T) {
+		require.NoError(t, c.Unlock(key, vaultKey))
+
+		// Другой экземпляр с тем же ключом хранилища расшифровывает данные.
+		other := NewCipher()
+		require.NoError(t, other.Unlock(key, vaultKey))
+
+		ciphertext, err := c.Encrypt("secret", testField)
+		require.NoError(t, err)
+		plaintext, err := other.Decrypt(ciphertext, testField)
+		require.NoError(t, err)
+		require.Equal(t, "secret", plaintext)
+	})
+}
+
+func TestCipherWrapVaultKey(t *testing.T) {
+	newKey := mustDeriveKeys(t, "alice", "new master")
+
+	_, err := NewCipher().WrapVaultKey(newKey)
+	require.ErrorIs(t, err, client.ErrVaultLocked)
+
+	c := newUnlockedCipher(t)
+	ciphertext, err := c.Encrypt("secret", testField)
+	require.NoError(t, err)
+
+	vaultKey, err := c.WrapVaultKey(newKey)
+	require.NoError(t, err)
+
+	// Данные, зашифрованные до смены пароля, открываются новым паролем.
+	other := NewCipher()
+	require.ErrorIs(t, other.Unlock(mustDeriveKeys(t, "alice", "master"), vaultKey), ErrInvalidPassword)
+	require.NoError(t, other.Unlock(newKey, vaultKey))
+
+	plaintext, err := other.Decrypt(ciphertext, testField)
+	require.NoError(t, err)
+	require.Equal(t, "secret", plaintext)
+}
+
+func TestCipherEncrypt(t *testing.T) {
+	c := newUnlockedCipher(t)
+
+	t.Run("roundtrip", func(t *testing.T) {
+		for _, plaintext := range []string{"", "secret", "пароль"} {
+			ciphertext, err := c.Encrypt(plaintext, testField)
+			require.NoError(t, err)
+
+			got, err := c.Decrypt(ciphertext, testField)
+			require.NoError(t, err)
+			require.Equal(t, plaintext, got)
+		}
+	})
+	t.Run("random_nonce", func(t *testing.T) {
+		a, err := c.Encrypt("secret", testField)
+		require.NoError(t, err)
+		b, err := c.Encrypt("secret", testField)
+		require.NoError(t, err)
+		require.NotEqual(t, a, b)
+	})
+	t.Run("tampered", func(t *testing.T) {
+		ciphertext, err := c.Encrypt("secret", testField)
+		require.NoError(t, err)
+
+		tampered := []byte(ciphertext)
+		tampered[len(tampered)/2] ^= 1
+		_, err = c.Decrypt(string(tampered), testField)
+		require.ErrorIs(t, err, ErrMalformedData)
+	})
+	t.Run("other_field", func(t *testing.T) {
+		ciphertext, err := c.Encrypt("secret", testField)
+		require.NoError(t, err)
+
+		item, err := c.ItemID(ciphertext)
+		require.NoError(t, err)
+		require.Equal(t, testField.Item, item)
+
+		otherKind, otherItem, otherName := testField, testField, testField
+		otherKind.Kind = client.DataKindCard
+		otherItem.Item = bytes.Repeat([]byte{2}, itemIDSize)
+		otherName.Name = "login"
+		for _, field := range []client.Field{otherKind, otherItem, otherName} {
+			_, err = c.Decrypt(ciphertext, field)
+			require.ErrorIs(t, err, ErrMalformedData)
+		}
+	})
+	t.Run("other_key", func(t *testing.T) {
+		ciphertext, err := c.Encrypt("secret", testField)
+		require.NoError(t, err)
+
+		_, err = newUnlockedCipher(t).Decrypt(ciphertext, testField)
+		require.ErrorIs(t, err, ErrMalformedData)
+	})
+}
+
+func TestCipherStream(t *testing.T) {
+	c := newUnlockedCipher(t)
+
+	encrypt := func(t *testing.T, plaintext []byte) []byte {
+		t.Helper()
+		r, err := c.EncryptStream(bytes.NewReader(plaintext))
+		require.NoError(t, err)
+		ciphertext, err := io.ReadAll(r)
+		require.NoError(t, err)
+		return ciphertext
+	}
+
+	for name, size := range map[string]int{
+		"empty":    0,
+		"small":    10,
+		"exact":    streamChunkSize,
+		"multiple": 3*streamChunkSize + 17,
+	} {
+		t.Run(name, func(t *testing.T) {
+			plaintext := make([]byte, size)
+			_, _ = rand.Read(plaintext)
+
+			ciphertext := encrypt(t, plaintext)
+			if size > 0 {
+				require.False(t, bytes.Contains(ciphertext, plaintext))
+			}
+
+			// Пишем шифротекст мелкими порциями, как при получении по сети.
+			var out bytes.Buffer
+			w, err := c.DecryptStream(&out)
+			require.NoError(t, err)
+			for len(ciphertext) > 0 {
+				n := min(len(ciphertext), 1000)
+				_, err := w.Write(ciphertext[:n])
+				require.NoError(t, err)
+				ciphertext = ciphertext[n:]
+			}
+			require.NoError(t, w.Close())
+			require.Equal(t, string(plaintext), out.String())
+		})
+	}
+
+	t.Run("truncated", func(t *testing.T) {
+		ciphertext := encrypt(t, make([]byte, 2*streamChunkSize+1))
+
+		w, err := c.DecryptStream(io.Discard)
+		require.NoError(t, err)
+		_, err = w.Write(ciphertext[:len(ciphertext)-30])
+		require.NoError(t, err)
+		require.ErrorIs(t, w.Close(), ErrTruncatedStream)
+	})
+	t.Run("truncated_at_frame_boundary", func(t *testing.T) {
+		plaintext := make([]byte, 2*streamChunkSize+1)
+		ciphertext := encrypt(t, plaintext)
+		frameSize := 4 + streamChunkSize + chacha20poly1305.Overhead
+		headerSize := 1 + streamPrefixSize
+
+		w, err := c.DecryptStream(io.Discard)
+		require.NoError(t, err)
+		_, err = w.Write(ciphertext[:headerSize+frameSize])
+		require.NoError(t, err)
+		require.ErrorIs(t, w.Close(), ErrTruncatedStream)
+	})
+	t.Run("tampered", func(t *testing.T) {
+		ciphertext := encrypt(t, []byte("secret file contents"))
+		ciphertext[len(ciphertext)-1] ^= 1
+
+		w, err := c.DecryptStream(io.Discard)
+		require.NoError(t, err)
+		_, err = w.Write(ciphertext)
+		require.ErrorIs(t, err, ErrMalformedData)
+	})
+}
+
+func TestCipherContentKey(t *testing.T) {
+	_, err := NewCipher().ContentKey(bytes.NewReader([]byte("content")))
+	require.ErrorIs(t, err, client.ErrVaultLocked)
+
+	c := newUnlockedCipher(t)
+	key, err := c.ContentKey(bytes.NewReader([]byte("content")))
+	require.NoError(t, err)
+	require.Len(t, key, 32)
+
+	// Ключ зависит только от содержимого и ключа хранилища.
+	same, err := c.ContentKey(bytes.NewReader([]byte("content")))
+	require.NoError(t, err)
+	require.Equal(t, key, same)
+
+	other, err := c.ContentKey(bytes.NewReader([]byte("other content")))
+	require.NoError(t, err)
+	require.NotEqual(t, key, other)
+
+	otherVault, err := newUnlockedCipher(t).ContentKey(bytes.NewReader([]byte("content")))
+	require.NoError(t, err)
+	require.NotEqual(t, key, otherVault)
+}
@@ -0,0 +1,124 @@
+package crypto
+
+import (
+	"context"
+	"crypto/rand"
+	"github.com/mkolibaba/gophkeeper/client"
+)
+
+// fieldCipher шифрует и расшифровывает поля структур на месте, запоминая
+// первую возникшую ошибку. Шифротексты привязываются к типу записи,
+// ее идентификатору и названию поля, поэтому сервер не может незаметно
+// переставить их между полями или записями.
+type fieldCipher struct {
+	cipher client.Cipher
+	kind   client.DataKind
+	item   []byte
+	err    error
+}
+
+func newFieldCipher(cipher client.Cipher, kind client.DataKind) *fieldCipher {
+	return &fieldCipher{cipher: cipher, kind: kind}
+}
+
+// newItem привязывает шифруемые поля к новой записи.
+func (f *fieldCipher) newItem() {
+	if f.err != nil {
+		return
+	}
+	f.item = make([]byte, itemIDSize)
+	_, f.err = rand.Read(f.item)
+}
+
+// bind привязывает поля к записи, которой принадлежит шифротекст ciphertext.
+func (f *fieldCipher) bind(ciphertext string) {
+	if f.err != nil {
+		return
+	}
+	f.item, f.err = f.cipher.ItemID(ciphertext)
+}
+
+func (f *fieldCipher) encrypt(name string, field *string) {
+	if f.err != nil {
+		return
+	}
+	*field, f.err = f.cipher.Encrypt(*field, f.field(name))
+}
+
+func (f *fieldCipher) decrypt(name string, field *string) {
+	if f.err != nil {
+		return
+	}
+	*field, f.err = f.cipher.Decrypt(*field, f.field(name))
+}
+
+// encryptOptional шифрует поле, если оно задано. Значение копируется, чтобы
+// не изменять данные вызывающей стороны.
+func (f *fieldCipher) encryptOptional(name string, field **string) {
+	if *field == nil {
+		return
+	}
+	value := **field
+	f.encrypt(name, &value)
+	*field = &value
+}
+
+func (f *fieldCipher) field(name string) client.Field {
+	return client.Field{Kind: f.kind, Item: f.item, Name: name}
+}
+
+// decryptData возвращает копию данных записи с расшифрованными секретными
+// полями.
+func (f *fieldCipher) decryptData(data client.Data) client.Data {
+	f.kind, _ = client.DataKindOf(data)
+	switch data := data.(type) {
+	case client.LoginData:
+		decryptLogin(f, &data)
+		return data
+	case client.NoteData:
+		decryptNote(f, &data)
+		return data
+	case client.BinaryData:
+		decryptBinary(f, &data)
+		return data
+	case client.CardData:
+		decryptCard(f, &data)
+		return data
+	case client.OTPData:
+		decryptOTP(f, &data)
+		return data
+	}
+	return data
+}
+
+// bindStored привязывает поля к сохраненной записи id, которую возвращает
+// getAll. Идентификатор записи берется из ее поля field.
+func bindStored[T client.Data](
+	ctx context.Context,
+	f *fieldCipher,
+	getAll func(ctx context.Context) ([]T, error),
+	id int64,
+	field func(T) string,
+) error {
+	items, err := getAll(ctx)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		if item.GetID() == id {
+			f.bind(field(item))
+			return f.err
+		}
+	}
+	return client.ErrDataNotFound
+}
+
+// anySet сообщает, задано ли хотя бы одно из полей.
+func anySet(fields ...*string) bool {
+	for _, field := range fields {
+		if field != nil {
+			return true
+		}
+	}
+	return false
+}
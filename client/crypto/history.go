@@ -0,0 +1,42 @@
+package crypto
+
+import (
+	"context"
+	"fmt"
+	"github.com/mkolibaba/gophkeeper/client"
+)
+
+// HistoryService расшифровывает секретные поля прежних версий записей,
+// полученных от next.
+type HistoryService struct {
+	next   client.HistoryService
+	cipher client.Cipher
+}
+
+func NewHistoryService(next client.HistoryService, cipher client.Cipher) *HistoryService {
+	return &HistoryService{
+		next:   next,
+		cipher: cipher,
+	}
+}
+
+func (s *HistoryService) List(ctx context.Context, kind client.DataKind, id int64) ([]client.HistoryEntry, error) {
+	entries, err := s.next.List(ctx, kind, id)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := newFieldCipher(s.cipher, "")
+	for i := range entries {
+		entries[i].Data = fields.decryptData(entries[i].Data)
+	}
+	if fields.err != nil {
+		return nil, fmt.Errorf("list: %w", fields.err)
+	}
+
+	return entries, nil
+}
+
+func (s *HistoryService) Restore(ctx context.Context, kind client.DataKind, id int64, version int64) error {
+	return s.next.Restore(ctx, kind, id, version)
+}
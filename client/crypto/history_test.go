@@ -0,0 +1,40 @@
+package crypto
+
+import (
+	"context"
+	"github.com/mkolibaba/gophkeeper/client"
+	"github.com/mkolibaba/gophkeeper/client/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestHistoryList(t *testing.T) {
+	cipher := newUnlockedCipher(t)
+
+	encrypt := func(kind client.DataKind, name, plaintext string) string {
+		return encryptField(t, cipher, kind, 1, name, plaintext)
+	}
+	login := client.LoginData{
+		Name:     "login",
+		Login:    encrypt(client.DataKindLogin, "login", "alice"),
+		Password: encrypt(client.DataKindLogin, "password", "secret"),
+		Website:  encrypt(client.DataKindLogin, "website", ""),
+		Notes:    encrypt(client.DataKindLogin, "notes", ""),
+	}
+
+	srv := NewHistoryService(&mock.HistoryServiceMock{
+		ListFunc: func(ctx context.Context, kind client.DataKind, id int64) ([]client.HistoryEntry, error) {
+			return []client.HistoryEntry{
+				{Version: 2, Data: login},
+				{Version: 1, Data: client.NoteData{Name: "note", Text: encrypt(client.DataKindNote, "text", "text")}},
+			}, nil
+		},
+	}, cipher)
+
+	entries, err := srv.List(t.Context(), client.DataKindLogin, 1)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	require.Equal(t, "secret", entries[0].Data.(client.LoginData).Password)
+	require.Equal(t, "login", entries[0].Data.GetName())
+	require.Equal(t, "text", entries[1].Data.(client.NoteData).Text)
+}
@@ -0,0 +1,93 @@
+package crypto
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/mkolibaba/gophkeeper/client"
+)
+
+// LoginService шифрует секретные поля логинов перед передачей в next
+// и расшифровывает их при получении.
+type LoginService struct {
+	next     client.LoginService
+	cipher   client.Cipher
+	validate *validator.Validate
+}
+
+func NewLoginService(next client.LoginService, cipher client.Cipher, validate *validator.Validate) *LoginService {
+	return &LoginService{
+		next:     next,
+		cipher:   cipher,
+		validate: validate,
+	}
+}
+
+func (s *LoginService) Save(ctx context.Context, data client.LoginData) error {
+	if err := s.validate.Struct(data); err != nil {
+		return err
+	}
+
+	fields := newFieldCipher(s.cipher, client.DataKindLogin)
+	fields.newItem()
+	fields.encrypt("login", &data.Login)
+	fields.encrypt("password", &data.Password)
+	fields.encrypt("website", &data.Website)
+	fields.encrypt("notes", &data.Notes)
+	if fields.err != nil {
+		return fmt.Errorf("save: %w", fields.err)
+	}
+
+	return s.next.Save(ctx, data)
+}
+
+func (s *LoginService) GetAll(ctx context.Context) ([]client.LoginData, error) {
+	logins, err := s.next.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := newFieldCipher(s.cipher, client.DataKindLogin)
+	for i := range logins {
+		decryptLogin(fields, &logins[i])
+	}
+	if fields.err != nil {
+		return nil, fmt.Errorf("get all: %w", fields.err)
+	}
+
+	return logins, nil
+}
+
+func (s *LoginService) Update(ctx context.Context, data client.LoginDataUpdate) error {
+	fields := newFieldCipher(s.cipher, client.DataKindLogin)
+	if anySet(data.Login, data.Password, data.Website, data.Notes) {
+		err := bindStored(ctx, fields, s.next.GetAll, data.ID, func(data client.LoginData) string {
+			return data.Login
+		})
+		if err != nil {
+			return fmt.Errorf("update: %w", err)
+		}
+	}
+	fields.encryptOptional("login", &data.Login)
+	fields.encryptOptional("password", &data.Password)
+	fields.encryptOptional("website", &data.Website)
+	fields.encryptOptional("notes", &data.Notes)
+	if fields.err != nil {
+		return fmt.Errorf("update: %w", fields.err)
+	}
+
+	return s.next.Update(ctx, data)
+}
+
+func (s *LoginService) Remove(ctx context.Context, id int64, version int64) error {
+	return s.next.Remove(ctx, id, version)
+}
+
+// decryptLogin расшифровывает секретные поля записи data.
+func decryptLogin(f *fieldCipher, data *client.LoginData) {
+	f.bind(data.Login)
+	f.decrypt("login", &data.Login)
+	f.decrypt("password", &data.Password)
+	f.decrypt("website", &data.Website)
+	f.decrypt("notes", &data.Notes)
+}
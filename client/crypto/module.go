@@ -0,0 +1,38 @@
+package crypto
+
+import (
+	"github.com/go-playground/validator/v10"
+	"github.com/mkolibaba/gophkeeper/client"
+	"go.uber.org/fx"
+)
+
+// Module подключает шифрование данных. Декораторы объявлены на корневом
+// уровне, чтобы сервисы с шифрованием получали все модули приложения.
+var Module = fx.Options(
+	fx.Provide(
+		fx.Annotate(NewCipher, fx.As(new(client.Cipher))),
+	),
+	fx.Decorate(
+		func(next client.LoginService, cipher client.Cipher, validate *validator.Validate) client.LoginService {
+			return NewLoginService(next, cipher, validate)
+		},
+		func(next client.NoteService, cipher client.Cipher, validate *validator.Validate) client.NoteService {
+			return NewNoteService(next, cipher, validate)
+		},
+		func(next client.BinaryService, cipher client.Cipher, validate *validator.Validate) client.BinaryService {
+			return NewBinaryService(next, cipher, validate)
+		},
+		func(next client.CardService, cipher client.Cipher, validate *validator.Validate) client.CardService {
+			return NewCardService(next, cipher, validate)
+		},
+		func(next client.OTPService, cipher client.Cipher, validate *validator.Validate) client.OTPService {
+			return NewOTPService(next, cipher, validate)
+		},
+		func(next client.HistoryService, cipher client.Cipher) client.HistoryService {
+			return NewHistoryService(next, cipher)
+		},
+		func(next client.TrashService, cipher client.Cipher) client.TrashService {
+			return NewTrashService(next, cipher)
+		},
+	),
+)
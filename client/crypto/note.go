@@ -0,0 +1,84 @@
+package crypto
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/mkolibaba/gophkeeper/client"
+)
+
+// NoteService шифрует текст заметок перед передачей в next
+// и расшифровывает его при получении.
+type NoteService struct {
+	next     client.NoteService
+	cipher   client.Cipher
+	validate *validator.Validate
+}
+
+func NewNoteService(next client.NoteService, cipher client.Cipher, validate *validator.Validate) *NoteService {
+	return &NoteService{
+		next:     next,
+		cipher:   cipher,
+		validate: validate,
+	}
+}
+
+func (s *NoteService) Save(ctx context.Context, data client.NoteData) error {
+	if err := s.validate.Struct(data); err != nil {
+		return err
+	}
+
+	fields := newFieldCipher(s.cipher, client.DataKindNote)
+	fields.newItem()
+	fields.encrypt("text", &data.Text)
+	if fields.err != nil {
+		return fmt.Errorf("save: %w", fields.err)
+	}
+
+	return s.next.Save(ctx, data)
+}
+
+func (s *NoteService) GetAll(ctx context.Context) ([]client.NoteData, error) {
+	notes, err := s.next.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := newFieldCipher(s.cipher, client.DataKindNote)
+	for i := range notes {
+		decryptNote(fields, &notes[i])
+	}
+	if fields.err != nil {
+		return nil, fmt.Errorf("get all: %w", fields.err)
+	}
+
+	return notes, nil
+}
+
+func (s *NoteService) Update(ctx context.Context, data client.NoteDataUpdate) error {
+	fields := newFieldCipher(s.cipher, client.DataKindNote)
+	if anySet(data.Text) {
+		err := bindStored(ctx, fields, s.next.GetAll, data.ID, func(data client.NoteData) string {
+			return data.Text
+		})
+		if err != nil {
+			return fmt.Errorf("update: %w", err)
+		}
+	}
+	fields.encryptOptional("text", &data.Text)
+	if fields.err != nil {
+		return fmt.Errorf("update: %w", fields.err)
+	}
+
+	return s.next.Update(ctx, data)
+}
+
+func (s *NoteService) Remove(ctx context.Context, id int64, version int64) error {
+	return s.next.Remove(ctx, id, version)
+}
+
+// decryptNote расшифровывает секретные поля записи data.
+func decryptNote(f *fieldCipher, data *client.NoteData) {
+	f.bind(data.Text)
+	f.decrypt("text", &data.Text)
+}
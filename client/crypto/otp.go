@@ -0,0 +1,112 @@
+package crypto
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/mkolibaba/gophkeeper/client"
+)
+
+// OTPService шифрует секрет и издателя одноразовых паролей перед передачей
+// в next и расшифровывает их при получении. Параметры генерации паролей
+// не секретны и передаются как есть.
+type OTPService struct {
+	next     client.OTPService
+	cipher   client.Cipher
+	validate *validator.Validate
+}
+
+func NewOTPService(next client.OTPService, cipher client.Cipher, validate *validator.Validate) *OTPService {
+	return &OTPService{
+		next:     next,
+		cipher:   cipher,
+		validate: validate,
+	}
+}
+
+func (s *OTPService) Save(ctx context.Context, data client.OTPData) error {
+	if err := s.validate.Struct(data); err != nil {
+		return err
+	}
+
+	fields := newFieldCipher(s.cipher, client.DataKindOTP)
+	fields.newItem()
+	fields.encrypt("secret", &data.Secret)
+	fields.encrypt("issuer", &data.Issuer)
+	if fields.err != nil {
+		return fmt.Errorf("save: %w", fields.err)
+	}
+
+	return s.next.Save(ctx, data)
+}
+
+func (s *OTPService) GetAll(ctx context.Context) ([]client.OTPData, error) {
+	otps, err := s.next.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := newFieldCipher(s.cipher, client.DataKindOTP)
+	for i := range otps {
+		decryptOTP(fields, &otps[i])
+	}
+	if fields.err != nil {
+		return nil, fmt.Errorf("get all: %w", fields.err)
+	}
+
+	return otps, nil
+}
+
+func (s *OTPService) Update(ctx context.Context, data client.OTPDataUpdate) error {
+	if err := s.validateUpdate(data); err != nil {
+		return err
+	}
+
+	fields := newFieldCipher(s.cipher, client.DataKindOTP)
+	if anySet(data.Secret, data.Issuer) {
+		err := bindStored(ctx, fields, s.next.GetAll, data.ID, func(data client.OTPData) string {
+			return data.Secret
+		})
+		if err != nil {
+			return fmt.Errorf("update: %w", err)
+		}
+	}
+	fields.encryptOptional("secret", &data.Secret)
+	fields.encryptOptional("issuer", &data.Issuer)
+	if fields.err != nil {
+		return fmt.Errorf("update: %w", fields.err)
+	}
+
+	return s.next.Update(ctx, data)
+}
+
+func (s *OTPService) Remove(ctx context.Context, id int64, version int64) error {
+	return s.next.Remove(ctx, id, version)
+}
+
+func (s *OTPService) validateUpdate(data client.OTPDataUpdate) error {
+	rules := []struct {
+		value any
+		tag   string
+	}{
+		{data.Type, "omitnil,oneof=totp hotp"},
+		{data.Secret, "omitnil,otp_secret"},
+		{data.Algorithm, "omitnil,oneof=SHA1 SHA256 SHA512"},
+		{data.Digits, "omitnil,min=6,max=8"},
+		{data.Period, "omitnil,min=0"},
+		{data.Counter, "omitnil,min=0"},
+	}
+	for _, rule := range rules {
+		if err := s.validate.Var(rule.value, rule.tag); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decryptOTP расшифровывает секретные поля записи data.
+func decryptOTP(f *fieldCipher, data *client.OTPData) {
+	f.bind(data.Secret)
+	f.decrypt("secret", &data.Secret)
+	f.decrypt("issuer", &data.Issuer)
+}
@@ -0,0 +1,79 @@
+package crypto
+
+import (
+	"context"
+	"github.com/mkolibaba/gophkeeper/client"
+	"github.com/mkolibaba/gophkeeper/client/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestOTPSave(t *testing.T) {
+	cipher := newUnlockedCipher(t)
+
+	t.Run("success", func(t *testing.T) {
+		var saved client.OTPData
+		srv := NewOTPService(&mock.OTPServiceMock{
+			SaveFunc: func(ctx context.Context, data client.OTPData) error {
+				saved = data
+				return nil
+			},
+		}, cipher, newTestValidator(t))
+
+		otp := client.OTPData{
+			Name:      "github",
+			Type:      client.OTPTypeTOTP,
+			Secret:    "JBSWY3DPEHPK3PXP",
+			Algorithm: "SHA1",
+			Digits:    6,
+			Period:    30,
+			Issuer:    "GitHub",
+		}
+		err := srv.Save(t.Context(), otp)
+		require.NoError(t, err)
+
+		require.Equal(t, "github", saved.Name)
+		require.Equal(t, int64(30), saved.Period)
+		require.NotEqual(t, otp.Secret, saved.Secret)
+
+		require.Equal(t, otp.Secret, decryptField(t, cipher, client.DataKindOTP, "secret", saved.Secret))
+	})
+	t.Run("invalid", func(t *testing.T) {
+		next := &mock.OTPServiceMock{}
+		srv := NewOTPService(next, cipher, newTestValidator(t))
+
+		err := srv.Save(t.Context(), client.OTPData{
+			Name:      "github",
+			Type:      client.OTPTypeTOTP,
+			Secret:    "JBSWY3DPEHPK3PXP",
+			Algorithm: "SHA1",
+			Digits:    6,
+		})
+		require.Error(t, err)
+		require.Empty(t, next.SaveCalls())
+	})
+}
+
+func TestOTPUpdate(t *testing.T) {
+	cipher := newUnlockedCipher(t)
+
+	t.Run("success", func(t *testing.T) {
+		next := &mock.OTPServiceMock{}
+		srv := NewOTPService(next, cipher, newTestValidator(t))
+
+		var counter int64 = 2
+		err := srv.Update(t.Context(), client.OTPDataUpdate{ID: 1, Version: 1, Counter: &counter})
+		require.NoError(t, err)
+		require.Len(t, next.UpdateCalls(), 1)
+		require.Nil(t, next.UpdateCalls()[0].Data.Secret)
+	})
+	t.Run("invalid_secret", func(t *testing.T) {
+		next := &mock.OTPServiceMock{}
+		srv := NewOTPService(next, cipher, newTestValidator(t))
+
+		secret := "not base32!"
+		err := srv.Update(t.Context(), client.OTPDataUpdate{ID: 1, Version: 1, Secret: &secret})
+		require.Error(t, err)
+		require.Empty(t, next.UpdateCalls())
+	})
+}
@@ -0,0 +1,46 @@
+package crypto
+
+import (
+	"context"
+	"fmt"
+	"github.com/mkolibaba/gophkeeper/client"
+)
+
+// TrashService расшифровывает секретные поля записей корзины, полученных
+// от next.
+type TrashService struct {
+	next   client.TrashService
+	cipher client.Cipher
+}
+
+func NewTrashService(next client.TrashService, cipher client.Cipher) *TrashService {
+	return &TrashService{
+		next:   next,
+		cipher: cipher,
+	}
+}
+
+func (s *TrashService) List(ctx context.Context) ([]client.TrashEntry, error) {
+	entries, err := s.next.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := newFieldCipher(s.cipher, "")
+	for i := range entries {
+		entries[i].Data = fields.decryptData(entries[i].Data)
+	}
+	if fields.err != nil {
+		return nil, fmt.Errorf("list: %w", fields.err)
+	}
+
+	return entries, nil
+}
+
+func (s *TrashService) Restore(ctx context.Context, kind client.DataKind, id int64) error {
+	return s.next.Restore(ctx, kind, id)
+}
+
+func (s *TrashService) Purge(ctx context.Context, kind client.DataKind, id int64) error {
+	return s.next.Purge(ctx, kind, id)
+}
@@ -0,0 +1,33 @@
+package crypto
+
+import (
+	"context"
+	"github.com/mkolibaba/gophkeeper/client"
+	"github.com/mkolibaba/gophkeeper/client/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestTrashList(t *testing.T) {
+	cipher := newUnlockedCipher(t)
+
+	encrypt := func(kind client.DataKind, name, plaintext string) string {
+		return encryptField(t, cipher, kind, 1, name, plaintext)
+	}
+
+	srv := NewTrashService(&mock.TrashServiceMock{
+		ListFunc: func(ctx context.Context) ([]client.TrashEntry, error) {
+			return []client.TrashEntry{
+				{Data: client.OTPData{Name: "otp", Secret: encrypt(client.DataKindOTP, "secret", "JBSWY3DPEHPK3PXP"), Issuer: encrypt(client.DataKindOTP, "issuer", "GitHub")}},
+				{Data: client.NoteData{Name: "note", Text: encrypt(client.DataKindNote, "text", "text")}},
+			}, nil
+		},
+	}, cipher)
+
+	entries, err := srv.List(t.Context())
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	require.Equal(t, "JBSWY3DPEHPK3PXP", entries[0].Data.(client.OTPData).Secret)
+	require.Equal(t, "GitHub", entries[0].Data.(client.OTPData).Issuer)
+	require.Equal(t, "text", entries[1].Data.(client.NoteData).Text)
+}
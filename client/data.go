@@ -2,13 +2,30 @@ package client
 
 import (
 	"context"
+	"errors"
 	"github.com/go-playground/validator/v10"
 	"regexp"
 )
 
+var ErrDataNotFound = errors.New("data not found")
+
+// VersionConflictError возвращается при изменении или удалении данных,
+// которые уже изменили с другого клиента.
+type VersionConflictError struct {
+	// Version - текущая версия данных на сервере.
+	Version int64
+}
+
+func (e *VersionConflictError) Error() string {
+	return "data was modified by another client"
+}
+
 type Data interface {
 	GetID() int64
 	GetName() string
+	// GetVersion возвращает версию данных на сервере. Версия меняется
+	// при каждом изменении и передается при обновлении и удалении.
+	GetVersion() int64
 }
 
 type LoginData struct {
@@ -18,6 +35,7 @@ type LoginData struct {
 	Password string
 	Website  string
 	Notes    string
+	Version  int64
 }
 
 func (d LoginData) GetID() int64 {
@@ -28,8 +46,13 @@ func (d LoginData) GetName() string {
 	return d.Name
 }
 
+func (d LoginData) GetVersion() int64 {
+	return d.Version
+}
+
 type LoginDataUpdate struct {
 	ID       int64
+	Version  int64
 	Name     *string
 	Login    *string
 	Password *string
@@ -41,13 +64,14 @@ type LoginService interface {
 	Save(ctx context.Context, data LoginData) error
 	GetAll(ctx context.Context) ([]LoginData, error)
 	Update(ctx context.Context, data LoginDataUpdate) error
-	Remove(ctx context.Context, id int64) error
+	Remove(ctx context.Context, id int64, version int64) error
 }
 
 type NoteData struct {
-	ID   int64
-	Name string `validate:"required"`
-	Text string
+	ID      int64
+	Name    string `validate:"required"`
+	Text    string
+	Version int64
 }
 
 func (d NoteData) GetID() int64 {
@@ -58,17 +82,22 @@ func (d NoteData) GetName() string {
 	return d.Name
 }
 
+func (d NoteData) GetVersion() int64 {
+	return d.Version
+}
+
 type NoteDataUpdate struct {
-	ID   int64
-	Name *string
-	Text *string
+	ID      int64
+	Version int64
+	Name    *string
+	Text    *string
 }
 
 type NoteService interface {
 	Save(ctx context.Context, data NoteData) error
 	GetAll(ctx context.Context) ([]NoteData, error)
 	Update(ctx context.Context, data NoteDataUpdate) error
-	Remove(ctx context.Context, id int64) error
+	Remove(ctx context.Context, id int64, version int64) error
 }
 
 type BinaryData struct {
@@ -77,6 +106,7 @@ type BinaryData struct {
 	Filename string `validate:"required"`
 	Size     int64
 	Notes    string
+	Version  int64
 }
 
 func (d BinaryData) GetID() int64 {
@@ -87,18 +117,24 @@ func (d BinaryData) GetName() string {
 	return d.Name
 }
 
+func (d BinaryData) GetVersion() int64 {
+	return d.Version
+}
+
 type BinaryDataUpdate struct {
-	ID    int64
-	Name  *string
-	Notes *string
+	ID      int64
+	Version int64
+	Name    *string
+	Notes   *string
 }
 
 type BinaryService interface {
 	Save(ctx context.Context, data BinaryData) error
 	GetAll(ctx context.Context) ([]BinaryData, error)
 	Update(ctx context.Context, data BinaryDataUpdate) error
-	Remove(ctx context.Context, id int64) error
-	Download(ctx context.Context, id int64) error
+	Remove(ctx context.Context, id int64, version int64) error
+	// Download скачивает файл и возвращает путь, по которому он сохранен.
+	Download(ctx context.Context, id int64) (string, error)
 }
 
 type CardData struct {
@@ -109,6 +145,7 @@ type CardData struct {
 	CVV        string `validate:"required,len=3"`
 	Cardholder string `validate:"required"`
 	Notes      string
+	Version    int64
 }
 
 func (d CardData) GetID() int64 {
@@ -119,8 +156,13 @@ func (d CardData) GetName() string {
 	return d.Name
 }
 
+func (d CardData) GetVersion() int64 {
+	return d.Version
+}
+
 type CardDataUpdate struct {
 	ID         int64
+	Version    int64
 	Name       *string
 	Number     *string
 	ExpDate    *string
@@ -133,7 +175,54 @@ type CardService interface {
 	Save(ctx context.Context, data CardData) error
 	GetAll(ctx context.Context) ([]CardData, error)
 	Update(ctx context.Context, data CardDataUpdate) error
-	Remove(ctx context.Context, id int64) error
+	Remove(ctx context.Context, id int64, version int64) error
+}
+
+// OTPData - данные для генерации одноразовых паролей. Для TOTP пароль
+// меняется каждые Period секунд, для HOTP - при увеличении Counter.
+type OTPData struct {
+	ID        int64
+	Name      string `validate:"required"`
+	Type      string `validate:"oneof=totp hotp"`
+	Secret    string `validate:"required,otp_secret"`
+	Algorithm string `validate:"oneof=SHA1 SHA256 SHA512"`
+	Digits    int64  `validate:"min=6,max=8"`
+	Period    int64  `validate:"required_if=Type totp,min=0"`
+	Counter   int64  `validate:"min=0"`
+	Issuer    string
+	Version   int64
+}
+
+func (d OTPData) GetID() int64 {
+	return d.ID
+}
+
+func (d OTPData) GetName() string {
+	return d.Name
+}
+
+func (d OTPData) GetVersion() int64 {
+	return d.Version
+}
+
+type OTPDataUpdate struct {
+	ID        int64
+	Version   int64
+	Name      *string
+	Type      *string
+	Secret    *string
+	Algorithm *string
+	Digits    *int64
+	Period    *int64
+	Counter   *int64
+	Issuer    *string
+}
+
+type OTPService interface {
+	Save(ctx context.Context, data OTPData) error
+	GetAll(ctx context.Context) ([]OTPData, error)
+	Update(ctx context.Context, data OTPDataUpdate) error
+	Remove(ctx context.Context, id int64, version int64) error
 }
 
 func NewDataValidator() (*validator.Validate, error) {
@@ -146,6 +235,14 @@ func NewDataValidator() (*validator.Validate, error) {
 	err = v.RegisterValidation("exp_date", func(fl validator.FieldLevel) bool {
 		return expDateRegexp.MatchString(fl.Field().String())
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = v.RegisterValidation("otp_secret", func(fl validator.FieldLevel) bool {
+		_, err := decodeOTPSecret(fl.Field().String())
+		return err == nil
+	})
 
 	return v, err
 }
@@ -13,12 +13,17 @@ require (
 	github.com/fatih/color v1.18.0
 	github.com/go-playground/validator/v10 v10.27.0
 	github.com/golang/protobuf v1.5.4
+	github.com/klauspost/compress v1.18.0
 	github.com/magefile/mage v1.15.0
 	github.com/mkolibaba/gophkeeper/proto v0.0.1
+	github.com/spf13/cobra v1.10.1
 	github.com/spf13/viper v1.21.0
 	github.com/stretchr/testify v1.11.1
 	github.com/uwu-tools/magex v0.10.1
+	go.etcd.io/bbolt v1.4.3
 	go.uber.org/fx v1.24.0
+	golang.org/x/crypto v0.40.0
+	golang.org/x/term v0.35.0
 	google.golang.org/grpc v1.76.0
 )
 
@@ -39,6 +44,7 @@ require (
 	github.com/go-playground/locales v0.14.1 // indirect
 	github.com/go-playground/universal-translator v0.18.1 // indirect
 	github.com/go-viper/mapstructure/v2 v2.4.0 // indirect
+	github.com/inconshreveable/mousetrap v1.1.0 // indirect
 	github.com/itchyny/gojq v0.12.13 // indirect
 	github.com/itchyny/timefmt-go v0.1.5 // indirect
 	github.com/leodido/go-urn v1.4.0 // indirect
@@ -64,7 +70,6 @@ require (
 	go.uber.org/multierr v1.10.0 // indirect
 	go.uber.org/zap v1.27.0 // indirect
 	go.yaml.in/yaml/v3 v3.0.4 // indirect
-	golang.org/x/crypto v0.40.0 // indirect
 	golang.org/x/exp v0.0.0-20250620022241-b7579e27df2b // indirect
 	golang.org/x/net v0.42.0 // indirect
 	golang.org/x/sys v0.36.0 // indirect
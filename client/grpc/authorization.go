@@ -4,8 +4,11 @@ import (
 	"context"
 	"fmt"
 	"github.com/charmbracelet/log"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/mkolibaba/gophkeeper/client"
 	"github.com/mkolibaba/gophkeeper/proto/gen/go/gophkeeperv1"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
@@ -15,42 +18,284 @@ func NewAuthorizationServiceClient(conn *grpc.ClientConn) gophkeeperv1.Authoriza
 
 type AuthorizationService struct {
 	client gophkeeperv1.AuthorizationServiceClient
+	cipher client.Cipher
 	logger *log.Logger
 }
 
-func NewAuthorizationService(client gophkeeperv1.AuthorizationServiceClient, logger *log.Logger) *AuthorizationService {
+func NewAuthorizationService(
+	client gophkeeperv1.AuthorizationServiceClient,
+	cipher client.Cipher,
+	logger *log.Logger,
+) *AuthorizationService {
 	return &AuthorizationService{
 		client: client,
+		cipher: cipher,
 		logger: logger,
 	}
 }
 
-func (s *AuthorizationService) Authorize(ctx context.Context, login string, password string) (string, error) {
-	s.logger.Debug("trying to authorize", "login", login, "password", password)
-	return s.send(ctx, s.client.Authorize, login, password)
+func (s *AuthorizationService) Authorize(ctx context.Context, login string, password string) (client.Tokens, error) {
+	s.logger.Debug("trying to authorize", "login", login)
+
+	key, err := s.cipher.DeriveKeys(login, password)
+	if err != nil {
+		return client.Tokens{}, err
+	}
+
+	out, err := s.send(ctx, s.client.Authorize, login, key, nil)
+	if status.Code(err) == codes.InvalidArgument {
+		// Сервер не отличает неверный пароль от учетной записи, созданной до
+		// шифрования на клиенте. Пробуем перевести ее на ключ аутентификации:
+		// если мастер-пароль не подошел и здесь, возвращаем исходную ошибку.
+		tokens, enrollErr := s.enroll(ctx, login, password, key)
+		if status.Code(enrollErr) == codes.InvalidArgument {
+			return client.Tokens{}, statusError(err)
+		}
+		return tokens, enrollErr
+	}
+	if err != nil {
+		return client.Tokens{}, statusError(err)
+	}
+
+	return s.response(key, out)
 }
 
-func (s *AuthorizationService) Register(ctx context.Context, login string, password string) (string, error) {
-	return s.send(ctx, s.client.Register, login, password)
+func (s *AuthorizationService) Register(ctx context.Context, login string, password string) (client.Tokens, error) {
+	key, err := s.cipher.DeriveKeys(login, password)
+	if err != nil {
+		return client.Tokens{}, err
+	}
+
+	vaultKey, err := s.cipher.NewVaultKey(key)
+	if err != nil {
+		return client.Tokens{}, err
+	}
+
+	out, err := s.send(ctx, s.client.Register, login, key, vaultKey)
+	if err != nil {
+		return client.Tokens{}, statusError(err)
+	}
+
+	return s.response(key, out)
+}
+
+// enroll создает ключ хранилища для учетной записи, созданной до шифрования
+// на клиенте, и выполняет вход. Сервер последний раз получает мастер-пароль,
+// чтобы сверить его с сохраненным хэшем.
+func (s *AuthorizationService) enroll(
+	ctx context.Context,
+	login, password string,
+	key client.MasterKey,
+) (client.Tokens, error) {
+	s.logger.Info("enrolling vault key", "login", login)
+
+	vaultKey, err := s.cipher.NewVaultKey(key)
+	if err != nil {
+		return client.Tokens{}, err
+	}
+
+	var in gophkeeperv1.EnrollRequest
+	in.SetLogin(login)
+	in.SetPassword(password)
+	in.SetAuthKey(key.AuthKey)
+	in.SetVaultKey(vaultKey)
+
+	out, err := s.client.Enroll(ctx, &in)
+	if err != nil {
+		return client.Tokens{}, statusError(err)
+	}
+
+	return s.response(key, out)
 }
 
 func (s *AuthorizationService) send(
 	ctx context.Context,
 	sender func(ctx context.Context, in *gophkeeperv1.UserCredentials, opts ...grpc.CallOption) (*gophkeeperv1.TokenResponse, error),
-	login, password string,
-) (string, error) {
+	login string,
+	key client.MasterKey,
+	vaultKey []byte,
+) (*gophkeeperv1.TokenResponse, error) {
 	var in gophkeeperv1.UserCredentials
 	in.SetLogin(login)
-	in.SetPassword(password)
+	in.SetPassword(key.AuthKey)
+	in.SetVaultKey(vaultKey)
+
+	return sender(ctx, &in)
+}
 
-	out, err := sender(ctx, &in)
+// response обрабатывает ответ на вход: если нужен второй фактор, возвращает
+// MFARequiredError, иначе открывает хранилище.
+func (s *AuthorizationService) response(key client.MasterKey, out *gophkeeperv1.TokenResponse) (client.Tokens, error) {
+	if challenge := out.GetMfaChallenge(); challenge != "" {
+		return client.Tokens{}, &client.MFARequiredError{Challenge: challenge}
+	}
+
+	return s.unlock(key, out)
+}
 
+func (s *AuthorizationService) VerifyMFA(
+	ctx context.Context,
+	challenge string,
+	login string,
+	password string,
+	code string,
+) (client.Tokens, error) {
+	key, err := s.cipher.DeriveKeys(login, password)
 	if err != nil {
-		if statusErr, ok := status.FromError(err); ok {
-			return "", fmt.Errorf("%s", statusErr.Message())
-		}
-		return "", err
+		return client.Tokens{}, err
+	}
+
+	var in gophkeeperv1.VerifyMFARequest
+	in.SetMfaChallenge(challenge)
+	in.SetCode(code)
+
+	out, err := s.client.VerifyMFA(ctx, &in)
+	if err != nil {
+		return client.Tokens{}, statusError(err)
 	}
 
-	return out.GetToken(), nil
+	return s.unlock(key, out)
+}
+
+// unlock открывает хранилище ключом из ответа и возвращает токены сессии.
+func (s *AuthorizationService) unlock(key client.MasterKey, out *gophkeeperv1.TokenResponse) (client.Tokens, error) {
+	// Ключ хранилища приходит с сервера зашифрованным,
+	// расшифровываем его ключом из мастер-пароля.
+	if err := s.cipher.Unlock(key, out.GetVaultKey()); err != nil {
+		return client.Tokens{}, fmt.Errorf("unlock vault: %w", err)
+	}
+
+	return client.Tokens{
+		AccessToken:  out.GetToken(),
+		RefreshToken: out.GetRefreshToken(),
+		VaultKey:     out.GetVaultKey(),
+	}, nil
+}
+
+func (s *AuthorizationService) Logout(ctx context.Context) error {
+	_, err := s.client.Logout(ctx, &empty.Empty{})
+	return err
+}
+
+func (s *AuthorizationService) ListSessions(ctx context.Context) ([]client.Session, error) {
+	out, err := s.client.ListSessions(ctx, &empty.Empty{})
+	if err != nil {
+		return nil, err
+	}
+
+	var sessions []client.Session
+	for _, session := range out.GetSessions() {
+		sessions = append(sessions, client.Session{
+			ID:         session.GetId(),
+			CreatedAt:  session.GetCreatedAt().AsTime(),
+			LastUsedAt: session.GetLastUsedAt().AsTime(),
+			ExpiresAt:  session.GetExpiresAt().AsTime(),
+			Current:    session.GetCurrent(),
+		})
+	}
+
+	return sessions, nil
+}
+
+func (s *AuthorizationService) RevokeSession(ctx context.Context, id string) error {
+	var in gophkeeperv1.RevokeSessionRequest
+	in.SetId(id)
+
+	_, err := s.client.RevokeSession(ctx, &in)
+	return err
+}
+
+func (s *AuthorizationService) SetupMFA(ctx context.Context) (client.MFASetup, error) {
+	out, err := s.client.SetupMFA(ctx, &empty.Empty{})
+	if err != nil {
+		return client.MFASetup{}, err
+	}
+
+	return client.MFASetup{
+		Secret: out.GetSecret(),
+		URI:    out.GetUri(),
+	}, nil
+}
+
+func (s *AuthorizationService) EnableMFA(ctx context.Context, code string) ([]string, error) {
+	var in gophkeeperv1.MFACode
+	in.SetCode(code)
+
+	out, err := s.client.EnableMFA(ctx, &in)
+	if err != nil {
+		return nil, err
+	}
+	return out.GetCodes(), nil
+}
+
+func (s *AuthorizationService) DisableMFA(ctx context.Context, code string) error {
+	var in gophkeeperv1.MFACode
+	in.SetCode(code)
+
+	_, err := s.client.DisableMFA(ctx, &in)
+	return err
+}
+
+func (s *AuthorizationService) ChangePassword(
+	ctx context.Context,
+	login string,
+	currentPassword string,
+	newPassword string,
+) error {
+	currentKey, err := s.cipher.DeriveKeys(login, currentPassword)
+	if err != nil {
+		return err
+	}
+	newKey, err := s.cipher.DeriveKeys(login, newPassword)
+	if err != nil {
+		return err
+	}
+
+	vaultKey, err := s.cipher.WrapVaultKey(newKey)
+	if err != nil {
+		return err
+	}
+
+	var in gophkeeperv1.ChangePasswordRequest
+	in.SetCurrentPassword(currentKey.AuthKey)
+	in.SetNewPassword(newKey.AuthKey)
+	in.SetVaultKey(vaultKey)
+
+	_, err = s.client.ChangePassword(ctx, &in)
+	return err
+}
+
+func (s *AuthorizationService) DeleteAccount(ctx context.Context, login string, password string) error {
+	key, err := s.cipher.DeriveKeys(login, password)
+	if err != nil {
+		return err
+	}
+
+	var in gophkeeperv1.DeleteAccountRequest
+	in.SetPassword(key.AuthKey)
+
+	_, err = s.client.DeleteAccount(ctx, &in)
+	return err
+}
+
+// statusError оставляет в тексте ошибки сервера только сообщение,
+// чтобы его можно было показать пользователю. Код ошибки сохраняется.
+func statusError(err error) error {
+	if statusErr, ok := status.FromError(err); ok {
+		return &messageError{status: statusErr}
+	}
+	return err
+}
+
+// messageError - ошибка сервера, текст которой состоит только из сообщения.
+type messageError struct {
+	status *status.Status
+}
+
+func (e *messageError) Error() string {
+	return e.status.Message()
+}
+
+func (e *messageError) GRPCStatus() *status.Status {
+	return e.status
 }
@@ -3,18 +3,73 @@ package grpc
 import (
 	"context"
 	"github.com/charmbracelet/log"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/mkolibaba/gophkeeper/client"
 	"github.com/mkolibaba/gophkeeper/client/grpc/mock"
+	clientmock "github.com/mkolibaba/gophkeeper/client/mock"
 	"github.com/mkolibaba/gophkeeper/proto/gen/go/gophkeeperv1"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	"io"
 	"testing"
+	"time"
 )
 
+// deriveKeys - детерминированная замена вывода ключей из мастер-пароля.
+func deriveKeys(login string, password string) (client.MasterKey, error) {
+	return client.MasterKey{
+		AuthKey:       "auth key " + login + ":" + password,
+		EncryptionKey: []byte(password),
+	}, nil
+}
+
 func TestAuthorizationAuthorize(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
+		cipher := &clientmock.CipherMock{
+			DeriveKeysFunc: deriveKeys,
+			UnlockFunc: func(key client.MasterKey, vaultKey []byte) error {
+				return nil
+			},
+		}
+		clientMock := &mock.AuthorizationServiceClientMock{
+			AuthorizeFunc: func(ctx context.Context, in *gophkeeperv1.UserCredentials, opts ...grpc.CallOption) (*gophkeeperv1.TokenResponse, error) {
+				var out gophkeeperv1.TokenResponse
+				out.SetToken("cool token")
+				out.SetVaultKey([]byte("vault key"))
+				return &out, nil
+			},
+		}
+		srv := NewAuthorizationService(clientMock, cipher, log.New(io.Discard))
+
+		resp, err := srv.Authorize(t.Context(), "testuser", "123")
+		require.NoError(t, err)
+		require.NotEmpty(t, resp)
+
+		// Вместо мастер-пароля на сервер уходит ключ аутентификации.
+		require.Equal(t, "auth key testuser:123", clientMock.AuthorizeCalls()[0].In.GetPassword())
+		require.Len(t, cipher.UnlockCalls(), 1)
+		require.Equal(t, []byte("123"), cipher.UnlockCalls()[0].Key.EncryptionKey)
+		require.Equal(t, []byte("vault key"), cipher.UnlockCalls()[0].VaultKey)
+	})
+	t.Run("fail", func(t *testing.T) {
+		srv := NewAuthorizationService(
+			&mock.AuthorizationServiceClientMock{
+				AuthorizeFunc: func(ctx context.Context, in *gophkeeperv1.UserCredentials, opts ...grpc.CallOption) (*gophkeeperv1.TokenResponse, error) {
+					return nil, status.Error(codes.Internal, "some error")
+				},
+			},
+			&clientmock.CipherMock{DeriveKeysFunc: deriveKeys},
+			log.New(io.Discard),
+		)
+
+		_, err := srv.Authorize(t.Context(), "testuser", "123")
+		require.EqualError(t, err, "some error")
+		require.Equal(t, codes.Internal, status.Code(err))
+	})
+	t.Run("invalid_vault_key", func(t *testing.T) {
 		srv := NewAuthorizationService(
 			&mock.AuthorizationServiceClientMock{
 				AuthorizeFunc: func(ctx context.Context, in *gophkeeperv1.UserCredentials, opts ...grpc.CallOption) (*gophkeeperv1.TokenResponse, error) {
@@ -23,24 +78,222 @@ func TestAuthorizationAuthorize(t *testing.T) {
 					return &out, nil
 				},
 			},
+			&clientmock.CipherMock{
+				DeriveKeysFunc: deriveKeys,
+				UnlockFunc: func(key client.MasterKey, vaultKey []byte) error {
+					return io.ErrUnexpectedEOF
+				},
+			},
 			log.New(io.Discard),
 		)
 
-		resp, err := srv.Authorize(t.Context(), "testuser", "123")
+		_, err := srv.Authorize(t.Context(), "testuser", "123")
+		require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	})
+	t.Run("enroll", func(t *testing.T) {
+		cipher := &clientmock.CipherMock{
+			DeriveKeysFunc: deriveKeys,
+			NewVaultKeyFunc: func(key client.MasterKey) ([]byte, error) {
+				return []byte("new vault key"), nil
+			},
+			UnlockFunc: func(key client.MasterKey, vaultKey []byte) error {
+				return nil
+			},
+		}
+		clientMock := &mock.AuthorizationServiceClientMock{
+			AuthorizeFunc: func(ctx context.Context, in *gophkeeperv1.UserCredentials, opts ...grpc.CallOption) (*gophkeeperv1.TokenResponse, error) {
+				return nil, status.Error(codes.InvalidArgument, "invalid login or password")
+			},
+			EnrollFunc: func(ctx context.Context, in *gophkeeperv1.EnrollRequest, opts ...grpc.CallOption) (*gophkeeperv1.TokenResponse, error) {
+				var out gophkeeperv1.TokenResponse
+				out.SetToken("cool token")
+				out.SetVaultKey(in.GetVaultKey())
+				return &out, nil
+			},
+		}
+		srv := NewAuthorizationService(clientMock, cipher, log.New(io.Discard))
+
+		tokens, err := srv.Authorize(t.Context(), "testuser", "123")
 		require.NoError(t, err)
-		require.NotEmpty(t, resp)
+		require.Equal(t, "cool token", tokens.AccessToken)
+
+		require.Len(t, clientMock.EnrollCalls(), 1)
+		in := clientMock.EnrollCalls()[0].In
+		require.Equal(t, "testuser", in.GetLogin())
+		require.Equal(t, "123", in.GetPassword())
+		require.Equal(t, "auth key testuser:123", in.GetAuthKey())
+		require.Equal(t, []byte("new vault key"), in.GetVaultKey())
+		require.Equal(t, []byte("new vault key"), cipher.UnlockCalls()[0].VaultKey)
 	})
-	t.Run("fail", func(t *testing.T) {
+	t.Run("invalid_password", func(t *testing.T) {
+		clientMock := &mock.AuthorizationServiceClientMock{
+			AuthorizeFunc: func(ctx context.Context, in *gophkeeperv1.UserCredentials, opts ...grpc.CallOption) (*gophkeeperv1.TokenResponse, error) {
+				return nil, status.Error(codes.InvalidArgument, "invalid login or password")
+			},
+			EnrollFunc: func(ctx context.Context, in *gophkeeperv1.EnrollRequest, opts ...grpc.CallOption) (*gophkeeperv1.TokenResponse, error) {
+				return nil, status.Error(codes.InvalidArgument, "invalid password")
+			},
+		}
 		srv := NewAuthorizationService(
-			&mock.AuthorizationServiceClientMock{
-				AuthorizeFunc: func(ctx context.Context, in *gophkeeperv1.UserCredentials, opts ...grpc.CallOption) (*gophkeeperv1.TokenResponse, error) {
-					return nil, status.Error(codes.Internal, "some error")
+			clientMock,
+			&clientmock.CipherMock{
+				DeriveKeysFunc: deriveKeys,
+				NewVaultKeyFunc: func(key client.MasterKey) ([]byte, error) {
+					return []byte("new vault key"), nil
 				},
 			},
 			log.New(io.Discard),
 		)
 
 		_, err := srv.Authorize(t.Context(), "testuser", "123")
-		require.Error(t, err)
+		require.EqualError(t, err, "invalid login or password")
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+		require.Len(t, clientMock.EnrollCalls(), 1)
 	})
 }
+
+func TestAuthorizationRegister(t *testing.T) {
+	cipher := &clientmock.CipherMock{
+		DeriveKeysFunc: deriveKeys,
+		NewVaultKeyFunc: func(key client.MasterKey) ([]byte, error) {
+			return []byte("vault key"), nil
+		},
+		UnlockFunc: func(key client.MasterKey, vaultKey []byte) error {
+			return nil
+		},
+	}
+	srv := NewAuthorizationService(
+		&mock.AuthorizationServiceClientMock{
+			RegisterFunc: func(ctx context.Context, in *gophkeeperv1.UserCredentials, opts ...grpc.CallOption) (*gophkeeperv1.TokenResponse, error) {
+				require.Equal(t, "auth key testuser:123", in.GetPassword())
+				require.Equal(t, []byte("vault key"), in.GetVaultKey())
+
+				var out gophkeeperv1.TokenResponse
+				out.SetToken("cool token")
+				out.SetRefreshToken("refresh token")
+				out.SetVaultKey(in.GetVaultKey())
+				return &out, nil
+			},
+		},
+		cipher,
+		log.New(io.Discard),
+	)
+
+	resp, err := srv.Register(t.Context(), "testuser", "123")
+	require.NoError(t, err)
+	require.Equal(t, client.Tokens{AccessToken: "cool token", RefreshToken: "refresh token", VaultKey: []byte("vault key")}, resp)
+	require.Len(t, cipher.NewVaultKeyCalls(), 1)
+	require.Len(t, cipher.UnlockCalls(), 1)
+}
+
+func TestAuthorizationSessions(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	clientMock := &mock.AuthorizationServiceClientMock{
+		ListSessionsFunc: func(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*gophkeeperv1.ListSessionsResponse, error) {
+			var session gophkeeperv1.Session
+			session.SetId("session")
+			session.SetCreatedAt(timestamppb.New(now))
+			session.SetLastUsedAt(timestamppb.New(now))
+			session.SetExpiresAt(timestamppb.New(now.Add(time.Hour)))
+			session.SetCurrent(true)
+
+			var out gophkeeperv1.ListSessionsResponse
+			out.SetSessions([]*gophkeeperv1.Session{&session})
+			return &out, nil
+		},
+		RevokeSessionFunc: func(ctx context.Context, in *gophkeeperv1.RevokeSessionRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+			return &empty.Empty{}, nil
+		},
+		LogoutFunc: func(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error) {
+			return &empty.Empty{}, nil
+		},
+	}
+	srv := NewAuthorizationService(clientMock, &clientmock.CipherMock{}, log.New(io.Discard))
+
+	sessions, err := srv.ListSessions(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, []client.Session{{
+		ID:         "session",
+		CreatedAt:  now,
+		LastUsedAt: now,
+		ExpiresAt:  now.Add(time.Hour),
+		Current:    true,
+	}}, sessions)
+
+	require.NoError(t, srv.RevokeSession(t.Context(), "session"))
+	require.Equal(t, "session", clientMock.RevokeSessionCalls()[0].In.GetId())
+
+	require.NoError(t, srv.Logout(t.Context()))
+	require.Len(t, clientMock.LogoutCalls(), 1)
+}
+
+func TestAuthorizationMFA(t *testing.T) {
+	cipher := &clientmock.CipherMock{
+		DeriveKeysFunc: deriveKeys,
+		UnlockFunc: func(key client.MasterKey, vaultKey []byte) error {
+			return nil
+		},
+	}
+	clientMock := &mock.AuthorizationServiceClientMock{
+		AuthorizeFunc: func(ctx context.Context, in *gophkeeperv1.UserCredentials, opts ...grpc.CallOption) (*gophkeeperv1.TokenResponse, error) {
+			var out gophkeeperv1.TokenResponse
+			out.SetMfaChallenge("challenge")
+			return &out, nil
+		},
+		VerifyMFAFunc: func(ctx context.Context, in *gophkeeperv1.VerifyMFARequest, opts ...grpc.CallOption) (*gophkeeperv1.TokenResponse, error) {
+			if in.GetMfaChallenge() != "challenge" || in.GetCode() != "123456" {
+				return nil, status.Error(codes.InvalidArgument, "invalid mfa code")
+			}
+
+			var out gophkeeperv1.TokenResponse
+			out.SetToken("cool token")
+			out.SetRefreshToken("refresh token")
+			out.SetVaultKey([]byte("vault key"))
+			return &out, nil
+		},
+	}
+	srv := NewAuthorizationService(clientMock, cipher, log.New(io.Discard))
+
+	_, err := srv.Authorize(t.Context(), "testuser", "123")
+	var mfaErr *client.MFARequiredError
+	require.ErrorAs(t, err, &mfaErr)
+	require.Equal(t, "challenge", mfaErr.Challenge)
+	require.Empty(t, cipher.UnlockCalls())
+
+	_, err = srv.VerifyMFA(t.Context(), mfaErr.Challenge, "testuser", "123", "000000")
+	require.EqualError(t, err, "invalid mfa code")
+
+	tokens, err := srv.VerifyMFA(t.Context(), mfaErr.Challenge, "testuser", "123", "123456")
+	require.NoError(t, err)
+	require.Equal(t, client.Tokens{AccessToken: "cool token", RefreshToken: "refresh token", VaultKey: []byte("vault key")}, tokens)
+	require.Len(t, cipher.UnlockCalls(), 1)
+	require.Equal(t, []byte("123"), cipher.UnlockCalls()[0].Key.EncryptionKey)
+}
+
+func TestAuthorizationChangePassword(t *testing.T) {
+	cipher := &clientmock.CipherMock{
+		DeriveKeysFunc: deriveKeys,
+		WrapVaultKeyFunc: func(key client.MasterKey) ([]byte, error) {
+			return []byte("vault key for " + string(key.EncryptionKey)), nil
+		},
+	}
+	clientMock := &mock.AuthorizationServiceClientMock{
+		ChangePasswordFunc: func(ctx context.Context, in *gophkeeperv1.ChangePasswordRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+			if in.GetCurrentPassword() != "auth key testuser:123" {
+				return nil, status.Error(codes.InvalidArgument, "invalid password")
+			}
+			return &empty.Empty{}, nil
+		},
+	}
+	srv := NewAuthorizationService(clientMock, cipher, log.New(io.Discard))
+
+	err := srv.ChangePassword(t.Context(), "testuser", "wrong", "456")
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	require.NoError(t, srv.ChangePassword(t.Context(), "testuser", "123", "456"))
+
+	calls := clientMock.ChangePasswordCalls()
+	in := calls[len(calls)-1].In
+	require.Equal(t, "auth key testuser:456", in.GetNewPassword())
+	require.Equal(t, []byte("vault key for 456"), in.GetVaultKey())
+}
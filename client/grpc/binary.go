@@ -1,28 +1,88 @@
 package grpc
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"errors"
 	"fmt"
+	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/mkolibaba/gophkeeper/client"
 	"github.com/mkolibaba/gophkeeper/proto/gen/go/gophkeeperv1"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"io"
 	"os"
+	"path/filepath"
+	"slices"
 	"strings"
+	"time"
 )
 
 func NewBinaryServiceClient(conn *grpc.ClientConn) gophkeeperv1.BinaryServiceClient {
 	return gophkeeperv1.NewBinaryServiceClient(conn)
 }
 
+const (
+	// uploadChunkSize - размер отправляемой части содержимого.
+	uploadChunkSize = 64 * 1024 // 64 KB
+	// uploadAttempts - сколько раз отправляется или скачивается содержимое,
+	// прежде чем загрузка считается неудачной.
+	uploadAttempts = 5
+)
+
 type BinaryService struct {
-	client gophkeeperv1.BinaryServiceClient
+	client     gophkeeperv1.BinaryServiceClient
+	infoClient gophkeeperv1.InfoServiceClient
+	cipher     client.Cipher
+	// compression - способ сжатия файлов из конфигурации.
+	compression gophkeeperv1.Compression
+	// retryDelay - пауза перед возобновлением загрузки или скачивания.
+	retryDelay time.Duration
+	// downloadDir - каталог скачанных файлов.
+	downloadDir string
+	// partDir - каталог данных клиента, в котором хранятся .part-файлы
+	// незавершенных скачиваний.
+	partDir string
 }
 
-func NewBinaryService(client gophkeeperv1.BinaryServiceClient) *BinaryService {
+func NewBinaryService(
+	client gophkeeperv1.BinaryServiceClient,
+	infoClient gophkeeperv1.InfoServiceClient,
+	cipher client.Cipher,
+	config *client.Config,
+) (*BinaryService, error) {
+	compression, err := parseCompression(config.Binary.Compression)
+	if err != nil {
+		return nil, err
+	}
+
 	return &BinaryService{
-		client: client,
+		client:      client,
+		infoClient:  infoClient,
+		cipher:      cipher,
+		compression: compression,
+		retryDelay:  time.Second,
+		downloadDir: downloadDir(config),
+		partDir:     filepath.Dir(config.Cache.Path),
+	}, nil
+}
+
+// downloadDir возвращает каталог скачанных файлов из конфигурации или
+// каталог загрузок пользователя по XDG.
+func downloadDir(config *client.Config) string {
+	if config.Binary.DownloadDir != "" {
+		return config.Binary.DownloadDir
+	}
+	if dir := os.Getenv("XDG_DOWNLOAD_DIR"); dir != "" {
+		return dir
 	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "."
+	}
+	return filepath.Join(home, "Downloads")
 }
 
 func (s *BinaryService) Save(ctx context.Context, data client.BinaryData) error {
@@ -30,53 +90,168 @@ func (s *BinaryService) Save(ctx context.Context, data client.BinaryData) error
 	if err != nil {
 		return fmt.Errorf("save: %w", err)
 	}
+	defer file.Close()
 
 	fileInfo, err := file.Stat()
 	if err != nil {
 		return fmt.Errorf("save: %w", err)
 	}
 
-	stream, err := s.client.Upload(ctx)
+	// Шифрование использует случайный nonce, поэтому одинаковые файлы
+	// сервер узнает только по ключу дедупликации исходного содержимого.
+	contentKey, err := s.cipher.ContentKey(file)
 	if err != nil {
 		return fmt.Errorf("save: %w", err)
 	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("save: %w", err)
+	}
 
-	buffer := make([]byte, 64*1024)
-	var idx int32
+	// Зашифрованное содержимое не сжимается, поэтому файл сжимается
+	// до шифрования.
+	compression := s.negotiateCompression(ctx)
+	compressed, err := compress(file, compression)
+	if err != nil {
+		return fmt.Errorf("save: %w", err)
+	}
+	defer compressed.Close()
 
-	for {
-		n, err := file.Read(buffer)
-		if err == io.EOF {
-			break
+	encrypted, err := s.cipher.EncryptStream(compressed)
+	if err != nil {
+		return fmt.Errorf("save: %w", err)
+	}
+
+	// Шифрование использует случайный nonce, поэтому зашифрованное
+	// содержимое сохраняется во временный файл: при возобновлении
+	// загрузки дослать нужно те же байты, а размер и хэш нужны заранее.
+	content, err := os.CreateTemp("", "gophkeeper-upload-*")
+	if err != nil {
+		return fmt.Errorf("save: %w", err)
+	}
+	defer os.Remove(content.Name())
+	defer content.Close()
+
+	hash := sha256.New()
+	contentSize, err := io.Copy(io.MultiWriter(content, hash), encrypted)
+	if err != nil {
+		return fmt.Errorf("save: %w", err)
+	}
+
+	var in gophkeeperv1.CreateUploadRequest
+	in.SetName(data.Name)
+	in.SetFilename(data.Filename[strings.LastIndex(data.Filename, "/")+1:])
+	in.SetSize(fileInfo.Size())
+	in.SetNotes(data.Notes)
+	in.SetContentSize(contentSize)
+	in.SetSha256(hash.Sum(nil))
+	in.SetCompression(compression)
+	in.SetContentKey(contentKey)
+
+	upload, err := s.client.CreateUpload(ctx, &in)
+	if err != nil {
+		return fmt.Errorf("save: %w", statusError(err))
+	}
+
+	var uploadRequest gophkeeperv1.UploadRequest
+	uploadRequest.SetUploadId(upload.GetUploadId())
+
+	offset := upload.GetOffset()
+	for attempt := 1; offset < contentSize; attempt++ {
+		offset, err = s.sendChunks(ctx, content, upload.GetUploadId(), offset)
+		if err == nil {
+			continue
+		}
+		if attempt == uploadAttempts || !resumable(err) {
+			return fmt.Errorf("save: %w", statusError(err))
 		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("save: %w", ctx.Err())
+		case <-time.After(s.retryDelay):
+		}
+
+		// Сервер мог сохранить часть отправленного до обрыва.
+		committed, err := s.client.GetUpload(ctx, &uploadRequest)
 		if err != nil {
-			return fmt.Errorf("save: %w", err)
+			return fmt.Errorf("save: %w", statusError(err))
 		}
+		offset = committed.GetOffset()
+	}
 
-		var chunk gophkeeperv1.FileChunk
-		chunk.SetData(buffer[:n])
-		chunk.SetIndex(idx)
+	if _, err := s.client.CompleteUpload(ctx, &uploadRequest); err != nil {
+		return fmt.Errorf("save: %w", statusError(err))
+	}
 
-		var in gophkeeperv1.SaveBinaryRequest
-		in.SetChunk(&chunk)
-		in.SetName(data.Name)
-		in.SetFilename(data.Filename[strings.LastIndex(data.Filename, "/")+1:])
-		in.SetSize(fileInfo.Size())
-		in.SetNotes(data.Notes)
+	return nil
+}
 
-		if err := stream.Send(&in); err != nil {
-			return fmt.Errorf("save: %w", err)
-		}
+// negotiateCompression возвращает способ сжатия для загрузки: настроенный,
+// если сервер может его хранить. Иначе файл не сжимается: сервер, который
+// не знает о сжатии, потерял бы способ сжатия, и файл нельзя было бы
+// распаковать.
+func (s *BinaryService) negotiateCompression(ctx context.Context) gophkeeperv1.Compression {
+	if s.compression == gophkeeperv1.Compression_COMPRESSION_NONE {
+		return s.compression
+	}
+
+	info, err := s.infoClient.ServerInfo(ctx, &empty.Empty{})
+	if err != nil || !slices.Contains(info.GetCompressions(), s.compression) {
+		return gophkeeperv1.Compression_COMPRESSION_NONE
+	}
+	return s.compression
+}
 
-		idx++
+// sendChunks отправляет содержимое content, начиная с offset, и возвращает
+// сохраненное сервером смещение.
+func (s *BinaryService) sendChunks(ctx context.Context, content io.ReadSeeker, id string, offset int64) (int64, error) {
+	if _, err := content.Seek(offset, io.SeekStart); err != nil {
+		return 0, err
 	}
 
-	_, err = stream.CloseAndRecv()
+	stream, err := s.client.UploadChunks(ctx)
 	if err != nil {
-		return fmt.Errorf("save: %w", err)
+		return 0, err
 	}
 
-	return nil
+	buffer := make([]byte, uploadChunkSize)
+	for {
+		n, readErr := content.Read(buffer)
+		if n > 0 {
+			var chunk gophkeeperv1.UploadChunk
+			chunk.SetUploadId(id)
+			chunk.SetOffset(offset)
+			chunk.SetData(buffer[:n])
+
+			// Причину ошибки отправки возвращает CloseAndRecv.
+			if err := stream.Send(&chunk); err != nil {
+				break
+			}
+			offset += int64(n)
+		}
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			return 0, readErr
+		}
+	}
+
+	out, err := stream.CloseAndRecv()
+	if err != nil {
+		return 0, err
+	}
+	return out.GetOffset(), nil
+}
+
+// resumable сообщает, можно ли продолжить загрузку после ошибки.
+func resumable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.Aborted, codes.FailedPrecondition:
+		return true
+	default:
+		return false
+	}
 }
 
 func (s *BinaryService) GetAll(ctx context.Context) ([]client.BinaryData, error) {
@@ -93,6 +268,7 @@ func (s *BinaryService) GetAll(ctx context.Context) ([]client.BinaryData, error)
 			Filename: b.GetFilename(),
 			Size:     b.GetSize(),
 			Notes:    b.GetNotes(),
+			Version:  b.GetVersion(),
 		})
 	}
 	return binaries, nil
@@ -101,6 +277,7 @@ func (s *BinaryService) GetAll(ctx context.Context) ([]client.BinaryData, error)
 func (s *BinaryService) Update(ctx context.Context, data client.BinaryDataUpdate) error {
 	var in gophkeeperv1.UpdateBinaryRequest
 	in.SetId(data.ID)
+	in.SetVersion(data.Version)
 	if data.Name != nil {
 		in.SetName(*data.Name)
 	}
@@ -109,60 +286,177 @@ func (s *BinaryService) Update(ctx context.Context, data client.BinaryDataUpdate
 	}
 
 	_, err := s.client.Update(ctx, &in)
-	return err
+	return unwrapError(err)
 }
 
-func (s *BinaryService) Download(ctx context.Context, id int64) error {
+func (s *BinaryService) Download(ctx context.Context, id int64) (string, error) {
+	for _, dir := range []string{s.partDir, s.downloadDir} {
+		if err := os.MkdirAll(dir, 0700); err != nil {
+			return "", fmt.Errorf("download: %w", err)
+		}
+	}
+
+	// Зашифрованное содержимое скачивается в .part-файл, который
+	// переживает обрыв соединения и перезапуск клиента: следующая
+	// попытка запрашивает только недостающую часть.
+	partName := filepath.Join(s.partDir, fmt.Sprintf("gophkeeper-%d.part", id))
+	part, err := os.OpenFile(partName, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return "", fmt.Errorf("download: %w", err)
+	}
+	defer part.Close()
+
+	var meta *gophkeeperv1.DownloadBinaryResponse
+	for attempt := 1; ; attempt++ {
+		meta, err = s.receive(ctx, id, part)
+		if err == nil {
+			if err = verifyPart(part.Name(), meta.GetSha256()); err == nil {
+				break
+			}
+		}
+
+		switch {
+		case errors.Is(err, errChecksumMismatch), status.Code(err) == codes.OutOfRange:
+			// .part-файл поврежден или остался от другого содержимого,
+			// скачиваем заново.
+			if truncErr := part.Truncate(0); truncErr != nil {
+				return "", fmt.Errorf("download: %w", truncErr)
+			}
+		case !resumable(err):
+			return "", fmt.Errorf("download: %w", statusError(err))
+		}
+		if attempt == uploadAttempts {
+			return "", fmt.Errorf("download: %w", statusError(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return "", fmt.Errorf("download: %w", ctx.Err())
+		case <-time.After(s.retryDelay):
+		}
+	}
+
+	filename := filepath.Join(s.downloadDir, filepath.Base(meta.GetFilename()))
+	if err := s.decryptPart(part.Name(), filename, meta.GetCompression()); err != nil {
+		return "", fmt.Errorf("download: %w", err)
+	}
+
+	part.Close()
+	os.Remove(part.Name())
+	return filename, nil
+}
+
+// receive дописывает в part содержимое, которого в нем еще нет, и
+// возвращает первое сообщение потока с метаданными файла.
+func (s *BinaryService) receive(ctx context.Context, id int64, part *os.File) (*gophkeeperv1.DownloadBinaryResponse, error) {
+	info, err := part.Stat()
+	if err != nil {
+		return nil, err
+	}
+
 	var in gophkeeperv1.DownloadBinaryRequest
 	in.SetId(id)
+	in.SetOffset(info.Size())
 
 	stream, err := s.client.Download(ctx, &in)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	var file *os.File
-	//var totalSize int64
-	var receivedBytes int64
-
+	var meta *gophkeeperv1.DownloadBinaryResponse
+	received := info.Size()
 	for {
-		chunk, err := stream.Recv()
+		out, err := stream.Recv()
 		if err == io.EOF {
 			break
 		}
 		if err != nil {
-			panic(err)
+			return nil, err
 		}
-
-		if file == nil {
-			file, err = os.Create(chunk.GetFilename())
-			if err != nil {
-				panic(err)
-			}
-			defer file.Close()
-
-			//totalSize = chunk.GetTotalSize()
+		if meta == nil {
+			meta = out
 		}
 
-		n, err := file.Write(chunk.GetChunk().GetData())
+		n, err := part.Write(out.GetChunk().GetData())
 		if err != nil {
-			panic(err)
+			return nil, err
 		}
+		received += int64(n)
+	}
 
-		receivedBytes += int64(n)
+	if meta == nil || received != meta.GetContentSize() {
+		return nil, io.ErrUnexpectedEOF
+	}
+	return meta, nil
+}
 
-		//if totalSize > 0 {
-		//	progress := float64(receivedBytes) / float64(totalSize) * 100
-		//}
+// decryptPart расшифровывает и распаковывает скачанное содержимое во
+// временный файл и атомарно переименовывает его в filename.
+func (s *BinaryService) decryptPart(partName string, filename string, compression gophkeeperv1.Compression) error {
+	part, err := os.Open(partName)
+	if err != nil {
+		return err
+	}
+	defer part.Close()
+
+	tmp, err := os.CreateTemp(filepath.Dir(filename), filepath.Base(filename)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	decompressed, err := decompress(tmp, compression)
+	if err != nil {
+		return err
+	}
+	defer decompressed.Close()
+
+	decrypted, err := s.cipher.DecryptStream(decompressed)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(decrypted, part); err != nil {
+		return err
 	}
+	if err := decrypted.Close(); err != nil {
+		return err
+	}
+	if err := decompressed.Close(); err != nil {
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), filename)
+}
 
+var errChecksumMismatch = errors.New("checksum mismatch")
+
+// verifyPart сверяет SHA-256 скачанного содержимого с хэшем сервера.
+func verifyPart(partName string, digest []byte) error {
+	part, err := os.Open(partName)
+	if err != nil {
+		return err
+	}
+	defer part.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, part); err != nil {
+		return err
+	}
+	if !bytes.Equal(hash.Sum(nil), digest) {
+		return errChecksumMismatch
+	}
 	return nil
 }
 
-func (s *BinaryService) Remove(ctx context.Context, id int64) error {
+func (s *BinaryService) Remove(ctx context.Context, id int64, version int64) error {
 	var in gophkeeperv1.RemoveDataRequest
 	in.SetId(id)
+	in.SetVersion(version)
 
 	_, err := s.client.Remove(ctx, &in)
-	return err
+	return unwrapError(err)
 }
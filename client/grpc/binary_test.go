@@ -0,0 +1,359 @@
+package grpc
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/klauspost/compress/zstd"
+	"github.com/mkolibaba/gophkeeper/client"
+	"github.com/mkolibaba/gophkeeper/client/crypto"
+	"github.com/mkolibaba/gophkeeper/client/grpc/mock"
+	clientmock "github.com/mkolibaba/gophkeeper/client/mock"
+	"github.com/mkolibaba/gophkeeper/proto/gen/go/gophkeeperv1"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestBinarySave(t *testing.T) {
+	content := bytes.Repeat([]byte("0123456789"), 20_000)
+	filename := filepath.Join(t.TempDir(), "data.bin")
+	require.NoError(t, os.WriteFile(filename, content, 0600))
+
+	cipher := &clientmock.CipherMock{
+		EncryptStreamFunc: func(r io.Reader) (io.Reader, error) {
+			return r, nil
+		},
+	}
+
+	t.Run("resume", func(t *testing.T) {
+		upload := &fakeUpload{failAfter: 1}
+		srv := newBinaryService(t, upload.client(t), cipher, "")
+
+		err := srv.Save(t.Context(), client.BinaryData{Name: "data", Filename: filename})
+		require.NoError(t, err)
+		require.Equal(t, content, upload.stored)
+		require.Equal(t, 2, upload.streams)
+		require.True(t, upload.completed)
+	})
+	t.Run("zstd", func(t *testing.T) {
+		upload := &fakeUpload{}
+		srv := newBinaryService(t, upload.client(t), cipher, "zstd")
+
+		err := srv.Save(t.Context(), client.BinaryData{Name: "data", Filename: filename})
+		require.NoError(t, err)
+		require.Equal(t, gophkeeperv1.Compression_COMPRESSION_ZSTD, upload.request.GetCompression())
+		require.Less(t, len(upload.stored), len(content))
+
+		zr, err := zstd.NewReader(bytes.NewReader(upload.stored))
+		require.NoError(t, err)
+		defer zr.Close()
+		got, err := io.ReadAll(zr)
+		require.NoError(t, err)
+		require.Equal(t, content, got)
+	})
+	t.Run("zstd_unsupported_by_server", func(t *testing.T) {
+		upload := &fakeUpload{}
+		srv := newBinaryService(t, upload.client(t), cipher, "zstd")
+		srv.infoClient = &mock.InfoServiceClientMock{
+			ServerInfoFunc: func(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*gophkeeperv1.ServerInfoResponse, error) {
+				return &gophkeeperv1.ServerInfoResponse{}, nil
+			},
+		}
+
+		err := srv.Save(t.Context(), client.BinaryData{Name: "data", Filename: filename})
+		require.NoError(t, err)
+		require.Equal(t, gophkeeperv1.Compression_COMPRESSION_NONE, upload.request.GetCompression())
+		require.Equal(t, content, upload.stored)
+	})
+	t.Run("content_key", func(t *testing.T) {
+		// Настоящий шифр: каждая загрузка одного файла дает новый шифротекст,
+		// а сервер сопоставляет их по ключу дедупликации.
+		realCipher := crypto.NewCipher()
+		key, err := realCipher.DeriveKeys("alice", "master")
+		require.NoError(t, err)
+		vaultKey, err := realCipher.NewVaultKey(key)
+		require.NoError(t, err)
+		require.NoError(t, realCipher.Unlock(key, vaultKey))
+
+		save := func(filename string) *fakeUpload {
+			upload := &fakeUpload{}
+			srv := newBinaryService(t, upload.client(t), realCipher, "")
+			require.NoError(t, srv.Save(t.Context(), client.BinaryData{Name: "data", Filename: filename}))
+			return upload
+		}
+
+		first, second := save(filename), save(filename)
+		require.NotEqual(t, first.stored, second.stored)
+		require.Len(t, first.request.GetContentKey(), sha256.Size)
+		require.Equal(t, first.request.GetContentKey(), second.request.GetContentKey())
+
+		otherFilename := filepath.Join(t.TempDir(), "other.bin")
+		require.NoError(t, os.WriteFile(otherFilename, []byte("other content"), 0600))
+		other := save(otherFilename)
+		require.NotEqual(t, first.request.GetContentKey(), other.request.GetContentKey())
+	})
+	t.Run("not_resumable", func(t *testing.T) {
+		upload := &fakeUpload{failAfter: 1, failCode: codes.DataLoss}
+		srv := newBinaryService(t, upload.client(t), cipher, "")
+
+		err := srv.Save(t.Context(), client.BinaryData{Name: "data", Filename: filename})
+		require.Error(t, err)
+		require.Equal(t, 1, upload.streams)
+		require.False(t, upload.completed)
+	})
+}
+
+func TestBinaryDownload(t *testing.T) {
+	content := bytes.Repeat([]byte("0123456789"), 20_000)
+	hash := sha256.Sum256(content)
+
+	cipher := &clientmock.CipherMock{
+		DecryptStreamFunc: func(w io.Writer) (io.WriteCloser, error) {
+			return nopWriteCloser{w}, nil
+		},
+	}
+
+	t.Run("resume", func(t *testing.T) {
+		t.Chdir(t.TempDir())
+		// Остаток прошлой попытки.
+		require.NoError(t, os.Mkdir("data", 0700))
+		require.NoError(t, os.WriteFile("data/gophkeeper-1.part", content[:1000], 0600))
+
+		download := &fakeDownload{content: content, digest: hash[:], failAfter: 1}
+		srv := newBinaryService(t, download.client(), cipher, "")
+
+		path, err := srv.Download(t.Context(), 1)
+		require.NoError(t, err)
+		require.Equal(t, "downloads/data.bin", path)
+
+		got, err := os.ReadFile("downloads/data.bin")
+		require.NoError(t, err)
+		require.Equal(t, content, got)
+		require.Equal(t, []int64{1000, 1000 + 64*1024}, download.offsets)
+		require.NoFileExists(t, "data/gophkeeper-1.part")
+	})
+	t.Run("stale_part", func(t *testing.T) {
+		t.Chdir(t.TempDir())
+		require.NoError(t, os.Mkdir("data", 0700))
+		require.NoError(t, os.WriteFile("data/gophkeeper-1.part", []byte("garbage"), 0600))
+
+		download := &fakeDownload{content: content, digest: hash[:]}
+		srv := newBinaryService(t, download.client(), cipher, "")
+
+		path, err := srv.Download(t.Context(), 1)
+		require.NoError(t, err)
+		require.Equal(t, "downloads/data.bin", path)
+
+		got, err := os.ReadFile("downloads/data.bin")
+		require.NoError(t, err)
+		require.Equal(t, content, got)
+		require.Equal(t, []int64{7, 0}, download.offsets)
+	})
+	t.Run("checksum_mismatch", func(t *testing.T) {
+		t.Chdir(t.TempDir())
+
+		download := &fakeDownload{content: content, digest: make([]byte, 32)}
+		srv := newBinaryService(t, download.client(), cipher, "")
+
+		_, err := srv.Download(t.Context(), 1)
+		require.Error(t, err)
+		require.NoFileExists(t, "downloads/data.bin")
+		require.Len(t, download.offsets, uploadAttempts)
+	})
+	t.Run("zstd", func(t *testing.T) {
+		t.Chdir(t.TempDir())
+
+		zw, err := zstd.NewWriter(nil)
+		require.NoError(t, err)
+		compressed := zw.EncodeAll(content, nil)
+		require.NoError(t, zw.Close())
+		hash := sha256.Sum256(compressed)
+
+		download := &fakeDownload{
+			content:     compressed,
+			digest:      hash[:],
+			compression: gophkeeperv1.Compression_COMPRESSION_ZSTD,
+		}
+		srv := newBinaryService(t, download.client(), cipher, "")
+
+		path, err := srv.Download(t.Context(), 1)
+		require.NoError(t, err)
+		require.Equal(t, "downloads/data.bin", path)
+
+		got, err := os.ReadFile("downloads/data.bin")
+		require.NoError(t, err)
+		require.Equal(t, content, got)
+	})
+}
+
+// newBinaryService создает сервис со сжатием compression, которое
+// поддерживает сервер.
+func newBinaryService(t *testing.T, binaryClient gophkeeperv1.BinaryServiceClient, cipher client.Cipher, compression string) *BinaryService {
+	t.Helper()
+
+	info := &mock.InfoServiceClientMock{
+		ServerInfoFunc: func(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*gophkeeperv1.ServerInfoResponse, error) {
+			var out gophkeeperv1.ServerInfoResponse
+			out.SetCompressions([]gophkeeperv1.Compression{
+				gophkeeperv1.Compression_COMPRESSION_NONE,
+				gophkeeperv1.Compression_COMPRESSION_ZSTD,
+			})
+			return &out, nil
+		},
+	}
+
+	config := &client.Config{}
+	config.Binary.Compression = compression
+	config.Binary.DownloadDir = "downloads"
+	config.Cache.Path = "data/vault.db"
+	srv, err := NewBinaryService(binaryClient, info, cipher, config)
+	require.NoError(t, err)
+	srv.retryDelay = 0
+	return srv
+}
+
+// fakeDownload - сервер скачивания, который обрывает первый поток после
+// failAfter частей.
+type fakeDownload struct {
+	content     []byte
+	digest      []byte
+	compression gophkeeperv1.Compression
+	failAfter   int
+
+	offsets []int64
+}
+
+func (d *fakeDownload) client() *mock.BinaryServiceClientMock {
+	return &mock.BinaryServiceClientMock{
+		DownloadFunc: func(ctx context.Context, in *gophkeeperv1.DownloadBinaryRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[gophkeeperv1.DownloadBinaryResponse], error) {
+			d.offsets = append(d.offsets, in.GetOffset())
+			if in.GetOffset() > int64(len(d.content)) {
+				return nil, status.Error(codes.OutOfRange, "offset exceeds content size")
+			}
+
+			var responses []*gophkeeperv1.DownloadBinaryResponse
+			for offset := in.GetOffset(); offset == in.GetOffset() || offset < int64(len(d.content)); offset += 64 * 1024 {
+				var chunk gophkeeperv1.FileChunk
+				chunk.SetData(d.content[offset:min(offset+64*1024, int64(len(d.content)))])
+
+				var out gophkeeperv1.DownloadBinaryResponse
+				out.SetChunk(&chunk)
+				if len(responses) == 0 {
+					out.SetFilename("data.bin")
+					out.SetContentSize(int64(len(d.content)))
+					out.SetSha256(d.digest)
+					out.SetCompression(d.compression)
+				}
+				responses = append(responses, &out)
+			}
+
+			stream := &fakeDownloadStream{responses: responses}
+			if len(d.offsets) == 1 {
+				stream.failAfter = d.failAfter
+			}
+			return stream, nil
+		},
+	}
+}
+
+type fakeDownloadStream struct {
+	grpc.ClientStream
+	responses []*gophkeeperv1.DownloadBinaryResponse
+	failAfter int
+	received  int
+}
+
+func (s *fakeDownloadStream) Recv() (*gophkeeperv1.DownloadBinaryResponse, error) {
+	if s.failAfter > 0 && s.received == s.failAfter {
+		return nil, status.Error(codes.Unavailable, "connection lost")
+	}
+	if s.received == len(s.responses) {
+		return nil, io.EOF
+	}
+	s.received++
+	return s.responses[s.received-1], nil
+}
+
+// fakeUpload - сервер загрузки, который обрывает первый поток после
+// failAfter частей.
+type fakeUpload struct {
+	failAfter int
+	failCode  codes.Code
+
+	request   *gophkeeperv1.CreateUploadRequest
+	stored    []byte
+	streams   int
+	completed bool
+}
+
+func (u *fakeUpload) client(t *testing.T) *mock.BinaryServiceClientMock {
+	newStatus := func() *gophkeeperv1.UploadStatus {
+		var out gophkeeperv1.UploadStatus
+		out.SetUploadId("upload-1")
+		out.SetOffset(int64(len(u.stored)))
+		return &out
+	}
+
+	return &mock.BinaryServiceClientMock{
+		CreateUploadFunc: func(ctx context.Context, in *gophkeeperv1.CreateUploadRequest, opts ...grpc.CallOption) (*gophkeeperv1.UploadStatus, error) {
+			u.request = in
+			return newStatus(), nil
+		},
+		UploadChunksFunc: func(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[gophkeeperv1.UploadChunk, gophkeeperv1.UploadStatus], error) {
+			u.streams++
+			stream := &fakeUploadStream{upload: u, newStatus: newStatus}
+			if u.streams == 1 {
+				stream.failAfter = u.failAfter
+			}
+			return stream, nil
+		},
+		GetUploadFunc: func(ctx context.Context, in *gophkeeperv1.UploadRequest, opts ...grpc.CallOption) (*gophkeeperv1.UploadStatus, error) {
+			return newStatus(), nil
+		},
+		CompleteUploadFunc: func(ctx context.Context, in *gophkeeperv1.UploadRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+			hash := sha256.Sum256(u.stored)
+			require.Equal(t, hash[:], u.request.GetSha256())
+			require.EqualValues(t, len(u.stored), u.request.GetContentSize())
+			u.completed = true
+			return &empty.Empty{}, nil
+		},
+	}
+}
+
+type fakeUploadStream struct {
+	grpc.ClientStream
+	upload    *fakeUpload
+	newStatus func() *gophkeeperv1.UploadStatus
+	failAfter int
+	sent      int
+}
+
+func (s *fakeUploadStream) Send(chunk *gophkeeperv1.UploadChunk) error {
+	if s.failAfter > 0 && s.sent == s.failAfter {
+		return io.EOF
+	}
+	if chunk.GetOffset() != int64(len(s.upload.stored)) {
+		return io.EOF
+	}
+	s.upload.stored = append(s.upload.stored, chunk.GetData()...)
+	s.sent++
+	return nil
+}
+
+func (s *fakeUploadStream) CloseAndRecv() (*gophkeeperv1.UploadStatus, error) {
+	if s.failAfter > 0 && s.sent == s.failAfter {
+		code := s.upload.failCode
+		if code == codes.OK {
+			code = codes.Unavailable
+		}
+		return nil, status.Error(code, "connection lost")
+	}
+	return s.newStatus(), nil
+}
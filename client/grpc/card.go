@@ -51,6 +51,7 @@ func (s *CardService) GetAll(ctx context.Context) ([]client.CardData, error) {
 			CVV:        data.GetCvv(),
 			Cardholder: data.GetCardholder(),
 			Notes:      data.GetNotes(),
+			Version:    data.GetVersion(),
 		})
 	}
 	return cards, nil
@@ -59,6 +60,7 @@ func (s *CardService) GetAll(ctx context.Context) ([]client.CardData, error) {
 func (s *CardService) Update(ctx context.Context, data client.CardDataUpdate) error {
 	var in gophkeeperv1.Card
 	in.SetId(data.ID)
+	in.SetVersion(data.Version)
 	if data.Name != nil {
 		in.SetName(*data.Name)
 	}
@@ -79,13 +81,14 @@ func (s *CardService) Update(ctx context.Context, data client.CardDataUpdate) er
 	}
 
 	_, err := s.client.Update(ctx, &in)
-	return err
+	return unwrapError(err)
 }
 
-func (s *CardService) Remove(ctx context.Context, id int64) error {
+func (s *CardService) Remove(ctx context.Context, id int64, version int64) error {
 	var in gophkeeperv1.RemoveDataRequest
 	in.SetId(id)
+	in.SetVersion(version)
 
 	_, err := s.client.Remove(ctx, &in)
-	return err
+	return unwrapError(err)
 }
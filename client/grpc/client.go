@@ -5,7 +5,7 @@ import (
 	"github.com/mkolibaba/gophkeeper/client/grpc/interceptors"
 	"go.uber.org/fx"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/credentials"
 )
 
 type ConnectionParams struct {
@@ -13,14 +13,17 @@ type ConnectionParams struct {
 
 	Config      *client.Config
 	UserService client.UserService
+	Credentials credentials.TransportCredentials
 }
 
 func NewConnection(p ConnectionParams) (*grpc.ClientConn, error) {
+	auth := interceptors.NewAuth(p.UserService, p.Config.GRPC.TLS.Enabled)
+
 	conn, err := grpc.NewClient(
 		p.Config.GRPC.ServerAddress,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-		grpc.WithUnaryInterceptor(interceptors.UnaryAuth(p.UserService)),
-		grpc.WithStreamInterceptor(interceptors.StreamAuth(p.UserService)),
+		grpc.WithTransportCredentials(p.Credentials),
+		grpc.WithUnaryInterceptor(auth.Unary),
+		grpc.WithStreamInterceptor(auth.Stream),
 	)
 	return conn, err
 }
@@ -0,0 +1,100 @@
+package grpc
+
+import (
+	"fmt"
+	"github.com/klauspost/compress/zstd"
+	"github.com/mkolibaba/gophkeeper/proto/gen/go/gophkeeperv1"
+	"io"
+	"sync"
+)
+
+// parseCompression возвращает способ сжатия по его имени в конфигурации.
+func parseCompression(name string) (gophkeeperv1.Compression, error) {
+	switch name {
+	case "", "none":
+		return gophkeeperv1.Compression_COMPRESSION_NONE, nil
+	case "zstd":
+		return gophkeeperv1.Compression_COMPRESSION_ZSTD, nil
+	default:
+		return 0, fmt.Errorf("unknown compression %q", name)
+	}
+}
+
+// compress возвращает поток содержимого r, сжатого способом compression.
+// Поток нужно закрыть, даже если он не дочитан.
+func compress(r io.Reader, compression gophkeeperv1.Compression) (io.ReadCloser, error) {
+	switch compression {
+	case gophkeeperv1.Compression_COMPRESSION_NONE:
+		return io.NopCloser(r), nil
+	case gophkeeperv1.Compression_COMPRESSION_ZSTD:
+	default:
+		return nil, fmt.Errorf("unsupported compression %v", compression)
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		zw, err := zstd.NewWriter(pw)
+		if err != nil {
+			pw.CloseWithError(err)
+			return
+		}
+		if _, err := io.Copy(zw, r); err != nil {
+			zw.Close()
+			pw.CloseWithError(err)
+			return
+		}
+		pw.CloseWithError(zw.Close())
+	}()
+	return pr, nil
+}
+
+// decompress возвращает writer, который распаковывает записанное
+// содержимое в w. Close дожидается записи всего содержимого.
+func decompress(w io.Writer, compression gophkeeperv1.Compression) (io.WriteCloser, error) {
+	switch compression {
+	case gophkeeperv1.Compression_COMPRESSION_NONE:
+		return nopWriteCloser{w}, nil
+	case gophkeeperv1.Compression_COMPRESSION_ZSTD:
+	default:
+		return nil, fmt.Errorf("unsupported compression %v", compression)
+	}
+
+	pr, pw := io.Pipe()
+	d := &decompressor{pw: pw, done: make(chan error, 1)}
+	go func() {
+		zr, err := zstd.NewReader(pr)
+		if err == nil {
+			_, err = io.Copy(w, zr)
+			zr.Close()
+		}
+		// Если распаковка прервалась, запись в pw вернет ошибку.
+		pr.CloseWithError(err)
+		d.done <- err
+	}()
+	return d, nil
+}
+
+type decompressor struct {
+	pw   *io.PipeWriter
+	done chan error
+	err  error
+	once sync.Once
+}
+
+func (d *decompressor) Write(p []byte) (int, error) {
+	return d.pw.Write(p)
+}
+
+func (d *decompressor) Close() error {
+	d.once.Do(func() {
+		d.pw.Close()
+		d.err = <-d.done
+	})
+	return d.err
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error { return nil }
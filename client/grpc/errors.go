@@ -0,0 +1,24 @@
+package grpc
+
+import (
+	"github.com/mkolibaba/gophkeeper/client"
+	"github.com/mkolibaba/gophkeeper/proto/gen/go/gophkeeperv1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// unwrapError преобразует ошибку конфликта версий, пришедшую с сервера,
+// в *client.VersionConflictError. Остальные ошибки возвращаются как есть.
+func unwrapError(err error) error {
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.Aborted {
+		return err
+	}
+
+	for _, detail := range st.Details() {
+		if conflict, ok := detail.(*gophkeeperv1.VersionConflict); ok {
+			return &client.VersionConflictError{Version: conflict.GetVersion()}
+		}
+	}
+	return err
+}
@@ -0,0 +1,158 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"github.com/mkolibaba/gophkeeper/client"
+	"github.com/mkolibaba/gophkeeper/proto/gen/go/gophkeeperv1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func NewHistoryServiceClient(conn *grpc.ClientConn) gophkeeperv1.HistoryServiceClient {
+	return gophkeeperv1.NewHistoryServiceClient(conn)
+}
+
+// HistoryService передает версии как есть: секретные поля в них
+// зашифрованы.
+type HistoryService struct {
+	client gophkeeperv1.HistoryServiceClient
+}
+
+func NewHistoryService(client gophkeeperv1.HistoryServiceClient) *HistoryService {
+	return &HistoryService{
+		client: client,
+	}
+}
+
+func (s *HistoryService) List(ctx context.Context, kind client.DataKind, id int64) ([]client.HistoryEntry, error) {
+	k, ok := dataKinds[kind]
+	if !ok {
+		return nil, fmt.Errorf("unknown data kind %q", kind)
+	}
+
+	var in gophkeeperv1.ListHistoryRequest
+	in.SetKind(k)
+	in.SetId(id)
+
+	result, err := s.client.ListHistory(ctx, &in)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []client.HistoryEntry
+	for _, version := range result.GetVersions() {
+		data, err := newHistoryData(version)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, client.HistoryEntry{
+			Version:   version.GetVersion(),
+			Removed:   version.GetRemoved(),
+			CreatedAt: version.GetCreatedAt().AsTime(),
+			Data:      data,
+		})
+	}
+	return entries, nil
+}
+
+func (s *HistoryService) Restore(ctx context.Context, kind client.DataKind, id int64, version int64) error {
+	k, ok := dataKinds[kind]
+	if !ok {
+		return fmt.Errorf("unknown data kind %q", kind)
+	}
+
+	var in gophkeeperv1.RestoreVersionRequest
+	in.SetKind(k)
+	in.SetId(id)
+	in.SetVersion(version)
+
+	_, err := s.client.RestoreVersion(ctx, &in)
+	switch status.Code(err) {
+	case codes.NotFound:
+		return client.ErrVersionNotFound
+	case codes.FailedPrecondition:
+		return client.ErrContentRemoved
+	}
+	return unwrapError(err)
+}
+
+func newHistoryData(version *gophkeeperv1.HistoryVersion) (client.Data, error) {
+	data, ok := newItemData(version)
+	if !ok {
+		return nil, fmt.Errorf("empty history version %d", version.GetVersion())
+	}
+	return data, nil
+}
+
+// itemMessage - сообщение с данными записи одного из видов.
+type itemMessage interface {
+	GetLogin() *gophkeeperv1.Login
+	GetNote() *gophkeeperv1.Note
+	GetBinary() *gophkeeperv1.Binary
+	GetCard() *gophkeeperv1.Card
+	GetOtp() *gophkeeperv1.OTP
+	HasLogin() bool
+	HasNote() bool
+	HasBinary() bool
+	HasCard() bool
+	HasOtp() bool
+}
+
+// newItemData возвращает данные записи из сообщения или false, если
+// данных в сообщении нет.
+func newItemData(msg itemMessage) (client.Data, bool) {
+	switch {
+	case msg.HasLogin():
+		data := msg.GetLogin()
+		return client.LoginData{
+			ID:       data.GetId(),
+			Name:     data.GetName(),
+			Login:    data.GetLogin(),
+			Password: data.GetPassword(),
+			Website:  data.GetWebsite(),
+			Notes:    data.GetNotes(),
+			Version:  data.GetVersion(),
+		}, true
+
+	case msg.HasNote():
+		data := msg.GetNote()
+		return client.NoteData{
+			ID:      data.GetId(),
+			Name:    data.GetName(),
+			Text:    data.GetText(),
+			Version: data.GetVersion(),
+		}, true
+
+	case msg.HasBinary():
+		data := msg.GetBinary()
+		return client.BinaryData{
+			ID:       data.GetId(),
+			Name:     data.GetName(),
+			Filename: data.GetFilename(),
+			Size:     data.GetSize(),
+			Notes:    data.GetNotes(),
+			Version:  data.GetVersion(),
+		}, true
+
+	case msg.HasCard():
+		data := msg.GetCard()
+		return client.CardData{
+			ID:         data.GetId(),
+			Name:       data.GetName(),
+			Number:     data.GetNumber(),
+			ExpDate:    data.GetExpDate(),
+			CVV:        data.GetCvv(),
+			Cardholder: data.GetCardholder(),
+			Notes:      data.GetNotes(),
+			Version:    data.GetVersion(),
+		}, true
+
+	case msg.HasOtp():
+		return newOTPData(msg.GetOtp()), true
+
+	default:
+		return nil, false
+	}
+}
@@ -0,0 +1,95 @@
+package grpc
+
+import (
+	"context"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/mkolibaba/gophkeeper/client"
+	"github.com/mkolibaba/gophkeeper/client/grpc/mock"
+	"github.com/mkolibaba/gophkeeper/proto/gen/go/gophkeeperv1"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"testing"
+	"time"
+)
+
+func TestHistoryList(t *testing.T) {
+	createdAt := time.Unix(1700000000, 0).UTC()
+	clientMock := &mock.HistoryServiceClientMock{
+		ListHistoryFunc: func(ctx context.Context, in *gophkeeperv1.ListHistoryRequest, opts ...grpc.CallOption) (*gophkeeperv1.ListHistoryResponse, error) {
+			var note gophkeeperv1.Note
+			note.SetId(1)
+			note.SetName("note")
+			note.SetText("text")
+			note.SetVersion(4)
+
+			var version gophkeeperv1.HistoryVersion
+			version.SetVersion(4)
+			version.SetRemoved(true)
+			version.SetCreatedAt(timestamppb.New(createdAt))
+			version.SetNote(&note)
+
+			var out gophkeeperv1.ListHistoryResponse
+			out.SetVersions([]*gophkeeperv1.HistoryVersion{&version})
+			return &out, nil
+		},
+	}
+	srv := NewHistoryService(clientMock)
+
+	entries, err := srv.List(t.Context(), client.DataKindNote, 1)
+	require.NoError(t, err)
+	require.Equal(t, []client.HistoryEntry{
+		{
+			Version:   4,
+			Removed:   true,
+			CreatedAt: createdAt,
+			Data:      client.NoteData{ID: 1, Name: "note", Text: "text", Version: 4},
+		},
+	}, entries)
+
+	in := clientMock.ListHistoryCalls()[0].In
+	require.Equal(t, gophkeeperv1.DataKind_DATA_KIND_NOTE, in.GetKind())
+	require.Equal(t, int64(1), in.GetId())
+}
+
+func TestHistoryRestore(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		clientMock := &mock.HistoryServiceClientMock{
+			RestoreVersionFunc: func(ctx context.Context, in *gophkeeperv1.RestoreVersionRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+				return &empty.Empty{}, nil
+			},
+		}
+		srv := NewHistoryService(clientMock)
+
+		require.NoError(t, srv.Restore(t.Context(), client.DataKindCard, 2, 3))
+
+		in := clientMock.RestoreVersionCalls()[0].In
+		require.Equal(t, gophkeeperv1.DataKind_DATA_KIND_CARD, in.GetKind())
+		require.Equal(t, int64(2), in.GetId())
+		require.Equal(t, int64(3), in.GetVersion())
+	})
+
+	tests := []struct {
+		name string
+		code codes.Code
+		want error
+	}{
+		{name: "version_not_found", code: codes.NotFound, want: client.ErrVersionNotFound},
+		{name: "content_removed", code: codes.FailedPrecondition, want: client.ErrContentRemoved},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientMock := &mock.HistoryServiceClientMock{
+				RestoreVersionFunc: func(ctx context.Context, in *gophkeeperv1.RestoreVersionRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+					return nil, status.Error(tt.code, "error")
+				},
+			}
+			srv := NewHistoryService(clientMock)
+
+			err := srv.Restore(t.Context(), client.DataKindBinary, 2, 3)
+			require.ErrorIs(t, err, tt.want)
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package grpc
+
+import (
+	"context"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/mkolibaba/gophkeeper/client"
+	"github.com/mkolibaba/gophkeeper/proto/gen/go/gophkeeperv1"
+	"google.golang.org/grpc"
+)
+
+func NewInfoServiceClient(conn *grpc.ClientConn) gophkeeperv1.InfoServiceClient {
+	return gophkeeperv1.NewInfoServiceClient(conn)
+}
+
+type InfoService struct {
+	client gophkeeperv1.InfoServiceClient
+}
+
+func NewInfoService(client gophkeeperv1.InfoServiceClient) *InfoService {
+	return &InfoService{
+		client: client,
+	}
+}
+
+func (s *InfoService) ServerInfo(ctx context.Context) (client.ServerInfo, error) {
+	out, err := s.client.ServerInfo(ctx, &empty.Empty{})
+	if err != nil {
+		return client.ServerInfo{}, err
+	}
+
+	return client.ServerInfo{
+		Build: client.BuildInfo{
+			Version: out.GetVersion(),
+			Commit:  out.GetCommit(),
+			Date:    out.GetBuildDate(),
+		},
+		ProtocolVersion: out.GetProtocolVersion(),
+	}, nil
+}
+
+func (s *InfoService) Usage(ctx context.Context) (client.Usage, error) {
+	out, err := s.client.GetUsage(ctx, &empty.Empty{})
+	if err != nil {
+		return client.Usage{}, err
+	}
+
+	return client.Usage{
+		Bytes:       out.GetBytes(),
+		Items:       out.GetItems(),
+		QuotaBytes:  out.GetQuotaBytes(),
+		QuotaItems:  out.GetQuotaItems(),
+		MaxFileSize: out.GetMaxFileSize(),
+	}, nil
+}
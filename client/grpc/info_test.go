@@ -0,0 +1,60 @@
+package grpc
+
+import (
+	"context"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/mkolibaba/gophkeeper/client"
+	"github.com/mkolibaba/gophkeeper/client/grpc/mock"
+	"github.com/mkolibaba/gophkeeper/proto/gen/go/gophkeeperv1"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"testing"
+)
+
+func TestServerInfo(t *testing.T) {
+	clientMock := &mock.InfoServiceClientMock{
+		ServerInfoFunc: func(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*gophkeeperv1.ServerInfoResponse, error) {
+			var out gophkeeperv1.ServerInfoResponse
+			out.SetVersion("v1.2.0")
+			out.SetCommit("abc123")
+			out.SetBuildDate("2025-10-01T00:00:00Z")
+			out.SetProtocolVersion(client.ProtocolVersion + 1)
+			return &out, nil
+		},
+	}
+	srv := NewInfoService(clientMock)
+
+	info, err := srv.ServerInfo(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, client.BuildInfo{
+		Version: "v1.2.0",
+		Commit:  "abc123",
+		Date:    "2025-10-01T00:00:00Z",
+	}, info.Build)
+	require.False(t, info.Compatible())
+}
+
+func TestUsage(t *testing.T) {
+	clientMock := &mock.InfoServiceClientMock{
+		GetUsageFunc: func(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*gophkeeperv1.UsageResponse, error) {
+			var out gophkeeperv1.UsageResponse
+			out.SetBytes(1024)
+			out.SetItems(3)
+			out.SetQuotaBytes(4096)
+			out.SetQuotaItems(10)
+			out.SetMaxFileSize(2048)
+			return &out, nil
+		},
+	}
+	srv := NewInfoService(clientMock)
+
+	usage, err := srv.Usage(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, client.Usage{
+		Bytes:       1024,
+		Items:       3,
+		QuotaBytes:  4096,
+		QuotaItems:  10,
+		MaxFileSize: 2048,
+	}, usage)
+}
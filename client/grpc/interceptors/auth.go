@@ -2,74 +2,185 @@ package interceptors
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"github.com/mkolibaba/gophkeeper/client"
 	"github.com/mkolibaba/gophkeeper/proto/gen/go/gophkeeperv1"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"slices"
+	"strings"
+	"sync"
+	"time"
 )
 
+// refreshBefore - за сколько до истечения access-токена он обновляется
+// заранее, чтобы запрос не ушел с уже истекшим токеном.
+const refreshBefore = 30 * time.Second
+
 var skip = []string{
 	gophkeeperv1.AuthorizationService_Authorize_FullMethodName,
 	gophkeeperv1.AuthorizationService_Register_FullMethodName,
+	gophkeeperv1.AuthorizationService_Enroll_FullMethodName,
+	gophkeeperv1.AuthorizationService_Refresh_FullMethodName,
+	gophkeeperv1.AuthorizationService_VerifyMFA_FullMethodName,
+	gophkeeperv1.InfoService_ServerInfo_FullMethodName,
 }
 
-func UnaryAuth(userService client.UserService) grpc.UnaryClientInterceptor {
-	return func(
-		ctx context.Context,
-		method string,
-		req, reply any,
-		cc *grpc.ClientConn,
-		invoker grpc.UnaryInvoker,
-		opts ...grpc.CallOption,
-	) error {
-		if slices.Contains(skip, method) {
-			return invoker(ctx, method, req, reply, cc, opts...)
-		}
-
-		baOpt, err := newBearerAccessOption(userService)
-		if err != nil {
-			return fmt.Errorf("auth interceptor: %w", err)
-		}
-		opts = append(opts, baOpt)
+// Auth добавляет bearer-токен пользователя к запросам и обновляет
+// истекающий токен по refresh-токену. Если secure равен true, токен
+// передается только по защищенному соединению.
+type Auth struct {
+	userService client.UserService
+	secure      bool
+	now         func() time.Time
 
-		return invoker(ctx, method, req, reply, cc, opts...)
+	// mu не дает обновлять токены одновременно: сервер считает повторное
+	// использование refresh-токена кражей и завершает сессию.
+	mu sync.Mutex
+}
+
+func NewAuth(userService client.UserService, secure bool) *Auth {
+	return &Auth{
+		userService: userService,
+		secure:      secure,
+		now:         time.Now,
 	}
 }
 
-func StreamAuth(userService client.UserService) grpc.StreamClientInterceptor {
-	return func(
-		ctx context.Context,
-		desc *grpc.StreamDesc,
-		cc *grpc.ClientConn,
-		method string,
-		streamer grpc.Streamer,
-		opts ...grpc.CallOption,
-	) (grpc.ClientStream, error) {
-		if slices.Contains(skip, method) {
-			return streamer(ctx, desc, cc, method, opts...)
-		}
-
-		baOpt, err := newBearerAccessOption(userService)
-		if err != nil {
-			return nil, fmt.Errorf("auth interceptor: %w", err)
-		}
-		opts = append(opts, baOpt)
+// Unary - интерсептор для унарных запросов. Если сервер отклонил токен,
+// токен обновляется и запрос повторяется один раз.
+func (a *Auth) Unary(
+	ctx context.Context,
+	method string,
+	req, reply any,
+	cc *grpc.ClientConn,
+	invoker grpc.UnaryInvoker,
+	opts ...grpc.CallOption,
+) error {
+	if slices.Contains(skip, method) {
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+
+	token, err := a.token(ctx, cc)
+	if err != nil {
+		return fmt.Errorf("auth interceptor: %w", err)
+	}
+
+	err = invoker(ctx, method, req, reply, cc, append(opts, a.bearerAccessOption(token))...)
+	if status.Code(err) != codes.Unauthenticated || a.userService.GetRefreshToken() == "" {
+		return err
+	}
+
+	if refreshErr := a.refresh(ctx, cc, token); refreshErr != nil {
+		return err
+	}
 
+	return invoker(ctx, method, req, reply, cc, append(opts, a.bearerAccessOption(a.userService.GetBearerToken()))...)
+}
+
+// Stream - аналог Unary для потоковых запросов. Поток нельзя повторить,
+// поэтому токен только обновляется заранее.
+func (a *Auth) Stream(
+	ctx context.Context,
+	desc *grpc.StreamDesc,
+	cc *grpc.ClientConn,
+	method string,
+	streamer grpc.Streamer,
+	opts ...grpc.CallOption,
+) (grpc.ClientStream, error) {
+	if slices.Contains(skip, method) {
 		return streamer(ctx, desc, cc, method, opts...)
 	}
+
+	token, err := a.token(ctx, cc)
+	if err != nil {
+		return nil, fmt.Errorf("auth interceptor: %w", err)
+	}
+
+	return streamer(ctx, desc, cc, method, append(opts, a.bearerAccessOption(token))...)
 }
 
-func newBearerAccessOption(userService client.UserService) (grpc.CallOption, error) {
-	token := userService.GetBearerToken()
+// token возвращает access-токен пользователя, предварительно обновив его,
+// если он скоро истечет.
+func (a *Auth) token(ctx context.Context, cc grpc.ClientConnInterface) (string, error) {
+	token := a.userService.GetBearerToken()
 	if token == "" {
-		return nil, fmt.Errorf("bearer token not found in session")
+		return "", fmt.Errorf("bearer token not found in session")
+	}
+
+	expiresAt, ok := tokenExpiration(token)
+	if !ok || a.now().Add(refreshBefore).Before(expiresAt) || a.userService.GetRefreshToken() == "" {
+		return token, nil
+	}
+
+	// Если обновить токен не удалось, отправляем запрос со старым:
+	// сервер сам решит, действителен ли он.
+	if err := a.refresh(ctx, cc, token); err != nil {
+		return token, nil
+	}
+	return a.userService.GetBearerToken(), nil
+}
+
+// refresh обменивает refresh-токен на новую пару токенов. expired - access-токен,
+// который требуется заменить: если он уже заменен другим запросом, повторно
+// токены не обновляются.
+func (a *Auth) refresh(ctx context.Context, cc grpc.ClientConnInterface, expired string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.userService.GetBearerToken() != expired {
+		return nil
+	}
+
+	var in gophkeeperv1.RefreshRequest
+	in.SetRefreshToken(a.userService.GetRefreshToken())
+
+	var out gophkeeperv1.TokenResponse
+	err := cc.Invoke(ctx, gophkeeperv1.AuthorizationService_Refresh_FullMethodName, &in, &out)
+	if err != nil {
+		return fmt.Errorf("refresh token: %w", err)
+	}
+
+	a.userService.SetTokens(client.Tokens{
+		AccessToken:  out.GetToken(),
+		RefreshToken: out.GetRefreshToken(),
+	})
+	return nil
+}
+
+func (a *Auth) bearerAccessOption(token string) grpc.CallOption {
+	return grpc.PerRPCCredentials(bearerAccess{token: token, secure: a.secure})
+}
+
+// tokenExpiration возвращает время истечения JWT. Подпись не проверяется:
+// время нужно только чтобы вовремя обновить токен.
+func tokenExpiration(token string) (time.Time, bool) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}, false
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	var claims struct {
+		ExpiresAt int64 `json:"exp"`
 	}
-	return grpc.PerRPCCredentials(bearerAccess{token}), nil
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.ExpiresAt == 0 {
+		return time.Time{}, false
+	}
+
+	return time.Unix(claims.ExpiresAt, 0), true
 }
 
 type bearerAccess struct {
-	token string
+	token  string
+	secure bool
 }
 
 func (b bearerAccess) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
@@ -79,5 +190,5 @@ func (b bearerAccess) GetRequestMetadata(ctx context.Context, uri ...string) (ma
 }
 
 func (b bearerAccess) RequireTransportSecurity() bool {
-	return false
+	return b.secure
 }
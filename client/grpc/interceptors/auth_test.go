@@ -2,11 +2,22 @@ package interceptors
 
 import (
 	"context"
+	"encoding/base64"
+	"fmt"
+	"github.com/charmbracelet/log"
+	"github.com/mkolibaba/gophkeeper/client"
+	"github.com/mkolibaba/gophkeeper/client/inmem"
 	"github.com/mkolibaba/gophkeeper/client/mock"
+	"github.com/mkolibaba/gophkeeper/proto/gen/go/gophkeeperv1"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/interop/grpc_testing"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"io"
 	"testing"
+	"time"
 )
 
 func TestAuthUnary(t *testing.T) {
@@ -20,8 +31,13 @@ func TestAuthUnary(t *testing.T) {
 	}
 	stubServer.startServer()
 
-	userServiceMock := &mock.UserServiceMock{}
-	if err := stubServer.startClient(grpc.WithUnaryInterceptor(UnaryAuth(userServiceMock))); err != nil {
+	userServiceMock := &mock.UserServiceMock{
+		GetRefreshTokenFunc: func() string {
+			return ""
+		},
+	}
+	auth := NewAuth(userServiceMock, false)
+	if err := stubServer.startClient(grpc.WithUnaryInterceptor(auth.Unary)); err != nil {
 		t.Fatal(err)
 	}
 
@@ -56,7 +72,8 @@ func TestAuthStream(t *testing.T) {
 			return "cool token"
 		},
 	}
-	if err := stubServer.startClient(grpc.WithStreamInterceptor(StreamAuth(userServiceMock))); err != nil {
+	auth := NewAuth(userServiceMock, false)
+	if err := stubServer.startClient(grpc.WithStreamInterceptor(auth.Stream)); err != nil {
 		t.Fatal(err)
 	}
 
@@ -65,3 +82,121 @@ func TestAuthStream(t *testing.T) {
 	_, err := stubServer.client.StreamingOutputCall(t.Context(), &grpc_testing.StreamingOutputCallRequest{})
 	require.NoError(t, err)
 }
+
+func TestAuthRequireTransportSecurity(t *testing.T) {
+	stubServer := newStubServer()
+	stubServer.startServer()
+
+	userServiceMock := &mock.UserServiceMock{
+		GetBearerTokenFunc: func() string {
+			return "cool token"
+		},
+	}
+	auth := NewAuth(userServiceMock, true)
+	if err := stubServer.startClient(grpc.WithUnaryInterceptor(auth.Unary)); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(stubServer.stop)
+
+	// Токен не должен уходить по незащищенному соединению.
+	_, err := stubServer.client.UnaryCall(t.Context(), &grpc_testing.SimpleRequest{})
+	require.Error(t, err)
+}
+
+func TestAuthRefresh(t *testing.T) {
+	authorization := &refreshServer{}
+
+	stubServer := newStubServer()
+	stubServer.authorization = authorization
+	stubServer.UnaryCallF = func(ctx context.Context, request *grpc_testing.SimpleRequest) (*grpc_testing.SimpleResponse, error) {
+		token := metadata.ValueFromIncomingContext(ctx, "authorization")
+		if len(token) == 0 || token[0] != "Bearer "+authorization.current() {
+			return nil, status.Error(codes.Unauthenticated, "token is expired")
+		}
+		return &grpc_testing.SimpleResponse{}, nil
+	}
+	stubServer.startServer()
+
+	userService := inmem.NewUserService(log.New(io.Discard))
+	auth := NewAuth(userService, false)
+	if err := stubServer.startClient(
+		grpc.WithUnaryInterceptor(auth.Unary),
+		grpc.WithStreamInterceptor(auth.Stream),
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(stubServer.stop)
+
+	t.Run("retry_unauthenticated", func(t *testing.T) {
+		userService.SetInfo("testuser", client.Tokens{AccessToken: "expired", RefreshToken: "refresh 0"})
+
+		_, err := stubServer.client.UnaryCall(t.Context(), &grpc_testing.SimpleRequest{})
+		require.NoError(t, err)
+
+		require.Equal(t, 1, authorization.refreshed)
+		require.Equal(t, authorization.current(), userService.GetBearerToken())
+		require.Equal(t, "refresh 1", userService.GetRefreshToken())
+	})
+	t.Run("expiring_token", func(t *testing.T) {
+		userService.SetTokens(client.Tokens{
+			AccessToken:  newTestJWT(time.Now().Add(10 * time.Second)),
+			RefreshToken: "refresh 1",
+		})
+
+		_, err := stubServer.client.StreamingOutputCall(t.Context(), &grpc_testing.StreamingOutputCallRequest{})
+		require.NoError(t, err)
+
+		require.Equal(t, 2, authorization.refreshed)
+		require.Equal(t, "refresh 2", userService.GetRefreshToken())
+	})
+	t.Run("valid_token", func(t *testing.T) {
+		userService.SetTokens(client.Tokens{
+			AccessToken:  newTestJWT(time.Now().Add(time.Hour)),
+			RefreshToken: "refresh 2",
+		})
+
+		_, err := stubServer.client.StreamingOutputCall(t.Context(), &grpc_testing.StreamingOutputCallRequest{})
+		require.NoError(t, err)
+
+		require.Equal(t, 2, authorization.refreshed)
+	})
+	t.Run("refresh_failed", func(t *testing.T) {
+		userService.SetTokens(client.Tokens{AccessToken: "expired", RefreshToken: "stolen"})
+
+		_, err := stubServer.client.UnaryCall(t.Context(), &grpc_testing.SimpleRequest{})
+		require.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+}
+
+// refreshServer выдает новую пару токенов в обмен на последний выданный
+// refresh-токен.
+type refreshServer struct {
+	gophkeeperv1.UnimplementedAuthorizationServiceServer
+	refreshed int
+}
+
+func (s *refreshServer) current() string {
+	return fmt.Sprintf("token %d", s.refreshed)
+}
+
+func (s *refreshServer) Refresh(
+	ctx context.Context,
+	in *gophkeeperv1.RefreshRequest,
+) (*gophkeeperv1.TokenResponse, error) {
+	if in.GetRefreshToken() != fmt.Sprintf("refresh %d", s.refreshed) {
+		return nil, status.Error(codes.Unauthenticated, "invalid token")
+	}
+	s.refreshed++
+
+	var out gophkeeperv1.TokenResponse
+	out.SetToken(s.current())
+	out.SetRefreshToken(fmt.Sprintf("refresh %d", s.refreshed))
+	return &out, nil
+}
+
+func newTestJWT(expiresAt time.Time) string {
+	payload := fmt.Sprintf(`{"sub":"testuser","exp":%d}`, expiresAt.Unix())
+	return "header." + base64.RawURLEncoding.EncodeToString([]byte(payload)) + ".signature"
+}
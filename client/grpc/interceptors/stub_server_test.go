@@ -3,6 +3,7 @@ package interceptors
 import (
 	"context"
 	"fmt"
+	"github.com/mkolibaba/gophkeeper/proto/gen/go/gophkeeperv1"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/interop/grpc_testing"
@@ -21,6 +22,9 @@ type stubServer struct {
 	client   grpc_testing.TestServiceClient
 
 	UnaryCallF func(context.Context, *grpc_testing.SimpleRequest) (*grpc_testing.SimpleResponse, error)
+
+	// authorization регистрируется на сервере, если задан.
+	authorization gophkeeperv1.AuthorizationServiceServer
 }
 
 func (s *stubServer) UnaryCall(ctx context.Context, in *grpc_testing.SimpleRequest) (*grpc_testing.SimpleResponse, error) {
@@ -60,6 +64,9 @@ func newStubServer() *stubServer {
 func (s *stubServer) startServer(opts ...grpc.ServerOption) {
 	srv := grpc.NewServer(opts...)
 	grpc_testing.RegisterTestServiceServer(srv, s)
+	if s.authorization != nil {
+		gophkeeperv1.RegisterAuthorizationServiceServer(srv, s.authorization)
+	}
 
 	go func() {
 		defer srv.Stop()
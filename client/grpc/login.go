@@ -49,6 +49,7 @@ func (s *LoginService) GetAll(ctx context.Context) ([]client.LoginData, error) {
 			Password: data.GetPassword(),
 			Website:  data.GetWebsite(),
 			Notes:    data.GetNotes(),
+			Version:  data.GetVersion(),
 		})
 	}
 
@@ -58,6 +59,7 @@ func (s *LoginService) GetAll(ctx context.Context) ([]client.LoginData, error) {
 func (s *LoginService) Update(ctx context.Context, data client.LoginDataUpdate) error {
 	var in gophkeeperv1.Login
 	in.SetId(data.ID)
+	in.SetVersion(data.Version)
 	if data.Name != nil {
 		in.SetName(*data.Name)
 	}
@@ -75,13 +77,14 @@ func (s *LoginService) Update(ctx context.Context, data client.LoginDataUpdate)
 	}
 
 	_, err := s.client.Update(ctx, &in)
-	return err
+	return unwrapError(err)
 }
 
-func (s *LoginService) Remove(ctx context.Context, id int64) error {
+func (s *LoginService) Remove(ctx context.Context, id int64, version int64) error {
 	var in gophkeeperv1.RemoveDataRequest
 	in.SetId(id)
+	in.SetVersion(version)
 
 	_, err := s.client.Remove(ctx, &in)
-	return err
+	return unwrapError(err)
 }
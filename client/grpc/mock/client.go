@@ -26,175 +26,2259 @@ var _ gophkeeperv1.AuthorizationServiceClient = &AuthorizationServiceClientMock{
 //			AuthorizeFunc: func(ctx context.Context, in *gophkeeperv1.UserCredentials, opts ...grpc.CallOption) (*gophkeeperv1.TokenResponse, error) {
 //				panic("mock out the Authorize method")
 //			},
+//			ChangePasswordFunc: func(ctx context.Context, in *gophkeeperv1.ChangePasswordRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+//				panic("mock out the ChangePassword method")
+//			},
+//			DeleteAccountFunc: func(ctx context.Context, in *gophkeeperv1.DeleteAccountRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+//				panic("mock out the DeleteAccount method")
+//			},
+//			DisableMFAFunc: func(ctx context.Context, in *gophkeeperv1.MFACode, opts ...grpc.CallOption) (*empty.Empty, error) {
+//				panic("mock out the DisableMFA method")
+//			},
+//			EnableMFAFunc: func(ctx context.Context, in *gophkeeperv1.MFACode, opts ...grpc.CallOption) (*gophkeeperv1.RecoveryCodesResponse, error) {
+//				panic("mock out the EnableMFA method")
+//			},
+//			EnrollFunc: func(ctx context.Context, in *gophkeeperv1.EnrollRequest, opts ...grpc.CallOption) (*gophkeeperv1.TokenResponse, error) {
+//				panic("mock out the Enroll method")
+//			},
+//			ListSessionsFunc: func(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*gophkeeperv1.ListSessionsResponse, error) {
+//				panic("mock out the ListSessions method")
+//			},
+//			LogoutFunc: func(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error) {
+//				panic("mock out the Logout method")
+//			},
+//			RefreshFunc: func(ctx context.Context, in *gophkeeperv1.RefreshRequest, opts ...grpc.CallOption) (*gophkeeperv1.TokenResponse, error) {
+//				panic("mock out the Refresh method")
+//			},
 //			RegisterFunc: func(ctx context.Context, in *gophkeeperv1.UserCredentials, opts ...grpc.CallOption) (*gophkeeperv1.TokenResponse, error) {
 //				panic("mock out the Register method")
 //			},
+//			RevokeSessionFunc: func(ctx context.Context, in *gophkeeperv1.RevokeSessionRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+//				panic("mock out the RevokeSession method")
+//			},
+//			SetupMFAFunc: func(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*gophkeeperv1.SetupMFAResponse, error) {
+//				panic("mock out the SetupMFA method")
+//			},
+//			VerifyMFAFunc: func(ctx context.Context, in *gophkeeperv1.VerifyMFARequest, opts ...grpc.CallOption) (*gophkeeperv1.TokenResponse, error) {
+//				panic("mock out the VerifyMFA method")
+//			},
 //		}
 //
 //		// use mockedAuthorizationServiceClient in code that requires gophkeeperv1.AuthorizationServiceClient
 //		// and then make assertions.
 //
-//	}
-type AuthorizationServiceClientMock struct {
-	// AuthorizeFunc mocks the Authorize method.
-	AuthorizeFunc func(ctx context.Context, in *gophkeeperv1.UserCredentials, opts ...grpc.CallOption) (*gophkeeperv1.TokenResponse, error)
+//	}
+type AuthorizationServiceClientMock struct {
+	// AuthorizeFunc mocks the Authorize method.
+	AuthorizeFunc func(ctx context.Context, in *gophkeeperv1.UserCredentials, opts ...grpc.CallOption) (*gophkeeperv1.TokenResponse, error)
+
+	// ChangePasswordFunc mocks the ChangePassword method.
+	ChangePasswordFunc func(ctx context.Context, in *gophkeeperv1.ChangePasswordRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+
+	// DeleteAccountFunc mocks the DeleteAccount method.
+	DeleteAccountFunc func(ctx context.Context, in *gophkeeperv1.DeleteAccountRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+
+	// DisableMFAFunc mocks the DisableMFA method.
+	DisableMFAFunc func(ctx context.Context, in *gophkeeperv1.MFACode, opts ...grpc.CallOption) (*empty.Empty, error)
+
+	// EnableMFAFunc mocks the EnableMFA method.
+	EnableMFAFunc func(ctx context.Context, in *gophkeeperv1.MFACode, opts ...grpc.CallOption) (*gophkeeperv1.RecoveryCodesResponse, error)
+
+	// EnrollFunc mocks the Enroll method.
+	EnrollFunc func(ctx context.Context, in *gophkeeperv1.EnrollRequest, opts ...grpc.CallOption) (*gophkeeperv1.TokenResponse, error)
+
+	// ListSessionsFunc mocks the ListSessions method.
+	ListSessionsFunc func(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*gophkeeperv1.ListSessionsResponse, error)
+
+	// LogoutFunc mocks the Logout method.
+	LogoutFunc func(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error)
+
+	// RefreshFunc mocks the Refresh method.
+	RefreshFunc func(ctx context.Context, in *gophkeeperv1.RefreshRequest, opts ...grpc.CallOption) (*gophkeeperv1.TokenResponse, error)
+
+	// RegisterFunc mocks the Register method.
+	RegisterFunc func(ctx context.Context, in *gophkeeperv1.UserCredentials, opts ...grpc.CallOption) (*gophkeeperv1.TokenResponse, error)
+
+	// RevokeSessionFunc mocks the RevokeSession method.
+	RevokeSessionFunc func(ctx context.Context, in *gophkeeperv1.RevokeSessionRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+
+	// SetupMFAFunc mocks the SetupMFA method.
+	SetupMFAFunc func(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*gophkeeperv1.SetupMFAResponse, error)
+
+	// VerifyMFAFunc mocks the VerifyMFA method.
+	VerifyMFAFunc func(ctx context.Context, in *gophkeeperv1.VerifyMFARequest, opts ...grpc.CallOption) (*gophkeeperv1.TokenResponse, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Authorize holds details about calls to the Authorize method.
+		Authorize []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// In is the in argument value.
+			In *gophkeeperv1.UserCredentials
+			// Opts is the opts argument value.
+			Opts []grpc.CallOption
+		}
+		// ChangePassword holds details about calls to the ChangePassword method.
+		ChangePassword []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// In is the in argument value.
+			In *gophkeeperv1.ChangePasswordRequest
+			// Opts is the opts argument value.
+			Opts []grpc.CallOption
+		}
+		// DeleteAccount holds details about calls to the DeleteAccount method.
+		DeleteAccount []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// In is the in argument value.
+			In *gophkeeperv1.DeleteAccountRequest
+			// Opts is the opts argument value.
+			Opts []grpc.CallOption
+		}
+		// DisableMFA holds details about calls to the DisableMFA method.
+		DisableMFA []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// In is the in argument value.
+			In *gophkeeperv1.MFACode
+			// Opts is the opts argument value.
+			Opts []grpc.CallOption
+		}
+		// EnableMFA holds details about calls to the EnableMFA method.
+		EnableMFA []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// In is the in argument value.
+			In *gophkeeperv1.MFACode
+			// Opts is the opts argument value.
+			Opts []grpc.CallOption
+		}
+		// Enroll holds details about calls to the Enroll method.
+		Enroll []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// In is the in argument value.
+			In *gophkeeperv1.EnrollRequest
+			// Opts is the opts argument value.
+			Opts []grpc.CallOption
+		}
+		// ListSessions holds details about calls to the ListSessions method.
+		ListSessions []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// In is the in argument value.
+			In *empty.Empty
+			// Opts is the opts argument value.
+			Opts []grpc.CallOption
+		}
+		// Logout holds details about calls to the Logout method.
+		Logout []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// In is the in argument value.
+			In *empty.Empty
+			// Opts is the opts argument value.
+			Opts []grpc.CallOption
+		}
+		// Refresh holds details about calls to the Refresh method.
+		Refresh []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// In is the in argument value.
+			In *gophkeeperv1.RefreshRequest
+			// Opts is the opts argument value.
+			Opts []grpc.CallOption
+		}
+		// Register holds details about calls to the Register method.
+		Register []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// In is the in argument value.
+			In *gophkeeperv1.UserCredentials
+			// Opts is the opts argument value.
+			Opts []grpc.CallOption
+		}
+		// RevokeSession holds details about calls to the RevokeSession method.
+		RevokeSession []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// In is the in argument value.
+			In *gophkeeperv1.RevokeSessionRequest
+			// Opts is the opts argument value.
+			Opts []grpc.CallOption
+		}
+		// SetupMFA holds details about calls to the SetupMFA method.
+		SetupMFA []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// In is the in argument value.
+			In *empty.Empty
+			// Opts is the opts argument value.
+			Opts []grpc.CallOption
+		}
+		// VerifyMFA holds details about calls to the VerifyMFA method.
+		VerifyMFA []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// In is the in argument value.
+			In *gophkeeperv1.VerifyMFARequest
+			// Opts is the opts argument value.
+			Opts []grpc.CallOption
+		}
+	}
+	lockAuthorize      sync.RWMutex
+	lockChangePassword sync.RWMutex
+	lockDeleteAccount  sync.RWMutex
+	lockDisableMFA     sync.RWMutex
+	lockEnableMFA      sync.RWMutex
+	lockEnroll         sync.RWMutex
+	lockListSessions   sync.RWMutex
+	lockLogout         sync.RWMutex
+	lockRefresh        sync.RWMutex
+	lockRegister       sync.RWMutex
+	lockRevokeSession  sync.RWMutex
+	lockSetupMFA       sync.RWMutex
+	lockVerifyMFA      sync.RWMutex
+}
+
+// Authorize calls AuthorizeFunc.
+func (mock *AuthorizationServiceClientMock) Authorize(ctx context.Context, in *gophkeeperv1.UserCredentials, opts ...grpc.CallOption) (*gophkeeperv1.TokenResponse, error) {
+	callInfo := struct {
+		Ctx  context.Context
+		In   *gophkeeperv1.UserCredentials
+		Opts []grpc.CallOption
+	}{
+		Ctx:  ctx,
+		In:   in,
+		Opts: opts,
+	}
+	mock.lockAuthorize.Lock()
+	mock.calls.Authorize = append(mock.calls.Authorize, callInfo)
+	mock.lockAuthorize.Unlock()
+	if mock.AuthorizeFunc == nil {
+		var (
+			tokenResponse *gophkeeperv1.TokenResponse
+			err           error
+		)
+		return tokenResponse, err
+	}
+	return mock.AuthorizeFunc(ctx, in, opts...)
+}
+
+// AuthorizeCalls gets all the calls that were made to Authorize.
+// Check the length with:
+//
+//	len(mockedAuthorizationServiceClient.AuthorizeCalls())
+func (mock *AuthorizationServiceClientMock) AuthorizeCalls() []struct {
+	Ctx  context.Context
+	In   *gophkeeperv1.UserCredentials
+	Opts []grpc.CallOption
+} {
+	var calls []struct {
+		Ctx  context.Context
+		In   *gophkeeperv1.UserCredentials
+		Opts []grpc.CallOption
+	}
+	mock.lockAuthorize.RLock()
+	calls = mock.calls.Authorize
+	mock.lockAuthorize.RUnlock()
+	return calls
+}
+
+// ChangePassword calls ChangePasswordFunc.
+func (mock *AuthorizationServiceClientMock) ChangePassword(ctx context.Context, in *gophkeeperv1.ChangePasswordRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	callInfo := struct {
+		Ctx  context.Context
+		In   *gophkeeperv1.ChangePasswordRequest
+		Opts []grpc.CallOption
+	}{
+		Ctx:  ctx,
+		In:   in,
+		Opts: opts,
+	}
+	mock.lockChangePassword.Lock()
+	mock.calls.ChangePassword = append(mock.calls.ChangePassword, callInfo)
+	mock.lockChangePassword.Unlock()
+	if mock.ChangePasswordFunc == nil {
+		var (
+			v   *empty.Empty
+			err error
+		)
+		return v, err
+	}
+	return mock.ChangePasswordFunc(ctx, in, opts...)
+}
+
+// ChangePasswordCalls gets all the calls that were made to ChangePassword.
+// Check the length with:
+//
+//	len(mockedAuthorizationServiceClient.ChangePasswordCalls())
+func (mock *AuthorizationServiceClientMock) ChangePasswordCalls() []struct {
+	Ctx  context.Context
+	In   *gophkeeperv1.ChangePasswordRequest
+	Opts []grpc.CallOption
+} {
+	var calls []struct {
+		Ctx  context.Context
+		In   *gophkeeperv1.ChangePasswordRequest
+		Opts []grpc.CallOption
+	}
+	mock.lockChangePassword.RLock()
+	calls = mock.calls.ChangePassword
+	mock.lockChangePassword.RUnlock()
+	return calls
+}
+
+// DeleteAccount calls DeleteAccountFunc.
+func (mock *AuthorizationServiceClientMock) DeleteAccount(ctx context.Context, in *gophkeeperv1.DeleteAccountRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	callInfo := struct {
+		Ctx  context.Context
+		In   *gophkeeperv1.DeleteAccountRequest
+		Opts []grpc.CallOption
+	}{
+		Ctx:  ctx,
+		In:   in,
+		Opts: opts,
+	}
+	mock.lockDeleteAccount.Lock()
+	mock.calls.DeleteAccount = append(mock.calls.DeleteAccount, callInfo)
+	mock.lockDeleteAccount.Unlock()
+	if mock.DeleteAccountFunc == nil {
+		var (
+			v   *empty.Empty
+			err error
+		)
+		return v, err
+	}
+	return mock.DeleteAccountFunc(ctx, in, opts...)
+}
+
+// DeleteAccountCalls gets all the calls that were made to DeleteAccount.
+// Check the length with:
+//
+//	len(mockedAuthorizationServiceClient.DeleteAccountCalls())
+func (mock *AuthorizationServiceClientMock) DeleteAccountCalls() []struct {
+	Ctx  context.Context
+	In   *gophkeeperv1.DeleteAccountRequest
+	Opts []grpc.CallOption
+} {
+	var calls []struct {
+		Ctx  context.Context
+		In   *gophkeeperv1.DeleteAccountRequest
+		Opts []grpc.CallOption
+	}
+	mock.lockDeleteAccount.RLock()
+	calls = mock.calls.DeleteAccount
+	mock.lockDeleteAccount.RUnlock()
+	return calls
+}
+
+// DisableMFA calls DisableMFAFunc.
+func (mock *AuthorizationServiceClientMock) DisableMFA(ctx context.Context, in *gophkeeperv1.MFACode, opts ...grpc.CallOption) (*empty.Empty, error) {
+	callInfo := struct {
+		Ctx  context.Context
+		In   *gophkeeperv1.MFACode
+		Opts []grpc.CallOption
+	}{
+		Ctx:  ctx,
+		In:   in,
+		Opts: opts,
+	}
+	mock.lockDisableMFA.Lock()
+	mock.calls.DisableMFA = append(mock.calls.DisableMFA, callInfo)
+	mock.lockDisableMFA.Unlock()
+	if mock.DisableMFAFunc == nil {
+		var (
+			v   *empty.Empty
+			err error
+		)
+		return v, err
+	}
+	return mock.DisableMFAFunc(ctx, in, opts...)
+}
+
+// DisableMFACalls gets all the calls that were made to DisableMFA.
+// Check the length with:
+//
+//	len(mockedAuthorizationServiceClient.DisableMFACalls())
+func (mock *AuthorizationServiceClientMock) DisableMFACalls() []struct {
+	Ctx  context.Context
+	In   *gophkeeperv1.MFACode
+	Opts []grpc.CallOption
+} {
+	var calls []struct {
+		Ctx  context.Context
+		In   *gophkeeperv1.MFACode
+		Opts []grpc.CallOption
+	}
+	mock.lockDisableMFA.RLock()
+	calls = mock.calls.DisableMFA
+	mock.lockDisableMFA.RUnlock()
+	return calls
+}
+
+// EnableMFA calls EnableMFAFunc.
+func (mock *AuthorizationServiceClientMock) EnableMFA(ctx context.Context, in *gophkeeperv1.MFACode, opts ...grpc.CallOption) (*gophkeeperv1.RecoveryCodesResponse, error) {
+	callInfo := struct {
+		Ctx  context.Context
+		In   *gophkeeperv1.MFACode
+		Opts []grpc.CallOption
+	}{
+		Ctx:  ctx,
+		In:   in,
+		Opts: opts,
+	}
+	mock.lockEnableMFA.Lock()
+	mock.calls.EnableMFA = append(mock.calls.EnableMFA, callInfo)
+	mock.lockEnableMFA.Unlock()
+	if mock.EnableMFAFunc == nil {
+		var (
+			recoveryCodesResponse *gophkeeperv1.RecoveryCodesResponse
+			err                   error
+		)
+		return recoveryCodesResponse, err
+	}
+	return mock.EnableMFAFunc(ctx, in, opts...)
+}
+
+// EnableMFACalls gets all the calls that were made to EnableMFA.
+// Check the length with:
+//
+//	len(mockedAuthorizationServiceClient.EnableMFACalls())
+func (mock *AuthorizationServiceClientMock) EnableMFACalls() []struct {
+	Ctx  context.Context
+	In   *gophkeeperv1.MFACode
+	Opts []grpc.CallOption
+} {
+	var calls []struct {
+		Ctx  context.Context
+		In   *gophkeeperv1.MFACode
+		Opts []grpc.CallOption
+	}
+	mock.lockEnableMFA.RLock()
+	calls = mock.calls.EnableMFA
+	mock.lockEnableMFA.RUnlock()
+	return calls
+}
+
+// Enroll calls EnrollFunc.
+func (mock *AuthorizationServiceClientMock) Enroll(ctx context.Context, in *gophkeeperv1.EnrollRequest, opts ...grpc.CallOption) (*gophkeeperv1.TokenResponse, error) {
+	callInfo := struct {
+		Ctx  context.Context
+		In   *gophkeeperv1.EnrollRequest
+		Opts []grpc.CallOption
+	}{
+		Ctx:  ctx,
+		In:   in,
+		Opts: opts,
+	}
+	mock.lockEnroll.Lock()
+	mock.calls.Enroll = append(mock.calls.Enroll, callInfo)
+	mock.lockEnroll.Unlock()
+	if mock.EnrollFunc == nil {
+		var (
+			tokenResponse *gophkeeperv1.TokenResponse
+			err           error
+		)
+		return tokenResponse, err
+	}
+	return mock.EnrollFunc(ctx, in, opts...)
+}
+
+// EnrollCalls gets all the calls that were made to Enroll.
+// Check the length with:
+//
+//	len(mockedAuthorizationServiceClient.EnrollCalls())
+func (mock *AuthorizationServiceClientMock) EnrollCalls() []struct {
+	Ctx  context.Context
+	In   *gophkeeperv1.EnrollRequest
+	Opts []grpc.CallOption
+} {
+	var calls []struct {
+		Ctx  context.Context
+		In   *gophkeeperv1.EnrollRequest
+		Opts []grpc.CallOption
+	}
+	mock.lockEnroll.RLock()
+	calls = mock.calls.Enroll
+	mock.lockEnroll.RUnlock()
+	return calls
+}
+
+// ListSessions calls ListSessionsFunc.
+func (mock *AuthorizationServiceClientMock) ListSessions(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*gophkeeperv1.ListSessionsResponse, error) {
+	callInfo := struct {
+		Ctx  context.Context
+		In   *empty.Empty
+		Opts []grpc.CallOption
+	}{
+		Ctx:  ctx,
+		In:   in,
+		Opts: opts,
+	}
+	mock.lockListSessions.Lock()
+	mock.calls.ListSessions = append(mock.calls.ListSessions, callInfo)
+	mock.lockListSessions.Unlock()
+	if mock.ListSessionsFunc == nil {
+		var (
+			listSessionsResponse *gophkeeperv1.ListSessionsResponse
+			err                  error
+		)
+		return listSessionsResponse, err
+	}
+	return mock.ListSessionsFunc(ctx, in, opts...)
+}
+
+// ListSessionsCalls gets all the calls that were made to ListSessions.
+// Check the length with:
+//
+//	len(mockedAuthorizationServiceClient.ListSessionsCalls())
+func (mock *AuthorizationServiceClientMock) ListSessionsCalls() []struct {
+	Ctx  context.Context
+	In   *empty.Empty
+	Opts []grpc.CallOption
+} {
+	var calls []struct {
+		Ctx  context.Context
+		In   *empty.Empty
+		Opts []grpc.CallOption
+	}
+	mock.lockListSessions.RLock()
+	calls = mock.calls.ListSessions
+	mock.lockListSessions.RUnlock()
+	return calls
+}
+
+// Logout calls LogoutFunc.
+func (mock *AuthorizationServiceClientMock) Logout(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error) {
+	callInfo := struct {
+		Ctx  context.Context
+		In   *empty.Empty
+		Opts []grpc.CallOption
+	}{
+		Ctx:  ctx,
+		In:   in,
+		Opts: opts,
+	}
+	mock.lockLogout.Lock()
+	mock.calls.Logout = append(mock.calls.Logout, callInfo)
+	mock.lockLogout.Unlock()
+	if mock.LogoutFunc == nil {
+		var (
+			v   *empty.Empty
+			err error
+		)
+		return v, err
+	}
+	return mock.LogoutFunc(ctx, in, opts...)
+}
+
+// LogoutCalls gets all the calls that were made to Logout.
+// Check the length with:
+//
+//	len(mockedAuthorizationServiceClient.LogoutCalls())
+func (mock *AuthorizationServiceClientMock) LogoutCalls() []struct {
+	Ctx  context.Context
+	In   *empty.Empty
+	Opts []grpc.CallOption
+} {
+	var calls []struct {
+		Ctx  context.Context
+		In   *empty.Empty
+		Opts []grpc.CallOption
+	}
+	mock.lockLogout.RLock()
+	calls = mock.calls.Logout
+	mock.lockLogout.RUnlock()
+	return calls
+}
+
+// Refresh calls RefreshFunc.
+func (mock *AuthorizationServiceClientMock) Refresh(ctx context.Context, in *gophkeeperv1.RefreshRequest, opts ...grpc.CallOption) (*gophkeeperv1.TokenResponse, error) {
+	callInfo := struct {
+		Ctx  context.Context
+		In   *gophkeeperv1.RefreshRequest
+		Opts []grpc.CallOption
+	}{
+		Ctx:  ctx,
+		In:   in,
+		Opts: opts,
+	}
+	mock.lockRefresh.Lock()
+	mock.calls.Refresh = append(mock.calls.Refresh, callInfo)
+	mock.lockRefresh.Unlock()
+	if mock.RefreshFunc == nil {
+		var (
+			tokenResponse *gophkeeperv1.TokenResponse
+			err           error
+		)
+		return tokenResponse, err
+	}
+	return mock.RefreshFunc(ctx, in, opts...)
+}
+
+// RefreshCalls gets all the calls that were made to Refresh.
+// Check the length with:
+//
+//	len(mockedAuthorizationServiceClient.RefreshCalls())
+func (mock *AuthorizationServiceClientMock) RefreshCalls() []struct {
+	Ctx  context.Context
+	In   *gophkeeperv1.RefreshRequest
+	Opts []grpc.CallOption
+} {
+	var calls []struct {
+		Ctx  context.Context
+		In   *gophkeeperv1.RefreshRequest
+		Opts []grpc.CallOption
+	}
+	mock.lockRefresh.RLock()
+	calls = mock.calls.Refresh
+	mock.lockRefresh.RUnlock()
+	return calls
+}
+
+// Register calls RegisterFunc.
+func (mock *AuthorizationServiceClientMock) Register(ctx context.Context, in *gophkeeperv1.UserCredentials, opts ...grpc.CallOption) (*gophkeeperv1.TokenResponse, error) {
+	callInfo := struct {
+		Ctx  context.Context
+		In   *gophkeeperv1.UserCredentials
+		Opts []grpc.CallOption
+	}{
+		Ctx:  ctx,
+		In:   in,
+		Opts: opts,
+	}
+	mock.lockRegister.Lock()
+	mock.calls.Register = append(mock.calls.Register, callInfo)
+	mock.lockRegister.Unlock()
+	if mock.RegisterFunc == nil {
+		var (
+			tokenResponse *gophkeeperv1.TokenResponse
+			err           error
+		)
+		return tokenResponse, err
+	}
+	return mock.RegisterFunc(ctx, in, opts...)
+}
+
+// RegisterCalls gets all the calls that were made to Register.
+// Check the length with:
+//
+//	len(mockedAuthorizationServiceClient.RegisterCalls())
+func (mock *AuthorizationServiceClientMock) RegisterCalls() []struct {
+	Ctx  context.Context
+	In   *gophkeeperv1.UserCredentials
+	Opts []grpc.CallOption
+} {
+	var calls []struct {
+		Ctx  context.Context
+		In   *gophkeeperv1.UserCredentials
+		Opts []grpc.CallOption
+	}
+	mock.lockRegister.RLock()
+	calls = mock.calls.Register
+	mock.lockRegister.RUnlock()
+	return calls
+}
+
+// RevokeSession calls RevokeSessionFunc.
+func (mock *AuthorizationServiceClientMock) RevokeSession(ctx context.Context, in *gophkeeperv1.RevokeSessionRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	callInfo := struct {
+		Ctx  context.Context
+		In   *gophkeeperv1.RevokeSessionRequest
+		Opts []grpc.CallOption
+	}{
+		Ctx:  ctx,
+		In:   in,
+		Opts: opts,
+	}
+	mock.lockRevokeSession.Lock()
+	mock.calls.RevokeSession = append(mock.calls.RevokeSession, callInfo)
+	mock.lockRevokeSession.Unlock()
+	if mock.RevokeSessionFunc == nil {
+		var (
+			v   *empty.Empty
+			err error
+		)
+		return v, err
+	}
+	return mock.RevokeSessionFunc(ctx, in, opts...)
+}
+
+// RevokeSessionCalls gets all the calls that were made to RevokeSession.
+// Check the length with:
+//
+//	len(mockedAuthorizationServiceClient.RevokeSessionCalls())
+func (mock *AuthorizationServiceClientMock) RevokeSessionCalls() []struct {
+	Ctx  context.Context
+	In   *gophkeeperv1.RevokeSessionRequest
+	Opts []grpc.CallOption
+} {
+	var calls []struct {
+		Ctx  context.Context
+		In   *gophkeeperv1.RevokeSessionRequest
+		Opts []grpc.CallOption
+	}
+	mock.lockRevokeSession.RLock()
+	calls = mock.calls.RevokeSession
+	mock.lockRevokeSession.RUnlock()
+	return calls
+}
+
+// SetupMFA calls SetupMFAFunc.
+func (mock *AuthorizationServiceClientMock) SetupMFA(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*gophkeeperv1.SetupMFAResponse, error) {
+	callInfo := struct {
+		Ctx  context.Context
+		In   *empty.Empty
+		Opts []grpc.CallOption
+	}{
+		Ctx:  ctx,
+		In:   in,
+		Opts: opts,
+	}
+	mock.lockSetupMFA.Lock()
+	mock.calls.SetupMFA = append(mock.calls.SetupMFA, callInfo)
+	mock.lockSetupMFA.Unlock()
+	if mock.SetupMFAFunc == nil {
+		var (
+			setupMFAResponse *gophkeeperv1.SetupMFAResponse
+			err              error
+		)
+		return setupMFAResponse, err
+	}
+	return mock.SetupMFAFunc(ctx, in, opts...)
+}
+
+// SetupMFACalls gets all the calls that were made to SetupMFA.
+// Check the length with:
+//
+//	len(mockedAuthorizationServiceClient.SetupMFACalls())
+func (mock *AuthorizationServiceClientMock) SetupMFACalls() []struct {
+	Ctx  context.Context
+	In   *empty.Empty
+	Opts []grpc.CallOption
+} {
+	var calls []struct {
+		Ctx  context.Context
+		In   *empty.Empty
+		Opts []grpc.CallOption
+	}
+	mock.lockSetupMFA.RLock()
+	calls = mock.calls.SetupMFA
+	mock.lockSetupMFA.RUnlock()
+	return calls
+}
+
+// VerifyMFA calls VerifyMFAFunc.
+func (mock *AuthorizationServiceClientMock) VerifyMFA(ctx context.Context, in *gophkeeperv1.VerifyMFARequest, opts ...grpc.CallOption) (*gophkeeperv1.TokenResponse, error) {
+	callInfo := struct {
+		Ctx  context.Context
+		In   *gophkeeperv1.VerifyMFARequest
+		Opts []grpc.CallOption
+	}{
+		Ctx:  ctx,
+		In:   in,
+		Opts: opts,
+	}
+	mock.lockVerifyMFA.Lock()
+	mock.calls.VerifyMFA = append(mock.calls.VerifyMFA, callInfo)
+	mock.lockVerifyMFA.Unlock()
+	if mock.VerifyMFAFunc == nil {
+		var (
+			tokenResponse *gophkeeperv1.TokenResponse
+			err           error
+		)
+		return tokenResponse, err
+	}
+	return mock.VerifyMFAFunc(ctx, in, opts...)
+}
+
+// VerifyMFACalls gets all the calls that were made to VerifyMFA.
+// Check the length with:
+//
+//	len(mockedAuthorizationServiceClient.VerifyMFACalls())
+func (mock *AuthorizationServiceClientMock) VerifyMFACalls() []struct {
+	Ctx  context.Context
+	In   *gophkeeperv1.VerifyMFARequest
+	Opts []grpc.CallOption
+} {
+	var calls []struct {
+		Ctx  context.Context
+		In   *gophkeeperv1.VerifyMFARequest
+		Opts []grpc.CallOption
+	}
+	mock.lockVerifyMFA.RLock()
+	calls = mock.calls.VerifyMFA
+	mock.lockVerifyMFA.RUnlock()
+	return calls
+}
+
+// Ensure that BinaryServiceClientMock does implement gophkeeperv1.BinaryServiceClient.
+// If this is not the case, regenerate this file with mockery.
+var _ gophkeeperv1.BinaryServiceClient = &BinaryServiceClientMock{}
+
+// BinaryServiceClientMock is a mock implementation of gophkeeperv1.BinaryServiceClient.
+//
+//	func TestSomethingThatUsesBinaryServiceClient(t *testing.T) {
+//
+//		// make and configure a mocked gophkeeperv1.BinaryServiceClient
+//		mockedBinaryServiceClient := &BinaryServiceClientMock{
+//			CompleteUploadFunc: func(ctx context.Context, in *gophkeeperv1.UploadRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+//				panic("mock out the CompleteUpload method")
+//			},
+//			CreateUploadFunc: func(ctx context.Context, in *gophkeeperv1.CreateUploadRequest, opts ...grpc.CallOption) (*gophkeeperv1.UploadStatus, error) {
+//				panic("mock out the CreateUpload method")
+//			},
+//			DownloadFunc: func(ctx context.Context, in *gophkeeperv1.DownloadBinaryRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[gophkeeperv1.DownloadBinaryResponse], error) {
+//				panic("mock out the Download method")
+//			},
+//			GetAllFunc: func(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*gophkeeperv1.GetAllBinariesResponse, error) {
+//				panic("mock out the GetAll method")
+//			},
+//			GetUploadFunc: func(ctx context.Context, in *gophkeeperv1.UploadRequest, opts ...grpc.CallOption) (*gophkeeperv1.UploadStatus, error) {
+//				panic("mock out the GetUpload method")
+//			},
+//			RemoveFunc: func(ctx context.Context, in *gophkeeperv1.RemoveDataRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+//				panic("mock out the Remove method")
+//			},
+//			UpdateFunc: func(ctx context.Context, in *gophkeeperv1.UpdateBinaryRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+//				panic("mock out the Update method")
+//			},
+//			UploadChunksFunc: func(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[gophkeeperv1.UploadChunk, gophkeeperv1.UploadStatus], error) {
+//				panic("mock out the UploadChunks method")
+//			},
+//		}
+//
+//		// use mockedBinaryServiceClient in code that requires gophkeeperv1.BinaryServiceClient
+//		// and then make assertions.
+//
+//	}
+type BinaryServiceClientMock struct {
+	// CompleteUploadFunc mocks the CompleteUpload method.
+	CompleteUploadFunc func(ctx context.Context, in *gophkeeperv1.UploadRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+
+	// CreateUploadFunc mocks the CreateUpload method.
+	CreateUploadFunc func(ctx context.Context, in *gophkeeperv1.CreateUploadRequest, opts ...grpc.CallOption) (*gophkeeperv1.UploadStatus, error)
+
+	// DownloadFunc mocks the Download method.
+	DownloadFunc func(ctx context.Context, in *gophkeeperv1.DownloadBinaryRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[gophkeeperv1.DownloadBinaryResponse], error)
+
+	// GetAllFunc mocks the GetAll method.
+	GetAllFunc func(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*gophkeeperv1.GetAllBinariesResponse, error)
+
+	// GetUploadFunc mocks the GetUpload method.
+	GetUploadFunc func(ctx context.Context, in *gophkeeperv1.UploadRequest, opts ...grpc.CallOption) (*gophkeeperv1.UploadStatus, error)
+
+	// RemoveFunc mocks the Remove method.
+	RemoveFunc func(ctx context.Context, in *gophkeeperv1.RemoveDataRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+
+	// UpdateFunc mocks the Update method.
+	UpdateFunc func(ctx context.Context, in *gophkeeperv1.UpdateBinaryRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+
+	// UploadChunksFunc mocks the UploadChunks method.
+	UploadChunksFunc func(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[gophkeeperv1.UploadChunk, gophkeeperv1.UploadStatus], error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// CompleteUpload holds details about calls to the CompleteUpload method.
+		CompleteUpload []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// In is the in argument value.
+			In *gophkeeperv1.UploadRequest
+			// Opts is the opts argument value.
+			Opts []grpc.CallOption
+		}
+		// CreateUpload holds details about calls to the CreateUpload method.
+		CreateUpload []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// In is the in argument value.
+			In *gophkeeperv1.CreateUploadRequest
+			// Opts is the opts argument value.
+			Opts []grpc.CallOption
+		}
+		// Download holds details about calls to the Download method.
+		Download []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// In is the in argument value.
+			In *gophkeeperv1.DownloadBinaryRequest
+			// Opts is the opts argument value.
+			Opts []grpc.CallOption
+		}
+		// GetAll holds details about calls to the GetAll method.
+		GetAll []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// In is the in argument value.
+			In *empty.Empty
+			// Opts is the opts argument value.
+			Opts []grpc.CallOption
+		}
+		// GetUpload holds details about calls to the GetUpload method.
+		GetUpload []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// In is the in argument value.
+			In *gophkeeperv1.UploadRequest
+			// Opts is the opts argument value.
+			Opts []grpc.CallOption
+		}
+		// Remove holds details about calls to the Remove method.
+		Remove []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// In is the in argument value.
+			In *gophkeeperv1.RemoveDataRequest
+			// Opts is the opts argument value.
+			Opts []grpc.CallOption
+		}
+		// Update holds details about calls to the Update method.
+		Update []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// In is the in argument value.
+			In *gophkeeperv1.UpdateBinaryRequest
+			// Opts is the opts argument value.
+			Opts []grpc.CallOption
+		}
+		// UploadChunks holds details about calls to the UploadChunks method.
+		UploadChunks []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Opts is the opts argument value.
+			Opts []grpc.CallOption
+		}
+	}
+	lockCompleteUpload sync.RWMutex
+	lockCreateUpload   sync.RWMutex
+	lockDownload       sync.RWMutex
+	lockGetAll         sync.RWMutex
+	lockGetUpload      sync.RWMutex
+	lockRemove         sync.RWMutex
+	lockUpdate         sync.RWMutex
+	lockUploadChunks   sync.RWMutex
+}
+
+// CompleteUpload calls CompleteUploadFunc.
+func (mock *BinaryServiceClientMock) CompleteUpload(ctx context.Context, in *gophkeeperv1.UploadRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	callInfo := struct {
+		Ctx  context.Context
+		In   *gophkeeperv1.UploadRequest
+		Opts []grpc.CallOption
+	}{
+		Ctx:  ctx,
+		In:   in,
+		Opts: opts,
+	}
+	mock.lockCompleteUpload.Lock()
+	mock.calls.CompleteUpload = append(mock.calls.CompleteUpload, callInfo)
+	mock.lockCompleteUpload.Unlock()
+	if mock.CompleteUploadFunc == nil {
+		var (
+			v   *empty.Empty
+			err error
+		)
+		return v, err
+	}
+	return mock.CompleteUploadFunc(ctx, in, opts...)
+}
+
+// CompleteUploadCalls gets all the calls that were made to CompleteUpload.
+// Check the length with:
+//
+//	len(mockedBinaryServiceClient.CompleteUploadCalls())
+func (mock *BinaryServiceClientMock) CompleteUploadCalls() []struct {
+	Ctx  context.Context
+	In   *gophkeeperv1.UploadRequest
+	Opts []grpc.CallOption
+} {
+	var calls []struct {
+		Ctx  context.Context
+		In   *gophkeeperv1.UploadRequest
+		Opts []grpc.CallOption
+	}
+	mock.lockCompleteUpload.RLock()
+	calls = mock.calls.CompleteUpload
+	mock.lockCompleteUpload.RUnlock()
+	return calls
+}
+
+// CreateUpload calls CreateUploadFunc.
+func (mock *BinaryServiceClientMock) CreateUpload(ctx context.Context, in *gophkeeperv1.CreateUploadRequest, opts ...grpc.CallOption) (*gophkeeperv1.UploadStatus, error) {
+	callInfo := struct {
+		Ctx  context.Context
+		In   *gophkeeperv1.CreateUploadRequest
+		Opts []grpc.CallOption
+	}{
+		Ctx:  ctx,
+		In:   in,
+		Opts: opts,
+	}
+	mock.lockCreateUpload.Lock()
+	mock.calls.CreateUpload = append(mock.calls.CreateUpload, callInfo)
+	mock.lockCreateUpload.Unlock()
+	if mock.CreateUploadFunc == nil {
+		var (
+			uploadStatus *gophkeeperv1.UploadStatus
+			err          error
+		)
+		return uploadStatus, err
+	}
+	return mock.CreateUploadFunc(ctx, in, opts...)
+}
+
+// CreateUploadCalls gets all the calls that were made to CreateUpload.
+// Check the length with:
+//
+//	len(mockedBinaryServiceClient.CreateUploadCalls())
+func (mock *BinaryServiceClientMock) CreateUploadCalls() []struct {
+	Ctx  context.Context
+	In   *gophkeeperv1.CreateUploadRequest
+	Opts []grpc.CallOption
+} {
+	var calls []struct {
+		Ctx  context.Context
+		In   *gophkeeperv1.CreateUploadRequest
+		Opts []grpc.CallOption
+	}
+	mock.lockCreateUpload.RLock()
+	calls = mock.calls.CreateUpload
+	mock.lockCreateUpload.RUnlock()
+	return calls
+}
+
+// Download calls DownloadFunc.
+func (mock *BinaryServiceClientMock) Download(ctx context.Context, in *gophkeeperv1.DownloadBinaryRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[gophkeeperv1.DownloadBinaryResponse], error) {
+	callInfo := struct {
+		Ctx  context.Context
+		In   *gophkeeperv1.DownloadBinaryRequest
+		Opts []grpc.CallOption
+	}{
+		Ctx:  ctx,
+		In:   in,
+		Opts: opts,
+	}
+	mock.lockDownload.Lock()
+	mock.calls.Download = append(mock.calls.Download, callInfo)
+	mock.lockDownload.Unlock()
+	if mock.DownloadFunc == nil {
+		var (
+			serverStreamingClient grpc.ServerStreamingClient[gophkeeperv1.DownloadBinaryResponse]
+			err                   error
+		)
+		return serverStreamingClient, err
+	}
+	return mock.DownloadFunc(ctx, in, opts...)
+}
+
+// DownloadCalls gets all the calls that were made to Download.
+// Check the length with:
+//
+//	len(mockedBinaryServiceClient.DownloadCalls())
+func (mock *BinaryServiceClientMock) DownloadCalls() []struct {
+	Ctx  context.Context
+	In   *gophkeeperv1.DownloadBinaryRequest
+	Opts []grpc.CallOption
+} {
+	var calls []struct {
+		Ctx  context.Context
+		In   *gophkeeperv1.DownloadBinaryRequest
+		Opts []grpc.CallOption
+	}
+	mock.lockDownload.RLock()
+	calls = mock.calls.Download
+	mock.lockDownload.RUnlock()
+	return calls
+}
+
+// GetAll calls GetAllFunc.
+func (mock *BinaryServiceClientMock) GetAll(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*gophkeeperv1.GetAllBinariesResponse, error) {
+	callInfo := struct {
+		Ctx  context.Context
+		In   *empty.Empty
+		Opts []grpc.CallOption
+	}{
+		Ctx:  ctx,
+		In:   in,
+		Opts: opts,
+	}
+	mock.lockGetAll.Lock()
+	mock.calls.GetAll = append(mock.calls.GetAll, callInfo)
+	mock.lockGetAll.Unlock()
+	if mock.GetAllFunc == nil {
+		var (
+			getAllBinariesResponse *gophkeeperv1.GetAllBinariesResponse
+			err                    error
+		)
+		return getAllBinariesResponse, err
+	}
+	return mock.GetAllFunc(ctx, in, opts...)
+}
+
+// GetAllCalls gets all the calls that were made to GetAll.
+// Check the length with:
+//
+//	len(mockedBinaryServiceClient.GetAllCalls())
+func (mock *BinaryServiceClientMock) GetAllCalls() []struct {
+	Ctx  context.Context
+	In   *empty.Empty
+	Opts []grpc.CallOption
+} {
+	var calls []struct {
+		Ctx  context.Context
+		In   *empty.Empty
+		Opts []grpc.CallOption
+	}
+	mock.lockGetAll.RLock()
+	calls = mock.calls.GetAll
+	mock.lockGetAll.RUnlock()
+	return calls
+}
+
+// GetUpload calls GetUploadFunc.
+func (mock *BinaryServiceClientMock) GetUpload(ctx context.Context, in *gophkeeperv1.UploadRequest, opts ...grpc.CallOption) (*gophkeeperv1.UploadStatus, error) {
+	callInfo := struct {
+		Ctx  context.Context
+		In   *gophkeeperv1.UploadRequest
+		Opts []grpc.CallOption
+	}{
+		Ctx:  ctx,
+		In:   in,
+		Opts: opts,
+	}
+	mock.lockGetUpload.Lock()
+	mock.calls.GetUpload = append(mock.calls.GetUpload, callInfo)
+	mock.lockGetUpload.Unlock()
+	if mock.GetUploadFunc == nil {
+		var (
+			uploadStatus *gophkeeperv1.UploadStatus
+			err          error
+		)
+		return uploadStatus, err
+	}
+	return mock.GetUploadFunc(ctx, in, opts...)
+}
+
+// GetUploadCalls gets all the calls that were made to GetUpload.
+// Check the length with:
+//
+//	len(mockedBinaryServiceClient.GetUploadCalls())
+func (mock *BinaryServiceClientMock) GetUploadCalls() []struct {
+	Ctx  context.Context
+	In   *gophkeeperv1.UploadRequest
+	Opts []grpc.CallOption
+} {
+	var calls []struct {
+		Ctx  context.Context
+		In   *gophkeeperv1.UploadRequest
+		Opts []grpc.CallOption
+	}
+	mock.lockGetUpload.RLock()
+	calls = mock.calls.GetUpload
+	mock.lockGetUpload.RUnlock()
+	return calls
+}
+
+// Remove calls RemoveFunc.
+func (mock *BinaryServiceClientMock) Remove(ctx context.Context, in *gophkeeperv1.RemoveDataRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	callInfo := struct {
+		Ctx  context.Context
+		In   *gophkeeperv1.RemoveDataRequest
+		Opts []grpc.CallOption
+	}{
+		Ctx:  ctx,
+		In:   in,
+		Opts: opts,
+	}
+	mock.lockRemove.Lock()
+	mock.calls.Remove = append(mock.calls.Remove, callInfo)
+	mock.lockRemove.Unlock()
+	if mock.RemoveFunc == nil {
+		var (
+			v   *empty.Empty
+			err error
+		)
+		return v, err
+	}
+	return mock.RemoveFunc(ctx, in, opts...)
+}
+
+// RemoveCalls gets all the calls that were made to Remove.
+// Check the length with:
+//
+//	len(mockedBinaryServiceClient.RemoveCalls())
+func (mock *BinaryServiceClientMock) RemoveCalls() []struct {
+	Ctx  context.Context
+	In   *gophkeeperv1.RemoveDataRequest
+	Opts []grpc.CallOption
+} {
+	var calls []struct {
+		Ctx  context.Context
+		In   *gophkeeperv1.RemoveDataRequest
+		Opts []grpc.CallOption
+	}
+	mock.lockRemove.RLock()
+	calls = mock.calls.Remove
+	mock.lockRemove.RUnlock()
+	return calls
+}
+
+// Update calls UpdateFunc.
+func (mock *BinaryServiceClientMock) Update(ctx context.Context, in *gophkeeperv1.UpdateBinaryRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	callInfo := struct {
+		Ctx  context.Context
+		In   *gophkeeperv1.UpdateBinaryRequest
+		Opts []grpc.CallOption
+	}{
+		Ctx:  ctx,
+		In:   in,
+		Opts: opts,
+	}
+	mock.lockUpdate.Lock()
+	mock.calls.Update = append(mock.calls.Update, callInfo)
+	mock.lockUpdate.Unlock()
+	if mock.UpdateFunc == nil {
+		var (
+			v   *empty.Empty
+			err error
+		)
+		return v, err
+	}
+	return mock.UpdateFunc(ctx, in, opts...)
+}
+
+// UpdateCalls gets all the calls that were made to Update.
+// Check the length with:
+//
+//	len(mockedBinaryServiceClient.UpdateCalls())
+func (mock *BinaryServiceClientMock) UpdateCalls() []struct {
+	Ctx  context.Context
+	In   *gophkeeperv1.UpdateBinaryRequest
+	Opts []grpc.CallOption
+} {
+	var calls []struct {
+		Ctx  context.Context
+		In   *gophkeeperv1.UpdateBinaryRequest
+		Opts []grpc.CallOption
+	}
+	mock.lockUpdate.RLock()
+	calls = mock.calls.Update
+	mock.lockUpdate.RUnlock()
+	return calls
+}
+
+// UploadChunks calls UploadChunksFunc.
+func (mock *BinaryServiceClientMock) UploadChunks(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[gophkeeperv1.UploadChunk, gophkeeperv1.UploadStatus], error) {
+	callInfo := struct {
+		Ctx  context.Context
+		Opts []grpc.CallOption
+	}{
+		Ctx:  ctx,
+		Opts: opts,
+	}
+	mock.lockUploadChunks.Lock()
+	mock.calls.UploadChunks = append(mock.calls.UploadChunks, callInfo)
+	mock.lockUploadChunks.Unlock()
+	if mock.UploadChunksFunc == nil {
+		var (
+			clientStreamingClient grpc.ClientStreamingClient[gophkeeperv1.UploadChunk, gophkeeperv1.UploadStatus]
+			err                   error
+		)
+		return clientStreamingClient, err
+	}
+	return mock.UploadChunksFunc(ctx, opts...)
+}
+
+// UploadChunksCalls gets all the calls that were made to UploadChunks.
+// Check the length with:
+//
+//	len(mockedBinaryServiceClient.UploadChunksCalls())
+func (mock *BinaryServiceClientMock) UploadChunksCalls() []struct {
+	Ctx  context.Context
+	Opts []grpc.CallOption
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Opts []grpc.CallOption
+	}
+	mock.lockUploadChunks.RLock()
+	calls = mock.calls.UploadChunks
+	mock.lockUploadChunks.RUnlock()
+	return calls
+}
+
+// Ensure that CardServiceClientMock does implement gophkeeperv1.CardServiceClient.
+// If this is not the case, regenerate this file with mockery.
+var _ gophkeeperv1.CardServiceClient = &CardServiceClientMock{}
+
+// CardServiceClientMock is a mock implementation of gophkeeperv1.CardServiceClient.
+//
+//	func TestSomethingThatUsesCardServiceClient(t *testing.T) {
+//
+//		// make and configure a mocked gophkeeperv1.CardServiceClient
+//		mockedCardServiceClient := &CardServiceClientMock{
+//			GetAllFunc: func(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*gophkeeperv1.GetAllCardsResponse, error) {
+//				panic("mock out the GetAll method")
+//			},
+//			RemoveFunc: func(ctx context.Context, in *gophkeeperv1.RemoveDataRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+//				panic("mock out the Remove method")
+//			},
+//			SaveFunc: func(ctx context.Context, in *gophkeeperv1.Card, opts ...grpc.CallOption) (*empty.Empty, error) {
+//				panic("mock out the Save method")
+//			},
+//			UpdateFunc: func(ctx context.Context, in *gophkeeperv1.Card, opts ...grpc.CallOption) (*empty.Empty, error) {
+//				panic("mock out the Update method")
+//			},
+//		}
+//
+//		// use mockedCardServiceClient in code that requires gophkeeperv1.CardServiceClient
+//		// and then make assertions.
+//
+//	}
+type CardServiceClientMock struct {
+	// GetAllFunc mocks the GetAll method.
+	GetAllFunc func(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*gophkeeperv1.GetAllCardsResponse, error)
+
+	// RemoveFunc mocks the Remove method.
+	RemoveFunc func(ctx context.Context, in *gophkeeperv1.RemoveDataRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+
+	// SaveFunc mocks the Save method.
+	SaveFunc func(ctx context.Context, in *gophkeeperv1.Card, opts ...grpc.CallOption) (*empty.Empty, error)
+
+	// UpdateFunc mocks the Update method.
+	UpdateFunc func(ctx context.Context, in *gophkeeperv1.Card, opts ...grpc.CallOption) (*empty.Empty, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// GetAll holds details about calls to the GetAll method.
+		GetAll []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// In is the in argument value.
+			In *empty.Empty
+			// Opts is the opts argument value.
+			Opts []grpc.CallOption
+		}
+		// Remove holds details about calls to the Remove method.
+		Remove []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// In is the in argument value.
+			In *gophkeeperv1.RemoveDataRequest
+			// Opts is the opts argument value.
+			Opts []grpc.CallOption
+		}
+		// Save holds details about calls to the Save method.
+		Save []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// In is the in argument value.
+			In *gophkeeperv1.Card
+			// Opts is the opts argument value.
+			Opts []grpc.CallOption
+		}
+		// Update holds details about calls to the Update method.
+		Update []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// In is the in argument value.
+			In *gophkeeperv1.Card
+			// Opts is the opts argument value.
+			Opts []grpc.CallOption
+		}
+	}
+	lockGetAll sync.RWMutex
+	lockRemove sync.RWMutex
+	lockSave   sync.RWMutex
+	lockUpdate sync.RWMutex
+}
+
+// GetAll calls GetAllFunc.
+func (mock *CardServiceClientMock) GetAll(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*gophkeeperv1.GetAllCardsResponse, error) {
+	callInfo := struct {
+		Ctx  context.Context
+		In   *empty.Empty
+		Opts []grpc.CallOption
+	}{
+		Ctx:  ctx,
+		In:   in,
+		Opts: opts,
+	}
+	mock.lockGetAll.Lock()
+	mock.calls.GetAll = append(mock.calls.GetAll, callInfo)
+	mock.lockGetAll.Unlock()
+	if mock.GetAllFunc == nil {
+		var (
+			getAllCardsResponse *gophkeeperv1.GetAllCardsResponse
+			err                 error
+		)
+		return getAllCardsResponse, err
+	}
+	return mock.GetAllFunc(ctx, in, opts...)
+}
+
+// GetAllCalls gets all the calls that were made to GetAll.
+// Check the length with:
+//
+//	len(mockedCardServiceClient.GetAllCalls())
+func (mock *CardServiceClientMock) GetAllCalls() []struct {
+	Ctx  context.Context
+	In   *empty.Empty
+	Opts []grpc.CallOption
+} {
+	var calls []struct {
+		Ctx  context.Context
+		In   *empty.Empty
+		Opts []grpc.CallOption
+	}
+	mock.lockGetAll.RLock()
+	calls = mock.calls.GetAll
+	mock.lockGetAll.RUnlock()
+	return calls
+}
+
+// Remove calls RemoveFunc.
+func (mock *CardServiceClientMock) Remove(ctx context.Context, in *gophkeeperv1.RemoveDataRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	callInfo := struct {
+		Ctx  context.Context
+		In   *gophkeeperv1.RemoveDataRequest
+		Opts []grpc.CallOption
+	}{
+		Ctx:  ctx,
+		In:   in,
+		Opts: opts,
+	}
+	mock.lockRemove.Lock()
+	mock.calls.Remove = append(mock.calls.Remove, callInfo)
+	mock.lockRemove.Unlock()
+	if mock.RemoveFunc == nil {
+		var (
+			v   *empty.Empty
+			err error
+		)
+		return v, err
+	}
+	return mock.RemoveFunc(ctx, in, opts...)
+}
+
+// RemoveCalls gets all the calls that were made to Remove.
+// Check the length with:
+//
+//	len(mockedCardServiceClient.RemoveCalls())
+func (mock *CardServiceClientMock) RemoveCalls() []struct {
+	Ctx  context.Context
+	In   *gophkeeperv1.RemoveDataRequest
+	Opts []grpc.CallOption
+} {
+	var calls []struct {
+		Ctx  context.Context
+		In   *gophkeeperv1.RemoveDataRequest
+		Opts []grpc.CallOption
+	}
+	mock.lockRemove.RLock()
+	calls = mock.calls.Remove
+	mock.lockRemove.RUnlock()
+	return calls
+}
+
+// Save calls SaveFunc.
+func (mock *CardServiceClientMock) Save(ctx context.Context, in *gophkeeperv1.Card, opts ...grpc.CallOption) (*empty.Empty, error) {
+	callInfo := struct {
+		Ctx  context.Context
+		In   *gophkeeperv1.Card
+		Opts []grpc.CallOption
+	}{
+		Ctx:  ctx,
+		In:   in,
+		Opts: opts,
+	}
+	mock.lockSave.Lock()
+	mock.calls.Save = append(mock.calls.Save, callInfo)
+	mock.lockSave.Unlock()
+	if mock.SaveFunc == nil {
+		var (
+			v   *empty.Empty
+			err error
+		)
+		return v, err
+	}
+	return mock.SaveFunc(ctx, in, opts...)
+}
+
+// SaveCalls gets all the calls that were made to Save.
+// Check the length with:
+//
+//	len(mockedCardServiceClient.SaveCalls())
+func (mock *CardServiceClientMock) SaveCalls() []struct {
+	Ctx  context.Context
+	In   *gophkeeperv1.Card
+	Opts []grpc.CallOption
+} {
+	var calls []struct {
+		Ctx  context.Context
+		In   *gophkeeperv1.Card
+		Opts []grpc.CallOption
+	}
+	mock.lockSave.RLock()
+	calls = mock.calls.Save
+	mock.lockSave.RUnlock()
+	return calls
+}
+
+// Update calls UpdateFunc.
+func (mock *CardServiceClientMock) Update(ctx context.Context, in *gophkeeperv1.Card, opts ...grpc.CallOption) (*empty.Empty, error) {
+	callInfo := struct {
+		Ctx  context.Context
+		In   *gophkeeperv1.Card
+		Opts []grpc.CallOption
+	}{
+		Ctx:  ctx,
+		In:   in,
+		Opts: opts,
+	}
+	mock.lockUpdate.Lock()
+	mock.calls.Update = append(mock.calls.Update, callInfo)
+	mock.lockUpdate.Unlock()
+	if mock.UpdateFunc == nil {
+		var (
+			v   *empty.Empty
+			err error
+		)
+		return v, err
+	}
+	return mock.UpdateFunc(ctx, in, opts...)
+}
+
+// UpdateCalls gets all the calls that were made to Update.
+// Check the length with:
+//
+//	len(mockedCardServiceClient.UpdateCalls())
+func (mock *CardServiceClientMock) UpdateCalls() []struct {
+	Ctx  context.Context
+	In   *gophkeeperv1.Card
+	Opts []grpc.CallOption
+} {
+	var calls []struct {
+		Ctx  context.Context
+		In   *gophkeeperv1.Card
+		Opts []grpc.CallOption
+	}
+	mock.lockUpdate.RLock()
+	calls = mock.calls.Update
+	mock.lockUpdate.RUnlock()
+	return calls
+}
+
+// Ensure that HistoryServiceClientMock does implement gophkeeperv1.HistoryServiceClient.
+// If this is not the case, regenerate this file with mockery.
+var _ gophkeeperv1.HistoryServiceClient = &HistoryServiceClientMock{}
+
+// HistoryServiceClientMock is a mock implementation of gophkeeperv1.HistoryServiceClient.
+//
+//	func TestSomethingThatUsesHistoryServiceClient(t *testing.T) {
+//
+//		// make and configure a mocked gophkeeperv1.HistoryServiceClient
+//		mockedHistoryServiceClient := &HistoryServiceClientMock{
+//			ListHistoryFunc: func(ctx context.Context, in *gophkeeperv1.ListHistoryRequest, opts ...grpc.CallOption) (*gophkeeperv1.ListHistoryResponse, error) {
+//				panic("mock out the ListHistory method")
+//			},
+//			RestoreVersionFunc: func(ctx context.Context, in *gophkeeperv1.RestoreVersionRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+//				panic("mock out the RestoreVersion method")
+//			},
+//		}
+//
+//		// use mockedHistoryServiceClient in code that requires gophkeeperv1.HistoryServiceClient
+//		// and then make assertions.
+//
+//	}
+type HistoryServiceClientMock struct {
+	// ListHistoryFunc mocks the ListHistory method.
+	ListHistoryFunc func(ctx context.Context, in *gophkeeperv1.ListHistoryRequest, opts ...grpc.CallOption) (*gophkeeperv1.ListHistoryResponse, error)
+
+	// RestoreVersionFunc mocks the RestoreVersion method.
+	RestoreVersionFunc func(ctx context.Context, in *gophkeeperv1.RestoreVersionRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// ListHistory holds details about calls to the ListHistory method.
+		ListHistory []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// In is the in argument value.
+			In *gophkeeperv1.ListHistoryRequest
+			// Opts is the opts argument value.
+			Opts []grpc.CallOption
+		}
+		// RestoreVersion holds details about calls to the RestoreVersion method.
+		RestoreVersion []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// In is the in argument value.
+			In *gophkeeperv1.RestoreVersionRequest
+			// Opts is the opts argument value.
+			Opts []grpc.CallOption
+		}
+	}
+	lockListHistory    sync.RWMutex
+	lockRestoreVersion sync.RWMutex
+}
+
+// ListHistory calls ListHistoryFunc.
+func (mock *HistoryServiceClientMock) ListHistory(ctx context.Context, in *gophkeeperv1.ListHistoryRequest, opts ...grpc.CallOption) (*gophkeeperv1.ListHistoryResponse, error) {
+	callInfo := struct {
+		Ctx  context.Context
+		In   *gophkeeperv1.ListHistoryRequest
+		Opts []grpc.CallOption
+	}{
+		Ctx:  ctx,
+		In:   in,
+		Opts: opts,
+	}
+	mock.lockListHistory.Lock()
+	mock.calls.ListHistory = append(mock.calls.ListHistory, callInfo)
+	mock.lockListHistory.Unlock()
+	if mock.ListHistoryFunc == nil {
+		var (
+			listHistoryResponse *gophkeeperv1.ListHistoryResponse
+			err                 error
+		)
+		return listHistoryResponse, err
+	}
+	return mock.ListHistoryFunc(ctx, in, opts...)
+}
+
+// ListHistoryCalls gets all the calls that were made to ListHistory.
+// Check the length with:
+//
+//	len(mockedHistoryServiceClient.ListHistoryCalls())
+func (mock *HistoryServiceClientMock) ListHistoryCalls() []struct {
+	Ctx  context.Context
+	In   *gophkeeperv1.ListHistoryRequest
+	Opts []grpc.CallOption
+} {
+	var calls []struct {
+		Ctx  context.Context
+		In   *gophkeeperv1.ListHistoryRequest
+		Opts []grpc.CallOption
+	}
+	mock.lockListHistory.RLock()
+	calls = mock.calls.ListHistory
+	mock.lockListHistory.RUnlock()
+	return calls
+}
+
+// RestoreVersion calls RestoreVersionFunc.
+func (mock *HistoryServiceClientMock) RestoreVersion(ctx context.Context, in *gophkeeperv1.RestoreVersionRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	callInfo := struct {
+		Ctx  context.Context
+		In   *gophkeeperv1.RestoreVersionRequest
+		Opts []grpc.CallOption
+	}{
+		Ctx:  ctx,
+		In:   in,
+		Opts: opts,
+	}
+	mock.lockRestoreVersion.Lock()
+	mock.calls.RestoreVersion = append(mock.calls.RestoreVersion, callInfo)
+	mock.lockRestoreVersion.Unlock()
+	if mock.RestoreVersionFunc == nil {
+		var (
+			v   *empty.Empty
+			err error
+		)
+		return v, err
+	}
+	return mock.RestoreVersionFunc(ctx, in, opts...)
+}
+
+// RestoreVersionCalls gets all the calls that were made to RestoreVersion.
+// Check the length with:
+//
+//	len(mockedHistoryServiceClient.RestoreVersionCalls())
+func (mock *HistoryServiceClientMock) RestoreVersionCalls() []struct {
+	Ctx  context.Context
+	In   *gophkeeperv1.RestoreVersionRequest
+	Opts []grpc.CallOption
+} {
+	var calls []struct {
+		Ctx  context.Context
+		In   *gophkeeperv1.RestoreVersionRequest
+		Opts []grpc.CallOption
+	}
+	mock.lockRestoreVersion.RLock()
+	calls = mock.calls.RestoreVersion
+	mock.lockRestoreVersion.RUnlock()
+	return calls
+}
+
+// Ensure that LoginServiceClientMock does implement gophkeeperv1.LoginServiceClient.
+// If this is not the case, regenerate this file with mockery.
+var _ gophkeeperv1.LoginServiceClient = &LoginServiceClientMock{}
+
+// LoginServiceClientMock is a mock implementation of gophkeeperv1.LoginServiceClient.
+//
+//	func TestSomethingThatUsesLoginServiceClient(t *testing.T) {
+//
+//		// make and configure a mocked gophkeeperv1.LoginServiceClient
+//		mockedLoginServiceClient := &LoginServiceClientMock{
+//			GetAllFunc: func(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*gophkeeperv1.GetAllLoginsResponse, error) {
+//				panic("mock out the GetAll method")
+//			},
+//			RemoveFunc: func(ctx context.Context, in *gophkeeperv1.RemoveDataRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+//				panic("mock out the Remove method")
+//			},
+//			SaveFunc: func(ctx context.Context, in *gophkeeperv1.Login, opts ...grpc.CallOption) (*empty.Empty, error) {
+//				panic("mock out the Save method")
+//			},
+//			UpdateFunc: func(ctx context.Context, in *gophkeeperv1.Login, opts ...grpc.CallOption) (*empty.Empty, error) {
+//				panic("mock out the Update method")
+//			},
+//		}
+//
+//		// use mockedLoginServiceClient in code that requires gophkeeperv1.LoginServiceClient
+//		// and then make assertions.
+//
+//	}
+type LoginServiceClientMock struct {
+	// GetAllFunc mocks the GetAll method.
+	GetAllFunc func(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*gophkeeperv1.GetAllLoginsResponse, error)
+
+	// RemoveFunc mocks the Remove method.
+	RemoveFunc func(ctx context.Context, in *gophkeeperv1.RemoveDataRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+
+	// SaveFunc mocks the Save method.
+	SaveFunc func(ctx context.Context, in *gophkeeperv1.Login, opts ...grpc.CallOption) (*empty.Empty, error)
+
+	// UpdateFunc mocks the Update method.
+	UpdateFunc func(ctx context.Context, in *gophkeeperv1.Login, opts ...grpc.CallOption) (*empty.Empty, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// GetAll holds details about calls to the GetAll method.
+		GetAll []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// In is the in argument value.
+			In *empty.Empty
+			// Opts is the opts argument value.
+			Opts []grpc.CallOption
+		}
+		// Remove holds details about calls to the Remove method.
+		Remove []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// In is the in argument value.
+			In *gophkeeperv1.RemoveDataRequest
+			// Opts is the opts argument value.
+			Opts []grpc.CallOption
+		}
+		// Save holds details about calls to the Save method.
+		Save []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// In is the in argument value.
+			In *gophkeeperv1.Login
+			// Opts is the opts argument value.
+			Opts []grpc.CallOption
+		}
+		// Update holds details about calls to the Update method.
+		Update []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// In is the in argument value.
+			In *gophkeeperv1.Login
+			// Opts is the opts argument value.
+			Opts []grpc.CallOption
+		}
+	}
+	lockGetAll sync.RWMutex
+	lockRemove sync.RWMutex
+	lockSave   sync.RWMutex
+	lockUpdate sync.RWMutex
+}
+
+// GetAll calls GetAllFunc.
+func (mock *LoginServiceClientMock) GetAll(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*gophkeeperv1.GetAllLoginsResponse, error) {
+	callInfo := struct {
+		Ctx  context.Context
+		In   *empty.Empty
+		Opts []grpc.CallOption
+	}{
+		Ctx:  ctx,
+		In:   in,
+		Opts: opts,
+	}
+	mock.lockGetAll.Lock()
+	mock.calls.GetAll = append(mock.calls.GetAll, callInfo)
+	mock.lockGetAll.Unlock()
+	if mock.GetAllFunc == nil {
+		var (
+			getAllLoginsResponse *gophkeeperv1.GetAllLoginsResponse
+			err                  error
+		)
+		return getAllLoginsResponse, err
+	}
+	return mock.GetAllFunc(ctx, in, opts...)
+}
+
+// GetAllCalls gets all the calls that were made to GetAll.
+// Check the length with:
+//
+//	len(mockedLoginServiceClient.GetAllCalls())
+func (mock *LoginServiceClientMock) GetAllCalls() []struct {
+	Ctx  context.Context
+	In   *empty.Empty
+	Opts []grpc.CallOption
+} {
+	var calls []struct {
+		Ctx  context.Context
+		In   *empty.Empty
+		Opts []grpc.CallOption
+	}
+	mock.lockGetAll.RLock()
+	calls = mock.calls.GetAll
+	mock.lockGetAll.RUnlock()
+	return calls
+}
+
+// Remove calls RemoveFunc.
+func (mock *LoginServiceClientMock) Remove(ctx context.Context, in *gophkeeperv1.RemoveDataRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	callInfo := struct {
+		Ctx  context.Context
+		In   *gophkeeperv1.RemoveDataRequest
+		Opts []grpc.CallOption
+	}{
+		Ctx:  ctx,
+		In:   in,
+		Opts: opts,
+	}
+	mock.lockRemove.Lock()
+	mock.calls.Remove = append(mock.calls.Remove, callInfo)
+	mock.lockRemove.Unlock()
+	if mock.RemoveFunc == nil {
+		var (
+			v   *empty.Empty
+			err error
+		)
+		return v, err
+	}
+	return mock.RemoveFunc(ctx, in, opts...)
+}
+
+// RemoveCalls gets all the calls that were made to Remove.
+// Check the length with:
+//
+//	len(mockedLoginServiceClient.RemoveCalls())
+func (mock *LoginServiceClientMock) RemoveCalls() []struct {
+	Ctx  context.Context
+	In   *gophkeeperv1.RemoveDataRequest
+	Opts []grpc.CallOption
+} {
+	var calls []struct {
+		Ctx  context.Context
+		In   *gophkeeperv1.RemoveDataRequest
+		Opts []grpc.CallOption
+	}
+	mock.lockRemove.RLock()
+	calls = mock.calls.Remove
+	mock.lockRemove.RUnlock()
+	return calls
+}
+
+// Save calls SaveFunc.
+func (mock *LoginServiceClientMock) Save(ctx context.Context, in *gophkeeperv1.Login, opts ...grpc.CallOption) (*empty.Empty, error) {
+	callInfo := struct {
+		Ctx  context.Context
+		In   *gophkeeperv1.Login
+		Opts []grpc.CallOption
+	}{
+		Ctx:  ctx,
+		In:   in,
+		Opts: opts,
+	}
+	mock.lockSave.Lock()
+	mock.calls.Save = append(mock.calls.Save, callInfo)
+	mock.lockSave.Unlock()
+	if mock.SaveFunc == nil {
+		var (
+			v   *empty.Empty
+			err error
+		)
+		return v, err
+	}
+	return mock.SaveFunc(ctx, in, opts...)
+}
+
+// SaveCalls gets all the calls that were made to Save.
+// Check the length with:
+//
+//	len(mockedLoginServiceClient.SaveCalls())
+func (mock *LoginServiceClientMock) SaveCalls() []struct {
+	Ctx  context.Context
+	In   *gophkeeperv1.Login
+	Opts []grpc.CallOption
+} {
+	var calls []struct {
+		Ctx  context.Context
+		In   *gophkeeperv1.Login
+		Opts []grpc.CallOption
+	}
+	mock.lockSave.RLock()
+	calls = mock.calls.Save
+	mock.lockSave.RUnlock()
+	return calls
+}
+
+// Update calls UpdateFunc.
+func (mock *LoginServiceClientMock) Update(ctx context.Context, in *gophkeeperv1.Login, opts ...grpc.CallOption) (*empty.Empty, error) {
+	callInfo := struct {
+		Ctx  context.Context
+		In   *gophkeeperv1.Login
+		Opts []grpc.CallOption
+	}{
+		Ctx:  ctx,
+		In:   in,
+		Opts: opts,
+	}
+	mock.lockUpdate.Lock()
+	mock.calls.Update = append(mock.calls.Update, callInfo)
+	mock.lockUpdate.Unlock()
+	if mock.UpdateFunc == nil {
+		var (
+			v   *empty.Empty
+			err error
+		)
+		return v, err
+	}
+	return mock.UpdateFunc(ctx, in, opts...)
+}
+
+// UpdateCalls gets all the calls that were made to Update.
+// Check the length with:
+//
+//	len(mockedLoginServiceClient.UpdateCalls())
+func (mock *LoginServiceClientMock) UpdateCalls() []struct {
+	Ctx  context.Context
+	In   *gophkeeperv1.Login
+	Opts []grpc.CallOption
+} {
+	var calls []struct {
+		Ctx  context.Context
+		In   *gophkeeperv1.Login
+		Opts []grpc.CallOption
+	}
+	mock.lockUpdate.RLock()
+	calls = mock.calls.Update
+	mock.lockUpdate.RUnlock()
+	return calls
+}
+
+// Ensure that NoteServiceClientMock does implement gophkeeperv1.NoteServiceClient.
+// If this is not the case, regenerate this file with mockery.
+var _ gophkeeperv1.NoteServiceClient = &NoteServiceClientMock{}
+
+// NoteServiceClientMock is a mock implementation of gophkeeperv1.NoteServiceClient.
+//
+//	func TestSomethingThatUsesNoteServiceClient(t *testing.T) {
+//
+//		// make and configure a mocked gophkeeperv1.NoteServiceClient
+//		mockedNoteServiceClient := &NoteServiceClientMock{
+//			GetAllFunc: func(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*gophkeeperv1.GetAllNotesResponse, error) {
+//				panic("mock out the GetAll method")
+//			},
+//			RemoveFunc: func(ctx context.Context, in *gophkeeperv1.RemoveDataRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+//				panic("mock out the Remove method")
+//			},
+//			SaveFunc: func(ctx context.Context, in *gophkeeperv1.Note, opts ...grpc.CallOption) (*empty.Empty, error) {
+//				panic("mock out the Save method")
+//			},
+//			UpdateFunc: func(ctx context.Context, in *gophkeeperv1.Note, opts ...grpc.CallOption) (*empty.Empty, error) {
+//				panic("mock out the Update method")
+//			},
+//		}
+//
+//		// use mockedNoteServiceClient in code that requires gophkeeperv1.NoteServiceClient
+//		// and then make assertions.
+//
+//	}
+type NoteServiceClientMock struct {
+	// GetAllFunc mocks the GetAll method.
+	GetAllFunc func(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*gophkeeperv1.GetAllNotesResponse, error)
+
+	// RemoveFunc mocks the Remove method.
+	RemoveFunc func(ctx context.Context, in *gophkeeperv1.RemoveDataRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+
+	// SaveFunc mocks the Save method.
+	SaveFunc func(ctx context.Context, in *gophkeeperv1.Note, opts ...grpc.CallOption) (*empty.Empty, error)
+
+	// UpdateFunc mocks the Update method.
+	UpdateFunc func(ctx context.Context, in *gophkeeperv1.Note, opts ...grpc.CallOption) (*empty.Empty, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// GetAll holds details about calls to the GetAll method.
+		GetAll []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// In is the in argument value.
+			In *empty.Empty
+			// Opts is the opts argument value.
+			Opts []grpc.CallOption
+		}
+		// Remove holds details about calls to the Remove method.
+		Remove []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// In is the in argument value.
+			In *gophkeeperv1.RemoveDataRequest
+			// Opts is the opts argument value.
+			Opts []grpc.CallOption
+		}
+		// Save holds details about calls to the Save method.
+		Save []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// In is the in argument value.
+			In *gophkeeperv1.Note
+			// Opts is the opts argument value.
+			Opts []grpc.CallOption
+		}
+		// Update holds details about calls to the Update method.
+		Update []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// In is the in argument value.
+			In *gophkeeperv1.Note
+			// Opts is the opts argument value.
+			Opts []grpc.CallOption
+		}
+	}
+	lockGetAll sync.RWMutex
+	lockRemove sync.RWMutex
+	lockSave   sync.RWMutex
+	lockUpdate sync.RWMutex
+}
+
+// GetAll calls GetAllFunc.
+func (mock *NoteServiceClientMock) GetAll(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*gophkeeperv1.GetAllNotesResponse, error) {
+	callInfo := struct {
+		Ctx  context.Context
+		In   *empty.Empty
+		Opts []grpc.CallOption
+	}{
+		Ctx:  ctx,
+		In:   in,
+		Opts: opts,
+	}
+	mock.lockGetAll.Lock()
+	mock.calls.GetAll = append(mock.calls.GetAll, callInfo)
+	mock.lockGetAll.Unlock()
+	if mock.GetAllFunc == nil {
+		var (
+			getAllNotesResponse *gophkeeperv1.GetAllNotesResponse
+			err                 error
+		)
+		return getAllNotesResponse, err
+	}
+	return mock.GetAllFunc(ctx, in, opts...)
+}
+
+// GetAllCalls gets all the calls that were made to GetAll.
+// Check the length with:
+//
+//	len(mockedNoteServiceClient.GetAllCalls())
+func (mock *NoteServiceClientMock) GetAllCalls() []struct {
+	Ctx  context.Context
+	In   *empty.Empty
+	Opts []grpc.CallOption
+} {
+	var calls []struct {
+		Ctx  context.Context
+		In   *empty.Empty
+		Opts []grpc.CallOption
+	}
+	mock.lockGetAll.RLock()
+	calls = mock.calls.GetAll
+	mock.lockGetAll.RUnlock()
+	return calls
+}
 
-	// RegisterFunc mocks the Register method.
-	RegisterFunc func(ctx context.Context, in *gophkeeperv1.UserCredentials, opts ...grpc.CallOption) (*gophkeeperv1.TokenResponse, error)
+// Remove calls RemoveFunc.
+func (mock *NoteServiceClientMock) Remove(ctx context.Context, in *gophkeeperv1.RemoveDataRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	callInfo := struct {
+		Ctx  context.Context
+		In   *gophkeeperv1.RemoveDataRequest
+		Opts []grpc.CallOption
+	}{
+		Ctx:  ctx,
+		In:   in,
+		Opts: opts,
+	}
+	mock.lockRemove.Lock()
+	mock.calls.Remove = append(mock.calls.Remove, callInfo)
+	mock.lockRemove.Unlock()
+	if mock.RemoveFunc == nil {
+		var (
+			v   *empty.Empty
+			err error
+		)
+		return v, err
+	}
+	return mock.RemoveFunc(ctx, in, opts...)
+}
 
-	// calls tracks calls to the methods.
-	calls struct {
-		// Authorize holds details about calls to the Authorize method.
-		Authorize []struct {
-			// Ctx is the ctx argument value.
-			Ctx context.Context
-			// In is the in argument value.
-			In *gophkeeperv1.UserCredentials
-			// Opts is the opts argument value.
-			Opts []grpc.CallOption
-		}
-		// Register holds details about calls to the Register method.
-		Register []struct {
-			// Ctx is the ctx argument value.
-			Ctx context.Context
-			// In is the in argument value.
-			In *gophkeeperv1.UserCredentials
-			// Opts is the opts argument value.
-			Opts []grpc.CallOption
-		}
+// RemoveCalls gets all the calls that were made to Remove.
+// Check the length with:
+//
+//	len(mockedNoteServiceClient.RemoveCalls())
+func (mock *NoteServiceClientMock) RemoveCalls() []struct {
+	Ctx  context.Context
+	In   *gophkeeperv1.RemoveDataRequest
+	Opts []grpc.CallOption
+} {
+	var calls []struct {
+		Ctx  context.Context
+		In   *gophkeeperv1.RemoveDataRequest
+		Opts []grpc.CallOption
 	}
-	lockAuthorize sync.RWMutex
-	lockRegister  sync.RWMutex
+	mock.lockRemove.RLock()
+	calls = mock.calls.Remove
+	mock.lockRemove.RUnlock()
+	return calls
 }
 
-// Authorize calls AuthorizeFunc.
-func (mock *AuthorizationServiceClientMock) Authorize(ctx context.Context, in *gophkeeperv1.UserCredentials, opts ...grpc.CallOption) (*gophkeeperv1.TokenResponse, error) {
+// Save calls SaveFunc.
+func (mock *NoteServiceClientMock) Save(ctx context.Context, in *gophkeeperv1.Note, opts ...grpc.CallOption) (*empty.Empty, error) {
 	callInfo := struct {
 		Ctx  context.Context
-		In   *gophkeeperv1.UserCredentials
+		In   *gophkeeperv1.Note
 		Opts []grpc.CallOption
 	}{
 		Ctx:  ctx,
 		In:   in,
 		Opts: opts,
 	}
-	mock.lockAuthorize.Lock()
-	mock.calls.Authorize = append(mock.calls.Authorize, callInfo)
-	mock.lockAuthorize.Unlock()
-	if mock.AuthorizeFunc == nil {
+	mock.lockSave.Lock()
+	mock.calls.Save = append(mock.calls.Save, callInfo)
+	mock.lockSave.Unlock()
+	if mock.SaveFunc == nil {
 		var (
-			tokenResponse *gophkeeperv1.TokenResponse
-			err           error
+			v   *empty.Empty
+			err error
 		)
-		return tokenResponse, err
+		return v, err
 	}
-	return mock.AuthorizeFunc(ctx, in, opts...)
+	return mock.SaveFunc(ctx, in, opts...)
 }
 
-// AuthorizeCalls gets all the calls that were made to Authorize.
+// SaveCalls gets all the calls that were made to Save.
 // Check the length with:
 //
-//	len(mockedAuthorizationServiceClient.AuthorizeCalls())
-func (mock *AuthorizationServiceClientMock) AuthorizeCalls() []struct {
+//	len(mockedNoteServiceClient.SaveCalls())
+func (mock *NoteServiceClientMock) SaveCalls() []struct {
 	Ctx  context.Context
-	In   *gophkeeperv1.UserCredentials
+	In   *gophkeeperv1.Note
 	Opts []grpc.CallOption
 } {
 	var calls []struct {
 		Ctx  context.Context
-		In   *gophkeeperv1.UserCredentials
+		In   *gophkeeperv1.Note
 		Opts []grpc.CallOption
 	}
-	mock.lockAuthorize.RLock()
-	calls = mock.calls.Authorize
-	mock.lockAuthorize.RUnlock()
+	mock.lockSave.RLock()
+	calls = mock.calls.Save
+	mock.lockSave.RUnlock()
 	return calls
 }
 
-// Register calls RegisterFunc.
-func (mock *AuthorizationServiceClientMock) Register(ctx context.Context, in *gophkeeperv1.UserCredentials, opts ...grpc.CallOption) (*gophkeeperv1.TokenResponse, error) {
+// Update calls UpdateFunc.
+func (mock *NoteServiceClientMock) Update(ctx context.Context, in *gophkeeperv1.Note, opts ...grpc.CallOption) (*empty.Empty, error) {
 	callInfo := struct {
 		Ctx  context.Context
-		In   *gophkeeperv1.UserCredentials
+		In   *gophkeeperv1.Note
 		Opts []grpc.CallOption
 	}{
 		Ctx:  ctx,
 		In:   in,
 		Opts: opts,
 	}
-	mock.lockRegister.Lock()
-	mock.calls.Register = append(mock.calls.Register, callInfo)
-	mock.lockRegister.Unlock()
-	if mock.RegisterFunc == nil {
+	mock.lockUpdate.Lock()
+	mock.calls.Update = append(mock.calls.Update, callInfo)
+	mock.lockUpdate.Unlock()
+	if mock.UpdateFunc == nil {
 		var (
-			tokenResponse *gophkeeperv1.TokenResponse
-			err           error
+			v   *empty.Empty
+			err error
 		)
-		return tokenResponse, err
+		return v, err
 	}
-	return mock.RegisterFunc(ctx, in, opts...)
+	return mock.UpdateFunc(ctx, in, opts...)
 }
 
-// RegisterCalls gets all the calls that were made to Register.
+// UpdateCalls gets all the calls that were made to Update.
 // Check the length with:
 //
-//	len(mockedAuthorizationServiceClient.RegisterCalls())
-func (mock *AuthorizationServiceClientMock) RegisterCalls() []struct {
+//	len(mockedNoteServiceClient.UpdateCalls())
+func (mock *NoteServiceClientMock) UpdateCalls() []struct {
 	Ctx  context.Context
-	In   *gophkeeperv1.UserCredentials
+	In   *gophkeeperv1.Note
 	Opts []grpc.CallOption
 } {
 	var calls []struct {
 		Ctx  context.Context
-		In   *gophkeeperv1.UserCredentials
+		In   *gophkeeperv1.Note
 		Opts []grpc.CallOption
 	}
-	mock.lockRegister.RLock()
-	calls = mock.calls.Register
-	mock.lockRegister.RUnlock()
+	mock.lockUpdate.RLock()
+	calls = mock.calls.Update
+	mock.lockUpdate.RUnlock()
 	return calls
 }
 
-// Ensure that CardServiceClientMock does implement gophkeeperv1.CardServiceClient.
+// Ensure that OTPServiceClientMock does implement gophkeeperv1.OTPServiceClient.
 // If this is not the case, regenerate this file with mockery.
-var _ gophkeeperv1.CardServiceClient = &CardServiceClientMock{}
+var _ gophkeeperv1.OTPServiceClient = &OTPServiceClientMock{}
 
-// CardServiceClientMock is a mock implementation of gophkeeperv1.CardServiceClient.
+// OTPServiceClientMock is a mock implementation of gophkeeperv1.OTPServiceClient.
 //
-//	func TestSomethingThatUsesCardServiceClient(t *testing.T) {
+//	func TestSomethingThatUsesOTPServiceClient(t *testing.T) {
 //
-//		// make and configure a mocked gophkeeperv1.CardServiceClient
-//		mockedCardServiceClient := &CardServiceClientMock{
-//			GetAllFunc: func(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*gophkeeperv1.GetAllCardsResponse, error) {
+//		// make and configure a mocked gophkeeperv1.OTPServiceClient
+//		mockedOTPServiceClient := &OTPServiceClientMock{
+//			GetAllFunc: func(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*gophkeeperv1.GetAllOTPsResponse, error) {
 //				panic("mock out the GetAll method")
 //			},
 //			RemoveFunc: func(ctx context.Context, in *gophkeeperv1.RemoveDataRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
 //				panic("mock out the Remove method")
 //			},
-//			SaveFunc: func(ctx context.Context, in *gophkeeperv1.Card, opts ...grpc.CallOption) (*empty.Empty, error) {
+//			SaveFunc: func(ctx context.Context, in *gophkeeperv1.OTP, opts ...grpc.CallOption) (*empty.Empty, error) {
 //				panic("mock out the Save method")
 //			},
-//			UpdateFunc: func(ctx context.Context, in *gophkeeperv1.Card, opts ...grpc.CallOption) (*empty.Empty, error) {
+//			UpdateFunc: func(ctx context.Context, in *gophkeeperv1.OTP, opts ...grpc.CallOption) (*empty.Empty, error) {
 //				panic("mock out the Update method")
 //			},
 //		}
 //
-//		// use mockedCardServiceClient in code that requires gophkeeperv1.CardServiceClient
+//		// use mockedOTPServiceClient in code that requires gophkeeperv1.OTPServiceClient
 //		// and then make assertions.
 //
 //	}
-type CardServiceClientMock struct {
+type OTPServiceClientMock struct {
 	// GetAllFunc mocks the GetAll method.
-	GetAllFunc func(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*gophkeeperv1.GetAllCardsResponse, error)
+	GetAllFunc func(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*gophkeeperv1.GetAllOTPsResponse, error)
 
 	// RemoveFunc mocks the Remove method.
 	RemoveFunc func(ctx context.Context, in *gophkeeperv1.RemoveDataRequest, opts ...grpc.CallOption) (*empty.Empty, error)
 
 	// SaveFunc mocks the Save method.
-	SaveFunc func(ctx context.Context, in *gophkeeperv1.Card, opts ...grpc.CallOption) (*empty.Empty, error)
+	SaveFunc func(ctx context.Context, in *gophkeeperv1.OTP, opts ...grpc.CallOption) (*empty.Empty, error)
 
 	// UpdateFunc mocks the Update method.
-	UpdateFunc func(ctx context.Context, in *gophkeeperv1.Card, opts ...grpc.CallOption) (*empty.Empty, error)
+	UpdateFunc func(ctx context.Context, in *gophkeeperv1.OTP, opts ...grpc.CallOption) (*empty.Empty, error)
 
 	// calls tracks calls to the methods.
 	calls struct {
@@ -221,7 +2305,7 @@ type CardServiceClientMock struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 			// In is the in argument value.
-			In *gophkeeperv1.Card
+			In *gophkeeperv1.OTP
 			// Opts is the opts argument value.
 			Opts []grpc.CallOption
 		}
@@ -230,7 +2314,7 @@ type CardServiceClientMock struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 			// In is the in argument value.
-			In *gophkeeperv1.Card
+			In *gophkeeperv1.OTP
 			// Opts is the opts argument value.
 			Opts []grpc.CallOption
 		}
@@ -242,7 +2326,7 @@ type CardServiceClientMock struct {
 }
 
 // GetAll calls GetAllFunc.
-func (mock *CardServiceClientMock) GetAll(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*gophkeeperv1.GetAllCardsResponse, error) {
+func (mock *OTPServiceClientMock) GetAll(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*gophkeeperv1.GetAllOTPsResponse, error) {
 	callInfo := struct {
 		Ctx  context.Context
 		In   *empty.Empty
@@ -257,10 +2341,10 @@ func (mock *CardServiceClientMock) GetAll(ctx context.Context, in *empty.Empty,
 	mock.lockGetAll.Unlock()
 	if mock.GetAllFunc == nil {
 		var (
-			getAllCardsResponse *gophkeeperv1.GetAllCardsResponse
-			err                 error
+			getAllOTPsResponse *gophkeeperv1.GetAllOTPsResponse
+			err                error
 		)
-		return getAllCardsResponse, err
+		return getAllOTPsResponse, err
 	}
 	return mock.GetAllFunc(ctx, in, opts...)
 }
@@ -268,8 +2352,8 @@ func (mock *CardServiceClientMock) GetAll(ctx context.Context, in *empty.Empty,
 // GetAllCalls gets all the calls that were made to GetAll.
 // Check the length with:
 //
-//	len(mockedCardServiceClient.GetAllCalls())
-func (mock *CardServiceClientMock) GetAllCalls() []struct {
+//	len(mockedOTPServiceClient.GetAllCalls())
+func (mock *OTPServiceClientMock) GetAllCalls() []struct {
 	Ctx  context.Context
 	In   *empty.Empty
 	Opts []grpc.CallOption
@@ -286,7 +2370,7 @@ func (mock *CardServiceClientMock) GetAllCalls() []struct {
 }
 
 // Remove calls RemoveFunc.
-func (mock *CardServiceClientMock) Remove(ctx context.Context, in *gophkeeperv1.RemoveDataRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+func (mock *OTPServiceClientMock) Remove(ctx context.Context, in *gophkeeperv1.RemoveDataRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
 	callInfo := struct {
 		Ctx  context.Context
 		In   *gophkeeperv1.RemoveDataRequest
@@ -312,8 +2396,8 @@ func (mock *CardServiceClientMock) Remove(ctx context.Context, in *gophkeeperv1.
 // RemoveCalls gets all the calls that were made to Remove.
 // Check the length with:
 //
-//	len(mockedCardServiceClient.RemoveCalls())
-func (mock *CardServiceClientMock) RemoveCalls() []struct {
+//	len(mockedOTPServiceClient.RemoveCalls())
+func (mock *OTPServiceClientMock) RemoveCalls() []struct {
 	Ctx  context.Context
 	In   *gophkeeperv1.RemoveDataRequest
 	Opts []grpc.CallOption
@@ -330,10 +2414,10 @@ func (mock *CardServiceClientMock) RemoveCalls() []struct {
 }
 
 // Save calls SaveFunc.
-func (mock *CardServiceClientMock) Save(ctx context.Context, in *gophkeeperv1.Card, opts ...grpc.CallOption) (*empty.Empty, error) {
+func (mock *OTPServiceClientMock) Save(ctx context.Context, in *gophkeeperv1.OTP, opts ...grpc.CallOption) (*empty.Empty, error) {
 	callInfo := struct {
 		Ctx  context.Context
-		In   *gophkeeperv1.Card
+		In   *gophkeeperv1.OTP
 		Opts []grpc.CallOption
 	}{
 		Ctx:  ctx,
@@ -356,15 +2440,15 @@ func (mock *CardServiceClientMock) Save(ctx context.Context, in *gophkeeperv1.Ca
 // SaveCalls gets all the calls that were made to Save.
 // Check the length with:
 //
-//	len(mockedCardServiceClient.SaveCalls())
-func (mock *CardServiceClientMock) SaveCalls() []struct {
+//	len(mockedOTPServiceClient.SaveCalls())
+func (mock *OTPServiceClientMock) SaveCalls() []struct {
 	Ctx  context.Context
-	In   *gophkeeperv1.Card
+	In   *gophkeeperv1.OTP
 	Opts []grpc.CallOption
 } {
 	var calls []struct {
 		Ctx  context.Context
-		In   *gophkeeperv1.Card
+		In   *gophkeeperv1.OTP
 		Opts []grpc.CallOption
 	}
 	mock.lockSave.RLock()
@@ -374,10 +2458,10 @@ func (mock *CardServiceClientMock) SaveCalls() []struct {
 }
 
 // Update calls UpdateFunc.
-func (mock *CardServiceClientMock) Update(ctx context.Context, in *gophkeeperv1.Card, opts ...grpc.CallOption) (*empty.Empty, error) {
+func (mock *OTPServiceClientMock) Update(ctx context.Context, in *gophkeeperv1.OTP, opts ...grpc.CallOption) (*empty.Empty, error) {
 	callInfo := struct {
 		Ctx  context.Context
-		In   *gophkeeperv1.Card
+		In   *gophkeeperv1.OTP
 		Opts []grpc.CallOption
 	}{
 		Ctx:  ctx,
@@ -400,15 +2484,15 @@ func (mock *CardServiceClientMock) Update(ctx context.Context, in *gophkeeperv1.
 // UpdateCalls gets all the calls that were made to Update.
 // Check the length with:
 //
-//	len(mockedCardServiceClient.UpdateCalls())
-func (mock *CardServiceClientMock) UpdateCalls() []struct {
+//	len(mockedOTPServiceClient.UpdateCalls())
+func (mock *OTPServiceClientMock) UpdateCalls() []struct {
 	Ctx  context.Context
-	In   *gophkeeperv1.Card
+	In   *gophkeeperv1.OTP
 	Opts []grpc.CallOption
 } {
 	var calls []struct {
 		Ctx  context.Context
-		In   *gophkeeperv1.Card
+		In   *gophkeeperv1.OTP
 		Opts []grpc.CallOption
 	}
 	mock.lockUpdate.RLock()
@@ -417,313 +2501,329 @@ func (mock *CardServiceClientMock) UpdateCalls() []struct {
 	return calls
 }
 
-// Ensure that LoginServiceClientMock does implement gophkeeperv1.LoginServiceClient.
+// Ensure that SyncServiceClientMock does implement gophkeeperv1.SyncServiceClient.
 // If this is not the case, regenerate this file with mockery.
-var _ gophkeeperv1.LoginServiceClient = &LoginServiceClientMock{}
+var _ gophkeeperv1.SyncServiceClient = &SyncServiceClientMock{}
 
-// LoginServiceClientMock is a mock implementation of gophkeeperv1.LoginServiceClient.
+// SyncServiceClientMock is a mock implementation of gophkeeperv1.SyncServiceClient.
 //
-//	func TestSomethingThatUsesLoginServiceClient(t *testing.T) {
+//	func TestSomethingThatUsesSyncServiceClient(t *testing.T) {
 //
-//		// make and configure a mocked gophkeeperv1.LoginServiceClient
-//		mockedLoginServiceClient := &LoginServiceClientMock{
-//			GetAllFunc: func(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*gophkeeperv1.GetAllLoginsResponse, error) {
-//				panic("mock out the GetAll method")
-//			},
-//			RemoveFunc: func(ctx context.Context, in *gophkeeperv1.RemoveDataRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
-//				panic("mock out the Remove method")
-//			},
-//			SaveFunc: func(ctx context.Context, in *gophkeeperv1.Login, opts ...grpc.CallOption) (*empty.Empty, error) {
-//				panic("mock out the Save method")
+//		// make and configure a mocked gophkeeperv1.SyncServiceClient
+//		mockedSyncServiceClient := &SyncServiceClientMock{
+//			PullFunc: func(ctx context.Context, in *gophkeeperv1.PullRequest, opts ...grpc.CallOption) (*gophkeeperv1.PullResponse, error) {
+//				panic("mock out the Pull method")
 //			},
-//			UpdateFunc: func(ctx context.Context, in *gophkeeperv1.Login, opts ...grpc.CallOption) (*empty.Empty, error) {
-//				panic("mock out the Update method")
+//			PushFunc: func(ctx context.Context, in *gophkeeperv1.PushRequest, opts ...grpc.CallOption) (*gophkeeperv1.PushResponse, error) {
+//				panic("mock out the Push method")
 //			},
 //		}
 //
-//		// use mockedLoginServiceClient in code that requires gophkeeperv1.LoginServiceClient
+//		// use mockedSyncServiceClient in code that requires gophkeeperv1.SyncServiceClient
 //		// and then make assertions.
 //
 //	}
-type LoginServiceClientMock struct {
-	// GetAllFunc mocks the GetAll method.
-	GetAllFunc func(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*gophkeeperv1.GetAllLoginsResponse, error)
-
-	// RemoveFunc mocks the Remove method.
-	RemoveFunc func(ctx context.Context, in *gophkeeperv1.RemoveDataRequest, opts ...grpc.CallOption) (*empty.Empty, error)
-
-	// SaveFunc mocks the Save method.
-	SaveFunc func(ctx context.Context, in *gophkeeperv1.Login, opts ...grpc.CallOption) (*empty.Empty, error)
+type SyncServiceClientMock struct {
+	// PullFunc mocks the Pull method.
+	PullFunc func(ctx context.Context, in *gophkeeperv1.PullRequest, opts ...grpc.CallOption) (*gophkeeperv1.PullResponse, error)
 
-	// UpdateFunc mocks the Update method.
-	UpdateFunc func(ctx context.Context, in *gophkeeperv1.Login, opts ...grpc.CallOption) (*empty.Empty, error)
+	// PushFunc mocks the Push method.
+	PushFunc func(ctx context.Context, in *gophkeeperv1.PushRequest, opts ...grpc.CallOption) (*gophkeeperv1.PushResponse, error)
 
 	// calls tracks calls to the methods.
 	calls struct {
-		// GetAll holds details about calls to the GetAll method.
-		GetAll []struct {
-			// Ctx is the ctx argument value.
-			Ctx context.Context
-			// In is the in argument value.
-			In *empty.Empty
-			// Opts is the opts argument value.
-			Opts []grpc.CallOption
-		}
-		// Remove holds details about calls to the Remove method.
-		Remove []struct {
-			// Ctx is the ctx argument value.
-			Ctx context.Context
-			// In is the in argument value.
-			In *gophkeeperv1.RemoveDataRequest
-			// Opts is the opts argument value.
-			Opts []grpc.CallOption
-		}
-		// Save holds details about calls to the Save method.
-		Save []struct {
+		// Pull holds details about calls to the Pull method.
+		Pull []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 			// In is the in argument value.
-			In *gophkeeperv1.Login
+			In *gophkeeperv1.PullRequest
 			// Opts is the opts argument value.
 			Opts []grpc.CallOption
 		}
-		// Update holds details about calls to the Update method.
-		Update []struct {
+		// Push holds details about calls to the Push method.
+		Push []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 			// In is the in argument value.
-			In *gophkeeperv1.Login
+			In *gophkeeperv1.PushRequest
 			// Opts is the opts argument value.
 			Opts []grpc.CallOption
-		}
-	}
-	lockGetAll sync.RWMutex
-	lockRemove sync.RWMutex
-	lockSave   sync.RWMutex
-	lockUpdate sync.RWMutex
+		}
+	}
+	lockPull sync.RWMutex
+	lockPush sync.RWMutex
 }
 
-// GetAll calls GetAllFunc.
-func (mock *LoginServiceClientMock) GetAll(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*gophkeeperv1.GetAllLoginsResponse, error) {
+// Pull calls PullFunc.
+func (mock *SyncServiceClientMock) Pull(ctx context.Context, in *gophkeeperv1.PullRequest, opts ...grpc.CallOption) (*gophkeeperv1.PullResponse, error) {
 	callInfo := struct {
 		Ctx  context.Context
-		In   *empty.Empty
+		In   *gophkeeperv1.PullRequest
 		Opts []grpc.CallOption
 	}{
 		Ctx:  ctx,
 		In:   in,
 		Opts: opts,
 	}
-	mock.lockGetAll.Lock()
-	mock.calls.GetAll = append(mock.calls.GetAll, callInfo)
-	mock.lockGetAll.Unlock()
-	if mock.GetAllFunc == nil {
+	mock.lockPull.Lock()
+	mock.calls.Pull = append(mock.calls.Pull, callInfo)
+	mock.lockPull.Unlock()
+	if mock.PullFunc == nil {
 		var (
-			getAllLoginsResponse *gophkeeperv1.GetAllLoginsResponse
-			err                  error
+			pullResponse *gophkeeperv1.PullResponse
+			err          error
 		)
-		return getAllLoginsResponse, err
+		return pullResponse, err
 	}
-	return mock.GetAllFunc(ctx, in, opts...)
+	return mock.PullFunc(ctx, in, opts...)
 }
 
-// GetAllCalls gets all the calls that were made to GetAll.
+// PullCalls gets all the calls that were made to Pull.
 // Check the length with:
 //
-//	len(mockedLoginServiceClient.GetAllCalls())
-func (mock *LoginServiceClientMock) GetAllCalls() []struct {
+//	len(mockedSyncServiceClient.PullCalls())
+func (mock *SyncServiceClientMock) PullCalls() []struct {
 	Ctx  context.Context
-	In   *empty.Empty
+	In   *gophkeeperv1.PullRequest
 	Opts []grpc.CallOption
 } {
 	var calls []struct {
 		Ctx  context.Context
-		In   *empty.Empty
+		In   *gophkeeperv1.PullRequest
 		Opts []grpc.CallOption
 	}
-	mock.lockGetAll.RLock()
-	calls = mock.calls.GetAll
-	mock.lockGetAll.RUnlock()
+	mock.lockPull.RLock()
+	calls = mock.calls.Pull
+	mock.lockPull.RUnlock()
 	return calls
 }
 
-// Remove calls RemoveFunc.
-func (mock *LoginServiceClientMock) Remove(ctx context.Context, in *gophkeeperv1.RemoveDataRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+// Push calls PushFunc.
+func (mock *SyncServiceClientMock) Push(ctx context.Context, in *gophkeeperv1.PushRequest, opts ...grpc.CallOption) (*gophkeeperv1.PushResponse, error) {
 	callInfo := struct {
 		Ctx  context.Context
-		In   *gophkeeperv1.RemoveDataRequest
+		In   *gophkeeperv1.PushRequest
 		Opts []grpc.CallOption
 	}{
 		Ctx:  ctx,
 		In:   in,
 		Opts: opts,
 	}
-	mock.lockRemove.Lock()
-	mock.calls.Remove = append(mock.calls.Remove, callInfo)
-	mock.lockRemove.Unlock()
-	if mock.RemoveFunc == nil {
+	mock.lockPush.Lock()
+	mock.calls.Push = append(mock.calls.Push, callInfo)
+	mock.lockPush.Unlock()
+	if mock.PushFunc == nil {
 		var (
-			v   *empty.Empty
-			err error
+			pushResponse *gophkeeperv1.PushResponse
+			err          error
 		)
-		return v, err
+		return pushResponse, err
 	}
-	return mock.RemoveFunc(ctx, in, opts...)
+	return mock.PushFunc(ctx, in, opts...)
 }
 
-// RemoveCalls gets all the calls that were made to Remove.
+// PushCalls gets all the calls that were made to Push.
 // Check the length with:
 //
-//	len(mockedLoginServiceClient.RemoveCalls())
-func (mock *LoginServiceClientMock) RemoveCalls() []struct {
+//	len(mockedSyncServiceClient.PushCalls())
+func (mock *SyncServiceClientMock) PushCalls() []struct {
 	Ctx  context.Context
-	In   *gophkeeperv1.RemoveDataRequest
+	In   *gophkeeperv1.PushRequest
 	Opts []grpc.CallOption
 } {
 	var calls []struct {
 		Ctx  context.Context
-		In   *gophkeeperv1.RemoveDataRequest
+		In   *gophkeeperv1.PushRequest
 		Opts []grpc.CallOption
 	}
-	mock.lockRemove.RLock()
-	calls = mock.calls.Remove
-	mock.lockRemove.RUnlock()
+	mock.lockPush.RLock()
+	calls = mock.calls.Push
+	mock.lockPush.RUnlock()
 	return calls
 }
 
-// Save calls SaveFunc.
-func (mock *LoginServiceClientMock) Save(ctx context.Context, in *gophkeeperv1.Login, opts ...grpc.CallOption) (*empty.Empty, error) {
+// Ensure that InfoServiceClientMock does implement gophkeeperv1.InfoServiceClient.
+// If this is not the case, regenerate this file with mockery.
+var _ gophkeeperv1.InfoServiceClient = &InfoServiceClientMock{}
+
+// InfoServiceClientMock is a mock implementation of gophkeeperv1.InfoServiceClient.
+//
+//	func TestSomethingThatUsesInfoServiceClient(t *testing.T) {
+//
+//		// make and configure a mocked gophkeeperv1.InfoServiceClient
+//		mockedInfoServiceClient := &InfoServiceClientMock{
+//			GetUsageFunc: func(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*gophkeeperv1.UsageResponse, error) {
+//				panic("mock out the GetUsage method")
+//			},
+//			ServerInfoFunc: func(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*gophkeeperv1.ServerInfoResponse, error) {
+//				panic("mock out the ServerInfo method")
+//			},
+//		}
+//
+//		// use mockedInfoServiceClient in code that requires gophkeeperv1.InfoServiceClient
+//		// and then make assertions.
+//
+//	}
+type InfoServiceClientMock struct {
+	// GetUsageFunc mocks the GetUsage method.
+	GetUsageFunc func(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*gophkeeperv1.UsageResponse, error)
+
+	// ServerInfoFunc mocks the ServerInfo method.
+	ServerInfoFunc func(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*gophkeeperv1.ServerInfoResponse, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// GetUsage holds details about calls to the GetUsage method.
+		GetUsage []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// In is the in argument value.
+			In *empty.Empty
+			// Opts is the opts argument value.
+			Opts []grpc.CallOption
+		}
+		// ServerInfo holds details about calls to the ServerInfo method.
+		ServerInfo []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// In is the in argument value.
+			In *empty.Empty
+			// Opts is the opts argument value.
+			Opts []grpc.CallOption
+		}
+	}
+	lockGetUsage   sync.RWMutex
+	lockServerInfo sync.RWMutex
+}
+
+// GetUsage calls GetUsageFunc.
+func (mock *InfoServiceClientMock) GetUsage(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*gophkeeperv1.UsageResponse, error) {
 	callInfo := struct {
 		Ctx  context.Context
-		In   *gophkeeperv1.Login
+		In   *empty.Empty
 		Opts []grpc.CallOption
 	}{
 		Ctx:  ctx,
 		In:   in,
 		Opts: opts,
 	}
-	mock.lockSave.Lock()
-	mock.calls.Save = append(mock.calls.Save, callInfo)
-	mock.lockSave.Unlock()
-	if mock.SaveFunc == nil {
+	mock.lockGetUsage.Lock()
+	mock.calls.GetUsage = append(mock.calls.GetUsage, callInfo)
+	mock.lockGetUsage.Unlock()
+	if mock.GetUsageFunc == nil {
 		var (
-			v   *empty.Empty
-			err error
+			usageResponse *gophkeeperv1.UsageResponse
+			err           error
 		)
-		return v, err
+		return usageResponse, err
 	}
-	return mock.SaveFunc(ctx, in, opts...)
+	return mock.GetUsageFunc(ctx, in, opts...)
 }
 
-// SaveCalls gets all the calls that were made to Save.
+// GetUsageCalls gets all the calls that were made to GetUsage.
 // Check the length with:
 //
-//	len(mockedLoginServiceClient.SaveCalls())
-func (mock *LoginServiceClientMock) SaveCalls() []struct {
+//	len(mockedInfoServiceClient.GetUsageCalls())
+func (mock *InfoServiceClientMock) GetUsageCalls() []struct {
 	Ctx  context.Context
-	In   *gophkeeperv1.Login
+	In   *empty.Empty
 	Opts []grpc.CallOption
 } {
 	var calls []struct {
 		Ctx  context.Context
-		In   *gophkeeperv1.Login
+		In   *empty.Empty
 		Opts []grpc.CallOption
 	}
-	mock.lockSave.RLock()
-	calls = mock.calls.Save
-	mock.lockSave.RUnlock()
+	mock.lockGetUsage.RLock()
+	calls = mock.calls.GetUsage
+	mock.lockGetUsage.RUnlock()
 	return calls
 }
 
-// Update calls UpdateFunc.
-func (mock *LoginServiceClientMock) Update(ctx context.Context, in *gophkeeperv1.Login, opts ...grpc.CallOption) (*empty.Empty, error) {
+// ServerInfo calls ServerInfoFunc.
+func (mock *InfoServiceClientMock) ServerInfo(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*gophkeeperv1.ServerInfoResponse, error) {
 	callInfo := struct {
 		Ctx  context.Context
-		In   *gophkeeperv1.Login
+		In   *empty.Empty
 		Opts []grpc.CallOption
 	}{
 		Ctx:  ctx,
 		In:   in,
 		Opts: opts,
 	}
-	mock.lockUpdate.Lock()
-	mock.calls.Update = append(mock.calls.Update, callInfo)
-	mock.lockUpdate.Unlock()
-	if mock.UpdateFunc == nil {
+	mock.lockServerInfo.Lock()
+	mock.calls.ServerInfo = append(mock.calls.ServerInfo, callInfo)
+	mock.lockServerInfo.Unlock()
+	if mock.ServerInfoFunc == nil {
 		var (
-			v   *empty.Empty
-			err error
+			serverInfoResponse *gophkeeperv1.ServerInfoResponse
+			err                error
 		)
-		return v, err
+		return serverInfoResponse, err
 	}
-	return mock.UpdateFunc(ctx, in, opts...)
+	return mock.ServerInfoFunc(ctx, in, opts...)
 }
 
-// UpdateCalls gets all the calls that were made to Update.
+// ServerInfoCalls gets all the calls that were made to ServerInfo.
 // Check the length with:
 //
-//	len(mockedLoginServiceClient.UpdateCalls())
-func (mock *LoginServiceClientMock) UpdateCalls() []struct {
+//	len(mockedInfoServiceClient.ServerInfoCalls())
+func (mock *InfoServiceClientMock) ServerInfoCalls() []struct {
 	Ctx  context.Context
-	In   *gophkeeperv1.Login
+	In   *empty.Empty
 	Opts []grpc.CallOption
 } {
 	var calls []struct {
 		Ctx  context.Context
-		In   *gophkeeperv1.Login
+		In   *empty.Empty
 		Opts []grpc.CallOption
 	}
-	mock.lockUpdate.RLock()
-	calls = mock.calls.Update
-	mock.lockUpdate.RUnlock()
+	mock.lockServerInfo.RLock()
+	calls = mock.calls.ServerInfo
+	mock.lockServerInfo.RUnlock()
 	return calls
 }
 
-// Ensure that NoteServiceClientMock does implement gophkeeperv1.NoteServiceClient.
+// Ensure that TrashServiceClientMock does implement gophkeeperv1.TrashServiceClient.
 // If this is not the case, regenerate this file with mockery.
-var _ gophkeeperv1.NoteServiceClient = &NoteServiceClientMock{}
+var _ gophkeeperv1.TrashServiceClient = &TrashServiceClientMock{}
 
-// NoteServiceClientMock is a mock implementation of gophkeeperv1.NoteServiceClient.
+// TrashServiceClientMock is a mock implementation of gophkeeperv1.TrashServiceClient.
 //
-//	func TestSomethingThatUsesNoteServiceClient(t *testing.T) {
+//	func TestSomethingThatUsesTrashServiceClient(t *testing.T) {
 //
-//		// make and configure a mocked gophkeeperv1.NoteServiceClient
-//		mockedNoteServiceClient := &NoteServiceClientMock{
-//			GetAllFunc: func(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*gophkeeperv1.GetAllNotesResponse, error) {
-//				panic("mock out the GetAll method")
-//			},
-//			RemoveFunc: func(ctx context.Context, in *gophkeeperv1.RemoveDataRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
-//				panic("mock out the Remove method")
+//		// make and configure a mocked gophkeeperv1.TrashServiceClient
+//		mockedTrashServiceClient := &TrashServiceClientMock{
+//			ListTrashFunc: func(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*gophkeeperv1.ListTrashResponse, error) {
+//				panic("mock out the ListTrash method")
 //			},
-//			SaveFunc: func(ctx context.Context, in *gophkeeperv1.Note, opts ...grpc.CallOption) (*empty.Empty, error) {
-//				panic("mock out the Save method")
+//			PurgeFunc: func(ctx context.Context, in *gophkeeperv1.TrashItemRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+//				panic("mock out the Purge method")
 //			},
-//			UpdateFunc: func(ctx context.Context, in *gophkeeperv1.Note, opts ...grpc.CallOption) (*empty.Empty, error) {
-//				panic("mock out the Update method")
+//			RestoreFunc: func(ctx context.Context, in *gophkeeperv1.TrashItemRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+//				panic("mock out the Restore method")
 //			},
 //		}
 //
-//		// use mockedNoteServiceClient in code that requires gophkeeperv1.NoteServiceClient
+//		// use mockedTrashServiceClient in code that requires gophkeeperv1.TrashServiceClient
 //		// and then make assertions.
 //
 //	}
-type NoteServiceClientMock struct {
-	// GetAllFunc mocks the GetAll method.
-	GetAllFunc func(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*gophkeeperv1.GetAllNotesResponse, error)
+type TrashServiceClientMock struct {
+	// ListTrashFunc mocks the ListTrash method.
+	ListTrashFunc func(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*gophkeeperv1.ListTrashResponse, error)
 
-	// RemoveFunc mocks the Remove method.
-	RemoveFunc func(ctx context.Context, in *gophkeeperv1.RemoveDataRequest, opts ...grpc.CallOption) (*empty.Empty, error)
-
-	// SaveFunc mocks the Save method.
-	SaveFunc func(ctx context.Context, in *gophkeeperv1.Note, opts ...grpc.CallOption) (*empty.Empty, error)
+	// PurgeFunc mocks the Purge method.
+	PurgeFunc func(ctx context.Context, in *gophkeeperv1.TrashItemRequest, opts ...grpc.CallOption) (*empty.Empty, error)
 
-	// UpdateFunc mocks the Update method.
-	UpdateFunc func(ctx context.Context, in *gophkeeperv1.Note, opts ...grpc.CallOption) (*empty.Empty, error)
+	// RestoreFunc mocks the Restore method.
+	RestoreFunc func(ctx context.Context, in *gophkeeperv1.TrashItemRequest, opts ...grpc.CallOption) (*empty.Empty, error)
 
 	// calls tracks calls to the methods.
 	calls struct {
-		// GetAll holds details about calls to the GetAll method.
-		GetAll []struct {
+		// ListTrash holds details about calls to the ListTrash method.
+		ListTrash []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 			// In is the in argument value.
@@ -731,42 +2831,32 @@ type NoteServiceClientMock struct {
 			// Opts is the opts argument value.
 			Opts []grpc.CallOption
 		}
-		// Remove holds details about calls to the Remove method.
-		Remove []struct {
-			// Ctx is the ctx argument value.
-			Ctx context.Context
-			// In is the in argument value.
-			In *gophkeeperv1.RemoveDataRequest
-			// Opts is the opts argument value.
-			Opts []grpc.CallOption
-		}
-		// Save holds details about calls to the Save method.
-		Save []struct {
+		// Purge holds details about calls to the Purge method.
+		Purge []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 			// In is the in argument value.
-			In *gophkeeperv1.Note
+			In *gophkeeperv1.TrashItemRequest
 			// Opts is the opts argument value.
 			Opts []grpc.CallOption
 		}
-		// Update holds details about calls to the Update method.
-		Update []struct {
+		// Restore holds details about calls to the Restore method.
+		Restore []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 			// In is the in argument value.
-			In *gophkeeperv1.Note
+			In *gophkeeperv1.TrashItemRequest
 			// Opts is the opts argument value.
 			Opts []grpc.CallOption
 		}
 	}
-	lockGetAll sync.RWMutex
-	lockRemove sync.RWMutex
-	lockSave   sync.RWMutex
-	lockUpdate sync.RWMutex
+	lockListTrash sync.RWMutex
+	lockPurge     sync.RWMutex
+	lockRestore   sync.RWMutex
 }
 
-// GetAll calls GetAllFunc.
-func (mock *NoteServiceClientMock) GetAll(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*gophkeeperv1.GetAllNotesResponse, error) {
+// ListTrash calls ListTrashFunc.
+func (mock *TrashServiceClientMock) ListTrash(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*gophkeeperv1.ListTrashResponse, error) {
 	callInfo := struct {
 		Ctx  context.Context
 		In   *empty.Empty
@@ -776,24 +2866,24 @@ func (mock *NoteServiceClientMock) GetAll(ctx context.Context, in *empty.Empty,
 		In:   in,
 		Opts: opts,
 	}
-	mock.lockGetAll.Lock()
-	mock.calls.GetAll = append(mock.calls.GetAll, callInfo)
-	mock.lockGetAll.Unlock()
-	if mock.GetAllFunc == nil {
+	mock.lockListTrash.Lock()
+	mock.calls.ListTrash = append(mock.calls.ListTrash, callInfo)
+	mock.lockListTrash.Unlock()
+	if mock.ListTrashFunc == nil {
 		var (
-			getAllNotesResponse *gophkeeperv1.GetAllNotesResponse
-			err                 error
+			listTrashResponse *gophkeeperv1.ListTrashResponse
+			err               error
 		)
-		return getAllNotesResponse, err
+		return listTrashResponse, err
 	}
-	return mock.GetAllFunc(ctx, in, opts...)
+	return mock.ListTrashFunc(ctx, in, opts...)
 }
 
-// GetAllCalls gets all the calls that were made to GetAll.
+// ListTrashCalls gets all the calls that were made to ListTrash.
 // Check the length with:
 //
-//	len(mockedNoteServiceClient.GetAllCalls())
-func (mock *NoteServiceClientMock) GetAllCalls() []struct {
+//	len(mockedTrashServiceClient.ListTrashCalls())
+func (mock *TrashServiceClientMock) ListTrashCalls() []struct {
 	Ctx  context.Context
 	In   *empty.Empty
 	Opts []grpc.CallOption
@@ -803,140 +2893,96 @@ func (mock *NoteServiceClientMock) GetAllCalls() []struct {
 		In   *empty.Empty
 		Opts []grpc.CallOption
 	}
-	mock.lockGetAll.RLock()
-	calls = mock.calls.GetAll
-	mock.lockGetAll.RUnlock()
-	return calls
-}
-
-// Remove calls RemoveFunc.
-func (mock *NoteServiceClientMock) Remove(ctx context.Context, in *gophkeeperv1.RemoveDataRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
-	callInfo := struct {
-		Ctx  context.Context
-		In   *gophkeeperv1.RemoveDataRequest
-		Opts []grpc.CallOption
-	}{
-		Ctx:  ctx,
-		In:   in,
-		Opts: opts,
-	}
-	mock.lockRemove.Lock()
-	mock.calls.Remove = append(mock.calls.Remove, callInfo)
-	mock.lockRemove.Unlock()
-	if mock.RemoveFunc == nil {
-		var (
-			v   *empty.Empty
-			err error
-		)
-		return v, err
-	}
-	return mock.RemoveFunc(ctx, in, opts...)
-}
-
-// RemoveCalls gets all the calls that were made to Remove.
-// Check the length with:
-//
-//	len(mockedNoteServiceClient.RemoveCalls())
-func (mock *NoteServiceClientMock) RemoveCalls() []struct {
-	Ctx  context.Context
-	In   *gophkeeperv1.RemoveDataRequest
-	Opts []grpc.CallOption
-} {
-	var calls []struct {
-		Ctx  context.Context
-		In   *gophkeeperv1.RemoveDataRequest
-		Opts []grpc.CallOption
-	}
-	mock.lockRemove.RLock()
-	calls = mock.calls.Remove
-	mock.lockRemove.RUnlock()
+	mock.lockListTrash.RLock()
+	calls = mock.calls.ListTrash
+	mock.lockListTrash.RUnlock()
 	return calls
 }
 
-// Save calls SaveFunc.
-func (mock *NoteServiceClientMock) Save(ctx context.Context, in *gophkeeperv1.Note, opts ...grpc.CallOption) (*empty.Empty, error) {
+// Purge calls PurgeFunc.
+func (mock *TrashServiceClientMock) Purge(ctx context.Context, in *gophkeeperv1.TrashItemRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
 	callInfo := struct {
 		Ctx  context.Context
-		In   *gophkeeperv1.Note
+		In   *gophkeeperv1.TrashItemRequest
 		Opts []grpc.CallOption
 	}{
 		Ctx:  ctx,
 		In:   in,
 		Opts: opts,
 	}
-	mock.lockSave.Lock()
-	mock.calls.Save = append(mock.calls.Save, callInfo)
-	mock.lockSave.Unlock()
-	if mock.SaveFunc == nil {
+	mock.lockPurge.Lock()
+	mock.calls.Purge = append(mock.calls.Purge, callInfo)
+	mock.lockPurge.Unlock()
+	if mock.PurgeFunc == nil {
 		var (
 			v   *empty.Empty
 			err error
 		)
 		return v, err
 	}
-	return mock.SaveFunc(ctx, in, opts...)
+	return mock.PurgeFunc(ctx, in, opts...)
 }
 
-// SaveCalls gets all the calls that were made to Save.
+// PurgeCalls gets all the calls that were made to Purge.
 // Check the length with:
 //
-//	len(mockedNoteServiceClient.SaveCalls())
-func (mock *NoteServiceClientMock) SaveCalls() []struct {
+//	len(mockedTrashServiceClient.PurgeCalls())
+func (mock *TrashServiceClientMock) PurgeCalls() []struct {
 	Ctx  context.Context
-	In   *gophkeeperv1.Note
+	In   *gophkeeperv1.TrashItemRequest
 	Opts []grpc.CallOption
 } {
 	var calls []struct {
 		Ctx  context.Context
-		In   *gophkeeperv1.Note
+		In   *gophkeeperv1.TrashItemRequest
 		Opts []grpc.CallOption
 	}
-	mock.lockSave.RLock()
-	calls = mock.calls.Save
-	mock.lockSave.RUnlock()
+	mock.lockPurge.RLock()
+	calls = mock.calls.Purge
+	mock.lockPurge.RUnlock()
 	return calls
 }
 
-// Update calls UpdateFunc.
-func (mock *NoteServiceClientMock) Update(ctx context.Context, in *gophkeeperv1.Note, opts ...grpc.CallOption) (*empty.Empty, error) {
+// Restore calls RestoreFunc.
+func (mock *TrashServiceClientMock) Restore(ctx context.Context, in *gophkeeperv1.TrashItemRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
 	callInfo := struct {
 		Ctx  context.Context
-		In   *gophkeeperv1.Note
+		In   *gophkeeperv1.TrashItemRequest
 		Opts []grpc.CallOption
 	}{
 		Ctx:  ctx,
 		In:   in,
 		Opts: opts,
 	}
-	mock.lockUpdate.Lock()
-	mock.calls.Update = append(mock.calls.Update, callInfo)
-	mock.lockUpdate.Unlock()
-	if mock.UpdateFunc == nil {
+	mock.lockRestore.Lock()
+	mock.calls.Restore = append(mock.calls.Restore, callInfo)
+	mock.lockRestore.Unlock()
+	if mock.RestoreFunc == nil {
 		var (
 			v   *empty.Empty
 			err error
 		)
 		return v, err
 	}
-	return mock.UpdateFunc(ctx, in, opts...)
+	return mock.RestoreFunc(ctx, in, opts...)
 }
 
-// UpdateCalls gets all the calls that were made to Update.
+// RestoreCalls gets all the calls that were made to Restore.
 // Check the length with:
 //
-//	len(mockedNoteServiceClient.UpdateCalls())
-func (mock *NoteServiceClientMock) UpdateCalls() []struct {
+//	len(mockedTrashServiceClient.RestoreCalls())
+func (mock *TrashServiceClientMock) RestoreCalls() []struct {
 	Ctx  context.Context
-	In   *gophkeeperv1.Note
+	In   *gophkeeperv1.TrashItemRequest
 	Opts []grpc.CallOption
 } {
 	var calls []struct {
 		Ctx  context.Context
-		In   *gophkeeperv1.Note
+		In   *gophkeeperv1.TrashItemRequest
 		Opts []grpc.CallOption
 	}
-	mock.lockUpdate.RLock()
-	calls = mock.calls.Update
-	mock.lockUpdate.RUnlock()
+	mock.lockRestore.RLock()
+	calls = mock.calls.Restore
+	mock.lockRestore.RUnlock()
 	return calls
 }
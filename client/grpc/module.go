@@ -8,16 +8,27 @@ import (
 var Module = fx.Module(
 	"grpc",
 	fx.Provide(
+		NewTransportCredentials,
 		NewConnection,
 		NewAuthorizationServiceClient,
-		fx.Annotate(NewAuthorizationService, fx.As(new(client.AuthorizationService))),
+		fx.Annotate(NewAuthorizationService, fx.As(new(client.AuthorizationService)), fx.ResultTags(`name:"remote"`)),
 		NewLoginServiceClient,
-		fx.Annotate(NewLoginService, fx.As(new(client.LoginService))),
+		fx.Annotate(NewLoginService, fx.As(new(client.LoginService)), fx.ResultTags(`name:"remote"`)),
 		NewNoteServiceClient,
-		fx.Annotate(NewNoteService, fx.As(new(client.NoteService))),
+		fx.Annotate(NewNoteService, fx.As(new(client.NoteService)), fx.ResultTags(`name:"remote"`)),
 		NewBinaryServiceClient,
-		fx.Annotate(NewBinaryService, fx.As(new(client.BinaryService))),
+		fx.Annotate(NewBinaryService, fx.As(new(client.BinaryService)), fx.ResultTags(`name:"remote"`)),
 		NewCardServiceClient,
-		fx.Annotate(NewCardService, fx.As(new(client.CardService))),
+		fx.Annotate(NewCardService, fx.As(new(client.CardService)), fx.ResultTags(`name:"remote"`)),
+		NewOTPServiceClient,
+		fx.Annotate(NewOTPService, fx.As(new(client.OTPService)), fx.ResultTags(`name:"remote"`)),
+		NewSyncServiceClient,
+		fx.Annotate(NewSyncService, fx.As(new(client.SyncService))),
+		NewHistoryServiceClient,
+		fx.Annotate(NewHistoryService, fx.As(new(client.HistoryService))),
+		NewTrashServiceClient,
+		fx.Annotate(NewTrashService, fx.As(new(client.TrashService))),
+		NewInfoServiceClient,
+		fx.Annotate(NewInfoService, fx.As(new(client.InfoService))),
 	),
 )
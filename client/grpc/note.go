@@ -40,9 +40,10 @@ func (s *NoteService) GetAll(ctx context.Context) ([]client.NoteData, error) {
 	var notes []client.NoteData
 	for _, data := range result.GetResult() {
 		notes = append(notes, client.NoteData{
-			ID:   data.GetId(),
-			Name: data.GetName(),
-			Text: data.GetText(),
+			ID:      data.GetId(),
+			Name:    data.GetName(),
+			Text:    data.GetText(),
+			Version: data.GetVersion(),
 		})
 	}
 	return notes, nil
@@ -51,6 +52,7 @@ func (s *NoteService) GetAll(ctx context.Context) ([]client.NoteData, error) {
 func (s *NoteService) Update(ctx context.Context, data client.NoteDataUpdate) error {
 	var in gophkeeperv1.Note
 	in.SetId(data.ID)
+	in.SetVersion(data.Version)
 	if data.Name != nil {
 		in.SetName(*data.Name)
 	}
@@ -59,13 +61,14 @@ func (s *NoteService) Update(ctx context.Context, data client.NoteDataUpdate) er
 	}
 
 	_, err := s.client.Update(ctx, &in)
-	return err
+	return unwrapError(err)
 }
 
-func (s *NoteService) Remove(ctx context.Context, id int64) error {
+func (s *NoteService) Remove(ctx context.Context, id int64, version int64) error {
 	var in gophkeeperv1.RemoveDataRequest
 	in.SetId(id)
+	in.SetVersion(version)
 
 	_, err := s.client.Remove(ctx, &in)
-	return err
+	return unwrapError(err)
 }
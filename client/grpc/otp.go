@@ -0,0 +1,113 @@
+package grpc
+
+import (
+	"context"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/mkolibaba/gophkeeper/client"
+	"github.com/mkolibaba/gophkeeper/proto/gen/go/gophkeeperv1"
+	"google.golang.org/grpc"
+)
+
+func NewOTPServiceClient(conn *grpc.ClientConn) gophkeeperv1.OTPServiceClient {
+	return gophkeeperv1.NewOTPServiceClient(conn)
+}
+
+type OTPService struct {
+	client gophkeeperv1.OTPServiceClient
+}
+
+func NewOTPService(client gophkeeperv1.OTPServiceClient) *OTPService {
+	return &OTPService{
+		client: client,
+	}
+}
+
+func (s *OTPService) Save(ctx context.Context, data client.OTPData) error {
+	_, err := s.client.Save(ctx, newOTPMessage(data))
+	return err
+}
+
+func (s *OTPService) GetAll(ctx context.Context) ([]client.OTPData, error) {
+	result, err := s.client.GetAll(ctx, &empty.Empty{})
+	if err != nil {
+		return nil, err
+	}
+
+	var otps []client.OTPData
+	for _, data := range result.GetResult() {
+		otps = append(otps, newOTPData(data))
+	}
+	return otps, nil
+}
+
+func (s *OTPService) Update(ctx context.Context, data client.OTPDataUpdate) error {
+	var in gophkeeperv1.OTP
+	in.SetId(data.ID)
+	in.SetVersion(data.Version)
+	if data.Name != nil {
+		in.SetName(*data.Name)
+	}
+	if data.Type != nil {
+		in.SetType(*data.Type)
+	}
+	if data.Secret != nil {
+		in.SetSecret(*data.Secret)
+	}
+	if data.Algorithm != nil {
+		in.SetAlgorithm(*data.Algorithm)
+	}
+	if data.Digits != nil {
+		in.SetDigits(*data.Digits)
+	}
+	if data.Period != nil {
+		in.SetPeriod(*data.Period)
+	}
+	if data.Counter != nil {
+		in.SetCounter(*data.Counter)
+	}
+	if data.Issuer != nil {
+		in.SetIssuer(*data.Issuer)
+	}
+
+	_, err := s.client.Update(ctx, &in)
+	return unwrapError(err)
+}
+
+func (s *OTPService) Remove(ctx context.Context, id int64, version int64) error {
+	var in gophkeeperv1.RemoveDataRequest
+	in.SetId(id)
+	in.SetVersion(version)
+
+	_, err := s.client.Remove(ctx, &in)
+	return unwrapError(err)
+}
+
+func newOTPMessage(data client.OTPData) *gophkeeperv1.OTP {
+	var otp gophkeeperv1.OTP
+	otp.SetId(data.ID)
+	otp.SetVersion(data.Version)
+	otp.SetName(data.Name)
+	otp.SetType(data.Type)
+	otp.SetSecret(data.Secret)
+	otp.SetAlgorithm(data.Algorithm)
+	otp.SetDigits(data.Digits)
+	otp.SetPeriod(data.Period)
+	otp.SetCounter(data.Counter)
+	otp.SetIssuer(data.Issuer)
+	return &otp
+}
+
+func newOTPData(data *gophkeeperv1.OTP) client.OTPData {
+	return client.OTPData{
+		ID:        data.GetId(),
+		Name:      data.GetName(),
+		Type:      data.GetType(),
+		Secret:    data.GetSecret(),
+		Algorithm: data.GetAlgorithm(),
+		Digits:    data.GetDigits(),
+		Period:    data.GetPeriod(),
+		Counter:   data.GetCounter(),
+		Issuer:    data.GetIssuer(),
+		Version:   data.GetVersion(),
+	}
+}
@@ -0,0 +1,82 @@
+package grpc
+
+import (
+	"context"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/mkolibaba/gophkeeper/client"
+	"github.com/mkolibaba/gophkeeper/client/grpc/mock"
+	"github.com/mkolibaba/gophkeeper/proto/gen/go/gophkeeperv1"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"testing"
+)
+
+func TestOTPSave(t *testing.T) {
+	clientMock := &mock.OTPServiceClientMock{}
+	srv := NewOTPService(clientMock)
+
+	err := srv.Save(t.Context(), client.OTPData{
+		Name:      "github",
+		Type:      client.OTPTypeTOTP,
+		Secret:    "JBSWY3DPEHPK3PXP",
+		Algorithm: "SHA1",
+		Digits:    6,
+		Period:    30,
+	})
+	require.NoError(t, err)
+
+	cc := clientMock.SaveCalls()
+	require.Len(t, cc, 1)
+	c := cc[0]
+	require.Equal(t, "github", c.In.GetName())
+	require.Equal(t, "totp", c.In.GetType())
+	require.Equal(t, int64(30), c.In.GetPeriod())
+}
+
+func TestOTPGetAll(t *testing.T) {
+	clientMock := &mock.OTPServiceClientMock{
+		GetAllFunc: func(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*gophkeeperv1.GetAllOTPsResponse, error) {
+			var otp gophkeeperv1.OTP
+			otp.SetId(1)
+			otp.SetName("github")
+			otp.SetType("hotp")
+			otp.SetCounter(3)
+			otp.SetVersion(2)
+			var out gophkeeperv1.GetAllOTPsResponse
+			out.SetResult([]*gophkeeperv1.OTP{&otp})
+			return &out, nil
+		},
+	}
+	srv := NewOTPService(clientMock)
+
+	all, err := srv.GetAll(t.Context())
+	require.NoError(t, err)
+
+	require.Len(t, all, 1)
+	o := all[0]
+	require.Equal(t, "github", o.Name)
+	require.Equal(t, client.OTPTypeHOTP, o.Type)
+	require.Equal(t, int64(3), o.Counter)
+	require.Equal(t, int64(2), o.Version)
+}
+
+func TestOTPUpdate(t *testing.T) {
+	clientMock := &mock.OTPServiceClientMock{}
+	srv := NewOTPService(clientMock)
+
+	var counter int64 = 4
+	err := srv.Update(t.Context(), client.OTPDataUpdate{
+		ID:      1,
+		Version: 2,
+		Counter: &counter,
+	})
+	require.NoError(t, err)
+
+	cc := clientMock.UpdateCalls()
+	require.Len(t, cc, 1)
+	c := cc[0]
+	require.Equal(t, int64(4), c.In.GetCounter())
+	require.Equal(t, int64(2), c.In.GetVersion())
+	require.False(t, c.In.HasName())
+	require.False(t, c.In.HasSecret())
+}
@@ -0,0 +1,258 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"github.com/mkolibaba/gophkeeper/client"
+	"github.com/mkolibaba/gophkeeper/proto/gen/go/gophkeeperv1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var dataKinds = map[client.DataKind]gophkeeperv1.DataKind{
+	client.DataKindLogin:  gophkeeperv1.DataKind_DATA_KIND_LOGIN,
+	client.DataKindNote:   gophkeeperv1.DataKind_DATA_KIND_NOTE,
+	client.DataKindBinary: gophkeeperv1.DataKind_DATA_KIND_BINARY,
+	client.DataKindCard:   gophkeeperv1.DataKind_DATA_KIND_CARD,
+	client.DataKindOTP:    gophkeeperv1.DataKind_DATA_KIND_OTP,
+}
+
+func NewSyncServiceClient(conn *grpc.ClientConn) gophkeeperv1.SyncServiceClient {
+	return gophkeeperv1.NewSyncServiceClient(conn)
+}
+
+// SyncService передает изменения как есть, поэтому используется локальным
+// кэшем напрямую: секретные поля в изменениях уже зашифрованы.
+type SyncService struct {
+	client gophkeeperv1.SyncServiceClient
+}
+
+func NewSyncService(client gophkeeperv1.SyncServiceClient) *SyncService {
+	return &SyncService{
+		client: client,
+	}
+}
+
+func (s *SyncService) Pull(ctx context.Context, since int64) (int64, []client.Change, error) {
+	var in gophkeeperv1.PullRequest
+	in.SetSinceRevision(since)
+
+	result, err := s.client.Pull(ctx, &in)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var changes []client.Change
+	for _, change := range result.GetChanges() {
+		c, err := newChange(change)
+		if err != nil {
+			return 0, nil, err
+		}
+		changes = append(changes, c)
+	}
+
+	return result.GetRevision(), changes, nil
+}
+
+func (s *SyncService) Push(ctx context.Context, changes []client.Change) ([]error, error) {
+	var messages []*gophkeeperv1.Change
+	for _, change := range changes {
+		message, err := newChangeMessage(change)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, message)
+	}
+
+	var in gophkeeperv1.PushRequest
+	in.SetChanges(messages)
+
+	result, err := s.client.Push(ctx, &in)
+	if err != nil {
+		for _, detail := range status.Convert(err).Details() {
+			if applied, ok := detail.(*gophkeeperv1.PushResponse); ok {
+				return newChangeResults(applied), err
+			}
+		}
+		return nil, err
+	}
+
+	return newChangeResults(result), nil
+}
+
+// newChangeResults возвращает результаты изменений из ответа сервера.
+func newChangeResults(result *gophkeeperv1.PushResponse) []error {
+	results := make([]error, 0, len(result.GetResults()))
+	for _, r := range result.GetResults() {
+		var err error
+		switch code := codes.Code(r.GetCode()); code {
+		case codes.OK:
+		case codes.Aborted:
+			err = &client.VersionConflictError{Version: r.GetVersion()}
+		default:
+			err = status.Error(code, r.GetMessage())
+		}
+		results = append(results, err)
+	}
+	return results
+}
+
+func newChange(change *gophkeeperv1.Change) (client.Change, error) {
+	switch change.WhichChange() {
+	case gophkeeperv1.Change_Login_case:
+		data := change.GetLogin()
+		return client.Change{
+			Kind:    client.DataKindLogin,
+			ID:      data.GetId(),
+			Version: data.GetVersion(),
+			Data: client.LoginData{
+				ID:       data.GetId(),
+				Name:     data.GetName(),
+				Login:    data.GetLogin(),
+				Password: data.GetPassword(),
+				Website:  data.GetWebsite(),
+				Notes:    data.GetNotes(),
+				Version:  data.GetVersion(),
+			},
+		}, nil
+
+	case gophkeeperv1.Change_Note_case:
+		data := change.GetNote()
+		return client.Change{
+			Kind:    client.DataKindNote,
+			ID:      data.GetId(),
+			Version: data.GetVersion(),
+			Data: client.NoteData{
+				ID:      data.GetId(),
+				Name:    data.GetName(),
+				Text:    data.GetText(),
+				Version: data.GetVersion(),
+			},
+		}, nil
+
+	case gophkeeperv1.Change_Binary_case:
+		data := change.GetBinary()
+		return client.Change{
+			Kind:    client.DataKindBinary,
+			ID:      data.GetId(),
+			Version: data.GetVersion(),
+			Data: client.BinaryData{
+				ID:       data.GetId(),
+				Name:     data.GetName(),
+				Filename: data.GetFilename(),
+				Size:     data.GetSize(),
+				Notes:    data.GetNotes(),
+				Version:  data.GetVersion(),
+			},
+		}, nil
+
+	case gophkeeperv1.Change_Card_case:
+		data := change.GetCard()
+		return client.Change{
+			Kind:    client.DataKindCard,
+			ID:      data.GetId(),
+			Version: data.GetVersion(),
+			Data: client.CardData{
+				ID:         data.GetId(),
+				Name:       data.GetName(),
+				Number:     data.GetNumber(),
+				ExpDate:    data.GetExpDate(),
+				CVV:        data.GetCvv(),
+				Cardholder: data.GetCardholder(),
+				Notes:      data.GetNotes(),
+				Version:    data.GetVersion(),
+			},
+		}, nil
+
+	case gophkeeperv1.Change_Otp_case:
+		data := change.GetOtp()
+		return client.Change{
+			Kind:    client.DataKindOTP,
+			ID:      data.GetId(),
+			Version: data.GetVersion(),
+			Data:    newOTPData(data),
+		}, nil
+
+	case gophkeeperv1.Change_Removed_case:
+		removed := change.GetRemoved()
+		for kind, k := range dataKinds {
+			if k == removed.GetKind() {
+				return client.Change{Kind: kind, ID: removed.GetId()}, nil
+			}
+		}
+		return client.Change{}, fmt.Errorf("unknown data kind %s", removed.GetKind())
+
+	default:
+		return client.Change{}, fmt.Errorf("empty change")
+	}
+}
+
+func newChangeMessage(change client.Change) (*gophkeeperv1.Change, error) {
+	var out gophkeeperv1.Change
+
+	switch data := change.Data.(type) {
+	case nil:
+		kind, ok := dataKinds[change.Kind]
+		if !ok {
+			return nil, fmt.Errorf("unknown data kind %q", change.Kind)
+		}
+
+		var removed gophkeeperv1.Tombstone
+		removed.SetKind(kind)
+		removed.SetId(change.ID)
+		removed.SetVersion(change.Version)
+		out.SetRemoved(&removed)
+
+	case client.LoginData:
+		var login gophkeeperv1.Login
+		login.SetId(change.ID)
+		login.SetVersion(change.Version)
+		login.SetName(data.Name)
+		login.SetLogin(data.Login)
+		login.SetPassword(data.Password)
+		login.SetWebsite(data.Website)
+		login.SetNotes(data.Notes)
+		out.SetLogin(&login)
+
+	case client.NoteData:
+		var note gophkeeperv1.Note
+		note.SetId(change.ID)
+		note.SetVersion(change.Version)
+		note.SetName(data.Name)
+		note.SetText(data.Text)
+		out.SetNote(&note)
+
+	case client.BinaryData:
+		var binary gophkeeperv1.Binary
+		binary.SetId(change.ID)
+		binary.SetVersion(change.Version)
+		binary.SetName(data.Name)
+		binary.SetFilename(data.Filename)
+		binary.SetSize(data.Size)
+		binary.SetNotes(data.Notes)
+		out.SetBinary(&binary)
+
+	case client.CardData:
+		var card gophkeeperv1.Card
+		card.SetId(change.ID)
+		card.SetVersion(change.Version)
+		card.SetName(data.Name)
+		card.SetNumber(data.Number)
+		card.SetExpDate(data.ExpDate)
+		card.SetCvv(data.CVV)
+		card.SetCardholder(data.Cardholder)
+		card.SetNotes(data.Notes)
+		out.SetCard(&card)
+
+	case client.OTPData:
+		data.ID = change.ID
+		data.Version = change.Version
+		out.SetOtp(newOTPMessage(data))
+
+	default:
+		return nil, fmt.Errorf("unsupported data type %T", data)
+	}
+
+	return &out, nil
+}
@@ -0,0 +1,110 @@
+package grpc
+
+import (
+	"context"
+	"github.com/mkolibaba/gophkeeper/client"
+	"github.com/mkolibaba/gophkeeper/client/grpc/mock"
+	"github.com/mkolibaba/gophkeeper/proto/gen/go/gophkeeperv1"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"testing"
+)
+
+func TestSyncPull(t *testing.T) {
+	clientMock := &mock.SyncServiceClientMock{
+		PullFunc: func(ctx context.Context, in *gophkeeperv1.PullRequest, opts ...grpc.CallOption) (*gophkeeperv1.PullResponse, error) {
+			var note gophkeeperv1.Note
+			note.SetId(1)
+			note.SetName("note")
+			note.SetText("text")
+			var noteChange gophkeeperv1.Change
+			noteChange.SetNote(&note)
+
+			var removed gophkeeperv1.Tombstone
+			removed.SetKind(gophkeeperv1.DataKind_DATA_KIND_CARD)
+			removed.SetId(2)
+			var removedChange gophkeeperv1.Change
+			removedChange.SetRemoved(&removed)
+
+			var out gophkeeperv1.PullResponse
+			out.SetRevision(5)
+			out.SetChanges([]*gophkeeperv1.Change{&noteChange, &removedChange})
+			return &out, nil
+		},
+	}
+	srv := NewSyncService(clientMock)
+
+	revision, changes, err := srv.Pull(t.Context(), 3)
+	require.NoError(t, err)
+	require.Equal(t, int64(5), revision)
+	require.Equal(t, []client.Change{
+		{Kind: client.DataKindNote, ID: 1, Data: client.NoteData{ID: 1, Name: "note", Text: "text"}},
+		{Kind: client.DataKindCard, ID: 2},
+	}, changes)
+
+	require.Equal(t, int64(3), clientMock.PullCalls()[0].In.GetSinceRevision())
+}
+
+func TestSyncPush(t *testing.T) {
+	clientMock := &mock.SyncServiceClientMock{
+		PushFunc: func(ctx context.Context, in *gophkeeperv1.PushRequest, opts ...grpc.CallOption) (*gophkeeperv1.PushResponse, error) {
+			var out gophkeeperv1.PushResponse
+			out.SetResults([]*gophkeeperv1.ChangeResult{
+				newChangeResult(codes.OK, 0),
+				newChangeResult(codes.Aborted, 5),
+			})
+			return &out, nil
+		},
+	}
+	srv := NewSyncService(clientMock)
+
+	results, err := srv.Push(t.Context(), []client.Change{
+		{Kind: client.DataKindLogin, Data: client.LoginData{ID: -1, Name: "github", Login: "alice"}},
+		{Kind: client.DataKindBinary, ID: 3},
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	require.NoError(t, results[0])
+	var conflict *client.VersionConflictError
+	require.ErrorAs(t, results[1], &conflict)
+	require.Equal(t, int64(5), conflict.Version)
+
+	changes := clientMock.PushCalls()[0].In.GetChanges()
+	require.Len(t, changes, 2)
+	require.Equal(t, int64(0), changes[0].GetLogin().GetId())
+	require.Equal(t, "github", changes[0].GetLogin().GetName())
+	require.Equal(t, gophkeeperv1.DataKind_DATA_KIND_BINARY, changes[1].GetRemoved().GetKind())
+	require.Equal(t, int64(3), changes[1].GetRemoved().GetId())
+}
+
+func TestSyncPushServerError(t *testing.T) {
+	clientMock := &mock.SyncServiceClientMock{
+		PushFunc: func(ctx context.Context, in *gophkeeperv1.PushRequest, opts ...grpc.CallOption) (*gophkeeperv1.PushResponse, error) {
+			var applied gophkeeperv1.PushResponse
+			applied.SetResults([]*gophkeeperv1.ChangeResult{
+				newChangeResult(codes.NotFound, 0),
+			})
+			st, err := status.New(codes.Internal, "internal server error").WithDetails(&applied)
+			require.NoError(t, err)
+			return nil, st.Err()
+		},
+	}
+	srv := NewSyncService(clientMock)
+
+	results, err := srv.Push(t.Context(), []client.Change{
+		{Kind: client.DataKindLogin, ID: 1},
+		{Kind: client.DataKindLogin, ID: 2},
+	})
+	require.Equal(t, codes.Internal, status.Code(err))
+	require.Len(t, results, 1)
+	require.Equal(t, codes.NotFound, status.Code(results[0]))
+}
+
+func newChangeResult(code codes.Code, version int64) *gophkeeperv1.ChangeResult {
+	var result gophkeeperv1.ChangeResult
+	result.SetCode(int32(code))
+	result.SetVersion(version)
+	return &result
+}
@@ -0,0 +1,165 @@
+package grpc
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"github.com/mkolibaba/gophkeeper/client"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type testCert struct {
+	cert     *x509.Certificate
+	key      *ecdsa.PrivateKey
+	certFile string
+	keyFile  string
+}
+
+// newTestCert создает сертификат, подписанный parent. Если parent не задан,
+// сертификат самоподписанный и может использоваться как CA.
+func newTestCert(t *testing.T, name string, parent *testCert) *testCert {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+
+	signer, signerKey := template, key
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+	} else {
+		signer, signerKey = parent.cert, parent.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, name+".crt")
+	keyFile := filepath.Join(dir, name+".key")
+	err = os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600)
+	require.NoError(t, err)
+	err = os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600)
+	require.NoError(t, err)
+
+	return &testCert{cert: cert, key: key, certFile: certFile, keyFile: keyFile}
+}
+
+func startTLSTestServer(t *testing.T, tlsConfig *tls.Config) string {
+	t.Helper()
+
+	listen, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	s := grpc.NewServer(grpc.Creds(credentials.NewTLS(tlsConfig)))
+	grpc_health_v1.RegisterHealthServer(s, health.NewServer())
+	go s.Serve(listen)
+	t.Cleanup(s.Stop)
+
+	return listen.Addr().String()
+}
+
+func checkConnection(t *testing.T, addr string, creds credentials.TransportCredentials) error {
+	t.Helper()
+
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(creds))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	_, err = grpc_health_v1.NewHealthClient(conn).Check(t.Context(), &grpc_health_v1.HealthCheckRequest{})
+	return err
+}
+
+func TestNewTransportCredentials(t *testing.T) {
+	ca := newTestCert(t, "ca", nil)
+	serverCert := newTestCert(t, "server", ca)
+	clientCert := newTestCert(t, "client", ca)
+
+	serverKeyPair, err := tls.LoadX509KeyPair(serverCert.certFile, serverCert.keyFile)
+	require.NoError(t, err)
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(ca.cert)
+
+	newConfig := func() *client.Config {
+		var config client.Config
+		config.GRPC.TLS.Enabled = true
+		config.GRPC.TLS.CAFile = ca.certFile
+		config.GRPC.TLS.ServerName = "localhost"
+		return &config
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		creds, err := NewTransportCredentials(&client.Config{})
+		require.NoError(t, err)
+		require.Equal(t, "insecure", creds.Info().SecurityProtocol)
+	})
+	t.Run("tls", func(t *testing.T) {
+		addr := startTLSTestServer(t, &tls.Config{Certificates: []tls.Certificate{serverKeyPair}})
+
+		creds, err := NewTransportCredentials(newConfig())
+		require.NoError(t, err)
+		require.NoError(t, checkConnection(t, addr, creds))
+	})
+	t.Run("untrusted_server", func(t *testing.T) {
+		addr := startTLSTestServer(t, &tls.Config{Certificates: []tls.Certificate{serverKeyPair}})
+
+		config := newConfig()
+		config.GRPC.TLS.CAFile = newTestCert(t, "other-ca", nil).certFile
+		creds, err := NewTransportCredentials(config)
+		require.NoError(t, err)
+		require.Error(t, checkConnection(t, addr, creds))
+	})
+	t.Run("mtls", func(t *testing.T) {
+		addr := startTLSTestServer(t, &tls.Config{
+			Certificates: []tls.Certificate{serverKeyPair},
+			ClientCAs:    clientCAs,
+			ClientAuth:   tls.RequireAndVerifyClientCert,
+		})
+
+		config := newConfig()
+		config.GRPC.TLS.CertFile = clientCert.certFile
+		config.GRPC.TLS.KeyFile = clientCert.keyFile
+		creds, err := NewTransportCredentials(config)
+		require.NoError(t, err)
+		require.NoError(t, checkConnection(t, addr, creds))
+
+		creds, err = NewTransportCredentials(newConfig())
+		require.NoError(t, err)
+		require.Error(t, checkConnection(t, addr, creds))
+	})
+	t.Run("missing_ca", func(t *testing.T) {
+		config := newConfig()
+		config.GRPC.TLS.CAFile = filepath.Join(t.TempDir(), "missing.crt")
+		_, err := NewTransportCredentials(config)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,87 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/mkolibaba/gophkeeper/client"
+	"github.com/mkolibaba/gophkeeper/proto/gen/go/gophkeeperv1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func NewTrashServiceClient(conn *grpc.ClientConn) gophkeeperv1.TrashServiceClient {
+	return gophkeeperv1.NewTrashServiceClient(conn)
+}
+
+// TrashService передает записи корзины как есть: секретные поля в них
+// зашифрованы.
+type TrashService struct {
+	client gophkeeperv1.TrashServiceClient
+}
+
+func NewTrashService(client gophkeeperv1.TrashServiceClient) *TrashService {
+	return &TrashService{
+		client: client,
+	}
+}
+
+func (s *TrashService) List(ctx context.Context) ([]client.TrashEntry, error) {
+	result, err := s.client.ListTrash(ctx, &empty.Empty{})
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []client.TrashEntry
+	for _, item := range result.GetItems() {
+		data, ok := newItemData(item)
+		if !ok {
+			return nil, fmt.Errorf("empty trash item %d", item.GetId())
+		}
+		entries = append(entries, client.TrashEntry{
+			DeletedAt: item.GetDeletedAt().AsTime(),
+			Data:      data,
+		})
+	}
+	return entries, nil
+}
+
+func (s *TrashService) Restore(ctx context.Context, kind client.DataKind, id int64) error {
+	in, err := newTrashItemRequest(kind, id)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.Restore(ctx, in)
+	return trashError(err)
+}
+
+func (s *TrashService) Purge(ctx context.Context, kind client.DataKind, id int64) error {
+	in, err := newTrashItemRequest(kind, id)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.Purge(ctx, in)
+	return trashError(err)
+}
+
+func newTrashItemRequest(kind client.DataKind, id int64) (*gophkeeperv1.TrashItemRequest, error) {
+	k, ok := dataKinds[kind]
+	if !ok {
+		return nil, fmt.Errorf("unknown data kind %q", kind)
+	}
+
+	var in gophkeeperv1.TrashItemRequest
+	in.SetKind(k)
+	in.SetId(id)
+	return &in, nil
+}
+
+func trashError(err error) error {
+	if status.Code(err) == codes.NotFound {
+		return client.ErrNotInTrash
+	}
+	return err
+}
@@ -0,0 +1,99 @@
+package grpc
+
+import (
+	"context"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/mkolibaba/gophkeeper/client"
+	"github.com/mkolibaba/gophkeeper/client/grpc/mock"
+	"github.com/mkolibaba/gophkeeper/proto/gen/go/gophkeeperv1"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"testing"
+	"time"
+)
+
+func TestTrashList(t *testing.T) {
+	deletedAt := time.Unix(1700000000, 0).UTC()
+	clientMock := &mock.TrashServiceClientMock{
+		ListTrashFunc: func(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*gophkeeperv1.ListTrashResponse, error) {
+			var card gophkeeperv1.Card
+			card.SetId(2)
+			card.SetName("card")
+			card.SetNumber("4111")
+			card.SetVersion(3)
+
+			var item gophkeeperv1.TrashItem
+			item.SetKind(gophkeeperv1.DataKind_DATA_KIND_CARD)
+			item.SetId(2)
+			item.SetDeletedAt(timestamppb.New(deletedAt))
+			item.SetCard(&card)
+
+			var out gophkeeperv1.ListTrashResponse
+			out.SetItems([]*gophkeeperv1.TrashItem{&item})
+			return &out, nil
+		},
+	}
+	srv := NewTrashService(clientMock)
+
+	entries, err := srv.List(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, []client.TrashEntry{
+		{
+			DeletedAt: deletedAt,
+			Data:      client.CardData{ID: 2, Name: "card", Number: "4111", Version: 3},
+		},
+	}, entries)
+}
+
+func TestTrashRestore(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		clientMock := &mock.TrashServiceClientMock{
+			RestoreFunc: func(ctx context.Context, in *gophkeeperv1.TrashItemRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+				return &empty.Empty{}, nil
+			},
+		}
+		srv := NewTrashService(clientMock)
+
+		require.NoError(t, srv.Restore(t.Context(), client.DataKindLogin, 5))
+
+		in := clientMock.RestoreCalls()[0].In
+		require.Equal(t, gophkeeperv1.DataKind_DATA_KIND_LOGIN, in.GetKind())
+		require.Equal(t, int64(5), in.GetId())
+	})
+	t.Run("not_in_trash", func(t *testing.T) {
+		clientMock := &mock.TrashServiceClientMock{
+			RestoreFunc: func(ctx context.Context, in *gophkeeperv1.TrashItemRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+				return nil, status.Error(codes.NotFound, "data not found")
+			},
+		}
+		srv := NewTrashService(clientMock)
+
+		err := srv.Restore(t.Context(), client.DataKindLogin, 5)
+		require.ErrorIs(t, err, client.ErrNotInTrash)
+	})
+}
+
+func TestTrashPurge(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		clientMock := &mock.TrashServiceClientMock{
+			PurgeFunc: func(ctx context.Context, in *gophkeeperv1.TrashItemRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+				return &empty.Empty{}, nil
+			},
+		}
+		srv := NewTrashService(clientMock)
+
+		require.NoError(t, srv.Purge(t.Context(), client.DataKindBinary, 7))
+
+		in := clientMock.PurgeCalls()[0].In
+		require.Equal(t, gophkeeperv1.DataKind_DATA_KIND_BINARY, in.GetKind())
+		require.Equal(t, int64(7), in.GetId())
+	})
+	t.Run("unknown_kind", func(t *testing.T) {
+		srv := NewTrashService(&mock.TrashServiceClientMock{})
+
+		require.Error(t, srv.Purge(t.Context(), "unknown", 7))
+	})
+}
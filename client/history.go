@@ -0,0 +1,33 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	ErrVersionNotFound = errors.New("version not found")
+	ErrContentRemoved  = errors.New("binary content was removed with the data")
+)
+
+// HistoryEntry - прежняя версия записи. Data содержит данные в том виде,
+// в котором их заменило изменение или удаление.
+type HistoryEntry struct {
+	Version int64
+	// Removed - версия удалена вместе с записью.
+	Removed   bool
+	CreatedAt time.Time
+	Data      Data
+}
+
+// HistoryService - сервис прежних версий записей. Работает только при связи
+// с сервером: история не кэшируется.
+type HistoryService interface {
+	// List возвращает версии записи id от новых к старым.
+	List(ctx context.Context, kind DataKind, id int64) ([]HistoryEntry, error)
+
+	// Restore делает версию записи текущей. Удаленная запись создается
+	// заново с новым идентификатором.
+	Restore(ctx context.Context, kind DataKind, id int64, version int64) error
+}
@@ -1,10 +1,15 @@
 package inmem
 
-import "github.com/charmbracelet/log"
+import (
+	"github.com/charmbracelet/log"
+	"github.com/mkolibaba/gophkeeper/client"
+	"sync"
+)
 
 type UserService struct {
-	login string
-	token string
+	mu     sync.RWMutex
+	login  string
+	tokens client.Tokens
 
 	logger *log.Logger
 }
@@ -13,15 +18,33 @@ func NewUserService(logger *log.Logger) *UserService {
 	return &UserService{logger: logger}
 }
 
-func (s *UserService) SetInfo(login, token string) {
+func (s *UserService) SetInfo(login string, tokens client.Tokens) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	s.login = login
-	s.token = token
+	s.tokens = tokens
+}
+
+func (s *UserService) SetTokens(tokens client.Tokens) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokens = tokens
 }
 
 func (s *UserService) GetUserLogin() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 	return s.login
 }
 
 func (s *UserService) GetBearerToken() string {
-	return s.token
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.tokens.AccessToken
+}
+
+func (s *UserService) GetRefreshToken() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.tokens.RefreshToken
 }
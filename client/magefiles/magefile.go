@@ -5,6 +5,7 @@ import (
 	"github.com/bitfield/script"
 	"github.com/magefile/mage/mg"
 	"github.com/magefile/mage/sh"
+	"github.com/mkolibaba/gophkeeper/shared/mage/buildinfo"
 	"github.com/mkolibaba/gophkeeper/shared/mage/gen"
 	"github.com/uwu-tools/magex/shx"
 	"os/exec"
@@ -26,7 +27,7 @@ var (
 // Run client build
 func Build() error {
 	return sh.RunV("go", "build",
-		"-ldflags", "-s -w",
+		"-ldflags", buildinfo.LDFlags(),
 		"-o", binaryPath(),
 		"cmd/client/main.go")
 }
@@ -6,11 +6,537 @@ package mock
 
 import (
 	"context"
+	"io"
 	"sync"
 
 	"github.com/mkolibaba/gophkeeper/client"
 )
 
+// Ensure that CipherMock does implement client.Cipher.
+// If this is not the case, regenerate this file with mockery.
+var _ client.Cipher = &CipherMock{}
+
+// CipherMock is a mock implementation of client.Cipher.
+//
+//	func TestSomethingThatUsesCipher(t *testing.T) {
+//
+//		// make and configure a mocked client.Cipher
+//		mockedCipher := &CipherMock{
+//			ContentKeyFunc: func(r io.Reader) ([]byte, error) {
+//				panic("mock out the ContentKey method")
+//			},
+//			DecryptFunc: func(ciphertext string, field client.Field) (string, error) {
+//				panic("mock out the Decrypt method")
+//			},
+//			DecryptStreamFunc: func(w io.Writer) (io.WriteCloser, error) {
+//				panic("mock out the DecryptStream method")
+//			},
+//			DeriveKeysFunc: func(login string, password string) (client.MasterKey, error) {
+//				panic("mock out the DeriveKeys method")
+//			},
+//			EncryptFunc: func(plaintext string, field client.Field) (string, error) {
+//				panic("mock out the Encrypt method")
+//			},
+//			EncryptStreamFunc: func(r io.Reader) (io.Reader, error) {
+//				panic("mock out the EncryptStream method")
+//			},
+//			ItemIDFunc: func(ciphertext string) ([]byte, error) {
+//				panic("mock out the ItemID method")
+//			},
+//			NewVaultKeyFunc: func(key client.MasterKey) ([]byte, error) {
+//				panic("mock out the NewVaultKey method")
+//			},
+//			UnlockFunc: func(key client.MasterKey, vaultKey []byte) error {
+//				panic("mock out the Unlock method")
+//			},
+//			WrapVaultKeyFunc: func(key client.MasterKey) ([]byte, error) {
+//				panic("mock out the WrapVaultKey method")
+//			},
+//		}
+//
+//		// use mockedCipher in code that requires client.Cipher
+//		// and then make assertions.
+//
+//	}
+type CipherMock struct {
+	// ContentKeyFunc mocks the ContentKey method.
+	ContentKeyFunc func(r io.Reader) ([]byte, error)
+
+	// DecryptFunc mocks the Decrypt method.
+	DecryptFunc func(ciphertext string, field client.Field) (string, error)
+
+	// DecryptStreamFunc mocks the DecryptStream method.
+	DecryptStreamFunc func(w io.Writer) (io.WriteCloser, error)
+
+	// DeriveKeysFunc mocks the DeriveKeys method.
+	DeriveKeysFunc func(login string, password string) (client.MasterKey, error)
+
+	// EncryptFunc mocks the Encrypt method.
+	EncryptFunc func(plaintext string, field client.Field) (string, error)
+
+	// EncryptStreamFunc mocks the EncryptStream method.
+	EncryptStreamFunc func(r io.Reader) (io.Reader, error)
+
+	// ItemIDFunc mocks the ItemID method.
+	ItemIDFunc func(ciphertext string) ([]byte, error)
+
+	// NewVaultKeyFunc mocks the NewVaultKey method.
+	NewVaultKeyFunc func(key client.MasterKey) ([]byte, error)
+
+	// UnlockFunc mocks the Unlock method.
+	UnlockFunc func(key client.MasterKey, vaultKey []byte) error
+
+	// WrapVaultKeyFunc mocks the WrapVaultKey method.
+	WrapVaultKeyFunc func(key client.MasterKey) ([]byte, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// ContentKey holds details about calls to the ContentKey method.
+		ContentKey []struct {
+			// R is the r argument value.
+			R io.Reader
+		}
+		// Decrypt holds details about calls to the Decrypt method.
+		Decrypt []struct {
+			// Ciphertext is the ciphertext argument value.
+			Ciphertext string
+			// Field is the field argument value.
+			Field client.Field
+		}
+		// DecryptStream holds details about calls to the DecryptStream method.
+		DecryptStream []struct {
+			// W is the w argument value.
+			W io.Writer
+		}
+		// DeriveKeys holds details about calls to the DeriveKeys method.
+		DeriveKeys []struct {
+			// Login is the login argument value.
+			Login string
+			// Password is the password argument value.
+			Password string
+		}
+		// Encrypt holds details about calls to the Encrypt method.
+		Encrypt []struct {
+			// Plaintext is the plaintext argument value.
+			Plaintext string
+			// Field is the field argument value.
+			Field client.Field
+		}
+		// EncryptStream holds details about calls to the EncryptStream method.
+		EncryptStream []struct {
+			// R is the r argument value.
+			R io.Reader
+		}
+		// ItemID holds details about calls to the ItemID method.
+		ItemID []struct {
+			// Ciphertext is the ciphertext argument value.
+			Ciphertext string
+		}
+		// NewVaultKey holds details about calls to the NewVaultKey method.
+		NewVaultKey []struct {
+			// Key is the key argument value.
+			Key client.MasterKey
+		}
+		// Unlock holds details about calls to the Unlock method.
+		Unlock []struct {
+			// Key is the key argument value.
+			Key client.MasterKey
+			// VaultKey is the vaultKey argument value.
+			VaultKey []byte
+		}
+		// WrapVaultKey holds details about calls to the WrapVaultKey method.
+		WrapVaultKey []struct {
+			// Key is the key argument value.
+			Key client.MasterKey
+		}
+	}
+	lockContentKey    sync.RWMutex
+	lockDecrypt       sync.RWMutex
+	lockDecryptStream sync.RWMutex
+	lockDeriveKeys    sync.RWMutex
+	lockEncrypt       sync.RWMutex
+	lockEncryptStream sync.RWMutex
+	lockItemID        sync.RWMutex
+	lockNewVaultKey   sync.RWMutex
+	lockUnlock        sync.RWMutex
+	lockWrapVaultKey  sync.RWMutex
+}
+
+// ContentKey calls ContentKeyFunc.
+func (mock *CipherMock) ContentKey(r io.Reader) ([]byte, error) {
+	callInfo := struct {
+		R io.Reader
+	}{
+		R: r,
+	}
+	mock.lockContentKey.Lock()
+	mock.calls.ContentKey = append(mock.calls.ContentKey, callInfo)
+	mock.lockContentKey.Unlock()
+	if mock.ContentKeyFunc == nil {
+		var (
+			bytes []byte
+			err   error
+		)
+		return bytes, err
+	}
+	return mock.ContentKeyFunc(r)
+}
+
+// ContentKeyCalls gets all the calls that were made to ContentKey.
+// Check the length with:
+//
+//	len(mockedCipher.ContentKeyCalls())
+func (mock *CipherMock) ContentKeyCalls() []struct {
+	R io.Reader
+} {
+	var calls []struct {
+		R io.Reader
+	}
+	mock.lockContentKey.RLock()
+	calls = mock.calls.ContentKey
+	mock.lockContentKey.RUnlock()
+	return calls
+}
+
+// Decrypt calls DecryptFunc.
+func (mock *CipherMock) Decrypt(ciphertext string, field client.Field) (string, error) {
+	callInfo := struct {
+		Ciphertext string
+		Field      client.Field
+	}{
+		Ciphertext: ciphertext,
+		Field:      field,
+	}
+	mock.lockDecrypt.Lock()
+	mock.calls.Decrypt = append(mock.calls.Decrypt, callInfo)
+	mock.lockDecrypt.Unlock()
+	if mock.DecryptFunc == nil {
+		var (
+			s   string
+			err error
+		)
+		return s, err
+	}
+	return mock.DecryptFunc(ciphertext, field)
+}
+
+// DecryptCalls gets all the calls that were made to Decrypt.
+// Check the length with:
+//
+//	len(mockedCipher.DecryptCalls())
+func (mock *CipherMock) DecryptCalls() []struct {
+	Ciphertext string
+	Field      client.Field
+} {
+	var calls []struct {
+		Ciphertext string
+		Field      client.Field
+	}
+	mock.lockDecrypt.RLock()
+	calls = mock.calls.Decrypt
+	mock.lockDecrypt.RUnlock()
+	return calls
+}
+
+// DecryptStream calls DecryptStreamFunc.
+func (mock *CipherMock) DecryptStream(w io.Writer) (io.WriteCloser, error) {
+	callInfo := struct {
+		W io.Writer
+	}{
+		W: w,
+	}
+	mock.lockDecryptStream.Lock()
+	mock.calls.DecryptStream = append(mock.calls.DecryptStream, callInfo)
+	mock.lockDecryptStream.Unlock()
+	if mock.DecryptStreamFunc == nil {
+		var (
+			writeCloser io.WriteCloser
+			err         error
+		)
+		return writeCloser, err
+	}
+	return mock.DecryptStreamFunc(w)
+}
+
+// DecryptStreamCalls gets all the calls that were made to DecryptStream.
+// Check the length with:
+//
+//	len(mockedCipher.DecryptStreamCalls())
+func (mock *CipherMock) DecryptStreamCalls() []struct {
+	W io.Writer
+} {
+	var calls []struct {
+		W io.Writer
+	}
+	mock.lockDecryptStream.RLock()
+	calls = mock.calls.DecryptStream
+	mock.lockDecryptStream.RUnlock()
+	return calls
+}
+
+// DeriveKeys calls DeriveKeysFunc.
+func (mock *CipherMock) DeriveKeys(login string, password string) (client.MasterKey, error) {
+	callInfo := struct {
+		Login    string
+		Password string
+	}{
+		Login:    login,
+		Password: password,
+	}
+	mock.lockDeriveKeys.Lock()
+	mock.calls.DeriveKeys = append(mock.calls.DeriveKeys, callInfo)
+	mock.lockDeriveKeys.Unlock()
+	if mock.DeriveKeysFunc == nil {
+		var (
+			masterKey client.MasterKey
+			err       error
+		)
+		return masterKey, err
+	}
+	return mock.DeriveKeysFunc(login, password)
+}
+
+// DeriveKeysCalls gets all the calls that were made to DeriveKeys.
+// Check the length with:
+//
+//	len(mockedCipher.DeriveKeysCalls())
+func (mock *CipherMock) DeriveKeysCalls() []struct {
+	Login    string
+	Password string
+} {
+	var calls []struct {
+		Login    string
+		Password string
+	}
+	mock.lockDeriveKeys.RLock()
+	calls = mock.calls.DeriveKeys
+	mock.lockDeriveKeys.RUnlock()
+	return calls
+}
+
+// Encrypt calls EncryptFunc.
+func (mock *CipherMock) Encrypt(plaintext string, field client.Field) (string, error) {
+	callInfo := struct {
+		Plaintext string
+		Field     client.Field
+	}{
+		Plaintext: plaintext,
+		Field:     field,
+	}
+	mock.lockEncrypt.Lock()
+	mock.calls.Encrypt = append(mock.calls.Encrypt, callInfo)
+	mock.lockEncrypt.Unlock()
+	if mock.EncryptFunc == nil {
+		var (
+			s   string
+			err error
+		)
+		return s, err
+	}
+	return mock.EncryptFunc(plaintext, field)
+}
+
+// EncryptCalls gets all the calls that were made to Encrypt.
+// Check the length with:
+//
+//	len(mockedCipher.EncryptCalls())
+func (mock *CipherMock) EncryptCalls() []struct {
+	Plaintext string
+	Field     client.Field
+} {
+	var calls []struct {
+		Plaintext string
+		Field     client.Field
+	}
+	mock.lockEncrypt.RLock()
+	calls = mock.calls.Encrypt
+	mock.lockEncrypt.RUnlock()
+	return calls
+}
+
+// EncryptStream calls EncryptStreamFunc.
+func (mock *CipherMock) EncryptStream(r io.Reader) (io.Reader, error) {
+	callInfo := struct {
+		R io.Reader
+	}{
+		R: r,
+	}
+	mock.lockEncryptStream.Lock()
+	mock.calls.EncryptStream = append(mock.calls.EncryptStream, callInfo)
+	mock.lockEncryptStream.Unlock()
+	if mock.EncryptStreamFunc == nil {
+		var (
+			reader io.Reader
+			err    error
+		)
+		return reader, err
+	}
+	return mock.EncryptStreamFunc(r)
+}
+
+// EncryptStreamCalls gets all the calls that were made to EncryptStream.
+// Check the length with:
+//
+//	len(mockedCipher.EncryptStreamCalls())
+func (mock *CipherMock) EncryptStreamCalls() []struct {
+	R io.Reader
+} {
+	var calls []struct {
+		R io.Reader
+	}
+	mock.lockEncryptStream.RLock()
+	calls = mock.calls.EncryptStream
+	mock.lockEncryptStream.RUnlock()
+	return calls
+}
+
+// ItemID calls ItemIDFunc.
+func (mock *CipherMock) ItemID(ciphertext string) ([]byte, error) {
+	callInfo := struct {
+		Ciphertext string
+	}{
+		Ciphertext: ciphertext,
+	}
+	mock.lockItemID.Lock()
+	mock.calls.ItemID = append(mock.calls.ItemID, callInfo)
+	mock.lockItemID.Unlock()
+	if mock.ItemIDFunc == nil {
+		var (
+			bytes []byte
+			err   error
+		)
+		return bytes, err
+	}
+	return mock.ItemIDFunc(ciphertext)
+}
+
+// ItemIDCalls gets all the calls that were made to ItemID.
+// Check the length with:
+//
+//	len(mockedCipher.ItemIDCalls())
+func (mock *CipherMock) ItemIDCalls() []struct {
+	Ciphertext string
+} {
+	var calls []struct {
+		Ciphertext string
+	}
+	mock.lockItemID.RLock()
+	calls = mock.calls.ItemID
+	mock.lockItemID.RUnlock()
+	return calls
+}
+
+// NewVaultKey calls NewVaultKeyFunc.
+func (mock *CipherMock) NewVaultKey(key client.MasterKey) ([]byte, error) {
+	callInfo := struct {
+		Key client.MasterKey
+	}{
+		Key: key,
+	}
+	mock.lockNewVaultKey.Lock()
+	mock.calls.NewVaultKey = append(mock.calls.NewVaultKey, callInfo)
+	mock.lockNewVaultKey.Unlock()
+	if mock.NewVaultKeyFunc == nil {
+		var (
+			bytes []byte
+			err   error
+		)
+		return bytes, err
+	}
+	return mock.NewVaultKeyFunc(key)
+}
+
+// NewVaultKeyCalls gets all the calls that were made to NewVaultKey.
+// Check the length with:
+//
+//	len(mockedCipher.NewVaultKeyCalls())
+func (mock *CipherMock) NewVaultKeyCalls() []struct {
+	Key client.MasterKey
+} {
+	var calls []struct {
+		Key client.MasterKey
+	}
+	mock.lockNewVaultKey.RLock()
+	calls = mock.calls.NewVaultKey
+	mock.lockNewVaultKey.RUnlock()
+	return calls
+}
+
+// Unlock calls UnlockFunc.
+func (mock *CipherMock) Unlock(key client.MasterKey, vaultKey []byte) error {
+	callInfo := struct {
+		Key      client.MasterKey
+		VaultKey []byte
+	}{
+		Key:      key,
+		VaultKey: vaultKey,
+	}
+	mock.lockUnlock.Lock()
+	mock.calls.Unlock = append(mock.calls.Unlock, callInfo)
+	mock.lockUnlock.Unlock()
+	if mock.UnlockFunc == nil {
+		var (
+			err error
+		)
+		return err
+	}
+	return mock.UnlockFunc(key, vaultKey)
+}
+
+// UnlockCalls gets all the calls that were made to Unlock.
+// Check the length with:
+//
+//	len(mockedCipher.UnlockCalls())
+func (mock *CipherMock) UnlockCalls() []struct {
+	Key      client.MasterKey
+	VaultKey []byte
+} {
+	var calls []struct {
+		Key      client.MasterKey
+		VaultKey []byte
+	}
+	mock.lockUnlock.RLock()
+	calls = mock.calls.Unlock
+	mock.lockUnlock.RUnlock()
+	return calls
+}
+
+// WrapVaultKey calls WrapVaultKeyFunc.
+func (mock *CipherMock) WrapVaultKey(key client.MasterKey) ([]byte, error) {
+	callInfo := struct {
+		Key client.MasterKey
+	}{
+		Key: key,
+	}
+	mock.lockWrapVaultKey.Lock()
+	mock.calls.WrapVaultKey = append(mock.calls.WrapVaultKey, callInfo)
+	mock.lockWrapVaultKey.Unlock()
+	if mock.WrapVaultKeyFunc == nil {
+		var (
+			bytes []byte
+			err   error
+		)
+		return bytes, err
+	}
+	return mock.WrapVaultKeyFunc(key)
+}
+
+// WrapVaultKeyCalls gets all the calls that were made to WrapVaultKey.
+// Check the length with:
+//
+//	len(mockedCipher.WrapVaultKeyCalls())
+func (mock *CipherMock) WrapVaultKeyCalls() []struct {
+	Key client.MasterKey
+} {
+	var calls []struct {
+		Key client.MasterKey
+	}
+	mock.lockWrapVaultKey.RLock()
+	calls = mock.calls.WrapVaultKey
+	mock.lockWrapVaultKey.RUnlock()
+	return calls
+}
+
 // Ensure that LoginServiceMock does implement client.LoginService.
 // If this is not the case, regenerate this file with mockery.
 var _ client.LoginService = &LoginServiceMock{}
@@ -24,7 +550,7 @@ var _ client.LoginService = &LoginServiceMock{}
 //			GetAllFunc: func(ctx context.Context) ([]client.LoginData, error) {
 //				panic("mock out the GetAll method")
 //			},
-//			RemoveFunc: func(ctx context.Context, id int64) error {
+//			RemoveFunc: func(ctx context.Context, id int64, version int64) error {
 //				panic("mock out the Remove method")
 //			},
 //			SaveFunc: func(ctx context.Context, data client.LoginData) error {
@@ -44,7 +570,7 @@ type LoginServiceMock struct {
 	GetAllFunc func(ctx context.Context) ([]client.LoginData, error)
 
 	// RemoveFunc mocks the Remove method.
-	RemoveFunc func(ctx context.Context, id int64) error
+	RemoveFunc func(ctx context.Context, id int64, version int64) error
 
 	// SaveFunc mocks the Save method.
 	SaveFunc func(ctx context.Context, data client.LoginData) error
@@ -65,6 +591,8 @@ type LoginServiceMock struct {
 			Ctx context.Context
 			// ID is the id argument value.
 			ID int64
+			// Version is the version argument value.
+			Version int64
 		}
 		// Save holds details about calls to the Save method.
 		Save []struct {
@@ -124,13 +652,15 @@ func (mock *LoginServiceMock) GetAllCalls() []struct {
 }
 
 // Remove calls RemoveFunc.
-func (mock *LoginServiceMock) Remove(ctx context.Context, id int64) error {
+func (mock *LoginServiceMock) Remove(ctx context.Context, id int64, version int64) error {
 	callInfo := struct {
-		Ctx context.Context
-		ID  int64
+		Ctx     context.Context
+		ID      int64
+		Version int64
 	}{
-		Ctx: ctx,
-		ID:  id,
+		Ctx:     ctx,
+		ID:      id,
+		Version: version,
 	}
 	mock.lockRemove.Lock()
 	mock.calls.Remove = append(mock.calls.Remove, callInfo)
@@ -141,7 +671,7 @@ func (mock *LoginServiceMock) Remove(ctx context.Context, id int64) error {
 		)
 		return err
 	}
-	return mock.RemoveFunc(ctx, id)
+	return mock.RemoveFunc(ctx, id, version)
 }
 
 // RemoveCalls gets all the calls that were made to Remove.
@@ -149,12 +679,14 @@ func (mock *LoginServiceMock) Remove(ctx context.Context, id int64) error {
 //
 //	len(mockedLoginService.RemoveCalls())
 func (mock *LoginServiceMock) RemoveCalls() []struct {
-	Ctx context.Context
-	ID  int64
+	Ctx     context.Context
+	ID      int64
+	Version int64
 } {
 	var calls []struct {
-		Ctx context.Context
-		ID  int64
+		Ctx     context.Context
+		ID      int64
+		Version int64
 	}
 	mock.lockRemove.RLock()
 	calls = mock.calls.Remove
@@ -253,7 +785,7 @@ var _ client.NoteService = &NoteServiceMock{}
 //			GetAllFunc: func(ctx context.Context) ([]client.NoteData, error) {
 //				panic("mock out the GetAll method")
 //			},
-//			RemoveFunc: func(ctx context.Context, id int64) error {
+//			RemoveFunc: func(ctx context.Context, id int64, version int64) error {
 //				panic("mock out the Remove method")
 //			},
 //			SaveFunc: func(ctx context.Context, data client.NoteData) error {
@@ -273,7 +805,7 @@ type NoteServiceMock struct {
 	GetAllFunc func(ctx context.Context) ([]client.NoteData, error)
 
 	// RemoveFunc mocks the Remove method.
-	RemoveFunc func(ctx context.Context, id int64) error
+	RemoveFunc func(ctx context.Context, id int64, version int64) error
 
 	// SaveFunc mocks the Save method.
 	SaveFunc func(ctx context.Context, data client.NoteData) error
@@ -294,6 +826,8 @@ type NoteServiceMock struct {
 			Ctx context.Context
 			// ID is the id argument value.
 			ID int64
+			// Version is the version argument value.
+			Version int64
 		}
 		// Save holds details about calls to the Save method.
 		Save []struct {
@@ -353,13 +887,15 @@ func (mock *NoteServiceMock) GetAllCalls() []struct {
 }
 
 // Remove calls RemoveFunc.
-func (mock *NoteServiceMock) Remove(ctx context.Context, id int64) error {
+func (mock *NoteServiceMock) Remove(ctx context.Context, id int64, version int64) error {
 	callInfo := struct {
-		Ctx context.Context
-		ID  int64
+		Ctx     context.Context
+		ID      int64
+		Version int64
 	}{
-		Ctx: ctx,
-		ID:  id,
+		Ctx:     ctx,
+		ID:      id,
+		Version: version,
 	}
 	mock.lockRemove.Lock()
 	mock.calls.Remove = append(mock.calls.Remove, callInfo)
@@ -370,7 +906,7 @@ func (mock *NoteServiceMock) Remove(ctx context.Context, id int64) error {
 		)
 		return err
 	}
-	return mock.RemoveFunc(ctx, id)
+	return mock.RemoveFunc(ctx, id, version)
 }
 
 // RemoveCalls gets all the calls that were made to Remove.
@@ -378,12 +914,14 @@ func (mock *NoteServiceMock) Remove(ctx context.Context, id int64) error {
 //
 //	len(mockedNoteService.RemoveCalls())
 func (mock *NoteServiceMock) RemoveCalls() []struct {
-	Ctx context.Context
-	ID  int64
+	Ctx     context.Context
+	ID      int64
+	Version int64
 } {
 	var calls []struct {
-		Ctx context.Context
-		ID  int64
+		Ctx     context.Context
+		ID      int64
+		Version int64
 	}
 	mock.lockRemove.RLock()
 	calls = mock.calls.Remove
@@ -479,13 +1017,13 @@ var _ client.BinaryService = &BinaryServiceMock{}
 //
 //		// make and configure a mocked client.BinaryService
 //		mockedBinaryService := &BinaryServiceMock{
-//			DownloadFunc: func(ctx context.Context, id int64) error {
+//			DownloadFunc: func(ctx context.Context, id int64) (string, error) {
 //				panic("mock out the Download method")
 //			},
 //			GetAllFunc: func(ctx context.Context) ([]client.BinaryData, error) {
 //				panic("mock out the GetAll method")
 //			},
-//			RemoveFunc: func(ctx context.Context, id int64) error {
+//			RemoveFunc: func(ctx context.Context, id int64, version int64) error {
 //				panic("mock out the Remove method")
 //			},
 //			SaveFunc: func(ctx context.Context, data client.BinaryData) error {
@@ -502,13 +1040,13 @@ var _ client.BinaryService = &BinaryServiceMock{}
 //	}
 type BinaryServiceMock struct {
 	// DownloadFunc mocks the Download method.
-	DownloadFunc func(ctx context.Context, id int64) error
+	DownloadFunc func(ctx context.Context, id int64) (string, error)
 
 	// GetAllFunc mocks the GetAll method.
 	GetAllFunc func(ctx context.Context) ([]client.BinaryData, error)
 
 	// RemoveFunc mocks the Remove method.
-	RemoveFunc func(ctx context.Context, id int64) error
+	RemoveFunc func(ctx context.Context, id int64, version int64) error
 
 	// SaveFunc mocks the Save method.
 	SaveFunc func(ctx context.Context, data client.BinaryData) error
@@ -536,6 +1074,8 @@ type BinaryServiceMock struct {
 			Ctx context.Context
 			// ID is the id argument value.
 			ID int64
+			// Version is the version argument value.
+			Version int64
 		}
 		// Save holds details about calls to the Save method.
 		Save []struct {
@@ -560,7 +1100,7 @@ type BinaryServiceMock struct {
 }
 
 // Download calls DownloadFunc.
-func (mock *BinaryServiceMock) Download(ctx context.Context, id int64) error {
+func (mock *BinaryServiceMock) Download(ctx context.Context, id int64) (string, error) {
 	callInfo := struct {
 		Ctx context.Context
 		ID  int64
@@ -573,9 +1113,10 @@ func (mock *BinaryServiceMock) Download(ctx context.Context, id int64) error {
 	mock.lockDownload.Unlock()
 	if mock.DownloadFunc == nil {
 		var (
+			s   string
 			err error
 		)
-		return err
+		return s, err
 	}
 	return mock.DownloadFunc(ctx, id)
 }
@@ -635,13 +1176,15 @@ func (mock *BinaryServiceMock) GetAllCalls() []struct {
 }
 
 // Remove calls RemoveFunc.
-func (mock *BinaryServiceMock) Remove(ctx context.Context, id int64) error {
+func (mock *BinaryServiceMock) Remove(ctx context.Context, id int64, version int64) error {
 	callInfo := struct {
-		Ctx context.Context
-		ID  int64
+		Ctx     context.Context
+		ID      int64
+		Version int64
 	}{
-		Ctx: ctx,
-		ID:  id,
+		Ctx:     ctx,
+		ID:      id,
+		Version: version,
 	}
 	mock.lockRemove.Lock()
 	mock.calls.Remove = append(mock.calls.Remove, callInfo)
@@ -652,7 +1195,7 @@ func (mock *BinaryServiceMock) Remove(ctx context.Context, id int64) error {
 		)
 		return err
 	}
-	return mock.RemoveFunc(ctx, id)
+	return mock.RemoveFunc(ctx, id, version)
 }
 
 // RemoveCalls gets all the calls that were made to Remove.
@@ -660,12 +1203,14 @@ func (mock *BinaryServiceMock) Remove(ctx context.Context, id int64) error {
 //
 //	len(mockedBinaryService.RemoveCalls())
 func (mock *BinaryServiceMock) RemoveCalls() []struct {
-	Ctx context.Context
-	ID  int64
+	Ctx     context.Context
+	ID      int64
+	Version int64
 } {
 	var calls []struct {
-		Ctx context.Context
-		ID  int64
+		Ctx     context.Context
+		ID      int64
+		Version int64
 	}
 	mock.lockRemove.RLock()
 	calls = mock.calls.Remove
@@ -764,7 +1309,7 @@ var _ client.CardService = &CardServiceMock{}
 //			GetAllFunc: func(ctx context.Context) ([]client.CardData, error) {
 //				panic("mock out the GetAll method")
 //			},
-//			RemoveFunc: func(ctx context.Context, id int64) error {
+//			RemoveFunc: func(ctx context.Context, id int64, version int64) error {
 //				panic("mock out the Remove method")
 //			},
 //			SaveFunc: func(ctx context.Context, data client.CardData) error {
@@ -784,7 +1329,7 @@ type CardServiceMock struct {
 	GetAllFunc func(ctx context.Context) ([]client.CardData, error)
 
 	// RemoveFunc mocks the Remove method.
-	RemoveFunc func(ctx context.Context, id int64) error
+	RemoveFunc func(ctx context.Context, id int64, version int64) error
 
 	// SaveFunc mocks the Save method.
 	SaveFunc func(ctx context.Context, data client.CardData) error
@@ -805,6 +1350,8 @@ type CardServiceMock struct {
 			Ctx context.Context
 			// ID is the id argument value.
 			ID int64
+			// Version is the version argument value.
+			Version int64
 		}
 		// Save holds details about calls to the Save method.
 		Save []struct {
@@ -864,13 +1411,15 @@ func (mock *CardServiceMock) GetAllCalls() []struct {
 }
 
 // Remove calls RemoveFunc.
-func (mock *CardServiceMock) Remove(ctx context.Context, id int64) error {
+func (mock *CardServiceMock) Remove(ctx context.Context, id int64, version int64) error {
 	callInfo := struct {
-		Ctx context.Context
-		ID  int64
+		Ctx     context.Context
+		ID      int64
+		Version int64
 	}{
-		Ctx: ctx,
-		ID:  id,
+		Ctx:     ctx,
+		ID:      id,
+		Version: version,
 	}
 	mock.lockRemove.Lock()
 	mock.calls.Remove = append(mock.calls.Remove, callInfo)
@@ -881,7 +1430,7 @@ func (mock *CardServiceMock) Remove(ctx context.Context, id int64) error {
 		)
 		return err
 	}
-	return mock.RemoveFunc(ctx, id)
+	return mock.RemoveFunc(ctx, id, version)
 }
 
 // RemoveCalls gets all the calls that were made to Remove.
@@ -889,12 +1438,14 @@ func (mock *CardServiceMock) Remove(ctx context.Context, id int64) error {
 //
 //	len(mockedCardService.RemoveCalls())
 func (mock *CardServiceMock) RemoveCalls() []struct {
-	Ctx context.Context
-	ID  int64
+	Ctx     context.Context
+	ID      int64
+	Version int64
 } {
 	var calls []struct {
-		Ctx context.Context
-		ID  int64
+		Ctx     context.Context
+		ID      int64
+		Version int64
 	}
 	mock.lockRemove.RLock()
 	calls = mock.calls.Remove
@@ -980,221 +1531,1124 @@ func (mock *CardServiceMock) UpdateCalls() []struct {
 	return calls
 }
 
-// Ensure that AuthorizationServiceMock does implement client.AuthorizationService.
+// Ensure that OTPServiceMock does implement client.OTPService.
 // If this is not the case, regenerate this file with mockery.
-var _ client.AuthorizationService = &AuthorizationServiceMock{}
+var _ client.OTPService = &OTPServiceMock{}
 
-// AuthorizationServiceMock is a mock implementation of client.AuthorizationService.
+// OTPServiceMock is a mock implementation of client.OTPService.
 //
-//	func TestSomethingThatUsesAuthorizationService(t *testing.T) {
+//	func TestSomethingThatUsesOTPService(t *testing.T) {
 //
-//		// make and configure a mocked client.AuthorizationService
-//		mockedAuthorizationService := &AuthorizationServiceMock{
-//			AuthorizeFunc: func(ctx context.Context, login string, password string) (string, error) {
-//				panic("mock out the Authorize method")
+//		// make and configure a mocked client.OTPService
+//		mockedOTPService := &OTPServiceMock{
+//			GetAllFunc: func(ctx context.Context) ([]client.OTPData, error) {
+//				panic("mock out the GetAll method")
 //			},
-//			RegisterFunc: func(ctx context.Context, login string, password string) (string, error) {
-//				panic("mock out the Register method")
+//			RemoveFunc: func(ctx context.Context, id int64, version int64) error {
+//				panic("mock out the Remove method")
+//			},
+//			SaveFunc: func(ctx context.Context, data client.OTPData) error {
+//				panic("mock out the Save method")
+//			},
+//			UpdateFunc: func(ctx context.Context, data client.OTPDataUpdate) error {
+//				panic("mock out the Update method")
 //			},
 //		}
 //
-//		// use mockedAuthorizationService in code that requires client.AuthorizationService
+//		// use mockedOTPService in code that requires client.OTPService
 //		// and then make assertions.
 //
 //	}
-type AuthorizationServiceMock struct {
-	// AuthorizeFunc mocks the Authorize method.
-	AuthorizeFunc func(ctx context.Context, login string, password string) (string, error)
+type OTPServiceMock struct {
+	// GetAllFunc mocks the GetAll method.
+	GetAllFunc func(ctx context.Context) ([]client.OTPData, error)
 
-	// RegisterFunc mocks the Register method.
-	RegisterFunc func(ctx context.Context, login string, password string) (string, error)
+	// RemoveFunc mocks the Remove method.
+	RemoveFunc func(ctx context.Context, id int64, version int64) error
+
+	// SaveFunc mocks the Save method.
+	SaveFunc func(ctx context.Context, data client.OTPData) error
+
+	// UpdateFunc mocks the Update method.
+	UpdateFunc func(ctx context.Context, data client.OTPDataUpdate) error
 
 	// calls tracks calls to the methods.
 	calls struct {
-		// Authorize holds details about calls to the Authorize method.
-		Authorize []struct {
+		// GetAll holds details about calls to the GetAll method.
+		GetAll []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
-			// Login is the login argument value.
-			Login string
-			// Password is the password argument value.
-			Password string
 		}
-		// Register holds details about calls to the Register method.
-		Register []struct {
+		// Remove holds details about calls to the Remove method.
+		Remove []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
-			// Login is the login argument value.
-			Login string
-			// Password is the password argument value.
-			Password string
+			// ID is the id argument value.
+			ID int64
+			// Version is the version argument value.
+			Version int64
+		}
+		// Save holds details about calls to the Save method.
+		Save []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Data is the data argument value.
+			Data client.OTPData
+		}
+		// Update holds details about calls to the Update method.
+		Update []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Data is the data argument value.
+			Data client.OTPDataUpdate
 		}
 	}
-	lockAuthorize sync.RWMutex
-	lockRegister  sync.RWMutex
+	lockGetAll sync.RWMutex
+	lockRemove sync.RWMutex
+	lockSave   sync.RWMutex
+	lockUpdate sync.RWMutex
 }
 
-// Authorize calls AuthorizeFunc.
-func (mock *AuthorizationServiceMock) Authorize(ctx context.Context, login string, password string) (string, error) {
+// GetAll calls GetAllFunc.
+func (mock *OTPServiceMock) GetAll(ctx context.Context) ([]client.OTPData, error) {
 	callInfo := struct {
-		Ctx      context.Context
-		Login    string
-		Password string
+		Ctx context.Context
 	}{
-		Ctx:      ctx,
-		Login:    login,
-		Password: password,
+		Ctx: ctx,
 	}
-	mock.lockAuthorize.Lock()
-	mock.calls.Authorize = append(mock.calls.Authorize, callInfo)
-	mock.lockAuthorize.Unlock()
-	if mock.AuthorizeFunc == nil {
+	mock.lockGetAll.Lock()
+	mock.calls.GetAll = append(mock.calls.GetAll, callInfo)
+	mock.lockGetAll.Unlock()
+	if mock.GetAllFunc == nil {
+		var (
+			oTPDatas []client.OTPData
+			err      error
+		)
+		return oTPDatas, err
+	}
+	return mock.GetAllFunc(ctx)
+}
+
+// GetAllCalls gets all the calls that were made to GetAll.
+// Check the length with:
+//
+//	len(mockedOTPService.GetAllCalls())
+func (mock *OTPServiceMock) GetAllCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockGetAll.RLock()
+	calls = mock.calls.GetAll
+	mock.lockGetAll.RUnlock()
+	return calls
+}
+
+// Remove calls RemoveFunc.
+func (mock *OTPServiceMock) Remove(ctx context.Context, id int64, version int64) error {
+	callInfo := struct {
+		Ctx     context.Context
+		ID      int64
+		Version int64
+	}{
+		Ctx:     ctx,
+		ID:      id,
+		Version: version,
+	}
+	mock.lockRemove.Lock()
+	mock.calls.Remove = append(mock.calls.Remove, callInfo)
+	mock.lockRemove.Unlock()
+	if mock.RemoveFunc == nil {
 		var (
-			s   string
 			err error
 		)
-		return s, err
+		return err
 	}
-	return mock.AuthorizeFunc(ctx, login, password)
+	return mock.RemoveFunc(ctx, id, version)
 }
 
-// AuthorizeCalls gets all the calls that were made to Authorize.
+// RemoveCalls gets all the calls that were made to Remove.
 // Check the length with:
 //
-//	len(mockedAuthorizationService.AuthorizeCalls())
-func (mock *AuthorizationServiceMock) AuthorizeCalls() []struct {
-	Ctx      context.Context
-	Login    string
-	Password string
+//	len(mockedOTPService.RemoveCalls())
+func (mock *OTPServiceMock) RemoveCalls() []struct {
+	Ctx     context.Context
+	ID      int64
+	Version int64
 } {
 	var calls []struct {
-		Ctx      context.Context
-		Login    string
-		Password string
+		Ctx     context.Context
+		ID      int64
+		Version int64
 	}
-	mock.lockAuthorize.RLock()
-	calls = mock.calls.Authorize
-	mock.lockAuthorize.RUnlock()
+	mock.lockRemove.RLock()
+	calls = mock.calls.Remove
+	mock.lockRemove.RUnlock()
 	return calls
 }
 
-// Register calls RegisterFunc.
-func (mock *AuthorizationServiceMock) Register(ctx context.Context, login string, password string) (string, error) {
+// Save calls SaveFunc.
+func (mock *OTPServiceMock) Save(ctx context.Context, data client.OTPData) error {
 	callInfo := struct {
-		Ctx      context.Context
-		Login    string
-		Password string
+		Ctx  context.Context
+		Data client.OTPData
 	}{
-		Ctx:      ctx,
-		Login:    login,
-		Password: password,
+		Ctx:  ctx,
+		Data: data,
 	}
-	mock.lockRegister.Lock()
-	mock.calls.Register = append(mock.calls.Register, callInfo)
-	mock.lockRegister.Unlock()
-	if mock.RegisterFunc == nil {
+	mock.lockSave.Lock()
+	mock.calls.Save = append(mock.calls.Save, callInfo)
+	mock.lockSave.Unlock()
+	if mock.SaveFunc == nil {
 		var (
-			s   string
 			err error
 		)
-		return s, err
+		return err
 	}
-	return mock.RegisterFunc(ctx, login, password)
+	return mock.SaveFunc(ctx, data)
 }
 
-// RegisterCalls gets all the calls that were made to Register.
+// SaveCalls gets all the calls that were made to Save.
 // Check the length with:
 //
-//	len(mockedAuthorizationService.RegisterCalls())
-func (mock *AuthorizationServiceMock) RegisterCalls() []struct {
-	Ctx      context.Context
-	Login    string
-	Password string
+//	len(mockedOTPService.SaveCalls())
+func (mock *OTPServiceMock) SaveCalls() []struct {
+	Ctx  context.Context
+	Data client.OTPData
 } {
 	var calls []struct {
-		Ctx      context.Context
-		Login    string
-		Password string
+		Ctx  context.Context
+		Data client.OTPData
 	}
-	mock.lockRegister.RLock()
-	calls = mock.calls.Register
-	mock.lockRegister.RUnlock()
+	mock.lockSave.RLock()
+	calls = mock.calls.Save
+	mock.lockSave.RUnlock()
 	return calls
 }
 
-// Ensure that UserServiceMock does implement client.UserService.
+// Update calls UpdateFunc.
+func (mock *OTPServiceMock) Update(ctx context.Context, data client.OTPDataUpdate) error {
+	callInfo := struct {
+		Ctx  context.Context
+		Data client.OTPDataUpdate
+	}{
+		Ctx:  ctx,
+		Data: data,
+	}
+	mock.lockUpdate.Lock()
+	mock.calls.Update = append(mock.calls.Update, callInfo)
+	mock.lockUpdate.Unlock()
+	if mock.UpdateFunc == nil {
+		var (
+			err error
+		)
+		return err
+	}
+	return mock.UpdateFunc(ctx, data)
+}
+
+// UpdateCalls gets all the calls that were made to Update.
+// Check the length with:
+//
+//	len(mockedOTPService.UpdateCalls())
+func (mock *OTPServiceMock) UpdateCalls() []struct {
+	Ctx  context.Context
+	Data client.OTPDataUpdate
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Data client.OTPDataUpdate
+	}
+	mock.lockUpdate.RLock()
+	calls = mock.calls.Update
+	mock.lockUpdate.RUnlock()
+	return calls
+}
+
+// Ensure that HistoryServiceMock does implement client.HistoryService.
 // If this is not the case, regenerate this file with mockery.
-var _ client.UserService = &UserServiceMock{}
+var _ client.HistoryService = &HistoryServiceMock{}
 
-// UserServiceMock is a mock implementation of client.UserService.
+// HistoryServiceMock is a mock implementation of client.HistoryService.
 //
-//	func TestSomethingThatUsesUserService(t *testing.T) {
+//	func TestSomethingThatUsesHistoryService(t *testing.T) {
 //
-//		// make and configure a mocked client.UserService
-//		mockedUserService := &UserServiceMock{
-//			GetBearerTokenFunc: func() string {
-//				panic("mock out the GetBearerToken method")
+//		// make and configure a mocked client.HistoryService
+//		mockedHistoryService := &HistoryServiceMock{
+//			ListFunc: func(ctx context.Context, kind client.DataKind, id int64) ([]client.HistoryEntry, error) {
+//				panic("mock out the List method")
 //			},
-//			GetUserLoginFunc: func() string {
-//				panic("mock out the GetUserLogin method")
-//			},
-//			SetInfoFunc: func(login string, token string)  {
-//				panic("mock out the SetInfo method")
+//			RestoreFunc: func(ctx context.Context, kind client.DataKind, id int64, version int64) error {
+//				panic("mock out the Restore method")
 //			},
 //		}
 //
-//		// use mockedUserService in code that requires client.UserService
+//		// use mockedHistoryService in code that requires client.HistoryService
 //		// and then make assertions.
 //
 //	}
-type UserServiceMock struct {
-	// GetBearerTokenFunc mocks the GetBearerToken method.
-	GetBearerTokenFunc func() string
+type HistoryServiceMock struct {
+	// ListFunc mocks the List method.
+	ListFunc func(ctx context.Context, kind client.DataKind, id int64) ([]client.HistoryEntry, error)
 
-	// GetUserLoginFunc mocks the GetUserLogin method.
-	GetUserLoginFunc func() string
-
-	// SetInfoFunc mocks the SetInfo method.
-	SetInfoFunc func(login string, token string)
+	// RestoreFunc mocks the Restore method.
+	RestoreFunc func(ctx context.Context, kind client.DataKind, id int64, version int64) error
 
 	// calls tracks calls to the methods.
 	calls struct {
-		// GetBearerToken holds details about calls to the GetBearerToken method.
-		GetBearerToken []struct {
-		}
-		// GetUserLogin holds details about calls to the GetUserLogin method.
-		GetUserLogin []struct {
+		// List holds details about calls to the List method.
+		List []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Kind is the kind argument value.
+			Kind client.DataKind
+			// ID is the id argument value.
+			ID int64
 		}
-		// SetInfo holds details about calls to the SetInfo method.
-		SetInfo []struct {
-			// Login is the login argument value.
-			Login string
-			// Token is the token argument value.
-			Token string
+		// Restore holds details about calls to the Restore method.
+		Restore []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Kind is the kind argument value.
+			Kind client.DataKind
+			// ID is the id argument value.
+			ID int64
+			// Version is the version argument value.
+			Version int64
 		}
 	}
-	lockGetBearerToken sync.RWMutex
-	lockGetUserLogin   sync.RWMutex
-	lockSetInfo        sync.RWMutex
+	lockList    sync.RWMutex
+	lockRestore sync.RWMutex
 }
 
-// GetBearerToken calls GetBearerTokenFunc.
-func (mock *UserServiceMock) GetBearerToken() string {
+// List calls ListFunc.
+func (mock *HistoryServiceMock) List(ctx context.Context, kind client.DataKind, id int64) ([]client.HistoryEntry, error) {
 	callInfo := struct {
-	}{}
-	mock.lockGetBearerToken.Lock()
-	mock.calls.GetBearerToken = append(mock.calls.GetBearerToken, callInfo)
-	mock.lockGetBearerToken.Unlock()
-	if mock.GetBearerTokenFunc == nil {
+		Ctx  context.Context
+		Kind client.DataKind
+		ID   int64
+	}{
+		Ctx:  ctx,
+		Kind: kind,
+		ID:   id,
+	}
+	mock.lockList.Lock()
+	mock.calls.List = append(mock.calls.List, callInfo)
+	mock.lockList.Unlock()
+	if mock.ListFunc == nil {
 		var (
-			s string
+			historyEntrys []client.HistoryEntry
+			err           error
 		)
-		return s
+		return historyEntrys, err
 	}
-	return mock.GetBearerTokenFunc()
+	return mock.ListFunc(ctx, kind, id)
 }
 
-// GetBearerTokenCalls gets all the calls that were made to GetBearerToken.
+// ListCalls gets all the calls that were made to List.
+// Check the length with:
+//
+//	len(mockedHistoryService.ListCalls())
+func (mock *HistoryServiceMock) ListCalls() []struct {
+	Ctx  context.Context
+	Kind client.DataKind
+	ID   int64
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Kind client.DataKind
+		ID   int64
+	}
+	mock.lockList.RLock()
+	calls = mock.calls.List
+	mock.lockList.RUnlock()
+	return calls
+}
+
+// Restore calls RestoreFunc.
+func (mock *HistoryServiceMock) Restore(ctx context.Context, kind client.DataKind, id int64, version int64) error {
+	callInfo := struct {
+		Ctx     context.Context
+		Kind    client.DataKind
+		ID      int64
+		Version int64
+	}{
+		Ctx:     ctx,
+		Kind:    kind,
+		ID:      id,
+		Version: version,
+	}
+	mock.lockRestore.Lock()
+	mock.calls.Restore = append(mock.calls.Restore, callInfo)
+	mock.lockRestore.Unlock()
+	if mock.RestoreFunc == nil {
+		var (
+			err error
+		)
+		return err
+	}
+	return mock.RestoreFunc(ctx, kind, id, version)
+}
+
+// RestoreCalls gets all the calls that were made to Restore.
+// Check the length with:
+//
+//	len(mockedHistoryService.RestoreCalls())
+func (mock *HistoryServiceMock) RestoreCalls() []struct {
+	Ctx     context.Context
+	Kind    client.DataKind
+	ID      int64
+	Version int64
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Kind    client.DataKind
+		ID      int64
+		Version int64
+	}
+	mock.lockRestore.RLock()
+	calls = mock.calls.Restore
+	mock.lockRestore.RUnlock()
+	return calls
+}
+
+// Ensure that AuthorizationServiceMock does implement client.AuthorizationService.
+// If this is not the case, regenerate this file with mockery.
+var _ client.AuthorizationService = &AuthorizationServiceMock{}
+
+// AuthorizationServiceMock is a mock implementation of client.AuthorizationService.
+//
+//	func TestSomethingThatUsesAuthorizationService(t *testing.T) {
+//
+//		// make and configure a mocked client.AuthorizationService
+//		mockedAuthorizationService := &AuthorizationServiceMock{
+//			AuthorizeFunc: func(ctx context.Context, login string, password string) (client.Tokens, error) {
+//				panic("mock out the Authorize method")
+//			},
+//			ChangePasswordFunc: func(ctx context.Context, login string, currentPassword string, newPassword string) error {
+//				panic("mock out the ChangePassword method")
+//			},
+//			DeleteAccountFunc: func(ctx context.Context, login string, password string) error {
+//				panic("mock out the DeleteAccount method")
+//			},
+//			DisableMFAFunc: func(ctx context.Context, code string) error {
+//				panic("mock out the DisableMFA method")
+//			},
+//			EnableMFAFunc: func(ctx context.Context, code string) ([]string, error) {
+//				panic("mock out the EnableMFA method")
+//			},
+//			ListSessionsFunc: func(ctx context.Context) ([]client.Session, error) {
+//				panic("mock out the ListSessions method")
+//			},
+//			LogoutFunc: func(ctx context.Context) error {
+//				panic("mock out the Logout method")
+//			},
+//			RegisterFunc: func(ctx context.Context, login string, password string) (client.Tokens, error) {
+//				panic("mock out the Register method")
+//			},
+//			RevokeSessionFunc: func(ctx context.Context, id string) error {
+//				panic("mock out the RevokeSession method")
+//			},
+//			SetupMFAFunc: func(ctx context.Context) (client.MFASetup, error) {
+//				panic("mock out the SetupMFA method")
+//			},
+//			VerifyMFAFunc: func(ctx context.Context, challenge string, login string, password string, code string) (client.Tokens, error) {
+//				panic("mock out the VerifyMFA method")
+//			},
+//		}
+//
+//		// use mockedAuthorizationService in code that requires client.AuthorizationService
+//		// and then make assertions.
+//
+//	}
+type AuthorizationServiceMock struct {
+	// AuthorizeFunc mocks the Authorize method.
+	AuthorizeFunc func(ctx context.Context, login string, password string) (client.Tokens, error)
+
+	// ChangePasswordFunc mocks the ChangePassword method.
+	ChangePasswordFunc func(ctx context.Context, login string, currentPassword string, newPassword string) error
+
+	// DeleteAccountFunc mocks the DeleteAccount method.
+	DeleteAccountFunc func(ctx context.Context, login string, password string) error
+
+	// DisableMFAFunc mocks the DisableMFA method.
+	DisableMFAFunc func(ctx context.Context, code string) error
+
+	// EnableMFAFunc mocks the EnableMFA method.
+	EnableMFAFunc func(ctx context.Context, code string) ([]string, error)
+
+	// ListSessionsFunc mocks the ListSessions method.
+	ListSessionsFunc func(ctx context.Context) ([]client.Session, error)
+
+	// LogoutFunc mocks the Logout method.
+	LogoutFunc func(ctx context.Context) error
+
+	// RegisterFunc mocks the Register method.
+	RegisterFunc func(ctx context.Context, login string, password string) (client.Tokens, error)
+
+	// RevokeSessionFunc mocks the RevokeSession method.
+	RevokeSessionFunc func(ctx context.Context, id string) error
+
+	// SetupMFAFunc mocks the SetupMFA method.
+	SetupMFAFunc func(ctx context.Context) (client.MFASetup, error)
+
+	// VerifyMFAFunc mocks the VerifyMFA method.
+	VerifyMFAFunc func(ctx context.Context, challenge string, login string, password string, code string) (client.Tokens, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Authorize holds details about calls to the Authorize method.
+		Authorize []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Login is the login argument value.
+			Login string
+			// Password is the password argument value.
+			Password string
+		}
+		// ChangePassword holds details about calls to the ChangePassword method.
+		ChangePassword []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Login is the login argument value.
+			Login string
+			// CurrentPassword is the currentPassword argument value.
+			CurrentPassword string
+			// NewPassword is the newPassword argument value.
+			NewPassword string
+		}
+		// DeleteAccount holds details about calls to the DeleteAccount method.
+		DeleteAccount []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Login is the login argument value.
+			Login string
+			// Password is the password argument value.
+			Password string
+		}
+		// DisableMFA holds details about calls to the DisableMFA method.
+		DisableMFA []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Code is the code argument value.
+			Code string
+		}
+		// EnableMFA holds details about calls to the EnableMFA method.
+		EnableMFA []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Code is the code argument value.
+			Code string
+		}
+		// ListSessions holds details about calls to the ListSessions method.
+		ListSessions []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// Logout holds details about calls to the Logout method.
+		Logout []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// Register holds details about calls to the Register method.
+		Register []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Login is the login argument value.
+			Login string
+			// Password is the password argument value.
+			Password string
+		}
+		// RevokeSession holds details about calls to the RevokeSession method.
+		RevokeSession []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID string
+		}
+		// SetupMFA holds details about calls to the SetupMFA method.
+		SetupMFA []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// VerifyMFA holds details about calls to the VerifyMFA method.
+		VerifyMFA []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Challenge is the challenge argument value.
+			Challenge string
+			// Login is the login argument value.
+			Login string
+			// Password is the password argument value.
+			Password string
+			// Code is the code argument value.
+			Code string
+		}
+	}
+	lockAuthorize      sync.RWMutex
+	lockChangePassword sync.RWMutex
+	lockDeleteAccount  sync.RWMutex
+	lockDisableMFA     sync.RWMutex
+	lockEnableMFA      sync.RWMutex
+	lockListSessions   sync.RWMutex
+	lockLogout         sync.RWMutex
+	lockRegister       sync.RWMutex
+	lockRevokeSession  sync.RWMutex
+	lockSetupMFA       sync.RWMutex
+	lockVerifyMFA      sync.RWMutex
+}
+
+// Authorize calls AuthorizeFunc.
+func (mock *AuthorizationServiceMock) Authorize(ctx context.Context, login string, password string) (client.Tokens, error) {
+	callInfo := struct {
+		Ctx      context.Context
+		Login    string
+		Password string
+	}{
+		Ctx:      ctx,
+		Login:    login,
+		Password: password,
+	}
+	mock.lockAuthorize.Lock()
+	mock.calls.Authorize = append(mock.calls.Authorize, callInfo)
+	mock.lockAuthorize.Unlock()
+	if mock.AuthorizeFunc == nil {
+		var (
+			tokens client.Tokens
+			err    error
+		)
+		return tokens, err
+	}
+	return mock.AuthorizeFunc(ctx, login, password)
+}
+
+// AuthorizeCalls gets all the calls that were made to Authorize.
+// Check the length with:
+//
+//	len(mockedAuthorizationService.AuthorizeCalls())
+func (mock *AuthorizationServiceMock) AuthorizeCalls() []struct {
+	Ctx      context.Context
+	Login    string
+	Password string
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Login    string
+		Password string
+	}
+	mock.lockAuthorize.RLock()
+	calls = mock.calls.Authorize
+	mock.lockAuthorize.RUnlock()
+	return calls
+}
+
+// ChangePassword calls ChangePasswordFunc.
+func (mock *AuthorizationServiceMock) ChangePassword(ctx context.Context, login string, currentPassword string, newPassword string) error {
+	callInfo := struct {
+		Ctx             context.Context
+		Login           string
+		CurrentPassword string
+		NewPassword     string
+	}{
+		Ctx:             ctx,
+		Login:           login,
+		CurrentPassword: currentPassword,
+		NewPassword:     newPassword,
+	}
+	mock.lockChangePassword.Lock()
+	mock.calls.ChangePassword = append(mock.calls.ChangePassword, callInfo)
+	mock.lockChangePassword.Unlock()
+	if mock.ChangePasswordFunc == nil {
+		var (
+			err error
+		)
+		return err
+	}
+	return mock.ChangePasswordFunc(ctx, login, currentPassword, newPassword)
+}
+
+// ChangePasswordCalls gets all the calls that were made to ChangePassword.
+// Check the length with:
+//
+//	len(mockedAuthorizationService.ChangePasswordCalls())
+func (mock *AuthorizationServiceMock) ChangePasswordCalls() []struct {
+	Ctx             context.Context
+	Login           string
+	CurrentPassword string
+	NewPassword     string
+} {
+	var calls []struct {
+		Ctx             context.Context
+		Login           string
+		CurrentPassword string
+		NewPassword     string
+	}
+	mock.lockChangePassword.RLock()
+	calls = mock.calls.ChangePassword
+	mock.lockChangePassword.RUnlock()
+	return calls
+}
+
+// DeleteAccount calls DeleteAccountFunc.
+func (mock *AuthorizationServiceMock) DeleteAccount(ctx context.Context, login string, password string) error {
+	callInfo := struct {
+		Ctx      context.Context
+		Login    string
+		Password string
+	}{
+		Ctx:      ctx,
+		Login:    login,
+		Password: password,
+	}
+	mock.lockDeleteAccount.Lock()
+	mock.calls.DeleteAccount = append(mock.calls.DeleteAccount, callInfo)
+	mock.lockDeleteAccount.Unlock()
+	if mock.DeleteAccountFunc == nil {
+		var (
+			err error
+		)
+		return err
+	}
+	return mock.DeleteAccountFunc(ctx, login, password)
+}
+
+// DeleteAccountCalls gets all the calls that were made to DeleteAccount.
+// Check the length with:
+//
+//	len(mockedAuthorizationService.DeleteAccountCalls())
+func (mock *AuthorizationServiceMock) DeleteAccountCalls() []struct {
+	Ctx      context.Context
+	Login    string
+	Password string
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Login    string
+		Password string
+	}
+	mock.lockDeleteAccount.RLock()
+	calls = mock.calls.DeleteAccount
+	mock.lockDeleteAccount.RUnlock()
+	return calls
+}
+
+// DisableMFA calls DisableMFAFunc.
+func (mock *AuthorizationServiceMock) DisableMFA(ctx context.Context, code string) error {
+	callInfo := struct {
+		Ctx  context.Context
+		Code string
+	}{
+		Ctx:  ctx,
+		Code: code,
+	}
+	mock.lockDisableMFA.Lock()
+	mock.calls.DisableMFA = append(mock.calls.DisableMFA, callInfo)
+	mock.lockDisableMFA.Unlock()
+	if mock.DisableMFAFunc == nil {
+		var (
+			err error
+		)
+		return err
+	}
+	return mock.DisableMFAFunc(ctx, code)
+}
+
+// DisableMFACalls gets all the calls that were made to DisableMFA.
+// Check the length with:
+//
+//	len(mockedAuthorizationService.DisableMFACalls())
+func (mock *AuthorizationServiceMock) DisableMFACalls() []struct {
+	Ctx  context.Context
+	Code string
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Code string
+	}
+	mock.lockDisableMFA.RLock()
+	calls = mock.calls.DisableMFA
+	mock.lockDisableMFA.RUnlock()
+	return calls
+}
+
+// EnableMFA calls EnableMFAFunc.
+func (mock *AuthorizationServiceMock) EnableMFA(ctx context.Context, code string) ([]string, error) {
+	callInfo := struct {
+		Ctx  context.Context
+		Code string
+	}{
+		Ctx:  ctx,
+		Code: code,
+	}
+	mock.lockEnableMFA.Lock()
+	mock.calls.EnableMFA = append(mock.calls.EnableMFA, callInfo)
+	mock.lockEnableMFA.Unlock()
+	if mock.EnableMFAFunc == nil {
+		var (
+			ss  []string
+			err error
+		)
+		return ss, err
+	}
+	return mock.EnableMFAFunc(ctx, code)
+}
+
+// EnableMFACalls gets all the calls that were made to EnableMFA.
+// Check the length with:
+//
+//	len(mockedAuthorizationService.EnableMFACalls())
+func (mock *AuthorizationServiceMock) EnableMFACalls() []struct {
+	Ctx  context.Context
+	Code string
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Code string
+	}
+	mock.lockEnableMFA.RLock()
+	calls = mock.calls.EnableMFA
+	mock.lockEnableMFA.RUnlock()
+	return calls
+}
+
+// ListSessions calls ListSessionsFunc.
+func (mock *AuthorizationServiceMock) ListSessions(ctx context.Context) ([]client.Session, error) {
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockListSessions.Lock()
+	mock.calls.ListSessions = append(mock.calls.ListSessions, callInfo)
+	mock.lockListSessions.Unlock()
+	if mock.ListSessionsFunc == nil {
+		var (
+			sessions []client.Session
+			err      error
+		)
+		return sessions, err
+	}
+	return mock.ListSessionsFunc(ctx)
+}
+
+// ListSessionsCalls gets all the calls that were made to ListSessions.
+// Check the length with:
+//
+//	len(mockedAuthorizationService.ListSessionsCalls())
+func (mock *AuthorizationServiceMock) ListSessionsCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockListSessions.RLock()
+	calls = mock.calls.ListSessions
+	mock.lockListSessions.RUnlock()
+	return calls
+}
+
+// Logout calls LogoutFunc.
+func (mock *AuthorizationServiceMock) Logout(ctx context.Context) error {
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockLogout.Lock()
+	mock.calls.Logout = append(mock.calls.Logout, callInfo)
+	mock.lockLogout.Unlock()
+	if mock.LogoutFunc == nil {
+		var (
+			err error
+		)
+		return err
+	}
+	return mock.LogoutFunc(ctx)
+}
+
+// LogoutCalls gets all the calls that were made to Logout.
+// Check the length with:
+//
+//	len(mockedAuthorizationService.LogoutCalls())
+func (mock *AuthorizationServiceMock) LogoutCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockLogout.RLock()
+	calls = mock.calls.Logout
+	mock.lockLogout.RUnlock()
+	return calls
+}
+
+// Register calls RegisterFunc.
+func (mock *AuthorizationServiceMock) Register(ctx context.Context, login string, password string) (client.Tokens, error) {
+	callInfo := struct {
+		Ctx      context.Context
+		Login    string
+		Password string
+	}{
+		Ctx:      ctx,
+		Login:    login,
+		Password: password,
+	}
+	mock.lockRegister.Lock()
+	mock.calls.Register = append(mock.calls.Register, callInfo)
+	mock.lockRegister.Unlock()
+	if mock.RegisterFunc == nil {
+		var (
+			tokens client.Tokens
+			err    error
+		)
+		return tokens, err
+	}
+	return mock.RegisterFunc(ctx, login, password)
+}
+
+// RegisterCalls gets all the calls that were made to Register.
+// Check the length with:
+//
+//	len(mockedAuthorizationService.RegisterCalls())
+func (mock *AuthorizationServiceMock) RegisterCalls() []struct {
+	Ctx      context.Context
+	Login    string
+	Password string
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Login    string
+		Password string
+	}
+	mock.lockRegister.RLock()
+	calls = mock.calls.Register
+	mock.lockRegister.RUnlock()
+	return calls
+}
+
+// RevokeSession calls RevokeSessionFunc.
+func (mock *AuthorizationServiceMock) RevokeSession(ctx context.Context, id string) error {
+	callInfo := struct {
+		Ctx context.Context
+		ID  string
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockRevokeSession.Lock()
+	mock.calls.RevokeSession = append(mock.calls.RevokeSession, callInfo)
+	mock.lockRevokeSession.Unlock()
+	if mock.RevokeSessionFunc == nil {
+		var (
+			err error
+		)
+		return err
+	}
+	return mock.RevokeSessionFunc(ctx, id)
+}
+
+// RevokeSessionCalls gets all the calls that were made to RevokeSession.
+// Check the length with:
+//
+//	len(mockedAuthorizationService.RevokeSessionCalls())
+func (mock *AuthorizationServiceMock) RevokeSessionCalls() []struct {
+	Ctx context.Context
+	ID  string
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  string
+	}
+	mock.lockRevokeSession.RLock()
+	calls = mock.calls.RevokeSession
+	mock.lockRevokeSession.RUnlock()
+	return calls
+}
+
+// SetupMFA calls SetupMFAFunc.
+func (mock *AuthorizationServiceMock) SetupMFA(ctx context.Context) (client.MFASetup, error) {
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockSetupMFA.Lock()
+	mock.calls.SetupMFA = append(mock.calls.SetupMFA, callInfo)
+	mock.lockSetupMFA.Unlock()
+	if mock.SetupMFAFunc == nil {
+		var (
+			mFASetup client.MFASetup
+			err      error
+		)
+		return mFASetup, err
+	}
+	return mock.SetupMFAFunc(ctx)
+}
+
+// SetupMFACalls gets all the calls that were made to SetupMFA.
+// Check the length with:
+//
+//	len(mockedAuthorizationService.SetupMFACalls())
+func (mock *AuthorizationServiceMock) SetupMFACalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockSetupMFA.RLock()
+	calls = mock.calls.SetupMFA
+	mock.lockSetupMFA.RUnlock()
+	return calls
+}
+
+// VerifyMFA calls VerifyMFAFunc.
+func (mock *AuthorizationServiceMock) VerifyMFA(ctx context.Context, challenge string, login string, password string, code string) (client.Tokens, error) {
+	callInfo := struct {
+		Ctx       context.Context
+		Challenge string
+		Login     string
+		Password  string
+		Code      string
+	}{
+		Ctx:       ctx,
+		Challenge: challenge,
+		Login:     login,
+		Password:  password,
+		Code:      code,
+	}
+	mock.lockVerifyMFA.Lock()
+	mock.calls.VerifyMFA = append(mock.calls.VerifyMFA, callInfo)
+	mock.lockVerifyMFA.Unlock()
+	if mock.VerifyMFAFunc == nil {
+		var (
+			tokens client.Tokens
+			err    error
+		)
+		return tokens, err
+	}
+	return mock.VerifyMFAFunc(ctx, challenge, login, password, code)
+}
+
+// VerifyMFACalls gets all the calls that were made to VerifyMFA.
+// Check the length with:
+//
+//	len(mockedAuthorizationService.VerifyMFACalls())
+func (mock *AuthorizationServiceMock) VerifyMFACalls() []struct {
+	Ctx       context.Context
+	Challenge string
+	Login     string
+	Password  string
+	Code      string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		Challenge string
+		Login     string
+		Password  string
+		Code      string
+	}
+	mock.lockVerifyMFA.RLock()
+	calls = mock.calls.VerifyMFA
+	mock.lockVerifyMFA.RUnlock()
+	return calls
+}
+
+// Ensure that UserServiceMock does implement client.UserService.
+// If this is not the case, regenerate this file with mockery.
+var _ client.UserService = &UserServiceMock{}
+
+// UserServiceMock is a mock implementation of client.UserService.
+//
+//	func TestSomethingThatUsesUserService(t *testing.T) {
+//
+//		// make and configure a mocked client.UserService
+//		mockedUserService := &UserServiceMock{
+//			GetBearerTokenFunc: func() string {
+//				panic("mock out the GetBearerToken method")
+//			},
+//			GetRefreshTokenFunc: func() string {
+//				panic("mock out the GetRefreshToken method")
+//			},
+//			GetUserLoginFunc: func() string {
+//				panic("mock out the GetUserLogin method")
+//			},
+//			SetInfoFunc: func(login string, tokens client.Tokens)  {
+//				panic("mock out the SetInfo method")
+//			},
+//			SetTokensFunc: func(tokens client.Tokens)  {
+//				panic("mock out the SetTokens method")
+//			},
+//		}
+//
+//		// use mockedUserService in code that requires client.UserService
+//		// and then make assertions.
+//
+//	}
+type UserServiceMock struct {
+	// GetBearerTokenFunc mocks the GetBearerToken method.
+	GetBearerTokenFunc func() string
+
+	// GetRefreshTokenFunc mocks the GetRefreshToken method.
+	GetRefreshTokenFunc func() string
+
+	// GetUserLoginFunc mocks the GetUserLogin method.
+	GetUserLoginFunc func() string
+
+	// SetInfoFunc mocks the SetInfo method.
+	SetInfoFunc func(login string, tokens client.Tokens)
+
+	// SetTokensFunc mocks the SetTokens method.
+	SetTokensFunc func(tokens client.Tokens)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// GetBearerToken holds details about calls to the GetBearerToken method.
+		GetBearerToken []struct {
+		}
+		// GetRefreshToken holds details about calls to the GetRefreshToken method.
+		GetRefreshToken []struct {
+		}
+		// GetUserLogin holds details about calls to the GetUserLogin method.
+		GetUserLogin []struct {
+		}
+		// SetInfo holds details about calls to the SetInfo method.
+		SetInfo []struct {
+			// Login is the login argument value.
+			Login string
+			// Tokens is the tokens argument value.
+			Tokens client.Tokens
+		}
+		// SetTokens holds details about calls to the SetTokens method.
+		SetTokens []struct {
+			// Tokens is the tokens argument value.
+			Tokens client.Tokens
+		}
+	}
+	lockGetBearerToken  sync.RWMutex
+	lockGetRefreshToken sync.RWMutex
+	lockGetUserLogin    sync.RWMutex
+	lockSetInfo         sync.RWMutex
+	lockSetTokens       sync.RWMutex
+}
+
+// GetBearerToken calls GetBearerTokenFunc.
+func (mock *UserServiceMock) GetBearerToken() string {
+	callInfo := struct {
+	}{}
+	mock.lockGetBearerToken.Lock()
+	mock.calls.GetBearerToken = append(mock.calls.GetBearerToken, callInfo)
+	mock.lockGetBearerToken.Unlock()
+	if mock.GetBearerTokenFunc == nil {
+		var (
+			s string
+		)
+		return s
+	}
+	return mock.GetBearerTokenFunc()
+}
+
+// GetBearerTokenCalls gets all the calls that were made to GetBearerToken.
 // Check the length with:
 //
 //	len(mockedUserService.GetBearerTokenCalls())
@@ -1208,6 +2662,36 @@ func (mock *UserServiceMock) GetBearerTokenCalls() []struct {
 	return calls
 }
 
+// GetRefreshToken calls GetRefreshTokenFunc.
+func (mock *UserServiceMock) GetRefreshToken() string {
+	callInfo := struct {
+	}{}
+	mock.lockGetRefreshToken.Lock()
+	mock.calls.GetRefreshToken = append(mock.calls.GetRefreshToken, callInfo)
+	mock.lockGetRefreshToken.Unlock()
+	if mock.GetRefreshTokenFunc == nil {
+		var (
+			s string
+		)
+		return s
+	}
+	return mock.GetRefreshTokenFunc()
+}
+
+// GetRefreshTokenCalls gets all the calls that were made to GetRefreshToken.
+// Check the length with:
+//
+//	len(mockedUserService.GetRefreshTokenCalls())
+func (mock *UserServiceMock) GetRefreshTokenCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetRefreshToken.RLock()
+	calls = mock.calls.GetRefreshToken
+	mock.lockGetRefreshToken.RUnlock()
+	return calls
+}
+
 // GetUserLogin calls GetUserLoginFunc.
 func (mock *UserServiceMock) GetUserLogin() string {
 	callInfo := struct {
@@ -1239,13 +2723,13 @@ func (mock *UserServiceMock) GetUserLoginCalls() []struct {
 }
 
 // SetInfo calls SetInfoFunc.
-func (mock *UserServiceMock) SetInfo(login string, token string) {
+func (mock *UserServiceMock) SetInfo(login string, tokens client.Tokens) {
 	callInfo := struct {
-		Login string
-		Token string
+		Login  string
+		Tokens client.Tokens
 	}{
-		Login: login,
-		Token: token,
+		Login:  login,
+		Tokens: tokens,
 	}
 	mock.lockSetInfo.Lock()
 	mock.calls.SetInfo = append(mock.calls.SetInfo, callInfo)
@@ -1253,7 +2737,7 @@ func (mock *UserServiceMock) SetInfo(login string, token string) {
 	if mock.SetInfoFunc == nil {
 		return
 	}
-	mock.SetInfoFunc(login, token)
+	mock.SetInfoFunc(login, tokens)
 }
 
 // SetInfoCalls gets all the calls that were made to SetInfo.
@@ -1261,15 +2745,484 @@ func (mock *UserServiceMock) SetInfo(login string, token string) {
 //
 //	len(mockedUserService.SetInfoCalls())
 func (mock *UserServiceMock) SetInfoCalls() []struct {
-	Login string
-	Token string
+	Login  string
+	Tokens client.Tokens
 } {
 	var calls []struct {
-		Login string
-		Token string
+		Login  string
+		Tokens client.Tokens
 	}
 	mock.lockSetInfo.RLock()
 	calls = mock.calls.SetInfo
 	mock.lockSetInfo.RUnlock()
 	return calls
 }
+
+// SetTokens calls SetTokensFunc.
+func (mock *UserServiceMock) SetTokens(tokens client.Tokens) {
+	callInfo := struct {
+		Tokens client.Tokens
+	}{
+		Tokens: tokens,
+	}
+	mock.lockSetTokens.Lock()
+	mock.calls.SetTokens = append(mock.calls.SetTokens, callInfo)
+	mock.lockSetTokens.Unlock()
+	if mock.SetTokensFunc == nil {
+		return
+	}
+	mock.SetTokensFunc(tokens)
+}
+
+// SetTokensCalls gets all the calls that were made to SetTokens.
+// Check the length with:
+//
+//	len(mockedUserService.SetTokensCalls())
+func (mock *UserServiceMock) SetTokensCalls() []struct {
+	Tokens client.Tokens
+} {
+	var calls []struct {
+		Tokens client.Tokens
+	}
+	mock.lockSetTokens.RLock()
+	calls = mock.calls.SetTokens
+	mock.lockSetTokens.RUnlock()
+	return calls
+}
+
+// Ensure that SyncServiceMock does implement client.SyncService.
+// If this is not the case, regenerate this file with mockery.
+var _ client.SyncService = &SyncServiceMock{}
+
+// SyncServiceMock is a mock implementation of client.SyncService.
+//
+//	func TestSomethingThatUsesSyncService(t *testing.T) {
+//
+//		// make and configure a mocked client.SyncService
+//		mockedSyncService := &SyncServiceMock{
+//			PullFunc: func(ctx context.Context, since int64) (int64, []client.Change, error) {
+//				panic("mock out the Pull method")
+//			},
+//			PushFunc: func(ctx context.Context, changes []client.Change) ([]error, error) {
+//				panic("mock out the Push method")
+//			},
+//		}
+//
+//		// use mockedSyncService in code that requires client.SyncService
+//		// and then make assertions.
+//
+//	}
+type SyncServiceMock struct {
+	// PullFunc mocks the Pull method.
+	PullFunc func(ctx context.Context, since int64) (int64, []client.Change, error)
+
+	// PushFunc mocks the Push method.
+	PushFunc func(ctx context.Context, changes []client.Change) ([]error, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Pull holds details about calls to the Pull method.
+		Pull []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Since is the since argument value.
+			Since int64
+		}
+		// Push holds details about calls to the Push method.
+		Push []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Changes is the changes argument value.
+			Changes []client.Change
+		}
+	}
+	lockPull sync.RWMutex
+	lockPush sync.RWMutex
+}
+
+// Pull calls PullFunc.
+func (mock *SyncServiceMock) Pull(ctx context.Context, since int64) (int64, []client.Change, error) {
+	callInfo := struct {
+		Ctx   context.Context
+		Since int64
+	}{
+		Ctx:   ctx,
+		Since: since,
+	}
+	mock.lockPull.Lock()
+	mock.calls.Pull = append(mock.calls.Pull, callInfo)
+	mock.lockPull.Unlock()
+	if mock.PullFunc == nil {
+		var (
+			n       int64
+			changes []client.Change
+			err     error
+		)
+		return n, changes, err
+	}
+	return mock.PullFunc(ctx, since)
+}
+
+// PullCalls gets all the calls that were made to Pull.
+// Check the length with:
+//
+//	len(mockedSyncService.PullCalls())
+func (mock *SyncServiceMock) PullCalls() []struct {
+	Ctx   context.Context
+	Since int64
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Since int64
+	}
+	mock.lockPull.RLock()
+	calls = mock.calls.Pull
+	mock.lockPull.RUnlock()
+	return calls
+}
+
+// Push calls PushFunc.
+func (mock *SyncServiceMock) Push(ctx context.Context, changes []client.Change) ([]error, error) {
+	callInfo := struct {
+		Ctx     context.Context
+		Changes []client.Change
+	}{
+		Ctx:     ctx,
+		Changes: changes,
+	}
+	mock.lockPush.Lock()
+	mock.calls.Push = append(mock.calls.Push, callInfo)
+	mock.lockPush.Unlock()
+	if mock.PushFunc == nil {
+		var (
+			err  []error
+			err1 error
+		)
+		return err, err1
+	}
+	return mock.PushFunc(ctx, changes)
+}
+
+// PushCalls gets all the calls that were made to Push.
+// Check the length with:
+//
+//	len(mockedSyncService.PushCalls())
+func (mock *SyncServiceMock) PushCalls() []struct {
+	Ctx     context.Context
+	Changes []client.Change
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Changes []client.Change
+	}
+	mock.lockPush.RLock()
+	calls = mock.calls.Push
+	mock.lockPush.RUnlock()
+	return calls
+}
+
+// Ensure that InfoServiceMock does implement client.InfoService.
+// If this is not the case, regenerate this file with mockery.
+var _ client.InfoService = &InfoServiceMock{}
+
+// InfoServiceMock is a mock implementation of client.InfoService.
+//
+//	func TestSomethingThatUsesInfoService(t *testing.T) {
+//
+//		// make and configure a mocked client.InfoService
+//		mockedInfoService := &InfoServiceMock{
+//			ServerInfoFunc: func(ctx context.Context) (client.ServerInfo, error) {
+//				panic("mock out the ServerInfo method")
+//			},
+//			UsageFunc: func(ctx context.Context) (client.Usage, error) {
+//				panic("mock out the Usage method")
+//			},
+//		}
+//
+//		// use mockedInfoService in code that requires client.InfoService
+//		// and then make assertions.
+//
+//	}
+type InfoServiceMock struct {
+	// ServerInfoFunc mocks the ServerInfo method.
+	ServerInfoFunc func(ctx context.Context) (client.ServerInfo, error)
+
+	// UsageFunc mocks the Usage method.
+	UsageFunc func(ctx context.Context) (client.Usage, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// ServerInfo holds details about calls to the ServerInfo method.
+		ServerInfo []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// Usage holds details about calls to the Usage method.
+		Usage []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+	}
+	lockServerInfo sync.RWMutex
+	lockUsage      sync.RWMutex
+}
+
+// ServerInfo calls ServerInfoFunc.
+func (mock *InfoServiceMock) ServerInfo(ctx context.Context) (client.ServerInfo, error) {
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockServerInfo.Lock()
+	mock.calls.ServerInfo = append(mock.calls.ServerInfo, callInfo)
+	mock.lockServerInfo.Unlock()
+	if mock.ServerInfoFunc == nil {
+		var (
+			serverInfo client.ServerInfo
+			err        error
+		)
+		return serverInfo, err
+	}
+	return mock.ServerInfoFunc(ctx)
+}
+
+// ServerInfoCalls gets all the calls that were made to ServerInfo.
+// Check the length with:
+//
+//	len(mockedInfoService.ServerInfoCalls())
+func (mock *InfoServiceMock) ServerInfoCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockServerInfo.RLock()
+	calls = mock.calls.ServerInfo
+	mock.lockServerInfo.RUnlock()
+	return calls
+}
+
+// Usage calls UsageFunc.
+func (mock *InfoServiceMock) Usage(ctx context.Context) (client.Usage, error) {
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockUsage.Lock()
+	mock.calls.Usage = append(mock.calls.Usage, callInfo)
+	mock.lockUsage.Unlock()
+	if mock.UsageFunc == nil {
+		var (
+			usage client.Usage
+			err   error
+		)
+		return usage, err
+	}
+	return mock.UsageFunc(ctx)
+}
+
+// UsageCalls gets all the calls that were made to Usage.
+// Check the length with:
+//
+//	len(mockedInfoService.UsageCalls())
+func (mock *InfoServiceMock) UsageCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockUsage.RLock()
+	calls = mock.calls.Usage
+	mock.lockUsage.RUnlock()
+	return calls
+}
+
+// Ensure that TrashServiceMock does implement client.TrashService.
+// If this is not the case, regenerate this file with mockery.
+var _ client.TrashService = &TrashServiceMock{}
+
+// TrashServiceMock is a mock implementation of client.TrashService.
+//
+//	func TestSomethingThatUsesTrashService(t *testing.T) {
+//
+//		// make and configure a mocked client.TrashService
+//		mockedTrashService := &TrashServiceMock{
+//			ListFunc: func(ctx context.Context) ([]client.TrashEntry, error) {
+//				panic("mock out the List method")
+//			},
+//			PurgeFunc: func(ctx context.Context, kind client.DataKind, id int64) error {
+//				panic("mock out the Purge method")
+//			},
+//			RestoreFunc: func(ctx context.Context, kind client.DataKind, id int64) error {
+//				panic("mock out the Restore method")
+//			},
+//		}
+//
+//		// use mockedTrashService in code that requires client.TrashService
+//		// and then make assertions.
+//
+//	}
+type TrashServiceMock struct {
+	// ListFunc mocks the List method.
+	ListFunc func(ctx context.Context) ([]client.TrashEntry, error)
+
+	// PurgeFunc mocks the Purge method.
+	PurgeFunc func(ctx context.Context, kind client.DataKind, id int64) error
+
+	// RestoreFunc mocks the Restore method.
+	RestoreFunc func(ctx context.Context, kind client.DataKind, id int64) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// List holds details about calls to the List method.
+		List []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// Purge holds details about calls to the Purge method.
+		Purge []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Kind is the kind argument value.
+			Kind client.DataKind
+			// ID is the id argument value.
+			ID int64
+		}
+		// Restore holds details about calls to the Restore method.
+		Restore []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Kind is the kind argument value.
+			Kind client.DataKind
+			// ID is the id argument value.
+			ID int64
+		}
+	}
+	lockList    sync.RWMutex
+	lockPurge   sync.RWMutex
+	lockRestore sync.RWMutex
+}
+
+// List calls ListFunc.
+func (mock *TrashServiceMock) List(ctx context.Context) ([]client.TrashEntry, error) {
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockList.Lock()
+	mock.calls.List = append(mock.calls.List, callInfo)
+	mock.lockList.Unlock()
+	if mock.ListFunc == nil {
+		var (
+			trashEntrys []client.TrashEntry
+			err         error
+		)
+		return trashEntrys, err
+	}
+	return mock.ListFunc(ctx)
+}
+
+// ListCalls gets all the calls that were made to List.
+// Check the length with:
+//
+//	len(mockedTrashService.ListCalls())
+func (mock *TrashServiceMock) ListCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockList.RLock()
+	calls = mock.calls.List
+	mock.lockList.RUnlock()
+	return calls
+}
+
+// Purge calls PurgeFunc.
+func (mock *TrashServiceMock) Purge(ctx context.Context, kind client.DataKind, id int64) error {
+	callInfo := struct {
+		Ctx  context.Context
+		Kind client.DataKind
+		ID   int64
+	}{
+		Ctx:  ctx,
+		Kind: kind,
+		ID:   id,
+	}
+	mock.lockPurge.Lock()
+	mock.calls.Purge = append(mock.calls.Purge, callInfo)
+	mock.lockPurge.Unlock()
+	if mock.PurgeFunc == nil {
+		var (
+			err error
+		)
+		return err
+	}
+	return mock.PurgeFunc(ctx, kind, id)
+}
+
+// PurgeCalls gets all the calls that were made to Purge.
+// Check the length with:
+//
+//	len(mockedTrashService.PurgeCalls())
+func (mock *TrashServiceMock) PurgeCalls() []struct {
+	Ctx  context.Context
+	Kind client.DataKind
+	ID   int64
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Kind client.DataKind
+		ID   int64
+	}
+	mock.lockPurge.RLock()
+	calls = mock.calls.Purge
+	mock.lockPurge.RUnlock()
+	return calls
+}
+
+// Restore calls RestoreFunc.
+func (mock *TrashServiceMock) Restore(ctx context.Context, kind client.DataKind, id int64) error {
+	callInfo := struct {
+		Ctx  context.Context
+		Kind client.DataKind
+		ID   int64
+	}{
+		Ctx:  ctx,
+		Kind: kind,
+		ID:   id,
+	}
+	mock.lockRestore.Lock()
+	mock.calls.Restore = append(mock.calls.Restore, callInfo)
+	mock.lockRestore.Unlock()
+	if mock.RestoreFunc == nil {
+		var (
+			err error
+		)
+		return err
+	}
+	return mock.RestoreFunc(ctx, kind, id)
+}
+
+// RestoreCalls gets all the calls that were made to Restore.
+// Check the length with:
+//
+//	len(mockedTrashService.RestoreCalls())
+func (mock *TrashServiceMock) RestoreCalls() []struct {
+	Ctx  context.Context
+	Kind client.DataKind
+	ID   int64
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Kind client.DataKind
+		ID   int64
+	}
+	mock.lockRestore.RLock()
+	calls = mock.calls.Restore
+	mock.lockRestore.RUnlock()
+	return calls
+}
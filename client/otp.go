@@ -0,0 +1,152 @@
+package client
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	OTPTypeTOTP = "totp"
+	OTPTypeHOTP = "hotp"
+)
+
+var ErrInvalidOTPURI = errors.New("invalid otpauth uri")
+
+// Code возвращает одноразовый пароль на момент t. TOTP вычисляется
+// по RFC 6238, HOTP - по RFC 4226 для текущего значения счетчика.
+func (d OTPData) Code(t time.Time) (string, error) {
+	key, err := decodeOTPSecret(d.Secret)
+	if err != nil {
+		return "", err
+	}
+
+	var newHash func() hash.Hash
+	switch d.Algorithm {
+	case "SHA1":
+		newHash = sha1.New
+	case "SHA256":
+		newHash = sha256.New
+	case "SHA512":
+		newHash = sha512.New
+	default:
+		return "", fmt.Errorf("unsupported algorithm %q", d.Algorithm)
+	}
+
+	counter := uint64(d.Counter)
+	if d.Type == OTPTypeTOTP {
+		if d.Period <= 0 {
+			return "", errors.New("totp period must be positive")
+		}
+		counter = uint64(t.Unix() / d.Period)
+	}
+
+	var message [8]byte
+	binary.BigEndian.PutUint64(message[:], counter)
+
+	mac := hmac.New(newHash, key)
+	mac.Write(message[:])
+	sum := mac.Sum(nil)
+
+	// Динамическое усечение из RFC 4226.
+	offset := sum[len(sum)-1] & 0x0f
+	value := int64(binary.BigEndian.Uint32(sum[offset:]) & 0x7fffffff)
+
+	mod := int64(1)
+	for range d.Digits {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", d.Digits, value%mod), nil
+}
+
+// Remaining возвращает время, через которое TOTP пароль сменится.
+// Для HOTP возвращает 0.
+func (d OTPData) Remaining(t time.Time) time.Duration {
+	if d.Type != OTPTypeTOTP || d.Period <= 0 {
+		return 0
+	}
+	period := time.Duration(d.Period) * time.Second
+	return period - time.Duration(t.UnixNano())%period
+}
+
+// ParseOTPURI разбирает URI вида otpauth://TYPE/LABEL?PARAMETERS, которые
+// приложения-аутентификаторы получают из QR-кодов.
+func ParseOTPURI(uri string) (OTPData, error) {
+	u, err := url.Parse(strings.TrimSpace(uri))
+	if err != nil || u.Scheme != "otpauth" {
+		return OTPData{}, ErrInvalidOTPURI
+	}
+
+	data := OTPData{
+		Type:      strings.ToLower(u.Host),
+		Name:      strings.TrimPrefix(u.Path, "/"),
+		Algorithm: "SHA1",
+		Digits:    6,
+	}
+	if data.Type != OTPTypeTOTP && data.Type != OTPTypeHOTP {
+		return OTPData{}, fmt.Errorf("%w: unknown type %q", ErrInvalidOTPURI, u.Host)
+	}
+	if data.Type == OTPTypeTOTP {
+		data.Period = 30
+	}
+
+	// Издатель может быть указан и в параметрах, и префиксом в метке.
+	if issuer, _, ok := strings.Cut(data.Name, ":"); ok {
+		data.Issuer = issuer
+	}
+
+	query := u.Query()
+	if data.Secret = query.Get("secret"); data.Secret == "" {
+		return OTPData{}, fmt.Errorf("%w: secret is required", ErrInvalidOTPURI)
+	}
+	if issuer := query.Get("issuer"); issuer != "" {
+		data.Issuer = issuer
+	}
+	if algorithm := query.Get("algorithm"); algorithm != "" {
+		data.Algorithm = strings.ToUpper(algorithm)
+	}
+
+	numbers := []struct {
+		name   string
+		target *int64
+	}{
+		{"digits", &data.Digits},
+		{"period", &data.Period},
+		{"counter", &data.Counter},
+	}
+	for _, number := range numbers {
+		value := query.Get(number.name)
+		if value == "" {
+			continue
+		}
+		if *number.target, err = strconv.ParseInt(value, 10, 64); err != nil {
+			return OTPData{}, fmt.Errorf("%w: invalid %s", ErrInvalidOTPURI, number.name)
+		}
+	}
+
+	return data, nil
+}
+
+// decodeOTPSecret декодирует секрет в base32. Приложения часто показывают
+// секрет группами символов в нижнем регистре и без выравнивания.
+func decodeOTPSecret(secret string) ([]byte, error) {
+	secret = strings.ToUpper(strings.ReplaceAll(secret, " ", ""))
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(strings.TrimRight(secret, "="))
+	if err != nil {
+		return nil, fmt.Errorf("invalid otp secret: %w", err)
+	}
+	if len(key) == 0 {
+		return nil, errors.New("otp secret is empty")
+	}
+	return key, nil
+}
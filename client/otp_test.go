@@ -0,0 +1,112 @@
+package client
+
+import (
+	"encoding/base32"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestOTPCode(t *testing.T) {
+	// Тестовые векторы из RFC 6238 и RFC 4226.
+	secret := func(key string) string {
+		return base32.StdEncoding.EncodeToString([]byte(key))
+	}
+	seed := "12345678901234567890"
+
+	tests := []struct {
+		name string
+		data OTPData
+		time time.Time
+		want string
+	}{
+		{
+			name: "totp_sha1",
+			data: OTPData{Type: OTPTypeTOTP, Secret: secret(seed), Algorithm: "SHA1", Digits: 8, Period: 30},
+			time: time.Unix(59, 0),
+			want: "94287082",
+		},
+		{
+			name: "totp_sha256",
+			data: OTPData{Type: OTPTypeTOTP, Secret: secret(seed + seed[:12]), Algorithm: "SHA256", Digits: 8, Period: 30},
+			time: time.Unix(1111111109, 0),
+			want: "68084774",
+		},
+		{
+			name: "totp_sha512",
+			data: OTPData{Type: OTPTypeTOTP, Secret: secret(seed + seed + seed + seed[:4]), Algorithm: "SHA512", Digits: 8, Period: 30},
+			time: time.Unix(20000000000, 0),
+			want: "47863826",
+		},
+		{
+			name: "hotp",
+			data: OTPData{Type: OTPTypeHOTP, Secret: secret(seed), Algorithm: "SHA1", Digits: 6, Counter: 1},
+			want: "287082",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, err := tt.data.Code(tt.time)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, code)
+		})
+	}
+
+	t.Run("invalid_secret", func(t *testing.T) {
+		_, err := OTPData{Type: OTPTypeHOTP, Secret: "not base32!", Algorithm: "SHA1", Digits: 6}.Code(time.Now())
+		require.Error(t, err)
+	})
+}
+
+func TestOTPRemaining(t *testing.T) {
+	data := OTPData{Type: OTPTypeTOTP, Period: 30}
+	require.Equal(t, 20*time.Second, data.Remaining(time.Unix(70, 0)))
+	require.Zero(t, OTPData{Type: OTPTypeHOTP}.Remaining(time.Unix(70, 0)))
+}
+
+func TestParseOTPURI(t *testing.T) {
+	t.Run("totp", func(t *testing.T) {
+		data, err := ParseOTPURI("otpauth://totp/GitHub:alice?secret=jbswy3dpehpk3pxp&issuer=GitHub&digits=8")
+		require.NoError(t, err)
+		require.Equal(t, OTPData{
+			Name:      "GitHub:alice",
+			Type:      OTPTypeTOTP,
+			Secret:    "jbswy3dpehpk3pxp",
+			Algorithm: "SHA1",
+			Digits:    8,
+			Period:    30,
+			Issuer:    "GitHub",
+		}, data)
+	})
+	t.Run("hotp", func(t *testing.T) {
+		data, err := ParseOTPURI("otpauth://hotp/Example%20Corp:bob?secret=JBSWY3DPEHPK3PXP&algorithm=sha256&counter=5")
+		require.NoError(t, err)
+		require.Equal(t, "Example Corp:bob", data.Name)
+		require.Equal(t, "Example Corp", data.Issuer)
+		require.Equal(t, "SHA256", data.Algorithm)
+		require.Equal(t, int64(5), data.Counter)
+		require.Zero(t, data.Period)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		for _, uri := range []string{
+			"https://example.com",
+			"otpauth://sms/alice?secret=JBSWY3DPEHPK3PXP",
+			"otpauth://totp/alice",
+			"otpauth://totp/alice?secret=JBSWY3DPEHPK3PXP&digits=six",
+		} {
+			_, err := ParseOTPURI(uri)
+			require.ErrorIs(t, err, ErrInvalidOTPURI, uri)
+		}
+	})
+}
+
+func TestOTPValidation(t *testing.T) {
+	validate, err := NewDataValidator()
+	require.NoError(t, err)
+
+	data := OTPData{Name: "github", Type: OTPTypeTOTP, Secret: "JBSWY3DPEHPK3PXP", Algorithm: "SHA1", Digits: 6, Period: 30}
+	require.NoError(t, validate.Struct(data))
+
+	data.Secret = "not base32!"
+	require.Error(t, validate.Struct(data))
+}
@@ -0,0 +1,54 @@
+package client
+
+import "context"
+
+// DataKind - тип хранимых данных.
+type DataKind string
+
+const (
+	DataKindLogin  DataKind = "login"
+	DataKindNote   DataKind = "note"
+	DataKindBinary DataKind = "binary"
+	DataKindCard   DataKind = "card"
+	DataKindOTP    DataKind = "otp"
+)
+
+// DataKindOf возвращает тип данных data.
+func DataKindOf(data Data) (DataKind, bool) {
+	switch data.(type) {
+	case LoginData:
+		return DataKindLogin, true
+	case NoteData:
+		return DataKindNote, true
+	case BinaryData:
+		return DataKindBinary, true
+	case CardData:
+		return DataKindCard, true
+	case OTPData:
+		return DataKindOTP, true
+	}
+	return "", false
+}
+
+// Change - изменение одной записи. Если Data равно nil, запись с ID удалена.
+// Version - версия записи, на основе которой сделано изменение.
+type Change struct {
+	Kind    DataKind
+	ID      int64
+	Version int64
+	Data    Data
+}
+
+// SyncService - сервис инкрементальной синхронизации данных с сервером.
+type SyncService interface {
+	// Pull возвращает текущую ревизию данных и изменения после ревизии since.
+	Pull(ctx context.Context, since int64) (int64, []Change, error)
+
+	// Push отправляет изменения на сервер. Данные с нулевым ID создаются,
+	// остальные обновляются целиком. Возвращает результаты изменений
+	// в порядке changes: nil для примененных, *VersionConflictError при
+	// конфликте версий и ошибку gRPC для остальных отклоненных. Если сервер
+	// не смог применить изменение, вместе с ошибкой возвращаются результаты
+	// изменений, примененных до него.
+	Push(ctx context.Context, changes []Change) ([]error, error)
+}
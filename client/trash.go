@@ -0,0 +1,30 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var ErrNotInTrash = errors.New("data is not in trash")
+
+// TrashEntry - запись в корзине. Data содержит данные в том виде, в котором
+// их удалили.
+type TrashEntry struct {
+	DeletedAt time.Time
+	Data      Data
+}
+
+// TrashService - сервис корзины. Удаленные записи хранятся на сервере, пока
+// их не удалят окончательно или не истечет срок хранения. Работает только
+// при связи с сервером: корзина не кэшируется.
+type TrashService interface {
+	// List возвращает записи в корзине, начиная с удаленных последними.
+	List(ctx context.Context) ([]TrashEntry, error)
+
+	// Restore возвращает запись из корзины с прежним идентификатором.
+	Restore(ctx context.Context, kind DataKind, id int64) error
+
+	// Purge окончательно удаляет запись из корзины.
+	Purge(ctx context.Context, kind DataKind, id int64) error
+}
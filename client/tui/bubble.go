@@ -12,6 +12,7 @@ import (
 	"github.com/mkolibaba/gophkeeper/client/tui/view/editdata"
 	"github.com/mkolibaba/gophkeeper/client/tui/view/home"
 	"github.com/mkolibaba/gophkeeper/client/tui/view/registration"
+	"github.com/mkolibaba/gophkeeper/client/tui/view/trash"
 	"go.uber.org/fx"
 	"io"
 	"os"
@@ -43,6 +44,7 @@ type BubbleParams struct {
 	AddDataView       *adddata.Model
 	RegistrationView  *registration.Model
 	EditDataView      *editdata.Model
+	TrashView         *trash.Model
 }
 
 func NewBubble(p BubbleParams) (Bubble, error) {
@@ -63,6 +65,7 @@ func NewBubble(p BubbleParams) (Bubble, error) {
 			view.ViewAddData:       p.AddDataView,
 			view.ViewRegistration:  p.RegistrationView,
 			view.ViewEditData:      p.EditDataView,
+			view.ViewTrash:         p.TrashView,
 		},
 	}, nil
 }
@@ -128,6 +131,13 @@ func (b Bubble) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		editDataView.ResetFor(msg)
 		return b, editDataView.Init()
 
+	// Вызов окна корзины
+	case home.CallTrashViewMsg:
+		b.view = view.ViewTrash
+		trashView := b.views[view.ViewTrash].(*trash.Model)
+		trashView.Reset()
+		return b, trashView.Init()
+
 	// Вызов окна регистрации
 	case authorization.CallRegistrationViewMsg:
 		b.view = view.ViewRegistration
@@ -136,6 +146,17 @@ func (b Bubble) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case adddata.ExitMsg:
 		b.view = view.ViewHome
 
+	// Выход из окна редактирования данных
+	case editdata.ExitMsg:
+		b.view = view.ViewHome
+		return b, b.views[view.ViewHome].(*home.Model).LoadData()
+
+	// Выход из окна корзины: восстановленные данные появляются на главном
+	// экране.
+	case trash.ExitMsg:
+		b.view = view.ViewHome
+		return b, b.views[view.ViewHome].(*home.Model).LoadData()
+
 	// Изменение размеров окна терминала
 	case tea.WindowSizeMsg:
 		b.width = msg.Width
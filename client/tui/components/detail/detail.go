@@ -2,27 +2,130 @@ package detail
 
 import (
 	"fmt"
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/mkolibaba/gophkeeper/client"
 	"github.com/mkolibaba/gophkeeper/client/tui/helper"
+	"time"
 )
 
 var (
 	fieldStyle = helper.HeaderStyle
 )
 
+// tickMsg обновляет одноразовый пароль и обратный отсчет до его смены.
+type tickMsg struct {
+	id int
+}
+
 type Model struct {
 	Data client.Data
+	// tickID отличает актуальную цепочку тиков от цепочек, запущенных
+	// для ранее выбранных данных.
+	tickID int
+
+	// history - прежние версии Data. Пока панель истории открыта, вместо
+	// Data показывается версия под курсором.
+	history       []client.HistoryEntry
+	historyCursor int
+	historyShown  bool
 }
 
 func New() Model {
 	return Model{}
 }
 
+// SetData меняет отображаемые данные и закрывает панель истории. Для
+// одноразовых паролей запускает ежесекундное обновление.
+func (m *Model) SetData(data client.Data) tea.Cmd {
+	m.Data = data
+	m.HideHistory()
+	m.tickID++
+	if _, ok := data.(client.OTPData); !ok {
+		return nil
+	}
+	return m.tick()
+}
+
+// ShowHistory открывает панель истории с версиями entries, упорядоченными
+// от новых к старым.
+func (m *Model) ShowHistory(entries []client.HistoryEntry) {
+	m.history = entries
+	m.historyCursor = 0
+	m.historyShown = true
+}
+
+func (m *Model) HideHistory() {
+	m.history = nil
+	m.historyShown = false
+}
+
+func (m *Model) HistoryShown() bool {
+	return m.historyShown
+}
+
+// MoveHistoryCursor перемещает курсор панели истории на delta версий.
+func (m *Model) MoveHistoryCursor(delta int) {
+	m.historyCursor = max(0, min(m.historyCursor+delta, len(m.history)-1))
+}
+
+// SelectedVersion возвращает версию под курсором панели истории.
+func (m *Model) SelectedVersion() (client.HistoryEntry, bool) {
+	if !m.historyShown || len(m.history) == 0 {
+		return client.HistoryEntry{}, false
+	}
+	return m.history[m.historyCursor], true
+}
+
+func (m *Model) Update(msg tea.Msg) tea.Cmd {
+	if msg, ok := msg.(tickMsg); ok && msg.id == m.tickID {
+		return m.tick()
+	}
+	return nil
+}
+
+func (m *Model) tick() tea.Cmd {
+	id := m.tickID
+	return tea.Tick(time.Second, func(time.Time) tea.Msg {
+		return tickMsg{id: id}
+	})
+}
+
 func (m Model) View() string {
+	if m.historyShown {
+		return m.renderHistory()
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, renderData(m.Data)...)
+}
+
+// renderHistory возвращает список версий и данные версии под курсором.
+func (m Model) renderHistory() string {
+	if len(m.history) == 0 {
+		return "No previous versions"
+	}
+
+	lines := []string{fieldStyle.Render("History"), ""}
+	for i, entry := range m.history {
+		cursor := "  "
+		if i == m.historyCursor {
+			cursor = "> "
+		}
+		line := fmt.Sprintf("%sv%d  %s", cursor, entry.Version, entry.CreatedAt.Local().Format(time.DateTime))
+		if entry.Removed {
+			line += "  removed"
+		}
+		lines = append(lines, line)
+	}
+	lines = append(lines, "")
+	lines = append(lines, renderData(m.history[m.historyCursor].Data)...)
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+func renderData(data client.Data) []string {
 	var lines []string
 
-	switch d := m.Data.(type) {
+	switch d := data.(type) {
 	case client.LoginData:
 		lines = []string{
 			fieldStyle.Render("Type"),
@@ -94,9 +197,40 @@ func (m Model) View() string {
 			fieldStyle.Render("Notes"),
 			d.Notes,
 		}
+	case client.OTPData:
+		lines = []string{
+			fieldStyle.Render("Type"),
+			"OTP",
+			"",
+			fieldStyle.Render("Name"),
+			d.Name,
+			"",
+			fieldStyle.Render("Code"),
+			renderOTPCode(d, time.Now()),
+			"",
+			fieldStyle.Render("Issuer"),
+			d.Issuer,
+			"",
+			fieldStyle.Render("Secret"),
+			d.Secret,
+			"",
+			fieldStyle.Render("Parameters"),
+			fmt.Sprintf("%s, %s, %d digits", d.Type, d.Algorithm, d.Digits),
+		}
 	case nil:
 		lines = []string{"No data"}
 	}
 
-	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+	return lines
+}
+
+func renderOTPCode(data client.OTPData, now time.Time) string {
+	code, err := data.Code(now)
+	if err != nil {
+		return fmt.Sprintf("error: %v", err)
+	}
+	if data.Type == client.OTPTypeHOTP {
+		return fmt.Sprintf("%s (counter %d)", code, data.Counter)
+	}
+	return fmt.Sprintf("%s (%s left)", code, data.Remaining(now).Round(time.Second))
 }
@@ -23,6 +23,7 @@ type Input interface {
 	Update(tea.Msg) (Input, tea.Cmd)
 	Placeholder() string
 	Value() string
+	SetValue(string)
 	Focus() tea.Cmd
 	Blur()
 	Reset()
@@ -198,6 +199,10 @@ func (i FilePicker) Value() string {
 	return i.textInput.Value()
 }
 
+func (i *FilePicker) SetValue(value string) {
+	i.textInput.SetValue(value)
+}
+
 func (i *FilePicker) Focus() tea.Cmd {
 	i.focused = true
 	i.textInput.Focus()
@@ -111,6 +111,15 @@ func (m *Model) Values() map[string]string {
 	return values
 }
 
+// SetValues устанавливает значения инпутов по их плейсхолдерам.
+func (m *Model) SetValues(values map[string]string) {
+	for i := range m.inputs {
+		if value, ok := values[m.inputs[i].Placeholder()]; ok {
+			m.inputs[i].SetValue(value)
+		}
+	}
+}
+
 func (m *Model) Reset() {
 	for i := range m.inputs {
 		m.inputs[i].Reset()
@@ -12,12 +12,15 @@ const (
 	NotificationNone NotificationType = iota
 	NotificationOk
 	NotificationError
+	// NotificationUndo - уведомление о действии, которое можно отменить.
+	NotificationUndo
 )
 
 var notificationColors = map[NotificationType]lipgloss.Color{
 	NotificationNone:  lipgloss.Color("105"),
 	NotificationOk:    lipgloss.Color("34"),
 	NotificationError: lipgloss.Color("169"),
+	NotificationUndo:  lipgloss.Color("172"),
 }
 
 type clearNotificationMsg struct{}
@@ -27,13 +30,22 @@ type Model struct {
 	notificationText string
 	notificationType NotificationType
 	CurrentUser      string
-	ttl              time.Duration
-	until            time.Time
+	// Version - версии клиента и сервера.
+	Version string
+	// VersionWarning подсвечивает версию, если сервер несовместим с клиентом.
+	VersionWarning bool
+	// Usage - занятое пользователем место на сервере.
+	Usage string
+	ttl   time.Duration
+	// undoTTL - время, в течение которого действие можно отменить.
+	undoTTL time.Duration
+	until   time.Time
 }
 
 func New() *Model {
 	return &Model{
-		ttl: 3 * time.Second,
+		ttl:     3 * time.Second,
+		undoTTL: 10 * time.Second,
 	}
 }
 
@@ -66,13 +78,35 @@ func (m *Model) View() string {
 		Background(lipgloss.Color("243")).
 		Render(m.CurrentUser)
 
+	var version string
+	if m.Version != "" {
+		versionColor := lipgloss.Color("240")
+		if m.VersionWarning {
+			versionColor = notificationColors[NotificationError]
+		}
+		version = lipgloss.NewStyle().
+			Width(w(m.Version) + 2).
+			PaddingLeft(1).
+			Background(versionColor).
+			Render(m.Version)
+	}
+
+	var usage string
+	if m.Usage != "" {
+		usage = lipgloss.NewStyle().
+			Width(w(m.Usage) + 2).
+			PaddingLeft(1).
+			Background(lipgloss.Color("238")).
+			Render(m.Usage)
+	}
+
 	rest := lipgloss.NewStyle().
-		Width(m.Width - w(helpInfo) - w(user)).
+		Width(m.Width - w(helpInfo) - w(usage) - w(version) - w(user)).
 		PaddingLeft(1).
 		Background(notificationColors[m.notificationType]).
 		Render(m.notificationText)
 
-	return lipgloss.JoinHorizontal(lipgloss.Top, helpInfo, rest, user)
+	return lipgloss.JoinHorizontal(lipgloss.Top, helpInfo, rest, usage, version, user)
 }
 
 func (m *Model) NotifyOk(text string) tea.Cmd {
@@ -83,11 +117,26 @@ func (m *Model) NotifyError(text string) tea.Cmd {
 	return m.notify(text, NotificationError)
 }
 
+// NotifyUndo показывает уведомление о действии, которое можно отменить,
+// пока уведомление не скрыто.
+func (m *Model) NotifyUndo(text string) tea.Cmd {
+	return m.notifyFor(text, NotificationUndo, m.undoTTL)
+}
+
+// UndoAvailable сообщает, показано ли уведомление NotifyUndo.
+func (m *Model) UndoAvailable() bool {
+	return m.notificationType == NotificationUndo && time.Now().Before(m.until)
+}
+
 func (m *Model) notify(text string, t NotificationType) tea.Cmd {
+	return m.notifyFor(text, t, m.ttl)
+}
+
+func (m *Model) notifyFor(text string, t NotificationType, ttl time.Duration) tea.Cmd {
 	m.notificationText = text
 	m.notificationType = t
-	m.until = time.Now().Add(m.ttl)
-	return tea.Tick(m.ttl, func(t time.Time) tea.Msg {
+	m.until = time.Now().Add(ttl)
+	return tea.Tick(ttl, func(t time.Time) tea.Msg {
 		return clearNotificationMsg{}
 	})
 }
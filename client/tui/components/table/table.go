@@ -147,6 +147,13 @@ func (m *Model) ProcessFetchedData(msg []client.Data) {
 				Value:         el.Number,
 				RenderedValue: maskCardNumber(el.Number),
 			})
+		case client.OTPData:
+			m.renderedRows = append(m.renderedRows, Row{
+				DataType:      helper.DataTypeOTP,
+				Name:          el.Name,
+				Value:         el.Issuer,
+				RenderedValue: el.Issuer,
+			})
 		}
 	}
 }
@@ -7,4 +7,5 @@ const (
 	DataTypeNote   = "Note"
 	DataTypeBinary = "Binary"
 	DataTypeCard   = "Card"
+	DataTypeOTP    = "OTP"
 )
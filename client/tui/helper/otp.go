@@ -0,0 +1,40 @@
+package helper
+
+import (
+	"fmt"
+	"github.com/mkolibaba/gophkeeper/client"
+	"strconv"
+)
+
+// ParseOTPValues собирает данные одноразовых паролей из значений формы.
+func ParseOTPValues(values map[string]string) (client.OTPData, error) {
+	data := client.OTPData{
+		Name:      values["Name"],
+		Type:      values["Type"],
+		Secret:    values["Secret"],
+		Algorithm: values["Algorithm"],
+		Issuer:    values["Issuer"],
+	}
+
+	numbers := []struct {
+		field  string
+		target *int64
+	}{
+		{"Digits", &data.Digits},
+		{"Period", &data.Period},
+		{"Counter", &data.Counter},
+	}
+	for _, number := range numbers {
+		value := values[number.field]
+		if value == "" {
+			continue
+		}
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return client.OTPData{}, fmt.Errorf("%s must be a number", number.field)
+		}
+		*number.target = n
+	}
+
+	return data, nil
+}
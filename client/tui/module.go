@@ -6,6 +6,7 @@ import (
 	"github.com/mkolibaba/gophkeeper/client/tui/view/editdata"
 	"github.com/mkolibaba/gophkeeper/client/tui/view/home"
 	"github.com/mkolibaba/gophkeeper/client/tui/view/registration"
+	"github.com/mkolibaba/gophkeeper/client/tui/view/trash"
 	"go.uber.org/fx"
 )
 
@@ -17,6 +18,7 @@ var Module = fx.Module(
 		adddata.New,
 		registration.New,
 		editdata.New,
+		trash.New,
 		NewBubble,
 	),
 	fx.Invoke(
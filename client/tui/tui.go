@@ -1,13 +1,21 @@
 package tui
 
 import (
+	"context"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/log"
+	"github.com/mkolibaba/gophkeeper/client"
 	"go.uber.org/fx"
+	"time"
 )
 
+// logoutTimeout - сколько ждать завершения сессии при выходе.
+const logoutTimeout = 5 * time.Second
+
 func Start(
 	bubble Bubble,
+	authorizationService client.AuthorizationService,
+	userService client.UserService,
 	shutdowner fx.Shutdowner,
 	logger *log.Logger,
 ) {
@@ -17,6 +25,23 @@ func Start(
 		if err != nil {
 			logger.Error("error shutting down tui", "err", err)
 		}
+		logout(authorizationService, userService, logger)
 		shutdowner.Shutdown()
 	}()
 }
+
+// logout завершает сессию пользователя на сервере, чтобы ее токены
+// нельзя было использовать после выхода из клиента.
+func logout(authorizationService client.AuthorizationService, userService client.UserService, logger *log.Logger) {
+	if userService.GetBearerToken() == "" {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), logoutTimeout)
+	defer cancel()
+
+	if err := authorizationService.Logout(ctx); err != nil {
+		logger.Error("error logging out", "err", err)
+	}
+	userService.SetInfo("", client.Tokens{})
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/mkolibaba/gophkeeper/client/tui/view/editdata"
 	"github.com/mkolibaba/gophkeeper/client/tui/view/home"
 	"github.com/mkolibaba/gophkeeper/client/tui/view/registration"
+	"github.com/mkolibaba/gophkeeper/client/tui/view/trash"
 	"github.com/stretchr/testify/require"
 	"io"
 	"strings"
@@ -27,8 +28,8 @@ func TestAuthorizationView(t *testing.T) {
 
 	userService := inmem.NewUserService(log.New(io.Discard))
 	authMock := &mock.AuthorizationServiceMock{
-		AuthorizeFunc: func(ctx context.Context, login string, password string) (string, error) {
-			return "", fmt.Errorf("some error")
+		AuthorizeFunc: func(ctx context.Context, login string, password string) (client.Tokens, error) {
+			return client.Tokens{}, fmt.Errorf("some error")
 		},
 	}
 	var config client.Config
@@ -45,11 +46,14 @@ func TestAuthorizationView(t *testing.T) {
 			BinaryService: &mock.BinaryServiceMock{},
 			NoteService:   &mock.NoteServiceMock{},
 			CardService:   &mock.CardServiceMock{},
+			OTPService:    &mock.OTPServiceMock{},
+			InfoService:   newInfoServiceMock(),
 			UserService:   userService,
 		}),
 		AddDataView:      adddata.New(adddata.Params{}),
 		EditDataView:     editdata.New(editdata.Params{}),
 		RegistrationView: registration.New(registration.Params{}),
+		TrashView:        trash.New(trash.Params{}),
 	})
 	require.NoError(t, err)
 
@@ -75,10 +79,10 @@ func TestAuthorizationView(t *testing.T) {
 	})
 
 	// Меняем моковый метод и пытаемся авторизоваться снова.
-	authMock.AuthorizeFunc = func(ctx context.Context, login string, password string) (string, error) {
-		return "super token", nil
+	authMock.AuthorizeFunc = func(ctx context.Context, login string, password string) (client.Tokens, error) {
+		return client.Tokens{AccessToken: "super token"}, nil
 	}
-	userService.SetInfo("foo", "super token") // TODO: выглядит неправильно
+	userService.SetInfo("foo", client.Tokens{AccessToken: "super token"}) // TODO: выглядит неправильно
 	tm.Type("foo")
 	tm.Send(tea.KeyMsg{Type: tea.KeyTab})
 	tm.Type("bar")
@@ -91,13 +95,87 @@ func TestAuthorizationView(t *testing.T) {
 	})
 }
 
+func TestAuthorizationView_MFA(t *testing.T) {
+	t.Parallel()
+
+	userService := inmem.NewUserService(log.New(io.Discard))
+	authMock := &mock.AuthorizationServiceMock{
+		AuthorizeFunc: func(ctx context.Context, login string, password string) (client.Tokens, error) {
+			return client.Tokens{}, &client.MFARequiredError{Challenge: "challenge"}
+		},
+		VerifyMFAFunc: func(ctx context.Context, challenge string, login string, password string, code string) (client.Tokens, error) {
+			if challenge != "challenge" || login != "foo" || password != "bar" || code != "123456" {
+				return client.Tokens{}, fmt.Errorf("invalid mfa code")
+			}
+			return client.Tokens{AccessToken: "super token"}, nil
+		},
+	}
+	var config client.Config
+
+	bubble, err := tui.NewBubble(tui.BubbleParams{
+		Config: &config,
+		AuthorizationView: authorization.New(authorization.Params{
+			AuthorizationService: authMock,
+			UserService:          userService,
+		}),
+		MainView: home.New(home.Params{
+			LoginService:  &mock.LoginServiceMock{GetAllFunc: func(ctx context.Context) ([]client.LoginData, error) { return nil, nil }},
+			BinaryService: &mock.BinaryServiceMock{GetAllFunc: func(ctx context.Context) ([]client.BinaryData, error) { return nil, nil }},
+			NoteService:   &mock.NoteServiceMock{GetAllFunc: func(ctx context.Context) ([]client.NoteData, error) { return nil, nil }},
+			CardService:   &mock.CardServiceMock{GetAllFunc: func(ctx context.Context) ([]client.CardData, error) { return nil, nil }},
+			OTPService:    &mock.OTPServiceMock{GetAllFunc: func(ctx context.Context) ([]client.OTPData, error) { return nil, nil }},
+			InfoService:   newInfoServiceMock(),
+			UserService:   userService,
+		}),
+		AddDataView:      adddata.New(adddata.Params{}),
+		EditDataView:     editdata.New(editdata.Params{}),
+		RegistrationView: registration.New(registration.Params{}),
+		TrashView:        trash.New(trash.Params{}),
+	})
+	require.NoError(t, err)
+
+	tm := teatest.NewTestModel(t, bubble, teatest.WithInitialTermSize(130, 40))
+
+	waitFor(t, tm, func(s string) bool {
+		return strings.Contains(s, "Authorization")
+	})
+
+	tm.Type("foo")
+	tm.Send(tea.KeyMsg{Type: tea.KeyTab})
+	tm.Type("bar")
+	tm.Send(tea.KeyMsg{Type: tea.KeyEnter})
+
+	// После проверки пароля запрашивается второй фактор.
+	waitFor(t, tm, func(s string) bool {
+		return strings.Contains(s, "Two-factor authentication") &&
+			strings.Contains(s, "Code")
+	})
+
+	tm.Type("000000")
+	tm.Send(tea.KeyMsg{Type: tea.KeyEnter})
+
+	waitFor(t, tm, func(s string) bool {
+		return strings.Contains(s, "invalid mfa code")
+	})
+
+	tm.Type("123456")
+	tm.Send(tea.KeyMsg{Type: tea.KeyEnter})
+
+	waitFor(t, tm, func(s string) bool {
+		return strings.Contains(s, "Data") &&
+			strings.Contains(s, "Detail")
+	})
+	require.Equal(t, "foo", userService.GetUserLogin())
+	require.Equal(t, "super token", userService.GetBearerToken())
+}
+
 func TestRegistrationView(t *testing.T) {
 	t.Parallel()
 
 	userService := inmem.NewUserService(log.New(io.Discard))
 	authMock := &mock.AuthorizationServiceMock{
-		RegisterFunc: func(ctx context.Context, login string, password string) (string, error) {
-			return "", fmt.Errorf("some error")
+		RegisterFunc: func(ctx context.Context, login string, password string) (client.Tokens, error) {
+			return client.Tokens{}, fmt.Errorf("some error")
 		},
 	}
 	var config client.Config
@@ -114,6 +192,8 @@ func TestRegistrationView(t *testing.T) {
 			BinaryService: &mock.BinaryServiceMock{},
 			NoteService:   &mock.NoteServiceMock{},
 			CardService:   &mock.CardServiceMock{},
+			OTPService:    &mock.OTPServiceMock{},
+			InfoService:   newInfoServiceMock(),
 			UserService:   userService,
 		}),
 		AddDataView:  adddata.New(adddata.Params{}),
@@ -122,6 +202,7 @@ func TestRegistrationView(t *testing.T) {
 			AuthorizationService: authMock,
 			UserService:          userService,
 		}),
+		TrashView: trash.New(trash.Params{}),
 	})
 	require.NoError(t, err)
 
@@ -156,10 +237,10 @@ func TestRegistrationView(t *testing.T) {
 	})
 
 	// Меняем моковый метод и пытаемся зарегистрироваться снова.
-	authMock.RegisterFunc = func(ctx context.Context, login string, password string) (string, error) {
-		return "super token", nil
+	authMock.RegisterFunc = func(ctx context.Context, login string, password string) (client.Tokens, error) {
+		return client.Tokens{AccessToken: "super token"}, nil
 	}
-	userService.SetInfo("foo", "super token") // TODO: выглядит неправильно
+	userService.SetInfo("foo", client.Tokens{AccessToken: "super token"}) // TODO: выглядит неправильно
 	tm.Type("foo")
 	tm.Send(tea.KeyMsg{Type: tea.KeyTab})
 	tm.Type("bar")
@@ -179,8 +260,8 @@ func TestAddDataView(t *testing.T) {
 
 	userService := inmem.NewUserService(log.New(io.Discard))
 	authMock := &mock.AuthorizationServiceMock{
-		AuthorizeFunc: func(ctx context.Context, login string, password string) (string, error) {
-			return "some token", nil
+		AuthorizeFunc: func(ctx context.Context, login string, password string) (client.Tokens, error) {
+			return client.Tokens{AccessToken: "some token"}, nil
 		},
 	}
 	noteServiceMock := &mock.NoteServiceMock{
@@ -202,6 +283,8 @@ func TestAddDataView(t *testing.T) {
 			BinaryService: &mock.BinaryServiceMock{},
 			NoteService:   noteServiceMock,
 			CardService:   &mock.CardServiceMock{},
+			OTPService:    &mock.OTPServiceMock{},
+			InfoService:   newInfoServiceMock(),
 			UserService:   userService,
 		}),
 		AddDataView: adddata.New(adddata.Params{
@@ -209,6 +292,7 @@ func TestAddDataView(t *testing.T) {
 		}),
 		EditDataView:     editdata.New(editdata.Params{}),
 		RegistrationView: registration.New(registration.Params{}),
+		TrashView:        trash.New(trash.Params{}),
 	})
 	require.NoError(t, err)
 
@@ -267,8 +351,8 @@ func TestAddDataView_Binary(t *testing.T) {
 
 	userService := inmem.NewUserService(log.New(io.Discard))
 	authMock := &mock.AuthorizationServiceMock{
-		AuthorizeFunc: func(ctx context.Context, login string, password string) (string, error) {
-			return "some token", nil
+		AuthorizeFunc: func(ctx context.Context, login string, password string) (client.Tokens, error) {
+			return client.Tokens{AccessToken: "some token"}, nil
 		},
 	}
 	noteServiceMock := &mock.NoteServiceMock{
@@ -290,6 +374,8 @@ func TestAddDataView_Binary(t *testing.T) {
 			BinaryService: &mock.BinaryServiceMock{},
 			NoteService:   noteServiceMock,
 			CardService:   &mock.CardServiceMock{},
+			OTPService:    &mock.OTPServiceMock{},
+			InfoService:   newInfoServiceMock(),
 			UserService:   userService,
 		}),
 		AddDataView: adddata.New(adddata.Params{
@@ -297,6 +383,7 @@ func TestAddDataView_Binary(t *testing.T) {
 		}),
 		EditDataView:     editdata.New(editdata.Params{}),
 		RegistrationView: registration.New(registration.Params{}),
+		TrashView:        trash.New(trash.Params{}),
 	})
 	require.NoError(t, err)
 
@@ -334,8 +421,8 @@ func TestEditDataView_Note(t *testing.T) {
 
 	userService := inmem.NewUserService(log.New(io.Discard))
 	authMock := &mock.AuthorizationServiceMock{
-		AuthorizeFunc: func(ctx context.Context, login string, password string) (string, error) {
-			return "some token", nil
+		AuthorizeFunc: func(ctx context.Context, login string, password string) (client.Tokens, error) {
+			return client.Tokens{AccessToken: "some token"}, nil
 		},
 	}
 	noteServiceMock := &mock.NoteServiceMock{
@@ -364,6 +451,8 @@ func TestEditDataView_Note(t *testing.T) {
 			BinaryService: &mock.BinaryServiceMock{},
 			NoteService:   noteServiceMock,
 			CardService:   &mock.CardServiceMock{},
+			OTPService:    &mock.OTPServiceMock{},
+			InfoService:   newInfoServiceMock(),
 			UserService:   userService,
 		}),
 		AddDataView: adddata.New(adddata.Params{}),
@@ -371,6 +460,7 @@ func TestEditDataView_Note(t *testing.T) {
 			NoteService: noteServiceMock,
 		}),
 		RegistrationView: registration.New(registration.Params{}),
+		TrashView:        trash.New(trash.Params{}),
 	})
 	require.NoError(t, err)
 
@@ -420,8 +510,8 @@ func TestEditDataView_Binary(t *testing.T) {
 
 	userService := inmem.NewUserService(log.New(io.Discard))
 	authMock := &mock.AuthorizationServiceMock{
-		AuthorizeFunc: func(ctx context.Context, login string, password string) (string, error) {
-			return "some token", nil
+		AuthorizeFunc: func(ctx context.Context, login string, password string) (client.Tokens, error) {
+			return client.Tokens{AccessToken: "some token"}, nil
 		},
 	}
 	binaryServiceMock := &mock.BinaryServiceMock{
@@ -449,6 +539,8 @@ func TestEditDataView_Binary(t *testing.T) {
 			BinaryService: binaryServiceMock,
 			NoteService:   &mock.NoteServiceMock{},
 			CardService:   &mock.CardServiceMock{},
+			OTPService:    &mock.OTPServiceMock{},
+			InfoService:   newInfoServiceMock(),
 			UserService:   userService,
 		}),
 		AddDataView: adddata.New(adddata.Params{}),
@@ -456,6 +548,7 @@ func TestEditDataView_Binary(t *testing.T) {
 			BinaryService: binaryServiceMock,
 		}),
 		RegistrationView: registration.New(registration.Params{}),
+		TrashView:        trash.New(trash.Params{}),
 	})
 	require.NoError(t, err)
 
@@ -491,8 +584,8 @@ func TestEditDataView_Card(t *testing.T) {
 
 	userService := inmem.NewUserService(log.New(io.Discard))
 	authMock := &mock.AuthorizationServiceMock{
-		AuthorizeFunc: func(ctx context.Context, login string, password string) (string, error) {
-			return "some token", nil
+		AuthorizeFunc: func(ctx context.Context, login string, password string) (client.Tokens, error) {
+			return client.Tokens{AccessToken: "some token"}, nil
 		},
 	}
 	cardServiceMock := &mock.CardServiceMock{
@@ -521,6 +614,8 @@ func TestEditDataView_Card(t *testing.T) {
 			BinaryService: &mock.BinaryServiceMock{},
 			NoteService:   &mock.NoteServiceMock{},
 			CardService:   cardServiceMock,
+			OTPService:    &mock.OTPServiceMock{},
+			InfoService:   newInfoServiceMock(),
 			UserService:   userService,
 		}),
 		AddDataView: adddata.New(adddata.Params{}),
@@ -528,6 +623,7 @@ func TestEditDataView_Card(t *testing.T) {
 			CardService: cardServiceMock,
 		}),
 		RegistrationView: registration.New(registration.Params{}),
+		TrashView:        trash.New(trash.Params{}),
 	})
 	require.NoError(t, err)
 
@@ -577,8 +673,8 @@ func TestEditDataView_Login(t *testing.T) {
 
 	userService := inmem.NewUserService(log.New(io.Discard))
 	authMock := &mock.AuthorizationServiceMock{
-		AuthorizeFunc: func(ctx context.Context, login string, password string) (string, error) {
-			return "some token", nil
+		AuthorizeFunc: func(ctx context.Context, login string, password string) (client.Tokens, error) {
+			return client.Tokens{AccessToken: "some token"}, nil
 		},
 	}
 	loginServiceMock := &mock.LoginServiceMock{
@@ -607,6 +703,8 @@ func TestEditDataView_Login(t *testing.T) {
 			BinaryService: &mock.BinaryServiceMock{},
 			NoteService:   &mock.NoteServiceMock{},
 			CardService:   &mock.CardServiceMock{},
+			OTPService:    &mock.OTPServiceMock{},
+			InfoService:   newInfoServiceMock(),
 			UserService:   userService,
 		}),
 		AddDataView: adddata.New(adddata.Params{}),
@@ -614,6 +712,7 @@ func TestEditDataView_Login(t *testing.T) {
 			LoginService: loginServiceMock,
 		}),
 		RegistrationView: registration.New(registration.Params{}),
+		TrashView:        trash.New(trash.Params{}),
 	})
 	require.NoError(t, err)
 
@@ -658,6 +757,412 @@ func TestEditDataView_Login(t *testing.T) {
 	require.Equal(t, *c.Name, "my login123 new new")
 }
 
+func TestHomeView_OTP(t *testing.T) {
+	t.Parallel()
+
+	userService := inmem.NewUserService(log.New(io.Discard))
+	authMock := &mock.AuthorizationServiceMock{
+		AuthorizeFunc: func(ctx context.Context, login string, password string) (client.Tokens, error) {
+			return client.Tokens{AccessToken: "some token"}, nil
+		},
+	}
+	otp := client.OTPData{
+		ID:        1,
+		Name:      "my otp",
+		Type:      client.OTPTypeTOTP,
+		Secret:    "JBSWY3DPEHPK3PXP",
+		Algorithm: "SHA1",
+		Digits:    6,
+		Period:    30,
+		Issuer:    "GitHub",
+	}
+	otpServiceMock := &mock.OTPServiceMock{
+		GetAllFunc: func(ctx context.Context) ([]client.OTPData, error) {
+			return []client.OTPData{otp}, nil
+		},
+	}
+	var config client.Config
+	config.Development.Enabled = false
+
+	bubble, err := tui.NewBubble(tui.BubbleParams{
+		Config: &config, // TODO: выглядит как сильная связанность
+		AuthorizationView: authorization.New(authorization.Params{
+			AuthorizationService: authMock,
+			UserService:          userService,
+		}),
+		MainView: home.New(home.Params{
+			LoginService:  &mock.LoginServiceMock{},
+			BinaryService: &mock.BinaryServiceMock{},
+			NoteService:   &mock.NoteServiceMock{},
+			CardService:   &mock.CardServiceMock{},
+			OTPService:    otpServiceMock,
+			InfoService:   newInfoServiceMock(),
+			UserService:   userService,
+		}),
+		AddDataView:      adddata.New(adddata.Params{}),
+		EditDataView:     editdata.New(editdata.Params{}),
+		RegistrationView: registration.New(registration.Params{}),
+		TrashView:        trash.New(trash.Params{}),
+	})
+	require.NoError(t, err)
+
+	// Инициализируем приложение.
+	tm := teatest.NewTestModel(t, bubble, teatest.WithInitialTermSize(130, 40))
+
+	waitFor(t, tm, func(s string) bool {
+		return strings.Contains(s, "Authorization")
+	})
+
+	// За счет мока сразу авторизуемся.
+	tm.Send(tea.KeyMsg{Type: tea.KeyEnter})
+
+	// Проверяем, что в детальном просмотре отображается текущий код
+	// с обратным отсчетом.
+	waitFor(t, tm, func(s string) bool {
+		code, err := otp.Code(time.Now())
+		require.NoError(t, err)
+		return strings.Contains(s, "my otp") &&
+			strings.Contains(s, code) &&
+			strings.Contains(s, "s left)")
+	})
+}
+
+func TestHomeView_History(t *testing.T) {
+	t.Parallel()
+
+	userService := inmem.NewUserService(log.New(io.Discard))
+	authMock := &mock.AuthorizationServiceMock{
+		AuthorizeFunc: func(ctx context.Context, login string, password string) (client.Tokens, error) {
+			return client.Tokens{AccessToken: "some token"}, nil
+		},
+	}
+	note := client.NoteData{ID: 1, Name: "my note", Text: "current text", Version: 3}
+	noteServiceMock := &mock.NoteServiceMock{
+		GetAllFunc: func(ctx context.Context) ([]client.NoteData, error) {
+			return []client.NoteData{note}, nil
+		},
+	}
+	historyServiceMock := &mock.HistoryServiceMock{
+		ListFunc: func(ctx context.Context, kind client.DataKind, id int64) ([]client.HistoryEntry, error) {
+			return []client.HistoryEntry{
+				{Version: 2, CreatedAt: time.Now(), Data: client.NoteData{ID: 1, Name: "my note", Text: "old text", Version: 2}},
+				{Version: 1, CreatedAt: time.Now(), Data: client.NoteData{ID: 1, Name: "my note", Text: "first text", Version: 1}},
+			}, nil
+		},
+		RestoreFunc: func(ctx context.Context, kind client.DataKind, id int64, version int64) error {
+			return nil
+		},
+	}
+	var config client.Config
+	config.Development.Enabled = false
+
+	bubble, err := tui.NewBubble(tui.BubbleParams{
+		Config: &config, // TODO: выглядит как сильная связанность
+		AuthorizationView: authorization.New(authorization.Params{
+			AuthorizationService: authMock,
+			UserService:          userService,
+		}),
+		MainView: home.New(home.Params{
+			LoginService:   &mock.LoginServiceMock{},
+			BinaryService:  &mock.BinaryServiceMock{},
+			NoteService:    noteServiceMock,
+			CardService:    &mock.CardServiceMock{},
+			OTPService:     &mock.OTPServiceMock{},
+			HistoryService: historyServiceMock,
+			InfoService:    newInfoServiceMock(),
+			UserService:    userService,
+		}),
+		AddDataView:      adddata.New(adddata.Params{}),
+		EditDataView:     editdata.New(editdata.Params{}),
+		RegistrationView: registration.New(registration.Params{}),
+		TrashView:        trash.New(trash.Params{}),
+	})
+	require.NoError(t, err)
+
+	// Инициализируем приложение.
+	tm := teatest.NewTestModel(t, bubble, teatest.WithInitialTermSize(130, 40))
+
+	waitFor(t, tm, func(s string) bool {
+		return strings.Contains(s, "Authorization")
+	})
+
+	// За счет мока сразу авторизуемся.
+	tm.Send(tea.KeyMsg{Type: tea.KeyEnter})
+
+	waitFor(t, tm, func(s string) bool {
+		return strings.Contains(s, "current text")
+	})
+
+	// Открываем историю: показывается последняя прежняя версия.
+	tm.Send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'h'}, Alt: true})
+	waitFor(t, tm, func(s string) bool {
+		return strings.Contains(s, "History") &&
+			strings.Contains(s, "> v2") &&
+			strings.Contains(s, "old text")
+	})
+
+	// Выбираем более старую версию и восстанавливаем ее.
+	tm.Send(tea.KeyMsg{Type: tea.KeyDown})
+	waitFor(t, tm, func(s string) bool {
+		return strings.Contains(s, "> v1") &&
+			strings.Contains(s, "first text")
+	})
+	tm.Send(tea.KeyMsg{Type: tea.KeyEnter})
+	waitFor(t, tm, func(s string) bool {
+		return strings.Contains(s, "Restored my note to version 1")
+	})
+
+	require.Len(t, historyServiceMock.RestoreCalls(), 1)
+	call := historyServiceMock.RestoreCalls()[0]
+	require.Equal(t, client.DataKindNote, call.Kind)
+	require.Equal(t, int64(1), call.ID)
+	require.Equal(t, int64(1), call.Version)
+}
+
+func TestHomeView_Undo(t *testing.T) {
+	t.Parallel()
+
+	userService := inmem.NewUserService(log.New(io.Discard))
+	authMock := &mock.AuthorizationServiceMock{
+		AuthorizeFunc: func(ctx context.Context, login string, password string) (client.Tokens, error) {
+			return client.Tokens{AccessToken: "some token"}, nil
+		},
+	}
+	note := client.NoteData{ID: 1, Name: "my note", Text: "note text", Version: 3}
+	noteServiceMock := &mock.NoteServiceMock{
+		GetAllFunc: func(ctx context.Context) ([]client.NoteData, error) {
+			return []client.NoteData{note}, nil
+		},
+		RemoveFunc: func(ctx context.Context, id int64, version int64) error {
+			return nil
+		},
+	}
+	trashServiceMock := &mock.TrashServiceMock{
+		RestoreFunc: func(ctx context.Context, kind client.DataKind, id int64) error {
+			return nil
+		},
+	}
+	var config client.Config
+	config.Development.Enabled = false
+
+	bubble, err := tui.NewBubble(tui.BubbleParams{
+		Config: &config, // TODO: выглядит как сильная связанность
+		AuthorizationView: authorization.New(authorization.Params{
+			AuthorizationService: authMock,
+			UserService:          userService,
+		}),
+		MainView: home.New(home.Params{
+			LoginService:  &mock.LoginServiceMock{},
+			BinaryService: &mock.BinaryServiceMock{},
+			NoteService:   noteServiceMock,
+			CardService:   &mock.CardServiceMock{},
+			OTPService:    &mock.OTPServiceMock{},
+			TrashService:  trashServiceMock,
+			InfoService:   newInfoServiceMock(),
+			UserService:   userService,
+		}),
+		AddDataView:      adddata.New(adddata.Params{}),
+		EditDataView:     editdata.New(editdata.Params{}),
+		RegistrationView: registration.New(registration.Params{}),
+		TrashView:        trash.New(trash.Params{}),
+	})
+	require.NoError(t, err)
+
+	// Инициализируем приложение.
+	tm := teatest.NewTestModel(t, bubble, teatest.WithInitialTermSize(130, 40))
+
+	waitFor(t, tm, func(s string) bool {
+		return strings.Contains(s, "Authorization")
+	})
+
+	// За счет мока сразу авторизуемся.
+	tm.Send(tea.KeyMsg{Type: tea.KeyEnter})
+
+	waitFor(t, tm, func(s string) bool {
+		return strings.Contains(s, "note text")
+	})
+
+	// Удаляем заметку: в строке состояния предлагается отменить удаление.
+	tm.Send(tea.KeyMsg{Type: tea.KeyCtrlR})
+	waitFor(t, tm, func(s string) bool {
+		return strings.Contains(s, "Removed my note. ctrl+z to undo")
+	})
+
+	tm.Send(tea.KeyMsg{Type: tea.KeyCtrlZ})
+	waitFor(t, tm, func(s string) bool {
+		return strings.Contains(s, "Restored my note")
+	})
+
+	require.Len(t, noteServiceMock.RemoveCalls(), 1)
+	require.Len(t, trashServiceMock.RestoreCalls(), 1)
+	call := trashServiceMock.RestoreCalls()[0]
+	require.Equal(t, client.DataKindNote, call.Kind)
+	require.Equal(t, int64(1), call.ID)
+}
+
+func TestTrashView(t *testing.T) {
+	t.Parallel()
+
+	userService := inmem.NewUserService(log.New(io.Discard))
+	authMock := &mock.AuthorizationServiceMock{
+		AuthorizeFunc: func(ctx context.Context, login string, password string) (client.Tokens, error) {
+			return client.Tokens{AccessToken: "some token"}, nil
+		},
+	}
+	trashServiceMock := &mock.TrashServiceMock{
+		ListFunc: func(ctx context.Context) ([]client.TrashEntry, error) {
+			return []client.TrashEntry{
+				{
+					DeletedAt: time.Date(2025, 3, 4, 5, 6, 7, 0, time.Local),
+					Data:      client.NoteData{ID: 2, Name: "removed note", Text: "old text", Version: 4},
+				},
+			}, nil
+		},
+		RestoreFunc: func(ctx context.Context, kind client.DataKind, id int64) error {
+			return nil
+		},
+	}
+	var config client.Config
+	config.Development.Enabled = false
+
+	bubble, err := tui.NewBubble(tui.BubbleParams{
+		Config: &config, // TODO: выглядит как сильная связанность
+		AuthorizationView: authorization.New(authorization.Params{
+			AuthorizationService: authMock,
+			UserService:          userService,
+		}),
+		MainView: home.New(home.Params{
+			LoginService:  &mock.LoginServiceMock{GetAllFunc: func(ctx context.Context) ([]client.LoginData, error) { return nil, nil }},
+			BinaryService: &mock.BinaryServiceMock{GetAllFunc: func(ctx context.Context) ([]client.BinaryData, error) { return nil, nil }},
+			NoteService:   &mock.NoteServiceMock{GetAllFunc: func(ctx context.Context) ([]client.NoteData, error) { return nil, nil }},
+			CardService:   &mock.CardServiceMock{GetAllFunc: func(ctx context.Context) ([]client.CardData, error) { return nil, nil }},
+			OTPService:    &mock.OTPServiceMock{GetAllFunc: func(ctx context.Context) ([]client.OTPData, error) { return nil, nil }},
+			InfoService:   newInfoServiceMock(),
+			UserService:   userService,
+		}),
+		AddDataView:      adddata.New(adddata.Params{}),
+		EditDataView:     editdata.New(editdata.Params{}),
+		RegistrationView: registration.New(registration.Params{}),
+		TrashView:        trash.New(trash.Params{TrashService: trashServiceMock}),
+	})
+	require.NoError(t, err)
+
+	// Инициализируем приложение.
+	tm := teatest.NewTestModel(t, bubble, teatest.WithInitialTermSize(130, 40))
+
+	waitFor(t, tm, func(s string) bool {
+		return strings.Contains(s, "Authorization")
+	})
+
+	// За счет мока сразу авторизуемся.
+	tm.Send(tea.KeyMsg{Type: tea.KeyEnter})
+
+	waitFor(t, tm, func(s string) bool {
+		return strings.Contains(s, "0 items")
+	})
+
+	// Открываем корзину и восстанавливаем заметку.
+	tm.Send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'t'}, Alt: true})
+	waitFor(t, tm, func(s string) bool {
+		return strings.Contains(s, "Trash") &&
+			strings.Contains(s, "removed note") &&
+			strings.Contains(s, "deleted 2025-03-04 05:06:07")
+	})
+
+	tm.Send(tea.KeyMsg{Type: tea.KeyEnter})
+	waitFor(t, tm, func(s string) bool {
+		return strings.Contains(s, "Restored removed note")
+	})
+
+	require.Len(t, trashServiceMock.RestoreCalls(), 1)
+	call := trashServiceMock.RestoreCalls()[0]
+	require.Equal(t, client.DataKindNote, call.Kind)
+	require.Equal(t, int64(2), call.ID)
+
+	// Возвращаемся на главный экран.
+	tm.Send(tea.KeyMsg{Type: tea.KeyEsc})
+	waitFor(t, tm, func(s string) bool {
+		return strings.Contains(s, "Data") &&
+			strings.Contains(s, "Detail")
+	})
+}
+
+func TestHomeView_ServerVersion(t *testing.T) {
+	t.Parallel()
+
+	userService := inmem.NewUserService(log.New(io.Discard))
+	authMock := &mock.AuthorizationServiceMock{
+		AuthorizeFunc: func(ctx context.Context, login string, password string) (client.Tokens, error) {
+			return client.Tokens{AccessToken: "some token"}, nil
+		},
+	}
+	infoServiceMock := &mock.InfoServiceMock{
+		ServerInfoFunc: func(ctx context.Context) (client.ServerInfo, error) {
+			return client.ServerInfo{
+				Build:           client.BuildInfo{Version: "v2.0.0"},
+				ProtocolVersion: client.ProtocolVersion + 1,
+			}, nil
+		},
+		UsageFunc: func(ctx context.Context) (client.Usage, error) {
+			return client.Usage{Bytes: 2048, Items: 3, QuotaItems: 100}, nil
+		},
+	}
+	var config client.Config
+	config.Development.Enabled = false
+
+	bubble, err := tui.NewBubble(tui.BubbleParams{
+		Config: &config, // TODO: выглядит как сильная связанность
+		AuthorizationView: authorization.New(authorization.Params{
+			AuthorizationService: authMock,
+			UserService:          userService,
+		}),
+		MainView: home.New(home.Params{
+			LoginService:  &mock.LoginServiceMock{GetAllFunc: func(ctx context.Context) ([]client.LoginData, error) { return nil, nil }},
+			BinaryService: &mock.BinaryServiceMock{GetAllFunc: func(ctx context.Context) ([]client.BinaryData, error) { return nil, nil }},
+			NoteService:   &mock.NoteServiceMock{GetAllFunc: func(ctx context.Context) ([]client.NoteData, error) { return nil, nil }},
+			CardService:   &mock.CardServiceMock{GetAllFunc: func(ctx context.Context) ([]client.CardData, error) { return nil, nil }},
+			OTPService:    &mock.OTPServiceMock{GetAllFunc: func(ctx context.Context) ([]client.OTPData, error) { return nil, nil }},
+			InfoService:   infoServiceMock,
+			UserService:   userService,
+			Build:         client.BuildInfo{Version: "v1.0.0"},
+		}),
+		AddDataView:      adddata.New(adddata.Params{}),
+		EditDataView:     editdata.New(editdata.Params{}),
+		RegistrationView: registration.New(registration.Params{}),
+		TrashView:        trash.New(trash.Params{}),
+	})
+	require.NoError(t, err)
+
+	// Инициализируем приложение.
+	tm := teatest.NewTestModel(t, bubble, teatest.WithInitialTermSize(130, 40))
+
+	waitFor(t, tm, func(s string) bool {
+		return strings.Contains(s, "Authorization")
+	})
+
+	// За счет мока сразу авторизуемся.
+	tm.Send(tea.KeyMsg{Type: tea.KeyEnter})
+
+	// Проверяем, что в строке состояния отображаются версии,
+	// предупреждение о несовместимости и занятое место.
+	waitFor(t, tm, func(s string) bool {
+		return strings.Contains(s, "client v1.0.0 | server v2.0.0") &&
+			strings.Contains(s, "is incompatible") &&
+			strings.Contains(s, "3/100 items | 2.0 KiB")
+	})
+}
+
+func newInfoServiceMock() *mock.InfoServiceMock {
+	return &mock.InfoServiceMock{
+		ServerInfoFunc: func(ctx context.Context) (client.ServerInfo, error) {
+			return client.ServerInfo{}, nil
+		},
+		UsageFunc: func(ctx context.Context) (client.Usage, error) {
+			return client.Usage{}, nil
+		},
+	}
+}
+
 func waitFor(t *testing.T, tm *teatest.TestModel, cond func(s string) bool) {
 	t.Helper()
 
@@ -56,6 +56,7 @@ type Model struct {
 	noteService   client.NoteService
 	binaryService client.BinaryService
 	cardService   client.CardService
+	otpService    client.OTPService
 }
 
 type Params struct {
@@ -65,6 +66,7 @@ type Params struct {
 	NoteService   client.NoteService
 	BinaryService client.BinaryService
 	CardService   client.CardService
+	OTPService    client.OTPService
 }
 
 func New(p Params) *Model {
@@ -93,6 +95,7 @@ func New(p Params) *Model {
 		noteService:   p.NoteService,
 		binaryService: p.BinaryService,
 		cardService:   p.CardService,
+		otpService:    p.OTPService,
 	}
 }
 
@@ -209,6 +212,33 @@ func (m *Model) ResetFor(t helper.DataType) {
 				Notes:      values["Notes"],
 			})
 		}
+	case helper.DataTypeOTP:
+		m.inputSet = inputset.NewInputSet(
+			inputset.NewTextInput("otpauth:// URI"),
+			inputset.NewTextInput("Name"),
+			inputset.NewTextInput("Secret", inputset.WithEchoModePassword()),
+			inputset.NewTextInput("Issuer"),
+			inputset.NewTextInput("Type", inputset.WithValue(client.OTPTypeTOTP)),
+			inputset.NewTextInput("Algorithm", inputset.WithValue("SHA1")),
+			inputset.NewTextInput("Digits", inputset.WithValue("6")),
+			inputset.NewTextInput("Period", inputset.WithValue("30")),
+			inputset.NewTextInput("Counter", inputset.WithValue("0")),
+		)
+		m.send = func(values map[string]string) error {
+			data, err := helper.ParseOTPValues(values)
+			if uri := values["otpauth:// URI"]; uri != "" {
+				// Параметры берутся из URI, имя можно задать вручную.
+				name := values["Name"]
+				data, err = client.ParseOTPURI(uri)
+				if name != "" {
+					data.Name = name
+				}
+			}
+			if err != nil {
+				return err
+			}
+			return m.otpService.Save(context.Background(), data)
+		}
 	}
 }
 
@@ -2,6 +2,7 @@ package authorization
 
 import (
 	"context"
+	"errors"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/mkolibaba/gophkeeper/client"
@@ -18,6 +19,12 @@ type Model struct {
 	inputSet             *inputset.Model
 	authorizationService client.AuthorizationService
 	userService          client.UserService
+
+	// mfaInputSet - форма ввода второго фактора.
+	mfaInputSet *inputset.Model
+	// mfa - незавершенный вход, ожидающий второй фактор. Пока он задан,
+	// вместо формы логина показывается форма ввода кода.
+	mfa *MFARequiredMsg
 }
 
 type Params struct {
@@ -35,6 +42,9 @@ func New(p Params) *Model {
 			inputset.NewTextInput("Login"),
 			inputset.NewTextInput("Password", inputset.WithEchoModePassword()),
 		),
+		mfaInputSet: inputset.NewInputSet(
+			inputset.NewTextInput("Code"),
+		),
 	}
 }
 
@@ -43,7 +53,19 @@ func (m *Model) Init() tea.Cmd {
 }
 
 func (m *Model) Update(msg tea.Msg) tea.Cmd {
+	if m.mfa != nil {
+		return m.updateMFA(msg)
+	}
+
 	switch msg := msg.(type) {
+	case MFARequiredMsg:
+		m.mfa = &msg
+		m.inputSet.Err = nil
+		m.inputSet.Reset()
+		m.mfaInputSet.Err = nil
+		m.mfaInputSet.Reset()
+		return m.mfaInputSet.Init()
+
 	case AuthorizationResultMsg:
 		m.inputSet.Err = msg.Err
 		m.inputSet.Reset()
@@ -69,7 +91,54 @@ func (m *Model) Update(msg tea.Msg) tea.Cmd {
 	return m.inputSet.Update(msg)
 }
 
+// updateMFA обрабатывает события формы ввода второго фактора.
+func (m *Model) updateMFA(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case AuthorizationResultMsg:
+		m.mfaInputSet.Err = msg.Err
+		m.mfaInputSet.Reset()
+		if msg.Err == nil {
+			m.mfa = nil
+		}
+		return nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c":
+			return tea.Quit
+
+		case "esc":
+			// Возврат к вводу логина и пароля.
+			m.mfa = nil
+			return m.inputSet.Init()
+
+		case "enter":
+			return m.verifyMFA(*m.mfa)
+		}
+	}
+
+	return m.mfaInputSet.Update(msg)
+}
+
 func (m *Model) View() string {
+	if m.mfa != nil {
+		return helper.Borderize(
+			"Two-factor authentication",
+			"",
+			lipgloss.NewStyle().
+				PaddingTop(1).
+				PaddingLeft(1).
+				Render(lipgloss.JoinVertical(
+					lipgloss.Top,
+					"Enter a code from the authenticator app or a recovery code.",
+					"",
+					m.mfaInputSet.View(),
+				)),
+			m.Width,
+			m.Height/2,
+		)
+	}
+
 	return helper.Borderize(
 		"Authorization",
 		"",
@@ -90,9 +159,38 @@ func (m *Model) authorize() tea.Cmd {
 	values := m.inputSet.Values()
 	return func() tea.Msg {
 		login, password := values["Login"], values["Password"]
-		token, err := m.authorizationService.Authorize(context.Background(), login, password)
+		tokens, err := m.authorizationService.Authorize(context.Background(), login, password)
+		var mfaErr *client.MFARequiredError
+		if errors.As(err, &mfaErr) {
+			return MFARequiredMsg{
+				Login:     login,
+				Password:  password,
+				Challenge: mfaErr.Challenge,
+			}
+		}
+		if err == nil {
+			m.userService.SetInfo(login, tokens)
+		}
+
+		return AuthorizationResultMsg{
+			Err: err,
+		}
+	}
+}
+
+// MFARequiredMsg - пароль верный, для входа нужен второй фактор.
+type MFARequiredMsg struct {
+	Login     string
+	Password  string
+	Challenge string
+}
+
+func (m *Model) verifyMFA(mfa MFARequiredMsg) tea.Cmd {
+	code := m.mfaInputSet.Values()["Code"]
+	return func() tea.Msg {
+		tokens, err := m.authorizationService.VerifyMFA(context.Background(), mfa.Challenge, mfa.Login, mfa.Password, code)
 		if err == nil {
-			m.userService.SetInfo(login, token)
+			m.userService.SetInfo(mfa.Login, tokens)
 		}
 
 		return AuthorizationResultMsg{
@@ -2,6 +2,7 @@ package editdata
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/key"
@@ -12,11 +13,17 @@ import (
 	"github.com/mkolibaba/gophkeeper/client/tui/helper"
 	"github.com/mkolibaba/gophkeeper/client/tui/view"
 	"go.uber.org/fx"
+	"strconv"
 )
 
+var errDataRemoved = errors.New("data was removed by another client")
+
 type keyMap struct {
-	Send key.Binding
-	Exit key.Binding
+	Send       key.Binding
+	KeepMine   key.Binding
+	TakeTheirs key.Binding
+	Merge      key.Binding
+	Exit       key.Binding
 }
 
 func (k keyMap) ShortHelp() []key.Binding {
@@ -26,20 +33,33 @@ func (k keyMap) ShortHelp() []key.Binding {
 func (k keyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
 		{k.Send},
+		{k.KeepMine, k.TakeTheirs, k.Merge},
 		{k.Exit},
 	}
 }
 
+// setConflictKeysEnabled включает клавиши разрешения конфликта версий.
+func (k *keyMap) setConflictKeysEnabled(enabled bool) {
+	k.KeepMine.SetEnabled(enabled)
+	k.TakeTheirs.SetEnabled(enabled)
+	k.Merge.SetEnabled(enabled)
+}
+
 type Model struct {
 	view.BaseModel
 	keyMap        keyMap
 	inputSet      *inputset.Model
 	dataName      string
-	send          func(map[string]string) error
+	data          client.Data
+	base          map[string]string // значения полей до редактирования
+	conflict      *client.VersionConflictError
+	send          func(values map[string]string, version int64) error
+	fetch         func(ctx context.Context) (client.Data, error)
 	loginService  client.LoginService
 	noteService   client.NoteService
 	binaryService client.BinaryService
 	cardService   client.CardService
+	otpService    client.OTPService
 }
 
 type Params struct {
@@ -49,6 +69,7 @@ type Params struct {
 	NoteService   client.NoteService
 	BinaryService client.BinaryService
 	CardService   client.CardService
+	OTPService    client.OTPService
 }
 
 func New(p Params) *Model {
@@ -58,6 +79,21 @@ func New(p Params) *Model {
 				key.WithKeys("ctrl+s"),
 				key.WithHelp("ctrl+s", "save"),
 			),
+			KeepMine: key.NewBinding(
+				key.WithKeys("alt+m"),
+				key.WithHelp("alt+m", "keep mine"),
+				key.WithDisabled(),
+			),
+			TakeTheirs: key.NewBinding(
+				key.WithKeys("alt+t"),
+				key.WithHelp("alt+t", "take theirs"),
+				key.WithDisabled(),
+			),
+			Merge: key.NewBinding(
+				key.WithKeys("alt+g"),
+				key.WithHelp("alt+g", "merge"),
+				key.WithDisabled(),
+			),
 			Exit: key.NewBinding(
 				key.WithKeys("esc"),
 				key.WithHelp("esc", "exit"),
@@ -67,6 +103,7 @@ func New(p Params) *Model {
 		noteService:   p.NoteService,
 		binaryService: p.BinaryService,
 		cardService:   p.CardService,
+		otpService:    p.OTPService,
 	}
 }
 
@@ -78,11 +115,33 @@ func (m *Model) Update(msg tea.Msg) tea.Cmd {
 	switch msg := msg.(type) {
 	case EditDataResultMsg:
 		m.inputSet.Err = msg.Err
+		if errors.As(msg.Err, &m.conflict) {
+			m.keyMap.setConflictKeysEnabled(true)
+		}
+
+	case theirsMsg:
+		if msg.err != nil {
+			m.inputSet.Err = msg.err
+			return nil
+		}
+		m.merge(msg.data)
 
 	case tea.KeyMsg:
 		switch {
 		case key.Matches(msg, m.keyMap.Send):
-			return m.save()
+			return m.save(m.data.GetVersion())
+
+		case key.Matches(msg, m.keyMap.KeepMine):
+			// Перезаписываем серверную версию своими изменениями.
+			return m.save(m.conflict.Version)
+
+		case key.Matches(msg, m.keyMap.TakeTheirs):
+			// Отказываемся от своих изменений, на главном экране будут
+			// загружены актуальные данные.
+			return Exit
+
+		case key.Matches(msg, m.keyMap.Merge):
+			return m.fetchTheirs()
 
 		case key.Matches(msg, m.keyMap.Exit):
 			return Exit
@@ -114,6 +173,9 @@ func (m *Model) View() string {
 
 func (m *Model) ResetFor(data client.Data) {
 	m.dataName = data.GetName()
+	m.data = data
+	m.conflict = nil
+	m.keyMap.setConflictKeysEnabled(false)
 	switch data := data.(type) {
 	case client.LoginData:
 		m.inputSet = inputset.NewInputSet(
@@ -123,7 +185,7 @@ func (m *Model) ResetFor(data client.Data) {
 			inputset.NewTextInput("Website", inputset.WithValue(data.Website)),
 			inputset.NewTextInput("Notes", inputset.WithValue(data.Notes)),
 		)
-		m.send = func(values map[string]string) error {
+		m.send = func(values map[string]string, version int64) error {
 			name := values["Name"]
 			login := values["Login"]
 			password := values["Password"]
@@ -131,6 +193,7 @@ func (m *Model) ResetFor(data client.Data) {
 			notes := values["Notes"]
 			return m.loginService.Update(context.Background(), client.LoginDataUpdate{
 				ID:       data.ID,
+				Version:  version,
 				Name:     &name,
 				Login:    &login,
 				Password: &password,
@@ -138,33 +201,47 @@ func (m *Model) ResetFor(data client.Data) {
 				Notes:    &notes,
 			})
 		}
+		m.fetch = func(ctx context.Context) (client.Data, error) {
+			items, err := m.loginService.GetAll(ctx)
+			return find(items, err, data.ID)
+		}
 	case client.NoteData:
 		m.inputSet = inputset.NewInputSet(
 			inputset.NewTextInput("Name", inputset.WithValue(data.Name)),
 			inputset.NewTextArea("Text", inputset.WithTextAreaValue(data.Text)),
 		)
-		m.send = func(values map[string]string) error {
+		m.send = func(values map[string]string, version int64) error {
 			name, text := values["Name"], values["Text"]
 			return m.noteService.Update(context.Background(), client.NoteDataUpdate{
-				ID:   data.ID,
-				Name: &name,
-				Text: &text,
+				ID:      data.ID,
+				Version: version,
+				Name:    &name,
+				Text:    &text,
 			})
 		}
+		m.fetch = func(ctx context.Context) (client.Data, error) {
+			items, err := m.noteService.GetAll(ctx)
+			return find(items, err, data.ID)
+		}
 	case client.BinaryData:
 		m.inputSet = inputset.NewInputSet(
 			inputset.NewTextInput("Name", inputset.WithValue(data.Name)),
 			inputset.NewFilePicker("File path", inputset.WithFilePickerDisabled()),
 			inputset.NewTextInput("Notes", inputset.WithValue(data.Notes)),
 		)
-		m.send = func(values map[string]string) error {
+		m.send = func(values map[string]string, version int64) error {
 			name, notes := values["Name"], values["Notes"]
 			return m.binaryService.Update(context.Background(), client.BinaryDataUpdate{
-				ID:    data.ID,
-				Name:  &name,
-				Notes: &notes,
+				ID:      data.ID,
+				Version: version,
+				Name:    &name,
+				Notes:   &notes,
 			})
 		}
+		m.fetch = func(ctx context.Context) (client.Data, error) {
+			items, err := m.binaryService.GetAll(ctx)
+			return find(items, err, data.ID)
+		}
 	case client.CardData:
 		m.inputSet = inputset.NewInputSet(
 			inputset.NewTextInput("Name", inputset.WithValue(data.Name)),
@@ -174,7 +251,7 @@ func (m *Model) ResetFor(data client.Data) {
 			inputset.NewTextInput("Cardholder", inputset.WithValue(data.Cardholder)),
 			inputset.NewTextInput("Notes", inputset.WithValue(data.Notes)),
 		)
-		m.send = func(values map[string]string) error {
+		m.send = func(values map[string]string, version int64) error {
 			name := values["Name"]
 			number := values["Number"]
 			expDate := values["Expiration date"]
@@ -183,6 +260,7 @@ func (m *Model) ResetFor(data client.Data) {
 			notes := values["Notes"]
 			return m.cardService.Update(context.Background(), client.CardDataUpdate{
 				ID:         data.ID,
+				Version:    version,
 				Name:       &name,
 				Number:     &number,
 				ExpDate:    &expDate,
@@ -191,7 +269,45 @@ func (m *Model) ResetFor(data client.Data) {
 				Notes:      &notes,
 			})
 		}
+		m.fetch = func(ctx context.Context) (client.Data, error) {
+			items, err := m.cardService.GetAll(ctx)
+			return find(items, err, data.ID)
+		}
+	case client.OTPData:
+		m.inputSet = inputset.NewInputSet(
+			inputset.NewTextInput("Name", inputset.WithValue(data.Name)),
+			inputset.NewTextInput("Secret", inputset.WithValue(data.Secret), inputset.WithEchoModePassword()),
+			inputset.NewTextInput("Issuer", inputset.WithValue(data.Issuer)),
+			inputset.NewTextInput("Type", inputset.WithValue(data.Type)),
+			inputset.NewTextInput("Algorithm", inputset.WithValue(data.Algorithm)),
+			inputset.NewTextInput("Digits", inputset.WithValue(strconv.FormatInt(data.Digits, 10))),
+			inputset.NewTextInput("Period", inputset.WithValue(strconv.FormatInt(data.Period, 10))),
+			inputset.NewTextInput("Counter", inputset.WithValue(strconv.FormatInt(data.Counter, 10))),
+		)
+		m.send = func(values map[string]string, version int64) error {
+			otp, err := helper.ParseOTPValues(values)
+			if err != nil {
+				return err
+			}
+			return m.otpService.Update(context.Background(), client.OTPDataUpdate{
+				ID:        data.ID,
+				Version:   version,
+				Name:      &otp.Name,
+				Type:      &otp.Type,
+				Secret:    &otp.Secret,
+				Algorithm: &otp.Algorithm,
+				Digits:    &otp.Digits,
+				Period:    &otp.Period,
+				Counter:   &otp.Counter,
+				Issuer:    &otp.Issuer,
+			})
+		}
+		m.fetch = func(ctx context.Context) (client.Data, error) {
+			items, err := m.otpService.GetAll(ctx)
+			return find(items, err, data.ID)
+		}
 	}
+	m.base = m.inputSet.Values()
 }
 
 type ExitMsg struct{}
@@ -205,12 +321,54 @@ type EditDataResultMsg struct {
 	Err  error
 }
 
-func (m *Model) save() tea.Cmd {
+// theirsMsg содержит актуальную серверную версию редактируемых данных.
+type theirsMsg struct {
+	data client.Data
+	err  error
+}
+
+func (m *Model) save(version int64) tea.Cmd {
 	values := m.inputSet.Values()
 	return func() tea.Msg {
 		return EditDataResultMsg{
 			Name: values["Name"],
-			Err:  m.send(values),
+			Err:  m.send(values, version),
+		}
+	}
+}
+
+func (m *Model) fetchTheirs() tea.Cmd {
+	return func() tea.Msg {
+		data, err := m.fetch(context.Background())
+		return theirsMsg{data: data, err: err}
+	}
+}
+
+// merge выполняет трехстороннее слияние: поля, измененные пользователем,
+// сохраняют его значения, остальные берутся из серверной версии.
+func (m *Model) merge(theirs client.Data) {
+	mine, base := m.inputSet.Values(), m.base
+	m.ResetFor(theirs)
+
+	merged := make(map[string]string, len(m.base))
+	for field, value := range m.base {
+		merged[field] = value
+		if mine[field] != base[field] {
+			merged[field] = mine[field]
+		}
+	}
+	m.inputSet.SetValues(merged)
+}
+
+// find ищет данные с указанным id среди полученных с сервера.
+func find[T client.Data](items []T, err error, id int64) (client.Data, error) {
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		if item.GetID() == id {
+			return item, nil
 		}
 	}
+	return nil, errDataRemoved
 }
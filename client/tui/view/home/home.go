@@ -16,6 +16,7 @@ import (
 	"github.com/mkolibaba/gophkeeper/client/tui/view/adddata"
 	"github.com/mkolibaba/gophkeeper/client/tui/view/authorization"
 	"github.com/mkolibaba/gophkeeper/client/tui/view/editdata"
+	"github.com/mkolibaba/gophkeeper/client/tui/view/registration"
 	"go.uber.org/fx"
 	"sync"
 )
@@ -33,17 +34,47 @@ func CallAddDataView(dataType helper.DataType) tea.Cmd {
 
 type CallEditDataViewMsg client.Data
 
+// CallTrashViewMsg отправляется при вызове пользователем окна корзины.
+type CallTrashViewMsg struct{}
+
 type loadDataMsg []client.Data
 
+type serverInfoMsg struct {
+	info client.ServerInfo
+	err  error
+}
+
+type usageMsg struct {
+	usage client.Usage
+	err   error
+}
+
+// removedMsg сообщает об удалении данных: удаление можно отменить.
+type removedMsg struct {
+	data client.Data
+}
+
+type historyMsg struct {
+	data    client.Data
+	entries []client.HistoryEntry
+	err     error
+}
+
 type keyMap struct {
 	UpDown         key.Binding
 	AddLogin       key.Binding
 	AddNote        key.Binding
 	AddBinary      key.Binding
 	AddCard        key.Binding
+	AddOTP         key.Binding
 	EditData       key.Binding
 	DownloadBinary key.Binding // TODO(minor): показывать только тогда, когда выбран binary тип
 	Remove         key.Binding
+	Undo           key.Binding
+	Trash          key.Binding
+	History        key.Binding
+	RestoreVersion key.Binding
+	CloseHistory   key.Binding
 	Help           key.Binding
 	Quit           key.Binding
 }
@@ -55,34 +86,47 @@ func (k keyMap) ShortHelp() []key.Binding {
 func (k keyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
 		{k.UpDown},
-		{k.AddLogin, k.AddNote, k.AddBinary, k.AddCard},
-		{k.EditData, k.DownloadBinary, k.Remove},
+		{k.AddLogin, k.AddNote, k.AddBinary, k.AddCard, k.AddOTP},
+		{k.EditData, k.DownloadBinary, k.Remove, k.Undo, k.Trash},
+		{k.History, k.RestoreVersion, k.CloseHistory},
 		{k.Quit},
 	}
 }
 
 type Model struct {
 	view.BaseModel
-	dataTable     *table.Model
-	dataDetail    detail.Model
-	keyMap        keyMap
-	showHelp      bool
-	statusBar     *statusbar.Model
-	loginService  client.LoginService
-	binaryService client.BinaryService
-	noteService   client.NoteService
-	cardService   client.CardService
-	userService   client.UserService
+	dataTable      *table.Model
+	dataDetail     detail.Model
+	keyMap         keyMap
+	showHelp       bool
+	statusBar      *statusbar.Model
+	loginService   client.LoginService
+	binaryService  client.BinaryService
+	noteService    client.NoteService
+	cardService    client.CardService
+	otpService     client.OTPService
+	historyService client.HistoryService
+	trashService   client.TrashService
+	userService    client.UserService
+	infoService    client.InfoService
+	build          client.BuildInfo
+	// removed - последние удаленные данные, удаление которых можно отменить.
+	removed client.Data
 }
 
 type Params struct {
 	fx.In
 
-	LoginService  client.LoginService
-	BinaryService client.BinaryService
-	NoteService   client.NoteService
-	CardService   client.CardService
-	UserService   client.UserService
+	LoginService   client.LoginService
+	BinaryService  client.BinaryService
+	NoteService    client.NoteService
+	CardService    client.CardService
+	OTPService     client.OTPService
+	HistoryService client.HistoryService
+	TrashService   client.TrashService
+	UserService    client.UserService
+	InfoService    client.InfoService
+	Build          client.BuildInfo
 }
 
 func New(p Params) *Model {
@@ -107,10 +151,22 @@ func New(p Params) *Model {
 			key.WithKeys("alt+4"),
 			key.WithHelp("alt+4", "add card"),
 		),
+		AddOTP: key.NewBinding(
+			key.WithKeys("alt+5"),
+			key.WithHelp("alt+5", "add otp"),
+		),
 		Remove: key.NewBinding(
 			key.WithKeys("ctrl+r"),
 			key.WithHelp("ctrl+r", "remove"),
 		),
+		Undo: key.NewBinding(
+			key.WithKeys("ctrl+z"),
+			key.WithHelp("ctrl+z", "undo remove"),
+		),
+		Trash: key.NewBinding(
+			key.WithKeys("alt+t"),
+			key.WithHelp("alt+t", "trash"),
+		),
 		EditData: key.NewBinding(
 			key.WithKeys("alt+e"),
 			key.WithHelp("alt+e", "edit"),
@@ -119,6 +175,18 @@ func New(p Params) *Model {
 			key.WithKeys("d"),
 			key.WithHelp("d", "download binary"),
 		),
+		History: key.NewBinding(
+			key.WithKeys("alt+h"),
+			key.WithHelp("alt+h", "history"),
+		),
+		RestoreVersion: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "restore version"),
+		),
+		CloseHistory: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "close history"),
+		),
 		Help: key.NewBinding(
 			key.WithKeys("h"),
 		),
@@ -133,15 +201,20 @@ func New(p Params) *Model {
 	statusBar := statusbar.New()
 
 	return &Model{
-		dataTable:     dataTable,
-		dataDetail:    dataDetail,
-		statusBar:     statusBar,
-		keyMap:        keys,
-		loginService:  p.LoginService,
-		binaryService: p.BinaryService,
-		noteService:   p.NoteService,
-		cardService:   p.CardService,
-		userService:   p.UserService,
+		dataTable:      dataTable,
+		dataDetail:     dataDetail,
+		statusBar:      statusBar,
+		keyMap:         keys,
+		loginService:   p.LoginService,
+		binaryService:  p.BinaryService,
+		noteService:    p.NoteService,
+		cardService:    p.CardService,
+		otpService:     p.OTPService,
+		historyService: p.HistoryService,
+		trashService:   p.TrashService,
+		userService:    p.UserService,
+		infoService:    p.InfoService,
+		build:          p.Build,
 	}
 }
 
@@ -156,7 +229,10 @@ func (m *Model) Update(msg tea.Msg) tea.Cmd {
 	case loadDataMsg:
 		m.statusBar.CurrentUser = m.userService.GetUserLogin()
 		m.dataTable.ProcessFetchedData(msg)
-		m.dataDetail.Data = m.dataTable.GetCurrentRow()
+		cmd = tea.Batch(
+			m.dataDetail.SetData(m.dataTable.GetCurrentRow()),
+			m.loadUsage(),
+		)
 
 	case adddata.AddDataResultMsg:
 		// По процессу условие всегда true.
@@ -181,14 +257,46 @@ func (m *Model) Update(msg tea.Msg) tea.Cmd {
 	case authorization.AuthorizationResultMsg:
 		// По процессу условие всегда true.
 		if msg.Err == nil {
-			return m.LoadData()
+			return tea.Batch(m.LoadData(), m.loadServerInfo())
+		}
+
+	case registration.RegistrationResultMsg:
+		if msg.Err == nil {
+			return tea.Batch(m.LoadData(), m.loadServerInfo())
+		}
+
+	case serverInfoMsg:
+		return m.setServerInfo(msg)
+
+	case usageMsg:
+		// Без связи с сервером остается последнее известное значение.
+		if msg.err == nil {
+			m.statusBar.Usage = msg.usage.String()
+		}
+
+	case removedMsg:
+		return m.setRemoved(msg.data)
+
+	case historyMsg:
+		if msg.err != nil {
+			return m.NotifyError("Loading history of %s failed: %v", msg.data.GetName(), msg.err)
+		}
+		// Пока история загружалась, могли выбрать другие данные.
+		if m.dataDetail.Data == msg.data {
+			m.dataDetail.ShowHistory(msg.entries)
 		}
 
 	case tea.KeyMsg:
+		if m.dataDetail.HistoryShown() {
+			return tea.Batch(m.updateHistory(msg), m.statusBar.Update(msg))
+		}
+
 		switch {
 		case key.Matches(msg, m.keyMap.UpDown):
-			cmd = m.dataTable.Update(msg)
-			m.dataDetail.Data = m.dataTable.GetCurrentRow()
+			cmd = tea.Batch(
+				m.dataTable.Update(msg),
+				m.dataDetail.SetData(m.dataTable.GetCurrentRow()),
+			)
 
 		case key.Matches(msg, m.keyMap.Quit):
 			return tea.Quit
@@ -209,6 +317,23 @@ func (m *Model) Update(msg tea.Msg) tea.Cmd {
 			current := m.dataTable.GetCurrentRow()
 			return m.removeData(current)
 
+		case key.Matches(msg, m.keyMap.Undo):
+			if m.removed != nil && m.statusBar.UndoAvailable() {
+				removed := m.removed
+				m.removed = nil
+				return m.undoRemove(removed)
+			}
+
+		case key.Matches(msg, m.keyMap.Trash):
+			return func() tea.Msg {
+				return CallTrashViewMsg{}
+			}
+
+		case key.Matches(msg, m.keyMap.History):
+			if current := m.dataTable.GetCurrentRow(); current != nil {
+				return m.loadHistory(current)
+			}
+
 		case key.Matches(msg, m.keyMap.AddLogin):
 			return CallAddDataView(helper.DataTypeLogin)
 
@@ -221,6 +346,9 @@ func (m *Model) Update(msg tea.Msg) tea.Cmd {
 		case key.Matches(msg, m.keyMap.AddCard):
 			return CallAddDataView(helper.DataTypeCard)
 
+		case key.Matches(msg, m.keyMap.AddOTP):
+			return CallAddDataView(helper.DataTypeOTP)
+
 		case key.Matches(msg, m.keyMap.Help):
 			m.showHelp = !m.showHelp
 		}
@@ -228,6 +356,7 @@ func (m *Model) Update(msg tea.Msg) tea.Cmd {
 
 	return tea.Batch(
 		cmd,
+		m.dataDetail.Update(msg),
 		m.statusBar.Update(msg),
 	)
 }
@@ -321,6 +450,10 @@ func (m *Model) LoadData() tea.Cmd {
 			elems, err := m.cardService.GetAll(ctx)
 			collect(elems, err, ch)
 		})
+		wg.Go(func() {
+			elems, err := m.otpService.GetAll(ctx)
+			collect(elems, err, ch)
+		})
 
 		go func() {
 			wg.Wait()
@@ -335,6 +468,39 @@ func (m *Model) LoadData() tea.Cmd {
 	}
 }
 
+func (m *Model) loadServerInfo() tea.Cmd {
+	return func() tea.Msg {
+		info, err := m.infoService.ServerInfo(context.Background())
+		return serverInfoMsg{info: info, err: err}
+	}
+}
+
+// loadUsage запрашивает занятое место: оно меняется вместе с данными,
+// поэтому запрашивается после каждой загрузки данных.
+func (m *Model) loadUsage() tea.Cmd {
+	return func() tea.Msg {
+		usage, err := m.infoService.Usage(context.Background())
+		return usageMsg{usage: usage, err: err}
+	}
+}
+
+func (m *Model) setServerInfo(msg serverInfoMsg) tea.Cmd {
+	if msg.err != nil {
+		m.statusBar.Version = fmt.Sprintf("client %s", m.build.Version)
+		return nil
+	}
+
+	m.statusBar.Version = fmt.Sprintf("client %s | server %s", m.build.Version, msg.info.Build.Version)
+	m.statusBar.VersionWarning = !msg.info.Compatible()
+	if m.statusBar.VersionWarning {
+		return m.statusBar.NotifyError(fmt.Sprintf(
+			"Server protocol v%d is incompatible with client protocol v%d",
+			msg.info.ProtocolVersion, client.ProtocolVersion,
+		))
+	}
+	return nil
+}
+
 func (m *Model) NotifyOk(format string, a ...any) tea.Cmd {
 	return m.statusBar.NotifyOk(fmt.Sprintf(format, a...))
 }
@@ -345,12 +511,12 @@ func (m *Model) NotifyError(format string, a ...any) tea.Cmd {
 
 func (m *Model) startDownloadBinary(data client.BinaryData) tea.Cmd {
 	return func() tea.Msg {
-		err := m.binaryService.Download(context.Background(), data.ID)
+		path, err := m.binaryService.Download(context.Background(), data.ID)
 		if err != nil {
 			return m.statusBar.NotifyError(fmt.Sprintf("Download %s failed: %v", data.Name, err))
 		}
 
-		return m.statusBar.NotifyOk(fmt.Sprintf("Downloaded %s successfully", data.Name))
+		return m.statusBar.NotifyOk(fmt.Sprintf("Downloaded %s to %s", data.Name, path))
 	}
 }
 
@@ -363,22 +529,122 @@ func (m *Model) removeData(data client.Data) tea.Cmd {
 
 		switch data := data.(type) {
 		case client.LoginData:
-			err = m.loginService.Remove(ctx, data.ID)
+			err = m.loginService.Remove(ctx, data.ID, data.Version)
 		case client.NoteData:
-			err = m.noteService.Remove(ctx, data.ID)
+			err = m.noteService.Remove(ctx, data.ID, data.Version)
 		case client.BinaryData:
-			err = m.binaryService.Remove(ctx, data.ID)
+			err = m.binaryService.Remove(ctx, data.ID, data.Version)
 		case client.CardData:
-			err = m.cardService.Remove(ctx, data.ID)
+			err = m.cardService.Remove(ctx, data.ID, data.Version)
+		case client.OTPData:
+			err = m.otpService.Remove(ctx, data.ID, data.Version)
 		}
 
 		if err != nil {
 			return m.NotifyError("Removing %s failed: %v", data.GetName(), err)
 		}
 
+		return removedMsg{data: data}
+	}
+}
+
+// setRemoved запоминает удаленные данные для отмены удаления. Данные,
+// созданные без связи с сервером, удаляются без корзины.
+func (m *Model) setRemoved(data client.Data) tea.Cmd {
+	if data.GetID() < 0 {
+		m.removed = nil
 		return tea.Batch(
 			m.NotifyOk("Removed %s successfully", data.GetName()),
 			m.LoadData(),
+		)
+	}
+
+	m.removed = data
+	return tea.Batch(
+		m.statusBar.NotifyUndo(fmt.Sprintf("Removed %s. %s to undo", data.GetName(), m.keyMap.Undo.Help().Key)),
+		m.LoadData(),
+	)
+}
+
+// undoRemove возвращает удаленные данные из корзины.
+func (m *Model) undoRemove(data client.Data) tea.Cmd {
+	kind, ok := client.DataKindOf(data)
+	if !ok {
+		return nil
+	}
+
+	return func() tea.Msg {
+		err := m.trashService.Restore(context.Background(), kind, data.GetID())
+		if err != nil {
+			return m.NotifyError("Undoing removal of %s failed: %v", data.GetName(), err)
+		}
+
+		return tea.Batch(
+			m.NotifyOk("Restored %s", data.GetName()),
+			m.LoadData(),
+		)()
+	}
+}
+
+// updateHistory обрабатывает нажатия клавиш, пока открыта панель истории.
+func (m *Model) updateHistory(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, m.keyMap.UpDown):
+		if msg.String() == "up" {
+			m.dataDetail.MoveHistoryCursor(-1)
+		} else {
+			m.dataDetail.MoveHistoryCursor(1)
+		}
+
+	case key.Matches(msg, m.keyMap.RestoreVersion):
+		if entry, ok := m.dataDetail.SelectedVersion(); ok {
+			return m.restoreVersion(m.dataDetail.Data, entry)
+		}
+
+	case key.Matches(msg, m.keyMap.CloseHistory), key.Matches(msg, m.keyMap.History):
+		m.dataDetail.HideHistory()
+
+	case key.Matches(msg, m.keyMap.Quit):
+		return tea.Quit
+
+	case key.Matches(msg, m.keyMap.Help):
+		m.showHelp = !m.showHelp
+	}
+	return nil
+}
+
+// loadHistory запрашивает прежние версии data. Данные, созданные без связи
+// с сервером, еще не имеют истории.
+func (m *Model) loadHistory(data client.Data) tea.Cmd {
+	kind, ok := client.DataKindOf(data)
+	if !ok {
+		return nil
+	}
+	if data.GetID() < 0 {
+		return m.NotifyError("%s is not synced with the server yet", data.GetName())
+	}
+
+	return func() tea.Msg {
+		entries, err := m.historyService.List(context.Background(), kind, data.GetID())
+		return historyMsg{data: data, entries: entries, err: err}
+	}
+}
+
+func (m *Model) restoreVersion(data client.Data, entry client.HistoryEntry) tea.Cmd {
+	kind, ok := client.DataKindOf(data)
+	if !ok {
+		return nil
+	}
+
+	return func() tea.Msg {
+		err := m.historyService.Restore(context.Background(), kind, data.GetID(), entry.Version)
+		if err != nil {
+			return m.NotifyError("Restoring %s failed: %v", data.GetName(), err)
+		}
+
+		return tea.Batch(
+			m.NotifyOk("Restored %s to version %d", data.GetName(), entry.Version),
+			m.LoadData(),
 		)()
 	}
 }
@@ -89,9 +89,9 @@ func (m *Model) register() tea.Cmd {
 			return RegistrationResultMsg{Err: fmt.Errorf("passwords do not match")}
 		}
 
-		token, err := m.authorizationService.Register(context.Background(), login, password)
+		tokens, err := m.authorizationService.Register(context.Background(), login, password)
 		if err == nil {
-			m.userService.SetInfo(login, token)
+			m.userService.SetInfo(login, tokens)
 		}
 
 		return RegistrationResultMsg{Err: err}
@@ -0,0 +1,241 @@
+package trash
+
+import (
+	"context"
+	"fmt"
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mkolibaba/gophkeeper/client"
+	"github.com/mkolibaba/gophkeeper/client/tui/components/table"
+	"github.com/mkolibaba/gophkeeper/client/tui/helper"
+	"github.com/mkolibaba/gophkeeper/client/tui/view"
+	"go.uber.org/fx"
+	"time"
+)
+
+type keyMap struct {
+	UpDown  key.Binding
+	Restore key.Binding
+	Purge   key.Binding
+	Exit    key.Binding
+}
+
+func (k keyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Restore, k.Exit}
+}
+
+func (k keyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.UpDown},
+		{k.Restore, k.Purge},
+		{k.Exit},
+	}
+}
+
+type ExitMsg struct{}
+
+func Exit() tea.Msg {
+	return ExitMsg{}
+}
+
+type loadTrashMsg struct {
+	entries []client.TrashEntry
+	err     error
+}
+
+// actionResultMsg - результат восстановления или окончательного удаления.
+type actionResultMsg struct {
+	text string
+	err  error
+}
+
+type Model struct {
+	view.BaseModel
+	keyMap       keyMap
+	dataTable    *table.Model
+	entries      []client.TrashEntry
+	message      string
+	err          error
+	trashService client.TrashService
+}
+
+type Params struct {
+	fx.In
+
+	TrashService client.TrashService
+}
+
+func New(p Params) *Model {
+	return &Model{
+		keyMap: keyMap{
+			UpDown: key.NewBinding(
+				key.WithKeys("up", "down"),
+				key.WithHelp("↑/↓", "move up/down"),
+			),
+			Restore: key.NewBinding(
+				key.WithKeys("enter"),
+				key.WithHelp("enter", "restore"),
+			),
+			Purge: key.NewBinding(
+				key.WithKeys("alt+d"),
+				key.WithHelp("alt+d", "delete forever"),
+			),
+			Exit: key.NewBinding(
+				key.WithKeys("esc"),
+				key.WithHelp("esc", "exit"),
+			),
+		},
+		dataTable:    table.New(),
+		trashService: p.TrashService,
+	}
+}
+
+// Init загружает содержимое корзины.
+func (m *Model) Init() tea.Cmd {
+	return m.load()
+}
+
+// Reset очищает окно перед открытием.
+func (m *Model) Reset() {
+	m.entries = nil
+	m.message = ""
+	m.err = nil
+	m.dataTable.ProcessFetchedData(nil)
+}
+
+func (m *Model) Update(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case loadTrashMsg:
+		if msg.err != nil {
+			m.err = fmt.Errorf("loading trash failed: %w", msg.err)
+			return nil
+		}
+		m.entries = msg.entries
+		data := make([]client.Data, 0, len(msg.entries))
+		for _, entry := range msg.entries {
+			data = append(data, entry.Data)
+		}
+		m.dataTable.ProcessFetchedData(data)
+
+	case actionResultMsg:
+		m.message, m.err = msg.text, msg.err
+		if msg.err == nil {
+			return m.load()
+		}
+
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.keyMap.UpDown):
+			// Пустая таблица не перемещает курсор.
+			if len(m.entries) > 0 {
+				return m.dataTable.Update(msg)
+			}
+
+		case key.Matches(msg, m.keyMap.Restore):
+			if data := m.dataTable.GetCurrentRow(); data != nil {
+				return m.restore(data)
+			}
+
+		case key.Matches(msg, m.keyMap.Purge):
+			if data := m.dataTable.GetCurrentRow(); data != nil {
+				return m.purge(data)
+			}
+
+		case key.Matches(msg, m.keyMap.Exit):
+			return Exit
+		}
+	}
+
+	return nil
+}
+
+func (m *Model) View() string {
+	// Строка помощи
+	hm := help.New()
+	hm.ShowAll = true
+	helpView := lipgloss.NewStyle().PaddingLeft(1).Render(hm.View(m.keyMap))
+
+	var messageView string
+	switch {
+	case m.err != nil:
+		messageView = lipgloss.NewStyle().PaddingLeft(1).Foreground(lipgloss.Color("169")).Render(m.err.Error())
+	case m.message != "":
+		messageView = lipgloss.NewStyle().PaddingLeft(1).Foreground(lipgloss.Color("34")).Render(m.message)
+	}
+
+	trashView := helper.Borderize(
+		"Trash",
+		m.renderInfoBar(),
+		lipgloss.NewStyle().
+			Padding(0, 1).
+			Render(m.dataTable.View()),
+		m.Width,
+		m.Height-lipgloss.Height(helpView)-lipgloss.Height(messageView),
+	)
+
+	return lipgloss.JoinVertical(lipgloss.Top, trashView, messageView, helpView)
+}
+
+func (m *Model) SetSize(width int, height int) {
+	m.BaseModel.SetSize(width, height)
+	m.dataTable.SetWidth(width - 2 - 2) // -2 для паддинга и -2 для границ
+}
+
+// renderInfoBar возвращает время удаления выбранной записи.
+func (m *Model) renderInfoBar() string {
+	entry, ok := m.current()
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf("deleted %s", entry.DeletedAt.Local().Format(time.DateTime))
+}
+
+// current возвращает запись корзины, выбранную в таблице.
+func (m *Model) current() (client.TrashEntry, bool) {
+	data := m.dataTable.GetCurrentRow()
+	for _, entry := range m.entries {
+		if entry.Data == data {
+			return entry, true
+		}
+	}
+	return client.TrashEntry{}, false
+}
+
+func (m *Model) load() tea.Cmd {
+	return func() tea.Msg {
+		entries, err := m.trashService.List(context.Background())
+		return loadTrashMsg{entries: entries, err: err}
+	}
+}
+
+func (m *Model) restore(data client.Data) tea.Cmd {
+	kind, ok := client.DataKindOf(data)
+	if !ok {
+		return nil
+	}
+
+	return func() tea.Msg {
+		err := m.trashService.Restore(context.Background(), kind, data.GetID())
+		if err != nil {
+			return actionResultMsg{err: fmt.Errorf("restoring %s failed: %w", data.GetName(), err)}
+		}
+		return actionResultMsg{text: fmt.Sprintf("Restored %s", data.GetName())}
+	}
+}
+
+func (m *Model) purge(data client.Data) tea.Cmd {
+	kind, ok := client.DataKindOf(data)
+	if !ok {
+		return nil
+	}
+
+	return func() tea.Msg {
+		err := m.trashService.Purge(context.Background(), kind, data.GetID())
+		if err != nil {
+			return actionResultMsg{err: fmt.Errorf("deleting %s failed: %w", data.GetName(), err)}
+		}
+		return actionResultMsg{text: fmt.Sprintf("Deleted %s forever", data.GetName())}
+	}
+}
@@ -20,6 +20,9 @@ const (
 
 	// ViewEditData - окно редактирования данных.
 	ViewEditData
+
+	// ViewTrash - корзина удаленных данных.
+	ViewTrash
 )
 
 // Model - представление состояния UI.
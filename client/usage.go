@@ -0,0 +1,42 @@
+package client
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Usage - данные, которые пользователь занимает на сервере, и его квота.
+// Нулевое ограничение квоты не действует.
+type Usage struct {
+	Bytes       int64
+	Items       int64
+	QuotaBytes  int64
+	QuotaItems  int64
+	MaxFileSize int64
+}
+
+func (u Usage) String() string {
+	items := strconv.FormatInt(u.Items, 10)
+	if u.QuotaItems > 0 {
+		items += "/" + strconv.FormatInt(u.QuotaItems, 10)
+	}
+	bytes := FormatBytes(u.Bytes)
+	if u.QuotaBytes > 0 {
+		bytes += "/" + FormatBytes(u.QuotaBytes)
+	}
+	return fmt.Sprintf("%s items | %s", items, bytes)
+}
+
+// FormatBytes возвращает размер в двоичных единицах, например 1.5 MiB.
+func FormatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,14 @@
+package client
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestUsageString(t *testing.T) {
+	usage := Usage{Bytes: 1536, Items: 3, QuotaBytes: 1 << 30, QuotaItems: 100}
+	require.Equal(t, "3/100 items | 1.5 KiB/1.0 GiB", usage.String())
+
+	usage = Usage{Bytes: 512, Items: 3}
+	require.Equal(t, "3 items | 512 B", usage.String())
+}
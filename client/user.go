@@ -1,16 +1,99 @@
 package client
 
-import "context"
+import (
+	"context"
+	"time"
+)
+
+// MFARequiredError возвращается при входе, если у пользователя включена
+// двухфакторная аутентификация. Вход завершается вызовом VerifyMFA.
+type MFARequiredError struct {
+	// Challenge - токен входа, который передается в VerifyMFA.
+	Challenge string
+}
+
+func (e *MFARequiredError) Error() string {
+	return "second factor is required"
+}
 
 type (
 	AuthorizationService interface {
-		Authorize(ctx context.Context, login string, password string) (string, error)
-		Register(ctx context.Context, login string, password string) (string, error)
+		// Authorize выполняет вход и открывает хранилище. Учетной записи,
+		// созданной до шифрования на клиенте, при первом входе создается
+		// ключ хранилища.
+		Authorize(ctx context.Context, login string, password string) (Tokens, error)
+		Register(ctx context.Context, login string, password string) (Tokens, error)
+
+		// VerifyMFA завершает вход кодом второго фактора: кодом TOTP или
+		// кодом восстановления. Логин и мастер-пароль нужны для открытия
+		// хранилища.
+		VerifyMFA(ctx context.Context, challenge string, login string, password string, code string) (Tokens, error)
+
+		// Logout завершает текущую сессию на сервере.
+		Logout(ctx context.Context) error
+
+		// ListSessions возвращает активные сессии пользователя.
+		ListSessions(ctx context.Context) ([]Session, error)
+
+		// RevokeSession завершает сессию пользователя с идентификатором id.
+		RevokeSession(ctx context.Context, id string) error
+
+		// SetupMFA создает новый секрет второго фактора.
+		SetupMFA(ctx context.Context) (MFASetup, error)
+
+		// EnableMFA включает второй фактор после проверки кода и возвращает
+		// коды восстановления.
+		EnableMFA(ctx context.Context, code string) ([]string, error)
+
+		// DisableMFA выключает второй фактор после проверки кода.
+		DisableMFA(ctx context.Context, code string) error
+
+		// ChangePassword меняет мастер-пароль. Хранилище должно быть открыто:
+		// его ключ заново шифруется новым паролем. Остальные сессии
+		// пользователя завершаются.
+		ChangePassword(ctx context.Context, login string, currentPassword string, newPassword string) error
+
+		// DeleteAccount удаляет учетную запись со всеми данными на сервере.
+		DeleteAccount(ctx context.Context, login string, password string) error
 	}
 
 	UserService interface {
-		SetInfo(login string, token string)
+		SetInfo(login string, tokens Tokens)
+		// SetTokens заменяет токены сессии после их обновления.
+		SetTokens(tokens Tokens)
 		GetUserLogin() string
 		GetBearerToken() string
+		GetRefreshToken() string
 	}
 )
+
+// Tokens - токены сессии пользователя.
+type Tokens struct {
+	// AccessToken - короткоживущий токен, передается с каждым запросом.
+	AccessToken string
+	// RefreshToken - токен для получения новой пары токенов, когда
+	// AccessToken истекает.
+	RefreshToken string
+	// VaultKey - ключ хранилища в зашифрованном виде. Заполняется только
+	// при входе и сохраняется в кэше, чтобы открывать хранилище без связи
+	// с сервером.
+	VaultKey []byte
+}
+
+// Session - сессия пользователя на сервере.
+type Session struct {
+	ID         string
+	CreatedAt  time.Time
+	LastUsedAt time.Time
+	ExpiresAt  time.Time
+	// Current - сессия текущего клиента.
+	Current bool
+}
+
+// MFASetup - секрет второго фактора для приложения-аутентификатора.
+type MFASetup struct {
+	// Secret - секрет TOTP в base32.
+	Secret string
+	// URI - URI otpauth:// с секретом.
+	URI string
+}
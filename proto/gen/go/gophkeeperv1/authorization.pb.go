@@ -7,8 +7,10 @@
 package gophkeeperv1
 
 import (
+	empty "github.com/golang/protobuf/ptypes/empty"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	unsafe "unsafe"
 )
@@ -24,6 +26,7 @@ type UserCredentials struct {
 	state                  protoimpl.MessageState `protogen:"opaque.v1"`
 	xxx_hidden_Login       *string                `protobuf:"bytes,1,opt,name=login"`
 	xxx_hidden_Password    *string                `protobuf:"bytes,2,opt,name=password"`
+	xxx_hidden_VaultKey    []byte                 `protobuf:"bytes,3,opt,name=vault_key,json=vaultKey"`
 	XXX_raceDetectHookData protoimpl.RaceDetectHookData
 	XXX_presence           [1]uint32
 	unknownFields          protoimpl.UnknownFields
@@ -75,14 +78,29 @@ func (x *UserCredentials) GetPassword() string {
 	return ""
 }
 
+func (x *UserCredentials) GetVaultKey() []byte {
+	if x != nil {
+		return x.xxx_hidden_VaultKey
+	}
+	return nil
+}
+
 func (x *UserCredentials) SetLogin(v string) {
 	x.xxx_hidden_Login = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 3)
 }
 
 func (x *UserCredentials) SetPassword(v string) {
 	x.xxx_hidden_Password = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 3)
+}
+
+func (x *UserCredentials) SetVaultKey(v []byte) {
+	if v == nil {
+		v = []byte{}
+	}
+	x.xxx_hidden_VaultKey = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 3)
 }
 
 func (x *UserCredentials) HasLogin() bool {
@@ -99,6 +117,13 @@ func (x *UserCredentials) HasPassword() bool {
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
 }
 
+func (x *UserCredentials) HasVaultKey() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
 func (x *UserCredentials) ClearLogin() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
 	x.xxx_hidden_Login = nil
@@ -109,11 +134,21 @@ func (x *UserCredentials) ClearPassword() {
 	x.xxx_hidden_Password = nil
 }
 
+func (x *UserCredentials) ClearVaultKey() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_VaultKey = nil
+}
+
 type UserCredentials_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
-	Login    *string
+	Login *string
+	// Ключ аутентификации, выведенный из мастер-пароля на клиенте.
+	// Сам мастер-пароль на сервер не передается.
 	Password *string
+	// Ключ хранилища, зашифрованный мастер-паролем на клиенте.
+	// Передается только при регистрации.
+	VaultKey []byte
 }
 
 func (b0 UserCredentials_builder) Build() *UserCredentials {
@@ -121,28 +156,217 @@ func (b0 UserCredentials_builder) Build() *UserCredentials {
 	b, x := &b0, m0
 	_, _ = b, x
 	if b.Login != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 3)
 		x.xxx_hidden_Login = b.Login
 	}
 	if b.Password != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 3)
 		x.xxx_hidden_Password = b.Password
 	}
+	if b.VaultKey != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 3)
+		x.xxx_hidden_VaultKey = b.VaultKey
+	}
 	return m0
 }
 
-type TokenResponse struct {
+type EnrollRequest struct {
 	state                  protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_Token       *string                `protobuf:"bytes,1,opt,name=token"`
+	xxx_hidden_Login       *string                `protobuf:"bytes,1,opt,name=login"`
+	xxx_hidden_Password    *string                `protobuf:"bytes,2,opt,name=password"`
+	xxx_hidden_AuthKey     *string                `protobuf:"bytes,3,opt,name=auth_key,json=authKey"`
+	xxx_hidden_VaultKey    []byte                 `protobuf:"bytes,4,opt,name=vault_key,json=vaultKey"`
 	XXX_raceDetectHookData protoimpl.RaceDetectHookData
 	XXX_presence           [1]uint32
 	unknownFields          protoimpl.UnknownFields
 	sizeCache              protoimpl.SizeCache
 }
 
+func (x *EnrollRequest) Reset() {
+	*x = EnrollRequest{}
+	mi := &file_authorization_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnrollRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnrollRequest) ProtoMessage() {}
+
+func (x *EnrollRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_authorization_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *EnrollRequest) GetLogin() string {
+	if x != nil {
+		if x.xxx_hidden_Login != nil {
+			return *x.xxx_hidden_Login
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *EnrollRequest) GetPassword() string {
+	if x != nil {
+		if x.xxx_hidden_Password != nil {
+			return *x.xxx_hidden_Password
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *EnrollRequest) GetAuthKey() string {
+	if x != nil {
+		if x.xxx_hidden_AuthKey != nil {
+			return *x.xxx_hidden_AuthKey
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *EnrollRequest) GetVaultKey() []byte {
+	if x != nil {
+		return x.xxx_hidden_VaultKey
+	}
+	return nil
+}
+
+func (x *EnrollRequest) SetLogin(v string) {
+	x.xxx_hidden_Login = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 4)
+}
+
+func (x *EnrollRequest) SetPassword(v string) {
+	x.xxx_hidden_Password = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 4)
+}
+
+func (x *EnrollRequest) SetAuthKey(v string) {
+	x.xxx_hidden_AuthKey = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 4)
+}
+
+func (x *EnrollRequest) SetVaultKey(v []byte) {
+	if v == nil {
+		v = []byte{}
+	}
+	x.xxx_hidden_VaultKey = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 4)
+}
+
+func (x *EnrollRequest) HasLogin() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *EnrollRequest) HasPassword() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *EnrollRequest) HasAuthKey() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *EnrollRequest) HasVaultKey() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *EnrollRequest) ClearLogin() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Login = nil
+}
+
+func (x *EnrollRequest) ClearPassword() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Password = nil
+}
+
+func (x *EnrollRequest) ClearAuthKey() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_AuthKey = nil
+}
+
+func (x *EnrollRequest) ClearVaultKey() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_VaultKey = nil
+}
+
+type EnrollRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Login *string
+	// Мастер-пароль, которым учетная запись защищена до перехода
+	// на шифрование на клиенте.
+	Password *string
+	// Ключ аутентификации, выведенный из мастер-пароля. Заменяет пароль
+	// при следующих входах.
+	AuthKey *string
+	// Новый ключ хранилища, зашифрованный мастер-паролем на клиенте.
+	VaultKey []byte
+}
+
+func (b0 EnrollRequest_builder) Build() *EnrollRequest {
+	m0 := &EnrollRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Login != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 4)
+		x.xxx_hidden_Login = b.Login
+	}
+	if b.Password != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 4)
+		x.xxx_hidden_Password = b.Password
+	}
+	if b.AuthKey != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 4)
+		x.xxx_hidden_AuthKey = b.AuthKey
+	}
+	if b.VaultKey != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 4)
+		x.xxx_hidden_VaultKey = b.VaultKey
+	}
+	return m0
+}
+
+type TokenResponse struct {
+	state                   protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Token        *string                `protobuf:"bytes,1,opt,name=token"`
+	xxx_hidden_VaultKey     []byte                 `protobuf:"bytes,2,opt,name=vault_key,json=vaultKey"`
+	xxx_hidden_RefreshToken *string                `protobuf:"bytes,3,opt,name=refresh_token,json=refreshToken"`
+	xxx_hidden_MfaChallenge *string                `protobuf:"bytes,4,opt,name=mfa_challenge,json=mfaChallenge"`
+	XXX_raceDetectHookData  protoimpl.RaceDetectHookData
+	XXX_presence            [1]uint32
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
 func (x *TokenResponse) Reset() {
 	*x = TokenResponse{}
-	mi := &file_authorization_proto_msgTypes[1]
+	mi := &file_authorization_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -154,7 +378,7 @@ func (x *TokenResponse) String() string {
 func (*TokenResponse) ProtoMessage() {}
 
 func (x *TokenResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_authorization_proto_msgTypes[1]
+	mi := &file_authorization_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -175,9 +399,54 @@ func (x *TokenResponse) GetToken() string {
 	return ""
 }
 
+func (x *TokenResponse) GetVaultKey() []byte {
+	if x != nil {
+		return x.xxx_hidden_VaultKey
+	}
+	return nil
+}
+
+func (x *TokenResponse) GetRefreshToken() string {
+	if x != nil {
+		if x.xxx_hidden_RefreshToken != nil {
+			return *x.xxx_hidden_RefreshToken
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *TokenResponse) GetMfaChallenge() string {
+	if x != nil {
+		if x.xxx_hidden_MfaChallenge != nil {
+			return *x.xxx_hidden_MfaChallenge
+		}
+		return ""
+	}
+	return ""
+}
+
 func (x *TokenResponse) SetToken(v string) {
 	x.xxx_hidden_Token = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 1)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 4)
+}
+
+func (x *TokenResponse) SetVaultKey(v []byte) {
+	if v == nil {
+		v = []byte{}
+	}
+	x.xxx_hidden_VaultKey = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 4)
+}
+
+func (x *TokenResponse) SetRefreshToken(v string) {
+	x.xxx_hidden_RefreshToken = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 4)
+}
+
+func (x *TokenResponse) SetMfaChallenge(v string) {
+	x.xxx_hidden_MfaChallenge = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 4)
 }
 
 func (x *TokenResponse) HasToken() bool {
@@ -187,15 +456,61 @@ func (x *TokenResponse) HasToken() bool {
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
 }
 
+func (x *TokenResponse) HasVaultKey() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *TokenResponse) HasRefreshToken() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *TokenResponse) HasMfaChallenge() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
 func (x *TokenResponse) ClearToken() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
 	x.xxx_hidden_Token = nil
 }
 
+func (x *TokenResponse) ClearVaultKey() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_VaultKey = nil
+}
+
+func (x *TokenResponse) ClearRefreshToken() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_RefreshToken = nil
+}
+
+func (x *TokenResponse) ClearMfaChallenge() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_MfaChallenge = nil
+}
+
 type TokenResponse_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
+	// Короткоживущий access-токен для запросов к сервису.
 	Token *string
+	// Ключ хранилища, зашифрованный мастер-паролем на клиенте.
+	VaultKey []byte
+	// Долгоживущий refresh-токен для получения нового access-токена.
+	// Каждый refresh-токен можно использовать только один раз.
+	RefreshToken *string
+	// Если у пользователя включена двухфакторная аутентификация, Authorize
+	// возвращает только этот токен. Токены сессии и ключ хранилища выдает
+	// VerifyMFA после проверки одноразового кода.
+	MfaChallenge *string
 }
 
 func (b0 TokenResponse_builder) Build() *TokenResponse {
@@ -203,42 +518,1130 @@ func (b0 TokenResponse_builder) Build() *TokenResponse {
 	b, x := &b0, m0
 	_, _ = b, x
 	if b.Token != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 1)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 4)
 		x.xxx_hidden_Token = b.Token
 	}
+	if b.VaultKey != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 4)
+		x.xxx_hidden_VaultKey = b.VaultKey
+	}
+	if b.RefreshToken != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 4)
+		x.xxx_hidden_RefreshToken = b.RefreshToken
+	}
+	if b.MfaChallenge != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 4)
+		x.xxx_hidden_MfaChallenge = b.MfaChallenge
+	}
 	return m0
 }
 
-var File_authorization_proto protoreflect.FileDescriptor
+type VerifyMFARequest struct {
+	state                   protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_MfaChallenge *string                `protobuf:"bytes,1,opt,name=mfa_challenge,json=mfaChallenge"`
+	xxx_hidden_Code         *string                `protobuf:"bytes,2,opt,name=code"`
+	XXX_raceDetectHookData  protoimpl.RaceDetectHookData
+	XXX_presence            [1]uint32
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
 
-const file_authorization_proto_rawDesc = "" +
-	"\n" +
-	"\x13authorization.proto\x12\n" +
-	"gophkeeper\"C\n" +
-	"\x0fUserCredentials\x12\x14\n" +
-	"\x05login\x18\x01 \x01(\tR\x05login\x12\x1a\n" +
-	"\bpassword\x18\x02 \x01(\tR\bpassword\"%\n" +
-	"\rTokenResponse\x12\x14\n" +
-	"\x05token\x18\x01 \x01(\tR\x05token2\x9f\x01\n" +
-	"\x14AuthorizationService\x12C\n" +
-	"\tAuthorize\x12\x1b.gophkeeper.UserCredentials\x1a\x19.gophkeeper.TokenResponse\x12B\n" +
-	"\bRegister\x12\x1b.gophkeeper.UserCredentials\x1a\x19.gophkeeper.TokenResponseB\x1cZ\x1agophkeeper.v1;gophkeeperv1b\beditionsp\xe8\a"
+func (x *VerifyMFARequest) Reset() {
+	*x = VerifyMFARequest{}
+	mi := &file_authorization_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyMFARequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyMFARequest) ProtoMessage() {}
+
+func (x *VerifyMFARequest) ProtoReflect() protoreflect.Message {
+	mi := &file_authorization_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *VerifyMFARequest) GetMfaChallenge() string {
+	if x != nil {
+		if x.xxx_hidden_MfaChallenge != nil {
+			return *x.xxx_hidden_MfaChallenge
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *VerifyMFARequest) GetCode() string {
+	if x != nil {
+		if x.xxx_hidden_Code != nil {
+			return *x.xxx_hidden_Code
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *VerifyMFARequest) SetMfaChallenge(v string) {
+	x.xxx_hidden_MfaChallenge = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+}
+
+func (x *VerifyMFARequest) SetCode(v string) {
+	x.xxx_hidden_Code = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
+}
+
+func (x *VerifyMFARequest) HasMfaChallenge() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *VerifyMFARequest) HasCode() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *VerifyMFARequest) ClearMfaChallenge() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_MfaChallenge = nil
+}
+
+func (x *VerifyMFARequest) ClearCode() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Code = nil
+}
+
+type VerifyMFARequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	MfaChallenge *string
+	// Код TOTP или один из кодов восстановления.
+	Code *string
+}
+
+func (b0 VerifyMFARequest_builder) Build() *VerifyMFARequest {
+	m0 := &VerifyMFARequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.MfaChallenge != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		x.xxx_hidden_MfaChallenge = b.MfaChallenge
+	}
+	if b.Code != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		x.xxx_hidden_Code = b.Code
+	}
+	return m0
+}
+
+type MFACode struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Code        *string                `protobuf:"bytes,1,opt,name=code"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *MFACode) Reset() {
+	*x = MFACode{}
+	mi := &file_authorization_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MFACode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MFACode) ProtoMessage() {}
+
+func (x *MFACode) ProtoReflect() protoreflect.Message {
+	mi := &file_authorization_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *MFACode) GetCode() string {
+	if x != nil {
+		if x.xxx_hidden_Code != nil {
+			return *x.xxx_hidden_Code
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *MFACode) SetCode(v string) {
+	x.xxx_hidden_Code = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 1)
+}
+
+func (x *MFACode) HasCode() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *MFACode) ClearCode() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Code = nil
+}
+
+type MFACode_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Code *string
+}
+
+func (b0 MFACode_builder) Build() *MFACode {
+	m0 := &MFACode{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Code != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 1)
+		x.xxx_hidden_Code = b.Code
+	}
+	return m0
+}
+
+type SetupMFAResponse struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Secret      *string                `protobuf:"bytes,1,opt,name=secret"`
+	xxx_hidden_Uri         *string                `protobuf:"bytes,2,opt,name=uri"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *SetupMFAResponse) Reset() {
+	*x = SetupMFAResponse{}
+	mi := &file_authorization_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetupMFAResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetupMFAResponse) ProtoMessage() {}
+
+func (x *SetupMFAResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_authorization_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *SetupMFAResponse) GetSecret() string {
+	if x != nil {
+		if x.xxx_hidden_Secret != nil {
+			return *x.xxx_hidden_Secret
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *SetupMFAResponse) GetUri() string {
+	if x != nil {
+		if x.xxx_hidden_Uri != nil {
+			return *x.xxx_hidden_Uri
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *SetupMFAResponse) SetSecret(v string) {
+	x.xxx_hidden_Secret = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+}
+
+func (x *SetupMFAResponse) SetUri(v string) {
+	x.xxx_hidden_Uri = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
+}
+
+func (x *SetupMFAResponse) HasSecret() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *SetupMFAResponse) HasUri() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *SetupMFAResponse) ClearSecret() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Secret = nil
+}
+
+func (x *SetupMFAResponse) ClearUri() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Uri = nil
+}
+
+type SetupMFAResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Секрет TOTP в base32.
+	Secret *string
+	// URI otpauth:// для приложений-аутентификаторов.
+	Uri *string
+}
+
+func (b0 SetupMFAResponse_builder) Build() *SetupMFAResponse {
+	m0 := &SetupMFAResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Secret != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		x.xxx_hidden_Secret = b.Secret
+	}
+	if b.Uri != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		x.xxx_hidden_Uri = b.Uri
+	}
+	return m0
+}
+
+type RecoveryCodesResponse struct {
+	state            protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Codes []string               `protobuf:"bytes,1,rep,name=codes"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *RecoveryCodesResponse) Reset() {
+	*x = RecoveryCodesResponse{}
+	mi := &file_authorization_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecoveryCodesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecoveryCodesResponse) ProtoMessage() {}
+
+func (x *RecoveryCodesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_authorization_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *RecoveryCodesResponse) GetCodes() []string {
+	if x != nil {
+		return x.xxx_hidden_Codes
+	}
+	return nil
+}
+
+func (x *RecoveryCodesResponse) SetCodes(v []string) {
+	x.xxx_hidden_Codes = v
+}
+
+type RecoveryCodesResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Codes []string
+}
+
+func (b0 RecoveryCodesResponse_builder) Build() *RecoveryCodesResponse {
+	m0 := &RecoveryCodesResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Codes = b.Codes
+	return m0
+}
+
+type RefreshRequest struct {
+	state                   protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_RefreshToken *string                `protobuf:"bytes,1,opt,name=refresh_token,json=refreshToken"`
+	XXX_raceDetectHookData  protoimpl.RaceDetectHookData
+	XXX_presence            [1]uint32
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *RefreshRequest) Reset() {
+	*x = RefreshRequest{}
+	mi := &file_authorization_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefreshRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefreshRequest) ProtoMessage() {}
+
+func (x *RefreshRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_authorization_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *RefreshRequest) GetRefreshToken() string {
+	if x != nil {
+		if x.xxx_hidden_RefreshToken != nil {
+			return *x.xxx_hidden_RefreshToken
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *RefreshRequest) SetRefreshToken(v string) {
+	x.xxx_hidden_RefreshToken = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 1)
+}
+
+func (x *RefreshRequest) HasRefreshToken() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *RefreshRequest) ClearRefreshToken() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_RefreshToken = nil
+}
+
+type RefreshRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	RefreshToken *string
+}
+
+func (b0 RefreshRequest_builder) Build() *RefreshRequest {
+	m0 := &RefreshRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.RefreshToken != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 1)
+		x.xxx_hidden_RefreshToken = b.RefreshToken
+	}
+	return m0
+}
+
+type Session struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Id          *string                `protobuf:"bytes,1,opt,name=id"`
+	xxx_hidden_CreatedAt   *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=created_at,json=createdAt"`
+	xxx_hidden_LastUsedAt  *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=last_used_at,json=lastUsedAt"`
+	xxx_hidden_ExpiresAt   *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt"`
+	xxx_hidden_Current     bool                   `protobuf:"varint,5,opt,name=current"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *Session) Reset() {
+	*x = Session{}
+	mi := &file_authorization_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Session) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Session) ProtoMessage() {}
+
+func (x *Session) ProtoReflect() protoreflect.Message {
+	mi := &file_authorization_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *Session) GetId() string {
+	if x != nil {
+		if x.xxx_hidden_Id != nil {
+			return *x.xxx_hidden_Id
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *Session) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_CreatedAt
+	}
+	return nil
+}
+
+func (x *Session) GetLastUsedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_LastUsedAt
+	}
+	return nil
+}
+
+func (x *Session) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_ExpiresAt
+	}
+	return nil
+}
+
+func (x *Session) GetCurrent() bool {
+	if x != nil {
+		return x.xxx_hidden_Current
+	}
+	return false
+}
+
+func (x *Session) SetId(v string) {
+	x.xxx_hidden_Id = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 5)
+}
+
+func (x *Session) SetCreatedAt(v *timestamppb.Timestamp) {
+	x.xxx_hidden_CreatedAt = v
+}
+
+func (x *Session) SetLastUsedAt(v *timestamppb.Timestamp) {
+	x.xxx_hidden_LastUsedAt = v
+}
+
+func (x *Session) SetExpiresAt(v *timestamppb.Timestamp) {
+	x.xxx_hidden_ExpiresAt = v
+}
+
+func (x *Session) SetCurrent(v bool) {
+	x.xxx_hidden_Current = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 5)
+}
+
+func (x *Session) HasId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *Session) HasCreatedAt() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_CreatedAt != nil
+}
+
+func (x *Session) HasLastUsedAt() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_LastUsedAt != nil
+}
+
+func (x *Session) HasExpiresAt() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_ExpiresAt != nil
+}
+
+func (x *Session) HasCurrent() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *Session) ClearId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Id = nil
+}
+
+func (x *Session) ClearCreatedAt() {
+	x.xxx_hidden_CreatedAt = nil
+}
+
+func (x *Session) ClearLastUsedAt() {
+	x.xxx_hidden_LastUsedAt = nil
+}
+
+func (x *Session) ClearExpiresAt() {
+	x.xxx_hidden_ExpiresAt = nil
+}
+
+func (x *Session) ClearCurrent() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_Current = false
+}
+
+type Session_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Id         *string
+	CreatedAt  *timestamppb.Timestamp
+	LastUsedAt *timestamppb.Timestamp
+	ExpiresAt  *timestamppb.Timestamp
+	// Признак сессии, от имени которой выполнен запрос.
+	Current *bool
+}
+
+func (b0 Session_builder) Build() *Session {
+	m0 := &Session{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Id != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 5)
+		x.xxx_hidden_Id = b.Id
+	}
+	x.xxx_hidden_CreatedAt = b.CreatedAt
+	x.xxx_hidden_LastUsedAt = b.LastUsedAt
+	x.xxx_hidden_ExpiresAt = b.ExpiresAt
+	if b.Current != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 5)
+		x.xxx_hidden_Current = *b.Current
+	}
+	return m0
+}
+
+type ListSessionsResponse struct {
+	state               protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Sessions *[]*Session            `protobuf:"bytes,1,rep,name=sessions"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *ListSessionsResponse) Reset() {
+	*x = ListSessionsResponse{}
+	mi := &file_authorization_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSessionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSessionsResponse) ProtoMessage() {}
+
+func (x *ListSessionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_authorization_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ListSessionsResponse) GetSessions() []*Session {
+	if x != nil {
+		if x.xxx_hidden_Sessions != nil {
+			return *x.xxx_hidden_Sessions
+		}
+	}
+	return nil
+}
+
+func (x *ListSessionsResponse) SetSessions(v []*Session) {
+	x.xxx_hidden_Sessions = &v
+}
+
+type ListSessionsResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Sessions []*Session
+}
+
+func (b0 ListSessionsResponse_builder) Build() *ListSessionsResponse {
+	m0 := &ListSessionsResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Sessions = &b.Sessions
+	return m0
+}
+
+type RevokeSessionRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Id          *string                `protobuf:"bytes,1,opt,name=id"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *RevokeSessionRequest) Reset() {
+	*x = RevokeSessionRequest{}
+	mi := &file_authorization_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeSessionRequest) ProtoMessage() {}
+
+func (x *RevokeSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_authorization_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *RevokeSessionRequest) GetId() string {
+	if x != nil {
+		if x.xxx_hidden_Id != nil {
+			return *x.xxx_hidden_Id
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *RevokeSessionRequest) SetId(v string) {
+	x.xxx_hidden_Id = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 1)
+}
+
+func (x *RevokeSessionRequest) HasId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *RevokeSessionRequest) ClearId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Id = nil
+}
+
+type RevokeSessionRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Id *string
+}
+
+func (b0 RevokeSessionRequest_builder) Build() *RevokeSessionRequest {
+	m0 := &RevokeSessionRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Id != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 1)
+		x.xxx_hidden_Id = b.Id
+	}
+	return m0
+}
+
+type ChangePasswordRequest struct {
+	state                      protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_CurrentPassword *string                `protobuf:"bytes,1,opt,name=current_password,json=currentPassword"`
+	xxx_hidden_NewPassword     *string                `protobuf:"bytes,2,opt,name=new_password,json=newPassword"`
+	xxx_hidden_VaultKey        []byte                 `protobuf:"bytes,3,opt,name=vault_key,json=vaultKey"`
+	XXX_raceDetectHookData     protoimpl.RaceDetectHookData
+	XXX_presence               [1]uint32
+	unknownFields              protoimpl.UnknownFields
+	sizeCache                  protoimpl.SizeCache
+}
+
+func (x *ChangePasswordRequest) Reset() {
+	*x = ChangePasswordRequest{}
+	mi := &file_authorization_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangePasswordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangePasswordRequest) ProtoMessage() {}
+
+func (x *ChangePasswordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_authorization_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ChangePasswordRequest) GetCurrentPassword() string {
+	if x != nil {
+		if x.xxx_hidden_CurrentPassword != nil {
+			return *x.xxx_hidden_CurrentPassword
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ChangePasswordRequest) GetNewPassword() string {
+	if x != nil {
+		if x.xxx_hidden_NewPassword != nil {
+			return *x.xxx_hidden_NewPassword
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ChangePasswordRequest) GetVaultKey() []byte {
+	if x != nil {
+		return x.xxx_hidden_VaultKey
+	}
+	return nil
+}
+
+func (x *ChangePasswordRequest) SetCurrentPassword(v string) {
+	x.xxx_hidden_CurrentPassword = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 3)
+}
+
+func (x *ChangePasswordRequest) SetNewPassword(v string) {
+	x.xxx_hidden_NewPassword = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 3)
+}
+
+func (x *ChangePasswordRequest) SetVaultKey(v []byte) {
+	if v == nil {
+		v = []byte{}
+	}
+	x.xxx_hidden_VaultKey = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 3)
+}
+
+func (x *ChangePasswordRequest) HasCurrentPassword() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *ChangePasswordRequest) HasNewPassword() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *ChangePasswordRequest) HasVaultKey() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *ChangePasswordRequest) ClearCurrentPassword() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_CurrentPassword = nil
+}
+
+func (x *ChangePasswordRequest) ClearNewPassword() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_NewPassword = nil
+}
+
+func (x *ChangePasswordRequest) ClearVaultKey() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_VaultKey = nil
+}
+
+type ChangePasswordRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Пароли передаются ключами аутентификации, выведенными
+	// из мастер-паролей на клиенте.
+	CurrentPassword *string
+	NewPassword     *string
+	// Ключ хранилища, заново зашифрованный новым мастер-паролем на клиенте.
+	VaultKey []byte
+}
+
+func (b0 ChangePasswordRequest_builder) Build() *ChangePasswordRequest {
+	m0 := &ChangePasswordRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.CurrentPassword != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 3)
+		x.xxx_hidden_CurrentPassword = b.CurrentPassword
+	}
+	if b.NewPassword != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 3)
+		x.xxx_hidden_NewPassword = b.NewPassword
+	}
+	if b.VaultKey != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 3)
+		x.xxx_hidden_VaultKey = b.VaultKey
+	}
+	return m0
+}
+
+type DeleteAccountRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Password    *string                `protobuf:"bytes,1,opt,name=password"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *DeleteAccountRequest) Reset() {
+	*x = DeleteAccountRequest{}
+	mi := &file_authorization_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAccountRequest) ProtoMessage() {}
+
+func (x *DeleteAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_authorization_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *DeleteAccountRequest) GetPassword() string {
+	if x != nil {
+		if x.xxx_hidden_Password != nil {
+			return *x.xxx_hidden_Password
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *DeleteAccountRequest) SetPassword(v string) {
+	x.xxx_hidden_Password = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 1)
+}
+
+func (x *DeleteAccountRequest) HasPassword() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *DeleteAccountRequest) ClearPassword() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Password = nil
+}
+
+type DeleteAccountRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Ключ аутентификации, выведенный из мастер-пароля.
+	Password *string
+}
+
+func (b0 DeleteAccountRequest_builder) Build() *DeleteAccountRequest {
+	m0 := &DeleteAccountRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Password != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 1)
+		x.xxx_hidden_Password = b.Password
+	}
+	return m0
+}
+
+var File_authorization_proto protoreflect.FileDescriptor
+
+const file_authorization_proto_rawDesc = "" +
+	"\n" +
+	"\x13authorization.proto\x12\n" +
+	"gophkeeper\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"`\n" +
+	"\x0fUserCredentials\x12\x14\n" +
+	"\x05login\x18\x01 \x01(\tR\x05login\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\x12\x1b\n" +
+	"\tvault_key\x18\x03 \x01(\fR\bvaultKey\"y\n" +
+	"\rEnrollRequest\x12\x14\n" +
+	"\x05login\x18\x01 \x01(\tR\x05login\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\x12\x19\n" +
+	"\bauth_key\x18\x03 \x01(\tR\aauthKey\x12\x1b\n" +
+	"\tvault_key\x18\x04 \x01(\fR\bvaultKey\"\x8c\x01\n" +
+	"\rTokenResponse\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x1b\n" +
+	"\tvault_key\x18\x02 \x01(\fR\bvaultKey\x12#\n" +
+	"\rrefresh_token\x18\x03 \x01(\tR\frefreshToken\x12#\n" +
+	"\rmfa_challenge\x18\x04 \x01(\tR\fmfaChallenge\"K\n" +
+	"\x10VerifyMFARequest\x12#\n" +
+	"\rmfa_challenge\x18\x01 \x01(\tR\fmfaChallenge\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\"\x1d\n" +
+	"\aMFACode\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\"<\n" +
+	"\x10SetupMFAResponse\x12\x16\n" +
+	"\x06secret\x18\x01 \x01(\tR\x06secret\x12\x10\n" +
+	"\x03uri\x18\x02 \x01(\tR\x03uri\"-\n" +
+	"\x15RecoveryCodesResponse\x12\x14\n" +
+	"\x05codes\x18\x01 \x03(\tR\x05codes\"5\n" +
+	"\x0eRefreshRequest\x12#\n" +
+	"\rrefresh_token\x18\x01 \x01(\tR\frefreshToken\"\xe7\x01\n" +
+	"\aSession\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x129\n" +
+	"\n" +
+	"created_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12<\n" +
+	"\flast_used_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"lastUsedAt\x129\n" +
+	"\n" +
+	"expires_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x18\n" +
+	"\acurrent\x18\x05 \x01(\bR\acurrent\"G\n" +
+	"\x14ListSessionsResponse\x12/\n" +
+	"\bsessions\x18\x01 \x03(\v2\x13.gophkeeper.SessionR\bsessions\"&\n" +
+	"\x14RevokeSessionRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x82\x01\n" +
+	"\x15ChangePasswordRequest\x12)\n" +
+	"\x10current_password\x18\x01 \x01(\tR\x0fcurrentPassword\x12!\n" +
+	"\fnew_password\x18\x02 \x01(\tR\vnewPassword\x12\x1b\n" +
+	"\tvault_key\x18\x03 \x01(\fR\bvaultKey\"2\n" +
+	"\x14DeleteAccountRequest\x12\x1a\n" +
+	"\bpassword\x18\x01 \x01(\tR\bpassword2\x90\a\n" +
+	"\x14AuthorizationService\x12C\n" +
+	"\tAuthorize\x12\x1b.gophkeeper.UserCredentials\x1a\x19.gophkeeper.TokenResponse\x12B\n" +
+	"\bRegister\x12\x1b.gophkeeper.UserCredentials\x1a\x19.gophkeeper.TokenResponse\x12>\n" +
+	"\x06Enroll\x12\x19.gophkeeper.EnrollRequest\x1a\x19.gophkeeper.TokenResponse\x12@\n" +
+	"\aRefresh\x12\x1a.gophkeeper.RefreshRequest\x1a\x19.gophkeeper.TokenResponse\x128\n" +
+	"\x06Logout\x12\x16.google.protobuf.Empty\x1a\x16.google.protobuf.Empty\x12H\n" +
+	"\fListSessions\x12\x16.google.protobuf.Empty\x1a .gophkeeper.ListSessionsResponse\x12I\n" +
+	"\rRevokeSession\x12 .gophkeeper.RevokeSessionRequest\x1a\x16.google.protobuf.Empty\x12D\n" +
+	"\tVerifyMFA\x12\x1c.gophkeeper.VerifyMFARequest\x1a\x19.gophkeeper.TokenResponse\x12@\n" +
+	"\bSetupMFA\x12\x16.google.protobuf.Empty\x1a\x1c.gophkeeper.SetupMFAResponse\x12C\n" +
+	"\tEnableMFA\x12\x13.gophkeeper.MFACode\x1a!.gophkeeper.RecoveryCodesResponse\x129\n" +
+	"\n" +
+	"DisableMFA\x12\x13.gophkeeper.MFACode\x1a\x16.google.protobuf.Empty\x12K\n" +
+	"\x0eChangePassword\x12!.gophkeeper.ChangePasswordRequest\x1a\x16.google.protobuf.Empty\x12I\n" +
+	"\rDeleteAccount\x12 .gophkeeper.DeleteAccountRequest\x1a\x16.google.protobuf.EmptyB\x1cZ\x1agophkeeper.v1;gophkeeperv1b\beditionsp\xe8\a"
 
-var file_authorization_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_authorization_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
 var file_authorization_proto_goTypes = []any{
-	(*UserCredentials)(nil), // 0: gophkeeper.UserCredentials
-	(*TokenResponse)(nil),   // 1: gophkeeper.TokenResponse
+	(*UserCredentials)(nil),       // 0: gophkeeper.UserCredentials
+	(*EnrollRequest)(nil),         // 1: gophkeeper.EnrollRequest
+	(*TokenResponse)(nil),         // 2: gophkeeper.TokenResponse
+	(*VerifyMFARequest)(nil),      // 3: gophkeeper.VerifyMFARequest
+	(*MFACode)(nil),               // 4: gophkeeper.MFACode
+	(*SetupMFAResponse)(nil),      // 5: gophkeeper.SetupMFAResponse
+	(*RecoveryCodesResponse)(nil), // 6: gophkeeper.RecoveryCodesResponse
+	(*RefreshRequest)(nil),        // 7: gophkeeper.RefreshRequest
+	(*Session)(nil),               // 8: gophkeeper.Session
+	(*ListSessionsResponse)(nil),  // 9: gophkeeper.ListSessionsResponse
+	(*RevokeSessionRequest)(nil),  // 10: gophkeeper.RevokeSessionRequest
+	(*ChangePasswordRequest)(nil), // 11: gophkeeper.ChangePasswordRequest
+	(*DeleteAccountRequest)(nil),  // 12: gophkeeper.DeleteAccountRequest
+	(*timestamppb.Timestamp)(nil), // 13: google.protobuf.Timestamp
+	(*empty.Empty)(nil),           // 14: google.protobuf.Empty
 }
 var file_authorization_proto_depIdxs = []int32{
-	0, // 0: gophkeeper.AuthorizationService.Authorize:input_type -> gophkeeper.UserCredentials
-	0, // 1: gophkeeper.AuthorizationService.Register:input_type -> gophkeeper.UserCredentials
-	1, // 2: gophkeeper.AuthorizationService.Authorize:output_type -> gophkeeper.TokenResponse
-	1, // 3: gophkeeper.AuthorizationService.Register:output_type -> gophkeeper.TokenResponse
-	2, // [2:4] is the sub-list for method output_type
-	0, // [0:2] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	13, // 0: gophkeeper.Session.created_at:type_name -> google.protobuf.Timestamp
+	13, // 1: gophkeeper.Session.last_used_at:type_name -> google.protobuf.Timestamp
+	13, // 2: gophkeeper.Session.expires_at:type_name -> google.protobuf.Timestamp
+	8,  // 3: gophkeeper.ListSessionsResponse.sessions:type_name -> gophkeeper.Session
+	0,  // 4: gophkeeper.AuthorizationService.Authorize:input_type -> gophkeeper.UserCredentials
+	0,  // 5: gophkeeper.AuthorizationService.Register:input_type -> gophkeeper.UserCredentials
+	1,  // 6: gophkeeper.AuthorizationService.Enroll:input_type -> gophkeeper.EnrollRequest
+	7,  // 7: gophkeeper.AuthorizationService.Refresh:input_type -> gophkeeper.RefreshRequest
+	14, // 8: gophkeeper.AuthorizationService.Logout:input_type -> google.protobuf.Empty
+	14, // 9: gophkeeper.AuthorizationService.ListSessions:input_type -> google.protobuf.Empty
+	10, // 10: gophkeeper.AuthorizationService.RevokeSession:input_type -> gophkeeper.RevokeSessionRequest
+	3,  // 11: gophkeeper.AuthorizationService.VerifyMFA:input_type -> gophkeeper.VerifyMFARequest
+	14, // 12: gophkeeper.AuthorizationService.SetupMFA:input_type -> google.protobuf.Empty
+	4,  // 13: gophkeeper.AuthorizationService.EnableMFA:input_type -> gophkeeper.MFACode
+	4,  // 14: gophkeeper.AuthorizationService.DisableMFA:input_type -> gophkeeper.MFACode
+	11, // 15: gophkeeper.AuthorizationService.ChangePassword:input_type -> gophkeeper.ChangePasswordRequest
+	12, // 16: gophkeeper.AuthorizationService.DeleteAccount:input_type -> gophkeeper.DeleteAccountRequest
+	2,  // 17: gophkeeper.AuthorizationService.Authorize:output_type -> gophkeeper.TokenResponse
+	2,  // 18: gophkeeper.AuthorizationService.Register:output_type -> gophkeeper.TokenResponse
+	2,  // 19: gophkeeper.AuthorizationService.Enroll:output_type -> gophkeeper.TokenResponse
+	2,  // 20: gophkeeper.AuthorizationService.Refresh:output_type -> gophkeeper.TokenResponse
+	14, // 21: gophkeeper.AuthorizationService.Logout:output_type -> google.protobuf.Empty
+	9,  // 22: gophkeeper.AuthorizationService.ListSessions:output_type -> gophkeeper.ListSessionsResponse
+	14, // 23: gophkeeper.AuthorizationService.RevokeSession:output_type -> google.protobuf.Empty
+	2,  // 24: gophkeeper.AuthorizationService.VerifyMFA:output_type -> gophkeeper.TokenResponse
+	5,  // 25: gophkeeper.AuthorizationService.SetupMFA:output_type -> gophkeeper.SetupMFAResponse
+	6,  // 26: gophkeeper.AuthorizationService.EnableMFA:output_type -> gophkeeper.RecoveryCodesResponse
+	14, // 27: gophkeeper.AuthorizationService.DisableMFA:output_type -> google.protobuf.Empty
+	14, // 28: gophkeeper.AuthorizationService.ChangePassword:output_type -> google.protobuf.Empty
+	14, // 29: gophkeeper.AuthorizationService.DeleteAccount:output_type -> google.protobuf.Empty
+	17, // [17:30] is the sub-list for method output_type
+	4,  // [4:17] is the sub-list for method input_type
+	4,  // [4:4] is the sub-list for extension type_name
+	4,  // [4:4] is the sub-list for extension extendee
+	0,  // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_authorization_proto_init() }
@@ -252,7 +1655,7 @@ func file_authorization_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_authorization_proto_rawDesc), len(file_authorization_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   13,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -8,6 +8,7 @@ package gophkeeperv1
 
 import (
 	context "context"
+	empty "github.com/golang/protobuf/ptypes/empty"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
@@ -19,8 +20,19 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	AuthorizationService_Authorize_FullMethodName = "/gophkeeper.AuthorizationService/Authorize"
-	AuthorizationService_Register_FullMethodName  = "/gophkeeper.AuthorizationService/Register"
+	AuthorizationService_Authorize_FullMethodName      = "/gophkeeper.AuthorizationService/Authorize"
+	AuthorizationService_Register_FullMethodName       = "/gophkeeper.AuthorizationService/Register"
+	AuthorizationService_Enroll_FullMethodName         = "/gophkeeper.AuthorizationService/Enroll"
+	AuthorizationService_Refresh_FullMethodName        = "/gophkeeper.AuthorizationService/Refresh"
+	AuthorizationService_Logout_FullMethodName         = "/gophkeeper.AuthorizationService/Logout"
+	AuthorizationService_ListSessions_FullMethodName   = "/gophkeeper.AuthorizationService/ListSessions"
+	AuthorizationService_RevokeSession_FullMethodName  = "/gophkeeper.AuthorizationService/RevokeSession"
+	AuthorizationService_VerifyMFA_FullMethodName      = "/gophkeeper.AuthorizationService/VerifyMFA"
+	AuthorizationService_SetupMFA_FullMethodName       = "/gophkeeper.AuthorizationService/SetupMFA"
+	AuthorizationService_EnableMFA_FullMethodName      = "/gophkeeper.AuthorizationService/EnableMFA"
+	AuthorizationService_DisableMFA_FullMethodName     = "/gophkeeper.AuthorizationService/DisableMFA"
+	AuthorizationService_ChangePassword_FullMethodName = "/gophkeeper.AuthorizationService/ChangePassword"
+	AuthorizationService_DeleteAccount_FullMethodName  = "/gophkeeper.AuthorizationService/DeleteAccount"
 )
 
 // AuthorizationServiceClient is the client API for AuthorizationService service.
@@ -29,6 +41,33 @@ const (
 type AuthorizationServiceClient interface {
 	Authorize(ctx context.Context, in *UserCredentials, opts ...grpc.CallOption) (*TokenResponse, error)
 	Register(ctx context.Context, in *UserCredentials, opts ...grpc.CallOption) (*TokenResponse, error)
+	// Enroll переводит учетную запись, созданную до шифрования на клиенте,
+	// на ключ аутентификации и ключ хранилища и выполняет вход. Authorize
+	// с ключом аутентификации для такой учетной записи возвращает ту же
+	// ошибку, что и при неверном пароле, а с принятым мастер-паролем -
+	// FAILED_PRECONDITION.
+	Enroll(ctx context.Context, in *EnrollRequest, opts ...grpc.CallOption) (*TokenResponse, error)
+	// Refresh обменивает refresh-токен на новую пару токенов.
+	Refresh(ctx context.Context, in *RefreshRequest, opts ...grpc.CallOption) (*TokenResponse, error)
+	// Logout завершает текущую сессию.
+	Logout(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error)
+	ListSessions(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*ListSessionsResponse, error)
+	RevokeSession(ctx context.Context, in *RevokeSessionRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// VerifyMFA завершает вход пользователя с двухфакторной аутентификацией.
+	VerifyMFA(ctx context.Context, in *VerifyMFARequest, opts ...grpc.CallOption) (*TokenResponse, error)
+	// SetupMFA создает новый секрет TOTP. Двухфакторная аутентификация
+	// включается только после подтверждения кодом в EnableMFA.
+	SetupMFA(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*SetupMFAResponse, error)
+	// EnableMFA включает двухфакторную аутентификацию и возвращает
+	// коды восстановления.
+	EnableMFA(ctx context.Context, in *MFACode, opts ...grpc.CallOption) (*RecoveryCodesResponse, error)
+	DisableMFA(ctx context.Context, in *MFACode, opts ...grpc.CallOption) (*empty.Empty, error)
+	// ChangePassword меняет мастер-пароль и завершает остальные сессии
+	// пользователя. Данные не перешифровываются: меняется только
+	// зашифрованный ключ хранилища.
+	ChangePassword(ctx context.Context, in *ChangePasswordRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// DeleteAccount удаляет пользователя со всеми его данными.
+	DeleteAccount(ctx context.Context, in *DeleteAccountRequest, opts ...grpc.CallOption) (*empty.Empty, error)
 }
 
 type authorizationServiceClient struct {
@@ -59,12 +98,149 @@ func (c *authorizationServiceClient) Register(ctx context.Context, in *UserCrede
 	return out, nil
 }
 
+func (c *authorizationServiceClient) Enroll(ctx context.Context, in *EnrollRequest, opts ...grpc.CallOption) (*TokenResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TokenResponse)
+	err := c.cc.Invoke(ctx, AuthorizationService_Enroll_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authorizationServiceClient) Refresh(ctx context.Context, in *RefreshRequest, opts ...grpc.CallOption) (*TokenResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TokenResponse)
+	err := c.cc.Invoke(ctx, AuthorizationService_Refresh_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authorizationServiceClient) Logout(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, AuthorizationService_Logout_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authorizationServiceClient) ListSessions(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*ListSessionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSessionsResponse)
+	err := c.cc.Invoke(ctx, AuthorizationService_ListSessions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authorizationServiceClient) RevokeSession(ctx context.Context, in *RevokeSessionRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, AuthorizationService_RevokeSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authorizationServiceClient) VerifyMFA(ctx context.Context, in *VerifyMFARequest, opts ...grpc.CallOption) (*TokenResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TokenResponse)
+	err := c.cc.Invoke(ctx, AuthorizationService_VerifyMFA_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authorizationServiceClient) SetupMFA(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*SetupMFAResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetupMFAResponse)
+	err := c.cc.Invoke(ctx, AuthorizationService_SetupMFA_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authorizationServiceClient) EnableMFA(ctx context.Context, in *MFACode, opts ...grpc.CallOption) (*RecoveryCodesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecoveryCodesResponse)
+	err := c.cc.Invoke(ctx, AuthorizationService_EnableMFA_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authorizationServiceClient) DisableMFA(ctx context.Context, in *MFACode, opts ...grpc.CallOption) (*empty.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, AuthorizationService_DisableMFA_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authorizationServiceClient) ChangePassword(ctx context.Context, in *ChangePasswordRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, AuthorizationService_ChangePassword_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authorizationServiceClient) DeleteAccount(ctx context.Context, in *DeleteAccountRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, AuthorizationService_DeleteAccount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AuthorizationServiceServer is the server API for AuthorizationService service.
 // All implementations must embed UnimplementedAuthorizationServiceServer
 // for forward compatibility.
 type AuthorizationServiceServer interface {
 	Authorize(context.Context, *UserCredentials) (*TokenResponse, error)
 	Register(context.Context, *UserCredentials) (*TokenResponse, error)
+	// Enroll переводит учетную запись, созданную до шифрования на клиенте,
+	// на ключ аутентификации и ключ хранилища и выполняет вход. Authorize
+	// с ключом аутентификации для такой учетной записи возвращает ту же
+	// ошибку, что и при неверном пароле, а с принятым мастер-паролем -
+	// FAILED_PRECONDITION.
+	Enroll(context.Context, *EnrollRequest) (*TokenResponse, error)
+	// Refresh обменивает refresh-токен на новую пару токенов.
+	Refresh(context.Context, *RefreshRequest) (*TokenResponse, error)
+	// Logout завершает текущую сессию.
+	Logout(context.Context, *empty.Empty) (*empty.Empty, error)
+	ListSessions(context.Context, *empty.Empty) (*ListSessionsResponse, error)
+	RevokeSession(context.Context, *RevokeSessionRequest) (*empty.Empty, error)
+	// VerifyMFA завершает вход пользователя с двухфакторной аутентификацией.
+	VerifyMFA(context.Context, *VerifyMFARequest) (*TokenResponse, error)
+	// SetupMFA создает новый секрет TOTP. Двухфакторная аутентификация
+	// включается только после подтверждения кодом в EnableMFA.
+	SetupMFA(context.Context, *empty.Empty) (*SetupMFAResponse, error)
+	// EnableMFA включает двухфакторную аутентификацию и возвращает
+	// коды восстановления.
+	EnableMFA(context.Context, *MFACode) (*RecoveryCodesResponse, error)
+	DisableMFA(context.Context, *MFACode) (*empty.Empty, error)
+	// ChangePassword меняет мастер-пароль и завершает остальные сессии
+	// пользователя. Данные не перешифровываются: меняется только
+	// зашифрованный ключ хранилища.
+	ChangePassword(context.Context, *ChangePasswordRequest) (*empty.Empty, error)
+	// DeleteAccount удаляет пользователя со всеми его данными.
+	DeleteAccount(context.Context, *DeleteAccountRequest) (*empty.Empty, error)
 	mustEmbedUnimplementedAuthorizationServiceServer()
 }
 
@@ -81,6 +257,39 @@ func (UnimplementedAuthorizationServiceServer) Authorize(context.Context, *UserC
 func (UnimplementedAuthorizationServiceServer) Register(context.Context, *UserCredentials) (*TokenResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Register not implemented")
 }
+func (UnimplementedAuthorizationServiceServer) Enroll(context.Context, *EnrollRequest) (*TokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Enroll not implemented")
+}
+func (UnimplementedAuthorizationServiceServer) Refresh(context.Context, *RefreshRequest) (*TokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Refresh not implemented")
+}
+func (UnimplementedAuthorizationServiceServer) Logout(context.Context, *empty.Empty) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Logout not implemented")
+}
+func (UnimplementedAuthorizationServiceServer) ListSessions(context.Context, *empty.Empty) (*ListSessionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSessions not implemented")
+}
+func (UnimplementedAuthorizationServiceServer) RevokeSession(context.Context, *RevokeSessionRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeSession not implemented")
+}
+func (UnimplementedAuthorizationServiceServer) VerifyMFA(context.Context, *VerifyMFARequest) (*TokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyMFA not implemented")
+}
+func (UnimplementedAuthorizationServiceServer) SetupMFA(context.Context, *empty.Empty) (*SetupMFAResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetupMFA not implemented")
+}
+func (UnimplementedAuthorizationServiceServer) EnableMFA(context.Context, *MFACode) (*RecoveryCodesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EnableMFA not implemented")
+}
+func (UnimplementedAuthorizationServiceServer) DisableMFA(context.Context, *MFACode) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DisableMFA not implemented")
+}
+func (UnimplementedAuthorizationServiceServer) ChangePassword(context.Context, *ChangePasswordRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChangePassword not implemented")
+}
+func (UnimplementedAuthorizationServiceServer) DeleteAccount(context.Context, *DeleteAccountRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteAccount not implemented")
+}
 func (UnimplementedAuthorizationServiceServer) mustEmbedUnimplementedAuthorizationServiceServer() {}
 func (UnimplementedAuthorizationServiceServer) testEmbeddedByValue()                              {}
 
@@ -138,6 +347,204 @@ func _AuthorizationService_Register_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AuthorizationService_Enroll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnrollRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthorizationServiceServer).Enroll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthorizationService_Enroll_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthorizationServiceServer).Enroll(ctx, req.(*EnrollRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthorizationService_Refresh_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefreshRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthorizationServiceServer).Refresh(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthorizationService_Refresh_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthorizationServiceServer).Refresh(ctx, req.(*RefreshRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthorizationService_Logout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthorizationServiceServer).Logout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthorizationService_Logout_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthorizationServiceServer).Logout(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthorizationService_ListSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthorizationServiceServer).ListSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthorizationService_ListSessions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthorizationServiceServer).ListSessions(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthorizationService_RevokeSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthorizationServiceServer).RevokeSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthorizationService_RevokeSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthorizationServiceServer).RevokeSession(ctx, req.(*RevokeSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthorizationService_VerifyMFA_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyMFARequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthorizationServiceServer).VerifyMFA(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthorizationService_VerifyMFA_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthorizationServiceServer).VerifyMFA(ctx, req.(*VerifyMFARequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthorizationService_SetupMFA_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthorizationServiceServer).SetupMFA(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthorizationService_SetupMFA_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthorizationServiceServer).SetupMFA(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthorizationService_EnableMFA_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MFACode)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthorizationServiceServer).EnableMFA(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthorizationService_EnableMFA_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthorizationServiceServer).EnableMFA(ctx, req.(*MFACode))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthorizationService_DisableMFA_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MFACode)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthorizationServiceServer).DisableMFA(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthorizationService_DisableMFA_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthorizationServiceServer).DisableMFA(ctx, req.(*MFACode))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthorizationService_ChangePassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangePasswordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthorizationServiceServer).ChangePassword(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthorizationService_ChangePassword_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthorizationServiceServer).ChangePassword(ctx, req.(*ChangePasswordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthorizationService_DeleteAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthorizationServiceServer).DeleteAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthorizationService_DeleteAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthorizationServiceServer).DeleteAccount(ctx, req.(*DeleteAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // AuthorizationService_ServiceDesc is the grpc.ServiceDesc for AuthorizationService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -153,6 +560,50 @@ var AuthorizationService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Register",
 			Handler:    _AuthorizationService_Register_Handler,
 		},
+		{
+			MethodName: "Enroll",
+			Handler:    _AuthorizationService_Enroll_Handler,
+		},
+		{
+			MethodName: "Refresh",
+			Handler:    _AuthorizationService_Refresh_Handler,
+		},
+		{
+			MethodName: "Logout",
+			Handler:    _AuthorizationService_Logout_Handler,
+		},
+		{
+			MethodName: "ListSessions",
+			Handler:    _AuthorizationService_ListSessions_Handler,
+		},
+		{
+			MethodName: "RevokeSession",
+			Handler:    _AuthorizationService_RevokeSession_Handler,
+		},
+		{
+			MethodName: "VerifyMFA",
+			Handler:    _AuthorizationService_VerifyMFA_Handler,
+		},
+		{
+			MethodName: "SetupMFA",
+			Handler:    _AuthorizationService_SetupMFA_Handler,
+		},
+		{
+			MethodName: "EnableMFA",
+			Handler:    _AuthorizationService_EnableMFA_Handler,
+		},
+		{
+			MethodName: "DisableMFA",
+			Handler:    _AuthorizationService_DisableMFA_Handler,
+		},
+		{
+			MethodName: "ChangePassword",
+			Handler:    _AuthorizationService_ChangePassword_Handler,
+		},
+		{
+			MethodName: "DeleteAccount",
+			Handler:    _AuthorizationService_DeleteAccount_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "authorization.proto",
@@ -21,6 +21,49 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Способ сжатия содержимого. Клиент сжимает файл до шифрования, поэтому
+// распаковать содержимое может только клиент.
+type Compression int32
+
+const (
+	Compression_COMPRESSION_NONE Compression = 0
+	Compression_COMPRESSION_ZSTD Compression = 1
+)
+
+// Enum value maps for Compression.
+var (
+	Compression_name = map[int32]string{
+		0: "COMPRESSION_NONE",
+		1: "COMPRESSION_ZSTD",
+	}
+	Compression_value = map[string]int32{
+		"COMPRESSION_NONE": 0,
+		"COMPRESSION_ZSTD": 1,
+	}
+)
+
+func (x Compression) Enum() *Compression {
+	p := new(Compression)
+	*p = x
+	return p
+}
+
+func (x Compression) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Compression) Descriptor() protoreflect.EnumDescriptor {
+	return file_binary_proto_enumTypes[0].Descriptor()
+}
+
+func (Compression) Type() protoreflect.EnumType {
+	return &file_binary_proto_enumTypes[0]
+}
+
+func (x Compression) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
 type Binary struct {
 	state                  protoimpl.MessageState `protogen:"opaque.v1"`
 	xxx_hidden_Id          int64                  `protobuf:"varint,1,opt,name=id"`
@@ -28,6 +71,8 @@ type Binary struct {
 	xxx_hidden_Filename    *string                `protobuf:"bytes,3,opt,name=filename"`
 	xxx_hidden_Size        int64                  `protobuf:"varint,4,opt,name=size"`
 	xxx_hidden_Notes       *string                `protobuf:"bytes,5,opt,name=notes"`
+	xxx_hidden_Version     int64                  `protobuf:"varint,6,opt,name=version"`
+	xxx_hidden_Compression Compression            `protobuf:"varint,7,opt,name=compression,enum=gophkeeper.Compression"`
 	XXX_raceDetectHookData protoimpl.RaceDetectHookData
 	XXX_presence           [1]uint32
 	unknownFields          protoimpl.UnknownFields
@@ -103,29 +148,55 @@ func (x *Binary) GetNotes() string {
 	return ""
 }
 
+func (x *Binary) GetVersion() int64 {
+	if x != nil {
+		return x.xxx_hidden_Version
+	}
+	return 0
+}
+
+func (x *Binary) GetCompression() Compression {
+	if x != nil {
+		if protoimpl.X.Present(&(x.XXX_presence[0]), 6) {
+			return x.xxx_hidden_Compression
+		}
+	}
+	return Compression_COMPRESSION_NONE
+}
+
 func (x *Binary) SetId(v int64) {
 	x.xxx_hidden_Id = v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 5)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 7)
 }
 
 func (x *Binary) SetName(v string) {
 	x.xxx_hidden_Name = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 5)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 7)
 }
 
 func (x *Binary) SetFilename(v string) {
 	x.xxx_hidden_Filename = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 5)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 7)
 }
 
 func (x *Binary) SetSize(v int64) {
 	x.xxx_hidden_Size = v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 5)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 7)
 }
 
 func (x *Binary) SetNotes(v string) {
 	x.xxx_hidden_Notes = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 5)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 7)
+}
+
+func (x *Binary) SetVersion(v int64) {
+	x.xxx_hidden_Version = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 7)
+}
+
+func (x *Binary) SetCompression(v Compression) {
+	x.xxx_hidden_Compression = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 6, 7)
 }
 
 func (x *Binary) HasId() bool {
@@ -163,6 +234,20 @@ func (x *Binary) HasNotes() bool {
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
 }
 
+func (x *Binary) HasVersion() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
+}
+
+func (x *Binary) HasCompression() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 6)
+}
+
 func (x *Binary) ClearId() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
 	x.xxx_hidden_Id = 0
@@ -188,14 +273,26 @@ func (x *Binary) ClearNotes() {
 	x.xxx_hidden_Notes = nil
 }
 
+func (x *Binary) ClearVersion() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 5)
+	x.xxx_hidden_Version = 0
+}
+
+func (x *Binary) ClearCompression() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 6)
+	x.xxx_hidden_Compression = Compression_COMPRESSION_NONE
+}
+
 type Binary_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
-	Id       *int64
-	Name     *string
-	Filename *string
-	Size     *int64
-	Notes    *string
+	Id          *int64
+	Name        *string
+	Filename    *string
+	Size        *int64
+	Notes       *string
+	Version     *int64
+	Compression *Compression
 }
 
 func (b0 Binary_builder) Build() *Binary {
@@ -203,25 +300,33 @@ func (b0 Binary_builder) Build() *Binary {
 	b, x := &b0, m0
 	_, _ = b, x
 	if b.Id != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 5)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 7)
 		x.xxx_hidden_Id = *b.Id
 	}
 	if b.Name != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 5)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 7)
 		x.xxx_hidden_Name = b.Name
 	}
 	if b.Filename != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 5)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 7)
 		x.xxx_hidden_Filename = b.Filename
 	}
 	if b.Size != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 5)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 7)
 		x.xxx_hidden_Size = *b.Size
 	}
 	if b.Notes != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 5)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 7)
 		x.xxx_hidden_Notes = b.Notes
 	}
+	if b.Version != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 7)
+		x.xxx_hidden_Version = *b.Version
+	}
+	if b.Compression != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 6, 7)
+		x.xxx_hidden_Compression = *b.Compression
+	}
 	return m0
 }
 
@@ -333,33 +438,36 @@ func (b0 FileChunk_builder) Build() *FileChunk {
 	return m0
 }
 
-type SaveBinaryRequest struct {
+type CreateUploadRequest struct {
 	state                  protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_Chunk       *FileChunk             `protobuf:"bytes,1,opt,name=chunk"`
-	xxx_hidden_Name        *string                `protobuf:"bytes,2,opt,name=name"`
-	xxx_hidden_Filename    *string                `protobuf:"bytes,3,opt,name=filename"`
-	xxx_hidden_Size        int64                  `protobuf:"varint,4,opt,name=size"`
-	xxx_hidden_Notes       *string                `protobuf:"bytes,5,opt,name=notes"`
+	xxx_hidden_Name        *string                `protobuf:"bytes,1,opt,name=name"`
+	xxx_hidden_Filename    *string                `protobuf:"bytes,2,opt,name=filename"`
+	xxx_hidden_Size        int64                  `protobuf:"varint,3,opt,name=size"`
+	xxx_hidden_Notes       *string                `protobuf:"bytes,4,opt,name=notes"`
+	xxx_hidden_ContentSize int64                  `protobuf:"varint,5,opt,name=content_size,json=contentSize"`
+	xxx_hidden_Sha256      []byte                 `protobuf:"bytes,6,opt,name=sha256"`
+	xxx_hidden_Compression Compression            `protobuf:"varint,7,opt,name=compression,enum=gophkeeper.Compression"`
+	xxx_hidden_ContentKey  []byte                 `protobuf:"bytes,8,opt,name=content_key,json=contentKey"`
 	XXX_raceDetectHookData protoimpl.RaceDetectHookData
 	XXX_presence           [1]uint32
 	unknownFields          protoimpl.UnknownFields
 	sizeCache              protoimpl.SizeCache
 }
 
-func (x *SaveBinaryRequest) Reset() {
-	*x = SaveBinaryRequest{}
+func (x *CreateUploadRequest) Reset() {
+	*x = CreateUploadRequest{}
 	mi := &file_binary_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SaveBinaryRequest) String() string {
+func (x *CreateUploadRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SaveBinaryRequest) ProtoMessage() {}
+func (*CreateUploadRequest) ProtoMessage() {}
 
-func (x *SaveBinaryRequest) ProtoReflect() protoreflect.Message {
+func (x *CreateUploadRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_binary_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -371,14 +479,7 @@ func (x *SaveBinaryRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-func (x *SaveBinaryRequest) GetChunk() *FileChunk {
-	if x != nil {
-		return x.xxx_hidden_Chunk
-	}
-	return nil
-}
-
-func (x *SaveBinaryRequest) GetName() string {
+func (x *CreateUploadRequest) GetName() string {
 	if x != nil {
 		if x.xxx_hidden_Name != nil {
 			return *x.xxx_hidden_Name
@@ -388,7 +489,7 @@ func (x *SaveBinaryRequest) GetName() string {
 	return ""
 }
 
-func (x *SaveBinaryRequest) GetFilename() string {
+func (x *CreateUploadRequest) GetFilename() string {
 	if x != nil {
 		if x.xxx_hidden_Filename != nil {
 			return *x.xxx_hidden_Filename
@@ -398,14 +499,14 @@ func (x *SaveBinaryRequest) GetFilename() string {
 	return ""
 }
 
-func (x *SaveBinaryRequest) GetSize() int64 {
+func (x *CreateUploadRequest) GetSize() int64 {
 	if x != nil {
 		return x.xxx_hidden_Size
 	}
 	return 0
 }
 
-func (x *SaveBinaryRequest) GetNotes() string {
+func (x *CreateUploadRequest) GetNotes() string {
 	if x != nil {
 		if x.xxx_hidden_Notes != nil {
 			return *x.xxx_hidden_Notes
@@ -415,146 +516,262 @@ func (x *SaveBinaryRequest) GetNotes() string {
 	return ""
 }
 
-func (x *SaveBinaryRequest) SetChunk(v *FileChunk) {
-	x.xxx_hidden_Chunk = v
+func (x *CreateUploadRequest) GetContentSize() int64 {
+	if x != nil {
+		return x.xxx_hidden_ContentSize
+	}
+	return 0
+}
+
+func (x *CreateUploadRequest) GetSha256() []byte {
+	if x != nil {
+		return x.xxx_hidden_Sha256
+	}
+	return nil
+}
+
+func (x *CreateUploadRequest) GetCompression() Compression {
+	if x != nil {
+		if protoimpl.X.Present(&(x.XXX_presence[0]), 6) {
+			return x.xxx_hidden_Compression
+		}
+	}
+	return Compression_COMPRESSION_NONE
+}
+
+func (x *CreateUploadRequest) GetContentKey() []byte {
+	if x != nil {
+		return x.xxx_hidden_ContentKey
+	}
+	return nil
 }
 
-func (x *SaveBinaryRequest) SetName(v string) {
+func (x *CreateUploadRequest) SetName(v string) {
 	x.xxx_hidden_Name = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 5)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 8)
 }
 
-func (x *SaveBinaryRequest) SetFilename(v string) {
+func (x *CreateUploadRequest) SetFilename(v string) {
 	x.xxx_hidden_Filename = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 5)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 8)
 }
 
-func (x *SaveBinaryRequest) SetSize(v int64) {
+func (x *CreateUploadRequest) SetSize(v int64) {
 	x.xxx_hidden_Size = v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 5)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 8)
 }
 
-func (x *SaveBinaryRequest) SetNotes(v string) {
+func (x *CreateUploadRequest) SetNotes(v string) {
 	x.xxx_hidden_Notes = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 5)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 8)
 }
 
-func (x *SaveBinaryRequest) HasChunk() bool {
+func (x *CreateUploadRequest) SetContentSize(v int64) {
+	x.xxx_hidden_ContentSize = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 8)
+}
+
+func (x *CreateUploadRequest) SetSha256(v []byte) {
+	if v == nil {
+		v = []byte{}
+	}
+	x.xxx_hidden_Sha256 = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 8)
+}
+
+func (x *CreateUploadRequest) SetCompression(v Compression) {
+	x.xxx_hidden_Compression = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 6, 8)
+}
+
+func (x *CreateUploadRequest) SetContentKey(v []byte) {
+	if v == nil {
+		v = []byte{}
+	}
+	x.xxx_hidden_ContentKey = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 7, 8)
+}
+
+func (x *CreateUploadRequest) HasName() bool {
 	if x == nil {
 		return false
 	}
-	return x.xxx_hidden_Chunk != nil
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
 }
 
-func (x *SaveBinaryRequest) HasName() bool {
+func (x *CreateUploadRequest) HasFilename() bool {
 	if x == nil {
 		return false
 	}
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
 }
 
-func (x *SaveBinaryRequest) HasFilename() bool {
+func (x *CreateUploadRequest) HasSize() bool {
 	if x == nil {
 		return false
 	}
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
 }
 
-func (x *SaveBinaryRequest) HasSize() bool {
+func (x *CreateUploadRequest) HasNotes() bool {
 	if x == nil {
 		return false
 	}
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
 }
 
-func (x *SaveBinaryRequest) HasNotes() bool {
+func (x *CreateUploadRequest) HasContentSize() bool {
 	if x == nil {
 		return false
 	}
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
 }
 
-func (x *SaveBinaryRequest) ClearChunk() {
-	x.xxx_hidden_Chunk = nil
+func (x *CreateUploadRequest) HasSha256() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
 }
 
-func (x *SaveBinaryRequest) ClearName() {
-	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+func (x *CreateUploadRequest) HasCompression() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 6)
+}
+
+func (x *CreateUploadRequest) HasContentKey() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 7)
+}
+
+func (x *CreateUploadRequest) ClearName() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
 	x.xxx_hidden_Name = nil
 }
 
-func (x *SaveBinaryRequest) ClearFilename() {
-	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+func (x *CreateUploadRequest) ClearFilename() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
 	x.xxx_hidden_Filename = nil
 }
 
-func (x *SaveBinaryRequest) ClearSize() {
-	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+func (x *CreateUploadRequest) ClearSize() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
 	x.xxx_hidden_Size = 0
 }
 
-func (x *SaveBinaryRequest) ClearNotes() {
-	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+func (x *CreateUploadRequest) ClearNotes() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
 	x.xxx_hidden_Notes = nil
 }
 
-type SaveBinaryRequest_builder struct {
+func (x *CreateUploadRequest) ClearContentSize() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_ContentSize = 0
+}
+
+func (x *CreateUploadRequest) ClearSha256() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 5)
+	x.xxx_hidden_Sha256 = nil
+}
+
+func (x *CreateUploadRequest) ClearCompression() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 6)
+	x.xxx_hidden_Compression = Compression_COMPRESSION_NONE
+}
+
+func (x *CreateUploadRequest) ClearContentKey() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 7)
+	x.xxx_hidden_ContentKey = nil
+}
+
+type CreateUploadRequest_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
-	Chunk    *FileChunk
 	Name     *string
 	Filename *string
-	Size     *int64
-	Notes    *string
-}
-
-func (b0 SaveBinaryRequest_builder) Build() *SaveBinaryRequest {
-	m0 := &SaveBinaryRequest{}
+	// Размер исходного файла.
+	Size  *int64
+	Notes *string
+	// Размер загружаемого содержимого. Содержимое шифруется на клиенте,
+	// поэтому оно больше исходного файла.
+	ContentSize *int64
+	// SHA-256 загружаемого содержимого.
+	Sha256 []byte
+	// Способ сжатия файла. Должен быть в ServerInfoResponse.compressions.
+	Compression *Compression
+	// Ключ дедупликации: HMAC-SHA256 исходного файла на ключе, выведенном
+	// из ключа хранилища. Записи пользователя с одинаковым ключом и способом
+	// сжатия хранят содержимое один раз. Необязательный: без него совпадает
+	// только одинаковое загружаемое содержимое.
+	ContentKey []byte
+}
+
+func (b0 CreateUploadRequest_builder) Build() *CreateUploadRequest {
+	m0 := &CreateUploadRequest{}
 	b, x := &b0, m0
 	_, _ = b, x
-	x.xxx_hidden_Chunk = b.Chunk
 	if b.Name != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 5)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 8)
 		x.xxx_hidden_Name = b.Name
 	}
 	if b.Filename != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 5)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 8)
 		x.xxx_hidden_Filename = b.Filename
 	}
 	if b.Size != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 5)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 8)
 		x.xxx_hidden_Size = *b.Size
 	}
 	if b.Notes != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 5)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 8)
 		x.xxx_hidden_Notes = b.Notes
 	}
+	if b.ContentSize != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 8)
+		x.xxx_hidden_ContentSize = *b.ContentSize
+	}
+	if b.Sha256 != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 8)
+		x.xxx_hidden_Sha256 = b.Sha256
+	}
+	if b.Compression != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 6, 8)
+		x.xxx_hidden_Compression = *b.Compression
+	}
+	if b.ContentKey != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 7, 8)
+		x.xxx_hidden_ContentKey = b.ContentKey
+	}
 	return m0
 }
 
-type DownloadBinaryRequest struct {
+type UploadRequest struct {
 	state                  protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_Id          int64                  `protobuf:"varint,1,opt,name=id"`
+	xxx_hidden_UploadId    *string                `protobuf:"bytes,1,opt,name=upload_id,json=uploadId"`
 	XXX_raceDetectHookData protoimpl.RaceDetectHookData
 	XXX_presence           [1]uint32
 	unknownFields          protoimpl.UnknownFields
 	sizeCache              protoimpl.SizeCache
 }
 
-func (x *DownloadBinaryRequest) Reset() {
-	*x = DownloadBinaryRequest{}
+func (x *UploadRequest) Reset() {
+	*x = UploadRequest{}
 	mi := &file_binary_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DownloadBinaryRequest) String() string {
+func (x *UploadRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DownloadBinaryRequest) ProtoMessage() {}
+func (*UploadRequest) ProtoMessage() {}
 
-func (x *DownloadBinaryRequest) ProtoReflect() protoreflect.Message {
+func (x *UploadRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_binary_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -566,73 +783,74 @@ func (x *DownloadBinaryRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-func (x *DownloadBinaryRequest) GetId() int64 {
+func (x *UploadRequest) GetUploadId() string {
 	if x != nil {
-		return x.xxx_hidden_Id
+		if x.xxx_hidden_UploadId != nil {
+			return *x.xxx_hidden_UploadId
+		}
+		return ""
 	}
-	return 0
+	return ""
 }
 
-func (x *DownloadBinaryRequest) SetId(v int64) {
-	x.xxx_hidden_Id = v
+func (x *UploadRequest) SetUploadId(v string) {
+	x.xxx_hidden_UploadId = &v
 	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 1)
 }
 
-func (x *DownloadBinaryRequest) HasId() bool {
+func (x *UploadRequest) HasUploadId() bool {
 	if x == nil {
 		return false
 	}
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
 }
 
-func (x *DownloadBinaryRequest) ClearId() {
+func (x *UploadRequest) ClearUploadId() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
-	x.xxx_hidden_Id = 0
+	x.xxx_hidden_UploadId = nil
 }
 
-type DownloadBinaryRequest_builder struct {
+type UploadRequest_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
-	Id *int64
+	UploadId *string
 }
 
-func (b0 DownloadBinaryRequest_builder) Build() *DownloadBinaryRequest {
-	m0 := &DownloadBinaryRequest{}
+func (b0 UploadRequest_builder) Build() *UploadRequest {
+	m0 := &UploadRequest{}
 	b, x := &b0, m0
 	_, _ = b, x
-	if b.Id != nil {
+	if b.UploadId != nil {
 		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 1)
-		x.xxx_hidden_Id = *b.Id
+		x.xxx_hidden_UploadId = b.UploadId
 	}
 	return m0
 }
 
-type DownloadBinaryResponse struct {
+type UploadStatus struct {
 	state                  protoimpl.MessageState `protogen:"opaque.v1"`
-	xxx_hidden_Chunk       *FileChunk             `protobuf:"bytes,1,opt,name=chunk"`
-	xxx_hidden_Name        *string                `protobuf:"bytes,2,opt,name=name"`
-	xxx_hidden_Filename    *string                `protobuf:"bytes,3,opt,name=filename"`
-	xxx_hidden_Size        int64                  `protobuf:"varint,4,opt,name=size"`
+	xxx_hidden_UploadId    *string                `protobuf:"bytes,1,opt,name=upload_id,json=uploadId"`
+	xxx_hidden_Offset      int64                  `protobuf:"varint,2,opt,name=offset"`
 	XXX_raceDetectHookData protoimpl.RaceDetectHookData
 	XXX_presence           [1]uint32
 	unknownFields          protoimpl.UnknownFields
 	sizeCache              protoimpl.SizeCache
 }
 
-func (x *DownloadBinaryResponse) Reset() {
-	*x = DownloadBinaryResponse{}
+func (x *UploadStatus) Reset() {
+	*x = UploadStatus{}
 	mi := &file_binary_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DownloadBinaryResponse) String() string {
+func (x *UploadStatus) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DownloadBinaryResponse) ProtoMessage() {}
+func (*UploadStatus) ProtoMessage() {}
 
-func (x *DownloadBinaryResponse) ProtoReflect() protoreflect.Message {
+func (x *UploadStatus) ProtoReflect() protoreflect.Message {
 	mi := &file_binary_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -644,132 +862,624 @@ func (x *DownloadBinaryResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-func (x *DownloadBinaryResponse) GetChunk() *FileChunk {
-	if x != nil {
-		return x.xxx_hidden_Chunk
-	}
-	return nil
-}
-
-func (x *DownloadBinaryResponse) GetName() string {
-	if x != nil {
-		if x.xxx_hidden_Name != nil {
-			return *x.xxx_hidden_Name
-		}
-		return ""
-	}
-	return ""
-}
-
-func (x *DownloadBinaryResponse) GetFilename() string {
+func (x *UploadStatus) GetUploadId() string {
 	if x != nil {
-		if x.xxx_hidden_Filename != nil {
-			return *x.xxx_hidden_Filename
+		if x.xxx_hidden_UploadId != nil {
+			return *x.xxx_hidden_UploadId
 		}
 		return ""
 	}
 	return ""
 }
 
-func (x *DownloadBinaryResponse) GetSize() int64 {
+func (x *UploadStatus) GetOffset() int64 {
 	if x != nil {
-		return x.xxx_hidden_Size
+		return x.xxx_hidden_Offset
 	}
 	return 0
 }
 
-func (x *DownloadBinaryResponse) SetChunk(v *FileChunk) {
-	x.xxx_hidden_Chunk = v
-}
-
-func (x *DownloadBinaryResponse) SetName(v string) {
-	x.xxx_hidden_Name = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 4)
-}
-
-func (x *DownloadBinaryResponse) SetFilename(v string) {
-	x.xxx_hidden_Filename = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 4)
+func (x *UploadStatus) SetUploadId(v string) {
+	x.xxx_hidden_UploadId = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
 }
 
-func (x *DownloadBinaryResponse) SetSize(v int64) {
-	x.xxx_hidden_Size = v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 4)
+func (x *UploadStatus) SetOffset(v int64) {
+	x.xxx_hidden_Offset = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
 }
 
-func (x *DownloadBinaryResponse) HasChunk() bool {
+func (x *UploadStatus) HasUploadId() bool {
 	if x == nil {
 		return false
 	}
-	return x.xxx_hidden_Chunk != nil
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
 }
 
-func (x *DownloadBinaryResponse) HasName() bool {
+func (x *UploadStatus) HasOffset() bool {
 	if x == nil {
 		return false
 	}
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
 }
 
-func (x *DownloadBinaryResponse) HasFilename() bool {
-	if x == nil {
-		return false
-	}
-	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
-}
-
-func (x *DownloadBinaryResponse) HasSize() bool {
-	if x == nil {
-		return false
-	}
-	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
-}
-
-func (x *DownloadBinaryResponse) ClearChunk() {
-	x.xxx_hidden_Chunk = nil
+func (x *UploadStatus) ClearUploadId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_UploadId = nil
 }
 
-func (x *DownloadBinaryResponse) ClearName() {
+func (x *UploadStatus) ClearOffset() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
-	x.xxx_hidden_Name = nil
-}
-
-func (x *DownloadBinaryResponse) ClearFilename() {
-	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
-	x.xxx_hidden_Filename = nil
-}
-
-func (x *DownloadBinaryResponse) ClearSize() {
-	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
-	x.xxx_hidden_Size = 0
+	x.xxx_hidden_Offset = 0
 }
 
-type DownloadBinaryResponse_builder struct {
+type UploadStatus_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
-	Chunk    *FileChunk
-	Name     *string
-	Filename *string
-	Size     *int64
+	UploadId *string
+	// Число байт, уже сохраненных на сервере. С этого смещения
+	// загрузка продолжается после обрыва соединения.
+	Offset *int64
 }
 
-func (b0 DownloadBinaryResponse_builder) Build() *DownloadBinaryResponse {
-	m0 := &DownloadBinaryResponse{}
+func (b0 UploadStatus_builder) Build() *UploadStatus {
+	m0 := &UploadStatus{}
 	b, x := &b0, m0
 	_, _ = b, x
-	x.xxx_hidden_Chunk = b.Chunk
-	if b.Name != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 4)
-		x.xxx_hidden_Name = b.Name
+	if b.UploadId != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		x.xxx_hidden_UploadId = b.UploadId
 	}
-	if b.Filename != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 4)
-		x.xxx_hidden_Filename = b.Filename
+	if b.Offset != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		x.xxx_hidden_Offset = *b.Offset
+	}
+	return m0
+}
+
+type UploadChunk struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_UploadId    *string                `protobuf:"bytes,1,opt,name=upload_id,json=uploadId"`
+	xxx_hidden_Offset      int64                  `protobuf:"varint,2,opt,name=offset"`
+	xxx_hidden_Data        []byte                 `protobuf:"bytes,3,opt,name=data"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *UploadChunk) Reset() {
+	*x = UploadChunk{}
+	mi := &file_binary_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadChunk) ProtoMessage() {}
+
+func (x *UploadChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_binary_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *UploadChunk) GetUploadId() string {
+	if x != nil {
+		if x.xxx_hidden_UploadId != nil {
+			return *x.xxx_hidden_UploadId
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *UploadChunk) GetOffset() int64 {
+	if x != nil {
+		return x.xxx_hidden_Offset
+	}
+	return 0
+}
+
+func (x *UploadChunk) GetData() []byte {
+	if x != nil {
+		return x.xxx_hidden_Data
+	}
+	return nil
+}
+
+func (x *UploadChunk) SetUploadId(v string) {
+	x.xxx_hidden_UploadId = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 3)
+}
+
+func (x *UploadChunk) SetOffset(v int64) {
+	x.xxx_hidden_Offset = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 3)
+}
+
+func (x *UploadChunk) SetData(v []byte) {
+	if v == nil {
+		v = []byte{}
+	}
+	x.xxx_hidden_Data = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 3)
+}
+
+func (x *UploadChunk) HasUploadId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *UploadChunk) HasOffset() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *UploadChunk) HasData() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *UploadChunk) ClearUploadId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_UploadId = nil
+}
+
+func (x *UploadChunk) ClearOffset() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Offset = 0
+}
+
+func (x *UploadChunk) ClearData() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Data = nil
+}
+
+type UploadChunk_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	UploadId *string
+	// Смещение данных чанка от начала содержимого.
+	Offset *int64
+	Data   []byte
+}
+
+func (b0 UploadChunk_builder) Build() *UploadChunk {
+	m0 := &UploadChunk{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.UploadId != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 3)
+		x.xxx_hidden_UploadId = b.UploadId
+	}
+	if b.Offset != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 3)
+		x.xxx_hidden_Offset = *b.Offset
+	}
+	if b.Data != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 3)
+		x.xxx_hidden_Data = b.Data
+	}
+	return m0
+}
+
+type DownloadBinaryRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Id          int64                  `protobuf:"varint,1,opt,name=id"`
+	xxx_hidden_Offset      int64                  `protobuf:"varint,2,opt,name=offset"`
+	xxx_hidden_Length      int64                  `protobuf:"varint,3,opt,name=length"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *DownloadBinaryRequest) Reset() {
+	*x = DownloadBinaryRequest{}
+	mi := &file_binary_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadBinaryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadBinaryRequest) ProtoMessage() {}
+
+func (x *DownloadBinaryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_binary_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *DownloadBinaryRequest) GetId() int64 {
+	if x != nil {
+		return x.xxx_hidden_Id
+	}
+	return 0
+}
+
+func (x *DownloadBinaryRequest) GetOffset() int64 {
+	if x != nil {
+		return x.xxx_hidden_Offset
+	}
+	return 0
+}
+
+func (x *DownloadBinaryRequest) GetLength() int64 {
+	if x != nil {
+		return x.xxx_hidden_Length
+	}
+	return 0
+}
+
+func (x *DownloadBinaryRequest) SetId(v int64) {
+	x.xxx_hidden_Id = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 3)
+}
+
+func (x *DownloadBinaryRequest) SetOffset(v int64) {
+	x.xxx_hidden_Offset = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 3)
+}
+
+func (x *DownloadBinaryRequest) SetLength(v int64) {
+	x.xxx_hidden_Length = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 3)
+}
+
+func (x *DownloadBinaryRequest) HasId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *DownloadBinaryRequest) HasOffset() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *DownloadBinaryRequest) HasLength() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *DownloadBinaryRequest) ClearId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Id = 0
+}
+
+func (x *DownloadBinaryRequest) ClearOffset() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Offset = 0
+}
+
+func (x *DownloadBinaryRequest) ClearLength() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Length = 0
+}
+
+type DownloadBinaryRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Id *int64
+	// Смещение, с которого отдается содержимое. Позволяет докачать файл
+	// после обрыва соединения.
+	Offset *int64
+	// Сколько байт отдать. 0 - до конца содержимого.
+	Length *int64
+}
+
+func (b0 DownloadBinaryRequest_builder) Build() *DownloadBinaryRequest {
+	m0 := &DownloadBinaryRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Id != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 3)
+		x.xxx_hidden_Id = *b.Id
+	}
+	if b.Offset != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 3)
+		x.xxx_hidden_Offset = *b.Offset
+	}
+	if b.Length != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 3)
+		x.xxx_hidden_Length = *b.Length
+	}
+	return m0
+}
+
+// Метаданные файла передаются только в первом сообщении потока.
+type DownloadBinaryResponse struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Chunk       *FileChunk             `protobuf:"bytes,1,opt,name=chunk"`
+	xxx_hidden_Name        *string                `protobuf:"bytes,2,opt,name=name"`
+	xxx_hidden_Filename    *string                `protobuf:"bytes,3,opt,name=filename"`
+	xxx_hidden_Size        int64                  `protobuf:"varint,4,opt,name=size"`
+	xxx_hidden_ContentSize int64                  `protobuf:"varint,5,opt,name=content_size,json=contentSize"`
+	xxx_hidden_Sha256      []byte                 `protobuf:"bytes,6,opt,name=sha256"`
+	xxx_hidden_Compression Compression            `protobuf:"varint,7,opt,name=compression,enum=gophkeeper.Compression"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *DownloadBinaryResponse) Reset() {
+	*x = DownloadBinaryResponse{}
+	mi := &file_binary_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadBinaryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadBinaryResponse) ProtoMessage() {}
+
+func (x *DownloadBinaryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_binary_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *DownloadBinaryResponse) GetChunk() *FileChunk {
+	if x != nil {
+		return x.xxx_hidden_Chunk
+	}
+	return nil
+}
+
+func (x *DownloadBinaryResponse) GetName() string {
+	if x != nil {
+		if x.xxx_hidden_Name != nil {
+			return *x.xxx_hidden_Name
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *DownloadBinaryResponse) GetFilename() string {
+	if x != nil {
+		if x.xxx_hidden_Filename != nil {
+			return *x.xxx_hidden_Filename
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *DownloadBinaryResponse) GetSize() int64 {
+	if x != nil {
+		return x.xxx_hidden_Size
+	}
+	return 0
+}
+
+func (x *DownloadBinaryResponse) GetContentSize() int64 {
+	if x != nil {
+		return x.xxx_hidden_ContentSize
+	}
+	return 0
+}
+
+func (x *DownloadBinaryResponse) GetSha256() []byte {
+	if x != nil {
+		return x.xxx_hidden_Sha256
+	}
+	return nil
+}
+
+func (x *DownloadBinaryResponse) GetCompression() Compression {
+	if x != nil {
+		if protoimpl.X.Present(&(x.XXX_presence[0]), 6) {
+			return x.xxx_hidden_Compression
+		}
+	}
+	return Compression_COMPRESSION_NONE
+}
+
+func (x *DownloadBinaryResponse) SetChunk(v *FileChunk) {
+	x.xxx_hidden_Chunk = v
+}
+
+func (x *DownloadBinaryResponse) SetName(v string) {
+	x.xxx_hidden_Name = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 7)
+}
+
+func (x *DownloadBinaryResponse) SetFilename(v string) {
+	x.xxx_hidden_Filename = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 7)
+}
+
+func (x *DownloadBinaryResponse) SetSize(v int64) {
+	x.xxx_hidden_Size = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 7)
+}
+
+func (x *DownloadBinaryResponse) SetContentSize(v int64) {
+	x.xxx_hidden_ContentSize = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 7)
+}
+
+func (x *DownloadBinaryResponse) SetSha256(v []byte) {
+	if v == nil {
+		v = []byte{}
+	}
+	x.xxx_hidden_Sha256 = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 7)
+}
+
+func (x *DownloadBinaryResponse) SetCompression(v Compression) {
+	x.xxx_hidden_Compression = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 6, 7)
+}
+
+func (x *DownloadBinaryResponse) HasChunk() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Chunk != nil
+}
+
+func (x *DownloadBinaryResponse) HasName() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *DownloadBinaryResponse) HasFilename() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *DownloadBinaryResponse) HasSize() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *DownloadBinaryResponse) HasContentSize() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *DownloadBinaryResponse) HasSha256() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
+}
+
+func (x *DownloadBinaryResponse) HasCompression() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 6)
+}
+
+func (x *DownloadBinaryResponse) ClearChunk() {
+	x.xxx_hidden_Chunk = nil
+}
+
+func (x *DownloadBinaryResponse) ClearName() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Name = nil
+}
+
+func (x *DownloadBinaryResponse) ClearFilename() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Filename = nil
+}
+
+func (x *DownloadBinaryResponse) ClearSize() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_Size = 0
+}
+
+func (x *DownloadBinaryResponse) ClearContentSize() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_ContentSize = 0
+}
+
+func (x *DownloadBinaryResponse) ClearSha256() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 5)
+	x.xxx_hidden_Sha256 = nil
+}
+
+func (x *DownloadBinaryResponse) ClearCompression() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 6)
+	x.xxx_hidden_Compression = Compression_COMPRESSION_NONE
+}
+
+type DownloadBinaryResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Chunk    *FileChunk
+	Name     *string
+	Filename *string
+	// Размер исходного файла.
+	Size *int64
+	// Размер всего сохраненного содержимого.
+	ContentSize *int64
+	// SHA-256 всего сохраненного содержимого.
+	Sha256 []byte
+	// Способ сжатия файла: после расшифровки содержимое нужно распаковать.
+	Compression *Compression
+}
+
+func (b0 DownloadBinaryResponse_builder) Build() *DownloadBinaryResponse {
+	m0 := &DownloadBinaryResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Chunk = b.Chunk
+	if b.Name != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 7)
+		x.xxx_hidden_Name = b.Name
+	}
+	if b.Filename != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 7)
+		x.xxx_hidden_Filename = b.Filename
 	}
 	if b.Size != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 4)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 7)
 		x.xxx_hidden_Size = *b.Size
 	}
+	if b.ContentSize != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 7)
+		x.xxx_hidden_ContentSize = *b.ContentSize
+	}
+	if b.Sha256 != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 7)
+		x.xxx_hidden_Sha256 = b.Sha256
+	}
+	if b.Compression != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 6, 7)
+		x.xxx_hidden_Compression = *b.Compression
+	}
 	return m0
 }
 
@@ -782,7 +1492,7 @@ type GetAllBinariesResponse struct {
 
 func (x *GetAllBinariesResponse) Reset() {
 	*x = GetAllBinariesResponse{}
-	mi := &file_binary_proto_msgTypes[5]
+	mi := &file_binary_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -794,7 +1504,7 @@ func (x *GetAllBinariesResponse) String() string {
 func (*GetAllBinariesResponse) ProtoMessage() {}
 
 func (x *GetAllBinariesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_binary_proto_msgTypes[5]
+	mi := &file_binary_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -837,6 +1547,7 @@ type UpdateBinaryRequest struct {
 	xxx_hidden_Id          int64                  `protobuf:"varint,1,opt,name=id"`
 	xxx_hidden_Name        *string                `protobuf:"bytes,2,opt,name=name"`
 	xxx_hidden_Notes       *string                `protobuf:"bytes,3,opt,name=notes"`
+	xxx_hidden_Version     int64                  `protobuf:"varint,4,opt,name=version"`
 	XXX_raceDetectHookData protoimpl.RaceDetectHookData
 	XXX_presence           [1]uint32
 	unknownFields          protoimpl.UnknownFields
@@ -845,7 +1556,7 @@ type UpdateBinaryRequest struct {
 
 func (x *UpdateBinaryRequest) Reset() {
 	*x = UpdateBinaryRequest{}
-	mi := &file_binary_proto_msgTypes[6]
+	mi := &file_binary_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -857,7 +1568,7 @@ func (x *UpdateBinaryRequest) String() string {
 func (*UpdateBinaryRequest) ProtoMessage() {}
 
 func (x *UpdateBinaryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_binary_proto_msgTypes[6]
+	mi := &file_binary_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -895,19 +1606,31 @@ func (x *UpdateBinaryRequest) GetNotes() string {
 	return ""
 }
 
+func (x *UpdateBinaryRequest) GetVersion() int64 {
+	if x != nil {
+		return x.xxx_hidden_Version
+	}
+	return 0
+}
+
 func (x *UpdateBinaryRequest) SetId(v int64) {
 	x.xxx_hidden_Id = v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 3)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 4)
 }
 
 func (x *UpdateBinaryRequest) SetName(v string) {
 	x.xxx_hidden_Name = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 3)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 4)
 }
 
 func (x *UpdateBinaryRequest) SetNotes(v string) {
 	x.xxx_hidden_Notes = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 3)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 4)
+}
+
+func (x *UpdateBinaryRequest) SetVersion(v int64) {
+	x.xxx_hidden_Version = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 4)
 }
 
 func (x *UpdateBinaryRequest) HasId() bool {
@@ -931,6 +1654,13 @@ func (x *UpdateBinaryRequest) HasNotes() bool {
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
 }
 
+func (x *UpdateBinaryRequest) HasVersion() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
 func (x *UpdateBinaryRequest) ClearId() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
 	x.xxx_hidden_Id = 0
@@ -946,12 +1676,18 @@ func (x *UpdateBinaryRequest) ClearNotes() {
 	x.xxx_hidden_Notes = nil
 }
 
+func (x *UpdateBinaryRequest) ClearVersion() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_Version = 0
+}
+
 type UpdateBinaryRequest_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
-	Id    *int64
-	Name  *string
-	Notes *string
+	Id      *int64
+	Name    *string
+	Notes   *string
+	Version *int64
 }
 
 func (b0 UpdateBinaryRequest_builder) Build() *UpdateBinaryRequest {
@@ -959,17 +1695,21 @@ func (b0 UpdateBinaryRequest_builder) Build() *UpdateBinaryRequest {
 	b, x := &b0, m0
 	_, _ = b, x
 	if b.Id != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 3)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 4)
 		x.xxx_hidden_Id = *b.Id
 	}
 	if b.Name != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 3)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 4)
 		x.xxx_hidden_Name = b.Name
 	}
 	if b.Notes != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 3)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 4)
 		x.xxx_hidden_Notes = b.Notes
 	}
+	if b.Version != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 4)
+		x.xxx_hidden_Version = *b.Version
+	}
 	return m0
 }
 
@@ -979,73 +1719,113 @@ const file_binary_proto_rawDesc = "" +
 	"\n" +
 	"\fbinary.proto\x12\n" +
 	"gophkeeper\x1a\x1bgoogle/protobuf/empty.proto\x1a\n" +
-	"data.proto\"r\n" +
+	"data.proto\"\xc7\x01\n" +
 	"\x06Binary\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1a\n" +
 	"\bfilename\x18\x03 \x01(\tR\bfilename\x12\x12\n" +
 	"\x04size\x18\x04 \x01(\x03R\x04size\x12\x14\n" +
-	"\x05notes\x18\x05 \x01(\tR\x05notes\"5\n" +
+	"\x05notes\x18\x05 \x01(\tR\x05notes\x12\x18\n" +
+	"\aversion\x18\x06 \x01(\x03R\aversion\x129\n" +
+	"\vcompression\x18\a \x01(\x0e2\x17.gophkeeper.CompressionR\vcompression\"5\n" +
 	"\tFileChunk\x12\x12\n" +
 	"\x04data\x18\x01 \x01(\fR\x04data\x12\x14\n" +
-	"\x05index\x18\x02 \x01(\x05R\x05index\"\x9a\x01\n" +
-	"\x11SaveBinaryRequest\x12+\n" +
-	"\x05chunk\x18\x01 \x01(\v2\x15.gophkeeper.FileChunkR\x05chunk\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1a\n" +
-	"\bfilename\x18\x03 \x01(\tR\bfilename\x12\x12\n" +
-	"\x04size\x18\x04 \x01(\x03R\x04size\x12\x14\n" +
-	"\x05notes\x18\x05 \x01(\tR\x05notes\"'\n" +
+	"\x05index\x18\x02 \x01(\x05R\x05index\"\x86\x02\n" +
+	"\x13CreateUploadRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1a\n" +
+	"\bfilename\x18\x02 \x01(\tR\bfilename\x12\x12\n" +
+	"\x04size\x18\x03 \x01(\x03R\x04size\x12\x14\n" +
+	"\x05notes\x18\x04 \x01(\tR\x05notes\x12!\n" +
+	"\fcontent_size\x18\x05 \x01(\x03R\vcontentSize\x12\x16\n" +
+	"\x06sha256\x18\x06 \x01(\fR\x06sha256\x129\n" +
+	"\vcompression\x18\a \x01(\x0e2\x17.gophkeeper.CompressionR\vcompression\x12\x1f\n" +
+	"\vcontent_key\x18\b \x01(\fR\n" +
+	"contentKey\",\n" +
+	"\rUploadRequest\x12\x1b\n" +
+	"\tupload_id\x18\x01 \x01(\tR\buploadId\"C\n" +
+	"\fUploadStatus\x12\x1b\n" +
+	"\tupload_id\x18\x01 \x01(\tR\buploadId\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x03R\x06offset\"V\n" +
+	"\vUploadChunk\x12\x1b\n" +
+	"\tupload_id\x18\x01 \x01(\tR\buploadId\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x03R\x06offset\x12\x12\n" +
+	"\x04data\x18\x03 \x01(\fR\x04data\"W\n" +
 	"\x15DownloadBinaryRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x03R\x02id\"\x89\x01\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x03R\x06offset\x12\x16\n" +
+	"\x06length\x18\x03 \x01(\x03R\x06length\"\xff\x01\n" +
 	"\x16DownloadBinaryResponse\x12+\n" +
 	"\x05chunk\x18\x01 \x01(\v2\x15.gophkeeper.FileChunkR\x05chunk\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1a\n" +
 	"\bfilename\x18\x03 \x01(\tR\bfilename\x12\x12\n" +
-	"\x04size\x18\x04 \x01(\x03R\x04size\"D\n" +
+	"\x04size\x18\x04 \x01(\x03R\x04size\x12!\n" +
+	"\fcontent_size\x18\x05 \x01(\x03R\vcontentSize\x12\x16\n" +
+	"\x06sha256\x18\x06 \x01(\fR\x06sha256\x129\n" +
+	"\vcompression\x18\a \x01(\x0e2\x17.gophkeeper.CompressionR\vcompression\"D\n" +
 	"\x16GetAllBinariesResponse\x12*\n" +
-	"\x06result\x18\x01 \x03(\v2\x12.gophkeeper.BinaryR\x06result\"O\n" +
+	"\x06result\x18\x01 \x03(\v2\x12.gophkeeper.BinaryR\x06result\"i\n" +
 	"\x13UpdateBinaryRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
-	"\x05notes\x18\x03 \x01(\tR\x05notes2\xf1\x02\n" +
-	"\rBinaryService\x12A\n" +
-	"\x06Upload\x12\x1d.gophkeeper.SaveBinaryRequest\x1a\x16.google.protobuf.Empty(\x01\x12S\n" +
+	"\x05notes\x18\x03 \x01(\tR\x05notes\x12\x18\n" +
+	"\aversion\x18\x04 \x01(\x03R\aversion*9\n" +
+	"\vCompression\x12\x14\n" +
+	"\x10COMPRESSION_NONE\x10\x00\x12\x14\n" +
+	"\x10COMPRESSION_ZSTD\x10\x012\xc5\x04\n" +
+	"\rBinaryService\x12I\n" +
+	"\fCreateUpload\x12\x1f.gophkeeper.CreateUploadRequest\x1a\x18.gophkeeper.UploadStatus\x12C\n" +
+	"\fUploadChunks\x12\x17.gophkeeper.UploadChunk\x1a\x18.gophkeeper.UploadStatus(\x01\x12@\n" +
+	"\tGetUpload\x12\x19.gophkeeper.UploadRequest\x1a\x18.gophkeeper.UploadStatus\x12C\n" +
+	"\x0eCompleteUpload\x12\x19.gophkeeper.UploadRequest\x1a\x16.google.protobuf.Empty\x12S\n" +
 	"\bDownload\x12!.gophkeeper.DownloadBinaryRequest\x1a\".gophkeeper.DownloadBinaryResponse0\x01\x12D\n" +
 	"\x06GetAll\x12\x16.google.protobuf.Empty\x1a\".gophkeeper.GetAllBinariesResponse\x12A\n" +
 	"\x06Update\x12\x1f.gophkeeper.UpdateBinaryRequest\x1a\x16.google.protobuf.Empty\x12?\n" +
 	"\x06Remove\x12\x1d.gophkeeper.RemoveDataRequest\x1a\x16.google.protobuf.EmptyB\x1cZ\x1agophkeeper.v1;gophkeeperv1b\beditionsp\xe8\a"
 
-var file_binary_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_binary_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_binary_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
 var file_binary_proto_goTypes = []any{
-	(*Binary)(nil),                 // 0: gophkeeper.Binary
-	(*FileChunk)(nil),              // 1: gophkeeper.FileChunk
-	(*SaveBinaryRequest)(nil),      // 2: gophkeeper.SaveBinaryRequest
-	(*DownloadBinaryRequest)(nil),  // 3: gophkeeper.DownloadBinaryRequest
-	(*DownloadBinaryResponse)(nil), // 4: gophkeeper.DownloadBinaryResponse
-	(*GetAllBinariesResponse)(nil), // 5: gophkeeper.GetAllBinariesResponse
-	(*UpdateBinaryRequest)(nil),    // 6: gophkeeper.UpdateBinaryRequest
-	(*empty.Empty)(nil),            // 7: google.protobuf.Empty
-	(*RemoveDataRequest)(nil),      // 8: gophkeeper.RemoveDataRequest
+	(Compression)(0),               // 0: gophkeeper.Compression
+	(*Binary)(nil),                 // 1: gophkeeper.Binary
+	(*FileChunk)(nil),              // 2: gophkeeper.FileChunk
+	(*CreateUploadRequest)(nil),    // 3: gophkeeper.CreateUploadRequest
+	(*UploadRequest)(nil),          // 4: gophkeeper.UploadRequest
+	(*UploadStatus)(nil),           // 5: gophkeeper.UploadStatus
+	(*UploadChunk)(nil),            // 6: gophkeeper.UploadChunk
+	(*DownloadBinaryRequest)(nil),  // 7: gophkeeper.DownloadBinaryRequest
+	(*DownloadBinaryResponse)(nil), // 8: gophkeeper.DownloadBinaryResponse
+	(*GetAllBinariesResponse)(nil), // 9: gophkeeper.GetAllBinariesResponse
+	(*UpdateBinaryRequest)(nil),    // 10: gophkeeper.UpdateBinaryRequest
+	(*empty.Empty)(nil),            // 11: google.protobuf.Empty
+	(*RemoveDataRequest)(nil),      // 12: gophkeeper.RemoveDataRequest
 }
 var file_binary_proto_depIdxs = []int32{
-	1, // 0: gophkeeper.SaveBinaryRequest.chunk:type_name -> gophkeeper.FileChunk
-	1, // 1: gophkeeper.DownloadBinaryResponse.chunk:type_name -> gophkeeper.FileChunk
-	0, // 2: gophkeeper.GetAllBinariesResponse.result:type_name -> gophkeeper.Binary
-	2, // 3: gophkeeper.BinaryService.Upload:input_type -> gophkeeper.SaveBinaryRequest
-	3, // 4: gophkeeper.BinaryService.Download:input_type -> gophkeeper.DownloadBinaryRequest
-	7, // 5: gophkeeper.BinaryService.GetAll:input_type -> google.protobuf.Empty
-	6, // 6: gophkeeper.BinaryService.Update:input_type -> gophkeeper.UpdateBinaryRequest
-	8, // 7: gophkeeper.BinaryService.Remove:input_type -> gophkeeper.RemoveDataRequest
-	7, // 8: gophkeeper.BinaryService.Upload:output_type -> google.protobuf.Empty
-	4, // 9: gophkeeper.BinaryService.Download:output_type -> gophkeeper.DownloadBinaryResponse
-	5, // 10: gophkeeper.BinaryService.GetAll:output_type -> gophkeeper.GetAllBinariesResponse
-	7, // 11: gophkeeper.BinaryService.Update:output_type -> google.protobuf.Empty
-	7, // 12: gophkeeper.BinaryService.Remove:output_type -> google.protobuf.Empty
-	8, // [8:13] is the sub-list for method output_type
-	3, // [3:8] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	0,  // 0: gophkeeper.Binary.compression:type_name -> gophkeeper.Compression
+	0,  // 1: gophkeeper.CreateUploadRequest.compression:type_name -> gophkeeper.Compression
+	2,  // 2: gophkeeper.DownloadBinaryResponse.chunk:type_name -> gophkeeper.FileChunk
+	0,  // 3: gophkeeper.DownloadBinaryResponse.compression:type_name -> gophkeeper.Compression
+	1,  // 4: gophkeeper.GetAllBinariesResponse.result:type_name -> gophkeeper.Binary
+	3,  // 5: gophkeeper.BinaryService.CreateUpload:input_type -> gophkeeper.CreateUploadRequest
+	6,  // 6: gophkeeper.BinaryService.UploadChunks:input_type -> gophkeeper.UploadChunk
+	4,  // 7: gophkeeper.BinaryService.GetUpload:input_type -> gophkeeper.UploadRequest
+	4,  // 8: gophkeeper.BinaryService.CompleteUpload:input_type -> gophkeeper.UploadRequest
+	7,  // 9: gophkeeper.BinaryService.Download:input_type -> gophkeeper.DownloadBinaryRequest
+	11, // 10: gophkeeper.BinaryService.GetAll:input_type -> google.protobuf.Empty
+	10, // 11: gophkeeper.BinaryService.Update:input_type -> gophkeeper.UpdateBinaryRequest
+	12, // 12: gophkeeper.BinaryService.Remove:input_type -> gophkeeper.RemoveDataRequest
+	5,  // 13: gophkeeper.BinaryService.CreateUpload:output_type -> gophkeeper.UploadStatus
+	5,  // 14: gophkeeper.BinaryService.UploadChunks:output_type -> gophkeeper.UploadStatus
+	5,  // 15: gophkeeper.BinaryService.GetUpload:output_type -> gophkeeper.UploadStatus
+	11, // 16: gophkeeper.BinaryService.CompleteUpload:output_type -> google.protobuf.Empty
+	8,  // 17: gophkeeper.BinaryService.Download:output_type -> gophkeeper.DownloadBinaryResponse
+	9,  // 18: gophkeeper.BinaryService.GetAll:output_type -> gophkeeper.GetAllBinariesResponse
+	11, // 19: gophkeeper.BinaryService.Update:output_type -> google.protobuf.Empty
+	11, // 20: gophkeeper.BinaryService.Remove:output_type -> google.protobuf.Empty
+	13, // [13:21] is the sub-list for method output_type
+	5,  // [5:13] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_binary_proto_init() }
@@ -1059,13 +1839,14 @@ func file_binary_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_binary_proto_rawDesc), len(file_binary_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   7,
+			NumEnums:      1,
+			NumMessages:   10,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_binary_proto_goTypes,
 		DependencyIndexes: file_binary_proto_depIdxs,
+		EnumInfos:         file_binary_proto_enumTypes,
 		MessageInfos:      file_binary_proto_msgTypes,
 	}.Build()
 	File_binary_proto = out.File
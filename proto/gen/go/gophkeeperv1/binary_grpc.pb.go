@@ -20,18 +20,30 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	BinaryService_Upload_FullMethodName   = "/gophkeeper.BinaryService/Upload"
-	BinaryService_Download_FullMethodName = "/gophkeeper.BinaryService/Download"
-	BinaryService_GetAll_FullMethodName   = "/gophkeeper.BinaryService/GetAll"
-	BinaryService_Update_FullMethodName   = "/gophkeeper.BinaryService/Update"
-	BinaryService_Remove_FullMethodName   = "/gophkeeper.BinaryService/Remove"
+	BinaryService_CreateUpload_FullMethodName   = "/gophkeeper.BinaryService/CreateUpload"
+	BinaryService_UploadChunks_FullMethodName   = "/gophkeeper.BinaryService/UploadChunks"
+	BinaryService_GetUpload_FullMethodName      = "/gophkeeper.BinaryService/GetUpload"
+	BinaryService_CompleteUpload_FullMethodName = "/gophkeeper.BinaryService/CompleteUpload"
+	BinaryService_Download_FullMethodName       = "/gophkeeper.BinaryService/Download"
+	BinaryService_GetAll_FullMethodName         = "/gophkeeper.BinaryService/GetAll"
+	BinaryService_Update_FullMethodName         = "/gophkeeper.BinaryService/Update"
+	BinaryService_Remove_FullMethodName         = "/gophkeeper.BinaryService/Remove"
 )
 
 // BinaryServiceClient is the client API for BinaryService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type BinaryServiceClient interface {
-	Upload(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[SaveBinaryRequest, empty.Empty], error)
+	// CreateUpload начинает загрузку файла.
+	CreateUpload(ctx context.Context, in *CreateUploadRequest, opts ...grpc.CallOption) (*UploadStatus, error)
+	// UploadChunks дописывает чанки к загрузке. Смещение первого чанка должно
+	// совпадать с сохраненным на сервере, чанки идут подряд.
+	UploadChunks(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UploadChunk, UploadStatus], error)
+	// GetUpload возвращает сохраненное смещение загрузки.
+	GetUpload(ctx context.Context, in *UploadRequest, opts ...grpc.CallOption) (*UploadStatus, error)
+	// CompleteUpload проверяет размер и SHA-256 содержимого и сохраняет файл.
+	CompleteUpload(ctx context.Context, in *UploadRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// Download отдает содержимое файла с указанного смещения.
 	Download(ctx context.Context, in *DownloadBinaryRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DownloadBinaryResponse], error)
 	GetAll(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*GetAllBinariesResponse, error)
 	Update(ctx context.Context, in *UpdateBinaryRequest, opts ...grpc.CallOption) (*empty.Empty, error)
@@ -46,18 +58,48 @@ func NewBinaryServiceClient(cc grpc.ClientConnInterface) BinaryServiceClient {
 	return &binaryServiceClient{cc}
 }
 
-func (c *binaryServiceClient) Upload(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[SaveBinaryRequest, empty.Empty], error) {
+func (c *binaryServiceClient) CreateUpload(ctx context.Context, in *CreateUploadRequest, opts ...grpc.CallOption) (*UploadStatus, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &BinaryService_ServiceDesc.Streams[0], BinaryService_Upload_FullMethodName, cOpts...)
+	out := new(UploadStatus)
+	err := c.cc.Invoke(ctx, BinaryService_CreateUpload_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &grpc.GenericClientStream[SaveBinaryRequest, empty.Empty]{ClientStream: stream}
+	return out, nil
+}
+
+func (c *binaryServiceClient) UploadChunks(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UploadChunk, UploadStatus], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &BinaryService_ServiceDesc.Streams[0], BinaryService_UploadChunks_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[UploadChunk, UploadStatus]{ClientStream: stream}
 	return x, nil
 }
 
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
-type BinaryService_UploadClient = grpc.ClientStreamingClient[SaveBinaryRequest, empty.Empty]
+type BinaryService_UploadChunksClient = grpc.ClientStreamingClient[UploadChunk, UploadStatus]
+
+func (c *binaryServiceClient) GetUpload(ctx context.Context, in *UploadRequest, opts ...grpc.CallOption) (*UploadStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UploadStatus)
+	err := c.cc.Invoke(ctx, BinaryService_GetUpload_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *binaryServiceClient) CompleteUpload(ctx context.Context, in *UploadRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, BinaryService_CompleteUpload_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
 
 func (c *binaryServiceClient) Download(ctx context.Context, in *DownloadBinaryRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DownloadBinaryResponse], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
@@ -112,7 +154,16 @@ func (c *binaryServiceClient) Remove(ctx context.Context, in *RemoveDataRequest,
 // All implementations must embed UnimplementedBinaryServiceServer
 // for forward compatibility.
 type BinaryServiceServer interface {
-	Upload(grpc.ClientStreamingServer[SaveBinaryRequest, empty.Empty]) error
+	// CreateUpload начинает загрузку файла.
+	CreateUpload(context.Context, *CreateUploadRequest) (*UploadStatus, error)
+	// UploadChunks дописывает чанки к загрузке. Смещение первого чанка должно
+	// совпадать с сохраненным на сервере, чанки идут подряд.
+	UploadChunks(grpc.ClientStreamingServer[UploadChunk, UploadStatus]) error
+	// GetUpload возвращает сохраненное смещение загрузки.
+	GetUpload(context.Context, *UploadRequest) (*UploadStatus, error)
+	// CompleteUpload проверяет размер и SHA-256 содержимого и сохраняет файл.
+	CompleteUpload(context.Context, *UploadRequest) (*empty.Empty, error)
+	// Download отдает содержимое файла с указанного смещения.
 	Download(*DownloadBinaryRequest, grpc.ServerStreamingServer[DownloadBinaryResponse]) error
 	GetAll(context.Context, *empty.Empty) (*GetAllBinariesResponse, error)
 	Update(context.Context, *UpdateBinaryRequest) (*empty.Empty, error)
@@ -127,8 +178,17 @@ type BinaryServiceServer interface {
 // pointer dereference when methods are called.
 type UnimplementedBinaryServiceServer struct{}
 
-func (UnimplementedBinaryServiceServer) Upload(grpc.ClientStreamingServer[SaveBinaryRequest, empty.Empty]) error {
-	return status.Errorf(codes.Unimplemented, "method Upload not implemented")
+func (UnimplementedBinaryServiceServer) CreateUpload(context.Context, *CreateUploadRequest) (*UploadStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateUpload not implemented")
+}
+func (UnimplementedBinaryServiceServer) UploadChunks(grpc.ClientStreamingServer[UploadChunk, UploadStatus]) error {
+	return status.Errorf(codes.Unimplemented, "method UploadChunks not implemented")
+}
+func (UnimplementedBinaryServiceServer) GetUpload(context.Context, *UploadRequest) (*UploadStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUpload not implemented")
+}
+func (UnimplementedBinaryServiceServer) CompleteUpload(context.Context, *UploadRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CompleteUpload not implemented")
 }
 func (UnimplementedBinaryServiceServer) Download(*DownloadBinaryRequest, grpc.ServerStreamingServer[DownloadBinaryResponse]) error {
 	return status.Errorf(codes.Unimplemented, "method Download not implemented")
@@ -163,12 +223,66 @@ func RegisterBinaryServiceServer(s grpc.ServiceRegistrar, srv BinaryServiceServe
 	s.RegisterService(&BinaryService_ServiceDesc, srv)
 }
 
-func _BinaryService_Upload_Handler(srv interface{}, stream grpc.ServerStream) error {
-	return srv.(BinaryServiceServer).Upload(&grpc.GenericServerStream[SaveBinaryRequest, empty.Empty]{ServerStream: stream})
+func _BinaryService_CreateUpload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateUploadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BinaryServiceServer).CreateUpload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BinaryService_CreateUpload_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BinaryServiceServer).CreateUpload(ctx, req.(*CreateUploadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BinaryService_UploadChunks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(BinaryServiceServer).UploadChunks(&grpc.GenericServerStream[UploadChunk, UploadStatus]{ServerStream: stream})
 }
 
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
-type BinaryService_UploadServer = grpc.ClientStreamingServer[SaveBinaryRequest, empty.Empty]
+type BinaryService_UploadChunksServer = grpc.ClientStreamingServer[UploadChunk, UploadStatus]
+
+func _BinaryService_GetUpload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UploadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BinaryServiceServer).GetUpload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BinaryService_GetUpload_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BinaryServiceServer).GetUpload(ctx, req.(*UploadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BinaryService_CompleteUpload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UploadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BinaryServiceServer).CompleteUpload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BinaryService_CompleteUpload_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BinaryServiceServer).CompleteUpload(ctx, req.(*UploadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
 
 func _BinaryService_Download_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(DownloadBinaryRequest)
@@ -242,6 +356,18 @@ var BinaryService_ServiceDesc = grpc.ServiceDesc{
 	ServiceName: "gophkeeper.BinaryService",
 	HandlerType: (*BinaryServiceServer)(nil),
 	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateUpload",
+			Handler:    _BinaryService_CreateUpload_Handler,
+		},
+		{
+			MethodName: "GetUpload",
+			Handler:    _BinaryService_GetUpload_Handler,
+		},
+		{
+			MethodName: "CompleteUpload",
+			Handler:    _BinaryService_CompleteUpload_Handler,
+		},
 		{
 			MethodName: "GetAll",
 			Handler:    _BinaryService_GetAll_Handler,
@@ -257,8 +383,8 @@ var BinaryService_ServiceDesc = grpc.ServiceDesc{
 	},
 	Streams: []grpc.StreamDesc{
 		{
-			StreamName:    "Upload",
-			Handler:       _BinaryService_Upload_Handler,
+			StreamName:    "UploadChunks",
+			Handler:       _BinaryService_UploadChunks_Handler,
 			ClientStreams: true,
 		},
 		{
@@ -30,6 +30,7 @@ type Card struct {
 	xxx_hidden_Cvv         *string                `protobuf:"bytes,5,opt,name=cvv"`
 	xxx_hidden_Cardholder  *string                `protobuf:"bytes,6,opt,name=cardholder"`
 	xxx_hidden_Notes       *string                `protobuf:"bytes,7,opt,name=notes"`
+	xxx_hidden_Version     int64                  `protobuf:"varint,8,opt,name=version"`
 	XXX_raceDetectHookData protoimpl.RaceDetectHookData
 	XXX_presence           [1]uint32
 	unknownFields          protoimpl.UnknownFields
@@ -128,39 +129,51 @@ func (x *Card) GetNotes() string {
 	return ""
 }
 
+func (x *Card) GetVersion() int64 {
+	if x != nil {
+		return x.xxx_hidden_Version
+	}
+	return 0
+}
+
 func (x *Card) SetId(v int64) {
 	x.xxx_hidden_Id = v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 7)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 8)
 }
 
 func (x *Card) SetName(v string) {
 	x.xxx_hidden_Name = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 7)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 8)
 }
 
 func (x *Card) SetNumber(v string) {
 	x.xxx_hidden_Number = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 7)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 8)
 }
 
 func (x *Card) SetExpDate(v string) {
 	x.xxx_hidden_ExpDate = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 7)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 8)
 }
 
 func (x *Card) SetCvv(v string) {
 	x.xxx_hidden_Cvv = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 7)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 8)
 }
 
 func (x *Card) SetCardholder(v string) {
 	x.xxx_hidden_Cardholder = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 7)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 8)
 }
 
 func (x *Card) SetNotes(v string) {
 	x.xxx_hidden_Notes = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 6, 7)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 6, 8)
+}
+
+func (x *Card) SetVersion(v int64) {
+	x.xxx_hidden_Version = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 7, 8)
 }
 
 func (x *Card) HasId() bool {
@@ -212,6 +225,13 @@ func (x *Card) HasNotes() bool {
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 6)
 }
 
+func (x *Card) HasVersion() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 7)
+}
+
 func (x *Card) ClearId() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
 	x.xxx_hidden_Id = 0
@@ -247,6 +267,11 @@ func (x *Card) ClearNotes() {
 	x.xxx_hidden_Notes = nil
 }
 
+func (x *Card) ClearVersion() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 7)
+	x.xxx_hidden_Version = 0
+}
+
 type Card_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
@@ -257,6 +282,7 @@ type Card_builder struct {
 	Cvv        *string
 	Cardholder *string
 	Notes      *string
+	Version    *int64
 }
 
 func (b0 Card_builder) Build() *Card {
@@ -264,33 +290,37 @@ func (b0 Card_builder) Build() *Card {
 	b, x := &b0, m0
 	_, _ = b, x
 	if b.Id != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 7)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 8)
 		x.xxx_hidden_Id = *b.Id
 	}
 	if b.Name != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 7)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 8)
 		x.xxx_hidden_Name = b.Name
 	}
 	if b.Number != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 7)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 8)
 		x.xxx_hidden_Number = b.Number
 	}
 	if b.ExpDate != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 7)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 8)
 		x.xxx_hidden_ExpDate = b.ExpDate
 	}
 	if b.Cvv != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 7)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 8)
 		x.xxx_hidden_Cvv = b.Cvv
 	}
 	if b.Cardholder != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 7)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 8)
 		x.xxx_hidden_Cardholder = b.Cardholder
 	}
 	if b.Notes != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 6, 7)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 6, 8)
 		x.xxx_hidden_Notes = b.Notes
 	}
+	if b.Version != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 7, 8)
+		x.xxx_hidden_Version = *b.Version
+	}
 	return m0
 }
 
@@ -360,7 +390,7 @@ const file_card_proto_rawDesc = "" +
 	"\n" +
 	"card.proto\x12\n" +
 	"gophkeeper\x1a\x1bgoogle/protobuf/empty.proto\x1a\n" +
-	"data.proto\"\xa5\x01\n" +
+	"data.proto\"\xbf\x01\n" +
 	"\x04Card\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
@@ -370,7 +400,8 @@ const file_card_proto_rawDesc = "" +
 	"\n" +
 	"cardholder\x18\x06 \x01(\tR\n" +
 	"cardholder\x12\x14\n" +
-	"\x05notes\x18\a \x01(\tR\x05notes\"?\n" +
+	"\x05notes\x18\a \x01(\tR\x05notes\x12\x18\n" +
+	"\aversion\x18\b \x01(\x03R\aversion\"?\n" +
 	"\x13GetAllCardsResponse\x12(\n" +
 	"\x06result\x18\x01 \x03(\v2\x10.gophkeeper.CardR\x06result2\xf7\x01\n" +
 	"\vCardService\x120\n" +
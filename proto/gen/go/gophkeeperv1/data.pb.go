@@ -23,6 +23,7 @@ const (
 type RemoveDataRequest struct {
 	state                  protoimpl.MessageState `protogen:"opaque.v1"`
 	xxx_hidden_Id          int64                  `protobuf:"varint,1,opt,name=id"`
+	xxx_hidden_Version     int64                  `protobuf:"varint,2,opt,name=version"`
 	XXX_raceDetectHookData protoimpl.RaceDetectHookData
 	XXX_presence           [1]uint32
 	unknownFields          protoimpl.UnknownFields
@@ -61,9 +62,21 @@ func (x *RemoveDataRequest) GetId() int64 {
 	return 0
 }
 
+func (x *RemoveDataRequest) GetVersion() int64 {
+	if x != nil {
+		return x.xxx_hidden_Version
+	}
+	return 0
+}
+
 func (x *RemoveDataRequest) SetId(v int64) {
 	x.xxx_hidden_Id = v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 1)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+}
+
+func (x *RemoveDataRequest) SetVersion(v int64) {
+	x.xxx_hidden_Version = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
 }
 
 func (x *RemoveDataRequest) HasId() bool {
@@ -73,15 +86,28 @@ func (x *RemoveDataRequest) HasId() bool {
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
 }
 
+func (x *RemoveDataRequest) HasVersion() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
 func (x *RemoveDataRequest) ClearId() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
 	x.xxx_hidden_Id = 0
 }
 
+func (x *RemoveDataRequest) ClearVersion() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Version = 0
+}
+
 type RemoveDataRequest_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
-	Id *int64
+	Id      *int64
+	Version *int64
 }
 
 func (b0 RemoveDataRequest_builder) Build() *RemoveDataRequest {
@@ -89,9 +115,90 @@ func (b0 RemoveDataRequest_builder) Build() *RemoveDataRequest {
 	b, x := &b0, m0
 	_, _ = b, x
 	if b.Id != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 1)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
 		x.xxx_hidden_Id = *b.Id
 	}
+	if b.Version != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		x.xxx_hidden_Version = *b.Version
+	}
+	return m0
+}
+
+// Детали ошибки Aborted: данные были изменены другим клиентом.
+type VersionConflict struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Version     int64                  `protobuf:"varint,1,opt,name=version"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *VersionConflict) Reset() {
+	*x = VersionConflict{}
+	mi := &file_data_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VersionConflict) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VersionConflict) ProtoMessage() {}
+
+func (x *VersionConflict) ProtoReflect() protoreflect.Message {
+	mi := &file_data_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *VersionConflict) GetVersion() int64 {
+	if x != nil {
+		return x.xxx_hidden_Version
+	}
+	return 0
+}
+
+func (x *VersionConflict) SetVersion(v int64) {
+	x.xxx_hidden_Version = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 1)
+}
+
+func (x *VersionConflict) HasVersion() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *VersionConflict) ClearVersion() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Version = 0
+}
+
+type VersionConflict_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Текущая версия данных на сервере.
+	Version *int64
+}
+
+func (b0 VersionConflict_builder) Build() *VersionConflict {
+	m0 := &VersionConflict{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Version != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 1)
+		x.xxx_hidden_Version = *b.Version
+	}
 	return m0
 }
 
@@ -101,13 +208,17 @@ const file_data_proto_rawDesc = "" +
 	"\n" +
 	"\n" +
 	"data.proto\x12\n" +
-	"gophkeeper\"#\n" +
+	"gophkeeper\"=\n" +
 	"\x11RemoveDataRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x03R\x02idB\x1cZ\x1agophkeeper.v1;gophkeeperv1b\beditionsp\xe8\a"
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x03R\aversion\"+\n" +
+	"\x0fVersionConflict\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\x03R\aversionB\x1cZ\x1agophkeeper.v1;gophkeeperv1b\beditionsp\xe8\a"
 
-var file_data_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_data_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
 var file_data_proto_goTypes = []any{
 	(*RemoveDataRequest)(nil), // 0: gophkeeper.RemoveDataRequest
+	(*VersionConflict)(nil),   // 1: gophkeeper.VersionConflict
 }
 var file_data_proto_depIdxs = []int32{
 	0, // [0:0] is the sub-list for method output_type
@@ -128,7 +239,7 @@ func file_data_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_data_proto_rawDesc), len(file_data_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   1,
+			NumMessages:   2,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
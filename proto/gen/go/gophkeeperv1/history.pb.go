@@ -0,0 +1,826 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        v6.30.2
+// source: history.proto
+
+package gophkeeperv1
+
+import (
+	empty "github.com/golang/protobuf/ptypes/empty"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ListHistoryRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Kind        DataKind               `protobuf:"varint,1,opt,name=kind,enum=gophkeeper.DataKind"`
+	xxx_hidden_Id          int64                  `protobuf:"varint,2,opt,name=id"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *ListHistoryRequest) Reset() {
+	*x = ListHistoryRequest{}
+	mi := &file_history_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListHistoryRequest) ProtoMessage() {}
+
+func (x *ListHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_history_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ListHistoryRequest) GetKind() DataKind {
+	if x != nil {
+		if protoimpl.X.Present(&(x.XXX_presence[0]), 0) {
+			return x.xxx_hidden_Kind
+		}
+	}
+	return DataKind_DATA_KIND_UNSPECIFIED
+}
+
+func (x *ListHistoryRequest) GetId() int64 {
+	if x != nil {
+		return x.xxx_hidden_Id
+	}
+	return 0
+}
+
+func (x *ListHistoryRequest) SetKind(v DataKind) {
+	x.xxx_hidden_Kind = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+}
+
+func (x *ListHistoryRequest) SetId(v int64) {
+	x.xxx_hidden_Id = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
+}
+
+func (x *ListHistoryRequest) HasKind() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *ListHistoryRequest) HasId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *ListHistoryRequest) ClearKind() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Kind = DataKind_DATA_KIND_UNSPECIFIED
+}
+
+func (x *ListHistoryRequest) ClearId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Id = 0
+}
+
+type ListHistoryRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Kind *DataKind
+	Id   *int64
+}
+
+func (b0 ListHistoryRequest_builder) Build() *ListHistoryRequest {
+	m0 := &ListHistoryRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Kind != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		x.xxx_hidden_Kind = *b.Kind
+	}
+	if b.Id != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		x.xxx_hidden_Id = *b.Id
+	}
+	return m0
+}
+
+// Прежняя версия записи. Данные содержат версию, которую заменило
+// изменение или удаление.
+type HistoryVersion struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Version     int64                  `protobuf:"varint,1,opt,name=version"`
+	xxx_hidden_Removed     bool                   `protobuf:"varint,2,opt,name=removed"`
+	xxx_hidden_CreatedAt   *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt"`
+	xxx_hidden_Data        isHistoryVersion_Data  `protobuf_oneof:"data"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *HistoryVersion) Reset() {
+	*x = HistoryVersion{}
+	mi := &file_history_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HistoryVersion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HistoryVersion) ProtoMessage() {}
+
+func (x *HistoryVersion) ProtoReflect() protoreflect.Message {
+	mi := &file_history_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *HistoryVersion) GetVersion() int64 {
+	if x != nil {
+		return x.xxx_hidden_Version
+	}
+	return 0
+}
+
+func (x *HistoryVersion) GetRemoved() bool {
+	if x != nil {
+		return x.xxx_hidden_Removed
+	}
+	return false
+}
+
+func (x *HistoryVersion) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_CreatedAt
+	}
+	return nil
+}
+
+func (x *HistoryVersion) GetLogin() *Login {
+	if x != nil {
+		if x, ok := x.xxx_hidden_Data.(*historyVersion_Login); ok {
+			return x.Login
+		}
+	}
+	return nil
+}
+
+func (x *HistoryVersion) GetNote() *Note {
+	if x != nil {
+		if x, ok := x.xxx_hidden_Data.(*historyVersion_Note); ok {
+			return x.Note
+		}
+	}
+	return nil
+}
+
+func (x *HistoryVersion) GetBinary() *Binary {
+	if x != nil {
+		if x, ok := x.xxx_hidden_Data.(*historyVersion_Binary); ok {
+			return x.Binary
+		}
+	}
+	return nil
+}
+
+func (x *HistoryVersion) GetCard() *Card {
+	if x != nil {
+		if x, ok := x.xxx_hidden_Data.(*historyVersion_Card); ok {
+			return x.Card
+		}
+	}
+	return nil
+}
+
+func (x *HistoryVersion) GetOtp() *OTP {
+	if x != nil {
+		if x, ok := x.xxx_hidden_Data.(*historyVersion_Otp); ok {
+			return x.Otp
+		}
+	}
+	return nil
+}
+
+func (x *HistoryVersion) SetVersion(v int64) {
+	x.xxx_hidden_Version = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 4)
+}
+
+func (x *HistoryVersion) SetRemoved(v bool) {
+	x.xxx_hidden_Removed = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 4)
+}
+
+func (x *HistoryVersion) SetCreatedAt(v *timestamppb.Timestamp) {
+	x.xxx_hidden_CreatedAt = v
+}
+
+func (x *HistoryVersion) SetLogin(v *Login) {
+	if v == nil {
+		x.xxx_hidden_Data = nil
+		return
+	}
+	x.xxx_hidden_Data = &historyVersion_Login{v}
+}
+
+func (x *HistoryVersion) SetNote(v *Note) {
+	if v == nil {
+		x.xxx_hidden_Data = nil
+		return
+	}
+	x.xxx_hidden_Data = &historyVersion_Note{v}
+}
+
+func (x *HistoryVersion) SetBinary(v *Binary) {
+	if v == nil {
+		x.xxx_hidden_Data = nil
+		return
+	}
+	x.xxx_hidden_Data = &historyVersion_Binary{v}
+}
+
+func (x *HistoryVersion) SetCard(v *Card) {
+	if v == nil {
+		x.xxx_hidden_Data = nil
+		return
+	}
+	x.xxx_hidden_Data = &historyVersion_Card{v}
+}
+
+func (x *HistoryVersion) SetOtp(v *OTP) {
+	if v == nil {
+		x.xxx_hidden_Data = nil
+		return
+	}
+	x.xxx_hidden_Data = &historyVersion_Otp{v}
+}
+
+func (x *HistoryVersion) HasVersion() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *HistoryVersion) HasRemoved() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *HistoryVersion) HasCreatedAt() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_CreatedAt != nil
+}
+
+func (x *HistoryVersion) HasData() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Data != nil
+}
+
+func (x *HistoryVersion) HasLogin() bool {
+	if x == nil {
+		return false
+	}
+	_, ok := x.xxx_hidden_Data.(*historyVersion_Login)
+	return ok
+}
+
+func (x *HistoryVersion) HasNote() bool {
+	if x == nil {
+		return false
+	}
+	_, ok := x.xxx_hidden_Data.(*historyVersion_Note)
+	return ok
+}
+
+func (x *HistoryVersion) HasBinary() bool {
+	if x == nil {
+		return false
+	}
+	_, ok := x.xxx_hidden_Data.(*historyVersion_Binary)
+	return ok
+}
+
+func (x *HistoryVersion) HasCard() bool {
+	if x == nil {
+		return false
+	}
+	_, ok := x.xxx_hidden_Data.(*historyVersion_Card)
+	return ok
+}
+
+func (x *HistoryVersion) HasOtp() bool {
+	if x == nil {
+		return false
+	}
+	_, ok := x.xxx_hidden_Data.(*historyVersion_Otp)
+	return ok
+}
+
+func (x *HistoryVersion) ClearVersion() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Version = 0
+}
+
+func (x *HistoryVersion) ClearRemoved() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Removed = false
+}
+
+func (x *HistoryVersion) ClearCreatedAt() {
+	x.xxx_hidden_CreatedAt = nil
+}
+
+func (x *HistoryVersion) ClearData() {
+	x.xxx_hidden_Data = nil
+}
+
+func (x *HistoryVersion) ClearLogin() {
+	if _, ok := x.xxx_hidden_Data.(*historyVersion_Login); ok {
+		x.xxx_hidden_Data = nil
+	}
+}
+
+func (x *HistoryVersion) ClearNote() {
+	if _, ok := x.xxx_hidden_Data.(*historyVersion_Note); ok {
+		x.xxx_hidden_Data = nil
+	}
+}
+
+func (x *HistoryVersion) ClearBinary() {
+	if _, ok := x.xxx_hidden_Data.(*historyVersion_Binary); ok {
+		x.xxx_hidden_Data = nil
+	}
+}
+
+func (x *HistoryVersion) ClearCard() {
+	if _, ok := x.xxx_hidden_Data.(*historyVersion_Card); ok {
+		x.xxx_hidden_Data = nil
+	}
+}
+
+func (x *HistoryVersion) ClearOtp() {
+	if _, ok := x.xxx_hidden_Data.(*historyVersion_Otp); ok {
+		x.xxx_hidden_Data = nil
+	}
+}
+
+const HistoryVersion_Data_not_set_case case_HistoryVersion_Data = 0
+const HistoryVersion_Login_case case_HistoryVersion_Data = 4
+const HistoryVersion_Note_case case_HistoryVersion_Data = 5
+const HistoryVersion_Binary_case case_HistoryVersion_Data = 6
+const HistoryVersion_Card_case case_HistoryVersion_Data = 7
+const HistoryVersion_Otp_case case_HistoryVersion_Data = 8
+
+func (x *HistoryVersion) WhichData() case_HistoryVersion_Data {
+	if x == nil {
+		return HistoryVersion_Data_not_set_case
+	}
+	switch x.xxx_hidden_Data.(type) {
+	case *historyVersion_Login:
+		return HistoryVersion_Login_case
+	case *historyVersion_Note:
+		return HistoryVersion_Note_case
+	case *historyVersion_Binary:
+		return HistoryVersion_Binary_case
+	case *historyVersion_Card:
+		return HistoryVersion_Card_case
+	case *historyVersion_Otp:
+		return HistoryVersion_Otp_case
+	default:
+		return HistoryVersion_Data_not_set_case
+	}
+}
+
+type HistoryVersion_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Version *int64
+	// Версия удалена вместе с записью.
+	Removed   *bool
+	CreatedAt *timestamppb.Timestamp
+	// Fields of oneof xxx_hidden_Data:
+	Login  *Login
+	Note   *Note
+	Binary *Binary
+	Card   *Card
+	Otp    *OTP
+	// -- end of xxx_hidden_Data
+}
+
+func (b0 HistoryVersion_builder) Build() *HistoryVersion {
+	m0 := &HistoryVersion{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Version != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 4)
+		x.xxx_hidden_Version = *b.Version
+	}
+	if b.Removed != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 4)
+		x.xxx_hidden_Removed = *b.Removed
+	}
+	x.xxx_hidden_CreatedAt = b.CreatedAt
+	if b.Login != nil {
+		x.xxx_hidden_Data = &historyVersion_Login{b.Login}
+	}
+	if b.Note != nil {
+		x.xxx_hidden_Data = &historyVersion_Note{b.Note}
+	}
+	if b.Binary != nil {
+		x.xxx_hidden_Data = &historyVersion_Binary{b.Binary}
+	}
+	if b.Card != nil {
+		x.xxx_hidden_Data = &historyVersion_Card{b.Card}
+	}
+	if b.Otp != nil {
+		x.xxx_hidden_Data = &historyVersion_Otp{b.Otp}
+	}
+	return m0
+}
+
+type case_HistoryVersion_Data protoreflect.FieldNumber
+
+func (x case_HistoryVersion_Data) String() string {
+	md := file_history_proto_msgTypes[1].Descriptor()
+	if x == 0 {
+		return "not set"
+	}
+	return protoimpl.X.MessageFieldStringOf(md, protoreflect.FieldNumber(x))
+}
+
+type isHistoryVersion_Data interface {
+	isHistoryVersion_Data()
+}
+
+type historyVersion_Login struct {
+	Login *Login `protobuf:"bytes,4,opt,name=login,oneof"`
+}
+
+type historyVersion_Note struct {
+	Note *Note `protobuf:"bytes,5,opt,name=note,oneof"`
+}
+
+type historyVersion_Binary struct {
+	Binary *Binary `protobuf:"bytes,6,opt,name=binary,oneof"`
+}
+
+type historyVersion_Card struct {
+	Card *Card `protobuf:"bytes,7,opt,name=card,oneof"`
+}
+
+type historyVersion_Otp struct {
+	Otp *OTP `protobuf:"bytes,8,opt,name=otp,oneof"`
+}
+
+func (*historyVersion_Login) isHistoryVersion_Data() {}
+
+func (*historyVersion_Note) isHistoryVersion_Data() {}
+
+func (*historyVersion_Binary) isHistoryVersion_Data() {}
+
+func (*historyVersion_Card) isHistoryVersion_Data() {}
+
+func (*historyVersion_Otp) isHistoryVersion_Data() {}
+
+type ListHistoryResponse struct {
+	state               protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Versions *[]*HistoryVersion     `protobuf:"bytes,1,rep,name=versions"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *ListHistoryResponse) Reset() {
+	*x = ListHistoryResponse{}
+	mi := &file_history_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListHistoryResponse) ProtoMessage() {}
+
+func (x *ListHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_history_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ListHistoryResponse) GetVersions() []*HistoryVersion {
+	if x != nil {
+		if x.xxx_hidden_Versions != nil {
+			return *x.xxx_hidden_Versions
+		}
+	}
+	return nil
+}
+
+func (x *ListHistoryResponse) SetVersions(v []*HistoryVersion) {
+	x.xxx_hidden_Versions = &v
+}
+
+type ListHistoryResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Версии от новых к старым.
+	Versions []*HistoryVersion
+}
+
+func (b0 ListHistoryResponse_builder) Build() *ListHistoryResponse {
+	m0 := &ListHistoryResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Versions = &b.Versions
+	return m0
+}
+
+type RestoreVersionRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Kind        DataKind               `protobuf:"varint,1,opt,name=kind,enum=gophkeeper.DataKind"`
+	xxx_hidden_Id          int64                  `protobuf:"varint,2,opt,name=id"`
+	xxx_hidden_Version     int64                  `protobuf:"varint,3,opt,name=version"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *RestoreVersionRequest) Reset() {
+	*x = RestoreVersionRequest{}
+	mi := &file_history_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreVersionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreVersionRequest) ProtoMessage() {}
+
+func (x *RestoreVersionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_history_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *RestoreVersionRequest) GetKind() DataKind {
+	if x != nil {
+		if protoimpl.X.Present(&(x.XXX_presence[0]), 0) {
+			return x.xxx_hidden_Kind
+		}
+	}
+	return DataKind_DATA_KIND_UNSPECIFIED
+}
+
+func (x *RestoreVersionRequest) GetId() int64 {
+	if x != nil {
+		return x.xxx_hidden_Id
+	}
+	return 0
+}
+
+func (x *RestoreVersionRequest) GetVersion() int64 {
+	if x != nil {
+		return x.xxx_hidden_Version
+	}
+	return 0
+}
+
+func (x *RestoreVersionRequest) SetKind(v DataKind) {
+	x.xxx_hidden_Kind = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 3)
+}
+
+func (x *RestoreVersionRequest) SetId(v int64) {
+	x.xxx_hidden_Id = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 3)
+}
+
+func (x *RestoreVersionRequest) SetVersion(v int64) {
+	x.xxx_hidden_Version = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 3)
+}
+
+func (x *RestoreVersionRequest) HasKind() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *RestoreVersionRequest) HasId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *RestoreVersionRequest) HasVersion() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *RestoreVersionRequest) ClearKind() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Kind = DataKind_DATA_KIND_UNSPECIFIED
+}
+
+func (x *RestoreVersionRequest) ClearId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Id = 0
+}
+
+func (x *RestoreVersionRequest) ClearVersion() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Version = 0
+}
+
+type RestoreVersionRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Kind    *DataKind
+	Id      *int64
+	Version *int64
+}
+
+func (b0 RestoreVersionRequest_builder) Build() *RestoreVersionRequest {
+	m0 := &RestoreVersionRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Kind != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 3)
+		x.xxx_hidden_Kind = *b.Kind
+	}
+	if b.Id != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 3)
+		x.xxx_hidden_Id = *b.Id
+	}
+	if b.Version != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 3)
+		x.xxx_hidden_Version = *b.Version
+	}
+	return m0
+}
+
+var File_history_proto protoreflect.FileDescriptor
+
+const file_history_proto_rawDesc = "" +
+	"\n" +
+	"\rhistory.proto\x12\n" +
+	"gophkeeper\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\fbinary.proto\x1a\n" +
+	"card.proto\x1a\vlogin.proto\x1a\n" +
+	"note.proto\x1a\totp.proto\x1a\n" +
+	"sync.proto\"N\n" +
+	"\x12ListHistoryRequest\x12(\n" +
+	"\x04kind\x18\x01 \x01(\x0e2\x14.gophkeeper.DataKindR\x04kind\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\x03R\x02id\"\xd5\x02\n" +
+	"\x0eHistoryVersion\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\x03R\aversion\x12\x18\n" +
+	"\aremoved\x18\x02 \x01(\bR\aremoved\x129\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12)\n" +
+	"\x05login\x18\x04 \x01(\v2\x11.gophkeeper.LoginH\x00R\x05login\x12&\n" +
+	"\x04note\x18\x05 \x01(\v2\x10.gophkeeper.NoteH\x00R\x04note\x12,\n" +
+	"\x06binary\x18\x06 \x01(\v2\x12.gophkeeper.BinaryH\x00R\x06binary\x12&\n" +
+	"\x04card\x18\a \x01(\v2\x10.gophkeeper.CardH\x00R\x04card\x12#\n" +
+	"\x03otp\x18\b \x01(\v2\x0f.gophkeeper.OTPH\x00R\x03otpB\x06\n" +
+	"\x04data\"M\n" +
+	"\x13ListHistoryResponse\x126\n" +
+	"\bversions\x18\x01 \x03(\v2\x1a.gophkeeper.HistoryVersionR\bversions\"k\n" +
+	"\x15RestoreVersionRequest\x12(\n" +
+	"\x04kind\x18\x01 \x01(\x0e2\x14.gophkeeper.DataKindR\x04kind\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\x03R\x02id\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\x03R\aversion2\xad\x01\n" +
+	"\x0eHistoryService\x12N\n" +
+	"\vListHistory\x12\x1e.gophkeeper.ListHistoryRequest\x1a\x1f.gophkeeper.ListHistoryResponse\x12K\n" +
+	"\x0eRestoreVersion\x12!.gophkeeper.RestoreVersionRequest\x1a\x16.google.protobuf.EmptyB\x1cZ\x1agophkeeper.v1;gophkeeperv1b\beditionsp\xe8\a"
+
+var file_history_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_history_proto_goTypes = []any{
+	(*ListHistoryRequest)(nil),    // 0: gophkeeper.ListHistoryRequest
+	(*HistoryVersion)(nil),        // 1: gophkeeper.HistoryVersion
+	(*ListHistoryResponse)(nil),   // 2: gophkeeper.ListHistoryResponse
+	(*RestoreVersionRequest)(nil), // 3: gophkeeper.RestoreVersionRequest
+	(DataKind)(0),                 // 4: gophkeeper.DataKind
+	(*timestamppb.Timestamp)(nil), // 5: google.protobuf.Timestamp
+	(*Login)(nil),                 // 6: gophkeeper.Login
+	(*Note)(nil),                  // 7: gophkeeper.Note
+	(*Binary)(nil),                // 8: gophkeeper.Binary
+	(*Card)(nil),                  // 9: gophkeeper.Card
+	(*OTP)(nil),                   // 10: gophkeeper.OTP
+	(*empty.Empty)(nil),           // 11: google.protobuf.Empty
+}
+var file_history_proto_depIdxs = []int32{
+	4,  // 0: gophkeeper.ListHistoryRequest.kind:type_name -> gophkeeper.DataKind
+	5,  // 1: gophkeeper.HistoryVersion.created_at:type_name -> google.protobuf.Timestamp
+	6,  // 2: gophkeeper.HistoryVersion.login:type_name -> gophkeeper.Login
+	7,  // 3: gophkeeper.HistoryVersion.note:type_name -> gophkeeper.Note
+	8,  // 4: gophkeeper.HistoryVersion.binary:type_name -> gophkeeper.Binary
+	9,  // 5: gophkeeper.HistoryVersion.card:type_name -> gophkeeper.Card
+	10, // 6: gophkeeper.HistoryVersion.otp:type_name -> gophkeeper.OTP
+	1,  // 7: gophkeeper.ListHistoryResponse.versions:type_name -> gophkeeper.HistoryVersion
+	4,  // 8: gophkeeper.RestoreVersionRequest.kind:type_name -> gophkeeper.DataKind
+	0,  // 9: gophkeeper.HistoryService.ListHistory:input_type -> gophkeeper.ListHistoryRequest
+	3,  // 10: gophkeeper.HistoryService.RestoreVersion:input_type -> gophkeeper.RestoreVersionRequest
+	2,  // 11: gophkeeper.HistoryService.ListHistory:output_type -> gophkeeper.ListHistoryResponse
+	11, // 12: gophkeeper.HistoryService.RestoreVersion:output_type -> google.protobuf.Empty
+	11, // [11:13] is the sub-list for method output_type
+	9,  // [9:11] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
+}
+
+func init() { file_history_proto_init() }
+func file_history_proto_init() {
+	if File_history_proto != nil {
+		return
+	}
+	file_binary_proto_init()
+	file_card_proto_init()
+	file_login_proto_init()
+	file_note_proto_init()
+	file_otp_proto_init()
+	file_sync_proto_init()
+	file_history_proto_msgTypes[1].OneofWrappers = []any{
+		(*historyVersion_Login)(nil),
+		(*historyVersion_Note)(nil),
+		(*historyVersion_Binary)(nil),
+		(*historyVersion_Card)(nil),
+		(*historyVersion_Otp)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_history_proto_rawDesc), len(file_history_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_history_proto_goTypes,
+		DependencyIndexes: file_history_proto_depIdxs,
+		MessageInfos:      file_history_proto_msgTypes,
+	}.Build()
+	File_history_proto = out.File
+	file_history_proto_goTypes = nil
+	file_history_proto_depIdxs = nil
+}
@@ -0,0 +1,484 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        v6.30.2
+// source: info.proto
+
+package gophkeeperv1
+
+import (
+	empty "github.com/golang/protobuf/ptypes/empty"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ServerInfoResponse struct {
+	state                      protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Version         *string                `protobuf:"bytes,1,opt,name=version"`
+	xxx_hidden_Commit          *string                `protobuf:"bytes,2,opt,name=commit"`
+	xxx_hidden_BuildDate       *string                `protobuf:"bytes,3,opt,name=build_date,json=buildDate"`
+	xxx_hidden_ProtocolVersion int64                  `protobuf:"varint,4,opt,name=protocol_version,json=protocolVersion"`
+	xxx_hidden_Compressions    []Compression          `protobuf:"varint,5,rep,packed,name=compressions,enum=gophkeeper.Compression"`
+	XXX_raceDetectHookData     protoimpl.RaceDetectHookData
+	XXX_presence               [1]uint32
+	unknownFields              protoimpl.UnknownFields
+	sizeCache                  protoimpl.SizeCache
+}
+
+func (x *ServerInfoResponse) Reset() {
+	*x = ServerInfoResponse{}
+	mi := &file_info_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServerInfoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServerInfoResponse) ProtoMessage() {}
+
+func (x *ServerInfoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_info_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ServerInfoResponse) GetVersion() string {
+	if x != nil {
+		if x.xxx_hidden_Version != nil {
+			return *x.xxx_hidden_Version
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ServerInfoResponse) GetCommit() string {
+	if x != nil {
+		if x.xxx_hidden_Commit != nil {
+			return *x.xxx_hidden_Commit
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ServerInfoResponse) GetBuildDate() string {
+	if x != nil {
+		if x.xxx_hidden_BuildDate != nil {
+			return *x.xxx_hidden_BuildDate
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ServerInfoResponse) GetProtocolVersion() int64 {
+	if x != nil {
+		return x.xxx_hidden_ProtocolVersion
+	}
+	return 0
+}
+
+func (x *ServerInfoResponse) GetCompressions() []Compression {
+	if x != nil {
+		return x.xxx_hidden_Compressions
+	}
+	return nil
+}
+
+func (x *ServerInfoResponse) SetVersion(v string) {
+	x.xxx_hidden_Version = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 5)
+}
+
+func (x *ServerInfoResponse) SetCommit(v string) {
+	x.xxx_hidden_Commit = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 5)
+}
+
+func (x *ServerInfoResponse) SetBuildDate(v string) {
+	x.xxx_hidden_BuildDate = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 5)
+}
+
+func (x *ServerInfoResponse) SetProtocolVersion(v int64) {
+	x.xxx_hidden_ProtocolVersion = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 5)
+}
+
+func (x *ServerInfoResponse) SetCompressions(v []Compression) {
+	x.xxx_hidden_Compressions = v
+}
+
+func (x *ServerInfoResponse) HasVersion() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *ServerInfoResponse) HasCommit() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *ServerInfoResponse) HasBuildDate() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *ServerInfoResponse) HasProtocolVersion() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *ServerInfoResponse) ClearVersion() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Version = nil
+}
+
+func (x *ServerInfoResponse) ClearCommit() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Commit = nil
+}
+
+func (x *ServerInfoResponse) ClearBuildDate() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_BuildDate = nil
+}
+
+func (x *ServerInfoResponse) ClearProtocolVersion() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_ProtocolVersion = 0
+}
+
+type ServerInfoResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Version   *string
+	Commit    *string
+	BuildDate *string
+	// Версия протокола. Увеличивается при несовместимых изменениях API:
+	// клиент с другой версией протокола предупреждает пользователя.
+	ProtocolVersion *int64
+	// Способы сжатия файлов, которые сервер может хранить.
+	Compressions []Compression
+}
+
+func (b0 ServerInfoResponse_builder) Build() *ServerInfoResponse {
+	m0 := &ServerInfoResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Version != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 5)
+		x.xxx_hidden_Version = b.Version
+	}
+	if b.Commit != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 5)
+		x.xxx_hidden_Commit = b.Commit
+	}
+	if b.BuildDate != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 5)
+		x.xxx_hidden_BuildDate = b.BuildDate
+	}
+	if b.ProtocolVersion != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 5)
+		x.xxx_hidden_ProtocolVersion = *b.ProtocolVersion
+	}
+	x.xxx_hidden_Compressions = b.Compressions
+	return m0
+}
+
+// Данные, которые занимает пользователь, и его квота. Нулевое
+// ограничение квоты не действует.
+type UsageResponse struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Bytes       int64                  `protobuf:"varint,1,opt,name=bytes"`
+	xxx_hidden_Items       int64                  `protobuf:"varint,2,opt,name=items"`
+	xxx_hidden_QuotaBytes  int64                  `protobuf:"varint,3,opt,name=quota_bytes,json=quotaBytes"`
+	xxx_hidden_QuotaItems  int64                  `protobuf:"varint,4,opt,name=quota_items,json=quotaItems"`
+	xxx_hidden_MaxFileSize int64                  `protobuf:"varint,5,opt,name=max_file_size,json=maxFileSize"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *UsageResponse) Reset() {
+	*x = UsageResponse{}
+	mi := &file_info_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UsageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UsageResponse) ProtoMessage() {}
+
+func (x *UsageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_info_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *UsageResponse) GetBytes() int64 {
+	if x != nil {
+		return x.xxx_hidden_Bytes
+	}
+	return 0
+}
+
+func (x *UsageResponse) GetItems() int64 {
+	if x != nil {
+		return x.xxx_hidden_Items
+	}
+	return 0
+}
+
+func (x *UsageResponse) GetQuotaBytes() int64 {
+	if x != nil {
+		return x.xxx_hidden_QuotaBytes
+	}
+	return 0
+}
+
+func (x *UsageResponse) GetQuotaItems() int64 {
+	if x != nil {
+		return x.xxx_hidden_QuotaItems
+	}
+	return 0
+}
+
+func (x *UsageResponse) GetMaxFileSize() int64 {
+	if x != nil {
+		return x.xxx_hidden_MaxFileSize
+	}
+	return 0
+}
+
+func (x *UsageResponse) SetBytes(v int64) {
+	x.xxx_hidden_Bytes = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 5)
+}
+
+func (x *UsageResponse) SetItems(v int64) {
+	x.xxx_hidden_Items = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 5)
+}
+
+func (x *UsageResponse) SetQuotaBytes(v int64) {
+	x.xxx_hidden_QuotaBytes = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 5)
+}
+
+func (x *UsageResponse) SetQuotaItems(v int64) {
+	x.xxx_hidden_QuotaItems = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 5)
+}
+
+func (x *UsageResponse) SetMaxFileSize(v int64) {
+	x.xxx_hidden_MaxFileSize = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 5)
+}
+
+func (x *UsageResponse) HasBytes() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *UsageResponse) HasItems() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *UsageResponse) HasQuotaBytes() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *UsageResponse) HasQuotaItems() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *UsageResponse) HasMaxFileSize() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *UsageResponse) ClearBytes() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Bytes = 0
+}
+
+func (x *UsageResponse) ClearItems() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Items = 0
+}
+
+func (x *UsageResponse) ClearQuotaBytes() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_QuotaBytes = 0
+}
+
+func (x *UsageResponse) ClearQuotaItems() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_QuotaItems = 0
+}
+
+func (x *UsageResponse) ClearMaxFileSize() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_MaxFileSize = 0
+}
+
+type UsageResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Размер содержимого бинарных данных в байтах.
+	Bytes *int64
+	// Число записей всех типов.
+	Items       *int64
+	QuotaBytes  *int64
+	QuotaItems  *int64
+	MaxFileSize *int64
+}
+
+func (b0 UsageResponse_builder) Build() *UsageResponse {
+	m0 := &UsageResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Bytes != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 5)
+		x.xxx_hidden_Bytes = *b.Bytes
+	}
+	if b.Items != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 5)
+		x.xxx_hidden_Items = *b.Items
+	}
+	if b.QuotaBytes != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 5)
+		x.xxx_hidden_QuotaBytes = *b.QuotaBytes
+	}
+	if b.QuotaItems != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 5)
+		x.xxx_hidden_QuotaItems = *b.QuotaItems
+	}
+	if b.MaxFileSize != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 5)
+		x.xxx_hidden_MaxFileSize = *b.MaxFileSize
+	}
+	return m0
+}
+
+var File_info_proto protoreflect.FileDescriptor
+
+const file_info_proto_rawDesc = "" +
+	"\n" +
+	"\n" +
+	"info.proto\x12\n" +
+	"gophkeeper\x1a\x1bgoogle/protobuf/empty.proto\x1a\fbinary.proto\"\xcd\x01\n" +
+	"\x12ServerInfoResponse\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\tR\aversion\x12\x16\n" +
+	"\x06commit\x18\x02 \x01(\tR\x06commit\x12\x1d\n" +
+	"\n" +
+	"build_date\x18\x03 \x01(\tR\tbuildDate\x12)\n" +
+	"\x10protocol_version\x18\x04 \x01(\x03R\x0fprotocolVersion\x12;\n" +
+	"\fcompressions\x18\x05 \x03(\x0e2\x17.gophkeeper.CompressionR\fcompressions\"\xa1\x01\n" +
+	"\rUsageResponse\x12\x14\n" +
+	"\x05bytes\x18\x01 \x01(\x03R\x05bytes\x12\x14\n" +
+	"\x05items\x18\x02 \x01(\x03R\x05items\x12\x1f\n" +
+	"\vquota_bytes\x18\x03 \x01(\x03R\n" +
+	"quotaBytes\x12\x1f\n" +
+	"\vquota_items\x18\x04 \x01(\x03R\n" +
+	"quotaItems\x12\"\n" +
+	"\rmax_file_size\x18\x05 \x01(\x03R\vmaxFileSize2\x92\x01\n" +
+	"\vInfoService\x12D\n" +
+	"\n" +
+	"ServerInfo\x12\x16.google.protobuf.Empty\x1a\x1e.gophkeeper.ServerInfoResponse\x12=\n" +
+	"\bGetUsage\x12\x16.google.protobuf.Empty\x1a\x19.gophkeeper.UsageResponseB\x1cZ\x1agophkeeper.v1;gophkeeperv1b\beditionsp\xe8\a"
+
+var file_info_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_info_proto_goTypes = []any{
+	(*ServerInfoResponse)(nil), // 0: gophkeeper.ServerInfoResponse
+	(*UsageResponse)(nil),      // 1: gophkeeper.UsageResponse
+	(Compression)(0),           // 2: gophkeeper.Compression
+	(*empty.Empty)(nil),        // 3: google.protobuf.Empty
+}
+var file_info_proto_depIdxs = []int32{
+	2, // 0: gophkeeper.ServerInfoResponse.compressions:type_name -> gophkeeper.Compression
+	3, // 1: gophkeeper.InfoService.ServerInfo:input_type -> google.protobuf.Empty
+	3, // 2: gophkeeper.InfoService.GetUsage:input_type -> google.protobuf.Empty
+	0, // 3: gophkeeper.InfoService.ServerInfo:output_type -> gophkeeper.ServerInfoResponse
+	1, // 4: gophkeeper.InfoService.GetUsage:output_type -> gophkeeper.UsageResponse
+	3, // [3:5] is the sub-list for method output_type
+	1, // [1:3] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_info_proto_init() }
+func file_info_proto_init() {
+	if File_info_proto != nil {
+		return
+	}
+	file_binary_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_info_proto_rawDesc), len(file_info_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_info_proto_goTypes,
+		DependencyIndexes: file_info_proto_depIdxs,
+		MessageInfos:      file_info_proto_msgTypes,
+	}.Build()
+	File_info_proto = out.File
+	file_info_proto_goTypes = nil
+	file_info_proto_depIdxs = nil
+}
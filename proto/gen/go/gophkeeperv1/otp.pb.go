@@ -0,0 +1,524 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        v6.30.2
+// source: otp.proto
+
+package gophkeeperv1
+
+import (
+	empty "github.com/golang/protobuf/ptypes/empty"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Данные для генерации одноразовых паролей по RFC 6238 (TOTP)
+// или RFC 4226 (HOTP).
+type OTP struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Id          int64                  `protobuf:"varint,1,opt,name=id"`
+	xxx_hidden_Name        *string                `protobuf:"bytes,2,opt,name=name"`
+	xxx_hidden_Type        *string                `protobuf:"bytes,3,opt,name=type"`
+	xxx_hidden_Secret      *string                `protobuf:"bytes,4,opt,name=secret"`
+	xxx_hidden_Algorithm   *string                `protobuf:"bytes,5,opt,name=algorithm"`
+	xxx_hidden_Digits      int64                  `protobuf:"varint,6,opt,name=digits"`
+	xxx_hidden_Period      int64                  `protobuf:"varint,7,opt,name=period"`
+	xxx_hidden_Counter     int64                  `protobuf:"varint,8,opt,name=counter"`
+	xxx_hidden_Issuer      *string                `protobuf:"bytes,9,opt,name=issuer"`
+	xxx_hidden_Version     int64                  `protobuf:"varint,10,opt,name=version"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *OTP) Reset() {
+	*x = OTP{}
+	mi := &file_otp_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OTP) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OTP) ProtoMessage() {}
+
+func (x *OTP) ProtoReflect() protoreflect.Message {
+	mi := &file_otp_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *OTP) GetId() int64 {
+	if x != nil {
+		return x.xxx_hidden_Id
+	}
+	return 0
+}
+
+func (x *OTP) GetName() string {
+	if x != nil {
+		if x.xxx_hidden_Name != nil {
+			return *x.xxx_hidden_Name
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *OTP) GetType() string {
+	if x != nil {
+		if x.xxx_hidden_Type != nil {
+			return *x.xxx_hidden_Type
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *OTP) GetSecret() string {
+	if x != nil {
+		if x.xxx_hidden_Secret != nil {
+			return *x.xxx_hidden_Secret
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *OTP) GetAlgorithm() string {
+	if x != nil {
+		if x.xxx_hidden_Algorithm != nil {
+			return *x.xxx_hidden_Algorithm
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *OTP) GetDigits() int64 {
+	if x != nil {
+		return x.xxx_hidden_Digits
+	}
+	return 0
+}
+
+func (x *OTP) GetPeriod() int64 {
+	if x != nil {
+		return x.xxx_hidden_Period
+	}
+	return 0
+}
+
+func (x *OTP) GetCounter() int64 {
+	if x != nil {
+		return x.xxx_hidden_Counter
+	}
+	return 0
+}
+
+func (x *OTP) GetIssuer() string {
+	if x != nil {
+		if x.xxx_hidden_Issuer != nil {
+			return *x.xxx_hidden_Issuer
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *OTP) GetVersion() int64 {
+	if x != nil {
+		return x.xxx_hidden_Version
+	}
+	return 0
+}
+
+func (x *OTP) SetId(v int64) {
+	x.xxx_hidden_Id = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 10)
+}
+
+func (x *OTP) SetName(v string) {
+	x.xxx_hidden_Name = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 10)
+}
+
+func (x *OTP) SetType(v string) {
+	x.xxx_hidden_Type = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 10)
+}
+
+func (x *OTP) SetSecret(v string) {
+	x.xxx_hidden_Secret = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 10)
+}
+
+func (x *OTP) SetAlgorithm(v string) {
+	x.xxx_hidden_Algorithm = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 10)
+}
+
+func (x *OTP) SetDigits(v int64) {
+	x.xxx_hidden_Digits = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 10)
+}
+
+func (x *OTP) SetPeriod(v int64) {
+	x.xxx_hidden_Period = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 6, 10)
+}
+
+func (x *OTP) SetCounter(v int64) {
+	x.xxx_hidden_Counter = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 7, 10)
+}
+
+func (x *OTP) SetIssuer(v string) {
+	x.xxx_hidden_Issuer = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 8, 10)
+}
+
+func (x *OTP) SetVersion(v int64) {
+	x.xxx_hidden_Version = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 9, 10)
+}
+
+func (x *OTP) HasId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *OTP) HasName() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *OTP) HasType() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *OTP) HasSecret() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *OTP) HasAlgorithm() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *OTP) HasDigits() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
+}
+
+func (x *OTP) HasPeriod() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 6)
+}
+
+func (x *OTP) HasCounter() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 7)
+}
+
+func (x *OTP) HasIssuer() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 8)
+}
+
+func (x *OTP) HasVersion() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 9)
+}
+
+func (x *OTP) ClearId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Id = 0
+}
+
+func (x *OTP) ClearName() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Name = nil
+}
+
+func (x *OTP) ClearType() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Type = nil
+}
+
+func (x *OTP) ClearSecret() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_Secret = nil
+}
+
+func (x *OTP) ClearAlgorithm() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_Algorithm = nil
+}
+
+func (x *OTP) ClearDigits() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 5)
+	x.xxx_hidden_Digits = 0
+}
+
+func (x *OTP) ClearPeriod() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 6)
+	x.xxx_hidden_Period = 0
+}
+
+func (x *OTP) ClearCounter() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 7)
+	x.xxx_hidden_Counter = 0
+}
+
+func (x *OTP) ClearIssuer() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 8)
+	x.xxx_hidden_Issuer = nil
+}
+
+func (x *OTP) ClearVersion() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 9)
+	x.xxx_hidden_Version = 0
+}
+
+type OTP_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Id   *int64
+	Name *string
+	// Тип пароля: totp или hotp.
+	Type   *string
+	Secret *string
+	// Хэш-функция: SHA1, SHA256 или SHA512.
+	Algorithm *string
+	Digits    *int64
+	// Период действия пароля в секундах, только для TOTP.
+	Period *int64
+	// Счетчик, только для HOTP.
+	Counter *int64
+	Issuer  *string
+	Version *int64
+}
+
+func (b0 OTP_builder) Build() *OTP {
+	m0 := &OTP{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Id != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 10)
+		x.xxx_hidden_Id = *b.Id
+	}
+	if b.Name != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 10)
+		x.xxx_hidden_Name = b.Name
+	}
+	if b.Type != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 10)
+		x.xxx_hidden_Type = b.Type
+	}
+	if b.Secret != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 10)
+		x.xxx_hidden_Secret = b.Secret
+	}
+	if b.Algorithm != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 10)
+		x.xxx_hidden_Algorithm = b.Algorithm
+	}
+	if b.Digits != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 10)
+		x.xxx_hidden_Digits = *b.Digits
+	}
+	if b.Period != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 6, 10)
+		x.xxx_hidden_Period = *b.Period
+	}
+	if b.Counter != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 7, 10)
+		x.xxx_hidden_Counter = *b.Counter
+	}
+	if b.Issuer != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 8, 10)
+		x.xxx_hidden_Issuer = b.Issuer
+	}
+	if b.Version != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 9, 10)
+		x.xxx_hidden_Version = *b.Version
+	}
+	return m0
+}
+
+type GetAllOTPsResponse struct {
+	state             protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Result *[]*OTP                `protobuf:"bytes,1,rep,name=result"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *GetAllOTPsResponse) Reset() {
+	*x = GetAllOTPsResponse{}
+	mi := &file_otp_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAllOTPsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAllOTPsResponse) ProtoMessage() {}
+
+func (x *GetAllOTPsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_otp_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *GetAllOTPsResponse) GetResult() []*OTP {
+	if x != nil {
+		if x.xxx_hidden_Result != nil {
+			return *x.xxx_hidden_Result
+		}
+	}
+	return nil
+}
+
+func (x *GetAllOTPsResponse) SetResult(v []*OTP) {
+	x.xxx_hidden_Result = &v
+}
+
+type GetAllOTPsResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Result []*OTP
+}
+
+func (b0 GetAllOTPsResponse_builder) Build() *GetAllOTPsResponse {
+	m0 := &GetAllOTPsResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Result = &b.Result
+	return m0
+}
+
+var File_otp_proto protoreflect.FileDescriptor
+
+const file_otp_proto_rawDesc = "" +
+	"\n" +
+	"\totp.proto\x12\n" +
+	"gophkeeper\x1a\x1bgoogle/protobuf/empty.proto\x1a\n" +
+	"data.proto\"\xef\x01\n" +
+	"\x03OTP\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12\x16\n" +
+	"\x06secret\x18\x04 \x01(\tR\x06secret\x12\x1c\n" +
+	"\talgorithm\x18\x05 \x01(\tR\talgorithm\x12\x16\n" +
+	"\x06digits\x18\x06 \x01(\x03R\x06digits\x12\x16\n" +
+	"\x06period\x18\a \x01(\x03R\x06period\x12\x18\n" +
+	"\acounter\x18\b \x01(\x03R\acounter\x12\x16\n" +
+	"\x06issuer\x18\t \x01(\tR\x06issuer\x12\x18\n" +
+	"\aversion\x18\n" +
+	" \x01(\x03R\aversion\"=\n" +
+	"\x12GetAllOTPsResponse\x12'\n" +
+	"\x06result\x18\x01 \x03(\v2\x0f.gophkeeper.OTPR\x06result2\xf3\x01\n" +
+	"\n" +
+	"OTPService\x12/\n" +
+	"\x04Save\x12\x0f.gophkeeper.OTP\x1a\x16.google.protobuf.Empty\x12@\n" +
+	"\x06GetAll\x12\x16.google.protobuf.Empty\x1a\x1e.gophkeeper.GetAllOTPsResponse\x121\n" +
+	"\x06Update\x12\x0f.gophkeeper.OTP\x1a\x16.google.protobuf.Empty\x12?\n" +
+	"\x06Remove\x12\x1d.gophkeeper.RemoveDataRequest\x1a\x16.google.protobuf.EmptyB\x1cZ\x1agophkeeper.v1;gophkeeperv1b\beditionsp\xe8\a"
+
+var file_otp_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_otp_proto_goTypes = []any{
+	(*OTP)(nil),                // 0: gophkeeper.OTP
+	(*GetAllOTPsResponse)(nil), // 1: gophkeeper.GetAllOTPsResponse
+	(*empty.Empty)(nil),        // 2: google.protobuf.Empty
+	(*RemoveDataRequest)(nil),  // 3: gophkeeper.RemoveDataRequest
+}
+var file_otp_proto_depIdxs = []int32{
+	0, // 0: gophkeeper.GetAllOTPsResponse.result:type_name -> gophkeeper.OTP
+	0, // 1: gophkeeper.OTPService.Save:input_type -> gophkeeper.OTP
+	2, // 2: gophkeeper.OTPService.GetAll:input_type -> google.protobuf.Empty
+	0, // 3: gophkeeper.OTPService.Update:input_type -> gophkeeper.OTP
+	3, // 4: gophkeeper.OTPService.Remove:input_type -> gophkeeper.RemoveDataRequest
+	2, // 5: gophkeeper.OTPService.Save:output_type -> google.protobuf.Empty
+	1, // 6: gophkeeper.OTPService.GetAll:output_type -> gophkeeper.GetAllOTPsResponse
+	2, // 7: gophkeeper.OTPService.Update:output_type -> google.protobuf.Empty
+	2, // 8: gophkeeper.OTPService.Remove:output_type -> google.protobuf.Empty
+	5, // [5:9] is the sub-list for method output_type
+	1, // [1:5] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_otp_proto_init() }
+func file_otp_proto_init() {
+	if File_otp_proto != nil {
+		return
+	}
+	file_data_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_otp_proto_rawDesc), len(file_otp_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_otp_proto_goTypes,
+		DependencyIndexes: file_otp_proto_depIdxs,
+		MessageInfos:      file_otp_proto_msgTypes,
+	}.Build()
+	File_otp_proto = out.File
+	file_otp_proto_goTypes = nil
+	file_otp_proto_depIdxs = nil
+}
@@ -0,0 +1,236 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v6.30.2
+// source: otp.proto
+
+package gophkeeperv1
+
+import (
+	context "context"
+	empty "github.com/golang/protobuf/ptypes/empty"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	OTPService_Save_FullMethodName   = "/gophkeeper.OTPService/Save"
+	OTPService_GetAll_FullMethodName = "/gophkeeper.OTPService/GetAll"
+	OTPService_Update_FullMethodName = "/gophkeeper.OTPService/Update"
+	OTPService_Remove_FullMethodName = "/gophkeeper.OTPService/Remove"
+)
+
+// OTPServiceClient is the client API for OTPService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type OTPServiceClient interface {
+	Save(ctx context.Context, in *OTP, opts ...grpc.CallOption) (*empty.Empty, error)
+	GetAll(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*GetAllOTPsResponse, error)
+	Update(ctx context.Context, in *OTP, opts ...grpc.CallOption) (*empty.Empty, error)
+	Remove(ctx context.Context, in *RemoveDataRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+}
+
+type oTPServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewOTPServiceClient(cc grpc.ClientConnInterface) OTPServiceClient {
+	return &oTPServiceClient{cc}
+}
+
+func (c *oTPServiceClient) Save(ctx context.Context, in *OTP, opts ...grpc.CallOption) (*empty.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, OTPService_Save_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *oTPServiceClient) GetAll(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*GetAllOTPsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAllOTPsResponse)
+	err := c.cc.Invoke(ctx, OTPService_GetAll_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *oTPServiceClient) Update(ctx context.Context, in *OTP, opts ...grpc.CallOption) (*empty.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, OTPService_Update_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *oTPServiceClient) Remove(ctx context.Context, in *RemoveDataRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, OTPService_Remove_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OTPServiceServer is the server API for OTPService service.
+// All implementations must embed UnimplementedOTPServiceServer
+// for forward compatibility.
+type OTPServiceServer interface {
+	Save(context.Context, *OTP) (*empty.Empty, error)
+	GetAll(context.Context, *empty.Empty) (*GetAllOTPsResponse, error)
+	Update(context.Context, *OTP) (*empty.Empty, error)
+	Remove(context.Context, *RemoveDataRequest) (*empty.Empty, error)
+	mustEmbedUnimplementedOTPServiceServer()
+}
+
+// UnimplementedOTPServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedOTPServiceServer struct{}
+
+func (UnimplementedOTPServiceServer) Save(context.Context, *OTP) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Save not implemented")
+}
+func (UnimplementedOTPServiceServer) GetAll(context.Context, *empty.Empty) (*GetAllOTPsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAll not implemented")
+}
+func (UnimplementedOTPServiceServer) Update(context.Context, *OTP) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Update not implemented")
+}
+func (UnimplementedOTPServiceServer) Remove(context.Context, *RemoveDataRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Remove not implemented")
+}
+func (UnimplementedOTPServiceServer) mustEmbedUnimplementedOTPServiceServer() {}
+func (UnimplementedOTPServiceServer) testEmbeddedByValue()                    {}
+
+// UnsafeOTPServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to OTPServiceServer will
+// result in compilation errors.
+type UnsafeOTPServiceServer interface {
+	mustEmbedUnimplementedOTPServiceServer()
+}
+
+func RegisterOTPServiceServer(s grpc.ServiceRegistrar, srv OTPServiceServer) {
+	// If the following call pancis, it indicates UnimplementedOTPServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&OTPService_ServiceDesc, srv)
+}
+
+func _OTPService_Save_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OTP)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OTPServiceServer).Save(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OTPService_Save_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OTPServiceServer).Save(ctx, req.(*OTP))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OTPService_GetAll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OTPServiceServer).GetAll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OTPService_GetAll_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OTPServiceServer).GetAll(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OTPService_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OTP)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OTPServiceServer).Update(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OTPService_Update_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OTPServiceServer).Update(ctx, req.(*OTP))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OTPService_Remove_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OTPServiceServer).Remove(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OTPService_Remove_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OTPServiceServer).Remove(ctx, req.(*RemoveDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// OTPService_ServiceDesc is the grpc.ServiceDesc for OTPService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var OTPService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "gophkeeper.OTPService",
+	HandlerType: (*OTPServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Save",
+			Handler:    _OTPService_Save_Handler,
+		},
+		{
+			MethodName: "GetAll",
+			Handler:    _OTPService_GetAll_Handler,
+		},
+		{
+			MethodName: "Update",
+			Handler:    _OTPService_Update_Handler,
+		},
+		{
+			MethodName: "Remove",
+			Handler:    _OTPService_Remove_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "otp.proto",
+}
@@ -0,0 +1,1148 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        v6.30.2
+// source: sync.proto
+
+package gophkeeperv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type DataKind int32
+
+const (
+	DataKind_DATA_KIND_UNSPECIFIED DataKind = 0
+	DataKind_DATA_KIND_LOGIN       DataKind = 1
+	DataKind_DATA_KIND_NOTE        DataKind = 2
+	DataKind_DATA_KIND_BINARY      DataKind = 3
+	DataKind_DATA_KIND_CARD        DataKind = 4
+	DataKind_DATA_KIND_OTP         DataKind = 5
+)
+
+// Enum value maps for DataKind.
+var (
+	DataKind_name = map[int32]string{
+		0: "DATA_KIND_UNSPECIFIED",
+		1: "DATA_KIND_LOGIN",
+		2: "DATA_KIND_NOTE",
+		3: "DATA_KIND_BINARY",
+		4: "DATA_KIND_CARD",
+		5: "DATA_KIND_OTP",
+	}
+	DataKind_value = map[string]int32{
+		"DATA_KIND_UNSPECIFIED": 0,
+		"DATA_KIND_LOGIN":       1,
+		"DATA_KIND_NOTE":        2,
+		"DATA_KIND_BINARY":      3,
+		"DATA_KIND_CARD":        4,
+		"DATA_KIND_OTP":         5,
+	}
+)
+
+func (x DataKind) Enum() *DataKind {
+	p := new(DataKind)
+	*p = x
+	return p
+}
+
+func (x DataKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DataKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_sync_proto_enumTypes[0].Descriptor()
+}
+
+func (DataKind) Type() protoreflect.EnumType {
+	return &file_sync_proto_enumTypes[0]
+}
+
+func (x DataKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Отметка об удалении данных.
+type Tombstone struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Kind        DataKind               `protobuf:"varint,1,opt,name=kind,enum=gophkeeper.DataKind"`
+	xxx_hidden_Id          int64                  `protobuf:"varint,2,opt,name=id"`
+	xxx_hidden_Version     int64                  `protobuf:"varint,3,opt,name=version"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *Tombstone) Reset() {
+	*x = Tombstone{}
+	mi := &file_sync_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Tombstone) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Tombstone) ProtoMessage() {}
+
+func (x *Tombstone) ProtoReflect() protoreflect.Message {
+	mi := &file_sync_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *Tombstone) GetKind() DataKind {
+	if x != nil {
+		if protoimpl.X.Present(&(x.XXX_presence[0]), 0) {
+			return x.xxx_hidden_Kind
+		}
+	}
+	return DataKind_DATA_KIND_UNSPECIFIED
+}
+
+func (x *Tombstone) GetId() int64 {
+	if x != nil {
+		return x.xxx_hidden_Id
+	}
+	return 0
+}
+
+func (x *Tombstone) GetVersion() int64 {
+	if x != nil {
+		return x.xxx_hidden_Version
+	}
+	return 0
+}
+
+func (x *Tombstone) SetKind(v DataKind) {
+	x.xxx_hidden_Kind = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 3)
+}
+
+func (x *Tombstone) SetId(v int64) {
+	x.xxx_hidden_Id = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 3)
+}
+
+func (x *Tombstone) SetVersion(v int64) {
+	x.xxx_hidden_Version = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 3)
+}
+
+func (x *Tombstone) HasKind() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *Tombstone) HasId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *Tombstone) HasVersion() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *Tombstone) ClearKind() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Kind = DataKind_DATA_KIND_UNSPECIFIED
+}
+
+func (x *Tombstone) ClearId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Id = 0
+}
+
+func (x *Tombstone) ClearVersion() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Version = 0
+}
+
+type Tombstone_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Kind *DataKind
+	Id   *int64
+	// Версия удаляемых данных. Заполняется при отправке на сервер.
+	Version *int64
+}
+
+func (b0 Tombstone_builder) Build() *Tombstone {
+	m0 := &Tombstone{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Kind != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 3)
+		x.xxx_hidden_Kind = *b.Kind
+	}
+	if b.Id != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 3)
+		x.xxx_hidden_Id = *b.Id
+	}
+	if b.Version != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 3)
+		x.xxx_hidden_Version = *b.Version
+	}
+	return m0
+}
+
+// Изменение одной записи. При отправке на сервер записи с id = 0 создаются,
+// остальные обновляются целиком.
+type Change struct {
+	state             protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Change isChange_Change        `protobuf_oneof:"change"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Change) Reset() {
+	*x = Change{}
+	mi := &file_sync_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Change) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Change) ProtoMessage() {}
+
+func (x *Change) ProtoReflect() protoreflect.Message {
+	mi := &file_sync_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *Change) GetLogin() *Login {
+	if x != nil {
+		if x, ok := x.xxx_hidden_Change.(*change_Login); ok {
+			return x.Login
+		}
+	}
+	return nil
+}
+
+func (x *Change) GetNote() *Note {
+	if x != nil {
+		if x, ok := x.xxx_hidden_Change.(*change_Note); ok {
+			return x.Note
+		}
+	}
+	return nil
+}
+
+func (x *Change) GetBinary() *Binary {
+	if x != nil {
+		if x, ok := x.xxx_hidden_Change.(*change_Binary); ok {
+			return x.Binary
+		}
+	}
+	return nil
+}
+
+func (x *Change) GetCard() *Card {
+	if x != nil {
+		if x, ok := x.xxx_hidden_Change.(*change_Card); ok {
+			return x.Card
+		}
+	}
+	return nil
+}
+
+func (x *Change) GetRemoved() *Tombstone {
+	if x != nil {
+		if x, ok := x.xxx_hidden_Change.(*change_Removed); ok {
+			return x.Removed
+		}
+	}
+	return nil
+}
+
+func (x *Change) GetOtp() *OTP {
+	if x != nil {
+		if x, ok := x.xxx_hidden_Change.(*change_Otp); ok {
+			return x.Otp
+		}
+	}
+	return nil
+}
+
+func (x *Change) SetLogin(v *Login) {
+	if v == nil {
+		x.xxx_hidden_Change = nil
+		return
+	}
+	x.xxx_hidden_Change = &change_Login{v}
+}
+
+func (x *Change) SetNote(v *Note) {
+	if v == nil {
+		x.xxx_hidden_Change = nil
+		return
+	}
+	x.xxx_hidden_Change = &change_Note{v}
+}
+
+func (x *Change) SetBinary(v *Binary) {
+	if v == nil {
+		x.xxx_hidden_Change = nil
+		return
+	}
+	x.xxx_hidden_Change = &change_Binary{v}
+}
+
+func (x *Change) SetCard(v *Card) {
+	if v == nil {
+		x.xxx_hidden_Change = nil
+		return
+	}
+	x.xxx_hidden_Change = &change_Card{v}
+}
+
+func (x *Change) SetRemoved(v *Tombstone) {
+	if v == nil {
+		x.xxx_hidden_Change = nil
+		return
+	}
+	x.xxx_hidden_Change = &change_Removed{v}
+}
+
+func (x *Change) SetOtp(v *OTP) {
+	if v == nil {
+		x.xxx_hidden_Change = nil
+		return
+	}
+	x.xxx_hidden_Change = &change_Otp{v}
+}
+
+func (x *Change) HasChange() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Change != nil
+}
+
+func (x *Change) HasLogin() bool {
+	if x == nil {
+		return false
+	}
+	_, ok := x.xxx_hidden_Change.(*change_Login)
+	return ok
+}
+
+func (x *Change) HasNote() bool {
+	if x == nil {
+		return false
+	}
+	_, ok := x.xxx_hidden_Change.(*change_Note)
+	return ok
+}
+
+func (x *Change) HasBinary() bool {
+	if x == nil {
+		return false
+	}
+	_, ok := x.xxx_hidden_Change.(*change_Binary)
+	return ok
+}
+
+func (x *Change) HasCard() bool {
+	if x == nil {
+		return false
+	}
+	_, ok := x.xxx_hidden_Change.(*change_Card)
+	return ok
+}
+
+func (x *Change) HasRemoved() bool {
+	if x == nil {
+		return false
+	}
+	_, ok := x.xxx_hidden_Change.(*change_Removed)
+	return ok
+}
+
+func (x *Change) HasOtp() bool {
+	if x == nil {
+		return false
+	}
+	_, ok := x.xxx_hidden_Change.(*change_Otp)
+	return ok
+}
+
+func (x *Change) ClearChange() {
+	x.xxx_hidden_Change = nil
+}
+
+func (x *Change) ClearLogin() {
+	if _, ok := x.xxx_hidden_Change.(*change_Login); ok {
+		x.xxx_hidden_Change = nil
+	}
+}
+
+func (x *Change) ClearNote() {
+	if _, ok := x.xxx_hidden_Change.(*change_Note); ok {
+		x.xxx_hidden_Change = nil
+	}
+}
+
+func (x *Change) ClearBinary() {
+	if _, ok := x.xxx_hidden_Change.(*change_Binary); ok {
+		x.xxx_hidden_Change = nil
+	}
+}
+
+func (x *Change) ClearCard() {
+	if _, ok := x.xxx_hidden_Change.(*change_Card); ok {
+		x.xxx_hidden_Change = nil
+	}
+}
+
+func (x *Change) ClearRemoved() {
+	if _, ok := x.xxx_hidden_Change.(*change_Removed); ok {
+		x.xxx_hidden_Change = nil
+	}
+}
+
+func (x *Change) ClearOtp() {
+	if _, ok := x.xxx_hidden_Change.(*change_Otp); ok {
+		x.xxx_hidden_Change = nil
+	}
+}
+
+const Change_Change_not_set_case case_Change_Change = 0
+const Change_Login_case case_Change_Change = 1
+const Change_Note_case case_Change_Change = 2
+const Change_Binary_case case_Change_Change = 3
+const Change_Card_case case_Change_Change = 4
+const Change_Removed_case case_Change_Change = 5
+const Change_Otp_case case_Change_Change = 6
+
+func (x *Change) WhichChange() case_Change_Change {
+	if x == nil {
+		return Change_Change_not_set_case
+	}
+	switch x.xxx_hidden_Change.(type) {
+	case *change_Login:
+		return Change_Login_case
+	case *change_Note:
+		return Change_Note_case
+	case *change_Binary:
+		return Change_Binary_case
+	case *change_Card:
+		return Change_Card_case
+	case *change_Removed:
+		return Change_Removed_case
+	case *change_Otp:
+		return Change_Otp_case
+	default:
+		return Change_Change_not_set_case
+	}
+}
+
+type Change_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Fields of oneof xxx_hidden_Change:
+	Login   *Login
+	Note    *Note
+	Binary  *Binary
+	Card    *Card
+	Removed *Tombstone
+	Otp     *OTP
+	// -- end of xxx_hidden_Change
+}
+
+func (b0 Change_builder) Build() *Change {
+	m0 := &Change{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Login != nil {
+		x.xxx_hidden_Change = &change_Login{b.Login}
+	}
+	if b.Note != nil {
+		x.xxx_hidden_Change = &change_Note{b.Note}
+	}
+	if b.Binary != nil {
+		x.xxx_hidden_Change = &change_Binary{b.Binary}
+	}
+	if b.Card != nil {
+		x.xxx_hidden_Change = &change_Card{b.Card}
+	}
+	if b.Removed != nil {
+		x.xxx_hidden_Change = &change_Removed{b.Removed}
+	}
+	if b.Otp != nil {
+		x.xxx_hidden_Change = &change_Otp{b.Otp}
+	}
+	return m0
+}
+
+type case_Change_Change protoreflect.FieldNumber
+
+func (x case_Change_Change) String() string {
+	md := file_sync_proto_msgTypes[1].Descriptor()
+	if x == 0 {
+		return "not set"
+	}
+	return protoimpl.X.MessageFieldStringOf(md, protoreflect.FieldNumber(x))
+}
+
+type isChange_Change interface {
+	isChange_Change()
+}
+
+type change_Login struct {
+	Login *Login `protobuf:"bytes,1,opt,name=login,oneof"`
+}
+
+type change_Note struct {
+	Note *Note `protobuf:"bytes,2,opt,name=note,oneof"`
+}
+
+type change_Binary struct {
+	Binary *Binary `protobuf:"bytes,3,opt,name=binary,oneof"`
+}
+
+type change_Card struct {
+	Card *Card `protobuf:"bytes,4,opt,name=card,oneof"`
+}
+
+type change_Removed struct {
+	Removed *Tombstone `protobuf:"bytes,5,opt,name=removed,oneof"`
+}
+
+type change_Otp struct {
+	Otp *OTP `protobuf:"bytes,6,opt,name=otp,oneof"`
+}
+
+func (*change_Login) isChange_Change() {}
+
+func (*change_Note) isChange_Change() {}
+
+func (*change_Binary) isChange_Change() {}
+
+func (*change_Card) isChange_Change() {}
+
+func (*change_Removed) isChange_Change() {}
+
+func (*change_Otp) isChange_Change() {}
+
+type PullRequest struct {
+	state                    protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_SinceRevision int64                  `protobuf:"varint,1,opt,name=since_revision,json=sinceRevision"`
+	XXX_raceDetectHookData   protoimpl.RaceDetectHookData
+	XXX_presence             [1]uint32
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *PullRequest) Reset() {
+	*x = PullRequest{}
+	mi := &file_sync_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PullRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PullRequest) ProtoMessage() {}
+
+func (x *PullRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sync_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *PullRequest) GetSinceRevision() int64 {
+	if x != nil {
+		return x.xxx_hidden_SinceRevision
+	}
+	return 0
+}
+
+func (x *PullRequest) SetSinceRevision(v int64) {
+	x.xxx_hidden_SinceRevision = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 1)
+}
+
+func (x *PullRequest) HasSinceRevision() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *PullRequest) ClearSinceRevision() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_SinceRevision = 0
+}
+
+type PullRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	SinceRevision *int64
+}
+
+func (b0 PullRequest_builder) Build() *PullRequest {
+	m0 := &PullRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.SinceRevision != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 1)
+		x.xxx_hidden_SinceRevision = *b.SinceRevision
+	}
+	return m0
+}
+
+type PullResponse struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Revision    int64                  `protobuf:"varint,1,opt,name=revision"`
+	xxx_hidden_Changes     *[]*Change             `protobuf:"bytes,2,rep,name=changes"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *PullResponse) Reset() {
+	*x = PullResponse{}
+	mi := &file_sync_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PullResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PullResponse) ProtoMessage() {}
+
+func (x *PullResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_sync_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *PullResponse) GetRevision() int64 {
+	if x != nil {
+		return x.xxx_hidden_Revision
+	}
+	return 0
+}
+
+func (x *PullResponse) GetChanges() []*Change {
+	if x != nil {
+		if x.xxx_hidden_Changes != nil {
+			return *x.xxx_hidden_Changes
+		}
+	}
+	return nil
+}
+
+func (x *PullResponse) SetRevision(v int64) {
+	x.xxx_hidden_Revision = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+}
+
+func (x *PullResponse) SetChanges(v []*Change) {
+	x.xxx_hidden_Changes = &v
+}
+
+func (x *PullResponse) HasRevision() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *PullResponse) ClearRevision() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Revision = 0
+}
+
+type PullResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Текущая ревизия данных пользователя.
+	Revision *int64
+	Changes  []*Change
+}
+
+func (b0 PullResponse_builder) Build() *PullResponse {
+	m0 := &PullResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Revision != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		x.xxx_hidden_Revision = *b.Revision
+	}
+	x.xxx_hidden_Changes = &b.Changes
+	return m0
+}
+
+type PushRequest struct {
+	state              protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Changes *[]*Change             `protobuf:"bytes,1,rep,name=changes"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *PushRequest) Reset() {
+	*x = PushRequest{}
+	mi := &file_sync_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PushRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PushRequest) ProtoMessage() {}
+
+func (x *PushRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sync_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *PushRequest) GetChanges() []*Change {
+	if x != nil {
+		if x.xxx_hidden_Changes != nil {
+			return *x.xxx_hidden_Changes
+		}
+	}
+	return nil
+}
+
+func (x *PushRequest) SetChanges(v []*Change) {
+	x.xxx_hidden_Changes = &v
+}
+
+type PushRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Changes []*Change
+}
+
+func (b0 PushRequest_builder) Build() *PushRequest {
+	m0 := &PushRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Changes = &b.Changes
+	return m0
+}
+
+// Результат применения одного изменения.
+type ChangeResult struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Code        int32                  `protobuf:"varint,1,opt,name=code"`
+	xxx_hidden_Message     *string                `protobuf:"bytes,2,opt,name=message"`
+	xxx_hidden_Version     int64                  `protobuf:"varint,3,opt,name=version"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *ChangeResult) Reset() {
+	*x = ChangeResult{}
+	mi := &file_sync_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangeResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeResult) ProtoMessage() {}
+
+func (x *ChangeResult) ProtoReflect() protoreflect.Message {
+	mi := &file_sync_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ChangeResult) GetCode() int32 {
+	if x != nil {
+		return x.xxx_hidden_Code
+	}
+	return 0
+}
+
+func (x *ChangeResult) GetMessage() string {
+	if x != nil {
+		if x.xxx_hidden_Message != nil {
+			return *x.xxx_hidden_Message
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ChangeResult) GetVersion() int64 {
+	if x != nil {
+		return x.xxx_hidden_Version
+	}
+	return 0
+}
+
+func (x *ChangeResult) SetCode(v int32) {
+	x.xxx_hidden_Code = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 3)
+}
+
+func (x *ChangeResult) SetMessage(v string) {
+	x.xxx_hidden_Message = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 3)
+}
+
+func (x *ChangeResult) SetVersion(v int64) {
+	x.xxx_hidden_Version = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 3)
+}
+
+func (x *ChangeResult) HasCode() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *ChangeResult) HasMessage() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *ChangeResult) HasVersion() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *ChangeResult) ClearCode() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Code = 0
+}
+
+func (x *ChangeResult) ClearMessage() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Message = nil
+}
+
+func (x *ChangeResult) ClearVersion() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Version = 0
+}
+
+type ChangeResult_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Код gRPC. OK - изменение применено, остальные коды - изменение
+	// отклонено.
+	Code    *int32
+	Message *string
+	// Текущая версия данных на сервере при конфликте версий (код ABORTED).
+	Version *int64
+}
+
+func (b0 ChangeResult_builder) Build() *ChangeResult {
+	m0 := &ChangeResult{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Code != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 3)
+		x.xxx_hidden_Code = *b.Code
+	}
+	if b.Message != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 3)
+		x.xxx_hidden_Message = b.Message
+	}
+	if b.Version != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 3)
+		x.xxx_hidden_Version = *b.Version
+	}
+	return m0
+}
+
+// Ответ на отправку изменений. Если изменение не удалось применить из-за
+// ошибки сервера, Push завершается ошибкой, а в ее деталях передается
+// PushResponse с результатами изменений, примененных до нее.
+type PushResponse struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Revision    int64                  `protobuf:"varint,1,opt,name=revision"`
+	xxx_hidden_Results     *[]*ChangeResult       `protobuf:"bytes,3,rep,name=results"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *PushResponse) Reset() {
+	*x = PushResponse{}
+	mi := &file_sync_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PushResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PushResponse) ProtoMessage() {}
+
+func (x *PushResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_sync_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *PushResponse) GetRevision() int64 {
+	if x != nil {
+		return x.xxx_hidden_Revision
+	}
+	return 0
+}
+
+func (x *PushResponse) GetResults() []*ChangeResult {
+	if x != nil {
+		if x.xxx_hidden_Results != nil {
+			return *x.xxx_hidden_Results
+		}
+	}
+	return nil
+}
+
+func (x *PushResponse) SetRevision(v int64) {
+	x.xxx_hidden_Revision = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+}
+
+func (x *PushResponse) SetResults(v []*ChangeResult) {
+	x.xxx_hidden_Results = &v
+}
+
+func (x *PushResponse) HasRevision() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *PushResponse) ClearRevision() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Revision = 0
+}
+
+type PushResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Revision *int64
+	// Результаты изменений в порядке запроса.
+	Results []*ChangeResult
+}
+
+func (b0 PushResponse_builder) Build() *PushResponse {
+	m0 := &PushResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Revision != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		x.xxx_hidden_Revision = *b.Revision
+	}
+	x.xxx_hidden_Results = &b.Results
+	return m0
+}
+
+var File_sync_proto protoreflect.FileDescriptor
+
+const file_sync_proto_rawDesc = "" +
+	"\n" +
+	"\n" +
+	"sync.proto\x12\n" +
+	"gophkeeper\x1a\fbinary.proto\x1a\n" +
+	"card.proto\x1a\vlogin.proto\x1a\n" +
+	"note.proto\x1a\totp.proto\"_\n" +
+	"\tTombstone\x12(\n" +
+	"\x04kind\x18\x01 \x01(\x0e2\x14.gophkeeper.DataKindR\x04kind\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\x03R\x02id\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\x03R\aversion\"\x93\x02\n" +
+	"\x06Change\x12)\n" +
+	"\x05login\x18\x01 \x01(\v2\x11.gophkeeper.LoginH\x00R\x05login\x12&\n" +
+	"\x04note\x18\x02 \x01(\v2\x10.gophkeeper.NoteH\x00R\x04note\x12,\n" +
+	"\x06binary\x18\x03 \x01(\v2\x12.gophkeeper.BinaryH\x00R\x06binary\x12&\n" +
+	"\x04card\x18\x04 \x01(\v2\x10.gophkeeper.CardH\x00R\x04card\x121\n" +
+	"\aremoved\x18\x05 \x01(\v2\x15.gophkeeper.TombstoneH\x00R\aremoved\x12#\n" +
+	"\x03otp\x18\x06 \x01(\v2\x0f.gophkeeper.OTPH\x00R\x03otpB\b\n" +
+	"\x06change\"4\n" +
+	"\vPullRequest\x12%\n" +
+	"\x0esince_revision\x18\x01 \x01(\x03R\rsinceRevision\"X\n" +
+	"\fPullResponse\x12\x1a\n" +
+	"\brevision\x18\x01 \x01(\x03R\brevision\x12,\n" +
+	"\achanges\x18\x02 \x03(\v2\x12.gophkeeper.ChangeR\achanges\";\n" +
+	"\vPushRequest\x12,\n" +
+	"\achanges\x18\x01 \x03(\v2\x12.gophkeeper.ChangeR\achanges\"V\n" +
+	"\fChangeResult\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\x05R\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\x03R\aversion\"d\n" +
+	"\fPushResponse\x12\x1a\n" +
+	"\brevision\x18\x01 \x01(\x03R\brevision\x122\n" +
+	"\aresults\x18\x03 \x03(\v2\x18.gophkeeper.ChangeResultR\aresultsJ\x04\b\x02\x10\x03*\x8b\x01\n" +
+	"\bDataKind\x12\x19\n" +
+	"\x15DATA_KIND_UNSPECIFIED\x10\x00\x12\x13\n" +
+	"\x0fDATA_KIND_LOGIN\x10\x01\x12\x12\n" +
+	"\x0eDATA_KIND_NOTE\x10\x02\x12\x14\n" +
+	"\x10DATA_KIND_BINARY\x10\x03\x12\x12\n" +
+	"\x0eDATA_KIND_CARD\x10\x04\x12\x11\n" +
+	"\rDATA_KIND_OTP\x10\x052\x83\x01\n" +
+	"\vSyncService\x129\n" +
+	"\x04Pull\x12\x17.gophkeeper.PullRequest\x1a\x18.gophkeeper.PullResponse\x129\n" +
+	"\x04Push\x12\x17.gophkeeper.PushRequest\x1a\x18.gophkeeper.PushResponseB\x1cZ\x1agophkeeper.v1;gophkeeperv1b\beditionsp\xe8\a"
+
+var file_sync_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_sync_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_sync_proto_goTypes = []any{
+	(DataKind)(0),        // 0: gophkeeper.DataKind
+	(*Tombstone)(nil),    // 1: gophkeeper.Tombstone
+	(*Change)(nil),       // 2: gophkeeper.Change
+	(*PullRequest)(nil),  // 3: gophkeeper.PullRequest
+	(*PullResponse)(nil), // 4: gophkeeper.PullResponse
+	(*PushRequest)(nil),  // 5: gophkeeper.PushRequest
+	(*ChangeResult)(nil), // 6: gophkeeper.ChangeResult
+	(*PushResponse)(nil), // 7: gophkeeper.PushResponse
+	(*Login)(nil),        // 8: gophkeeper.Login
+	(*Note)(nil),         // 9: gophkeeper.Note
+	(*Binary)(nil),       // 10: gophkeeper.Binary
+	(*Card)(nil),         // 11: gophkeeper.Card
+	(*OTP)(nil),          // 12: gophkeeper.OTP
+}
+var file_sync_proto_depIdxs = []int32{
+	0,  // 0: gophkeeper.Tombstone.kind:type_name -> gophkeeper.DataKind
+	8,  // 1: gophkeeper.Change.login:type_name -> gophkeeper.Login
+	9,  // 2: gophkeeper.Change.note:type_name -> gophkeeper.Note
+	10, // 3: gophkeeper.Change.binary:type_name -> gophkeeper.Binary
+	11, // 4: gophkeeper.Change.card:type_name -> gophkeeper.Card
+	1,  // 5: gophkeeper.Change.removed:type_name -> gophkeeper.Tombstone
+	12, // 6: gophkeeper.Change.otp:type_name -> gophkeeper.OTP
+	2,  // 7: gophkeeper.PullResponse.changes:type_name -> gophkeeper.Change
+	2,  // 8: gophkeeper.PushRequest.changes:type_name -> gophkeeper.Change
+	6,  // 9: gophkeeper.PushResponse.results:type_name -> gophkeeper.ChangeResult
+	3,  // 10: gophkeeper.SyncService.Pull:input_type -> gophkeeper.PullRequest
+	5,  // 11: gophkeeper.SyncService.Push:input_type -> gophkeeper.PushRequest
+	4,  // 12: gophkeeper.SyncService.Pull:output_type -> gophkeeper.PullResponse
+	7,  // 13: gophkeeper.SyncService.Push:output_type -> gophkeeper.PushResponse
+	12, // [12:14] is the sub-list for method output_type
+	10, // [10:12] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
+}
+
+func init() { file_sync_proto_init() }
+func file_sync_proto_init() {
+	if File_sync_proto != nil {
+		return
+	}
+	file_binary_proto_init()
+	file_card_proto_init()
+	file_login_proto_init()
+	file_note_proto_init()
+	file_otp_proto_init()
+	file_sync_proto_msgTypes[1].OneofWrappers = []any{
+		(*change_Login)(nil),
+		(*change_Note)(nil),
+		(*change_Binary)(nil),
+		(*change_Card)(nil),
+		(*change_Removed)(nil),
+		(*change_Otp)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_sync_proto_rawDesc), len(file_sync_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_sync_proto_goTypes,
+		DependencyIndexes: file_sync_proto_depIdxs,
+		EnumInfos:         file_sync_proto_enumTypes,
+		MessageInfos:      file_sync_proto_msgTypes,
+	}.Build()
+	File_sync_proto = out.File
+	file_sync_proto_goTypes = nil
+	file_sync_proto_depIdxs = nil
+}
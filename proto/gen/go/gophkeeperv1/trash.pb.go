@@ -0,0 +1,687 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        v6.30.2
+// source: trash.proto
+
+package gophkeeperv1
+
+import (
+	empty "github.com/golang/protobuf/ptypes/empty"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Запись в корзине. Данные содержат версию записи на момент удаления.
+type TrashItem struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Kind        DataKind               `protobuf:"varint,1,opt,name=kind,enum=gophkeeper.DataKind"`
+	xxx_hidden_Id          int64                  `protobuf:"varint,2,opt,name=id"`
+	xxx_hidden_DeletedAt   *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=deleted_at,json=deletedAt"`
+	xxx_hidden_Data        isTrashItem_Data       `protobuf_oneof:"data"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *TrashItem) Reset() {
+	*x = TrashItem{}
+	mi := &file_trash_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrashItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrashItem) ProtoMessage() {}
+
+func (x *TrashItem) ProtoReflect() protoreflect.Message {
+	mi := &file_trash_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *TrashItem) GetKind() DataKind {
+	if x != nil {
+		if protoimpl.X.Present(&(x.XXX_presence[0]), 0) {
+			return x.xxx_hidden_Kind
+		}
+	}
+	return DataKind_DATA_KIND_UNSPECIFIED
+}
+
+func (x *TrashItem) GetId() int64 {
+	if x != nil {
+		return x.xxx_hidden_Id
+	}
+	return 0
+}
+
+func (x *TrashItem) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_DeletedAt
+	}
+	return nil
+}
+
+func (x *TrashItem) GetLogin() *Login {
+	if x != nil {
+		if x, ok := x.xxx_hidden_Data.(*trashItem_Login); ok {
+			return x.Login
+		}
+	}
+	return nil
+}
+
+func (x *TrashItem) GetNote() *Note {
+	if x != nil {
+		if x, ok := x.xxx_hidden_Data.(*trashItem_Note); ok {
+			return x.Note
+		}
+	}
+	return nil
+}
+
+func (x *TrashItem) GetBinary() *Binary {
+	if x != nil {
+		if x, ok := x.xxx_hidden_Data.(*trashItem_Binary); ok {
+			return x.Binary
+		}
+	}
+	return nil
+}
+
+func (x *TrashItem) GetCard() *Card {
+	if x != nil {
+		if x, ok := x.xxx_hidden_Data.(*trashItem_Card); ok {
+			return x.Card
+		}
+	}
+	return nil
+}
+
+func (x *TrashItem) GetOtp() *OTP {
+	if x != nil {
+		if x, ok := x.xxx_hidden_Data.(*trashItem_Otp); ok {
+			return x.Otp
+		}
+	}
+	return nil
+}
+
+func (x *TrashItem) SetKind(v DataKind) {
+	x.xxx_hidden_Kind = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 4)
+}
+
+func (x *TrashItem) SetId(v int64) {
+	x.xxx_hidden_Id = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 4)
+}
+
+func (x *TrashItem) SetDeletedAt(v *timestamppb.Timestamp) {
+	x.xxx_hidden_DeletedAt = v
+}
+
+func (x *TrashItem) SetLogin(v *Login) {
+	if v == nil {
+		x.xxx_hidden_Data = nil
+		return
+	}
+	x.xxx_hidden_Data = &trashItem_Login{v}
+}
+
+func (x *TrashItem) SetNote(v *Note) {
+	if v == nil {
+		x.xxx_hidden_Data = nil
+		return
+	}
+	x.xxx_hidden_Data = &trashItem_Note{v}
+}
+
+func (x *TrashItem) SetBinary(v *Binary) {
+	if v == nil {
+		x.xxx_hidden_Data = nil
+		return
+	}
+	x.xxx_hidden_Data = &trashItem_Binary{v}
+}
+
+func (x *TrashItem) SetCard(v *Card) {
+	if v == nil {
+		x.xxx_hidden_Data = nil
+		return
+	}
+	x.xxx_hidden_Data = &trashItem_Card{v}
+}
+
+func (x *TrashItem) SetOtp(v *OTP) {
+	if v == nil {
+		x.xxx_hidden_Data = nil
+		return
+	}
+	x.xxx_hidden_Data = &trashItem_Otp{v}
+}
+
+func (x *TrashItem) HasKind() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *TrashItem) HasId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *TrashItem) HasDeletedAt() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_DeletedAt != nil
+}
+
+func (x *TrashItem) HasData() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Data != nil
+}
+
+func (x *TrashItem) HasLogin() bool {
+	if x == nil {
+		return false
+	}
+	_, ok := x.xxx_hidden_Data.(*trashItem_Login)
+	return ok
+}
+
+func (x *TrashItem) HasNote() bool {
+	if x == nil {
+		return false
+	}
+	_, ok := x.xxx_hidden_Data.(*trashItem_Note)
+	return ok
+}
+
+func (x *TrashItem) HasBinary() bool {
+	if x == nil {
+		return false
+	}
+	_, ok := x.xxx_hidden_Data.(*trashItem_Binary)
+	return ok
+}
+
+func (x *TrashItem) HasCard() bool {
+	if x == nil {
+		return false
+	}
+	_, ok := x.xxx_hidden_Data.(*trashItem_Card)
+	return ok
+}
+
+func (x *TrashItem) HasOtp() bool {
+	if x == nil {
+		return false
+	}
+	_, ok := x.xxx_hidden_Data.(*trashItem_Otp)
+	return ok
+}
+
+func (x *TrashItem) ClearKind() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Kind = DataKind_DATA_KIND_UNSPECIFIED
+}
+
+func (x *TrashItem) ClearId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Id = 0
+}
+
+func (x *TrashItem) ClearDeletedAt() {
+	x.xxx_hidden_DeletedAt = nil
+}
+
+func (x *TrashItem) ClearData() {
+	x.xxx_hidden_Data = nil
+}
+
+func (x *TrashItem) ClearLogin() {
+	if _, ok := x.xxx_hidden_Data.(*trashItem_Login); ok {
+		x.xxx_hidden_Data = nil
+	}
+}
+
+func (x *TrashItem) ClearNote() {
+	if _, ok := x.xxx_hidden_Data.(*trashItem_Note); ok {
+		x.xxx_hidden_Data = nil
+	}
+}
+
+func (x *TrashItem) ClearBinary() {
+	if _, ok := x.xxx_hidden_Data.(*trashItem_Binary); ok {
+		x.xxx_hidden_Data = nil
+	}
+}
+
+func (x *TrashItem) ClearCard() {
+	if _, ok := x.xxx_hidden_Data.(*trashItem_Card); ok {
+		x.xxx_hidden_Data = nil
+	}
+}
+
+func (x *TrashItem) ClearOtp() {
+	if _, ok := x.xxx_hidden_Data.(*trashItem_Otp); ok {
+		x.xxx_hidden_Data = nil
+	}
+}
+
+const TrashItem_Data_not_set_case case_TrashItem_Data = 0
+const TrashItem_Login_case case_TrashItem_Data = 4
+const TrashItem_Note_case case_TrashItem_Data = 5
+const TrashItem_Binary_case case_TrashItem_Data = 6
+const TrashItem_Card_case case_TrashItem_Data = 7
+const TrashItem_Otp_case case_TrashItem_Data = 8
+
+func (x *TrashItem) WhichData() case_TrashItem_Data {
+	if x == nil {
+		return TrashItem_Data_not_set_case
+	}
+	switch x.xxx_hidden_Data.(type) {
+	case *trashItem_Login:
+		return TrashItem_Login_case
+	case *trashItem_Note:
+		return TrashItem_Note_case
+	case *trashItem_Binary:
+		return TrashItem_Binary_case
+	case *trashItem_Card:
+		return TrashItem_Card_case
+	case *trashItem_Otp:
+		return TrashItem_Otp_case
+	default:
+		return TrashItem_Data_not_set_case
+	}
+}
+
+type TrashItem_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Kind      *DataKind
+	Id        *int64
+	DeletedAt *timestamppb.Timestamp
+	// Fields of oneof xxx_hidden_Data:
+	Login  *Login
+	Note   *Note
+	Binary *Binary
+	Card   *Card
+	Otp    *OTP
+	// -- end of xxx_hidden_Data
+}
+
+func (b0 TrashItem_builder) Build() *TrashItem {
+	m0 := &TrashItem{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Kind != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 4)
+		x.xxx_hidden_Kind = *b.Kind
+	}
+	if b.Id != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 4)
+		x.xxx_hidden_Id = *b.Id
+	}
+	x.xxx_hidden_DeletedAt = b.DeletedAt
+	if b.Login != nil {
+		x.xxx_hidden_Data = &trashItem_Login{b.Login}
+	}
+	if b.Note != nil {
+		x.xxx_hidden_Data = &trashItem_Note{b.Note}
+	}
+	if b.Binary != nil {
+		x.xxx_hidden_Data = &trashItem_Binary{b.Binary}
+	}
+	if b.Card != nil {
+		x.xxx_hidden_Data = &trashItem_Card{b.Card}
+	}
+	if b.Otp != nil {
+		x.xxx_hidden_Data = &trashItem_Otp{b.Otp}
+	}
+	return m0
+}
+
+type case_TrashItem_Data protoreflect.FieldNumber
+
+func (x case_TrashItem_Data) String() string {
+	md := file_trash_proto_msgTypes[0].Descriptor()
+	if x == 0 {
+		return "not set"
+	}
+	return protoimpl.X.MessageFieldStringOf(md, protoreflect.FieldNumber(x))
+}
+
+type isTrashItem_Data interface {
+	isTrashItem_Data()
+}
+
+type trashItem_Login struct {
+	Login *Login `protobuf:"bytes,4,opt,name=login,oneof"`
+}
+
+type trashItem_Note struct {
+	Note *Note `protobuf:"bytes,5,opt,name=note,oneof"`
+}
+
+type trashItem_Binary struct {
+	Binary *Binary `protobuf:"bytes,6,opt,name=binary,oneof"`
+}
+
+type trashItem_Card struct {
+	Card *Card `protobuf:"bytes,7,opt,name=card,oneof"`
+}
+
+type trashItem_Otp struct {
+	Otp *OTP `protobuf:"bytes,8,opt,name=otp,oneof"`
+}
+
+func (*trashItem_Login) isTrashItem_Data() {}
+
+func (*trashItem_Note) isTrashItem_Data() {}
+
+func (*trashItem_Binary) isTrashItem_Data() {}
+
+func (*trashItem_Card) isTrashItem_Data() {}
+
+func (*trashItem_Otp) isTrashItem_Data() {}
+
+type ListTrashResponse struct {
+	state            protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Items *[]*TrashItem          `protobuf:"bytes,1,rep,name=items"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ListTrashResponse) Reset() {
+	*x = ListTrashResponse{}
+	mi := &file_trash_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTrashResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTrashResponse) ProtoMessage() {}
+
+func (x *ListTrashResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_trash_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ListTrashResponse) GetItems() []*TrashItem {
+	if x != nil {
+		if x.xxx_hidden_Items != nil {
+			return *x.xxx_hidden_Items
+		}
+	}
+	return nil
+}
+
+func (x *ListTrashResponse) SetItems(v []*TrashItem) {
+	x.xxx_hidden_Items = &v
+}
+
+type ListTrashResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Записи от удаленных последними к удаленным первыми.
+	Items []*TrashItem
+}
+
+func (b0 ListTrashResponse_builder) Build() *ListTrashResponse {
+	m0 := &ListTrashResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Items = &b.Items
+	return m0
+}
+
+type TrashItemRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Kind        DataKind               `protobuf:"varint,1,opt,name=kind,enum=gophkeeper.DataKind"`
+	xxx_hidden_Id          int64                  `protobuf:"varint,2,opt,name=id"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *TrashItemRequest) Reset() {
+	*x = TrashItemRequest{}
+	mi := &file_trash_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrashItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrashItemRequest) ProtoMessage() {}
+
+func (x *TrashItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_trash_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *TrashItemRequest) GetKind() DataKind {
+	if x != nil {
+		if protoimpl.X.Present(&(x.XXX_presence[0]), 0) {
+			return x.xxx_hidden_Kind
+		}
+	}
+	return DataKind_DATA_KIND_UNSPECIFIED
+}
+
+func (x *TrashItemRequest) GetId() int64 {
+	if x != nil {
+		return x.xxx_hidden_Id
+	}
+	return 0
+}
+
+func (x *TrashItemRequest) SetKind(v DataKind) {
+	x.xxx_hidden_Kind = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+}
+
+func (x *TrashItemRequest) SetId(v int64) {
+	x.xxx_hidden_Id = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
+}
+
+func (x *TrashItemRequest) HasKind() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *TrashItemRequest) HasId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *TrashItemRequest) ClearKind() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Kind = DataKind_DATA_KIND_UNSPECIFIED
+}
+
+func (x *TrashItemRequest) ClearId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Id = 0
+}
+
+type TrashItemRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Kind *DataKind
+	Id   *int64
+}
+
+func (b0 TrashItemRequest_builder) Build() *TrashItemRequest {
+	m0 := &TrashItemRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Kind != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		x.xxx_hidden_Kind = *b.Kind
+	}
+	if b.Id != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		x.xxx_hidden_Id = *b.Id
+	}
+	return m0
+}
+
+var File_trash_proto protoreflect.FileDescriptor
+
+const file_trash_proto_rawDesc = "" +
+	"\n" +
+	"\vtrash.proto\x12\n" +
+	"gophkeeper\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\fbinary.proto\x1a\n" +
+	"card.proto\x1a\vlogin.proto\x1a\n" +
+	"note.proto\x1a\totp.proto\x1a\n" +
+	"sync.proto\"\xd6\x02\n" +
+	"\tTrashItem\x12(\n" +
+	"\x04kind\x18\x01 \x01(\x0e2\x14.gophkeeper.DataKindR\x04kind\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\x03R\x02id\x129\n" +
+	"\n" +
+	"deleted_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tdeletedAt\x12)\n" +
+	"\x05login\x18\x04 \x01(\v2\x11.gophkeeper.LoginH\x00R\x05login\x12&\n" +
+	"\x04note\x18\x05 \x01(\v2\x10.gophkeeper.NoteH\x00R\x04note\x12,\n" +
+	"\x06binary\x18\x06 \x01(\v2\x12.gophkeeper.BinaryH\x00R\x06binary\x12&\n" +
+	"\x04card\x18\a \x01(\v2\x10.gophkeeper.CardH\x00R\x04card\x12#\n" +
+	"\x03otp\x18\b \x01(\v2\x0f.gophkeeper.OTPH\x00R\x03otpB\x06\n" +
+	"\x04data\"@\n" +
+	"\x11ListTrashResponse\x12+\n" +
+	"\x05items\x18\x01 \x03(\v2\x15.gophkeeper.TrashItemR\x05items\"L\n" +
+	"\x10TrashItemRequest\x12(\n" +
+	"\x04kind\x18\x01 \x01(\x0e2\x14.gophkeeper.DataKindR\x04kind\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\x03R\x02id2\xd2\x01\n" +
+	"\fTrashService\x12B\n" +
+	"\tListTrash\x12\x16.google.protobuf.Empty\x1a\x1d.gophkeeper.ListTrashResponse\x12?\n" +
+	"\aRestore\x12\x1c.gophkeeper.TrashItemRequest\x1a\x16.google.protobuf.Empty\x12=\n" +
+	"\x05Purge\x12\x1c.gophkeeper.TrashItemRequest\x1a\x16.google.protobuf.EmptyB\x1cZ\x1agophkeeper.v1;gophkeeperv1b\beditionsp\xe8\a"
+
+var file_trash_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_trash_proto_goTypes = []any{
+	(*TrashItem)(nil),             // 0: gophkeeper.TrashItem
+	(*ListTrashResponse)(nil),     // 1: gophkeeper.ListTrashResponse
+	(*TrashItemRequest)(nil),      // 2: gophkeeper.TrashItemRequest
+	(DataKind)(0),                 // 3: gophkeeper.DataKind
+	(*timestamppb.Timestamp)(nil), // 4: google.protobuf.Timestamp
+	(*Login)(nil),                 // 5: gophkeeper.Login
+	(*Note)(nil),                  // 6: gophkeeper.Note
+	(*Binary)(nil),                // 7: gophkeeper.Binary
+	(*Card)(nil),                  // 8: gophkeeper.Card
+	(*OTP)(nil),                   // 9: gophkeeper.OTP
+	(*empty.Empty)(nil),           // 10: google.protobuf.Empty
+}
+var file_trash_proto_depIdxs = []int32{
+	3,  // 0: gophkeeper.TrashItem.kind:type_name -> gophkeeper.DataKind
+	4,  // 1: gophkeeper.TrashItem.deleted_at:type_name -> google.protobuf.Timestamp
+	5,  // 2: gophkeeper.TrashItem.login:type_name -> gophkeeper.Login
+	6,  // 3: gophkeeper.TrashItem.note:type_name -> gophkeeper.Note
+	7,  // 4: gophkeeper.TrashItem.binary:type_name -> gophkeeper.Binary
+	8,  // 5: gophkeeper.TrashItem.card:type_name -> gophkeeper.Card
+	9,  // 6: gophkeeper.TrashItem.otp:type_name -> gophkeeper.OTP
+	0,  // 7: gophkeeper.ListTrashResponse.items:type_name -> gophkeeper.TrashItem
+	3,  // 8: gophkeeper.TrashItemRequest.kind:type_name -> gophkeeper.DataKind
+	10, // 9: gophkeeper.TrashService.ListTrash:input_type -> google.protobuf.Empty
+	2,  // 10: gophkeeper.TrashService.Restore:input_type -> gophkeeper.TrashItemRequest
+	2,  // 11: gophkeeper.TrashService.Purge:input_type -> gophkeeper.TrashItemRequest
+	1,  // 12: gophkeeper.TrashService.ListTrash:output_type -> gophkeeper.ListTrashResponse
+	10, // 13: gophkeeper.TrashService.Restore:output_type -> google.protobuf.Empty
+	10, // 14: gophkeeper.TrashService.Purge:output_type -> google.protobuf.Empty
+	12, // [12:15] is the sub-list for method output_type
+	9,  // [9:12] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
+}
+
+func init() { file_trash_proto_init() }
+func file_trash_proto_init() {
+	if File_trash_proto != nil {
+		return
+	}
+	file_binary_proto_init()
+	file_card_proto_init()
+	file_login_proto_init()
+	file_note_proto_init()
+	file_otp_proto_init()
+	file_sync_proto_init()
+	file_trash_proto_msgTypes[0].OneofWrappers = []any{
+		(*trashItem_Login)(nil),
+		(*trashItem_Note)(nil),
+		(*trashItem_Binary)(nil),
+		(*trashItem_Card)(nil),
+		(*trashItem_Otp)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_trash_proto_rawDesc), len(file_trash_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_trash_proto_goTypes,
+		DependencyIndexes: file_trash_proto_depIdxs,
+		MessageInfos:      file_trash_proto_msgTypes,
+	}.Build()
+	File_trash_proto = out.File
+	file_trash_proto_goTypes = nil
+	file_trash_proto_depIdxs = nil
+}
@@ -0,0 +1,202 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v6.30.2
+// source: trash.proto
+
+package gophkeeperv1
+
+import (
+	context "context"
+	empty "github.com/golang/protobuf/ptypes/empty"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	TrashService_ListTrash_FullMethodName = "/gophkeeper.TrashService/ListTrash"
+	TrashService_Restore_FullMethodName   = "/gophkeeper.TrashService/Restore"
+	TrashService_Purge_FullMethodName     = "/gophkeeper.TrashService/Purge"
+)
+
+// TrashServiceClient is the client API for TrashService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type TrashServiceClient interface {
+	ListTrash(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*ListTrashResponse, error)
+	// Restore возвращает запись из корзины с прежним идентификатором.
+	Restore(ctx context.Context, in *TrashItemRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// Purge окончательно удаляет запись из корзины вместе с ее историей.
+	Purge(ctx context.Context, in *TrashItemRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+}
+
+type trashServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTrashServiceClient(cc grpc.ClientConnInterface) TrashServiceClient {
+	return &trashServiceClient{cc}
+}
+
+func (c *trashServiceClient) ListTrash(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*ListTrashResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTrashResponse)
+	err := c.cc.Invoke(ctx, TrashService_ListTrash_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trashServiceClient) Restore(ctx context.Context, in *TrashItemRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, TrashService_Restore_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trashServiceClient) Purge(ctx context.Context, in *TrashItemRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, TrashService_Purge_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TrashServiceServer is the server API for TrashService service.
+// All implementations must embed UnimplementedTrashServiceServer
+// for forward compatibility.
+type TrashServiceServer interface {
+	ListTrash(context.Context, *empty.Empty) (*ListTrashResponse, error)
+	// Restore возвращает запись из корзины с прежним идентификатором.
+	Restore(context.Context, *TrashItemRequest) (*empty.Empty, error)
+	// Purge окончательно удаляет запись из корзины вместе с ее историей.
+	Purge(context.Context, *TrashItemRequest) (*empty.Empty, error)
+	mustEmbedUnimplementedTrashServiceServer()
+}
+
+// UnimplementedTrashServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedTrashServiceServer struct{}
+
+func (UnimplementedTrashServiceServer) ListTrash(context.Context, *empty.Empty) (*ListTrashResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTrash not implemented")
+}
+func (UnimplementedTrashServiceServer) Restore(context.Context, *TrashItemRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Restore not implemented")
+}
+func (UnimplementedTrashServiceServer) Purge(context.Context, *TrashItemRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Purge not implemented")
+}
+func (UnimplementedTrashServiceServer) mustEmbedUnimplementedTrashServiceServer() {}
+func (UnimplementedTrashServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeTrashServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TrashServiceServer will
+// result in compilation errors.
+type UnsafeTrashServiceServer interface {
+	mustEmbedUnimplementedTrashServiceServer()
+}
+
+func RegisterTrashServiceServer(s grpc.ServiceRegistrar, srv TrashServiceServer) {
+	// If the following call pancis, it indicates UnimplementedTrashServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&TrashService_ServiceDesc, srv)
+}
+
+func _TrashService_ListTrash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrashServiceServer).ListTrash(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TrashService_ListTrash_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrashServiceServer).ListTrash(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrashService_Restore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TrashItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrashServiceServer).Restore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TrashService_Restore_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrashServiceServer).Restore(ctx, req.(*TrashItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrashService_Purge_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TrashItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrashServiceServer).Purge(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TrashService_Purge_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrashServiceServer).Purge(ctx, req.(*TrashItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// TrashService_ServiceDesc is the grpc.ServiceDesc for TrashService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TrashService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "gophkeeper.TrashService",
+	HandlerType: (*TrashServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListTrash",
+			Handler:    _TrashService_ListTrash_Handler,
+		},
+		{
+			MethodName: "Restore",
+			Handler:    _TrashService_Restore_Handler,
+		},
+		{
+			MethodName: "Purge",
+			Handler:    _TrashService_Purge_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "trash.proto",
+}
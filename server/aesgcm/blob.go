@@ -0,0 +1,86 @@
+package aesgcm
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/mkolibaba/gophkeeper/server"
+	"io"
+)
+
+// BlobStore шифрует содержимое перед сохранением в next. Содержимое,
+// сохраненное до включения шифрования, читается как есть.
+type BlobStore struct {
+	next    server.BlobStore
+	keyring *Keyring
+}
+
+func NewBlobStore(next server.BlobStore, keyring *Keyring) *BlobStore {
+	return &BlobStore{
+		next:    next,
+		keyring: keyring,
+	}
+}
+
+func (s *BlobStore) Put(ctx context.Context, key string, r io.Reader) error {
+	sealed, err := s.keyring.SealStream(r)
+	if err != nil {
+		return fmt.Errorf("put: %w", err)
+	}
+	return s.next.Put(ctx, key, sealed)
+}
+
+func (s *BlobStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	content, err := s.next.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	// Содержимое, сохраненное до включения шифрования, возвращается как есть.
+	buffered := bufio.NewReader(content)
+	if magic, _ := buffered.Peek(len(streamMagic)); !bytes.Equal(magic, streamMagic) {
+		return readCloser{Reader: buffered, Closer: content}, nil
+	}
+
+	opened, err := s.keyring.OpenStream(buffered)
+	if err != nil {
+		content.Close()
+		return nil, fmt.Errorf("get: %w", err)
+	}
+	return readCloser{Reader: opened, Closer: content}, nil
+}
+
+func (s *BlobStore) Delete(ctx context.Context, key string) error {
+	return s.next.Delete(ctx, key)
+}
+
+// Stat возвращает размер расшифрованного содержимого. Чтобы узнать,
+// зашифровано ли содержимое, читается его заголовок.
+func (s *BlobStore) Stat(ctx context.Context, key string) (server.BlobInfo, error) {
+	info, err := s.next.Stat(ctx, key)
+	if err != nil {
+		return server.BlobInfo{}, err
+	}
+
+	content, err := s.next.Get(ctx, key)
+	if err != nil {
+		return server.BlobInfo{}, err
+	}
+	defer content.Close()
+
+	header := make([]byte, len(streamMagic))
+	if _, err := io.ReadFull(content, header); err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return server.BlobInfo{}, fmt.Errorf("stat: %w", err)
+	}
+	if bytes.Equal(header, streamMagic) {
+		info.Size = plaintextSize(info.Size)
+	}
+	return info, nil
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
@@ -0,0 +1,86 @@
+package aesgcm
+
+import (
+	"bytes"
+	"crypto/rand"
+	"github.com/mkolibaba/gophkeeper/server"
+	"github.com/mkolibaba/gophkeeper/server/fs"
+	"github.com/stretchr/testify/require"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestBlobStore(t *testing.T) {
+	var config server.Config
+	config.Blob.FS.Folder = t.TempDir()
+
+	plain, err := fs.NewBlobStore(&config)
+	require.NoError(t, err)
+	keyring, err := New(bytes.Repeat([]byte{1}, KeySize))
+	require.NoError(t, err)
+	store := NewBlobStore(plain, keyring)
+
+	for _, size := range []int{0, 1, segmentSize, segmentSize + 1, 3*segmentSize - 7} {
+		content := make([]byte, size)
+		_, _ = rand.Read(content)
+
+		require.NoError(t, store.Put(t.Context(), "1", bytes.NewReader(content)))
+
+		stored, err := os.ReadFile(filepath.Join(config.Blob.FS.Folder, "1"))
+		require.NoError(t, err)
+		if size >= 16 {
+			require.False(t, bytes.Contains(stored, content), "size %d", size)
+		}
+
+		info, err := store.Stat(t.Context(), "1")
+		require.NoError(t, err)
+		require.EqualValues(t, size, info.Size, "size %d", size)
+
+		require.Equal(t, content, mustReadBlob(t, store, "1"), "size %d", size)
+	}
+
+	t.Run("plaintext", func(t *testing.T) {
+		// Содержимое, сохраненное до включения шифрования.
+		require.NoError(t, plain.Put(t.Context(), "2", bytes.NewReader([]byte("content"))))
+
+		info, err := store.Stat(t.Context(), "2")
+		require.NoError(t, err)
+		require.EqualValues(t, 7, info.Size)
+		require.Equal(t, "content", string(mustReadBlob(t, store, "2")))
+	})
+	t.Run("truncated", func(t *testing.T) {
+		content := make([]byte, 2*segmentSize)
+		require.NoError(t, store.Put(t.Context(), "3", bytes.NewReader(content)))
+
+		// Отрезаем последний сегмент целиком.
+		path := filepath.Join(config.Blob.FS.Folder, "3")
+		require.NoError(t, os.Truncate(path, int64(streamHeaderSize+segmentSize+tagSize)))
+
+		r, err := store.Get(t.Context(), "3")
+		require.NoError(t, err)
+		defer r.Close()
+		_, err = io.ReadAll(r)
+		require.Error(t, err)
+	})
+	t.Run("unknown_key", func(t *testing.T) {
+		require.NoError(t, store.Put(t.Context(), "4", bytes.NewReader([]byte("content"))))
+
+		other, err := New(bytes.Repeat([]byte{2}, KeySize))
+		require.NoError(t, err)
+		_, err = NewBlobStore(plain, other).Get(t.Context(), "4")
+		require.ErrorIs(t, err, server.ErrUnknownKey)
+	})
+}
+
+func mustReadBlob(t *testing.T, store server.BlobStore, key string) []byte {
+	t.Helper()
+
+	r, err := store.Get(t.Context(), key)
+	require.NoError(t, err)
+	defer r.Close()
+	content, err := io.ReadAll(r)
+	require.NoError(t, err)
+	return content
+}
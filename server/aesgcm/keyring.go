@@ -0,0 +1,181 @@
+package aesgcm
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"github.com/mkolibaba/gophkeeper/server"
+	"os"
+	"strings"
+)
+
+const (
+	// KeySize - размер мастер-ключа в байтах (AES-256).
+	KeySize = 32
+
+	// version - версия формата зашифрованных данных.
+	version = 1
+
+	// headerSize - размер заголовка: версия и id ключа.
+	headerSize = 1 + 4
+)
+
+var errMalformed = errors.New("malformed ciphertext")
+
+// Keyring шифрует данные AES-256-GCM. Данные начинаются с заголовка
+// с версией формата и id ключа, поэтому их можно расшифровать и после
+// смены текущего ключа, если прежний ключ остался в связке.
+type Keyring struct {
+	current *key
+	keys    map[uint32]*key
+}
+
+type key struct {
+	id   uint32
+	aead cipher.AEAD
+	// raw нужен для вывода ключей потоков содержимого.
+	raw []byte
+}
+
+// NewKeyring создает связку из мастер-ключа и прежних ключей из
+// конфигурации. Если мастер-ключ не задан, шифрование выключено
+// и возвращается nil.
+func NewKeyring(config *server.Config) (*Keyring, error) {
+	encoded := config.Encryption.Key
+	if encoded == "" && config.Encryption.KeyFile != "" {
+		data, err := os.ReadFile(config.Encryption.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("new keyring: %w", err)
+		}
+		encoded = strings.TrimSpace(string(data))
+	}
+	if encoded == "" {
+		return nil, nil
+	}
+
+	current, err := decodeKey(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("new keyring: %w", err)
+	}
+
+	previous := make([][]byte, 0, len(config.Encryption.PreviousKeys))
+	for _, encoded := range config.Encryption.PreviousKeys {
+		k, err := decodeKey(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("new keyring: previous key: %w", err)
+		}
+		previous = append(previous, k)
+	}
+
+	keyring, err := New(current, previous...)
+	if err != nil {
+		return nil, fmt.Errorf("new keyring: %w", err)
+	}
+	return keyring, nil
+}
+
+// New создает связку с текущим ключом current и прежними ключами previous.
+func New(current []byte, previous ...[]byte) (*Keyring, error) {
+	k := &Keyring{keys: make(map[uint32]*key)}
+	for i, raw := range append([][]byte{current}, previous...) {
+		key, err := newKey(raw)
+		if err != nil {
+			return nil, err
+		}
+		if i == 0 {
+			k.current = key
+		}
+		if _, ok := k.keys[key.id]; !ok {
+			k.keys[key.id] = key
+		}
+	}
+	return k, nil
+}
+
+func (k *Keyring) Seal(plaintext []byte) ([]byte, error) {
+	out := make([]byte, headerSize, headerSize+k.current.aead.NonceSize()+len(plaintext)+k.current.aead.Overhead())
+	out[0] = version
+	binary.BigEndian.PutUint32(out[1:headerSize], k.current.id)
+
+	nonce := out[headerSize : headerSize+k.current.aead.NonceSize()]
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("seal: %w", err)
+	}
+	out = out[:headerSize+len(nonce)]
+
+	// Заголовок входит в дополнительные данные, чтобы его нельзя было
+	// подменить.
+	return k.current.aead.Seal(out, nonce, plaintext, out[:headerSize]), nil
+}
+
+func (k *Keyring) Open(ciphertext []byte) ([]byte, error) {
+	key, err := k.keyFor(ciphertext)
+	if err != nil {
+		return nil, fmt.Errorf("open: %w", err)
+	}
+
+	nonceSize := key.aead.NonceSize()
+	if len(ciphertext) < headerSize+nonceSize+key.aead.Overhead() {
+		return nil, fmt.Errorf("open: %w", errMalformed)
+	}
+	nonce := ciphertext[headerSize : headerSize+nonceSize]
+
+	plaintext, err := key.aead.Open(nil, nonce, ciphertext[headerSize+nonceSize:], ciphertext[:headerSize])
+	if err != nil {
+		return nil, fmt.Errorf("open: %w", err)
+	}
+	return plaintext, nil
+}
+
+// keyFor возвращает ключ, которым зашифрованы данные с заголовком header.
+func (k *Keyring) keyFor(header []byte) (*key, error) {
+	if len(header) < headerSize || header[0] != version {
+		return nil, errMalformed
+	}
+	key, ok := k.keys[binary.BigEndian.Uint32(header[1:headerSize])]
+	if !ok {
+		return nil, server.ErrUnknownKey
+	}
+	return key, nil
+}
+
+func newKey(raw []byte) (*key, error) {
+	if len(raw) != KeySize {
+		return nil, fmt.Errorf("key must be %d bytes, got %d", KeySize, len(raw))
+	}
+
+	aead, err := newAEAD(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	// id - начало хэша ключа: по нему ключ находится при расшифровке,
+	// а сам ключ не раскрывается.
+	sum := sha256.Sum256(raw)
+	return &key{
+		id:   binary.BigEndian.Uint32(sum[:4]),
+		aead: aead,
+		raw:  raw,
+	}, nil
+}
+
+func newAEAD(raw []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(raw)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func decodeKey(encoded string) ([]byte, error) {
+	raw, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("decode key: %w", err)
+	}
+	return raw, nil
+}
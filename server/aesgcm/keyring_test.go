@@ -0,0 +1,91 @@
+package aesgcm
+
+import (
+	"bytes"
+	"encoding/base64"
+	"github.com/mkolibaba/gophkeeper/server"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestKeyring(t *testing.T) {
+	oldKey := bytes.Repeat([]byte{1}, KeySize)
+	newKey := bytes.Repeat([]byte{2}, KeySize)
+
+	old, err := New(oldKey)
+	require.NoError(t, err)
+	rotated, err := New(newKey, oldKey)
+	require.NoError(t, err)
+
+	t.Run("seal_open", func(t *testing.T) {
+		sealed, err := old.Seal([]byte("secret"))
+		require.NoError(t, err)
+		require.NotContains(t, string(sealed), "secret")
+
+		opened, err := old.Open(sealed)
+		require.NoError(t, err)
+		require.Equal(t, "secret", string(opened))
+
+		// Одинаковые данные шифруются по-разному.
+		again, err := old.Seal([]byte("secret"))
+		require.NoError(t, err)
+		require.NotEqual(t, sealed, again)
+	})
+	t.Run("previous_key", func(t *testing.T) {
+		sealed, err := old.Seal([]byte("secret"))
+		require.NoError(t, err)
+
+		opened, err := rotated.Open(sealed)
+		require.NoError(t, err)
+		require.Equal(t, "secret", string(opened))
+
+		resealed, err := rotated.Seal(opened)
+		require.NoError(t, err)
+		_, err = old.Open(resealed)
+		require.ErrorIs(t, err, server.ErrUnknownKey)
+	})
+	t.Run("tampered", func(t *testing.T) {
+		sealed, err := old.Seal([]byte("secret"))
+		require.NoError(t, err)
+		sealed[len(sealed)-1] ^= 1
+
+		_, err = old.Open(sealed)
+		require.Error(t, err)
+
+		_, err = old.Open([]byte{version})
+		require.Error(t, err)
+	})
+	t.Run("invalid_key", func(t *testing.T) {
+		_, err := New([]byte("short"))
+		require.Error(t, err)
+	})
+}
+
+func TestNewKeyring(t *testing.T) {
+	key := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{1}, KeySize))
+
+	t.Run("disabled", func(t *testing.T) {
+		keyring, err := NewKeyring(&server.Config{})
+		require.NoError(t, err)
+		require.Nil(t, keyring)
+	})
+	t.Run("key_file", func(t *testing.T) {
+		var config server.Config
+		config.Encryption.KeyFile = filepath.Join(t.TempDir(), "key")
+		require.NoError(t, os.WriteFile(config.Encryption.KeyFile, []byte(key+"\n"), 0600))
+
+		keyring, err := NewKeyring(&config)
+		require.NoError(t, err)
+		require.NotNil(t, keyring)
+	})
+	t.Run("invalid_previous_key", func(t *testing.T) {
+		var config server.Config
+		config.Encryption.Key = key
+		config.Encryption.PreviousKeys = []string{"not base64"}
+
+		_, err := NewKeyring(&config)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,217 @@
+package aesgcm
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/cipher"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const (
+	// segmentSize - размер открытого текста в одном сегменте потока.
+	segmentSize = 64 * 1024
+
+	// tagSize - размер тега аутентификации GCM.
+	tagSize = 16
+
+	saltSize = 16
+)
+
+// streamMagic отличает зашифрованное содержимое от сохраненного до
+// включения шифрования.
+var streamMagic = []byte("GKBE")
+
+// streamHeaderSize - размер заголовка потока: метка, заголовок ключа
+// и соль.
+var streamHeaderSize = len(streamMagic) + headerSize + saltSize
+
+// ErrNotEncrypted возвращается, если поток не зашифрован.
+var ErrNotEncrypted = errors.New("stream is not encrypted")
+
+// SealStream возвращает поток r, зашифрованный текущим ключом. Поток
+// делится на сегменты, каждый из которых шифруется отдельно, поэтому его
+// не нужно целиком держать в памяти. Сегменты шифруются ключом, выведенным
+// из мастер-ключа и случайной соли потока, а nonce сегмента - его номер
+// и признак последнего сегмента, поэтому сегменты нельзя переставить или
+// отрезать.
+func (k *Keyring) SealStream(r io.Reader) (io.Reader, error) {
+	header := make([]byte, streamHeaderSize)
+	copy(header, streamMagic)
+	header[len(streamMagic)] = version
+	binary.BigEndian.PutUint32(header[len(streamMagic)+1:], k.current.id)
+
+	salt := header[streamHeaderSize-saltSize:]
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+
+	aead, err := k.current.streamAEAD(salt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sealReader{
+		src:   bufio.NewReader(r),
+		aead:  aead,
+		plain: make([]byte, segmentSize),
+		out:   header,
+	}, nil
+}
+
+// OpenStream возвращает расшифрованный поток r. Если r не начинается
+// с заголовка потока, возвращается ErrNotEncrypted.
+func (k *Keyring) OpenStream(r io.Reader) (io.Reader, error) {
+	header := make([]byte, streamHeaderSize)
+	if _, err := io.ReadFull(r, header); errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, ErrNotEncrypted
+	} else if err != nil {
+		return nil, err
+	}
+	if !bytes.HasPrefix(header, streamMagic) {
+		return nil, ErrNotEncrypted
+	}
+
+	key, err := k.keyFor(header[len(streamMagic):])
+	if err != nil {
+		return nil, err
+	}
+	aead, err := key.streamAEAD(header[streamHeaderSize-saltSize:])
+	if err != nil {
+		return nil, err
+	}
+
+	return &openReader{
+		src:     bufio.NewReader(r),
+		aead:    aead,
+		segment: make([]byte, segmentSize+tagSize),
+	}, nil
+}
+
+// streamAEAD возвращает шифр потока с солью salt.
+func (k *key) streamAEAD(salt []byte) (cipher.AEAD, error) {
+	raw, err := hkdf.Key(sha256.New, k.raw, salt, "gophkeeper blob", KeySize)
+	if err != nil {
+		return nil, err
+	}
+	return newAEAD(raw)
+}
+
+// segmentNonce возвращает nonce сегмента с номером counter.
+func segmentNonce(counter uint64, last bool) []byte {
+	nonce := make([]byte, 12)
+	binary.BigEndian.PutUint64(nonce, counter)
+	if last {
+		nonce[len(nonce)-1] = 1
+	}
+	return nonce
+}
+
+// plaintextSize возвращает размер открытого текста потока размера size.
+func plaintextSize(size int64) int64 {
+	body := size - int64(streamHeaderSize)
+	n := body / (segmentSize + tagSize) * segmentSize
+	if rest := body % (segmentSize + tagSize); rest > tagSize {
+		n += rest - tagSize
+	}
+	return max(n, 0)
+}
+
+type sealReader struct {
+	src     *bufio.Reader
+	aead    cipher.AEAD
+	plain   []byte
+	out     []byte
+	counter uint64
+	done    bool
+}
+
+func (r *sealReader) Read(p []byte) (int, error) {
+	for len(r.out) == 0 {
+		if r.done {
+			return 0, io.EOF
+		}
+		if err := r.next(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, r.out)
+	r.out = r.out[n:]
+	return n, nil
+}
+
+// next шифрует следующий сегмент.
+func (r *sealReader) next() error {
+	n, err := io.ReadFull(r.src, r.plain)
+	last := errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+	if err != nil && !last {
+		return err
+	}
+	if !last {
+		// Последний сегмент может быть полным: проверяем, есть ли еще данные.
+		if _, err := r.src.Peek(1); errors.Is(err, io.EOF) {
+			last = true
+		} else if err != nil {
+			return err
+		}
+	}
+
+	r.out = r.aead.Seal(r.out[:0], segmentNonce(r.counter, last), r.plain[:n], nil)
+	r.counter++
+	r.done = last
+	return nil
+}
+
+type openReader struct {
+	src     *bufio.Reader
+	aead    cipher.AEAD
+	segment []byte
+	out     []byte
+	counter uint64
+	done    bool
+}
+
+func (r *openReader) Read(p []byte) (int, error) {
+	for len(r.out) == 0 {
+		if r.done {
+			return 0, io.EOF
+		}
+		if err := r.next(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, r.out)
+	r.out = r.out[n:]
+	return n, nil
+}
+
+// next расшифровывает следующий сегмент. Если поток обрезан, тег
+// последнего прочитанного сегмента не сойдется.
+func (r *openReader) next() error {
+	n, err := io.ReadFull(r.src, r.segment)
+	last := errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+	if err != nil && !last {
+		return err
+	}
+	if !last {
+		if _, err := r.src.Peek(1); errors.Is(err, io.EOF) {
+			last = true
+		} else if err != nil {
+			return err
+		}
+	}
+
+	plain, err := r.aead.Open(r.segment[:0], segmentNonce(r.counter, last), r.segment[:n], nil)
+	if err != nil {
+		return fmt.Errorf("open segment %d: %w", r.counter, err)
+	}
+	r.out = plain
+	r.counter++
+	r.done = last
+	return nil
+}
@@ -2,10 +2,103 @@ package server
 
 import (
 	"context"
+	"errors"
+	"time"
 )
 
+var (
+	ErrSessionNotFound = errors.New("session not found")
+	ErrInvalidToken    = errors.New("invalid token")
+)
+
+// Tokens - токены сессии.
+type Tokens struct {
+	// AccessToken - короткоживущий токен для запросов к сервису.
+	AccessToken string
+	// RefreshToken - долгоживущий токен для получения новой пары токенов.
+	RefreshToken string
+}
+
+// Session - сессия пользователя. Создается при входе и действует, пока
+// не истечет refresh-токен или пользователь не отзовет ее.
+type Session struct {
+	ID    string
+	Login string
+	// RefreshTokenHash - хэш текущего refresh-токена сессии.
+	// Сам токен на сервере не хранится.
+	RefreshTokenHash []byte
+	CreatedAt        time.Time
+	LastUsedAt       time.Time
+	ExpiresAt        time.Time
+}
+
 // AuthorizationService представляет сервис авторизации.
 type AuthorizationService interface {
-	// Authorize авторизует пользователя по его login, возвращая токен.
-	Authorize(ctx context.Context, login string) (string, error)
+	// Authorize создает сессию пользователя login и возвращает ее токены.
+	Authorize(ctx context.Context, login string) (Tokens, error)
+
+	// Refresh обменивает refresh-токен на новую пару токенов. Использованный
+	// refresh-токен становится недействительным, а его повторное использование
+	// завершает сессию.
+	Refresh(ctx context.Context, refreshToken string) (Tokens, error)
+
+	// Verify проверяет access-токен и возвращает пользователя и идентификатор
+	// его сессии. Токен отозванной сессии недействителен.
+	Verify(ctx context.Context, accessToken string) (login string, sessionID string, err error)
+
+	// Challenge возвращает короткоживущий токен входа пользователя login,
+	// для которого осталось проверить второй фактор.
+	Challenge(ctx context.Context, login string) (string, error)
+
+	// VerifyChallenge проверяет токен входа и возвращает пользователя.
+	VerifyChallenge(ctx context.Context, challenge string) (login string, err error)
+}
+
+// SessionService представляет хранилище сессий.
+type SessionService interface {
+	Create(ctx context.Context, session Session) error
+	Get(ctx context.Context, id string) (*Session, error)
+	GetAll(ctx context.Context, login string) ([]Session, error)
+
+	// Rotate заменяет хэш refresh-токена сессии, если текущий хэш
+	// равен oldHash. Иначе возвращает ErrSessionNotFound.
+	Rotate(ctx context.Context, id string, oldHash []byte, session Session) error
+
+	// Remove удаляет сессию пользователя login.
+	Remove(ctx context.Context, login string, id string) error
+
+	// RemoveExpired удаляет истекшие сессии пользователя login.
+	RemoveExpired(ctx context.Context, login string, now time.Time) error
+}
+
+// LoginAttempts - неудачные попытки входа под логином.
+type LoginAttempts struct {
+	Login string
+	// Failures - число неудачных попыток подряд.
+	Failures int
+	// LockedUntil - момент, до которого вход под логином заблокирован.
+	LockedUntil time.Time
+}
+
+// LoginAttemptService представляет хранилище неудачных попыток входа.
+// Логин может не принадлежать ни одному пользователю: перебор
+// несуществующих логинов тоже ограничивается.
+type LoginAttemptService interface {
+	// Get возвращает попытки входа под логином. Если их не было,
+	// возвращает пустое значение.
+	Get(ctx context.Context, login string) (LoginAttempts, error)
+
+	// Fail учитывает неудачную попытку в момент at и возвращает число
+	// неудачных попыток подряд.
+	Fail(ctx context.Context, login string, at time.Time) (int, error)
+
+	// Lock блокирует вход под логином до until.
+	Lock(ctx context.Context, login string, until time.Time) error
+
+	// Reset сбрасывает счетчик после успешного входа.
+	Reset(ctx context.Context, login string) error
+
+	// RemoveExpired удаляет попытки логинов, последняя неудача и блокировка
+	// которых закончились раньше before.
+	RemoveExpired(ctx context.Context, before time.Time) error
 }
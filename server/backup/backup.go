@@ -0,0 +1,225 @@
+package backup
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"github.com/mkolibaba/gophkeeper/server"
+	"github.com/mkolibaba/gophkeeper/server/aesgcm"
+	"golang.org/x/crypto/argon2"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// DatabaseName - имя снимка базы данных в архиве.
+	DatabaseName = "gophkeeper.sqlite"
+
+	// blobPrefix - каталог архива с содержимым файлов.
+	blobPrefix = "blob/"
+
+	saltSize = 16
+)
+
+// magic - начало архива: метка и версия формата.
+var magic = []byte("GKBACKUP1")
+
+var (
+	ErrInvalidArchive = errors.New("invalid backup archive")
+	ErrWrongPassword  = errors.New("wrong backup password")
+)
+
+// Archive - резервная копия, распакованная в каталог.
+type Archive struct {
+	// Database - путь к снимку базы данных.
+	Database string
+	// Blobs - пути к содержимому файлов по ключам хранилища.
+	Blobs map[string]string
+}
+
+// Write записывает в w архив со снимком базы database и содержимым blobs
+// по ключам keys. Архив - tar, зашифрованный ключом, выведенным из пароля
+// password. Содержимое копируется из хранилища как есть, в том числе
+// зашифрованное ключом сервера.
+func Write(
+	ctx context.Context,
+	w io.Writer,
+	password string,
+	database string,
+	keys []string,
+	blobs server.BlobStore,
+) error {
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return fmt.Errorf("write: %w", err)
+	}
+	keyring, err := newKeyring(password, salt)
+	if err != nil {
+		return fmt.Errorf("write: %w", err)
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(writeTar(ctx, pw, database, keys, blobs))
+	}()
+	defer pr.Close()
+
+	sealed, err := keyring.SealStream(pr)
+	if err != nil {
+		return fmt.Errorf("write: %w", err)
+	}
+
+	if _, err := w.Write(append(bytes.Clone(magic), salt...)); err != nil {
+		return fmt.Errorf("write: %w", err)
+	}
+	if _, err := io.Copy(w, sealed); err != nil {
+		return fmt.Errorf("write: %w", err)
+	}
+	return nil
+}
+
+// Extract расшифровывает архив r паролем password и распаковывает его
+// в каталог dir. Архив проверяется целиком: измененный или обрезанный
+// архив не распаковывается.
+func Extract(r io.Reader, password string, dir string) (*Archive, error) {
+	header := make([]byte, len(magic)+saltSize)
+	if _, err := io.ReadFull(r, header); err != nil || !bytes.HasPrefix(header, magic) {
+		return nil, ErrInvalidArchive
+	}
+	keyring, err := newKeyring(password, header[len(magic):])
+	if err != nil {
+		return nil, fmt.Errorf("extract: %w", err)
+	}
+
+	// Идентификатор ключа в заголовке не совпадает с ключом из другого пароля.
+	opened, err := keyring.OpenStream(r)
+	if errors.Is(err, server.ErrUnknownKey) {
+		return nil, ErrWrongPassword
+	}
+	if err != nil {
+		return nil, fmt.Errorf("extract: %w: %w", ErrInvalidArchive, err)
+	}
+
+	archive := &Archive{Blobs: make(map[string]string)}
+	if err := os.MkdirAll(filepath.Join(dir, blobPrefix), 0700); err != nil {
+		return nil, fmt.Errorf("extract: %w", err)
+	}
+
+	tr := tar.NewReader(opened)
+	for {
+		h, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("extract: %w: %w", ErrInvalidArchive, err)
+		}
+
+		var path string
+		switch {
+		case h.Name == DatabaseName:
+			path = filepath.Join(dir, DatabaseName)
+			archive.Database = path
+		case strings.HasPrefix(h.Name, blobPrefix):
+			key := strings.TrimPrefix(h.Name, blobPrefix)
+			if key == "" || key != filepath.Base(key) || key == "." || key == ".." {
+				return nil, fmt.Errorf("extract: %w: invalid blob key %q", ErrInvalidArchive, key)
+			}
+			path = filepath.Join(dir, blobPrefix, key)
+			archive.Blobs[key] = path
+		default:
+			return nil, fmt.Errorf("extract: %w: unexpected entry %q", ErrInvalidArchive, h.Name)
+		}
+
+		if err := extractFile(tr, path); err != nil {
+			return nil, fmt.Errorf("extract: %w: %w", ErrInvalidArchive, err)
+		}
+	}
+
+	if archive.Database == "" {
+		return nil, fmt.Errorf("extract: %w: database is missing", ErrInvalidArchive)
+	}
+	return archive, nil
+}
+
+func writeTar(ctx context.Context, w io.Writer, database string, keys []string, blobs server.BlobStore) error {
+	tw := tar.NewWriter(w)
+
+	file, err := os.Open(database)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	if err := writeEntry(tw, DatabaseName, info.Size(), file); err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := writeBlob(ctx, tw, key, blobs); err != nil {
+			return fmt.Errorf("blob %q: %w", key, err)
+		}
+	}
+
+	return tw.Close()
+}
+
+func writeBlob(ctx context.Context, tw *tar.Writer, key string, blobs server.BlobStore) error {
+	info, err := blobs.Stat(ctx, key)
+	if err != nil {
+		return err
+	}
+	content, err := blobs.Get(ctx, key)
+	if err != nil {
+		return err
+	}
+	defer content.Close()
+
+	return writeEntry(tw, blobPrefix+key, info.Size, content)
+}
+
+func writeEntry(tw *tar.Writer, name string, size int64, r io.Reader) error {
+	err := tw.WriteHeader(&tar.Header{
+		Name: name,
+		Mode: 0600,
+		Size: size,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(tw, r)
+	return err
+}
+
+func extractFile(r io.Reader, path string) error {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if _, err := io.Copy(file, r); err != nil {
+		return err
+	}
+	return file.Close()
+}
+
+// newKeyring выводит ключ архива из пароля алгоритмом Argon2id.
+func newKeyring(password string, salt []byte) (*aesgcm.Keyring, error) {
+	if password == "" {
+		return nil, errors.New("password is required")
+	}
+	key := argon2.IDKey([]byte(password), salt, 1, 64*1024, 4, aesgcm.KeySize)
+	return aesgcm.New(key)
+}
@@ -0,0 +1,70 @@
+package backup
+
+import (
+	"bytes"
+	"github.com/mkolibaba/gophkeeper/server"
+	"github.com/mkolibaba/gophkeeper/server/fs"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestArchive(t *testing.T) {
+	var config server.Config
+	config.Blob.FS.Folder = filepath.Join(t.TempDir(), "binary")
+	blobs, err := fs.NewBlobStore(&config)
+	require.NoError(t, err)
+	require.NoError(t, blobs.Put(t.Context(), "a", strings.NewReader("content a")))
+	require.NoError(t, blobs.Put(t.Context(), "b", strings.NewReader(strings.Repeat("b", 200_000))))
+
+	database := filepath.Join(t.TempDir(), "db.sqlite")
+	require.NoError(t, os.WriteFile(database, []byte("database"), 0600))
+
+	var archive bytes.Buffer
+	err = Write(t.Context(), &archive, "secret", database, []string{"a", "b"}, blobs)
+	require.NoError(t, err)
+	require.NotContains(t, archive.String(), "content a")
+
+	t.Run("extract", func(t *testing.T) {
+		extracted, err := Extract(bytes.NewReader(archive.Bytes()), "secret", t.TempDir())
+		require.NoError(t, err)
+
+		content, err := os.ReadFile(extracted.Database)
+		require.NoError(t, err)
+		require.Equal(t, "database", string(content))
+
+		require.Len(t, extracted.Blobs, 2)
+		content, err = os.ReadFile(extracted.Blobs["a"])
+		require.NoError(t, err)
+		require.Equal(t, "content a", string(content))
+		content, err = os.ReadFile(extracted.Blobs["b"])
+		require.NoError(t, err)
+		require.Len(t, content, 200_000)
+	})
+	t.Run("wrong_password", func(t *testing.T) {
+		_, err := Extract(bytes.NewReader(archive.Bytes()), "wrong", t.TempDir())
+		require.ErrorIs(t, err, ErrWrongPassword)
+	})
+	t.Run("tampered", func(t *testing.T) {
+		tampered := bytes.Clone(archive.Bytes())
+		tampered[len(tampered)/2] ^= 1
+		_, err := Extract(bytes.NewReader(tampered), "secret", t.TempDir())
+		require.ErrorIs(t, err, ErrInvalidArchive)
+	})
+	t.Run("truncated", func(t *testing.T) {
+		truncated := archive.Bytes()[:archive.Len()-100]
+		_, err := Extract(bytes.NewReader(truncated), "secret", t.TempDir())
+		require.ErrorIs(t, err, ErrInvalidArchive)
+	})
+	t.Run("not_archive", func(t *testing.T) {
+		_, err := Extract(strings.NewReader("not an archive at all"), "secret", t.TempDir())
+		require.ErrorIs(t, err, ErrInvalidArchive)
+	})
+	t.Run("missing_blob", func(t *testing.T) {
+		var archive bytes.Buffer
+		err := Write(t.Context(), &archive, "secret", database, []string{"missing"}, blobs)
+		require.ErrorIs(t, err, server.ErrBlobNotFound)
+	})
+}
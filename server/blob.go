@@ -0,0 +1,33 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+var ErrBlobNotFound = errors.New("blob not found")
+
+// BlobInfo - сведения о сохраненном содержимом.
+type BlobInfo struct {
+	Size int64
+}
+
+// BlobStore - хранилище содержимого бинарных данных. Ключ - непрозрачная
+// строка без разделителей пути.
+type BlobStore interface {
+	// Put сохраняет содержимое r под ключом key, заменяя прежнее.
+	Put(ctx context.Context, key string, r io.Reader) error
+
+	// Get возвращает содержимое по ключу key. Если содержимого нет,
+	// возвращается ErrBlobNotFound.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+
+	// Delete удаляет содержимое по ключу key. Удаление отсутствующего
+	// содержимого не считается ошибкой.
+	Delete(ctx context.Context, key string) error
+
+	// Stat возвращает сведения о содержимом по ключу key. Если содержимого
+	// нет, возвращается ErrBlobNotFound.
+	Stat(ctx context.Context, key string) (BlobInfo, error)
+}
@@ -0,0 +1,18 @@
+package server
+
+import "fmt"
+
+// ProtocolVersion - версия протокола клиент-серверного взаимодействия.
+// Увеличивается при несовместимых изменениях API.
+const ProtocolVersion = 4
+
+// BuildInfo - сведения о сборке. Задаются при сборке через -ldflags.
+type BuildInfo struct {
+	Version string
+	Commit  string
+	Date    string
+}
+
+func (b BuildInfo) String() string {
+	return fmt.Sprintf("%s (commit %s, built %s)", b.Version, b.Commit, b.Date)
+}
@@ -11,6 +11,7 @@ import (
 	"go/format"
 	"go/parser"
 	"go/token"
+	"go/types"
 	"golang.org/x/tools/go/packages"
 	"os"
 	"path/filepath"
@@ -32,7 +33,7 @@ package {{.Package}}
 type in_{{.Type}} interface {
 {{range .Fields}}
 	Has{{.In}}() bool
-	Get{{.In}}() string
+	Get{{.In}}() {{.Type}}
 {{end}}
 }
 
@@ -53,8 +54,9 @@ func Map{{.Type}}(in in_{{.Type}}) {{.SrcQualifier}}{{.Type}} {
 var tmpl = template.Must(template.New("opaque").Parse(templateStr))
 
 type mapping struct {
-	Out string
-	In  string
+	Out  string
+	In   string
+	Type string
 }
 
 // opaque to update struct generator
@@ -122,13 +124,18 @@ func main() {
 
 			for _, field := range structType.Fields.List {
 				if field.Names != nil {
+					// Поля структуры обновления - указатели на значения,
+					// геттеры opaque сообщений возвращают сами значения.
+					typ := field.Type
+					if star, ok := typ.(*ast.StarExpr); ok {
+						typ = star.X
+					}
 					for _, name := range field.Names {
 						to, ok := mappings[name.Name]
-						if ok {
-							fields = append(fields, mapping{Out: name.Name, In: to})
-						} else {
-							fields = append(fields, mapping{Out: name.Name, In: name.Name})
+						if !ok {
+							to = name.Name
 						}
+						fields = append(fields, mapping{Out: name.Name, In: to, Type: types.ExprString(typ)})
 					}
 				}
 			}
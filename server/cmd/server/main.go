@@ -1,30 +1,318 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
 	"github.com/charmbracelet/log"
 	"github.com/mkolibaba/gophkeeper/server"
+	"github.com/mkolibaba/gophkeeper/server/aesgcm"
+	"github.com/mkolibaba/gophkeeper/server/backup"
+	"github.com/mkolibaba/gophkeeper/server/fs"
 	"github.com/mkolibaba/gophkeeper/server/grpc"
 	"github.com/mkolibaba/gophkeeper/server/jwt"
+	"github.com/mkolibaba/gophkeeper/server/postgres"
+	"github.com/mkolibaba/gophkeeper/server/purge"
+	"github.com/mkolibaba/gophkeeper/server/s3"
 	"github.com/mkolibaba/gophkeeper/server/sqlite"
+	sqlc "github.com/mkolibaba/gophkeeper/server/sqlite/sqlc/gen"
+	"github.com/mkolibaba/gophkeeper/server/totp"
 	"go.uber.org/fx"
 	"go.uber.org/fx/fxevent"
+	"golang.org/x/term"
 	"log/slog"
+	"os"
+	"path/filepath"
+)
+
+// Сведения о сборке, задаются через -ldflags "-X main.version=...".
+var (
+	version = "dev"
+	commit  = "none"
+	date    = "unknown"
 )
 
 func main() {
-	fx.New(createApp()).Run()
+	showVersion := flag.Bool("version", false, "print version and exit")
+	flag.Parse()
+
+	if *showVersion {
+		fmt.Println(buildInfo())
+		return
+	}
+
+	config, err := server.NewConfig()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	switch flag.Arg(0) {
+	case "":
+		fx.New(createApp(config)).Run()
+	case "rotate-key":
+		if err := rotateKey(config); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	case "backup", "restore":
+		if flag.NArg() != 2 {
+			fmt.Fprintf(os.Stderr, "usage: %s %s <file>\n", os.Args[0], flag.Arg(0))
+			os.Exit(2)
+		}
+		run := backupVault
+		if flag.Arg(0) == "restore" {
+			run = restoreVault
+		}
+		if err := run(config, flag.Arg(1)); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	case "migrate":
+		err := runMigrate(config, flag.Args()[1:], os.Stdout)
+		if errors.Is(err, errMigrateUsage) {
+			fmt.Fprintf(os.Stderr, "%v\nusage: %s %s\n", err, os.Args[0], migrateUsage)
+			os.Exit(2)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n", flag.Arg(0))
+		os.Exit(2)
+	}
+}
+
+func buildInfo() server.BuildInfo {
+	return server.BuildInfo{
+		Version: version,
+		Commit:  commit,
+		Date:    date,
+	}
 }
 
-func createApp() fx.Option {
+func createApp(config *server.Config) fx.Option {
+	return fx.Options(
+		storage(config),
+		fx.Supply(buildInfo()),
+		grpc.Module,
+		purge.Module,
+		fx.Provide(
+			fx.Annotate(jwt.NewAuthorizationService, fx.As(new(server.AuthorizationService))),
+			fx.Annotate(totp.NewMFAService, fx.As(new(server.MFAService))),
+		),
+	)
+}
+
+// storage - хранилище данных сервера без gRPC-сервера.
+func storage(config *server.Config) fx.Option {
 	return fx.Options(
 		fx.WithLogger(func(logger *log.Logger) fxevent.Logger {
 			return &fxevent.SlogLogger{Logger: slog.New(logger)}
 		}),
+		fx.Supply(config),
 		server.Module,
-		sqlite.Module,
-		grpc.Module,
+		storageBackend(config),
 		fx.Provide(
-			fx.Annotate(jwt.NewAuthorizationService, fx.As(new(server.AuthorizationService))),
+			aesgcm.NewKeyring,
+			newKeyring,
+			fx.Annotate(newBackendBlobStore, fx.ParamTags(``, `optional:"true"`), fx.ResultTags(`name:"backend"`)),
+			fx.Annotate(newBlobStore, fx.ParamTags(`name:"backend"`)),
 		),
 	)
 }
+
+// storageBackend возвращает модуль хранилища данных, выбранного в конфигурации.
+func storageBackend(config *server.Config) fx.Option {
+	switch config.Storage.Backend {
+	case "", "sqlite":
+		return fx.Options(
+			sqlite.Module,
+			fx.Provide(
+				fx.Annotate(sqlite.NewBackupService, fx.ParamTags(``, `name:"backend"`)),
+			),
+		)
+	case "postgres":
+		return postgres.Module
+	default:
+		return fx.Error(fmt.Errorf("unknown storage backend %q", config.Storage.Backend))
+	}
+}
+
+// requireSQLite проверяет, что команда command запущена с хранилищем SQLite.
+// Для PostgreSQL используются его собственные средства, например pg_dump.
+func requireSQLite(config *server.Config, command string) error {
+	if backend := config.Storage.Backend; backend != "" && backend != "sqlite" {
+		return fmt.Errorf("%s is not supported by %s storage backend, see README for alternatives", command, backend)
+	}
+	return nil
+}
+
+// rotateKey перешифровывает данные сервера текущим мастер-ключом.
+func rotateKey(config *server.Config) error {
+	if err := requireSQLite(config, "rotate-key"); err != nil {
+		return err
+	}
+
+	var rotator *sqlite.KeyRotator
+	app := fx.New(storage(config), fx.Populate(&rotator))
+
+	ctx := context.Background()
+	if err := app.Start(ctx); err != nil {
+		return err
+	}
+	defer app.Stop(ctx)
+
+	return rotator.Rotate(ctx)
+}
+
+// backupVault записывает резервную копию сервера в файл path.
+func backupVault(config *server.Config, path string) error {
+	if err := requireSQLite(config, "backup"); err != nil {
+		return err
+	}
+	password, err := backupPassword()
+	if err != nil {
+		return err
+	}
+
+	var service *sqlite.BackupService
+	app := fx.New(storage(config), fx.Populate(&service))
+
+	ctx := context.Background()
+	if err := app.Start(ctx); err != nil {
+		return err
+	}
+	defer app.Stop(ctx)
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+	if err := service.Backup(ctx, file, password); err != nil {
+		file.Close()
+		os.Remove(path)
+		return err
+	}
+	return file.Close()
+}
+
+// restoreVault восстанавливает сервер из резервной копии в файле path.
+// Архив распаковывается рядом с базой данных, чтобы заменить ее
+// переименованием.
+func restoreVault(config *server.Config, path string) error {
+	if err := requireSQLite(config, "restore"); err != nil {
+		return err
+	}
+	password, err := backupPassword()
+	if err != nil {
+		return err
+	}
+	logger := server.NewLogger(config)
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	dir, err := os.MkdirTemp(filepath.Dir(config.SQLite.DSN), ".restore-*")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	archive, err := backup.Extract(file, password, dir)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	if err := sqlite.Restore(ctx, config, archive, logger); err != nil {
+		return err
+	}
+
+	// Без содержимого восстановленная база данных ссылается на
+	// отсутствующие файлы: возвращаем прежнюю.
+	if err := restoreBlobs(ctx, config, archive); err != nil {
+		if rollbackErr := sqlite.RollbackRestore(config, logger); rollbackErr != nil {
+			return errors.Join(err, rollbackErr)
+		}
+		return err
+	}
+	return nil
+}
+
+// restoreBlobs записывает в хранилище содержимое из резервной копии
+// archive. Вызывается после замены базы данных: хранилище sqlite
+// находится в ней.
+func restoreBlobs(ctx context.Context, config *server.Config, archive *backup.Archive) error {
+	var service *sqlite.BackupService
+	app := fx.New(storage(config), fx.Populate(&service))
+	if err := app.Start(ctx); err != nil {
+		return err
+	}
+	defer app.Stop(ctx)
+
+	return service.RestoreBlobs(ctx, archive)
+}
+
+// backupPassword возвращает пароль резервной копии из переменной окружения
+// GOPHKEEPER_BACKUP_PASSWORD или запрашивает его в терминале.
+func backupPassword() (string, error) {
+	if password := os.Getenv("GOPHKEEPER_BACKUP_PASSWORD"); password != "" {
+		return password, nil
+	}
+
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return "", errors.New("backup password is not set: use GOPHKEEPER_BACKUP_PASSWORD")
+	}
+	fmt.Fprint(os.Stderr, "Backup password: ")
+	password, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", err
+	}
+	if len(password) == 0 {
+		return "", errors.New("backup password is empty")
+	}
+	return string(password), nil
+}
+
+// newKeyring возвращает связку ключей или nil, если шифрование выключено.
+func newKeyring(keyring *aesgcm.Keyring) server.Keyring {
+	if keyring == nil {
+		return nil
+	}
+	return keyring
+}
+
+// newBlobStore возвращает хранилище содержимого для сервисов. Если задан
+// мастер-ключ, содержимое шифруется.
+func newBlobStore(backend server.BlobStore, keyring *aesgcm.Keyring) server.BlobStore {
+	if keyring == nil {
+		return backend
+	}
+	return aesgcm.NewBlobStore(backend, keyring)
+}
+
+// newBackendBlobStore возвращает хранилище содержимого, выбранное в конфигурации.
+// Запросы SQLite есть только у хранилища данных SQLite.
+func newBackendBlobStore(config *server.Config, queries *sqlc.Queries) (server.BlobStore, error) {
+	switch config.Blob.Backend {
+	case "", "fs":
+		return fs.NewBlobStore(config)
+	case "s3":
+		return s3.NewBlobStore(config)
+	case "sqlite":
+		if queries == nil {
+			return nil, errors.New("sqlite blob backend requires sqlite storage backend")
+		}
+		return sqlite.NewBlobStore(queries), nil
+	default:
+		return nil, fmt.Errorf("unknown blob backend %q", config.Blob.Backend)
+	}
+}
@@ -1,12 +1,59 @@
 package main
 
 import (
+	"github.com/mkolibaba/gophkeeper/server"
+	"github.com/mkolibaba/gophkeeper/server/aesgcm"
+	"github.com/mkolibaba/gophkeeper/server/sqlite"
+	sqlc "github.com/mkolibaba/gophkeeper/server/sqlite/sqlc/gen"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/fx"
 	"testing"
 )
 
 func TestValidateApp(t *testing.T) {
-	err := fx.ValidateApp(createApp())
+	for _, backend := range []string{"sqlite", "postgres"} {
+		t.Run(backend, func(t *testing.T) {
+			var config server.Config
+			config.Storage.Backend = backend
+			require.NoError(t, fx.ValidateApp(createApp(&config)))
+		})
+	}
+
+	var config server.Config
+	config.Storage.Backend = "mysql"
+	require.Error(t, fx.ValidateApp(createApp(&config)))
+}
+
+func TestRequireSQLite(t *testing.T) {
+	var config server.Config
+	require.NoError(t, requireSQLite(&config, "backup"))
+
+	config.Storage.Backend = "postgres"
+	require.ErrorContains(t, requireSQLite(&config, "backup"), "not supported")
+}
+
+func TestNewBlobStore(t *testing.T) {
+	var config server.Config
+	config.Blob.Backend = "ftp"
+
+	_, err := newBackendBlobStore(&config, nil)
+	require.Error(t, err)
+
+	// Содержимое в SQLite хранится только вместе с данными.
+	config.Blob.Backend = "sqlite"
+	_, err = newBackendBlobStore(&config, nil)
+	require.Error(t, err)
+
+	backend, err := newBackendBlobStore(&config, sqlc.New(nil))
+	require.NoError(t, err)
+	require.IsType(t, &sqlite.BlobStore{}, backend)
+	require.Same(t, backend, newBlobStore(backend, nil))
+
+	keyring, err := aesgcm.New(make([]byte, aesgcm.KeySize))
 	require.NoError(t, err)
+	require.IsType(t, &aesgcm.BlobStore{}, newBlobStore(backend, keyring))
+}
+
+func TestNewKeyring(t *testing.T) {
+	require.Nil(t, newKeyring(nil))
 }
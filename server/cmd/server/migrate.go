@@ -0,0 +1,181 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/charmbracelet/log"
+	"github.com/mkolibaba/gophkeeper/server"
+	"github.com/mkolibaba/gophkeeper/server/migrate"
+	"github.com/mkolibaba/gophkeeper/server/postgres"
+	"github.com/mkolibaba/gophkeeper/server/sqlite"
+	"io"
+	"strconv"
+	"text/tabwriter"
+	"time"
+)
+
+const migrateUsage = "migrate [-dry-run] status|up|down|to <version>"
+
+// errMigrateUsage - неверные аргументы команды migrate.
+var errMigrateUsage = errors.New("invalid migrate arguments")
+
+// migrateCommand - разобранные аргументы команды migrate.
+type migrateCommand struct {
+	name    string
+	version int64
+	dryRun  bool
+}
+
+// parseMigrateArgs разбирает аргументы команды migrate. Флаг -dry-run
+// допускается и до, и после подкоманды.
+func parseMigrateArgs(args []string) (migrateCommand, error) {
+	flags := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	dryRun := flags.Bool("dry-run", false, "print pending SQL without applying it")
+
+	var positional []string
+	for {
+		if err := flags.Parse(args); err != nil {
+			return migrateCommand{}, fmt.Errorf("%w: %w", errMigrateUsage, err)
+		}
+		if flags.NArg() == 0 {
+			break
+		}
+		positional = append(positional, flags.Arg(0))
+		args = flags.Args()[1:]
+
+		// Версия -1 (Initial) не должна разбираться как флаг.
+		if len(positional) == 1 && positional[0] == "to" && len(args) > 0 {
+			positional = append(positional, args[0])
+			args = args[1:]
+		}
+	}
+
+	command := migrateCommand{dryRun: *dryRun}
+	if len(positional) == 0 {
+		return command, errMigrateUsage
+	}
+	command.name = positional[0]
+
+	switch {
+	case command.name == "to" && len(positional) == 2:
+		version, err := strconv.ParseInt(positional[1], 10, 64)
+		if err != nil || version < migrate.Initial {
+			return command, fmt.Errorf("%w: invalid version %q", errMigrateUsage, positional[1])
+		}
+		command.version = version
+	case (command.name == "status" || command.name == "up" || command.name == "down") && len(positional) == 1:
+	default:
+		return command, errMigrateUsage
+	}
+	return command, nil
+}
+
+// migrator - хранилище данных, схему которого можно мигрировать.
+type migrator interface {
+	Connect() error
+	Migrator() (*migrate.Migrator, error)
+	Close() error
+}
+
+// newMigrator возвращает хранилище данных, выбранное в конфигурации.
+func newMigrator(config *server.Config, logger *log.Logger) (migrator, error) {
+	switch config.Storage.Backend {
+	case "", "sqlite":
+		return sqlite.NewDB(config, nil, logger), nil
+	case "postgres":
+		return postgres.NewDB(config, nil, logger), nil
+	default:
+		return nil, fmt.Errorf("unknown storage backend %q", config.Storage.Backend)
+	}
+}
+
+// runMigrate выполняет команду migrate и выводит результат в w. Команда
+// работает с базой данных без запуска сервера, миграции не применяются
+// автоматически.
+func runMigrate(config *server.Config, args []string, w io.Writer) error {
+	command, err := parseMigrateArgs(args)
+	if err != nil {
+		return err
+	}
+
+	db, err := newMigrator(config, server.NewLogger(config))
+	if err != nil {
+		return err
+	}
+	if err := db.Connect(); err != nil {
+		return err
+	}
+	defer db.Close()
+
+	m, err := db.Migrator()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	var steps []migrate.Step
+	switch command.name {
+	case "status":
+		status, err := m.Status(ctx)
+		if err != nil {
+			return err
+		}
+		return printMigrationStatus(w, status)
+	case "up":
+		steps, err = m.Up(ctx, command.dryRun)
+	case "down":
+		steps, err = m.Down(ctx, command.dryRun)
+	case "to":
+		steps, err = m.To(ctx, command.version, command.dryRun)
+	}
+	if err != nil {
+		return err
+	}
+	return printMigrationSteps(w, steps, command.dryRun)
+}
+
+// printMigrationStatus выводит таблицу состояния миграций.
+func printMigrationStatus(w io.Writer, status []migrate.Status) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "VERSION\tNAME\tSTATUS\tAPPLIED AT")
+	for _, s := range status {
+		state, appliedAt := "pending", ""
+		if s.Applied {
+			state = "applied"
+			appliedAt = s.AppliedAt.Format(time.DateTime)
+		}
+		switch {
+		case s.Unknown:
+			state += ", unknown"
+		case s.Modified:
+			state += ", modified"
+		case !s.Reversible:
+			state += ", irreversible"
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\n", s.Version, s.Name, state, appliedAt)
+	}
+	return tw.Flush()
+}
+
+// printMigrationSteps выводит выполненные шаги, а в режиме dryRun - их SQL.
+func printMigrationSteps(w io.Writer, steps []migrate.Step, dryRun bool) error {
+	if len(steps) == 0 {
+		_, err := fmt.Fprintln(w, "no migrations to run")
+		return err
+	}
+	for _, step := range steps {
+		var err error
+		if dryRun {
+			_, err = fmt.Fprintf(w, "-- %s\n%s\n", step, step.SQL())
+		} else {
+			_, err = fmt.Fprintf(w, "%s done\n", step)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"bytes"
+	"github.com/mkolibaba/gophkeeper/server"
+	"github.com/mkolibaba/gophkeeper/server/migrate"
+	"github.com/stretchr/testify/require"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseMigrateArgs(t *testing.T) {
+	tests := []struct {
+		args []string
+		want migrateCommand
+	}{
+		{args: []string{"status"}, want: migrateCommand{name: "status"}},
+		{args: []string{"up"}, want: migrateCommand{name: "up"}},
+		{args: []string{"-dry-run", "down"}, want: migrateCommand{name: "down", dryRun: true}},
+		{args: []string{"to", "5", "-dry-run"}, want: migrateCommand{name: "to", version: 5, dryRun: true}},
+		{args: []string{"to", "-1"}, want: migrateCommand{name: "to", version: migrate.Initial}},
+	}
+	for _, tt := range tests {
+		command, err := parseMigrateArgs(tt.args)
+		require.NoError(t, err, tt.args)
+		require.Equal(t, tt.want, command, tt.args)
+	}
+
+	for _, args := range [][]string{
+		nil,
+		{"sideways"},
+		{"up", "1"},
+		{"to"},
+		{"to", "five"},
+		{"to", "-2"},
+		{"-force", "up"},
+	} {
+		_, err := parseMigrateArgs(args)
+		require.ErrorIs(t, err, errMigrateUsage, args)
+	}
+}
+
+func TestRunMigrate(t *testing.T) {
+	var config server.Config
+	config.SQLite.DataFolder = t.TempDir()
+	config.SQLite.DSN = filepath.Join(config.SQLite.DataFolder, "db.sqlite")
+
+	run := func(args ...string) string {
+		t.Helper()
+		var out bytes.Buffer
+		require.NoError(t, runMigrate(&config, args, &out))
+		return out.String()
+	}
+
+	require.Regexp(t, `0 +init +pending`, run("status"))
+
+	out := run("up", "-dry-run")
+	require.Contains(t, out, "-- 0_init (up)\nCREATE TABLE user")
+	require.Regexp(t, `0 +init +pending`, run("status"))
+
+	require.Contains(t, run("up"), "0_init (up) done")
+	require.Contains(t, run("up"), "no migrations to run")
+
+	// Более поздние миграции отменяются, чтобы тест не зависел от них.
+	run("to", "11")
+	require.Regexp(t, `11 +binary_compression +applied`, run("status"))
+
+	out = run("down", "-dry-run")
+	require.Contains(t, out, "-- 11_binary_compression (down)\nALTER TABLE upload DROP COLUMN compression;")
+	require.Contains(t, run("down"), "11_binary_compression (down) done")
+
+	status := run("status")
+	require.Regexp(t, `11 +binary_compression +pending`, status)
+	require.Regexp(t, `10 +content_addressed +applied +\d{4}-`, status)
+
+	require.Contains(t, run("to", "9"), "10_content_addressed (down) done")
+	require.Regexp(t, `10 +content_addressed +pending`, run("status"))
+
+	var discard bytes.Buffer
+
+	config.Storage.Backend = "mysql"
+	err := runMigrate(&config, []string{"status"}, &discard)
+	require.ErrorContains(t, err, "unknown storage backend")
+}
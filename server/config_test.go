@@ -9,12 +9,31 @@ import (
 func TestConfig(t *testing.T) {
 	t.Setenv("SQLITE_DATA_FOLDER", "some_path")
 	t.Setenv("JWT_TTL", "20m")
+	t.Setenv("BLOB_BACKEND", "s3")
+	t.Setenv("ENCRYPTION_KEY", "c2VjcmV0")
+	t.Setenv("POSTGRES_DSN", "postgres://localhost/gophkeeper")
 
 	config, err := NewConfig()
 	require.NoError(t, err)
 	require.Equal(t, "some_path", config.SQLite.DataFolder)
 	require.Equal(t, "8080", config.GRPC.Port)
 	require.Equal(t, 20*time.Minute, config.JWT.TTL)
+	require.Equal(t, 5, config.RateLimit.MaxFailures)
+	require.Equal(t, time.Minute, config.RateLimit.Lockout)
+	require.Equal(t, 24*time.Hour, config.RateLimit.FailureTTL)
+	require.Equal(t, 10, config.RateLimit.IP.Burst)
+	require.Equal(t, "s3", config.Blob.Backend)
+	require.Equal(t, "gophkeeper", config.Blob.S3.Bucket)
+	require.EqualValues(t, 10000, config.Quota.Items)
+	require.EqualValues(t, 20, config.History.MaxVersions)
+	require.Equal(t, 90*24*time.Hour, config.History.MaxAge)
+	require.Equal(t, 30*24*time.Hour, config.Trash.Retention)
+	require.Equal(t, time.Hour, config.Trash.PurgeInterval)
+	require.Equal(t, 1048576, config.GRPC.MaxMessageSize)
+	require.Equal(t, "c2VjcmV0", config.Encryption.Key)
+	require.Empty(t, config.Encryption.PreviousKeys)
+	require.Equal(t, "sqlite", config.Storage.Backend)
+	require.Equal(t, "postgres://localhost/gophkeeper", config.Postgres.DSN)
 }
 
 func TestInvalidConfig(t *testing.T) {
@@ -8,6 +8,8 @@ type contextKey int
 const (
 	// userContextKey - ключ для пользователя.
 	userContextKey contextKey = iota + 1
+	// sessionContextKey - ключ для идентификатора сессии.
+	sessionContextKey
 )
 
 // NewContextWithUser возвращает новый контекст с пользователем.
@@ -20,3 +22,14 @@ func UserFromContext(ctx context.Context) string {
 	user, _ := ctx.Value(userContextKey).(string)
 	return user
 }
+
+// NewContextWithSession возвращает новый контекст с идентификатором сессии.
+func NewContextWithSession(ctx context.Context, sessionID string) context.Context {
+	return context.WithValue(ctx, sessionContextKey, sessionID)
+}
+
+// SessionFromContext возвращает идентификатор сессии из контекста.
+func SessionFromContext(ctx context.Context) string {
+	sessionID, _ := ctx.Value(sessionContextKey).(string)
+	return sessionID
+}
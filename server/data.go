@@ -2,23 +2,36 @@
 //go:generate go run cmd/opaquemapper/main.go -pkg grpcgen -out grpc/gen/note_mapping.go . NoteDataUpdate
 //go:generate go run cmd/opaquemapper/main.go -pkg grpcgen -out grpc/gen/binary_mapping.go . BinaryDataUpdate
 //go:generate go run cmd/opaquemapper/main.go -pkg grpcgen -out grpc/gen/card_mapping.go -mappings CVV:Cvv . CardDataUpdate
+//go:generate go run cmd/opaquemapper/main.go -pkg grpcgen -out grpc/gen/otp_mapping.go . OTPDataUpdate
 
 package server
 
 import (
 	"context"
 	"errors"
-	"github.com/go-playground/validator/v10"
+	"fmt"
 	"io"
-	"regexp"
 )
 
 var (
 	ErrDataNotFound      = errors.New("data not found")
 	ErrUserAlreadyExists = errors.New("user already exists")
 	ErrUserNotFound      = errors.New("user not found")
+	ErrVaultKeyExists    = errors.New("vault key already exists")
 )
 
+// VersionConflictError возвращается при изменении или удалении данных,
+// если переданная версия не совпадает с текущей: данные успели изменить
+// с другого клиента.
+type VersionConflictError struct {
+	// Version - текущая версия данных.
+	Version int64
+}
+
+func (e *VersionConflictError) Error() string {
+	return fmt.Sprintf("version conflict: current version is %d", e.Version)
+}
+
 type LoginData struct {
 	ID       int64
 	Name     string `validate:"required"`
@@ -26,6 +39,7 @@ type LoginData struct {
 	Password string
 	Website  string
 	Notes    string
+	Version  int64
 }
 
 type LoginDataUpdate struct {
@@ -46,18 +60,20 @@ type LoginService interface {
 	GetAll(ctx context.Context) ([]LoginData, error)
 
 	// Update обновляет данные с переданным id. Только владелец
-	// данных может редактировать их.
-	Update(ctx context.Context, id int64, data LoginDataUpdate) error
+	// данных может редактировать их. Если version не совпадает с текущей
+	// версией данных, возвращается *VersionConflictError.
+	Update(ctx context.Context, id int64, version int64, data LoginDataUpdate) error
 
-	// Remove удаляет данные. Только владелец данных может
-	// удалять их.
-	Remove(ctx context.Context, id int64) error
+	// Remove перемещает данные в корзину (см. TrashService). Только
+	// владелец данных может удалять их.
+	Remove(ctx context.Context, id int64, version int64) error
 }
 
 type NoteData struct {
-	ID   int64
-	Name string `validate:"required"`
-	Text string
+	ID      int64
+	Name    string `validate:"required"`
+	Text    string
+	Version int64
 }
 
 type NoteDataUpdate struct {
@@ -75,12 +91,27 @@ type NoteService interface {
 	GetAll(ctx context.Context) ([]NoteData, error)
 
 	// Update обновляет бинарные данные с переданным id. Только владелец
-	// данных может редактировать их.
-	Update(ctx context.Context, id int64, data NoteDataUpdate) error
+	// данных может редактировать их. Если version не совпадает с текущей
+	// версией данных, возвращается *VersionConflictError.
+	Update(ctx context.Context, id int64, version int64, data NoteDataUpdate) error
+
+	// Remove перемещает данные в корзину (см. TrashService). Только
+	// владелец данных может удалять их.
+	Remove(ctx context.Context, id int64, version int64) error
+}
+
+// Compression - способ сжатия содержимого бинарных данных. Содержимое
+// сжимает клиент до шифрования, сервер только хранит способ сжатия.
+type Compression int64
+
+const (
+	CompressionNone Compression = iota
+	CompressionZstd
+)
 
-	// Remove удаляет данные. Только владелец данных может
-	// удалять их.
-	Remove(ctx context.Context, id int64) error
+// Valid сообщает, известен ли серверу способ сжатия.
+func (c Compression) Valid() bool {
+	return c == CompressionNone || c == CompressionZstd
 }
 
 type BinaryData struct {
@@ -89,11 +120,23 @@ type BinaryData struct {
 	Filename string `validate:"required"`
 	Size     int64
 	Notes    string
+	Version  int64
+	// Compression - способ сжатия содержимого.
+	Compression Compression
 }
 
 type ReadableBinaryData struct {
 	BinaryData
 	DataReader io.ReadCloser
+	// ContentSize и SHA256 - размер и хэш сохраненного содержимого.
+	// Заполняются при получении данных. Если хэш содержимого уже известен,
+	// его можно передать в Create, чтобы не вычислять заново.
+	ContentSize int64
+	SHA256      []byte
+	// ContentKey - ключ дедупликации исходного файла от клиента. Если он
+	// задан, одинаковым считается содержимое записей пользователя с тем же
+	// ключом и способом сжатия, а не только одинаковые байты.
+	ContentKey []byte
 }
 
 type BinaryDataUpdate struct {
@@ -114,22 +157,26 @@ type BinaryService interface {
 	GetAll(ctx context.Context) ([]BinaryData, error)
 
 	// Update обновляет бинарные данные с переданным id. Только владелец
-	// данных может редактировать их.
-	Update(ctx context.Context, id int64, data BinaryDataUpdate) error
+	// данных может редактировать их. Если version не совпадает с текущей
+	// версией данных, возвращается *VersionConflictError.
+	Update(ctx context.Context, id int64, version int64, data BinaryDataUpdate) error
 
-	// Remove удаляет данные. Только владелец данных может
-	// удалять их.
-	Remove(ctx context.Context, id int64) error
+	// Remove перемещает данные в корзину (см. TrashService). Только
+	// владелец данных может удалять их.
+	Remove(ctx context.Context, id int64, version int64) error
 }
 
+// CardData - данные карты. Все поля, кроме Name, приходят от клиента
+// в зашифрованном виде, поэтому сервер проверяет только их наличие.
 type CardData struct {
 	ID         int64
 	Name       string `validate:"required"`
-	Number     string `validate:"required,credit_card"`
-	ExpDate    string `validate:"required,exp_date"`
-	CVV        string `validate:"required,len=3"`
+	Number     string `validate:"required"`
+	ExpDate    string `validate:"required"`
+	CVV        string `validate:"required"`
 	Cardholder string `validate:"required"`
 	Notes      string
+	Version    int64
 }
 
 type CardDataUpdate struct {
@@ -151,21 +198,57 @@ type CardService interface {
 	GetAll(ctx context.Context) ([]CardData, error)
 
 	// Update обновляет данные карты с переданным id. Только владелец
-	// данных может редактировать их.
-	Update(ctx context.Context, id int64, data CardDataUpdate) error
+	// данных может редактировать их. Если version не совпадает с текущей
+	// версией данных, возвращается *VersionConflictError.
+	Update(ctx context.Context, id int64, version int64, data CardDataUpdate) error
+
+	// Remove перемещает данные в корзину (см. TrashService). Только
+	// владелец данных может удалять их.
+	Remove(ctx context.Context, id int64, version int64) error
+}
+
+// OTPData - данные для генерации одноразовых паролей (TOTP или HOTP).
+// Секрет и издатель приходят от клиента в зашифрованном виде, поэтому
+// сервер проверяет только их наличие.
+type OTPData struct {
+	ID        int64
+	Name      string `validate:"required"`
+	Type      string `validate:"oneof=totp hotp"`
+	Secret    string `validate:"required"`
+	Algorithm string `validate:"oneof=SHA1 SHA256 SHA512"`
+	Digits    int64  `validate:"min=6,max=8"`
+	Period    int64  `validate:"required_if=Type totp,min=0"`
+	Counter   int64  `validate:"min=0"`
+	Issuer    string
+	Version   int64
+}
 
-	// Remove удаляет данные. Только владелец данных может
-	// удалять их.
-	Remove(ctx context.Context, id int64) error
+type OTPDataUpdate struct {
+	Name      *string
+	Type      *string
+	Secret    *string
+	Algorithm *string
+	Digits    *int64
+	Period    *int64
+	Counter   *int64
+	Issuer    *string
 }
 
-func RegisterDataValidationRules(validate *validator.Validate) error {
-	expDateRegexp, err := regexp.Compile(`^\d{2}/\d{2}$`)
-	if err != nil {
-		return err
-	}
+// OTPService - сервис для работы с данными одноразовых паролей.
+// Работать с данными может только их владелец.
+type OTPService interface {
+	// Create сохраняет данные одноразовых паролей для текущего пользователя.
+	Create(ctx context.Context, data OTPData) error
+
+	// GetAll возвращает все данные одноразовых паролей текущего пользователя.
+	GetAll(ctx context.Context) ([]OTPData, error)
+
+	// Update обновляет данные одноразовых паролей с переданным id. Только
+	// владелец данных может редактировать их. Если version не совпадает
+	// с текущей версией данных, возвращается *VersionConflictError.
+	Update(ctx context.Context, id int64, version int64, data OTPDataUpdate) error
 
-	return validate.RegisterValidation("exp_date", func(fl validator.FieldLevel) bool {
-		return expDateRegexp.MatchString(fl.Field().String())
-	})
+	// Remove перемещает данные в корзину (см. TrashService). Только
+	// владелец данных может удалять их.
+	Remove(ctx context.Context, id int64, version int64) error
 }
@@ -0,0 +1,22 @@
+package server
+
+import (
+	"errors"
+)
+
+var (
+	ErrUnknownKey       = errors.New("data is encrypted with unknown key")
+	ErrEncryptionNotSet = errors.New("data is encrypted, but encryption key is not set")
+)
+
+// Keyring шифрует данные, которые сервер хранит на диске, мастер-ключом
+// сервера. Кроме текущего ключа в нем могут быть прежние: ими данные
+// только расшифровываются, пока их не перешифрует ротация ключа.
+type Keyring interface {
+	// Seal шифрует plaintext текущим ключом.
+	Seal(plaintext []byte) ([]byte, error)
+
+	// Open расшифровывает данные, зашифрованные любым ключом из связки.
+	// Если ключа нет в связке, возвращается ErrUnknownKey.
+	Open(ciphertext []byte) ([]byte, error)
+}
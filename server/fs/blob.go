@@ -0,0 +1,107 @@
+package fs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/mkolibaba/gophkeeper/server"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// BlobStore хранит содержимое в файлах каталога на локальном диске.
+type BlobStore struct {
+	folder string
+}
+
+func NewBlobStore(config *server.Config) (*BlobStore, error) {
+	folder := config.Blob.FS.Folder
+	if err := os.MkdirAll(folder, 0755); err != nil {
+		return nil, fmt.Errorf("new blob store: %w", err)
+	}
+	return &BlobStore{folder: folder}, nil
+}
+
+func (s *BlobStore) Put(ctx context.Context, key string, r io.Reader) error {
+	path, err := s.getPath(key)
+	if err != nil {
+		return fmt.Errorf("put: %w", err)
+	}
+
+	// Содержимое пишется во временный файл и переименовывается, чтобы
+	// по ключу никогда не лежал недописанный файл.
+	tmp, err := os.CreateTemp(s.folder, key+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("put: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	if _, err := io.CopyBuffer(tmp, r, make([]byte, 1024*1024)); err != nil {
+		return fmt.Errorf("put: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		return fmt.Errorf("put: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("put: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("put: %w", err)
+	}
+	return nil
+}
+
+func (s *BlobStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	path, err := s.getPath(key)
+	if err != nil {
+		return nil, fmt.Errorf("get: %w", err)
+	}
+
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, server.ErrBlobNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get: %w", err)
+	}
+	return file, nil
+}
+
+func (s *BlobStore) Delete(ctx context.Context, key string) error {
+	path, err := s.getPath(key)
+	if err != nil {
+		return fmt.Errorf("delete: %w", err)
+	}
+
+	err = os.Remove(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("delete: %w", err)
+	}
+	return nil
+}
+
+func (s *BlobStore) Stat(ctx context.Context, key string) (server.BlobInfo, error) {
+	path, err := s.getPath(key)
+	if err != nil {
+		return server.BlobInfo{}, fmt.Errorf("stat: %w", err)
+	}
+
+	info, err := os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return server.BlobInfo{}, server.ErrBlobNotFound
+	}
+	if err != nil {
+		return server.BlobInfo{}, fmt.Errorf("stat: %w", err)
+	}
+	return server.BlobInfo{Size: info.Size()}, nil
+}
+
+func (s *BlobStore) getPath(key string) (string, error) {
+	if key == "" || key != filepath.Base(key) || key == "." || key == ".." {
+		return "", fmt.Errorf("invalid key %q", key)
+	}
+	return filepath.Join(s.folder, key), nil
+}
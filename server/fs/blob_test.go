@@ -0,0 +1,54 @@
+package fs
+
+import (
+	"github.com/mkolibaba/gophkeeper/server"
+	"github.com/stretchr/testify/require"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBlobStore(t *testing.T) {
+	var config server.Config
+	config.Blob.FS.Folder = filepath.Join(t.TempDir(), "binary")
+
+	store, err := NewBlobStore(&config)
+	require.NoError(t, err)
+
+	t.Run("put_get", func(t *testing.T) {
+		require.NoError(t, store.Put(t.Context(), "1", strings.NewReader("content 1")))
+		require.NoError(t, store.Put(t.Context(), "1", strings.NewReader("content 2")))
+
+		r, err := store.Get(t.Context(), "1")
+		require.NoError(t, err)
+		defer r.Close()
+		content, err := io.ReadAll(r)
+		require.NoError(t, err)
+		require.Equal(t, "content 2", string(content))
+
+		info, err := store.Stat(t.Context(), "1")
+		require.NoError(t, err)
+		require.EqualValues(t, 9, info.Size)
+
+		// Временные файлы не остаются.
+		entries, err := os.ReadDir(config.Blob.FS.Folder)
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+	})
+	t.Run("delete", func(t *testing.T) {
+		require.NoError(t, store.Put(t.Context(), "2", strings.NewReader("content")))
+		require.NoError(t, store.Delete(t.Context(), "2"))
+		require.NoError(t, store.Delete(t.Context(), "2"))
+
+		_, err := store.Get(t.Context(), "2")
+		require.ErrorIs(t, err, server.ErrBlobNotFound)
+		_, err = store.Stat(t.Context(), "2")
+		require.ErrorIs(t, err, server.ErrBlobNotFound)
+	})
+	t.Run("invalid_key", func(t *testing.T) {
+		err := store.Put(t.Context(), "../1", strings.NewReader("content"))
+		require.Error(t, err)
+	})
+}
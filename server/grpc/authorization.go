@@ -4,30 +4,46 @@ import (
 	"context"
 	"errors"
 	"github.com/go-playground/validator/v10"
+	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/mkolibaba/gophkeeper/proto/gen/go/gophkeeperv1"
 	"github.com/mkolibaba/gophkeeper/server"
 	"golang.org/x/crypto/bcrypt"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
-var ErrInvalidCredentials = status.Error(codes.InvalidArgument, "invalid login or password")
+var (
+	ErrInvalidCredentials = status.Error(codes.InvalidArgument, "invalid login or password")
+	ErrInvalidPassword    = status.Error(codes.InvalidArgument, "invalid password")
+	// ErrEnrollmentRequired возвращается при входе в учетную запись без
+	// ключа хранилища, если пароль принят: учетная запись создана до
+	// шифрования на клиенте и защищена самим мастер-паролем, а не ключом
+	// аутентификации.
+	ErrEnrollmentRequired = status.Error(codes.FailedPrecondition, "vault key enrollment required")
+)
 
 type AuthorizationServiceServer struct {
 	gophkeeperv1.UnimplementedAuthorizationServiceServer
 	userService          server.UserService
 	authorizationService server.AuthorizationService
+	sessionService       server.SessionService
+	mfaService           server.MFAService
 	validate             *validator.Validate
 }
 
 func NewAuthorizationServiceServer(
 	userService server.UserService,
 	authorizationService server.AuthorizationService,
+	sessionService server.SessionService,
+	mfaService server.MFAService,
 	validate *validator.Validate,
 ) *AuthorizationServiceServer {
 	return &AuthorizationServiceServer{
 		userService:          userService,
 		authorizationService: authorizationService,
+		sessionService:       sessionService,
+		mfaService:           mfaService,
 		validate:             validate,
 	}
 }
@@ -51,14 +67,105 @@ func (s *AuthorizationServiceServer) Authorize(
 	if bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(in.GetPassword())) != nil {
 		return nil, ErrInvalidCredentials
 	}
+	if len(user.VaultKey) == 0 {
+		// Принят сам мастер-пароль: сессия выдается только после Enroll.
+		return nil, ErrEnrollmentRequired
+	}
+
+	return s.signIn(ctx, user)
+}
+
+// Enroll переводит учетную запись без ключа хранилища на ключ
+// аутентификации и ключ хранилища клиента. Мастер-пароль проверяется
+// по сохраненному хэшу и больше не принимается.
+func (s *AuthorizationServiceServer) Enroll(
+	ctx context.Context,
+	in *gophkeeperv1.EnrollRequest,
+) (*gophkeeperv1.TokenResponse, error) {
+	if len(in.GetAuthKey()) == 0 || len(in.GetVaultKey()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "auth key and vault key are required")
+	}
+
+	user, err := s.userService.Get(ctx, in.GetLogin())
+	if errors.Is(err, server.ErrUserNotFound) {
+		return nil, ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	if bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(in.GetPassword())) != nil {
+		return nil, ErrInvalidCredentials
+	}
+
+	err = s.userService.EnrollVaultKey(ctx, user.Login, in.GetAuthKey(), in.GetVaultKey())
+	if errors.Is(err, server.ErrVaultKeyExists) {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	user.VaultKey = in.GetVaultKey()
+	return s.signIn(ctx, user)
+}
+
+// signIn завершает вход пользователя с проверенным паролем. Если включен
+// второй фактор, возвращает только токен входа для VerifyMFA.
+func (s *AuthorizationServiceServer) signIn(ctx context.Context, user *server.User) (*gophkeeperv1.TokenResponse, error) {
+	if user.MFAEnabled {
+		// Пароль верный, но сессия создается только после проверки
+		// второго фактора в VerifyMFA.
+		challenge, err := s.authorizationService.Challenge(ctx, user.Login)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+
+		var out gophkeeperv1.TokenResponse
+		out.SetMfaChallenge(challenge)
+		return &out, nil
+	}
+
+	return s.authorize(ctx, user)
+}
+
+func (s *AuthorizationServiceServer) VerifyMFA(
+	ctx context.Context,
+	in *gophkeeperv1.VerifyMFARequest,
+) (*gophkeeperv1.TokenResponse, error) {
+	login, err := s.authorizationService.VerifyChallenge(ctx, in.GetMfaChallenge())
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	err = s.mfaService.Verify(ctx, login, in.GetCode())
+	if errors.Is(err, server.ErrInvalidMFACode) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	user, err := s.userService.Get(ctx, login)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return s.authorize(ctx, user)
+}
 
-	token, err := s.authorizationService.Authorize(ctx, in.GetLogin())
+// authorize создает сессию пользователя и возвращает ее токены
+// вместе с ключом хранилища.
+func (s *AuthorizationServiceServer) authorize(ctx context.Context, user *server.User) (*gophkeeperv1.TokenResponse, error) {
+	tokens, err := s.authorizationService.Authorize(ctx, user.Login)
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
 	var out gophkeeperv1.TokenResponse
-	out.SetToken(token)
+	out.SetToken(tokens.AccessToken)
+	out.SetRefreshToken(tokens.RefreshToken)
+	out.SetVaultKey(user.VaultKey)
 	return &out, nil
 }
 
@@ -69,10 +176,14 @@ func (s *AuthorizationServiceServer) Register(
 	if err := s.validate.StructCtx(ctx, in); err != nil {
 		return nil, ErrInvalidCredentials
 	}
+	if len(in.GetVaultKey()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "vault key is required")
+	}
 
 	err := s.userService.Save(ctx, server.User{
 		Login:    in.GetLogin(),
 		Password: in.GetPassword(),
+		VaultKey: in.GetVaultKey(),
 	})
 	if errors.Is(err, server.ErrUserAlreadyExists) {
 		// Здесь можно было бы возвращать ошибку "Такой пользователь уже существует",
@@ -83,12 +194,189 @@ func (s *AuthorizationServiceServer) Register(
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
-	token, err := s.authorizationService.Authorize(ctx, in.GetLogin())
+	return s.authorize(ctx, &server.User{
+		Login:    in.GetLogin(),
+		VaultKey: in.GetVaultKey(),
+	})
+}
+
+func (s *AuthorizationServiceServer) Refresh(
+	ctx context.Context,
+	in *gophkeeperv1.RefreshRequest,
+) (*gophkeeperv1.TokenResponse, error) {
+	tokens, err := s.authorizationService.Refresh(ctx, in.GetRefreshToken())
+	if errors.Is(err, server.ErrInvalidToken) {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
 	var out gophkeeperv1.TokenResponse
-	out.SetToken(token)
+	out.SetToken(tokens.AccessToken)
+	out.SetRefreshToken(tokens.RefreshToken)
 	return &out, nil
 }
+
+func (s *AuthorizationServiceServer) Logout(ctx context.Context, _ *empty.Empty) (*empty.Empty, error) {
+	err := s.sessionService.Remove(ctx, server.UserFromContext(ctx), server.SessionFromContext(ctx))
+	if err != nil && !errors.Is(err, server.ErrSessionNotFound) {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &empty.Empty{}, nil
+}
+
+func (s *AuthorizationServiceServer) ListSessions(
+	ctx context.Context,
+	_ *empty.Empty,
+) (*gophkeeperv1.ListSessionsResponse, error) {
+	sessions, err := s.sessionService.GetAll(ctx, server.UserFromContext(ctx))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	current := server.SessionFromContext(ctx)
+	result := make([]*gophkeeperv1.Session, 0, len(sessions))
+	for _, session := range sessions {
+		var out gophkeeperv1.Session
+		out.SetId(session.ID)
+		out.SetCreatedAt(timestamppb.New(session.CreatedAt))
+		out.SetLastUsedAt(timestamppb.New(session.LastUsedAt))
+		out.SetExpiresAt(timestamppb.New(session.ExpiresAt))
+		out.SetCurrent(session.ID == current)
+		result = append(result, &out)
+	}
+
+	var out gophkeeperv1.ListSessionsResponse
+	out.SetSessions(result)
+	return &out, nil
+}
+
+func (s *AuthorizationServiceServer) RevokeSession(
+	ctx context.Context,
+	in *gophkeeperv1.RevokeSessionRequest,
+) (*empty.Empty, error) {
+	err := s.sessionService.Remove(ctx, server.UserFromContext(ctx), in.GetId())
+	if errors.Is(err, server.ErrSessionNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &empty.Empty{}, nil
+}
+
+func (s *AuthorizationServiceServer) SetupMFA(ctx context.Context, _ *empty.Empty) (*gophkeeperv1.SetupMFAResponse, error) {
+	setup, err := s.mfaService.Setup(ctx, server.UserFromContext(ctx))
+	if err != nil {
+		return nil, mfaError(err)
+	}
+
+	var out gophkeeperv1.SetupMFAResponse
+	out.SetSecret(setup.Secret)
+	out.SetUri(setup.URI)
+	return &out, nil
+}
+
+func (s *AuthorizationServiceServer) EnableMFA(
+	ctx context.Context,
+	in *gophkeeperv1.MFACode,
+) (*gophkeeperv1.RecoveryCodesResponse, error) {
+	recoveryCodes, err := s.mfaService.Enable(ctx, server.UserFromContext(ctx), in.GetCode())
+	if err != nil {
+		return nil, mfaError(err)
+	}
+
+	var out gophkeeperv1.RecoveryCodesResponse
+	out.SetCodes(recoveryCodes)
+	return &out, nil
+}
+
+func (s *AuthorizationServiceServer) DisableMFA(ctx context.Context, in *gophkeeperv1.MFACode) (*empty.Empty, error) {
+	if err := s.mfaService.Disable(ctx, server.UserFromContext(ctx), in.GetCode()); err != nil {
+		return nil, mfaError(err)
+	}
+	return &empty.Empty{}, nil
+}
+
+func (s *AuthorizationServiceServer) ChangePassword(
+	ctx context.Context,
+	in *gophkeeperv1.ChangePasswordRequest,
+) (*empty.Empty, error) {
+	if len(in.GetNewPassword()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "new password is required")
+	}
+	if len(in.GetVaultKey()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "vault key is required")
+	}
+
+	login := server.UserFromContext(ctx)
+	if err := s.checkPassword(ctx, login, in.GetCurrentPassword()); err != nil {
+		return nil, err
+	}
+
+	if err := s.userService.ChangePassword(ctx, login, in.GetNewPassword(), in.GetVaultKey()); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	// Остальные сессии могли быть открыты тем, кто узнал старый пароль.
+	sessions, err := s.sessionService.GetAll(ctx, login)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	current := server.SessionFromContext(ctx)
+	for _, session := range sessions {
+		if session.ID == current {
+			continue
+		}
+		err := s.sessionService.Remove(ctx, login, session.ID)
+		if err != nil && !errors.Is(err, server.ErrSessionNotFound) {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+
+	return &empty.Empty{}, nil
+}
+
+func (s *AuthorizationServiceServer) DeleteAccount(
+	ctx context.Context,
+	in *gophkeeperv1.DeleteAccountRequest,
+) (*empty.Empty, error) {
+	login := server.UserFromContext(ctx)
+	if err := s.checkPassword(ctx, login, in.GetPassword()); err != nil {
+		return nil, err
+	}
+
+	if err := s.userService.Delete(ctx, login); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &empty.Empty{}, nil
+}
+
+// checkPassword проверяет мастер-пароль пользователя перед изменением
+// учетной записи.
+func (s *AuthorizationServiceServer) checkPassword(ctx context.Context, login string, password string) error {
+	user, err := s.userService.Get(ctx, login)
+	if errors.Is(err, server.ErrUserNotFound) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	if bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)) != nil {
+		return ErrInvalidPassword
+	}
+	return nil
+}
+
+func mfaError(err error) error {
+	switch {
+	case errors.Is(err, server.ErrInvalidMFACode):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, server.ErrMFANotSetUp), errors.Is(err, server.ErrMFAAlreadyEnabled):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
@@ -2,6 +2,7 @@ package grpc
 
 import (
 	"context"
+	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/mkolibaba/gophkeeper/proto/gen/go/gophkeeperv1"
 	"github.com/mkolibaba/gophkeeper/server"
 	"github.com/mkolibaba/gophkeeper/server/mock"
@@ -9,6 +10,7 @@ import (
 	"golang.org/x/crypto/bcrypt"
 	"google.golang.org/grpc/codes"
 	"testing"
+	"time"
 )
 
 func TestAuthorize(t *testing.T) {
@@ -23,7 +25,9 @@ func TestAuthorize(t *testing.T) {
 			checks: func(out *gophkeeperv1.TokenResponse, err error) {
 				t.Helper()
 				require.NoError(t, err)
-				require.NotEmpty(t, out.GetToken())
+				require.Equal(t, "some token", out.GetToken())
+				require.Equal(t, "refresh token", out.GetRefreshToken())
+				require.Equal(t, []byte("vault key"), out.GetVaultKey())
 			},
 		},
 		"invalid_login": {
@@ -63,16 +67,17 @@ func TestAuthorize(t *testing.T) {
 			return &server.User{
 				Login:    "alice",
 				Password: string(hash),
+				VaultKey: []byte("vault key"),
 			}, nil
 		},
 	}
 	authorizationServiceMock := &mock.AuthorizationServiceMock{
-		AuthorizeFunc: func(ctx context.Context, login string) (string, error) {
-			return "some token", nil
+		AuthorizeFunc: func(ctx context.Context, login string) (server.Tokens, error) {
+			return server.Tokens{AccessToken: "some token", RefreshToken: "refresh token"}, nil
 		},
 	}
 
-	srv := createAuthorizationServiceServer(t, userServiceMock, authorizationServiceMock)
+	srv := createAuthorizationServiceServer(t, userServiceMock, authorizationServiceMock, &mock.SessionServiceMock{}, &mock.MFAServiceMock{})
 
 	for name, c := range cases {
 		t.Run(name, func(t *testing.T) {
@@ -95,29 +100,33 @@ func TestRegister(t *testing.T) {
 		},
 	}
 	authorizationServiceMock := &mock.AuthorizationServiceMock{
-		AuthorizeFunc: func(ctx context.Context, login string) (string, error) {
-			return "some token", nil
+		AuthorizeFunc: func(ctx context.Context, login string) (server.Tokens, error) {
+			return server.Tokens{AccessToken: "some token", RefreshToken: "refresh token"}, nil
 		},
 	}
 
-	srv := createAuthorizationServiceServer(t, userServiceMock, authorizationServiceMock)
+	srv := createAuthorizationServiceServer(t, userServiceMock, authorizationServiceMock, &mock.SessionServiceMock{}, &mock.MFAServiceMock{})
 
 	cases := map[string]struct {
 		login    string
 		password string
+		vaultKey []byte
 		checks   func(out *gophkeeperv1.TokenResponse, err error)
 	}{
 		"success": {
 			login:    "bob",
 			password: "123",
+			vaultKey: []byte("vault key"),
 			checks: func(out *gophkeeperv1.TokenResponse, err error) {
 				t.Helper()
 				require.NoError(t, err)
+				require.Equal(t, []byte("vault key"), out.GetVaultKey())
 			},
 		},
 		"user_exists": {
 			login:    "alice",
 			password: "123",
+			vaultKey: []byte("vault key"),
 			checks: func(out *gophkeeperv1.TokenResponse, err error) {
 				t.Helper()
 				require.ErrorIs(t, err, ErrInvalidCredentials)
@@ -126,6 +135,15 @@ func TestRegister(t *testing.T) {
 		"no_password": {
 			login:    "bob",
 			password: "",
+			vaultKey: []byte("vault key"),
+			checks: func(out *gophkeeperv1.TokenResponse, err error) {
+				t.Helper()
+				requireGrpcError(t, err, codes.InvalidArgument)
+			},
+		},
+		"no_vault_key": {
+			login:    "bob",
+			password: "123",
 			checks: func(out *gophkeeperv1.TokenResponse, err error) {
 				t.Helper()
 				requireGrpcError(t, err, codes.InvalidArgument)
@@ -138,16 +156,408 @@ func TestRegister(t *testing.T) {
 			var in gophkeeperv1.UserCredentials
 			in.SetLogin(c.login)
 			in.SetPassword(c.password)
+			in.SetVaultKey(c.vaultKey)
 			out, err := srv.Register(t.Context(), &in)
 			c.checks(out, err)
 		})
 	}
 }
 
+func TestEnroll(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("123"), bcrypt.DefaultCost)
+	require.NoError(t, err)
+
+	var enrolled []byte
+	userServiceMock := &mock.UserServiceMock{
+		GetFunc: func(ctx context.Context, login string) (*server.User, error) {
+			if login != "alice" {
+				return nil, server.ErrUserNotFound
+			}
+			return &server.User{Login: "alice", Password: string(hash), VaultKey: enrolled}, nil
+		},
+		EnrollVaultKeyFunc: func(ctx context.Context, login string, password string, vaultKey []byte) error {
+			if enrolled != nil {
+				return server.ErrVaultKeyExists
+			}
+			enrolled = vaultKey
+			return nil
+		},
+	}
+	authorizationServiceMock := &mock.AuthorizationServiceMock{
+		AuthorizeFunc: func(ctx context.Context, login string) (server.Tokens, error) {
+			return server.Tokens{AccessToken: "some token", RefreshToken: "refresh token"}, nil
+		},
+	}
+
+	srv := createAuthorizationServiceServer(t, userServiceMock, authorizationServiceMock, &mock.SessionServiceMock{}, &mock.MFAServiceMock{})
+
+	enroll := func(password string) (*gophkeeperv1.TokenResponse, error) {
+		var in gophkeeperv1.EnrollRequest
+		in.SetLogin("alice")
+		in.SetPassword(password)
+		in.SetAuthKey("auth key")
+		in.SetVaultKey([]byte("vault key"))
+		return srv.Enroll(t.Context(), &in)
+	}
+
+	// Учетная запись без ключа хранилища не принимает ключ аутентификации
+	// и не отличается от существующей до проверки пароля.
+	var in gophkeeperv1.UserCredentials
+	in.SetLogin("alice")
+	in.SetPassword("auth key")
+	_, err = srv.Authorize(t.Context(), &in)
+	require.ErrorIs(t, err, ErrInvalidCredentials)
+
+	// Принятый мастер-пароль требует перехода, но сессия не выдается.
+	in.SetPassword("123")
+	_, err = srv.Authorize(t.Context(), &in)
+	require.ErrorIs(t, err, ErrEnrollmentRequired)
+	require.Empty(t, authorizationServiceMock.AuthorizeCalls())
+
+	_, err = enroll("1234")
+	require.ErrorIs(t, err, ErrInvalidCredentials)
+	require.Empty(t, userServiceMock.EnrollVaultKeyCalls())
+
+	out, err := enroll("123")
+	require.NoError(t, err)
+	require.Equal(t, "some token", out.GetToken())
+	require.Equal(t, []byte("vault key"), out.GetVaultKey())
+	require.Equal(t, "auth key", userServiceMock.EnrollVaultKeyCalls()[0].Password)
+
+	_, err = enroll("123")
+	requireGrpcError(t, err, codes.FailedPrecondition)
+}
+
+func TestRefresh(t *testing.T) {
+	authorizationServiceMock := &mock.AuthorizationServiceMock{
+		RefreshFunc: func(ctx context.Context, refreshToken string) (server.Tokens, error) {
+			if refreshToken != "refresh token" {
+				return server.Tokens{}, server.ErrInvalidToken
+			}
+			return server.Tokens{AccessToken: "new token", RefreshToken: "new refresh token"}, nil
+		},
+	}
+
+	srv := createAuthorizationServiceServer(t, &mock.UserServiceMock{}, authorizationServiceMock, &mock.SessionServiceMock{}, &mock.MFAServiceMock{})
+
+	t.Run("success", func(t *testing.T) {
+		var in gophkeeperv1.RefreshRequest
+		in.SetRefreshToken("refresh token")
+		out, err := srv.Refresh(t.Context(), &in)
+		require.NoError(t, err)
+		require.Equal(t, "new token", out.GetToken())
+		require.Equal(t, "new refresh token", out.GetRefreshToken())
+	})
+	t.Run("invalid_token", func(t *testing.T) {
+		var in gophkeeperv1.RefreshRequest
+		in.SetRefreshToken("used token")
+		_, err := srv.Refresh(t.Context(), &in)
+		requireGrpcError(t, err, codes.Unauthenticated)
+	})
+}
+
+func TestSessions(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	sessionServiceMock := &mock.SessionServiceMock{
+		GetAllFunc: func(ctx context.Context, login string) ([]server.Session, error) {
+			return []server.Session{
+				{ID: "first", Login: login, CreatedAt: now, LastUsedAt: now, ExpiresAt: now.Add(time.Hour)},
+				{ID: "second", Login: login, CreatedAt: now, LastUsedAt: now, ExpiresAt: now.Add(time.Hour)},
+			}, nil
+		},
+		RemoveFunc: func(ctx context.Context, login string, id string) error {
+			if id != "first" && id != "second" {
+				return server.ErrSessionNotFound
+			}
+			return nil
+		},
+	}
+
+	srv := createAuthorizationServiceServer(t, &mock.UserServiceMock{}, &mock.AuthorizationServiceMock{}, sessionServiceMock, &mock.MFAServiceMock{})
+	ctx := server.NewContextWithSession(server.NewContextWithUser(t.Context(), "alice"), "second")
+
+	t.Run("list", func(t *testing.T) {
+		out, err := srv.ListSessions(ctx, &empty.Empty{})
+		require.NoError(t, err)
+
+		sessions := out.GetSessions()
+		require.Len(t, sessions, 2)
+		require.Equal(t, "first", sessions[0].GetId())
+		require.False(t, sessions[0].GetCurrent())
+		require.Equal(t, now, sessions[0].GetCreatedAt().AsTime())
+		require.Equal(t, now.Add(time.Hour), sessions[0].GetExpiresAt().AsTime())
+		require.True(t, sessions[1].GetCurrent())
+	})
+	t.Run("revoke", func(t *testing.T) {
+		var in gophkeeperv1.RevokeSessionRequest
+		in.SetId("first")
+		_, err := srv.RevokeSession(ctx, &in)
+		require.NoError(t, err)
+
+		in.SetId("unknown")
+		_, err = srv.RevokeSession(ctx, &in)
+		requireGrpcError(t, err, codes.NotFound)
+	})
+	t.Run("logout", func(t *testing.T) {
+		_, err := srv.Logout(ctx, &empty.Empty{})
+		require.NoError(t, err)
+
+		calls := sessionServiceMock.RemoveCalls()
+		last := calls[len(calls)-1]
+		require.Equal(t, "alice", last.Login)
+		require.Equal(t, "second", last.ID)
+	})
+}
+
+func TestAuthorizeMFA(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("123"), bcrypt.DefaultCost)
+	require.NoError(t, err)
+
+	userServiceMock := &mock.UserServiceMock{
+		GetFunc: func(ctx context.Context, login string) (*server.User, error) {
+			return &server.User{
+				Login:      "alice",
+				Password:   string(hash),
+				VaultKey:   []byte("vault key"),
+				MFASecret:  "SECRET",
+				MFAEnabled: true,
+			}, nil
+		},
+	}
+	authorizationServiceMock := &mock.AuthorizationServiceMock{
+		AuthorizeFunc: func(ctx context.Context, login string) (server.Tokens, error) {
+			return server.Tokens{AccessToken: "some token", RefreshToken: "refresh token"}, nil
+		},
+		ChallengeFunc: func(ctx context.Context, login string) (string, error) {
+			return "challenge for " + login, nil
+		},
+		VerifyChallengeFunc: func(ctx context.Context, challenge string) (string, error) {
+			if challenge != "challenge for alice" {
+				return "", server.ErrInvalidToken
+			}
+			return "alice", nil
+		},
+	}
+	mfaServiceMock := &mock.MFAServiceMock{
+		VerifyFunc: func(ctx context.Context, login string, code string) error {
+			if code != "123456" {
+				return server.ErrInvalidMFACode
+			}
+			return nil
+		},
+	}
+
+	srv := createAuthorizationServiceServer(t, userServiceMock, authorizationServiceMock, &mock.SessionServiceMock{}, mfaServiceMock)
+
+	var credentials gophkeeperv1.UserCredentials
+	credentials.SetLogin("alice")
+	credentials.SetPassword("123")
+	out, err := srv.Authorize(t.Context(), &credentials)
+	require.NoError(t, err)
+	require.Equal(t, "challenge for alice", out.GetMfaChallenge())
+	require.Empty(t, out.GetToken())
+	require.Empty(t, out.GetVaultKey())
+	require.Empty(t, authorizationServiceMock.AuthorizeCalls())
+
+	cases := map[string]struct {
+		challenge string
+		code      string
+		checks    func(out *gophkeeperv1.TokenResponse, err error)
+	}{
+		"success": {
+			challenge: out.GetMfaChallenge(),
+			code:      "123456",
+			checks: func(out *gophkeeperv1.TokenResponse, err error) {
+				t.Helper()
+				require.NoError(t, err)
+				require.Equal(t, "some token", out.GetToken())
+				require.Equal(t, []byte("vault key"), out.GetVaultKey())
+			},
+		},
+		"invalid_code": {
+			challenge: out.GetMfaChallenge(),
+			code:      "000000",
+			checks: func(out *gophkeeperv1.TokenResponse, err error) {
+				t.Helper()
+				requireGrpcError(t, err, codes.InvalidArgument)
+			},
+		},
+		"invalid_challenge": {
+			challenge: "forged",
+			code:      "123456",
+			checks: func(out *gophkeeperv1.TokenResponse, err error) {
+				t.Helper()
+				requireGrpcError(t, err, codes.Unauthenticated)
+			},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			var in gophkeeperv1.VerifyMFARequest
+			in.SetMfaChallenge(c.challenge)
+			in.SetCode(c.code)
+			out, err := srv.VerifyMFA(t.Context(), &in)
+			c.checks(out, err)
+		})
+	}
+}
+
+func TestMFASetup(t *testing.T) {
+	enabled := false
+	mfaServiceMock := &mock.MFAServiceMock{
+		SetupFunc: func(ctx context.Context, login string) (server.MFASetup, error) {
+			if enabled {
+				return server.MFASetup{}, server.ErrMFAAlreadyEnabled
+			}
+			return server.MFASetup{Secret: "SECRET", URI: "otpauth://totp/GophKeeper:" + login}, nil
+		},
+		EnableFunc: func(ctx context.Context, login string, code string) ([]string, error) {
+			if code != "123456" {
+				return nil, server.ErrInvalidMFACode
+			}
+			enabled = true
+			return []string{"aaaaa-bbbbb"}, nil
+		},
+		DisableFunc: func(ctx context.Context, login string, code string) error {
+			return server.ErrMFANotSetUp
+		},
+	}
+
+	srv := createAuthorizationServiceServer(t, &mock.UserServiceMock{}, &mock.AuthorizationServiceMock{}, &mock.SessionServiceMock{}, mfaServiceMock)
+	ctx := server.NewContextWithUser(t.Context(), "alice")
+
+	setup, err := srv.SetupMFA(ctx, &empty.Empty{})
+	require.NoError(t, err)
+	require.Equal(t, "SECRET", setup.GetSecret())
+	require.Equal(t, "otpauth://totp/GophKeeper:alice", setup.GetUri())
+
+	var code gophkeeperv1.MFACode
+	code.SetCode("123456")
+	recoveryCodes, err := srv.EnableMFA(ctx, &code)
+	require.NoError(t, err)
+	require.Equal(t, []string{"aaaaa-bbbbb"}, recoveryCodes.GetCodes())
+
+	_, err = srv.SetupMFA(ctx, &empty.Empty{})
+	requireGrpcError(t, err, codes.FailedPrecondition)
+
+	code.SetCode("000000")
+	_, err = srv.EnableMFA(ctx, &code)
+	requireGrpcError(t, err, codes.InvalidArgument)
+
+	_, err = srv.DisableMFA(ctx, &code)
+	requireGrpcError(t, err, codes.FailedPrecondition)
+}
+
 func createAuthorizationServiceServer(
 	t *testing.T,
 	userService server.UserService,
 	authorizationService server.AuthorizationService,
+	sessionService server.SessionService,
+	mfaService server.MFAService,
 ) *AuthorizationServiceServer {
-	return NewAuthorizationServiceServer(userService, authorizationService, newTestValidator(t))
+	return NewAuthorizationServiceServer(userService, authorizationService, sessionService, mfaService, newTestValidator(t))
+}
+
+func TestChangePassword(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("123"), bcrypt.DefaultCost)
+	require.NoError(t, err)
+
+	userServiceMock := &mock.UserServiceMock{
+		GetFunc: func(ctx context.Context, login string) (*server.User, error) {
+			return &server.User{Login: login, Password: string(hash)}, nil
+		},
+		ChangePasswordFunc: func(ctx context.Context, login string, password string, vaultKey []byte) error {
+			return nil
+		},
+	}
+	sessionServiceMock := &mock.SessionServiceMock{
+		GetAllFunc: func(ctx context.Context, login string) ([]server.Session, error) {
+			return []server.Session{{ID: "first"}, {ID: "second"}, {ID: "third"}}, nil
+		},
+		RemoveFunc: func(ctx context.Context, login string, id string) error {
+			return nil
+		},
+	}
+
+	srv := createAuthorizationServiceServer(t, userServiceMock, &mock.AuthorizationServiceMock{}, sessionServiceMock, &mock.MFAServiceMock{})
+	ctx := server.NewContextWithSession(server.NewContextWithUser(t.Context(), "alice"), "second")
+
+	t.Run("invalid_password", func(t *testing.T) {
+		var in gophkeeperv1.ChangePasswordRequest
+		in.SetCurrentPassword("wrong")
+		in.SetNewPassword("456")
+		in.SetVaultKey([]byte("new vault key"))
+
+		_, err := srv.ChangePassword(ctx, &in)
+		requireGrpcError(t, err, codes.InvalidArgument)
+		require.Empty(t, userServiceMock.ChangePasswordCalls())
+	})
+	t.Run("no_vault_key", func(t *testing.T) {
+		var in gophkeeperv1.ChangePasswordRequest
+		in.SetCurrentPassword("123")
+		in.SetNewPassword("456")
+
+		_, err := srv.ChangePassword(ctx, &in)
+		requireGrpcError(t, err, codes.InvalidArgument)
+	})
+	t.Run("success", func(t *testing.T) {
+		var in gophkeeperv1.ChangePasswordRequest
+		in.SetCurrentPassword("123")
+		in.SetNewPassword("456")
+		in.SetVaultKey([]byte("new vault key"))
+
+		_, err := srv.ChangePassword(ctx, &in)
+		require.NoError(t, err)
+
+		calls := userServiceMock.ChangePasswordCalls()
+		require.Len(t, calls, 1)
+		require.Equal(t, "alice", calls[0].Login)
+		require.Equal(t, "456", calls[0].Password)
+		require.Equal(t, []byte("new vault key"), calls[0].VaultKey)
+
+		// Текущая сессия остается, остальные завершаются.
+		var removed []string
+		for _, call := range sessionServiceMock.RemoveCalls() {
+			removed = append(removed, call.ID)
+		}
+		require.Equal(t, []string{"first", "third"}, removed)
+	})
+}
+
+func TestDeleteAccount(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("123"), bcrypt.DefaultCost)
+	require.NoError(t, err)
+
+	userServiceMock := &mock.UserServiceMock{
+		GetFunc: func(ctx context.Context, login string) (*server.User, error) {
+			return &server.User{Login: login, Password: string(hash)}, nil
+		},
+		DeleteFunc: func(ctx context.Context, login string) error {
+			return nil
+		},
+	}
+
+	srv := createAuthorizationServiceServer(t, userServiceMock, &mock.AuthorizationServiceMock{}, &mock.SessionServiceMock{}, &mock.MFAServiceMock{})
+	ctx := server.NewContextWithUser(t.Context(), "alice")
+
+	t.Run("invalid_password", func(t *testing.T) {
+		var in gophkeeperv1.DeleteAccountRequest
+		in.SetPassword("wrong")
+
+		_, err := srv.DeleteAccount(ctx, &in)
+		requireGrpcError(t, err, codes.InvalidArgument)
+		require.Empty(t, userServiceMock.DeleteCalls())
+	})
+	t.Run("success", func(t *testing.T) {
+		var in gophkeeperv1.DeleteAccountRequest
+		in.SetPassword("123")
+
+		_, err := srv.DeleteAccount(ctx, &in)
+		require.NoError(t, err)
+
+		calls := userServiceMock.DeleteCalls()
+		require.Len(t, calls, 1)
+		require.Equal(t, "alice", calls[0].Login)
+	})
 }
@@ -2,6 +2,7 @@ package grpc
 
 import (
 	"context"
+	"crypto/sha256"
 	"errors"
 	"github.com/charmbracelet/log"
 	"github.com/go-playground/validator/v10"
@@ -13,81 +14,106 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"io"
-	"os"
 )
 
 type BinaryServiceServer struct {
 	gophkeeperv1.UnimplementedBinaryServiceServer
 	binaryService server.BinaryService
+	uploadService server.UploadService
 	validate      *validator.Validate
 	logger        *log.Logger
 }
 
 func NewBinaryServiceServer(
 	binaryService server.BinaryService,
+	uploadService server.UploadService,
 	validate *validator.Validate,
 	logger *log.Logger,
 ) *BinaryServiceServer {
 	return &BinaryServiceServer{
 		binaryService: binaryService,
+		uploadService: uploadService,
 		validate:      validate,
 		logger:        logger,
 	}
 }
 
-func (s *BinaryServiceServer) Upload(stream grpc.ClientStreamingServer[gophkeeperv1.SaveBinaryRequest, empty.Empty]) error {
-	file, err := os.CreateTemp("", "*.tmp")
-	if err != nil {
-		return status.Error(codes.Internal, err.Error())
+func (s *BinaryServiceServer) CreateUpload(ctx context.Context, in *gophkeeperv1.CreateUploadRequest) (*gophkeeperv1.UploadStatus, error) {
+	upload := server.Upload{
+		BinaryData: server.BinaryData{
+			Name:        in.GetName(),
+			Filename:    in.GetFilename(),
+			Size:        in.GetSize(),
+			Notes:       in.GetNotes(),
+			Compression: server.Compression(in.GetCompression()),
+		},
+		ContentSize: in.GetContentSize(),
+		SHA256:      in.GetSha256(),
+		ContentKey:  in.GetContentKey(),
 	}
-	defer os.Remove(file.Name())
 
-	var data server.BinaryData
+	if err := s.validate.StructCtx(ctx, &upload.BinaryData); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if upload.ContentSize < 0 {
+		return nil, status.Error(codes.InvalidArgument, "content size must not be negative")
+	}
+	if len(upload.SHA256) != sha256.Size {
+		return nil, status.Error(codes.InvalidArgument, "sha256 must be 32 bytes long")
+	}
+	if upload.ContentKey != nil && len(upload.ContentKey) != sha256.Size {
+		return nil, status.Error(codes.InvalidArgument, "content key must be 32 bytes long")
+	}
+	if !upload.Compression.Valid() {
+		return nil, status.Error(codes.InvalidArgument, "unsupported compression")
+	}
 
-	for {
-		in, err := stream.Recv()
-		if err == io.EOF {
-			break
-		}
-		if err != nil {
-			return status.Error(codes.Internal, err.Error())
-		}
+	created, err := s.uploadService.Create(ctx, upload)
+	if err != nil {
+		return nil, s.uploadError(err)
+	}
 
-		if data.Name == "" {
-			data = server.BinaryData{
-				Name:     in.GetName(),
-				Filename: in.GetFilename(),
-				Size:     in.GetSize(),
-				Notes:    in.GetNotes(),
-			}
-
-			if err := s.validate.StructCtx(stream.Context(), &data); err != nil {
-				return status.Error(codes.InvalidArgument, err.Error())
-			}
-		}
+	return newUploadStatusMessage(created.ID, created.Offset), nil
+}
 
-		_, err = file.Write(in.GetChunk().GetData())
-		if err != nil {
-			return status.Error(codes.Internal, err.Error())
-		}
+func (s *BinaryServiceServer) UploadChunks(stream grpc.ClientStreamingServer[gophkeeperv1.UploadChunk, gophkeeperv1.UploadStatus]) error {
+	first, err := stream.Recv()
+	if err == io.EOF {
+		return status.Error(codes.InvalidArgument, "no chunks received")
+	}
+	if err != nil {
+		return err
 	}
 
-	if err = file.Sync(); err != nil {
-		return status.Error(codes.Internal, err.Error())
+	reader := &chunkReader{
+		stream: stream,
+		id:     first.GetUploadId(),
+		offset: first.GetOffset(),
+		data:   first.GetData(),
 	}
-	if _, err := file.Seek(0, io.SeekStart); err != nil {
-		return status.Error(codes.Internal, err.Error())
+	offset, err := s.uploadService.Write(stream.Context(), reader.id, first.GetOffset(), reader)
+	if err != nil {
+		return s.uploadError(err)
 	}
 
-	err = s.binaryService.Create(stream.Context(), server.ReadableBinaryData{
-		BinaryData: data,
-		DataReader: file,
-	})
+	return stream.SendAndClose(newUploadStatusMessage(reader.id, offset))
+}
+
+func (s *BinaryServiceServer) GetUpload(ctx context.Context, in *gophkeeperv1.UploadRequest) (*gophkeeperv1.UploadStatus, error) {
+	upload, err := s.uploadService.Get(ctx, in.GetUploadId())
 	if err != nil {
-		return status.Error(codes.Internal, err.Error())
+		return nil, s.uploadError(err)
+	}
+
+	return newUploadStatusMessage(upload.ID, upload.Offset), nil
+}
+
+func (s *BinaryServiceServer) CompleteUpload(ctx context.Context, in *gophkeeperv1.UploadRequest) (*empty.Empty, error) {
+	if err := s.uploadService.Complete(ctx, in.GetUploadId()); err != nil {
+		return nil, s.uploadError(err)
 	}
 
-	return stream.SendAndClose(&empty.Empty{})
+	return &empty.Empty{}, nil
 }
 
 func (s *BinaryServiceServer) GetAll(ctx context.Context, _ *empty.Empty) (*gophkeeperv1.GetAllBinariesResponse, error) {
@@ -99,13 +125,7 @@ func (s *BinaryServiceServer) GetAll(ctx context.Context, _ *empty.Empty) (*goph
 
 	var result []*gophkeeperv1.Binary
 	for _, binary := range binaries {
-		var out gophkeeperv1.Binary
-		out.SetId(binary.ID)
-		out.SetName(binary.Name)
-		out.SetFilename(binary.Filename)
-		out.SetSize(binary.Size)
-		out.SetNotes(binary.Notes)
-		result = append(result, &out)
+		result = append(result, newBinaryMessage(binary))
 	}
 
 	var out gophkeeperv1.GetAllBinariesResponse
@@ -125,6 +145,10 @@ func (s *BinaryServiceServer) Remove(ctx context.Context, in *gophkeeperv1.Remov
 }
 
 func (s *BinaryServiceServer) Download(in *gophkeeperv1.DownloadBinaryRequest, stream grpc.ServerStreamingServer[gophkeeperv1.DownloadBinaryResponse]) error {
+	if in.GetOffset() < 0 || in.GetLength() < 0 {
+		return status.Error(codes.InvalidArgument, "offset and length must not be negative")
+	}
+
 	binary, err := s.binaryService.Get(stream.Context(), in.GetId())
 	if err != nil {
 		if errors.Is(err, server.ErrDataNotFound) {
@@ -133,17 +157,29 @@ func (s *BinaryServiceServer) Download(in *gophkeeperv1.DownloadBinaryRequest, s
 		s.logger.Error("failed to retrieve data", "err", err)
 		return status.Error(codes.Internal, "internal server error")
 	}
+	defer binary.DataReader.Close()
+
+	if in.GetOffset() > binary.ContentSize {
+		return status.Errorf(codes.OutOfRange, "offset exceeds content size %d", binary.ContentSize)
+	}
+	if err := skip(binary.DataReader, in.GetOffset()); err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	var content io.Reader = binary.DataReader
+	if in.GetLength() > 0 {
+		content = io.LimitReader(content, in.GetLength())
+	}
 
-	file := binary.DataReader
 	buf := make([]byte, 64*1024) // 64 KB
-	idx := 0
+	first := true
 
 	for {
-		n, err := file.Read(buf)
-		if err == io.EOF {
+		n, err := io.ReadFull(content, buf)
+		if err == io.EOF && !first {
 			break
 		}
-		if err != nil {
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
 			return status.Error(codes.Internal, err.Error())
 		}
 
@@ -152,16 +188,110 @@ func (s *BinaryServiceServer) Download(in *gophkeeperv1.DownloadBinaryRequest, s
 
 		var out gophkeeperv1.DownloadBinaryResponse
 		out.SetChunk(&chunk)
-		out.SetName(binary.Name)
-		out.SetFilename(binary.Filename)
-		out.SetSize(binary.Size)
+		if first {
+			// Первое сообщение отправляется, даже если отдавать нечего:
+			// клиенту нужны метаданные.
+			out.SetName(binary.Name)
+			out.SetFilename(binary.Filename)
+			out.SetSize(binary.Size)
+			out.SetContentSize(binary.ContentSize)
+			out.SetSha256(binary.SHA256)
+			out.SetCompression(gophkeeperv1.Compression(binary.Compression))
+			first = false
+		}
 
 		if err := stream.Send(&out); err != nil {
 			return status.Error(codes.Internal, err.Error())
 		}
-
-		idx++
+		if n < len(buf) {
+			break
+		}
 	}
 
 	return nil
 }
+
+// skip пропускает первые offset байт r.
+func skip(r io.Reader, offset int64) error {
+	if seeker, ok := r.(io.Seeker); ok {
+		_, err := seeker.Seek(offset, io.SeekStart)
+		return err
+	}
+	_, err := io.CopyN(io.Discard, r, offset)
+	return err
+}
+
+// uploadError преобразует ошибку загрузки в статус gRPC.
+func (s *BinaryServiceServer) uploadError(err error) error {
+	var offsetErr *server.UploadOffsetError
+	switch {
+	case errors.Is(err, server.ErrUploadNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.As(err, &offsetErr), errors.Is(err, server.ErrUploadIncomplete):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, server.ErrUploadInProgress):
+		return status.Error(codes.Aborted, err.Error())
+	case errors.Is(err, server.ErrUploadTooLarge):
+		return status.Error(codes.OutOfRange, err.Error())
+	case errors.Is(err, server.ErrChecksumMismatch):
+		return status.Error(codes.DataLoss, err.Error())
+	case errors.Is(err, server.ErrQuotaExceeded), errors.Is(err, server.ErrFileTooLarge):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, errUnexpectedChunk):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case status.Code(err) != codes.Unknown:
+		// Ошибка чтения потока, например отмена запроса клиентом.
+		return err
+	}
+	s.logger.Error("failed to process upload", "err", err)
+	return status.Error(codes.Internal, "internal server error")
+}
+
+var errUnexpectedChunk = errors.New("chunk does not continue the upload")
+
+// chunkReader читает содержимое из потока частей загрузки. Части должны
+// относиться к одной загрузке и идти подряд.
+type chunkReader struct {
+	stream grpc.ClientStreamingServer[gophkeeperv1.UploadChunk, gophkeeperv1.UploadStatus]
+	id     string
+	// offset - смещение начала data.
+	offset int64
+	data   []byte
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	for len(r.data) == 0 {
+		in, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		if in.GetUploadId() != r.id || in.GetOffset() != r.offset {
+			return 0, errUnexpectedChunk
+		}
+		r.data = in.GetData()
+	}
+
+	n := copy(p, r.data)
+	r.data = r.data[n:]
+	r.offset += int64(n)
+	return n, nil
+}
+
+func newUploadStatusMessage(id string, offset int64) *gophkeeperv1.UploadStatus {
+	var out gophkeeperv1.UploadStatus
+	out.SetUploadId(id)
+	out.SetOffset(offset)
+	return &out
+}
+
+func newBinaryMessage(binary server.BinaryData) *gophkeeperv1.Binary {
+	var out gophkeeperv1.Binary
+	out.SetId(binary.ID)
+	out.SetName(binary.Name)
+	out.SetFilename(binary.Filename)
+	out.SetSize(binary.Size)
+	out.SetNotes(binary.Notes)
+	out.SetVersion(binary.Version)
+	out.SetCompression(gophkeeperv1.Compression(binary.Compression))
+	return &out
+}
@@ -3,12 +3,13 @@ package grpc
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
 	"fmt"
 	"io"
+	"strings"
 	"testing"
 
 	"github.com/charmbracelet/log"
-	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/mkolibaba/gophkeeper/proto/gen/go/gophkeeperv1"
 	"github.com/mkolibaba/gophkeeper/server"
 	"github.com/mkolibaba/gophkeeper/server/mock"
@@ -16,18 +17,20 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
 )
 
 // mockUploadStream for testing client-side streaming
 type mockUploadStream struct {
-	grpc.ClientStream
+	grpc.ServerStream
 	ctx      context.Context
-	requests []*gophkeeperv1.SaveBinaryRequest
+	requests []*gophkeeperv1.UploadChunk
 	recvIdx  int
+	response *gophkeeperv1.UploadStatus
 }
 
 func (s *mockUploadStream) Context() context.Context { return s.ctx }
-func (s *mockUploadStream) Recv() (*gophkeeperv1.SaveBinaryRequest, error) {
+func (s *mockUploadStream) Recv() (*gophkeeperv1.UploadChunk, error) {
 	if s.recvIdx >= len(s.requests) {
 		return nil, io.EOF
 	}
@@ -35,13 +38,13 @@ func (s *mockUploadStream) Recv() (*gophkeeperv1.SaveBinaryRequest, error) {
 	s.recvIdx++
 	return req, nil
 }
-func (s *mockUploadStream) SendAndClose(_ *empty.Empty) error { return nil }
-func (s *mockUploadStream) SendHeader(_ metadata.MD) error    { return nil }
-func (s *mockUploadStream) SetHeader(_ metadata.MD) error     { return nil }
-func (s *mockUploadStream) SetTrailer(_ metadata.MD)          {}
-func (s *mockUploadStream) CloseSend() error                  { return nil }
-func (s *mockUploadStream) Header() (metadata.MD, error)      { return nil, nil }
-func (s *mockUploadStream) Trailer() metadata.MD              { return nil }
+func (s *mockUploadStream) SendAndClose(resp *gophkeeperv1.UploadStatus) error {
+	s.response = resp
+	return nil
+}
+func (s *mockUploadStream) SendHeader(_ metadata.MD) error { return nil }
+func (s *mockUploadStream) SetHeader(_ metadata.MD) error  { return nil }
+func (s *mockUploadStream) SetTrailer(_ metadata.MD)       {}
 
 // mockDownloadStream for testing server-side streaming
 type mockDownloadStream struct {
@@ -52,84 +55,216 @@ type mockDownloadStream struct {
 
 func (s *mockDownloadStream) Context() context.Context { return s.ctx }
 func (s *mockDownloadStream) Send(resp *gophkeeperv1.DownloadBinaryResponse) error {
-	s.responses = append(s.responses, resp)
+	// Сервер переиспользует буфер чанка, как и настоящий поток, копируем
+	// сообщение до следующей отправки.
+	s.responses = append(s.responses, proto.CloneOf(resp))
 	return nil
 }
 func (s *mockDownloadStream) SendHeader(_ metadata.MD) error { return nil }
 func (s *mockDownloadStream) SetHeader(_ metadata.MD) error  { return nil }
 func (s *mockDownloadStream) SetTrailer(_ metadata.MD)       {}
 
-func TestBinaryUpload(t *testing.T) {
+func TestBinaryCreateUpload(t *testing.T) {
+	hash := sha256.Sum256([]byte("helloworld"))
+	newRequest := func() *gophkeeperv1.CreateUploadRequest {
+		var in gophkeeperv1.CreateUploadRequest
+		in.SetName("testfile")
+		in.SetFilename("test.txt")
+		in.SetSize(10)
+		in.SetContentSize(10)
+		in.SetSha256(hash[:])
+		in.SetCompression(gophkeeperv1.Compression_COMPRESSION_ZSTD)
+		in.SetContentKey(bytes.Repeat([]byte{1}, sha256.Size))
+		return &in
+	}
+
 	t.Run("success", func(t *testing.T) {
-		service := &mock.BinaryServiceMock{
-			CreateFunc: func(ctx context.Context, data server.ReadableBinaryData) error {
-				return nil
+		service := &mock.UploadServiceMock{
+			CreateFunc: func(ctx context.Context, upload server.Upload) (*server.Upload, error) {
+				require.Equal(t, "testfile", upload.Name)
+				require.EqualValues(t, 10, upload.ContentSize)
+				require.Equal(t, server.CompressionZstd, upload.Compression)
+				require.Len(t, upload.ContentKey, sha256.Size)
+				upload.ID = "upload-1"
+				return &upload, nil
 			},
 		}
-		srv := createBinaryServiceServer(t, service)
+		srv := createUploadServiceServer(t, service)
 
-		var req1 gophkeeperv1.SaveBinaryRequest
-		req1.SetName("testfile")
-		req1.SetFilename("test.txt")
-		req1.SetSize(10)
-		var chunk1 gophkeeperv1.FileChunk
-		chunk1.SetData([]byte("hello"))
-		req1.SetChunk(&chunk1)
+		out, err := srv.CreateUpload(t.Context(), newRequest())
+		require.NoError(t, err)
+		require.Equal(t, "upload-1", out.GetUploadId())
+		require.Zero(t, out.GetOffset())
+	})
+	t.Run("validation_error", func(t *testing.T) {
+		srv := createUploadServiceServer(t, &mock.UploadServiceMock{})
 
-		var req2 gophkeeperv1.SaveBinaryRequest
-		var chunk2 gophkeeperv1.FileChunk
-		chunk2.SetData([]byte("world"))
-		req2.SetChunk(&chunk2)
+		in := newRequest()
+		in.SetName("")
+		_, err := srv.CreateUpload(t.Context(), in)
+		requireGrpcError(t, err, codes.InvalidArgument)
 
-		stream := &mockUploadStream{
-			ctx:      t.Context(),
-			requests: []*gophkeeperv1.SaveBinaryRequest{&req1, &req2},
+		in = newRequest()
+		in.SetSha256([]byte("short"))
+		_, err = srv.CreateUpload(t.Context(), in)
+		requireGrpcError(t, err, codes.InvalidArgument)
+
+		in = newRequest()
+		in.SetContentKey([]byte("short"))
+		_, err = srv.CreateUpload(t.Context(), in)
+		requireGrpcError(t, err, codes.InvalidArgument)
+
+		in = newRequest()
+		in.SetCompression(42)
+		_, err = srv.CreateUpload(t.Context(), in)
+		requireGrpcError(t, err, codes.InvalidArgument)
+	})
+	t.Run("quota_exceeded", func(t *testing.T) {
+		for _, quotaErr := range []error{server.ErrQuotaExceeded, server.ErrFileTooLarge} {
+			service := &mock.UploadServiceMock{
+				CreateFunc: func(ctx context.Context, upload server.Upload) (*server.Upload, error) {
+					return nil, quotaErr
+				},
+			}
+			srv := createUploadServiceServer(t, service)
+
+			_, err := srv.CreateUpload(t.Context(), newRequest())
+			requireGrpcError(t, err, codes.ResourceExhausted)
 		}
+	})
+}
+
+func TestBinaryUploadChunks(t *testing.T) {
+	newChunk := func(id string, offset int64, data string) *gophkeeperv1.UploadChunk {
+		var chunk gophkeeperv1.UploadChunk
+		chunk.SetUploadId(id)
+		chunk.SetOffset(offset)
+		chunk.SetData([]byte(data))
+		return &chunk
+	}
+	newService := func(committed int64) (*mock.UploadServiceMock, *bytes.Buffer) {
+		var content bytes.Buffer
+		return &mock.UploadServiceMock{
+			WriteFunc: func(ctx context.Context, id string, offset int64, r io.Reader) (int64, error) {
+				if offset != committed {
+					return committed, &server.UploadOffsetError{Offset: committed}
+				}
+				n, err := io.Copy(&content, r)
+				return offset + n, err
+			},
+		}, &content
+	}
 
-		err := srv.Upload(stream)
+	t.Run("success", func(t *testing.T) {
+		service, content := newService(5)
+		srv := createUploadServiceServer(t, service)
+
+		stream := &mockUploadStream{
+			ctx: t.Context(),
+			requests: []*gophkeeperv1.UploadChunk{
+				newChunk("upload-1", 5, "hello"),
+				newChunk("upload-1", 10, "world"),
+			},
+		}
+		err := srv.UploadChunks(stream)
 		require.NoError(t, err)
+		require.Equal(t, "helloworld", content.String())
+		require.EqualValues(t, 15, stream.response.GetOffset())
 	})
-
-	t.Run("validation_error", func(t *testing.T) {
-		srv := createBinaryServiceServer(t, &mock.BinaryServiceMock{})
-		var req gophkeeperv1.SaveBinaryRequest
-		var chunk gophkeeperv1.FileChunk
-		chunk.SetData([]byte("hello"))
-		req.SetChunk(&chunk)
+	t.Run("offset_mismatch", func(t *testing.T) {
+		service, _ := newService(5)
+		srv := createUploadServiceServer(t, service)
 
 		stream := &mockUploadStream{
 			ctx:      t.Context(),
-			requests: []*gophkeeperv1.SaveBinaryRequest{&req},
+			requests: []*gophkeeperv1.UploadChunk{newChunk("upload-1", 0, "hello")},
 		}
-		err := srv.Upload(stream)
-		requireGrpcError(t, err, codes.InvalidArgument)
+		err := srv.UploadChunks(stream)
+		requireGrpcError(t, err, codes.FailedPrecondition)
 	})
+	t.Run("gap", func(t *testing.T) {
+		service, _ := newService(0)
+		srv := createUploadServiceServer(t, service)
 
-	t.Run("service_error", func(t *testing.T) {
-		service := &mock.BinaryServiceMock{
-			CreateFunc: func(ctx context.Context, data server.ReadableBinaryData) error {
-				return fmt.Errorf("db error")
+		stream := &mockUploadStream{
+			ctx: t.Context(),
+			requests: []*gophkeeperv1.UploadChunk{
+				newChunk("upload-1", 0, "hello"),
+				newChunk("upload-1", 6, "world"),
 			},
 		}
-		srv := createBinaryServiceServer(t, service)
+		err := srv.UploadChunks(stream)
+		requireGrpcError(t, err, codes.InvalidArgument)
+	})
+	t.Run("no_chunks", func(t *testing.T) {
+		srv := createUploadServiceServer(t, &mock.UploadServiceMock{})
 
-		var req gophkeeperv1.SaveBinaryRequest
-		req.SetName("testfile")
-		req.SetFilename("test.txt")
-		req.SetSize(5)
-		var chunk gophkeeperv1.FileChunk
-		chunk.SetData([]byte("hello"))
-		req.SetChunk(&chunk)
+		err := srv.UploadChunks(&mockUploadStream{ctx: t.Context()})
+		requireGrpcError(t, err, codes.InvalidArgument)
+	})
+}
 
-		stream := &mockUploadStream{
-			ctx:      t.Context(),
-			requests: []*gophkeeperv1.SaveBinaryRequest{&req},
+func TestBinaryGetUpload(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		service := &mock.UploadServiceMock{
+			GetFunc: func(ctx context.Context, id string) (*server.Upload, error) {
+				return &server.Upload{ID: id, Offset: 42}, nil
+			},
 		}
-		err := srv.Upload(stream)
-		requireGrpcError(t, err, codes.Internal)
+		srv := createUploadServiceServer(t, service)
+
+		var in gophkeeperv1.UploadRequest
+		in.SetUploadId("upload-1")
+		out, err := srv.GetUpload(t.Context(), &in)
+		require.NoError(t, err)
+		require.EqualValues(t, 42, out.GetOffset())
+	})
+	t.Run("not_found", func(t *testing.T) {
+		service := &mock.UploadServiceMock{
+			GetFunc: func(ctx context.Context, id string) (*server.Upload, error) {
+				return nil, server.ErrUploadNotFound
+			},
+		}
+		srv := createUploadServiceServer(t, service)
+
+		_, err := srv.GetUpload(t.Context(), &gophkeeperv1.UploadRequest{})
+		requireGrpcError(t, err, codes.NotFound)
 	})
 }
 
+func TestBinaryCompleteUpload(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		code codes.Code
+	}{
+		{"success", nil, codes.OK},
+		{"incomplete", server.ErrUploadIncomplete, codes.FailedPrecondition},
+		{"in_progress", server.ErrUploadInProgress, codes.Aborted},
+		{"checksum_mismatch", server.ErrChecksumMismatch, codes.DataLoss},
+		{"service_error", fmt.Errorf("db error"), codes.Internal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &mock.UploadServiceMock{
+				CompleteFunc: func(ctx context.Context, id string) error {
+					return tt.err
+				},
+			}
+			srv := createUploadServiceServer(t, service)
+
+			var in gophkeeperv1.UploadRequest
+			in.SetUploadId("upload-1")
+			_, err := srv.CompleteUpload(t.Context(), &in)
+			if tt.code == codes.OK {
+				require.NoError(t, err)
+				return
+			}
+			requireGrpcError(t, err, tt.code)
+		})
+	}
+}
+
 func TestBinaryDownload(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		fileContent := "this is the file content"
@@ -162,6 +297,72 @@ func TestBinaryDownload(t *testing.T) {
 		require.Equal(t, fileContent, string(downloadedContent))
 	})
 
+	t.Run("range", func(t *testing.T) {
+		fileContent := bytes.Repeat([]byte("0123456789"), 10_000)
+		hash := sha256.Sum256(fileContent)
+		service := &mock.BinaryServiceMock{
+			GetFunc: func(ctx context.Context, id int64) (*server.ReadableBinaryData, error) {
+				return &server.ReadableBinaryData{
+					BinaryData: server.BinaryData{
+						Name:        "testfile",
+						Filename:    "test.txt",
+						Compression: server.CompressionZstd,
+					},
+					DataReader:  io.NopCloser(bytes.NewReader(fileContent)),
+					ContentSize: int64(len(fileContent)),
+					SHA256:      hash[:],
+				}, nil
+			},
+		}
+		srv := createBinaryServiceServer(t, service)
+
+		var req gophkeeperv1.DownloadBinaryRequest
+		req.SetId(1)
+		req.SetOffset(5)
+		req.SetLength(70_000)
+
+		stream := &mockDownloadStream{ctx: t.Context()}
+		err := srv.Download(&req, stream)
+		require.NoError(t, err)
+		require.Len(t, stream.responses, 2)
+
+		// Метаданные приходят только в первом сообщении.
+		require.Equal(t, hash[:], stream.responses[0].GetSha256())
+		require.EqualValues(t, len(fileContent), stream.responses[0].GetContentSize())
+		require.Equal(t, gophkeeperv1.Compression_COMPRESSION_ZSTD, stream.responses[0].GetCompression())
+		require.Empty(t, stream.responses[1].GetSha256())
+
+		var downloadedContent []byte
+		for _, resp := range stream.responses {
+			downloadedContent = append(downloadedContent, resp.GetChunk().GetData()...)
+		}
+		require.Equal(t, fileContent[5:70_005], downloadedContent)
+	})
+
+	t.Run("empty_range", func(t *testing.T) {
+		service := &mock.BinaryServiceMock{
+			GetFunc: func(ctx context.Context, id int64) (*server.ReadableBinaryData, error) {
+				return &server.ReadableBinaryData{
+					BinaryData:  server.BinaryData{Name: "testfile"},
+					DataReader:  io.NopCloser(strings.NewReader("content")),
+					ContentSize: 7,
+				}, nil
+			},
+		}
+		srv := createBinaryServiceServer(t, service)
+
+		var req gophkeeperv1.DownloadBinaryRequest
+		req.SetOffset(7)
+		stream := &mockDownloadStream{ctx: t.Context()}
+		require.NoError(t, srv.Download(&req, stream))
+		require.Len(t, stream.responses, 1)
+		require.Equal(t, "testfile", stream.responses[0].GetName())
+
+		req.SetOffset(8)
+		err := srv.Download(&req, &mockDownloadStream{ctx: t.Context()})
+		requireGrpcError(t, err, codes.OutOfRange)
+	})
+
 	t.Run("not_found", func(t *testing.T) {
 		service := &mock.BinaryServiceMock{
 			GetFunc: func(ctx context.Context, id int64) (*server.ReadableBinaryData, error) {
@@ -197,6 +398,7 @@ func TestBinaryUpdate(t *testing.T) {
 
 		var in gophkeeperv1.UpdateBinaryRequest
 		in.SetId(1)
+		in.SetVersion(1)
 		in.SetName("new binary name")
 
 		_, err := srv.Update(t.Context(), &in)
@@ -219,6 +421,7 @@ func TestBinaryRemove(t *testing.T) {
 
 		var in gophkeeperv1.RemoveDataRequest
 		in.SetId(1)
+		in.SetVersion(1)
 
 		_, err := srv.Remove(t.Context(), &in)
 		require.NoError(t, err)
@@ -233,7 +436,7 @@ func TestBinaryRemove(t *testing.T) {
 	})
 	t.Run("not_found", func(t *testing.T) {
 		service := &mock.BinaryServiceMock{
-			RemoveFunc: func(ctx context.Context, id int64) error {
+			RemoveFunc: func(ctx context.Context, id int64, version int64) error {
 				return server.ErrDataNotFound
 			},
 		}
@@ -241,6 +444,7 @@ func TestBinaryRemove(t *testing.T) {
 
 		var in gophkeeperv1.RemoveDataRequest
 		in.SetId(1)
+		in.SetVersion(1)
 
 		_, err := srv.Remove(t.Context(), &in)
 		requireGrpcError(t, err, codes.NotFound)
@@ -275,5 +479,9 @@ func TestBinaryGetAll(t *testing.T) {
 }
 
 func createBinaryServiceServer(t *testing.T, binaryService server.BinaryService) *BinaryServiceServer {
-	return NewBinaryServiceServer(binaryService, newTestValidator(t), log.New(io.Discard))
+	return NewBinaryServiceServer(binaryService, &mock.UploadServiceMock{}, newTestValidator(t), log.New(io.Discard))
+}
+
+func createUploadServiceServer(t *testing.T, uploadService server.UploadService) *BinaryServiceServer {
+	return NewBinaryServiceServer(&mock.BinaryServiceMock{}, uploadService, newTestValidator(t), log.New(io.Discard))
 }
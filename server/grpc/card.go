@@ -46,8 +46,7 @@ func (s *CardServiceServer) Save(ctx context.Context, in *gophkeeperv1.Card) (*e
 	}
 
 	if err := s.cardService.Create(ctx, data); err != nil {
-		s.logger.Error("failed to save data", "err", err)
-		return nil, status.Error(codes.Internal, "internal server error")
+		return nil, createError(err, s.logger)
 	}
 
 	return &empty.Empty{}, nil
@@ -62,15 +61,7 @@ func (s *CardServiceServer) GetAll(ctx context.Context, _ *empty.Empty) (*gophke
 
 	var result []*gophkeeperv1.Card
 	for _, card := range cards {
-		var out gophkeeperv1.Card
-		out.SetId(card.ID)
-		out.SetName(card.Name)
-		out.SetNumber(card.Number)
-		out.SetExpDate(card.ExpDate)
-		out.SetCvv(card.CVV)
-		out.SetCardholder(card.Cardholder)
-		out.SetNotes(card.Notes)
-		result = append(result, &out)
+		result = append(result, newCardMessage(card))
 	}
 
 	var out gophkeeperv1.GetAllCardsResponse
@@ -88,3 +79,16 @@ func (s *CardServiceServer) Update(ctx context.Context, in *gophkeeperv1.Card) (
 func (s *CardServiceServer) Remove(ctx context.Context, in *gophkeeperv1.RemoveDataRequest) (*empty.Empty, error) {
 	return removeData(ctx, in, s.cardService.Remove, s.logger)
 }
+
+func newCardMessage(card server.CardData) *gophkeeperv1.Card {
+	var out gophkeeperv1.Card
+	out.SetId(card.ID)
+	out.SetName(card.Name)
+	out.SetNumber(card.Number)
+	out.SetExpDate(card.ExpDate)
+	out.SetCvv(card.CVV)
+	out.SetCardholder(card.Cardholder)
+	out.SetNotes(card.Notes)
+	out.SetVersion(card.Version)
+	return &out
+}
@@ -62,6 +62,7 @@ func TestCardUpdate(t *testing.T) {
 
 		var in gophkeeperv1.Card
 		in.SetId(1)
+		in.SetVersion(1)
 		in.SetName("new card name")
 
 		_, err := srv.Update(t.Context(), &in)
@@ -78,13 +79,14 @@ func TestCardUpdate(t *testing.T) {
 	})
 	t.Run("not_found", func(t *testing.T) {
 		srv := createCardServiceServer(t, &mock.CardServiceMock{
-			UpdateFunc: func(ctx context.Context, id int64, data server.CardDataUpdate) error {
+			UpdateFunc: func(ctx context.Context, id int64, version int64, data server.CardDataUpdate) error {
 				return server.ErrDataNotFound
 			},
 		})
 
 		var in gophkeeperv1.Card
 		in.SetId(1)
+		in.SetVersion(1)
 		in.SetName("new card name")
 
 		_, err := srv.Update(t.Context(), &in)
@@ -98,6 +100,7 @@ func TestCardRemove(t *testing.T) {
 
 		var in gophkeeperv1.RemoveDataRequest
 		in.SetId(1)
+		in.SetVersion(1)
 
 		_, err := srv.Remove(t.Context(), &in)
 		require.NoError(t, err)
@@ -112,7 +115,7 @@ func TestCardRemove(t *testing.T) {
 	})
 	t.Run("not_found", func(t *testing.T) {
 		service := &mock.CardServiceMock{
-			RemoveFunc: func(ctx context.Context, id int64) error {
+			RemoveFunc: func(ctx context.Context, id int64, version int64) error {
 				return server.ErrDataNotFound
 			},
 		}
@@ -120,6 +123,7 @@ func TestCardRemove(t *testing.T) {
 
 		var in gophkeeperv1.RemoveDataRequest
 		in.SetId(1)
+		in.SetVersion(1)
 
 		_, err := srv.Remove(t.Context(), &in)
 		requireGrpcError(t, err, codes.NotFound)
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"github.com/charmbracelet/log"
 	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/mkolibaba/gophkeeper/proto/gen/go/gophkeeperv1"
 	"github.com/mkolibaba/gophkeeper/server"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -13,27 +14,35 @@ import (
 type updateIn interface {
 	HasId() bool
 	GetId() int64
+	GetVersion() int64
 }
 
 func updateData[I updateIn, U any](
 	ctx context.Context,
 	in I,
 	mapper func(I) U,
-	updater func(context.Context, int64, U) error,
+	updater func(context.Context, int64, int64, U) error,
 	logger *log.Logger,
 ) (*empty.Empty, error) {
 	if !in.HasId() {
 		return nil, status.Error(codes.InvalidArgument, "id is required")
 	}
+	if in.GetVersion() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "version is required")
+	}
 
 	data := mapper(in)
 
 	logger.Debug("updating data", "id", in.GetId(), "data", data)
 
-	if err := updater(ctx, in.GetId(), data); err != nil {
+	if err := updater(ctx, in.GetId(), in.GetVersion(), data); err != nil {
 		if errors.Is(err, server.ErrDataNotFound) {
 			return nil, status.Error(codes.NotFound, "data not found")
 		}
+		var conflict *server.VersionConflictError
+		if errors.As(err, &conflict) {
+			return nil, versionConflictError(conflict.Version)
+		}
 		logger.Error("failed to update data", "err", err)
 		return nil, status.Error(codes.Internal, err.Error())
 	}
@@ -41,28 +50,58 @@ func updateData[I updateIn, U any](
 	return &empty.Empty{}, nil
 }
 
+// createError преобразует ошибку создания данных в ошибку gRPC.
+func createError(err error, logger *log.Logger) error {
+	if errors.Is(err, server.ErrQuotaExceeded) || errors.Is(err, server.ErrFileTooLarge) {
+		return status.Error(codes.ResourceExhausted, err.Error())
+	}
+	logger.Error("failed to save data", "err", err)
+	return status.Error(codes.Internal, "internal server error")
+}
+
 type removable interface {
 	HasId() bool
 	GetId() int64
+	GetVersion() int64
 }
 
 func removeData(
 	ctx context.Context,
 	in removable,
-	remove func(context.Context, int64) error,
+	remove func(context.Context, int64, int64) error,
 	logger *log.Logger,
 ) (*empty.Empty, error) {
 	if !in.HasId() {
 		return nil, status.Error(codes.InvalidArgument, "id is required")
 	}
+	if in.GetVersion() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "version is required")
+	}
 
-	if err := remove(ctx, in.GetId()); err != nil {
+	if err := remove(ctx, in.GetId(), in.GetVersion()); err != nil {
 		if errors.Is(err, server.ErrDataNotFound) {
 			return nil, status.Error(codes.NotFound, "data not found")
 		}
+		var conflict *server.VersionConflictError
+		if errors.As(err, &conflict) {
+			return nil, versionConflictError(conflict.Version)
+		}
 		logger.Error("failed to remove data", "err", err)
 		return nil, status.Error(codes.Internal, "internal server error")
 	}
 
 	return &empty.Empty{}, nil
 }
+
+// versionConflictError возвращает ошибку Aborted с текущей версией данных
+// в деталях, чтобы клиент мог разрешить конфликт.
+func versionConflictError(version int64) error {
+	var details gophkeeperv1.VersionConflict
+	details.SetVersion(version)
+
+	st, err := status.New(codes.Aborted, "data was modified by another client").WithDetails(&details)
+	if err != nil {
+		return status.Error(codes.Internal, "internal server error")
+	}
+	return st.Err()
+}
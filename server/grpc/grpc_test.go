@@ -2,7 +2,6 @@ package grpc
 
 import (
 	"github.com/go-playground/validator/v10"
-	"github.com/mkolibaba/gophkeeper/server"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -21,8 +20,6 @@ func requireGrpcError(t *testing.T, err error, code codes.Code) {
 func newTestValidator(t *testing.T) *validator.Validate {
 	t.Helper()
 	v := validator.New()
-	err := server.RegisterDataValidationRules(v)
-	require.NoError(t, err)
 	RegisterValidationRules(v)
 	return v
 }
@@ -0,0 +1,116 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"github.com/charmbracelet/log"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/mkolibaba/gophkeeper/proto/gen/go/gophkeeperv1"
+	"github.com/mkolibaba/gophkeeper/server"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type HistoryServiceServer struct {
+	gophkeeperv1.UnimplementedHistoryServiceServer
+	historyService server.HistoryService
+	logger         *log.Logger
+}
+
+func NewHistoryServiceServer(historyService server.HistoryService, logger *log.Logger) *HistoryServiceServer {
+	return &HistoryServiceServer{
+		historyService: historyService,
+		logger:         logger,
+	}
+}
+
+func (s *HistoryServiceServer) ListHistory(ctx context.Context, in *gophkeeperv1.ListHistoryRequest) (*gophkeeperv1.ListHistoryResponse, error) {
+	kind, err := historyItem(in)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := s.historyService.List(ctx, kind, in.GetId())
+	if err != nil {
+		s.logger.Error("failed to retrieve history", "err", err)
+		return nil, status.Error(codes.Internal, "internal server error")
+	}
+
+	var versions []*gophkeeperv1.HistoryVersion
+	for _, entry := range entries {
+		versions = append(versions, newHistoryVersionMessage(entry))
+	}
+
+	var out gophkeeperv1.ListHistoryResponse
+	out.SetVersions(versions)
+
+	return &out, nil
+}
+
+func (s *HistoryServiceServer) RestoreVersion(ctx context.Context, in *gophkeeperv1.RestoreVersionRequest) (*empty.Empty, error) {
+	kind, err := historyItem(in)
+	if err != nil {
+		return nil, err
+	}
+	if in.GetVersion() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "version is required")
+	}
+
+	err = s.historyService.Restore(ctx, kind, in.GetId(), in.GetVersion())
+	if errors.Is(err, server.ErrVersionNotFound) {
+		return nil, status.Error(codes.NotFound, "version not found")
+	}
+	if errors.Is(err, server.ErrContentRemoved) {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	var conflict *server.VersionConflictError
+	if errors.As(err, &conflict) {
+		return nil, versionConflictError(conflict.Version)
+	}
+	if err != nil {
+		return nil, createError(err, s.logger)
+	}
+
+	return &empty.Empty{}, nil
+}
+
+type historyIn interface {
+	GetKind() gophkeeperv1.DataKind
+	HasId() bool
+	GetId() int64
+}
+
+// historyItem проверяет запись запроса и возвращает ее тип.
+func historyItem(in historyIn) (server.DataKind, error) {
+	if !in.HasId() {
+		return "", status.Error(codes.InvalidArgument, "id is required")
+	}
+	for kind, message := range dataKinds {
+		if message == in.GetKind() {
+			return kind, nil
+		}
+	}
+	return "", status.Error(codes.InvalidArgument, "unknown data kind")
+}
+
+func newHistoryVersionMessage(entry server.HistoryEntry) *gophkeeperv1.HistoryVersion {
+	var out gophkeeperv1.HistoryVersion
+	out.SetVersion(entry.Version)
+	out.SetRemoved(entry.Removed)
+	out.SetCreatedAt(timestamppb.New(entry.CreatedAt))
+
+	switch {
+	case entry.Login != nil:
+		out.SetLogin(newLoginMessage(*entry.Login))
+	case entry.Note != nil:
+		out.SetNote(newNoteMessage(*entry.Note))
+	case entry.Binary != nil:
+		out.SetBinary(newBinaryMessage(*entry.Binary))
+	case entry.Card != nil:
+		out.SetCard(newCardMessage(*entry.Card))
+	case entry.OTP != nil:
+		out.SetOtp(newOTPMessage(*entry.OTP))
+	}
+	return &out
+}
@@ -0,0 +1,151 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"github.com/charmbracelet/log"
+	"github.com/mkolibaba/gophkeeper/proto/gen/go/gophkeeperv1"
+	"github.com/mkolibaba/gophkeeper/server"
+	"github.com/mkolibaba/gophkeeper/server/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestListHistory(t *testing.T) {
+	newRequest := func(kind gophkeeperv1.DataKind, id int64) *gophkeeperv1.ListHistoryRequest {
+		var in gophkeeperv1.ListHistoryRequest
+		in.SetKind(kind)
+		in.SetId(id)
+		return &in
+	}
+
+	t.Run("success", func(t *testing.T) {
+		createdAt := time.Unix(1700000000, 0)
+		historyService := &mock.HistoryServiceMock{
+			ListFunc: func(ctx context.Context, kind server.DataKind, id int64) ([]server.HistoryEntry, error) {
+				require.Equal(t, server.DataKindLogin, kind)
+				require.Equal(t, int64(3), id)
+				return []server.HistoryEntry{
+					{
+						Kind:      server.DataKindLogin,
+						ID:        3,
+						Version:   5,
+						Removed:   true,
+						CreatedAt: createdAt,
+						Login:     &server.LoginData{ID: 3, Name: "login", Password: "123", Version: 5},
+					},
+					{
+						Kind:    server.DataKindLogin,
+						ID:      3,
+						Version: 2,
+						Login:   &server.LoginData{ID: 3, Name: "login", Password: "old", Version: 2},
+					},
+				}, nil
+			},
+		}
+		srv := createHistoryServiceServer(t, historyService)
+
+		out, err := srv.ListHistory(t.Context(), newRequest(gophkeeperv1.DataKind_DATA_KIND_LOGIN, 3))
+		require.NoError(t, err)
+		require.Len(t, out.GetVersions(), 2)
+
+		latest := out.GetVersions()[0]
+		require.Equal(t, int64(5), latest.GetVersion())
+		require.True(t, latest.GetRemoved())
+		require.Equal(t, createdAt, latest.GetCreatedAt().AsTime().Local())
+		require.Equal(t, "123", latest.GetLogin().GetPassword())
+		require.Equal(t, "old", out.GetVersions()[1].GetLogin().GetPassword())
+	})
+	t.Run("unknown_kind", func(t *testing.T) {
+		srv := createHistoryServiceServer(t, &mock.HistoryServiceMock{})
+
+		_, err := srv.ListHistory(t.Context(), newRequest(gophkeeperv1.DataKind_DATA_KIND_UNSPECIFIED, 3))
+		requireGrpcError(t, err, codes.InvalidArgument)
+	})
+	t.Run("no_id", func(t *testing.T) {
+		srv := createHistoryServiceServer(t, &mock.HistoryServiceMock{})
+
+		var in gophkeeperv1.ListHistoryRequest
+		in.SetKind(gophkeeperv1.DataKind_DATA_KIND_NOTE)
+
+		_, err := srv.ListHistory(t.Context(), &in)
+		requireGrpcError(t, err, codes.InvalidArgument)
+	})
+	t.Run("db_error", func(t *testing.T) {
+		historyService := &mock.HistoryServiceMock{
+			ListFunc: func(ctx context.Context, kind server.DataKind, id int64) ([]server.HistoryEntry, error) {
+				return nil, errors.New("some error")
+			},
+		}
+		srv := createHistoryServiceServer(t, historyService)
+
+		_, err := srv.ListHistory(t.Context(), newRequest(gophkeeperv1.DataKind_DATA_KIND_NOTE, 3))
+		requireGrpcError(t, err, codes.Internal)
+	})
+}
+
+func TestRestoreVersion(t *testing.T) {
+	newRequest := func(version int64) *gophkeeperv1.RestoreVersionRequest {
+		var in gophkeeperv1.RestoreVersionRequest
+		in.SetKind(gophkeeperv1.DataKind_DATA_KIND_CARD)
+		in.SetId(3)
+		in.SetVersion(version)
+		return &in
+	}
+
+	t.Run("success", func(t *testing.T) {
+		historyService := &mock.HistoryServiceMock{
+			RestoreFunc: func(ctx context.Context, kind server.DataKind, id int64, version int64) error {
+				return nil
+			},
+		}
+		srv := createHistoryServiceServer(t, historyService)
+
+		_, err := srv.RestoreVersion(t.Context(), newRequest(2))
+		require.NoError(t, err)
+		require.Len(t, historyService.RestoreCalls(), 1)
+		call := historyService.RestoreCalls()[0]
+		require.Equal(t, server.DataKindCard, call.Kind)
+		require.Equal(t, int64(3), call.ID)
+		require.Equal(t, int64(2), call.Version)
+	})
+	t.Run("no_version", func(t *testing.T) {
+		srv := createHistoryServiceServer(t, &mock.HistoryServiceMock{})
+
+		_, err := srv.RestoreVersion(t.Context(), newRequest(0))
+		requireGrpcError(t, err, codes.InvalidArgument)
+	})
+
+	tests := []struct {
+		name string
+		err  error
+		code codes.Code
+	}{
+		{name: "version_not_found", err: server.ErrVersionNotFound, code: codes.NotFound},
+		{name: "content_removed", err: server.ErrContentRemoved, code: codes.FailedPrecondition},
+		{name: "version_conflict", err: &server.VersionConflictError{Version: 4}, code: codes.Aborted},
+		{name: "quota_exceeded", err: server.ErrQuotaExceeded, code: codes.ResourceExhausted},
+		{name: "db_error", err: errors.New("some error"), code: codes.Internal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			historyService := &mock.HistoryServiceMock{
+				RestoreFunc: func(ctx context.Context, kind server.DataKind, id int64, version int64) error {
+					return tt.err
+				},
+			}
+			srv := createHistoryServiceServer(t, historyService)
+
+			_, err := srv.RestoreVersion(t.Context(), newRequest(2))
+			requireGrpcError(t, err, tt.code)
+		})
+	}
+}
+
+func createHistoryServiceServer(t *testing.T, historyService server.HistoryService) *HistoryServiceServer {
+	t.Helper()
+	return NewHistoryServiceServer(historyService, log.New(io.Discard))
+}
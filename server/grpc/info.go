@@ -0,0 +1,59 @@
+package grpc
+
+import (
+	"context"
+	"github.com/charmbracelet/log"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/mkolibaba/gophkeeper/proto/gen/go/gophkeeperv1"
+	"github.com/mkolibaba/gophkeeper/server"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type InfoServiceServer struct {
+	gophkeeperv1.UnimplementedInfoServiceServer
+	build        server.BuildInfo
+	usageService server.UsageService
+	logger       *log.Logger
+}
+
+func NewInfoServiceServer(
+	build server.BuildInfo,
+	usageService server.UsageService,
+	logger *log.Logger,
+) *InfoServiceServer {
+	return &InfoServiceServer{
+		build:        build,
+		usageService: usageService,
+		logger:       logger,
+	}
+}
+
+func (s *InfoServiceServer) ServerInfo(ctx context.Context, _ *empty.Empty) (*gophkeeperv1.ServerInfoResponse, error) {
+	var out gophkeeperv1.ServerInfoResponse
+	out.SetVersion(s.build.Version)
+	out.SetCommit(s.build.Commit)
+	out.SetBuildDate(s.build.Date)
+	out.SetProtocolVersion(server.ProtocolVersion)
+	out.SetCompressions([]gophkeeperv1.Compression{
+		gophkeeperv1.Compression_COMPRESSION_NONE,
+		gophkeeperv1.Compression_COMPRESSION_ZSTD,
+	})
+	return &out, nil
+}
+
+func (s *InfoServiceServer) GetUsage(ctx context.Context, _ *empty.Empty) (*gophkeeperv1.UsageResponse, error) {
+	usage, err := s.usageService.Get(ctx)
+	if err != nil {
+		s.logger.Error("failed to retrieve usage", "err", err)
+		return nil, status.Error(codes.Internal, "internal server error")
+	}
+
+	var out gophkeeperv1.UsageResponse
+	out.SetBytes(usage.Bytes)
+	out.SetItems(usage.Items)
+	out.SetQuotaBytes(usage.Quota.Bytes)
+	out.SetQuotaItems(usage.Quota.Items)
+	out.SetMaxFileSize(usage.Quota.MaxFileSize)
+	return &out, nil
+}
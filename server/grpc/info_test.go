@@ -0,0 +1,49 @@
+package grpc
+
+import (
+	"context"
+	"github.com/charmbracelet/log"
+	"github.com/mkolibaba/gophkeeper/proto/gen/go/gophkeeperv1"
+	"github.com/mkolibaba/gophkeeper/server"
+	"github.com/mkolibaba/gophkeeper/server/mock"
+	"github.com/stretchr/testify/require"
+	"io"
+	"testing"
+)
+
+func TestServerInfo(t *testing.T) {
+	srv := NewInfoServiceServer(server.BuildInfo{
+		Version: "v1.2.0",
+		Commit:  "abc123",
+		Date:    "2025-10-01T00:00:00Z",
+	}, &mock.UsageServiceMock{}, log.New(io.Discard))
+
+	out, err := srv.ServerInfo(t.Context(), nil)
+	require.NoError(t, err)
+	require.Equal(t, "v1.2.0", out.GetVersion())
+	require.Equal(t, "abc123", out.GetCommit())
+	require.Equal(t, "2025-10-01T00:00:00Z", out.GetBuildDate())
+	require.Equal(t, int64(server.ProtocolVersion), out.GetProtocolVersion())
+	require.Contains(t, out.GetCompressions(), gophkeeperv1.Compression_COMPRESSION_ZSTD)
+}
+
+func TestGetUsage(t *testing.T) {
+	usageService := &mock.UsageServiceMock{
+		GetFunc: func(ctx context.Context) (*server.Usage, error) {
+			return &server.Usage{
+				Bytes: 1024,
+				Items: 3,
+				Quota: server.Quota{Bytes: 4096, Items: 10, MaxFileSize: 2048},
+			}, nil
+		},
+	}
+	srv := NewInfoServiceServer(server.BuildInfo{}, usageService, log.New(io.Discard))
+
+	out, err := srv.GetUsage(t.Context(), nil)
+	require.NoError(t, err)
+	require.EqualValues(t, 1024, out.GetBytes())
+	require.EqualValues(t, 3, out.GetItems())
+	require.EqualValues(t, 4096, out.GetQuotaBytes())
+	require.EqualValues(t, 10, out.GetQuotaItems())
+	require.EqualValues(t, 2048, out.GetMaxFileSize())
+}
@@ -2,8 +2,8 @@ package interceptors
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"github.com/golang-jwt/jwt/v5"
 	"github.com/mkolibaba/gophkeeper/proto/gen/go/gophkeeperv1"
 	"github.com/mkolibaba/gophkeeper/server"
 	"google.golang.org/grpc"
@@ -19,16 +19,20 @@ import (
 var skip = []string{
 	gophkeeperv1.AuthorizationService_Authorize_FullMethodName,
 	gophkeeperv1.AuthorizationService_Register_FullMethodName,
+	gophkeeperv1.AuthorizationService_Enroll_FullMethodName,
+	gophkeeperv1.AuthorizationService_Refresh_FullMethodName,
+	gophkeeperv1.AuthorizationService_VerifyMFA_FullMethodName,
+	gophkeeperv1.InfoService_ServerInfo_FullMethodName,
 	grpc_reflection_v1.ServerReflection_ServerReflectionInfo_FullMethodName,
 	grpc_reflection_v1alpha.ServerReflection_ServerReflectionInfo_FullMethodName,
 }
 
 type AuthInterceptor struct {
-	config *server.Config
+	authorizationService server.AuthorizationService
 }
 
-func NewAuthInterceptor(config *server.Config) *AuthInterceptor {
-	return &AuthInterceptor{config: config}
+func NewAuthInterceptor(authorizationService server.AuthorizationService) *AuthInterceptor {
+	return &AuthInterceptor{authorizationService: authorizationService}
 }
 
 func (i *AuthInterceptor) Unary(
@@ -41,12 +45,11 @@ func (i *AuthInterceptor) Unary(
 		return handler(ctx, req)
 	}
 
-	sub, err := i.getSub(ctx)
+	userCtx, err := i.authorize(ctx)
 	if err != nil {
-		return nil, status.Error(codes.Unauthenticated, err.Error())
+		return nil, err
 	}
 
-	userCtx := server.NewContextWithUser(ctx, sub)
 	return handler(userCtx, req)
 }
 
@@ -64,42 +67,34 @@ func (i *AuthInterceptor) Stream(srv any, ss grpc.ServerStream, info *grpc.Strea
 		return handler(srv, ss)
 	}
 
-	sub, err := i.getSub(ss.Context())
+	userCtx, err := i.authorize(ss.Context())
 	if err != nil {
-		return status.Error(codes.Unauthenticated, err.Error())
+		return err
 	}
 
-	userCtx := server.NewContextWithUser(ss.Context(), sub)
 	wss := &wrappedServerStream{ServerStream: ss, ctx: userCtx}
 
 	return handler(srv, wss)
 }
 
-func (i *AuthInterceptor) getSub(ctx context.Context) (string, error) {
+// authorize проверяет токен запроса и возвращает контекст
+// с пользователем и его сессией.
+func (i *AuthInterceptor) authorize(ctx context.Context) (context.Context, error) {
 	authorization := metadata.ValueFromIncomingContext(ctx, "authorization")
 	if len(authorization) == 0 {
-		return "", fmt.Errorf("credentials are not provided")
+		return nil, status.Error(codes.Unauthenticated, "credentials are not provided")
 	}
 
-	tokenString := strings.TrimPrefix(authorization[0], "Bearer ")
+	token := strings.TrimPrefix(authorization[0], "Bearer ")
 
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("jwt: unexpected signing method")
-		}
-		return []byte(i.config.JWT.Secret), nil
-	})
-	if err != nil {
-		return "", fmt.Errorf("bearer token: %w", err)
-	}
-	if !token.Valid {
-		return "", fmt.Errorf("bearer token: invalid")
+	login, sessionID, err := i.authorizationService.Verify(ctx, token)
+	if errors.Is(err, server.ErrInvalidToken) {
+		return nil, status.Error(codes.Unauthenticated, fmt.Sprintf("bearer token: %s", err))
 	}
-
-	sub, err := token.Claims.GetSubject()
 	if err != nil {
-		return "", fmt.Errorf("jwt claims: %w", err)
+		return nil, status.Error(codes.Internal, err.Error())
 	}
 
-	return sub, nil
+	ctx = server.NewContextWithUser(ctx, login)
+	return server.NewContextWithSession(ctx, sessionID), nil
 }
@@ -0,0 +1,284 @@
+package interceptors
+
+import (
+	"context"
+	"fmt"
+	"github.com/charmbracelet/log"
+	"github.com/mkolibaba/gophkeeper/proto/gen/go/gophkeeperv1"
+	"github.com/mkolibaba/gophkeeper/server"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"math"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// maxBuckets - число корзин, после которого из limiter удаляются
+// заполненные корзины. Заполненная корзина ничем не отличается от новой.
+const maxBuckets = 10_000
+
+// limited - запросы аутентификации, частота которых ограничивается.
+// Для запросов со значением true учитываются неудачные попытки входа.
+// Смена пароля и удаление учетной записи проверяют пароль, поэтому
+// ограничиваются так же, как вход: иначе украденная сессия позволила бы
+// подбирать мастер-пароль.
+var limited = map[string]bool{
+	gophkeeperv1.AuthorizationService_Authorize_FullMethodName:      true,
+	gophkeeperv1.AuthorizationService_Enroll_FullMethodName:         true,
+	gophkeeperv1.AuthorizationService_VerifyMFA_FullMethodName:      true,
+	gophkeeperv1.AuthorizationService_ChangePassword_FullMethodName: true,
+	gophkeeperv1.AuthorizationService_DeleteAccount_FullMethodName:  true,
+	gophkeeperv1.AuthorizationService_Register_FullMethodName:       false,
+	gophkeeperv1.AuthorizationService_Refresh_FullMethodName:        false,
+}
+
+// RateLimitInterceptor ограничивает частоту запросов аутентификации
+// с одного адреса и под одним логином, а после нескольких неудачных
+// попыток входа подряд блокирует логин. Отклоненные запросы получают
+// codes.ResourceExhausted и заголовок retry-after в секундах. Выполняется
+// после проверки токена, чтобы запросы с сессией учитывались под ее
+// пользователем.
+type RateLimitInterceptor struct {
+	ip                   *limiter
+	login                *limiter
+	loginAttemptService  server.LoginAttemptService
+	authorizationService server.AuthorizationService
+	maxFailures          int
+	lockout              time.Duration
+	maxLockout           time.Duration
+	failureTTL           time.Duration
+	now                  func() time.Time
+	logger               *log.Logger
+}
+
+func NewRateLimitInterceptor(
+	config *server.Config,
+	loginAttemptService server.LoginAttemptService,
+	authorizationService server.AuthorizationService,
+	logger *log.Logger,
+) *RateLimitInterceptor {
+	return &RateLimitInterceptor{
+		ip:                   newLimiter(config.RateLimit.IP),
+		login:                newLimiter(config.RateLimit.Login),
+		loginAttemptService:  loginAttemptService,
+		authorizationService: authorizationService,
+		maxFailures:          config.RateLimit.MaxFailures,
+		lockout:              config.RateLimit.Lockout,
+		maxLockout:           config.RateLimit.MaxLockout,
+		failureTTL:           config.RateLimit.FailureTTL,
+		now:                  time.Now,
+		logger:               logger,
+	}
+}
+
+func (i *RateLimitInterceptor) Unary(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	countFailures, ok := limited[info.FullMethod]
+	if !ok {
+		return handler(ctx, req)
+	}
+
+	now := i.now()
+	if wait, ok := i.ip.allow(peerHost(ctx), now); !ok {
+		return nil, i.reject(ctx, wait)
+	}
+
+	login := i.requestLogin(ctx, req)
+	if login == "" {
+		return handler(ctx, req)
+	}
+
+	if wait, ok := i.login.allow(login, now); !ok {
+		return nil, i.reject(ctx, wait)
+	}
+
+	if !countFailures {
+		return handler(ctx, req)
+	}
+
+	attempts, err := i.loginAttemptService.Get(ctx, login)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if attempts.LockedUntil.After(now) {
+		return nil, i.reject(ctx, attempts.LockedUntil.Sub(now))
+	}
+
+	resp, err := handler(ctx, req)
+
+	// FailedPrecondition тоже считается неудачей: иначе запросы, которые
+	// сервер отклоняет по состоянию учетной записи, не вели бы к блокировке.
+	switch code := status.Code(err); {
+	case code == codes.InvalidArgument || code == codes.Unauthenticated || code == codes.FailedPrecondition:
+		if failErr := i.fail(ctx, login); failErr != nil {
+			return nil, status.Error(codes.Internal, failErr.Error())
+		}
+	case err == nil && succeeded(resp) && attempts.Failures > 0:
+		if resetErr := i.loginAttemptService.Reset(ctx, login); resetErr != nil {
+			i.logger.Error("failed to reset login attempts", "login", login, "err", resetErr)
+		}
+	}
+
+	return resp, err
+}
+
+// requestLogin возвращает логин, под которым выполняется запрос. Для
+// VerifyMFA логин берется из токена входа, чтобы перебор одноразовых
+// кодов ограничивался так же, как перебор паролей. Смена пароля и удаление
+// учетной записи выполняются под пользователем сессии.
+func (i *RateLimitInterceptor) requestLogin(ctx context.Context, req any) string {
+	switch in := req.(type) {
+	case *gophkeeperv1.UserCredentials:
+		return in.GetLogin()
+	case *gophkeeperv1.EnrollRequest:
+		return in.GetLogin()
+	case *gophkeeperv1.ChangePasswordRequest, *gophkeeperv1.DeleteAccountRequest:
+		return server.UserFromContext(ctx)
+	case *gophkeeperv1.VerifyMFARequest:
+		login, err := i.authorizationService.VerifyChallenge(ctx, in.GetMfaChallenge())
+		if err != nil {
+			return ""
+		}
+		return login
+	default:
+		return ""
+	}
+}
+
+// fail учитывает неудачную попытку входа и, если их накопилось
+// MaxFailures подряд, блокирует логин. Каждая следующая неудачная
+// попытка вдвое увеличивает блокировку.
+func (i *RateLimitInterceptor) fail(ctx context.Context, login string) error {
+	now := i.now()
+
+	// Попытки хранятся и для несуществующих логинов, поэтому устаревшие
+	// удаляются при каждой новой неудаче.
+	if i.failureTTL > 0 {
+		if err := i.loginAttemptService.RemoveExpired(ctx, now.Add(-i.failureTTL)); err != nil {
+			return err
+		}
+	}
+
+	failures, err := i.loginAttemptService.Fail(ctx, login, now)
+	if err != nil {
+		return err
+	}
+	if i.maxFailures <= 0 || failures < i.maxFailures {
+		return nil
+	}
+
+	lockout := i.maxLockout
+	if shift := failures - i.maxFailures; shift < 32 {
+		if d := i.lockout << shift; d > 0 && (i.maxLockout <= 0 || d < i.maxLockout) {
+			lockout = d
+		}
+	}
+
+	i.logger.Warn("login locked after failed attempts", "login", login, "failures", failures, "lockout", lockout)
+	return i.loginAttemptService.Lock(ctx, login, now.Add(lockout))
+}
+
+func (i *RateLimitInterceptor) reject(ctx context.Context, wait time.Duration) error {
+	seconds := strconv.Itoa(int(math.Ceil(wait.Seconds())))
+	// Ошибку установки заголовка игнорируем: клиент все равно получит
+	// код ResourceExhausted.
+	_ = grpc.SetHeader(ctx, metadata.Pairs("retry-after", seconds))
+	return status.Error(codes.ResourceExhausted, fmt.Sprintf("too many requests, retry after %s seconds", seconds))
+}
+
+// succeeded сообщает, завершена ли успешным ответом resp попытка. Authorize
+// с включенным вторым фактором возвращает только токен входа: попытка
+// еще не завершена.
+func succeeded(resp any) bool {
+	out, ok := resp.(*gophkeeperv1.TokenResponse)
+	return !ok || out.GetToken() != ""
+}
+
+// peerHost возвращает адрес клиента без порта.
+func peerHost(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
+
+// limiter - набор корзин токенов по ключу.
+type limiter struct {
+	mu      sync.Mutex
+	rate    float64
+	burst   float64
+	buckets map[string]*bucket
+}
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// newLimiter возвращает limiter или nil, если ограничение отключено.
+func newLimiter(config server.RateLimitBucket) *limiter {
+	if config.Rate <= 0 {
+		return nil
+	}
+	return &limiter{
+		rate:    config.Rate,
+		burst:   math.Max(float64(config.Burst), 1),
+		buckets: make(map[string]*bucket),
+	}
+}
+
+// allow забирает токен из корзины key. Если корзина пуста, возвращает
+// время, через которое в ней появится токен.
+func (l *limiter) allow(key string, now time.Time) (time.Duration, bool) {
+	if l == nil {
+		return 0, true
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b, ok := l.buckets[key]
+	if !ok {
+		if len(l.buckets) >= maxBuckets {
+			l.prune(now)
+		}
+		b = &bucket{tokens: l.burst, updated: now}
+		l.buckets[key] = b
+	}
+
+	b.tokens = l.refill(b, now)
+	b.updated = now
+
+	if b.tokens < 1 {
+		return time.Duration((1 - b.tokens) / l.rate * float64(time.Second)), false
+	}
+	b.tokens--
+	return 0, true
+}
+
+func (l *limiter) refill(b *bucket, now time.Time) float64 {
+	return math.Min(l.burst, b.tokens+now.Sub(b.updated).Seconds()*l.rate)
+}
+
+// prune удаляет заполненные корзины.
+func (l *limiter) prune(now time.Time) {
+	for key, b := range l.buckets {
+		if l.refill(b, now) >= l.burst {
+			delete(l.buckets, key)
+		}
+	}
+}
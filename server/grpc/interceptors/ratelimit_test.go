@@ -0,0 +1,319 @@
+package interceptors
+
+import (
+	"context"
+	"github.com/charmbracelet/log"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/mkolibaba/gophkeeper/proto/gen/go/gophkeeperv1"
+	"github.com/mkolibaba/gophkeeper/server"
+	"github.com/mkolibaba/gophkeeper/server/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"io"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestRateLimitIP(t *testing.T) {
+	var config server.Config
+	config.RateLimit.IP = server.RateLimitBucket{Rate: 1, Burst: 2}
+
+	i, _ := newTestRateLimitInterceptor(config)
+	now := time.Unix(1_700_000_000, 0)
+	i.now = func() time.Time { return now }
+
+	info := &grpc.UnaryServerInfo{FullMethod: gophkeeperv1.AuthorizationService_Register_FullMethodName}
+	call := func(addr string) (metadata.MD, error) {
+		stream := &headerStream{}
+		ctx := newPeerContext(t.Context(), stream, addr)
+		_, err := i.Unary(ctx, &gophkeeperv1.UserCredentials{}, info, okHandler)
+		return stream.header, err
+	}
+
+	for range 2 {
+		_, err := call("10.0.0.1:1000")
+		require.NoError(t, err)
+	}
+
+	// Порт не влияет на корзину адреса.
+	header, err := call("10.0.0.1:2000")
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+	require.Equal(t, []string{"1"}, header.Get("retry-after"))
+
+	_, err = call("10.0.0.2:1000")
+	require.NoError(t, err)
+
+	now = now.Add(time.Second)
+	_, err = call("10.0.0.1:1000")
+	require.NoError(t, err)
+
+	// Остальные запросы не ограничиваются.
+	info = &grpc.UnaryServerInfo{FullMethod: gophkeeperv1.LoginService_GetAll_FullMethodName}
+	for range 5 {
+		_, err := i.Unary(newPeerContext(t.Context(), &headerStream{}, "10.0.0.1:1000"), nil, info, okHandler)
+		require.NoError(t, err)
+	}
+}
+
+func TestRateLimitLockout(t *testing.T) {
+	var config server.Config
+	config.RateLimit.MaxFailures = 2
+	config.RateLimit.Lockout = time.Minute
+	config.RateLimit.MaxLockout = 3 * time.Minute
+
+	i, attempts := newTestRateLimitInterceptor(config)
+	now := time.Unix(1_700_000_000, 0)
+	i.now = func() time.Time { return now }
+
+	password := "wrong"
+	handled := 0
+	handler := func(ctx context.Context, req any) (any, error) {
+		handled++
+		if password != "123" {
+			return nil, status.Error(codes.InvalidArgument, "invalid login or password")
+		}
+		var out gophkeeperv1.TokenResponse
+		out.SetToken("token")
+		return &out, nil
+	}
+
+	info := &grpc.UnaryServerInfo{FullMethod: gophkeeperv1.AuthorizationService_Authorize_FullMethodName}
+	call := func() (metadata.MD, error) {
+		var in gophkeeperv1.UserCredentials
+		in.SetLogin("alice")
+		in.SetPassword(password)
+
+		stream := &headerStream{}
+		_, err := i.Unary(newPeerContext(t.Context(), stream, "10.0.0.1:1000"), &in, info, handler)
+		return stream.header, err
+	}
+
+	for range 2 {
+		_, err := call()
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	}
+
+	// Заблокированный логин не проверяется даже с верным паролем.
+	password = "123"
+	header, err := call()
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+	require.Equal(t, []string{"60"}, header.Get("retry-after"))
+	require.Equal(t, 2, handled)
+
+	// Каждая следующая неудача удваивает блокировку.
+	now = now.Add(time.Minute)
+	password = "wrong"
+	_, err = call()
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	header, err = call()
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+	require.Equal(t, []string{"120"}, header.Get("retry-after"))
+
+	// Блокировка не превышает MaxLockout.
+	now = now.Add(2 * time.Minute)
+	_, err = call()
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	require.Equal(t, now.Add(3*time.Minute), attempts["alice"].LockedUntil)
+
+	now = now.Add(3 * time.Minute)
+	password = "123"
+	_, err = call()
+	require.NoError(t, err)
+	require.NotContains(t, attempts, "alice")
+}
+
+func TestRateLimitVerifyMFA(t *testing.T) {
+	var config server.Config
+	config.RateLimit.MaxFailures = 1
+	config.RateLimit.Lockout = time.Minute
+
+	i, attempts := newTestRateLimitInterceptor(config)
+
+	info := &grpc.UnaryServerInfo{FullMethod: gophkeeperv1.AuthorizationService_VerifyMFA_FullMethodName}
+	handler := func(ctx context.Context, req any) (any, error) {
+		return nil, status.Error(codes.InvalidArgument, "invalid mfa code")
+	}
+
+	var in gophkeeperv1.VerifyMFARequest
+	in.SetMfaChallenge("challenge")
+	in.SetCode("000000")
+
+	ctx := newPeerContext(t.Context(), &headerStream{}, "10.0.0.1:1000")
+	_, err := i.Unary(ctx, &in, info, handler)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	require.Equal(t, 1, attempts["alice"].Failures)
+
+	_, err = i.Unary(ctx, &in, info, handler)
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
+func TestRateLimitChangePassword(t *testing.T) {
+	var config server.Config
+	config.RateLimit.MaxFailures = 2
+	config.RateLimit.Lockout = time.Minute
+
+	i, attempts := newTestRateLimitInterceptor(config)
+
+	password := "wrong"
+	handler := func(ctx context.Context, req any) (any, error) {
+		if password != "123" {
+			return nil, status.Error(codes.InvalidArgument, "invalid password")
+		}
+		return &empty.Empty{}, nil
+	}
+
+	// Попытки учитываются под пользователем сессии.
+	ctx := server.NewContextWithUser(newPeerContext(t.Context(), &headerStream{}, "10.0.0.1:1000"), "alice")
+	requests := map[string]any{
+		gophkeeperv1.AuthorizationService_ChangePassword_FullMethodName: &gophkeeperv1.ChangePasswordRequest{},
+		gophkeeperv1.AuthorizationService_DeleteAccount_FullMethodName:  &gophkeeperv1.DeleteAccountRequest{},
+	}
+	for method, req := range requests {
+		info := &grpc.UnaryServerInfo{FullMethod: method}
+
+		password = "wrong"
+		_, err := i.Unary(ctx, req, info, handler)
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+		require.Equal(t, 1, attempts["alice"].Failures)
+
+		password = "123"
+		_, err = i.Unary(ctx, req, info, handler)
+		require.NoError(t, err)
+		require.NotContains(t, attempts, "alice")
+	}
+}
+
+func TestRateLimitFailedPrecondition(t *testing.T) {
+	var config server.Config
+	config.RateLimit.MaxFailures = 2
+	config.RateLimit.Lockout = time.Minute
+
+	i, attempts := newTestRateLimitInterceptor(config)
+
+	handler := func(ctx context.Context, req any) (any, error) {
+		return nil, status.Error(codes.FailedPrecondition, "vault key enrollment required")
+	}
+
+	info := &grpc.UnaryServerInfo{FullMethod: gophkeeperv1.AuthorizationService_Authorize_FullMethodName}
+	var in gophkeeperv1.UserCredentials
+	in.SetLogin("alice")
+
+	ctx := newPeerContext(t.Context(), &headerStream{}, "10.0.0.1:1000")
+	for range 2 {
+		_, err := i.Unary(ctx, &in, info, handler)
+		require.Equal(t, codes.FailedPrecondition, status.Code(err))
+	}
+	require.Equal(t, 2, attempts["alice"].Failures)
+
+	_, err := i.Unary(ctx, &in, info, handler)
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
+func TestRateLimitFailureTTL(t *testing.T) {
+	var config server.Config
+	config.RateLimit.FailureTTL = time.Hour
+
+	i, _ := newTestRateLimitInterceptor(config)
+	now := time.Unix(1_700_000_000, 0)
+	i.now = func() time.Time { return now }
+
+	var removed []time.Time
+	loginAttemptService := i.loginAttemptService.(*mock.LoginAttemptServiceMock)
+	loginAttemptService.RemoveExpiredFunc = func(ctx context.Context, before time.Time) error {
+		removed = append(removed, before)
+		return nil
+	}
+
+	handler := func(ctx context.Context, req any) (any, error) {
+		return nil, status.Error(codes.InvalidArgument, "invalid login or password")
+	}
+
+	var in gophkeeperv1.UserCredentials
+	in.SetLogin("nobody")
+
+	info := &grpc.UnaryServerInfo{FullMethod: gophkeeperv1.AuthorizationService_Authorize_FullMethodName}
+	_, err := i.Unary(newPeerContext(t.Context(), &headerStream{}, "10.0.0.1:1000"), &in, info, handler)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// Попытки несуществующих логинов не копятся: устаревшие удаляются
+	// при каждой неудаче.
+	require.Equal(t, []time.Time{now.Add(-time.Hour)}, removed)
+	require.Equal(t, now, loginAttemptService.FailCalls()[0].At)
+}
+
+func newTestRateLimitInterceptor(config server.Config) (*RateLimitInterceptor, map[string]server.LoginAttempts) {
+	attempts := map[string]server.LoginAttempts{}
+	loginAttemptService := &mock.LoginAttemptServiceMock{
+		GetFunc: func(ctx context.Context, login string) (server.LoginAttempts, error) {
+			return attempts[login], nil
+		},
+		FailFunc: func(ctx context.Context, login string, at time.Time) (int, error) {
+			a := attempts[login]
+			a.Login = login
+			a.Failures++
+			attempts[login] = a
+			return a.Failures, nil
+		},
+		LockFunc: func(ctx context.Context, login string, until time.Time) error {
+			a := attempts[login]
+			a.LockedUntil = until
+			attempts[login] = a
+			return nil
+		},
+		ResetFunc: func(ctx context.Context, login string) error {
+			delete(attempts, login)
+			return nil
+		},
+	}
+	authorizationService := &mock.AuthorizationServiceMock{
+		VerifyChallengeFunc: func(ctx context.Context, challenge string) (string, error) {
+			if challenge != "challenge" {
+				return "", server.ErrInvalidToken
+			}
+			return "alice", nil
+		},
+	}
+
+	i := NewRateLimitInterceptor(&config, loginAttemptService, authorizationService, log.New(io.Discard))
+	return i, attempts
+}
+
+func okHandler(ctx context.Context, req any) (any, error) {
+	return &gophkeeperv1.TokenResponse{}, nil
+}
+
+func newPeerContext(ctx context.Context, stream *headerStream, addr string) context.Context {
+	tcpAddr, err := net.ResolveTCPAddr("tcp", addr)
+	if err != nil {
+		panic(err)
+	}
+	ctx = peer.NewContext(ctx, &peer.Peer{Addr: tcpAddr})
+	return grpc.NewContextWithServerTransportStream(ctx, stream)
+}
+
+// headerStream запоминает заголовки, установленные обработчиком.
+type headerStream struct {
+	header metadata.MD
+}
+
+func (s *headerStream) Method() string {
+	return ""
+}
+
+func (s *headerStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+func (s *headerStream) SendHeader(md metadata.MD) error {
+	return s.SetHeader(md)
+}
+
+func (s *headerStream) SetTrailer(md metadata.MD) error {
+	return nil
+}
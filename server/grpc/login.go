@@ -45,8 +45,7 @@ func (s *LoginServiceServer) Save(ctx context.Context, in *gophkeeperv1.Login) (
 	}
 
 	if err := s.loginService.Create(ctx, data); err != nil {
-		s.logger.Error("failed to save data", "err", err)
-		return nil, status.Error(codes.Internal, "internal server error")
+		return nil, createError(err, s.logger)
 	}
 
 	return &empty.Empty{}, nil
@@ -61,14 +60,7 @@ func (s *LoginServiceServer) GetAll(ctx context.Context, _ *empty.Empty) (*gophk
 
 	var result []*gophkeeperv1.Login
 	for _, login := range logins {
-		var out gophkeeperv1.Login
-		out.SetId(login.ID)
-		out.SetName(login.Name)
-		out.SetLogin(login.Login)
-		out.SetPassword(login.Password)
-		out.SetWebsite(login.Website)
-		out.SetNotes(login.Notes)
-		result = append(result, &out)
+		result = append(result, newLoginMessage(login))
 	}
 
 	var out gophkeeperv1.GetAllLoginsResponse
@@ -86,3 +78,15 @@ func (s *LoginServiceServer) Update(ctx context.Context, in *gophkeeperv1.Login)
 func (s *LoginServiceServer) Remove(ctx context.Context, in *gophkeeperv1.RemoveDataRequest) (*empty.Empty, error) {
 	return removeData(ctx, in, s.loginService.Remove, s.logger)
 }
+
+func newLoginMessage(login server.LoginData) *gophkeeperv1.Login {
+	var out gophkeeperv1.Login
+	out.SetId(login.ID)
+	out.SetName(login.Name)
+	out.SetLogin(login.Login)
+	out.SetPassword(login.Password)
+	out.SetWebsite(login.Website)
+	out.SetNotes(login.Notes)
+	out.SetVersion(login.Version)
+	return &out
+}
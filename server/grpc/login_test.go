@@ -12,6 +12,7 @@ import (
 	"github.com/mkolibaba/gophkeeper/server/mock"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestLoginSave(t *testing.T) {
@@ -50,6 +51,22 @@ func TestLoginSave(t *testing.T) {
 		_, err := srv.Save(t.Context(), &in)
 		requireGrpcError(t, err, codes.Internal)
 	})
+	t.Run("quota_exceeded", func(t *testing.T) {
+		loginServiceMock := &mock.LoginServiceMock{
+			CreateFunc: func(_ context.Context, _ server.LoginData) error {
+				return server.ErrQuotaExceeded
+			},
+		}
+		srv := createLoginServiceServer(t, loginServiceMock)
+
+		var in gophkeeperv1.Login
+		in.SetName("new login")
+		in.SetLogin("user")
+		in.SetPassword("pass")
+
+		_, err := srv.Save(t.Context(), &in)
+		requireGrpcError(t, err, codes.ResourceExhausted)
+	})
 }
 
 func TestLoginUpdate(t *testing.T) {
@@ -58,6 +75,7 @@ func TestLoginUpdate(t *testing.T) {
 
 		var in gophkeeperv1.Login
 		in.SetId(1)
+		in.SetVersion(1)
 		in.SetName("new login name")
 
 		_, err := srv.Update(t.Context(), &in)
@@ -72,6 +90,39 @@ func TestLoginUpdate(t *testing.T) {
 		_, err := srv.Update(t.Context(), &in)
 		requireGrpcError(t, err, codes.InvalidArgument)
 	})
+	t.Run("version_required", func(t *testing.T) {
+		srv := createLoginServiceServer(t, &mock.LoginServiceMock{})
+
+		var in gophkeeperv1.Login
+		in.SetId(1)
+		in.SetName("new login name")
+
+		_, err := srv.Update(t.Context(), &in)
+		requireGrpcError(t, err, codes.InvalidArgument)
+	})
+	t.Run("version_conflict", func(t *testing.T) {
+		service := &mock.LoginServiceMock{
+			UpdateFunc: func(ctx context.Context, id int64, version int64, data server.LoginDataUpdate) error {
+				return fmt.Errorf("update: %w", &server.VersionConflictError{Version: 7})
+			},
+		}
+		srv := createLoginServiceServer(t, service)
+
+		var in gophkeeperv1.Login
+		in.SetId(1)
+		in.SetVersion(5)
+		in.SetName("new login name")
+
+		_, err := srv.Update(t.Context(), &in)
+		requireGrpcError(t, err, codes.Aborted)
+
+		details := status.Convert(err).Details()
+		require.Len(t, details, 1)
+		conflict, ok := details[0].(*gophkeeperv1.VersionConflict)
+		require.True(t, ok)
+		require.Equal(t, int64(7), conflict.GetVersion())
+		require.Equal(t, int64(5), service.UpdateCalls()[0].Version)
+	})
 }
 
 func TestLoginRemove(t *testing.T) {
@@ -80,6 +131,7 @@ func TestLoginRemove(t *testing.T) {
 
 		var in gophkeeperv1.RemoveDataRequest
 		in.SetId(1)
+		in.SetVersion(1)
 
 		_, err := srv.Remove(t.Context(), &in)
 		require.NoError(t, err)
@@ -94,7 +146,7 @@ func TestLoginRemove(t *testing.T) {
 	})
 	t.Run("not_found", func(t *testing.T) {
 		service := &mock.LoginServiceMock{
-			RemoveFunc: func(ctx context.Context, id int64) error {
+			RemoveFunc: func(ctx context.Context, id int64, version int64) error {
 				return server.ErrDataNotFound
 			},
 		}
@@ -102,6 +154,7 @@ func TestLoginRemove(t *testing.T) {
 
 		var in gophkeeperv1.RemoveDataRequest
 		in.SetId(1)
+		in.SetVersion(1)
 
 		_, err := srv.Remove(t.Context(), &in)
 		requireGrpcError(t, err, codes.NotFound)
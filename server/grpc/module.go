@@ -12,11 +12,18 @@ var Module = fx.Module(
 	fx.Provide(
 		interceptors.NewAuthInterceptor,
 		interceptors.NewLoggerInterceptor,
+		interceptors.NewRateLimitInterceptor,
 		NewAuthorizationServiceServer,
 		NewLoginServiceServer,
 		NewNoteServiceServer,
 		NewBinaryServiceServer,
 		NewCardServiceServer,
+		NewOTPServiceServer,
+		NewSyncServiceServer,
+		NewHistoryServiceServer,
+		NewTrashServiceServer,
+		NewInfoServiceServer,
+		NewTransportCredentials,
 		NewServer,
 	),
 	fx.Invoke(
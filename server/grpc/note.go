@@ -42,8 +42,7 @@ func (s *NoteServiceServer) Save(ctx context.Context, in *gophkeeperv1.Note) (*e
 	}
 
 	if err := s.noteService.Create(ctx, data); err != nil {
-		s.logger.Error("failed to save data", "err", err)
-		return nil, status.Error(codes.Internal, "internal server error")
+		return nil, createError(err, s.logger)
 	}
 
 	return &empty.Empty{}, nil
@@ -59,11 +58,7 @@ func (s *NoteServiceServer) GetAll(ctx context.Context, _ *empty.Empty) (*gophke
 
 	var result []*gophkeeperv1.Note
 	for _, note := range notes {
-		var out gophkeeperv1.Note
-		out.SetId(note.ID)
-		out.SetName(note.Name)
-		out.SetText(note.Text)
-		result = append(result, &out)
+		result = append(result, newNoteMessage(note))
 	}
 
 	var out gophkeeperv1.GetAllNotesResponse
@@ -81,3 +76,12 @@ func (s *NoteServiceServer) Update(ctx context.Context, in *gophkeeperv1.Note) (
 func (s *NoteServiceServer) Remove(ctx context.Context, in *gophkeeperv1.RemoveDataRequest) (*empty.Empty, error) {
 	return removeData(ctx, in, s.noteService.Remove, s.logger)
 }
+
+func newNoteMessage(note server.NoteData) *gophkeeperv1.Note {
+	var out gophkeeperv1.Note
+	out.SetId(note.ID)
+	out.SetName(note.Name)
+	out.SetText(note.Text)
+	out.SetVersion(note.Version)
+	return &out
+}
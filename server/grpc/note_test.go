@@ -57,6 +57,7 @@ func TestNoteUpdate(t *testing.T) {
 
 		var in gophkeeperv1.Note
 		in.SetId(1)
+		in.SetVersion(1)
 		in.SetName("new note name")
 		in.SetText("updated text")
 
@@ -80,6 +81,7 @@ func TestNoteRemove(t *testing.T) {
 
 		var in gophkeeperv1.RemoveDataRequest
 		in.SetId(1)
+		in.SetVersion(1)
 
 		_, err := srv.Remove(t.Context(), &in)
 		require.NoError(t, err)
@@ -94,7 +96,7 @@ func TestNoteRemove(t *testing.T) {
 	})
 	t.Run("not_found", func(t *testing.T) {
 		service := &mock.NoteServiceMock{
-			RemoveFunc: func(ctx context.Context, id int64) error {
+			RemoveFunc: func(ctx context.Context, id int64, version int64) error {
 				return server.ErrDataNotFound
 			},
 		}
@@ -103,6 +105,7 @@ func TestNoteRemove(t *testing.T) {
 
 		var in gophkeeperv1.RemoveDataRequest
 		in.SetId(1)
+		in.SetVersion(1)
 
 		_, err := srv.Remove(t.Context(), &in)
 		requireGrpcError(t, err, codes.NotFound)
@@ -0,0 +1,98 @@
+package grpc
+
+import (
+	"context"
+	"github.com/charmbracelet/log"
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/mkolibaba/gophkeeper/proto/gen/go/gophkeeperv1"
+	"github.com/mkolibaba/gophkeeper/server"
+	grpcgen "github.com/mkolibaba/gophkeeper/server/grpc/gen"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type OTPServiceServer struct {
+	gophkeeperv1.UnimplementedOTPServiceServer
+	otpService server.OTPService
+	validate   *validator.Validate
+	logger     *log.Logger
+}
+
+func NewOTPServiceServer(
+	otpService server.OTPService,
+	validate *validator.Validate,
+	logger *log.Logger,
+) *OTPServiceServer {
+	return &OTPServiceServer{
+		otpService: otpService,
+		validate:   validate,
+		logger:     logger,
+	}
+}
+
+func (s *OTPServiceServer) Save(ctx context.Context, in *gophkeeperv1.OTP) (*empty.Empty, error) {
+	data := server.OTPData{
+		Name:      in.GetName(),
+		Type:      in.GetType(),
+		Secret:    in.GetSecret(),
+		Algorithm: in.GetAlgorithm(),
+		Digits:    in.GetDigits(),
+		Period:    in.GetPeriod(),
+		Counter:   in.GetCounter(),
+		Issuer:    in.GetIssuer(),
+	}
+
+	if err := s.validate.StructCtx(ctx, &data); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := s.otpService.Create(ctx, data); err != nil {
+		return nil, createError(err, s.logger)
+	}
+
+	return &empty.Empty{}, nil
+}
+
+func (s *OTPServiceServer) GetAll(ctx context.Context, _ *empty.Empty) (*gophkeeperv1.GetAllOTPsResponse, error) {
+	otps, err := s.otpService.GetAll(ctx)
+	if err != nil {
+		s.logger.Error("failed to retrieve otp data", "err", err)
+		return nil, status.Error(codes.Internal, "internal server error")
+	}
+
+	var result []*gophkeeperv1.OTP
+	for _, otp := range otps {
+		result = append(result, newOTPMessage(otp))
+	}
+
+	var out gophkeeperv1.GetAllOTPsResponse
+	out.SetResult(result)
+
+	return &out, nil
+}
+
+func (s *OTPServiceServer) Update(ctx context.Context, in *gophkeeperv1.OTP) (*empty.Empty, error) {
+	return updateData(ctx, in, func(i *gophkeeperv1.OTP) server.OTPDataUpdate {
+		return grpcgen.MapOTPDataUpdate(i)
+	}, s.otpService.Update, s.logger)
+}
+
+func (s *OTPServiceServer) Remove(ctx context.Context, in *gophkeeperv1.RemoveDataRequest) (*empty.Empty, error) {
+	return removeData(ctx, in, s.otpService.Remove, s.logger)
+}
+
+func newOTPMessage(otp server.OTPData) *gophkeeperv1.OTP {
+	var out gophkeeperv1.OTP
+	out.SetId(otp.ID)
+	out.SetName(otp.Name)
+	out.SetType(otp.Type)
+	out.SetSecret(otp.Secret)
+	out.SetAlgorithm(otp.Algorithm)
+	out.SetDigits(otp.Digits)
+	out.SetPeriod(otp.Period)
+	out.SetCounter(otp.Counter)
+	out.SetIssuer(otp.Issuer)
+	out.SetVersion(otp.Version)
+	return &out
+}
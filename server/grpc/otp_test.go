@@ -0,0 +1,130 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/charmbracelet/log"
+	"github.com/mkolibaba/gophkeeper/proto/gen/go/gophkeeperv1"
+	"github.com/mkolibaba/gophkeeper/server"
+	"github.com/mkolibaba/gophkeeper/server/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestOTPSave(t *testing.T) {
+	newTOTP := func() *gophkeeperv1.OTP {
+		var in gophkeeperv1.OTP
+		in.SetName("github")
+		in.SetType("totp")
+		in.SetSecret("JBSWY3DPEHPK3PXP")
+		in.SetAlgorithm("SHA1")
+		in.SetDigits(6)
+		in.SetPeriod(30)
+		return &in
+	}
+
+	t.Run("success", func(t *testing.T) {
+		service := &mock.OTPServiceMock{}
+		srv := createOTPServiceServer(t, service)
+
+		_, err := srv.Save(t.Context(), newTOTP())
+		require.NoError(t, err)
+		require.Len(t, service.CreateCalls(), 1)
+		require.Equal(t, int64(30), service.CreateCalls()[0].Data.Period)
+	})
+	t.Run("hotp_without_period", func(t *testing.T) {
+		srv := createOTPServiceServer(t, &mock.OTPServiceMock{})
+
+		in := newTOTP()
+		in.SetType("hotp")
+		in.SetPeriod(0)
+		in.SetCounter(3)
+
+		_, err := srv.Save(t.Context(), in)
+		require.NoError(t, err)
+	})
+	t.Run("totp_without_period", func(t *testing.T) {
+		srv := createOTPServiceServer(t, &mock.OTPServiceMock{})
+
+		in := newTOTP()
+		in.SetPeriod(0)
+
+		_, err := srv.Save(t.Context(), in)
+		requireGrpcError(t, err, codes.InvalidArgument)
+	})
+	t.Run("unknown_algorithm", func(t *testing.T) {
+		srv := createOTPServiceServer(t, &mock.OTPServiceMock{})
+
+		in := newTOTP()
+		in.SetAlgorithm("MD5")
+
+		_, err := srv.Save(t.Context(), in)
+		requireGrpcError(t, err, codes.InvalidArgument)
+	})
+	t.Run("db_error", func(t *testing.T) {
+		srv := createOTPServiceServer(t, &mock.OTPServiceMock{
+			CreateFunc: func(_ context.Context, _ server.OTPData) error {
+				return fmt.Errorf("some error")
+			},
+		})
+
+		_, err := srv.Save(t.Context(), newTOTP())
+		requireGrpcError(t, err, codes.Internal)
+	})
+}
+
+func TestOTPUpdate(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		service := &mock.OTPServiceMock{}
+		srv := createOTPServiceServer(t, service)
+
+		var in gophkeeperv1.OTP
+		in.SetId(1)
+		in.SetVersion(1)
+		in.SetCounter(7)
+
+		_, err := srv.Update(t.Context(), &in)
+		require.NoError(t, err)
+		require.Len(t, service.UpdateCalls(), 1)
+		require.Equal(t, int64(7), *service.UpdateCalls()[0].Data.Counter)
+		require.Nil(t, service.UpdateCalls()[0].Data.Name)
+	})
+	t.Run("not_found", func(t *testing.T) {
+		srv := createOTPServiceServer(t, &mock.OTPServiceMock{
+			UpdateFunc: func(ctx context.Context, id int64, version int64, data server.OTPDataUpdate) error {
+				return server.ErrDataNotFound
+			},
+		})
+
+		var in gophkeeperv1.OTP
+		in.SetId(1)
+		in.SetVersion(1)
+		in.SetName("gitlab")
+
+		_, err := srv.Update(t.Context(), &in)
+		requireGrpcError(t, err, codes.NotFound)
+	})
+}
+
+func TestOTPGetAll(t *testing.T) {
+	srv := createOTPServiceServer(t, &mock.OTPServiceMock{
+		GetAllFunc: func(ctx context.Context) ([]server.OTPData, error) {
+			return []server.OTPData{
+				{ID: 1, Name: "github", Type: "totp", Digits: 6, Period: 30, Version: 2},
+			}, nil
+		},
+	})
+
+	resp, err := srv.GetAll(t.Context(), nil)
+	require.NoError(t, err)
+	require.Len(t, resp.GetResult(), 1)
+	require.Equal(t, int64(30), resp.GetResult()[0].GetPeriod())
+	require.Equal(t, int64(2), resp.GetResult()[0].GetVersion())
+}
+
+func createOTPServiceServer(t *testing.T, otpService server.OTPService) *OTPServiceServer {
+	return NewOTPServiceServer(otpService, newTestValidator(t), log.New(io.Discard))
+}
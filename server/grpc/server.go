@@ -9,6 +9,7 @@ import (
 	"github.com/mkolibaba/gophkeeper/server/grpc/interceptors"
 	"go.uber.org/fx"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/reflection"
 	"net"
 )
@@ -16,6 +17,7 @@ import (
 type Server struct {
 	s      *grpc.Server
 	port   string
+	tls    bool
 	logger *log.Logger
 }
 
@@ -25,36 +27,56 @@ type ServerParams struct {
 	Lifecycle                  fx.Lifecycle
 	AuthInterceptor            *interceptors.AuthInterceptor
 	LoggerInterceptor          *interceptors.LoggerInterceptor
+	RateLimitInterceptor       *interceptors.RateLimitInterceptor
 	AuthorizationServiceServer *AuthorizationServiceServer
 	LoginServiceServer         *LoginServiceServer
 	NoteServiceServer          *NoteServiceServer
 	BinaryServiceServer        *BinaryServiceServer
 	CardServiceServer          *CardServiceServer
+	OTPServiceServer           *OTPServiceServer
+	SyncServiceServer          *SyncServiceServer
+	HistoryServiceServer       *HistoryServiceServer
+	TrashServiceServer         *TrashServiceServer
+	InfoServiceServer          *InfoServiceServer
+	Credentials                credentials.TransportCredentials
 	Config                     *server.Config
 	Logger                     *log.Logger
 }
 
 func NewServer(p ServerParams) *Server {
-	s := grpc.NewServer(
+	opts := []grpc.ServerOption{
+		grpc.Creds(p.Credentials),
 		grpc.ChainUnaryInterceptor(
 			p.LoggerInterceptor.Unary,
 			p.AuthInterceptor.Unary,
+			p.RateLimitInterceptor.Unary,
 		),
 		grpc.ChainStreamInterceptor(
 			p.LoggerInterceptor.Stream,
 			p.AuthInterceptor.Stream,
 		),
-	)
+	}
+	if p.Config.GRPC.MaxMessageSize > 0 {
+		opts = append(opts, grpc.MaxRecvMsgSize(p.Config.GRPC.MaxMessageSize))
+	}
+
+	s := grpc.NewServer(opts...)
 	gophkeeperv1.RegisterAuthorizationServiceServer(s, p.AuthorizationServiceServer)
 	gophkeeperv1.RegisterLoginServiceServer(s, p.LoginServiceServer)
 	gophkeeperv1.RegisterNoteServiceServer(s, p.NoteServiceServer)
 	gophkeeperv1.RegisterBinaryServiceServer(s, p.BinaryServiceServer)
 	gophkeeperv1.RegisterCardServiceServer(s, p.CardServiceServer)
+	gophkeeperv1.RegisterOTPServiceServer(s, p.OTPServiceServer)
+	gophkeeperv1.RegisterSyncServiceServer(s, p.SyncServiceServer)
+	gophkeeperv1.RegisterHistoryServiceServer(s, p.HistoryServiceServer)
+	gophkeeperv1.RegisterTrashServiceServer(s, p.TrashServiceServer)
+	gophkeeperv1.RegisterInfoServiceServer(s, p.InfoServiceServer)
 	reflection.Register(s)
 
 	srv := &Server{
 		s:      s,
 		port:   p.Config.GRPC.Port,
+		tls:    p.Config.GRPC.TLS.Enabled,
 		logger: p.Logger,
 	}
 
@@ -73,7 +95,7 @@ func NewServer(p ServerParams) *Server {
 }
 
 func (s *Server) start() error {
-	s.logger.Info("running grpc server", "port", s.port)
+	s.logger.Info("running grpc server", "port", s.port, "tls", s.tls)
 
 	listen, err := net.Listen("tcp", ":"+s.port)
 	if err != nil {
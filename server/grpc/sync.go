@@ -0,0 +1,261 @@
+package grpc
+
+import (
+	"context"
+	"github.com/charmbracelet/log"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/mkolibaba/gophkeeper/proto/gen/go/gophkeeperv1"
+	"github.com/mkolibaba/gophkeeper/server"
+	"go.uber.org/fx"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var dataKinds = map[server.DataKind]gophkeeperv1.DataKind{
+	server.DataKindLogin:  gophkeeperv1.DataKind_DATA_KIND_LOGIN,
+	server.DataKindNote:   gophkeeperv1.DataKind_DATA_KIND_NOTE,
+	server.DataKindBinary: gophkeeperv1.DataKind_DATA_KIND_BINARY,
+	server.DataKindCard:   gophkeeperv1.DataKind_DATA_KIND_CARD,
+	server.DataKindOTP:    gophkeeperv1.DataKind_DATA_KIND_OTP,
+}
+
+type SyncServiceServer struct {
+	gophkeeperv1.UnimplementedSyncServiceServer
+	syncService server.SyncService
+	logins      *LoginServiceServer
+	notes       *NoteServiceServer
+	binaries    *BinaryServiceServer
+	cards       *CardServiceServer
+	otps        *OTPServiceServer
+	logger      *log.Logger
+}
+
+type SyncServiceServerParams struct {
+	fx.In
+
+	SyncService server.SyncService
+	Logins      *LoginServiceServer
+	Notes       *NoteServiceServer
+	Binaries    *BinaryServiceServer
+	Cards       *CardServiceServer
+	OTPs        *OTPServiceServer
+	Logger      *log.Logger
+}
+
+// NewSyncServiceServer создает сервер синхронизации. Изменения, присланные
+// клиентом, применяются через серверы соответствующих типов данных, поэтому
+// проходят те же проверки, что и обычные запросы.
+func NewSyncServiceServer(p SyncServiceServerParams) *SyncServiceServer {
+	return &SyncServiceServer{
+		syncService: p.SyncService,
+		logins:      p.Logins,
+		notes:       p.Notes,
+		binaries:    p.Binaries,
+		cards:       p.Cards,
+		otps:        p.OTPs,
+		logger:      p.Logger,
+	}
+}
+
+func (s *SyncServiceServer) Pull(ctx context.Context, in *gophkeeperv1.PullRequest) (*gophkeeperv1.PullResponse, error) {
+	changes, err := s.syncService.Changes(ctx, in.GetSinceRevision())
+	if err != nil {
+		s.logger.Error("failed to retrieve changes", "err", err)
+		return nil, status.Error(codes.Internal, "internal server error")
+	}
+
+	var result []*gophkeeperv1.Change
+	for _, login := range changes.Logins {
+		var change gophkeeperv1.Change
+		change.SetLogin(newLoginMessage(login))
+		result = append(result, &change)
+	}
+	for _, note := range changes.Notes {
+		var change gophkeeperv1.Change
+		change.SetNote(newNoteMessage(note))
+		result = append(result, &change)
+	}
+	for _, binary := range changes.Binaries {
+		var change gophkeeperv1.Change
+		change.SetBinary(newBinaryMessage(binary))
+		result = append(result, &change)
+	}
+	for _, card := range changes.Cards {
+		var change gophkeeperv1.Change
+		change.SetCard(newCardMessage(card))
+		result = append(result, &change)
+	}
+	for _, otp := range changes.OTPs {
+		var change gophkeeperv1.Change
+		change.SetOtp(newOTPMessage(otp))
+		result = append(result, &change)
+	}
+	for _, tombstone := range changes.Tombstones {
+		var removed gophkeeperv1.Tombstone
+		removed.SetKind(dataKinds[tombstone.Kind])
+		removed.SetId(tombstone.ID)
+
+		var change gophkeeperv1.Change
+		change.SetRemoved(&removed)
+		result = append(result, &change)
+	}
+
+	var out gophkeeperv1.PullResponse
+	out.SetRevision(changes.Revision)
+	out.SetChanges(result)
+
+	return &out, nil
+}
+
+func (s *SyncServiceServer) Push(ctx context.Context, in *gophkeeperv1.PushRequest) (*gophkeeperv1.PushResponse, error) {
+	var out gophkeeperv1.PushResponse
+
+	var results []*gophkeeperv1.ChangeResult
+	for i, change := range in.GetChanges() {
+		st := status.Convert(s.apply(ctx, change))
+		if !isChangeResult(st.Code()) {
+			// Изменения применяются не атомарно: в деталях ошибки клиент
+			// получает результаты уже примененных, чтобы не отправить
+			// их повторно.
+			s.logger.Error("failed to apply change", "index", i, "err", st.Err())
+			out.SetResults(results)
+			return nil, pushError(&out)
+		}
+		if st.Code() != codes.OK {
+			s.logger.Debug("change rejected", "index", i, "err", st.Err())
+		}
+		results = append(results, newChangeResult(st))
+	}
+
+	revision, err := s.syncService.Revision(ctx)
+	if err != nil {
+		s.logger.Error("failed to retrieve revision", "err", err)
+		return nil, status.Error(codes.Internal, "internal server error")
+	}
+
+	out.SetRevision(revision)
+	out.SetResults(results)
+
+	return &out, nil
+}
+
+// isChangeResult сообщает, является ли код результатом изменения:
+// применением или отклонением данных клиента. Остальные коды означают
+// ошибку сервера.
+func isChangeResult(code codes.Code) bool {
+	switch code {
+	case codes.OK,
+		codes.InvalidArgument,
+		codes.NotFound,
+		codes.AlreadyExists,
+		codes.PermissionDenied,
+		codes.ResourceExhausted,
+		codes.FailedPrecondition,
+		codes.Aborted:
+		return true
+	default:
+		return false
+	}
+}
+
+func newChangeResult(st *status.Status) *gophkeeperv1.ChangeResult {
+	var result gophkeeperv1.ChangeResult
+	result.SetCode(int32(st.Code()))
+	result.SetMessage(st.Message())
+	for _, detail := range st.Details() {
+		if conflict, ok := detail.(*gophkeeperv1.VersionConflict); ok {
+			result.SetVersion(conflict.GetVersion())
+		}
+	}
+	return &result
+}
+
+// pushError возвращает ошибку Internal с результатами примененных
+// изменений в деталях.
+func pushError(applied *gophkeeperv1.PushResponse) error {
+	st, err := status.New(codes.Internal, "internal server error").WithDetails(applied)
+	if err != nil {
+		return status.Error(codes.Internal, "internal server error")
+	}
+	return st.Err()
+}
+
+func (s *SyncServiceServer) apply(ctx context.Context, change *gophkeeperv1.Change) error {
+	var err error
+
+	switch change.WhichChange() {
+	case gophkeeperv1.Change_Login_case:
+		if login := change.GetLogin(); login.GetId() == 0 {
+			_, err = s.logins.Save(ctx, login)
+		} else {
+			_, err = s.logins.Update(ctx, login)
+		}
+
+	case gophkeeperv1.Change_Note_case:
+		if note := change.GetNote(); note.GetId() == 0 {
+			_, err = s.notes.Save(ctx, note)
+		} else {
+			_, err = s.notes.Update(ctx, note)
+		}
+
+	case gophkeeperv1.Change_Binary_case:
+		binary := change.GetBinary()
+		if binary.GetId() == 0 {
+			return status.Error(codes.InvalidArgument, "binary data can only be created by upload")
+		}
+
+		var in gophkeeperv1.UpdateBinaryRequest
+		in.SetId(binary.GetId())
+		in.SetName(binary.GetName())
+		in.SetNotes(binary.GetNotes())
+		in.SetVersion(binary.GetVersion())
+		_, err = s.binaries.Update(ctx, &in)
+
+	case gophkeeperv1.Change_Card_case:
+		if card := change.GetCard(); card.GetId() == 0 {
+			_, err = s.cards.Save(ctx, card)
+		} else {
+			_, err = s.cards.Update(ctx, card)
+		}
+
+	case gophkeeperv1.Change_Otp_case:
+		if otp := change.GetOtp(); otp.GetId() == 0 {
+			_, err = s.otps.Save(ctx, otp)
+		} else {
+			_, err = s.otps.Update(ctx, otp)
+		}
+
+	case gophkeeperv1.Change_Removed_case:
+		err = s.remove(ctx, change.GetRemoved())
+
+	default:
+		return status.Error(codes.InvalidArgument, "change is empty")
+	}
+
+	return err
+}
+
+func (s *SyncServiceServer) remove(ctx context.Context, tombstone *gophkeeperv1.Tombstone) error {
+	var in gophkeeperv1.RemoveDataRequest
+	in.SetId(tombstone.GetId())
+	in.SetVersion(tombstone.GetVersion())
+
+	var remove func(context.Context, *gophkeeperv1.RemoveDataRequest) (*empty.Empty, error)
+	switch tombstone.GetKind() {
+	case gophkeeperv1.DataKind_DATA_KIND_LOGIN:
+		remove = s.logins.Remove
+	case gophkeeperv1.DataKind_DATA_KIND_NOTE:
+		remove = s.notes.Remove
+	case gophkeeperv1.DataKind_DATA_KIND_BINARY:
+		remove = s.binaries.Remove
+	case gophkeeperv1.DataKind_DATA_KIND_CARD:
+		remove = s.cards.Remove
+	case gophkeeperv1.DataKind_DATA_KIND_OTP:
+		remove = s.otps.Remove
+	default:
+		return status.Error(codes.InvalidArgument, "unknown data kind")
+	}
+
+	_, err := remove(ctx, &in)
+	return err
+}
@@ -0,0 +1,213 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"github.com/charmbracelet/log"
+	"github.com/mkolibaba/gophkeeper/proto/gen/go/gophkeeperv1"
+	"github.com/mkolibaba/gophkeeper/server"
+	"github.com/mkolibaba/gophkeeper/server/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"io"
+	"testing"
+)
+
+type syncTestServices struct {
+	sync     *mock.SyncServiceMock
+	logins   *mock.LoginServiceMock
+	notes    *mock.NoteServiceMock
+	binaries *mock.BinaryServiceMock
+	cards    *mock.CardServiceMock
+	otps     *mock.OTPServiceMock
+}
+
+func newSyncTestServices() *syncTestServices {
+	return &syncTestServices{
+		sync:     &mock.SyncServiceMock{},
+		logins:   &mock.LoginServiceMock{},
+		notes:    &mock.NoteServiceMock{},
+		binaries: &mock.BinaryServiceMock{},
+		cards:    &mock.CardServiceMock{},
+		otps:     &mock.OTPServiceMock{},
+	}
+}
+
+func TestSyncPull(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		services := newSyncTestServices()
+		services.sync.ChangesFunc = func(ctx context.Context, since int64) (*server.Changes, error) {
+			require.Equal(t, int64(5), since)
+			return &server.Changes{
+				Revision: 7,
+				Logins:   []server.LoginData{{ID: 1, Name: "login"}},
+				Cards:    []server.CardData{{ID: 2, Name: "card"}},
+				OTPs:     []server.OTPData{{ID: 4, Name: "otp"}},
+				Tombstones: []server.Tombstone{
+					{Kind: server.DataKindNote, ID: 3},
+				},
+			}, nil
+		}
+		srv := createSyncServiceServer(t, services)
+
+		var in gophkeeperv1.PullRequest
+		in.SetSinceRevision(5)
+
+		out, err := srv.Pull(t.Context(), &in)
+		require.NoError(t, err)
+		require.Equal(t, int64(7), out.GetRevision())
+		require.Len(t, out.GetChanges(), 4)
+		require.Equal(t, "login", out.GetChanges()[0].GetLogin().GetName())
+		require.Equal(t, "card", out.GetChanges()[1].GetCard().GetName())
+		require.Equal(t, "otp", out.GetChanges()[2].GetOtp().GetName())
+		require.Equal(t, gophkeeperv1.DataKind_DATA_KIND_NOTE, out.GetChanges()[3].GetRemoved().GetKind())
+		require.Equal(t, int64(3), out.GetChanges()[3].GetRemoved().GetId())
+	})
+	t.Run("db_error", func(t *testing.T) {
+		services := newSyncTestServices()
+		services.sync.ChangesFunc = func(ctx context.Context, since int64) (*server.Changes, error) {
+			return nil, errors.New("some error")
+		}
+		srv := createSyncServiceServer(t, services)
+
+		_, err := srv.Pull(t.Context(), &gophkeeperv1.PullRequest{})
+		requireGrpcError(t, err, codes.Internal)
+	})
+}
+
+func TestSyncPush(t *testing.T) {
+	t.Run("results", func(t *testing.T) {
+		services := newSyncTestServices()
+		services.sync.RevisionFunc = func(ctx context.Context) (int64, error) {
+			return 10, nil
+		}
+		services.notes.RemoveFunc = func(ctx context.Context, id int64, version int64) error {
+			return server.ErrDataNotFound
+		}
+		services.otps.UpdateFunc = func(ctx context.Context, id int64, version int64, data server.OTPDataUpdate) error {
+			return &server.VersionConflictError{Version: 7}
+		}
+		srv := createSyncServiceServer(t, services)
+
+		var newLogin gophkeeperv1.Login
+		newLogin.SetName("new login")
+		newLogin.SetLogin("user")
+
+		var updatedCard gophkeeperv1.Card
+		updatedCard.SetId(4)
+		updatedCard.SetVersion(3)
+		updatedCard.SetName("card")
+
+		var invalidLogin gophkeeperv1.Login
+		invalidLogin.SetName("login without login")
+
+		var newBinary gophkeeperv1.Binary
+		newBinary.SetName("binary")
+
+		var removedNote gophkeeperv1.Tombstone
+		removedNote.SetKind(gophkeeperv1.DataKind_DATA_KIND_NOTE)
+		removedNote.SetId(5)
+		removedNote.SetVersion(2)
+
+		var staleOTP gophkeeperv1.OTP
+		staleOTP.SetId(6)
+		staleOTP.SetVersion(5)
+		staleOTP.SetName("otp")
+		staleOTP.SetType("totp")
+		staleOTP.SetSecret("JBSWY3DPEHPK3PXP")
+
+		changes := make([]*gophkeeperv1.Change, 6)
+		for i := range changes {
+			changes[i] = &gophkeeperv1.Change{}
+		}
+		changes[0].SetLogin(&newLogin)
+		changes[1].SetCard(&updatedCard)
+		changes[2].SetLogin(&invalidLogin)
+		changes[3].SetBinary(&newBinary)
+		changes[4].SetRemoved(&removedNote)
+		changes[5].SetOtp(&staleOTP)
+
+		var in gophkeeperv1.PushRequest
+		in.SetChanges(changes)
+
+		out, err := srv.Push(t.Context(), &in)
+		require.NoError(t, err)
+		require.Equal(t, int64(10), out.GetRevision())
+
+		var results []codes.Code
+		for _, result := range out.GetResults() {
+			results = append(results, codes.Code(result.GetCode()))
+		}
+		require.Equal(t, []codes.Code{
+			codes.OK,
+			codes.OK,
+			codes.InvalidArgument,
+			codes.InvalidArgument,
+			codes.NotFound,
+			codes.Aborted,
+		}, results)
+		require.Equal(t, int64(7), out.GetResults()[5].GetVersion())
+
+		require.Len(t, services.logins.CreateCalls(), 1)
+		require.Equal(t, "new login", services.logins.CreateCalls()[0].Data.Name)
+		require.Len(t, services.cards.UpdateCalls(), 1)
+		require.Equal(t, int64(4), services.cards.UpdateCalls()[0].ID)
+		require.Equal(t, int64(3), services.cards.UpdateCalls()[0].Version)
+		require.Empty(t, services.binaries.CreateCalls())
+		require.Len(t, services.notes.RemoveCalls(), 1)
+	})
+	t.Run("server_error", func(t *testing.T) {
+		services := newSyncTestServices()
+		services.cards.UpdateFunc = func(ctx context.Context, id int64, version int64, data server.CardDataUpdate) error {
+			return errors.New("some error")
+		}
+		srv := createSyncServiceServer(t, services)
+
+		var newLogin gophkeeperv1.Login
+		newLogin.SetName("new login")
+		newLogin.SetLogin("user")
+
+		var updatedCard gophkeeperv1.Card
+		updatedCard.SetId(4)
+		updatedCard.SetVersion(3)
+		updatedCard.SetName("card")
+
+		changes := make([]*gophkeeperv1.Change, 3)
+		for i := range changes {
+			changes[i] = &gophkeeperv1.Change{}
+		}
+		changes[0].SetLogin(&newLogin)
+		changes[1].SetCard(&updatedCard)
+		changes[2].SetLogin(&newLogin)
+
+		var in gophkeeperv1.PushRequest
+		in.SetChanges(changes)
+
+		_, err := srv.Push(t.Context(), &in)
+		requireGrpcError(t, err, codes.Internal)
+
+		// Клиент узнает из деталей ошибки, какие изменения применены.
+		details := status.Convert(err).Details()
+		require.Len(t, details, 1)
+		applied, ok := details[0].(*gophkeeperv1.PushResponse)
+		require.True(t, ok)
+		require.Len(t, applied.GetResults(), 1)
+		require.Equal(t, int32(codes.OK), applied.GetResults()[0].GetCode())
+
+		// Изменения после ошибки не применяются.
+		require.Len(t, services.logins.CreateCalls(), 1)
+	})
+}
+
+func createSyncServiceServer(t *testing.T, services *syncTestServices) *SyncServiceServer {
+	return NewSyncServiceServer(SyncServiceServerParams{
+		SyncService: services.sync,
+		Logins:      createLoginServiceServer(t, services.logins),
+		Notes:       createNoteServiceServer(t, services.notes),
+		Binaries:    createBinaryServiceServer(t, services.binaries),
+		Cards:       createCardServiceServer(t, services.cards),
+		OTPs:        createOTPServiceServer(t, services.otps),
+		Logger:      log.New(io.Discard),
+	})
+}
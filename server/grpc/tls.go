@@ -0,0 +1,61 @@
+package grpc
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"github.com/mkolibaba/gophkeeper/server"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"os"
+)
+
+// NewTransportCredentials возвращает параметры транспорта сервера. Если TLS
+// выключен, соединения принимаются без шифрования.
+func NewTransportCredentials(config *server.Config) (credentials.TransportCredentials, error) {
+	cfg := config.GRPC.TLS
+	if !cfg.Enabled {
+		return insecure.NewCredentials(), nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("new transport credentials: %w", err)
+	}
+
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if cfg.ClientCAFile != "" {
+		pool, err := loadCertPool(cfg.ClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("new transport credentials: %w", err)
+		}
+		tlsConfig.ClientCAs = pool
+		tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+	}
+
+	if cfg.RequireClientCert {
+		if tlsConfig.ClientCAs == nil {
+			return nil, fmt.Errorf("new transport credentials: client CA is required to verify client certificates")
+		}
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return credentials.NewTLS(tlsConfig), nil
+}
+
+func loadCertPool(path string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates found in %s", path)
+	}
+	return pool, nil
+}
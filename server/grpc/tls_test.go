@@ -0,0 +1,171 @@
+package grpc
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"github.com/mkolibaba/gophkeeper/server"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type testCert struct {
+	cert     *x509.Certificate
+	key      *ecdsa.PrivateKey
+	certFile string
+	keyFile  string
+}
+
+// newTestCert создает сертификат, подписанный parent. Если parent не задан,
+// сертификат самоподписанный и может использоваться как CA.
+func newTestCert(t *testing.T, name string, parent *testCert) *testCert {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+
+	signer, signerKey := template, key
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+	} else {
+		signer, signerKey = parent.cert, parent.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, name+".crt")
+	keyFile := filepath.Join(dir, name+".key")
+	err = os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600)
+	require.NoError(t, err)
+	err = os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600)
+	require.NoError(t, err)
+
+	return &testCert{cert: cert, key: key, certFile: certFile, keyFile: keyFile}
+}
+
+func startTLSTestServer(t *testing.T, creds credentials.TransportCredentials) string {
+	t.Helper()
+
+	listen, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	s := grpc.NewServer(grpc.Creds(creds))
+	grpc_health_v1.RegisterHealthServer(s, health.NewServer())
+	go s.Serve(listen)
+	t.Cleanup(s.Stop)
+
+	return listen.Addr().String()
+}
+
+func checkTLSConnection(t *testing.T, addr string, tlsConfig *tls.Config) error {
+	t.Helper()
+
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	_, err = grpc_health_v1.NewHealthClient(conn).Check(t.Context(), &grpc_health_v1.HealthCheckRequest{})
+	return err
+}
+
+func TestNewTransportCredentials(t *testing.T) {
+	ca := newTestCert(t, "ca", nil)
+	serverCert := newTestCert(t, "server", ca)
+	clientCert := newTestCert(t, "client", ca)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+	clientKeyPair, err := tls.LoadX509KeyPair(clientCert.certFile, clientCert.keyFile)
+	require.NoError(t, err)
+
+	newConfig := func() *server.Config {
+		var config server.Config
+		config.GRPC.TLS.Enabled = true
+		config.GRPC.TLS.CertFile = serverCert.certFile
+		config.GRPC.TLS.KeyFile = serverCert.keyFile
+		return &config
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		creds, err := NewTransportCredentials(&server.Config{})
+		require.NoError(t, err)
+		require.Equal(t, "insecure", creds.Info().SecurityProtocol)
+	})
+	t.Run("tls", func(t *testing.T) {
+		creds, err := NewTransportCredentials(newConfig())
+		require.NoError(t, err)
+		addr := startTLSTestServer(t, creds)
+
+		err = checkTLSConnection(t, addr, &tls.Config{RootCAs: roots, ServerName: "localhost"})
+		require.NoError(t, err)
+	})
+	t.Run("untrusted_server", func(t *testing.T) {
+		creds, err := NewTransportCredentials(newConfig())
+		require.NoError(t, err)
+		addr := startTLSTestServer(t, creds)
+
+		err = checkTLSConnection(t, addr, &tls.Config{RootCAs: x509.NewCertPool(), ServerName: "localhost"})
+		require.Error(t, err)
+	})
+	t.Run("mtls", func(t *testing.T) {
+		config := newConfig()
+		config.GRPC.TLS.ClientCAFile = ca.certFile
+		config.GRPC.TLS.RequireClientCert = true
+		creds, err := NewTransportCredentials(config)
+		require.NoError(t, err)
+		addr := startTLSTestServer(t, creds)
+
+		err = checkTLSConnection(t, addr, &tls.Config{
+			RootCAs:      roots,
+			ServerName:   "localhost",
+			Certificates: []tls.Certificate{clientKeyPair},
+		})
+		require.NoError(t, err)
+
+		err = checkTLSConnection(t, addr, &tls.Config{RootCAs: roots, ServerName: "localhost"})
+		require.Error(t, err)
+	})
+	t.Run("mtls_without_ca", func(t *testing.T) {
+		config := newConfig()
+		config.GRPC.TLS.RequireClientCert = true
+		_, err := NewTransportCredentials(config)
+		require.Error(t, err)
+	})
+	t.Run("missing_cert", func(t *testing.T) {
+		config := newConfig()
+		config.GRPC.TLS.CertFile = filepath.Join(t.TempDir(), "missing.crt")
+		_, err := NewTransportCredentials(config)
+		require.Error(t, err)
+	})
+}
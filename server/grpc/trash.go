@@ -0,0 +1,99 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"github.com/charmbracelet/log"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/mkolibaba/gophkeeper/proto/gen/go/gophkeeperv1"
+	"github.com/mkolibaba/gophkeeper/server"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type TrashServiceServer struct {
+	gophkeeperv1.UnimplementedTrashServiceServer
+	trashService server.TrashService
+	logger       *log.Logger
+}
+
+func NewTrashServiceServer(trashService server.TrashService, logger *log.Logger) *TrashServiceServer {
+	return &TrashServiceServer{
+		trashService: trashService,
+		logger:       logger,
+	}
+}
+
+func (s *TrashServiceServer) ListTrash(ctx context.Context, _ *empty.Empty) (*gophkeeperv1.ListTrashResponse, error) {
+	entries, err := s.trashService.List(ctx)
+	if err != nil {
+		s.logger.Error("failed to retrieve trash", "err", err)
+		return nil, status.Error(codes.Internal, "internal server error")
+	}
+
+	var items []*gophkeeperv1.TrashItem
+	for _, entry := range entries {
+		items = append(items, newTrashItemMessage(entry))
+	}
+
+	var out gophkeeperv1.ListTrashResponse
+	out.SetItems(items)
+
+	return &out, nil
+}
+
+func (s *TrashServiceServer) Restore(ctx context.Context, in *gophkeeperv1.TrashItemRequest) (*empty.Empty, error) {
+	kind, err := historyItem(in)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.trashService.Restore(ctx, kind, in.GetId()); err != nil {
+		return nil, s.trashError(err, "failed to restore data")
+	}
+
+	return &empty.Empty{}, nil
+}
+
+func (s *TrashServiceServer) Purge(ctx context.Context, in *gophkeeperv1.TrashItemRequest) (*empty.Empty, error) {
+	kind, err := historyItem(in)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.trashService.Purge(ctx, kind, in.GetId()); err != nil {
+		return nil, s.trashError(err, "failed to purge data")
+	}
+
+	return &empty.Empty{}, nil
+}
+
+func (s *TrashServiceServer) trashError(err error, msg string) error {
+	if errors.Is(err, server.ErrDataNotFound) {
+		return status.Error(codes.NotFound, "data not found")
+	}
+	s.logger.Error(msg, "err", err)
+	return status.Error(codes.Internal, "internal server error")
+}
+
+func newTrashItemMessage(entry server.TrashEntry) *gophkeeperv1.TrashItem {
+	var out gophkeeperv1.TrashItem
+	out.SetKind(dataKinds[entry.Kind])
+	out.SetId(entry.ID)
+	out.SetDeletedAt(timestamppb.New(entry.DeletedAt))
+
+	switch {
+	case entry.Login != nil:
+		out.SetLogin(newLoginMessage(*entry.Login))
+	case entry.Note != nil:
+		out.SetNote(newNoteMessage(*entry.Note))
+	case entry.Binary != nil:
+		out.SetBinary(newBinaryMessage(*entry.Binary))
+	case entry.Card != nil:
+		out.SetCard(newCardMessage(*entry.Card))
+	case entry.OTP != nil:
+		out.SetOtp(newOTPMessage(*entry.OTP))
+	}
+	return &out
+}
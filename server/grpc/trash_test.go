@@ -0,0 +1,157 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"github.com/charmbracelet/log"
+	"github.com/mkolibaba/gophkeeper/proto/gen/go/gophkeeperv1"
+	"github.com/mkolibaba/gophkeeper/server"
+	"github.com/mkolibaba/gophkeeper/server/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestListTrash(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		deletedAt := time.Unix(1700000000, 0)
+		trashService := &mock.TrashServiceMock{
+			ListFunc: func(ctx context.Context) ([]server.TrashEntry, error) {
+				return []server.TrashEntry{
+					{
+						Kind:      server.DataKindNote,
+						ID:        4,
+						DeletedAt: deletedAt,
+						Note:      &server.NoteData{ID: 4, Name: "note", Text: "text", Version: 2},
+					},
+					{
+						Kind:  server.DataKindLogin,
+						ID:    3,
+						Login: &server.LoginData{ID: 3, Name: "login", Password: "123", Version: 5},
+					},
+				}, nil
+			},
+		}
+		srv := createTrashServiceServer(t, trashService)
+
+		out, err := srv.ListTrash(t.Context(), nil)
+		require.NoError(t, err)
+		require.Len(t, out.GetItems(), 2)
+
+		latest := out.GetItems()[0]
+		require.Equal(t, gophkeeperv1.DataKind_DATA_KIND_NOTE, latest.GetKind())
+		require.Equal(t, int64(4), latest.GetId())
+		require.Equal(t, deletedAt, latest.GetDeletedAt().AsTime().Local())
+		require.Equal(t, "text", latest.GetNote().GetText())
+		require.Equal(t, "123", out.GetItems()[1].GetLogin().GetPassword())
+	})
+	t.Run("db_error", func(t *testing.T) {
+		trashService := &mock.TrashServiceMock{
+			ListFunc: func(ctx context.Context) ([]server.TrashEntry, error) {
+				return nil, errors.New("some error")
+			},
+		}
+		srv := createTrashServiceServer(t, trashService)
+
+		_, err := srv.ListTrash(t.Context(), nil)
+		requireGrpcError(t, err, codes.Internal)
+	})
+}
+
+func TestTrashRestore(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		trashService := &mock.TrashServiceMock{
+			RestoreFunc: func(ctx context.Context, kind server.DataKind, id int64) error {
+				return nil
+			},
+		}
+		srv := createTrashServiceServer(t, trashService)
+
+		_, err := srv.Restore(t.Context(), newTrashItemRequest(gophkeeperv1.DataKind_DATA_KIND_CARD, 3))
+		require.NoError(t, err)
+		require.Len(t, trashService.RestoreCalls(), 1)
+		call := trashService.RestoreCalls()[0]
+		require.Equal(t, server.DataKindCard, call.Kind)
+		require.Equal(t, int64(3), call.ID)
+	})
+	t.Run("unknown_kind", func(t *testing.T) {
+		srv := createTrashServiceServer(t, &mock.TrashServiceMock{})
+
+		_, err := srv.Restore(t.Context(), newTrashItemRequest(gophkeeperv1.DataKind_DATA_KIND_UNSPECIFIED, 3))
+		requireGrpcError(t, err, codes.InvalidArgument)
+	})
+
+	tests := []struct {
+		name string
+		err  error
+		code codes.Code
+	}{
+		{name: "not_found", err: server.ErrDataNotFound, code: codes.NotFound},
+		{name: "db_error", err: errors.New("some error"), code: codes.Internal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trashService := &mock.TrashServiceMock{
+				RestoreFunc: func(ctx context.Context, kind server.DataKind, id int64) error {
+					return tt.err
+				},
+			}
+			srv := createTrashServiceServer(t, trashService)
+
+			_, err := srv.Restore(t.Context(), newTrashItemRequest(gophkeeperv1.DataKind_DATA_KIND_NOTE, 3))
+			requireGrpcError(t, err, tt.code)
+		})
+	}
+}
+
+func TestTrashPurge(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		trashService := &mock.TrashServiceMock{
+			PurgeFunc: func(ctx context.Context, kind server.DataKind, id int64) error {
+				return nil
+			},
+		}
+		srv := createTrashServiceServer(t, trashService)
+
+		_, err := srv.Purge(t.Context(), newTrashItemRequest(gophkeeperv1.DataKind_DATA_KIND_BINARY, 7))
+		require.NoError(t, err)
+		require.Len(t, trashService.PurgeCalls(), 1)
+		call := trashService.PurgeCalls()[0]
+		require.Equal(t, server.DataKindBinary, call.Kind)
+		require.Equal(t, int64(7), call.ID)
+	})
+	t.Run("no_id", func(t *testing.T) {
+		srv := createTrashServiceServer(t, &mock.TrashServiceMock{})
+
+		var in gophkeeperv1.TrashItemRequest
+		in.SetKind(gophkeeperv1.DataKind_DATA_KIND_NOTE)
+
+		_, err := srv.Purge(t.Context(), &in)
+		requireGrpcError(t, err, codes.InvalidArgument)
+	})
+	t.Run("not_found", func(t *testing.T) {
+		trashService := &mock.TrashServiceMock{
+			PurgeFunc: func(ctx context.Context, kind server.DataKind, id int64) error {
+				return server.ErrDataNotFound
+			},
+		}
+		srv := createTrashServiceServer(t, trashService)
+
+		_, err := srv.Purge(t.Context(), newTrashItemRequest(gophkeeperv1.DataKind_DATA_KIND_NOTE, 3))
+		requireGrpcError(t, err, codes.NotFound)
+	})
+}
+
+func newTrashItemRequest(kind gophkeeperv1.DataKind, id int64) *gophkeeperv1.TrashItemRequest {
+	var in gophkeeperv1.TrashItemRequest
+	in.SetKind(kind)
+	in.SetId(id)
+	return &in
+}
+
+func createTrashServiceServer(t *testing.T, trashService server.TrashService) *TrashServiceServer {
+	t.Helper()
+	return NewTrashServiceServer(trashService, log.New(io.Discard))
+}
@@ -0,0 +1,56 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	ErrVersionNotFound = errors.New("version not found")
+	// ErrContentRemoved возвращается при восстановлении удаленных бинарных
+	// данных: история хранит только их описание, содержимое удаляется
+	// вместе с записью.
+	ErrContentRemoved = errors.New("binary content was removed")
+)
+
+// History - ограничения хранения прежних версий записей. Нулевое
+// ограничение не действует.
+type History struct {
+	// MaxVersions - число хранимых версий одной записи.
+	MaxVersions int64 `mapstructure:"max_versions"`
+	// MaxAge - срок хранения версии.
+	MaxAge time.Duration `mapstructure:"max_age"`
+}
+
+// HistoryEntry - прежняя версия записи. Заполнено поле данных, которое
+// соответствует Kind.
+type HistoryEntry struct {
+	Kind DataKind
+	ID   int64
+	// Version - версия записи до изменения.
+	Version int64
+	// Removed - версия сохранена при удалении записи.
+	Removed bool
+	// CreatedAt - время изменения, заменившего версию.
+	CreatedAt time.Time
+
+	Login  *LoginData
+	Note   *NoteData
+	Binary *BinaryData
+	Card   *CardData
+	OTP    *OTPData
+}
+
+// HistoryService - сервис прежних версий записей. Каждое изменение
+// и удаление записи сохраняет ее версию до изменения. Работать с историей
+// может только владелец записи.
+type HistoryService interface {
+	// List возвращает прежние версии записи от новых к старым.
+	List(ctx context.Context, kind DataKind, id int64) ([]HistoryEntry, error)
+
+	// Restore восстанавливает версию записи. Существующая запись
+	// обновляется, и ее текущая версия тоже попадает в историю. Удаленная
+	// запись создается заново с новым id.
+	Restore(ctx context.Context, kind DataKind, id int64, version int64) error
+}
@@ -2,28 +2,236 @@ package jwt
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/mkolibaba/gophkeeper/server"
+	"strings"
 	"time"
 )
 
+const issuer = "gophkeeper"
+
+const (
+	// challengeAudience отличает токены входа от access-токенов.
+	challengeAudience = "mfa"
+	// challengeTTL - время на ввод второго фактора.
+	challengeTTL = 5 * time.Minute
+)
+
 type AuthorizationService struct {
-	secret string
-	ttl    time.Duration
+	secret     string
+	ttl        time.Duration
+	refreshTTL time.Duration
+	sessions   server.SessionService
+	now        func() time.Time
 }
 
-func NewAuthorizationService(config *server.Config) *AuthorizationService {
+func NewAuthorizationService(config *server.Config, sessions server.SessionService) *AuthorizationService {
 	return &AuthorizationService{
-		secret: config.JWT.Secret,
-		ttl:    config.JWT.TTL,
+		secret:     config.JWT.Secret,
+		ttl:        config.JWT.TTL,
+		refreshTTL: config.JWT.RefreshTTL,
+		sessions:   sessions,
+		now:        time.Now,
+	}
+}
+
+func (s *AuthorizationService) Authorize(ctx context.Context, login string) (server.Tokens, error) {
+	now := s.now()
+
+	// Заодно удаляем истекшие сессии, чтобы они не копились.
+	if err := s.sessions.RemoveExpired(ctx, login, now); err != nil {
+		return server.Tokens{}, err
+	}
+
+	id, err := newSessionID()
+	if err != nil {
+		return server.Tokens{}, err
+	}
+	refreshToken, hash, err := newRefreshToken(id)
+	if err != nil {
+		return server.Tokens{}, err
+	}
+
+	err = s.sessions.Create(ctx, server.Session{
+		ID:               id,
+		Login:            login,
+		RefreshTokenHash: hash,
+		CreatedAt:        now,
+		LastUsedAt:       now,
+		ExpiresAt:        now.Add(s.refreshTTL),
+	})
+	if err != nil {
+		return server.Tokens{}, err
+	}
+
+	accessToken, err := s.newAccessToken(login, id, now)
+	if err != nil {
+		return server.Tokens{}, err
+	}
+
+	return server.Tokens{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+func (s *AuthorizationService) Refresh(ctx context.Context, refreshToken string) (server.Tokens, error) {
+	id, _, ok := strings.Cut(refreshToken, ".")
+	if !ok {
+		return server.Tokens{}, server.ErrInvalidToken
+	}
+
+	session, err := s.sessions.Get(ctx, id)
+	if errors.Is(err, server.ErrSessionNotFound) {
+		return server.Tokens{}, server.ErrInvalidToken
+	}
+	if err != nil {
+		return server.Tokens{}, err
+	}
+
+	now := s.now()
+	hash := hashToken(refreshToken)
+	if subtle.ConstantTimeCompare(hash, session.RefreshTokenHash) != 1 || !now.Before(session.ExpiresAt) {
+		// Токен уже использован или истек. Повторное использование токена
+		// означает, что он мог быть украден, поэтому сессия завершается.
+		if err := s.sessions.Remove(ctx, session.Login, id); err != nil && !errors.Is(err, server.ErrSessionNotFound) {
+			return server.Tokens{}, err
+		}
+		return server.Tokens{}, server.ErrInvalidToken
+	}
+
+	newToken, newHash, err := newRefreshToken(id)
+	if err != nil {
+		return server.Tokens{}, err
+	}
+
+	session.RefreshTokenHash = newHash
+	session.LastUsedAt = now
+	session.ExpiresAt = now.Add(s.refreshTTL)
+
+	err = s.sessions.Rotate(ctx, id, hash, *session)
+	if errors.Is(err, server.ErrSessionNotFound) {
+		// Токен одновременно обменян другим запросом.
+		return server.Tokens{}, server.ErrInvalidToken
+	}
+	if err != nil {
+		return server.Tokens{}, err
+	}
+
+	accessToken, err := s.newAccessToken(session.Login, id, now)
+	if err != nil {
+		return server.Tokens{}, err
 	}
+
+	return server.Tokens{
+		AccessToken:  accessToken,
+		RefreshToken: newToken,
+	}, nil
+}
+
+func (s *AuthorizationService) Verify(ctx context.Context, accessToken string) (string, string, error) {
+	claims, err := s.parse(accessToken)
+	if err != nil {
+		return "", "", err
+	}
+	// У токенов входа нет сессии, поэтому как access-токены они не принимаются.
+	if claims.Subject == "" || claims.ID == "" {
+		return "", "", server.ErrInvalidToken
+	}
+
+	// Access-токен действителен, только пока существует его сессия:
+	// так отзыв сессии действует сразу, а не после истечения токена.
+	session, err := s.sessions.Get(ctx, claims.ID)
+	if errors.Is(err, server.ErrSessionNotFound) {
+		return "", "", fmt.Errorf("%w: session is revoked", server.ErrInvalidToken)
+	}
+	if err != nil {
+		return "", "", err
+	}
+	if session.Login != claims.Subject {
+		return "", "", server.ErrInvalidToken
+	}
+
+	return claims.Subject, claims.ID, nil
 }
 
-func (s *AuthorizationService) Authorize(ctx context.Context, login string) (string, error) {
+func (s *AuthorizationService) Challenge(ctx context.Context, login string) (string, error) {
+	now := s.now()
 	return jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
 		Subject:   login,
-		Issuer:    "gophkeeper",
-		IssuedAt:  jwt.NewNumericDate(time.Now()),
-		ExpiresAt: jwt.NewNumericDate(time.Now().Add(s.ttl)),
+		Issuer:    issuer,
+		Audience:  jwt.ClaimStrings{challengeAudience},
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(now.Add(challengeTTL)),
 	}).SignedString([]byte(s.secret))
 }
+
+func (s *AuthorizationService) VerifyChallenge(ctx context.Context, challenge string) (string, error) {
+	claims, err := s.parse(challenge, jwt.WithAudience(challengeAudience))
+	if err != nil {
+		return "", err
+	}
+	if claims.Subject == "" {
+		return "", server.ErrInvalidToken
+	}
+	return claims.Subject, nil
+}
+
+func (s *AuthorizationService) parse(token string, opts ...jwt.ParserOption) (*jwt.RegisteredClaims, error) {
+	var claims jwt.RegisteredClaims
+	opts = append(opts, jwt.WithTimeFunc(s.now), jwt.WithIssuer(issuer))
+	parsed, err := jwt.ParseWithClaims(token, &claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("jwt: unexpected signing method")
+		}
+		return []byte(s.secret), nil
+	}, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", server.ErrInvalidToken, err)
+	}
+	if !parsed.Valid {
+		return nil, server.ErrInvalidToken
+	}
+	return &claims, nil
+}
+
+func (s *AuthorizationService) newAccessToken(login string, sessionID string, now time.Time) (string, error) {
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+		ID:        sessionID,
+		Subject:   login,
+		Issuer:    issuer,
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(now.Add(s.ttl)),
+	}).SignedString([]byte(s.secret))
+}
+
+// newRefreshToken возвращает refresh-токен сессии и его хэш. Токен содержит
+// идентификатор сессии, чтобы по нему можно было найти сессию.
+func newRefreshToken(sessionID string) (string, []byte, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", nil, fmt.Errorf("new refresh token: %w", err)
+	}
+	token := sessionID + "." + base64.RawURLEncoding.EncodeToString(secret)
+	return token, hashToken(token), nil
+}
+
+func hashToken(token string) []byte {
+	hash := sha256.Sum256([]byte(token))
+	return hash[:]
+}
+
+func newSessionID() (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", fmt.Errorf("new session id: %w", err)
+	}
+	return hex.EncodeToString(id), nil
+}
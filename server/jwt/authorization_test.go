@@ -1,37 +1,160 @@
 package jwt
 
 import (
-	"fmt"
-	"github.com/golang-jwt/jwt/v5"
+	"bytes"
+	"context"
 	"github.com/mkolibaba/gophkeeper/server"
+	"github.com/mkolibaba/gophkeeper/server/mock"
 	"github.com/stretchr/testify/require"
 	"testing"
 	"time"
 )
 
 func TestAuthorize(t *testing.T) {
-	service := NewAuthorizationService(&server.Config{
-		JWT: struct {
-			Secret string
-			TTL    time.Duration
-		}{
-			Secret: "jwtsecret",
-			TTL:    1 * time.Hour,
-		}})
+	service, sessions := newTestService(t)
 
-	tokenString, err := service.Authorize(t.Context(), "testuser")
+	tokens, err := service.Authorize(t.Context(), "testuser")
 	require.NoError(t, err)
-	require.NotEmpty(t, tokenString)
+	require.NotEmpty(t, tokens.AccessToken)
+	require.NotEmpty(t, tokens.RefreshToken)
+	require.Len(t, sessions, 1)
 
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("jwt: unexpected signing method")
-		}
-		return []byte("jwtsecret"), nil
-	})
+	login, sessionID, err := service.Verify(t.Context(), tokens.AccessToken)
 	require.NoError(t, err)
+	require.Equal(t, "testuser", login)
+	require.Contains(t, sessions, sessionID)
 
-	subject, err := token.Claims.GetSubject()
+	_, _, err = service.Verify(t.Context(), tokens.AccessToken+"x")
+	require.ErrorIs(t, err, server.ErrInvalidToken)
+}
+
+func TestVerify_Expired(t *testing.T) {
+	service, _ := newTestService(t)
+
+	tokens, err := service.Authorize(t.Context(), "testuser")
+	require.NoError(t, err)
+
+	service.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+
+	_, _, err = service.Verify(t.Context(), tokens.AccessToken)
+	require.ErrorIs(t, err, server.ErrInvalidToken)
+}
+
+func TestVerify_RevokedSession(t *testing.T) {
+	service, sessions := newTestService(t)
+
+	tokens, err := service.Authorize(t.Context(), "testuser")
+	require.NoError(t, err)
+
+	clear(sessions)
+
+	_, _, err = service.Verify(t.Context(), tokens.AccessToken)
+	require.ErrorIs(t, err, server.ErrInvalidToken)
+}
+
+func TestRefresh(t *testing.T) {
+	service, sessions := newTestService(t)
+
+	tokens, err := service.Authorize(t.Context(), "testuser")
+	require.NoError(t, err)
+
+	refreshed, err := service.Refresh(t.Context(), tokens.RefreshToken)
 	require.NoError(t, err)
-	require.Equal(t, "testuser", subject)
+	require.NotEqual(t, tokens.RefreshToken, refreshed.RefreshToken)
+
+	login, _, err := service.Verify(t.Context(), refreshed.AccessToken)
+	require.NoError(t, err)
+	require.Equal(t, "testuser", login)
+
+	// Повторное использование токена завершает сессию.
+	_, err = service.Refresh(t.Context(), tokens.RefreshToken)
+	require.ErrorIs(t, err, server.ErrInvalidToken)
+	require.Empty(t, sessions)
+
+	_, err = service.Refresh(t.Context(), refreshed.RefreshToken)
+	require.ErrorIs(t, err, server.ErrInvalidToken)
+}
+
+func TestRefresh_Expired(t *testing.T) {
+	service, sessions := newTestService(t)
+
+	tokens, err := service.Authorize(t.Context(), "testuser")
+	require.NoError(t, err)
+
+	service.now = func() time.Time { return time.Now().Add(48 * time.Hour) }
+
+	_, err = service.Refresh(t.Context(), tokens.RefreshToken)
+	require.ErrorIs(t, err, server.ErrInvalidToken)
+	require.Empty(t, sessions)
+
+	_, err = service.Refresh(t.Context(), "malformed")
+	require.ErrorIs(t, err, server.ErrInvalidToken)
+}
+
+func TestChallenge(t *testing.T) {
+	service, _ := newTestService(t)
+
+	challenge, err := service.Challenge(t.Context(), "testuser")
+	require.NoError(t, err)
+
+	login, err := service.VerifyChallenge(t.Context(), challenge)
+	require.NoError(t, err)
+	require.Equal(t, "testuser", login)
+
+	// Токен входа не заменяет access-токен, и наоборот.
+	_, _, err = service.Verify(t.Context(), challenge)
+	require.ErrorIs(t, err, server.ErrInvalidToken)
+
+	tokens, err := service.Authorize(t.Context(), "testuser")
+	require.NoError(t, err)
+	_, err = service.VerifyChallenge(t.Context(), tokens.AccessToken)
+	require.ErrorIs(t, err, server.ErrInvalidToken)
+
+	service.now = func() time.Time { return time.Now().Add(10 * time.Minute) }
+	_, err = service.VerifyChallenge(t.Context(), challenge)
+	require.ErrorIs(t, err, server.ErrInvalidToken)
+}
+
+func newTestService(t *testing.T) (*AuthorizationService, map[string]server.Session) {
+	t.Helper()
+
+	var config server.Config
+	config.JWT.Secret = "jwtsecret"
+	config.JWT.TTL = time.Hour
+	config.JWT.RefreshTTL = 24 * time.Hour
+
+	sessions := map[string]server.Session{}
+	sessionService := &mock.SessionServiceMock{
+		CreateFunc: func(ctx context.Context, session server.Session) error {
+			sessions[session.ID] = session
+			return nil
+		},
+		GetFunc: func(ctx context.Context, id string) (*server.Session, error) {
+			session, ok := sessions[id]
+			if !ok {
+				return nil, server.ErrSessionNotFound
+			}
+			return &session, nil
+		},
+		RotateFunc: func(ctx context.Context, id string, oldHash []byte, session server.Session) error {
+			current, ok := sessions[id]
+			if !ok || !bytes.Equal(current.RefreshTokenHash, oldHash) {
+				return server.ErrSessionNotFound
+			}
+			sessions[id] = session
+			return nil
+		},
+		RemoveFunc: func(ctx context.Context, login string, id string) error {
+			if _, ok := sessions[id]; !ok {
+				return server.ErrSessionNotFound
+			}
+			delete(sessions, id)
+			return nil
+		},
+		RemoveExpiredFunc: func(ctx context.Context, login string, now time.Time) error {
+			return nil
+		},
+	}
+
+	return NewAuthorizationService(&config, sessionService), sessions
 }
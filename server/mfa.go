@@ -0,0 +1,40 @@
+package server
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	ErrInvalidMFACode       = errors.New("invalid mfa code")
+	ErrMFANotSetUp          = errors.New("mfa is not set up")
+	ErrMFAAlreadyEnabled    = errors.New("mfa is already enabled")
+	ErrRecoveryCodeNotFound = errors.New("recovery code not found")
+)
+
+// MFASetup - новый секрет второго фактора для приложения-аутентификатора.
+type MFASetup struct {
+	// Secret - секрет TOTP в base32.
+	Secret string
+	// URI - URI otpauth:// с секретом, обычно показывается QR-кодом.
+	URI string
+}
+
+// MFAService представляет второй фактор аутентификации на основе TOTP.
+type MFAService interface {
+	// Setup создает новый секрет пользователя. Второй фактор включается
+	// только после подтверждения кодом в Enable. Если второй фактор уже
+	// включен, возвращает ErrMFAAlreadyEnabled.
+	Setup(ctx context.Context, login string) (MFASetup, error)
+
+	// Enable проверяет код TOTP, включает второй фактор и возвращает
+	// новые коды восстановления.
+	Enable(ctx context.Context, login string, code string) ([]string, error)
+
+	// Disable проверяет код и выключает второй фактор.
+	Disable(ctx context.Context, login string, code string) error
+
+	// Verify проверяет код TOTP или код восстановления. Каждый код
+	// принимается только один раз.
+	Verify(ctx context.Context, login string, code string) error
+}
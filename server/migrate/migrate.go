@@ -0,0 +1,521 @@
+// Package migrate применяет версионированные миграции схемы базы данных.
+// Миграция - пара скриптов {version}_{name}.up.sql и {version}_{name}.down.sql,
+// обратный скрипт необязателен: без него миграция необратима. Примененные
+// версии хранятся в таблице schema_migration вместе с контрольными суммами
+// скриптов, поэтому измененный после применения скрипт обнаруживается.
+package migrate
+
+import (
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/charmbracelet/log"
+	"io/fs"
+	"maps"
+	"math"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// Latest - последняя известная версия схемы.
+	Latest int64 = math.MaxInt64
+	// Initial - схема без примененных миграций.
+	Initial int64 = -1
+)
+
+var (
+	ErrUnknownVersion = errors.New("unknown migration version")
+	ErrIrreversible   = errors.New("migration is irreversible")
+)
+
+// ChecksumError возвращается, если скрипт примененной миграции изменился.
+type ChecksumError struct {
+	Version int64
+	Name    string
+}
+
+func (e *ChecksumError) Error() string {
+	return fmt.Sprintf("migration %d %q was modified after it was applied", e.Version, e.Name)
+}
+
+// Migration - переход схемы на версию Version.
+type Migration struct {
+	Version int64
+	Name    string
+	// Up и Down - скрипты применения и отмены. Down пуст, если миграция
+	// необратима.
+	Up   string
+	Down string
+}
+
+// Checksum возвращает контрольную сумму скриптов применения и отмены.
+func (m Migration) Checksum() string {
+	h := sha256.New()
+	h.Write([]byte(m.Up))
+	// Разделитель не встречается в тексте скриптов: без него перенос
+	// строк из одного скрипта в другой не менял бы суммы.
+	h.Write([]byte{0})
+	h.Write([]byte(m.Down))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Step - применение или отмена одной миграции.
+type Step struct {
+	Migration
+	Down bool
+}
+
+// SQL возвращает скрипт шага.
+func (s Step) SQL() string {
+	if s.Down {
+		return s.Migration.Down
+	}
+	return s.Migration.Up
+}
+
+func (s Step) String() string {
+	direction := "up"
+	if s.Down {
+		direction = "down"
+	}
+	return fmt.Sprintf("%d_%s (%s)", s.Version, s.Name, direction)
+}
+
+// Status - состояние миграции в базе данных.
+type Status struct {
+	Version int64
+	Name    string
+	Applied bool
+	// AppliedAt - время применения, если миграция применена.
+	AppliedAt time.Time
+	// Modified - скрипт изменился после применения.
+	Modified bool
+	// Unknown - миграция применена, но ее скриптов нет, например база
+	// данных обновлена более новой версией сервера.
+	Unknown bool
+	// Reversible - у миграции есть скрипт отмены.
+	Reversible bool
+}
+
+var fileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// Load читает миграции из каталога dir файловой системы fsys.
+func Load(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, fmt.Errorf("load: %w", err)
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		match := fileName.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			return nil, fmt.Errorf("load: unexpected file %q", entry.Name())
+		}
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("load: %q: %w", entry.Name(), err)
+		}
+		script, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("load: %w", err)
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		}
+		if migration.Name != match[2] {
+			return nil, fmt.Errorf("load: version %d has two names: %q and %q", version, migration.Name, match[2])
+		}
+		if match[3] == "up" {
+			migration.Up = string(script)
+		} else {
+			migration.Down = string(script)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" {
+			return nil, fmt.Errorf("load: migration %d %q has no up script", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	slices.SortFunc(migrations, func(a, b Migration) int {
+		return cmp.Compare(a.Version, b.Version)
+	})
+	return migrations, nil
+}
+
+// Dialect - особенности базы данных.
+type Dialect struct {
+	// Placeholder возвращает обозначение n-го параметра запроса, начиная с 1.
+	Placeholder func(n int) string
+	// TableExists - запрос числа таблиц с именем из первого параметра.
+	TableExists string
+	// Lock - запрос, захватывающий блокировку миграций до конца
+	// транзакции. Пуст, если база данных блокируется транзакцией целиком.
+	Lock string
+}
+
+// Migrator приводит схему базы данных к нужной версии. Каждый запуск
+// выполняется в одной транзакции: при ошибке схема остается прежней.
+type Migrator struct {
+	db         *sql.DB
+	dialect    Dialect
+	migrations []Migration
+	logger     *log.Logger
+	now        func() time.Time
+}
+
+func New(db *sql.DB, dialect Dialect, migrations []Migration, logger *log.Logger) *Migrator {
+	return &Migrator{
+		db:         db,
+		dialect:    dialect,
+		migrations: migrations,
+		logger:     logger,
+		now:        time.Now,
+	}
+}
+
+// applied - запись о примененной миграции.
+type applied struct {
+	name      string
+	checksum  string
+	appliedAt int64
+}
+
+// Status возвращает состояние известных и примененных миграций
+// в порядке версий.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	var result []Status
+	err := m.inTx(ctx, false, func(tx *sql.Tx, records map[int64]applied) error {
+		for _, migration := range m.migrations {
+			status := Status{
+				Version:    migration.Version,
+				Name:       migration.Name,
+				Reversible: migration.Down != "",
+			}
+			if record, ok := records[migration.Version]; ok {
+				status.Applied = true
+				status.AppliedAt = time.Unix(record.appliedAt, 0)
+				status.Modified = record.checksum != migration.Checksum()
+			}
+			result = append(result, status)
+		}
+		for version, record := range records {
+			if m.find(version) == nil {
+				result = append(result, Status{
+					Version:   version,
+					Name:      record.name,
+					Applied:   true,
+					AppliedAt: time.Unix(record.appliedAt, 0),
+					Unknown:   true,
+				})
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("status: %w", err)
+	}
+
+	slices.SortFunc(result, func(a, b Status) int {
+		return cmp.Compare(a.Version, b.Version)
+	})
+	return result, nil
+}
+
+// Up применяет все непримененные миграции.
+func (m *Migrator) Up(ctx context.Context, dryRun bool) ([]Step, error) {
+	steps, err := m.migrate(ctx, dryRun, func(map[int64]applied) (int64, error) {
+		return Latest, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("up: %w", err)
+	}
+	return steps, nil
+}
+
+// Down отменяет последнюю примененную миграцию.
+func (m *Migrator) Down(ctx context.Context, dryRun bool) ([]Step, error) {
+	steps, err := m.migrate(ctx, dryRun, func(records map[int64]applied) (int64, error) {
+		versions := slices.Sorted(maps.Keys(records))
+		if len(versions) < 2 {
+			return Initial, nil
+		}
+		return versions[len(versions)-2], nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("down: %w", err)
+	}
+	return steps, nil
+}
+
+// To применяет или отменяет миграции так, чтобы последней примененной
+// стала версия version. Initial отменяет все миграции.
+func (m *Migrator) To(ctx context.Context, version int64, dryRun bool) ([]Step, error) {
+	if version != Initial && m.find(version) == nil {
+		return nil, fmt.Errorf("to: %w: %d", ErrUnknownVersion, version)
+	}
+	steps, err := m.migrate(ctx, dryRun, func(map[int64]applied) (int64, error) {
+		return version, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("to: %w", err)
+	}
+	return steps, nil
+}
+
+// migrate планирует и выполняет шаги к версии, которую target выбирает
+// по примененным миграциям. В режиме dryRun шаги только планируются.
+func (m *Migrator) migrate(
+	ctx context.Context,
+	dryRun bool,
+	target func(map[int64]applied) (int64, error),
+) ([]Step, error) {
+	var steps []Step
+	err := m.inTx(ctx, !dryRun, func(tx *sql.Tx, records map[int64]applied) error {
+		if err := m.verify(records); err != nil {
+			return err
+		}
+		version, err := target(records)
+		if err != nil {
+			return err
+		}
+		if steps, err = m.plan(records, version); err != nil {
+			return err
+		}
+		if dryRun {
+			return nil
+		}
+
+		for _, step := range steps {
+			if err := m.apply(ctx, tx, step); err != nil {
+				return fmt.Errorf("migration %s: %w", step, err)
+			}
+		}
+		return nil
+	})
+	return steps, err
+}
+
+// verify проверяет, что все примененные миграции известны и не изменились.
+func (m *Migrator) verify(records map[int64]applied) error {
+	for version, record := range records {
+		migration := m.find(version)
+		if migration == nil {
+			return fmt.Errorf("%w: %d %q is applied to the database", ErrUnknownVersion, version, record.name)
+		}
+		if record.checksum != migration.Checksum() {
+			return &ChecksumError{Version: version, Name: migration.Name}
+		}
+	}
+	return nil
+}
+
+// plan возвращает шаги к версии version: применение неприменных миграций
+// до нее включительно или отмену примененных после нее в обратном порядке.
+func (m *Migrator) plan(records map[int64]applied, version int64) ([]Step, error) {
+	var steps []Step
+	for _, migration := range m.migrations {
+		if _, ok := records[migration.Version]; !ok && migration.Version <= version {
+			steps = append(steps, Step{Migration: migration})
+		}
+	}
+	for _, migration := range slices.Backward(m.migrations) {
+		if _, ok := records[migration.Version]; ok && migration.Version > version {
+			if migration.Down == "" {
+				return nil, fmt.Errorf("%w: %d %q", ErrIrreversible, migration.Version, migration.Name)
+			}
+			steps = append(steps, Step{Migration: migration, Down: true})
+		}
+	}
+	return steps, nil
+}
+
+func (m *Migrator) apply(ctx context.Context, tx *sql.Tx, step Step) error {
+	m.logger.Info("running migration script", "version", step.Version, "name", step.Name, "down", step.Down)
+
+	if _, err := tx.ExecContext(ctx, step.SQL()); err != nil {
+		return err
+	}
+
+	var err error
+	if step.Down {
+		_, err = tx.ExecContext(ctx, m.rebind(`DELETE FROM schema_migration WHERE version = ?`), step.Version)
+	} else {
+		_, err = tx.ExecContext(ctx,
+			m.rebind(`INSERT INTO schema_migration (version, name, checksum, applied_at) VALUES (?, ?, ?, ?)`),
+			step.Version, step.Name, step.Checksum(), m.now().Unix(),
+		)
+	}
+	if err != nil {
+		return err
+	}
+
+	m.logger.Info("migration script run done", "version", step.Version, "name", step.Name, "down", step.Down)
+	return nil
+}
+
+// inTx выполняет fn в транзакции под блокировкой миграций и передает
+// ей примененные миграции. Если commit ложен, транзакция откатывается.
+func (m *Migrator) inTx(ctx context.Context, commit bool, fn func(*sql.Tx, map[int64]applied) error) error {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if m.dialect.Lock != "" {
+		if _, err := tx.ExecContext(ctx, m.dialect.Lock); err != nil {
+			return fmt.Errorf("cannot lock migrations: %w", err)
+		}
+	}
+	if err := m.prepare(ctx, tx); err != nil {
+		return err
+	}
+
+	records, err := m.applied(ctx, tx)
+	if err != nil {
+		return err
+	}
+	if err := fn(tx, records); err != nil {
+		return err
+	}
+
+	if !commit {
+		return nil
+	}
+	return tx.Commit()
+}
+
+// prepare создает таблицу миграций и переносит в нее миграции, примененные
+// прежней версией сервера: она хранила в таблице migration только имена
+// файлов вида migration/{version}_{name}.sql.
+func (m *Migrator) prepare(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migration
+(
+    version    BIGINT PRIMARY KEY,
+    name       TEXT   NOT NULL,
+    checksum   TEXT   NOT NULL,
+    applied_at BIGINT NOT NULL
+)`)
+	if err != nil {
+		return fmt.Errorf("cannot create migrations table: %w", err)
+	}
+
+	var n int
+	if err := tx.QueryRowContext(ctx, m.rebind(m.dialect.TableExists), "migration").Scan(&n); err != nil {
+		return err
+	}
+	if n == 0 {
+		return nil
+	}
+
+	names, err := legacyNames(ctx, tx)
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		match := fileName.FindStringSubmatch(strings.TrimSuffix(path.Base(name), ".sql") + ".up.sql")
+		if match == nil {
+			return fmt.Errorf("unexpected legacy migration %q", name)
+		}
+		version, _ := strconv.ParseInt(match[1], 10, 64)
+		migration := m.find(version)
+		if migration == nil {
+			return fmt.Errorf("%w: legacy migration %q", ErrUnknownVersion, name)
+		}
+
+		// Прежняя версия не хранила контрольных сумм: считаем, что
+		// примененные скрипты не менялись.
+		_, err = tx.ExecContext(ctx,
+			m.rebind(`INSERT INTO schema_migration (version, name, checksum, applied_at) VALUES (?, ?, ?, ?)`),
+			migration.Version, migration.Name, migration.Checksum(), m.now().Unix(),
+		)
+		if err != nil {
+			return err
+		}
+	}
+	m.logger.Info("legacy migrations imported", "count", len(names))
+
+	_, err = tx.ExecContext(ctx, `DROP TABLE migration`)
+	return err
+}
+
+func legacyNames(ctx context.Context, tx *sql.Tx) ([]string, error) {
+	rows, err := tx.QueryContext(ctx, `SELECT name FROM migration`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+	return names, rows.Err()
+}
+
+func (m *Migrator) applied(ctx context.Context, tx *sql.Tx) (map[int64]applied, error) {
+	rows, err := tx.QueryContext(ctx, `SELECT version, name, checksum, applied_at FROM schema_migration`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	records := make(map[int64]applied)
+	for rows.Next() {
+		var version int64
+		var record applied
+		if err := rows.Scan(&version, &record.name, &record.checksum, &record.appliedAt); err != nil {
+			return nil, err
+		}
+		records[version] = record
+	}
+	return records, rows.Err()
+}
+
+func (m *Migrator) find(version int64) *Migration {
+	for i := range m.migrations {
+		if m.migrations[i].Version == version {
+			return &m.migrations[i]
+		}
+	}
+	return nil
+}
+
+// rebind заменяет параметры ? обозначениями диалекта.
+func (m *Migrator) rebind(query string) string {
+	if m.dialect.Placeholder == nil {
+		return query
+	}
+
+	var b strings.Builder
+	n := 0
+	for _, r := range query {
+		if r == '?' {
+			n++
+			b.WriteString(m.dialect.Placeholder(n))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,276 @@
+package migrate
+
+import (
+	"database/sql"
+	"github.com/charmbracelet/log"
+	"github.com/stretchr/testify/require"
+	"io"
+	_ "modernc.org/sqlite"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+)
+
+var sqlite = Dialect{
+	TableExists: `SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?`,
+}
+
+func testFS() fstest.MapFS {
+	return fstest.MapFS{
+		"migration/00_init.up.sql":         {Data: []byte(`CREATE TABLE a (id INTEGER);`)},
+		"migration/00_init.down.sql":       {Data: []byte(`DROP TABLE a;`)},
+		"migration/01_b.up.sql":            {Data: []byte(`CREATE TABLE b (id INTEGER);`)},
+		"migration/01_b.down.sql":          {Data: []byte(`DROP TABLE b;`)},
+		"migration/02_c.up.sql":            {Data: []byte(`CREATE TABLE c (id INTEGER);`)},
+		"migration/02_c.down.sql":          {Data: []byte(`DROP TABLE c;`)},
+		"migration/03_final.up.sql":        {Data: []byte(`ALTER TABLE c ADD COLUMN name TEXT;`)},
+		"migration/10_irreversible.up.sql": {Data: []byte(`DROP TABLE b;`)},
+	}
+}
+
+func newTestMigrator(t *testing.T, fsys fstest.MapFS) (*Migrator, *sql.DB) {
+	t.Helper()
+
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "db.sqlite"))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		db.Close()
+	})
+
+	migrations, err := Load(fsys, "migration")
+	require.NoError(t, err)
+	return New(db, sqlite, migrations, log.New(io.Discard)), db
+}
+
+func requireTables(t *testing.T, db *sql.DB, tables ...string) {
+	t.Helper()
+
+	rows, err := db.Query(`SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT IN ('schema_migration', 'migration') ORDER BY name`)
+	require.NoError(t, err)
+	defer rows.Close()
+
+	var got []string
+	for rows.Next() {
+		var name string
+		require.NoError(t, rows.Scan(&name))
+		got = append(got, name)
+	}
+	require.NoError(t, rows.Err())
+	require.Equal(t, tables, got)
+}
+
+func versions(steps []Step) []int64 {
+	var result []int64
+	for _, step := range steps {
+		result = append(result, step.Version)
+	}
+	return result
+}
+
+func TestLoad(t *testing.T) {
+	migrations, err := Load(testFS(), "migration")
+	require.NoError(t, err)
+	require.Len(t, migrations, 5)
+	require.Equal(t, int64(0), migrations[0].Version)
+	require.Equal(t, "init", migrations[0].Name)
+	require.Equal(t, `DROP TABLE a;`, migrations[0].Down)
+	require.Equal(t, int64(10), migrations[4].Version)
+	require.Empty(t, migrations[4].Down)
+
+	t.Run("unexpected_file", func(t *testing.T) {
+		fsys := testFS()
+		fsys["migration/readme.md"] = &fstest.MapFile{}
+		_, err := Load(fsys, "migration")
+		require.ErrorContains(t, err, "unexpected file")
+	})
+	t.Run("names_differ", func(t *testing.T) {
+		fsys := testFS()
+		fsys["migration/01_other.down.sql"] = &fstest.MapFile{Data: []byte(`SELECT 1;`)}
+		_, err := Load(fsys, "migration")
+		require.ErrorContains(t, err, "two names")
+	})
+	t.Run("down_without_up", func(t *testing.T) {
+		fsys := testFS()
+		fsys["migration/20_orphan.down.sql"] = &fstest.MapFile{Data: []byte(`SELECT 1;`)}
+		_, err := Load(fsys, "migration")
+		require.ErrorContains(t, err, "no up script")
+	})
+}
+
+func TestMigrator(t *testing.T) {
+	m, db := newTestMigrator(t, testFS())
+	ctx := t.Context()
+
+	status, err := m.Status(ctx)
+	require.NoError(t, err)
+	require.Len(t, status, 5)
+	for _, s := range status {
+		require.False(t, s.Applied)
+	}
+	require.False(t, status[4].Reversible)
+
+	steps, err := m.To(ctx, 2, false)
+	require.NoError(t, err)
+	require.Equal(t, []int64{0, 1, 2}, versions(steps))
+	requireTables(t, db, "a", "b", "c")
+
+	t.Run("dry_run", func(t *testing.T) {
+		steps, err := m.Up(ctx, true)
+		require.NoError(t, err)
+		require.Equal(t, []int64{3, 10}, versions(steps))
+		require.Equal(t, `ALTER TABLE c ADD COLUMN name TEXT;`, steps[0].SQL())
+
+		steps, err = m.Down(ctx, true)
+		require.NoError(t, err)
+		require.Len(t, steps, 1)
+		require.True(t, steps[0].Down)
+		require.Equal(t, `DROP TABLE c;`, steps[0].SQL())
+
+		// Пробный запуск не меняет схему.
+		requireTables(t, db, "a", "b", "c")
+		status, err := m.Status(ctx)
+		require.NoError(t, err)
+		require.True(t, status[2].Applied)
+		require.False(t, status[3].Applied)
+	})
+	t.Run("down", func(t *testing.T) {
+		steps, err := m.Down(ctx, false)
+		require.NoError(t, err)
+		require.Equal(t, []int64{2}, versions(steps))
+		requireTables(t, db, "a", "b")
+
+		steps, err = m.To(ctx, Initial, false)
+		require.NoError(t, err)
+		require.Equal(t, []int64{1, 0}, versions(steps))
+		requireTables(t, db)
+
+		steps, err = m.Down(ctx, false)
+		require.NoError(t, err)
+		require.Empty(t, steps)
+	})
+	t.Run("up", func(t *testing.T) {
+		steps, err := m.Up(ctx, false)
+		require.NoError(t, err)
+		require.Equal(t, []int64{0, 1, 2, 3, 10}, versions(steps))
+		requireTables(t, db, "a", "c")
+
+		steps, err = m.Up(ctx, false)
+		require.NoError(t, err)
+		require.Empty(t, steps)
+
+		status, err := m.Status(ctx)
+		require.NoError(t, err)
+		for _, s := range status {
+			require.True(t, s.Applied)
+			require.False(t, s.AppliedAt.IsZero())
+		}
+	})
+	t.Run("irreversible", func(t *testing.T) {
+		_, err := m.To(ctx, 3, false)
+		require.ErrorIs(t, err, ErrIrreversible)
+	})
+	t.Run("unknown_target", func(t *testing.T) {
+		_, err := m.To(ctx, 5, false)
+		require.ErrorIs(t, err, ErrUnknownVersion)
+	})
+}
+
+func TestMigratorFailedStep(t *testing.T) {
+	fsys := testFS()
+	fsys["migration/02_c.up.sql"] = &fstest.MapFile{Data: []byte(`CREATE TABLE c (id INTEGER); CREATE TABLE a (id INTEGER);`)}
+	m, db := newTestMigrator(t, fsys)
+
+	_, err := m.Up(t.Context(), false)
+	require.ErrorContains(t, err, "2_c (up)")
+
+	// Запуск выполняется в одной транзакции: примененные до ошибки шаги
+	// откатываются.
+	requireTables(t, db)
+	status, err := m.Status(t.Context())
+	require.NoError(t, err)
+	require.False(t, status[0].Applied)
+}
+
+func TestMigratorChecksum(t *testing.T) {
+	fsys := testFS()
+	m, db := newTestMigrator(t, fsys)
+	_, err := m.To(t.Context(), 1, false)
+	require.NoError(t, err)
+
+	fsys["migration/01_b.up.sql"] = &fstest.MapFile{Data: []byte(`CREATE TABLE b (id INTEGER, name TEXT);`)}
+	migrations, err := Load(fsys, "migration")
+	require.NoError(t, err)
+	m = New(db, sqlite, migrations, log.New(io.Discard))
+
+	status, err := m.Status(t.Context())
+	require.NoError(t, err)
+	require.False(t, status[0].Modified)
+	require.True(t, status[1].Modified)
+
+	_, err = m.Up(t.Context(), false)
+	var checksumErr *ChecksumError
+	require.ErrorAs(t, err, &checksumErr)
+	require.Equal(t, int64(1), checksumErr.Version)
+
+	_, err = m.Down(t.Context(), true)
+	require.ErrorAs(t, err, &checksumErr)
+
+	// Скрипт отмены тоже входит в контрольную сумму.
+	fsys = testFS()
+	fsys["migration/00_init.down.sql"] = &fstest.MapFile{Data: []byte(`DROP TABLE IF EXISTS a;`)}
+	migrations, err = Load(fsys, "migration")
+	require.NoError(t, err)
+	m = New(db, sqlite, migrations, log.New(io.Discard))
+
+	status, err = m.Status(t.Context())
+	require.NoError(t, err)
+	require.True(t, status[0].Modified)
+}
+
+func TestMigratorUnknownApplied(t *testing.T) {
+	fsys := testFS()
+	m, db := newTestMigrator(t, fsys)
+	_, err := m.Up(t.Context(), false)
+	require.NoError(t, err)
+
+	// Более старая версия сервера не знает последних миграций.
+	delete(fsys, "migration/10_irreversible.up.sql")
+	migrations, err := Load(fsys, "migration")
+	require.NoError(t, err)
+	m = New(db, sqlite, migrations, log.New(io.Discard))
+
+	status, err := m.Status(t.Context())
+	require.NoError(t, err)
+	require.Len(t, status, 5)
+	require.True(t, status[4].Unknown)
+	require.Equal(t, "irreversible", status[4].Name)
+
+	_, err = m.Up(t.Context(), false)
+	require.ErrorIs(t, err, ErrUnknownVersion)
+}
+
+func TestMigratorLegacy(t *testing.T) {
+	m, db := newTestMigrator(t, testFS())
+
+	_, err := db.Exec(`
+CREATE TABLE migration (name TEXT PRIMARY KEY);
+CREATE TABLE a (id INTEGER);
+CREATE TABLE b (id INTEGER);
+INSERT INTO migration (name) VALUES ('migration/00_init.sql'), ('migration/01_b.sql');
+`)
+	require.NoError(t, err)
+
+	steps, err := m.Up(t.Context(), false)
+	require.NoError(t, err)
+	require.Equal(t, []int64{2, 3, 10}, versions(steps))
+	requireTables(t, db, "a", "c")
+
+	var n int
+	require.NoError(t, db.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE name = 'migration'`).Scan(&n))
+	require.Zero(t, n)
+
+	// Перенесенные миграции получают контрольную сумму обоих скриптов.
+	var checksum string
+	require.NoError(t, db.QueryRow(`SELECT checksum FROM schema_migration WHERE version = 1`).Scan(&checksum))
+	require.Equal(t, m.find(1).Checksum(), checksum)
+}
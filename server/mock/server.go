@@ -6,7 +6,9 @@ package mock
 
 import (
 	"context"
+	"io"
 	"sync"
+	"time"
 
 	"github.com/mkolibaba/gophkeeper/server"
 )
@@ -21,9 +23,21 @@ var _ server.AuthorizationService = &AuthorizationServiceMock{}
 //
 //		// make and configure a mocked server.AuthorizationService
 //		mockedAuthorizationService := &AuthorizationServiceMock{
-//			AuthorizeFunc: func(ctx context.Context, login string) (string, error) {
+//			AuthorizeFunc: func(ctx context.Context, login string) (server.Tokens, error) {
 //				panic("mock out the Authorize method")
 //			},
+//			ChallengeFunc: func(ctx context.Context, login string) (string, error) {
+//				panic("mock out the Challenge method")
+//			},
+//			RefreshFunc: func(ctx context.Context, refreshToken string) (server.Tokens, error) {
+//				panic("mock out the Refresh method")
+//			},
+//			VerifyFunc: func(ctx context.Context, accessToken string) (string, string, error) {
+//				panic("mock out the Verify method")
+//			},
+//			VerifyChallengeFunc: func(ctx context.Context, challenge string) (string, error) {
+//				panic("mock out the VerifyChallenge method")
+//			},
 //		}
 //
 //		// use mockedAuthorizationService in code that requires server.AuthorizationService
@@ -32,7 +46,19 @@ var _ server.AuthorizationService = &AuthorizationServiceMock{}
 //	}
 type AuthorizationServiceMock struct {
 	// AuthorizeFunc mocks the Authorize method.
-	AuthorizeFunc func(ctx context.Context, login string) (string, error)
+	AuthorizeFunc func(ctx context.Context, login string) (server.Tokens, error)
+
+	// ChallengeFunc mocks the Challenge method.
+	ChallengeFunc func(ctx context.Context, login string) (string, error)
+
+	// RefreshFunc mocks the Refresh method.
+	RefreshFunc func(ctx context.Context, refreshToken string) (server.Tokens, error)
+
+	// VerifyFunc mocks the Verify method.
+	VerifyFunc func(ctx context.Context, accessToken string) (string, string, error)
+
+	// VerifyChallengeFunc mocks the VerifyChallenge method.
+	VerifyChallengeFunc func(ctx context.Context, challenge string) (string, error)
 
 	// calls tracks calls to the methods.
 	calls struct {
@@ -43,12 +69,44 @@ type AuthorizationServiceMock struct {
 			// Login is the login argument value.
 			Login string
 		}
+		// Challenge holds details about calls to the Challenge method.
+		Challenge []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Login is the login argument value.
+			Login string
+		}
+		// Refresh holds details about calls to the Refresh method.
+		Refresh []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// RefreshToken is the refreshToken argument value.
+			RefreshToken string
+		}
+		// Verify holds details about calls to the Verify method.
+		Verify []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// AccessToken is the accessToken argument value.
+			AccessToken string
+		}
+		// VerifyChallenge holds details about calls to the VerifyChallenge method.
+		VerifyChallenge []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Challenge is the challenge argument value.
+			Challenge string
+		}
 	}
-	lockAuthorize sync.RWMutex
+	lockAuthorize       sync.RWMutex
+	lockChallenge       sync.RWMutex
+	lockRefresh         sync.RWMutex
+	lockVerify          sync.RWMutex
+	lockVerifyChallenge sync.RWMutex
 }
 
 // Authorize calls AuthorizeFunc.
-func (mock *AuthorizationServiceMock) Authorize(ctx context.Context, login string) (string, error) {
+func (mock *AuthorizationServiceMock) Authorize(ctx context.Context, login string) (server.Tokens, error) {
 	callInfo := struct {
 		Ctx   context.Context
 		Login string
@@ -61,10 +119,10 @@ func (mock *AuthorizationServiceMock) Authorize(ctx context.Context, login strin
 	mock.lockAuthorize.Unlock()
 	if mock.AuthorizeFunc == nil {
 		var (
-			s   string
-			err error
+			tokens server.Tokens
+			err    error
 		)
-		return s, err
+		return tokens, err
 	}
 	return mock.AuthorizeFunc(ctx, login)
 }
@@ -87,46 +145,219 @@ func (mock *AuthorizationServiceMock) AuthorizeCalls() []struct {
 	return calls
 }
 
-// Ensure that LoginServiceMock does implement server.LoginService.
+// Challenge calls ChallengeFunc.
+func (mock *AuthorizationServiceMock) Challenge(ctx context.Context, login string) (string, error) {
+	callInfo := struct {
+		Ctx   context.Context
+		Login string
+	}{
+		Ctx:   ctx,
+		Login: login,
+	}
+	mock.lockChallenge.Lock()
+	mock.calls.Challenge = append(mock.calls.Challenge, callInfo)
+	mock.lockChallenge.Unlock()
+	if mock.ChallengeFunc == nil {
+		var (
+			s   string
+			err error
+		)
+		return s, err
+	}
+	return mock.ChallengeFunc(ctx, login)
+}
+
+// ChallengeCalls gets all the calls that were made to Challenge.
+// Check the length with:
+//
+//	len(mockedAuthorizationService.ChallengeCalls())
+func (mock *AuthorizationServiceMock) ChallengeCalls() []struct {
+	Ctx   context.Context
+	Login string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Login string
+	}
+	mock.lockChallenge.RLock()
+	calls = mock.calls.Challenge
+	mock.lockChallenge.RUnlock()
+	return calls
+}
+
+// Refresh calls RefreshFunc.
+func (mock *AuthorizationServiceMock) Refresh(ctx context.Context, refreshToken string) (server.Tokens, error) {
+	callInfo := struct {
+		Ctx          context.Context
+		RefreshToken string
+	}{
+		Ctx:          ctx,
+		RefreshToken: refreshToken,
+	}
+	mock.lockRefresh.Lock()
+	mock.calls.Refresh = append(mock.calls.Refresh, callInfo)
+	mock.lockRefresh.Unlock()
+	if mock.RefreshFunc == nil {
+		var (
+			tokens server.Tokens
+			err    error
+		)
+		return tokens, err
+	}
+	return mock.RefreshFunc(ctx, refreshToken)
+}
+
+// RefreshCalls gets all the calls that were made to Refresh.
+// Check the length with:
+//
+//	len(mockedAuthorizationService.RefreshCalls())
+func (mock *AuthorizationServiceMock) RefreshCalls() []struct {
+	Ctx          context.Context
+	RefreshToken string
+} {
+	var calls []struct {
+		Ctx          context.Context
+		RefreshToken string
+	}
+	mock.lockRefresh.RLock()
+	calls = mock.calls.Refresh
+	mock.lockRefresh.RUnlock()
+	return calls
+}
+
+// Verify calls VerifyFunc.
+func (mock *AuthorizationServiceMock) Verify(ctx context.Context, accessToken string) (string, string, error) {
+	callInfo := struct {
+		Ctx         context.Context
+		AccessToken string
+	}{
+		Ctx:         ctx,
+		AccessToken: accessToken,
+	}
+	mock.lockVerify.Lock()
+	mock.calls.Verify = append(mock.calls.Verify, callInfo)
+	mock.lockVerify.Unlock()
+	if mock.VerifyFunc == nil {
+		var (
+			s   string
+			s1  string
+			err error
+		)
+		return s, s1, err
+	}
+	return mock.VerifyFunc(ctx, accessToken)
+}
+
+// VerifyCalls gets all the calls that were made to Verify.
+// Check the length with:
+//
+//	len(mockedAuthorizationService.VerifyCalls())
+func (mock *AuthorizationServiceMock) VerifyCalls() []struct {
+	Ctx         context.Context
+	AccessToken string
+} {
+	var calls []struct {
+		Ctx         context.Context
+		AccessToken string
+	}
+	mock.lockVerify.RLock()
+	calls = mock.calls.Verify
+	mock.lockVerify.RUnlock()
+	return calls
+}
+
+// VerifyChallenge calls VerifyChallengeFunc.
+func (mock *AuthorizationServiceMock) VerifyChallenge(ctx context.Context, challenge string) (string, error) {
+	callInfo := struct {
+		Ctx       context.Context
+		Challenge string
+	}{
+		Ctx:       ctx,
+		Challenge: challenge,
+	}
+	mock.lockVerifyChallenge.Lock()
+	mock.calls.VerifyChallenge = append(mock.calls.VerifyChallenge, callInfo)
+	mock.lockVerifyChallenge.Unlock()
+	if mock.VerifyChallengeFunc == nil {
+		var (
+			s   string
+			err error
+		)
+		return s, err
+	}
+	return mock.VerifyChallengeFunc(ctx, challenge)
+}
+
+// VerifyChallengeCalls gets all the calls that were made to VerifyChallenge.
+// Check the length with:
+//
+//	len(mockedAuthorizationService.VerifyChallengeCalls())
+func (mock *AuthorizationServiceMock) VerifyChallengeCalls() []struct {
+	Ctx       context.Context
+	Challenge string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		Challenge string
+	}
+	mock.lockVerifyChallenge.RLock()
+	calls = mock.calls.VerifyChallenge
+	mock.lockVerifyChallenge.RUnlock()
+	return calls
+}
+
+// Ensure that SessionServiceMock does implement server.SessionService.
 // If this is not the case, regenerate this file with mockery.
-var _ server.LoginService = &LoginServiceMock{}
+var _ server.SessionService = &SessionServiceMock{}
 
-// LoginServiceMock is a mock implementation of server.LoginService.
+// SessionServiceMock is a mock implementation of server.SessionService.
 //
-//	func TestSomethingThatUsesLoginService(t *testing.T) {
+//	func TestSomethingThatUsesSessionService(t *testing.T) {
 //
-//		// make and configure a mocked server.LoginService
-//		mockedLoginService := &LoginServiceMock{
-//			CreateFunc: func(ctx context.Context, data server.LoginData) error {
+//		// make and configure a mocked server.SessionService
+//		mockedSessionService := &SessionServiceMock{
+//			CreateFunc: func(ctx context.Context, session server.Session) error {
 //				panic("mock out the Create method")
 //			},
-//			GetAllFunc: func(ctx context.Context) ([]server.LoginData, error) {
+//			GetFunc: func(ctx context.Context, id string) (*server.Session, error) {
+//				panic("mock out the Get method")
+//			},
+//			GetAllFunc: func(ctx context.Context, login string) ([]server.Session, error) {
 //				panic("mock out the GetAll method")
 //			},
-//			RemoveFunc: func(ctx context.Context, id int64) error {
+//			RemoveFunc: func(ctx context.Context, login string, id string) error {
 //				panic("mock out the Remove method")
 //			},
-//			UpdateFunc: func(ctx context.Context, id int64, data server.LoginDataUpdate) error {
-//				panic("mock out the Update method")
+//			RemoveExpiredFunc: func(ctx context.Context, login string, now time.Time) error {
+//				panic("mock out the RemoveExpired method")
+//			},
+//			RotateFunc: func(ctx context.Context, id string, oldHash []byte, session server.Session) error {
+//				panic("mock out the Rotate method")
 //			},
 //		}
 //
-//		// use mockedLoginService in code that requires server.LoginService
+//		// use mockedSessionService in code that requires server.SessionService
 //		// and then make assertions.
 //
 //	}
-type LoginServiceMock struct {
+type SessionServiceMock struct {
 	// CreateFunc mocks the Create method.
-	CreateFunc func(ctx context.Context, data server.LoginData) error
+	CreateFunc func(ctx context.Context, session server.Session) error
+
+	// GetFunc mocks the Get method.
+	GetFunc func(ctx context.Context, id string) (*server.Session, error)
 
 	// GetAllFunc mocks the GetAll method.
-	GetAllFunc func(ctx context.Context) ([]server.LoginData, error)
+	GetAllFunc func(ctx context.Context, login string) ([]server.Session, error)
 
 	// RemoveFunc mocks the Remove method.
-	RemoveFunc func(ctx context.Context, id int64) error
+	RemoveFunc func(ctx context.Context, login string, id string) error
 
-	// UpdateFunc mocks the Update method.
-	UpdateFunc func(ctx context.Context, id int64, data server.LoginDataUpdate) error
+	// RemoveExpiredFunc mocks the RemoveExpired method.
+	RemoveExpiredFunc func(ctx context.Context, login string, now time.Time) error
+
+	// RotateFunc mocks the Rotate method.
+	RotateFunc func(ctx context.Context, id string, oldHash []byte, session server.Session) error
 
 	// calls tracks calls to the methods.
 	calls struct {
@@ -134,45 +365,69 @@ type LoginServiceMock struct {
 		Create []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
-			// Data is the data argument value.
-			Data server.LoginData
+			// Session is the session argument value.
+			Session server.Session
+		}
+		// Get holds details about calls to the Get method.
+		Get []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID string
 		}
 		// GetAll holds details about calls to the GetAll method.
 		GetAll []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
+			// Login is the login argument value.
+			Login string
 		}
 		// Remove holds details about calls to the Remove method.
 		Remove []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
+			// Login is the login argument value.
+			Login string
 			// ID is the id argument value.
-			ID int64
+			ID string
 		}
-		// Update holds details about calls to the Update method.
-		Update []struct {
+		// RemoveExpired holds details about calls to the RemoveExpired method.
+		RemoveExpired []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Login is the login argument value.
+			Login string
+			// Now is the now argument value.
+			Now time.Time
+		}
+		// Rotate holds details about calls to the Rotate method.
+		Rotate []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 			// ID is the id argument value.
-			ID int64
-			// Data is the data argument value.
-			Data server.LoginDataUpdate
+			ID string
+			// OldHash is the oldHash argument value.
+			OldHash []byte
+			// Session is the session argument value.
+			Session server.Session
 		}
 	}
-	lockCreate sync.RWMutex
-	lockGetAll sync.RWMutex
-	lockRemove sync.RWMutex
-	lockUpdate sync.RWMutex
+	lockCreate        sync.RWMutex
+	lockGet           sync.RWMutex
+	lockGetAll        sync.RWMutex
+	lockRemove        sync.RWMutex
+	lockRemoveExpired sync.RWMutex
+	lockRotate        sync.RWMutex
 }
 
 // Create calls CreateFunc.
-func (mock *LoginServiceMock) Create(ctx context.Context, data server.LoginData) error {
+func (mock *SessionServiceMock) Create(ctx context.Context, session server.Session) error {
 	callInfo := struct {
-		Ctx  context.Context
-		Data server.LoginData
+		Ctx     context.Context
+		Session server.Session
 	}{
-		Ctx:  ctx,
-		Data: data,
+		Ctx:     ctx,
+		Session: session,
 	}
 	mock.lockCreate.Lock()
 	mock.calls.Create = append(mock.calls.Create, callInfo)
@@ -183,20 +438,20 @@ func (mock *LoginServiceMock) Create(ctx context.Context, data server.LoginData)
 		)
 		return err
 	}
-	return mock.CreateFunc(ctx, data)
+	return mock.CreateFunc(ctx, session)
 }
 
 // CreateCalls gets all the calls that were made to Create.
 // Check the length with:
 //
-//	len(mockedLoginService.CreateCalls())
-func (mock *LoginServiceMock) CreateCalls() []struct {
-	Ctx  context.Context
-	Data server.LoginData
+//	len(mockedSessionService.CreateCalls())
+func (mock *SessionServiceMock) CreateCalls() []struct {
+	Ctx     context.Context
+	Session server.Session
 } {
 	var calls []struct {
-		Ctx  context.Context
-		Data server.LoginData
+		Ctx     context.Context
+		Session server.Session
 	}
 	mock.lockCreate.RLock()
 	calls = mock.calls.Create
@@ -204,35 +459,79 @@ func (mock *LoginServiceMock) CreateCalls() []struct {
 	return calls
 }
 
-// GetAll calls GetAllFunc.
-func (mock *LoginServiceMock) GetAll(ctx context.Context) ([]server.LoginData, error) {
+// Get calls GetFunc.
+func (mock *SessionServiceMock) Get(ctx context.Context, id string) (*server.Session, error) {
 	callInfo := struct {
 		Ctx context.Context
+		ID  string
 	}{
 		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockGet.Lock()
+	mock.calls.Get = append(mock.calls.Get, callInfo)
+	mock.lockGet.Unlock()
+	if mock.GetFunc == nil {
+		var (
+			session *server.Session
+			err     error
+		)
+		return session, err
+	}
+	return mock.GetFunc(ctx, id)
+}
+
+// GetCalls gets all the calls that were made to Get.
+// Check the length with:
+//
+//	len(mockedSessionService.GetCalls())
+func (mock *SessionServiceMock) GetCalls() []struct {
+	Ctx context.Context
+	ID  string
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  string
+	}
+	mock.lockGet.RLock()
+	calls = mock.calls.Get
+	mock.lockGet.RUnlock()
+	return calls
+}
+
+// GetAll calls GetAllFunc.
+func (mock *SessionServiceMock) GetAll(ctx context.Context, login string) ([]server.Session, error) {
+	callInfo := struct {
+		Ctx   context.Context
+		Login string
+	}{
+		Ctx:   ctx,
+		Login: login,
 	}
 	mock.lockGetAll.Lock()
 	mock.calls.GetAll = append(mock.calls.GetAll, callInfo)
 	mock.lockGetAll.Unlock()
 	if mock.GetAllFunc == nil {
 		var (
-			loginDatas []server.LoginData
-			err        error
+			sessions []server.Session
+			err      error
 		)
-		return loginDatas, err
+		return sessions, err
 	}
-	return mock.GetAllFunc(ctx)
+	return mock.GetAllFunc(ctx, login)
 }
 
 // GetAllCalls gets all the calls that were made to GetAll.
 // Check the length with:
 //
-//	len(mockedLoginService.GetAllCalls())
-func (mock *LoginServiceMock) GetAllCalls() []struct {
-	Ctx context.Context
+//	len(mockedSessionService.GetAllCalls())
+func (mock *SessionServiceMock) GetAllCalls() []struct {
+	Ctx   context.Context
+	Login string
 } {
 	var calls []struct {
-		Ctx context.Context
+		Ctx   context.Context
+		Login string
 	}
 	mock.lockGetAll.RLock()
 	calls = mock.calls.GetAll
@@ -241,13 +540,15 @@ func (mock *LoginServiceMock) GetAllCalls() []struct {
 }
 
 // Remove calls RemoveFunc.
-func (mock *LoginServiceMock) Remove(ctx context.Context, id int64) error {
+func (mock *SessionServiceMock) Remove(ctx context.Context, login string, id string) error {
 	callInfo := struct {
-		Ctx context.Context
-		ID  int64
+		Ctx   context.Context
+		Login string
+		ID    string
 	}{
-		Ctx: ctx,
-		ID:  id,
+		Ctx:   ctx,
+		Login: login,
+		ID:    id,
 	}
 	mock.lockRemove.Lock()
 	mock.calls.Remove = append(mock.calls.Remove, callInfo)
@@ -258,20 +559,22 @@ func (mock *LoginServiceMock) Remove(ctx context.Context, id int64) error {
 		)
 		return err
 	}
-	return mock.RemoveFunc(ctx, id)
+	return mock.RemoveFunc(ctx, login, id)
 }
 
 // RemoveCalls gets all the calls that were made to Remove.
 // Check the length with:
 //
-//	len(mockedLoginService.RemoveCalls())
-func (mock *LoginServiceMock) RemoveCalls() []struct {
-	Ctx context.Context
-	ID  int64
+//	len(mockedSessionService.RemoveCalls())
+func (mock *SessionServiceMock) RemoveCalls() []struct {
+	Ctx   context.Context
+	Login string
+	ID    string
 } {
 	var calls []struct {
-		Ctx context.Context
-		ID  int64
+		Ctx   context.Context
+		Login string
+		ID    string
 	}
 	mock.lockRemove.RLock()
 	calls = mock.calls.Remove
@@ -279,448 +582,549 @@ func (mock *LoginServiceMock) RemoveCalls() []struct {
 	return calls
 }
 
-// Update calls UpdateFunc.
-func (mock *LoginServiceMock) Update(ctx context.Context, id int64, data server.LoginDataUpdate) error {
+// RemoveExpired calls RemoveExpiredFunc.
+func (mock *SessionServiceMock) RemoveExpired(ctx context.Context, login string, now time.Time) error {
 	callInfo := struct {
-		Ctx  context.Context
-		ID   int64
-		Data server.LoginDataUpdate
+		Ctx   context.Context
+		Login string
+		Now   time.Time
 	}{
-		Ctx:  ctx,
-		ID:   id,
-		Data: data,
+		Ctx:   ctx,
+		Login: login,
+		Now:   now,
 	}
-	mock.lockUpdate.Lock()
-	mock.calls.Update = append(mock.calls.Update, callInfo)
-	mock.lockUpdate.Unlock()
-	if mock.UpdateFunc == nil {
+	mock.lockRemoveExpired.Lock()
+	mock.calls.RemoveExpired = append(mock.calls.RemoveExpired, callInfo)
+	mock.lockRemoveExpired.Unlock()
+	if mock.RemoveExpiredFunc == nil {
 		var (
 			err error
 		)
 		return err
 	}
-	return mock.UpdateFunc(ctx, id, data)
+	return mock.RemoveExpiredFunc(ctx, login, now)
 }
 
-// UpdateCalls gets all the calls that were made to Update.
+// RemoveExpiredCalls gets all the calls that were made to RemoveExpired.
 // Check the length with:
 //
-//	len(mockedLoginService.UpdateCalls())
-func (mock *LoginServiceMock) UpdateCalls() []struct {
-	Ctx  context.Context
-	ID   int64
-	Data server.LoginDataUpdate
+//	len(mockedSessionService.RemoveExpiredCalls())
+func (mock *SessionServiceMock) RemoveExpiredCalls() []struct {
+	Ctx   context.Context
+	Login string
+	Now   time.Time
 } {
 	var calls []struct {
-		Ctx  context.Context
-		ID   int64
-		Data server.LoginDataUpdate
+		Ctx   context.Context
+		Login string
+		Now   time.Time
 	}
-	mock.lockUpdate.RLock()
-	calls = mock.calls.Update
-	mock.lockUpdate.RUnlock()
+	mock.lockRemoveExpired.RLock()
+	calls = mock.calls.RemoveExpired
+	mock.lockRemoveExpired.RUnlock()
 	return calls
 }
 
-// Ensure that NoteServiceMock does implement server.NoteService.
+// Rotate calls RotateFunc.
+func (mock *SessionServiceMock) Rotate(ctx context.Context, id string, oldHash []byte, session server.Session) error {
+	callInfo := struct {
+		Ctx     context.Context
+		ID      string
+		OldHash []byte
+		Session server.Session
+	}{
+		Ctx:     ctx,
+		ID:      id,
+		OldHash: oldHash,
+		Session: session,
+	}
+	mock.lockRotate.Lock()
+	mock.calls.Rotate = append(mock.calls.Rotate, callInfo)
+	mock.lockRotate.Unlock()
+	if mock.RotateFunc == nil {
+		var (
+			err error
+		)
+		return err
+	}
+	return mock.RotateFunc(ctx, id, oldHash, session)
+}
+
+// RotateCalls gets all the calls that were made to Rotate.
+// Check the length with:
+//
+//	len(mockedSessionService.RotateCalls())
+func (mock *SessionServiceMock) RotateCalls() []struct {
+	Ctx     context.Context
+	ID      string
+	OldHash []byte
+	Session server.Session
+} {
+	var calls []struct {
+		Ctx     context.Context
+		ID      string
+		OldHash []byte
+		Session server.Session
+	}
+	mock.lockRotate.RLock()
+	calls = mock.calls.Rotate
+	mock.lockRotate.RUnlock()
+	return calls
+}
+
+// Ensure that LoginAttemptServiceMock does implement server.LoginAttemptService.
 // If this is not the case, regenerate this file with mockery.
-var _ server.NoteService = &NoteServiceMock{}
+var _ server.LoginAttemptService = &LoginAttemptServiceMock{}
 
-// NoteServiceMock is a mock implementation of server.NoteService.
+// LoginAttemptServiceMock is a mock implementation of server.LoginAttemptService.
 //
-//	func TestSomethingThatUsesNoteService(t *testing.T) {
+//	func TestSomethingThatUsesLoginAttemptService(t *testing.T) {
 //
-//		// make and configure a mocked server.NoteService
-//		mockedNoteService := &NoteServiceMock{
-//			CreateFunc: func(ctx context.Context, data server.NoteData) error {
-//				panic("mock out the Create method")
+//		// make and configure a mocked server.LoginAttemptService
+//		mockedLoginAttemptService := &LoginAttemptServiceMock{
+//			FailFunc: func(ctx context.Context, login string, at time.Time) (int, error) {
+//				panic("mock out the Fail method")
 //			},
-//			GetAllFunc: func(ctx context.Context) ([]server.NoteData, error) {
-//				panic("mock out the GetAll method")
+//			GetFunc: func(ctx context.Context, login string) (server.LoginAttempts, error) {
+//				panic("mock out the Get method")
 //			},
-//			RemoveFunc: func(ctx context.Context, id int64) error {
-//				panic("mock out the Remove method")
+//			LockFunc: func(ctx context.Context, login string, until time.Time) error {
+//				panic("mock out the Lock method")
 //			},
-//			UpdateFunc: func(ctx context.Context, id int64, data server.NoteDataUpdate) error {
-//				panic("mock out the Update method")
+//			RemoveExpiredFunc: func(ctx context.Context, before time.Time) error {
+//				panic("mock out the RemoveExpired method")
+//			},
+//			ResetFunc: func(ctx context.Context, login string) error {
+//				panic("mock out the Reset method")
 //			},
 //		}
 //
-//		// use mockedNoteService in code that requires server.NoteService
+//		// use mockedLoginAttemptService in code that requires server.LoginAttemptService
 //		// and then make assertions.
 //
 //	}
-type NoteServiceMock struct {
-	// CreateFunc mocks the Create method.
-	CreateFunc func(ctx context.Context, data server.NoteData) error
+type LoginAttemptServiceMock struct {
+	// FailFunc mocks the Fail method.
+	FailFunc func(ctx context.Context, login string, at time.Time) (int, error)
 
-	// GetAllFunc mocks the GetAll method.
-	GetAllFunc func(ctx context.Context) ([]server.NoteData, error)
+	// GetFunc mocks the Get method.
+	GetFunc func(ctx context.Context, login string) (server.LoginAttempts, error)
 
-	// RemoveFunc mocks the Remove method.
-	RemoveFunc func(ctx context.Context, id int64) error
+	// LockFunc mocks the Lock method.
+	LockFunc func(ctx context.Context, login string, until time.Time) error
 
-	// UpdateFunc mocks the Update method.
-	UpdateFunc func(ctx context.Context, id int64, data server.NoteDataUpdate) error
+	// RemoveExpiredFunc mocks the RemoveExpired method.
+	RemoveExpiredFunc func(ctx context.Context, before time.Time) error
+
+	// ResetFunc mocks the Reset method.
+	ResetFunc func(ctx context.Context, login string) error
 
 	// calls tracks calls to the methods.
 	calls struct {
-		// Create holds details about calls to the Create method.
-		Create []struct {
+		// Fail holds details about calls to the Fail method.
+		Fail []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
-			// Data is the data argument value.
-			Data server.NoteData
+			// Login is the login argument value.
+			Login string
+			// At is the at argument value.
+			At time.Time
 		}
-		// GetAll holds details about calls to the GetAll method.
-		GetAll []struct {
+		// Get holds details about calls to the Get method.
+		Get []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
+			// Login is the login argument value.
+			Login string
 		}
-		// Remove holds details about calls to the Remove method.
-		Remove []struct {
+		// Lock holds details about calls to the Lock method.
+		Lock []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
-			// ID is the id argument value.
-			ID int64
+			// Login is the login argument value.
+			Login string
+			// Until is the until argument value.
+			Until time.Time
 		}
-		// Update holds details about calls to the Update method.
-		Update []struct {
+		// RemoveExpired holds details about calls to the RemoveExpired method.
+		RemoveExpired []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
-			// ID is the id argument value.
-			ID int64
-			// Data is the data argument value.
-			Data server.NoteDataUpdate
+			// Before is the before argument value.
+			Before time.Time
+		}
+		// Reset holds details about calls to the Reset method.
+		Reset []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Login is the login argument value.
+			Login string
 		}
 	}
-	lockCreate sync.RWMutex
-	lockGetAll sync.RWMutex
-	lockRemove sync.RWMutex
-	lockUpdate sync.RWMutex
+	lockFail          sync.RWMutex
+	lockGet           sync.RWMutex
+	lockLock          sync.RWMutex
+	lockRemoveExpired sync.RWMutex
+	lockReset         sync.RWMutex
 }
 
-// Create calls CreateFunc.
-func (mock *NoteServiceMock) Create(ctx context.Context, data server.NoteData) error {
+// Fail calls FailFunc.
+func (mock *LoginAttemptServiceMock) Fail(ctx context.Context, login string, at time.Time) (int, error) {
 	callInfo := struct {
-		Ctx  context.Context
-		Data server.NoteData
+		Ctx   context.Context
+		Login string
+		At    time.Time
 	}{
-		Ctx:  ctx,
-		Data: data,
+		Ctx:   ctx,
+		Login: login,
+		At:    at,
 	}
-	mock.lockCreate.Lock()
-	mock.calls.Create = append(mock.calls.Create, callInfo)
-	mock.lockCreate.Unlock()
-	if mock.CreateFunc == nil {
+	mock.lockFail.Lock()
+	mock.calls.Fail = append(mock.calls.Fail, callInfo)
+	mock.lockFail.Unlock()
+	if mock.FailFunc == nil {
 		var (
+			n   int
 			err error
 		)
-		return err
+		return n, err
 	}
-	return mock.CreateFunc(ctx, data)
+	return mock.FailFunc(ctx, login, at)
 }
 
-// CreateCalls gets all the calls that were made to Create.
+// FailCalls gets all the calls that were made to Fail.
 // Check the length with:
 //
-//	len(mockedNoteService.CreateCalls())
-func (mock *NoteServiceMock) CreateCalls() []struct {
-	Ctx  context.Context
-	Data server.NoteData
+//	len(mockedLoginAttemptService.FailCalls())
+func (mock *LoginAttemptServiceMock) FailCalls() []struct {
+	Ctx   context.Context
+	Login string
+	At    time.Time
 } {
 	var calls []struct {
-		Ctx  context.Context
-		Data server.NoteData
+		Ctx   context.Context
+		Login string
+		At    time.Time
 	}
-	mock.lockCreate.RLock()
-	calls = mock.calls.Create
-	mock.lockCreate.RUnlock()
+	mock.lockFail.RLock()
+	calls = mock.calls.Fail
+	mock.lockFail.RUnlock()
 	return calls
 }
 
-// GetAll calls GetAllFunc.
-func (mock *NoteServiceMock) GetAll(ctx context.Context) ([]server.NoteData, error) {
+// Get calls GetFunc.
+func (mock *LoginAttemptServiceMock) Get(ctx context.Context, login string) (server.LoginAttempts, error) {
 	callInfo := struct {
-		Ctx context.Context
+		Ctx   context.Context
+		Login string
 	}{
-		Ctx: ctx,
+		Ctx:   ctx,
+		Login: login,
 	}
-	mock.lockGetAll.Lock()
-	mock.calls.GetAll = append(mock.calls.GetAll, callInfo)
-	mock.lockGetAll.Unlock()
-	if mock.GetAllFunc == nil {
+	mock.lockGet.Lock()
+	mock.calls.Get = append(mock.calls.Get, callInfo)
+	mock.lockGet.Unlock()
+	if mock.GetFunc == nil {
 		var (
-			noteDatas []server.NoteData
-			err       error
+			loginAttempts server.LoginAttempts
+			err           error
 		)
-		return noteDatas, err
+		return loginAttempts, err
 	}
-	return mock.GetAllFunc(ctx)
+	return mock.GetFunc(ctx, login)
 }
 
-// GetAllCalls gets all the calls that were made to GetAll.
+// GetCalls gets all the calls that were made to Get.
 // Check the length with:
 //
-//	len(mockedNoteService.GetAllCalls())
-func (mock *NoteServiceMock) GetAllCalls() []struct {
-	Ctx context.Context
+//	len(mockedLoginAttemptService.GetCalls())
+func (mock *LoginAttemptServiceMock) GetCalls() []struct {
+	Ctx   context.Context
+	Login string
 } {
 	var calls []struct {
-		Ctx context.Context
+		Ctx   context.Context
+		Login string
 	}
-	mock.lockGetAll.RLock()
-	calls = mock.calls.GetAll
-	mock.lockGetAll.RUnlock()
+	mock.lockGet.RLock()
+	calls = mock.calls.Get
+	mock.lockGet.RUnlock()
 	return calls
 }
 
-// Remove calls RemoveFunc.
-func (mock *NoteServiceMock) Remove(ctx context.Context, id int64) error {
+// Lock calls LockFunc.
+func (mock *LoginAttemptServiceMock) Lock(ctx context.Context, login string, until time.Time) error {
 	callInfo := struct {
-		Ctx context.Context
-		ID  int64
+		Ctx   context.Context
+		Login string
+		Until time.Time
 	}{
-		Ctx: ctx,
-		ID:  id,
+		Ctx:   ctx,
+		Login: login,
+		Until: until,
 	}
-	mock.lockRemove.Lock()
-	mock.calls.Remove = append(mock.calls.Remove, callInfo)
-	mock.lockRemove.Unlock()
-	if mock.RemoveFunc == nil {
+	mock.lockLock.Lock()
+	mock.calls.Lock = append(mock.calls.Lock, callInfo)
+	mock.lockLock.Unlock()
+	if mock.LockFunc == nil {
 		var (
 			err error
 		)
 		return err
 	}
-	return mock.RemoveFunc(ctx, id)
+	return mock.LockFunc(ctx, login, until)
 }
 
-// RemoveCalls gets all the calls that were made to Remove.
+// LockCalls gets all the calls that were made to Lock.
 // Check the length with:
 //
-//	len(mockedNoteService.RemoveCalls())
-func (mock *NoteServiceMock) RemoveCalls() []struct {
-	Ctx context.Context
-	ID  int64
+//	len(mockedLoginAttemptService.LockCalls())
+func (mock *LoginAttemptServiceMock) LockCalls() []struct {
+	Ctx   context.Context
+	Login string
+	Until time.Time
 } {
 	var calls []struct {
-		Ctx context.Context
-		ID  int64
+		Ctx   context.Context
+		Login string
+		Until time.Time
 	}
-	mock.lockRemove.RLock()
-	calls = mock.calls.Remove
-	mock.lockRemove.RUnlock()
+	mock.lockLock.RLock()
+	calls = mock.calls.Lock
+	mock.lockLock.RUnlock()
 	return calls
 }
 
-// Update calls UpdateFunc.
-func (mock *NoteServiceMock) Update(ctx context.Context, id int64, data server.NoteDataUpdate) error {
+// RemoveExpired calls RemoveExpiredFunc.
+func (mock *LoginAttemptServiceMock) RemoveExpired(ctx context.Context, before time.Time) error {
 	callInfo := struct {
-		Ctx  context.Context
-		ID   int64
-		Data server.NoteDataUpdate
+		Ctx    context.Context
+		Before time.Time
 	}{
-		Ctx:  ctx,
-		ID:   id,
-		Data: data,
+		Ctx:    ctx,
+		Before: before,
 	}
-	mock.lockUpdate.Lock()
-	mock.calls.Update = append(mock.calls.Update, callInfo)
-	mock.lockUpdate.Unlock()
-	if mock.UpdateFunc == nil {
+	mock.lockRemoveExpired.Lock()
+	mock.calls.RemoveExpired = append(mock.calls.RemoveExpired, callInfo)
+	mock.lockRemoveExpired.Unlock()
+	if mock.RemoveExpiredFunc == nil {
 		var (
 			err error
 		)
 		return err
 	}
-	return mock.UpdateFunc(ctx, id, data)
+	return mock.RemoveExpiredFunc(ctx, before)
 }
 
-// UpdateCalls gets all the calls that were made to Update.
+// RemoveExpiredCalls gets all the calls that were made to RemoveExpired.
 // Check the length with:
 //
-//	len(mockedNoteService.UpdateCalls())
-func (mock *NoteServiceMock) UpdateCalls() []struct {
-	Ctx  context.Context
-	ID   int64
-	Data server.NoteDataUpdate
+//	len(mockedLoginAttemptService.RemoveExpiredCalls())
+func (mock *LoginAttemptServiceMock) RemoveExpiredCalls() []struct {
+	Ctx    context.Context
+	Before time.Time
 } {
 	var calls []struct {
-		Ctx  context.Context
-		ID   int64
-		Data server.NoteDataUpdate
+		Ctx    context.Context
+		Before time.Time
 	}
-	mock.lockUpdate.RLock()
-	calls = mock.calls.Update
-	mock.lockUpdate.RUnlock()
+	mock.lockRemoveExpired.RLock()
+	calls = mock.calls.RemoveExpired
+	mock.lockRemoveExpired.RUnlock()
 	return calls
 }
 
-// Ensure that BinaryServiceMock does implement server.BinaryService.
+// Reset calls ResetFunc.
+func (mock *LoginAttemptServiceMock) Reset(ctx context.Context, login string) error {
+	callInfo := struct {
+		Ctx   context.Context
+		Login string
+	}{
+		Ctx:   ctx,
+		Login: login,
+	}
+	mock.lockReset.Lock()
+	mock.calls.Reset = append(mock.calls.Reset, callInfo)
+	mock.lockReset.Unlock()
+	if mock.ResetFunc == nil {
+		var (
+			err error
+		)
+		return err
+	}
+	return mock.ResetFunc(ctx, login)
+}
+
+// ResetCalls gets all the calls that were made to Reset.
+// Check the length with:
+//
+//	len(mockedLoginAttemptService.ResetCalls())
+func (mock *LoginAttemptServiceMock) ResetCalls() []struct {
+	Ctx   context.Context
+	Login string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Login string
+	}
+	mock.lockReset.RLock()
+	calls = mock.calls.Reset
+	mock.lockReset.RUnlock()
+	return calls
+}
+
+// Ensure that BlobStoreMock does implement server.BlobStore.
 // If this is not the case, regenerate this file with mockery.
-var _ server.BinaryService = &BinaryServiceMock{}
+var _ server.BlobStore = &BlobStoreMock{}
 
-// BinaryServiceMock is a mock implementation of server.BinaryService.
+// BlobStoreMock is a mock implementation of server.BlobStore.
 //
-//	func TestSomethingThatUsesBinaryService(t *testing.T) {
+//	func TestSomethingThatUsesBlobStore(t *testing.T) {
 //
-//		// make and configure a mocked server.BinaryService
-//		mockedBinaryService := &BinaryServiceMock{
-//			CreateFunc: func(ctx context.Context, data server.ReadableBinaryData) error {
-//				panic("mock out the Create method")
+//		// make and configure a mocked server.BlobStore
+//		mockedBlobStore := &BlobStoreMock{
+//			DeleteFunc: func(ctx context.Context, key string) error {
+//				panic("mock out the Delete method")
 //			},
-//			GetFunc: func(ctx context.Context, id int64) (*server.ReadableBinaryData, error) {
+//			GetFunc: func(ctx context.Context, key string) (io.ReadCloser, error) {
 //				panic("mock out the Get method")
 //			},
-//			GetAllFunc: func(ctx context.Context) ([]server.BinaryData, error) {
-//				panic("mock out the GetAll method")
-//			},
-//			RemoveFunc: func(ctx context.Context, id int64) error {
-//				panic("mock out the Remove method")
+//			PutFunc: func(ctx context.Context, key string, r io.Reader) error {
+//				panic("mock out the Put method")
 //			},
-//			UpdateFunc: func(ctx context.Context, id int64, data server.BinaryDataUpdate) error {
-//				panic("mock out the Update method")
+//			StatFunc: func(ctx context.Context, key string) (server.BlobInfo, error) {
+//				panic("mock out the Stat method")
 //			},
 //		}
 //
-//		// use mockedBinaryService in code that requires server.BinaryService
+//		// use mockedBlobStore in code that requires server.BlobStore
 //		// and then make assertions.
 //
 //	}
-type BinaryServiceMock struct {
-	// CreateFunc mocks the Create method.
-	CreateFunc func(ctx context.Context, data server.ReadableBinaryData) error
+type BlobStoreMock struct {
+	// DeleteFunc mocks the Delete method.
+	DeleteFunc func(ctx context.Context, key string) error
 
 	// GetFunc mocks the Get method.
-	GetFunc func(ctx context.Context, id int64) (*server.ReadableBinaryData, error)
-
-	// GetAllFunc mocks the GetAll method.
-	GetAllFunc func(ctx context.Context) ([]server.BinaryData, error)
+	GetFunc func(ctx context.Context, key string) (io.ReadCloser, error)
 
-	// RemoveFunc mocks the Remove method.
-	RemoveFunc func(ctx context.Context, id int64) error
+	// PutFunc mocks the Put method.
+	PutFunc func(ctx context.Context, key string, r io.Reader) error
 
-	// UpdateFunc mocks the Update method.
-	UpdateFunc func(ctx context.Context, id int64, data server.BinaryDataUpdate) error
+	// StatFunc mocks the Stat method.
+	StatFunc func(ctx context.Context, key string) (server.BlobInfo, error)
 
 	// calls tracks calls to the methods.
 	calls struct {
-		// Create holds details about calls to the Create method.
-		Create []struct {
+		// Delete holds details about calls to the Delete method.
+		Delete []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
-			// Data is the data argument value.
-			Data server.ReadableBinaryData
+			// Key is the key argument value.
+			Key string
 		}
 		// Get holds details about calls to the Get method.
 		Get []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
-			// ID is the id argument value.
-			ID int64
+			// Key is the key argument value.
+			Key string
 		}
-		// GetAll holds details about calls to the GetAll method.
-		GetAll []struct {
+		// Put holds details about calls to the Put method.
+		Put []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+			// R is the r argument value.
+			R io.Reader
 		}
-		// Remove holds details about calls to the Remove method.
-		Remove []struct {
+		// Stat holds details about calls to the Stat method.
+		Stat []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
-			// ID is the id argument value.
-			ID int64
-		}
-		// Update holds details about calls to the Update method.
-		Update []struct {
-			// Ctx is the ctx argument value.
-			Ctx context.Context
-			// ID is the id argument value.
-			ID int64
-			// Data is the data argument value.
-			Data server.BinaryDataUpdate
+			// Key is the key argument value.
+			Key string
 		}
 	}
-	lockCreate sync.RWMutex
+	lockDelete sync.RWMutex
 	lockGet    sync.RWMutex
-	lockGetAll sync.RWMutex
-	lockRemove sync.RWMutex
-	lockUpdate sync.RWMutex
+	lockPut    sync.RWMutex
+	lockStat   sync.RWMutex
 }
 
-// Create calls CreateFunc.
-func (mock *BinaryServiceMock) Create(ctx context.Context, data server.ReadableBinaryData) error {
+// Delete calls DeleteFunc.
+func (mock *BlobStoreMock) Delete(ctx context.Context, key string) error {
 	callInfo := struct {
-		Ctx  context.Context
-		Data server.ReadableBinaryData
+		Ctx context.Context
+		Key string
 	}{
-		Ctx:  ctx,
-		Data: data,
+		Ctx: ctx,
+		Key: key,
 	}
-	mock.lockCreate.Lock()
-	mock.calls.Create = append(mock.calls.Create, callInfo)
-	mock.lockCreate.Unlock()
-	if mock.CreateFunc == nil {
+	mock.lockDelete.Lock()
+	mock.calls.Delete = append(mock.calls.Delete, callInfo)
+	mock.lockDelete.Unlock()
+	if mock.DeleteFunc == nil {
 		var (
 			err error
 		)
 		return err
 	}
-	return mock.CreateFunc(ctx, data)
+	return mock.DeleteFunc(ctx, key)
 }
 
-// CreateCalls gets all the calls that were made to Create.
+// DeleteCalls gets all the calls that were made to Delete.
 // Check the length with:
 //
-//	len(mockedBinaryService.CreateCalls())
-func (mock *BinaryServiceMock) CreateCalls() []struct {
-	Ctx  context.Context
-	Data server.ReadableBinaryData
+//	len(mockedBlobStore.DeleteCalls())
+func (mock *BlobStoreMock) DeleteCalls() []struct {
+	Ctx context.Context
+	Key string
 } {
 	var calls []struct {
-		Ctx  context.Context
-		Data server.ReadableBinaryData
+		Ctx context.Context
+		Key string
 	}
-	mock.lockCreate.RLock()
-	calls = mock.calls.Create
-	mock.lockCreate.RUnlock()
+	mock.lockDelete.RLock()
+	calls = mock.calls.Delete
+	mock.lockDelete.RUnlock()
 	return calls
 }
 
 // Get calls GetFunc.
-func (mock *BinaryServiceMock) Get(ctx context.Context, id int64) (*server.ReadableBinaryData, error) {
+func (mock *BlobStoreMock) Get(ctx context.Context, key string) (io.ReadCloser, error) {
 	callInfo := struct {
 		Ctx context.Context
-		ID  int64
+		Key string
 	}{
 		Ctx: ctx,
-		ID:  id,
+		Key: key,
 	}
 	mock.lockGet.Lock()
 	mock.calls.Get = append(mock.calls.Get, callInfo)
 	mock.lockGet.Unlock()
 	if mock.GetFunc == nil {
 		var (
-			readableBinaryData *server.ReadableBinaryData
-			err                error
+			readCloser io.ReadCloser
+			err        error
 		)
-		return readableBinaryData, err
+		return readCloser, err
 	}
-	return mock.GetFunc(ctx, id)
+	return mock.GetFunc(ctx, key)
 }
 
 // GetCalls gets all the calls that were made to Get.
 // Check the length with:
 //
-//	len(mockedBinaryService.GetCalls())
-func (mock *BinaryServiceMock) GetCalls() []struct {
+//	len(mockedBlobStore.GetCalls())
+func (mock *BlobStoreMock) GetCalls() []struct {
 	Ctx context.Context
-	ID  int64
+	Key string
 } {
 	var calls []struct {
 		Ctx context.Context
-		ID  int64
+		Key string
 	}
 	mock.lockGet.RLock()
 	calls = mock.calls.Get
@@ -728,164 +1132,129 @@ func (mock *BinaryServiceMock) GetCalls() []struct {
 	return calls
 }
 
-// GetAll calls GetAllFunc.
-func (mock *BinaryServiceMock) GetAll(ctx context.Context) ([]server.BinaryData, error) {
+// Put calls PutFunc.
+func (mock *BlobStoreMock) Put(ctx context.Context, key string, r io.Reader) error {
 	callInfo := struct {
 		Ctx context.Context
+		Key string
+		R   io.Reader
 	}{
 		Ctx: ctx,
+		Key: key,
+		R:   r,
 	}
-	mock.lockGetAll.Lock()
-	mock.calls.GetAll = append(mock.calls.GetAll, callInfo)
-	mock.lockGetAll.Unlock()
-	if mock.GetAllFunc == nil {
+	mock.lockPut.Lock()
+	mock.calls.Put = append(mock.calls.Put, callInfo)
+	mock.lockPut.Unlock()
+	if mock.PutFunc == nil {
 		var (
-			binaryDatas []server.BinaryData
-			err         error
+			err error
 		)
-		return binaryDatas, err
+		return err
 	}
-	return mock.GetAllFunc(ctx)
+	return mock.PutFunc(ctx, key, r)
 }
 
-// GetAllCalls gets all the calls that were made to GetAll.
+// PutCalls gets all the calls that were made to Put.
 // Check the length with:
 //
-//	len(mockedBinaryService.GetAllCalls())
-func (mock *BinaryServiceMock) GetAllCalls() []struct {
+//	len(mockedBlobStore.PutCalls())
+func (mock *BlobStoreMock) PutCalls() []struct {
 	Ctx context.Context
+	Key string
+	R   io.Reader
 } {
 	var calls []struct {
 		Ctx context.Context
+		Key string
+		R   io.Reader
 	}
-	mock.lockGetAll.RLock()
-	calls = mock.calls.GetAll
-	mock.lockGetAll.RUnlock()
+	mock.lockPut.RLock()
+	calls = mock.calls.Put
+	mock.lockPut.RUnlock()
 	return calls
 }
 
-// Remove calls RemoveFunc.
-func (mock *BinaryServiceMock) Remove(ctx context.Context, id int64) error {
+// Stat calls StatFunc.
+func (mock *BlobStoreMock) Stat(ctx context.Context, key string) (server.BlobInfo, error) {
 	callInfo := struct {
 		Ctx context.Context
-		ID  int64
+		Key string
 	}{
 		Ctx: ctx,
-		ID:  id,
+		Key: key,
 	}
-	mock.lockRemove.Lock()
-	mock.calls.Remove = append(mock.calls.Remove, callInfo)
-	mock.lockRemove.Unlock()
-	if mock.RemoveFunc == nil {
+	mock.lockStat.Lock()
+	mock.calls.Stat = append(mock.calls.Stat, callInfo)
+	mock.lockStat.Unlock()
+	if mock.StatFunc == nil {
 		var (
-			err error
+			blobInfo server.BlobInfo
+			err      error
 		)
-		return err
+		return blobInfo, err
 	}
-	return mock.RemoveFunc(ctx, id)
+	return mock.StatFunc(ctx, key)
 }
 
-// RemoveCalls gets all the calls that were made to Remove.
+// StatCalls gets all the calls that were made to Stat.
 // Check the length with:
 //
-//	len(mockedBinaryService.RemoveCalls())
-func (mock *BinaryServiceMock) RemoveCalls() []struct {
+//	len(mockedBlobStore.StatCalls())
+func (mock *BlobStoreMock) StatCalls() []struct {
 	Ctx context.Context
-	ID  int64
+	Key string
 } {
 	var calls []struct {
 		Ctx context.Context
-		ID  int64
-	}
-	mock.lockRemove.RLock()
-	calls = mock.calls.Remove
-	mock.lockRemove.RUnlock()
-	return calls
-}
-
-// Update calls UpdateFunc.
-func (mock *BinaryServiceMock) Update(ctx context.Context, id int64, data server.BinaryDataUpdate) error {
-	callInfo := struct {
-		Ctx  context.Context
-		ID   int64
-		Data server.BinaryDataUpdate
-	}{
-		Ctx:  ctx,
-		ID:   id,
-		Data: data,
-	}
-	mock.lockUpdate.Lock()
-	mock.calls.Update = append(mock.calls.Update, callInfo)
-	mock.lockUpdate.Unlock()
-	if mock.UpdateFunc == nil {
-		var (
-			err error
-		)
-		return err
-	}
-	return mock.UpdateFunc(ctx, id, data)
-}
-
-// UpdateCalls gets all the calls that were made to Update.
-// Check the length with:
-//
-//	len(mockedBinaryService.UpdateCalls())
-func (mock *BinaryServiceMock) UpdateCalls() []struct {
-	Ctx  context.Context
-	ID   int64
-	Data server.BinaryDataUpdate
-} {
-	var calls []struct {
-		Ctx  context.Context
-		ID   int64
-		Data server.BinaryDataUpdate
+		Key string
 	}
-	mock.lockUpdate.RLock()
-	calls = mock.calls.Update
-	mock.lockUpdate.RUnlock()
+	mock.lockStat.RLock()
+	calls = mock.calls.Stat
+	mock.lockStat.RUnlock()
 	return calls
 }
 
-// Ensure that CardServiceMock does implement server.CardService.
+// Ensure that LoginServiceMock does implement server.LoginService.
 // If this is not the case, regenerate this file with mockery.
-var _ server.CardService = &CardServiceMock{}
+var _ server.LoginService = &LoginServiceMock{}
 
-// CardServiceMock is a mock implementation of server.CardService.
+// LoginServiceMock is a mock implementation of server.LoginService.
 //
-//	func TestSomethingThatUsesCardService(t *testing.T) {
+//	func TestSomethingThatUsesLoginService(t *testing.T) {
 //
-//		// make and configure a mocked server.CardService
-//		mockedCardService := &CardServiceMock{
-//			CreateFunc: func(ctx context.Context, data server.CardData) error {
+//		// make and configure a mocked server.LoginService
+//		mockedLoginService := &LoginServiceMock{
+//			CreateFunc: func(ctx context.Context, data server.LoginData) error {
 //				panic("mock out the Create method")
 //			},
-//			GetAllFunc: func(ctx context.Context) ([]server.CardData, error) {
+//			GetAllFunc: func(ctx context.Context) ([]server.LoginData, error) {
 //				panic("mock out the GetAll method")
 //			},
-//			RemoveFunc: func(ctx context.Context, id int64) error {
+//			RemoveFunc: func(ctx context.Context, id int64, version int64) error {
 //				panic("mock out the Remove method")
 //			},
-//			UpdateFunc: func(ctx context.Context, id int64, data server.CardDataUpdate) error {
+//			UpdateFunc: func(ctx context.Context, id int64, version int64, data server.LoginDataUpdate) error {
 //				panic("mock out the Update method")
 //			},
 //		}
 //
-//		// use mockedCardService in code that requires server.CardService
+//		// use mockedLoginService in code that requires server.LoginService
 //		// and then make assertions.
 //
 //	}
-type CardServiceMock struct {
+type LoginServiceMock struct {
 	// CreateFunc mocks the Create method.
-	CreateFunc func(ctx context.Context, data server.CardData) error
+	CreateFunc func(ctx context.Context, data server.LoginData) error
 
 	// GetAllFunc mocks the GetAll method.
-	GetAllFunc func(ctx context.Context) ([]server.CardData, error)
+	GetAllFunc func(ctx context.Context) ([]server.LoginData, error)
 
 	// RemoveFunc mocks the Remove method.
-	RemoveFunc func(ctx context.Context, id int64) error
+	RemoveFunc func(ctx context.Context, id int64, version int64) error
 
 	// UpdateFunc mocks the Update method.
-	UpdateFunc func(ctx context.Context, id int64, data server.CardDataUpdate) error
+	UpdateFunc func(ctx context.Context, id int64, version int64, data server.LoginDataUpdate) error
 
 	// calls tracks calls to the methods.
 	calls struct {
@@ -894,7 +1263,7 @@ type CardServiceMock struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 			// Data is the data argument value.
-			Data server.CardData
+			Data server.LoginData
 		}
 		// GetAll holds details about calls to the GetAll method.
 		GetAll []struct {
@@ -907,6 +1276,8 @@ type CardServiceMock struct {
 			Ctx context.Context
 			// ID is the id argument value.
 			ID int64
+			// Version is the version argument value.
+			Version int64
 		}
 		// Update holds details about calls to the Update method.
 		Update []struct {
@@ -914,8 +1285,10 @@ type CardServiceMock struct {
 			Ctx context.Context
 			// ID is the id argument value.
 			ID int64
+			// Version is the version argument value.
+			Version int64
 			// Data is the data argument value.
-			Data server.CardDataUpdate
+			Data server.LoginDataUpdate
 		}
 	}
 	lockCreate sync.RWMutex
@@ -925,10 +1298,10 @@ type CardServiceMock struct {
 }
 
 // Create calls CreateFunc.
-func (mock *CardServiceMock) Create(ctx context.Context, data server.CardData) error {
+func (mock *LoginServiceMock) Create(ctx context.Context, data server.LoginData) error {
 	callInfo := struct {
 		Ctx  context.Context
-		Data server.CardData
+		Data server.LoginData
 	}{
 		Ctx:  ctx,
 		Data: data,
@@ -942,195 +1315,2686 @@ func (mock *CardServiceMock) Create(ctx context.Context, data server.CardData) e
 		)
 		return err
 	}
-	return mock.CreateFunc(ctx, data)
+	return mock.CreateFunc(ctx, data)
+}
+
+// CreateCalls gets all the calls that were made to Create.
+// Check the length with:
+//
+//	len(mockedLoginService.CreateCalls())
+func (mock *LoginServiceMock) CreateCalls() []struct {
+	Ctx  context.Context
+	Data server.LoginData
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Data server.LoginData
+	}
+	mock.lockCreate.RLock()
+	calls = mock.calls.Create
+	mock.lockCreate.RUnlock()
+	return calls
+}
+
+// GetAll calls GetAllFunc.
+func (mock *LoginServiceMock) GetAll(ctx context.Context) ([]server.LoginData, error) {
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockGetAll.Lock()
+	mock.calls.GetAll = append(mock.calls.GetAll, callInfo)
+	mock.lockGetAll.Unlock()
+	if mock.GetAllFunc == nil {
+		var (
+			loginDatas []server.LoginData
+			err        error
+		)
+		return loginDatas, err
+	}
+	return mock.GetAllFunc(ctx)
+}
+
+// GetAllCalls gets all the calls that were made to GetAll.
+// Check the length with:
+//
+//	len(mockedLoginService.GetAllCalls())
+func (mock *LoginServiceMock) GetAllCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockGetAll.RLock()
+	calls = mock.calls.GetAll
+	mock.lockGetAll.RUnlock()
+	return calls
+}
+
+// Remove calls RemoveFunc.
+func (mock *LoginServiceMock) Remove(ctx context.Context, id int64, version int64) error {
+	callInfo := struct {
+		Ctx     context.Context
+		ID      int64
+		Version int64
+	}{
+		Ctx:     ctx,
+		ID:      id,
+		Version: version,
+	}
+	mock.lockRemove.Lock()
+	mock.calls.Remove = append(mock.calls.Remove, callInfo)
+	mock.lockRemove.Unlock()
+	if mock.RemoveFunc == nil {
+		var (
+			err error
+		)
+		return err
+	}
+	return mock.RemoveFunc(ctx, id, version)
+}
+
+// RemoveCalls gets all the calls that were made to Remove.
+// Check the length with:
+//
+//	len(mockedLoginService.RemoveCalls())
+func (mock *LoginServiceMock) RemoveCalls() []struct {
+	Ctx     context.Context
+	ID      int64
+	Version int64
+} {
+	var calls []struct {
+		Ctx     context.Context
+		ID      int64
+		Version int64
+	}
+	mock.lockRemove.RLock()
+	calls = mock.calls.Remove
+	mock.lockRemove.RUnlock()
+	return calls
+}
+
+// Update calls UpdateFunc.
+func (mock *LoginServiceMock) Update(ctx context.Context, id int64, version int64, data server.LoginDataUpdate) error {
+	callInfo := struct {
+		Ctx     context.Context
+		ID      int64
+		Version int64
+		Data    server.LoginDataUpdate
+	}{
+		Ctx:     ctx,
+		ID:      id,
+		Version: version,
+		Data:    data,
+	}
+	mock.lockUpdate.Lock()
+	mock.calls.Update = append(mock.calls.Update, callInfo)
+	mock.lockUpdate.Unlock()
+	if mock.UpdateFunc == nil {
+		var (
+			err error
+		)
+		return err
+	}
+	return mock.UpdateFunc(ctx, id, version, data)
+}
+
+// UpdateCalls gets all the calls that were made to Update.
+// Check the length with:
+//
+//	len(mockedLoginService.UpdateCalls())
+func (mock *LoginServiceMock) UpdateCalls() []struct {
+	Ctx     context.Context
+	ID      int64
+	Version int64
+	Data    server.LoginDataUpdate
+} {
+	var calls []struct {
+		Ctx     context.Context
+		ID      int64
+		Version int64
+		Data    server.LoginDataUpdate
+	}
+	mock.lockUpdate.RLock()
+	calls = mock.calls.Update
+	mock.lockUpdate.RUnlock()
+	return calls
+}
+
+// Ensure that NoteServiceMock does implement server.NoteService.
+// If this is not the case, regenerate this file with mockery.
+var _ server.NoteService = &NoteServiceMock{}
+
+// NoteServiceMock is a mock implementation of server.NoteService.
+//
+//	func TestSomethingThatUsesNoteService(t *testing.T) {
+//
+//		// make and configure a mocked server.NoteService
+//		mockedNoteService := &NoteServiceMock{
+//			CreateFunc: func(ctx context.Context, data server.NoteData) error {
+//				panic("mock out the Create method")
+//			},
+//			GetAllFunc: func(ctx context.Context) ([]server.NoteData, error) {
+//				panic("mock out the GetAll method")
+//			},
+//			RemoveFunc: func(ctx context.Context, id int64, version int64) error {
+//				panic("mock out the Remove method")
+//			},
+//			UpdateFunc: func(ctx context.Context, id int64, version int64, data server.NoteDataUpdate) error {
+//				panic("mock out the Update method")
+//			},
+//		}
+//
+//		// use mockedNoteService in code that requires server.NoteService
+//		// and then make assertions.
+//
+//	}
+type NoteServiceMock struct {
+	// CreateFunc mocks the Create method.
+	CreateFunc func(ctx context.Context, data server.NoteData) error
+
+	// GetAllFunc mocks the GetAll method.
+	GetAllFunc func(ctx context.Context) ([]server.NoteData, error)
+
+	// RemoveFunc mocks the Remove method.
+	RemoveFunc func(ctx context.Context, id int64, version int64) error
+
+	// UpdateFunc mocks the Update method.
+	UpdateFunc func(ctx context.Context, id int64, version int64, data server.NoteDataUpdate) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Create holds details about calls to the Create method.
+		Create []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Data is the data argument value.
+			Data server.NoteData
+		}
+		// GetAll holds details about calls to the GetAll method.
+		GetAll []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// Remove holds details about calls to the Remove method.
+		Remove []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID int64
+			// Version is the version argument value.
+			Version int64
+		}
+		// Update holds details about calls to the Update method.
+		Update []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID int64
+			// Version is the version argument value.
+			Version int64
+			// Data is the data argument value.
+			Data server.NoteDataUpdate
+		}
+	}
+	lockCreate sync.RWMutex
+	lockGetAll sync.RWMutex
+	lockRemove sync.RWMutex
+	lockUpdate sync.RWMutex
+}
+
+// Create calls CreateFunc.
+func (mock *NoteServiceMock) Create(ctx context.Context, data server.NoteData) error {
+	callInfo := struct {
+		Ctx  context.Context
+		Data server.NoteData
+	}{
+		Ctx:  ctx,
+		Data: data,
+	}
+	mock.lockCreate.Lock()
+	mock.calls.Create = append(mock.calls.Create, callInfo)
+	mock.lockCreate.Unlock()
+	if mock.CreateFunc == nil {
+		var (
+			err error
+		)
+		return err
+	}
+	return mock.CreateFunc(ctx, data)
+}
+
+// CreateCalls gets all the calls that were made to Create.
+// Check the length with:
+//
+//	len(mockedNoteService.CreateCalls())
+func (mock *NoteServiceMock) CreateCalls() []struct {
+	Ctx  context.Context
+	Data server.NoteData
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Data server.NoteData
+	}
+	mock.lockCreate.RLock()
+	calls = mock.calls.Create
+	mock.lockCreate.RUnlock()
+	return calls
+}
+
+// GetAll calls GetAllFunc.
+func (mock *NoteServiceMock) GetAll(ctx context.Context) ([]server.NoteData, error) {
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockGetAll.Lock()
+	mock.calls.GetAll = append(mock.calls.GetAll, callInfo)
+	mock.lockGetAll.Unlock()
+	if mock.GetAllFunc == nil {
+		var (
+			noteDatas []server.NoteData
+			err       error
+		)
+		return noteDatas, err
+	}
+	return mock.GetAllFunc(ctx)
+}
+
+// GetAllCalls gets all the calls that were made to GetAll.
+// Check the length with:
+//
+//	len(mockedNoteService.GetAllCalls())
+func (mock *NoteServiceMock) GetAllCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockGetAll.RLock()
+	calls = mock.calls.GetAll
+	mock.lockGetAll.RUnlock()
+	return calls
+}
+
+// Remove calls RemoveFunc.
+func (mock *NoteServiceMock) Remove(ctx context.Context, id int64, version int64) error {
+	callInfo := struct {
+		Ctx     context.Context
+		ID      int64
+		Version int64
+	}{
+		Ctx:     ctx,
+		ID:      id,
+		Version: version,
+	}
+	mock.lockRemove.Lock()
+	mock.calls.Remove = append(mock.calls.Remove, callInfo)
+	mock.lockRemove.Unlock()
+	if mock.RemoveFunc == nil {
+		var (
+			err error
+		)
+		return err
+	}
+	return mock.RemoveFunc(ctx, id, version)
+}
+
+// RemoveCalls gets all the calls that were made to Remove.
+// Check the length with:
+//
+//	len(mockedNoteService.RemoveCalls())
+func (mock *NoteServiceMock) RemoveCalls() []struct {
+	Ctx     context.Context
+	ID      int64
+	Version int64
+} {
+	var calls []struct {
+		Ctx     context.Context
+		ID      int64
+		Version int64
+	}
+	mock.lockRemove.RLock()
+	calls = mock.calls.Remove
+	mock.lockRemove.RUnlock()
+	return calls
+}
+
+// Update calls UpdateFunc.
+func (mock *NoteServiceMock) Update(ctx context.Context, id int64, version int64, data server.NoteDataUpdate) error {
+	callInfo := struct {
+		Ctx     context.Context
+		ID      int64
+		Version int64
+		Data    server.NoteDataUpdate
+	}{
+		Ctx:     ctx,
+		ID:      id,
+		Version: version,
+		Data:    data,
+	}
+	mock.lockUpdate.Lock()
+	mock.calls.Update = append(mock.calls.Update, callInfo)
+	mock.lockUpdate.Unlock()
+	if mock.UpdateFunc == nil {
+		var (
+			err error
+		)
+		return err
+	}
+	return mock.UpdateFunc(ctx, id, version, data)
+}
+
+// UpdateCalls gets all the calls that were made to Update.
+// Check the length with:
+//
+//	len(mockedNoteService.UpdateCalls())
+func (mock *NoteServiceMock) UpdateCalls() []struct {
+	Ctx     context.Context
+	ID      int64
+	Version int64
+	Data    server.NoteDataUpdate
+} {
+	var calls []struct {
+		Ctx     context.Context
+		ID      int64
+		Version int64
+		Data    server.NoteDataUpdate
+	}
+	mock.lockUpdate.RLock()
+	calls = mock.calls.Update
+	mock.lockUpdate.RUnlock()
+	return calls
+}
+
+// Ensure that BinaryServiceMock does implement server.BinaryService.
+// If this is not the case, regenerate this file with mockery.
+var _ server.BinaryService = &BinaryServiceMock{}
+
+// BinaryServiceMock is a mock implementation of server.BinaryService.
+//
+//	func TestSomethingThatUsesBinaryService(t *testing.T) {
+//
+//		// make and configure a mocked server.BinaryService
+//		mockedBinaryService := &BinaryServiceMock{
+//			CreateFunc: func(ctx context.Context, data server.ReadableBinaryData) error {
+//				panic("mock out the Create method")
+//			},
+//			GetFunc: func(ctx context.Context, id int64) (*server.ReadableBinaryData, error) {
+//				panic("mock out the Get method")
+//			},
+//			GetAllFunc: func(ctx context.Context) ([]server.BinaryData, error) {
+//				panic("mock out the GetAll method")
+//			},
+//			RemoveFunc: func(ctx context.Context, id int64, version int64) error {
+//				panic("mock out the Remove method")
+//			},
+//			UpdateFunc: func(ctx context.Context, id int64, version int64, data server.BinaryDataUpdate) error {
+//				panic("mock out the Update method")
+//			},
+//		}
+//
+//		// use mockedBinaryService in code that requires server.BinaryService
+//		// and then make assertions.
+//
+//	}
+type BinaryServiceMock struct {
+	// CreateFunc mocks the Create method.
+	CreateFunc func(ctx context.Context, data server.ReadableBinaryData) error
+
+	// GetFunc mocks the Get method.
+	GetFunc func(ctx context.Context, id int64) (*server.ReadableBinaryData, error)
+
+	// GetAllFunc mocks the GetAll method.
+	GetAllFunc func(ctx context.Context) ([]server.BinaryData, error)
+
+	// RemoveFunc mocks the Remove method.
+	RemoveFunc func(ctx context.Context, id int64, version int64) error
+
+	// UpdateFunc mocks the Update method.
+	UpdateFunc func(ctx context.Context, id int64, version int64, data server.BinaryDataUpdate) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Create holds details about calls to the Create method.
+		Create []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Data is the data argument value.
+			Data server.ReadableBinaryData
+		}
+		// Get holds details about calls to the Get method.
+		Get []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID int64
+		}
+		// GetAll holds details about calls to the GetAll method.
+		GetAll []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// Remove holds details about calls to the Remove method.
+		Remove []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID int64
+			// Version is the version argument value.
+			Version int64
+		}
+		// Update holds details about calls to the Update method.
+		Update []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID int64
+			// Version is the version argument value.
+			Version int64
+			// Data is the data argument value.
+			Data server.BinaryDataUpdate
+		}
+	}
+	lockCreate sync.RWMutex
+	lockGet    sync.RWMutex
+	lockGetAll sync.RWMutex
+	lockRemove sync.RWMutex
+	lockUpdate sync.RWMutex
+}
+
+// Create calls CreateFunc.
+func (mock *BinaryServiceMock) Create(ctx context.Context, data server.ReadableBinaryData) error {
+	callInfo := struct {
+		Ctx  context.Context
+		Data server.ReadableBinaryData
+	}{
+		Ctx:  ctx,
+		Data: data,
+	}
+	mock.lockCreate.Lock()
+	mock.calls.Create = append(mock.calls.Create, callInfo)
+	mock.lockCreate.Unlock()
+	if mock.CreateFunc == nil {
+		var (
+			err error
+		)
+		return err
+	}
+	return mock.CreateFunc(ctx, data)
+}
+
+// CreateCalls gets all the calls that were made to Create.
+// Check the length with:
+//
+//	len(mockedBinaryService.CreateCalls())
+func (mock *BinaryServiceMock) CreateCalls() []struct {
+	Ctx  context.Context
+	Data server.ReadableBinaryData
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Data server.ReadableBinaryData
+	}
+	mock.lockCreate.RLock()
+	calls = mock.calls.Create
+	mock.lockCreate.RUnlock()
+	return calls
+}
+
+// Get calls GetFunc.
+func (mock *BinaryServiceMock) Get(ctx context.Context, id int64) (*server.ReadableBinaryData, error) {
+	callInfo := struct {
+		Ctx context.Context
+		ID  int64
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockGet.Lock()
+	mock.calls.Get = append(mock.calls.Get, callInfo)
+	mock.lockGet.Unlock()
+	if mock.GetFunc == nil {
+		var (
+			readableBinaryData *server.ReadableBinaryData
+			err                error
+		)
+		return readableBinaryData, err
+	}
+	return mock.GetFunc(ctx, id)
+}
+
+// GetCalls gets all the calls that were made to Get.
+// Check the length with:
+//
+//	len(mockedBinaryService.GetCalls())
+func (mock *BinaryServiceMock) GetCalls() []struct {
+	Ctx context.Context
+	ID  int64
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  int64
+	}
+	mock.lockGet.RLock()
+	calls = mock.calls.Get
+	mock.lockGet.RUnlock()
+	return calls
+}
+
+// GetAll calls GetAllFunc.
+func (mock *BinaryServiceMock) GetAll(ctx context.Context) ([]server.BinaryData, error) {
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockGetAll.Lock()
+	mock.calls.GetAll = append(mock.calls.GetAll, callInfo)
+	mock.lockGetAll.Unlock()
+	if mock.GetAllFunc == nil {
+		var (
+			binaryDatas []server.BinaryData
+			err         error
+		)
+		return binaryDatas, err
+	}
+	return mock.GetAllFunc(ctx)
+}
+
+// GetAllCalls gets all the calls that were made to GetAll.
+// Check the length with:
+//
+//	len(mockedBinaryService.GetAllCalls())
+func (mock *BinaryServiceMock) GetAllCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockGetAll.RLock()
+	calls = mock.calls.GetAll
+	mock.lockGetAll.RUnlock()
+	return calls
+}
+
+// Remove calls RemoveFunc.
+func (mock *BinaryServiceMock) Remove(ctx context.Context, id int64, version int64) error {
+	callInfo := struct {
+		Ctx     context.Context
+		ID      int64
+		Version int64
+	}{
+		Ctx:     ctx,
+		ID:      id,
+		Version: version,
+	}
+	mock.lockRemove.Lock()
+	mock.calls.Remove = append(mock.calls.Remove, callInfo)
+	mock.lockRemove.Unlock()
+	if mock.RemoveFunc == nil {
+		var (
+			err error
+		)
+		return err
+	}
+	return mock.RemoveFunc(ctx, id, version)
+}
+
+// RemoveCalls gets all the calls that were made to Remove.
+// Check the length with:
+//
+//	len(mockedBinaryService.RemoveCalls())
+func (mock *BinaryServiceMock) RemoveCalls() []struct {
+	Ctx     context.Context
+	ID      int64
+	Version int64
+} {
+	var calls []struct {
+		Ctx     context.Context
+		ID      int64
+		Version int64
+	}
+	mock.lockRemove.RLock()
+	calls = mock.calls.Remove
+	mock.lockRemove.RUnlock()
+	return calls
+}
+
+// Update calls UpdateFunc.
+func (mock *BinaryServiceMock) Update(ctx context.Context, id int64, version int64, data server.BinaryDataUpdate) error {
+	callInfo := struct {
+		Ctx     context.Context
+		ID      int64
+		Version int64
+		Data    server.BinaryDataUpdate
+	}{
+		Ctx:     ctx,
+		ID:      id,
+		Version: version,
+		Data:    data,
+	}
+	mock.lockUpdate.Lock()
+	mock.calls.Update = append(mock.calls.Update, callInfo)
+	mock.lockUpdate.Unlock()
+	if mock.UpdateFunc == nil {
+		var (
+			err error
+		)
+		return err
+	}
+	return mock.UpdateFunc(ctx, id, version, data)
+}
+
+// UpdateCalls gets all the calls that were made to Update.
+// Check the length with:
+//
+//	len(mockedBinaryService.UpdateCalls())
+func (mock *BinaryServiceMock) UpdateCalls() []struct {
+	Ctx     context.Context
+	ID      int64
+	Version int64
+	Data    server.BinaryDataUpdate
+} {
+	var calls []struct {
+		Ctx     context.Context
+		ID      int64
+		Version int64
+		Data    server.BinaryDataUpdate
+	}
+	mock.lockUpdate.RLock()
+	calls = mock.calls.Update
+	mock.lockUpdate.RUnlock()
+	return calls
+}
+
+// Ensure that CardServiceMock does implement server.CardService.
+// If this is not the case, regenerate this file with mockery.
+var _ server.CardService = &CardServiceMock{}
+
+// CardServiceMock is a mock implementation of server.CardService.
+//
+//	func TestSomethingThatUsesCardService(t *testing.T) {
+//
+//		// make and configure a mocked server.CardService
+//		mockedCardService := &CardServiceMock{
+//			CreateFunc: func(ctx context.Context, data server.CardData) error {
+//				panic("mock out the Create method")
+//			},
+//			GetAllFunc: func(ctx context.Context) ([]server.CardData, error) {
+//				panic("mock out the GetAll method")
+//			},
+//			RemoveFunc: func(ctx context.Context, id int64, version int64) error {
+//				panic("mock out the Remove method")
+//			},
+//			UpdateFunc: func(ctx context.Context, id int64, version int64, data server.CardDataUpdate) error {
+//				panic("mock out the Update method")
+//			},
+//		}
+//
+//		// use mockedCardService in code that requires server.CardService
+//		// and then make assertions.
+//
+//	}
+type CardServiceMock struct {
+	// CreateFunc mocks the Create method.
+	CreateFunc func(ctx context.Context, data server.CardData) error
+
+	// GetAllFunc mocks the GetAll method.
+	GetAllFunc func(ctx context.Context) ([]server.CardData, error)
+
+	// RemoveFunc mocks the Remove method.
+	RemoveFunc func(ctx context.Context, id int64, version int64) error
+
+	// UpdateFunc mocks the Update method.
+	UpdateFunc func(ctx context.Context, id int64, version int64, data server.CardDataUpdate) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Create holds details about calls to the Create method.
+		Create []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Data is the data argument value.
+			Data server.CardData
+		}
+		// GetAll holds details about calls to the GetAll method.
+		GetAll []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// Remove holds details about calls to the Remove method.
+		Remove []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID int64
+			// Version is the version argument value.
+			Version int64
+		}
+		// Update holds details about calls to the Update method.
+		Update []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID int64
+			// Version is the version argument value.
+			Version int64
+			// Data is the data argument value.
+			Data server.CardDataUpdate
+		}
+	}
+	lockCreate sync.RWMutex
+	lockGetAll sync.RWMutex
+	lockRemove sync.RWMutex
+	lockUpdate sync.RWMutex
+}
+
+// Create calls CreateFunc.
+func (mock *CardServiceMock) Create(ctx context.Context, data server.CardData) error {
+	callInfo := struct {
+		Ctx  context.Context
+		Data server.CardData
+	}{
+		Ctx:  ctx,
+		Data: data,
+	}
+	mock.lockCreate.Lock()
+	mock.calls.Create = append(mock.calls.Create, callInfo)
+	mock.lockCreate.Unlock()
+	if mock.CreateFunc == nil {
+		var (
+			err error
+		)
+		return err
+	}
+	return mock.CreateFunc(ctx, data)
+}
+
+// CreateCalls gets all the calls that were made to Create.
+// Check the length with:
+//
+//	len(mockedCardService.CreateCalls())
+func (mock *CardServiceMock) CreateCalls() []struct {
+	Ctx  context.Context
+	Data server.CardData
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Data server.CardData
+	}
+	mock.lockCreate.RLock()
+	calls = mock.calls.Create
+	mock.lockCreate.RUnlock()
+	return calls
+}
+
+// GetAll calls GetAllFunc.
+func (mock *CardServiceMock) GetAll(ctx context.Context) ([]server.CardData, error) {
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockGetAll.Lock()
+	mock.calls.GetAll = append(mock.calls.GetAll, callInfo)
+	mock.lockGetAll.Unlock()
+	if mock.GetAllFunc == nil {
+		var (
+			cardDatas []server.CardData
+			err       error
+		)
+		return cardDatas, err
+	}
+	return mock.GetAllFunc(ctx)
+}
+
+// GetAllCalls gets all the calls that were made to GetAll.
+// Check the length with:
+//
+//	len(mockedCardService.GetAllCalls())
+func (mock *CardServiceMock) GetAllCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockGetAll.RLock()
+	calls = mock.calls.GetAll
+	mock.lockGetAll.RUnlock()
+	return calls
+}
+
+// Remove calls RemoveFunc.
+func (mock *CardServiceMock) Remove(ctx context.Context, id int64, version int64) error {
+	callInfo := struct {
+		Ctx     context.Context
+		ID      int64
+		Version int64
+	}{
+		Ctx:     ctx,
+		ID:      id,
+		Version: version,
+	}
+	mock.lockRemove.Lock()
+	mock.calls.Remove = append(mock.calls.Remove, callInfo)
+	mock.lockRemove.Unlock()
+	if mock.RemoveFunc == nil {
+		var (
+			err error
+		)
+		return err
+	}
+	return mock.RemoveFunc(ctx, id, version)
+}
+
+// RemoveCalls gets all the calls that were made to Remove.
+// Check the length with:
+//
+//	len(mockedCardService.RemoveCalls())
+func (mock *CardServiceMock) RemoveCalls() []struct {
+	Ctx     context.Context
+	ID      int64
+	Version int64
+} {
+	var calls []struct {
+		Ctx     context.Context
+		ID      int64
+		Version int64
+	}
+	mock.lockRemove.RLock()
+	calls = mock.calls.Remove
+	mock.lockRemove.RUnlock()
+	return calls
+}
+
+// Update calls UpdateFunc.
+func (mock *CardServiceMock) Update(ctx context.Context, id int64, version int64, data server.CardDataUpdate) error {
+	callInfo := struct {
+		Ctx     context.Context
+		ID      int64
+		Version int64
+		Data    server.CardDataUpdate
+	}{
+		Ctx:     ctx,
+		ID:      id,
+		Version: version,
+		Data:    data,
+	}
+	mock.lockUpdate.Lock()
+	mock.calls.Update = append(mock.calls.Update, callInfo)
+	mock.lockUpdate.Unlock()
+	if mock.UpdateFunc == nil {
+		var (
+			err error
+		)
+		return err
+	}
+	return mock.UpdateFunc(ctx, id, version, data)
+}
+
+// UpdateCalls gets all the calls that were made to Update.
+// Check the length with:
+//
+//	len(mockedCardService.UpdateCalls())
+func (mock *CardServiceMock) UpdateCalls() []struct {
+	Ctx     context.Context
+	ID      int64
+	Version int64
+	Data    server.CardDataUpdate
+} {
+	var calls []struct {
+		Ctx     context.Context
+		ID      int64
+		Version int64
+		Data    server.CardDataUpdate
+	}
+	mock.lockUpdate.RLock()
+	calls = mock.calls.Update
+	mock.lockUpdate.RUnlock()
+	return calls
+}
+
+// Ensure that OTPServiceMock does implement server.OTPService.
+// If this is not the case, regenerate this file with mockery.
+var _ server.OTPService = &OTPServiceMock{}
+
+// OTPServiceMock is a mock implementation of server.OTPService.
+//
+//	func TestSomethingThatUsesOTPService(t *testing.T) {
+//
+//		// make and configure a mocked server.OTPService
+//		mockedOTPService := &OTPServiceMock{
+//			CreateFunc: func(ctx context.Context, data server.OTPData) error {
+//				panic("mock out the Create method")
+//			},
+//			GetAllFunc: func(ctx context.Context) ([]server.OTPData, error) {
+//				panic("mock out the GetAll method")
+//			},
+//			RemoveFunc: func(ctx context.Context, id int64, version int64) error {
+//				panic("mock out the Remove method")
+//			},
+//			UpdateFunc: func(ctx context.Context, id int64, version int64, data server.OTPDataUpdate) error {
+//				panic("mock out the Update method")
+//			},
+//		}
+//
+//		// use mockedOTPService in code that requires server.OTPService
+//		// and then make assertions.
+//
+//	}
+type OTPServiceMock struct {
+	// CreateFunc mocks the Create method.
+	CreateFunc func(ctx context.Context, data server.OTPData) error
+
+	// GetAllFunc mocks the GetAll method.
+	GetAllFunc func(ctx context.Context) ([]server.OTPData, error)
+
+	// RemoveFunc mocks the Remove method.
+	RemoveFunc func(ctx context.Context, id int64, version int64) error
+
+	// UpdateFunc mocks the Update method.
+	UpdateFunc func(ctx context.Context, id int64, version int64, data server.OTPDataUpdate) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Create holds details about calls to the Create method.
+		Create []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Data is the data argument value.
+			Data server.OTPData
+		}
+		// GetAll holds details about calls to the GetAll method.
+		GetAll []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// Remove holds details about calls to the Remove method.
+		Remove []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID int64
+			// Version is the version argument value.
+			Version int64
+		}
+		// Update holds details about calls to the Update method.
+		Update []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID int64
+			// Version is the version argument value.
+			Version int64
+			// Data is the data argument value.
+			Data server.OTPDataUpdate
+		}
+	}
+	lockCreate sync.RWMutex
+	lockGetAll sync.RWMutex
+	lockRemove sync.RWMutex
+	lockUpdate sync.RWMutex
+}
+
+// Create calls CreateFunc.
+func (mock *OTPServiceMock) Create(ctx context.Context, data server.OTPData) error {
+	callInfo := struct {
+		Ctx  context.Context
+		Data server.OTPData
+	}{
+		Ctx:  ctx,
+		Data: data,
+	}
+	mock.lockCreate.Lock()
+	mock.calls.Create = append(mock.calls.Create, callInfo)
+	mock.lockCreate.Unlock()
+	if mock.CreateFunc == nil {
+		var (
+			err error
+		)
+		return err
+	}
+	return mock.CreateFunc(ctx, data)
+}
+
+// CreateCalls gets all the calls that were made to Create.
+// Check the length with:
+//
+//	len(mockedOTPService.CreateCalls())
+func (mock *OTPServiceMock) CreateCalls() []struct {
+	Ctx  context.Context
+	Data server.OTPData
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Data server.OTPData
+	}
+	mock.lockCreate.RLock()
+	calls = mock.calls.Create
+	mock.lockCreate.RUnlock()
+	return calls
+}
+
+// GetAll calls GetAllFunc.
+func (mock *OTPServiceMock) GetAll(ctx context.Context) ([]server.OTPData, error) {
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockGetAll.Lock()
+	mock.calls.GetAll = append(mock.calls.GetAll, callInfo)
+	mock.lockGetAll.Unlock()
+	if mock.GetAllFunc == nil {
+		var (
+			oTPDatas []server.OTPData
+			err      error
+		)
+		return oTPDatas, err
+	}
+	return mock.GetAllFunc(ctx)
+}
+
+// GetAllCalls gets all the calls that were made to GetAll.
+// Check the length with:
+//
+//	len(mockedOTPService.GetAllCalls())
+func (mock *OTPServiceMock) GetAllCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockGetAll.RLock()
+	calls = mock.calls.GetAll
+	mock.lockGetAll.RUnlock()
+	return calls
+}
+
+// Remove calls RemoveFunc.
+func (mock *OTPServiceMock) Remove(ctx context.Context, id int64, version int64) error {
+	callInfo := struct {
+		Ctx     context.Context
+		ID      int64
+		Version int64
+	}{
+		Ctx:     ctx,
+		ID:      id,
+		Version: version,
+	}
+	mock.lockRemove.Lock()
+	mock.calls.Remove = append(mock.calls.Remove, callInfo)
+	mock.lockRemove.Unlock()
+	if mock.RemoveFunc == nil {
+		var (
+			err error
+		)
+		return err
+	}
+	return mock.RemoveFunc(ctx, id, version)
+}
+
+// RemoveCalls gets all the calls that were made to Remove.
+// Check the length with:
+//
+//	len(mockedOTPService.RemoveCalls())
+func (mock *OTPServiceMock) RemoveCalls() []struct {
+	Ctx     context.Context
+	ID      int64
+	Version int64
+} {
+	var calls []struct {
+		Ctx     context.Context
+		ID      int64
+		Version int64
+	}
+	mock.lockRemove.RLock()
+	calls = mock.calls.Remove
+	mock.lockRemove.RUnlock()
+	return calls
+}
+
+// Update calls UpdateFunc.
+func (mock *OTPServiceMock) Update(ctx context.Context, id int64, version int64, data server.OTPDataUpdate) error {
+	callInfo := struct {
+		Ctx     context.Context
+		ID      int64
+		Version int64
+		Data    server.OTPDataUpdate
+	}{
+		Ctx:     ctx,
+		ID:      id,
+		Version: version,
+		Data:    data,
+	}
+	mock.lockUpdate.Lock()
+	mock.calls.Update = append(mock.calls.Update, callInfo)
+	mock.lockUpdate.Unlock()
+	if mock.UpdateFunc == nil {
+		var (
+			err error
+		)
+		return err
+	}
+	return mock.UpdateFunc(ctx, id, version, data)
+}
+
+// UpdateCalls gets all the calls that were made to Update.
+// Check the length with:
+//
+//	len(mockedOTPService.UpdateCalls())
+func (mock *OTPServiceMock) UpdateCalls() []struct {
+	Ctx     context.Context
+	ID      int64
+	Version int64
+	Data    server.OTPDataUpdate
+} {
+	var calls []struct {
+		Ctx     context.Context
+		ID      int64
+		Version int64
+		Data    server.OTPDataUpdate
+	}
+	mock.lockUpdate.RLock()
+	calls = mock.calls.Update
+	mock.lockUpdate.RUnlock()
+	return calls
+}
+
+// Ensure that HistoryServiceMock does implement server.HistoryService.
+// If this is not the case, regenerate this file with mockery.
+var _ server.HistoryService = &HistoryServiceMock{}
+
+// HistoryServiceMock is a mock implementation of server.HistoryService.
+//
+//	func TestSomethingThatUsesHistoryService(t *testing.T) {
+//
+//		// make and configure a mocked server.HistoryService
+//		mockedHistoryService := &HistoryServiceMock{
+//			ListFunc: func(ctx context.Context, kind server.DataKind, id int64) ([]server.HistoryEntry, error) {
+//				panic("mock out the List method")
+//			},
+//			RestoreFunc: func(ctx context.Context, kind server.DataKind, id int64, version int64) error {
+//				panic("mock out the Restore method")
+//			},
+//		}
+//
+//		// use mockedHistoryService in code that requires server.HistoryService
+//		// and then make assertions.
+//
+//	}
+type HistoryServiceMock struct {
+	// ListFunc mocks the List method.
+	ListFunc func(ctx context.Context, kind server.DataKind, id int64) ([]server.HistoryEntry, error)
+
+	// RestoreFunc mocks the Restore method.
+	RestoreFunc func(ctx context.Context, kind server.DataKind, id int64, version int64) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// List holds details about calls to the List method.
+		List []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Kind is the kind argument value.
+			Kind server.DataKind
+			// ID is the id argument value.
+			ID int64
+		}
+		// Restore holds details about calls to the Restore method.
+		Restore []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Kind is the kind argument value.
+			Kind server.DataKind
+			// ID is the id argument value.
+			ID int64
+			// Version is the version argument value.
+			Version int64
+		}
+	}
+	lockList    sync.RWMutex
+	lockRestore sync.RWMutex
+}
+
+// List calls ListFunc.
+func (mock *HistoryServiceMock) List(ctx context.Context, kind server.DataKind, id int64) ([]server.HistoryEntry, error) {
+	callInfo := struct {
+		Ctx  context.Context
+		Kind server.DataKind
+		ID   int64
+	}{
+		Ctx:  ctx,
+		Kind: kind,
+		ID:   id,
+	}
+	mock.lockList.Lock()
+	mock.calls.List = append(mock.calls.List, callInfo)
+	mock.lockList.Unlock()
+	if mock.ListFunc == nil {
+		var (
+			historyEntrys []server.HistoryEntry
+			err           error
+		)
+		return historyEntrys, err
+	}
+	return mock.ListFunc(ctx, kind, id)
+}
+
+// ListCalls gets all the calls that were made to List.
+// Check the length with:
+//
+//	len(mockedHistoryService.ListCalls())
+func (mock *HistoryServiceMock) ListCalls() []struct {
+	Ctx  context.Context
+	Kind server.DataKind
+	ID   int64
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Kind server.DataKind
+		ID   int64
+	}
+	mock.lockList.RLock()
+	calls = mock.calls.List
+	mock.lockList.RUnlock()
+	return calls
+}
+
+// Restore calls RestoreFunc.
+func (mock *HistoryServiceMock) Restore(ctx context.Context, kind server.DataKind, id int64, version int64) error {
+	callInfo := struct {
+		Ctx     context.Context
+		Kind    server.DataKind
+		ID      int64
+		Version int64
+	}{
+		Ctx:     ctx,
+		Kind:    kind,
+		ID:      id,
+		Version: version,
+	}
+	mock.lockRestore.Lock()
+	mock.calls.Restore = append(mock.calls.Restore, callInfo)
+	mock.lockRestore.Unlock()
+	if mock.RestoreFunc == nil {
+		var (
+			err error
+		)
+		return err
+	}
+	return mock.RestoreFunc(ctx, kind, id, version)
+}
+
+// RestoreCalls gets all the calls that were made to Restore.
+// Check the length with:
+//
+//	len(mockedHistoryService.RestoreCalls())
+func (mock *HistoryServiceMock) RestoreCalls() []struct {
+	Ctx     context.Context
+	Kind    server.DataKind
+	ID      int64
+	Version int64
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Kind    server.DataKind
+		ID      int64
+		Version int64
+	}
+	mock.lockRestore.RLock()
+	calls = mock.calls.Restore
+	mock.lockRestore.RUnlock()
+	return calls
+}
+
+// Ensure that MFAServiceMock does implement server.MFAService.
+// If this is not the case, regenerate this file with mockery.
+var _ server.MFAService = &MFAServiceMock{}
+
+// MFAServiceMock is a mock implementation of server.MFAService.
+//
+//	func TestSomethingThatUsesMFAService(t *testing.T) {
+//
+//		// make and configure a mocked server.MFAService
+//		mockedMFAService := &MFAServiceMock{
+//			DisableFunc: func(ctx context.Context, login string, code string) error {
+//				panic("mock out the Disable method")
+//			},
+//			EnableFunc: func(ctx context.Context, login string, code string) ([]string, error) {
+//				panic("mock out the Enable method")
+//			},
+//			SetupFunc: func(ctx context.Context, login string) (server.MFASetup, error) {
+//				panic("mock out the Setup method")
+//			},
+//			VerifyFunc: func(ctx context.Context, login string, code string) error {
+//				panic("mock out the Verify method")
+//			},
+//		}
+//
+//		// use mockedMFAService in code that requires server.MFAService
+//		// and then make assertions.
+//
+//	}
+type MFAServiceMock struct {
+	// DisableFunc mocks the Disable method.
+	DisableFunc func(ctx context.Context, login string, code string) error
+
+	// EnableFunc mocks the Enable method.
+	EnableFunc func(ctx context.Context, login string, code string) ([]string, error)
+
+	// SetupFunc mocks the Setup method.
+	SetupFunc func(ctx context.Context, login string) (server.MFASetup, error)
+
+	// VerifyFunc mocks the Verify method.
+	VerifyFunc func(ctx context.Context, login string, code string) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Disable holds details about calls to the Disable method.
+		Disable []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Login is the login argument value.
+			Login string
+			// Code is the code argument value.
+			Code string
+		}
+		// Enable holds details about calls to the Enable method.
+		Enable []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Login is the login argument value.
+			Login string
+			// Code is the code argument value.
+			Code string
+		}
+		// Setup holds details about calls to the Setup method.
+		Setup []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Login is the login argument value.
+			Login string
+		}
+		// Verify holds details about calls to the Verify method.
+		Verify []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Login is the login argument value.
+			Login string
+			// Code is the code argument value.
+			Code string
+		}
+	}
+	lockDisable sync.RWMutex
+	lockEnable  sync.RWMutex
+	lockSetup   sync.RWMutex
+	lockVerify  sync.RWMutex
+}
+
+// Disable calls DisableFunc.
+func (mock *MFAServiceMock) Disable(ctx context.Context, login string, code string) error {
+	callInfo := struct {
+		Ctx   context.Context
+		Login string
+		Code  string
+	}{
+		Ctx:   ctx,
+		Login: login,
+		Code:  code,
+	}
+	mock.lockDisable.Lock()
+	mock.calls.Disable = append(mock.calls.Disable, callInfo)
+	mock.lockDisable.Unlock()
+	if mock.DisableFunc == nil {
+		var (
+			err error
+		)
+		return err
+	}
+	return mock.DisableFunc(ctx, login, code)
+}
+
+// DisableCalls gets all the calls that were made to Disable.
+// Check the length with:
+//
+//	len(mockedMFAService.DisableCalls())
+func (mock *MFAServiceMock) DisableCalls() []struct {
+	Ctx   context.Context
+	Login string
+	Code  string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Login string
+		Code  string
+	}
+	mock.lockDisable.RLock()
+	calls = mock.calls.Disable
+	mock.lockDisable.RUnlock()
+	return calls
+}
+
+// Enable calls EnableFunc.
+func (mock *MFAServiceMock) Enable(ctx context.Context, login string, code string) ([]string, error) {
+	callInfo := struct {
+		Ctx   context.Context
+		Login string
+		Code  string
+	}{
+		Ctx:   ctx,
+		Login: login,
+		Code:  code,
+	}
+	mock.lockEnable.Lock()
+	mock.calls.Enable = append(mock.calls.Enable, callInfo)
+	mock.lockEnable.Unlock()
+	if mock.EnableFunc == nil {
+		var (
+			ss  []string
+			err error
+		)
+		return ss, err
+	}
+	return mock.EnableFunc(ctx, login, code)
+}
+
+// EnableCalls gets all the calls that were made to Enable.
+// Check the length with:
+//
+//	len(mockedMFAService.EnableCalls())
+func (mock *MFAServiceMock) EnableCalls() []struct {
+	Ctx   context.Context
+	Login string
+	Code  string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Login string
+		Code  string
+	}
+	mock.lockEnable.RLock()
+	calls = mock.calls.Enable
+	mock.lockEnable.RUnlock()
+	return calls
+}
+
+// Setup calls SetupFunc.
+func (mock *MFAServiceMock) Setup(ctx context.Context, login string) (server.MFASetup, error) {
+	callInfo := struct {
+		Ctx   context.Context
+		Login string
+	}{
+		Ctx:   ctx,
+		Login: login,
+	}
+	mock.lockSetup.Lock()
+	mock.calls.Setup = append(mock.calls.Setup, callInfo)
+	mock.lockSetup.Unlock()
+	if mock.SetupFunc == nil {
+		var (
+			mFASetup server.MFASetup
+			err      error
+		)
+		return mFASetup, err
+	}
+	return mock.SetupFunc(ctx, login)
+}
+
+// SetupCalls gets all the calls that were made to Setup.
+// Check the length with:
+//
+//	len(mockedMFAService.SetupCalls())
+func (mock *MFAServiceMock) SetupCalls() []struct {
+	Ctx   context.Context
+	Login string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Login string
+	}
+	mock.lockSetup.RLock()
+	calls = mock.calls.Setup
+	mock.lockSetup.RUnlock()
+	return calls
+}
+
+// Verify calls VerifyFunc.
+func (mock *MFAServiceMock) Verify(ctx context.Context, login string, code string) error {
+	callInfo := struct {
+		Ctx   context.Context
+		Login string
+		Code  string
+	}{
+		Ctx:   ctx,
+		Login: login,
+		Code:  code,
+	}
+	mock.lockVerify.Lock()
+	mock.calls.Verify = append(mock.calls.Verify, callInfo)
+	mock.lockVerify.Unlock()
+	if mock.VerifyFunc == nil {
+		var (
+			err error
+		)
+		return err
+	}
+	return mock.VerifyFunc(ctx, login, code)
+}
+
+// VerifyCalls gets all the calls that were made to Verify.
+// Check the length with:
+//
+//	len(mockedMFAService.VerifyCalls())
+func (mock *MFAServiceMock) VerifyCalls() []struct {
+	Ctx   context.Context
+	Login string
+	Code  string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Login string
+		Code  string
+	}
+	mock.lockVerify.RLock()
+	calls = mock.calls.Verify
+	mock.lockVerify.RUnlock()
+	return calls
+}
+
+// Ensure that SyncServiceMock does implement server.SyncService.
+// If this is not the case, regenerate this file with mockery.
+var _ server.SyncService = &SyncServiceMock{}
+
+// SyncServiceMock is a mock implementation of server.SyncService.
+//
+//	func TestSomethingThatUsesSyncService(t *testing.T) {
+//
+//		// make and configure a mocked server.SyncService
+//		mockedSyncService := &SyncServiceMock{
+//			ChangesFunc: func(ctx context.Context, since int64) (*server.Changes, error) {
+//				panic("mock out the Changes method")
+//			},
+//			RevisionFunc: func(ctx context.Context) (int64, error) {
+//				panic("mock out the Revision method")
+//			},
+//		}
+//
+//		// use mockedSyncService in code that requires server.SyncService
+//		// and then make assertions.
+//
+//	}
+type SyncServiceMock struct {
+	// ChangesFunc mocks the Changes method.
+	ChangesFunc func(ctx context.Context, since int64) (*server.Changes, error)
+
+	// RevisionFunc mocks the Revision method.
+	RevisionFunc func(ctx context.Context) (int64, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Changes holds details about calls to the Changes method.
+		Changes []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Since is the since argument value.
+			Since int64
+		}
+		// Revision holds details about calls to the Revision method.
+		Revision []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+	}
+	lockChanges  sync.RWMutex
+	lockRevision sync.RWMutex
+}
+
+// Changes calls ChangesFunc.
+func (mock *SyncServiceMock) Changes(ctx context.Context, since int64) (*server.Changes, error) {
+	callInfo := struct {
+		Ctx   context.Context
+		Since int64
+	}{
+		Ctx:   ctx,
+		Since: since,
+	}
+	mock.lockChanges.Lock()
+	mock.calls.Changes = append(mock.calls.Changes, callInfo)
+	mock.lockChanges.Unlock()
+	if mock.ChangesFunc == nil {
+		var (
+			changes *server.Changes
+			err     error
+		)
+		return changes, err
+	}
+	return mock.ChangesFunc(ctx, since)
+}
+
+// ChangesCalls gets all the calls that were made to Changes.
+// Check the length with:
+//
+//	len(mockedSyncService.ChangesCalls())
+func (mock *SyncServiceMock) ChangesCalls() []struct {
+	Ctx   context.Context
+	Since int64
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Since int64
+	}
+	mock.lockChanges.RLock()
+	calls = mock.calls.Changes
+	mock.lockChanges.RUnlock()
+	return calls
+}
+
+// Revision calls RevisionFunc.
+func (mock *SyncServiceMock) Revision(ctx context.Context) (int64, error) {
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockRevision.Lock()
+	mock.calls.Revision = append(mock.calls.Revision, callInfo)
+	mock.lockRevision.Unlock()
+	if mock.RevisionFunc == nil {
+		var (
+			n   int64
+			err error
+		)
+		return n, err
+	}
+	return mock.RevisionFunc(ctx)
+}
+
+// RevisionCalls gets all the calls that were made to Revision.
+// Check the length with:
+//
+//	len(mockedSyncService.RevisionCalls())
+func (mock *SyncServiceMock) RevisionCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockRevision.RLock()
+	calls = mock.calls.Revision
+	mock.lockRevision.RUnlock()
+	return calls
+}
+
+// Ensure that TrashServiceMock does implement server.TrashService.
+// If this is not the case, regenerate this file with mockery.
+var _ server.TrashService = &TrashServiceMock{}
+
+// TrashServiceMock is a mock implementation of server.TrashService.
+//
+//	func TestSomethingThatUsesTrashService(t *testing.T) {
+//
+//		// make and configure a mocked server.TrashService
+//		mockedTrashService := &TrashServiceMock{
+//			ListFunc: func(ctx context.Context) ([]server.TrashEntry, error) {
+//				panic("mock out the List method")
+//			},
+//			PurgeFunc: func(ctx context.Context, kind server.DataKind, id int64) error {
+//				panic("mock out the Purge method")
+//			},
+//			PurgeExpiredFunc: func(ctx context.Context, before time.Time) (int, error) {
+//				panic("mock out the PurgeExpired method")
+//			},
+//			RestoreFunc: func(ctx context.Context, kind server.DataKind, id int64) error {
+//				panic("mock out the Restore method")
+//			},
+//		}
+//
+//		// use mockedTrashService in code that requires server.TrashService
+//		// and then make assertions.
+//
+//	}
+type TrashServiceMock struct {
+	// ListFunc mocks the List method.
+	ListFunc func(ctx context.Context) ([]server.TrashEntry, error)
+
+	// PurgeFunc mocks the Purge method.
+	PurgeFunc func(ctx context.Context, kind server.DataKind, id int64) error
+
+	// PurgeExpiredFunc mocks the PurgeExpired method.
+	PurgeExpiredFunc func(ctx context.Context, before time.Time) (int, error)
+
+	// RestoreFunc mocks the Restore method.
+	RestoreFunc func(ctx context.Context, kind server.DataKind, id int64) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// List holds details about calls to the List method.
+		List []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// Purge holds details about calls to the Purge method.
+		Purge []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Kind is the kind argument value.
+			Kind server.DataKind
+			// ID is the id argument value.
+			ID int64
+		}
+		// PurgeExpired holds details about calls to the PurgeExpired method.
+		PurgeExpired []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Before is the before argument value.
+			Before time.Time
+		}
+		// Restore holds details about calls to the Restore method.
+		Restore []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Kind is the kind argument value.
+			Kind server.DataKind
+			// ID is the id argument value.
+			ID int64
+		}
+	}
+	lockList         sync.RWMutex
+	lockPurge        sync.RWMutex
+	lockPurgeExpired sync.RWMutex
+	lockRestore      sync.RWMutex
+}
+
+// List calls ListFunc.
+func (mock *TrashServiceMock) List(ctx context.Context) ([]server.TrashEntry, error) {
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockList.Lock()
+	mock.calls.List = append(mock.calls.List, callInfo)
+	mock.lockList.Unlock()
+	if mock.ListFunc == nil {
+		var (
+			trashEntrys []server.TrashEntry
+			err         error
+		)
+		return trashEntrys, err
+	}
+	return mock.ListFunc(ctx)
+}
+
+// ListCalls gets all the calls that were made to List.
+// Check the length with:
+//
+//	len(mockedTrashService.ListCalls())
+func (mock *TrashServiceMock) ListCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockList.RLock()
+	calls = mock.calls.List
+	mock.lockList.RUnlock()
+	return calls
+}
+
+// Purge calls PurgeFunc.
+func (mock *TrashServiceMock) Purge(ctx context.Context, kind server.DataKind, id int64) error {
+	callInfo := struct {
+		Ctx  context.Context
+		Kind server.DataKind
+		ID   int64
+	}{
+		Ctx:  ctx,
+		Kind: kind,
+		ID:   id,
+	}
+	mock.lockPurge.Lock()
+	mock.calls.Purge = append(mock.calls.Purge, callInfo)
+	mock.lockPurge.Unlock()
+	if mock.PurgeFunc == nil {
+		var (
+			err error
+		)
+		return err
+	}
+	return mock.PurgeFunc(ctx, kind, id)
+}
+
+// PurgeCalls gets all the calls that were made to Purge.
+// Check the length with:
+//
+//	len(mockedTrashService.PurgeCalls())
+func (mock *TrashServiceMock) PurgeCalls() []struct {
+	Ctx  context.Context
+	Kind server.DataKind
+	ID   int64
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Kind server.DataKind
+		ID   int64
+	}
+	mock.lockPurge.RLock()
+	calls = mock.calls.Purge
+	mock.lockPurge.RUnlock()
+	return calls
+}
+
+// PurgeExpired calls PurgeExpiredFunc.
+func (mock *TrashServiceMock) PurgeExpired(ctx context.Context, before time.Time) (int, error) {
+	callInfo := struct {
+		Ctx    context.Context
+		Before time.Time
+	}{
+		Ctx:    ctx,
+		Before: before,
+	}
+	mock.lockPurgeExpired.Lock()
+	mock.calls.PurgeExpired = append(mock.calls.PurgeExpired, callInfo)
+	mock.lockPurgeExpired.Unlock()
+	if mock.PurgeExpiredFunc == nil {
+		var (
+			n   int
+			err error
+		)
+		return n, err
+	}
+	return mock.PurgeExpiredFunc(ctx, before)
+}
+
+// PurgeExpiredCalls gets all the calls that were made to PurgeExpired.
+// Check the length with:
+//
+//	len(mockedTrashService.PurgeExpiredCalls())
+func (mock *TrashServiceMock) PurgeExpiredCalls() []struct {
+	Ctx    context.Context
+	Before time.Time
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Before time.Time
+	}
+	mock.lockPurgeExpired.RLock()
+	calls = mock.calls.PurgeExpired
+	mock.lockPurgeExpired.RUnlock()
+	return calls
+}
+
+// Restore calls RestoreFunc.
+func (mock *TrashServiceMock) Restore(ctx context.Context, kind server.DataKind, id int64) error {
+	callInfo := struct {
+		Ctx  context.Context
+		Kind server.DataKind
+		ID   int64
+	}{
+		Ctx:  ctx,
+		Kind: kind,
+		ID:   id,
+	}
+	mock.lockRestore.Lock()
+	mock.calls.Restore = append(mock.calls.Restore, callInfo)
+	mock.lockRestore.Unlock()
+	if mock.RestoreFunc == nil {
+		var (
+			err error
+		)
+		return err
+	}
+	return mock.RestoreFunc(ctx, kind, id)
+}
+
+// RestoreCalls gets all the calls that were made to Restore.
+// Check the length with:
+//
+//	len(mockedTrashService.RestoreCalls())
+func (mock *TrashServiceMock) RestoreCalls() []struct {
+	Ctx  context.Context
+	Kind server.DataKind
+	ID   int64
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Kind server.DataKind
+		ID   int64
+	}
+	mock.lockRestore.RLock()
+	calls = mock.calls.Restore
+	mock.lockRestore.RUnlock()
+	return calls
+}
+
+// Ensure that UploadServiceMock does implement server.UploadService.
+// If this is not the case, regenerate this file with mockery.
+var _ server.UploadService = &UploadServiceMock{}
+
+// UploadServiceMock is a mock implementation of server.UploadService.
+//
+//	func TestSomethingThatUsesUploadService(t *testing.T) {
+//
+//		// make and configure a mocked server.UploadService
+//		mockedUploadService := &UploadServiceMock{
+//			CompleteFunc: func(ctx context.Context, id string) error {
+//				panic("mock out the Complete method")
+//			},
+//			CreateFunc: func(ctx context.Context, upload server.Upload) (*server.Upload, error) {
+//				panic("mock out the Create method")
+//			},
+//			GetFunc: func(ctx context.Context, id string) (*server.Upload, error) {
+//				panic("mock out the Get method")
+//			},
+//			WriteFunc: func(ctx context.Context, id string, offset int64, r io.Reader) (int64, error) {
+//				panic("mock out the Write method")
+//			},
+//		}
+//
+//		// use mockedUploadService in code that requires server.UploadService
+//		// and then make assertions.
+//
+//	}
+type UploadServiceMock struct {
+	// CompleteFunc mocks the Complete method.
+	CompleteFunc func(ctx context.Context, id string) error
+
+	// CreateFunc mocks the Create method.
+	CreateFunc func(ctx context.Context, upload server.Upload) (*server.Upload, error)
+
+	// GetFunc mocks the Get method.
+	GetFunc func(ctx context.Context, id string) (*server.Upload, error)
+
+	// WriteFunc mocks the Write method.
+	WriteFunc func(ctx context.Context, id string, offset int64, r io.Reader) (int64, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Complete holds details about calls to the Complete method.
+		Complete []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID string
+		}
+		// Create holds details about calls to the Create method.
+		Create []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Upload is the upload argument value.
+			Upload server.Upload
+		}
+		// Get holds details about calls to the Get method.
+		Get []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID string
+		}
+		// Write holds details about calls to the Write method.
+		Write []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID string
+			// Offset is the offset argument value.
+			Offset int64
+			// R is the r argument value.
+			R io.Reader
+		}
+	}
+	lockComplete sync.RWMutex
+	lockCreate   sync.RWMutex
+	lockGet      sync.RWMutex
+	lockWrite    sync.RWMutex
+}
+
+// Complete calls CompleteFunc.
+func (mock *UploadServiceMock) Complete(ctx context.Context, id string) error {
+	callInfo := struct {
+		Ctx context.Context
+		ID  string
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockComplete.Lock()
+	mock.calls.Complete = append(mock.calls.Complete, callInfo)
+	mock.lockComplete.Unlock()
+	if mock.CompleteFunc == nil {
+		var (
+			err error
+		)
+		return err
+	}
+	return mock.CompleteFunc(ctx, id)
+}
+
+// CompleteCalls gets all the calls that were made to Complete.
+// Check the length with:
+//
+//	len(mockedUploadService.CompleteCalls())
+func (mock *UploadServiceMock) CompleteCalls() []struct {
+	Ctx context.Context
+	ID  string
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  string
+	}
+	mock.lockComplete.RLock()
+	calls = mock.calls.Complete
+	mock.lockComplete.RUnlock()
+	return calls
+}
+
+// Create calls CreateFunc.
+func (mock *UploadServiceMock) Create(ctx context.Context, upload server.Upload) (*server.Upload, error) {
+	callInfo := struct {
+		Ctx    context.Context
+		Upload server.Upload
+	}{
+		Ctx:    ctx,
+		Upload: upload,
+	}
+	mock.lockCreate.Lock()
+	mock.calls.Create = append(mock.calls.Create, callInfo)
+	mock.lockCreate.Unlock()
+	if mock.CreateFunc == nil {
+		var (
+			upload *server.Upload
+			err    error
+		)
+		return upload, err
+	}
+	return mock.CreateFunc(ctx, upload)
+}
+
+// CreateCalls gets all the calls that were made to Create.
+// Check the length with:
+//
+//	len(mockedUploadService.CreateCalls())
+func (mock *UploadServiceMock) CreateCalls() []struct {
+	Ctx    context.Context
+	Upload server.Upload
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Upload server.Upload
+	}
+	mock.lockCreate.RLock()
+	calls = mock.calls.Create
+	mock.lockCreate.RUnlock()
+	return calls
+}
+
+// Get calls GetFunc.
+func (mock *UploadServiceMock) Get(ctx context.Context, id string) (*server.Upload, error) {
+	callInfo := struct {
+		Ctx context.Context
+		ID  string
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockGet.Lock()
+	mock.calls.Get = append(mock.calls.Get, callInfo)
+	mock.lockGet.Unlock()
+	if mock.GetFunc == nil {
+		var (
+			upload *server.Upload
+			err    error
+		)
+		return upload, err
+	}
+	return mock.GetFunc(ctx, id)
+}
+
+// GetCalls gets all the calls that were made to Get.
+// Check the length with:
+//
+//	len(mockedUploadService.GetCalls())
+func (mock *UploadServiceMock) GetCalls() []struct {
+	Ctx context.Context
+	ID  string
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  string
+	}
+	mock.lockGet.RLock()
+	calls = mock.calls.Get
+	mock.lockGet.RUnlock()
+	return calls
+}
+
+// Write calls WriteFunc.
+func (mock *UploadServiceMock) Write(ctx context.Context, id string, offset int64, r io.Reader) (int64, error) {
+	callInfo := struct {
+		Ctx    context.Context
+		ID     string
+		Offset int64
+		R      io.Reader
+	}{
+		Ctx:    ctx,
+		ID:     id,
+		Offset: offset,
+		R:      r,
+	}
+	mock.lockWrite.Lock()
+	mock.calls.Write = append(mock.calls.Write, callInfo)
+	mock.lockWrite.Unlock()
+	if mock.WriteFunc == nil {
+		var (
+			n   int64
+			err error
+		)
+		return n, err
+	}
+	return mock.WriteFunc(ctx, id, offset, r)
+}
+
+// WriteCalls gets all the calls that were made to Write.
+// Check the length with:
+//
+//	len(mockedUploadService.WriteCalls())
+func (mock *UploadServiceMock) WriteCalls() []struct {
+	Ctx    context.Context
+	ID     string
+	Offset int64
+	R      io.Reader
+} {
+	var calls []struct {
+		Ctx    context.Context
+		ID     string
+		Offset int64
+		R      io.Reader
+	}
+	mock.lockWrite.RLock()
+	calls = mock.calls.Write
+	mock.lockWrite.RUnlock()
+	return calls
+}
+
+// Ensure that UsageServiceMock does implement server.UsageService.
+// If this is not the case, regenerate this file with mockery.
+var _ server.UsageService = &UsageServiceMock{}
+
+// UsageServiceMock is a mock implementation of server.UsageService.
+//
+//	func TestSomethingThatUsesUsageService(t *testing.T) {
+//
+//		// make and configure a mocked server.UsageService
+//		mockedUsageService := &UsageServiceMock{
+//			GetFunc: func(ctx context.Context) (*server.Usage, error) {
+//				panic("mock out the Get method")
+//			},
+//		}
+//
+//		// use mockedUsageService in code that requires server.UsageService
+//		// and then make assertions.
+//
+//	}
+type UsageServiceMock struct {
+	// GetFunc mocks the Get method.
+	GetFunc func(ctx context.Context) (*server.Usage, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Get holds details about calls to the Get method.
+		Get []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+	}
+	lockGet sync.RWMutex
+}
+
+// Get calls GetFunc.
+func (mock *UsageServiceMock) Get(ctx context.Context) (*server.Usage, error) {
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockGet.Lock()
+	mock.calls.Get = append(mock.calls.Get, callInfo)
+	mock.lockGet.Unlock()
+	if mock.GetFunc == nil {
+		var (
+			usage *server.Usage
+			err   error
+		)
+		return usage, err
+	}
+	return mock.GetFunc(ctx)
+}
+
+// GetCalls gets all the calls that were made to Get.
+// Check the length with:
+//
+//	len(mockedUsageService.GetCalls())
+func (mock *UsageServiceMock) GetCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockGet.RLock()
+	calls = mock.calls.Get
+	mock.lockGet.RUnlock()
+	return calls
+}
+
+// Ensure that UserServiceMock does implement server.UserService.
+// If this is not the case, regenerate this file with mockery.
+var _ server.UserService = &UserServiceMock{}
+
+// UserServiceMock is a mock implementation of server.UserService.
+//
+//	func TestSomethingThatUsesUserService(t *testing.T) {
+//
+//		// make and configure a mocked server.UserService
+//		mockedUserService := &UserServiceMock{
+//			ChangePasswordFunc: func(ctx context.Context, login string, password string, vaultKey []byte) error {
+//				panic("mock out the ChangePassword method")
+//			},
+//			DeleteFunc: func(ctx context.Context, login string) error {
+//				panic("mock out the Delete method")
+//			},
+//			DisableMFAFunc: func(ctx context.Context, login string) error {
+//				panic("mock out the DisableMFA method")
+//			},
+//			EnableMFAFunc: func(ctx context.Context, login string, secret string, step int64, recoveryCodes [][]byte) error {
+//				panic("mock out the EnableMFA method")
+//			},
+//			EnrollVaultKeyFunc: func(ctx context.Context, login string, password string, vaultKey []byte) error {
+//				panic("mock out the EnrollVaultKey method")
+//			},
+//			GetFunc: func(ctx context.Context, login string) (*server.User, error) {
+//				panic("mock out the Get method")
+//			},
+//			SaveFunc: func(ctx context.Context, user server.User) error {
+//				panic("mock out the Save method")
+//			},
+//			SetMFAPendingSecretFunc: func(ctx context.Context, login string, secret string) error {
+//				panic("mock out the SetMFAPendingSecret method")
+//			},
+//			UseMFAStepFunc: func(ctx context.Context, login string, step int64) error {
+//				panic("mock out the UseMFAStep method")
+//			},
+//			UseRecoveryCodeFunc: func(ctx context.Context, login string, code []byte) error {
+//				panic("mock out the UseRecoveryCode method")
+//			},
+//		}
+//
+//		// use mockedUserService in code that requires server.UserService
+//		// and then make assertions.
+//
+//	}
+type UserServiceMock struct {
+	// ChangePasswordFunc mocks the ChangePassword method.
+	ChangePasswordFunc func(ctx context.Context, login string, password string, vaultKey []byte) error
+
+	// DeleteFunc mocks the Delete method.
+	DeleteFunc func(ctx context.Context, login string) error
+
+	// DisableMFAFunc mocks the DisableMFA method.
+	DisableMFAFunc func(ctx context.Context, login string) error
+
+	// EnableMFAFunc mocks the EnableMFA method.
+	EnableMFAFunc func(ctx context.Context, login string, secret string, step int64, recoveryCodes [][]byte) error
+
+	// EnrollVaultKeyFunc mocks the EnrollVaultKey method.
+	EnrollVaultKeyFunc func(ctx context.Context, login string, password string, vaultKey []byte) error
+
+	// GetFunc mocks the Get method.
+	GetFunc func(ctx context.Context, login string) (*server.User, error)
+
+	// SaveFunc mocks the Save method.
+	SaveFunc func(ctx context.Context, user server.User) error
+
+	// SetMFAPendingSecretFunc mocks the SetMFAPendingSecret method.
+	SetMFAPendingSecretFunc func(ctx context.Context, login string, secret string) error
+
+	// UseMFAStepFunc mocks the UseMFAStep method.
+	UseMFAStepFunc func(ctx context.Context, login string, step int64) error
+
+	// UseRecoveryCodeFunc mocks the UseRecoveryCode method.
+	UseRecoveryCodeFunc func(ctx context.Context, login string, code []byte) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// ChangePassword holds details about calls to the ChangePassword method.
+		ChangePassword []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Login is the login argument value.
+			Login string
+			// Password is the password argument value.
+			Password string
+			// VaultKey is the vaultKey argument value.
+			VaultKey []byte
+		}
+		// Delete holds details about calls to the Delete method.
+		Delete []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Login is the login argument value.
+			Login string
+		}
+		// DisableMFA holds details about calls to the DisableMFA method.
+		DisableMFA []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Login is the login argument value.
+			Login string
+		}
+		// EnableMFA holds details about calls to the EnableMFA method.
+		EnableMFA []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Login is the login argument value.
+			Login string
+			// Secret is the secret argument value.
+			Secret string
+			// Step is the step argument value.
+			Step int64
+			// RecoveryCodes is the recoveryCodes argument value.
+			RecoveryCodes [][]byte
+		}
+		// EnrollVaultKey holds details about calls to the EnrollVaultKey method.
+		EnrollVaultKey []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Login is the login argument value.
+			Login string
+			// Password is the password argument value.
+			Password string
+			// VaultKey is the vaultKey argument value.
+			VaultKey []byte
+		}
+		// Get holds details about calls to the Get method.
+		Get []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Login is the login argument value.
+			Login string
+		}
+		// Save holds details about calls to the Save method.
+		Save []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// User is the user argument value.
+			User server.User
+		}
+		// SetMFAPendingSecret holds details about calls to the SetMFAPendingSecret method.
+		SetMFAPendingSecret []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Login is the login argument value.
+			Login string
+			// Secret is the secret argument value.
+			Secret string
+		}
+		// UseMFAStep holds details about calls to the UseMFAStep method.
+		UseMFAStep []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Login is the login argument value.
+			Login string
+			// Step is the step argument value.
+			Step int64
+		}
+		// UseRecoveryCode holds details about calls to the UseRecoveryCode method.
+		UseRecoveryCode []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Login is the login argument value.
+			Login string
+			// Code is the code argument value.
+			Code []byte
+		}
+	}
+	lockChangePassword      sync.RWMutex
+	lockDelete              sync.RWMutex
+	lockDisableMFA          sync.RWMutex
+	lockEnableMFA           sync.RWMutex
+	lockEnrollVaultKey      sync.RWMutex
+	lockGet                 sync.RWMutex
+	lockSave                sync.RWMutex
+	lockSetMFAPendingSecret sync.RWMutex
+	lockUseMFAStep          sync.RWMutex
+	lockUseRecoveryCode     sync.RWMutex
+}
+
+// ChangePassword calls ChangePasswordFunc.
+func (mock *UserServiceMock) ChangePassword(ctx context.Context, login string, password string, vaultKey []byte) error {
+	callInfo := struct {
+		Ctx      context.Context
+		Login    string
+		Password string
+		VaultKey []byte
+	}{
+		Ctx:      ctx,
+		Login:    login,
+		Password: password,
+		VaultKey: vaultKey,
+	}
+	mock.lockChangePassword.Lock()
+	mock.calls.ChangePassword = append(mock.calls.ChangePassword, callInfo)
+	mock.lockChangePassword.Unlock()
+	if mock.ChangePasswordFunc == nil {
+		var (
+			err error
+		)
+		return err
+	}
+	return mock.ChangePasswordFunc(ctx, login, password, vaultKey)
+}
+
+// ChangePasswordCalls gets all the calls that were made to ChangePassword.
+// Check the length with:
+//
+//	len(mockedUserService.ChangePasswordCalls())
+func (mock *UserServiceMock) ChangePasswordCalls() []struct {
+	Ctx      context.Context
+	Login    string
+	Password string
+	VaultKey []byte
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Login    string
+		Password string
+		VaultKey []byte
+	}
+	mock.lockChangePassword.RLock()
+	calls = mock.calls.ChangePassword
+	mock.lockChangePassword.RUnlock()
+	return calls
+}
+
+// Delete calls DeleteFunc.
+func (mock *UserServiceMock) Delete(ctx context.Context, login string) error {
+	callInfo := struct {
+		Ctx   context.Context
+		Login string
+	}{
+		Ctx:   ctx,
+		Login: login,
+	}
+	mock.lockDelete.Lock()
+	mock.calls.Delete = append(mock.calls.Delete, callInfo)
+	mock.lockDelete.Unlock()
+	if mock.DeleteFunc == nil {
+		var (
+			err error
+		)
+		return err
+	}
+	return mock.DeleteFunc(ctx, login)
 }
 
-// CreateCalls gets all the calls that were made to Create.
+// DeleteCalls gets all the calls that were made to Delete.
 // Check the length with:
 //
-//	len(mockedCardService.CreateCalls())
-func (mock *CardServiceMock) CreateCalls() []struct {
-	Ctx  context.Context
-	Data server.CardData
+//	len(mockedUserService.DeleteCalls())
+func (mock *UserServiceMock) DeleteCalls() []struct {
+	Ctx   context.Context
+	Login string
 } {
 	var calls []struct {
-		Ctx  context.Context
-		Data server.CardData
+		Ctx   context.Context
+		Login string
 	}
-	mock.lockCreate.RLock()
-	calls = mock.calls.Create
-	mock.lockCreate.RUnlock()
+	mock.lockDelete.RLock()
+	calls = mock.calls.Delete
+	mock.lockDelete.RUnlock()
 	return calls
 }
 
-// GetAll calls GetAllFunc.
-func (mock *CardServiceMock) GetAll(ctx context.Context) ([]server.CardData, error) {
+// DisableMFA calls DisableMFAFunc.
+func (mock *UserServiceMock) DisableMFA(ctx context.Context, login string) error {
 	callInfo := struct {
-		Ctx context.Context
+		Ctx   context.Context
+		Login string
 	}{
-		Ctx: ctx,
+		Ctx:   ctx,
+		Login: login,
 	}
-	mock.lockGetAll.Lock()
-	mock.calls.GetAll = append(mock.calls.GetAll, callInfo)
-	mock.lockGetAll.Unlock()
-	if mock.GetAllFunc == nil {
+	mock.lockDisableMFA.Lock()
+	mock.calls.DisableMFA = append(mock.calls.DisableMFA, callInfo)
+	mock.lockDisableMFA.Unlock()
+	if mock.DisableMFAFunc == nil {
 		var (
-			cardDatas []server.CardData
-			err       error
+			err error
 		)
-		return cardDatas, err
+		return err
 	}
-	return mock.GetAllFunc(ctx)
+	return mock.DisableMFAFunc(ctx, login)
 }
 
-// GetAllCalls gets all the calls that were made to GetAll.
+// DisableMFACalls gets all the calls that were made to DisableMFA.
 // Check the length with:
 //
-//	len(mockedCardService.GetAllCalls())
-func (mock *CardServiceMock) GetAllCalls() []struct {
-	Ctx context.Context
+//	len(mockedUserService.DisableMFACalls())
+func (mock *UserServiceMock) DisableMFACalls() []struct {
+	Ctx   context.Context
+	Login string
 } {
 	var calls []struct {
-		Ctx context.Context
+		Ctx   context.Context
+		Login string
 	}
-	mock.lockGetAll.RLock()
-	calls = mock.calls.GetAll
-	mock.lockGetAll.RUnlock()
+	mock.lockDisableMFA.RLock()
+	calls = mock.calls.DisableMFA
+	mock.lockDisableMFA.RUnlock()
 	return calls
 }
 
-// Remove calls RemoveFunc.
-func (mock *CardServiceMock) Remove(ctx context.Context, id int64) error {
+// EnableMFA calls EnableMFAFunc.
+func (mock *UserServiceMock) EnableMFA(ctx context.Context, login string, secret string, step int64, recoveryCodes [][]byte) error {
 	callInfo := struct {
-		Ctx context.Context
-		ID  int64
+		Ctx           context.Context
+		Login         string
+		Secret        string
+		Step          int64
+		RecoveryCodes [][]byte
 	}{
-		Ctx: ctx,
-		ID:  id,
+		Ctx:           ctx,
+		Login:         login,
+		Secret:        secret,
+		Step:          step,
+		RecoveryCodes: recoveryCodes,
 	}
-	mock.lockRemove.Lock()
-	mock.calls.Remove = append(mock.calls.Remove, callInfo)
-	mock.lockRemove.Unlock()
-	if mock.RemoveFunc == nil {
+	mock.lockEnableMFA.Lock()
+	mock.calls.EnableMFA = append(mock.calls.EnableMFA, callInfo)
+	mock.lockEnableMFA.Unlock()
+	if mock.EnableMFAFunc == nil {
 		var (
 			err error
 		)
 		return err
 	}
-	return mock.RemoveFunc(ctx, id)
+	return mock.EnableMFAFunc(ctx, login, secret, step, recoveryCodes)
 }
 
-// RemoveCalls gets all the calls that were made to Remove.
+// EnableMFACalls gets all the calls that were made to EnableMFA.
 // Check the length with:
 //
-//	len(mockedCardService.RemoveCalls())
-func (mock *CardServiceMock) RemoveCalls() []struct {
-	Ctx context.Context
-	ID  int64
+//	len(mockedUserService.EnableMFACalls())
+func (mock *UserServiceMock) EnableMFACalls() []struct {
+	Ctx           context.Context
+	Login         string
+	Secret        string
+	Step          int64
+	RecoveryCodes [][]byte
 } {
 	var calls []struct {
-		Ctx context.Context
-		ID  int64
+		Ctx           context.Context
+		Login         string
+		Secret        string
+		Step          int64
+		RecoveryCodes [][]byte
 	}
-	mock.lockRemove.RLock()
-	calls = mock.calls.Remove
-	mock.lockRemove.RUnlock()
+	mock.lockEnableMFA.RLock()
+	calls = mock.calls.EnableMFA
+	mock.lockEnableMFA.RUnlock()
 	return calls
 }
 
-// Update calls UpdateFunc.
-func (mock *CardServiceMock) Update(ctx context.Context, id int64, data server.CardDataUpdate) error {
+// EnrollVaultKey calls EnrollVaultKeyFunc.
+func (mock *UserServiceMock) EnrollVaultKey(ctx context.Context, login string, password string, vaultKey []byte) error {
 	callInfo := struct {
-		Ctx  context.Context
-		ID   int64
-		Data server.CardDataUpdate
+		Ctx      context.Context
+		Login    string
+		Password string
+		VaultKey []byte
 	}{
-		Ctx:  ctx,
-		ID:   id,
-		Data: data,
+		Ctx:      ctx,
+		Login:    login,
+		Password: password,
+		VaultKey: vaultKey,
 	}
-	mock.lockUpdate.Lock()
-	mock.calls.Update = append(mock.calls.Update, callInfo)
-	mock.lockUpdate.Unlock()
-	if mock.UpdateFunc == nil {
+	mock.lockEnrollVaultKey.Lock()
+	mock.calls.EnrollVaultKey = append(mock.calls.EnrollVaultKey, callInfo)
+	mock.lockEnrollVaultKey.Unlock()
+	if mock.EnrollVaultKeyFunc == nil {
 		var (
 			err error
 		)
 		return err
 	}
-	return mock.UpdateFunc(ctx, id, data)
+	return mock.EnrollVaultKeyFunc(ctx, login, password, vaultKey)
 }
 
-// UpdateCalls gets all the calls that were made to Update.
+// EnrollVaultKeyCalls gets all the calls that were made to EnrollVaultKey.
 // Check the length with:
 //
-//	len(mockedCardService.UpdateCalls())
-func (mock *CardServiceMock) UpdateCalls() []struct {
-	Ctx  context.Context
-	ID   int64
-	Data server.CardDataUpdate
+//	len(mockedUserService.EnrollVaultKeyCalls())
+func (mock *UserServiceMock) EnrollVaultKeyCalls() []struct {
+	Ctx      context.Context
+	Login    string
+	Password string
+	VaultKey []byte
 } {
 	var calls []struct {
-		Ctx  context.Context
-		ID   int64
-		Data server.CardDataUpdate
+		Ctx      context.Context
+		Login    string
+		Password string
+		VaultKey []byte
 	}
-	mock.lockUpdate.RLock()
-	calls = mock.calls.Update
-	mock.lockUpdate.RUnlock()
+	mock.lockEnrollVaultKey.RLock()
+	calls = mock.calls.EnrollVaultKey
+	mock.lockEnrollVaultKey.RUnlock()
 	return calls
 }
 
-// Ensure that UserServiceMock does implement server.UserService.
-// If this is not the case, regenerate this file with mockery.
-var _ server.UserService = &UserServiceMock{}
-
-// UserServiceMock is a mock implementation of server.UserService.
-//
-//	func TestSomethingThatUsesUserService(t *testing.T) {
-//
-//		// make and configure a mocked server.UserService
-//		mockedUserService := &UserServiceMock{
-//			GetFunc: func(ctx context.Context, login string) (*server.User, error) {
-//				panic("mock out the Get method")
-//			},
-//			SaveFunc: func(ctx context.Context, user server.User) error {
-//				panic("mock out the Save method")
-//			},
-//		}
-//
-//		// use mockedUserService in code that requires server.UserService
-//		// and then make assertions.
-//
-//	}
-type UserServiceMock struct {
-	// GetFunc mocks the Get method.
-	GetFunc func(ctx context.Context, login string) (*server.User, error)
-
-	// SaveFunc mocks the Save method.
-	SaveFunc func(ctx context.Context, user server.User) error
-
-	// calls tracks calls to the methods.
-	calls struct {
-		// Get holds details about calls to the Get method.
-		Get []struct {
-			// Ctx is the ctx argument value.
-			Ctx context.Context
-			// Login is the login argument value.
-			Login string
-		}
-		// Save holds details about calls to the Save method.
-		Save []struct {
-			// Ctx is the ctx argument value.
-			Ctx context.Context
-			// User is the user argument value.
-			User server.User
-		}
-	}
-	lockGet  sync.RWMutex
-	lockSave sync.RWMutex
-}
-
 // Get calls GetFunc.
 func (mock *UserServiceMock) Get(ctx context.Context, login string) (*server.User, error) {
 	callInfo := struct {
@@ -1209,3 +4073,250 @@ func (mock *UserServiceMock) SaveCalls() []struct {
 	mock.lockSave.RUnlock()
 	return calls
 }
+
+// SetMFAPendingSecret calls SetMFAPendingSecretFunc.
+func (mock *UserServiceMock) SetMFAPendingSecret(ctx context.Context, login string, secret string) error {
+	callInfo := struct {
+		Ctx    context.Context
+		Login  string
+		Secret string
+	}{
+		Ctx:    ctx,
+		Login:  login,
+		Secret: secret,
+	}
+	mock.lockSetMFAPendingSecret.Lock()
+	mock.calls.SetMFAPendingSecret = append(mock.calls.SetMFAPendingSecret, callInfo)
+	mock.lockSetMFAPendingSecret.Unlock()
+	if mock.SetMFAPendingSecretFunc == nil {
+		var (
+			err error
+		)
+		return err
+	}
+	return mock.SetMFAPendingSecretFunc(ctx, login, secret)
+}
+
+// SetMFAPendingSecretCalls gets all the calls that were made to SetMFAPendingSecret.
+// Check the length with:
+//
+//	len(mockedUserService.SetMFAPendingSecretCalls())
+func (mock *UserServiceMock) SetMFAPendingSecretCalls() []struct {
+	Ctx    context.Context
+	Login  string
+	Secret string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Login  string
+		Secret string
+	}
+	mock.lockSetMFAPendingSecret.RLock()
+	calls = mock.calls.SetMFAPendingSecret
+	mock.lockSetMFAPendingSecret.RUnlock()
+	return calls
+}
+
+// UseMFAStep calls UseMFAStepFunc.
+func (mock *UserServiceMock) UseMFAStep(ctx context.Context, login string, step int64) error {
+	callInfo := struct {
+		Ctx   context.Context
+		Login string
+		Step  int64
+	}{
+		Ctx:   ctx,
+		Login: login,
+		Step:  step,
+	}
+	mock.lockUseMFAStep.Lock()
+	mock.calls.UseMFAStep = append(mock.calls.UseMFAStep, callInfo)
+	mock.lockUseMFAStep.Unlock()
+	if mock.UseMFAStepFunc == nil {
+		var (
+			err error
+		)
+		return err
+	}
+	return mock.UseMFAStepFunc(ctx, login, step)
+}
+
+// UseMFAStepCalls gets all the calls that were made to UseMFAStep.
+// Check the length with:
+//
+//	len(mockedUserService.UseMFAStepCalls())
+func (mock *UserServiceMock) UseMFAStepCalls() []struct {
+	Ctx   context.Context
+	Login string
+	Step  int64
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Login string
+		Step  int64
+	}
+	mock.lockUseMFAStep.RLock()
+	calls = mock.calls.UseMFAStep
+	mock.lockUseMFAStep.RUnlock()
+	return calls
+}
+
+// UseRecoveryCode calls UseRecoveryCodeFunc.
+func (mock *UserServiceMock) UseRecoveryCode(ctx context.Context, login string, code []byte) error {
+	callInfo := struct {
+		Ctx   context.Context
+		Login string
+		Code  []byte
+	}{
+		Ctx:   ctx,
+		Login: login,
+		Code:  code,
+	}
+	mock.lockUseRecoveryCode.Lock()
+	mock.calls.UseRecoveryCode = append(mock.calls.UseRecoveryCode, callInfo)
+	mock.lockUseRecoveryCode.Unlock()
+	if mock.UseRecoveryCodeFunc == nil {
+		var (
+			err error
+		)
+		return err
+	}
+	return mock.UseRecoveryCodeFunc(ctx, login, code)
+}
+
+// UseRecoveryCodeCalls gets all the calls that were made to UseRecoveryCode.
+// Check the length with:
+//
+//	len(mockedUserService.UseRecoveryCodeCalls())
+func (mock *UserServiceMock) UseRecoveryCodeCalls() []struct {
+	Ctx   context.Context
+	Login string
+	Code  []byte
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Login string
+		Code  []byte
+	}
+	mock.lockUseRecoveryCode.RLock()
+	calls = mock.calls.UseRecoveryCode
+	mock.lockUseRecoveryCode.RUnlock()
+	return calls
+}
+
+// Ensure that KeyringMock does implement server.Keyring.
+// If this is not the case, regenerate this file with mockery.
+var _ server.Keyring = &KeyringMock{}
+
+// KeyringMock is a mock implementation of server.Keyring.
+//
+//	func TestSomethingThatUsesKeyring(t *testing.T) {
+//
+//		// make and configure a mocked server.Keyring
+//		mockedKeyring := &KeyringMock{
+//			OpenFunc: func(ciphertext []byte) ([]byte, error) {
+//				panic("mock out the Open method")
+//			},
+//			SealFunc: func(plaintext []byte) ([]byte, error) {
+//				panic("mock out the Seal method")
+//			},
+//		}
+//
+//		// use mockedKeyring in code that requires server.Keyring
+//		// and then make assertions.
+//
+//	}
+type KeyringMock struct {
+	// OpenFunc mocks the Open method.
+	OpenFunc func(ciphertext []byte) ([]byte, error)
+
+	// SealFunc mocks the Seal method.
+	SealFunc func(plaintext []byte) ([]byte, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Open holds details about calls to the Open method.
+		Open []struct {
+			// Ciphertext is the ciphertext argument value.
+			Ciphertext []byte
+		}
+		// Seal holds details about calls to the Seal method.
+		Seal []struct {
+			// Plaintext is the plaintext argument value.
+			Plaintext []byte
+		}
+	}
+	lockOpen sync.RWMutex
+	lockSeal sync.RWMutex
+}
+
+// Open calls OpenFunc.
+func (mock *KeyringMock) Open(ciphertext []byte) ([]byte, error) {
+	callInfo := struct {
+		Ciphertext []byte
+	}{
+		Ciphertext: ciphertext,
+	}
+	mock.lockOpen.Lock()
+	mock.calls.Open = append(mock.calls.Open, callInfo)
+	mock.lockOpen.Unlock()
+	if mock.OpenFunc == nil {
+		var (
+			bytes []byte
+			err   error
+		)
+		return bytes, err
+	}
+	return mock.OpenFunc(ciphertext)
+}
+
+// OpenCalls gets all the calls that were made to Open.
+// Check the length with:
+//
+//	len(mockedKeyring.OpenCalls())
+func (mock *KeyringMock) OpenCalls() []struct {
+	Ciphertext []byte
+} {
+	var calls []struct {
+		Ciphertext []byte
+	}
+	mock.lockOpen.RLock()
+	calls = mock.calls.Open
+	mock.lockOpen.RUnlock()
+	return calls
+}
+
+// Seal calls SealFunc.
+func (mock *KeyringMock) Seal(plaintext []byte) ([]byte, error) {
+	callInfo := struct {
+		Plaintext []byte
+	}{
+		Plaintext: plaintext,
+	}
+	mock.lockSeal.Lock()
+	mock.calls.Seal = append(mock.calls.Seal, callInfo)
+	mock.lockSeal.Unlock()
+	if mock.SealFunc == nil {
+		var (
+			bytes []byte
+			err   error
+		)
+		return bytes, err
+	}
+	return mock.SealFunc(plaintext)
+}
+
+// SealCalls gets all the calls that were made to Seal.
+// Check the length with:
+//
+//	len(mockedKeyring.SealCalls())
+func (mock *KeyringMock) SealCalls() []struct {
+	Plaintext []byte
+} {
+	var calls []struct {
+		Plaintext []byte
+	}
+	mock.lockSeal.RLock()
+	calls = mock.calls.Seal
+	mock.lockSeal.RUnlock()
+	return calls
+}
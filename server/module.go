@@ -12,10 +12,6 @@ var Module = fx.Module(
 	fx.Provide(
 		NewLogger,
 		NewValidate,
-		NewConfig,
-	),
-	fx.Invoke(
-		RegisterDataValidationRules,
 	),
 )
 
@@ -0,0 +1,122 @@
+package converter
+
+import (
+	"context"
+	"github.com/mkolibaba/gophkeeper/server"
+	sqlc "github.com/mkolibaba/gophkeeper/server/postgres/sqlc/gen"
+)
+
+// goverter:converter
+// goverter:output:file ./gen/converter.go
+type DataConverter interface {
+	// -- Login --
+
+	// goverter:context ctx
+	// goverter:map Owner | UserFromContext
+	ConvertToInsertLogin(ctx context.Context, source server.LoginData) sqlc.InsertLoginParams
+
+	ConvertToLoginDataSlice(source []sqlc.Login) []server.LoginData
+
+	// goverter:useZeroValueOnPointerInconsistency
+	// goverter:map Revision Version
+	ConvertToLoginData(source sqlc.Login) server.LoginData
+
+	ConvertToUpdateLogin(source sqlc.Login) sqlc.UpdateLoginParams
+
+	// goverter:update target
+	// goverter:useZeroValueOnPointerInconsistency
+	// goverter:ignore ID Revision
+	ConvertToUpdateLoginUpdate(source server.LoginDataUpdate, target *sqlc.UpdateLoginParams)
+
+	// -- Note --
+
+	// goverter:context ctx
+	// goverter:map Owner | UserFromContext
+	ConvertToInsertNote(ctx context.Context, source server.NoteData) sqlc.InsertNoteParams
+
+	ConvertToNoteDataSlice(source []sqlc.Note) []server.NoteData
+
+	// goverter:useZeroValueOnPointerInconsistency
+	// goverter:map Revision Version
+	ConvertToNoteData(source sqlc.Note) server.NoteData
+
+	ConvertToUpdateNote(source sqlc.Note) sqlc.UpdateNoteParams
+
+	// goverter:update target
+	// goverter:useZeroValueOnPointerInconsistency
+	// goverter:ignore ID Revision
+	ConvertToUpdateNoteUpdate(source server.NoteDataUpdate, target *sqlc.UpdateNoteParams)
+
+	// -- Binary --
+
+	// goverter:context ctx
+	// goverter:map Owner | UserFromContext
+	// goverter:autoMap BinaryData
+	// goverter:ignore Sha256 Blob
+	ConvertToInsertBinary(ctx context.Context, source server.ReadableBinaryData) sqlc.InsertBinaryParams
+
+	ConvertToBinaryDataSlice(source []sqlc.Binary) []server.BinaryData
+
+	// goverter:useZeroValueOnPointerInconsistency
+	// goverter:map Revision Version
+	ConvertToBinaryData(source sqlc.Binary) server.BinaryData
+
+	ConvertToUpdateBinary(source sqlc.Binary) sqlc.UpdateBinaryParams
+
+	// goverter:update target
+	// goverter:useZeroValueOnPointerInconsistency
+	// goverter:ignore ID Revision
+	ConvertToUpdateBinaryUpdate(source server.BinaryDataUpdate, target *sqlc.UpdateBinaryParams)
+
+	// -- Card --
+
+	// goverter:context ctx
+	// goverter:map Owner | UserFromContext
+	// goverter:map CVV Cvv
+	ConvertToInsertCard(ctx context.Context, source server.CardData) sqlc.InsertCardParams
+
+	ConvertToCardDataSlice(source []sqlc.Card) []server.CardData
+
+	// goverter:useZeroValueOnPointerInconsistency
+	// goverter:map Cvv CVV
+	// goverter:map Revision Version
+	ConvertToCardData(source sqlc.Card) server.CardData
+
+	ConvertToUpdateCard(source sqlc.Card) sqlc.UpdateCardParams
+
+	// goverter:update target
+	// goverter:useZeroValueOnPointerInconsistency
+	// goverter:ignore ID Revision
+	// goverter:map CVV Cvv
+	ConvertToUpdateCardUpdate(source server.CardDataUpdate, target *sqlc.UpdateCardParams)
+
+	// -- OTP --
+
+	// goverter:context ctx
+	// goverter:map Owner | UserFromContext
+	ConvertToInsertOTP(ctx context.Context, source server.OTPData) sqlc.InsertOTPParams
+
+	ConvertToOTPDataSlice(source []sqlc.OTP) []server.OTPData
+
+	// goverter:useZeroValueOnPointerInconsistency
+	// goverter:map Revision Version
+	ConvertToOTPData(source sqlc.OTP) server.OTPData
+
+	ConvertToUpdateOTP(source sqlc.OTP) sqlc.UpdateOTPParams
+
+	// goverter:update target
+	// goverter:useZeroValueOnPointerInconsistency
+	// goverter:ignore ID Revision
+	ConvertToUpdateOTPUpdate(source server.OTPDataUpdate, target *sqlc.UpdateOTPParams)
+
+	// -- Sync --
+
+	ConvertToTombstoneSlice(source []sqlc.Tombstone) []server.Tombstone
+
+	ConvertToTombstone(source sqlc.Tombstone) server.Tombstone
+}
+
+// goverter:context ctx
+func UserFromContext(ctx context.Context) string {
+	return server.UserFromContext(ctx)
+}
@@ -0,0 +1,37 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/mkolibaba/gophkeeper/server"
+	"github.com/mkolibaba/gophkeeper/server/sealed"
+)
+
+func unwrapInsertError(err error) error {
+	if pe, ok := asType[*pgconn.PgError](err); ok {
+		switch pe.Code {
+		case pgerrcode.ForeignKeyViolation:
+			return server.ErrUserNotFound
+		}
+	}
+	return err
+}
+
+func getAllData[S any, R any](
+	ctx context.Context,
+	keyring server.Keyring,
+	getter func(context.Context, string) ([]S, error),
+	fields func(*S) sealed.Fields,
+	mapper func([]S) []R,
+) ([]R, error) {
+	sources, err := getter(ctx, server.UserFromContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("get all: %w", err)
+	}
+	if err := sealed.OpenRows(keyring, sources, fields); err != nil {
+		return nil, fmt.Errorf("get all: %w", err)
+	}
+	return mapper(sources), nil
+}
@@ -0,0 +1,7 @@
+package postgres
+
+import "errors"
+
+func asType[E error](err error) (e E, _ bool) {
+	return e, errors.As(err, &e)
+}
@@ -0,0 +1,17 @@
+-- Триггеры удаляются вместе с таблицами.
+
+DROP TABLE uploads;
+DROP TABLE login_attempts;
+DROP TABLE recovery_codes;
+DROP TABLE sessions;
+DROP TABLE tombstones;
+DROP TABLE otps;
+DROP TABLE cards;
+DROP TABLE binaries;
+DROP TABLE blobs;
+DROP TABLE notes;
+DROP TABLE logins;
+DROP TABLE users;
+
+DROP FUNCTION record_tombstone();
+DROP FUNCTION bump_revision();
@@ -0,0 +1,274 @@
+-- Схема хранилища PostgreSQL, соответствующая схеме SQLite после всех ее
+-- миграций. Владелец данных хранится в колонке owner: user - ключевое
+-- слово PostgreSQL. Время хранится в секундах Unix.
+
+CREATE TABLE users
+(
+    login       TEXT PRIMARY KEY,
+    password    TEXT    NOT NULL,
+    vault_key   BYTEA,
+    revision    BIGINT  NOT NULL DEFAULT 0,
+    mfa_secret  TEXT,
+    mfa_enabled BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+CREATE TABLE logins
+(
+    id       BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    name     TEXT   NOT NULL,
+    login    TEXT   NOT NULL,
+    password TEXT,
+    website  TEXT,
+    notes    TEXT,
+    owner    TEXT   NOT NULL REFERENCES users (login),
+    revision BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE INDEX logins_owner_revision ON logins (owner, revision);
+
+CREATE TABLE notes
+(
+    id       BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    name     TEXT   NOT NULL,
+    text     TEXT,
+    owner    TEXT   NOT NULL REFERENCES users (login),
+    revision BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE INDEX notes_owner_revision ON notes (owner, revision);
+
+-- Содержимое бинарных данных хранится по SHA-256: одинаковое содержимое
+-- нескольких записей хранится один раз. Таблица blobs считает ссылки на
+-- содержимое, оно удаляется из хранилища вместе с последней ссылкой.
+CREATE TABLE blobs
+(
+    sha256 BYTEA PRIMARY KEY,
+    size   BIGINT NOT NULL,
+    refs   BIGINT NOT NULL
+);
+
+CREATE TABLE binaries
+(
+    id          BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    name        TEXT   NOT NULL,
+    filename    TEXT   NOT NULL,
+    size        BIGINT NOT NULL,
+    notes       TEXT,
+    owner       TEXT   NOT NULL REFERENCES users (login),
+    revision    BIGINT NOT NULL DEFAULT 0,
+    sha256      BYTEA  NOT NULL REFERENCES blobs (sha256),
+    compression BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE INDEX binaries_owner_revision ON binaries (owner, revision);
+
+CREATE TABLE cards
+(
+    id         BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    name       TEXT   NOT NULL,
+    number     TEXT   NOT NULL,
+    exp_date   TEXT   NOT NULL,
+    cvv        TEXT   NOT NULL,
+    cardholder TEXT   NOT NULL,
+    notes      TEXT,
+    owner      TEXT   NOT NULL REFERENCES users (login),
+    revision   BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE INDEX cards_owner_revision ON cards (owner, revision);
+
+CREATE TABLE otps
+(
+    id        BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    name      TEXT   NOT NULL,
+    type      TEXT   NOT NULL,
+    secret    TEXT   NOT NULL,
+    algorithm TEXT   NOT NULL,
+    digits    BIGINT NOT NULL,
+    period    BIGINT NOT NULL,
+    counter   BIGINT NOT NULL,
+    issuer    TEXT,
+    owner     TEXT   NOT NULL REFERENCES users (login),
+    revision  BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE INDEX otps_owner_revision ON otps (owner, revision);
+
+-- Удаленные записи оставляют после себя отметки, чтобы клиенты получали
+-- удаления после известной им ревизии.
+CREATE TABLE tombstones
+(
+    kind     TEXT   NOT NULL,
+    id       BIGINT NOT NULL,
+    owner    TEXT   NOT NULL REFERENCES users (login) ON DELETE CASCADE,
+    revision BIGINT NOT NULL,
+    PRIMARY KEY (kind, id)
+);
+
+CREATE INDEX tombstones_owner_revision ON tombstones (owner, revision);
+
+-- Сессии пользователей. Refresh-токен хранится только в виде хэша.
+CREATE TABLE sessions
+(
+    id                 TEXT PRIMARY KEY,
+    owner              TEXT   NOT NULL REFERENCES users (login) ON DELETE CASCADE,
+    refresh_token_hash BYTEA  NOT NULL,
+    created_at         BIGINT NOT NULL,
+    last_used_at       BIGINT NOT NULL,
+    expires_at         BIGINT NOT NULL
+);
+
+CREATE INDEX sessions_owner ON sessions (owner);
+
+-- Коды восстановления второго фактора хранятся в виде хэшей.
+CREATE TABLE recovery_codes
+(
+    owner     TEXT  NOT NULL REFERENCES users (login) ON DELETE CASCADE,
+    code_hash BYTEA NOT NULL,
+    PRIMARY KEY (owner, code_hash)
+);
+
+-- Неудачные попытки входа. Логин не связан с таблицей пользователей:
+-- блокируются и несуществующие логины.
+CREATE TABLE login_attempts
+(
+    login        TEXT PRIMARY KEY,
+    failures     BIGINT NOT NULL,
+    locked_until BIGINT NOT NULL DEFAULT 0
+);
+
+-- Незавершенные загрузки бинарных данных. Содержимое загрузки хранится
+-- в файле assets/upload/{id}, его размер - сохраненное смещение.
+CREATE TABLE uploads
+(
+    id           TEXT PRIMARY KEY,
+    owner        TEXT   NOT NULL REFERENCES users (login) ON DELETE CASCADE,
+    name         TEXT   NOT NULL,
+    filename     TEXT   NOT NULL,
+    size         BIGINT NOT NULL,
+    notes        TEXT,
+    content_size BIGINT NOT NULL,
+    sha256       BYTEA  NOT NULL,
+    expires_at   BIGINT NOT NULL,
+    compression  BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE INDEX uploads_expires_at ON uploads (expires_at);
+
+-- Ревизия пользователя увеличивается при каждом изменении его данных,
+-- а запись получает ревизию своего последнего изменения. Обновление
+-- строки пользователя блокирует ее, поэтому изменения данных одного
+-- пользователя получают ревизии по очереди.
+CREATE FUNCTION bump_revision() RETURNS TRIGGER AS
+$$
+BEGIN
+    UPDATE users SET revision = revision + 1 WHERE login = NEW.owner RETURNING revision INTO NEW.revision;
+    -- Для несуществующего пользователя вставку отклонит внешний ключ.
+    NEW.revision := COALESCE(NEW.revision, 0);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Удаление записи увеличивает ревизию пользователя и оставляет отметку
+-- с видом записи из аргумента триггера.
+CREATE FUNCTION record_tombstone() RETURNS TRIGGER AS
+$$
+DECLARE
+    current BIGINT;
+BEGIN
+    UPDATE users SET revision = revision + 1 WHERE login = OLD.owner RETURNING revision INTO current;
+    INSERT INTO tombstones (kind, id, owner, revision)
+    VALUES (TG_ARGV[0], OLD.id, OLD.owner, COALESCE(current, 0));
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER logins_insert_revision
+    BEFORE INSERT
+    ON logins
+    FOR EACH ROW
+EXECUTE FUNCTION bump_revision();
+
+CREATE TRIGGER logins_update_revision
+    BEFORE UPDATE OF name, login, password, website, notes
+    ON logins
+    FOR EACH ROW
+EXECUTE FUNCTION bump_revision();
+
+CREATE TRIGGER logins_delete_revision
+    AFTER DELETE
+    ON logins
+    FOR EACH ROW
+EXECUTE FUNCTION record_tombstone('login');
+
+CREATE TRIGGER notes_insert_revision
+    BEFORE INSERT
+    ON notes
+    FOR EACH ROW
+EXECUTE FUNCTION bump_revision();
+
+CREATE TRIGGER notes_update_revision
+    BEFORE UPDATE OF name, text
+    ON notes
+    FOR EACH ROW
+EXECUTE FUNCTION bump_revision();
+
+CREATE TRIGGER notes_delete_revision
+    AFTER DELETE
+    ON notes
+    FOR EACH ROW
+EXECUTE FUNCTION record_tombstone('note');
+
+CREATE TRIGGER binaries_insert_revision
+    BEFORE INSERT
+    ON binaries
+    FOR EACH ROW
+EXECUTE FUNCTION bump_revision();
+
+CREATE TRIGGER binaries_update_revision
+    BEFORE UPDATE OF name, notes
+    ON binaries
+    FOR EACH ROW
+EXECUTE FUNCTION bump_revision();
+
+CREATE TRIGGER binaries_delete_revision
+    AFTER DELETE
+    ON binaries
+    FOR EACH ROW
+EXECUTE FUNCTION record_tombstone('binary');
+
+CREATE TRIGGER cards_insert_revision
+    BEFORE INSERT
+    ON cards
+    FOR EACH ROW
+EXECUTE FUNCTION bump_revision();
+
+CREATE TRIGGER cards_update_revision
+    BEFORE UPDATE OF name, number, exp_date, cvv, cardholder, notes
+    ON cards
+    FOR EACH ROW
+EXECUTE FUNCTION bump_revision();
+
+CREATE TRIGGER cards_delete_revision
+    AFTER DELETE
+    ON cards
+    FOR EACH ROW
+EXECUTE FUNCTION record_tombstone('card');
+
+CREATE TRIGGER otps_insert_revision
+    BEFORE INSERT
+    ON otps
+    FOR EACH ROW
+EXECUTE FUNCTION bump_revision();
+
+CREATE TRIGGER otps_update_revision
+    BEFORE UPDATE OF name, type, secret, algorithm, digits, period, counter, issuer
+    ON otps
+    FOR EACH ROW
+EXECUTE FUNCTION bump_revision();
+
+CREATE TRIGGER otps_delete_revision
+    AFTER DELETE
+    ON otps
+    FOR EACH ROW
+EXECUTE FUNCTION record_tombstone('otp');
@@ -0,0 +1,16 @@
+-- Прежние версии записей. Изменение и удаление записи сохраняют ее версию
+-- до изменения: JSON данных, зашифрованный ключом сервера, если шифрование
+-- включено. Время хранится в секундах Unix.
+CREATE TABLE history
+(
+    id         BIGSERIAL PRIMARY KEY,
+    kind       TEXT    NOT NULL,
+    item_id    BIGINT  NOT NULL,
+    owner      TEXT    NOT NULL REFERENCES users (login) ON DELETE CASCADE,
+    version    BIGINT  NOT NULL,
+    removed    BOOLEAN NOT NULL,
+    data       TEXT    NOT NULL,
+    created_at BIGINT  NOT NULL
+);
+
+CREATE INDEX history_owner_item ON history (owner, kind, item_id);
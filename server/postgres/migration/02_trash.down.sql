@@ -0,0 +1,64 @@
+-- Записи из корзины удаляются окончательно. Содержимое их бинарных данных
+-- остается в хранилище, но ссылки на него освобождаются.
+
+UPDATE blobs
+SET refs = refs - (SELECT COUNT(*) FROM binaries WHERE binaries.sha256 = blobs.sha256 AND binaries.deleted_at IS NOT NULL);
+
+DELETE FROM logins WHERE deleted_at IS NOT NULL;
+DELETE FROM notes WHERE deleted_at IS NOT NULL;
+DELETE FROM binaries WHERE deleted_at IS NOT NULL;
+DELETE FROM cards WHERE deleted_at IS NOT NULL;
+DELETE FROM otps WHERE deleted_at IS NOT NULL;
+
+DROP TRIGGER logins_trash_revision ON logins;
+DROP TRIGGER logins_delete_revision ON logins;
+
+CREATE TRIGGER logins_delete_revision
+    AFTER DELETE
+    ON logins
+    FOR EACH ROW
+EXECUTE FUNCTION record_tombstone('login');
+
+DROP TRIGGER notes_trash_revision ON notes;
+DROP TRIGGER notes_delete_revision ON notes;
+
+CREATE TRIGGER notes_delete_revision
+    AFTER DELETE
+    ON notes
+    FOR EACH ROW
+EXECUTE FUNCTION record_tombstone('note');
+
+DROP TRIGGER binaries_trash_revision ON binaries;
+DROP TRIGGER binaries_delete_revision ON binaries;
+
+CREATE TRIGGER binaries_delete_revision
+    AFTER DELETE
+    ON binaries
+    FOR EACH ROW
+EXECUTE FUNCTION record_tombstone('binary');
+
+DROP TRIGGER cards_trash_revision ON cards;
+DROP TRIGGER cards_delete_revision ON cards;
+
+CREATE TRIGGER cards_delete_revision
+    AFTER DELETE
+    ON cards
+    FOR EACH ROW
+EXECUTE FUNCTION record_tombstone('card');
+
+DROP TRIGGER otps_trash_revision ON otps;
+DROP TRIGGER otps_delete_revision ON otps;
+
+CREATE TRIGGER otps_delete_revision
+    AFTER DELETE
+    ON otps
+    FOR EACH ROW
+EXECUTE FUNCTION record_tombstone('otp');
+
+DROP FUNCTION trash_revision();
+
+ALTER TABLE logins DROP COLUMN deleted_at;
+ALTER TABLE notes DROP COLUMN deleted_at;
+ALTER TABLE binaries DROP COLUMN deleted_at;
+ALTER TABLE cards DROP COLUMN deleted_at;
+ALTER TABLE otps DROP COLUMN deleted_at;
@@ -0,0 +1,111 @@
+-- Удаленные записи попадают в корзину: deleted_at - время удаления
+-- в секундах Unix, у записей вне корзины колонка пустая. Для клиентов
+-- перемещение в корзину - удаление, оно оставляет отметку. Восстановление
+-- из корзины убирает отметку и дает записи новую ревизию, а окончательное
+-- удаление записи из корзины ревизий не меняет: клиенты ее уже удалили.
+
+ALTER TABLE logins ADD COLUMN deleted_at BIGINT;
+ALTER TABLE notes ADD COLUMN deleted_at BIGINT;
+ALTER TABLE binaries ADD COLUMN deleted_at BIGINT;
+ALTER TABLE cards ADD COLUMN deleted_at BIGINT;
+ALTER TABLE otps ADD COLUMN deleted_at BIGINT;
+
+-- Перемещение записи в корзину и восстановление из нее увеличивают
+-- ревизию пользователя. Вид записи передается аргументом триггера.
+CREATE FUNCTION trash_revision() RETURNS TRIGGER AS
+$$
+DECLARE
+    current BIGINT;
+BEGIN
+    UPDATE users SET revision = revision + 1 WHERE login = NEW.owner RETURNING revision INTO current;
+    IF NEW.deleted_at IS NULL THEN
+        NEW.revision := COALESCE(current, 0);
+        DELETE FROM tombstones WHERE kind = TG_ARGV[0] AND id = NEW.id;
+    ELSE
+        INSERT INTO tombstones (kind, id, owner, revision)
+        VALUES (TG_ARGV[0], NEW.id, NEW.owner, COALESCE(current, 0))
+        ON CONFLICT (kind, id) DO UPDATE SET owner = EXCLUDED.owner, revision = EXCLUDED.revision;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER logins_delete_revision ON logins;
+
+CREATE TRIGGER logins_delete_revision
+    AFTER DELETE
+    ON logins
+    FOR EACH ROW
+    WHEN (OLD.deleted_at IS NULL)
+EXECUTE FUNCTION record_tombstone('login');
+
+CREATE TRIGGER logins_trash_revision
+    BEFORE UPDATE OF deleted_at
+    ON logins
+    FOR EACH ROW
+    WHEN (OLD.deleted_at IS DISTINCT FROM NEW.deleted_at)
+EXECUTE FUNCTION trash_revision('login');
+
+DROP TRIGGER notes_delete_revision ON notes;
+
+CREATE TRIGGER notes_delete_revision
+    AFTER DELETE
+    ON notes
+    FOR EACH ROW
+    WHEN (OLD.deleted_at IS NULL)
+EXECUTE FUNCTION record_tombstone('note');
+
+CREATE TRIGGER notes_trash_revision
+    BEFORE UPDATE OF deleted_at
+    ON notes
+    FOR EACH ROW
+    WHEN (OLD.deleted_at IS DISTINCT FROM NEW.deleted_at)
+EXECUTE FUNCTION trash_revision('note');
+
+DROP TRIGGER binaries_delete_revision ON binaries;
+
+CREATE TRIGGER binaries_delete_revision
+    AFTER DELETE
+    ON binaries
+    FOR EACH ROW
+    WHEN (OLD.deleted_at IS NULL)
+EXECUTE FUNCTION record_tombstone('binary');
+
+CREATE TRIGGER binaries_trash_revision
+    BEFORE UPDATE OF deleted_at
+    ON binaries
+    FOR EACH ROW
+    WHEN (OLD.deleted_at IS DISTINCT FROM NEW.deleted_at)
+EXECUTE FUNCTION trash_revision('binary');
+
+DROP TRIGGER cards_delete_revision ON cards;
+
+CREATE TRIGGER cards_delete_revision
+    AFTER DELETE
+    ON cards
+    FOR EACH ROW
+    WHEN (OLD.deleted_at IS NULL)
+EXECUTE FUNCTION record_tombstone('card');
+
+CREATE TRIGGER cards_trash_revision
+    BEFORE UPDATE OF deleted_at
+    ON cards
+    FOR EACH ROW
+    WHEN (OLD.deleted_at IS DISTINCT FROM NEW.deleted_at)
+EXECUTE FUNCTION trash_revision('card');
+
+DROP TRIGGER otps_delete_revision ON otps;
+
+CREATE TRIGGER otps_delete_revision
+    AFTER DELETE
+    ON otps
+    FOR EACH ROW
+    WHEN (OLD.deleted_at IS NULL)
+EXECUTE FUNCTION record_tombstone('otp');
+
+CREATE TRIGGER otps_trash_revision
+    BEFORE UPDATE OF deleted_at
+    ON otps
+    FOR EACH ROW
+    WHEN (OLD.deleted_at IS DISTINCT FROM NEW.deleted_at)
+EXECUTE FUNCTION trash_revision('otp');
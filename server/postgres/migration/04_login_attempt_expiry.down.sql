@@ -0,0 +1,3 @@
+DROP INDEX login_attempts_failed_at;
+
+ALTER TABLE login_attempts DROP COLUMN failed_at;
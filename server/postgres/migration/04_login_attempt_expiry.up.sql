@@ -0,0 +1,11 @@
+-- failed_at - время последней неудачной попытки в секундах Unix. Попытки
+-- удаляются, когда после нее и после блокировки проходит failure_ttl: иначе
+-- строки перебираемых несуществующих логинов копились бы без ограничений.
+-- Существующие попытки считаются сделанными во время миграции.
+
+ALTER TABLE login_attempts ADD COLUMN failed_at BIGINT NOT NULL DEFAULT 0;
+
+UPDATE login_attempts
+SET failed_at = EXTRACT(EPOCH FROM now())::BIGINT;
+
+CREATE INDEX login_attempts_failed_at ON login_attempts (failed_at);
@@ -0,0 +1,19 @@
+-- До этой миграции хэш записи был и ссылкой на содержимое, поэтому отмена
+-- невозможна, если хотя бы одна запись хранит содержимое под ключом
+-- дедупликации.
+
+DO
+$$
+    BEGIN
+        IF EXISTS (SELECT 1 FROM binaries WHERE blob <> sha256) THEN
+            RAISE EXCEPTION 'binaries deduplicated by content key cannot be migrated down';
+        END IF;
+    END
+$$;
+
+DROP INDEX binaries_blob;
+
+ALTER TABLE binaries ADD CONSTRAINT binaries_sha256_fkey FOREIGN KEY (sha256) REFERENCES blobs (sha256);
+ALTER TABLE binaries DROP COLUMN blob;
+
+ALTER TABLE uploads DROP COLUMN content_key;
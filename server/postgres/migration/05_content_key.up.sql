@@ -0,0 +1,16 @@
+-- Запись ссылается на общее содержимое колонкой blob, а sha256 - хэш этого
+-- содержимого. Они различаются у записей, загруженных с ключом
+-- дедупликации клиента (uploads.content_key): такое содержимое хранится под
+-- идентификатором из ключа.
+
+ALTER TABLE uploads ADD COLUMN content_key BYTEA;
+
+ALTER TABLE binaries ADD COLUMN blob BYTEA REFERENCES blobs (sha256);
+
+UPDATE binaries
+SET blob = sha256;
+
+ALTER TABLE binaries ALTER COLUMN blob SET NOT NULL;
+ALTER TABLE binaries DROP CONSTRAINT binaries_sha256_fkey;
+
+CREATE INDEX binaries_blob ON binaries (blob);
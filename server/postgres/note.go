@@ -0,0 +1,109 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/mkolibaba/gophkeeper/server"
+	"github.com/mkolibaba/gophkeeper/server/postgres/converter"
+	sqlc "github.com/mkolibaba/gophkeeper/server/postgres/sqlc/gen"
+	"github.com/mkolibaba/gophkeeper/server/sqlstore"
+)
+
+type NoteService struct {
+	db        *DB
+	store     *Store
+	converter converter.DataConverter
+}
+
+func NewNoteService(db *DB, queries *sqlc.Queries, converter converter.DataConverter) *NoteService {
+	return &NoteService{
+		db:        db,
+		store:     NewStore(db, queries),
+		converter: converter,
+	}
+}
+
+func (s *NoteService) Create(ctx context.Context, data server.NoteData) error {
+	if err := sqlstore.CheckItemQuota(ctx, s.store, s.db.quota, 1); err != nil {
+		return err
+	}
+
+	params := s.converter.ConvertToInsertNote(ctx, data)
+	if err := s.db.seal(insertNoteFields(&params)); err != nil {
+		return fmt.Errorf("create: %w", err)
+	}
+
+	_, err := s.store.InsertNote(ctx, params)
+	return unwrapInsertError(err)
+}
+
+func (s *NoteService) GetAll(ctx context.Context) ([]server.NoteData, error) {
+	return getAllData(ctx, s.db.keyring, s.store.SelectNotes, noteFields, s.converter.ConvertToNoteDataSlice)
+}
+
+func (s *NoteService) Update(ctx context.Context, id int64, version int64, data server.NoteDataUpdate) error {
+	note, err := s.store.SelectNote(ctx, id, server.UserFromContext(ctx))
+	if errors.Is(err, sql.ErrNoRows) {
+		return server.ErrDataNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("update: %w", err)
+	}
+	if note.Revision != version {
+		return &server.VersionConflictError{Version: note.Revision}
+	}
+	if err := s.db.open(noteFields(&note)); err != nil {
+		return fmt.Errorf("update: %w", err)
+	}
+
+	previous := s.converter.ConvertToNoteData(note)
+
+	params := s.converter.ConvertToUpdateNote(note)
+	s.converter.ConvertToUpdateNoteUpdate(data, &params)
+	params.Revision = version
+	if err := s.db.seal(updateNoteFields(&params)); err != nil {
+		return fmt.Errorf("update: %w", err)
+	}
+
+	err = s.store.changeWithHistory(ctx, server.DataKindNote, id, version, previous, false, func(qs *sqlc.Queries) error {
+		n, err := qs.UpdateNote(ctx, params)
+		return sqlstore.CheckChanged(ctx, n, err, qs.SelectNoteRevision, id)
+	})
+	if err != nil {
+		return fmt.Errorf("update: %w", err)
+	}
+	return nil
+}
+
+func (s *NoteService) Remove(ctx context.Context, id int64, version int64) error {
+	note, err := s.store.SelectNote(ctx, id, server.UserFromContext(ctx))
+	if errors.Is(err, sql.ErrNoRows) {
+		return server.ErrDataNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("remove: %w", err)
+	}
+	if note.Revision != version {
+		return &server.VersionConflictError{Version: note.Revision}
+	}
+	if err := s.db.open(noteFields(&note)); err != nil {
+		return fmt.Errorf("remove: %w", err)
+	}
+	previous := s.converter.ConvertToNoteData(note)
+
+	err = s.store.changeWithHistory(ctx, server.DataKindNote, id, version, previous, true, func(qs *sqlc.Queries) error {
+		n, err := qs.TrashNote(ctx, sqlc.TrashNoteParams{
+			DeletedAt: sqlstore.TrashedNow(),
+			ID:        id,
+			Owner:     server.UserFromContext(ctx),
+			Revision:  version,
+		})
+		return sqlstore.CheckChanged(ctx, n, err, qs.SelectNoteRevision, id)
+	})
+	if err != nil {
+		return fmt.Errorf("remove: %w", err)
+	}
+	return nil
+}
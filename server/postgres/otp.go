@@ -0,0 +1,109 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/mkolibaba/gophkeeper/server"
+	"github.com/mkolibaba/gophkeeper/server/postgres/converter"
+	sqlc "github.com/mkolibaba/gophkeeper/server/postgres/sqlc/gen"
+	"github.com/mkolibaba/gophkeeper/server/sqlstore"
+)
+
+type OTPService struct {
+	db        *DB
+	store     *Store
+	converter converter.DataConverter
+}
+
+func NewOTPService(db *DB, queries *sqlc.Queries, converter converter.DataConverter) *OTPService {
+	return &OTPService{
+		db:        db,
+		store:     NewStore(db, queries),
+		converter: converter,
+	}
+}
+
+func (s *OTPService) Create(ctx context.Context, data server.OTPData) error {
+	if err := sqlstore.CheckItemQuota(ctx, s.store, s.db.quota, 1); err != nil {
+		return err
+	}
+
+	params := s.converter.ConvertToInsertOTP(ctx, data)
+	if err := s.db.seal(insertOTPFields(&params)); err != nil {
+		return fmt.Errorf("create: %w", err)
+	}
+
+	_, err := s.store.InsertOTP(ctx, params)
+	return unwrapInsertError(err)
+}
+
+func (s *OTPService) GetAll(ctx context.Context) ([]server.OTPData, error) {
+	return getAllData(ctx, s.db.keyring, s.store.SelectOTPs, otpFields, s.converter.ConvertToOTPDataSlice)
+}
+
+func (s *OTPService) Update(ctx context.Context, id int64, version int64, data server.OTPDataUpdate) error {
+	otp, err := s.store.SelectOTP(ctx, id, server.UserFromContext(ctx))
+	if errors.Is(err, sql.ErrNoRows) {
+		return server.ErrDataNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("update: %w", err)
+	}
+	if otp.Revision != version {
+		return &server.VersionConflictError{Version: otp.Revision}
+	}
+	if err := s.db.open(otpFields(&otp)); err != nil {
+		return fmt.Errorf("update: %w", err)
+	}
+
+	previous := s.converter.ConvertToOTPData(otp)
+
+	params := s.converter.ConvertToUpdateOTP(otp)
+	s.converter.ConvertToUpdateOTPUpdate(data, &params)
+	params.Revision = version
+	if err := s.db.seal(updateOTPFields(&params)); err != nil {
+		return fmt.Errorf("update: %w", err)
+	}
+
+	err = s.store.changeWithHistory(ctx, server.DataKindOTP, id, version, previous, false, func(qs *sqlc.Queries) error {
+		n, err := qs.UpdateOTP(ctx, params)
+		return sqlstore.CheckChanged(ctx, n, err, qs.SelectOTPRevision, id)
+	})
+	if err != nil {
+		return fmt.Errorf("update: %w", err)
+	}
+	return nil
+}
+
+func (s *OTPService) Remove(ctx context.Context, id int64, version int64) error {
+	otp, err := s.store.SelectOTP(ctx, id, server.UserFromContext(ctx))
+	if errors.Is(err, sql.ErrNoRows) {
+		return server.ErrDataNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("remove: %w", err)
+	}
+	if otp.Revision != version {
+		return &server.VersionConflictError{Version: otp.Revision}
+	}
+	if err := s.db.open(otpFields(&otp)); err != nil {
+		return fmt.Errorf("remove: %w", err)
+	}
+	previous := s.converter.ConvertToOTPData(otp)
+
+	err = s.store.changeWithHistory(ctx, server.DataKindOTP, id, version, previous, true, func(qs *sqlc.Queries) error {
+		n, err := qs.TrashOTP(ctx, sqlc.TrashOTPParams{
+			DeletedAt: sqlstore.TrashedNow(),
+			ID:        id,
+			Owner:     server.UserFromContext(ctx),
+			Revision:  version,
+		})
+		return sqlstore.CheckChanged(ctx, n, err, qs.SelectOTPRevision, id)
+	})
+	if err != nil {
+		return fmt.Errorf("remove: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"github.com/charmbracelet/log"
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/mkolibaba/gophkeeper/server"
+	"github.com/mkolibaba/gophkeeper/server/migrate"
+	"os"
+	"slices"
+	"strconv"
+)
+
+//go:embed migration/*.sql
+var migrationFS embed.FS
+
+// migrationLock - ключ рекомендательной блокировки, под которой серверы,
+// запущенные с одной базой данных, применяют миграции по очереди.
+const migrationLock = 0x67706b6d // "gpkm"
+
+type DB struct {
+	db            *sql.DB
+	dsn           string
+	uploadsFolder string
+	quota         server.Quota
+	history       server.History
+	logger        *log.Logger
+
+	// keyring шифрует секретные поля. nil, если шифрование выключено.
+	keyring server.Keyring
+}
+
+func NewDB(config *server.Config, keyring server.Keyring, logger *log.Logger) *DB {
+	return &DB{
+		dsn:           config.Postgres.DSN,
+		uploadsFolder: fmt.Sprintf("%s/assets/upload", config.Postgres.DataFolder),
+		quota:         config.Quota,
+		history:       config.History,
+		logger:        logger,
+		keyring:       keyring,
+	}
+}
+
+// Connect открывает соединение с базой данных без применения миграций.
+func (d *DB) Connect() (err error) {
+	if d.dsn == "" {
+		return fmt.Errorf("dsn is required")
+	}
+
+	d.db, err = sql.Open("pgx", d.dsn)
+	return err
+}
+
+func (d *DB) Open() error {
+	if err := d.Connect(); err != nil {
+		return err
+	}
+
+	err := os.MkdirAll(d.uploadsFolder, 0755)
+	if err != nil {
+		return err
+	}
+
+	migrator, err := d.Migrator()
+	if err != nil {
+		return fmt.Errorf("migrate: %w", err)
+	}
+	if _, err := migrator.Up(context.Background(), false); err != nil {
+		return fmt.Errorf("migrate: %w", err)
+	}
+
+	return nil
+}
+
+func (d *DB) Close() error {
+	if d.db == nil {
+		return nil
+	}
+	return d.db.Close()
+}
+
+// Migrator возвращает миграции схемы базы данных. Соединение должно быть
+// открыто.
+func (d *DB) Migrator() (*migrate.Migrator, error) {
+	migrations, err := migrate.Load(migrationFS, "migration")
+	if err != nil {
+		return nil, err
+	}
+	return migrate.New(d.db, dialect, migrations, d.logger), nil
+}
+
+// dialect - особенности PostgreSQL для миграций. Миграции применяются под
+// рекомендательной блокировкой: серверы, запущенные с одной базой данных,
+// применяют их по очереди.
+var dialect = migrate.Dialect{
+	Placeholder: func(n int) string {
+		return "$" + strconv.Itoa(n)
+	},
+	TableExists: `SELECT COUNT(*) FROM information_schema.tables WHERE table_schema = current_schema() AND table_name = ?`,
+	Lock:        fmt.Sprintf(`SELECT pg_advisory_xact_lock(%d)`, migrationLock),
+}
+
+// lockContent захватывает ключи содержимого бинарных данных. Блокировки
+// рекомендательные и действуют для всех серверов, работающих с базой
+// данных, поэтому изменения ссылок на общее содержимое и операции с ним
+// в хранилище упорядочены и между серверами. Ключи захватываются в порядке
+// сортировки, чтобы одновременные захваты пересекающихся наборов не
+// блокировали друг друга. Возвращает функцию освобождения.
+func (d *DB) lockContent(ctx context.Context, keys ...string) (func(), error) {
+	// Блокировки держит отдельная транзакция: ее завершение освобождает их,
+	// даже если соединение оборвалось. Отмена ctx не должна завершать ее
+	// раньше операции под блокировкой.
+	tx, err := d.db.BeginTx(context.WithoutCancel(ctx), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	keys = slices.Compact(slices.Sorted(slices.Values(keys)))
+	for _, key := range keys {
+		if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtextextended($1, 0))`, key); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+
+	return func() {
+		tx.Rollback()
+	}, nil
+}
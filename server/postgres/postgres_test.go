@@ -0,0 +1,92 @@
+package postgres
+
+import (
+	"github.com/charmbracelet/log"
+	"github.com/mkolibaba/gophkeeper/server"
+	"github.com/mkolibaba/gophkeeper/server/fs"
+	"github.com/mkolibaba/gophkeeper/server/migrate"
+	"github.com/mkolibaba/gophkeeper/server/servicetest"
+	"github.com/stretchr/testify/require"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// dsnEnv - переменная окружения со строкой подключения к тестовой базе
+// данных. Без нее тесты пропускаются.
+const dsnEnv = "GOPHKEEPER_TEST_POSTGRES_DSN"
+
+// openTestDB открывает тестовую базу данных с примененными миграциями.
+func openTestDB(t *testing.T) *DB {
+	t.Helper()
+
+	dsn := os.Getenv(dsnEnv)
+	if dsn == "" {
+		t.Skipf("%s is not set", dsnEnv)
+	}
+
+	var config server.Config
+	config.Postgres.DataFolder = t.TempDir()
+	config.Postgres.DSN = dsn
+
+	db := NewDB(&config, nil, log.New(io.Discard))
+	require.NoError(t, OpenDB(db))
+	t.Cleanup(func() {
+		db.Close()
+	})
+	return db
+}
+
+func TestServices(t *testing.T) {
+	db := openTestDB(t)
+	queries := NewQueries(db)
+	converter := NewDataConverter()
+
+	var config server.Config
+	config.Blob.FS.Folder = filepath.Join(t.TempDir(), "binary")
+	blobs, err := fs.NewBlobStore(&config)
+	require.NoError(t, err)
+
+	logins := NewLoginService(db, queries, converter)
+	notes := NewNoteService(db, queries, converter)
+	cards := NewCardService(db, queries, converter)
+	otps := NewOTPService(db, queries, converter)
+	binaries := NewBinaryService(queries, db, blobs, converter)
+	servicetest.Run(t, servicetest.Services{
+		Users:    NewUserService(db, queries, blobs),
+		Logins:   logins,
+		Notes:    notes,
+		Cards:    cards,
+		OTPs:     otps,
+		Binaries: binaries,
+		History:  NewHistoryService(db, queries, logins, notes, binaries, cards, otps),
+		Trash:    NewTrashService(db, queries, binaries, converter),
+	})
+}
+
+func TestMigrate(t *testing.T) {
+	db := openTestDB(t)
+
+	migrator, err := db.Migrator()
+	require.NoError(t, err)
+
+	// Повторное открытие не применяет миграции заново.
+	steps, err := migrator.Up(t.Context(), false)
+	require.NoError(t, err)
+	require.Empty(t, steps)
+
+	// Схема отменяется целиком и применяется заново.
+	_, err = migrator.To(t.Context(), migrate.Initial, false)
+	require.NoError(t, err)
+	_, err = migrator.Up(t.Context(), false)
+	require.NoError(t, err)
+}
+
+func TestOpenWithoutDSN(t *testing.T) {
+	var config server.Config
+	config.Postgres.DataFolder = t.TempDir()
+
+	db := NewDB(&config, nil, log.New(io.Discard))
+	require.ErrorContains(t, db.Open(), "dsn is required")
+}
@@ -0,0 +1,681 @@
+-- name: SelectUser :one
+SELECT *
+FROM users
+WHERE login = $1;
+
+-- name: InsertUser :exec
+INSERT INTO users (login, password, vault_key)
+VALUES ($1, $2, $3);
+
+-- name: UpdateUserPassword :execrows
+UPDATE users
+SET password  = $1,
+    vault_key = $2
+WHERE login = $3;
+
+-- name: EnrollUserVaultKey :execrows
+UPDATE users
+SET password  = $1,
+    vault_key = $2
+WHERE login = $3
+  AND vault_key IS NULL;
+
+-- name: DeleteUser :execrows
+DELETE
+FROM users
+WHERE login = $1;
+
+-- name: DeleteUserLogins :exec
+DELETE
+FROM logins
+WHERE owner = $1;
+
+-- name: DeleteUserNotes :exec
+DELETE
+FROM notes
+WHERE owner = $1;
+
+-- name: DeleteUserBinaries :exec
+DELETE
+FROM binaries
+WHERE owner = $1;
+
+-- name: DeleteUserCards :exec
+DELETE
+FROM cards
+WHERE owner = $1;
+
+-- name: DeleteUserOTPs :exec
+DELETE
+FROM otps
+WHERE owner = $1;
+
+-- name: InsertLogin :one
+INSERT INTO logins (name, login, password, website, notes, owner)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id;
+
+-- name: UpdateLogin :execrows
+UPDATE logins
+SET name     = $1,
+    login    = $2,
+    password = $3,
+    website  = $4,
+    notes    = $5
+WHERE id = $6
+  AND revision = $7
+  AND deleted_at IS NULL;
+
+-- name: SelectLogin :one
+SELECT *
+FROM logins
+WHERE id = $1
+  AND owner = $2
+  AND deleted_at IS NULL;
+
+-- name: SelectLoginRevision :one
+SELECT revision
+FROM logins
+WHERE id = $1
+  AND owner = $2
+  AND deleted_at IS NULL;
+
+-- name: SelectLogins :many
+SELECT *
+FROM logins
+WHERE owner = $1
+  AND deleted_at IS NULL
+ORDER BY id;
+
+-- name: TrashLogin :execrows
+UPDATE logins
+SET deleted_at = $1
+WHERE id = $2
+  AND owner = $3
+  AND revision = $4
+  AND deleted_at IS NULL;
+
+-- name: InsertNote :one
+INSERT INTO notes (name, text, owner)
+VALUES ($1, $2, $3)
+RETURNING id;
+
+-- name: UpdateNote :execrows
+UPDATE notes
+SET name = $1,
+    text = $2
+WHERE id = $3
+  AND revision = $4
+  AND deleted_at IS NULL;
+
+-- name: SelectNote :one
+SELECT *
+FROM notes
+WHERE id = $1
+  AND owner = $2
+  AND deleted_at IS NULL;
+
+-- name: SelectNoteRevision :one
+SELECT revision
+FROM notes
+WHERE id = $1
+  AND owner = $2
+  AND deleted_at IS NULL;
+
+-- name: SelectNotes :many
+SELECT *
+FROM notes
+WHERE owner = $1
+  AND deleted_at IS NULL
+ORDER BY id;
+
+-- name: TrashNote :execrows
+UPDATE notes
+SET deleted_at = $1
+WHERE id = $2
+  AND owner = $3
+  AND revision = $4
+  AND deleted_at IS NULL;
+
+-- name: InsertBinary :one
+INSERT INTO binaries (name, filename, size, notes, owner, sha256, compression, blob)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING id;
+
+-- name: UpdateBinary :execrows
+UPDATE binaries
+SET name  = $1,
+    notes = $2
+WHERE id = $3
+  AND revision = $4
+  AND deleted_at IS NULL;
+
+-- name: SelectBinary :one
+SELECT *
+FROM binaries
+WHERE id = $1
+  AND owner = $2
+  AND deleted_at IS NULL;
+
+-- name: SelectBinaryRevision :one
+SELECT revision
+FROM binaries
+WHERE id = $1
+  AND owner = $2
+  AND deleted_at IS NULL;
+
+-- name: SelectBinaries :many
+SELECT *
+FROM binaries
+WHERE owner = $1
+  AND deleted_at IS NULL
+ORDER BY id;
+
+-- name: SelectUserBinaries :many
+SELECT *
+FROM binaries
+WHERE owner = $1;
+
+-- name: TrashBinary :execrows
+UPDATE binaries
+SET deleted_at = $1
+WHERE id = $2
+  AND owner = $3
+  AND revision = $4
+  AND deleted_at IS NULL;
+
+-- name: InsertCard :one
+INSERT INTO cards (name, number, exp_date, cvv, cardholder, notes, owner)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING id;
+
+-- name: UpdateCard :execrows
+UPDATE cards
+SET name       = $1,
+    number     = $2,
+    exp_date   = $3,
+    cvv        = $4,
+    cardholder = $5,
+    notes      = $6
+WHERE id = $7
+  AND revision = $8
+  AND deleted_at IS NULL;
+
+-- name: SelectCard :one
+SELECT *
+FROM cards
+WHERE id = $1
+  AND owner = $2
+  AND deleted_at IS NULL;
+
+-- name: SelectCardRevision :one
+SELECT revision
+FROM cards
+WHERE id = $1
+  AND owner = $2
+  AND deleted_at IS NULL;
+
+-- name: SelectCards :many
+SELECT *
+FROM cards
+WHERE owner = $1
+  AND deleted_at IS NULL
+ORDER BY id;
+
+-- name: TrashCard :execrows
+UPDATE cards
+SET deleted_at = $1
+WHERE id = $2
+  AND owner = $3
+  AND revision = $4
+  AND deleted_at IS NULL;
+
+-- name: InsertOTP :one
+INSERT INTO otps (name, type, secret, algorithm, digits, period, counter, issuer, owner)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING id;
+
+-- name: UpdateOTP :execrows
+UPDATE otps
+SET name      = $1,
+    type      = $2,
+    secret    = $3,
+    algorithm = $4,
+    digits    = $5,
+    period    = $6,
+    counter   = $7,
+    issuer    = $8
+WHERE id = $9
+  AND revision = $10
+  AND deleted_at IS NULL;
+
+-- name: SelectOTP :one
+SELECT *
+FROM otps
+WHERE id = $1
+  AND owner = $2
+  AND deleted_at IS NULL;
+
+-- name: SelectOTPRevision :one
+SELECT revision
+FROM otps
+WHERE id = $1
+  AND owner = $2
+  AND deleted_at IS NULL;
+
+-- name: SelectOTPs :many
+SELECT *
+FROM otps
+WHERE owner = $1
+  AND deleted_at IS NULL
+ORDER BY id;
+
+-- name: TrashOTP :execrows
+UPDATE otps
+SET deleted_at = $1
+WHERE id = $2
+  AND owner = $3
+  AND revision = $4
+  AND deleted_at IS NULL;
+
+-- name: SelectUserRevision :one
+SELECT revision
+FROM users
+WHERE login = $1;
+
+-- name: SelectLoginsSince :many
+SELECT *
+FROM logins
+WHERE owner = $1
+  AND revision > $2
+  AND deleted_at IS NULL;
+
+-- name: SelectNotesSince :many
+SELECT *
+FROM notes
+WHERE owner = $1
+  AND revision > $2
+  AND deleted_at IS NULL;
+
+-- name: SelectBinariesSince :many
+SELECT *
+FROM binaries
+WHERE owner = $1
+  AND revision > $2
+  AND deleted_at IS NULL;
+
+-- name: SelectCardsSince :many
+SELECT *
+FROM cards
+WHERE owner = $1
+  AND revision > $2
+  AND deleted_at IS NULL;
+
+-- name: SelectOTPsSince :many
+SELECT *
+FROM otps
+WHERE owner = $1
+  AND revision > $2
+  AND deleted_at IS NULL;
+
+-- name: SelectTombstonesSince :many
+SELECT *
+FROM tombstones
+WHERE owner = $1
+  AND revision > $2;
+
+-- name: InsertSession :exec
+INSERT INTO sessions (id, owner, refresh_token_hash, created_at, last_used_at, expires_at)
+VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: SelectSession :one
+SELECT *
+FROM sessions
+WHERE id = $1;
+
+-- name: SelectSessions :many
+SELECT *
+FROM sessions
+WHERE owner = $1
+ORDER BY created_at;
+
+-- name: UpdateSessionToken :execrows
+UPDATE sessions
+SET refresh_token_hash = sqlc.arg(refresh_token_hash),
+    last_used_at       = sqlc.arg(last_used_at),
+    expires_at         = sqlc.arg(expires_at)
+WHERE id = sqlc.arg(id)
+  AND refresh_token_hash = sqlc.arg(old_refresh_token_hash);
+
+-- name: DeleteSession :execrows
+DELETE
+FROM sessions
+WHERE id = $1
+  AND owner = $2;
+
+-- name: DeleteExpiredSessions :exec
+DELETE
+FROM sessions
+WHERE owner = $1
+  AND expires_at <= $2;
+
+-- name: UpdateUserMFAPendingSecret :execrows
+UPDATE users
+SET mfa_pending_secret = $1
+WHERE login = $2;
+
+-- name: EnableUserMFA :execrows
+UPDATE users
+SET mfa_secret         = $1,
+    mfa_pending_secret = NULL,
+    mfa_enabled        = TRUE,
+    mfa_last_step      = $2
+WHERE login = $3
+  AND NOT mfa_enabled;
+
+-- name: DisableUserMFA :execrows
+UPDATE users
+SET mfa_secret         = NULL,
+    mfa_pending_secret = NULL,
+    mfa_enabled        = FALSE,
+    mfa_last_step      = 0
+WHERE login = $1;
+
+-- name: UpdateUserMFALastStep :execrows
+UPDATE users
+SET mfa_last_step = sqlc.arg(mfa_last_step)
+WHERE login = sqlc.arg(login)
+  AND mfa_enabled
+  AND mfa_last_step < sqlc.arg(mfa_last_step);
+
+-- name: InsertRecoveryCode :exec
+INSERT INTO recovery_codes (owner, code_hash)
+VALUES ($1, $2);
+
+-- name: DeleteRecoveryCode :execrows
+DELETE
+FROM recovery_codes
+WHERE owner = $1
+  AND code_hash = $2;
+
+-- name: DeleteRecoveryCodes :exec
+DELETE
+FROM recovery_codes
+WHERE owner = $1;
+
+-- name: SelectLoginAttempt :one
+SELECT *
+FROM login_attempts
+WHERE login = $1;
+
+-- name: UpsertLoginAttemptFailure :one
+INSERT INTO login_attempts (login, failures, failed_at)
+VALUES ($1, 1, $2)
+ON CONFLICT (login) DO UPDATE SET failures  = login_attempts.failures + 1,
+                                  failed_at = excluded.failed_at
+RETURNING failures;
+
+-- name: UpdateLoginAttemptLock :exec
+UPDATE login_attempts
+SET locked_until = $1
+WHERE login = $2;
+
+-- name: DeleteLoginAttempt :exec
+DELETE
+FROM login_attempts
+WHERE login = $1;
+
+-- name: DeleteExpiredLoginAttempts :exec
+DELETE
+FROM login_attempts
+WHERE failed_at < sqlc.arg(before)
+  AND locked_until < sqlc.arg(before);
+
+-- name: InsertUpload :exec
+INSERT INTO uploads (id, owner, name, filename, size, notes, content_size, sha256, expires_at, compression, content_key)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11);
+
+-- name: SelectUpload :one
+SELECT *
+FROM uploads
+WHERE id = $1
+  AND owner = $2;
+
+-- name: SelectUploadIDs :many
+SELECT id
+FROM uploads
+WHERE owner = $1;
+
+-- name: SelectExpiredUploadIDs :many
+SELECT id
+FROM uploads
+WHERE expires_at <= $1;
+
+-- name: DeleteUpload :exec
+DELETE
+FROM uploads
+WHERE id = $1;
+
+-- name: SelectBlobSHA256 :one
+SELECT sha256
+FROM binaries
+WHERE blob = $1
+LIMIT 1;
+
+-- name: AcquireBlob :one
+INSERT INTO blobs (sha256, size, refs)
+VALUES ($1, $2, 1)
+ON CONFLICT (sha256) DO UPDATE SET refs = blobs.refs + 1
+RETURNING refs;
+
+-- name: ReleaseBlob :one
+UPDATE blobs
+SET refs = refs - 1
+WHERE sha256 = $1
+RETURNING refs;
+
+-- name: DeleteUnusedBlob :exec
+DELETE
+FROM blobs
+WHERE sha256 = $1
+  AND refs = 0;
+
+-- name: SelectUserItemCount :one
+SELECT (SELECT COUNT(*) FROM logins WHERE logins.owner = sqlc.arg(owner))
+           + (SELECT COUNT(*) FROM notes WHERE notes.owner = sqlc.arg(owner))
+           + (SELECT COUNT(*) FROM binaries WHERE binaries.owner = sqlc.arg(owner))
+           + (SELECT COUNT(*) FROM cards WHERE cards.owner = sqlc.arg(owner))
+           + (SELECT COUNT(*) FROM otps WHERE otps.owner = sqlc.arg(owner)) AS count;
+
+-- name: SelectUserContentSize :one
+SELECT CAST(COALESCE(SUM(blobs.size), 0) AS BIGINT) AS size
+FROM binaries
+         JOIN blobs ON blobs.sha256 = binaries.blob
+WHERE binaries.owner = $1;
+
+-- name: SelectUserPendingUploads :one
+SELECT COUNT(*)                                       AS count,
+       CAST(COALESCE(SUM(content_size), 0) AS BIGINT) AS size
+FROM uploads
+WHERE owner = $1;
+
+-- name: InsertHistory :exec
+INSERT INTO history (kind, item_id, owner, version, removed, data, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7);
+
+-- name: SelectHistory :many
+SELECT *
+FROM history
+WHERE owner = $1
+  AND kind = $2
+  AND item_id = $3
+  AND created_at >= $4
+ORDER BY id DESC;
+
+-- name: SelectHistoryVersion :one
+SELECT *
+FROM history
+WHERE owner = $1
+  AND kind = $2
+  AND item_id = $3
+  AND version = $4
+  AND created_at >= $5;
+
+-- name: DeleteHistoryOverLimit :exec
+DELETE
+FROM history
+WHERE owner = sqlc.arg(owner)
+  AND kind = sqlc.arg(kind)
+  AND item_id = sqlc.arg(item_id)
+  AND id NOT IN (SELECT id
+                 FROM history
+                 WHERE owner = sqlc.arg(owner)
+                   AND kind = sqlc.arg(kind)
+                   AND item_id = sqlc.arg(item_id)
+                 ORDER BY id DESC
+                 LIMIT sqlc.arg(keep));
+
+-- name: DeleteExpiredHistory :exec
+DELETE
+FROM history
+WHERE owner = $1
+  AND created_at < $2;
+
+-- name: DeleteItemHistory :exec
+DELETE
+FROM history
+WHERE owner = $1
+  AND kind = $2
+  AND item_id = $3;
+
+-- name: SelectTrashedLogins :many
+SELECT *
+FROM logins
+WHERE owner = $1
+  AND deleted_at IS NOT NULL;
+
+-- name: RestoreLogin :execrows
+UPDATE logins
+SET deleted_at = NULL
+WHERE id = $1
+  AND owner = $2
+  AND deleted_at IS NOT NULL;
+
+-- name: PurgeLogin :execrows
+DELETE
+FROM logins
+WHERE id = $1
+  AND owner = $2
+  AND deleted_at IS NOT NULL;
+
+-- name: SelectExpiredLogins :many
+SELECT id, owner
+FROM logins
+WHERE deleted_at <= $1;
+
+-- name: SelectTrashedNotes :many
+SELECT *
+FROM notes
+WHERE owner = $1
+  AND deleted_at IS NOT NULL;
+
+-- name: RestoreNote :execrows
+UPDATE notes
+SET deleted_at = NULL
+WHERE id = $1
+  AND owner = $2
+  AND deleted_at IS NOT NULL;
+
+-- name: PurgeNote :execrows
+DELETE
+FROM notes
+WHERE id = $1
+  AND owner = $2
+  AND deleted_at IS NOT NULL;
+
+-- name: SelectExpiredNotes :many
+SELECT id, owner
+FROM notes
+WHERE deleted_at <= $1;
+
+-- name: SelectTrashedBinaries :many
+SELECT *
+FROM binaries
+WHERE owner = $1
+  AND deleted_at IS NOT NULL;
+
+-- name: SelectTrashedBinary :one
+SELECT *
+FROM binaries
+WHERE id = $1
+  AND owner = $2
+  AND deleted_at IS NOT NULL;
+
+-- name: RestoreBinary :execrows
+UPDATE binaries
+SET deleted_at = NULL
+WHERE id = $1
+  AND owner = $2
+  AND deleted_at IS NOT NULL;
+
+-- name: PurgeBinary :execrows
+DELETE
+FROM binaries
+WHERE id = $1
+  AND owner = $2
+  AND deleted_at IS NOT NULL;
+
+-- name: SelectExpiredBinaries :many
+SELECT id, owner
+FROM binaries
+WHERE deleted_at <= $1;
+
+-- name: SelectTrashedCards :many
+SELECT *
+FROM cards
+WHERE owner = $1
+  AND deleted_at IS NOT NULL;
+
+-- name: RestoreCard :execrows
+UPDATE cards
+SET deleted_at = NULL
+WHERE id = $1
+  AND owner = $2
+  AND deleted_at IS NOT NULL;
+
+-- name: PurgeCard :execrows
+DELETE
+FROM cards
+WHERE id = $1
+  AND owner = $2
+  AND deleted_at IS NOT NULL;
+
+-- name: SelectExpiredCards :many
+SELECT id, owner
+FROM cards
+WHERE deleted_at <= $1;
+
+-- name: SelectTrashedOTPs :many
+SELECT *
+FROM otps
+WHERE owner = $1
+  AND deleted_at IS NOT NULL;
+
+-- name: RestoreOTP :execrows
+UPDATE otps
+SET deleted_at = NULL
+WHERE id = $1
+  AND owner = $2
+  AND deleted_at IS NOT NULL;
+
+-- name: PurgeOTP :execrows
+DELETE
+FROM otps
+WHERE id = $1
+  AND owner = $2
+  AND deleted_at IS NOT NULL;
+
+-- name: SelectExpiredOTPs :many
+SELECT id, owner
+FROM otps
+WHERE deleted_at <= $1;
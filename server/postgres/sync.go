@@ -0,0 +1,104 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"github.com/mkolibaba/gophkeeper/server"
+	"github.com/mkolibaba/gophkeeper/server/postgres/converter"
+	sqlc "github.com/mkolibaba/gophkeeper/server/postgres/sqlc/gen"
+	"github.com/mkolibaba/gophkeeper/server/sealed"
+)
+
+type SyncService struct {
+	db        *DB
+	qs        *sqlc.Queries
+	converter converter.DataConverter
+}
+
+func NewSyncService(db *DB, queries *sqlc.Queries, converter converter.DataConverter) *SyncService {
+	return &SyncService{
+		db:        db,
+		qs:        queries,
+		converter: converter,
+	}
+}
+
+func (s *SyncService) Changes(ctx context.Context, since int64) (*server.Changes, error) {
+	user := server.UserFromContext(ctx)
+
+	// Читаем все изменения в одной транзакции, чтобы ревизия
+	// соответствовала возвращаемым данным.
+	tx, err := s.db.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("changes: %w", err)
+	}
+	defer tx.Rollback()
+
+	qs := s.qs.WithTx(tx)
+
+	var changes server.Changes
+	if changes.Revision, err = qs.SelectUserRevision(ctx, user); err != nil {
+		return nil, fmt.Errorf("changes: %w", err)
+	}
+
+	logins, err := qs.SelectLoginsSince(ctx, user, since)
+	if err != nil {
+		return nil, fmt.Errorf("changes: %w", err)
+	}
+	if err := sealed.OpenRows(s.db.keyring, logins, loginFields); err != nil {
+		return nil, fmt.Errorf("changes: %w", err)
+	}
+	changes.Logins = s.converter.ConvertToLoginDataSlice(logins)
+
+	notes, err := qs.SelectNotesSince(ctx, user, since)
+	if err != nil {
+		return nil, fmt.Errorf("changes: %w", err)
+	}
+	if err := sealed.OpenRows(s.db.keyring, notes, noteFields); err != nil {
+		return nil, fmt.Errorf("changes: %w", err)
+	}
+	changes.Notes = s.converter.ConvertToNoteDataSlice(notes)
+
+	binaries, err := qs.SelectBinariesSince(ctx, user, since)
+	if err != nil {
+		return nil, fmt.Errorf("changes: %w", err)
+	}
+	if err := sealed.OpenRows(s.db.keyring, binaries, binaryFields); err != nil {
+		return nil, fmt.Errorf("changes: %w", err)
+	}
+	changes.Binaries = s.converter.ConvertToBinaryDataSlice(binaries)
+
+	cards, err := qs.SelectCardsSince(ctx, user, since)
+	if err != nil {
+		return nil, fmt.Errorf("changes: %w", err)
+	}
+	if err := sealed.OpenRows(s.db.keyring, cards, cardFields); err != nil {
+		return nil, fmt.Errorf("changes: %w", err)
+	}
+	changes.Cards = s.converter.ConvertToCardDataSlice(cards)
+
+	otps, err := qs.SelectOTPsSince(ctx, user, since)
+	if err != nil {
+		return nil, fmt.Errorf("changes: %w", err)
+	}
+	if err := sealed.OpenRows(s.db.keyring, otps, otpFields); err != nil {
+		return nil, fmt.Errorf("changes: %w", err)
+	}
+	changes.OTPs = s.converter.ConvertToOTPDataSlice(otps)
+
+	tombstones, err := qs.SelectTombstonesSince(ctx, user, since)
+	if err != nil {
+		return nil, fmt.Errorf("changes: %w", err)
+	}
+	changes.Tombstones = s.converter.ConvertToTombstoneSlice(tombstones)
+
+	return &changes, nil
+}
+
+func (s *SyncService) Revision(ctx context.Context) (int64, error) {
+	revision, err := s.qs.SelectUserRevision(ctx, server.UserFromContext(ctx))
+	if err != nil {
+		return 0, fmt.Errorf("revision: %w", err)
+	}
+	return revision, nil
+}
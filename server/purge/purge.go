@@ -0,0 +1,109 @@
+// Package purge - фоновая очистка корзины: записи, срок хранения которых
+// в корзине истек, удаляются окончательно.
+package purge
+
+import (
+	"context"
+	"github.com/charmbracelet/log"
+	"github.com/mkolibaba/gophkeeper/server"
+	"go.uber.org/fx"
+	"time"
+)
+
+// defaultInterval - период очистки, если он не задан в конфигурации.
+const defaultInterval = time.Hour
+
+var Module = fx.Module(
+	"purge",
+	fx.Provide(
+		NewPurger,
+	),
+	fx.Invoke(
+		StartPurger,
+	),
+)
+
+func StartPurger(*Purger) {
+}
+
+type Purger struct {
+	trash     server.TrashService
+	retention time.Duration
+	interval  time.Duration
+	logger    *log.Logger
+	now       func() time.Time
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewPurger создает очистку корзины и запускает ее вместе с приложением.
+// Очистка выключена, если срок хранения в корзине не задан.
+func NewPurger(lc fx.Lifecycle, trash server.TrashService, config *server.Config, logger *log.Logger) *Purger {
+	p := &Purger{
+		trash:     trash,
+		retention: config.Trash.Retention,
+		interval:  config.Trash.PurgeInterval,
+		logger:    logger,
+		now:       time.Now,
+	}
+	if p.interval <= 0 {
+		p.interval = defaultInterval
+	}
+
+	lc.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			if p.retention <= 0 {
+				p.logger.Info("trash purge disabled")
+				return nil
+			}
+			ctx, cancel := context.WithCancel(context.Background())
+			p.cancel = cancel
+			p.done = make(chan struct{})
+			go p.run(ctx)
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			if p.cancel == nil {
+				return nil
+			}
+			p.cancel()
+			select {
+			case <-p.done:
+			case <-ctx.Done():
+			}
+			return nil
+		},
+	})
+
+	return p
+}
+
+// run очищает корзину сразу и затем каждые interval, пока ctx не отменен.
+func (p *Purger) run(ctx context.Context) {
+	defer close(p.done)
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		p.purge(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// purge удаляет записи, пролежавшие в корзине дольше срока хранения.
+func (p *Purger) purge(ctx context.Context) {
+	purged, err := p.trash.PurgeExpired(ctx, p.now().Add(-p.retention))
+	if err != nil && ctx.Err() == nil {
+		p.logger.Error("failed to purge trash", "err", err, "purged", purged)
+		return
+	}
+	if purged > 0 {
+		p.logger.Info("trash purged", "purged", purged)
+	}
+}
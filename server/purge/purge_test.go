@@ -0,0 +1,54 @@
+package purge
+
+import (
+	"context"
+	"github.com/charmbracelet/log"
+	"github.com/mkolibaba/gophkeeper/server"
+	"github.com/mkolibaba/gophkeeper/server/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx/fxtest"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestPurger(t *testing.T) {
+	now := time.Date(2025, 1, 31, 12, 0, 0, 0, time.UTC)
+
+	t.Run("purge_on_start", func(t *testing.T) {
+		calls := make(chan time.Time, 1)
+		trash := &mock.TrashServiceMock{
+			PurgeExpiredFunc: func(ctx context.Context, before time.Time) (int, error) {
+				calls <- before
+				return 1, nil
+			},
+		}
+
+		var config server.Config
+		config.Trash.Retention = 30 * 24 * time.Hour
+
+		lc := fxtest.NewLifecycle(t)
+		p := NewPurger(lc, trash, &config, log.New(io.Discard))
+		p.now = func() time.Time { return now }
+		require.Equal(t, defaultInterval, p.interval)
+
+		lc.RequireStart()
+		select {
+		case before := <-calls:
+			require.Equal(t, now.Add(-30*24*time.Hour), before)
+		case <-time.After(time.Second):
+			t.Fatal("trash was not purged")
+		}
+		lc.RequireStop()
+	})
+	t.Run("disabled", func(t *testing.T) {
+		trash := &mock.TrashServiceMock{}
+
+		lc := fxtest.NewLifecycle(t)
+		NewPurger(lc, trash, &server.Config{}, log.New(io.Discard))
+		lc.RequireStart()
+		lc.RequireStop()
+
+		require.Empty(t, trash.PurgeExpiredCalls())
+	})
+}
@@ -0,0 +1,209 @@
+package s3
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"github.com/mkolibaba/gophkeeper/server"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// BlobStore хранит содержимое в бакете S3-совместимого хранилища
+// (AWS S3, MinIO и т.д.). Объекты адресуются в стиле path:
+// {endpoint}/{bucket}/{key}.
+type BlobStore struct {
+	client      *http.Client
+	endpoint    *url.URL
+	bucket      string
+	credentials credentials
+	now         func() time.Time
+}
+
+func NewBlobStore(config *server.Config) (*BlobStore, error) {
+	endpoint, err := url.Parse(config.Blob.S3.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("new blob store: %w", err)
+	}
+	if endpoint.Scheme == "" || endpoint.Host == "" {
+		return nil, fmt.Errorf("new blob store: invalid endpoint %q", config.Blob.S3.Endpoint)
+	}
+	if config.Blob.S3.Bucket == "" {
+		return nil, errors.New("new blob store: bucket is required")
+	}
+
+	return &BlobStore{
+		client:   http.DefaultClient,
+		endpoint: endpoint,
+		bucket:   config.Blob.S3.Bucket,
+		credentials: credentials{
+			accessKey: config.Blob.S3.AccessKey,
+			secretKey: config.Blob.S3.SecretKey,
+			region:    config.Blob.S3.Region,
+		},
+		now: time.Now,
+	}, nil
+}
+
+func (s *BlobStore) Put(ctx context.Context, key string, r io.Reader) error {
+	// S3 требует знать размер тела заранее.
+	size, err := contentLength(r)
+	if err != nil {
+		// Размер неизвестен: сохраняем содержимое во временный файл.
+		tmp, err := spool(r)
+		if err != nil {
+			return fmt.Errorf("put: %w", err)
+		}
+		defer os.Remove(tmp.Name())
+		defer tmp.Close()
+
+		r = tmp
+		if size, err = contentLength(tmp); err != nil {
+			return fmt.Errorf("put: %w", err)
+		}
+	}
+
+	resp, err := s.do(ctx, http.MethodPut, key, r, size)
+	if err != nil {
+		return fmt.Errorf("put: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("put: %w", responseError(resp))
+	}
+	return nil
+}
+
+func (s *BlobStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	resp, err := s.do(ctx, http.MethodGet, key, nil, 0)
+	if err != nil {
+		return nil, fmt.Errorf("get: %w", err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return resp.Body, nil
+	case http.StatusNotFound:
+		resp.Body.Close()
+		return nil, server.ErrBlobNotFound
+	default:
+		defer resp.Body.Close()
+		return nil, fmt.Errorf("get: %w", responseError(resp))
+	}
+}
+
+func (s *BlobStore) Delete(ctx context.Context, key string) error {
+	resp, err := s.do(ctx, http.MethodDelete, key, nil, 0)
+	if err != nil {
+		return fmt.Errorf("delete: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent, http.StatusNotFound:
+		return nil
+	default:
+		return fmt.Errorf("delete: %w", responseError(resp))
+	}
+}
+
+func (s *BlobStore) Stat(ctx context.Context, key string) (server.BlobInfo, error) {
+	resp, err := s.do(ctx, http.MethodHead, key, nil, 0)
+	if err != nil {
+		return server.BlobInfo{}, fmt.Errorf("stat: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return server.BlobInfo{Size: resp.ContentLength}, nil
+	case http.StatusNotFound:
+		return server.BlobInfo{}, server.ErrBlobNotFound
+	default:
+		return server.BlobInfo{}, fmt.Errorf("stat: %w", responseError(resp))
+	}
+}
+
+func (s *BlobStore) do(ctx context.Context, method string, key string, body io.Reader, size int64) (*http.Response, error) {
+	if key == "" || strings.Contains(key, "/") {
+		return nil, fmt.Errorf("invalid key %q", key)
+	}
+
+	u := *s.endpoint
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/" + s.bucket + "/" + key
+	u.RawPath = uriEncode(u.Path, false)
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		// Тело не закрывается клиентом: им владеет вызывающий код.
+		req.Body = io.NopCloser(body)
+		req.ContentLength = size
+		if size == 0 {
+			req.Body = http.NoBody
+		}
+	}
+
+	s.credentials.sign(req, s.now())
+	return s.client.Do(req)
+}
+
+// contentLength возвращает число непрочитанных байт r, если r
+// поддерживает Seek.
+func contentLength(r io.Reader) (int64, error) {
+	seeker, ok := r.(io.Seeker)
+	if !ok {
+		return 0, errors.New("content length is unknown")
+	}
+
+	current, err := seeker.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return 0, err
+	}
+	end, err := seeker.Seek(0, io.SeekEnd)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := seeker.Seek(current, io.SeekStart); err != nil {
+		return 0, err
+	}
+	return end - current, nil
+}
+
+func spool(r io.Reader) (*os.File, error) {
+	tmp, err := os.CreateTemp("", "gophkeeper-blob-*")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return nil, err
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return nil, err
+	}
+	return tmp, nil
+}
+
+// responseError возвращает ошибку из XML-ответа хранилища.
+func responseError(resp *http.Response) error {
+	var body struct {
+		Code    string
+		Message string
+	}
+	if err := xml.NewDecoder(io.LimitReader(resp.Body, 64*1024)).Decode(&body); err != nil || body.Code == "" {
+		return fmt.Errorf("unexpected response: %s", resp.Status)
+	}
+	return fmt.Errorf("%s: %s", body.Code, body.Message)
+}
@@ -0,0 +1,137 @@
+package s3
+
+import (
+	"bytes"
+	"github.com/mkolibaba/gophkeeper/server"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"testing/iotest"
+)
+
+func TestBlobStore(t *testing.T) {
+	fake := newFakeS3("gophkeeper")
+	ts := httptest.NewServer(fake)
+	t.Cleanup(ts.Close)
+
+	var config server.Config
+	config.Blob.S3.Endpoint = ts.URL
+	config.Blob.S3.Region = "us-east-1"
+	config.Blob.S3.Bucket = "gophkeeper"
+	config.Blob.S3.AccessKey = "access"
+	config.Blob.S3.SecretKey = "secret"
+
+	store, err := NewBlobStore(&config)
+	require.NoError(t, err)
+
+	t.Run("put_get", func(t *testing.T) {
+		require.NoError(t, store.Put(t.Context(), "1", strings.NewReader("content 1")))
+
+		r, err := store.Get(t.Context(), "1")
+		require.NoError(t, err)
+		defer r.Close()
+		content, err := io.ReadAll(r)
+		require.NoError(t, err)
+		require.Equal(t, "content 1", string(content))
+
+		info, err := store.Stat(t.Context(), "1")
+		require.NoError(t, err)
+		require.EqualValues(t, 9, info.Size)
+	})
+	t.Run("put_unknown_size", func(t *testing.T) {
+		// Reader без Seek сохраняется во временный файл.
+		r := iotest.OneByteReader(strings.NewReader("content 2"))
+		require.NoError(t, store.Put(t.Context(), "2", r))
+		require.Equal(t, "content 2", string(fake.objects["2"]))
+	})
+	t.Run("put_empty", func(t *testing.T) {
+		require.NoError(t, store.Put(t.Context(), "3", bytes.NewReader(nil)))
+		info, err := store.Stat(t.Context(), "3")
+		require.NoError(t, err)
+		require.Zero(t, info.Size)
+	})
+	t.Run("not_found", func(t *testing.T) {
+		_, err := store.Get(t.Context(), "missing")
+		require.ErrorIs(t, err, server.ErrBlobNotFound)
+		_, err = store.Stat(t.Context(), "missing")
+		require.ErrorIs(t, err, server.ErrBlobNotFound)
+		require.NoError(t, store.Delete(t.Context(), "missing"))
+	})
+	t.Run("delete", func(t *testing.T) {
+		require.NoError(t, store.Put(t.Context(), "4", strings.NewReader("content")))
+		require.NoError(t, store.Delete(t.Context(), "4"))
+		require.NotContains(t, fake.objects, "4")
+	})
+	t.Run("access_denied", func(t *testing.T) {
+		config := config
+		config.Blob.S3.AccessKey = "unknown"
+		store, err := NewBlobStore(&config)
+		require.NoError(t, err)
+
+		err = store.Put(t.Context(), "5", strings.NewReader("content"))
+		require.ErrorContains(t, err, "AccessDenied")
+	})
+}
+
+// fakeS3 - хранилище объектов одного бакета в памяти. Проверяет только
+// ключ доступа в подписи.
+type fakeS3 struct {
+	mu      sync.Mutex
+	bucket  string
+	objects map[string][]byte
+}
+
+func newFakeS3(bucket string) *fakeS3 {
+	return &fakeS3{bucket: bucket, objects: make(map[string][]byte)}
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=access/") {
+		writeError(w, http.StatusForbidden, "AccessDenied")
+		return
+	}
+	key, ok := strings.CutPrefix(r.URL.Path, "/"+f.bucket+"/")
+	if !ok {
+		writeError(w, http.StatusNotFound, "NoSuchBucket")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		if r.ContentLength < 0 {
+			writeError(w, http.StatusLengthRequired, "MissingContentLength")
+			return
+		}
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "IncompleteBody")
+			return
+		}
+		f.objects[key] = data
+	case http.MethodGet, http.MethodHead:
+		data, ok := f.objects[key]
+		if !ok {
+			writeError(w, http.StatusNotFound, "NoSuchKey")
+			return
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+		w.Write(data)
+	case http.MethodDelete:
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	io.WriteString(w, "<Error><Code>"+code+"</Code><Message>"+code+"</Message></Error>")
+}
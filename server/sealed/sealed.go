@@ -0,0 +1,139 @@
+package sealed
+
+import (
+	"encoding/base64"
+	"fmt"
+	"github.com/mkolibaba/gophkeeper/server"
+	"strings"
+)
+
+// Prefix отмечает значения, зашифрованные ключом сервера. Значения без
+// нее сохранены до включения шифрования и читаются как есть.
+const Prefix = escape + "sealed:"
+
+// escape - управляющий символ, с которого начинается Prefix. Открытые
+// значения, начинающиеся с него, сохраняются с еще одним таким символом,
+// поэтому ни одно из них не совпадет с Prefix.
+const escape = "\x01"
+
+// Fields - секретные поля строки таблицы.
+type Fields struct {
+	Required []*string
+	Optional []**string
+}
+
+// Sealer шифрует и расшифровывает поля строк на месте, запоминая первую
+// возникшую ошибку. Если ключ не задан, поля не шифруются, а только
+// экранируются.
+type Sealer struct {
+	keyring server.Keyring
+	err     error
+}
+
+func NewSealer(keyring server.Keyring) *Sealer {
+	return &Sealer{keyring: keyring}
+}
+
+// Seal шифрует поля. Необязательные поля копируются, чтобы не изменять
+// данные вызывающей стороны.
+func (s *Sealer) Seal(fields Fields) {
+	for _, field := range fields.Required {
+		s.seal(field)
+	}
+	for _, field := range fields.Optional {
+		if *field == nil {
+			continue
+		}
+		value := **field
+		s.seal(&value)
+		*field = &value
+	}
+}
+
+// Open расшифровывает поля.
+func (s *Sealer) Open(fields Fields) {
+	for _, field := range fields.Required {
+		s.open(field)
+	}
+	for _, field := range fields.Optional {
+		if *field == nil {
+			continue
+		}
+		value := **field
+		s.open(&value)
+		*field = &value
+	}
+}
+
+// Err возвращает первую ошибку шифрования или расшифровки.
+func (s *Sealer) Err() error {
+	return s.err
+}
+
+func (s *Sealer) seal(field *string) {
+	if s.err != nil {
+		return
+	}
+	if s.keyring == nil {
+		if strings.HasPrefix(*field, escape) {
+			*field = escape + *field
+		}
+		return
+	}
+
+	sealed, err := s.keyring.Seal([]byte(*field))
+	if err != nil {
+		s.err = err
+		return
+	}
+	*field = Prefix + base64.StdEncoding.EncodeToString(sealed)
+}
+
+func (s *Sealer) open(field *string) {
+	if s.err != nil {
+		return
+	}
+	if !strings.HasPrefix(*field, Prefix) {
+		*field = strings.TrimPrefix(*field, escape)
+		return
+	}
+	if s.keyring == nil {
+		s.err = server.ErrEncryptionNotSet
+		return
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(*field, Prefix))
+	if err != nil {
+		s.err = fmt.Errorf("decode sealed field: %w", err)
+		return
+	}
+	opened, err := s.keyring.Open(sealed)
+	if err != nil {
+		s.err = err
+		return
+	}
+	*field = string(opened)
+}
+
+// Seal шифрует поля ключом keyring.
+func Seal(keyring server.Keyring, fields Fields) error {
+	sealer := NewSealer(keyring)
+	sealer.Seal(fields)
+	return sealer.Err()
+}
+
+// Open расшифровывает поля ключом keyring.
+func Open(keyring server.Keyring, fields Fields) error {
+	sealer := NewSealer(keyring)
+	sealer.Open(fields)
+	return sealer.Err()
+}
+
+// OpenRows расшифровывает поля строк rows.
+func OpenRows[S any](keyring server.Keyring, rows []S, fields func(*S) Fields) error {
+	sealer := NewSealer(keyring)
+	for i := range rows {
+		sealer.Open(fields(&rows[i]))
+	}
+	return sealer.Err()
+}
@@ -0,0 +1,58 @@
+package sealed
+
+import (
+	"bytes"
+	"github.com/mkolibaba/gophkeeper/server"
+	"github.com/mkolibaba/gophkeeper/server/aesgcm"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func TestSealer(t *testing.T) {
+	keyring, err := aesgcm.New(bytes.Repeat([]byte{1}, aesgcm.KeySize))
+	require.NoError(t, err)
+
+	name, notes := "name", "notes"
+	var empty *string
+	original := &notes
+	fields := Fields{Required: []*string{&name}, Optional: []**string{&original, &empty}}
+
+	require.NoError(t, Seal(keyring, fields))
+	require.True(t, strings.HasPrefix(name, Prefix))
+	require.True(t, strings.HasPrefix(*original, Prefix))
+	require.Nil(t, empty)
+	// Данные вызывающей стороны не изменились.
+	require.Equal(t, "notes", notes)
+
+	require.NoError(t, Open(keyring, fields))
+	require.Equal(t, "name", name)
+	require.Equal(t, "notes", *original)
+
+	t.Run("plaintext", func(t *testing.T) {
+		plain := "plain"
+		require.NoError(t, Open(keyring, Fields{Required: []*string{&plain}}))
+		require.Equal(t, "plain", plain)
+	})
+	t.Run("prefix_in_plaintext", func(t *testing.T) {
+		for _, value := range []string{"sealed:", "sealed:c2VjcmV0", "\x01", Prefix, "\x01" + Prefix} {
+			for _, keyring := range []server.Keyring{keyring, nil} {
+				field := value
+				require.NoError(t, Seal(keyring, Fields{Required: []*string{&field}}))
+				if keyring == nil {
+					require.False(t, strings.HasPrefix(field, Prefix))
+				}
+				require.NoError(t, Open(keyring, Fields{Required: []*string{&field}}))
+				require.Equal(t, value, field)
+			}
+		}
+	})
+	t.Run("no_keyring", func(t *testing.T) {
+		value := "value"
+		require.NoError(t, Seal(nil, Fields{Required: []*string{&value}}))
+		require.Equal(t, "value", value)
+
+		require.NoError(t, Seal(keyring, Fields{Required: []*string{&value}}))
+		require.ErrorIs(t, Open(nil, Fields{Required: []*string{&value}}), server.ErrEncryptionNotSet)
+	})
+}
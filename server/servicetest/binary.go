@@ -0,0 +1,188 @@
+package servicetest
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"github.com/mkolibaba/gophkeeper/server"
+	"github.com/stretchr/testify/require"
+	"io"
+	"testing"
+)
+
+func testBinaries(t *testing.T, services Services) {
+	service := services.Binaries
+	data := server.BinaryData{
+		Name:        "file",
+		Filename:    "file.txt",
+		Size:        7,
+		Notes:       "notes",
+		Compression: server.CompressionZstd,
+	}
+	content := []byte("content")
+
+	t.Run("round_trip", func(t *testing.T) {
+		large := make([]byte, 3<<20+123)
+		_, err := rand.Read(large)
+		require.NoError(t, err)
+
+		for name, content := range map[string][]byte{
+			"empty": {},
+			"small": []byte("content"),
+			"large": large,
+		} {
+			t.Run(name, func(t *testing.T) {
+				ctx := newUser(t, services)
+				createBinary(t, ctx, service, data, content)
+
+				binary := single(t, ctx, service)
+				require.NotZero(t, binary.ID)
+				want := data
+				want.ID, want.Version = binary.ID, binary.Version
+				require.Equal(t, want, binary)
+
+				got, gotContent := readBinary(t, ctx, service, binary.ID)
+				require.Equal(t, binary, got.BinaryData)
+				require.True(t, bytes.Equal(content, gotContent), "content differs")
+				require.Equal(t, int64(len(content)), got.ContentSize)
+				digest := sha256.Sum256(content)
+				require.Equal(t, digest[:], got.SHA256)
+			})
+		}
+	})
+	t.Run("known_digest", func(t *testing.T) {
+		// Содержимое завершенной загрузки приходит с уже посчитанным хэшем.
+		ctx := newUser(t, services)
+		digest := sha256.Sum256(content)
+		err := service.Create(ctx, server.ReadableBinaryData{
+			BinaryData:  data,
+			DataReader:  io.NopCloser(bytes.NewReader(content)),
+			ContentSize: int64(len(content)),
+			SHA256:      digest[:],
+		})
+		require.NoError(t, err)
+
+		got, gotContent := readBinary(t, ctx, service, single(t, ctx, service).ID)
+		require.Equal(t, content, gotContent)
+		require.Equal(t, digest[:], got.SHA256)
+	})
+	t.Run("shared_content", func(t *testing.T) {
+		// Одинаковое содержимое разных записей и пользователей не должно
+		// пропадать при удалении одной из записей.
+		ctx := newUser(t, services)
+		otherCtx := newUser(t, services)
+		createBinary(t, ctx, service, data, content)
+		createBinary(t, otherCtx, service, data, content)
+
+		binary := single(t, ctx, service)
+		require.NoError(t, service.Remove(ctx, binary.ID, binary.Version))
+
+		other := single(t, otherCtx, service)
+		_, gotContent := readBinary(t, otherCtx, service, other.ID)
+		require.Equal(t, content, gotContent)
+		require.NoError(t, service.Remove(otherCtx, other.ID, other.Version))
+
+		// Содержимое можно сохранить снова после удаления последней записи.
+		createBinary(t, ctx, service, data, content)
+		_, gotContent = readBinary(t, ctx, service, single(t, ctx, service).ID)
+		require.Equal(t, content, gotContent)
+	})
+	t.Run("partial_update", func(t *testing.T) {
+		ctx := newUser(t, services)
+		createBinary(t, ctx, service, data, content)
+		binary := single(t, ctx, service)
+
+		require.NoError(t, service.Update(ctx, binary.ID, binary.Version, server.BinaryDataUpdate{Name: ptr("new")}))
+
+		updated := single(t, ctx, service)
+		require.Greater(t, updated.Version, binary.Version)
+		want := binary
+		want.Name, want.Version = "new", updated.Version
+		require.Equal(t, want, updated)
+
+		// Пустая строка очищает поле, а не пропускает его.
+		require.NoError(t, service.Update(ctx, binary.ID, updated.Version, server.BinaryDataUpdate{Notes: ptr("")}))
+
+		updated = single(t, ctx, service)
+		want.Notes, want.Version = "", updated.Version
+		require.Equal(t, want, updated)
+
+		// Содержимое при обновлении описания не меняется.
+		_, gotContent := readBinary(t, ctx, service, binary.ID)
+		require.Equal(t, content, gotContent)
+	})
+	t.Run("version_conflict", func(t *testing.T) {
+		ctx := newUser(t, services)
+		createBinary(t, ctx, service, data, content)
+		binary := single(t, ctx, service)
+
+		require.NoError(t, service.Update(ctx, binary.ID, binary.Version, server.BinaryDataUpdate{Name: ptr("new")}))
+		current := single(t, ctx, service)
+
+		var conflict *server.VersionConflictError
+		err := service.Update(ctx, binary.ID, binary.Version, server.BinaryDataUpdate{Name: ptr("stale")})
+		require.ErrorAs(t, err, &conflict)
+		require.Equal(t, current.Version, conflict.Version)
+		require.ErrorAs(t, service.Remove(ctx, binary.ID, binary.Version), &conflict)
+		require.Equal(t, current, single(t, ctx, service))
+	})
+	t.Run("remove", func(t *testing.T) {
+		ctx := newUser(t, services)
+		createBinary(t, ctx, service, data, content)
+		binary := single(t, ctx, service)
+
+		require.NoError(t, service.Remove(ctx, binary.ID, binary.Version))
+		binaries, err := service.GetAll(ctx)
+		require.NoError(t, err)
+		require.Empty(t, binaries)
+		_, err = service.Get(ctx, binary.ID)
+		require.ErrorIs(t, err, server.ErrDataNotFound)
+		require.ErrorIs(t, service.Remove(ctx, binary.ID, binary.Version), server.ErrDataNotFound)
+	})
+	t.Run("not_found", func(t *testing.T) {
+		ctx := newUser(t, services)
+		testNotFound(t, ctx, service, server.BinaryDataUpdate{Name: ptr("new")})
+
+		_, err := service.Get(ctx, 1<<62)
+		require.ErrorIs(t, err, server.ErrDataNotFound)
+	})
+	t.Run("ownership", func(t *testing.T) {
+		ctx := newUser(t, services)
+		createBinary(t, ctx, service, data, content)
+		binary := single(t, ctx, service)
+
+		otherCtx := newUser(t, services)
+		_, err := service.Get(otherCtx, binary.ID)
+		require.ErrorIs(t, err, server.ErrDataNotFound)
+		testOwnership(t, otherCtx, service, binary.ID, binary.Version, server.BinaryDataUpdate{Name: ptr("stolen")})
+
+		require.Equal(t, binary, single(t, ctx, service))
+		_, gotContent := readBinary(t, ctx, service, binary.ID)
+		require.Equal(t, content, gotContent)
+	})
+}
+
+// createBinary сохраняет бинарные данные data с содержимым content.
+func createBinary(t *testing.T, ctx context.Context, service server.BinaryService, data server.BinaryData, content []byte) {
+	t.Helper()
+
+	err := service.Create(ctx, server.ReadableBinaryData{
+		BinaryData: data,
+		DataReader: io.NopCloser(bytes.NewReader(content)),
+	})
+	require.NoError(t, err)
+}
+
+// readBinary возвращает бинарные данные id и их прочитанное содержимое.
+func readBinary(t *testing.T, ctx context.Context, service server.BinaryService, id int64) (*server.ReadableBinaryData, []byte) {
+	t.Helper()
+
+	data, err := service.Get(ctx, id)
+	require.NoError(t, err)
+	defer data.DataReader.Close()
+
+	content, err := io.ReadAll(data.DataReader)
+	require.NoError(t, err)
+	return data, content
+}
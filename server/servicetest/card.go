@@ -0,0 +1,88 @@
+package servicetest
+
+import (
+	"github.com/mkolibaba/gophkeeper/server"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func testCards(t *testing.T, services Services) {
+	service := services.Cards
+	data := server.CardData{
+		Name:       "card",
+		Number:     "4111111111111111",
+		ExpDate:    "12/30",
+		CVV:        "123",
+		Cardholder: "ALICE",
+		Notes:      "salary",
+	}
+
+	t.Run("create", func(t *testing.T) {
+		ctx := newUser(t, services)
+		require.NoError(t, service.Create(ctx, data))
+
+		card := single(t, ctx, service)
+		require.NotZero(t, card.ID)
+		want := data
+		want.ID, want.Version = card.ID, card.Version
+		require.Equal(t, want, card)
+	})
+	t.Run("partial_update", func(t *testing.T) {
+		ctx := newUser(t, services)
+		require.NoError(t, service.Create(ctx, data))
+		card := single(t, ctx, service)
+
+		update := server.CardDataUpdate{ExpDate: ptr("01/32"), CVV: ptr("456")}
+		require.NoError(t, service.Update(ctx, card.ID, card.Version, update))
+
+		updated := single(t, ctx, service)
+		require.Greater(t, updated.Version, card.Version)
+		want := card
+		want.ExpDate, want.CVV, want.Version = "01/32", "456", updated.Version
+		require.Equal(t, want, updated)
+
+		// Пустая строка очищает поле, а не пропускает его.
+		require.NoError(t, service.Update(ctx, card.ID, updated.Version, server.CardDataUpdate{Notes: ptr("")}))
+
+		updated = single(t, ctx, service)
+		want.Notes, want.Version = "", updated.Version
+		require.Equal(t, want, updated)
+	})
+	t.Run("version_conflict", func(t *testing.T) {
+		ctx := newUser(t, services)
+		require.NoError(t, service.Create(ctx, data))
+		card := single(t, ctx, service)
+
+		require.NoError(t, service.Update(ctx, card.ID, card.Version, server.CardDataUpdate{Name: ptr("new")}))
+		current := single(t, ctx, service)
+
+		var conflict *server.VersionConflictError
+		err := service.Update(ctx, card.ID, card.Version, server.CardDataUpdate{Name: ptr("stale")})
+		require.ErrorAs(t, err, &conflict)
+		require.Equal(t, current.Version, conflict.Version)
+		require.ErrorAs(t, service.Remove(ctx, card.ID, card.Version), &conflict)
+		require.Equal(t, current, single(t, ctx, service))
+	})
+	t.Run("remove", func(t *testing.T) {
+		ctx := newUser(t, services)
+		require.NoError(t, service.Create(ctx, data))
+		card := single(t, ctx, service)
+
+		require.NoError(t, service.Remove(ctx, card.ID, card.Version))
+		cards, err := service.GetAll(ctx)
+		require.NoError(t, err)
+		require.Empty(t, cards)
+		require.ErrorIs(t, service.Remove(ctx, card.ID, card.Version), server.ErrDataNotFound)
+	})
+	t.Run("not_found", func(t *testing.T) {
+		testNotFound(t, newUser(t, services), service, server.CardDataUpdate{Name: ptr("new")})
+	})
+	t.Run("ownership", func(t *testing.T) {
+		ctx := newUser(t, services)
+		require.NoError(t, service.Create(ctx, data))
+		card := single(t, ctx, service)
+
+		testOwnership(t, newUser(t, services), service, card.ID, card.Version, server.CardDataUpdate{CVV: ptr("000")})
+		require.Equal(t, card, single(t, ctx, service))
+	})
+}
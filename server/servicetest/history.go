@@ -0,0 +1,116 @@
+package servicetest
+
+import (
+	"github.com/mkolibaba/gophkeeper/server"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func testHistory(t *testing.T, services Services) {
+	service := services.History
+	login := server.LoginData{
+		Name:     "login",
+		Login:    "user",
+		Password: "123",
+	}
+
+	t.Run("update", func(t *testing.T) {
+		ctx := newUser(t, services)
+		require.NoError(t, services.Logins.Create(ctx, login))
+		created := single(t, ctx, services.Logins)
+
+		require.NoError(t, services.Logins.Update(ctx, created.ID, created.Version, server.LoginDataUpdate{Password: ptr("456")}))
+
+		entries, err := service.List(ctx, server.DataKindLogin, created.ID)
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		require.Equal(t, server.DataKindLogin, entries[0].Kind)
+		require.Equal(t, created.ID, entries[0].ID)
+		require.Equal(t, created.Version, entries[0].Version)
+		require.False(t, entries[0].Removed)
+		require.NotZero(t, entries[0].CreatedAt)
+		require.Equal(t, created, *entries[0].Login)
+	})
+	t.Run("restore", func(t *testing.T) {
+		ctx := newUser(t, services)
+		require.NoError(t, services.Logins.Create(ctx, login))
+		created := single(t, ctx, services.Logins)
+		require.NoError(t, services.Logins.Update(ctx, created.ID, created.Version, server.LoginDataUpdate{Password: ptr("456")}))
+
+		require.NoError(t, service.Restore(ctx, server.DataKindLogin, created.ID, created.Version))
+
+		restored := single(t, ctx, services.Logins)
+		require.Equal(t, created.ID, restored.ID)
+		require.Greater(t, restored.Version, created.Version)
+		require.Equal(t, "123", restored.Password)
+
+		// Восстановление тоже изменение: замененная версия попадает
+		// в историю, новые версии идут первыми.
+		entries, err := service.List(ctx, server.DataKindLogin, created.ID)
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+		require.Equal(t, "456", entries[0].Login.Password)
+		require.Equal(t, "123", entries[1].Login.Password)
+	})
+	t.Run("restore_removed", func(t *testing.T) {
+		ctx := newUser(t, services)
+		note := server.NoteData{Name: "note", Text: "text"}
+		require.NoError(t, services.Notes.Create(ctx, note))
+		created := single(t, ctx, services.Notes)
+		require.NoError(t, services.Notes.Remove(ctx, created.ID, created.Version))
+
+		entries, err := service.List(ctx, server.DataKindNote, created.ID)
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		require.True(t, entries[0].Removed)
+		require.Equal(t, created, *entries[0].Note)
+
+		// Удаленная запись создается заново.
+		require.NoError(t, service.Restore(ctx, server.DataKindNote, created.ID, created.Version))
+		restored := single(t, ctx, services.Notes)
+		require.Equal(t, note.Name, restored.Name)
+		require.Equal(t, note.Text, restored.Text)
+	})
+	t.Run("binary", func(t *testing.T) {
+		ctx := newUser(t, services)
+		data := server.BinaryData{Name: "file", Filename: "file.txt", Size: 7, Notes: "notes"}
+		createBinary(t, ctx, services.Binaries, data, []byte("content"))
+		created := single(t, ctx, services.Binaries)
+
+		require.NoError(t, services.Binaries.Update(ctx, created.ID, created.Version, server.BinaryDataUpdate{Name: ptr("new")}))
+		updated := single(t, ctx, services.Binaries)
+		require.NoError(t, service.Restore(ctx, server.DataKindBinary, created.ID, created.Version))
+		require.Equal(t, "file", single(t, ctx, services.Binaries).Name)
+
+		// Содержимое удаленных данных не хранится.
+		restored := single(t, ctx, services.Binaries)
+		require.NoError(t, services.Binaries.Remove(ctx, restored.ID, restored.Version))
+		err := service.Restore(ctx, server.DataKindBinary, created.ID, updated.Version)
+		require.ErrorIs(t, err, server.ErrContentRemoved)
+	})
+	t.Run("version_not_found", func(t *testing.T) {
+		ctx := newUser(t, services)
+		require.NoError(t, services.Logins.Create(ctx, login))
+		created := single(t, ctx, services.Logins)
+
+		entries, err := service.List(ctx, server.DataKindLogin, created.ID)
+		require.NoError(t, err)
+		require.Empty(t, entries)
+		err = service.Restore(ctx, server.DataKindLogin, created.ID, created.Version)
+		require.ErrorIs(t, err, server.ErrVersionNotFound)
+	})
+	t.Run("ownership", func(t *testing.T) {
+		ctx := newUser(t, services)
+		require.NoError(t, services.Logins.Create(ctx, login))
+		created := single(t, ctx, services.Logins)
+		require.NoError(t, services.Logins.Update(ctx, created.ID, created.Version, server.LoginDataUpdate{Password: ptr("456")}))
+
+		other := newUser(t, services)
+		entries, err := service.List(other, server.DataKindLogin, created.ID)
+		require.NoError(t, err)
+		require.Empty(t, entries)
+		err = service.Restore(other, server.DataKindLogin, created.ID, created.Version)
+		require.ErrorIs(t, err, server.ErrVersionNotFound)
+		require.Equal(t, "456", single(t, ctx, services.Logins).Password)
+	})
+}
@@ -0,0 +1,94 @@
+package servicetest
+
+import (
+	"github.com/mkolibaba/gophkeeper/server"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func testLogins(t *testing.T, services Services) {
+	service := services.Logins
+	data := server.LoginData{
+		Name:     "mail",
+		Login:    "alice",
+		Password: "123",
+		Website:  "https://mail.example.com",
+		Notes:    "work",
+	}
+
+	t.Run("create", func(t *testing.T) {
+		ctx := newUser(t, services)
+		require.NoError(t, service.Create(ctx, data))
+
+		login := single(t, ctx, service)
+		require.NotZero(t, login.ID)
+		want := data
+		want.ID, want.Version = login.ID, login.Version
+		require.Equal(t, want, login)
+	})
+	t.Run("partial_update", func(t *testing.T) {
+		ctx := newUser(t, services)
+		require.NoError(t, service.Create(ctx, data))
+		login := single(t, ctx, service)
+
+		err := service.Update(ctx, login.ID, login.Version, server.LoginDataUpdate{Password: ptr("456")})
+		require.NoError(t, err)
+
+		updated := single(t, ctx, service)
+		require.Greater(t, updated.Version, login.Version)
+		want := login
+		want.Password, want.Version = "456", updated.Version
+		require.Equal(t, want, updated)
+
+		// Пустая строка очищает поле, а не пропускает его.
+		err = service.Update(ctx, login.ID, updated.Version, server.LoginDataUpdate{Website: ptr(""), Notes: ptr("")})
+		require.NoError(t, err)
+
+		updated = single(t, ctx, service)
+		want.Website, want.Notes, want.Version = "", "", updated.Version
+		require.Equal(t, want, updated)
+
+		// Обновление без полей ничего не меняет.
+		require.NoError(t, service.Update(ctx, login.ID, updated.Version, server.LoginDataUpdate{}))
+		updated = single(t, ctx, service)
+		want.Version = updated.Version
+		require.Equal(t, want, updated)
+	})
+	t.Run("version_conflict", func(t *testing.T) {
+		ctx := newUser(t, services)
+		require.NoError(t, service.Create(ctx, data))
+		login := single(t, ctx, service)
+
+		require.NoError(t, service.Update(ctx, login.ID, login.Version, server.LoginDataUpdate{Name: ptr("new")}))
+		current := single(t, ctx, service)
+
+		var conflict *server.VersionConflictError
+		err := service.Update(ctx, login.ID, login.Version, server.LoginDataUpdate{Name: ptr("stale")})
+		require.ErrorAs(t, err, &conflict)
+		require.Equal(t, current.Version, conflict.Version)
+		require.ErrorAs(t, service.Remove(ctx, login.ID, login.Version), &conflict)
+		require.Equal(t, current, single(t, ctx, service))
+	})
+	t.Run("remove", func(t *testing.T) {
+		ctx := newUser(t, services)
+		require.NoError(t, service.Create(ctx, data))
+		login := single(t, ctx, service)
+
+		require.NoError(t, service.Remove(ctx, login.ID, login.Version))
+		logins, err := service.GetAll(ctx)
+		require.NoError(t, err)
+		require.Empty(t, logins)
+		require.ErrorIs(t, service.Remove(ctx, login.ID, login.Version), server.ErrDataNotFound)
+	})
+	t.Run("not_found", func(t *testing.T) {
+		testNotFound(t, newUser(t, services), service, server.LoginDataUpdate{Name: ptr("new")})
+	})
+	t.Run("ownership", func(t *testing.T) {
+		ctx := newUser(t, services)
+		require.NoError(t, service.Create(ctx, data))
+		login := single(t, ctx, service)
+
+		testOwnership(t, newUser(t, services), service, login.ID, login.Version, server.LoginDataUpdate{Name: ptr("stolen")})
+		require.Equal(t, login, single(t, ctx, service))
+	})
+}
@@ -0,0 +1,83 @@
+package servicetest
+
+import (
+	"github.com/mkolibaba/gophkeeper/server"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func testNotes(t *testing.T, services Services) {
+	service := services.Notes
+	data := server.NoteData{
+		Name: "note",
+		Text: "text",
+	}
+
+	t.Run("create", func(t *testing.T) {
+		ctx := newUser(t, services)
+		require.NoError(t, service.Create(ctx, data))
+
+		note := single(t, ctx, service)
+		require.NotZero(t, note.ID)
+		want := data
+		want.ID, want.Version = note.ID, note.Version
+		require.Equal(t, want, note)
+	})
+	t.Run("partial_update", func(t *testing.T) {
+		ctx := newUser(t, services)
+		require.NoError(t, service.Create(ctx, data))
+		note := single(t, ctx, service)
+
+		require.NoError(t, service.Update(ctx, note.ID, note.Version, server.NoteDataUpdate{Name: ptr("new")}))
+
+		updated := single(t, ctx, service)
+		require.Greater(t, updated.Version, note.Version)
+		want := note
+		want.Name, want.Version = "new", updated.Version
+		require.Equal(t, want, updated)
+
+		// Пустая строка очищает поле, а не пропускает его.
+		require.NoError(t, service.Update(ctx, note.ID, updated.Version, server.NoteDataUpdate{Text: ptr("")}))
+
+		updated = single(t, ctx, service)
+		want.Text, want.Version = "", updated.Version
+		require.Equal(t, want, updated)
+	})
+	t.Run("version_conflict", func(t *testing.T) {
+		ctx := newUser(t, services)
+		require.NoError(t, service.Create(ctx, data))
+		note := single(t, ctx, service)
+
+		require.NoError(t, service.Update(ctx, note.ID, note.Version, server.NoteDataUpdate{Text: ptr("new")}))
+		current := single(t, ctx, service)
+
+		var conflict *server.VersionConflictError
+		err := service.Update(ctx, note.ID, note.Version, server.NoteDataUpdate{Text: ptr("stale")})
+		require.ErrorAs(t, err, &conflict)
+		require.Equal(t, current.Version, conflict.Version)
+		require.ErrorAs(t, service.Remove(ctx, note.ID, note.Version), &conflict)
+		require.Equal(t, current, single(t, ctx, service))
+	})
+	t.Run("remove", func(t *testing.T) {
+		ctx := newUser(t, services)
+		require.NoError(t, service.Create(ctx, data))
+		note := single(t, ctx, service)
+
+		require.NoError(t, service.Remove(ctx, note.ID, note.Version))
+		notes, err := service.GetAll(ctx)
+		require.NoError(t, err)
+		require.Empty(t, notes)
+		require.ErrorIs(t, service.Remove(ctx, note.ID, note.Version), server.ErrDataNotFound)
+	})
+	t.Run("not_found", func(t *testing.T) {
+		testNotFound(t, newUser(t, services), service, server.NoteDataUpdate{Name: ptr("new")})
+	})
+	t.Run("ownership", func(t *testing.T) {
+		ctx := newUser(t, services)
+		require.NoError(t, service.Create(ctx, data))
+		note := single(t, ctx, service)
+
+		testOwnership(t, newUser(t, services), service, note.ID, note.Version, server.NoteDataUpdate{Text: ptr("stolen")})
+		require.Equal(t, note, single(t, ctx, service))
+	})
+}
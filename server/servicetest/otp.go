@@ -0,0 +1,89 @@
+package servicetest
+
+import (
+	"github.com/mkolibaba/gophkeeper/server"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func testOTPs(t *testing.T, services Services) {
+	service := services.OTPs
+	data := server.OTPData{
+		Name:      "github",
+		Type:      "totp",
+		Secret:    "JBSWY3DPEHPK3PXP",
+		Algorithm: "SHA1",
+		Digits:    6,
+		Period:    30,
+		Issuer:    "GitHub",
+	}
+
+	t.Run("create", func(t *testing.T) {
+		ctx := newUser(t, services)
+		require.NoError(t, service.Create(ctx, data))
+
+		otp := single(t, ctx, service)
+		require.NotZero(t, otp.ID)
+		want := data
+		want.ID, want.Version = otp.ID, otp.Version
+		require.Equal(t, want, otp)
+	})
+	t.Run("partial_update", func(t *testing.T) {
+		ctx := newUser(t, services)
+		require.NoError(t, service.Create(ctx, data))
+		otp := single(t, ctx, service)
+
+		update := server.OTPDataUpdate{Type: ptr("hotp"), Period: ptr(int64(0)), Counter: ptr(int64(5))}
+		require.NoError(t, service.Update(ctx, otp.ID, otp.Version, update))
+
+		updated := single(t, ctx, service)
+		require.Greater(t, updated.Version, otp.Version)
+		want := otp
+		want.Type, want.Period, want.Counter, want.Version = "hotp", 0, 5, updated.Version
+		require.Equal(t, want, updated)
+
+		// Пустая строка очищает поле, а не пропускает его.
+		require.NoError(t, service.Update(ctx, otp.ID, updated.Version, server.OTPDataUpdate{Issuer: ptr("")}))
+
+		updated = single(t, ctx, service)
+		want.Issuer, want.Version = "", updated.Version
+		require.Equal(t, want, updated)
+	})
+	t.Run("version_conflict", func(t *testing.T) {
+		ctx := newUser(t, services)
+		require.NoError(t, service.Create(ctx, data))
+		otp := single(t, ctx, service)
+
+		require.NoError(t, service.Update(ctx, otp.ID, otp.Version, server.OTPDataUpdate{Digits: ptr(int64(8))}))
+		current := single(t, ctx, service)
+
+		var conflict *server.VersionConflictError
+		err := service.Update(ctx, otp.ID, otp.Version, server.OTPDataUpdate{Digits: ptr(int64(7))})
+		require.ErrorAs(t, err, &conflict)
+		require.Equal(t, current.Version, conflict.Version)
+		require.ErrorAs(t, service.Remove(ctx, otp.ID, otp.Version), &conflict)
+		require.Equal(t, current, single(t, ctx, service))
+	})
+	t.Run("remove", func(t *testing.T) {
+		ctx := newUser(t, services)
+		require.NoError(t, service.Create(ctx, data))
+		otp := single(t, ctx, service)
+
+		require.NoError(t, service.Remove(ctx, otp.ID, otp.Version))
+		otps, err := service.GetAll(ctx)
+		require.NoError(t, err)
+		require.Empty(t, otps)
+		require.ErrorIs(t, service.Remove(ctx, otp.ID, otp.Version), server.ErrDataNotFound)
+	})
+	t.Run("not_found", func(t *testing.T) {
+		testNotFound(t, newUser(t, services), service, server.OTPDataUpdate{Name: ptr("new")})
+	})
+	t.Run("ownership", func(t *testing.T) {
+		ctx := newUser(t, services)
+		require.NoError(t, service.Create(ctx, data))
+		otp := single(t, ctx, service)
+
+		testOwnership(t, newUser(t, services), service, otp.ID, otp.Version, server.OTPDataUpdate{Secret: ptr("stolen")})
+		require.Equal(t, otp, single(t, ctx, service))
+	})
+}
@@ -0,0 +1,134 @@
+// Package servicetest - общие тесты реализаций сервисов хранилища.
+// Любая реализация хранилища проверяется одним вызовом Run из своих тестов.
+package servicetest
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"github.com/mkolibaba/gophkeeper/server"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+// Services - проверяемые реализации сервисов. Сервисы должны работать
+// с одним хранилищем.
+type Services struct {
+	Users    server.UserService
+	Logins   server.LoginService
+	Notes    server.NoteService
+	Cards    server.CardService
+	OTPs     server.OTPService
+	Binaries server.BinaryService
+	History  server.HistoryService
+	Trash    server.TrashService
+}
+
+// Run проверяет, что реализации сервисов ведут себя так, как ожидает
+// остальной сервер: данные видны только владельцу, отсутствующие данные
+// дают ErrDataNotFound, обновления меняют только переданные поля,
+// а содержимое файлов читается в точности таким, каким сохранено.
+// Изменения и удаления сохраняют прежние версии в истории, а удаленные
+// записи попадают в корзину и восстанавливаются с прежним id.
+// Тесты создают пользователей со случайными логинами и удаляют их после
+// себя, поэтому хранилище может быть общим с другими тестами.
+func Run(t *testing.T, services Services) {
+	t.Run("users", func(t *testing.T) {
+		testUsers(t, services)
+	})
+	t.Run("logins", func(t *testing.T) {
+		testLogins(t, services)
+	})
+	t.Run("notes", func(t *testing.T) {
+		testNotes(t, services)
+	})
+	t.Run("cards", func(t *testing.T) {
+		testCards(t, services)
+	})
+	t.Run("otps", func(t *testing.T) {
+		testOTPs(t, services)
+	})
+	t.Run("binaries", func(t *testing.T) {
+		testBinaries(t, services)
+	})
+	t.Run("history", func(t *testing.T) {
+		testHistory(t, services)
+	})
+	t.Run("trash", func(t *testing.T) {
+		testTrash(t, services)
+	})
+}
+
+// itemService - общая часть сервисов данных.
+type itemService[D any, U any] interface {
+	GetAll(ctx context.Context) ([]D, error)
+	Update(ctx context.Context, id int64, version int64, data U) error
+	Remove(ctx context.Context, id int64, version int64) error
+}
+
+// testNotFound проверяет, что изменение и удаление несуществующих данных
+// возвращают ErrDataNotFound.
+func testNotFound[D any, U any](t *testing.T, ctx context.Context, service itemService[D, U], update U) {
+	t.Helper()
+
+	const missingID = 1 << 62
+	require.ErrorIs(t, service.Update(ctx, missingID, 0, update), server.ErrDataNotFound)
+	require.ErrorIs(t, service.Remove(ctx, missingID, 0), server.ErrDataNotFound)
+}
+
+// testOwnership проверяет, что пользователь из ctx не видит данные id
+// другого пользователя и не может их изменить или удалить. Чужие данные
+// должны выглядеть несуществующими, а не запрещенными.
+func testOwnership[D any, U any](
+	t *testing.T,
+	ctx context.Context,
+	service itemService[D, U],
+	id int64,
+	version int64,
+	update U,
+) {
+	t.Helper()
+
+	items, err := service.GetAll(ctx)
+	require.NoError(t, err)
+	require.Empty(t, items)
+
+	require.ErrorIs(t, service.Update(ctx, id, version, update), server.ErrDataNotFound)
+	require.ErrorIs(t, service.Remove(ctx, id, version), server.ErrDataNotFound)
+}
+
+// single возвращает единственные данные пользователя из ctx.
+func single[D any, U any](t *testing.T, ctx context.Context, service itemService[D, U]) D {
+	t.Helper()
+
+	items, err := service.GetAll(ctx)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	return items[0]
+}
+
+// newUser создает пользователя и возвращает контекст его запросов.
+func newUser(t *testing.T, services Services) context.Context {
+	t.Helper()
+
+	login := newLogin(t)
+	require.NoError(t, services.Users.Save(t.Context(), server.User{Login: login, Password: "secret"}))
+	t.Cleanup(func() {
+		services.Users.Delete(context.Background(), login)
+	})
+	return server.NewContextWithUser(t.Context(), login)
+}
+
+// newLogin возвращает логин, не занятый другими тестами.
+func newLogin(t *testing.T) string {
+	t.Helper()
+
+	suffix := make([]byte, 8)
+	_, err := rand.Read(suffix)
+	require.NoError(t, err)
+	return "servicetest-" + hex.EncodeToString(suffix)
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,156 @@
+package servicetest
+
+import (
+	"github.com/mkolibaba/gophkeeper/server"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func testTrash(t *testing.T, services Services) {
+	service := services.Trash
+	login := server.LoginData{
+		Name:     "login",
+		Login:    "user",
+		Password: "123",
+	}
+
+	t.Run("list", func(t *testing.T) {
+		ctx := newUser(t, services)
+		require.NoError(t, services.Logins.Create(ctx, login))
+		require.NoError(t, services.Notes.Create(ctx, server.NoteData{Name: "note", Text: "text"}))
+		require.NoError(t, services.Cards.Create(ctx, server.CardData{Name: "card", Number: "4111111111111111"}))
+		require.NoError(t, services.OTPs.Create(ctx, server.OTPData{Name: "otp", Type: "totp", Secret: "JBSWY3DPEHPK3PXP"}))
+		createBinary(t, ctx, services.Binaries, server.BinaryData{Name: "file", Filename: "file.txt", Size: 7}, []byte("content"))
+
+		createdLogin := single(t, ctx, services.Logins)
+		require.NoError(t, services.Logins.Remove(ctx, createdLogin.ID, createdLogin.Version))
+		note := single(t, ctx, services.Notes)
+		require.NoError(t, services.Notes.Remove(ctx, note.ID, note.Version))
+		card := single(t, ctx, services.Cards)
+		require.NoError(t, services.Cards.Remove(ctx, card.ID, card.Version))
+		otp := single(t, ctx, services.OTPs)
+		require.NoError(t, services.OTPs.Remove(ctx, otp.ID, otp.Version))
+		binary := single(t, ctx, services.Binaries)
+		require.NoError(t, services.Binaries.Remove(ctx, binary.ID, binary.Version))
+
+		entries, err := service.List(ctx)
+		require.NoError(t, err)
+		require.Len(t, entries, 5)
+
+		byKind := make(map[server.DataKind]server.TrashEntry)
+		for _, entry := range entries {
+			require.NotZero(t, entry.DeletedAt)
+			byKind[entry.Kind] = entry
+		}
+		require.Equal(t, createdLogin, *byKind[server.DataKindLogin].Login)
+		require.Equal(t, createdLogin.ID, byKind[server.DataKindLogin].ID)
+		require.Equal(t, note, *byKind[server.DataKindNote].Note)
+		require.Equal(t, card, *byKind[server.DataKindCard].Card)
+		require.Equal(t, otp, *byKind[server.DataKindOTP].OTP)
+		require.Equal(t, binary.Name, byKind[server.DataKindBinary].Binary.Name)
+	})
+	t.Run("restore", func(t *testing.T) {
+		ctx := newUser(t, services)
+		require.NoError(t, services.Logins.Create(ctx, login))
+		created := single(t, ctx, services.Logins)
+		require.NoError(t, services.Logins.Remove(ctx, created.ID, created.Version))
+
+		items, err := services.Logins.GetAll(ctx)
+		require.NoError(t, err)
+		require.Empty(t, items)
+
+		require.NoError(t, service.Restore(ctx, server.DataKindLogin, created.ID))
+
+		restored := single(t, ctx, services.Logins)
+		require.Equal(t, created.ID, restored.ID)
+		require.Greater(t, restored.Version, created.Version)
+		require.Equal(t, created.Password, restored.Password)
+
+		entries, err := service.List(ctx)
+		require.NoError(t, err)
+		require.Empty(t, entries)
+	})
+	t.Run("purge", func(t *testing.T) {
+		ctx := newUser(t, services)
+		require.NoError(t, services.Logins.Create(ctx, login))
+		created := single(t, ctx, services.Logins)
+		require.NoError(t, services.Logins.Remove(ctx, created.ID, created.Version))
+
+		require.NoError(t, service.Purge(ctx, server.DataKindLogin, created.ID))
+
+		entries, err := service.List(ctx)
+		require.NoError(t, err)
+		require.Empty(t, entries)
+		require.ErrorIs(t, service.Restore(ctx, server.DataKindLogin, created.ID), server.ErrDataNotFound)
+		require.ErrorIs(t, service.Purge(ctx, server.DataKindLogin, created.ID), server.ErrDataNotFound)
+
+		// История удаляется вместе с записью.
+		history, err := services.History.List(ctx, server.DataKindLogin, created.ID)
+		require.NoError(t, err)
+		require.Empty(t, history)
+	})
+	t.Run("not_in_trash", func(t *testing.T) {
+		ctx := newUser(t, services)
+		require.NoError(t, services.Logins.Create(ctx, login))
+		created := single(t, ctx, services.Logins)
+
+		require.ErrorIs(t, service.Restore(ctx, server.DataKindLogin, created.ID), server.ErrDataNotFound)
+		require.ErrorIs(t, service.Purge(ctx, server.DataKindLogin, created.ID), server.ErrDataNotFound)
+		require.Equal(t, created, single(t, ctx, services.Logins))
+	})
+	t.Run("binary", func(t *testing.T) {
+		ctx := newUser(t, services)
+		content := []byte("content")
+		createBinary(t, ctx, services.Binaries, server.BinaryData{Name: "file", Filename: "file.txt", Size: 7}, content)
+		created := single(t, ctx, services.Binaries)
+
+		// Содержимое хранится, пока запись в корзине.
+		require.NoError(t, services.Binaries.Remove(ctx, created.ID, created.Version))
+		require.NoError(t, service.Restore(ctx, server.DataKindBinary, created.ID))
+		_, restored := readBinary(t, ctx, services.Binaries, created.ID)
+		require.Equal(t, content, restored)
+
+		binary := single(t, ctx, services.Binaries)
+		require.NoError(t, services.Binaries.Remove(ctx, binary.ID, binary.Version))
+		require.NoError(t, service.Purge(ctx, server.DataKindBinary, binary.ID))
+		_, err := services.Binaries.Get(ctx, binary.ID)
+		require.ErrorIs(t, err, server.ErrDataNotFound)
+	})
+	t.Run("ownership", func(t *testing.T) {
+		ctx := newUser(t, services)
+		require.NoError(t, services.Logins.Create(ctx, login))
+		created := single(t, ctx, services.Logins)
+		require.NoError(t, services.Logins.Remove(ctx, created.ID, created.Version))
+
+		other := newUser(t, services)
+		entries, err := service.List(other)
+		require.NoError(t, err)
+		require.Empty(t, entries)
+		require.ErrorIs(t, service.Restore(other, server.DataKindLogin, created.ID), server.ErrDataNotFound)
+		require.ErrorIs(t, service.Purge(other, server.DataKindLogin, created.ID), server.ErrDataNotFound)
+
+		entries, err = service.List(ctx)
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+	})
+	t.Run("purge_expired", func(t *testing.T) {
+		ctx := newUser(t, services)
+		require.NoError(t, services.Notes.Create(ctx, server.NoteData{Name: "note", Text: "text"}))
+		created := single(t, ctx, services.Notes)
+		require.NoError(t, services.Notes.Remove(ctx, created.ID, created.Version))
+
+		_, err := service.PurgeExpired(t.Context(), time.Now().Add(-time.Hour))
+		require.NoError(t, err)
+		entries, err := service.List(ctx)
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+
+		purged, err := service.PurgeExpired(t.Context(), time.Now().Add(time.Minute))
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, purged, 1)
+		entries, err = service.List(ctx)
+		require.NoError(t, err)
+		require.Empty(t, entries)
+	})
+}
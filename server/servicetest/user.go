@@ -0,0 +1,94 @@
+package servicetest
+
+import (
+	"context"
+	"github.com/mkolibaba/gophkeeper/server"
+	"github.com/stretchr/testify/require"
+	"io"
+	"strings"
+	"testing"
+)
+
+func testUsers(t *testing.T, services Services) {
+	t.Run("save", func(t *testing.T) {
+		ctx := t.Context()
+		login := newLogin(t)
+
+		err := services.Users.Save(ctx, server.User{Login: login, Password: "secret", VaultKey: []byte("key")})
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			services.Users.Delete(context.Background(), login)
+		})
+
+		user, err := services.Users.Get(ctx, login)
+		require.NoError(t, err)
+		require.Equal(t, login, user.Login)
+		require.NotEqual(t, "secret", user.Password, "password must be hashed")
+		require.Equal(t, []byte("key"), user.VaultKey)
+		require.False(t, user.MFAEnabled)
+
+		err = services.Users.Save(ctx, server.User{Login: login, Password: "other"})
+		require.ErrorIs(t, err, server.ErrUserAlreadyExists)
+	})
+	t.Run("change_password", func(t *testing.T) {
+		ctx := t.Context()
+		login := server.UserFromContext(newUser(t, services))
+
+		before, err := services.Users.Get(ctx, login)
+		require.NoError(t, err)
+
+		require.NoError(t, services.Users.ChangePassword(ctx, login, "new secret", []byte("new key")))
+
+		after, err := services.Users.Get(ctx, login)
+		require.NoError(t, err)
+		require.NotEqual(t, before.Password, after.Password)
+		require.NotEqual(t, "new secret", after.Password, "password must be hashed")
+		require.Equal(t, []byte("new key"), after.VaultKey)
+	})
+	t.Run("not_found", func(t *testing.T) {
+		ctx := t.Context()
+		login := newLogin(t)
+
+		_, err := services.Users.Get(ctx, login)
+		require.ErrorIs(t, err, server.ErrUserNotFound)
+		require.ErrorIs(t, services.Users.ChangePassword(ctx, login, "secret", nil), server.ErrUserNotFound)
+		require.ErrorIs(t, services.Users.Delete(ctx, login), server.ErrUserNotFound)
+
+		// Данные несуществующего пользователя не сохраняются.
+		userCtx := server.NewContextWithUser(ctx, login)
+		err = services.Logins.Create(userCtx, server.LoginData{Name: "mail", Login: "alice"})
+		require.ErrorIs(t, err, server.ErrUserNotFound)
+		err = services.Notes.Create(userCtx, server.NoteData{Name: "note"})
+		require.ErrorIs(t, err, server.ErrUserNotFound)
+	})
+	t.Run("delete", func(t *testing.T) {
+		ctx := newUser(t, services)
+		login := server.UserFromContext(ctx)
+
+		require.NoError(t, services.Logins.Create(ctx, server.LoginData{Name: "mail", Login: "alice"}))
+		require.NoError(t, services.Notes.Create(ctx, server.NoteData{Name: "note"}))
+		err := services.Binaries.Create(ctx, server.ReadableBinaryData{
+			BinaryData: server.BinaryData{Name: "file", Filename: "file.txt"},
+			DataReader: io.NopCloser(strings.NewReader("content")),
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, services.Users.Delete(ctx, login))
+		_, err = services.Users.Get(ctx, login)
+		require.ErrorIs(t, err, server.ErrUserNotFound)
+
+		// Данные удаляются вместе с пользователем: новый пользователь
+		// с тем же логином их не получает.
+		require.NoError(t, services.Users.Save(ctx, server.User{Login: login, Password: "secret"}))
+
+		logins, err := services.Logins.GetAll(ctx)
+		require.NoError(t, err)
+		require.Empty(t, logins)
+		notes, err := services.Notes.GetAll(ctx)
+		require.NoError(t, err)
+		require.Empty(t, notes)
+		binaries, err := services.Binaries.GetAll(ctx)
+		require.NoError(t, err)
+		require.Empty(t, binaries)
+	})
+}
@@ -0,0 +1,171 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/charmbracelet/log"
+	"github.com/mkolibaba/gophkeeper/server"
+	"github.com/mkolibaba/gophkeeper/server/backup"
+	sqlc "github.com/mkolibaba/gophkeeper/server/sqlite/sqlc/gen"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// beforeRestoreSuffix добавляется к имени базы данных, замененной при
+// восстановлении из резервной копии.
+const beforeRestoreSuffix = ".before-restore"
+
+// BackupService создает резервные копии сервера и восстанавливает из них
+// содержимое файлов.
+type BackupService struct {
+	db    *DB
+	blobs server.BlobStore
+}
+
+// NewBackupService создает сервис резервного копирования. Хранилище blobs
+// должно возвращать содержимое как есть, без расшифровки: в резервную
+// копию оно попадает в том виде, в котором хранится.
+func NewBackupService(db *DB, blobs server.BlobStore) *BackupService {
+	return &BackupService{
+		db:    db,
+		blobs: blobs,
+	}
+}
+
+// Backup записывает в w резервную копию, зашифрованную паролем password.
+// Снимок базы данных делается без остановки сервера, а в копию попадает
+// содержимое, на которое ссылается снимок.
+func (s *BackupService) Backup(ctx context.Context, w io.Writer, password string) error {
+	dir, err := os.MkdirTemp("", "gophkeeper-backup-*")
+	if err != nil {
+		return fmt.Errorf("backup: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	snapshot := filepath.Join(dir, backup.DatabaseName)
+	if _, err := s.db.db.ExecContext(ctx, `VACUUM INTO ?`, snapshot); err != nil {
+		return fmt.Errorf("backup: snapshot: %w", err)
+	}
+
+	keys, err := snapshotBlobKeys(ctx, snapshot)
+	if err != nil {
+		return fmt.Errorf("backup: %w", err)
+	}
+
+	if err := backup.Write(ctx, w, password, snapshot, keys, s.blobs); err != nil {
+		return fmt.Errorf("backup: %w", err)
+	}
+	s.db.logger.Info("backup created", "blobs", len(keys))
+	return nil
+}
+
+// RestoreBlobs сохраняет в хранилище содержимое из резервной копии
+// archive. Вызывается после Restore.
+func (s *BackupService) RestoreBlobs(ctx context.Context, archive *backup.Archive) error {
+	for key, path := range archive.Blobs {
+		if err := s.restoreBlob(ctx, key, path); err != nil {
+			return fmt.Errorf("restore blob %q: %w", key, err)
+		}
+	}
+	s.db.logger.Info("blobs restored", "count", len(archive.Blobs))
+	return nil
+}
+
+func (s *BackupService) restoreBlob(ctx context.Context, key string, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return s.blobs.Put(ctx, key, file)
+}
+
+// Restore проверяет базу данных из резервной копии archive и заменяет ею
+// базу данных сервера. Перед заменой к копии применяются миграции, а
+// текущая база данных сохраняется рядом с суффиксом .before-restore.
+// Сервер во время восстановления должен быть остановлен.
+func Restore(ctx context.Context, config *server.Config, archive *backup.Archive, logger *log.Logger) error {
+	if err := checkRestore(ctx, config, archive, logger); err != nil {
+		return fmt.Errorf("restore: %w", err)
+	}
+
+	dsn := config.SQLite.DSN
+	if _, err := os.Stat(dsn); err == nil {
+		if err := os.Rename(dsn, dsn+beforeRestoreSuffix); err != nil {
+			return fmt.Errorf("restore: %w", err)
+		}
+		logger.Info("current database saved", "path", dsn+beforeRestoreSuffix)
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("restore: %w", err)
+	}
+
+	if err := os.Rename(archive.Database, dsn); err != nil {
+		return fmt.Errorf("restore: %w", err)
+	}
+	return nil
+}
+
+// RollbackRestore возвращает базу данных, замененную Restore: например,
+// если не удалось записать в хранилище содержимое из резервной копии.
+// Если базы данных до восстановления не было, восстановленная удаляется.
+func RollbackRestore(config *server.Config, logger *log.Logger) error {
+	dsn := config.SQLite.DSN
+	err := os.Rename(dsn+beforeRestoreSuffix, dsn)
+	if errors.Is(err, os.ErrNotExist) {
+		err = os.Remove(dsn)
+	}
+	if err != nil {
+		return fmt.Errorf("rollback restore: %w", err)
+	}
+	logger.Info("restore rolled back", "path", dsn)
+	return nil
+}
+
+// checkRestore применяет миграции к базе данных из резервной копии и
+// проверяет ее целостность и наличие всего содержимого, на которое она
+// ссылается.
+func checkRestore(ctx context.Context, config *server.Config, archive *backup.Archive, logger *log.Logger) error {
+	restoreConfig := *config
+	restoreConfig.SQLite.DSN = archive.Database
+
+	db := NewDB(&restoreConfig, nil, logger)
+	defer db.Close()
+	if err := db.Open(); err != nil {
+		return err
+	}
+
+	var result string
+	if err := db.db.QueryRowContext(ctx, `PRAGMA integrity_check`).Scan(&result); err != nil {
+		return fmt.Errorf("integrity check: %w", err)
+	}
+	if result != "ok" {
+		return fmt.Errorf("integrity check: %s", result)
+	}
+
+	keys, err := blobKeys(ctx, sqlc.New(db.db))
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if _, ok := archive.Blobs[key]; !ok {
+			return fmt.Errorf("blob %q is missing in backup", key)
+		}
+	}
+	return nil
+}
+
+// snapshotBlobKeys возвращает ключи содержимого, на которое ссылается
+// снимок базы данных.
+func snapshotBlobKeys(ctx context.Context, path string) ([]string, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	return blobKeys(ctx, sqlc.New(db))
+}
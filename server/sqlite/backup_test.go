@@ -0,0 +1,127 @@
+package sqlite
+
+import (
+	"bytes"
+	"github.com/charmbracelet/log"
+	"github.com/mkolibaba/gophkeeper/server"
+	"github.com/mkolibaba/gophkeeper/server/backup"
+	"github.com/mkolibaba/gophkeeper/server/fs"
+	"github.com/stretchr/testify/require"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBackup(t *testing.T) {
+	mustCreateUser(t, "alice", "123")
+	t.Cleanup(func() {
+		db.db.Exec("DELETE FROM login")
+		db.db.Exec("DELETE FROM binary")
+		db.db.Exec("DELETE FROM blob")
+		db.db.Exec("DELETE FROM user")
+	})
+
+	ctx := server.NewContextWithUser(t.Context(), "alice")
+	mustCreateLogin(t, "login", "alice", "123", "alice")
+	legacyID := mustCreateBinary(t, "legacy", "legacy.txt", strings.NewReader("legacy content"), "alice")
+	err := NewBinaryService(queries, db, blobs, NewDataConverter()).Create(ctx, server.ReadableBinaryData{
+		BinaryData: server.BinaryData{Name: "file", Filename: "file.txt"},
+		DataReader: io.NopCloser(strings.NewReader("file content")),
+	})
+	require.NoError(t, err)
+
+	var archive bytes.Buffer
+	require.NoError(t, NewBackupService(db, blobs).Backup(t.Context(), &archive, "secret"))
+
+	// Восстанавливаем в другой каталог с файловым хранилищем содержимого.
+	var config server.Config
+	config.SQLite.DataFolder = t.TempDir()
+	config.SQLite.DSN = filepath.Join(config.SQLite.DataFolder, "gophkeeper.sqlite")
+	config.Blob.FS.Folder = filepath.Join(config.SQLite.DataFolder, "binary")
+	require.NoError(t, os.WriteFile(config.SQLite.DSN, []byte("current"), 0600))
+
+	extracted, err := backup.Extract(bytes.NewReader(archive.Bytes()), "secret", t.TempDir())
+	require.NoError(t, err)
+	require.Len(t, extracted.Blobs, 2)
+
+	logger := log.New(io.Discard)
+	require.NoError(t, Restore(t.Context(), &config, extracted, logger))
+
+	// Текущая база данных сохранена.
+	current, err := os.ReadFile(config.SQLite.DSN + beforeRestoreSuffix)
+	require.NoError(t, err)
+	require.Equal(t, "current", string(current))
+
+	restored := NewDB(&config, nil, logger)
+	require.NoError(t, restored.Open())
+	defer restored.Close()
+
+	restoredBlobs, err := fs.NewBlobStore(&config)
+	require.NoError(t, err)
+	require.NoError(t, NewBackupService(restored, restoredBlobs).RestoreBlobs(t.Context(), extracted))
+
+	restoredQueries := NewQueries(restored)
+	logins, err := NewLoginService(restored, restoredQueries, NewDataConverter()).GetAll(ctx)
+	require.NoError(t, err)
+	require.Len(t, logins, 1)
+
+	binaryService := NewBinaryService(restoredQueries, restored, restoredBlobs, NewDataConverter())
+	binaries, err := binaryService.GetAll(ctx)
+	require.NoError(t, err)
+	require.Len(t, binaries, 2)
+	for _, binary := range binaries {
+		data, err := binaryService.Get(ctx, binary.ID)
+		require.NoError(t, err)
+		content, err := io.ReadAll(data.DataReader)
+		data.DataReader.Close()
+		require.NoError(t, err)
+		if binary.ID == legacyID {
+			require.Equal(t, "legacy content", string(content))
+		} else {
+			require.Equal(t, "file content", string(content))
+		}
+	}
+
+	t.Run("rollback", func(t *testing.T) {
+		extracted, err := backup.Extract(bytes.NewReader(archive.Bytes()), "secret", t.TempDir())
+		require.NoError(t, err)
+
+		var config server.Config
+		config.SQLite.DataFolder = t.TempDir()
+		config.SQLite.DSN = filepath.Join(config.SQLite.DataFolder, "gophkeeper.sqlite")
+		require.NoError(t, os.WriteFile(config.SQLite.DSN, []byte("current"), 0600))
+
+		require.NoError(t, Restore(t.Context(), &config, extracted, logger))
+		require.NoError(t, RollbackRestore(&config, logger))
+
+		current, err := os.ReadFile(config.SQLite.DSN)
+		require.NoError(t, err)
+		require.Equal(t, "current", string(current))
+		require.NoFileExists(t, config.SQLite.DSN+beforeRestoreSuffix)
+
+		// Без прежней базы данных восстановленная удаляется.
+		require.NoError(t, os.Remove(config.SQLite.DSN))
+		require.NoError(t, Restore(t.Context(), &config, extracted, logger))
+		require.NoError(t, RollbackRestore(&config, logger))
+		require.NoFileExists(t, config.SQLite.DSN)
+	})
+
+	t.Run("missing_blob", func(t *testing.T) {
+		extracted, err := backup.Extract(bytes.NewReader(archive.Bytes()), "secret", t.TempDir())
+		require.NoError(t, err)
+		for key := range extracted.Blobs {
+			delete(extracted.Blobs, key)
+			break
+		}
+
+		var config server.Config
+		config.SQLite.DataFolder = t.TempDir()
+		config.SQLite.DSN = filepath.Join(config.SQLite.DataFolder, "gophkeeper.sqlite")
+
+		err = Restore(t.Context(), &config, extracted, logger)
+		require.ErrorContains(t, err, "missing in backup")
+		require.NoFileExists(t, config.SQLite.DSN)
+	})
+}
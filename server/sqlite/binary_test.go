@@ -2,9 +2,13 @@ package sqlite
 
 import (
 	"bytes"
-	"fmt"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"errors"
 	"github.com/mkolibaba/gophkeeper/server"
 	sqlc "github.com/mkolibaba/gophkeeper/server/sqlite/sqlc/gen"
+	"github.com/mkolibaba/gophkeeper/server/sqlstore"
 	"github.com/stretchr/testify/require"
 	"io"
 	"os"
@@ -21,10 +25,11 @@ func TestBinaryCreate(t *testing.T) {
 	t.Cleanup(func() {
 		// TODO: подчищать файлы
 		db.db.Exec("DELETE FROM binary")
+		db.db.Exec("DELETE FROM blob")
 		db.db.Exec("DELETE FROM user")
 	})
 
-	srv := NewBinaryService(queries, db, NewDataConverter())
+	srv := NewBinaryService(queries, db, blobs, NewDataConverter())
 
 	t.Run("success", func(t *testing.T) {
 		ctx := server.NewContextWithUser(t.Context(), "alice")
@@ -72,10 +77,11 @@ func TestBinaryGen(t *testing.T) {
 	t.Cleanup(func() {
 		// TODO: подчищать файлы
 		db.db.Exec("DELETE FROM binary")
+		db.db.Exec("DELETE FROM blob")
 		db.db.Exec("DELETE FROM user")
 	})
 
-	srv := NewBinaryService(queries, db, NewDataConverter())
+	srv := NewBinaryService(queries, db, blobs, NewDataConverter())
 
 	t.Run("success", func(t *testing.T) {
 		ctx := server.NewContextWithUser(t.Context(), "alice")
@@ -85,12 +91,19 @@ func TestBinaryGen(t *testing.T) {
 		content, err := io.ReadAll(binaryData.DataReader)
 		require.NoError(t, err)
 		require.Equal(t, "content 1", string(content))
+
+		// Хэш файла без сохраненного хэша вычисляется и сохраняется.
+		hash := sha256.Sum256([]byte("content 1"))
+		require.Equal(t, hash[:], binaryData.SHA256)
+		require.EqualValues(t, 9, binaryData.ContentSize)
+		binary, err := queries.SelectBinary(ctx, binaryID, "alice")
+		require.NoError(t, err)
+		require.Equal(t, hash[:], binary.Sha256)
 	})
 	// TODO: почему-то err == nil, поправить
 	//t.Run("file_removed", func(t *testing.T) {
 	//	id := mustCreateBinary(t, "text2", "text_2.txt", strings.NewReader("content 2"), "alice")
-	//	// TODO(minor): имплементация скопирована из сервиса. возможно, не очень хорошо так делать
-	//	require.NoError(t, os.Remove(srv.getBinaryAssetPath(id)))
+	//	require.NoError(t, blobs.Delete(t.Context(), sqlstore.BinaryKey(id)))
 	//
 	//	ctx := server.NewContextWithUser(t.Context(), "alice")
 	//	_, err := srv.Get(ctx, binaryID)
@@ -107,10 +120,11 @@ func TestBinaryGetAll(t *testing.T) {
 	t.Cleanup(func() {
 		// TODO: подчищать файлы
 		db.db.Exec("DELETE FROM binary")
+		db.db.Exec("DELETE FROM blob")
 		db.db.Exec("DELETE FROM user")
 	})
 
-	srv := NewBinaryService(queries, db, NewDataConverter())
+	srv := NewBinaryService(queries, db, blobs, NewDataConverter())
 
 	t.Run("success", func(t *testing.T) {
 		ctx := server.NewContextWithUser(t.Context(), "alice")
@@ -141,15 +155,17 @@ func TestBinaryUpdate(t *testing.T) {
 	t.Cleanup(func() {
 		// TODO: подчищать файлы
 		db.db.Exec("DELETE FROM binary")
+		db.db.Exec("DELETE FROM blob")
 		db.db.Exec("DELETE FROM user")
 	})
 
-	srv := NewBinaryService(queries, db, NewDataConverter())
+	srv := NewBinaryService(queries, db, blobs, NewDataConverter())
 
 	t.Run("success", func(t *testing.T) {
 		ctx := server.NewContextWithUser(t.Context(), "alice")
 		name := "new name"
-		err := srv.Update(ctx, binary1ID, server.BinaryDataUpdate{
+		version := mustSelectRevision(t, queries.SelectBinaryRevision, binary1ID)
+		err := srv.Update(ctx, binary1ID, version, server.BinaryDataUpdate{
 			Name: &name,
 		})
 		require.NoError(t, err)
@@ -162,7 +178,7 @@ func TestBinaryUpdate(t *testing.T) {
 	t.Run("not_found", func(t *testing.T) {
 		ctx := server.NewContextWithUser(t.Context(), "alice")
 		name := "new name"
-		err := srv.Update(ctx, -100, server.BinaryDataUpdate{
+		err := srv.Update(ctx, -100, 1, server.BinaryDataUpdate{
 			Name: &name,
 		})
 		require.ErrorIs(t, err, server.ErrDataNotFound)
@@ -170,7 +186,8 @@ func TestBinaryUpdate(t *testing.T) {
 	t.Run("user_not_owner", func(t *testing.T) {
 		ctx := server.NewContextWithUser(t.Context(), "bob")
 		name := "new name"
-		err := srv.Update(ctx, binary1ID, server.BinaryDataUpdate{
+		version := mustSelectRevision(t, queries.SelectBinaryRevision, binary1ID)
+		err := srv.Update(ctx, binary1ID, version, server.BinaryDataUpdate{
 			Name: &name,
 		})
 		require.ErrorIs(t, err, server.ErrDataNotFound)
@@ -186,30 +203,199 @@ func TestBinaryDelete(t *testing.T) {
 	t.Cleanup(func() {
 		// TODO: подчищать файлы
 		db.db.Exec("DELETE FROM binary")
+		db.db.Exec("DELETE FROM blob")
 		db.db.Exec("DELETE FROM user")
 	})
 
-	srv := NewBinaryService(queries, db, NewDataConverter())
+	srv := NewBinaryService(queries, db, blobs, NewDataConverter())
 
 	t.Run("success", func(t *testing.T) {
 		binary3ID := mustCreateBinary(t, "text_2", "text_2.txt", strings.NewReader("content 2"), "alice")
 
 		ctx := server.NewContextWithUser(t.Context(), "alice")
-		err := srv.Remove(ctx, binary3ID)
+		version := mustSelectRevision(t, queries.SelectBinaryRevision, binary3ID)
+		err := srv.Remove(ctx, binary3ID, version)
 		require.NoError(t, err)
 	})
 	t.Run("not_found", func(t *testing.T) {
 		ctx := server.NewContextWithUser(t.Context(), "alice")
-		err := srv.Remove(ctx, -100)
+		err := srv.Remove(ctx, -100, 1)
 		require.ErrorIs(t, err, server.ErrDataNotFound)
 	})
 	t.Run("user_not_owner", func(t *testing.T) {
 		ctx := server.NewContextWithUser(t.Context(), "bob")
-		err := srv.Remove(ctx, binary1ID)
+		version := mustSelectRevision(t, queries.SelectBinaryRevision, binary1ID)
+		err := srv.Remove(ctx, binary1ID, version)
 		require.ErrorIs(t, err, server.ErrDataNotFound)
 	})
 }
 
+func TestBinaryDeduplication(t *testing.T) {
+	mustCreateUser(t, "alice", "123")
+	mustCreateUser(t, "bob", "123")
+	t.Cleanup(func() {
+		db.db.Exec("DELETE FROM binary")
+		db.db.Exec("DELETE FROM blob")
+		db.db.Exec("DELETE FROM user")
+	})
+
+	srv := NewBinaryService(queries, db, blobs, NewDataConverter())
+	trash := NewTrashService(db, queries, srv, NewDataConverter())
+
+	alice := server.NewContextWithUser(t.Context(), "alice")
+	bob := server.NewContextWithUser(t.Context(), "bob")
+	create := func(ctx context.Context, name string) int64 {
+		err := srv.Create(ctx, server.ReadableBinaryData{
+			BinaryData: server.BinaryData{Name: name, Filename: "same.txt"},
+			DataReader: io.NopCloser(strings.NewReader("same content")),
+		})
+		require.NoError(t, err)
+
+		binaries, err := srv.GetAll(ctx)
+		require.NoError(t, err)
+		for _, binary := range binaries {
+			if binary.Name == name {
+				return binary.ID
+			}
+		}
+		t.Fatalf("binary %q not found", name)
+		return 0
+	}
+	remove := func(ctx context.Context, id int64) {
+		revision, err := queries.SelectBinaryRevision(ctx, id, server.UserFromContext(ctx))
+		require.NoError(t, err)
+		require.NoError(t, srv.Remove(ctx, id, revision))
+		// Содержимое освобождается только при удалении из корзины.
+		require.NoError(t, trash.Purge(ctx, server.DataKindBinary, id))
+	}
+
+	hash := sha256.Sum256([]byte("same content"))
+	key := sqlstore.ContentKey(hash[:])
+	refs := func() int64 {
+		var refs int64
+		err := db.db.QueryRow("SELECT refs FROM blob WHERE sha256 = ?", hash[:]).Scan(&refs)
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0
+		}
+		require.NoError(t, err)
+		return refs
+	}
+
+	stored := func() int {
+		var count int
+		require.NoError(t, db.db.QueryRow("SELECT COUNT(*) FROM blob_content").Scan(&count))
+		return count
+	}
+	before := stored()
+
+	aliceID := create(alice, "alice_copy")
+	bobID := create(bob, "bob_copy")
+	require.EqualValues(t, 2, refs())
+	require.Equal(t, before+1, stored())
+
+	remove(alice, aliceID)
+	require.EqualValues(t, 1, refs())
+
+	data, err := srv.Get(bob, bobID)
+	require.NoError(t, err)
+	content, err := io.ReadAll(data.DataReader)
+	require.NoError(t, err)
+	data.DataReader.Close()
+	require.Equal(t, "same content", string(content))
+	require.Equal(t, hash[:], data.SHA256)
+
+	remove(bob, bobID)
+	require.Zero(t, refs())
+	_, err = blobs.Stat(t.Context(), key)
+	require.ErrorIs(t, err, server.ErrBlobNotFound)
+}
+
+func TestBinaryContentKeyDeduplication(t *testing.T) {
+	mustCreateUser(t, "alice", "123")
+	mustCreateUser(t, "bob", "123")
+	t.Cleanup(func() {
+		db.db.Exec("DELETE FROM binary")
+		db.db.Exec("DELETE FROM blob")
+		db.db.Exec("DELETE FROM user")
+	})
+
+	srv := NewBinaryService(queries, db, blobs, NewDataConverter())
+	trash := NewTrashService(db, queries, srv, NewDataConverter())
+
+	alice := server.NewContextWithUser(t.Context(), "alice")
+	bob := server.NewContextWithUser(t.Context(), "bob")
+	contentKey := bytes.Repeat([]byte{1}, sha256.Size)
+
+	// Клиент шифрует один файл каждый раз по-разному, совпадает только
+	// ключ дедупликации.
+	create := func(ctx context.Context, name string, ciphertext string, compression server.Compression) int64 {
+		hash := sha256.Sum256([]byte(ciphertext))
+		err := srv.Create(ctx, server.ReadableBinaryData{
+			BinaryData:  server.BinaryData{Name: name, Filename: "same.txt", Compression: compression},
+			DataReader:  io.NopCloser(strings.NewReader(ciphertext)),
+			ContentSize: int64(len(ciphertext)),
+			SHA256:      hash[:],
+			ContentKey:  contentKey,
+		})
+		require.NoError(t, err)
+
+		binaries, err := srv.GetAll(ctx)
+		require.NoError(t, err)
+		for _, binary := range binaries {
+			if binary.Name == name {
+				return binary.ID
+			}
+		}
+		t.Fatalf("binary %q not found", name)
+		return 0
+	}
+	get := func(ctx context.Context, id int64) (string, []byte) {
+		data, err := srv.Get(ctx, id)
+		require.NoError(t, err)
+		defer data.DataReader.Close()
+		content, err := io.ReadAll(data.DataReader)
+		require.NoError(t, err)
+		return string(content), data.SHA256
+	}
+	remove := func(ctx context.Context, id int64) {
+		revision, err := queries.SelectBinaryRevision(ctx, id, server.UserFromContext(ctx))
+		require.NoError(t, err)
+		require.NoError(t, srv.Remove(ctx, id, revision))
+		require.NoError(t, trash.Purge(ctx, server.DataKindBinary, id))
+	}
+	blobCount := func() int {
+		var count int
+		require.NoError(t, db.db.QueryRow("SELECT COUNT(*) FROM blob").Scan(&count))
+		return count
+	}
+
+	firstHash := sha256.Sum256([]byte("ciphertext 1"))
+	firstID := create(alice, "first", "ciphertext 1", server.CompressionNone)
+	secondID := create(alice, "second", "ciphertext 2", server.CompressionNone)
+	require.Equal(t, 1, blobCount())
+
+	// Вторая запись получает уже сохраненное содержимое и его хэш.
+	content, digest := get(alice, secondID)
+	require.Equal(t, "ciphertext 1", content)
+	require.Equal(t, firstHash[:], digest)
+
+	// Содержимое других пользователей и с другим сжатием не совпадает.
+	bobID := create(bob, "bob", "ciphertext 3", server.CompressionNone)
+	zstdID := create(alice, "zstd", "ciphertext 4", server.CompressionZstd)
+	require.Equal(t, 3, blobCount())
+	content, _ = get(bob, bobID)
+	require.Equal(t, "ciphertext 3", content)
+	content, _ = get(alice, zstdID)
+	require.Equal(t, "ciphertext 4", content)
+
+	remove(alice, firstID)
+	content, _ = get(alice, secondID)
+	require.Equal(t, "ciphertext 1", content)
+
+	remove(alice, secondID)
+	require.Equal(t, 2, blobCount())
+}
+
 func mustCreateBinary(t *testing.T, name string, filename string, content io.Reader, user string) int64 {
 	var buf bytes.Buffer
 	size, err := io.Copy(&buf, content)
@@ -224,9 +410,7 @@ func mustCreateBinary(t *testing.T, name string, filename string, content io.Rea
 	})
 	require.NoError(t, err)
 
-	// TODO(minor): имплементация скопирована из сервиса. возможно, не очень хорошо так делать
-	path := filepath.Join(db.binariesFolder, fmt.Sprintf("%d", id))
-	err = os.WriteFile(path, buf.Bytes(), 0666)
+	err = blobs.Put(t.Context(), sqlstore.BinaryKey(id), &buf)
 	require.NoError(t, err)
 
 	return id
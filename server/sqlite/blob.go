@@ -0,0 +1,125 @@
+package sqlite
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/mkolibaba/gophkeeper/server"
+	sqlc "github.com/mkolibaba/gophkeeper/server/sqlite/sqlc/gen"
+	"io"
+	"slices"
+	"sync"
+)
+
+// BlobStore хранит содержимое в таблице базы данных. Содержимое целиком
+// читается в память, поэтому хранилище подходит для небольших файлов.
+type BlobStore struct {
+	qs *sqlc.Queries
+}
+
+func NewBlobStore(queries *sqlc.Queries) *BlobStore {
+	return &BlobStore{
+		qs: queries,
+	}
+}
+
+func (s *BlobStore) Put(ctx context.Context, key string, r io.Reader) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("put: %w", err)
+	}
+
+	if err := s.qs.UpsertBlobContent(ctx, key, data); err != nil {
+		return fmt.Errorf("put: %w", err)
+	}
+	return nil
+}
+
+func (s *BlobStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	data, err := s.qs.SelectBlobContent(ctx, key)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, server.ErrBlobNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get: %w", err)
+	}
+	return blobReader{bytes.NewReader(data)}, nil
+}
+
+func (s *BlobStore) Delete(ctx context.Context, key string) error {
+	if err := s.qs.DeleteBlobContent(ctx, key); err != nil {
+		return fmt.Errorf("delete: %w", err)
+	}
+	return nil
+}
+
+func (s *BlobStore) Stat(ctx context.Context, key string) (server.BlobInfo, error) {
+	size, err := s.qs.SelectBlobContentSize(ctx, key)
+	if errors.Is(err, sql.ErrNoRows) {
+		return server.BlobInfo{}, server.ErrBlobNotFound
+	}
+	if err != nil {
+		return server.BlobInfo{}, fmt.Errorf("stat: %w", err)
+	}
+	return server.BlobInfo{Size: size}, nil
+}
+
+// blobReader - содержимое в памяти, поддерживающее Seek.
+type blobReader struct {
+	*bytes.Reader
+}
+
+func (blobReader) Close() error {
+	return nil
+}
+
+// keyLocks - блокировки ключей хранилища. Нулевое значение готово к
+// использованию.
+type keyLocks struct {
+	mu    sync.Mutex
+	locks map[string]*keyLock
+}
+
+type keyLock struct {
+	sync.Mutex
+	waiters int
+}
+
+// lock захватывает ключи в порядке сортировки, чтобы одновременные
+// захваты пересекающихся наборов не блокировали друг друга.
+// Возвращает функцию освобождения.
+func (l *keyLocks) lock(keys ...string) func() {
+	keys = slices.Compact(slices.Sorted(slices.Values(keys)))
+
+	acquired := make([]*keyLock, 0, len(keys))
+	for _, key := range keys {
+		l.mu.Lock()
+		if l.locks == nil {
+			l.locks = make(map[string]*keyLock)
+		}
+		lock, ok := l.locks[key]
+		if !ok {
+			lock = &keyLock{}
+			l.locks[key] = lock
+		}
+		lock.waiters++
+		l.mu.Unlock()
+
+		lock.Lock()
+		acquired = append(acquired, lock)
+	}
+
+	return func() {
+		for i, lock := range acquired {
+			lock.Unlock()
+
+			l.mu.Lock()
+			if lock.waiters--; lock.waiters == 0 {
+				delete(l.locks, keys[i])
+			}
+			l.mu.Unlock()
+		}
+	}
+}
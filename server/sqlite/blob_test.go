@@ -0,0 +1,47 @@
+package sqlite
+
+import (
+	"github.com/mkolibaba/gophkeeper/server"
+	"github.com/stretchr/testify/require"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestBlobStore(t *testing.T) {
+	t.Cleanup(func() {
+		db.db.Exec("DELETE FROM blob_content")
+	})
+
+	store := NewBlobStore(queries)
+
+	t.Run("put_get", func(t *testing.T) {
+		require.NoError(t, store.Put(t.Context(), "blob-1", strings.NewReader("content 1")))
+		require.NoError(t, store.Put(t.Context(), "blob-1", strings.NewReader("content 2")))
+
+		r, err := store.Get(t.Context(), "blob-1")
+		require.NoError(t, err)
+		defer r.Close()
+		content, err := io.ReadAll(r)
+		require.NoError(t, err)
+		require.Equal(t, "content 2", string(content))
+
+		// Содержимое поддерживает Seek для скачивания с середины.
+		_, ok := r.(io.Seeker)
+		require.True(t, ok)
+
+		info, err := store.Stat(t.Context(), "blob-1")
+		require.NoError(t, err)
+		require.EqualValues(t, 9, info.Size)
+	})
+	t.Run("delete", func(t *testing.T) {
+		require.NoError(t, store.Put(t.Context(), "blob-2", strings.NewReader("content")))
+		require.NoError(t, store.Delete(t.Context(), "blob-2"))
+		require.NoError(t, store.Delete(t.Context(), "blob-2"))
+
+		_, err := store.Get(t.Context(), "blob-2")
+		require.ErrorIs(t, err, server.ErrBlobNotFound)
+		_, err = store.Stat(t.Context(), "blob-2")
+		require.ErrorIs(t, err, server.ErrBlobNotFound)
+	})
+}
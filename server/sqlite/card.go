@@ -8,51 +8,102 @@ import (
 	"github.com/mkolibaba/gophkeeper/server"
 	"github.com/mkolibaba/gophkeeper/server/sqlite/converter"
 	sqlc "github.com/mkolibaba/gophkeeper/server/sqlite/sqlc/gen"
+	"github.com/mkolibaba/gophkeeper/server/sqlstore"
 )
 
 type CardService struct {
-	qs        *sqlc.Queries
+	db        *DB
+	store     *Store
 	converter converter.DataConverter
 }
 
-func NewCardService(queries *sqlc.Queries, converter converter.DataConverter) *CardService {
+func NewCardService(db *DB, queries *sqlc.Queries, converter converter.DataConverter) *CardService {
 	return &CardService{
-		qs:        queries,
+		db:        db,
+		store:     NewStore(db, queries),
 		converter: converter,
 	}
 }
 
 func (s *CardService) Create(ctx context.Context, data server.CardData) error {
-	_, err := s.qs.InsertCard(ctx, s.converter.ConvertToInsertCard(ctx, data))
+	if err := sqlstore.CheckItemQuota(ctx, s.store, s.db.quota, 1); err != nil {
+		return err
+	}
+
+	params := s.converter.ConvertToInsertCard(ctx, data)
+	if err := s.db.seal(insertCardFields(&params)); err != nil {
+		return fmt.Errorf("create: %w", err)
+	}
+
+	_, err := s.store.InsertCard(ctx, params)
 	return unwrapInsertError(err)
 }
 
 func (s *CardService) GetAll(ctx context.Context) ([]server.CardData, error) {
-	return getAllData(ctx, s.qs.SelectCards, s.converter.ConvertToCardDataSlice)
+	return getAllData(ctx, s.db.keyring, s.store.SelectCards, cardFields, s.converter.ConvertToCardDataSlice)
 }
 
-func (s *CardService) Update(ctx context.Context, id int64, data server.CardDataUpdate) error {
-	card, err := s.qs.SelectCard(ctx, id, server.UserFromContext(ctx))
+func (s *CardService) Update(ctx context.Context, id int64, version int64, data server.CardDataUpdate) error {
+	card, err := s.store.SelectCard(ctx, id, server.UserFromContext(ctx))
 	if errors.Is(err, sql.ErrNoRows) {
 		return server.ErrDataNotFound
 	}
 	if err != nil {
 		return fmt.Errorf("update: %w", err)
 	}
+	if card.Revision != version {
+		return &server.VersionConflictError{Version: card.Revision}
+	}
+	if err := s.db.open(cardFields(&card)); err != nil {
+		return fmt.Errorf("update: %w", err)
+	}
+
+	previous := s.converter.ConvertToCardData(card)
 
 	params := s.converter.ConvertToUpdateCard(card)
 	s.converter.ConvertToUpdateCardUpdate(data, &params)
-
-	n, err := s.qs.UpdateCard(ctx, params)
-	if n == 0 {
-		return server.ErrDataNotFound
+	params.Revision = version
+	if err := s.db.seal(updateCardFields(&params)); err != nil {
+		return fmt.Errorf("update: %w", err)
 	}
+
+	err = s.store.changeWithHistory(ctx, server.DataKindCard, id, version, previous, false, func(qs *sqlc.Queries) error {
+		n, err := qs.UpdateCard(ctx, params)
+		return sqlstore.CheckChanged(ctx, n, err, qs.SelectCardRevision, id)
+	})
 	if err != nil {
 		return fmt.Errorf("update: %w", err)
 	}
 	return nil
 }
 
-func (s *CardService) Remove(ctx context.Context, id int64) error {
-	return removeData(ctx, s.qs.DeleteCard, id)
+func (s *CardService) Remove(ctx context.Context, id int64, version int64) error {
+	card, err := s.store.SelectCard(ctx, id, server.UserFromContext(ctx))
+	if errors.Is(err, sql.ErrNoRows) {
+		return server.ErrDataNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("remove: %w", err)
+	}
+	if card.Revision != version {
+		return &server.VersionConflictError{Version: card.Revision}
+	}
+	if err := s.db.open(cardFields(&card)); err != nil {
+		return fmt.Errorf("remove: %w", err)
+	}
+	previous := s.converter.ConvertToCardData(card)
+
+	err = s.store.changeWithHistory(ctx, server.DataKindCard, id, version, previous, true, func(qs *sqlc.Queries) error {
+		n, err := qs.TrashCard(ctx, sqlc.TrashCardParams{
+			DeletedAt: sqlstore.TrashedNow(),
+			ID:        id,
+			User:      server.UserFromContext(ctx),
+			Revision:  version,
+		})
+		return sqlstore.CheckChanged(ctx, n, err, qs.SelectCardRevision, id)
+	})
+	if err != nil {
+		return fmt.Errorf("remove: %w", err)
+	}
+	return nil
 }
@@ -18,7 +18,7 @@ func TestCardCreate(t *testing.T) {
 		db.db.Exec("DELETE FROM user")
 	})
 
-	srv := NewCardService(queries, NewDataConverter())
+	srv := NewCardService(db, queries, NewDataConverter())
 
 	t.Run("success", func(t *testing.T) {
 		ctx := server.NewContextWithUser(t.Context(), "alice")
@@ -58,7 +58,7 @@ func TestCardGetAll(t *testing.T) {
 		db.db.Exec("DELETE FROM user")
 	})
 
-	srv := NewCardService(queries, NewDataConverter())
+	srv := NewCardService(db, queries, NewDataConverter())
 
 	t.Run("success", func(t *testing.T) {
 		ctx := server.NewContextWithUser(t.Context(), "alice")
@@ -91,12 +91,13 @@ func TestCardUpdate(t *testing.T) {
 		db.db.Exec("DELETE FROM user")
 	})
 
-	srv := NewCardService(queries, NewDataConverter())
+	srv := NewCardService(db, queries, NewDataConverter())
 
 	t.Run("success", func(t *testing.T) {
 		ctx := server.NewContextWithUser(t.Context(), "alice")
 		number := "41110011"
-		err := srv.Update(ctx, card1ID, server.CardDataUpdate{
+		version := mustSelectRevision(t, queries.SelectCardRevision, card1ID)
+		err := srv.Update(ctx, card1ID, version, server.CardDataUpdate{
 			Number: &number,
 		})
 		require.NoError(t, err)
@@ -109,7 +110,7 @@ func TestCardUpdate(t *testing.T) {
 	t.Run("not_found", func(t *testing.T) {
 		ctx := server.NewContextWithUser(t.Context(), "alice")
 		number := "41110011"
-		err := srv.Update(ctx, -100, server.CardDataUpdate{
+		err := srv.Update(ctx, -100, 1, server.CardDataUpdate{
 			Number: &number,
 		})
 		require.ErrorIs(t, err, server.ErrDataNotFound)
@@ -117,7 +118,8 @@ func TestCardUpdate(t *testing.T) {
 	t.Run("user_not_owner", func(t *testing.T) {
 		ctx := server.NewContextWithUser(t.Context(), "bob")
 		number := "41110011"
-		err := srv.Update(ctx, card1ID, server.CardDataUpdate{
+		version := mustSelectRevision(t, queries.SelectCardRevision, card1ID)
+		err := srv.Update(ctx, card1ID, version, server.CardDataUpdate{
 			Number: &number,
 		})
 		require.ErrorIs(t, err, server.ErrDataNotFound)
@@ -135,23 +137,25 @@ func TestCardDelete(t *testing.T) {
 		db.db.Exec("DELETE FROM user")
 	})
 
-	srv := NewCardService(queries, NewDataConverter())
+	srv := NewCardService(db, queries, NewDataConverter())
 
 	t.Run("success", func(t *testing.T) {
 		note3ID := mustCreateCard(t, "card3", "4444", "01/31", "alice")
 
 		ctx := server.NewContextWithUser(t.Context(), "alice")
-		err := srv.Remove(ctx, note3ID)
+		version := mustSelectRevision(t, queries.SelectCardRevision, note3ID)
+		err := srv.Remove(ctx, note3ID, version)
 		require.NoError(t, err)
 	})
 	t.Run("not_found", func(t *testing.T) {
 		ctx := server.NewContextWithUser(t.Context(), "alice")
-		err := srv.Remove(ctx, -100)
+		err := srv.Remove(ctx, -100, 1)
 		require.ErrorIs(t, err, server.ErrDataNotFound)
 	})
 	t.Run("user_not_owner", func(t *testing.T) {
 		ctx := server.NewContextWithUser(t.Context(), "bob")
-		err := srv.Remove(ctx, card1ID)
+		version := mustSelectRevision(t, queries.SelectCardRevision, card1ID)
+		err := srv.Remove(ctx, card1ID, version)
 		require.ErrorIs(t, err, server.ErrDataNotFound)
 	})
 }
@@ -18,13 +18,14 @@ type DataConverter interface {
 	ConvertToLoginDataSlice(source []sqlc.Login) []server.LoginData
 
 	// goverter:useZeroValueOnPointerInconsistency
+	// goverter:map Revision Version
 	ConvertToLoginData(source sqlc.Login) server.LoginData
 
 	ConvertToUpdateLogin(source sqlc.Login) sqlc.UpdateLoginParams
 
 	// goverter:update target
 	// goverter:useZeroValueOnPointerInconsistency
-	// goverter:ignore ID
+	// goverter:ignore ID Revision
 	ConvertToUpdateLoginUpdate(source server.LoginDataUpdate, target *sqlc.UpdateLoginParams)
 
 	// -- Note --
@@ -36,13 +37,14 @@ type DataConverter interface {
 	ConvertToNoteDataSlice(source []sqlc.Note) []server.NoteData
 
 	// goverter:useZeroValueOnPointerInconsistency
+	// goverter:map Revision Version
 	ConvertToNoteData(source sqlc.Note) server.NoteData
 
 	ConvertToUpdateNote(source sqlc.Note) sqlc.UpdateNoteParams
 
 	// goverter:update target
 	// goverter:useZeroValueOnPointerInconsistency
-	// goverter:ignore ID
+	// goverter:ignore ID Revision
 	ConvertToUpdateNoteUpdate(source server.NoteDataUpdate, target *sqlc.UpdateNoteParams)
 
 	// -- Binary --
@@ -55,13 +57,14 @@ type DataConverter interface {
 	ConvertToBinaryDataSlice(source []sqlc.Binary) []server.BinaryData
 
 	// goverter:useZeroValueOnPointerInconsistency
+	// goverter:map Revision Version
 	ConvertToBinaryData(source sqlc.Binary) server.BinaryData
 
 	ConvertToUpdateBinary(source sqlc.Binary) sqlc.UpdateBinaryParams
 
 	// goverter:update target
 	// goverter:useZeroValueOnPointerInconsistency
-	// goverter:ignore ID
+	// goverter:ignore ID Revision
 	ConvertToUpdateBinaryUpdate(source server.BinaryDataUpdate, target *sqlc.UpdateBinaryParams)
 
 	// -- Card --
@@ -75,15 +78,41 @@ type DataConverter interface {
 
 	// goverter:useZeroValueOnPointerInconsistency
 	// goverter:map Cvv CVV
+	// goverter:map Revision Version
 	ConvertToCardData(source sqlc.Card) server.CardData
 
 	ConvertToUpdateCard(source sqlc.Card) sqlc.UpdateCardParams
 
 	// goverter:update target
 	// goverter:useZeroValueOnPointerInconsistency
-	// goverter:ignore ID
+	// goverter:ignore ID Revision
 	// goverter:map CVV Cvv
 	ConvertToUpdateCardUpdate(source server.CardDataUpdate, target *sqlc.UpdateCardParams)
+
+	// -- OTP --
+
+	// goverter:context ctx
+	// goverter:map User | UserFromContext
+	ConvertToInsertOTP(ctx context.Context, source server.OTPData) sqlc.InsertOTPParams
+
+	ConvertToOTPDataSlice(source []sqlc.OTP) []server.OTPData
+
+	// goverter:useZeroValueOnPointerInconsistency
+	// goverter:map Revision Version
+	ConvertToOTPData(source sqlc.OTP) server.OTPData
+
+	ConvertToUpdateOTP(source sqlc.OTP) sqlc.UpdateOTPParams
+
+	// goverter:update target
+	// goverter:useZeroValueOnPointerInconsistency
+	// goverter:ignore ID Revision
+	ConvertToUpdateOTPUpdate(source server.OTPDataUpdate, target *sqlc.UpdateOTPParams)
+
+	// -- Sync --
+
+	ConvertToTombstoneSlice(source []sqlc.Tombstone) []server.Tombstone
+
+	ConvertToTombstone(source sqlc.Tombstone) server.Tombstone
 }
 
 // goverter:context ctx
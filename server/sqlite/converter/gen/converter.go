@@ -21,6 +21,8 @@ func (c *DataConverterImpl) ConvertToBinaryData(source gen.Binary) server.Binary
 	if source.Notes != nil {
 		serverBinaryData.Notes = *source.Notes
 	}
+	serverBinaryData.Version = source.Revision
+	serverBinaryData.Compression = server.Compression(source.Compression)
 	return serverBinaryData
 }
 func (c *DataConverterImpl) ConvertToBinaryDataSlice(source []gen.Binary) []server.BinaryData {
@@ -44,6 +46,7 @@ func (c *DataConverterImpl) ConvertToCardData(source gen.Card) server.CardData {
 	if source.Notes != nil {
 		serverCardData.Notes = *source.Notes
 	}
+	serverCardData.Version = source.Revision
 	return serverCardData
 }
 func (c *DataConverterImpl) ConvertToCardDataSlice(source []gen.Card) []server.CardData {
@@ -64,6 +67,7 @@ func (c *DataConverterImpl) ConvertToInsertBinary(context context.Context, sourc
 	pString := source.BinaryData.Notes
 	sqlcInsertBinaryParams.Notes = &pString
 	sqlcInsertBinaryParams.User = converter.UserFromContext(context)
+	sqlcInsertBinaryParams.Compression = int64(source.BinaryData.Compression)
 	return sqlcInsertBinaryParams
 }
 func (c *DataConverterImpl) ConvertToInsertCard(context context.Context, source server.CardData) gen.InsertCardParams {
@@ -99,6 +103,20 @@ func (c *DataConverterImpl) ConvertToInsertNote(context context.Context, source
 	sqlcInsertNoteParams.User = converter.UserFromContext(context)
 	return sqlcInsertNoteParams
 }
+func (c *DataConverterImpl) ConvertToInsertOTP(context context.Context, source server.OTPData) gen.InsertOTPParams {
+	var sqlcInsertOTPParams gen.InsertOTPParams
+	sqlcInsertOTPParams.Name = source.Name
+	sqlcInsertOTPParams.Type = source.Type
+	sqlcInsertOTPParams.Secret = source.Secret
+	sqlcInsertOTPParams.Algorithm = source.Algorithm
+	sqlcInsertOTPParams.Digits = source.Digits
+	sqlcInsertOTPParams.Period = source.Period
+	sqlcInsertOTPParams.Counter = source.Counter
+	pString := source.Issuer
+	sqlcInsertOTPParams.Issuer = &pString
+	sqlcInsertOTPParams.User = converter.UserFromContext(context)
+	return sqlcInsertOTPParams
+}
 func (c *DataConverterImpl) ConvertToLoginData(source gen.Login) server.LoginData {
 	var serverLoginData server.LoginData
 	serverLoginData.ID = source.ID
@@ -113,6 +131,7 @@ func (c *DataConverterImpl) ConvertToLoginData(source gen.Login) server.LoginDat
 	if source.Notes != nil {
 		serverLoginData.Notes = *source.Notes
 	}
+	serverLoginData.Version = source.Revision
 	return serverLoginData
 }
 func (c *DataConverterImpl) ConvertToLoginDataSlice(source []gen.Login) []server.LoginData {
@@ -132,6 +151,7 @@ func (c *DataConverterImpl) ConvertToNoteData(source gen.Note) server.NoteData {
 	if source.Text != nil {
 		serverNoteData.Text = *source.Text
 	}
+	serverNoteData.Version = source.Revision
 	return serverNoteData
 }
 func (c *DataConverterImpl) ConvertToNoteDataSlice(source []gen.Note) []server.NoteData {
@@ -144,6 +164,48 @@ func (c *DataConverterImpl) ConvertToNoteDataSlice(source []gen.Note) []server.N
 	}
 	return serverNoteDataList
 }
+func (c *DataConverterImpl) ConvertToOTPData(source gen.OTP) server.OTPData {
+	var serverOTPData server.OTPData
+	serverOTPData.ID = source.ID
+	serverOTPData.Name = source.Name
+	serverOTPData.Type = source.Type
+	serverOTPData.Secret = source.Secret
+	serverOTPData.Algorithm = source.Algorithm
+	serverOTPData.Digits = source.Digits
+	serverOTPData.Period = source.Period
+	serverOTPData.Counter = source.Counter
+	if source.Issuer != nil {
+		serverOTPData.Issuer = *source.Issuer
+	}
+	serverOTPData.Version = source.Revision
+	return serverOTPData
+}
+func (c *DataConverterImpl) ConvertToOTPDataSlice(source []gen.OTP) []server.OTPData {
+	var serverOTPDataList []server.OTPData
+	if source != nil {
+		serverOTPDataList = make([]server.OTPData, len(source))
+		for i := 0; i < len(source); i++ {
+			serverOTPDataList[i] = c.ConvertToOTPData(source[i])
+		}
+	}
+	return serverOTPDataList
+}
+func (c *DataConverterImpl) ConvertToTombstone(source gen.Tombstone) server.Tombstone {
+	var serverTombstone server.Tombstone
+	serverTombstone.Kind = server.DataKind(source.Kind)
+	serverTombstone.ID = source.ID
+	return serverTombstone
+}
+func (c *DataConverterImpl) ConvertToTombstoneSlice(source []gen.Tombstone) []server.Tombstone {
+	var serverTombstoneList []server.Tombstone
+	if source != nil {
+		serverTombstoneList = make([]server.Tombstone, len(source))
+		for i := 0; i < len(source); i++ {
+			serverTombstoneList[i] = c.ConvertToTombstone(source[i])
+		}
+	}
+	return serverTombstoneList
+}
 func (c *DataConverterImpl) ConvertToUpdateBinary(source gen.Binary) gen.UpdateBinaryParams {
 	var sqlcUpdateBinaryParams gen.UpdateBinaryParams
 	sqlcUpdateBinaryParams.Name = source.Name
@@ -152,6 +214,7 @@ func (c *DataConverterImpl) ConvertToUpdateBinary(source gen.Binary) gen.UpdateB
 		sqlcUpdateBinaryParams.Notes = &xstring
 	}
 	sqlcUpdateBinaryParams.ID = source.ID
+	sqlcUpdateBinaryParams.Revision = source.Revision
 	return sqlcUpdateBinaryParams
 }
 func (c *DataConverterImpl) ConvertToUpdateBinaryUpdate(source server.BinaryDataUpdate, target *gen.UpdateBinaryParams) {
@@ -175,6 +238,7 @@ func (c *DataConverterImpl) ConvertToUpdateCard(source gen.Card) gen.UpdateCardP
 		sqlcUpdateCardParams.Notes = &xstring
 	}
 	sqlcUpdateCardParams.ID = source.ID
+	sqlcUpdateCardParams.Revision = source.Revision
 	return sqlcUpdateCardParams
 }
 func (c *DataConverterImpl) ConvertToUpdateCardUpdate(source server.CardDataUpdate, target *gen.UpdateCardParams) {
@@ -215,6 +279,7 @@ func (c *DataConverterImpl) ConvertToUpdateLogin(source gen.Login) gen.UpdateLog
 		sqlcUpdateLoginParams.Notes = &xstring3
 	}
 	sqlcUpdateLoginParams.ID = source.ID
+	sqlcUpdateLoginParams.Revision = source.Revision
 	return sqlcUpdateLoginParams
 }
 func (c *DataConverterImpl) ConvertToUpdateLoginUpdate(source server.LoginDataUpdate, target *gen.UpdateLoginParams) {
@@ -245,6 +310,7 @@ func (c *DataConverterImpl) ConvertToUpdateNote(source gen.Note) gen.UpdateNoteP
 		sqlcUpdateNoteParams.Text = &xstring
 	}
 	sqlcUpdateNoteParams.ID = source.ID
+	sqlcUpdateNoteParams.Revision = source.Revision
 	return sqlcUpdateNoteParams
 }
 func (c *DataConverterImpl) ConvertToUpdateNoteUpdate(source server.NoteDataUpdate, target *gen.UpdateNoteParams) {
@@ -256,3 +322,47 @@ func (c *DataConverterImpl) ConvertToUpdateNoteUpdate(source server.NoteDataUpda
 		target.Text = &xstring
 	}
 }
+func (c *DataConverterImpl) ConvertToUpdateOTP(source gen.OTP) gen.UpdateOTPParams {
+	var sqlcUpdateOTPParams gen.UpdateOTPParams
+	sqlcUpdateOTPParams.Name = source.Name
+	sqlcUpdateOTPParams.Type = source.Type
+	sqlcUpdateOTPParams.Secret = source.Secret
+	sqlcUpdateOTPParams.Algorithm = source.Algorithm
+	sqlcUpdateOTPParams.Digits = source.Digits
+	sqlcUpdateOTPParams.Period = source.Period
+	sqlcUpdateOTPParams.Counter = source.Counter
+	if source.Issuer != nil {
+		xstring := *source.Issuer
+		sqlcUpdateOTPParams.Issuer = &xstring
+	}
+	sqlcUpdateOTPParams.ID = source.ID
+	sqlcUpdateOTPParams.Revision = source.Revision
+	return sqlcUpdateOTPParams
+}
+func (c *DataConverterImpl) ConvertToUpdateOTPUpdate(source server.OTPDataUpdate, target *gen.UpdateOTPParams) {
+	if source.Name != nil {
+		target.Name = *source.Name
+	}
+	if source.Type != nil {
+		target.Type = *source.Type
+	}
+	if source.Secret != nil {
+		target.Secret = *source.Secret
+	}
+	if source.Algorithm != nil {
+		target.Algorithm = *source.Algorithm
+	}
+	if source.Digits != nil {
+		target.Digits = *source.Digits
+	}
+	if source.Period != nil {
+		target.Period = *source.Period
+	}
+	if source.Counter != nil {
+		target.Counter = *source.Counter
+	}
+	if source.Issuer != nil {
+		xstring := *source.Issuer
+		target.Issuer = &xstring
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"github.com/mkolibaba/gophkeeper/server"
+	"github.com/mkolibaba/gophkeeper/server/sealed"
 	"modernc.org/sqlite"
 	sqlite3 "modernc.org/sqlite/lib"
 )
@@ -20,27 +21,17 @@ func unwrapInsertError(err error) error {
 
 func getAllData[S any, R any](
 	ctx context.Context,
+	keyring server.Keyring,
 	getter func(context.Context, string) ([]S, error),
+	fields func(*S) sealed.Fields,
 	mapper func([]S) []R,
 ) ([]R, error) {
 	sources, err := getter(ctx, server.UserFromContext(ctx))
 	if err != nil {
 		return nil, fmt.Errorf("get all: %w", err)
 	}
-	return mapper(sources), nil
-}
-
-func removeData(
-	ctx context.Context,
-	remove func(ctx context.Context, id int64, user string) (int64, error),
-	id int64,
-) error {
-	n, err := remove(ctx, id, server.UserFromContext(ctx))
-	if n == 0 {
-		return server.ErrDataNotFound
-	}
-	if err != nil {
-		return fmt.Errorf("remove: %w", err)
+	if err := sealed.OpenRows(keyring, sources, fields); err != nil {
+		return nil, fmt.Errorf("get all: %w", err)
 	}
-	return nil
+	return mapper(sources), nil
 }
@@ -0,0 +1,61 @@
+package sqlite
+
+import (
+	"github.com/mkolibaba/gophkeeper/server"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestHistoryRetention(t *testing.T) {
+	mustCreateUser(t, "alice", "123")
+	id := mustCreateNote(t, "note", "v0", "alice")
+
+	t.Cleanup(func() {
+		db.history = server.History{}
+		db.db.Exec("DELETE FROM history")
+		db.db.Exec("DELETE FROM note")
+		db.db.Exec("DELETE FROM user")
+	})
+
+	ctx := server.NewContextWithUser(t.Context(), "alice")
+	notes := NewNoteService(db, queries, NewDataConverter())
+	history := NewHistoryService(db, queries, nil, notes, nil, nil, nil)
+
+	update := func(text string) {
+		t.Helper()
+		revision := mustSelectRevision(t, queries.SelectNoteRevision, id)
+		require.NoError(t, notes.Update(ctx, id, revision, server.NoteDataUpdate{Text: &text}))
+	}
+
+	t.Run("max_versions", func(t *testing.T) {
+		db.history = server.History{MaxVersions: 2}
+		for _, text := range []string{"v1", "v2", "v3"} {
+			update(text)
+		}
+
+		entries, err := history.List(ctx, server.DataKindNote, id)
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+		require.Equal(t, "v2", entries[0].Note.Text)
+		require.Equal(t, "v1", entries[1].Note.Text)
+	})
+	t.Run("max_age", func(t *testing.T) {
+		db.history = server.History{MaxAge: time.Hour}
+		// Сервис истории читает ограничения хранения при создании.
+		history := NewHistoryService(db, queries, nil, notes, nil, nil, nil)
+		_, err := db.db.Exec("UPDATE history SET created_at = created_at - 7200")
+		require.NoError(t, err)
+
+		// Устаревшие версии не показываются еще до удаления.
+		entries, err := history.List(ctx, server.DataKindNote, id)
+		require.NoError(t, err)
+		require.Empty(t, entries)
+
+		update("v4")
+
+		var count int
+		require.NoError(t, db.db.QueryRow("SELECT COUNT(*) FROM history").Scan(&count))
+		require.Equal(t, 1, count)
+	})
+}
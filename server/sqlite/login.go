@@ -8,51 +8,102 @@ import (
 	"github.com/mkolibaba/gophkeeper/server"
 	"github.com/mkolibaba/gophkeeper/server/sqlite/converter"
 	sqlc "github.com/mkolibaba/gophkeeper/server/sqlite/sqlc/gen"
+	"github.com/mkolibaba/gophkeeper/server/sqlstore"
 )
 
 type LoginService struct {
-	qs        *sqlc.Queries
+	db        *DB
+	store     *Store
 	converter converter.DataConverter
 }
 
-func NewLoginService(queries *sqlc.Queries, converter converter.DataConverter) *LoginService {
+func NewLoginService(db *DB, queries *sqlc.Queries, converter converter.DataConverter) *LoginService {
 	return &LoginService{
-		qs:        queries,
+		db:        db,
+		store:     NewStore(db, queries),
 		converter: converter,
 	}
 }
 
 func (s *LoginService) Create(ctx context.Context, data server.LoginData) error {
-	_, err := s.qs.InsertLogin(ctx, s.converter.ConvertToInsertLogin(ctx, data))
+	if err := sqlstore.CheckItemQuota(ctx, s.store, s.db.quota, 1); err != nil {
+		return err
+	}
+
+	params := s.converter.ConvertToInsertLogin(ctx, data)
+	if err := s.db.seal(insertLoginFields(&params)); err != nil {
+		return fmt.Errorf("create: %w", err)
+	}
+
+	_, err := s.store.InsertLogin(ctx, params)
 	return unwrapInsertError(err)
 }
 
 func (s *LoginService) GetAll(ctx context.Context) ([]server.LoginData, error) {
-	return getAllData(ctx, s.qs.SelectLogins, s.converter.ConvertToLoginDataSlice)
+	return getAllData(ctx, s.db.keyring, s.store.SelectLogins, loginFields, s.converter.ConvertToLoginDataSlice)
 }
 
-func (s *LoginService) Update(ctx context.Context, id int64, data server.LoginDataUpdate) error {
-	login, err := s.qs.SelectLogin(ctx, id, server.UserFromContext(ctx))
+func (s *LoginService) Update(ctx context.Context, id int64, version int64, data server.LoginDataUpdate) error {
+	login, err := s.store.SelectLogin(ctx, id, server.UserFromContext(ctx))
 	if errors.Is(err, sql.ErrNoRows) {
 		return server.ErrDataNotFound
 	}
 	if err != nil {
 		return fmt.Errorf("update: %w", err)
 	}
+	if login.Revision != version {
+		return &server.VersionConflictError{Version: login.Revision}
+	}
+	if err := s.db.open(loginFields(&login)); err != nil {
+		return fmt.Errorf("update: %w", err)
+	}
+
+	previous := s.converter.ConvertToLoginData(login)
 
 	params := s.converter.ConvertToUpdateLogin(login)
 	s.converter.ConvertToUpdateLoginUpdate(data, &params)
-
-	n, err := s.qs.UpdateLogin(ctx, params)
-	if n == 0 {
-		return server.ErrDataNotFound
+	params.Revision = version
+	if err := s.db.seal(updateLoginFields(&params)); err != nil {
+		return fmt.Errorf("update: %w", err)
 	}
+
+	err = s.store.changeWithHistory(ctx, server.DataKindLogin, id, version, previous, false, func(qs *sqlc.Queries) error {
+		n, err := qs.UpdateLogin(ctx, params)
+		return sqlstore.CheckChanged(ctx, n, err, qs.SelectLoginRevision, id)
+	})
 	if err != nil {
 		return fmt.Errorf("update: %w", err)
 	}
 	return nil
 }
 
-func (s *LoginService) Remove(ctx context.Context, id int64) error {
-	return removeData(ctx, s.qs.DeleteLogin, id)
+func (s *LoginService) Remove(ctx context.Context, id int64, version int64) error {
+	login, err := s.store.SelectLogin(ctx, id, server.UserFromContext(ctx))
+	if errors.Is(err, sql.ErrNoRows) {
+		return server.ErrDataNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("remove: %w", err)
+	}
+	if login.Revision != version {
+		return &server.VersionConflictError{Version: login.Revision}
+	}
+	if err := s.db.open(loginFields(&login)); err != nil {
+		return fmt.Errorf("remove: %w", err)
+	}
+	previous := s.converter.ConvertToLoginData(login)
+
+	err = s.store.changeWithHistory(ctx, server.DataKindLogin, id, version, previous, true, func(qs *sqlc.Queries) error {
+		n, err := qs.TrashLogin(ctx, sqlc.TrashLoginParams{
+			DeletedAt: sqlstore.TrashedNow(),
+			ID:        id,
+			User:      server.UserFromContext(ctx),
+			Revision:  version,
+		})
+		return sqlstore.CheckChanged(ctx, n, err, qs.SelectLoginRevision, id)
+	})
+	if err != nil {
+		return fmt.Errorf("remove: %w", err)
+	}
+	return nil
 }
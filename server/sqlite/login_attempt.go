@@ -0,0 +1,69 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/mkolibaba/gophkeeper/server"
+	sqlc "github.com/mkolibaba/gophkeeper/server/sqlite/sqlc/gen"
+	"time"
+)
+
+type LoginAttemptService struct {
+	qs *sqlc.Queries
+}
+
+func NewLoginAttemptService(queries *sqlc.Queries) *LoginAttemptService {
+	return &LoginAttemptService{
+		qs: queries,
+	}
+}
+
+func (s *LoginAttemptService) Get(ctx context.Context, login string) (server.LoginAttempts, error) {
+	attempt, err := s.qs.SelectLoginAttempt(ctx, login)
+	if errors.Is(err, sql.ErrNoRows) {
+		return server.LoginAttempts{Login: login}, nil
+	}
+	if err != nil {
+		return server.LoginAttempts{}, fmt.Errorf("get: %w", err)
+	}
+
+	result := server.LoginAttempts{
+		Login:    attempt.Login,
+		Failures: int(attempt.Failures),
+	}
+	if attempt.LockedUntil > 0 {
+		result.LockedUntil = time.Unix(attempt.LockedUntil, 0)
+	}
+	return result, nil
+}
+
+func (s *LoginAttemptService) Fail(ctx context.Context, login string, at time.Time) (int, error) {
+	failures, err := s.qs.UpsertLoginAttemptFailure(ctx, login, at.Unix())
+	if err != nil {
+		return 0, fmt.Errorf("fail: %w", err)
+	}
+	return int(failures), nil
+}
+
+func (s *LoginAttemptService) Lock(ctx context.Context, login string, until time.Time) error {
+	if err := s.qs.UpdateLoginAttemptLock(ctx, until.Unix(), login); err != nil {
+		return fmt.Errorf("lock: %w", err)
+	}
+	return nil
+}
+
+func (s *LoginAttemptService) Reset(ctx context.Context, login string) error {
+	if err := s.qs.DeleteLoginAttempt(ctx, login); err != nil {
+		return fmt.Errorf("reset: %w", err)
+	}
+	return nil
+}
+
+func (s *LoginAttemptService) RemoveExpired(ctx context.Context, before time.Time) error {
+	if err := s.qs.DeleteExpiredLoginAttempts(ctx, before.Unix()); err != nil {
+		return fmt.Errorf("remove expired: %w", err)
+	}
+	return nil
+}
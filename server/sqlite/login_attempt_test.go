@@ -0,0 +1,58 @@
+package sqlite
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestLoginAttempt(t *testing.T) {
+	t.Cleanup(func() {
+		db.db.Exec("DELETE FROM login_attempt")
+	})
+
+	srv := NewLoginAttemptService(queries)
+	until := time.Unix(1_700_000_000, 0)
+
+	attempts, err := srv.Get(t.Context(), "alice")
+	require.NoError(t, err)
+	require.Zero(t, attempts.Failures)
+	require.True(t, attempts.LockedUntil.IsZero())
+
+	for want := 1; want <= 3; want++ {
+		failures, err := srv.Fail(t.Context(), "alice", until.Add(-time.Minute))
+		require.NoError(t, err)
+		require.Equal(t, want, failures)
+	}
+	require.NoError(t, srv.Lock(t.Context(), "alice", until))
+
+	attempts, err = srv.Get(t.Context(), "alice")
+	require.NoError(t, err)
+	require.Equal(t, 3, attempts.Failures)
+	require.Equal(t, until, attempts.LockedUntil)
+
+	// Попытки под другим логином учитываются отдельно.
+	failures, err := srv.Fail(t.Context(), "charlie", until.Add(-time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, 1, failures)
+
+	// Устаревшие попытки удаляются, пока логин заблокирован - нет.
+	require.NoError(t, srv.RemoveExpired(t.Context(), until.Add(-30*time.Minute)))
+	attempts, err = srv.Get(t.Context(), "charlie")
+	require.NoError(t, err)
+	require.Zero(t, attempts.Failures)
+	attempts, err = srv.Get(t.Context(), "alice")
+	require.NoError(t, err)
+	require.Equal(t, 3, attempts.Failures)
+
+	require.NoError(t, srv.RemoveExpired(t.Context(), until))
+	attempts, err = srv.Get(t.Context(), "alice")
+	require.NoError(t, err)
+	require.Equal(t, 3, attempts.Failures)
+
+	require.NoError(t, srv.Reset(t.Context(), "alice"))
+	attempts, err = srv.Get(t.Context(), "alice")
+	require.NoError(t, err)
+	require.Zero(t, attempts.Failures)
+	require.True(t, attempts.LockedUntil.IsZero())
+}
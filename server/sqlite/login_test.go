@@ -17,7 +17,7 @@ func TestLoginCreate(t *testing.T) {
 		db.db.Exec("DELETE FROM user")
 	})
 
-	srv := NewLoginService(queries, NewDataConverter())
+	srv := NewLoginService(db, queries, NewDataConverter())
 
 	t.Run("success", func(t *testing.T) {
 		ctx := server.NewContextWithUser(t.Context(), "alice")
@@ -57,7 +57,7 @@ func TestLoginGetAll(t *testing.T) {
 		db.db.Exec("DELETE FROM user")
 	})
 
-	srv := NewLoginService(queries, NewDataConverter())
+	srv := NewLoginService(db, queries, NewDataConverter())
 
 	t.Run("success", func(t *testing.T) {
 		ctx := server.NewContextWithUser(t.Context(), "alice")
@@ -90,12 +90,13 @@ func TestLoginUpdate(t *testing.T) {
 		db.db.Exec("DELETE FROM user")
 	})
 
-	srv := NewLoginService(queries, NewDataConverter())
+	srv := NewLoginService(db, queries, NewDataConverter())
 
 	t.Run("success", func(t *testing.T) {
 		ctx := server.NewContextWithUser(t.Context(), "alice")
 		password := "superpassword"
-		err := srv.Update(ctx, login1ID, server.LoginDataUpdate{
+		version := mustSelectRevision(t, queries.SelectLoginRevision, login1ID)
+		err := srv.Update(ctx, login1ID, version, server.LoginDataUpdate{
 			Password: &password,
 		})
 		require.NoError(t, err)
@@ -108,7 +109,7 @@ func TestLoginUpdate(t *testing.T) {
 	t.Run("not_found", func(t *testing.T) {
 		ctx := server.NewContextWithUser(t.Context(), "alice")
 		password := "superpassword"
-		err := srv.Update(ctx, -100, server.LoginDataUpdate{
+		err := srv.Update(ctx, -100, 1, server.LoginDataUpdate{
 			Password: &password,
 		})
 		require.ErrorIs(t, err, server.ErrDataNotFound)
@@ -116,11 +117,31 @@ func TestLoginUpdate(t *testing.T) {
 	t.Run("user_not_owner", func(t *testing.T) {
 		ctx := server.NewContextWithUser(t.Context(), "bob")
 		password := "superpassword"
-		err := srv.Update(ctx, login1ID, server.LoginDataUpdate{
+		version := mustSelectRevision(t, queries.SelectLoginRevision, login1ID)
+		err := srv.Update(ctx, login1ID, version, server.LoginDataUpdate{
 			Password: &password,
 		})
 		require.ErrorIs(t, err, server.ErrDataNotFound)
 	})
+	t.Run("version_conflict", func(t *testing.T) {
+		ctx := server.NewContextWithUser(t.Context(), "alice")
+		version := mustSelectRevision(t, queries.SelectLoginRevision, login1ID)
+
+		name := "app1 (first client)"
+		err := srv.Update(ctx, login1ID, version, server.LoginDataUpdate{Name: &name})
+		require.NoError(t, err)
+
+		name = "app1 (second client)"
+		err = srv.Update(ctx, login1ID, version, server.LoginDataUpdate{Name: &name})
+		var conflict *server.VersionConflictError
+		require.ErrorAs(t, err, &conflict)
+		require.Equal(t, mustSelectRevision(t, queries.SelectLoginRevision, login1ID), conflict.Version)
+		require.Greater(t, conflict.Version, version)
+
+		updatedLogin, err := queries.SelectLogin(ctx, login1ID, "alice")
+		require.NoError(t, err)
+		require.Equal(t, "app1 (first client)", updatedLogin.Name)
+	})
 }
 
 func TestLoginDelete(t *testing.T) {
@@ -134,25 +155,36 @@ func TestLoginDelete(t *testing.T) {
 		db.db.Exec("DELETE FROM user")
 	})
 
-	srv := NewLoginService(queries, NewDataConverter())
+	srv := NewLoginService(db, queries, NewDataConverter())
 
 	t.Run("success", func(t *testing.T) {
 		note3ID := mustCreateLogin(t, "app3", "login3", "", "alice")
 
 		ctx := server.NewContextWithUser(t.Context(), "alice")
-		err := srv.Remove(ctx, note3ID)
+		version := mustSelectRevision(t, queries.SelectLoginRevision, note3ID)
+		err := srv.Remove(ctx, note3ID, version)
 		require.NoError(t, err)
 	})
 	t.Run("not_found", func(t *testing.T) {
 		ctx := server.NewContextWithUser(t.Context(), "alice")
-		err := srv.Remove(ctx, -100)
+		err := srv.Remove(ctx, -100, 1)
 		require.ErrorIs(t, err, server.ErrDataNotFound)
 	})
 	t.Run("user_not_owner", func(t *testing.T) {
 		ctx := server.NewContextWithUser(t.Context(), "bob")
-		err := srv.Remove(ctx, login1ID)
+		version := mustSelectRevision(t, queries.SelectLoginRevision, login1ID)
+		err := srv.Remove(ctx, login1ID, version)
 		require.ErrorIs(t, err, server.ErrDataNotFound)
 	})
+	t.Run("version_conflict", func(t *testing.T) {
+		ctx := server.NewContextWithUser(t.Context(), "alice")
+		version := mustSelectRevision(t, queries.SelectLoginRevision, login1ID)
+
+		err := srv.Remove(ctx, login1ID, version-1)
+		var conflict *server.VersionConflictError
+		require.ErrorAs(t, err, &conflict)
+		require.Equal(t, version, conflict.Version)
+	})
 }
 
 func mustCreateLogin(t *testing.T, name string, login string, password string, user string) int64 {
@@ -0,0 +1,113 @@
+package sqlite
+
+import (
+	"github.com/charmbracelet/log"
+	"github.com/mkolibaba/gophkeeper/server"
+	"github.com/mkolibaba/gophkeeper/server/migrate"
+	"github.com/stretchr/testify/require"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func newMigrateTestDB(t *testing.T) *DB {
+	t.Helper()
+
+	var config server.Config
+	config.SQLite.DataFolder = t.TempDir()
+	config.SQLite.DSN = filepath.Join(config.SQLite.DataFolder, "db.sqlite")
+
+	db := NewDB(&config, nil, log.New(io.Discard))
+	require.NoError(t, db.Connect())
+	t.Cleanup(func() {
+		db.Close()
+	})
+	return db
+}
+
+func TestMigrations(t *testing.T) {
+	db := newMigrateTestDB(t)
+	migrator, err := db.Migrator()
+	require.NoError(t, err)
+	ctx := t.Context()
+
+	// Все миграции отменяются до пустой схемы.
+	_, err = migrator.Up(ctx, false)
+	require.NoError(t, err)
+	steps, err := migrator.To(ctx, migrate.Initial, false)
+	require.NoError(t, err)
+	require.Len(t, steps, 17)
+
+	_, err = migrator.Up(ctx, false)
+	require.NoError(t, err)
+	status, err := migrator.Status(ctx)
+	require.NoError(t, err)
+	for _, s := range status {
+		require.True(t, s.Applied, "migration %d", s.Version)
+	}
+}
+
+func TestContentAddressedDown(t *testing.T) {
+	db := newMigrateTestDB(t)
+	migrator, err := db.Migrator()
+	require.NoError(t, err)
+	ctx := t.Context()
+
+	_, err = migrator.To(ctx, 10, false)
+	require.NoError(t, err)
+	_, err = db.db.Exec(`INSERT INTO user (login, password) VALUES ('user', 'password')`)
+	require.NoError(t, err)
+	_, err = db.db.Exec(`INSERT INTO blob (sha256, size, refs) VALUES (x'0102', 7, 1)`)
+	require.NoError(t, err)
+	_, err = db.db.Exec(`INSERT INTO binary (id, name, filename, size, user, blob) VALUES (1, 'name', 'file', 7, 'user', x'0102')`)
+	require.NoError(t, err)
+
+	// Содержимое хранится вне базы данных: отмена прерывается.
+	_, err = migrator.Down(ctx, false)
+	require.ErrorContains(t, err, "CHECK constraint failed")
+
+	// Содержимое в базе данных снова хранится под id записи.
+	_, err = db.db.Exec(`INSERT INTO blob_content (id, data) VALUES ('0102', 'content')`)
+	require.NoError(t, err)
+	_, err = migrator.Down(ctx, false)
+	require.NoError(t, err)
+
+	var data string
+	require.NoError(t, db.db.QueryRow(`SELECT data FROM blob WHERE id = '1'`).Scan(&data))
+	require.Equal(t, "content", data)
+	var count int
+	require.NoError(t, db.db.QueryRow(`SELECT COUNT(*) FROM blob`).Scan(&count))
+	require.Equal(t, 1, count)
+}
+
+func TestLegacyMigrations(t *testing.T) {
+	db := newMigrateTestDB(t)
+
+	// Прежняя версия сервера отмечала примененные файлы в таблице migration.
+	_, err := db.db.Exec(`CREATE TABLE migration (name TEXT PRIMARY KEY);`)
+	require.NoError(t, err)
+	names, err := fs.Glob(migrationFS, "migration/*.up.sql")
+	require.NoError(t, err)
+	for _, name := range names {
+		script, err := fs.ReadFile(migrationFS, name)
+		require.NoError(t, err)
+		_, err = db.db.Exec(string(script))
+		require.NoError(t, err)
+		_, err = db.db.Exec(`INSERT INTO migration (name) VALUES (?)`, strings.TrimSuffix(name, ".up.sql")+".sql")
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, db.Open())
+
+	migrator, err := db.Migrator()
+	require.NoError(t, err)
+	status, err := migrator.Status(t.Context())
+	require.NoError(t, err)
+	require.Len(t, status, len(names))
+	for _, s := range status {
+		require.True(t, s.Applied, "migration %d", s.Version)
+		require.False(t, s.Modified, "migration %d", s.Version)
+	}
+}
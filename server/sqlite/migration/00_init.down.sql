@@ -0,0 +1,5 @@
+DROP TABLE card;
+DROP TABLE binary;
+DROP TABLE note;
+DROP TABLE login;
+DROP TABLE user;
@@ -0,0 +1,2 @@
+ALTER TABLE user
+    ADD COLUMN vault_key BLOB;
@@ -0,0 +1,23 @@
+-- Триггеры удаляются раньше колонок ревизий: SQLite не удаляет колонки,
+-- на которые ссылаются триггеры.
+
+DROP TRIGGER login_insert_revision;
+DROP TRIGGER login_update_revision;
+DROP TRIGGER login_delete_revision;
+DROP TRIGGER note_insert_revision;
+DROP TRIGGER note_update_revision;
+DROP TRIGGER note_delete_revision;
+DROP TRIGGER binary_insert_revision;
+DROP TRIGGER binary_update_revision;
+DROP TRIGGER binary_delete_revision;
+DROP TRIGGER card_insert_revision;
+DROP TRIGGER card_update_revision;
+DROP TRIGGER card_delete_revision;
+
+DROP TABLE tombstone;
+
+ALTER TABLE user DROP COLUMN revision;
+ALTER TABLE login DROP COLUMN revision;
+ALTER TABLE note DROP COLUMN revision;
+ALTER TABLE binary DROP COLUMN revision;
+ALTER TABLE card DROP COLUMN revision;
@@ -0,0 +1,129 @@
+-- Ревизия пользователя увеличивается при каждом изменении его данных.
+-- Каждая запись хранит ревизию, на которой она была изменена последний раз,
+-- а удаленные записи оставляют после себя отметки (tombstone). Это позволяет
+-- клиентам получать только изменения после известной им ревизии.
+
+ALTER TABLE user ADD COLUMN revision INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE login ADD COLUMN revision INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE note ADD COLUMN revision INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE binary ADD COLUMN revision INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE card ADD COLUMN revision INTEGER NOT NULL DEFAULT 0;
+
+-- Существующие данные считаются измененными на первой ревизии.
+UPDATE user SET revision = 1;
+UPDATE login SET revision = 1;
+UPDATE note SET revision = 1;
+UPDATE binary SET revision = 1;
+UPDATE card SET revision = 1;
+
+CREATE TABLE tombstone
+(
+    kind     TEXT    NOT NULL,
+    id       INTEGER NOT NULL,
+    user     TEXT    NOT NULL,
+    revision INTEGER NOT NULL,
+    PRIMARY KEY (kind, id),
+    FOREIGN KEY (user) REFERENCES user (login) ON DELETE CASCADE
+);
+
+CREATE INDEX tombstone_user_revision ON tombstone (user, revision);
+
+CREATE TRIGGER login_insert_revision
+    AFTER INSERT
+    ON login
+BEGIN
+    UPDATE user SET revision = revision + 1 WHERE login = NEW.user;
+    UPDATE login SET revision = COALESCE((SELECT revision FROM user WHERE login = NEW.user), 0) WHERE id = NEW.id;
+END;
+
+CREATE TRIGGER login_update_revision
+    AFTER UPDATE OF name, login, password, website, notes
+    ON login
+BEGIN
+    UPDATE user SET revision = revision + 1 WHERE login = NEW.user;
+    UPDATE login SET revision = COALESCE((SELECT revision FROM user WHERE login = NEW.user), 0) WHERE id = NEW.id;
+END;
+
+CREATE TRIGGER login_delete_revision
+    AFTER DELETE
+    ON login
+BEGIN
+    UPDATE user SET revision = revision + 1 WHERE login = OLD.user;
+    INSERT INTO tombstone (kind, id, user, revision)
+    VALUES ('login', OLD.id, OLD.user, COALESCE((SELECT revision FROM user WHERE login = OLD.user), 0));
+END;
+
+CREATE TRIGGER note_insert_revision
+    AFTER INSERT
+    ON note
+BEGIN
+    UPDATE user SET revision = revision + 1 WHERE login = NEW.user;
+    UPDATE note SET revision = COALESCE((SELECT revision FROM user WHERE login = NEW.user), 0) WHERE id = NEW.id;
+END;
+
+CREATE TRIGGER note_update_revision
+    AFTER UPDATE OF name, text
+    ON note
+BEGIN
+    UPDATE user SET revision = revision + 1 WHERE login = NEW.user;
+    UPDATE note SET revision = COALESCE((SELECT revision FROM user WHERE login = NEW.user), 0) WHERE id = NEW.id;
+END;
+
+CREATE TRIGGER note_delete_revision
+    AFTER DELETE
+    ON note
+BEGIN
+    UPDATE user SET revision = revision + 1 WHERE login = OLD.user;
+    INSERT INTO tombstone (kind, id, user, revision)
+    VALUES ('note', OLD.id, OLD.user, COALESCE((SELECT revision FROM user WHERE login = OLD.user), 0));
+END;
+
+CREATE TRIGGER binary_insert_revision
+    AFTER INSERT
+    ON binary
+BEGIN
+    UPDATE user SET revision = revision + 1 WHERE login = NEW.user;
+    UPDATE binary SET revision = COALESCE((SELECT revision FROM user WHERE login = NEW.user), 0) WHERE id = NEW.id;
+END;
+
+CREATE TRIGGER binary_update_revision
+    AFTER UPDATE OF name, notes
+    ON binary
+BEGIN
+    UPDATE user SET revision = revision + 1 WHERE login = NEW.user;
+    UPDATE binary SET revision = COALESCE((SELECT revision FROM user WHERE login = NEW.user), 0) WHERE id = NEW.id;
+END;
+
+CREATE TRIGGER binary_delete_revision
+    AFTER DELETE
+    ON binary
+BEGIN
+    UPDATE user SET revision = revision + 1 WHERE login = OLD.user;
+    INSERT INTO tombstone (kind, id, user, revision)
+    VALUES ('binary', OLD.id, OLD.user, COALESCE((SELECT revision FROM user WHERE login = OLD.user), 0));
+END;
+
+CREATE TRIGGER card_insert_revision
+    AFTER INSERT
+    ON card
+BEGIN
+    UPDATE user SET revision = revision + 1 WHERE login = NEW.user;
+    UPDATE card SET revision = COALESCE((SELECT revision FROM user WHERE login = NEW.user), 0) WHERE id = NEW.id;
+END;
+
+CREATE TRIGGER card_update_revision
+    AFTER UPDATE OF name, number, exp_date, cvv, cardholder, notes
+    ON card
+BEGIN
+    UPDATE user SET revision = revision + 1 WHERE login = NEW.user;
+    UPDATE card SET revision = COALESCE((SELECT revision FROM user WHERE login = NEW.user), 0) WHERE id = NEW.id;
+END;
+
+CREATE TRIGGER card_delete_revision
+    AFTER DELETE
+    ON card
+BEGIN
+    UPDATE user SET revision = revision + 1 WHERE login = OLD.user;
+    INSERT INTO tombstone (kind, id, user, revision)
+    VALUES ('card', OLD.id, OLD.user, COALESCE((SELECT revision FROM user WHERE login = OLD.user), 0));
+END;
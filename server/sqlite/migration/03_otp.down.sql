@@ -0,0 +1,5 @@
+DROP TRIGGER otp_insert_revision;
+DROP TRIGGER otp_update_revision;
+DROP TRIGGER otp_delete_revision;
+
+DROP TABLE otp;
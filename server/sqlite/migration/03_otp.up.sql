@@ -0,0 +1,40 @@
+CREATE TABLE otp
+(
+    id        INTEGER PRIMARY KEY AUTOINCREMENT,
+    name      TEXT    NOT NULL,
+    type      TEXT    NOT NULL,
+    secret    TEXT    NOT NULL,
+    algorithm TEXT    NOT NULL,
+    digits    INTEGER NOT NULL,
+    period    INTEGER NOT NULL,
+    counter   INTEGER NOT NULL,
+    issuer    TEXT,
+    user      TEXT    NOT NULL,
+    revision  INTEGER NOT NULL DEFAULT 0,
+    FOREIGN KEY (user) REFERENCES user (login)
+);
+
+CREATE TRIGGER otp_insert_revision
+    AFTER INSERT
+    ON otp
+BEGIN
+    UPDATE user SET revision = revision + 1 WHERE login = NEW.user;
+    UPDATE otp SET revision = COALESCE((SELECT revision FROM user WHERE login = NEW.user), 0) WHERE id = NEW.id;
+END;
+
+CREATE TRIGGER otp_update_revision
+    AFTER UPDATE OF name, type, secret, algorithm, digits, period, counter, issuer
+    ON otp
+BEGIN
+    UPDATE user SET revision = revision + 1 WHERE login = NEW.user;
+    UPDATE otp SET revision = COALESCE((SELECT revision FROM user WHERE login = NEW.user), 0) WHERE id = NEW.id;
+END;
+
+CREATE TRIGGER otp_delete_revision
+    AFTER DELETE
+    ON otp
+BEGIN
+    UPDATE user SET revision = revision + 1 WHERE login = OLD.user;
+    INSERT INTO tombstone (kind, id, user, revision)
+    VALUES ('otp', OLD.id, OLD.user, COALESCE((SELECT revision FROM user WHERE login = OLD.user), 0));
+END;
@@ -0,0 +1,15 @@
+-- Сессии пользователей. Refresh-токен хранится только в виде хэша,
+-- время хранится в секундах Unix.
+
+CREATE TABLE session
+(
+    id                 TEXT PRIMARY KEY,
+    user               TEXT    NOT NULL,
+    refresh_token_hash BLOB    NOT NULL,
+    created_at         INTEGER NOT NULL,
+    last_used_at       INTEGER NOT NULL,
+    expires_at         INTEGER NOT NULL,
+    FOREIGN KEY (user) REFERENCES user (login) ON DELETE CASCADE
+);
+
+CREATE INDEX session_user ON session (user);
@@ -0,0 +1,4 @@
+DROP TABLE recovery_code;
+
+ALTER TABLE user DROP COLUMN mfa_enabled;
+ALTER TABLE user DROP COLUMN mfa_secret;
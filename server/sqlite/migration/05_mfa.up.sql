@@ -0,0 +1,14 @@
+-- Двухфакторная аутентификация. Секрет TOTP хранится у пользователя и
+-- действует только после подтверждения (mfa_enabled). Коды восстановления
+-- хранятся в виде хэшей, каждый код можно использовать один раз.
+
+ALTER TABLE user ADD COLUMN mfa_secret TEXT;
+ALTER TABLE user ADD COLUMN mfa_enabled BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE recovery_code
+(
+    user      TEXT NOT NULL,
+    code_hash BLOB NOT NULL,
+    PRIMARY KEY (user, code_hash),
+    FOREIGN KEY (user) REFERENCES user (login) ON DELETE CASCADE
+);
@@ -0,0 +1,10 @@
+-- Неудачные попытки входа для защиты от перебора паролей. Логин не связан
+-- с таблицей пользователей: блокируются и несуществующие логины.
+-- Время хранится в секундах Unix.
+
+CREATE TABLE login_attempt
+(
+    login        TEXT PRIMARY KEY,
+    failures     INTEGER NOT NULL,
+    locked_until INTEGER NOT NULL DEFAULT 0
+);
@@ -0,0 +1,17 @@
+-- Незавершенные загрузки бинарных данных. Содержимое загрузки хранится
+-- в файле assets/upload/{id}, его размер - сохраненное смещение.
+-- Время хранится в секундах Unix.
+
+CREATE TABLE upload
+(
+    id           TEXT PRIMARY KEY,
+    user         TEXT    NOT NULL,
+    name         TEXT    NOT NULL,
+    filename     TEXT    NOT NULL,
+    size         INTEGER NOT NULL,
+    notes        TEXT,
+    content_size INTEGER NOT NULL,
+    sha256       BLOB    NOT NULL,
+    expires_at   INTEGER NOT NULL,
+    FOREIGN KEY (user) REFERENCES user (login) ON DELETE CASCADE
+);
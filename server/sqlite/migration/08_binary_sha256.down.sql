@@ -0,0 +1 @@
+ALTER TABLE binary DROP COLUMN sha256;
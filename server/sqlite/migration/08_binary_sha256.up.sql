@@ -0,0 +1,5 @@
+-- SHA-256 сохраненного содержимого бинарных данных. Клиент сверяет с ним
+-- скачанный файл. Для файлов, сохраненных до появления колонки, хэш
+-- вычисляется при первом скачивании.
+
+ALTER TABLE binary ADD COLUMN sha256 BLOB;
@@ -0,0 +1,8 @@
+-- Содержимое бинарных данных для хранилища blob = "sqlite". Подходит для
+-- небольших файлов: содержимое целиком читается в память.
+
+CREATE TABLE blob
+(
+    id   TEXT PRIMARY KEY,
+    data BLOB NOT NULL
+);
@@ -0,0 +1,32 @@
+-- Содержимое записей, сохраненных по SHA-256, снова хранится под их id.
+-- Перенести его можно только внутри хранилища blob = "sqlite": если
+-- содержимое хотя бы одной записи хранится вне базы данных, вставка
+-- нарушает ограничение content_check и отмена прерывается.
+
+CREATE TEMP TABLE content_check
+(
+    missing INTEGER CHECK (missing = 0)
+);
+
+INSERT INTO content_check (missing)
+SELECT COUNT(*)
+FROM binary
+WHERE blob IS NOT NULL
+  AND NOT EXISTS (SELECT 1 FROM blob_content WHERE blob_content.id = lower(hex(binary.blob)));
+
+DROP TABLE content_check;
+
+INSERT OR REPLACE INTO blob_content (id, data)
+SELECT CAST(binary.id AS TEXT), blob_content.data
+FROM binary
+         JOIN blob_content ON blob_content.id = lower(hex(binary.blob));
+
+DELETE
+FROM blob_content
+WHERE id IN (SELECT lower(hex(sha256)) FROM blob);
+
+ALTER TABLE binary DROP COLUMN blob;
+
+DROP TABLE blob;
+
+ALTER TABLE blob_content RENAME TO blob;
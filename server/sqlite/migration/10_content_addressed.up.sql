@@ -0,0 +1,16 @@
+-- Содержимое бинарных данных хранится по SHA-256: одинаковое содержимое
+-- нескольких записей хранится один раз. Таблица blob считает ссылки на
+-- содержимое, оно удаляется из хранилища вместе с последней ссылкой.
+-- Записи с пустой колонкой blob сохранены раньше и хранят содержимое
+-- под своим id.
+
+ALTER TABLE blob RENAME TO blob_content;
+
+CREATE TABLE blob
+(
+    sha256 BLOB PRIMARY KEY,
+    size   INTEGER NOT NULL,
+    refs   INTEGER NOT NULL
+);
+
+ALTER TABLE binary ADD COLUMN blob BLOB REFERENCES blob (sha256);
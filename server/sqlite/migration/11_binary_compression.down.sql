@@ -0,0 +1,3 @@
+ALTER TABLE upload DROP COLUMN compression;
+
+ALTER TABLE binary DROP COLUMN compression;
@@ -0,0 +1,7 @@
+-- Клиент может сжимать содержимое перед шифрованием. Способ сжатия
+-- хранится вместе с записью, чтобы другие клиенты могли распаковать
+-- содержимое. 0 - содержимое не сжато.
+
+ALTER TABLE binary ADD COLUMN compression INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE upload ADD COLUMN compression INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,18 @@
+-- Прежние версии записей. Изменение и удаление записи сохраняют ее версию
+-- до изменения: JSON данных, зашифрованный ключом сервера, если шифрование
+-- включено. Время хранится в секундах Unix.
+
+CREATE TABLE history
+(
+    id         INTEGER PRIMARY KEY AUTOINCREMENT,
+    kind       TEXT    NOT NULL,
+    item_id    INTEGER NOT NULL,
+    user       TEXT    NOT NULL,
+    version    INTEGER NOT NULL,
+    removed    BOOLEAN NOT NULL,
+    data       TEXT    NOT NULL,
+    created_at INTEGER NOT NULL,
+    FOREIGN KEY (user) REFERENCES user (login) ON DELETE CASCADE
+);
+
+CREATE INDEX history_user_item ON history (user, kind, item_id);
@@ -0,0 +1,82 @@
+-- Записи из корзины удаляются окончательно. Содержимое их бинарных данных
+-- остается в хранилище, но ссылки на него освобождаются.
+
+UPDATE blob
+SET refs = refs - (SELECT COUNT(*) FROM binary WHERE binary.blob = blob.sha256 AND binary.deleted_at IS NOT NULL);
+
+DELETE FROM login WHERE deleted_at IS NOT NULL;
+DELETE FROM note WHERE deleted_at IS NOT NULL;
+DELETE FROM binary WHERE deleted_at IS NOT NULL;
+DELETE FROM card WHERE deleted_at IS NOT NULL;
+DELETE FROM otp WHERE deleted_at IS NOT NULL;
+
+DROP TRIGGER login_trash_revision;
+DROP TRIGGER login_restore_revision;
+DROP TRIGGER login_delete_revision;
+
+CREATE TRIGGER login_delete_revision
+    AFTER DELETE
+    ON login
+BEGIN
+    UPDATE user SET revision = revision + 1 WHERE login = OLD.user;
+    INSERT INTO tombstone (kind, id, user, revision)
+    VALUES ('login', OLD.id, OLD.user, COALESCE((SELECT revision FROM user WHERE login = OLD.user), 0));
+END;
+
+DROP TRIGGER note_trash_revision;
+DROP TRIGGER note_restore_revision;
+DROP TRIGGER note_delete_revision;
+
+CREATE TRIGGER note_delete_revision
+    AFTER DELETE
+    ON note
+BEGIN
+    UPDATE user SET revision = revision + 1 WHERE login = OLD.user;
+    INSERT INTO tombstone (kind, id, user, revision)
+    VALUES ('note', OLD.id, OLD.user, COALESCE((SELECT revision FROM user WHERE login = OLD.user), 0));
+END;
+
+DROP TRIGGER binary_trash_revision;
+DROP TRIGGER binary_restore_revision;
+DROP TRIGGER binary_delete_revision;
+
+CREATE TRIGGER binary_delete_revision
+    AFTER DELETE
+    ON binary
+BEGIN
+    UPDATE user SET revision = revision + 1 WHERE login = OLD.user;
+    INSERT INTO tombstone (kind, id, user, revision)
+    VALUES ('binary', OLD.id, OLD.user, COALESCE((SELECT revision FROM user WHERE login = OLD.user), 0));
+END;
+
+DROP TRIGGER card_trash_revision;
+DROP TRIGGER card_restore_revision;
+DROP TRIGGER card_delete_revision;
+
+CREATE TRIGGER card_delete_revision
+    AFTER DELETE
+    ON card
+BEGIN
+    UPDATE user SET revision = revision + 1 WHERE login = OLD.user;
+    INSERT INTO tombstone (kind, id, user, revision)
+    VALUES ('card', OLD.id, OLD.user, COALESCE((SELECT revision FROM user WHERE login = OLD.user), 0));
+END;
+
+DROP TRIGGER otp_trash_revision;
+DROP TRIGGER otp_restore_revision;
+DROP TRIGGER otp_delete_revision;
+
+CREATE TRIGGER otp_delete_revision
+    AFTER DELETE
+    ON otp
+BEGIN
+    UPDATE user SET revision = revision + 1 WHERE login = OLD.user;
+    INSERT INTO tombstone (kind, id, user, revision)
+    VALUES ('otp', OLD.id, OLD.user, COALESCE((SELECT revision FROM user WHERE login = OLD.user), 0));
+END;
+
+ALTER TABLE login DROP COLUMN deleted_at;
+ALTER TABLE note DROP COLUMN deleted_at;
+ALTER TABLE binary DROP COLUMN deleted_at;
+ALTER TABLE card DROP COLUMN deleted_at;
+ALTER TABLE otp DROP COLUMN deleted_at;
@@ -0,0 +1,171 @@
+-- Удаленные записи попадают в корзину: deleted_at - время удаления
+-- в секундах Unix, у записей вне корзины колонка пустая. Для клиентов
+-- перемещение в корзину - удаление, оно оставляет отметку. Восстановление
+-- из корзины убирает отметку и дает записи новую ревизию, а окончательное
+-- удаление записи из корзины ревизий не меняет: клиенты ее уже удалили.
+
+ALTER TABLE login ADD COLUMN deleted_at INTEGER;
+ALTER TABLE note ADD COLUMN deleted_at INTEGER;
+ALTER TABLE binary ADD COLUMN deleted_at INTEGER;
+ALTER TABLE card ADD COLUMN deleted_at INTEGER;
+ALTER TABLE otp ADD COLUMN deleted_at INTEGER;
+
+DROP TRIGGER login_delete_revision;
+
+CREATE TRIGGER login_delete_revision
+    AFTER DELETE
+    ON login
+    WHEN OLD.deleted_at IS NULL
+BEGIN
+    UPDATE user SET revision = revision + 1 WHERE login = OLD.user;
+    INSERT INTO tombstone (kind, id, user, revision)
+    VALUES ('login', OLD.id, OLD.user, COALESCE((SELECT revision FROM user WHERE login = OLD.user), 0));
+END;
+
+CREATE TRIGGER login_trash_revision
+    AFTER UPDATE OF deleted_at
+    ON login
+    WHEN OLD.deleted_at IS NULL AND NEW.deleted_at IS NOT NULL
+BEGIN
+    UPDATE user SET revision = revision + 1 WHERE login = NEW.user;
+    INSERT OR REPLACE INTO tombstone (kind, id, user, revision)
+    VALUES ('login', NEW.id, NEW.user, COALESCE((SELECT revision FROM user WHERE login = NEW.user), 0));
+END;
+
+CREATE TRIGGER login_restore_revision
+    AFTER UPDATE OF deleted_at
+    ON login
+    WHEN OLD.deleted_at IS NOT NULL AND NEW.deleted_at IS NULL
+BEGIN
+    UPDATE user SET revision = revision + 1 WHERE login = NEW.user;
+    UPDATE login SET revision = COALESCE((SELECT revision FROM user WHERE login = NEW.user), 0) WHERE id = NEW.id;
+    DELETE FROM tombstone WHERE kind = 'login' AND id = NEW.id;
+END;
+
+DROP TRIGGER note_delete_revision;
+
+CREATE TRIGGER note_delete_revision
+    AFTER DELETE
+    ON note
+    WHEN OLD.deleted_at IS NULL
+BEGIN
+    UPDATE user SET revision = revision + 1 WHERE login = OLD.user;
+    INSERT INTO tombstone (kind, id, user, revision)
+    VALUES ('note', OLD.id, OLD.user, COALESCE((SELECT revision FROM user WHERE login = OLD.user), 0));
+END;
+
+CREATE TRIGGER note_trash_revision
+    AFTER UPDATE OF deleted_at
+    ON note
+    WHEN OLD.deleted_at IS NULL AND NEW.deleted_at IS NOT NULL
+BEGIN
+    UPDATE user SET revision = revision + 1 WHERE login = NEW.user;
+    INSERT OR REPLACE INTO tombstone (kind, id, user, revision)
+    VALUES ('note', NEW.id, NEW.user, COALESCE((SELECT revision FROM user WHERE login = NEW.user), 0));
+END;
+
+CREATE TRIGGER note_restore_revision
+    AFTER UPDATE OF deleted_at
+    ON note
+    WHEN OLD.deleted_at IS NOT NULL AND NEW.deleted_at IS NULL
+BEGIN
+    UPDATE user SET revision = revision + 1 WHERE login = NEW.user;
+    UPDATE note SET revision = COALESCE((SELECT revision FROM user WHERE login = NEW.user), 0) WHERE id = NEW.id;
+    DELETE FROM tombstone WHERE kind = 'note' AND id = NEW.id;
+END;
+
+DROP TRIGGER binary_delete_revision;
+
+CREATE TRIGGER binary_delete_revision
+    AFTER DELETE
+    ON binary
+    WHEN OLD.deleted_at IS NULL
+BEGIN
+    UPDATE user SET revision = revision + 1 WHERE login = OLD.user;
+    INSERT INTO tombstone (kind, id, user, revision)
+    VALUES ('binary', OLD.id, OLD.user, COALESCE((SELECT revision FROM user WHERE login = OLD.user), 0));
+END;
+
+CREATE TRIGGER binary_trash_revision
+    AFTER UPDATE OF deleted_at
+    ON binary
+    WHEN OLD.deleted_at IS NULL AND NEW.deleted_at IS NOT NULL
+BEGIN
+    UPDATE user SET revision = revision + 1 WHERE login = NEW.user;
+    INSERT OR REPLACE INTO tombstone (kind, id, user, revision)
+    VALUES ('binary', NEW.id, NEW.user, COALESCE((SELECT revision FROM user WHERE login = NEW.user), 0));
+END;
+
+CREATE TRIGGER binary_restore_revision
+    AFTER UPDATE OF deleted_at
+    ON binary
+    WHEN OLD.deleted_at IS NOT NULL AND NEW.deleted_at IS NULL
+BEGIN
+    UPDATE user SET revision = revision + 1 WHERE login = NEW.user;
+    UPDATE binary SET revision = COALESCE((SELECT revision FROM user WHERE login = NEW.user), 0) WHERE id = NEW.id;
+    DELETE FROM tombstone WHERE kind = 'binary' AND id = NEW.id;
+END;
+
+DROP TRIGGER card_delete_revision;
+
+CREATE TRIGGER card_delete_revision
+    AFTER DELETE
+    ON card
+    WHEN OLD.deleted_at IS NULL
+BEGIN
+    UPDATE user SET revision = revision + 1 WHERE login = OLD.user;
+    INSERT INTO tombstone (kind, id, user, revision)
+    VALUES ('card', OLD.id, OLD.user, COALESCE((SELECT revision FROM user WHERE login = OLD.user), 0));
+END;
+
+CREATE TRIGGER card_trash_revision
+    AFTER UPDATE OF deleted_at
+    ON card
+    WHEN OLD.deleted_at IS NULL AND NEW.deleted_at IS NOT NULL
+BEGIN
+    UPDATE user SET revision = revision + 1 WHERE login = NEW.user;
+    INSERT OR REPLACE INTO tombstone (kind, id, user, revision)
+    VALUES ('card', NEW.id, NEW.user, COALESCE((SELECT revision FROM user WHERE login = NEW.user), 0));
+END;
+
+CREATE TRIGGER card_restore_revision
+    AFTER UPDATE OF deleted_at
+    ON card
+    WHEN OLD.deleted_at IS NOT NULL AND NEW.deleted_at IS NULL
+BEGIN
+    UPDATE user SET revision = revision + 1 WHERE login = NEW.user;
+    UPDATE card SET revision = COALESCE((SELECT revision FROM user WHERE login = NEW.user), 0) WHERE id = NEW.id;
+    DELETE FROM tombstone WHERE kind = 'card' AND id = NEW.id;
+END;
+
+DROP TRIGGER otp_delete_revision;
+
+CREATE TRIGGER otp_delete_revision
+    AFTER DELETE
+    ON otp
+    WHEN OLD.deleted_at IS NULL
+BEGIN
+    UPDATE user SET revision = revision + 1 WHERE login = OLD.user;
+    INSERT INTO tombstone (kind, id, user, revision)
+    VALUES ('otp', OLD.id, OLD.user, COALESCE((SELECT revision FROM user WHERE login = OLD.user), 0));
+END;
+
+CREATE TRIGGER otp_trash_revision
+    AFTER UPDATE OF deleted_at
+    ON otp
+    WHEN OLD.deleted_at IS NULL AND NEW.deleted_at IS NOT NULL
+BEGIN
+    UPDATE user SET revision = revision + 1 WHERE login = NEW.user;
+    INSERT OR REPLACE INTO tombstone (kind, id, user, revision)
+    VALUES ('otp', NEW.id, NEW.user, COALESCE((SELECT revision FROM user WHERE login = NEW.user), 0));
+END;
+
+CREATE TRIGGER otp_restore_revision
+    AFTER UPDATE OF deleted_at
+    ON otp
+    WHEN OLD.deleted_at IS NOT NULL AND NEW.deleted_at IS NULL
+BEGIN
+    UPDATE user SET revision = revision + 1 WHERE login = NEW.user;
+    UPDATE otp SET revision = COALESCE((SELECT revision FROM user WHERE login = NEW.user), 0) WHERE id = NEW.id;
+    DELETE FROM tombstone WHERE kind = 'otp' AND id = NEW.id;
+END;
@@ -0,0 +1,7 @@
+UPDATE user
+SET mfa_secret = mfa_pending_secret
+WHERE NOT mfa_enabled
+  AND mfa_pending_secret IS NOT NULL;
+
+ALTER TABLE user DROP COLUMN mfa_last_step;
+ALTER TABLE user DROP COLUMN mfa_pending_secret;
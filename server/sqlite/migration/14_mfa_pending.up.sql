@@ -0,0 +1,13 @@
+-- Новый секрет TOTP хранится отдельно до подтверждения: повторная настройка
+-- не должна отключать действующий второй фактор. mfa_last_step - номер
+-- периода последнего принятого кода TOTP, коды этого и более ранних периодов
+-- повторно не принимаются.
+
+ALTER TABLE user ADD COLUMN mfa_pending_secret TEXT;
+ALTER TABLE user ADD COLUMN mfa_last_step INTEGER NOT NULL DEFAULT 0;
+
+UPDATE user
+SET mfa_pending_secret = mfa_secret,
+    mfa_secret         = NULL
+WHERE NOT mfa_enabled
+  AND mfa_secret IS NOT NULL;
@@ -0,0 +1,3 @@
+DROP INDEX login_attempt_failed_at;
+
+ALTER TABLE login_attempt DROP COLUMN failed_at;
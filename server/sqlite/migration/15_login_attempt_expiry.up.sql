@@ -0,0 +1,11 @@
+-- failed_at - время последней неудачной попытки в секундах Unix. Попытки
+-- удаляются, когда после нее и после блокировки проходит failure_ttl: иначе
+-- строки перебираемых несуществующих логинов копились бы без ограничений.
+-- Существующие попытки считаются сделанными во время миграции.
+
+ALTER TABLE login_attempt ADD COLUMN failed_at INTEGER NOT NULL DEFAULT 0;
+
+UPDATE login_attempt
+SET failed_at = unixepoch();
+
+CREATE INDEX login_attempt_failed_at ON login_attempt (failed_at);
@@ -0,0 +1,3 @@
+DROP INDEX binary_blob;
+
+ALTER TABLE upload DROP COLUMN content_key;
@@ -0,0 +1,7 @@
+-- content_key - ключ дедупликации исходного файла от клиента. Содержимое
+-- записей, загруженных с ним, хранится под идентификатором из этого ключа,
+-- поэтому binary.blob может не совпадать с binary.sha256.
+
+ALTER TABLE upload ADD COLUMN content_key BLOB;
+
+CREATE INDEX binary_blob ON binary (blob);
@@ -5,6 +5,7 @@ import (
 	"github.com/mkolibaba/gophkeeper/server/sqlite/converter"
 	convertergen "github.com/mkolibaba/gophkeeper/server/sqlite/converter/gen"
 	sqlc "github.com/mkolibaba/gophkeeper/server/sqlite/sqlc/gen"
+	"github.com/mkolibaba/gophkeeper/server/sqlstore"
 	"go.uber.org/fx"
 )
 
@@ -15,10 +16,19 @@ var Module = fx.Module(
 		NewQueries,
 		NewDataConverter,
 		fx.Annotate(NewUserService, fx.As(new(server.UserService))),
+		fx.Annotate(NewSessionService, fx.As(new(server.SessionService))),
+		fx.Annotate(NewLoginAttemptService, fx.As(new(server.LoginAttemptService))),
 		fx.Annotate(NewLoginService, fx.As(new(server.LoginService))),
 		fx.Annotate(NewNoteService, fx.As(new(server.NoteService))),
-		fx.Annotate(NewBinaryService, fx.As(new(server.BinaryService))),
+		fx.Annotate(NewBinaryService, fx.As(fx.Self()), fx.As(new(server.BinaryService))),
 		fx.Annotate(NewCardService, fx.As(new(server.CardService))),
+		fx.Annotate(NewOTPService, fx.As(new(server.OTPService))),
+		fx.Annotate(NewSyncService, fx.As(new(server.SyncService))),
+		fx.Annotate(NewHistoryService, fx.As(new(server.HistoryService))),
+		fx.Annotate(NewTrashService, fx.As(new(server.TrashService))),
+		fx.Annotate(NewUploadService, fx.As(new(server.UploadService))),
+		fx.Annotate(NewUsageService, fx.As(new(server.UsageService))),
+		NewKeyRotator,
 	),
 	fx.Invoke(
 		OpenDB,
@@ -29,6 +39,52 @@ func NewQueries(db *DB) *sqlc.Queries {
 	return sqlc.New(db.db)
 }
 
+func NewUserService(db *DB, queries *sqlc.Queries, blobs server.BlobStore) *sqlstore.UserService {
+	return sqlstore.NewUserService(NewStore(db, queries), blobs, db.uploadsFolder)
+}
+
+func NewBinaryService(
+	queries *sqlc.Queries,
+	db *DB,
+	blobs server.BlobStore,
+	converter converter.DataConverter,
+) *sqlstore.BinaryService {
+	return sqlstore.NewBinaryService(NewStore(db, queries), blobs, db.quota, db.history)
+}
+
+// NewHistoryService создает сервис истории. Версии восстанавливаются через
+// сервисы данных.
+func NewHistoryService(
+	db *DB,
+	queries *sqlc.Queries,
+	logins server.LoginService,
+	notes server.NoteService,
+	binaries server.BinaryService,
+	cards server.CardService,
+	otps server.OTPService,
+) *sqlstore.HistoryService {
+	return sqlstore.NewHistoryService(NewStore(db, queries), db.history, logins, notes, binaries, cards, otps)
+}
+
+// NewTrashService создает сервис корзины. Содержимое бинарных данных
+// освобождает сервис бинарных данных.
+func NewTrashService(
+	db *DB,
+	queries *sqlc.Queries,
+	binaries *sqlstore.BinaryService,
+	converter converter.DataConverter,
+) *sqlstore.TrashService {
+	return sqlstore.NewTrashService(NewStore(db, queries), binaries)
+}
+
+func NewUploadService(queries *sqlc.Queries, db *DB, binaries *sqlstore.BinaryService) *sqlstore.UploadService {
+	return sqlstore.NewUploadService(NewStore(db, queries), binaries, db.uploadsFolder, db.quota)
+}
+
+func NewUsageService(db *DB, queries *sqlc.Queries) *sqlstore.UsageService {
+	return sqlstore.NewUsageService(NewStore(db, queries), db.quota)
+}
+
 func NewDataConverter() converter.DataConverter {
 	return &convertergen.DataConverterImpl{}
 }
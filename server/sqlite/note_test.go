@@ -17,7 +17,7 @@ func TestNoteCreate(t *testing.T) {
 		db.db.Exec("DELETE FROM user")
 	})
 
-	srv := NewNoteService(queries, NewDataConverter())
+	srv := NewNoteService(db, queries, NewDataConverter())
 
 	t.Run("success", func(t *testing.T) {
 		ctx := server.NewContextWithUser(t.Context(), "alice")
@@ -56,7 +56,7 @@ func TestNoteGetAll(t *testing.T) {
 		db.db.Exec("DELETE FROM user")
 	})
 
-	srv := NewNoteService(queries, NewDataConverter())
+	srv := NewNoteService(db, queries, NewDataConverter())
 
 	t.Run("success", func(t *testing.T) {
 		ctx := server.NewContextWithUser(t.Context(), "alice")
@@ -89,12 +89,13 @@ func TestNoteUpdate(t *testing.T) {
 		db.db.Exec("DELETE FROM user")
 	})
 
-	srv := NewNoteService(queries, NewDataConverter())
+	srv := NewNoteService(db, queries, NewDataConverter())
 
 	t.Run("success", func(t *testing.T) {
 		ctx := server.NewContextWithUser(t.Context(), "alice")
 		text := "brand new text"
-		err := srv.Update(ctx, note1ID, server.NoteDataUpdate{
+		version := mustSelectRevision(t, queries.SelectNoteRevision, note1ID)
+		err := srv.Update(ctx, note1ID, version, server.NoteDataUpdate{
 			Text: &text,
 		})
 		require.NoError(t, err)
@@ -107,7 +108,7 @@ func TestNoteUpdate(t *testing.T) {
 	t.Run("not_found", func(t *testing.T) {
 		ctx := server.NewContextWithUser(t.Context(), "alice")
 		text := "brand new text"
-		err := srv.Update(ctx, -100, server.NoteDataUpdate{
+		err := srv.Update(ctx, -100, 1, server.NoteDataUpdate{
 			Text: &text,
 		})
 		require.ErrorIs(t, err, server.ErrDataNotFound)
@@ -115,7 +116,8 @@ func TestNoteUpdate(t *testing.T) {
 	t.Run("user_not_owner", func(t *testing.T) {
 		ctx := server.NewContextWithUser(t.Context(), "bob")
 		text := "brand new text"
-		err := srv.Update(ctx, note1ID, server.NoteDataUpdate{
+		version := mustSelectRevision(t, queries.SelectNoteRevision, note1ID)
+		err := srv.Update(ctx, note1ID, version, server.NoteDataUpdate{
 			Text: &text,
 		})
 		require.ErrorIs(t, err, server.ErrDataNotFound)
@@ -133,23 +135,25 @@ func TestNoteDelete(t *testing.T) {
 		db.db.Exec("DELETE FROM user")
 	})
 
-	srv := NewNoteService(queries, NewDataConverter())
+	srv := NewNoteService(db, queries, NewDataConverter())
 
 	t.Run("success", func(t *testing.T) {
 		note3ID := mustCreateNote(t, "note3", "some text", "alice")
 
 		ctx := server.NewContextWithUser(t.Context(), "alice")
-		err := srv.Remove(ctx, note3ID)
+		version := mustSelectRevision(t, queries.SelectNoteRevision, note3ID)
+		err := srv.Remove(ctx, note3ID, version)
 		require.NoError(t, err)
 	})
 	t.Run("not_found", func(t *testing.T) {
 		ctx := server.NewContextWithUser(t.Context(), "alice")
-		err := srv.Remove(ctx, -100)
+		err := srv.Remove(ctx, -100, 1)
 		require.ErrorIs(t, err, server.ErrDataNotFound)
 	})
 	t.Run("user_not_owner", func(t *testing.T) {
 		ctx := server.NewContextWithUser(t.Context(), "bob")
-		err := srv.Remove(ctx, note1ID)
+		version := mustSelectRevision(t, queries.SelectNoteRevision, note1ID)
+		err := srv.Remove(ctx, note1ID, version)
 		require.ErrorIs(t, err, server.ErrDataNotFound)
 	})
 }
@@ -0,0 +1,168 @@
+package sqlite
+
+import (
+	"github.com/mkolibaba/gophkeeper/server"
+	sqlc "github.com/mkolibaba/gophkeeper/server/sqlite/sqlc/gen"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestOTPCreate(t *testing.T) {
+	mustCreateUser(t, "alice", "123")
+
+	t.Cleanup(func() {
+		db.db.Exec("DELETE FROM otp")
+		db.db.Exec("DELETE FROM user")
+	})
+
+	srv := NewOTPService(db, queries, NewDataConverter())
+
+	t.Run("success", func(t *testing.T) {
+		ctx := server.NewContextWithUser(t.Context(), "alice")
+		err := srv.Create(ctx, server.OTPData{
+			Name:      "github",
+			Type:      "totp",
+			Secret:    "JBSWY3DPEHPK3PXP",
+			Algorithm: "SHA1",
+			Digits:    6,
+			Period:    30,
+			Issuer:    "GitHub",
+		})
+		require.NoError(t, err)
+
+		otps, err := srv.GetAll(ctx)
+		require.NoError(t, err)
+		require.Len(t, otps, 1)
+		require.Equal(t, "GitHub", otps[0].Issuer)
+		require.Equal(t, int64(30), otps[0].Period)
+		require.Positive(t, otps[0].Version)
+	})
+	t.Run("user_not_found", func(t *testing.T) {
+		ctx := server.NewContextWithUser(t.Context(), "charlie")
+		err := srv.Create(ctx, server.OTPData{
+			Name:   "github",
+			Type:   "totp",
+			Secret: "JBSWY3DPEHPK3PXP",
+		})
+		require.ErrorIs(t, err, server.ErrUserNotFound)
+	})
+}
+
+func TestOTPGetAll(t *testing.T) {
+	mustCreateUser(t, "alice", "123")
+	mustCreateUser(t, "bob", "123")
+	mustCreateOTP(t, "otp1", "alice")
+	mustCreateOTP(t, "otp2", "alice")
+
+	t.Cleanup(func() {
+		db.db.Exec("DELETE FROM otp")
+		db.db.Exec("DELETE FROM user")
+	})
+
+	srv := NewOTPService(db, queries, NewDataConverter())
+
+	t.Run("success", func(t *testing.T) {
+		ctx := server.NewContextWithUser(t.Context(), "alice")
+		otps, err := srv.GetAll(ctx)
+		require.NoError(t, err)
+		require.Len(t, otps, 2)
+	})
+	t.Run("no_rows", func(t *testing.T) {
+		ctx := server.NewContextWithUser(t.Context(), "bob")
+		otps, err := srv.GetAll(ctx)
+		require.NoError(t, err)
+		require.Len(t, otps, 0)
+	})
+}
+
+func TestOTPUpdate(t *testing.T) {
+	mustCreateUser(t, "alice", "123")
+	mustCreateUser(t, "bob", "123")
+	otp1ID := mustCreateOTP(t, "otp1", "alice")
+
+	t.Cleanup(func() {
+		db.db.Exec("DELETE FROM otp")
+		db.db.Exec("DELETE FROM user")
+	})
+
+	srv := NewOTPService(db, queries, NewDataConverter())
+
+	t.Run("success", func(t *testing.T) {
+		ctx := server.NewContextWithUser(t.Context(), "alice")
+		var counter int64 = 5
+		version := mustSelectRevision(t, queries.SelectOTPRevision, otp1ID)
+		err := srv.Update(ctx, otp1ID, version, server.OTPDataUpdate{
+			Counter: &counter,
+		})
+		require.NoError(t, err)
+
+		updatedOTP, err := queries.SelectOTP(ctx, otp1ID, "alice")
+		require.NoError(t, err)
+		require.Equal(t, counter, updatedOTP.Counter)
+		require.Equal(t, "otp1", updatedOTP.Name)
+	})
+	t.Run("version_conflict", func(t *testing.T) {
+		ctx := server.NewContextWithUser(t.Context(), "alice")
+		name := "otp1 updated"
+		err := srv.Update(ctx, otp1ID, 1, server.OTPDataUpdate{
+			Name: &name,
+		})
+		var conflict *server.VersionConflictError
+		require.ErrorAs(t, err, &conflict)
+		require.Equal(t, mustSelectRevision(t, queries.SelectOTPRevision, otp1ID), conflict.Version)
+	})
+	t.Run("user_not_owner", func(t *testing.T) {
+		ctx := server.NewContextWithUser(t.Context(), "bob")
+		name := "otp1 updated"
+		version := mustSelectRevision(t, queries.SelectOTPRevision, otp1ID)
+		err := srv.Update(ctx, otp1ID, version, server.OTPDataUpdate{
+			Name: &name,
+		})
+		require.ErrorIs(t, err, server.ErrDataNotFound)
+	})
+}
+
+func TestOTPDelete(t *testing.T) {
+	mustCreateUser(t, "alice", "123")
+	mustCreateUser(t, "bob", "123")
+	otp1ID := mustCreateOTP(t, "otp1", "alice")
+
+	t.Cleanup(func() {
+		db.db.Exec("DELETE FROM otp")
+		db.db.Exec("DELETE FROM user")
+	})
+
+	srv := NewOTPService(db, queries, NewDataConverter())
+
+	t.Run("user_not_owner", func(t *testing.T) {
+		ctx := server.NewContextWithUser(t.Context(), "bob")
+		version := mustSelectRevision(t, queries.SelectOTPRevision, otp1ID)
+		err := srv.Remove(ctx, otp1ID, version)
+		require.ErrorIs(t, err, server.ErrDataNotFound)
+	})
+	t.Run("success", func(t *testing.T) {
+		ctx := server.NewContextWithUser(t.Context(), "alice")
+		version := mustSelectRevision(t, queries.SelectOTPRevision, otp1ID)
+		err := srv.Remove(ctx, otp1ID, version)
+		require.NoError(t, err)
+	})
+	t.Run("not_found", func(t *testing.T) {
+		ctx := server.NewContextWithUser(t.Context(), "alice")
+		err := srv.Remove(ctx, otp1ID, 1)
+		require.ErrorIs(t, err, server.ErrDataNotFound)
+	})
+}
+
+func mustCreateOTP(t *testing.T, name string, user string) int64 {
+	id, err := queries.InsertOTP(t.Context(), sqlc.InsertOTPParams{
+		Name:      name,
+		Type:      "totp",
+		Secret:    "JBSWY3DPEHPK3PXP",
+		Algorithm: "SHA1",
+		Digits:    6,
+		Period:    30,
+		User:      user,
+	})
+	require.NoError(t, err)
+	return id
+}
@@ -0,0 +1,302 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/mkolibaba/gophkeeper/server"
+	"github.com/mkolibaba/gophkeeper/server/sealed"
+	sqlc "github.com/mkolibaba/gophkeeper/server/sqlite/sqlc/gen"
+	"github.com/mkolibaba/gophkeeper/server/sqlstore"
+	"io"
+	"os"
+)
+
+// KeyRotator перешифровывает данные сервера текущим мастер-ключом:
+// секретные поля записей и содержимое файлов. Данные, сохраненные до
+// включения шифрования, тоже шифруются.
+type KeyRotator struct {
+	db    *DB
+	qs    *sqlc.Queries
+	blobs server.BlobStore
+}
+
+func NewKeyRotator(db *DB, queries *sqlc.Queries, blobs server.BlobStore) *KeyRotator {
+	return &KeyRotator{
+		db:    db,
+		qs:    queries,
+		blobs: blobs,
+	}
+}
+
+// Rotate перешифровывает все данные. После него прежние ключи можно
+// убрать из конфигурации.
+func (r *KeyRotator) Rotate(ctx context.Context) error {
+	if r.db.keyring == nil {
+		return errors.New("rotate: encryption key is not set")
+	}
+
+	if err := r.rotateFields(ctx); err != nil {
+		return fmt.Errorf("rotate: %w", err)
+	}
+	if err := r.rotateBlobs(ctx); err != nil {
+		return fmt.Errorf("rotate: %w", err)
+	}
+	return nil
+}
+
+// rotateFields перешифровывает секретные поля в одной транзакции.
+// Изменение полей увеличивает ревизии записей и пользователей, хотя данные
+// остаются прежними, поэтому ревизии восстанавливаются: иначе клиенты
+// получили бы ложные изменения и конфликты версий.
+func (r *KeyRotator) rotateFields(ctx context.Context) error {
+	tx, err := r.db.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	qs := r.qs.WithTx(tx)
+	sealer := sealed.NewSealer(r.db.keyring)
+
+	users, err := qs.SelectAllUsers(ctx)
+	if err != nil {
+		return err
+	}
+
+	logins, err := qs.SelectAllLogins(ctx)
+	if err != nil {
+		return err
+	}
+	err = resealRows(sealer, logins, loginFields, func(l sqlc.Login) error {
+		err := qs.UpdateLoginSealed(ctx, sqlc.UpdateLoginSealedParams{
+			Name:     l.Name,
+			Login:    l.Login,
+			Password: l.Password,
+			Website:  l.Website,
+			Notes:    l.Notes,
+			ID:       l.ID,
+		})
+		if err != nil {
+			return err
+		}
+		return qs.UpdateLoginRevision(ctx, l.Revision, l.ID)
+	})
+	if err != nil {
+		return fmt.Errorf("logins: %w", err)
+	}
+
+	notes, err := qs.SelectAllNotes(ctx)
+	if err != nil {
+		return err
+	}
+	err = resealRows(sealer, notes, noteFields, func(n sqlc.Note) error {
+		err := qs.UpdateNoteSealed(ctx, sqlc.UpdateNoteSealedParams{
+			Name: n.Name,
+			Text: n.Text,
+			ID:   n.ID,
+		})
+		if err != nil {
+			return err
+		}
+		return qs.UpdateNoteRevision(ctx, n.Revision, n.ID)
+	})
+	if err != nil {
+		return fmt.Errorf("notes: %w", err)
+	}
+
+	binaries, err := qs.SelectAllBinaries(ctx)
+	if err != nil {
+		return err
+	}
+	err = resealRows(sealer, binaries, binaryFields, func(b sqlc.Binary) error {
+		err := qs.UpdateBinarySealed(ctx, sqlc.UpdateBinarySealedParams{
+			Name:     b.Name,
+			Filename: b.Filename,
+			Notes:    b.Notes,
+			ID:       b.ID,
+		})
+		if err != nil {
+			return err
+		}
+		return qs.UpdateBinaryRevision(ctx, b.Revision, b.ID)
+	})
+	if err != nil {
+		return fmt.Errorf("binaries: %w", err)
+	}
+
+	cards, err := qs.SelectAllCards(ctx)
+	if err != nil {
+		return err
+	}
+	err = resealRows(sealer, cards, cardFields, func(c sqlc.Card) error {
+		err := qs.UpdateCardSealed(ctx, sqlc.UpdateCardSealedParams{
+			Name:       c.Name,
+			Number:     c.Number,
+			ExpDate:    c.ExpDate,
+			Cvv:        c.Cvv,
+			Cardholder: c.Cardholder,
+			Notes:      c.Notes,
+			ID:         c.ID,
+		})
+		if err != nil {
+			return err
+		}
+		return qs.UpdateCardRevision(ctx, c.Revision, c.ID)
+	})
+	if err != nil {
+		return fmt.Errorf("cards: %w", err)
+	}
+
+	otps, err := qs.SelectAllOTPs(ctx)
+	if err != nil {
+		return err
+	}
+	err = resealRows(sealer, otps, otpFields, func(o sqlc.OTP) error {
+		err := qs.UpdateOTPSealed(ctx, sqlc.UpdateOTPSealedParams{
+			Name:   o.Name,
+			Secret: o.Secret,
+			Issuer: o.Issuer,
+			ID:     o.ID,
+		})
+		if err != nil {
+			return err
+		}
+		return qs.UpdateOTPRevision(ctx, o.Revision, o.ID)
+	})
+	if err != nil {
+		return fmt.Errorf("otps: %w", err)
+	}
+
+	uploads, err := qs.SelectAllUploads(ctx)
+	if err != nil {
+		return err
+	}
+	err = resealRows(sealer, uploads, uploadFields, func(u sqlc.Upload) error {
+		return qs.UpdateUploadSealed(ctx, sqlc.UpdateUploadSealedParams{
+			Name:     u.Name,
+			Filename: u.Filename,
+			Notes:    u.Notes,
+			ID:       u.ID,
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("uploads: %w", err)
+	}
+
+	history, err := qs.SelectAllHistory(ctx)
+	if err != nil {
+		return err
+	}
+	err = resealRows(sealer, history, historyFields, func(h sqlc.History) error {
+		return qs.UpdateHistorySealed(ctx, h.Data, h.ID)
+	})
+	if err != nil {
+		return fmt.Errorf("history: %w", err)
+	}
+
+	// Пользователи обновляются последними, чтобы восстановить ревизии,
+	// увеличенные изменением записей.
+	err = resealRows(sealer, users, userFields, func(u sqlc.User) error {
+		err := qs.UpdateUserSealed(ctx, sqlc.UpdateUserSealedParams{
+			MfaSecret:        u.MfaSecret,
+			MfaPendingSecret: u.MfaPendingSecret,
+			Login:            u.Login,
+		})
+		if err != nil {
+			return err
+		}
+		return qs.UpdateUserRevision(ctx, u.Revision, u.Login)
+	})
+	if err != nil {
+		return fmt.Errorf("users: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// rotateBlobs перешифровывает содержимое файлов в хранилище.
+func (r *KeyRotator) rotateBlobs(ctx context.Context) error {
+	keys, err := blobKeys(ctx, r.qs)
+	if err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		if err := r.rotateBlob(ctx, key); err != nil {
+			return fmt.Errorf("blob %q: %w", key, err)
+		}
+	}
+	r.db.logger.Info("blobs re-encrypted", "count", len(keys))
+	return nil
+}
+
+// rotateBlob перешифровывает содержимое по ключу key. Содержимое
+// читается во временный файл, потому что хранилище может не позволять
+// перезаписывать содержимое во время чтения.
+func (r *KeyRotator) rotateBlob(ctx context.Context, key string) error {
+	unlock := r.db.contentLocks.lock(key)
+	defer unlock()
+
+	content, err := r.blobs.Get(ctx, key)
+	if errors.Is(err, server.ErrBlobNotFound) {
+		r.db.logger.Warn("blob not found, skipping", "key", key)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer content.Close()
+
+	spool, err := os.CreateTemp("", "gophkeeper-rotate-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(spool.Name())
+	defer spool.Close()
+
+	if _, err := io.Copy(spool, content); err != nil {
+		return err
+	}
+	if _, err := spool.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	return r.blobs.Put(ctx, key, spool)
+}
+
+// blobKeys возвращает ключи всего содержимого в хранилище: общего
+// содержимого и бинарных данных, сохраненных до дедупликации.
+func blobKeys(ctx context.Context, qs *sqlc.Queries) ([]string, error) {
+	digests, err := qs.SelectBlobKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ids, err := qs.SelectLegacyBinaryIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(digests)+len(ids))
+	for _, digest := range digests {
+		keys = append(keys, sqlstore.ContentKey(digest))
+	}
+	for _, id := range ids {
+		keys = append(keys, sqlstore.BinaryKey(id))
+	}
+	return keys, nil
+}
+
+// resealRows перешифровывает поля строк rows и сохраняет их через update.
+func resealRows[S any](sealer *sealed.Sealer, rows []S, fields func(*S) sealed.Fields, update func(S) error) error {
+	for i := range rows {
+		sealer.Open(fields(&rows[i]))
+		sealer.Seal(fields(&rows[i]))
+		if err := sealer.Err(); err != nil {
+			return err
+		}
+		if err := update(rows[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
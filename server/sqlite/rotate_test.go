@@ -0,0 +1,111 @@
+package sqlite
+
+import (
+	"bytes"
+	"github.com/mkolibaba/gophkeeper/server"
+	"github.com/mkolibaba/gophkeeper/server/aesgcm"
+	"github.com/mkolibaba/gophkeeper/server/sealed"
+	"github.com/stretchr/testify/require"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestKeyRotation(t *testing.T) {
+	mustCreateUser(t, "alice", "123")
+	t.Cleanup(func() {
+		db.keyring = nil
+		db.db.Exec("DELETE FROM history")
+		db.db.Exec("DELETE FROM login")
+		db.db.Exec("DELETE FROM binary")
+		db.db.Exec("DELETE FROM blob")
+		db.db.Exec("DELETE FROM user")
+	})
+
+	oldKey := bytes.Repeat([]byte{1}, aesgcm.KeySize)
+	newKey := bytes.Repeat([]byte{2}, aesgcm.KeySize)
+	ctx := server.NewContextWithUser(t.Context(), "alice")
+
+	// Часть данных сохранена до включения шифрования.
+	plainID := mustCreateLogin(t, "plain", "login1", "123", "alice")
+	legacyID := mustCreateBinary(t, "legacy", "legacy.txt", strings.NewReader("legacy content"), "alice")
+
+	oldKeyring, err := aesgcm.New(oldKey)
+	require.NoError(t, err)
+	db.keyring = oldKeyring
+	logins := NewLoginService(db, queries, NewDataConverter())
+	binaries := NewBinaryService(queries, db, aesgcm.NewBlobStore(blobs, oldKeyring), NewDataConverter())
+
+	require.NoError(t, logins.Create(ctx, server.LoginData{Name: "sealed", Login: "login2", Password: "secret"}))
+	err = binaries.Create(ctx, server.ReadableBinaryData{
+		BinaryData: server.BinaryData{Name: "file", Filename: "file.txt"},
+		DataReader: io.NopCloser(strings.NewReader("file content")),
+	})
+	require.NoError(t, err)
+
+	// Прежняя версия записи попадает в историю.
+	password := "456"
+	revision := mustSelectRevision(t, queries.SelectLoginRevision, plainID)
+	require.NoError(t, logins.Update(ctx, plainID, revision, server.LoginDataUpdate{Password: &password}))
+
+	userRevision, err := queries.SelectUserRevision(ctx, "alice")
+	require.NoError(t, err)
+	loginRevision := mustSelectRevision(t, queries.SelectLoginRevision, plainID)
+
+	// Ротация: новый ключ становится текущим, старый остается прежним.
+	rotatingKeyring, err := aesgcm.New(newKey, oldKey)
+	require.NoError(t, err)
+	db.keyring = rotatingKeyring
+	rotator := NewKeyRotator(db, queries, aesgcm.NewBlobStore(blobs, rotatingKeyring))
+	require.NoError(t, rotator.Rotate(t.Context()))
+
+	// Ревизии не изменились.
+	revision, err = queries.SelectUserRevision(ctx, "alice")
+	require.NoError(t, err)
+	require.Equal(t, userRevision, revision)
+	require.Equal(t, loginRevision, mustSelectRevision(t, queries.SelectLoginRevision, plainID))
+
+	// Все данные читаются только с новым ключом.
+	newKeyring, err := aesgcm.New(newKey)
+	require.NoError(t, err)
+	db.keyring = newKeyring
+	binaries = NewBinaryService(queries, db, aesgcm.NewBlobStore(blobs, newKeyring), NewDataConverter())
+
+	all, err := logins.GetAll(ctx)
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+	for _, login := range all {
+		if login.Name == "sealed" {
+			require.Equal(t, "secret", login.Password)
+		}
+	}
+
+	rows, err := queries.SelectLogins(ctx, "alice")
+	require.NoError(t, err)
+	for _, row := range rows {
+		require.True(t, strings.HasPrefix(row.Name, sealed.Prefix))
+	}
+
+	history, err := NewHistoryService(db, queries, logins, nil, binaries, nil, nil).List(ctx, server.DataKindLogin, plainID)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	require.Equal(t, "123", history[0].Login.Password)
+
+	stored, err := binaries.GetAll(ctx)
+	require.NoError(t, err)
+	require.Len(t, stored, 2)
+	for _, binary := range stored {
+		data, err := binaries.Get(ctx, binary.ID)
+		require.NoError(t, err)
+		content, err := io.ReadAll(data.DataReader)
+		require.NoError(t, err)
+		data.DataReader.Close()
+
+		if binary.ID == legacyID {
+			require.Equal(t, "legacy content", string(content))
+		} else {
+			require.Equal(t, "file content", string(content))
+		}
+		require.EqualValues(t, len(content), data.ContentSize)
+	}
+}
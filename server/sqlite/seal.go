@@ -0,0 +1,153 @@
+package sqlite
+
+import (
+	"github.com/mkolibaba/gophkeeper/server/sealed"
+	sqlc "github.com/mkolibaba/gophkeeper/server/sqlite/sqlc/gen"
+)
+
+func loginFields(l *sqlc.Login) sealed.Fields {
+	return sealed.Fields{
+		Required: []*string{&l.Name, &l.Login},
+		Optional: []**string{&l.Password, &l.Website, &l.Notes},
+	}
+}
+
+func insertLoginFields(p *sqlc.InsertLoginParams) sealed.Fields {
+	return sealed.Fields{
+		Required: []*string{&p.Name, &p.Login},
+		Optional: []**string{&p.Password, &p.Website, &p.Notes},
+	}
+}
+
+func updateLoginFields(p *sqlc.UpdateLoginParams) sealed.Fields {
+	return sealed.Fields{
+		Required: []*string{&p.Name, &p.Login},
+		Optional: []**string{&p.Password, &p.Website, &p.Notes},
+	}
+}
+
+func noteFields(n *sqlc.Note) sealed.Fields {
+	return sealed.Fields{
+		Required: []*string{&n.Name},
+		Optional: []**string{&n.Text},
+	}
+}
+
+func insertNoteFields(p *sqlc.InsertNoteParams) sealed.Fields {
+	return sealed.Fields{
+		Required: []*string{&p.Name},
+		Optional: []**string{&p.Text},
+	}
+}
+
+func updateNoteFields(p *sqlc.UpdateNoteParams) sealed.Fields {
+	return sealed.Fields{
+		Required: []*string{&p.Name},
+		Optional: []**string{&p.Text},
+	}
+}
+
+func binaryFields(b *sqlc.Binary) sealed.Fields {
+	return sealed.Fields{
+		Required: []*string{&b.Name, &b.Filename},
+		Optional: []**string{&b.Notes},
+	}
+}
+
+func insertBinaryFields(p *sqlc.InsertBinaryParams) sealed.Fields {
+	return sealed.Fields{
+		Required: []*string{&p.Name, &p.Filename},
+		Optional: []**string{&p.Notes},
+	}
+}
+
+func updateBinaryFields(p *sqlc.UpdateBinaryParams) sealed.Fields {
+	return sealed.Fields{
+		Required: []*string{&p.Name},
+		Optional: []**string{&p.Notes},
+	}
+}
+
+func cardFields(c *sqlc.Card) sealed.Fields {
+	return sealed.Fields{
+		Required: []*string{&c.Name, &c.Number, &c.ExpDate, &c.Cvv, &c.Cardholder},
+		Optional: []**string{&c.Notes},
+	}
+}
+
+func insertCardFields(p *sqlc.InsertCardParams) sealed.Fields {
+	return sealed.Fields{
+		Required: []*string{&p.Name, &p.Number, &p.ExpDate, &p.Cvv, &p.Cardholder},
+		Optional: []**string{&p.Notes},
+	}
+}
+
+func updateCardFields(p *sqlc.UpdateCardParams) sealed.Fields {
+	return sealed.Fields{
+		Required: []*string{&p.Name, &p.Number, &p.ExpDate, &p.Cvv, &p.Cardholder},
+		Optional: []**string{&p.Notes},
+	}
+}
+
+func otpFields(o *sqlc.OTP) sealed.Fields {
+	return sealed.Fields{
+		Required: []*string{&o.Name, &o.Secret},
+		Optional: []**string{&o.Issuer},
+	}
+}
+
+func insertOTPFields(p *sqlc.InsertOTPParams) sealed.Fields {
+	return sealed.Fields{
+		Required: []*string{&p.Name, &p.Secret},
+		Optional: []**string{&p.Issuer},
+	}
+}
+
+func updateOTPFields(p *sqlc.UpdateOTPParams) sealed.Fields {
+	return sealed.Fields{
+		Required: []*string{&p.Name, &p.Secret},
+		Optional: []**string{&p.Issuer},
+	}
+}
+
+func uploadFields(u *sqlc.Upload) sealed.Fields {
+	return sealed.Fields{
+		Required: []*string{&u.Name, &u.Filename},
+		Optional: []**string{&u.Notes},
+	}
+}
+
+func insertUploadFields(p *sqlc.InsertUploadParams) sealed.Fields {
+	return sealed.Fields{
+		Required: []*string{&p.Name, &p.Filename},
+		Optional: []**string{&p.Notes},
+	}
+}
+
+func userFields(u *sqlc.User) sealed.Fields {
+	return sealed.Fields{
+		Optional: []**string{&u.MfaSecret, &u.MfaPendingSecret},
+	}
+}
+
+func historyFields(h *sqlc.History) sealed.Fields {
+	return sealed.Fields{
+		Required: []*string{&h.Data},
+	}
+}
+
+func insertHistoryFields(p *sqlc.InsertHistoryParams) sealed.Fields {
+	return sealed.Fields{
+		Required: []*string{&p.Data},
+	}
+}
+
+// seal шифрует поля ключом сервера.
+func (d *DB) seal(fields sealed.Fields) error {
+	return sealed.Seal(d.keyring, fields)
+}
+
+// open расшифровывает поля, зашифрованные ключом сервера.
+func (d *DB) open(fields sealed.Fields) error {
+	return sealed.Open(d.keyring, fields)
+}
@@ -0,0 +1,79 @@
+package sqlite
+
+import (
+	"bytes"
+	"github.com/mkolibaba/gophkeeper/server"
+	"github.com/mkolibaba/gophkeeper/server/aesgcm"
+	"github.com/mkolibaba/gophkeeper/server/sealed"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func TestSealedFields(t *testing.T) {
+	mustCreateUser(t, "alice", "123")
+	mustCreateLogin(t, "plain", "login1", "123", "alice")
+	t.Cleanup(func() {
+		db.keyring = nil
+		db.db.Exec("DELETE FROM login")
+		db.db.Exec("DELETE FROM user")
+	})
+
+	keyring, err := aesgcm.New(bytes.Repeat([]byte{1}, aesgcm.KeySize))
+	require.NoError(t, err)
+	db.keyring = keyring
+
+	srv := NewLoginService(db, queries, NewDataConverter())
+	ctx := server.NewContextWithUser(t.Context(), "alice")
+
+	err = srv.Create(ctx, server.LoginData{
+		Name:     "sealed",
+		Login:    "login2",
+		Password: "secret",
+	})
+	require.NoError(t, err)
+
+	// В базе секретные поля зашифрованы.
+	rows, err := queries.SelectLogins(ctx, "alice")
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	for _, row := range rows {
+		if row.Name == "plain" {
+			continue
+		}
+		require.True(t, strings.HasPrefix(row.Name, sealed.Prefix))
+		require.True(t, strings.HasPrefix(*row.Password, sealed.Prefix))
+		require.NotContains(t, *row.Password, "secret")
+	}
+
+	// Сервис возвращает расшифрованные данные, в том числе сохраненные до
+	// включения шифрования.
+	logins, err := srv.GetAll(ctx)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"plain", "sealed"}, []string{logins[0].Name, logins[1].Name})
+
+	for _, login := range logins {
+		if login.Name != "sealed" {
+			continue
+		}
+		require.Equal(t, "secret", login.Password)
+
+		notes := "notes"
+		err := srv.Update(ctx, login.ID, login.Version, server.LoginDataUpdate{Notes: &notes})
+		require.NoError(t, err)
+	}
+
+	logins, err = srv.GetAll(ctx)
+	require.NoError(t, err)
+	for _, login := range logins {
+		if login.Name == "sealed" {
+			require.Equal(t, "secret", login.Password)
+			require.Equal(t, "notes", login.Notes)
+		}
+	}
+
+	// Без ключа зашифрованные данные прочитать нельзя.
+	db.keyring = nil
+	_, err = srv.GetAll(ctx)
+	require.ErrorIs(t, err, server.ErrEncryptionNotSet)
+}
@@ -0,0 +1,25 @@
+package sqlite
+
+import (
+	"github.com/mkolibaba/gophkeeper/server/servicetest"
+	"testing"
+)
+
+func TestServices(t *testing.T) {
+	converter := NewDataConverter()
+	logins := NewLoginService(db, queries, converter)
+	notes := NewNoteService(db, queries, converter)
+	cards := NewCardService(db, queries, converter)
+	otps := NewOTPService(db, queries, converter)
+	binaries := NewBinaryService(queries, db, blobs, converter)
+	servicetest.Run(t, servicetest.Services{
+		Users:    NewUserService(db, queries, blobs),
+		Logins:   logins,
+		Notes:    notes,
+		Cards:    cards,
+		OTPs:     otps,
+		Binaries: binaries,
+		History:  NewHistoryService(db, queries, logins, notes, binaries, cards, otps),
+		Trash:    NewTrashService(db, queries, binaries, converter),
+	})
+}
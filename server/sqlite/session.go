@@ -0,0 +1,108 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/mkolibaba/gophkeeper/server"
+	sqlc "github.com/mkolibaba/gophkeeper/server/sqlite/sqlc/gen"
+	"time"
+)
+
+type SessionService struct {
+	qs *sqlc.Queries
+}
+
+func NewSessionService(queries *sqlc.Queries) *SessionService {
+	return &SessionService{
+		qs: queries,
+	}
+}
+
+func (s *SessionService) Create(ctx context.Context, session server.Session) error {
+	err := s.qs.InsertSession(ctx, sqlc.InsertSessionParams{
+		ID:               session.ID,
+		User:             session.Login,
+		RefreshTokenHash: session.RefreshTokenHash,
+		CreatedAt:        session.CreatedAt.Unix(),
+		LastUsedAt:       session.LastUsedAt.Unix(),
+		ExpiresAt:        session.ExpiresAt.Unix(),
+	})
+	if err != nil {
+		return fmt.Errorf("create: %w", err)
+	}
+	return nil
+}
+
+func (s *SessionService) Get(ctx context.Context, id string) (*server.Session, error) {
+	session, err := s.qs.SelectSession(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, server.ErrSessionNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get: %w", err)
+	}
+
+	result := newSession(session)
+	return &result, nil
+}
+
+func (s *SessionService) GetAll(ctx context.Context, login string) ([]server.Session, error) {
+	sessions, err := s.qs.SelectSessions(ctx, login)
+	if err != nil {
+		return nil, fmt.Errorf("get all: %w", err)
+	}
+
+	var result []server.Session
+	for _, session := range sessions {
+		result = append(result, newSession(session))
+	}
+	return result, nil
+}
+
+func (s *SessionService) Rotate(ctx context.Context, id string, oldHash []byte, session server.Session) error {
+	n, err := s.qs.UpdateSessionToken(ctx, sqlc.UpdateSessionTokenParams{
+		RefreshTokenHash:    session.RefreshTokenHash,
+		LastUsedAt:          session.LastUsedAt.Unix(),
+		ExpiresAt:           session.ExpiresAt.Unix(),
+		ID:                  id,
+		OldRefreshTokenHash: oldHash,
+	})
+	if err != nil {
+		return fmt.Errorf("rotate: %w", err)
+	}
+	if n == 0 {
+		return server.ErrSessionNotFound
+	}
+	return nil
+}
+
+func (s *SessionService) Remove(ctx context.Context, login string, id string) error {
+	n, err := s.qs.DeleteSession(ctx, id, login)
+	if err != nil {
+		return fmt.Errorf("remove: %w", err)
+	}
+	if n == 0 {
+		return server.ErrSessionNotFound
+	}
+	return nil
+}
+
+func (s *SessionService) RemoveExpired(ctx context.Context, login string, now time.Time) error {
+	if err := s.qs.DeleteExpiredSessions(ctx, login, now.Unix()); err != nil {
+		return fmt.Errorf("remove expired: %w", err)
+	}
+	return nil
+}
+
+func newSession(session sqlc.Session) server.Session {
+	return server.Session{
+		ID:               session.ID,
+		Login:            session.User,
+		RefreshTokenHash: session.RefreshTokenHash,
+		CreatedAt:        time.Unix(session.CreatedAt, 0),
+		LastUsedAt:       time.Unix(session.LastUsedAt, 0),
+		ExpiresAt:        time.Unix(session.ExpiresAt, 0),
+	}
+}
@@ -0,0 +1,86 @@
+package sqlite
+
+import (
+	"github.com/mkolibaba/gophkeeper/server"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestSession(t *testing.T) {
+	mustCreateUser(t, "alice", "123")
+	mustCreateUser(t, "bob", "123")
+	t.Cleanup(func() {
+		db.db.Exec("DELETE FROM user")
+	})
+
+	srv := NewSessionService(queries)
+	now := time.Unix(1_700_000_000, 0)
+
+	session := server.Session{
+		ID:               "session-1",
+		Login:            "alice",
+		RefreshTokenHash: []byte("hash 1"),
+		CreatedAt:        now,
+		LastUsedAt:       now,
+		ExpiresAt:        now.Add(time.Hour),
+	}
+	require.NoError(t, srv.Create(t.Context(), session))
+	require.NoError(t, srv.Create(t.Context(), server.Session{
+		ID:               "session-2",
+		Login:            "alice",
+		RefreshTokenHash: []byte("hash 2"),
+		CreatedAt:        now.Add(time.Second),
+		LastUsedAt:       now.Add(time.Second),
+		ExpiresAt:        now.Add(time.Minute),
+	}))
+
+	t.Run("get", func(t *testing.T) {
+		got, err := srv.Get(t.Context(), "session-1")
+		require.NoError(t, err)
+		require.Equal(t, session, *got)
+
+		_, err = srv.Get(t.Context(), "unknown")
+		require.ErrorIs(t, err, server.ErrSessionNotFound)
+	})
+	t.Run("get_all", func(t *testing.T) {
+		sessions, err := srv.GetAll(t.Context(), "alice")
+		require.NoError(t, err)
+		require.Len(t, sessions, 2)
+		require.Equal(t, "session-1", sessions[0].ID)
+
+		sessions, err = srv.GetAll(t.Context(), "bob")
+		require.NoError(t, err)
+		require.Empty(t, sessions)
+	})
+	t.Run("rotate", func(t *testing.T) {
+		rotated := session
+		rotated.RefreshTokenHash = []byte("hash 3")
+		rotated.LastUsedAt = now.Add(time.Minute)
+		rotated.ExpiresAt = now.Add(2 * time.Hour)
+
+		// Хэш уже заменен другим запросом.
+		err := srv.Rotate(t.Context(), "session-1", []byte("hash 2"), rotated)
+		require.ErrorIs(t, err, server.ErrSessionNotFound)
+
+		err = srv.Rotate(t.Context(), "session-1", []byte("hash 1"), rotated)
+		require.NoError(t, err)
+
+		got, err := srv.Get(t.Context(), "session-1")
+		require.NoError(t, err)
+		require.Equal(t, rotated, *got)
+	})
+	t.Run("remove", func(t *testing.T) {
+		// Сессию может удалить только ее владелец.
+		err := srv.Remove(t.Context(), "bob", "session-1")
+		require.ErrorIs(t, err, server.ErrSessionNotFound)
+
+		require.NoError(t, srv.RemoveExpired(t.Context(), "alice", now.Add(time.Minute)))
+		_, err = srv.Get(t.Context(), "session-2")
+		require.ErrorIs(t, err, server.ErrSessionNotFound)
+
+		require.NoError(t, srv.Remove(t.Context(), "alice", "session-1"))
+		_, err = srv.Get(t.Context(), "session-1")
+		require.ErrorIs(t, err, server.ErrSessionNotFound)
+	})
+}
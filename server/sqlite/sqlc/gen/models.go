@@ -5,12 +5,28 @@
 package sqlc
 
 type Binary struct {
-	ID       int64
-	Name     string
-	Filename string
-	Size     int64
-	Notes    *string
-	User     string
+	ID          int64
+	Name        string
+	Filename    string
+	Size        int64
+	Notes       *string
+	User        string
+	Revision    int64
+	Sha256      []byte
+	Blob        []byte
+	Compression int64
+	DeletedAt   *int64
+}
+
+type Blob struct {
+	Sha256 []byte
+	Size   int64
+	Refs   int64
+}
+
+type BlobContent struct {
+	ID   string
+	Data []byte
 }
 
 type Card struct {
@@ -22,26 +38,106 @@ type Card struct {
 	Cardholder string
 	Notes      *string
 	User       string
+	Revision   int64
+	DeletedAt  *int64
+}
+
+type History struct {
+	ID        int64
+	Kind      string
+	ItemID    int64
+	User      string
+	Version   int64
+	Removed   bool
+	Data      string
+	CreatedAt int64
 }
 
 type Login struct {
+	ID        int64
+	Name      string
+	Login     string
+	Password  *string
+	Website   *string
+	Notes     *string
+	User      string
+	Revision  int64
+	DeletedAt *int64
+}
+
+type LoginAttempt struct {
+	Login       string
+	Failures    int64
+	LockedUntil int64
+	FailedAt    int64
+}
+
+type Note struct {
+	ID        int64
+	Name      string
+	Text      *string
+	User      string
+	Revision  int64
+	DeletedAt *int64
+}
+
+type OTP struct {
+	ID        int64
+	Name      string
+	Type      string
+	Secret    string
+	Algorithm string
+	Digits    int64
+	Period    int64
+	Counter   int64
+	Issuer    *string
+	User      string
+	Revision  int64
+	DeletedAt *int64
+}
+
+type RecoveryCode struct {
+	User     string
+	CodeHash []byte
+}
+
+type Session struct {
+	ID               string
+	User             string
+	RefreshTokenHash []byte
+	CreatedAt        int64
+	LastUsedAt       int64
+	ExpiresAt        int64
+}
+
+type Tombstone struct {
+	Kind     string
 	ID       int64
-	Name     string
-	Login    string
-	Password *string
-	Website  *string
-	Notes    *string
 	User     string
+	Revision int64
 }
 
-type Note struct {
-	ID   int64
-	Name string
-	Text *string
-	User string
+type Upload struct {
+	ID          string
+	User        string
+	Name        string
+	Filename    string
+	Size        int64
+	Notes       *string
+	ContentSize int64
+	Sha256      []byte
+	ExpiresAt   int64
+	Compression int64
+	ContentKey  []byte
 }
 
 type User struct {
-	Login    string
-	Password string
+	Login            string
+	Password         string
+	VaultKey         []byte
+	Revision         int64
+	MfaSecret        *string
+	MfaEnabled       bool
+	MfaPendingSecret *string
+	MfaLastStep      int64
 }
@@ -9,60 +9,319 @@ import (
 	"context"
 )
 
-const deleteBinary = `-- name: DeleteBinary :execrows
+const acquireBlob = `-- name: AcquireBlob :one
+INSERT INTO blob (sha256, size, refs)
+VALUES (?, ?, 1)
+ON CONFLICT (sha256) DO UPDATE SET refs = refs + 1
+RETURNING refs
+`
+
+func (q *Queries) AcquireBlob(ctx context.Context, sha256 []byte, size int64) (int64, error) {
+	row := q.db.QueryRowContext(ctx, acquireBlob, sha256, size)
+	var refs int64
+	err := row.Scan(&refs)
+	return refs, err
+}
+
+const deleteBlobContent = `-- name: DeleteBlobContent :exec
 DELETE
-FROM binary
+FROM blob_content
 WHERE id = ?
-  AND user = ?
 `
 
-func (q *Queries) DeleteBinary(ctx context.Context, iD int64, user string) (int64, error) {
-	result, err := q.db.ExecContext(ctx, deleteBinary, iD, user)
+func (q *Queries) DeleteBlobContent(ctx context.Context, id string) error {
+	_, err := q.db.ExecContext(ctx, deleteBlobContent, id)
+	return err
+}
+
+const deleteExpiredHistory = `-- name: DeleteExpiredHistory :exec
+DELETE
+FROM history
+WHERE user = ?
+  AND created_at < ?
+`
+
+func (q *Queries) DeleteExpiredHistory(ctx context.Context, user string, createdAt int64) error {
+	_, err := q.db.ExecContext(ctx, deleteExpiredHistory, user, createdAt)
+	return err
+}
+
+const deleteExpiredLoginAttempts = `-- name: DeleteExpiredLoginAttempts :exec
+DELETE
+FROM login_attempt
+WHERE failed_at < ?1
+  AND locked_until < ?1
+`
+
+func (q *Queries) DeleteExpiredLoginAttempts(ctx context.Context, before int64) error {
+	_, err := q.db.ExecContext(ctx, deleteExpiredLoginAttempts, before)
+	return err
+}
+
+const deleteExpiredSessions = `-- name: DeleteExpiredSessions :exec
+DELETE
+FROM session
+WHERE user = ?
+  AND expires_at <= ?
+`
+
+func (q *Queries) DeleteExpiredSessions(ctx context.Context, user string, expiresAt int64) error {
+	_, err := q.db.ExecContext(ctx, deleteExpiredSessions, user, expiresAt)
+	return err
+}
+
+const deleteHistoryOverLimit = `-- name: DeleteHistoryOverLimit :exec
+DELETE
+FROM history
+WHERE user = ?1
+  AND kind = ?2
+  AND item_id = ?3
+  AND id NOT IN (SELECT id
+                 FROM history
+                 WHERE user = ?1
+                   AND kind = ?2
+                   AND item_id = ?3
+                 ORDER BY id DESC
+                 LIMIT ?4)
+`
+
+type DeleteHistoryOverLimitParams struct {
+	User   string
+	Kind   string
+	ItemID int64
+	Keep   int64
+}
+
+func (q *Queries) DeleteHistoryOverLimit(ctx context.Context, arg DeleteHistoryOverLimitParams) error {
+	_, err := q.db.ExecContext(ctx, deleteHistoryOverLimit,
+		arg.User,
+		arg.Kind,
+		arg.ItemID,
+		arg.Keep,
+	)
+	return err
+}
+
+const deleteItemHistory = `-- name: DeleteItemHistory :exec
+DELETE
+FROM history
+WHERE user = ?
+  AND kind = ?
+  AND item_id = ?
+`
+
+type DeleteItemHistoryParams struct {
+	User   string
+	Kind   string
+	ItemID int64
+}
+
+func (q *Queries) DeleteItemHistory(ctx context.Context, arg DeleteItemHistoryParams) error {
+	_, err := q.db.ExecContext(ctx, deleteItemHistory, arg.User, arg.Kind, arg.ItemID)
+	return err
+}
+
+const deleteLoginAttempt = `-- name: DeleteLoginAttempt :exec
+DELETE
+FROM login_attempt
+WHERE login = ?
+`
+
+func (q *Queries) DeleteLoginAttempt(ctx context.Context, login string) error {
+	_, err := q.db.ExecContext(ctx, deleteLoginAttempt, login)
+	return err
+}
+
+const deleteRecoveryCode = `-- name: DeleteRecoveryCode :execrows
+DELETE
+FROM recovery_code
+WHERE user = ?
+  AND code_hash = ?
+`
+
+func (q *Queries) DeleteRecoveryCode(ctx context.Context, user string, codeHash []byte) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteRecoveryCode, user, codeHash)
 	if err != nil {
 		return 0, err
 	}
 	return result.RowsAffected()
 }
 
-const deleteCard = `-- name: DeleteCard :execrows
+const deleteRecoveryCodes = `-- name: DeleteRecoveryCodes :exec
 DELETE
-FROM card
+FROM recovery_code
+WHERE user = ?
+`
+
+func (q *Queries) DeleteRecoveryCodes(ctx context.Context, user string) error {
+	_, err := q.db.ExecContext(ctx, deleteRecoveryCodes, user)
+	return err
+}
+
+const deleteSession = `-- name: DeleteSession :execrows
+DELETE
+FROM session
 WHERE id = ?
   AND user = ?
 `
 
-func (q *Queries) DeleteCard(ctx context.Context, iD int64, user string) (int64, error) {
-	result, err := q.db.ExecContext(ctx, deleteCard, iD, user)
+func (q *Queries) DeleteSession(ctx context.Context, iD string, user string) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteSession, iD, user)
 	if err != nil {
 		return 0, err
 	}
 	return result.RowsAffected()
 }
 
-const deleteLogin = `-- name: DeleteLogin :execrows
+const deleteUnusedBlob = `-- name: DeleteUnusedBlob :exec
 DELETE
-FROM login
+FROM blob
+WHERE sha256 = ?
+  AND refs = 0
+`
+
+func (q *Queries) DeleteUnusedBlob(ctx context.Context, sha256 []byte) error {
+	_, err := q.db.ExecContext(ctx, deleteUnusedBlob, sha256)
+	return err
+}
+
+const deleteUpload = `-- name: DeleteUpload :exec
+DELETE
+FROM upload
 WHERE id = ?
-  AND user = ?
 `
 
-func (q *Queries) DeleteLogin(ctx context.Context, iD int64, user string) (int64, error) {
-	result, err := q.db.ExecContext(ctx, deleteLogin, iD, user)
+func (q *Queries) DeleteUpload(ctx context.Context, id string) error {
+	_, err := q.db.ExecContext(ctx, deleteUpload, id)
+	return err
+}
+
+const deleteUser = `-- name: DeleteUser :execrows
+DELETE
+FROM user
+WHERE login = ?
+`
+
+func (q *Queries) DeleteUser(ctx context.Context, login string) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteUser, login)
 	if err != nil {
 		return 0, err
 	}
 	return result.RowsAffected()
 }
 
-const deleteNote = `-- name: DeleteNote :execrows
+const deleteUserBinaries = `-- name: DeleteUserBinaries :exec
+DELETE
+FROM binary
+WHERE user = ?
+`
+
+func (q *Queries) DeleteUserBinaries(ctx context.Context, user string) error {
+	_, err := q.db.ExecContext(ctx, deleteUserBinaries, user)
+	return err
+}
+
+const deleteUserCards = `-- name: DeleteUserCards :exec
+DELETE
+FROM card
+WHERE user = ?
+`
+
+func (q *Queries) DeleteUserCards(ctx context.Context, user string) error {
+	_, err := q.db.ExecContext(ctx, deleteUserCards, user)
+	return err
+}
+
+const deleteUserLogins = `-- name: DeleteUserLogins :exec
+DELETE
+FROM login
+WHERE user = ?
+`
+
+func (q *Queries) DeleteUserLogins(ctx context.Context, user string) error {
+	_, err := q.db.ExecContext(ctx, deleteUserLogins, user)
+	return err
+}
+
+const deleteUserNotes = `-- name: DeleteUserNotes :exec
 DELETE
 FROM note
-WHERE id = ?
-  AND user = ?
+WHERE user = ?
+`
+
+func (q *Queries) DeleteUserNotes(ctx context.Context, user string) error {
+	_, err := q.db.ExecContext(ctx, deleteUserNotes, user)
+	return err
+}
+
+const deleteUserOTPs = `-- name: DeleteUserOTPs :exec
+DELETE
+FROM otp
+WHERE user = ?
+`
+
+func (q *Queries) DeleteUserOTPs(ctx context.Context, user string) error {
+	_, err := q.db.ExecContext(ctx, deleteUserOTPs, user)
+	return err
+}
+
+const disableUserMFA = `-- name: DisableUserMFA :execrows
+UPDATE user
+SET mfa_secret         = NULL,
+    mfa_pending_secret = NULL,
+    mfa_enabled        = FALSE,
+    mfa_last_step      = 0
+WHERE login = ?
+`
+
+func (q *Queries) DisableUserMFA(ctx context.Context, login string) (int64, error) {
+	result, err := q.db.ExecContext(ctx, disableUserMFA, login)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const enableUserMFA = `-- name: EnableUserMFA :execrows
+UPDATE user
+SET mfa_secret         = ?,
+    mfa_pending_secret = NULL,
+    mfa_enabled        = TRUE,
+    mfa_last_step      = ?
+WHERE login = ?
+  AND NOT mfa_enabled
+`
+
+type EnableUserMFAParams struct {
+	MfaSecret   *string
+	MfaLastStep int64
+	Login       string
+}
+
+func (q *Queries) EnableUserMFA(ctx context.Context, arg EnableUserMFAParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, enableUserMFA, arg.MfaSecret, arg.MfaLastStep, arg.Login)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const enrollUserVaultKey = `-- name: EnrollUserVaultKey :execrows
+UPDATE user
+SET password  = ?,
+    vault_key = ?
+WHERE login = ?
+  AND vault_key IS NULL
 `
 
-func (q *Queries) DeleteNote(ctx context.Context, iD int64, user string) (int64, error) {
-	result, err := q.db.ExecContext(ctx, deleteNote, iD, user)
+type EnrollUserVaultKeyParams struct {
+	Password string
+	VaultKey []byte
+	Login    string
+}
+
+func (q *Queries) EnrollUserVaultKey(ctx context.Context, arg EnrollUserVaultKeyParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, enrollUserVaultKey, arg.Password, arg.VaultKey, arg.Login)
 	if err != nil {
 		return 0, err
 	}
@@ -70,17 +329,18 @@ func (q *Queries) DeleteNote(ctx context.Context, iD int64, user string) (int64,
 }
 
 const insertBinary = `-- name: InsertBinary :one
-INSERT INTO binary (name, filename, size, notes, user)
-VALUES (?, ?, ?, ?, ?)
+INSERT INTO binary (name, filename, size, notes, user, compression)
+VALUES (?, ?, ?, ?, ?, ?)
 RETURNING id
 `
 
 type InsertBinaryParams struct {
-	Name     string
-	Filename string
-	Size     int64
-	Notes    *string
-	User     string
+	Name        string
+	Filename    string
+	Size        int64
+	Notes       *string
+	User        string
+	Compression int64
 }
 
 func (q *Queries) InsertBinary(ctx context.Context, arg InsertBinaryParams) (int64, error) {
@@ -90,6 +350,7 @@ func (q *Queries) InsertBinary(ctx context.Context, arg InsertBinaryParams) (int
 		arg.Size,
 		arg.Notes,
 		arg.User,
+		arg.Compression,
 	)
 	var id int64
 	err := row.Scan(&id)
@@ -127,6 +388,34 @@ func (q *Queries) InsertCard(ctx context.Context, arg InsertCardParams) (int64,
 	return result.LastInsertId()
 }
 
+const insertHistory = `-- name: InsertHistory :exec
+INSERT INTO history (kind, item_id, user, version, removed, data, created_at)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+`
+
+type InsertHistoryParams struct {
+	Kind      string
+	ItemID    int64
+	User      string
+	Version   int64
+	Removed   bool
+	Data      string
+	CreatedAt int64
+}
+
+func (q *Queries) InsertHistory(ctx context.Context, arg InsertHistoryParams) error {
+	_, err := q.db.ExecContext(ctx, insertHistory,
+		arg.Kind,
+		arg.ItemID,
+		arg.User,
+		arg.Version,
+		arg.Removed,
+		arg.Data,
+		arg.CreatedAt,
+	)
+	return err
+}
+
 const insertLogin = `-- name: InsertLogin :execlastid
 INSERT INTO login (name, login, password, website, notes, user)
 VALUES (?, ?, ?, ?, ?, ?)
@@ -175,91 +464,824 @@ func (q *Queries) InsertNote(ctx context.Context, arg InsertNoteParams) (int64,
 	return result.LastInsertId()
 }
 
-const insertUser = `-- name: InsertUser :exec
-INSERT INTO user (login, password)
+const insertOTP = `-- name: InsertOTP :execlastid
+INSERT INTO otp (name, type, secret, algorithm, digits, period, counter, issuer, user)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+`
+
+type InsertOTPParams struct {
+	Name      string
+	Type      string
+	Secret    string
+	Algorithm string
+	Digits    int64
+	Period    int64
+	Counter   int64
+	Issuer    *string
+	User      string
+}
+
+func (q *Queries) InsertOTP(ctx context.Context, arg InsertOTPParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, insertOTP,
+		arg.Name,
+		arg.Type,
+		arg.Secret,
+		arg.Algorithm,
+		arg.Digits,
+		arg.Period,
+		arg.Counter,
+		arg.Issuer,
+		arg.User,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+const insertRecoveryCode = `-- name: InsertRecoveryCode :exec
+INSERT INTO recovery_code (user, code_hash)
 VALUES (?, ?)
 `
 
-func (q *Queries) InsertUser(ctx context.Context, login string, password string) error {
-	_, err := q.db.ExecContext(ctx, insertUser, login, password)
+func (q *Queries) InsertRecoveryCode(ctx context.Context, user string, codeHash []byte) error {
+	_, err := q.db.ExecContext(ctx, insertRecoveryCode, user, codeHash)
 	return err
 }
 
-const selectBinaries = `-- name: SelectBinaries :many
-SELECT id, name, filename, size, notes, user
-FROM binary
-WHERE user = ?
+const insertSession = `-- name: InsertSession :exec
+INSERT INTO session (id, user, refresh_token_hash, created_at, last_used_at, expires_at)
+VALUES (?, ?, ?, ?, ?, ?)
 `
 
-func (q *Queries) SelectBinaries(ctx context.Context, user string) ([]Binary, error) {
-	rows, err := q.db.QueryContext(ctx, selectBinaries, user)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	var items []Binary
-	for rows.Next() {
-		var i Binary
-		if err := rows.Scan(
-			&i.ID,
-			&i.Name,
-			&i.Filename,
-			&i.Size,
-			&i.Notes,
-			&i.User,
-		); err != nil {
-			return nil, err
-		}
-		items = append(items, i)
-	}
-	if err := rows.Close(); err != nil {
-		return nil, err
-	}
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
-	return items, nil
+type InsertSessionParams struct {
+	ID               string
+	User             string
+	RefreshTokenHash []byte
+	CreatedAt        int64
+	LastUsedAt       int64
+	ExpiresAt        int64
 }
 
-const selectBinary = `-- name: SelectBinary :one
-SELECT id, name, filename, size, notes, user
-FROM binary
-WHERE id = ?
-  AND user = ?
+func (q *Queries) InsertSession(ctx context.Context, arg InsertSessionParams) error {
+	_, err := q.db.ExecContext(ctx, insertSession,
+		arg.ID,
+		arg.User,
+		arg.RefreshTokenHash,
+		arg.CreatedAt,
+		arg.LastUsedAt,
+		arg.ExpiresAt,
+	)
+	return err
+}
+
+const insertUpload = `-- name: InsertUpload :exec
+INSERT INTO upload (id, user, name, filename, size, notes, content_size, sha256, expires_at, compression, content_key)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 `
 
-func (q *Queries) SelectBinary(ctx context.Context, iD int64, user string) (Binary, error) {
-	row := q.db.QueryRowContext(ctx, selectBinary, iD, user)
-	var i Binary
-	err := row.Scan(
-		&i.ID,
-		&i.Name,
-		&i.Filename,
-		&i.Size,
-		&i.Notes,
-		&i.User,
+type InsertUploadParams struct {
+	ID          string
+	User        string
+	Name        string
+	Filename    string
+	Size        int64
+	Notes       *string
+	ContentSize int64
+	Sha256      []byte
+	ExpiresAt   int64
+	Compression int64
+	ContentKey  []byte
+}
+
+func (q *Queries) InsertUpload(ctx context.Context, arg InsertUploadParams) error {
+	_, err := q.db.ExecContext(ctx, insertUpload,
+		arg.ID,
+		arg.User,
+		arg.Name,
+		arg.Filename,
+		arg.Size,
+		arg.Notes,
+		arg.ContentSize,
+		arg.Sha256,
+		arg.ExpiresAt,
+		arg.Compression,
+		arg.ContentKey,
 	)
-	return i, err
+	return err
 }
 
-const selectBinaryUser = `-- name: SelectBinaryUser :one
-SELECT user
-FROM binary
-WHERE id = ?
+const insertUser = `-- name: InsertUser :exec
+INSERT INTO user (login, password, vault_key)
+VALUES (?, ?, ?)
 `
 
-func (q *Queries) SelectBinaryUser(ctx context.Context, id int64) (string, error) {
-	row := q.db.QueryRowContext(ctx, selectBinaryUser, id)
-	var user string
-	err := row.Scan(&user)
-	return user, err
+type InsertUserParams struct {
+	Login    string
+	Password string
+	VaultKey []byte
 }
 
-const selectCard = `-- name: SelectCard :one
-SELECT id, name, number, exp_date, cvv, cardholder, notes, user
-FROM card
+func (q *Queries) InsertUser(ctx context.Context, arg InsertUserParams) error {
+	_, err := q.db.ExecContext(ctx, insertUser, arg.Login, arg.Password, arg.VaultKey)
+	return err
+}
+
+const purgeBinary = `-- name: PurgeBinary :execrows
+DELETE
+FROM binary
 WHERE id = ?
   AND user = ?
+  AND deleted_at IS NOT NULL
+`
+
+func (q *Queries) PurgeBinary(ctx context.Context, iD int64, user string) (int64, error) {
+	result, err := q.db.ExecContext(ctx, purgeBinary, iD, user)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const purgeCard = `-- name: PurgeCard :execrows
+DELETE
+FROM card
+WHERE id = ?
+  AND user = ?
+  AND deleted_at IS NOT NULL
+`
+
+func (q *Queries) PurgeCard(ctx context.Context, iD int64, user string) (int64, error) {
+	result, err := q.db.ExecContext(ctx, purgeCard, iD, user)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const purgeLogin = `-- name: PurgeLogin :execrows
+DELETE
+FROM login
+WHERE id = ?
+  AND user = ?
+  AND deleted_at IS NOT NULL
+`
+
+func (q *Queries) PurgeLogin(ctx context.Context, iD int64, user string) (int64, error) {
+	result, err := q.db.ExecContext(ctx, purgeLogin, iD, user)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const purgeNote = `-- name: PurgeNote :execrows
+DELETE
+FROM note
+WHERE id = ?
+  AND user = ?
+  AND deleted_at IS NOT NULL
+`
+
+func (q *Queries) PurgeNote(ctx context.Context, iD int64, user string) (int64, error) {
+	result, err := q.db.ExecContext(ctx, purgeNote, iD, user)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const purgeOTP = `-- name: PurgeOTP :execrows
+DELETE
+FROM otp
+WHERE id = ?
+  AND user = ?
+  AND deleted_at IS NOT NULL
+`
+
+func (q *Queries) PurgeOTP(ctx context.Context, iD int64, user string) (int64, error) {
+	result, err := q.db.ExecContext(ctx, purgeOTP, iD, user)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const releaseBlob = `-- name: ReleaseBlob :one
+UPDATE blob
+SET refs = refs - 1
+WHERE sha256 = ?
+RETURNING refs
+`
+
+func (q *Queries) ReleaseBlob(ctx context.Context, sha256 []byte) (int64, error) {
+	row := q.db.QueryRowContext(ctx, releaseBlob, sha256)
+	var refs int64
+	err := row.Scan(&refs)
+	return refs, err
+}
+
+const restoreBinary = `-- name: RestoreBinary :execrows
+UPDATE binary
+SET deleted_at = NULL
+WHERE id = ?
+  AND user = ?
+  AND deleted_at IS NOT NULL
+`
+
+func (q *Queries) RestoreBinary(ctx context.Context, iD int64, user string) (int64, error) {
+	result, err := q.db.ExecContext(ctx, restoreBinary, iD, user)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const restoreCard = `-- name: RestoreCard :execrows
+UPDATE card
+SET deleted_at = NULL
+WHERE id = ?
+  AND user = ?
+  AND deleted_at IS NOT NULL
+`
+
+func (q *Queries) RestoreCard(ctx context.Context, iD int64, user string) (int64, error) {
+	result, err := q.db.ExecContext(ctx, restoreCard, iD, user)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const restoreLogin = `-- name: RestoreLogin :execrows
+UPDATE login
+SET deleted_at = NULL
+WHERE id = ?
+  AND user = ?
+  AND deleted_at IS NOT NULL
+`
+
+func (q *Queries) RestoreLogin(ctx context.Context, iD int64, user string) (int64, error) {
+	result, err := q.db.ExecContext(ctx, restoreLogin, iD, user)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const restoreNote = `-- name: RestoreNote :execrows
+UPDATE note
+SET deleted_at = NULL
+WHERE id = ?
+  AND user = ?
+  AND deleted_at IS NOT NULL
+`
+
+func (q *Queries) RestoreNote(ctx context.Context, iD int64, user string) (int64, error) {
+	result, err := q.db.ExecContext(ctx, restoreNote, iD, user)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const restoreOTP = `-- name: RestoreOTP :execrows
+UPDATE otp
+SET deleted_at = NULL
+WHERE id = ?
+  AND user = ?
+  AND deleted_at IS NOT NULL
+`
+
+func (q *Queries) RestoreOTP(ctx context.Context, iD int64, user string) (int64, error) {
+	result, err := q.db.ExecContext(ctx, restoreOTP, iD, user)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const selectAllBinaries = `-- name: SelectAllBinaries :many
+SELECT id, name, filename, size, notes, user, revision, sha256, blob, compression, deleted_at
+FROM binary
+`
+
+func (q *Queries) SelectAllBinaries(ctx context.Context) ([]Binary, error) {
+	rows, err := q.db.QueryContext(ctx, selectAllBinaries)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Binary
+	for rows.Next() {
+		var i Binary
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Filename,
+			&i.Size,
+			&i.Notes,
+			&i.User,
+			&i.Revision,
+			&i.Sha256,
+			&i.Blob,
+			&i.Compression,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const selectAllCards = `-- name: SelectAllCards :many
+SELECT id, name, number, exp_date, cvv, cardholder, notes, user, revision, deleted_at
+FROM card
+`
+
+func (q *Queries) SelectAllCards(ctx context.Context) ([]Card, error) {
+	rows, err := q.db.QueryContext(ctx, selectAllCards)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Card
+	for rows.Next() {
+		var i Card
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Number,
+			&i.ExpDate,
+			&i.Cvv,
+			&i.Cardholder,
+			&i.Notes,
+			&i.User,
+			&i.Revision,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const selectAllHistory = `-- name: SelectAllHistory :many
+SELECT id, kind, item_id, user, version, removed, data, created_at
+FROM history
+`
+
+func (q *Queries) SelectAllHistory(ctx context.Context) ([]History, error) {
+	rows, err := q.db.QueryContext(ctx, selectAllHistory)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []History
+	for rows.Next() {
+		var i History
+		if err := rows.Scan(
+			&i.ID,
+			&i.Kind,
+			&i.ItemID,
+			&i.User,
+			&i.Version,
+			&i.Removed,
+			&i.Data,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const selectAllLogins = `-- name: SelectAllLogins :many
+SELECT id, name, login, password, website, notes, user, revision, deleted_at
+FROM login
+`
+
+func (q *Queries) SelectAllLogins(ctx context.Context) ([]Login, error) {
+	rows, err := q.db.QueryContext(ctx, selectAllLogins)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Login
+	for rows.Next() {
+		var i Login
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Login,
+			&i.Password,
+			&i.Website,
+			&i.Notes,
+			&i.User,
+			&i.Revision,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const selectAllNotes = `-- name: SelectAllNotes :many
+SELECT id, name, text, user, revision, deleted_at
+FROM note
+`
+
+func (q *Queries) SelectAllNotes(ctx context.Context) ([]Note, error) {
+	rows, err := q.db.QueryContext(ctx, selectAllNotes)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Note
+	for rows.Next() {
+		var i Note
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Text,
+			&i.User,
+			&i.Revision,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const selectAllOTPs = `-- name: SelectAllOTPs :many
+SELECT id, name, type, secret, algorithm, digits, period, counter, issuer, user, revision, deleted_at
+FROM otp
+`
+
+func (q *Queries) SelectAllOTPs(ctx context.Context) ([]OTP, error) {
+	rows, err := q.db.QueryContext(ctx, selectAllOTPs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []OTP
+	for rows.Next() {
+		var i OTP
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Type,
+			&i.Secret,
+			&i.Algorithm,
+			&i.Digits,
+			&i.Period,
+			&i.Counter,
+			&i.Issuer,
+			&i.User,
+			&i.Revision,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const selectAllUploads = `-- name: SelectAllUploads :many
+SELECT id, user, name, filename, size, notes, content_size, sha256, expires_at, compression, content_key
+FROM upload
+`
+
+func (q *Queries) SelectAllUploads(ctx context.Context) ([]Upload, error) {
+	rows, err := q.db.QueryContext(ctx, selectAllUploads)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Upload
+	for rows.Next() {
+		var i Upload
+		if err := rows.Scan(
+			&i.ID,
+			&i.User,
+			&i.Name,
+			&i.Filename,
+			&i.Size,
+			&i.Notes,
+			&i.ContentSize,
+			&i.Sha256,
+			&i.ExpiresAt,
+			&i.Compression,
+			&i.ContentKey,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const selectAllUsers = `-- name: SelectAllUsers :many
+SELECT login, password, vault_key, revision, mfa_secret, mfa_enabled, mfa_pending_secret, mfa_last_step
+FROM user
+`
+
+func (q *Queries) SelectAllUsers(ctx context.Context) ([]User, error) {
+	rows, err := q.db.QueryContext(ctx, selectAllUsers)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []User
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.Login,
+			&i.Password,
+			&i.VaultKey,
+			&i.Revision,
+			&i.MfaSecret,
+			&i.MfaEnabled,
+			&i.MfaPendingSecret,
+			&i.MfaLastStep,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const selectBinaries = `-- name: SelectBinaries :many
+SELECT id, name, filename, size, notes, user, revision, sha256, blob, compression, deleted_at
+FROM binary
+WHERE user = ?
+  AND deleted_at IS NULL
+`
+
+func (q *Queries) SelectBinaries(ctx context.Context, user string) ([]Binary, error) {
+	rows, err := q.db.QueryContext(ctx, selectBinaries, user)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Binary
+	for rows.Next() {
+		var i Binary
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Filename,
+			&i.Size,
+			&i.Notes,
+			&i.User,
+			&i.Revision,
+			&i.Sha256,
+			&i.Blob,
+			&i.Compression,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const selectBinariesSince = `-- name: SelectBinariesSince :many
+SELECT id, name, filename, size, notes, user, revision, sha256, blob, compression, deleted_at
+FROM binary
+WHERE user = ?
+  AND revision > ?
+  AND deleted_at IS NULL
+`
+
+func (q *Queries) SelectBinariesSince(ctx context.Context, user string, revision int64) ([]Binary, error) {
+	rows, err := q.db.QueryContext(ctx, selectBinariesSince, user, revision)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Binary
+	for rows.Next() {
+		var i Binary
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Filename,
+			&i.Size,
+			&i.Notes,
+			&i.User,
+			&i.Revision,
+			&i.Sha256,
+			&i.Blob,
+			&i.Compression,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const selectBinary = `-- name: SelectBinary :one
+SELECT id, name, filename, size, notes, user, revision, sha256, blob, compression, deleted_at
+FROM binary
+WHERE id = ?
+  AND user = ?
+  AND deleted_at IS NULL
+`
+
+func (q *Queries) SelectBinary(ctx context.Context, iD int64, user string) (Binary, error) {
+	row := q.db.QueryRowContext(ctx, selectBinary, iD, user)
+	var i Binary
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Filename,
+		&i.Size,
+		&i.Notes,
+		&i.User,
+		&i.Revision,
+		&i.Sha256,
+		&i.Blob,
+		&i.Compression,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const selectBinaryRevision = `-- name: SelectBinaryRevision :one
+SELECT revision
+FROM binary
+WHERE id = ?
+  AND user = ?
+  AND deleted_at IS NULL
+`
+
+func (q *Queries) SelectBinaryRevision(ctx context.Context, iD int64, user string) (int64, error) {
+	row := q.db.QueryRowContext(ctx, selectBinaryRevision, iD, user)
+	var revision int64
+	err := row.Scan(&revision)
+	return revision, err
+}
+
+const selectBinaryUser = `-- name: SelectBinaryUser :one
+SELECT user
+FROM binary
+WHERE id = ?
+`
+
+func (q *Queries) SelectBinaryUser(ctx context.Context, id int64) (string, error) {
+	row := q.db.QueryRowContext(ctx, selectBinaryUser, id)
+	var user string
+	err := row.Scan(&user)
+	return user, err
+}
+
+const selectBlobContent = `-- name: SelectBlobContent :one
+SELECT data
+FROM blob_content
+WHERE id = ?
+`
+
+func (q *Queries) SelectBlobContent(ctx context.Context, id string) ([]byte, error) {
+	row := q.db.QueryRowContext(ctx, selectBlobContent, id)
+	var data []byte
+	err := row.Scan(&data)
+	return data, err
+}
+
+const selectBlobContentSize = `-- name: SelectBlobContentSize :one
+SELECT length(data)
+FROM blob_content
+WHERE id = ?
+`
+
+func (q *Queries) SelectBlobContentSize(ctx context.Context, id string) (int64, error) {
+	row := q.db.QueryRowContext(ctx, selectBlobContentSize, id)
+	var length int64
+	err := row.Scan(&length)
+	return length, err
+}
+
+const selectBlobKeys = `-- name: SelectBlobKeys :many
+SELECT sha256
+FROM blob
+`
+
+func (q *Queries) SelectBlobKeys(ctx context.Context) ([][]byte, error) {
+	rows, err := q.db.QueryContext(ctx, selectBlobKeys)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items [][]byte
+	for rows.Next() {
+		var sha256 []byte
+		if err := rows.Scan(&sha256); err != nil {
+			return nil, err
+		}
+		items = append(items, sha256)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const selectBlobSHA256 = `-- name: SelectBlobSHA256 :one
+SELECT sha256
+FROM binary
+WHERE blob = ?
+LIMIT 1
+`
+
+func (q *Queries) SelectBlobSHA256(ctx context.Context, blob []byte) ([]byte, error) {
+	row := q.db.QueryRowContext(ctx, selectBlobSHA256, blob)
+	var sha256 []byte
+	err := row.Scan(&sha256)
+	return sha256, err
+}
+
+const selectCard = `-- name: SelectCard :one
+SELECT id, name, number, exp_date, cvv, cardholder, notes, user, revision, deleted_at
+FROM card
+WHERE id = ?
+  AND user = ?
+  AND deleted_at IS NULL
 `
 
 func (q *Queries) SelectCard(ctx context.Context, iD int64, user string) (Card, error) {
@@ -268,37 +1290,1028 @@ func (q *Queries) SelectCard(ctx context.Context, iD int64, user string) (Card,
 	err := row.Scan(
 		&i.ID,
 		&i.Name,
-		&i.Number,
-		&i.ExpDate,
-		&i.Cvv,
-		&i.Cardholder,
-		&i.Notes,
+		&i.Number,
+		&i.ExpDate,
+		&i.Cvv,
+		&i.Cardholder,
+		&i.Notes,
+		&i.User,
+		&i.Revision,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const selectCardRevision = `-- name: SelectCardRevision :one
+SELECT revision
+FROM card
+WHERE id = ?
+  AND user = ?
+  AND deleted_at IS NULL
+`
+
+func (q *Queries) SelectCardRevision(ctx context.Context, iD int64, user string) (int64, error) {
+	row := q.db.QueryRowContext(ctx, selectCardRevision, iD, user)
+	var revision int64
+	err := row.Scan(&revision)
+	return revision, err
+}
+
+const selectCardUser = `-- name: SelectCardUser :one
+SELECT user
+FROM card
+WHERE id = ?
+`
+
+func (q *Queries) SelectCardUser(ctx context.Context, id int64) (string, error) {
+	row := q.db.QueryRowContext(ctx, selectCardUser, id)
+	var user string
+	err := row.Scan(&user)
+	return user, err
+}
+
+const selectCards = `-- name: SelectCards :many
+SELECT id, name, number, exp_date, cvv, cardholder, notes, user, revision, deleted_at
+FROM card
+WHERE user = ?
+  AND deleted_at IS NULL
+`
+
+func (q *Queries) SelectCards(ctx context.Context, user string) ([]Card, error) {
+	rows, err := q.db.QueryContext(ctx, selectCards, user)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Card
+	for rows.Next() {
+		var i Card
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Number,
+			&i.ExpDate,
+			&i.Cvv,
+			&i.Cardholder,
+			&i.Notes,
+			&i.User,
+			&i.Revision,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const selectCardsSince = `-- name: SelectCardsSince :many
+SELECT id, name, number, exp_date, cvv, cardholder, notes, user, revision, deleted_at
+FROM card
+WHERE user = ?
+  AND revision > ?
+  AND deleted_at IS NULL
+`
+
+func (q *Queries) SelectCardsSince(ctx context.Context, user string, revision int64) ([]Card, error) {
+	rows, err := q.db.QueryContext(ctx, selectCardsSince, user, revision)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Card
+	for rows.Next() {
+		var i Card
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Number,
+			&i.ExpDate,
+			&i.Cvv,
+			&i.Cardholder,
+			&i.Notes,
+			&i.User,
+			&i.Revision,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const selectExpiredBinaries = `-- name: SelectExpiredBinaries :many
+SELECT id, user
+FROM binary
+WHERE deleted_at <= ?
+`
+
+type SelectExpiredBinariesRow struct {
+	ID   int64
+	User string
+}
+
+func (q *Queries) SelectExpiredBinaries(ctx context.Context, deletedAt *int64) ([]SelectExpiredBinariesRow, error) {
+	rows, err := q.db.QueryContext(ctx, selectExpiredBinaries, deletedAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SelectExpiredBinariesRow
+	for rows.Next() {
+		var i SelectExpiredBinariesRow
+		if err := rows.Scan(&i.ID, &i.User); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const selectExpiredCards = `-- name: SelectExpiredCards :many
+SELECT id, user
+FROM card
+WHERE deleted_at <= ?
+`
+
+type SelectExpiredCardsRow struct {
+	ID   int64
+	User string
+}
+
+func (q *Queries) SelectExpiredCards(ctx context.Context, deletedAt *int64) ([]SelectExpiredCardsRow, error) {
+	rows, err := q.db.QueryContext(ctx, selectExpiredCards, deletedAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SelectExpiredCardsRow
+	for rows.Next() {
+		var i SelectExpiredCardsRow
+		if err := rows.Scan(&i.ID, &i.User); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const selectExpiredLogins = `-- name: SelectExpiredLogins :many
+SELECT id, user
+FROM login
+WHERE deleted_at <= ?
+`
+
+type SelectExpiredLoginsRow struct {
+	ID   int64
+	User string
+}
+
+func (q *Queries) SelectExpiredLogins(ctx context.Context, deletedAt *int64) ([]SelectExpiredLoginsRow, error) {
+	rows, err := q.db.QueryContext(ctx, selectExpiredLogins, deletedAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SelectExpiredLoginsRow
+	for rows.Next() {
+		var i SelectExpiredLoginsRow
+		if err := rows.Scan(&i.ID, &i.User); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const selectExpiredNotes = `-- name: SelectExpiredNotes :many
+SELECT id, user
+FROM note
+WHERE deleted_at <= ?
+`
+
+type SelectExpiredNotesRow struct {
+	ID   int64
+	User string
+}
+
+func (q *Queries) SelectExpiredNotes(ctx context.Context, deletedAt *int64) ([]SelectExpiredNotesRow, error) {
+	rows, err := q.db.QueryContext(ctx, selectExpiredNotes, deletedAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SelectExpiredNotesRow
+	for rows.Next() {
+		var i SelectExpiredNotesRow
+		if err := rows.Scan(&i.ID, &i.User); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const selectExpiredOTPs = `-- name: SelectExpiredOTPs :many
+SELECT id, user
+FROM otp
+WHERE deleted_at <= ?
+`
+
+type SelectExpiredOTPsRow struct {
+	ID   int64
+	User string
+}
+
+func (q *Queries) SelectExpiredOTPs(ctx context.Context, deletedAt *int64) ([]SelectExpiredOTPsRow, error) {
+	rows, err := q.db.QueryContext(ctx, selectExpiredOTPs, deletedAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SelectExpiredOTPsRow
+	for rows.Next() {
+		var i SelectExpiredOTPsRow
+		if err := rows.Scan(&i.ID, &i.User); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const selectExpiredUploadIDs = `-- name: SelectExpiredUploadIDs :many
+SELECT id
+FROM upload
+WHERE expires_at <= ?
+`
+
+func (q *Queries) SelectExpiredUploadIDs(ctx context.Context, expiresAt int64) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, selectExpiredUploadIDs, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const selectHistory = `-- name: SelectHistory :many
+SELECT id, kind, item_id, user, version, removed, data, created_at
+FROM history
+WHERE user = ?
+  AND kind = ?
+  AND item_id = ?
+  AND created_at >= ?
+ORDER BY id DESC
+`
+
+type SelectHistoryParams struct {
+	User      string
+	Kind      string
+	ItemID    int64
+	CreatedAt int64
+}
+
+func (q *Queries) SelectHistory(ctx context.Context, arg SelectHistoryParams) ([]History, error) {
+	rows, err := q.db.QueryContext(ctx, selectHistory,
+		arg.User,
+		arg.Kind,
+		arg.ItemID,
+		arg.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []History
+	for rows.Next() {
+		var i History
+		if err := rows.Scan(
+			&i.ID,
+			&i.Kind,
+			&i.ItemID,
+			&i.User,
+			&i.Version,
+			&i.Removed,
+			&i.Data,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const selectHistoryVersion = `-- name: SelectHistoryVersion :one
+SELECT id, kind, item_id, user, version, removed, data, created_at
+FROM history
+WHERE user = ?
+  AND kind = ?
+  AND item_id = ?
+  AND version = ?
+  AND created_at >= ?
+`
+
+type SelectHistoryVersionParams struct {
+	User      string
+	Kind      string
+	ItemID    int64
+	Version   int64
+	CreatedAt int64
+}
+
+func (q *Queries) SelectHistoryVersion(ctx context.Context, arg SelectHistoryVersionParams) (History, error) {
+	row := q.db.QueryRowContext(ctx, selectHistoryVersion,
+		arg.User,
+		arg.Kind,
+		arg.ItemID,
+		arg.Version,
+		arg.CreatedAt,
+	)
+	var i History
+	err := row.Scan(
+		&i.ID,
+		&i.Kind,
+		&i.ItemID,
+		&i.User,
+		&i.Version,
+		&i.Removed,
+		&i.Data,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const selectLegacyBinaryIDs = `-- name: SelectLegacyBinaryIDs :many
+SELECT id
+FROM binary
+WHERE blob IS NULL
+`
+
+func (q *Queries) SelectLegacyBinaryIDs(ctx context.Context) ([]int64, error) {
+	rows, err := q.db.QueryContext(ctx, selectLegacyBinaryIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const selectLogin = `-- name: SelectLogin :one
+SELECT id, name, login, password, website, notes, user, revision, deleted_at
+FROM login
+WHERE id = ?
+  AND user = ?
+  AND deleted_at IS NULL
+`
+
+func (q *Queries) SelectLogin(ctx context.Context, iD int64, user string) (Login, error) {
+	row := q.db.QueryRowContext(ctx, selectLogin, iD, user)
+	var i Login
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Login,
+		&i.Password,
+		&i.Website,
+		&i.Notes,
+		&i.User,
+		&i.Revision,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const selectLoginAttempt = `-- name: SelectLoginAttempt :one
+SELECT login, failures, locked_until, failed_at
+FROM login_attempt
+WHERE login = ?
+`
+
+func (q *Queries) SelectLoginAttempt(ctx context.Context, login string) (LoginAttempt, error) {
+	row := q.db.QueryRowContext(ctx, selectLoginAttempt, login)
+	var i LoginAttempt
+	err := row.Scan(
+		&i.Login,
+		&i.Failures,
+		&i.LockedUntil,
+		&i.FailedAt,
+	)
+	return i, err
+}
+
+const selectLoginRevision = `-- name: SelectLoginRevision :one
+SELECT revision
+FROM login
+WHERE id = ?
+  AND user = ?
+  AND deleted_at IS NULL
+`
+
+func (q *Queries) SelectLoginRevision(ctx context.Context, iD int64, user string) (int64, error) {
+	row := q.db.QueryRowContext(ctx, selectLoginRevision, iD, user)
+	var revision int64
+	err := row.Scan(&revision)
+	return revision, err
+}
+
+const selectLoginUser = `-- name: SelectLoginUser :one
+SELECT user
+FROM login
+WHERE id = ?
+`
+
+func (q *Queries) SelectLoginUser(ctx context.Context, id int64) (string, error) {
+	row := q.db.QueryRowContext(ctx, selectLoginUser, id)
+	var user string
+	err := row.Scan(&user)
+	return user, err
+}
+
+const selectLogins = `-- name: SelectLogins :many
+SELECT id, name, login, password, website, notes, user, revision, deleted_at
+FROM login
+WHERE user = ?
+  AND deleted_at IS NULL
+`
+
+func (q *Queries) SelectLogins(ctx context.Context, user string) ([]Login, error) {
+	rows, err := q.db.QueryContext(ctx, selectLogins, user)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Login
+	for rows.Next() {
+		var i Login
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Login,
+			&i.Password,
+			&i.Website,
+			&i.Notes,
+			&i.User,
+			&i.Revision,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const selectLoginsSince = `-- name: SelectLoginsSince :many
+SELECT id, name, login, password, website, notes, user, revision, deleted_at
+FROM login
+WHERE user = ?
+  AND revision > ?
+  AND deleted_at IS NULL
+`
+
+func (q *Queries) SelectLoginsSince(ctx context.Context, user string, revision int64) ([]Login, error) {
+	rows, err := q.db.QueryContext(ctx, selectLoginsSince, user, revision)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Login
+	for rows.Next() {
+		var i Login
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Login,
+			&i.Password,
+			&i.Website,
+			&i.Notes,
+			&i.User,
+			&i.Revision,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const selectNote = `-- name: SelectNote :one
+SELECT id, name, text, user, revision, deleted_at
+FROM note
+WHERE id = ?
+  AND user = ?
+  AND deleted_at IS NULL
+`
+
+func (q *Queries) SelectNote(ctx context.Context, iD int64, user string) (Note, error) {
+	row := q.db.QueryRowContext(ctx, selectNote, iD, user)
+	var i Note
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Text,
 		&i.User,
+		&i.Revision,
+		&i.DeletedAt,
 	)
 	return i, err
 }
 
-const selectCardUser = `-- name: SelectCardUser :one
+const selectNoteRevision = `-- name: SelectNoteRevision :one
+SELECT revision
+FROM note
+WHERE id = ?
+  AND user = ?
+  AND deleted_at IS NULL
+`
+
+func (q *Queries) SelectNoteRevision(ctx context.Context, iD int64, user string) (int64, error) {
+	row := q.db.QueryRowContext(ctx, selectNoteRevision, iD, user)
+	var revision int64
+	err := row.Scan(&revision)
+	return revision, err
+}
+
+const selectNoteUser = `-- name: SelectNoteUser :one
 SELECT user
-FROM card
+FROM note
 WHERE id = ?
 `
 
-func (q *Queries) SelectCardUser(ctx context.Context, id int64) (string, error) {
-	row := q.db.QueryRowContext(ctx, selectCardUser, id)
+func (q *Queries) SelectNoteUser(ctx context.Context, id int64) (string, error) {
+	row := q.db.QueryRowContext(ctx, selectNoteUser, id)
 	var user string
 	err := row.Scan(&user)
 	return user, err
 }
 
-const selectCards = `-- name: SelectCards :many
-SELECT id, name, number, exp_date, cvv, cardholder, notes, user
+const selectNotes = `-- name: SelectNotes :many
+SELECT id, name, text, user, revision, deleted_at
+FROM note
+WHERE user = ?
+  AND deleted_at IS NULL
+`
+
+func (q *Queries) SelectNotes(ctx context.Context, user string) ([]Note, error) {
+	rows, err := q.db.QueryContext(ctx, selectNotes, user)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Note
+	for rows.Next() {
+		var i Note
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Text,
+			&i.User,
+			&i.Revision,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const selectNotesSince = `-- name: SelectNotesSince :many
+SELECT id, name, text, user, revision, deleted_at
+FROM note
+WHERE user = ?
+  AND revision > ?
+  AND deleted_at IS NULL
+`
+
+func (q *Queries) SelectNotesSince(ctx context.Context, user string, revision int64) ([]Note, error) {
+	rows, err := q.db.QueryContext(ctx, selectNotesSince, user, revision)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Note
+	for rows.Next() {
+		var i Note
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Text,
+			&i.User,
+			&i.Revision,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const selectOTP = `-- name: SelectOTP :one
+SELECT id, name, type, secret, algorithm, digits, period, counter, issuer, user, revision, deleted_at
+FROM otp
+WHERE id = ?
+  AND user = ?
+  AND deleted_at IS NULL
+`
+
+func (q *Queries) SelectOTP(ctx context.Context, iD int64, user string) (OTP, error) {
+	row := q.db.QueryRowContext(ctx, selectOTP, iD, user)
+	var i OTP
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Type,
+		&i.Secret,
+		&i.Algorithm,
+		&i.Digits,
+		&i.Period,
+		&i.Counter,
+		&i.Issuer,
+		&i.User,
+		&i.Revision,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const selectOTPRevision = `-- name: SelectOTPRevision :one
+SELECT revision
+FROM otp
+WHERE id = ?
+  AND user = ?
+  AND deleted_at IS NULL
+`
+
+func (q *Queries) SelectOTPRevision(ctx context.Context, iD int64, user string) (int64, error) {
+	row := q.db.QueryRowContext(ctx, selectOTPRevision, iD, user)
+	var revision int64
+	err := row.Scan(&revision)
+	return revision, err
+}
+
+const selectOTPs = `-- name: SelectOTPs :many
+SELECT id, name, type, secret, algorithm, digits, period, counter, issuer, user, revision, deleted_at
+FROM otp
+WHERE user = ?
+  AND deleted_at IS NULL
+`
+
+func (q *Queries) SelectOTPs(ctx context.Context, user string) ([]OTP, error) {
+	rows, err := q.db.QueryContext(ctx, selectOTPs, user)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []OTP
+	for rows.Next() {
+		var i OTP
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Type,
+			&i.Secret,
+			&i.Algorithm,
+			&i.Digits,
+			&i.Period,
+			&i.Counter,
+			&i.Issuer,
+			&i.User,
+			&i.Revision,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const selectOTPsSince = `-- name: SelectOTPsSince :many
+SELECT id, name, type, secret, algorithm, digits, period, counter, issuer, user, revision, deleted_at
+FROM otp
+WHERE user = ?
+  AND revision > ?
+  AND deleted_at IS NULL
+`
+
+func (q *Queries) SelectOTPsSince(ctx context.Context, user string, revision int64) ([]OTP, error) {
+	rows, err := q.db.QueryContext(ctx, selectOTPsSince, user, revision)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []OTP
+	for rows.Next() {
+		var i OTP
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Type,
+			&i.Secret,
+			&i.Algorithm,
+			&i.Digits,
+			&i.Period,
+			&i.Counter,
+			&i.Issuer,
+			&i.User,
+			&i.Revision,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const selectSession = `-- name: SelectSession :one
+SELECT id, user, refresh_token_hash, created_at, last_used_at, expires_at
+FROM session
+WHERE id = ?
+`
+
+func (q *Queries) SelectSession(ctx context.Context, id string) (Session, error) {
+	row := q.db.QueryRowContext(ctx, selectSession, id)
+	var i Session
+	err := row.Scan(
+		&i.ID,
+		&i.User,
+		&i.RefreshTokenHash,
+		&i.CreatedAt,
+		&i.LastUsedAt,
+		&i.ExpiresAt,
+	)
+	return i, err
+}
+
+const selectSessions = `-- name: SelectSessions :many
+SELECT id, user, refresh_token_hash, created_at, last_used_at, expires_at
+FROM session
+WHERE user = ?
+ORDER BY created_at
+`
+
+func (q *Queries) SelectSessions(ctx context.Context, user string) ([]Session, error) {
+	rows, err := q.db.QueryContext(ctx, selectSessions, user)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Session
+	for rows.Next() {
+		var i Session
+		if err := rows.Scan(
+			&i.ID,
+			&i.User,
+			&i.RefreshTokenHash,
+			&i.CreatedAt,
+			&i.LastUsedAt,
+			&i.ExpiresAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const selectTombstonesSince = `-- name: SelectTombstonesSince :many
+SELECT kind, id, user, revision
+FROM tombstone
+WHERE user = ?
+  AND revision > ?
+`
+
+func (q *Queries) SelectTombstonesSince(ctx context.Context, user string, revision int64) ([]Tombstone, error) {
+	rows, err := q.db.QueryContext(ctx, selectTombstonesSince, user, revision)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Tombstone
+	for rows.Next() {
+		var i Tombstone
+		if err := rows.Scan(
+			&i.Kind,
+			&i.ID,
+			&i.User,
+			&i.Revision,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const selectTrashedBinaries = `-- name: SelectTrashedBinaries :many
+SELECT id, name, filename, size, notes, user, revision, sha256, blob, compression, deleted_at
+FROM binary
+WHERE user = ?
+  AND deleted_at IS NOT NULL
+`
+
+func (q *Queries) SelectTrashedBinaries(ctx context.Context, user string) ([]Binary, error) {
+	rows, err := q.db.QueryContext(ctx, selectTrashedBinaries, user)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Binary
+	for rows.Next() {
+		var i Binary
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Filename,
+			&i.Size,
+			&i.Notes,
+			&i.User,
+			&i.Revision,
+			&i.Sha256,
+			&i.Blob,
+			&i.Compression,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const selectTrashedBinary = `-- name: SelectTrashedBinary :one
+SELECT id, name, filename, size, notes, user, revision, sha256, blob, compression, deleted_at
+FROM binary
+WHERE id = ?
+  AND user = ?
+  AND deleted_at IS NOT NULL
+`
+
+func (q *Queries) SelectTrashedBinary(ctx context.Context, iD int64, user string) (Binary, error) {
+	row := q.db.QueryRowContext(ctx, selectTrashedBinary, iD, user)
+	var i Binary
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Filename,
+		&i.Size,
+		&i.Notes,
+		&i.User,
+		&i.Revision,
+		&i.Sha256,
+		&i.Blob,
+		&i.Compression,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const selectTrashedCards = `-- name: SelectTrashedCards :many
+SELECT id, name, number, exp_date, cvv, cardholder, notes, user, revision, deleted_at
 FROM card
 WHERE user = ?
+  AND deleted_at IS NOT NULL
 `
 
-func (q *Queries) SelectCards(ctx context.Context, user string) ([]Card, error) {
-	rows, err := q.db.QueryContext(ctx, selectCards, user)
+func (q *Queries) SelectTrashedCards(ctx context.Context, user string) ([]Card, error) {
+	rows, err := q.db.QueryContext(ctx, selectTrashedCards, user)
 	if err != nil {
 		return nil, err
 	}
@@ -315,6 +2328,8 @@ func (q *Queries) SelectCards(ctx context.Context, user string) ([]Card, error)
 			&i.Cardholder,
 			&i.Notes,
 			&i.User,
+			&i.Revision,
+			&i.DeletedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -329,64 +2344,112 @@ func (q *Queries) SelectCards(ctx context.Context, user string) ([]Card, error)
 	return items, nil
 }
 
-const selectLogin = `-- name: SelectLogin :one
-SELECT id, name, login, password, website, notes, user
+const selectTrashedLogins = `-- name: SelectTrashedLogins :many
+SELECT id, name, login, password, website, notes, user, revision, deleted_at
 FROM login
-WHERE id = ?
-  AND user = ?
+WHERE user = ?
+  AND deleted_at IS NOT NULL
 `
 
-func (q *Queries) SelectLogin(ctx context.Context, iD int64, user string) (Login, error) {
-	row := q.db.QueryRowContext(ctx, selectLogin, iD, user)
-	var i Login
-	err := row.Scan(
-		&i.ID,
-		&i.Name,
-		&i.Login,
-		&i.Password,
-		&i.Website,
-		&i.Notes,
-		&i.User,
-	)
-	return i, err
+func (q *Queries) SelectTrashedLogins(ctx context.Context, user string) ([]Login, error) {
+	rows, err := q.db.QueryContext(ctx, selectTrashedLogins, user)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Login
+	for rows.Next() {
+		var i Login
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Login,
+			&i.Password,
+			&i.Website,
+			&i.Notes,
+			&i.User,
+			&i.Revision,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
-const selectLoginUser = `-- name: SelectLoginUser :one
-SELECT user
-FROM login
-WHERE id = ?
+const selectTrashedNotes = `-- name: SelectTrashedNotes :many
+SELECT id, name, text, user, revision, deleted_at
+FROM note
+WHERE user = ?
+  AND deleted_at IS NOT NULL
 `
 
-func (q *Queries) SelectLoginUser(ctx context.Context, id int64) (string, error) {
-	row := q.db.QueryRowContext(ctx, selectLoginUser, id)
-	var user string
-	err := row.Scan(&user)
-	return user, err
+func (q *Queries) SelectTrashedNotes(ctx context.Context, user string) ([]Note, error) {
+	rows, err := q.db.QueryContext(ctx, selectTrashedNotes, user)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Note
+	for rows.Next() {
+		var i Note
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Text,
+			&i.User,
+			&i.Revision,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
-const selectLogins = `-- name: SelectLogins :many
-SELECT id, name, login, password, website, notes, user
-FROM login
+const selectTrashedOTPs = `-- name: SelectTrashedOTPs :many
+SELECT id, name, type, secret, algorithm, digits, period, counter, issuer, user, revision, deleted_at
+FROM otp
 WHERE user = ?
+  AND deleted_at IS NOT NULL
 `
 
-func (q *Queries) SelectLogins(ctx context.Context, user string) ([]Login, error) {
-	rows, err := q.db.QueryContext(ctx, selectLogins, user)
+func (q *Queries) SelectTrashedOTPs(ctx context.Context, user string) ([]OTP, error) {
+	rows, err := q.db.QueryContext(ctx, selectTrashedOTPs, user)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Login
+	var items []OTP
 	for rows.Next() {
-		var i Login
+		var i OTP
 		if err := rows.Scan(
 			&i.ID,
 			&i.Name,
-			&i.Login,
-			&i.Password,
-			&i.Website,
-			&i.Notes,
+			&i.Type,
+			&i.Secret,
+			&i.Algorithm,
+			&i.Digits,
+			&i.Period,
+			&i.Counter,
+			&i.Issuer,
 			&i.User,
+			&i.Revision,
+			&i.DeletedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -401,58 +2464,110 @@ func (q *Queries) SelectLogins(ctx context.Context, user string) ([]Login, error
 	return items, nil
 }
 
-const selectNote = `-- name: SelectNote :one
-SELECT id, name, text, user
-FROM note
+const selectUpload = `-- name: SelectUpload :one
+SELECT id, user, name, filename, size, notes, content_size, sha256, expires_at, compression, content_key
+FROM upload
 WHERE id = ?
   AND user = ?
 `
 
-func (q *Queries) SelectNote(ctx context.Context, iD int64, user string) (Note, error) {
-	row := q.db.QueryRowContext(ctx, selectNote, iD, user)
-	var i Note
+func (q *Queries) SelectUpload(ctx context.Context, iD string, user string) (Upload, error) {
+	row := q.db.QueryRowContext(ctx, selectUpload, iD, user)
+	var i Upload
 	err := row.Scan(
 		&i.ID,
-		&i.Name,
-		&i.Text,
 		&i.User,
+		&i.Name,
+		&i.Filename,
+		&i.Size,
+		&i.Notes,
+		&i.ContentSize,
+		&i.Sha256,
+		&i.ExpiresAt,
+		&i.Compression,
+		&i.ContentKey,
 	)
 	return i, err
 }
 
-const selectNoteUser = `-- name: SelectNoteUser :one
-SELECT user
-FROM note
-WHERE id = ?
+const selectUploadIDs = `-- name: SelectUploadIDs :many
+SELECT id
+FROM upload
+WHERE user = ?
 `
 
-func (q *Queries) SelectNoteUser(ctx context.Context, id int64) (string, error) {
-	row := q.db.QueryRowContext(ctx, selectNoteUser, id)
-	var user string
-	err := row.Scan(&user)
-	return user, err
+func (q *Queries) SelectUploadIDs(ctx context.Context, user string) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, selectUploadIDs, user)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
-const selectNotes = `-- name: SelectNotes :many
-SELECT id, name, text, user
-FROM note
+const selectUser = `-- name: SelectUser :one
+SELECT login, password, vault_key, revision, mfa_secret, mfa_enabled, mfa_pending_secret, mfa_last_step
+FROM user
+WHERE login = ?
+`
+
+func (q *Queries) SelectUser(ctx context.Context, login string) (User, error) {
+	row := q.db.QueryRowContext(ctx, selectUser, login)
+	var i User
+	err := row.Scan(
+		&i.Login,
+		&i.Password,
+		&i.VaultKey,
+		&i.Revision,
+		&i.MfaSecret,
+		&i.MfaEnabled,
+		&i.MfaPendingSecret,
+		&i.MfaLastStep,
+	)
+	return i, err
+}
+
+const selectUserBinaries = `-- name: SelectUserBinaries :many
+SELECT id, name, filename, size, notes, user, revision, sha256, blob, compression, deleted_at
+FROM binary
 WHERE user = ?
 `
 
-func (q *Queries) SelectNotes(ctx context.Context, user string) ([]Note, error) {
-	rows, err := q.db.QueryContext(ctx, selectNotes, user)
+func (q *Queries) SelectUserBinaries(ctx context.Context, user string) ([]Binary, error) {
+	rows, err := q.db.QueryContext(ctx, selectUserBinaries, user)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Note
+	var items []Binary
 	for rows.Next() {
-		var i Note
+		var i Binary
 		if err := rows.Scan(
 			&i.ID,
 			&i.Name,
-			&i.Text,
+			&i.Filename,
+			&i.Size,
+			&i.Notes,
 			&i.User,
+			&i.Revision,
+			&i.Sha256,
+			&i.Blob,
+			&i.Compression,
+			&i.DeletedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -467,17 +2582,213 @@ func (q *Queries) SelectNotes(ctx context.Context, user string) ([]Note, error)
 	return items, nil
 }
 
-const selectUser = `-- name: SelectUser :one
-SELECT login, password
+const selectUserContentSize = `-- name: SelectUserContentSize :one
+SELECT CAST(COALESCE(SUM(COALESCE(blob.size, binary.size)), 0) AS INTEGER) AS size
+FROM binary
+         LEFT JOIN blob ON blob.sha256 = binary.blob
+WHERE binary.user = ?
+`
+
+func (q *Queries) SelectUserContentSize(ctx context.Context, user string) (int64, error) {
+	row := q.db.QueryRowContext(ctx, selectUserContentSize, user)
+	var size int64
+	err := row.Scan(&size)
+	return size, err
+}
+
+const selectUserItemCount = `-- name: SelectUserItemCount :one
+SELECT (SELECT COUNT(*) FROM login WHERE login.user = ?1)
+           + (SELECT COUNT(*) FROM note WHERE note.user = ?1)
+           + (SELECT COUNT(*) FROM binary WHERE binary.user = ?1)
+           + (SELECT COUNT(*) FROM card WHERE card.user = ?1)
+           + (SELECT COUNT(*) FROM otp WHERE otp.user = ?1) AS count
+`
+
+func (q *Queries) SelectUserItemCount(ctx context.Context, user string) (int64, error) {
+	row := q.db.QueryRowContext(ctx, selectUserItemCount, user)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const selectUserPendingUploads = `-- name: SelectUserPendingUploads :one
+SELECT COUNT(*)                                             AS count,
+       CAST(COALESCE(SUM(content_size), 0) AS INTEGER) AS size
+FROM upload
+WHERE user = ?
+`
+
+type SelectUserPendingUploadsRow struct {
+	Count int64
+	Size  int64
+}
+
+func (q *Queries) SelectUserPendingUploads(ctx context.Context, user string) (SelectUserPendingUploadsRow, error) {
+	row := q.db.QueryRowContext(ctx, selectUserPendingUploads, user)
+	var i SelectUserPendingUploadsRow
+	err := row.Scan(
+		&i.Count,
+		&i.Size,
+	)
+	return i, err
+}
+
+const selectUserRevision = `-- name: SelectUserRevision :one
+SELECT revision
 FROM user
 WHERE login = ?
 `
 
-func (q *Queries) SelectUser(ctx context.Context, login string) (User, error) {
-	row := q.db.QueryRowContext(ctx, selectUser, login)
-	var i User
-	err := row.Scan(&i.Login, &i.Password)
-	return i, err
+func (q *Queries) SelectUserRevision(ctx context.Context, login string) (int64, error) {
+	row := q.db.QueryRowContext(ctx, selectUserRevision, login)
+	var revision int64
+	err := row.Scan(&revision)
+	return revision, err
+}
+
+const trashBinary = `-- name: TrashBinary :execrows
+UPDATE binary
+SET deleted_at = ?
+WHERE id = ?
+  AND user = ?
+  AND revision = ?
+  AND deleted_at IS NULL
+`
+
+type TrashBinaryParams struct {
+	DeletedAt *int64
+	ID        int64
+	User      string
+	Revision  int64
+}
+
+func (q *Queries) TrashBinary(ctx context.Context, arg TrashBinaryParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, trashBinary,
+		arg.DeletedAt,
+		arg.ID,
+		arg.User,
+		arg.Revision,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const trashCard = `-- name: TrashCard :execrows
+UPDATE card
+SET deleted_at = ?
+WHERE id = ?
+  AND user = ?
+  AND revision = ?
+  AND deleted_at IS NULL
+`
+
+type TrashCardParams struct {
+	DeletedAt *int64
+	ID        int64
+	User      string
+	Revision  int64
+}
+
+func (q *Queries) TrashCard(ctx context.Context, arg TrashCardParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, trashCard,
+		arg.DeletedAt,
+		arg.ID,
+		arg.User,
+		arg.Revision,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const trashLogin = `-- name: TrashLogin :execrows
+UPDATE login
+SET deleted_at = ?
+WHERE id = ?
+  AND user = ?
+  AND revision = ?
+  AND deleted_at IS NULL
+`
+
+type TrashLoginParams struct {
+	DeletedAt *int64
+	ID        int64
+	User      string
+	Revision  int64
+}
+
+func (q *Queries) TrashLogin(ctx context.Context, arg TrashLoginParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, trashLogin,
+		arg.DeletedAt,
+		arg.ID,
+		arg.User,
+		arg.Revision,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const trashNote = `-- name: TrashNote :execrows
+UPDATE note
+SET deleted_at = ?
+WHERE id = ?
+  AND user = ?
+  AND revision = ?
+  AND deleted_at IS NULL
+`
+
+type TrashNoteParams struct {
+	DeletedAt *int64
+	ID        int64
+	User      string
+	Revision  int64
+}
+
+func (q *Queries) TrashNote(ctx context.Context, arg TrashNoteParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, trashNote,
+		arg.DeletedAt,
+		arg.ID,
+		arg.User,
+		arg.Revision,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const trashOTP = `-- name: TrashOTP :execrows
+UPDATE otp
+SET deleted_at = ?
+WHERE id = ?
+  AND user = ?
+  AND revision = ?
+  AND deleted_at IS NULL
+`
+
+type TrashOTPParams struct {
+	DeletedAt *int64
+	ID        int64
+	User      string
+	Revision  int64
+}
+
+func (q *Queries) TrashOTP(ctx context.Context, arg TrashOTPParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, trashOTP,
+		arg.DeletedAt,
+		arg.ID,
+		arg.User,
+		arg.Revision,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
 }
 
 const updateBinary = `-- name: UpdateBinary :execrows
@@ -485,22 +2796,90 @@ UPDATE binary
 SET name  = ?,
     notes = ?
 WHERE id = ?
+  AND revision = ?
+  AND deleted_at IS NULL
 `
 
 type UpdateBinaryParams struct {
-	Name  string
-	Notes *string
-	ID    int64
+	Name     string
+	Notes    *string
+	ID       int64
+	Revision int64
 }
 
 func (q *Queries) UpdateBinary(ctx context.Context, arg UpdateBinaryParams) (int64, error) {
-	result, err := q.db.ExecContext(ctx, updateBinary, arg.Name, arg.Notes, arg.ID)
+	result, err := q.db.ExecContext(ctx, updateBinary,
+		arg.Name,
+		arg.Notes,
+		arg.ID,
+		arg.Revision,
+	)
 	if err != nil {
 		return 0, err
 	}
 	return result.RowsAffected()
 }
 
+const updateBinaryContent = `-- name: UpdateBinaryContent :exec
+UPDATE binary
+SET sha256 = ?,
+    blob   = ?
+WHERE id = ?
+`
+
+type UpdateBinaryContentParams struct {
+	Sha256 []byte
+	Blob   []byte
+	ID     int64
+}
+
+func (q *Queries) UpdateBinaryContent(ctx context.Context, arg UpdateBinaryContentParams) error {
+	_, err := q.db.ExecContext(ctx, updateBinaryContent, arg.Sha256, arg.Blob, arg.ID)
+	return err
+}
+
+const updateBinaryRevision = `-- name: UpdateBinaryRevision :exec
+UPDATE binary
+SET revision = ?
+WHERE id = ?
+`
+
+func (q *Queries) UpdateBinaryRevision(ctx context.Context, revision int64, iD int64) error {
+	_, err := q.db.ExecContext(ctx, updateBinaryRevision, revision, iD)
+	return err
+}
+
+const updateBinarySealed = `-- name: UpdateBinarySealed :exec
+UPDATE binary
+SET name     = ?,
+    filename = ?,
+    notes    = ?
+WHERE id = ?
+`
+
+type UpdateBinarySealedParams struct {
+	Name     string
+	Filename string
+	Notes    *string
+	ID       int64
+}
+
+func (q *Queries) UpdateBinarySealed(ctx context.Context, arg UpdateBinarySealedParams) error {
+	_, err := q.db.ExecContext(ctx, updateBinarySealed, arg.Name, arg.Filename, arg.Notes, arg.ID)
+	return err
+}
+
+const updateBinarySha256 = `-- name: UpdateBinarySha256 :exec
+UPDATE binary
+SET sha256 = ?
+WHERE id = ?
+`
+
+func (q *Queries) UpdateBinarySha256(ctx context.Context, sha256 []byte, iD int64) error {
+	_, err := q.db.ExecContext(ctx, updateBinarySha256, sha256, iD)
+	return err
+}
+
 const updateCard = `-- name: UpdateCard :execrows
 UPDATE card
 SET name       = ?,
@@ -510,6 +2889,8 @@ SET name       = ?,
     cardholder = ?,
     notes      = ?
 WHERE id = ?
+  AND revision = ?
+  AND deleted_at IS NULL
 `
 
 type UpdateCardParams struct {
@@ -520,6 +2901,7 @@ type UpdateCardParams struct {
 	Cardholder string
 	Notes      *string
 	ID         int64
+	Revision   int64
 }
 
 func (q *Queries) UpdateCard(ctx context.Context, arg UpdateCardParams) (int64, error) {
@@ -531,6 +2913,7 @@ func (q *Queries) UpdateCard(ctx context.Context, arg UpdateCardParams) (int64,
 		arg.Cardholder,
 		arg.Notes,
 		arg.ID,
+		arg.Revision,
 	)
 	if err != nil {
 		return 0, err
@@ -538,6 +2921,54 @@ func (q *Queries) UpdateCard(ctx context.Context, arg UpdateCardParams) (int64,
 	return result.RowsAffected()
 }
 
+const updateCardRevision = `-- name: UpdateCardRevision :exec
+UPDATE card
+SET revision = ?
+WHERE id = ?
+`
+
+func (q *Queries) UpdateCardRevision(ctx context.Context, revision int64, iD int64) error {
+	_, err := q.db.ExecContext(ctx, updateCardRevision, revision, iD)
+	return err
+}
+
+const updateCardSealed = `-- name: UpdateCardSealed :exec
+UPDATE card
+SET name       = ?,
+    number     = ?,
+    exp_date   = ?,
+    cvv        = ?,
+    cardholder = ?,
+    notes      = ?
+WHERE id = ?
+`
+
+type UpdateCardSealedParams struct {
+	Name       string
+	Number     string
+	ExpDate    string
+	Cvv        string
+	Cardholder string
+	Notes      *string
+	ID         int64
+}
+
+func (q *Queries) UpdateCardSealed(ctx context.Context, arg UpdateCardSealedParams) error {
+	_, err := q.db.ExecContext(ctx, updateCardSealed, arg.Name, arg.Number, arg.ExpDate, arg.Cvv, arg.Cardholder, arg.Notes, arg.ID)
+	return err
+}
+
+const updateHistorySealed = `-- name: UpdateHistorySealed :exec
+UPDATE history
+SET data = ?
+WHERE id = ?
+`
+
+func (q *Queries) UpdateHistorySealed(ctx context.Context, data string, iD int64) error {
+	_, err := q.db.ExecContext(ctx, updateHistorySealed, data, iD)
+	return err
+}
+
 const updateLogin = `-- name: UpdateLogin :execrows
 UPDATE login
 SET name     = ?,
@@ -546,6 +2977,8 @@ SET name     = ?,
     website  = ?,
     notes    = ?
 WHERE id = ?
+  AND revision = ?
+  AND deleted_at IS NULL
 `
 
 type UpdateLoginParams struct {
@@ -555,6 +2988,7 @@ type UpdateLoginParams struct {
 	Website  *string
 	Notes    *string
 	ID       int64
+	Revision int64
 }
 
 func (q *Queries) UpdateLogin(ctx context.Context, arg UpdateLoginParams) (int64, error) {
@@ -565,6 +2999,7 @@ func (q *Queries) UpdateLogin(ctx context.Context, arg UpdateLoginParams) (int64
 		arg.Website,
 		arg.Notes,
 		arg.ID,
+		arg.Revision,
 	)
 	if err != nil {
 		return 0, err
@@ -572,23 +3007,341 @@ func (q *Queries) UpdateLogin(ctx context.Context, arg UpdateLoginParams) (int64
 	return result.RowsAffected()
 }
 
+const updateLoginAttemptLock = `-- name: UpdateLoginAttemptLock :exec
+UPDATE login_attempt
+SET locked_until = ?
+WHERE login = ?
+`
+
+func (q *Queries) UpdateLoginAttemptLock(ctx context.Context, lockedUntil int64, login string) error {
+	_, err := q.db.ExecContext(ctx, updateLoginAttemptLock, lockedUntil, login)
+	return err
+}
+
+const updateLoginRevision = `-- name: UpdateLoginRevision :exec
+UPDATE login
+SET revision = ?
+WHERE id = ?
+`
+
+func (q *Queries) UpdateLoginRevision(ctx context.Context, revision int64, iD int64) error {
+	_, err := q.db.ExecContext(ctx, updateLoginRevision, revision, iD)
+	return err
+}
+
+const updateLoginSealed = `-- name: UpdateLoginSealed :exec
+UPDATE login
+SET name     = ?,
+    login    = ?,
+    password = ?,
+    website  = ?,
+    notes    = ?
+WHERE id = ?
+`
+
+type UpdateLoginSealedParams struct {
+	Name     string
+	Login    string
+	Password *string
+	Website  *string
+	Notes    *string
+	ID       int64
+}
+
+func (q *Queries) UpdateLoginSealed(ctx context.Context, arg UpdateLoginSealedParams) error {
+	_, err := q.db.ExecContext(ctx, updateLoginSealed, arg.Name, arg.Login, arg.Password, arg.Website, arg.Notes, arg.ID)
+	return err
+}
+
 const updateNote = `-- name: UpdateNote :execrows
 UPDATE note
 SET name = ?,
     text = ?
 WHERE id = ?
+  AND revision = ?
+  AND deleted_at IS NULL
 `
 
 type UpdateNoteParams struct {
+	Name     string
+	Text     *string
+	ID       int64
+	Revision int64
+}
+
+func (q *Queries) UpdateNote(ctx context.Context, arg UpdateNoteParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateNote,
+		arg.Name,
+		arg.Text,
+		arg.ID,
+		arg.Revision,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const updateNoteRevision = `-- name: UpdateNoteRevision :exec
+UPDATE note
+SET revision = ?
+WHERE id = ?
+`
+
+func (q *Queries) UpdateNoteRevision(ctx context.Context, revision int64, iD int64) error {
+	_, err := q.db.ExecContext(ctx, updateNoteRevision, revision, iD)
+	return err
+}
+
+const updateNoteSealed = `-- name: UpdateNoteSealed :exec
+UPDATE note
+SET name = ?,
+    text = ?
+WHERE id = ?
+`
+
+type UpdateNoteSealedParams struct {
 	Name string
 	Text *string
 	ID   int64
 }
 
-func (q *Queries) UpdateNote(ctx context.Context, arg UpdateNoteParams) (int64, error) {
-	result, err := q.db.ExecContext(ctx, updateNote, arg.Name, arg.Text, arg.ID)
+func (q *Queries) UpdateNoteSealed(ctx context.Context, arg UpdateNoteSealedParams) error {
+	_, err := q.db.ExecContext(ctx, updateNoteSealed, arg.Name, arg.Text, arg.ID)
+	return err
+}
+
+const updateOTP = `-- name: UpdateOTP :execrows
+UPDATE otp
+SET name      = ?,
+    type      = ?,
+    secret    = ?,
+    algorithm = ?,
+    digits    = ?,
+    period    = ?,
+    counter   = ?,
+    issuer    = ?
+WHERE id = ?
+  AND revision = ?
+  AND deleted_at IS NULL
+`
+
+type UpdateOTPParams struct {
+	Name      string
+	Type      string
+	Secret    string
+	Algorithm string
+	Digits    int64
+	Period    int64
+	Counter   int64
+	Issuer    *string
+	ID        int64
+	Revision  int64
+}
+
+func (q *Queries) UpdateOTP(ctx context.Context, arg UpdateOTPParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateOTP,
+		arg.Name,
+		arg.Type,
+		arg.Secret,
+		arg.Algorithm,
+		arg.Digits,
+		arg.Period,
+		arg.Counter,
+		arg.Issuer,
+		arg.ID,
+		arg.Revision,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const updateOTPRevision = `-- name: UpdateOTPRevision :exec
+UPDATE otp
+SET revision = ?
+WHERE id = ?
+`
+
+func (q *Queries) UpdateOTPRevision(ctx context.Context, revision int64, iD int64) error {
+	_, err := q.db.ExecContext(ctx, updateOTPRevision, revision, iD)
+	return err
+}
+
+const updateOTPSealed = `-- name: UpdateOTPSealed :exec
+UPDATE otp
+SET name   = ?,
+    secret = ?,
+    issuer = ?
+WHERE id = ?
+`
+
+type UpdateOTPSealedParams struct {
+	Name   string
+	Secret string
+	Issuer *string
+	ID     int64
+}
+
+func (q *Queries) UpdateOTPSealed(ctx context.Context, arg UpdateOTPSealedParams) error {
+	_, err := q.db.ExecContext(ctx, updateOTPSealed, arg.Name, arg.Secret, arg.Issuer, arg.ID)
+	return err
+}
+
+const updateSessionToken = `-- name: UpdateSessionToken :execrows
+UPDATE session
+SET refresh_token_hash = ?,
+    last_used_at       = ?,
+    expires_at         = ?
+WHERE id = ?
+  AND refresh_token_hash = ?
+`
+
+type UpdateSessionTokenParams struct {
+	RefreshTokenHash    []byte
+	LastUsedAt          int64
+	ExpiresAt           int64
+	ID                  string
+	OldRefreshTokenHash []byte
+}
+
+func (q *Queries) UpdateSessionToken(ctx context.Context, arg UpdateSessionTokenParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateSessionToken,
+		arg.RefreshTokenHash,
+		arg.LastUsedAt,
+		arg.ExpiresAt,
+		arg.ID,
+		arg.OldRefreshTokenHash,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const updateUploadSealed = `-- name: UpdateUploadSealed :exec
+UPDATE upload
+SET name     = ?,
+    filename = ?,
+    notes    = ?
+WHERE id = ?
+`
+
+type UpdateUploadSealedParams struct {
+	Name     string
+	Filename string
+	Notes    *string
+	ID       string
+}
+
+func (q *Queries) UpdateUploadSealed(ctx context.Context, arg UpdateUploadSealedParams) error {
+	_, err := q.db.ExecContext(ctx, updateUploadSealed, arg.Name, arg.Filename, arg.Notes, arg.ID)
+	return err
+}
+
+const updateUserMFALastStep = `-- name: UpdateUserMFALastStep :execrows
+UPDATE user
+SET mfa_last_step = ?1
+WHERE login = ?2
+  AND mfa_enabled
+  AND mfa_last_step < ?1
+`
+
+func (q *Queries) UpdateUserMFALastStep(ctx context.Context, mfaLastStep int64, login string) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateUserMFALastStep, mfaLastStep, login)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const updateUserMFAPendingSecret = `-- name: UpdateUserMFAPendingSecret :execrows
+UPDATE user
+SET mfa_pending_secret = ?
+WHERE login = ?
+`
+
+func (q *Queries) UpdateUserMFAPendingSecret(ctx context.Context, mfaPendingSecret *string, login string) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateUserMFAPendingSecret, mfaPendingSecret, login)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const updateUserPassword = `-- name: UpdateUserPassword :execrows
+UPDATE user
+SET password  = ?,
+    vault_key = ?
+WHERE login = ?
+`
+
+type UpdateUserPasswordParams struct {
+	Password string
+	VaultKey []byte
+	Login    string
+}
+
+func (q *Queries) UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateUserPassword, arg.Password, arg.VaultKey, arg.Login)
 	if err != nil {
 		return 0, err
 	}
 	return result.RowsAffected()
 }
+
+const updateUserRevision = `-- name: UpdateUserRevision :exec
+UPDATE user
+SET revision = ?
+WHERE login = ?
+`
+
+func (q *Queries) UpdateUserRevision(ctx context.Context, revision int64, login string) error {
+	_, err := q.db.ExecContext(ctx, updateUserRevision, revision, login)
+	return err
+}
+
+const updateUserSealed = `-- name: UpdateUserSealed :exec
+UPDATE user
+SET mfa_secret         = ?,
+    mfa_pending_secret = ?
+WHERE login = ?
+`
+
+type UpdateUserSealedParams struct {
+	MfaSecret        *string
+	MfaPendingSecret *string
+	Login            string
+}
+
+func (q *Queries) UpdateUserSealed(ctx context.Context, arg UpdateUserSealedParams) error {
+	_, err := q.db.ExecContext(ctx, updateUserSealed, arg.MfaSecret, arg.MfaPendingSecret, arg.Login)
+	return err
+}
+
+const upsertBlobContent = `-- name: UpsertBlobContent :exec
+INSERT INTO blob_content (id, data)
+VALUES (?, ?)
+ON CONFLICT (id) DO UPDATE SET data = excluded.data
+`
+
+func (q *Queries) UpsertBlobContent(ctx context.Context, iD string, data []byte) error {
+	_, err := q.db.ExecContext(ctx, upsertBlobContent, iD, data)
+	return err
+}
+
+const upsertLoginAttemptFailure = `-- name: UpsertLoginAttemptFailure :one
+INSERT INTO login_attempt (login, failures, failed_at)
+VALUES (?, 1, ?)
+ON CONFLICT (login) DO UPDATE SET failures  = failures + 1,
+                                  failed_at = excluded.failed_at
+RETURNING failures
+`
+
+func (q *Queries) UpsertLoginAttemptFailure(ctx context.Context, login string, failedAt int64) (int64, error) {
+	row := q.db.QueryRowContext(ctx, upsertLoginAttemptFailure, login, failedAt)
+	var failures int64
+	err := row.Scan(&failures)
+	return failures, err
+}
@@ -4,8 +4,51 @@ FROM user
 WHERE login = ?;
 
 -- name: InsertUser :exec
-INSERT INTO user (login, password)
-VALUES (?, ?);
+INSERT INTO user (login, password, vault_key)
+VALUES (?, ?, ?);
+
+-- name: UpdateUserPassword :execrows
+UPDATE user
+SET password  = ?,
+    vault_key = ?
+WHERE login = ?;
+
+-- name: EnrollUserVaultKey :execrows
+UPDATE user
+SET password  = ?,
+    vault_key = ?
+WHERE login = ?
+  AND vault_key IS NULL;
+
+-- name: DeleteUser :execrows
+DELETE
+FROM user
+WHERE login = ?;
+
+-- name: DeleteUserLogins :exec
+DELETE
+FROM login
+WHERE user = ?;
+
+-- name: DeleteUserNotes :exec
+DELETE
+FROM note
+WHERE user = ?;
+
+-- name: DeleteUserBinaries :exec
+DELETE
+FROM binary
+WHERE user = ?;
+
+-- name: DeleteUserCards :exec
+DELETE
+FROM card
+WHERE user = ?;
+
+-- name: DeleteUserOTPs :exec
+DELETE
+FROM otp
+WHERE user = ?;
 
 -- name: InsertLogin :execlastid
 INSERT INTO login (name, login, password, website, notes, user)
@@ -18,29 +61,42 @@ SET name     = ?,
     password = ?,
     website  = ?,
     notes    = ?
-WHERE id = ?;
+WHERE id = ?
+  AND revision = ?
+  AND deleted_at IS NULL;
 
 -- name: SelectLogin :one
 SELECT *
 FROM login
 WHERE id = ?
-  AND user = ?;
+  AND user = ?
+  AND deleted_at IS NULL;
 
 -- name: SelectLoginUser :one
 SELECT user
 FROM login
 WHERE id = ?;
 
+-- name: SelectLoginRevision :one
+SELECT revision
+FROM login
+WHERE id = ?
+  AND user = ?
+  AND deleted_at IS NULL;
+
 -- name: SelectLogins :many
 SELECT *
 FROM login
-WHERE user = ?;
+WHERE user = ?
+  AND deleted_at IS NULL;
 
--- name: DeleteLogin :execrows
-DELETE
-FROM login
+-- name: TrashLogin :execrows
+UPDATE login
+SET deleted_at = ?
 WHERE id = ?
-  AND user = ?;
+  AND user = ?
+  AND revision = ?
+  AND deleted_at IS NULL;
 
 -- name: InsertNote :execlastid
 INSERT INTO note (name, text, user)
@@ -50,62 +106,104 @@ VALUES (?, ?, ?);
 UPDATE note
 SET name = ?,
     text = ?
-WHERE id = ?;
+WHERE id = ?
+  AND revision = ?
+  AND deleted_at IS NULL;
 
 -- name: SelectNote :one
 SELECT *
 FROM note
 WHERE id = ?
-  AND user = ?;
+  AND user = ?
+  AND deleted_at IS NULL;
 
 -- name: SelectNoteUser :one
 SELECT user
 FROM note
 WHERE id = ?;
 
+-- name: SelectNoteRevision :one
+SELECT revision
+FROM note
+WHERE id = ?
+  AND user = ?
+  AND deleted_at IS NULL;
+
 -- name: SelectNotes :many
 SELECT *
 FROM note
-WHERE user = ?;
+WHERE user = ?
+  AND deleted_at IS NULL;
 
--- name: DeleteNote :execrows
-DELETE
-FROM note
+-- name: TrashNote :execrows
+UPDATE note
+SET deleted_at = ?
 WHERE id = ?
-  AND user = ?;
+  AND user = ?
+  AND revision = ?
+  AND deleted_at IS NULL;
 
 -- name: InsertBinary :one
-INSERT INTO binary (name, filename, size, notes, user)
-VALUES (?, ?, ?, ?, ?)
+INSERT INTO binary (name, filename, size, notes, user, compression)
+VALUES (?, ?, ?, ?, ?, ?)
 RETURNING id;
 
 -- name: UpdateBinary :execrows
 UPDATE binary
 SET name  = ?,
     notes = ?
+WHERE id = ?
+  AND revision = ?
+  AND deleted_at IS NULL;
+
+-- name: UpdateBinarySha256 :exec
+UPDATE binary
+SET sha256 = ?
+WHERE id = ?;
+
+-- name: UpdateBinaryContent :exec
+UPDATE binary
+SET sha256 = ?,
+    blob   = ?
 WHERE id = ?;
 
 -- name: SelectBinary :one
 SELECT *
 FROM binary
 WHERE id = ?
-  AND user = ?;
+  AND user = ?
+  AND deleted_at IS NULL;
 
 -- name: SelectBinaryUser :one
 SELECT user
 FROM binary
 WHERE id = ?;
 
+-- name: SelectBinaryRevision :one
+SELECT revision
+FROM binary
+WHERE id = ?
+  AND user = ?
+  AND deleted_at IS NULL;
+
 -- name: SelectBinaries :many
 SELECT *
 FROM binary
-WHERE user = ?;
+WHERE user = ?
+  AND deleted_at IS NULL;
 
--- name: DeleteBinary :execrows
-DELETE
+-- name: SelectUserBinaries :many
+SELECT *
 FROM binary
+WHERE user = ?;
+
+-- name: TrashBinary :execrows
+UPDATE binary
+SET deleted_at = ?
 WHERE id = ?
-  AND user = ?;
+  AND user = ?
+  AND revision = ?
+  AND deleted_at IS NULL;
 
 -- name: InsertCard :execlastid
 INSERT INTO card (name, number, exp_date, cvv, cardholder, notes, user)
@@ -119,26 +217,635 @@ SET name       = ?,
     cvv        = ?,
     cardholder = ?,
     notes      = ?
-WHERE id = ?;
+WHERE id = ?
+  AND revision = ?
+  AND deleted_at IS NULL;
 
 -- name: SelectCard :one
 SELECT *
 FROM card
 WHERE id = ?
-  AND user = ?;
+  AND user = ?
+  AND deleted_at IS NULL;
 
 -- name: SelectCardUser :one
 SELECT user
 FROM card
 WHERE id = ?;
 
+-- name: SelectCardRevision :one
+SELECT revision
+FROM card
+WHERE id = ?
+  AND user = ?
+  AND deleted_at IS NULL;
+
 -- name: SelectCards :many
 SELECT *
 FROM card
+WHERE user = ?
+  AND deleted_at IS NULL;
+
+-- name: TrashCard :execrows
+UPDATE card
+SET deleted_at = ?
+WHERE id = ?
+  AND user = ?
+  AND revision = ?
+  AND deleted_at IS NULL;
+
+-- name: InsertOTP :execlastid
+INSERT INTO otp (name, type, secret, algorithm, digits, period, counter, issuer, user)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?);
+
+-- name: UpdateOTP :execrows
+UPDATE otp
+SET name      = ?,
+    type      = ?,
+    secret    = ?,
+    algorithm = ?,
+    digits    = ?,
+    period    = ?,
+    counter   = ?,
+    issuer    = ?
+WHERE id = ?
+  AND revision = ?
+  AND deleted_at IS NULL;
+
+-- name: SelectOTP :one
+SELECT *
+FROM otp
+WHERE id = ?
+  AND user = ?
+  AND deleted_at IS NULL;
+
+-- name: SelectOTPRevision :one
+SELECT revision
+FROM otp
+WHERE id = ?
+  AND user = ?
+  AND deleted_at IS NULL;
+
+-- name: SelectOTPs :many
+SELECT *
+FROM otp
+WHERE user = ?
+  AND deleted_at IS NULL;
+
+-- name: TrashOTP :execrows
+UPDATE otp
+SET deleted_at = ?
+WHERE id = ?
+  AND user = ?
+  AND revision = ?
+  AND deleted_at IS NULL;
+
+-- name: SelectUserRevision :one
+SELECT revision
+FROM user
+WHERE login = ?;
+
+-- name: SelectLoginsSince :many
+SELECT *
+FROM login
+WHERE user = ?
+  AND revision > ?
+  AND deleted_at IS NULL;
+
+-- name: SelectNotesSince :many
+SELECT *
+FROM note
+WHERE user = ?
+  AND revision > ?
+  AND deleted_at IS NULL;
+
+-- name: SelectBinariesSince :many
+SELECT *
+FROM binary
+WHERE user = ?
+  AND revision > ?
+  AND deleted_at IS NULL;
+
+-- name: SelectCardsSince :many
+SELECT *
+FROM card
+WHERE user = ?
+  AND revision > ?
+  AND deleted_at IS NULL;
+
+-- name: SelectOTPsSince :many
+SELECT *
+FROM otp
+WHERE user = ?
+  AND revision > ?
+  AND deleted_at IS NULL;
+
+-- name: SelectTombstonesSince :many
+SELECT *
+FROM tombstone
+WHERE user = ?
+  AND revision > ?;
+
+-- name: InsertSession :exec
+INSERT INTO session (id, user, refresh_token_hash, created_at, last_used_at, expires_at)
+VALUES (?, ?, ?, ?, ?, ?);
+
+-- name: SelectSession :one
+SELECT *
+FROM session
+WHERE id = ?;
+
+-- name: SelectSessions :many
+SELECT *
+FROM session
+WHERE user = ?
+ORDER BY created_at;
+
+-- name: UpdateSessionToken :execrows
+UPDATE session
+SET refresh_token_hash = ?,
+    last_used_at       = ?,
+    expires_at         = ?
+WHERE id = ?
+  AND refresh_token_hash = sqlc.arg(old_refresh_token_hash);
+
+-- name: DeleteSession :execrows
+DELETE
+FROM session
+WHERE id = ?
+  AND user = ?;
+
+-- name: DeleteExpiredSessions :exec
+DELETE
+FROM session
+WHERE user = ?
+  AND expires_at <= ?;
+
+-- name: UpdateUserMFAPendingSecret :execrows
+UPDATE user
+SET mfa_pending_secret = ?
+WHERE login = ?;
+
+-- name: EnableUserMFA :execrows
+UPDATE user
+SET mfa_secret         = ?,
+    mfa_pending_secret = NULL,
+    mfa_enabled        = TRUE,
+    mfa_last_step      = ?
+WHERE login = ?
+  AND NOT mfa_enabled;
+
+-- name: DisableUserMFA :execrows
+UPDATE user
+SET mfa_secret         = NULL,
+    mfa_pending_secret = NULL,
+    mfa_enabled        = FALSE,
+    mfa_last_step      = 0
+WHERE login = ?;
+
+-- name: UpdateUserMFALastStep :execrows
+UPDATE user
+SET mfa_last_step = sqlc.arg(mfa_last_step)
+WHERE login = sqlc.arg(login)
+  AND mfa_enabled
+  AND mfa_last_step < sqlc.arg(mfa_last_step);
+
+-- name: InsertRecoveryCode :exec
+INSERT INTO recovery_code (user, code_hash)
+VALUES (?, ?);
+
+-- name: DeleteRecoveryCode :execrows
+DELETE
+FROM recovery_code
+WHERE user = ?
+  AND code_hash = ?;
+
+-- name: DeleteRecoveryCodes :exec
+DELETE
+FROM recovery_code
 WHERE user = ?;
 
--- name: DeleteCard :execrows
+-- name: SelectLoginAttempt :one
+SELECT *
+FROM login_attempt
+WHERE login = ?;
+
+-- name: UpsertLoginAttemptFailure :one
+INSERT INTO login_attempt (login, failures, failed_at)
+VALUES (?, 1, ?)
+ON CONFLICT (login) DO UPDATE SET failures  = failures + 1,
+                                  failed_at = excluded.failed_at
+RETURNING failures;
+
+-- name: UpdateLoginAttemptLock :exec
+UPDATE login_attempt
+SET locked_until = ?
+WHERE login = ?;
+
+-- name: DeleteLoginAttempt :exec
 DELETE
-FROM card
+FROM login_attempt
+WHERE login = ?;
+
+-- name: DeleteExpiredLoginAttempts :exec
+DELETE
+FROM login_attempt
+WHERE failed_at < sqlc.arg(before)
+  AND locked_until < sqlc.arg(before);
+
+-- name: InsertUpload :exec
+INSERT INTO upload (id, user, name, filename, size, notes, content_size, sha256, expires_at, compression, content_key)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
+
+-- name: SelectUpload :one
+SELECT *
+FROM upload
 WHERE id = ?
   AND user = ?;
+
+-- name: SelectUploadIDs :many
+SELECT id
+FROM upload
+WHERE user = ?;
+
+-- name: SelectExpiredUploadIDs :many
+SELECT id
+FROM upload
+WHERE expires_at <= ?;
+
+-- name: DeleteUpload :exec
+DELETE
+FROM upload
+WHERE id = ?;
+
+-- name: UpsertBlobContent :exec
+INSERT INTO blob_content (id, data)
+VALUES (?, ?)
+ON CONFLICT (id) DO UPDATE SET data = excluded.data;
+
+-- name: SelectBlobContent :one
+SELECT data
+FROM blob_content
+WHERE id = ?;
+
+-- name: SelectBlobContentSize :one
+SELECT length(data)
+FROM blob_content
+WHERE id = ?;
+
+-- name: DeleteBlobContent :exec
+DELETE
+FROM blob_content
+WHERE id = ?;
+
+-- name: SelectBlobSHA256 :one
+SELECT sha256
+FROM binary
+WHERE blob = ?
+LIMIT 1;
+
+-- name: AcquireBlob :one
+INSERT INTO blob (sha256, size, refs)
+VALUES (?, ?, 1)
+ON CONFLICT (sha256) DO UPDATE SET refs = refs + 1
+RETURNING refs;
+
+-- name: ReleaseBlob :one
+UPDATE blob
+SET refs = refs - 1
+WHERE sha256 = ?
+RETURNING refs;
+
+-- name: DeleteUnusedBlob :exec
+DELETE
+FROM blob
+WHERE sha256 = ?
+  AND refs = 0;
+
+-- name: SelectUserItemCount :one
+SELECT (SELECT COUNT(*) FROM login WHERE login.user = sqlc.arg(user))
+           + (SELECT COUNT(*) FROM note WHERE note.user = sqlc.arg(user))
+           + (SELECT COUNT(*) FROM binary WHERE binary.user = sqlc.arg(user))
+           + (SELECT COUNT(*) FROM card WHERE card.user = sqlc.arg(user))
+           + (SELECT COUNT(*) FROM otp WHERE otp.user = sqlc.arg(user)) AS count;
+
+-- name: SelectUserContentSize :one
+SELECT CAST(COALESCE(SUM(COALESCE(blob.size, binary.size)), 0) AS INTEGER) AS size
+FROM binary
+         LEFT JOIN blob ON blob.sha256 = binary.blob
+WHERE binary.user = ?;
+
+-- name: SelectUserPendingUploads :one
+SELECT COUNT(*)                                             AS count,
+       CAST(COALESCE(SUM(content_size), 0) AS INTEGER) AS size
+FROM upload
+WHERE user = ?;
+
+-- name: SelectAllLogins :many
+SELECT *
+FROM login;
+
+-- name: SelectAllNotes :many
+SELECT *
+FROM note;
+
+-- name: SelectAllBinaries :many
+SELECT *
+FROM binary;
+
+-- name: SelectAllCards :many
+SELECT *
+FROM card;
+
+-- name: SelectAllOTPs :many
+SELECT *
+FROM otp;
+
+-- name: SelectAllUploads :many
+SELECT *
+FROM upload;
+
+-- name: SelectAllUsers :many
+SELECT *
+FROM user;
+
+-- name: SelectBlobKeys :many
+SELECT sha256
+FROM blob;
+
+-- name: SelectLegacyBinaryIDs :many
+SELECT id
+FROM binary
+WHERE blob IS NULL;
+
+-- name: UpdateLoginSealed :exec
+UPDATE login
+SET name     = ?,
+    login    = ?,
+    password = ?,
+    website  = ?,
+    notes    = ?
+WHERE id = ?;
+
+-- name: UpdateNoteSealed :exec
+UPDATE note
+SET name = ?,
+    text = ?
+WHERE id = ?;
+
+-- name: UpdateBinarySealed :exec
+UPDATE binary
+SET name     = ?,
+    filename = ?,
+    notes    = ?
+WHERE id = ?;
+
+-- name: UpdateCardSealed :exec
+UPDATE card
+SET name       = ?,
+    number     = ?,
+    exp_date   = ?,
+    cvv        = ?,
+    cardholder = ?,
+    notes      = ?
+WHERE id = ?;
+
+-- name: UpdateOTPSealed :exec
+UPDATE otp
+SET name   = ?,
+    secret = ?,
+    issuer = ?
+WHERE id = ?;
+
+-- name: UpdateUploadSealed :exec
+UPDATE upload
+SET name     = ?,
+    filename = ?,
+    notes    = ?
+WHERE id = ?;
+
+-- name: UpdateUserSealed :exec
+UPDATE user
+SET mfa_secret         = ?,
+    mfa_pending_secret = ?
+WHERE login = ?;
+
+-- name: UpdateLoginRevision :exec
+UPDATE login
+SET revision = ?
+WHERE id = ?;
+
+-- name: UpdateNoteRevision :exec
+UPDATE note
+SET revision = ?
+WHERE id = ?;
+
+-- name: UpdateBinaryRevision :exec
+UPDATE binary
+SET revision = ?
+WHERE id = ?;
+
+-- name: UpdateCardRevision :exec
+UPDATE card
+SET revision = ?
+WHERE id = ?;
+
+-- name: UpdateOTPRevision :exec
+UPDATE otp
+SET revision = ?
+WHERE id = ?;
+
+-- name: UpdateUserRevision :exec
+UPDATE user
+SET revision = ?
+WHERE login = ?;
+
+-- name: InsertHistory :exec
+INSERT INTO history (kind, item_id, user, version, removed, data, created_at)
+VALUES (?, ?, ?, ?, ?, ?, ?);
+
+-- name: SelectHistory :many
+SELECT *
+FROM history
+WHERE user = ?
+  AND kind = ?
+  AND item_id = ?
+  AND created_at >= ?
+ORDER BY id DESC;
+
+-- name: SelectHistoryVersion :one
+SELECT *
+FROM history
+WHERE user = ?
+  AND kind = ?
+  AND item_id = ?
+  AND version = ?
+  AND created_at >= ?;
+
+-- name: DeleteHistoryOverLimit :exec
+DELETE
+FROM history
+WHERE user = sqlc.arg(user)
+  AND kind = sqlc.arg(kind)
+  AND item_id = sqlc.arg(item_id)
+  AND id NOT IN (SELECT id
+                 FROM history
+                 WHERE user = sqlc.arg(user)
+                   AND kind = sqlc.arg(kind)
+                   AND item_id = sqlc.arg(item_id)
+                 ORDER BY id DESC
+                 LIMIT sqlc.arg(keep));
+
+-- name: DeleteExpiredHistory :exec
+DELETE
+FROM history
+WHERE user = ?
+  AND created_at < ?;
+
+-- name: SelectAllHistory :many
+SELECT *
+FROM history;
+
+-- name: UpdateHistorySealed :exec
+UPDATE history
+SET data = ?
+WHERE id = ?;
+
+-- name: DeleteItemHistory :exec
+DELETE
+FROM history
+WHERE user = ?
+  AND kind = ?
+  AND item_id = ?;
+
+-- name: SelectTrashedLogins :many
+SELECT *
+FROM login
+WHERE user = ?
+  AND deleted_at IS NOT NULL;
+
+-- name: RestoreLogin :execrows
+UPDATE login
+SET deleted_at = NULL
+WHERE id = ?
+  AND user = ?
+  AND deleted_at IS NOT NULL;
+
+-- name: PurgeLogin :execrows
+DELETE
+FROM login
+WHERE id = ?
+  AND user = ?
+  AND deleted_at IS NOT NULL;
+
+-- name: SelectExpiredLogins :many
+SELECT id, user
+FROM login
+WHERE deleted_at <= ?;
+
+-- name: SelectTrashedNotes :many
+SELECT *
+FROM note
+WHERE user = ?
+  AND deleted_at IS NOT NULL;
+
+-- name: RestoreNote :execrows
+UPDATE note
+SET deleted_at = NULL
+WHERE id = ?
+  AND user = ?
+  AND deleted_at IS NOT NULL;
+
+-- name: PurgeNote :execrows
+DELETE
+FROM note
+WHERE id = ?
+  AND user = ?
+  AND deleted_at IS NOT NULL;
+
+-- name: SelectExpiredNotes :many
+SELECT id, user
+FROM note
+WHERE deleted_at <= ?;
+
+-- name: SelectTrashedBinaries :many
+SELECT *
+FROM binary
+WHERE user = ?
+  AND deleted_at IS NOT NULL;
+
+-- name: SelectTrashedBinary :one
+SELECT *
+FROM binary
+WHERE id = ?
+  AND user = ?
+  AND deleted_at IS NOT NULL;
+
+-- name: RestoreBinary :execrows
+UPDATE binary
+SET deleted_at = NULL
+WHERE id = ?
+  AND user = ?
+  AND deleted_at IS NOT NULL;
+
+-- name: PurgeBinary :execrows
+DELETE
+FROM binary
+WHERE id = ?
+  AND user = ?
+  AND deleted_at IS NOT NULL;
+
+-- name: SelectExpiredBinaries :many
+SELECT id, user
+FROM binary
+WHERE deleted_at <= ?;
+
+-- name: SelectTrashedCards :many
+SELECT *
+FROM card
+WHERE user = ?
+  AND deleted_at IS NOT NULL;
+
+-- name: RestoreCard :execrows
+UPDATE card
+SET deleted_at = NULL
+WHERE id = ?
+  AND user = ?
+  AND deleted_at IS NOT NULL;
+
+-- name: PurgeCard :execrows
+DELETE
+FROM card
+WHERE id = ?
+  AND user = ?
+  AND deleted_at IS NOT NULL;
+
+-- name: SelectExpiredCards :many
+SELECT id, user
+FROM card
+WHERE deleted_at <= ?;
+
+-- name: SelectTrashedOTPs :many
+SELECT *
+FROM otp
+WHERE user = ?
+  AND deleted_at IS NOT NULL;
+
+-- name: RestoreOTP :execrows
+UPDATE otp
+SET deleted_at = NULL
+WHERE id = ?
+  AND user = ?
+  AND deleted_at IS NOT NULL;
+
+-- name: PurgeOTP :execrows
+DELETE
+FROM otp
+WHERE id = ?
+  AND user = ?
+  AND deleted_at IS NOT NULL;
+
+-- name: SelectExpiredOTPs :many
+SELECT id, user
+FROM otp
+WHERE deleted_at <= ?;
@@ -1,104 +1,105 @@
 package sqlite
 
 import (
+	"context"
 	"database/sql"
 	"embed"
 	"fmt"
 	"github.com/charmbracelet/log"
 	"github.com/mkolibaba/gophkeeper/server"
-	"io/fs"
+	"github.com/mkolibaba/gophkeeper/server/migrate"
 	_ "modernc.org/sqlite"
 	"os"
-	"sort"
+	"strings"
 )
 
 //go:embed migration/*.sql
 var migrationFS embed.FS
 
 type DB struct {
-	db             *sql.DB
-	dsn            string
-	binariesFolder string
-	logger         *log.Logger
+	db            *sql.DB
+	dsn           string
+	uploadsFolder string
+	quota         server.Quota
+	history       server.History
+	logger        *log.Logger
+
+	// keyring шифрует секретные поля. nil, если шифрование выключено.
+	keyring server.Keyring
+
+	// contentLocks упорядочивает изменения ссылок на общее содержимое
+	// бинарных данных и операции с ним в хранилище.
+	contentLocks keyLocks
 }
 
-func NewDB(config *server.Config, logger *log.Logger) *DB {
+func NewDB(config *server.Config, keyring server.Keyring, logger *log.Logger) *DB {
 	return &DB{
-		dsn:            config.SQLite.DSN,
-		binariesFolder: fmt.Sprintf("%s/assets/binary", config.SQLite.DataFolder),
-		logger:         logger,
+		dsn:           config.SQLite.DSN,
+		uploadsFolder: fmt.Sprintf("%s/assets/upload", config.SQLite.DataFolder),
+		quota:         config.Quota,
+		history:       config.History,
+		logger:        logger,
+		keyring:       keyring,
 	}
 }
 
-func (d *DB) Open() (err error) {
-	d.db, err = sql.Open("sqlite", d.dsn)
-	if err != nil {
+// Connect открывает соединение с базой данных без применения миграций.
+func (d *DB) Connect() (err error) {
+	d.db, err = sql.Open("sqlite", foreignKeysDSN(d.dsn))
+	return err
+}
+
+// foreignKeysDSN включает проверки foreign key, которые в SQLite по
+// умолчанию выключены. Прагма действует на одно соединение, поэтому задается
+// в строке подключения и выполняется драйвером для каждого соединения пула.
+func foreignKeysDSN(dsn string) string {
+	const pragma = "_pragma=foreign_keys(1)"
+	if strings.Contains(dsn, "?") {
+		return dsn + "&" + pragma
+	}
+	return dsn + "?" + pragma
+}
+
+func (d *DB) Open() error {
+	if err := d.Connect(); err != nil {
 		return err
 	}
 
-	err = os.MkdirAll(d.binariesFolder, 0755)
+	err := os.MkdirAll(d.uploadsFolder, 0755)
 	if err != nil {
 		return err
 	}
 
-	// Включаем проверки foreign key, которые в SQLite по умолчанию выключены.
-	if _, err := d.db.Exec(`PRAGMA foreign_keys = ON;`); err != nil {
-		return fmt.Errorf("migrate: foreign keys pragma: %w", err)
+	migrator, err := d.Migrator()
+	if err != nil {
+		return fmt.Errorf("migrate: %w", err)
 	}
-
-	if err := d.migrate(); err != nil {
+	if _, err := migrator.Up(context.Background(), false); err != nil {
 		return fmt.Errorf("migrate: %w", err)
 	}
 
 	return nil
 }
 
-func (d *DB) migrate() error {
-	if _, err := d.db.Exec(`CREATE TABLE IF NOT EXISTS migration (name TEXT PRIMARY KEY);`); err != nil {
-		return fmt.Errorf("cannot create migrations table: %w", err)
-	}
-
-	names, err := fs.Glob(migrationFS, "migration/*.sql")
-	if err != nil {
-		return err
-	}
-	sort.Strings(names)
-
-	for _, name := range names {
-		if err := d.migrateFile(name); err != nil {
-			return fmt.Errorf("migrate script %q: %w", name, err)
-		}
+func (d *DB) Close() error {
+	if d.db == nil {
+		return nil
 	}
-	return nil
+	return d.db.Close()
 }
 
-func (d *DB) migrateFile(name string) error {
-	d.logger.Info("running migration script", "name", name)
-
-	tx, err := d.db.Begin()
+// Migrator возвращает миграции схемы базы данных. Соединение должно быть
+// открыто.
+func (d *DB) Migrator() (*migrate.Migrator, error) {
+	migrations, err := migrate.Load(migrationFS, "migration")
 	if err != nil {
-		return err
-	}
-	defer tx.Rollback()
-
-	var n int
-	if err := tx.QueryRow(`SELECT COUNT(*) FROM migration WHERE name = ?`, name).Scan(&n); err != nil {
-		return err
-	} else if n != 0 {
-		d.logger.Info("migration script already run", "name", name)
-		return nil
-	}
-
-	if buf, err := fs.ReadFile(migrationFS, name); err != nil {
-		return err
-	} else if _, err := tx.Exec(string(buf)); err != nil {
-		return err
-	}
-
-	if _, err := tx.Exec(`INSERT INTO migration (name) VALUES (?)`, name); err != nil {
-		return err
+		return nil, err
 	}
+	return migrate.New(d.db, dialect, migrations, d.logger), nil
+}
 
-	d.logger.Info("migration script run done", "name", name)
-	return tx.Commit()
+// dialect - особенности SQLite для миграций. Транзакция SQLite блокирует
+// базу данных целиком, отдельная блокировка миграций не нужна.
+var dialect = migrate.Dialect{
+	TableExists: `SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?`,
 }
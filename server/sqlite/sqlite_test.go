@@ -1,9 +1,11 @@
 package sqlite
 
 import (
+	"context"
 	"github.com/charmbracelet/log"
 	"github.com/mkolibaba/gophkeeper/server"
 	sqlc "github.com/mkolibaba/gophkeeper/server/sqlite/sqlc/gen"
+	"github.com/stretchr/testify/require"
 	"io"
 	"os"
 	"testing"
@@ -12,6 +14,7 @@ import (
 var (
 	db      *DB
 	queries *sqlc.Queries
+	blobs   *BlobStore
 )
 
 func TestMain(m *testing.M) {
@@ -26,7 +29,7 @@ func TestMain(m *testing.M) {
 	config.SQLite.DataFolder = tmpDir
 	config.SQLite.DSN = ":memory:"
 
-	db = NewDB(&config, log.New(io.Discard))
+	db = NewDB(&config, nil, log.New(io.Discard))
 
 	// Запускаем миграции
 	if err := OpenDB(db); err != nil {
@@ -35,6 +38,7 @@ func TestMain(m *testing.M) {
 
 	// Инициализируем queries
 	queries = NewQueries(db)
+	blobs = NewBlobStore(queries)
 
 	// Запускаем тест
 	os.Exit(m.Run())
@@ -48,3 +52,14 @@ func initializeTestdata(t *testing.T) {
 	mustCreateUser(t, "alice", "123")
 	mustCreateUser(t, "bob", "123")
 }
+
+func mustSelectRevision(
+	t *testing.T,
+	selectRevision func(ctx context.Context, id int64, user string) (int64, error),
+	id int64,
+) int64 {
+	// Во всех тестах данные принадлежат alice.
+	revision, err := selectRevision(t.Context(), id, "alice")
+	require.NoError(t, err)
+	return revision
+}
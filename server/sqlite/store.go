@@ -0,0 +1,519 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"github.com/mkolibaba/gophkeeper/server"
+	"github.com/mkolibaba/gophkeeper/server/sealed"
+	"github.com/mkolibaba/gophkeeper/server/sqlite/converter"
+	sqlc "github.com/mkolibaba/gophkeeper/server/sqlite/sqlc/gen"
+	"github.com/mkolibaba/gophkeeper/server/sqlstore"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+	"time"
+)
+
+// Store реализует sqlstore.Store запросами SQLite. Запросы с одинаковыми
+// сигнатурами берутся из sqlc без изменений.
+type Store struct {
+	*sqlc.Queries
+	db        *DB
+	converter converter.DataConverter
+}
+
+var _ sqlstore.Store = (*Store)(nil)
+
+func NewStore(db *DB, queries *sqlc.Queries) *Store {
+	return &Store{
+		Queries:   queries,
+		db:        db,
+		converter: NewDataConverter(),
+	}
+}
+
+func (s *Store) InTx(ctx context.Context, fn func(q sqlstore.Queries) error) error {
+	return s.inTx(ctx, func(tx *Store) error {
+		return fn(tx)
+	})
+}
+
+// inTx - аналог InTx для сервисов пакета, которым нужны запросы sqlc.
+func (s *Store) inTx(ctx context.Context, fn func(tx *Store) error) error {
+	tx, err := s.db.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(&Store{Queries: s.Queries.WithTx(tx), db: s.db, converter: s.converter}); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (s *Store) LockContent(_ context.Context, keys ...string) (func(), error) {
+	return s.db.contentLocks.lock(keys...), nil
+}
+
+// changeWithHistory выполняет изменение change записи id в транзакции
+// и сохраняет в истории замененную версию previous.
+func (s *Store) changeWithHistory(
+	ctx context.Context,
+	kind server.DataKind,
+	id int64,
+	version int64,
+	previous any,
+	removed bool,
+	change func(qs *sqlc.Queries) error,
+) error {
+	return s.inTx(ctx, func(tx *Store) error {
+		if err := change(tx.Queries); err != nil {
+			return err
+		}
+		return sqlstore.RecordHistory(ctx, tx, s.db.history, kind, id, version, previous, removed)
+	})
+}
+
+func (s *Store) SelectUser(ctx context.Context, login string) (server.User, error) {
+	u, err := s.Queries.SelectUser(ctx, login)
+	if err != nil {
+		return server.User{}, err
+	}
+	if err := s.db.open(userFields(&u)); err != nil {
+		return server.User{}, err
+	}
+
+	user := server.User{
+		Login:       u.Login,
+		Password:    u.Password,
+		VaultKey:    u.VaultKey,
+		MFAEnabled:  u.MfaEnabled,
+		MFALastStep: u.MfaLastStep,
+	}
+	if u.MfaSecret != nil {
+		user.MFASecret = *u.MfaSecret
+	}
+	if u.MfaPendingSecret != nil {
+		user.MFAPendingSecret = *u.MfaPendingSecret
+	}
+	return user, nil
+}
+
+func (s *Store) InsertUser(ctx context.Context, login string, passwordHash string, vaultKey []byte) error {
+	err := s.Queries.InsertUser(ctx, sqlc.InsertUserParams{
+		Login:    login,
+		Password: passwordHash,
+		VaultKey: vaultKey,
+	})
+	if se, ok := asType[*sqlite.Error](err); ok && se.Code() == sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY {
+		return server.ErrUserAlreadyExists
+	}
+	return err
+}
+
+func (s *Store) UpdateUserPassword(ctx context.Context, login string, passwordHash string, vaultKey []byte) (int64, error) {
+	return s.Queries.UpdateUserPassword(ctx, sqlc.UpdateUserPasswordParams{
+		Password: passwordHash,
+		VaultKey: vaultKey,
+		Login:    login,
+	})
+}
+
+func (s *Store) EnrollUserVaultKey(ctx context.Context, login string, passwordHash string, vaultKey []byte) (int64, error) {
+	return s.Queries.EnrollUserVaultKey(ctx, sqlc.EnrollUserVaultKeyParams{
+		Password: passwordHash,
+		VaultKey: vaultKey,
+		Login:    login,
+	})
+}
+
+func (s *Store) UpdateUserMFAPendingSecret(ctx context.Context, mfaPendingSecret *string, login string) (int64, error) {
+	if err := s.db.seal(sealed.Fields{Optional: []**string{&mfaPendingSecret}}); err != nil {
+		return 0, err
+	}
+	return s.Queries.UpdateUserMFAPendingSecret(ctx, mfaPendingSecret, login)
+}
+
+func (s *Store) EnableUserMFA(ctx context.Context, login string, secret string, step int64) (int64, error) {
+	arg := sqlc.EnableUserMFAParams{
+		MfaSecret:   &secret,
+		MfaLastStep: step,
+		Login:       login,
+	}
+	if err := s.db.seal(sealed.Fields{Optional: []**string{&arg.MfaSecret}}); err != nil {
+		return 0, err
+	}
+	return s.Queries.EnableUserMFA(ctx, arg)
+}
+
+func (s *Store) SelectBinary(ctx context.Context, id int64, user string) (sqlstore.Binary, error) {
+	binary, err := s.Queries.SelectBinary(ctx, id, user)
+	if err != nil {
+		return sqlstore.Binary{}, err
+	}
+	return s.binary(binary)
+}
+
+func (s *Store) SelectBinaries(ctx context.Context, user string) ([]server.BinaryData, error) {
+	binaries, err := s.Queries.SelectBinaries(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	if err := sealed.OpenRows(s.db.keyring, binaries, binaryFields); err != nil {
+		return nil, err
+	}
+	return s.converter.ConvertToBinaryDataSlice(binaries), nil
+}
+
+func (s *Store) SelectTrashedBinary(ctx context.Context, id int64, user string) (sqlstore.Binary, error) {
+	binary, err := s.Queries.SelectTrashedBinary(ctx, id, user)
+	if err != nil {
+		return sqlstore.Binary{}, err
+	}
+	return s.binary(binary)
+}
+
+func (s *Store) SelectUserBinaries(ctx context.Context, user string) ([]sqlstore.Binary, error) {
+	rows, err := s.Queries.SelectUserBinaries(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	binaries := make([]sqlstore.Binary, 0, len(rows))
+	for _, row := range rows {
+		binary, err := s.binary(row)
+		if err != nil {
+			return nil, err
+		}
+		binaries = append(binaries, binary)
+	}
+	return binaries, nil
+}
+
+// binary возвращает запись бинарных данных из строки таблицы.
+func (s *Store) binary(binary sqlc.Binary) (sqlstore.Binary, error) {
+	if err := s.db.open(binaryFields(&binary)); err != nil {
+		return sqlstore.Binary{}, err
+	}
+	return sqlstore.Binary{
+		BinaryData: s.converter.ConvertToBinaryData(binary),
+		SHA256:     binary.Sha256,
+		Blob:       binary.Blob,
+	}, nil
+}
+
+func (s *Store) InsertBinary(ctx context.Context, data server.BinaryData, digest []byte, blob []byte) error {
+	params := s.converter.ConvertToInsertBinary(ctx, server.ReadableBinaryData{BinaryData: data})
+	if err := s.db.seal(insertBinaryFields(&params)); err != nil {
+		return err
+	}
+
+	id, err := s.Queries.InsertBinary(ctx, params)
+	if err != nil {
+		return unwrapInsertError(err)
+	}
+	return s.UpdateBinaryContent(ctx, sqlc.UpdateBinaryContentParams{
+		Sha256: digest,
+		Blob:   blob,
+		ID:     id,
+	})
+}
+
+func (s *Store) UpdateBinary(ctx context.Context, data server.BinaryData, revision int64) (int64, error) {
+	params := sqlc.UpdateBinaryParams{
+		Name:     data.Name,
+		ID:       data.ID,
+		Revision: revision,
+	}
+	if data.Notes != "" {
+		params.Notes = &data.Notes
+	}
+	if err := s.db.seal(updateBinaryFields(&params)); err != nil {
+		return 0, err
+	}
+	return s.Queries.UpdateBinary(ctx, params)
+}
+
+func (s *Store) TrashBinary(ctx context.Context, id int64, user string, revision int64, deletedAt *int64) (int64, error) {
+	return s.Queries.TrashBinary(ctx, sqlc.TrashBinaryParams{
+		DeletedAt: deletedAt,
+		ID:        id,
+		User:      user,
+		Revision:  revision,
+	})
+}
+
+func (s *Store) SelectTrashed(ctx context.Context, kind server.DataKind, user string) ([]server.TrashEntry, error) {
+	var entries []server.TrashEntry
+	switch kind {
+	case server.DataKindLogin:
+		logins, err := s.SelectTrashedLogins(ctx, user)
+		if err != nil {
+			return nil, err
+		}
+		if err := sealed.OpenRows(s.db.keyring, logins, loginFields); err != nil {
+			return nil, err
+		}
+		for i, data := range s.converter.ConvertToLoginDataSlice(logins) {
+			entries = append(entries, server.TrashEntry{
+				Kind:      kind,
+				ID:        data.ID,
+				DeletedAt: trashedAt(logins[i].DeletedAt),
+				Login:     &data,
+			})
+		}
+	case server.DataKindNote:
+		notes, err := s.SelectTrashedNotes(ctx, user)
+		if err != nil {
+			return nil, err
+		}
+		if err := sealed.OpenRows(s.db.keyring, notes, noteFields); err != nil {
+			return nil, err
+		}
+		for i, data := range s.converter.ConvertToNoteDataSlice(notes) {
+			entries = append(entries, server.TrashEntry{
+				Kind:      kind,
+				ID:        data.ID,
+				DeletedAt: trashedAt(notes[i].DeletedAt),
+				Note:      &data,
+			})
+		}
+	case server.DataKindBinary:
+		binaries, err := s.SelectTrashedBinaries(ctx, user)
+		if err != nil {
+			return nil, err
+		}
+		if err := sealed.OpenRows(s.db.keyring, binaries, binaryFields); err != nil {
+			return nil, err
+		}
+		for i, data := range s.converter.ConvertToBinaryDataSlice(binaries) {
+			entries = append(entries, server.TrashEntry{
+				Kind:      kind,
+				ID:        data.ID,
+				DeletedAt: trashedAt(binaries[i].DeletedAt),
+				Binary:    &data,
+			})
+		}
+	case server.DataKindCard:
+		cards, err := s.SelectTrashedCards(ctx, user)
+		if err != nil {
+			return nil, err
+		}
+		if err := sealed.OpenRows(s.db.keyring, cards, cardFields); err != nil {
+			return nil, err
+		}
+		for i, data := range s.converter.ConvertToCardDataSlice(cards) {
+			entries = append(entries, server.TrashEntry{
+				Kind:      kind,
+				ID:        data.ID,
+				DeletedAt: trashedAt(cards[i].DeletedAt),
+				Card:      &data,
+			})
+		}
+	case server.DataKindOTP:
+		otps, err := s.SelectTrashedOTPs(ctx, user)
+		if err != nil {
+			return nil, err
+		}
+		if err := sealed.OpenRows(s.db.keyring, otps, otpFields); err != nil {
+			return nil, err
+		}
+		for i, data := range s.converter.ConvertToOTPDataSlice(otps) {
+			entries = append(entries, server.TrashEntry{
+				Kind:      kind,
+				ID:        data.ID,
+				DeletedAt: trashedAt(otps[i].DeletedAt),
+				OTP:       &data,
+			})
+		}
+	default:
+		return nil, fmt.Errorf("unknown data kind %q", kind)
+	}
+	return entries, nil
+}
+
+func (s *Store) SelectExpired(ctx context.Context, kind server.DataKind, deletedAt int64) ([]sqlstore.Item, error) {
+	switch kind {
+	case server.DataKindLogin:
+		return selectExpired(ctx, &deletedAt, s.SelectExpiredLogins)
+	case server.DataKindNote:
+		return selectExpired(ctx, &deletedAt, s.SelectExpiredNotes)
+	case server.DataKindBinary:
+		return selectExpired(ctx, &deletedAt, s.SelectExpiredBinaries)
+	case server.DataKindCard:
+		return selectExpired(ctx, &deletedAt, s.SelectExpiredCards)
+	case server.DataKindOTP:
+		return selectExpired(ctx, &deletedAt, s.SelectExpiredOTPs)
+	}
+	return nil, fmt.Errorf("unknown data kind %q", kind)
+}
+
+// selectExpired приводит строки запросов SelectExpired* всех видов записей
+// к sqlstore.Item.
+func selectExpired[R ~struct {
+	ID   int64
+	User string
+}](
+	ctx context.Context,
+	deletedAt *int64,
+	query func(ctx context.Context, deletedAt *int64) ([]R, error),
+) ([]sqlstore.Item, error) {
+	rows, err := query(ctx, deletedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]sqlstore.Item, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, sqlstore.Item(row))
+	}
+	return items, nil
+}
+
+func (s *Store) SelectHistory(ctx context.Context, user string, kind server.DataKind, id int64, since int64) ([]sqlstore.History, error) {
+	rows, err := s.Queries.SelectHistory(ctx, sqlc.SelectHistoryParams{
+		User:      user,
+		Kind:      string(kind),
+		ItemID:    id,
+		CreatedAt: since,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := sealed.OpenRows(s.db.keyring, rows, historyFields); err != nil {
+		return nil, err
+	}
+
+	history := make([]sqlstore.History, 0, len(rows))
+	for _, row := range rows {
+		history = append(history, historyRow(row))
+	}
+	return history, nil
+}
+
+func (s *Store) SelectHistoryVersion(ctx context.Context, user string, kind server.DataKind, id int64, version int64, since int64) (sqlstore.History, error) {
+	row, err := s.Queries.SelectHistoryVersion(ctx, sqlc.SelectHistoryVersionParams{
+		User:      user,
+		Kind:      string(kind),
+		ItemID:    id,
+		Version:   version,
+		CreatedAt: since,
+	})
+	if err != nil {
+		return sqlstore.History{}, err
+	}
+	if err := s.db.open(historyFields(&row)); err != nil {
+		return sqlstore.History{}, err
+	}
+	return historyRow(row), nil
+}
+
+func (s *Store) InsertHistory(ctx context.Context, history sqlstore.History) error {
+	params := sqlc.InsertHistoryParams{
+		Kind:      string(history.Kind),
+		ItemID:    history.ItemID,
+		User:      history.User,
+		Version:   history.Version,
+		Removed:   history.Removed,
+		Data:      history.Data,
+		CreatedAt: history.CreatedAt,
+	}
+	if err := s.db.seal(insertHistoryFields(&params)); err != nil {
+		return err
+	}
+	return s.Queries.InsertHistory(ctx, params)
+}
+
+func (s *Store) DeleteHistoryOverLimit(ctx context.Context, user string, kind server.DataKind, id int64, keep int64) error {
+	return s.Queries.DeleteHistoryOverLimit(ctx, sqlc.DeleteHistoryOverLimitParams{
+		User:   user,
+		Kind:   string(kind),
+		ItemID: id,
+		Keep:   keep,
+	})
+}
+
+func (s *Store) DeleteItemHistory(ctx context.Context, user string, kind server.DataKind, id int64) error {
+	return s.Queries.DeleteItemHistory(ctx, sqlc.DeleteItemHistoryParams{
+		User:   user,
+		Kind:   string(kind),
+		ItemID: id,
+	})
+}
+
+func historyRow(row sqlc.History) sqlstore.History {
+	return sqlstore.History{
+		Kind:      server.DataKind(row.Kind),
+		ItemID:    row.ItemID,
+		User:      row.User,
+		Version:   row.Version,
+		Removed:   row.Removed,
+		Data:      row.Data,
+		CreatedAt: row.CreatedAt,
+	}
+}
+
+func (s *Store) SelectUpload(ctx context.Context, id string, user string) (server.Upload, error) {
+	upload, err := s.Queries.SelectUpload(ctx, id, user)
+	if err != nil {
+		return server.Upload{}, err
+	}
+	if err := s.db.open(uploadFields(&upload)); err != nil {
+		return server.Upload{}, err
+	}
+
+	result := server.Upload{
+		ID: upload.ID,
+		BinaryData: server.BinaryData{
+			Name:        upload.Name,
+			Filename:    upload.Filename,
+			Size:        upload.Size,
+			Compression: server.Compression(upload.Compression),
+		},
+		ContentSize: upload.ContentSize,
+		SHA256:      upload.Sha256,
+		ContentKey:  upload.ContentKey,
+		ExpiresAt:   time.Unix(upload.ExpiresAt, 0),
+	}
+	if upload.Notes != nil {
+		result.Notes = *upload.Notes
+	}
+	return result, nil
+}
+
+func (s *Store) InsertUpload(ctx context.Context, user string, upload server.Upload) error {
+	var notes *string
+	if upload.Notes != "" {
+		notes = &upload.Notes
+	}
+
+	params := sqlc.InsertUploadParams{
+		ID:          upload.ID,
+		User:        user,
+		Name:        upload.Name,
+		Filename:    upload.Filename,
+		Size:        upload.Size,
+		Notes:       notes,
+		ContentSize: upload.ContentSize,
+		Sha256:      upload.SHA256,
+		ExpiresAt:   upload.ExpiresAt.Unix(),
+		Compression: int64(upload.Compression),
+		ContentKey:  upload.ContentKey,
+	}
+	if err := s.db.seal(insertUploadFields(&params)); err != nil {
+		return err
+	}
+	return s.Queries.InsertUpload(ctx, params)
+}
+
+func (s *Store) SelectUserPendingUploads(ctx context.Context, user string) (sqlstore.PendingUploads, error) {
+	pending, err := s.Queries.SelectUserPendingUploads(ctx, user)
+	return sqlstore.PendingUploads(pending), err
+}
+
+func trashedAt(deletedAt *int64) time.Time {
+	if deletedAt == nil {
+		return time.Time{}
+	}
+	return time.Unix(*deletedAt, 0)
+}
@@ -0,0 +1,117 @@
+package sqlite
+
+import (
+	"github.com/mkolibaba/gophkeeper/server"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestSyncChanges(t *testing.T) {
+	mustCreateUser(t, "alice", "123")
+	mustCreateUser(t, "bob", "123")
+	loginID := mustCreateLogin(t, "app1", "login1", "123", "alice")
+	noteID := mustCreateNote(t, "note1", "text", "alice")
+	mustCreateCard(t, "card1", "4111111111111111", "12/30", "alice")
+	mustCreateLogin(t, "app2", "login2", "123", "bob")
+
+	t.Cleanup(func() {
+		db.db.Exec("DELETE FROM login")
+		db.db.Exec("DELETE FROM note")
+		db.db.Exec("DELETE FROM card")
+		db.db.Exec("DELETE FROM user")
+	})
+
+	srv := NewSyncService(db, queries, NewDataConverter())
+	loginService := NewLoginService(db, queries, NewDataConverter())
+	noteService := NewNoteService(db, queries, NewDataConverter())
+	ctx := server.NewContextWithUser(t.Context(), "alice")
+
+	changes, err := srv.Changes(ctx, 0)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), changes.Revision)
+	require.Len(t, changes.Logins, 1)
+	require.Len(t, changes.Notes, 1)
+	require.Len(t, changes.Cards, 1)
+	require.Empty(t, changes.Binaries)
+	require.Empty(t, changes.Tombstones)
+
+	since := changes.Revision
+
+	t.Run("no_changes", func(t *testing.T) {
+		changes, err := srv.Changes(ctx, since)
+		require.NoError(t, err)
+		require.Equal(t, since, changes.Revision)
+		require.Empty(t, changes.Logins)
+		require.Empty(t, changes.Notes)
+		require.Empty(t, changes.Cards)
+	})
+	t.Run("update", func(t *testing.T) {
+		name := "app1 updated"
+		version := mustSelectRevision(t, queries.SelectLoginRevision, loginID)
+		err := loginService.Update(ctx, loginID, version, server.LoginDataUpdate{Name: &name})
+		require.NoError(t, err)
+
+		changes, err := srv.Changes(ctx, since)
+		require.NoError(t, err)
+		require.Equal(t, since+1, changes.Revision)
+		require.Len(t, changes.Logins, 1)
+		require.Equal(t, "app1 updated", changes.Logins[0].Name)
+		require.Equal(t, changes.Revision, changes.Logins[0].Version)
+		require.Empty(t, changes.Notes)
+
+		since = changes.Revision
+	})
+	t.Run("remove", func(t *testing.T) {
+		err := noteService.Remove(ctx, noteID, mustSelectRevision(t, queries.SelectNoteRevision, noteID))
+		require.NoError(t, err)
+
+		changes, err := srv.Changes(ctx, since)
+		require.NoError(t, err)
+		require.Equal(t, since+1, changes.Revision)
+		require.Empty(t, changes.Notes)
+		require.Equal(t, []server.Tombstone{{Kind: server.DataKindNote, ID: noteID}}, changes.Tombstones)
+
+		since = changes.Revision
+	})
+	t.Run("restore", func(t *testing.T) {
+		trash := NewTrashService(db, queries, nil, NewDataConverter())
+		require.NoError(t, trash.Restore(ctx, server.DataKindNote, noteID))
+
+		changes, err := srv.Changes(ctx, since)
+		require.NoError(t, err)
+		require.Equal(t, since+1, changes.Revision)
+		require.Len(t, changes.Notes, 1)
+		require.Equal(t, noteID, changes.Notes[0].ID)
+		require.Equal(t, changes.Revision, changes.Notes[0].Version)
+		require.Empty(t, changes.Tombstones)
+	})
+	t.Run("other_user", func(t *testing.T) {
+		changes, err := srv.Changes(server.NewContextWithUser(t.Context(), "bob"), 0)
+		require.NoError(t, err)
+		require.Equal(t, int64(1), changes.Revision)
+		require.Len(t, changes.Logins, 1)
+		require.Empty(t, changes.Tombstones)
+	})
+}
+
+func TestSyncRevision(t *testing.T) {
+	mustCreateUser(t, "alice", "123")
+
+	t.Cleanup(func() {
+		db.db.Exec("DELETE FROM note")
+		db.db.Exec("DELETE FROM user")
+	})
+
+	srv := NewSyncService(db, queries, NewDataConverter())
+	ctx := server.NewContextWithUser(t.Context(), "alice")
+
+	revision, err := srv.Revision(ctx)
+	require.NoError(t, err)
+	require.Zero(t, revision)
+
+	mustCreateNote(t, "note1", "text", "alice")
+
+	revision, err = srv.Revision(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), revision)
+}
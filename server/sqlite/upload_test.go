@@ -0,0 +1,155 @@
+package sqlite
+
+import (
+	"crypto/sha256"
+	"errors"
+	"github.com/mkolibaba/gophkeeper/server"
+	"github.com/stretchr/testify/require"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestUpload(t *testing.T) {
+	mustCreateUser(t, "alice", "123")
+	mustCreateUser(t, "bob", "123")
+	t.Cleanup(func() {
+		db.db.Exec("DELETE FROM binary")
+		db.db.Exec("DELETE FROM blob")
+		db.db.Exec("DELETE FROM upload")
+		db.db.Exec("DELETE FROM user")
+	})
+
+	binaryService := NewBinaryService(queries, db, blobs, NewDataConverter())
+	srv := NewUploadService(queries, db, binaryService)
+
+	alice := server.NewContextWithUser(t.Context(), "alice")
+	bob := server.NewContextWithUser(t.Context(), "bob")
+
+	content := "hello, resumable world"
+	create := func(t *testing.T, content string) *server.Upload {
+		hash := sha256.Sum256([]byte(content))
+		upload, err := srv.Create(alice, server.Upload{
+			BinaryData: server.BinaryData{
+				Name:        "upload_" + t.Name(),
+				Filename:    "hello.txt",
+				Size:        int64(len(content)),
+				Compression: server.CompressionZstd,
+			},
+			ContentSize: int64(len(content)),
+			SHA256:      hash[:],
+		})
+		require.NoError(t, err)
+		return upload
+	}
+
+	t.Run("resume", func(t *testing.T) {
+		upload := create(t, content)
+		require.NotEmpty(t, upload.ID)
+		require.Zero(t, upload.Offset)
+
+		// Обрыв соединения посреди записи: сохраненное остается.
+		reader := io.MultiReader(strings.NewReader(content[:5]), iotest.ErrReader(errors.New("connection lost")))
+		offset, err := srv.Write(alice, upload.ID, 0, reader)
+		require.Error(t, err)
+		require.EqualValues(t, 5, offset)
+
+		got, err := srv.Get(alice, upload.ID)
+		require.NoError(t, err)
+		require.EqualValues(t, 5, got.Offset)
+
+		_, err = srv.Get(bob, upload.ID)
+		require.ErrorIs(t, err, server.ErrUploadNotFound)
+
+		var offsetErr *server.UploadOffsetError
+		_, err = srv.Write(alice, upload.ID, 0, strings.NewReader(content))
+		require.ErrorAs(t, err, &offsetErr)
+		require.EqualValues(t, 5, offsetErr.Offset)
+
+		offset, err = srv.Write(alice, upload.ID, 5, strings.NewReader(content[5:]))
+		require.NoError(t, err)
+		require.EqualValues(t, len(content), offset)
+
+		require.NoError(t, srv.Complete(alice, upload.ID))
+
+		_, err = srv.Get(alice, upload.ID)
+		require.ErrorIs(t, err, server.ErrUploadNotFound)
+
+		binaries, err := binaryService.GetAll(alice)
+		require.NoError(t, err)
+		require.Len(t, binaries, 1)
+		require.Equal(t, "upload_TestUpload/resume", binaries[0].Name)
+		require.Equal(t, server.CompressionZstd, binaries[0].Compression)
+
+		data, err := binaryService.Get(alice, binaries[0].ID)
+		require.NoError(t, err)
+		defer data.DataReader.Close()
+		stored, err := io.ReadAll(data.DataReader)
+		require.NoError(t, err)
+		require.Equal(t, content, string(stored))
+	})
+	t.Run("too_large", func(t *testing.T) {
+		upload := create(t, content)
+
+		offset, err := srv.Write(alice, upload.ID, 0, strings.NewReader(content+"!"))
+		require.ErrorIs(t, err, server.ErrUploadTooLarge)
+		require.EqualValues(t, len(content), offset)
+
+		got, err := srv.Get(alice, upload.ID)
+		require.NoError(t, err)
+		require.EqualValues(t, len(content), got.Offset)
+	})
+	t.Run("incomplete", func(t *testing.T) {
+		upload := create(t, content)
+
+		_, err := srv.Write(alice, upload.ID, 0, strings.NewReader(content[:5]))
+		require.NoError(t, err)
+
+		require.ErrorIs(t, srv.Complete(alice, upload.ID), server.ErrUploadIncomplete)
+	})
+	t.Run("checksum_mismatch", func(t *testing.T) {
+		upload := create(t, content)
+
+		_, err := srv.Write(alice, upload.ID, 0, strings.NewReader(strings.ToUpper(content)))
+		require.NoError(t, err)
+
+		require.ErrorIs(t, srv.Complete(alice, upload.ID), server.ErrChecksumMismatch)
+
+		// Поврежденная загрузка удаляется, запись не создается.
+		_, err = srv.Get(alice, upload.ID)
+		require.ErrorIs(t, err, server.ErrUploadNotFound)
+		binaries, err := binaryService.GetAll(alice)
+		require.NoError(t, err)
+		require.Len(t, binaries, 1)
+	})
+	t.Run("in_progress", func(t *testing.T) {
+		upload := create(t, content)
+
+		// Первый поток держит загрузку, пока читает содержимое.
+		pr, pw := io.Pipe()
+		done := make(chan error)
+		go func() {
+			_, err := srv.Write(alice, upload.ID, 0, pr)
+			done <- err
+		}()
+		_, err := pw.Write([]byte(content[:1]))
+		require.NoError(t, err)
+
+		_, err = srv.Write(alice, upload.ID, 0, strings.NewReader(content))
+		require.ErrorIs(t, err, server.ErrUploadInProgress)
+
+		pw.Close()
+		require.NoError(t, <-done)
+	})
+	t.Run("expired", func(t *testing.T) {
+		upload := create(t, content)
+
+		_, err := db.db.Exec("UPDATE upload SET expires_at = 0 WHERE id = ?", upload.ID)
+		require.NoError(t, err)
+		create(t, content)
+
+		_, err = srv.Get(alice, upload.ID)
+		require.ErrorIs(t, err, server.ErrUploadNotFound)
+	})
+}
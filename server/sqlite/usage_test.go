@@ -0,0 +1,90 @@
+package sqlite
+
+import (
+	"crypto/sha256"
+	"github.com/mkolibaba/gophkeeper/server"
+	"github.com/stretchr/testify/require"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestUsage(t *testing.T) {
+	mustCreateUser(t, "alice", "123")
+	mustCreateUser(t, "bob", "123")
+	mustCreateNote(t, "note", "text", "alice")
+	mustCreateLogin(t, "login", "alice", "123", "alice")
+	mustCreateNote(t, "note", "text", "bob")
+	t.Cleanup(func() {
+		db.db.Exec("DELETE FROM login")
+		db.db.Exec("DELETE FROM note")
+		db.db.Exec("DELETE FROM binary")
+		db.db.Exec("DELETE FROM blob")
+		db.db.Exec("DELETE FROM user")
+	})
+
+	binaryService := NewBinaryService(queries, db, blobs, NewDataConverter())
+	srv := NewUsageService(db, queries)
+
+	alice := server.NewContextWithUser(t.Context(), "alice")
+	err := binaryService.Create(alice, server.ReadableBinaryData{
+		BinaryData: server.BinaryData{Name: "file", Filename: "file.txt"},
+		DataReader: io.NopCloser(strings.NewReader("0123456789")),
+	})
+	require.NoError(t, err)
+
+	usage, err := srv.Get(alice)
+	require.NoError(t, err)
+	require.EqualValues(t, 3, usage.Items)
+	require.EqualValues(t, 10, usage.Bytes)
+}
+
+func TestQuota(t *testing.T) {
+	mustCreateUser(t, "alice", "123")
+	quota := db.quota
+	db.quota = server.Quota{Bytes: 25, Items: 3, MaxFileSize: 15}
+	t.Cleanup(func() {
+		db.quota = quota
+		db.db.Exec("DELETE FROM note")
+		db.db.Exec("DELETE FROM binary")
+		db.db.Exec("DELETE FROM blob")
+		db.db.Exec("DELETE FROM upload")
+		db.db.Exec("DELETE FROM user")
+	})
+
+	noteService := NewNoteService(db, queries, NewDataConverter())
+	binaryService := NewBinaryService(queries, db, blobs, NewDataConverter())
+	uploadService := NewUploadService(queries, db, binaryService)
+
+	alice := server.NewContextWithUser(t.Context(), "alice")
+	createUpload := func(content string) error {
+		hash := sha256.Sum256([]byte(content))
+		_, err := uploadService.Create(alice, server.Upload{
+			BinaryData:  server.BinaryData{Name: "upload_" + content, Filename: "file.txt"},
+			ContentSize: int64(len(content)),
+			SHA256:      hash[:],
+		})
+		return err
+	}
+
+	t.Run("max_file_size", func(t *testing.T) {
+		require.ErrorIs(t, createUpload("0123456789abcdef"), server.ErrFileTooLarge)
+	})
+	t.Run("bytes", func(t *testing.T) {
+		err := binaryService.Create(alice, server.ReadableBinaryData{
+			BinaryData: server.BinaryData{Name: "file", Filename: "file.txt"},
+			DataReader: io.NopCloser(strings.NewReader("0123456789")),
+		})
+		require.NoError(t, err)
+
+		// Незавершенная загрузка тоже занимает место.
+		require.NoError(t, createUpload("0123456789"))
+		require.ErrorIs(t, createUpload("0123456"), server.ErrQuotaExceeded)
+	})
+	t.Run("items", func(t *testing.T) {
+		// Файл и незавершенная загрузка уже занимают две записи из трех.
+		require.NoError(t, noteService.Create(alice, server.NoteData{Name: "note", Text: "text"}))
+		err := noteService.Create(alice, server.NoteData{Name: "note 2", Text: "text"})
+		require.ErrorIs(t, err, server.ErrQuotaExceeded)
+	})
+}
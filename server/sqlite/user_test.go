@@ -1,9 +1,15 @@
 package sqlite
 
 import (
+	"github.com/charmbracelet/log"
 	"github.com/mkolibaba/gophkeeper/server"
+	sqlc "github.com/mkolibaba/gophkeeper/server/sqlite/sqlc/gen"
+	"github.com/mkolibaba/gophkeeper/server/sqlstore"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/crypto/bcrypt"
+	"io"
+	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -14,7 +20,7 @@ func TestUserGet(t *testing.T) {
 		db.db.Exec("DELETE FROM user")
 	})
 
-	srv := NewUserService(queries)
+	srv := NewUserService(db, queries, blobs)
 
 	t.Run("success", func(t *testing.T) {
 		user, err := srv.Get(t.Context(), "alice")
@@ -35,12 +41,13 @@ func TestUserSave(t *testing.T) {
 		db.db.Exec("DELETE FROM user")
 	})
 
-	srv := NewUserService(queries)
+	srv := NewUserService(db, queries, blobs)
 
 	t.Run("success", func(t *testing.T) {
 		err := srv.Save(t.Context(), server.User{
 			Login:    "charlie",
 			Password: "123",
+			VaultKey: []byte("vault key"),
 		})
 		require.NoError(t, err)
 
@@ -49,6 +56,11 @@ func TestUserSave(t *testing.T) {
 			require.NoError(t, cErr)
 			require.NoError(t, bcrypt.CompareHashAndPassword([]byte(user.Password), []byte("123")))
 		})
+		t.Run("vault_key", func(t *testing.T) {
+			user, cErr := srv.Get(t.Context(), "charlie")
+			require.NoError(t, cErr)
+			require.Equal(t, []byte("vault key"), user.VaultKey)
+		})
 	})
 	t.Run("duplicate", func(t *testing.T) {
 		err := srv.Save(t.Context(), server.User{
@@ -59,7 +71,192 @@ func TestUserSave(t *testing.T) {
 	})
 }
 
+func TestUserChangePassword(t *testing.T) {
+	mustCreateUser(t, "alice", "123")
+	t.Cleanup(func() {
+		db.db.Exec("DELETE FROM user")
+	})
+
+	srv := NewUserService(db, queries, blobs)
+
+	t.Run("success", func(t *testing.T) {
+		require.NoError(t, srv.ChangePassword(t.Context(), "alice", "456", []byte("new vault key")))
+
+		user, err := srv.Get(t.Context(), "alice")
+		require.NoError(t, err)
+		require.NoError(t, bcrypt.CompareHashAndPassword([]byte(user.Password), []byte("456")))
+		require.Equal(t, []byte("new vault key"), user.VaultKey)
+	})
+	t.Run("not_found", func(t *testing.T) {
+		err := srv.ChangePassword(t.Context(), "charlie", "456", []byte("new vault key"))
+		require.ErrorIs(t, err, server.ErrUserNotFound)
+	})
+}
+
+func TestUserEnrollVaultKey(t *testing.T) {
+	mustCreateUser(t, "alice", "123")
+	t.Cleanup(func() {
+		db.db.Exec("DELETE FROM user")
+	})
+
+	srv := NewUserService(db, queries, blobs)
+
+	require.NoError(t, srv.EnrollVaultKey(t.Context(), "alice", "auth key", []byte("vault key")))
+
+	user, err := srv.Get(t.Context(), "alice")
+	require.NoError(t, err)
+	require.NoError(t, bcrypt.CompareHashAndPassword([]byte(user.Password), []byte("auth key")))
+	require.Equal(t, []byte("vault key"), user.VaultKey)
+
+	// Заданный ключ хранилища повторно не заменяется.
+	err = srv.EnrollVaultKey(t.Context(), "alice", "other key", []byte("other vault key"))
+	require.ErrorIs(t, err, server.ErrVaultKeyExists)
+	user, err = srv.Get(t.Context(), "alice")
+	require.NoError(t, err)
+	require.Equal(t, []byte("vault key"), user.VaultKey)
+}
+
+func TestUserDelete(t *testing.T) {
+	mustCreateUser(t, "alice", "123")
+	mustCreateUser(t, "bob", "123")
+	mustCreateLogin(t, "login", "alice", "123", "alice")
+	mustCreateNote(t, "note", "text", "alice")
+	mustCreateCard(t, "card", "4111111111111111", "12/30", "alice")
+	mustCreateOTP(t, "otp", "alice")
+	aliceBinaryID := mustCreateBinary(t, "text", "text_1.txt", strings.NewReader("content 1"), "alice")
+	bobBinaryID := mustCreateBinary(t, "text", "text_2.txt", strings.NewReader("content 2"), "bob")
+	t.Cleanup(func() {
+		db.db.Exec("DELETE FROM binary")
+		db.db.Exec("DELETE FROM blob")
+		db.db.Exec("DELETE FROM user")
+		blobs.Delete(t.Context(), sqlstore.BinaryKey(bobBinaryID))
+	})
+
+	srv := NewUserService(db, queries, blobs)
+
+	t.Run("success", func(t *testing.T) {
+		require.NoError(t, srv.Delete(t.Context(), "alice"))
+
+		_, err := srv.Get(t.Context(), "alice")
+		require.ErrorIs(t, err, server.ErrUserNotFound)
+
+		for _, table := range []string{"login", "note", "binary", "card", "otp", "tombstone"} {
+			var count int
+			require.NoError(t, db.db.QueryRow("SELECT COUNT(*) FROM "+table+" WHERE user = 'alice'").Scan(&count))
+			require.Zero(t, count, table)
+		}
+
+		_, err = blobs.Stat(t.Context(), sqlstore.BinaryKey(aliceBinaryID))
+		require.ErrorIs(t, err, server.ErrBlobNotFound)
+	})
+	t.Run("other_user_intact", func(t *testing.T) {
+		binaries, err := queries.SelectBinaries(t.Context(), "bob")
+		require.NoError(t, err)
+		require.Len(t, binaries, 1)
+		_, err = blobs.Stat(t.Context(), sqlstore.BinaryKey(bobBinaryID))
+		require.NoError(t, err)
+	})
+	t.Run("not_found", func(t *testing.T) {
+		err := srv.Delete(t.Context(), "alice")
+		require.ErrorIs(t, err, server.ErrUserNotFound)
+	})
+}
+
+func TestUserDeleteForeignKeys(t *testing.T) {
+	// Каскадное удаление должно работать в любом соединении пула, а не только
+	// в том, что открыто первым.
+	var config server.Config
+	config.SQLite.DataFolder = t.TempDir()
+	config.SQLite.DSN = filepath.Join(config.SQLite.DataFolder, "db.sqlite")
+
+	fileDB := NewDB(&config, nil, log.New(io.Discard))
+	require.NoError(t, OpenDB(fileDB))
+	t.Cleanup(func() {
+		fileDB.Close()
+	})
+	fileQueries := NewQueries(fileDB)
+
+	require.NoError(t, fileQueries.InsertUser(t.Context(), sqlc.InsertUserParams{Login: "alice", Password: "123"}))
+	_, err := fileDB.db.Exec(`INSERT INTO session (id, user, refresh_token_hash, created_at, last_used_at, expires_at)
+		VALUES ('session', 'alice', x'00', 0, 0, 0)`)
+	require.NoError(t, err)
+
+	// Первое соединение занято, удаление выполняется через второе.
+	first, err := fileDB.db.Conn(t.Context())
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		first.Close()
+	})
+
+	srv := NewUserService(fileDB, fileQueries, NewBlobStore(fileQueries))
+	require.NoError(t, srv.Delete(t.Context(), "alice"))
+
+	var count int
+	require.NoError(t, fileDB.db.QueryRow(`SELECT COUNT(*) FROM session WHERE user = 'alice'`).Scan(&count))
+	require.Zero(t, count)
+}
+
 func mustCreateUser(t *testing.T, login string, password string) {
-	err := queries.InsertUser(t.Context(), login, password)
+	err := queries.InsertUser(t.Context(), sqlc.InsertUserParams{
+		Login:    login,
+		Password: password,
+	})
+	require.NoError(t, err)
+}
+
+func TestUserMFA(t *testing.T) {
+	mustCreateUser(t, "alice", "123")
+	t.Cleanup(func() {
+		db.db.Exec("DELETE FROM user")
+	})
+
+	srv := NewUserService(db, queries, blobs)
+
+	require.NoError(t, srv.SetMFAPendingSecret(t.Context(), "alice", "SECRET"))
+	user, err := srv.Get(t.Context(), "alice")
 	require.NoError(t, err)
+	require.Equal(t, "SECRET", user.MFAPendingSecret)
+	require.Empty(t, user.MFASecret)
+	require.False(t, user.MFAEnabled)
+
+	require.NoError(t, srv.EnableMFA(t.Context(), "alice", "SECRET", 10, [][]byte{[]byte("first"), []byte("second")}))
+	user, err = srv.Get(t.Context(), "alice")
+	require.NoError(t, err)
+	require.Equal(t, "SECRET", user.MFASecret)
+	require.Empty(t, user.MFAPendingSecret)
+	require.Equal(t, int64(10), user.MFALastStep)
+	require.True(t, user.MFAEnabled)
+
+	// Новый секрет ожидает подтверждения и не заменяет действующий.
+	require.NoError(t, srv.SetMFAPendingSecret(t.Context(), "alice", "OTHER"))
+	err = srv.EnableMFA(t.Context(), "alice", "OTHER", 11, nil)
+	require.ErrorIs(t, err, server.ErrMFAAlreadyEnabled)
+	user, err = srv.Get(t.Context(), "alice")
+	require.NoError(t, err)
+	require.Equal(t, "SECRET", user.MFASecret)
+	require.True(t, user.MFAEnabled)
+
+	require.NoError(t, srv.UseMFAStep(t.Context(), "alice", 11))
+	err = srv.UseMFAStep(t.Context(), "alice", 11)
+	require.ErrorIs(t, err, server.ErrInvalidMFACode)
+	err = srv.UseMFAStep(t.Context(), "alice", 10)
+	require.ErrorIs(t, err, server.ErrInvalidMFACode)
+
+	require.NoError(t, srv.UseRecoveryCode(t.Context(), "alice", []byte("first")))
+	err = srv.UseRecoveryCode(t.Context(), "alice", []byte("first"))
+	require.ErrorIs(t, err, server.ErrRecoveryCodeNotFound)
+
+	require.NoError(t, srv.DisableMFA(t.Context(), "alice"))
+	user, err = srv.Get(t.Context(), "alice")
+	require.NoError(t, err)
+	require.Empty(t, user.MFASecret)
+	require.Empty(t, user.MFAPendingSecret)
+	require.Zero(t, user.MFALastStep)
+	require.False(t, user.MFAEnabled)
+
+	err = srv.UseRecoveryCode(t.Context(), "alice", []byte("second"))
+	require.ErrorIs(t, err, server.ErrRecoveryCodeNotFound)
+
+	err = srv.SetMFAPendingSecret(t.Context(), "bob", "SECRET")
+	require.ErrorIs(t, err, server.ErrUserNotFound)
 }
@@ -0,0 +1,347 @@
+package sqlstore
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/mkolibaba/gophkeeper/server"
+	"io"
+	"os"
+	"strconv"
+)
+
+type BinaryService struct {
+	store   Store
+	blobs   server.BlobStore
+	quota   server.Quota
+	history server.History
+}
+
+func NewBinaryService(store Store, blobs server.BlobStore, quota server.Quota, history server.History) *BinaryService {
+	return &BinaryService{
+		store:   store,
+		blobs:   blobs,
+		quota:   quota,
+		history: history,
+	}
+}
+
+// Create сохраняет бинарные данные. Если хэш содержимого известен,
+// содержимое пришло из загрузки, место для которой уже учтено в квоте.
+func (s *BinaryService) Create(ctx context.Context, data server.ReadableBinaryData) error {
+	content, digest, size := data.DataReader, data.SHA256, data.ContentSize
+	if digest == nil {
+		if err := CheckItemQuota(ctx, s.store, s.quota, 1); err != nil {
+			return err
+		}
+
+		// Ключ содержимого - его хэш, поэтому до сохранения содержимое
+		// читается во временный файл.
+		spool, err := os.CreateTemp("", "gophkeeper-binary-*")
+		if err != nil {
+			return fmt.Errorf("save: %w", err)
+		}
+		defer os.Remove(spool.Name())
+		defer spool.Close()
+
+		hash := sha256.New()
+		if size, err = io.Copy(spool, io.TeeReader(data.DataReader, hash)); err != nil {
+			return fmt.Errorf("save: %w", err)
+		}
+		if _, err := spool.Seek(0, io.SeekStart); err != nil {
+			return fmt.Errorf("save: %w", err)
+		}
+		content, digest = spool, hash.Sum(nil)
+
+		if err := CheckContentQuota(ctx, s.store, s.quota, size); err != nil {
+			return err
+		}
+	}
+
+	// Содержимое, зашифрованное на клиенте, каждый раз разное, поэтому
+	// при известном ключе дедупликации содержимое определяется им.
+	blob := digest
+	if data.ContentKey != nil {
+		blob = userContentID(server.UserFromContext(ctx), data.Compression, data.ContentKey)
+	}
+
+	key := ContentKey(blob)
+	unlock, err := s.store.LockContent(ctx, key)
+	if err != nil {
+		return fmt.Errorf("save: %w", err)
+	}
+	defer unlock()
+
+	// Одинаковое содержимое хранится один раз: запись получает хэш уже
+	// сохраненного содержимого.
+	stored, err := s.storedDigest(ctx, blob)
+	if err != nil {
+		return fmt.Errorf("save: %w", err)
+	}
+	put := stored == nil
+	if put {
+		if err := s.blobs.Put(ctx, key, content); err != nil {
+			return fmt.Errorf("save: %w", err)
+		}
+		stored = digest
+	}
+
+	if err := s.insert(ctx, data.BinaryData, stored, blob, size); err != nil {
+		if put {
+			s.blobs.Delete(ctx, key)
+		}
+		return err
+	}
+	return nil
+}
+
+// storedDigest возвращает хэш уже сохраненного содержимого blob или nil,
+// если его нет.
+func (s *BinaryService) storedDigest(ctx context.Context, blob []byte) ([]byte, error) {
+	digest, err := s.store.SelectBlobSHA256(ctx, blob)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.blobs.Stat(ctx, ContentKey(blob)); errors.Is(err, server.ErrBlobNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return digest, nil
+}
+
+// insert создает запись со ссылкой на сохраненное содержимое blob с хэшем
+// digest.
+func (s *BinaryService) insert(ctx context.Context, data server.BinaryData, digest []byte, blob []byte, size int64) error {
+	err := s.store.InTx(ctx, func(q Queries) error {
+		// Ссылка на содержимое создается до записи: запись может ссылаться
+		// на него внешним ключом.
+		if _, err := q.AcquireBlob(ctx, blob, size); err != nil {
+			return err
+		}
+		return q.InsertBinary(ctx, data, digest, blob)
+	})
+	if errors.Is(err, server.ErrUserNotFound) {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("save: %w", err)
+	}
+	return nil
+}
+
+func (s *BinaryService) Get(ctx context.Context, id int64) (*server.ReadableBinaryData, error) {
+	binary, err := s.store.SelectBinary(ctx, id, server.UserFromContext(ctx))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, server.ErrDataNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get: %w", err)
+	}
+
+	key := binaryContentKey(binary)
+	info, err := s.blobs.Stat(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("get: %w", err)
+	}
+
+	digest := binary.SHA256
+	if digest == nil {
+		// Файл сохранен до появления хэшей: вычисляем хэш один раз.
+		if digest, err = s.fillSha256(ctx, id); err != nil {
+			return nil, fmt.Errorf("get: %w", err)
+		}
+	}
+
+	content, err := s.blobs.Get(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("get: %w", err)
+	}
+
+	return &server.ReadableBinaryData{
+		BinaryData:  binary.BinaryData,
+		DataReader:  content,
+		ContentSize: info.Size,
+		SHA256:      digest,
+	}, nil
+}
+
+func (s *BinaryService) GetAll(ctx context.Context) ([]server.BinaryData, error) {
+	binaries, err := s.store.SelectBinaries(ctx, server.UserFromContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("get all: %w", err)
+	}
+	return binaries, nil
+}
+
+func (s *BinaryService) Update(ctx context.Context, id int64, version int64, data server.BinaryDataUpdate) error {
+	binary, err := s.store.SelectBinary(ctx, id, server.UserFromContext(ctx))
+	if errors.Is(err, sql.ErrNoRows) {
+		return server.ErrDataNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("update: %w", err)
+	}
+	if binary.Version != version {
+		return &server.VersionConflictError{Version: binary.Version}
+	}
+
+	previous := binary.BinaryData
+
+	updated := previous
+	if data.Name != nil {
+		updated.Name = *data.Name
+	}
+	if data.Notes != nil {
+		updated.Notes = *data.Notes
+	}
+
+	err = ChangeWithHistory(ctx, s.store, s.history, server.DataKindBinary, id, version, previous, false, func(q Queries) error {
+		n, err := q.UpdateBinary(ctx, updated, version)
+		return CheckChanged(ctx, n, err, q.SelectBinaryRevision, id)
+	})
+	if err != nil {
+		return fmt.Errorf("update: %w", err)
+	}
+	return nil
+}
+
+func (s *BinaryService) Remove(ctx context.Context, id int64, version int64) error {
+	user := server.UserFromContext(ctx)
+	binary, err := s.store.SelectBinary(ctx, id, user)
+	if errors.Is(err, sql.ErrNoRows) {
+		return server.ErrDataNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("remove: %w", err)
+	}
+	if binary.Version != version {
+		return &server.VersionConflictError{Version: binary.Version}
+	}
+
+	// Содержимое остается в хранилище, пока запись не удалена из корзины.
+	err = ChangeWithHistory(ctx, s.store, s.history, server.DataKindBinary, id, version, binary.BinaryData, true, func(q Queries) error {
+		n, err := q.TrashBinary(ctx, id, user, version, TrashedNow())
+		return CheckChanged(ctx, n, err, q.SelectBinaryRevision, id)
+	})
+	if err != nil {
+		return fmt.Errorf("remove: %w", err)
+	}
+	return nil
+}
+
+// purge окончательно удаляет запись id пользователя user из корзины
+// и удаляет содержимое, если на него больше нет ссылок.
+func (s *BinaryService) purge(ctx context.Context, user string, id int64) error {
+	binary, err := s.store.SelectTrashedBinary(ctx, id, user)
+	if errors.Is(err, sql.ErrNoRows) {
+		return server.ErrDataNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	key := binaryContentKey(binary)
+	unlock, err := s.store.LockContent(ctx, key)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	var unused bool
+	err = purgeItem(ctx, s.store, user, server.DataKindBinary, id, func(q Queries) (int64, error) {
+		n, err := q.PurgeBinary(ctx, id, user)
+		if err != nil || n == 0 {
+			return n, err
+		}
+		unused, err = releaseContent(ctx, q, binary)
+		return n, err
+	})
+	if err != nil {
+		return err
+	}
+
+	if unused {
+		return s.blobs.Delete(ctx, key)
+	}
+	return nil
+}
+
+// fillSha256 вычисляет и сохраняет хэш содержимого.
+func (s *BinaryService) fillSha256(ctx context.Context, id int64) ([]byte, error) {
+	// Содержимое записей без хэша хранится под их id.
+	content, err := s.blobs.Get(ctx, BinaryKey(id))
+	if err != nil {
+		return nil, err
+	}
+	defer content.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, content); err != nil {
+		return nil, err
+	}
+
+	digest := hash.Sum(nil)
+	if err := s.store.UpdateBinarySha256(ctx, digest, id); err != nil {
+		return nil, err
+	}
+	return digest, nil
+}
+
+// releaseContent освобождает ссылку записи на содержимое и сообщает,
+// нужно ли удалить содержимое из хранилища.
+func releaseContent(ctx context.Context, q Queries, binary Binary) (bool, error) {
+	if binary.Blob == nil {
+		// Содержимое сохранено до дедупликации и принадлежит только записи.
+		return true, nil
+	}
+
+	refs, err := q.ReleaseBlob(ctx, binary.Blob)
+	if err != nil {
+		return false, err
+	}
+	if refs > 0 {
+		return false, nil
+	}
+	if err := q.DeleteUnusedBlob(ctx, binary.Blob); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// binaryContentKey возвращает ключ содержимого записи в хранилище.
+func binaryContentKey(binary Binary) string {
+	if binary.Blob == nil {
+		return BinaryKey(binary.ID)
+	}
+	return ContentKey(binary.Blob)
+}
+
+// BinaryKey возвращает ключ содержимого, сохраненного до дедупликации.
+func BinaryKey(id int64) string {
+	return strconv.FormatInt(id, 10)
+}
+
+// ContentKey возвращает ключ содержимого с хэшем digest.
+func ContentKey(digest []byte) string {
+	return hex.EncodeToString(digest)
+}
+
+// userContentID возвращает идентификатор содержимого с ключом дедупликации
+// contentKey. В него входят логин пользователя, чтобы записи разных
+// пользователей не делили содержимое, и способ сжатия, от которого зависит
+// сохраненное содержимое.
+func userContentID(user string, compression server.Compression, contentKey []byte) []byte {
+	hash := sha256.New()
+	hash.Write([]byte(user))
+	hash.Write([]byte{0, byte(compression)})
+	hash.Write(contentKey)
+	return hash.Sum(nil)
+}
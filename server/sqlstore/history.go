@@ -0,0 +1,263 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/mkolibaba/gophkeeper/server"
+	"time"
+)
+
+type HistoryService struct {
+	store    Store
+	history  server.History
+	logins   server.LoginService
+	notes    server.NoteService
+	binaries server.BinaryService
+	cards    server.CardService
+	otps     server.OTPService
+}
+
+// NewHistoryService создает сервис истории. Версии восстанавливаются через
+// сервисы данных, поэтому проходят те же проверки, что и обычные изменения,
+// и сами попадают в историю.
+func NewHistoryService(
+	store Store,
+	history server.History,
+	logins server.LoginService,
+	notes server.NoteService,
+	binaries server.BinaryService,
+	cards server.CardService,
+	otps server.OTPService,
+) *HistoryService {
+	return &HistoryService{
+		store:    store,
+		history:  history,
+		logins:   logins,
+		notes:    notes,
+		binaries: binaries,
+		cards:    cards,
+		otps:     otps,
+	}
+}
+
+func (s *HistoryService) List(ctx context.Context, kind server.DataKind, id int64) ([]server.HistoryEntry, error) {
+	rows, err := s.store.SelectHistory(ctx, server.UserFromContext(ctx), kind, id, historySince(s.history))
+	if err != nil {
+		return nil, fmt.Errorf("list: %w", err)
+	}
+
+	entries := make([]server.HistoryEntry, 0, len(rows))
+	for _, row := range rows {
+		entry, err := historyEntry(row)
+		if err != nil {
+			return nil, fmt.Errorf("list: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func (s *HistoryService) Restore(ctx context.Context, kind server.DataKind, id int64, version int64) error {
+	row, err := s.store.SelectHistoryVersion(ctx, server.UserFromContext(ctx), kind, id, version, historySince(s.history))
+	if errors.Is(err, sql.ErrNoRows) {
+		return server.ErrVersionNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("restore: %w", err)
+	}
+	entry, err := historyEntry(row)
+	if err != nil {
+		return fmt.Errorf("restore: %w", err)
+	}
+
+	switch kind {
+	case server.DataKindLogin:
+		l := entry.Login
+		err = restoreItem(ctx, s.store.SelectLoginRevision, id, *l, s.logins.Create, s.logins.Update, server.LoginDataUpdate{
+			Name:     &l.Name,
+			Login:    &l.Login,
+			Password: &l.Password,
+			Website:  &l.Website,
+			Notes:    &l.Notes,
+		})
+	case server.DataKindNote:
+		n := entry.Note
+		err = restoreItem(ctx, s.store.SelectNoteRevision, id, *n, s.notes.Create, s.notes.Update, server.NoteDataUpdate{
+			Name: &n.Name,
+			Text: &n.Text,
+		})
+	case server.DataKindBinary:
+		// Содержимое удаленных бинарных данных не хранится, восстановить
+		// можно только описание существующих.
+		b := entry.Binary
+		create := func(context.Context, server.BinaryData) error {
+			return server.ErrContentRemoved
+		}
+		err = restoreItem(ctx, s.store.SelectBinaryRevision, id, *b, create, s.binaries.Update, server.BinaryDataUpdate{
+			Name:  &b.Name,
+			Notes: &b.Notes,
+		})
+	case server.DataKindCard:
+		c := entry.Card
+		err = restoreItem(ctx, s.store.SelectCardRevision, id, *c, s.cards.Create, s.cards.Update, server.CardDataUpdate{
+			Name:       &c.Name,
+			Number:     &c.Number,
+			ExpDate:    &c.ExpDate,
+			CVV:        &c.CVV,
+			Cardholder: &c.Cardholder,
+			Notes:      &c.Notes,
+		})
+	case server.DataKindOTP:
+		o := entry.OTP
+		err = restoreItem(ctx, s.store.SelectOTPRevision, id, *o, s.otps.Create, s.otps.Update, server.OTPDataUpdate{
+			Name:      &o.Name,
+			Type:      &o.Type,
+			Secret:    &o.Secret,
+			Algorithm: &o.Algorithm,
+			Digits:    &o.Digits,
+			Period:    &o.Period,
+			Counter:   &o.Counter,
+			Issuer:    &o.Issuer,
+		})
+	}
+	if err != nil {
+		return fmt.Errorf("restore: %w", err)
+	}
+	return nil
+}
+
+// restoreItem восстанавливает версию записи id: обновляет запись до данных
+// update, если она существует, или создает ее заново из data.
+func restoreItem[D any, U any](
+	ctx context.Context,
+	selectRevision func(ctx context.Context, id int64, user string) (int64, error),
+	id int64,
+	data D,
+	create func(context.Context, D) error,
+	update func(context.Context, int64, int64, U) error,
+	dataUpdate U,
+) error {
+	revision, err := selectRevision(ctx, id, server.UserFromContext(ctx))
+	if errors.Is(err, sql.ErrNoRows) {
+		return create(ctx, data)
+	}
+	if err != nil {
+		return err
+	}
+	return update(ctx, id, revision, dataUpdate)
+}
+
+// historyEntry возвращает версию записи из строки истории.
+func historyEntry(row History) (server.HistoryEntry, error) {
+	entry := server.HistoryEntry{
+		Kind:      row.Kind,
+		ID:        row.ItemID,
+		Version:   row.Version,
+		Removed:   row.Removed,
+		CreatedAt: time.Unix(row.CreatedAt, 0),
+	}
+
+	var data any
+	switch entry.Kind {
+	case server.DataKindLogin:
+		entry.Login = new(server.LoginData)
+		data = entry.Login
+	case server.DataKindNote:
+		entry.Note = new(server.NoteData)
+		data = entry.Note
+	case server.DataKindBinary:
+		entry.Binary = new(server.BinaryData)
+		data = entry.Binary
+	case server.DataKindCard:
+		entry.Card = new(server.CardData)
+		data = entry.Card
+	case server.DataKindOTP:
+		entry.OTP = new(server.OTPData)
+		data = entry.OTP
+	default:
+		return entry, fmt.Errorf("unknown data kind %q", row.Kind)
+	}
+
+	if err := json.Unmarshal([]byte(row.Data), data); err != nil {
+		return entry, fmt.Errorf("version %d: %w", row.Version, err)
+	}
+	return entry, nil
+}
+
+// ChangeWithHistory выполняет изменение change записи id в транзакции
+// и сохраняет в истории версию previous, которую изменение заменяет.
+// Версия previous должна совпадать с version: изменение проверяет ее,
+// поэтому в историю попадают именно замененные данные.
+func ChangeWithHistory(
+	ctx context.Context,
+	store Store,
+	history server.History,
+	kind server.DataKind,
+	id int64,
+	version int64,
+	previous any,
+	removed bool,
+	change func(q Queries) error,
+) error {
+	return store.InTx(ctx, func(q Queries) error {
+		if err := change(q); err != nil {
+			return err
+		}
+		return RecordHistory(ctx, q, history, kind, id, version, previous, removed)
+	})
+}
+
+// RecordHistory сохраняет версию data записи id и удаляет версии сверх
+// ограничений хранения history.
+func RecordHistory(
+	ctx context.Context,
+	q HistoryQueries,
+	history server.History,
+	kind server.DataKind,
+	id int64,
+	version int64,
+	data any,
+	removed bool,
+) error {
+	buf, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("history: %w", err)
+	}
+	row := History{
+		Kind:      kind,
+		ItemID:    id,
+		User:      server.UserFromContext(ctx),
+		Version:   version,
+		Removed:   removed,
+		Data:      string(buf),
+		CreatedAt: time.Now().Unix(),
+	}
+	if err := q.InsertHistory(ctx, row); err != nil {
+		return fmt.Errorf("history: %w", err)
+	}
+
+	if history.MaxVersions > 0 {
+		if err := q.DeleteHistoryOverLimit(ctx, row.User, kind, id, history.MaxVersions); err != nil {
+			return fmt.Errorf("history: %w", err)
+		}
+	}
+	if history.MaxAge > 0 {
+		if err := q.DeleteExpiredHistory(ctx, row.User, historySince(history)); err != nil {
+			return fmt.Errorf("history: %w", err)
+		}
+	}
+	return nil
+}
+
+// historySince возвращает время, с которого версии еще хранятся.
+// Устаревшие версии удаляются при следующем изменении, а до того
+// не показываются.
+func historySince(history server.History) int64 {
+	if history.MaxAge <= 0 {
+		return 0
+	}
+	return time.Now().Add(-history.MaxAge).Unix()
+}
@@ -0,0 +1,230 @@
+// Package sqlstore содержит логику сервисов хранилища, общую для баз
+// данных SQLite и PostgreSQL. Базы данных отличаются запросами и кодами
+// ошибок ограничений, поэтому сервисы работают с базой данных через
+// интерфейс Store, который реализует каждая из них.
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/mkolibaba/gophkeeper/server"
+	"time"
+)
+
+// Store - база данных хранилища.
+type Store interface {
+	Queries
+
+	// InTx выполняет fn в транзакции. Если fn возвращает ошибку,
+	// изменения откатываются.
+	InTx(ctx context.Context, fn func(q Queries) error) error
+
+	// LockContent захватывает ключи содержимого бинарных данных и
+	// возвращает функцию освобождения. Под блокировкой изменяются ссылки
+	// на общее содержимое и выполняются операции с ним в хранилище.
+	LockContent(ctx context.Context, keys ...string) (func(), error)
+}
+
+// Queries - запросы к базе данных. Запросы сами шифруют и расшифровывают
+// секретные поля. Если строка не найдена, запросы одной строки возвращают
+// sql.ErrNoRows.
+type Queries interface {
+	UserQueries
+	BinaryQueries
+	ItemQueries
+	HistoryQueries
+	UploadQueries
+	UsageQueries
+}
+
+// UserQueries - запросы пользователей.
+type UserQueries interface {
+	SelectUser(ctx context.Context, login string) (server.User, error)
+	// InsertUser создает пользователя с хэшем пароля passwordHash. Если
+	// логин занят, возвращает server.ErrUserAlreadyExists.
+	InsertUser(ctx context.Context, login string, passwordHash string, vaultKey []byte) error
+	UpdateUserPassword(ctx context.Context, login string, passwordHash string, vaultKey []byte) (int64, error)
+	// EnrollUserVaultKey заменяет хэш пароля и задает ключ хранилища, если
+	// он еще не задан.
+	EnrollUserVaultKey(ctx context.Context, login string, passwordHash string, vaultKey []byte) (int64, error)
+	UpdateUserMFAPendingSecret(ctx context.Context, mfaPendingSecret *string, login string) (int64, error)
+	// EnableUserMFA делает секрет secret действующим, если второй фактор
+	// еще не включен.
+	EnableUserMFA(ctx context.Context, login string, secret string, step int64) (int64, error)
+	DisableUserMFA(ctx context.Context, login string) (int64, error)
+	// UpdateUserMFALastStep сохраняет последний принятый период TOTP, если
+	// он позже сохраненного.
+	UpdateUserMFALastStep(ctx context.Context, mfaLastStep int64, login string) (int64, error)
+	DeleteUser(ctx context.Context, login string) (int64, error)
+
+	InsertRecoveryCode(ctx context.Context, user string, codeHash []byte) error
+	DeleteRecoveryCode(ctx context.Context, user string, codeHash []byte) (int64, error)
+	DeleteRecoveryCodes(ctx context.Context, user string) error
+
+	DeleteUserLogins(ctx context.Context, user string) error
+	DeleteUserNotes(ctx context.Context, user string) error
+	DeleteUserBinaries(ctx context.Context, user string) error
+	DeleteUserCards(ctx context.Context, user string) error
+	DeleteUserOTPs(ctx context.Context, user string) error
+}
+
+// BinaryQueries - запросы бинарных данных и ссылок на их содержимое.
+type BinaryQueries interface {
+	SelectBinary(ctx context.Context, id int64, user string) (Binary, error)
+	SelectBinaries(ctx context.Context, user string) ([]server.BinaryData, error)
+	SelectTrashedBinary(ctx context.Context, id int64, user string) (Binary, error)
+	// SelectUserBinaries возвращает все записи пользователя, в том числе
+	// из корзины.
+	SelectUserBinaries(ctx context.Context, user string) ([]Binary, error)
+	// InsertBinary создает запись пользователя из контекста со ссылкой на
+	// содержимое blob с хэшем digest. Ссылка должна быть уже захвачена
+	// AcquireBlob. Если пользователя нет, возвращает server.ErrUserNotFound.
+	InsertBinary(ctx context.Context, data server.BinaryData, digest []byte, blob []byte) error
+	// UpdateBinary изменяет имя и заметки записи data.ID, если ее версия
+	// равна revision.
+	UpdateBinary(ctx context.Context, data server.BinaryData, revision int64) (int64, error)
+	// UpdateBinarySha256 сохраняет хэш содержимого записи, сохраненной до
+	// появления хэшей.
+	UpdateBinarySha256(ctx context.Context, sha256 []byte, id int64) error
+	TrashBinary(ctx context.Context, id int64, user string, revision int64, deletedAt *int64) (int64, error)
+
+	// SelectBlobSHA256 возвращает хэш общего содержимого blob. Если на него
+	// нет ссылок, возвращает sql.ErrNoRows.
+	SelectBlobSHA256(ctx context.Context, blob []byte) ([]byte, error)
+	// AcquireBlob добавляет ссылку на содержимое и возвращает их число.
+	AcquireBlob(ctx context.Context, sha256 []byte, size int64) (int64, error)
+	// ReleaseBlob освобождает ссылку на содержимое и возвращает оставшееся
+	// число ссылок.
+	ReleaseBlob(ctx context.Context, sha256 []byte) (int64, error)
+	DeleteUnusedBlob(ctx context.Context, sha256 []byte) error
+}
+
+// ItemQueries - запросы записей любого вида для корзины и истории.
+type ItemQueries interface {
+	SelectLoginRevision(ctx context.Context, id int64, user string) (int64, error)
+	SelectNoteRevision(ctx context.Context, id int64, user string) (int64, error)
+	SelectBinaryRevision(ctx context.Context, id int64, user string) (int64, error)
+	SelectCardRevision(ctx context.Context, id int64, user string) (int64, error)
+	SelectOTPRevision(ctx context.Context, id int64, user string) (int64, error)
+
+	RestoreLogin(ctx context.Context, id int64, user string) (int64, error)
+	RestoreNote(ctx context.Context, id int64, user string) (int64, error)
+	RestoreBinary(ctx context.Context, id int64, user string) (int64, error)
+	RestoreCard(ctx context.Context, id int64, user string) (int64, error)
+	RestoreOTP(ctx context.Context, id int64, user string) (int64, error)
+
+	PurgeLogin(ctx context.Context, id int64, user string) (int64, error)
+	PurgeNote(ctx context.Context, id int64, user string) (int64, error)
+	PurgeBinary(ctx context.Context, id int64, user string) (int64, error)
+	PurgeCard(ctx context.Context, id int64, user string) (int64, error)
+	PurgeOTP(ctx context.Context, id int64, user string) (int64, error)
+
+	// SelectTrashed возвращает записи вида kind из корзины пользователя.
+	SelectTrashed(ctx context.Context, kind server.DataKind, user string) ([]server.TrashEntry, error)
+	// SelectExpired возвращает записи вида kind всех пользователей,
+	// перемещенные в корзину не позже deletedAt.
+	SelectExpired(ctx context.Context, kind server.DataKind, deletedAt int64) ([]Item, error)
+}
+
+// HistoryQueries - запросы истории изменений.
+type HistoryQueries interface {
+	// SelectHistory возвращает версии записи, сохраненные не раньше since,
+	// от новых к старым.
+	SelectHistory(ctx context.Context, user string, kind server.DataKind, id int64, since int64) ([]History, error)
+	SelectHistoryVersion(ctx context.Context, user string, kind server.DataKind, id int64, version int64, since int64) (History, error)
+	InsertHistory(ctx context.Context, history History) error
+	// DeleteHistoryOverLimit оставляет keep последних версий записи.
+	DeleteHistoryOverLimit(ctx context.Context, user string, kind server.DataKind, id int64, keep int64) error
+	DeleteExpiredHistory(ctx context.Context, user string, createdAt int64) error
+	DeleteItemHistory(ctx context.Context, user string, kind server.DataKind, id int64) error
+}
+
+// UploadQueries - запросы незавершенных загрузок.
+type UploadQueries interface {
+	// SelectUpload возвращает загрузку без смещения: оно хранится в
+	// размере файла загрузки.
+	SelectUpload(ctx context.Context, id string, user string) (server.Upload, error)
+	SelectUploadIDs(ctx context.Context, user string) ([]string, error)
+	SelectExpiredUploadIDs(ctx context.Context, expiresAt int64) ([]string, error)
+	InsertUpload(ctx context.Context, user string, upload server.Upload) error
+	DeleteUpload(ctx context.Context, id string) error
+}
+
+// UsageQueries - запросы занятого пользователем места.
+type UsageQueries interface {
+	SelectUserItemCount(ctx context.Context, user string) (int64, error)
+	SelectUserContentSize(ctx context.Context, user string) (int64, error)
+	SelectUserPendingUploads(ctx context.Context, user string) (PendingUploads, error)
+}
+
+// Binary - запись бинарных данных со ссылкой на содержимое.
+type Binary struct {
+	server.BinaryData
+	// SHA256 - хэш содержимого. nil у записей, сохраненных до появления
+	// хэшей.
+	SHA256 []byte
+	// Blob - идентификатор общего содержимого, на которое ссылается запись:
+	// его хэш или идентификатор из ключа дедупликации клиента. nil у записей,
+	// содержимое которых сохранено до дедупликации под их id.
+	Blob []byte
+}
+
+// History - сохраненная версия записи.
+type History struct {
+	Kind    server.DataKind
+	ItemID  int64
+	User    string
+	Version int64
+	Removed bool
+	// Data - данные версии в JSON.
+	Data      string
+	CreatedAt int64
+}
+
+// Item - запись пользователя.
+type Item struct {
+	ID   int64
+	User string
+}
+
+// PendingUploads - незавершенные загрузки пользователя.
+type PendingUploads struct {
+	Count int64
+	// Size - размер содержимого всех загрузок.
+	Size int64
+}
+
+// CheckChanged проверяет результат изменения данных с переданным id.
+// Если ни одна строка не затронута, выясняет причину: данные не найдены
+// или их версия отличается от переданной.
+func CheckChanged(
+	ctx context.Context,
+	n int64,
+	err error,
+	selectRevision func(ctx context.Context, id int64, user string) (int64, error),
+	id int64,
+) error {
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		return nil
+	}
+
+	revision, err := selectRevision(ctx, id, server.UserFromContext(ctx))
+	if errors.Is(err, sql.ErrNoRows) {
+		return server.ErrDataNotFound
+	}
+	if err != nil {
+		return err
+	}
+	return &server.VersionConflictError{Version: revision}
+}
+
+// TrashedNow возвращает значение deleted_at для записи, перемещаемой
+// в корзину сейчас.
+func TrashedNow() *int64 {
+	now := time.Now().Unix()
+	return &now
+}
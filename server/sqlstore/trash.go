@@ -0,0 +1,155 @@
+package sqlstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/mkolibaba/gophkeeper/server"
+	"slices"
+	"time"
+)
+
+// trashKinds - виды записей в порядке очистки корзины.
+var trashKinds = []server.DataKind{
+	server.DataKindLogin,
+	server.DataKindNote,
+	server.DataKindBinary,
+	server.DataKindCard,
+	server.DataKindOTP,
+}
+
+type TrashService struct {
+	store    Store
+	binaries *BinaryService
+}
+
+// NewTrashService создает сервис корзины. Содержимое бинарных данных
+// освобождает сервис бинарных данных: оно может быть общим с другими
+// записями.
+func NewTrashService(store Store, binaries *BinaryService) *TrashService {
+	return &TrashService{
+		store:    store,
+		binaries: binaries,
+	}
+}
+
+func (s *TrashService) List(ctx context.Context) ([]server.TrashEntry, error) {
+	user := server.UserFromContext(ctx)
+
+	var entries []server.TrashEntry
+	for _, kind := range trashKinds {
+		trashed, err := s.store.SelectTrashed(ctx, kind, user)
+		if err != nil {
+			return nil, fmt.Errorf("list: %w", err)
+		}
+		entries = append(entries, trashed...)
+	}
+
+	slices.SortStableFunc(entries, func(a, b server.TrashEntry) int {
+		return b.DeletedAt.Compare(a.DeletedAt)
+	})
+	return entries, nil
+}
+
+func (s *TrashService) Restore(ctx context.Context, kind server.DataKind, id int64) error {
+	var restore func(ctx context.Context, id int64, user string) (int64, error)
+	switch kind {
+	case server.DataKindLogin:
+		restore = s.store.RestoreLogin
+	case server.DataKindNote:
+		restore = s.store.RestoreNote
+	case server.DataKindBinary:
+		restore = s.store.RestoreBinary
+	case server.DataKindCard:
+		restore = s.store.RestoreCard
+	case server.DataKindOTP:
+		restore = s.store.RestoreOTP
+	default:
+		return fmt.Errorf("restore: unknown data kind %q", kind)
+	}
+
+	n, err := restore(ctx, id, server.UserFromContext(ctx))
+	if err != nil {
+		return fmt.Errorf("restore: %w", err)
+	}
+	if n == 0 {
+		return server.ErrDataNotFound
+	}
+	return nil
+}
+
+func (s *TrashService) Purge(ctx context.Context, kind server.DataKind, id int64) error {
+	if err := s.purge(ctx, server.UserFromContext(ctx), kind, id); err != nil {
+		return fmt.Errorf("purge: %w", err)
+	}
+	return nil
+}
+
+func (s *TrashService) PurgeExpired(ctx context.Context, before time.Time) (int, error) {
+	var purged int
+	for _, kind := range trashKinds {
+		items, err := s.store.SelectExpired(ctx, kind, before.Unix())
+		if err != nil {
+			return purged, fmt.Errorf("purge expired: %w", err)
+		}
+
+		for _, item := range items {
+			err := s.purge(ctx, item.User, kind, item.ID)
+			if errors.Is(err, server.ErrDataNotFound) {
+				// Запись успели восстановить или удалить.
+				continue
+			}
+			if err != nil {
+				return purged, fmt.Errorf("purge expired: %s %d: %w", kind, item.ID, err)
+			}
+			purged++
+		}
+	}
+	return purged, nil
+}
+
+// purge окончательно удаляет запись id пользователя user из корзины.
+func (s *TrashService) purge(ctx context.Context, user string, kind server.DataKind, id int64) error {
+	var purge func(q Queries, ctx context.Context, id int64, user string) (int64, error)
+	switch kind {
+	case server.DataKindLogin:
+		purge = Queries.PurgeLogin
+	case server.DataKindNote:
+		purge = Queries.PurgeNote
+	case server.DataKindBinary:
+		return s.binaries.purge(ctx, user, id)
+	case server.DataKindCard:
+		purge = Queries.PurgeCard
+	case server.DataKindOTP:
+		purge = Queries.PurgeOTP
+	default:
+		return fmt.Errorf("unknown data kind %q", kind)
+	}
+
+	return purgeItem(ctx, s.store, user, kind, id, func(q Queries) (int64, error) {
+		return purge(q, ctx, id, user)
+	})
+}
+
+// purgeItem окончательно удаляет запись функцией purge и удаляет ее
+// историю. purge возвращает число удаленных строк: ноль значит, что
+// записи нет в корзине.
+func purgeItem(
+	ctx context.Context,
+	store Store,
+	user string,
+	kind server.DataKind,
+	id int64,
+	purge func(q Queries) (int64, error),
+) error {
+	return store.InTx(ctx, func(q Queries) error {
+		n, err := purge(q)
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return server.ErrDataNotFound
+		}
+		return q.DeleteItemHistory(ctx, user, kind, id)
+	})
+}
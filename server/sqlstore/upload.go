@@ -0,0 +1,236 @@
+package sqlstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/mkolibaba/gophkeeper/server"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// uploadTTL - сколько хранится незавершенная загрузка.
+const uploadTTL = 24 * time.Hour
+
+type UploadService struct {
+	store         Store
+	binaryService *BinaryService
+	uploadsFolder string
+	quota         server.Quota
+	now           func() time.Time
+
+	// locks не дает одновременно писать в одну загрузку, например если
+	// клиент переподключился, а сервер еще не заметил обрыв старого потока.
+	locks sync.Map
+}
+
+func NewUploadService(store Store, binaryService *BinaryService, uploadsFolder string, quota server.Quota) *UploadService {
+	return &UploadService{
+		store:         store,
+		binaryService: binaryService,
+		uploadsFolder: uploadsFolder,
+		quota:         quota,
+		now:           time.Now,
+	}
+}
+
+func (s *UploadService) Create(ctx context.Context, upload server.Upload) (*server.Upload, error) {
+	s.removeExpired(ctx)
+
+	// Незавершенные загрузки занимают место в квоте, иначе ее можно
+	// обойти, начав несколько загрузок сразу.
+	if err := CheckItemQuota(ctx, s.store, s.quota, 1); err != nil {
+		return nil, err
+	}
+	if err := CheckContentQuota(ctx, s.store, s.quota, upload.ContentSize); err != nil {
+		return nil, err
+	}
+
+	id, err := newUploadID()
+	if err != nil {
+		return nil, fmt.Errorf("create: %w", err)
+	}
+
+	upload.ID = id
+	upload.Offset = 0
+	upload.ExpiresAt = s.now().Add(uploadTTL)
+
+	if err := s.store.InsertUpload(ctx, server.UserFromContext(ctx), upload); err != nil {
+		return nil, fmt.Errorf("create: %w", err)
+	}
+
+	file, err := os.Create(s.getUploadPath(upload.ID))
+	if err != nil {
+		s.store.DeleteUpload(ctx, upload.ID)
+		return nil, fmt.Errorf("create: %w", err)
+	}
+	file.Close()
+
+	return &upload, nil
+}
+
+func (s *UploadService) Get(ctx context.Context, id string) (*server.Upload, error) {
+	upload, err := s.store.SelectUpload(ctx, id, server.UserFromContext(ctx))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, server.ErrUploadNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get: %w", err)
+	}
+
+	info, err := os.Stat(s.getUploadPath(id))
+	if err != nil {
+		return nil, fmt.Errorf("get: %w", err)
+	}
+
+	upload.Offset = info.Size()
+	return &upload, nil
+}
+
+func (s *UploadService) Write(ctx context.Context, id string, offset int64, r io.Reader) (int64, error) {
+	unlock, err := s.lock(id)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
+	upload, err := s.get(ctx, id)
+	if err != nil {
+		return 0, fmt.Errorf("write: %w", err)
+	}
+	if offset != upload.Offset {
+		return upload.Offset, &server.UploadOffsetError{Offset: upload.Offset}
+	}
+
+	file, err := os.OpenFile(s.getUploadPath(id), os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		return upload.Offset, fmt.Errorf("write: %w", err)
+	}
+	defer file.Close()
+
+	// Читаем на байт больше оставшегося, чтобы заметить лишние данные.
+	remaining := upload.ContentSize - upload.Offset
+	n, err := io.Copy(file, io.LimitReader(r, remaining+1))
+	if n > remaining {
+		// Лишний байт не должен остаться в загрузке.
+		if truncErr := file.Truncate(upload.ContentSize); truncErr != nil {
+			return upload.Offset + n, fmt.Errorf("write: %w", truncErr)
+		}
+		return upload.ContentSize, server.ErrUploadTooLarge
+	}
+	if err != nil {
+		return upload.Offset + n, fmt.Errorf("write: %w", err)
+	}
+
+	if err := file.Sync(); err != nil {
+		return upload.Offset + n, fmt.Errorf("write: %w", err)
+	}
+	return upload.Offset + n, nil
+}
+
+func (s *UploadService) Complete(ctx context.Context, id string) error {
+	unlock, err := s.lock(id)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	upload, err := s.get(ctx, id)
+	if err != nil {
+		return fmt.Errorf("complete: %w", err)
+	}
+	if upload.Offset != upload.ContentSize {
+		return server.ErrUploadIncomplete
+	}
+
+	file, err := os.Open(s.getUploadPath(id))
+	if err != nil {
+		return fmt.Errorf("complete: %w", err)
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return fmt.Errorf("complete: %w", err)
+	}
+	if !bytes.Equal(hash.Sum(nil), upload.SHA256) {
+		// Поврежденное содержимое не докачать, загрузку нужно начинать заново.
+		s.remove(ctx, id)
+		return server.ErrChecksumMismatch
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("complete: %w", err)
+	}
+
+	err = s.binaryService.Create(ctx, server.ReadableBinaryData{
+		BinaryData:  upload.BinaryData,
+		DataReader:  file,
+		ContentSize: upload.ContentSize,
+		SHA256:      upload.SHA256,
+		ContentKey:  upload.ContentKey,
+	})
+	if err != nil {
+		return fmt.Errorf("complete: %w", err)
+	}
+
+	s.remove(ctx, id)
+	return nil
+}
+
+// get - аналог Get для захваченной загрузки. Блокировка несуществующей
+// загрузки сразу удаляется.
+func (s *UploadService) get(ctx context.Context, id string) (*server.Upload, error) {
+	upload, err := s.Get(ctx, id)
+	if errors.Is(err, server.ErrUploadNotFound) {
+		s.locks.Delete(id)
+	}
+	return upload, err
+}
+
+// lock захватывает загрузку id. Если загрузка уже захвачена, возвращает
+// ErrUploadInProgress.
+func (s *UploadService) lock(id string) (func(), error) {
+	mu, _ := s.locks.LoadOrStore(id, &sync.Mutex{})
+	if !mu.(*sync.Mutex).TryLock() {
+		return nil, server.ErrUploadInProgress
+	}
+	return mu.(*sync.Mutex).Unlock, nil
+}
+
+func (s *UploadService) remove(ctx context.Context, id string) {
+	s.store.DeleteUpload(ctx, id)
+	os.Remove(s.getUploadPath(id))
+	s.locks.Delete(id)
+}
+
+// removeExpired удаляет брошенные загрузки всех пользователей.
+func (s *UploadService) removeExpired(ctx context.Context) {
+	ids, err := s.store.SelectExpiredUploadIDs(ctx, s.now().Unix())
+	if err != nil {
+		return
+	}
+	for _, id := range ids {
+		s.remove(ctx, id)
+	}
+}
+
+func (s *UploadService) getUploadPath(id string) string {
+	return filepath.Join(s.uploadsFolder, id)
+}
+
+func newUploadID() (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(id), nil
+}
@@ -0,0 +1,86 @@
+package sqlstore
+
+import (
+	"context"
+	"fmt"
+	"github.com/mkolibaba/gophkeeper/server"
+)
+
+type UsageService struct {
+	store Store
+	quota server.Quota
+}
+
+func NewUsageService(store Store, quota server.Quota) *UsageService {
+	return &UsageService{
+		store: store,
+		quota: quota,
+	}
+}
+
+func (s *UsageService) Get(ctx context.Context) (*server.Usage, error) {
+	user := server.UserFromContext(ctx)
+
+	items, err := s.store.SelectUserItemCount(ctx, user)
+	if err != nil {
+		return nil, fmt.Errorf("get: %w", err)
+	}
+	size, err := s.store.SelectUserContentSize(ctx, user)
+	if err != nil {
+		return nil, fmt.Errorf("get: %w", err)
+	}
+
+	return &server.Usage{
+		Bytes: size,
+		Items: items,
+		Quota: s.quota,
+	}, nil
+}
+
+// CheckItemQuota проверяет, что пользователь из контекста может создать
+// еще count записей. Незавершенные загрузки считаются записями.
+func CheckItemQuota(ctx context.Context, q UsageQueries, quota server.Quota, count int64) error {
+	if quota.Items == 0 {
+		return nil
+	}
+
+	user := server.UserFromContext(ctx)
+	items, err := q.SelectUserItemCount(ctx, user)
+	if err != nil {
+		return err
+	}
+	pending, err := q.SelectUserPendingUploads(ctx, user)
+	if err != nil {
+		return err
+	}
+	if items+pending.Count+count > quota.Items {
+		return server.ErrQuotaExceeded
+	}
+	return nil
+}
+
+// CheckContentQuota проверяет, что пользователь из контекста может
+// сохранить файл размером size. Место, занятое незавершенными загрузками,
+// считается занятым.
+func CheckContentQuota(ctx context.Context, q UsageQueries, quota server.Quota, size int64) error {
+	if quota.MaxFileSize > 0 && size > quota.MaxFileSize {
+		return server.ErrFileTooLarge
+	}
+	if quota.Bytes == 0 {
+		return nil
+	}
+
+	user := server.UserFromContext(ctx)
+	stored, err := q.SelectUserContentSize(ctx, user)
+	if err != nil {
+		return err
+	}
+	pending, err := q.SelectUserPendingUploads(ctx, user)
+	if err != nil {
+		return err
+	}
+	if stored+pending.Size+size > quota.Bytes {
+		return server.ErrQuotaExceeded
+	}
+	return nil
+}
@@ -0,0 +1,256 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/mkolibaba/gophkeeper/server"
+	"golang.org/x/crypto/bcrypt"
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+type UserService struct {
+	store         Store
+	blobs         server.BlobStore
+	uploadsFolder string
+}
+
+func NewUserService(store Store, blobs server.BlobStore, uploadsFolder string) *UserService {
+	return &UserService{
+		store:         store,
+		blobs:         blobs,
+		uploadsFolder: uploadsFolder,
+	}
+}
+
+func (s *UserService) Get(ctx context.Context, login string) (*server.User, error) {
+	user, err := s.store.SelectUser(ctx, login)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, server.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get: %w", err)
+	}
+	return &user, nil
+}
+
+func (s *UserService) Save(ctx context.Context, user server.User) error {
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(user.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("save: %w", err)
+	}
+
+	err = s.store.InsertUser(ctx, user.Login, string(passwordHash), user.VaultKey)
+	if errors.Is(err, server.ErrUserAlreadyExists) {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("save: %w", err)
+	}
+	return nil
+}
+
+func (s *UserService) ChangePassword(ctx context.Context, login string, password string, vaultKey []byte) error {
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("change password: %w", err)
+	}
+
+	n, err := s.store.UpdateUserPassword(ctx, login, string(passwordHash), vaultKey)
+	if err != nil {
+		return fmt.Errorf("change password: %w", err)
+	}
+	if n == 0 {
+		return server.ErrUserNotFound
+	}
+	return nil
+}
+
+func (s *UserService) EnrollVaultKey(ctx context.Context, login string, password string, vaultKey []byte) error {
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("enroll vault key: %w", err)
+	}
+
+	n, err := s.store.EnrollUserVaultKey(ctx, login, string(passwordHash), vaultKey)
+	if err != nil {
+		return fmt.Errorf("enroll vault key: %w", err)
+	}
+	if n == 0 {
+		return server.ErrVaultKeyExists
+	}
+	return nil
+}
+
+func (s *UserService) Delete(ctx context.Context, login string) error {
+	binaries, err := s.store.SelectUserBinaries(ctx, login)
+	if err != nil {
+		return fmt.Errorf("delete: %w", err)
+	}
+
+	keys := make([]string, 0, len(binaries))
+	for _, binary := range binaries {
+		keys = append(keys, binaryContentKey(binary))
+	}
+	unlock, err := s.store.LockContent(ctx, keys...)
+	if err != nil {
+		return fmt.Errorf("delete: %w", err)
+	}
+	defer unlock()
+
+	var unused, uploads []string
+	err = s.store.InTx(ctx, func(q Queries) error {
+		// Список перечитывается под блокировкой: записи могли добавиться.
+		binaries, err := q.SelectUserBinaries(ctx, login)
+		if err != nil {
+			return err
+		}
+		if uploads, err = q.SelectUploadIDs(ctx, login); err != nil {
+			return err
+		}
+
+		// Записи удаляются явно: внешние ключи таблиц данных не каскадные.
+		// Сессии, коды восстановления, отметки об удалении и загрузки
+		// удаляются вместе с пользователем.
+		for _, deleteData := range []func(ctx context.Context, user string) error{
+			q.DeleteUserLogins,
+			q.DeleteUserNotes,
+			q.DeleteUserBinaries,
+			q.DeleteUserCards,
+			q.DeleteUserOTPs,
+		} {
+			if err := deleteData(ctx, login); err != nil {
+				return err
+			}
+		}
+
+		// Ссылки на содержимое освобождаются после удаления записей: записи
+		// ссылаются на содержимое внешним ключом.
+		for _, binary := range binaries {
+			ok, err := releaseContent(ctx, q, binary)
+			if err != nil {
+				return err
+			}
+			// Содержимое без блокировки не удаляем: его может переиспользовать
+			// одновременно создаваемая запись.
+			if key := binaryContentKey(binary); ok && slices.Contains(keys, key) {
+				unused = append(unused, key)
+			}
+		}
+
+		n, err := q.DeleteUser(ctx, login)
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return server.ErrUserNotFound
+		}
+		return nil
+	})
+	if errors.Is(err, server.ErrUserNotFound) {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("delete: %w", err)
+	}
+
+	// Содержимое удаляется после фиксации транзакции: если она не удалась,
+	// оставшиеся записи не должны лишиться файлов. Ошибки удаления
+	// оставляют только недоступное никому содержимое.
+	for _, key := range unused {
+		s.blobs.Delete(ctx, key)
+	}
+	// Незавершенные загрузки удаляются вместе с пользователем, остаются
+	// только их файлы.
+	for _, id := range uploads {
+		os.Remove(filepath.Join(s.uploadsFolder, id))
+	}
+	return nil
+}
+
+func (s *UserService) SetMFAPendingSecret(ctx context.Context, login string, secret string) error {
+	n, err := s.store.UpdateUserMFAPendingSecret(ctx, &secret, login)
+	if err != nil {
+		return fmt.Errorf("set mfa pending secret: %w", err)
+	}
+	if n == 0 {
+		return server.ErrUserNotFound
+	}
+	return nil
+}
+
+func (s *UserService) EnableMFA(ctx context.Context, login string, secret string, step int64, recoveryCodes [][]byte) error {
+	err := s.store.InTx(ctx, func(q Queries) error {
+		n, err := q.EnableUserMFA(ctx, login, secret, step)
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return server.ErrMFAAlreadyEnabled
+		}
+
+		if err := q.DeleteRecoveryCodes(ctx, login); err != nil {
+			return err
+		}
+		for _, code := range recoveryCodes {
+			if err := q.InsertRecoveryCode(ctx, login, code); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if errors.Is(err, server.ErrMFAAlreadyEnabled) {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("enable mfa: %w", err)
+	}
+	return nil
+}
+
+func (s *UserService) DisableMFA(ctx context.Context, login string) error {
+	err := s.store.InTx(ctx, func(q Queries) error {
+		n, err := q.DisableUserMFA(ctx, login)
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return server.ErrUserNotFound
+		}
+		return q.DeleteRecoveryCodes(ctx, login)
+	})
+	if errors.Is(err, server.ErrUserNotFound) {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("disable mfa: %w", err)
+	}
+	return nil
+}
+
+func (s *UserService) UseMFAStep(ctx context.Context, login string, step int64) error {
+	// Период сохраняется условным обновлением, поэтому из одновременных
+	// проверок одного кода успешна только одна.
+	n, err := s.store.UpdateUserMFALastStep(ctx, step, login)
+	if err != nil {
+		return fmt.Errorf("use mfa step: %w", err)
+	}
+	if n == 0 {
+		return server.ErrInvalidMFACode
+	}
+	return nil
+}
+
+func (s *UserService) UseRecoveryCode(ctx context.Context, login string, code []byte) error {
+	n, err := s.store.DeleteRecoveryCode(ctx, login, code)
+	if err != nil {
+		return fmt.Errorf("use recovery code: %w", err)
+	}
+	if n == 0 {
+		return server.ErrRecoveryCodeNotFound
+	}
+	return nil
+}
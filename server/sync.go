@@ -0,0 +1,44 @@
+package server
+
+import "context"
+
+// DataKind - тип хранимых данных.
+type DataKind string
+
+const (
+	DataKindLogin  DataKind = "login"
+	DataKindNote   DataKind = "note"
+	DataKindBinary DataKind = "binary"
+	DataKindCard   DataKind = "card"
+	DataKindOTP    DataKind = "otp"
+)
+
+// Tombstone - отметка об удалении данных.
+type Tombstone struct {
+	Kind DataKind
+	ID   int64
+}
+
+// Changes - изменения данных пользователя после некоторой ревизии.
+type Changes struct {
+	// Revision - текущая ревизия данных пользователя.
+	Revision   int64
+	Logins     []LoginData
+	Notes      []NoteData
+	Binaries   []BinaryData
+	Cards      []CardData
+	OTPs       []OTPData
+	Tombstones []Tombstone
+}
+
+// SyncService - сервис синхронизации данных между клиентами. Каждое изменение
+// данных пользователя увеличивает его ревизию, что позволяет клиентам
+// запрашивать только изменения после известной им ревизии.
+type SyncService interface {
+	// Changes возвращает данные текущего пользователя, измененные или удаленные
+	// после ревизии since.
+	Changes(ctx context.Context, since int64) (*Changes, error)
+
+	// Revision возвращает текущую ревизию данных пользователя.
+	Revision(ctx context.Context) (int64, error)
+}
@@ -0,0 +1,194 @@
+package totp
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/mkolibaba/gophkeeper/server"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	issuer = "GophKeeper"
+
+	// Параметры TOTP по умолчанию из RFC 6238, их поддерживают
+	// все приложения-аутентификаторы.
+	digits = 6
+	period = 30 * time.Second
+	// skew - сколько соседних периодов принимается, чтобы код не
+	// отклонялся из-за расхождения часов.
+	skew = 1
+
+	secretSize        = 20
+	recoveryCodeCount = 10
+)
+
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+type MFAService struct {
+	userService server.UserService
+	now         func() time.Time
+}
+
+func NewMFAService(userService server.UserService) *MFAService {
+	return &MFAService{
+		userService: userService,
+		now:         time.Now,
+	}
+}
+
+func (s *MFAService) Setup(ctx context.Context, login string) (server.MFASetup, error) {
+	user, err := s.userService.Get(ctx, login)
+	if err != nil {
+		return server.MFASetup{}, err
+	}
+	// Новый секрет заменил бы действующий без проверки кода.
+	if user.MFAEnabled {
+		return server.MFASetup{}, server.ErrMFAAlreadyEnabled
+	}
+
+	key := make([]byte, secretSize)
+	if _, err := rand.Read(key); err != nil {
+		return server.MFASetup{}, fmt.Errorf("setup: %w", err)
+	}
+	secret := encoding.EncodeToString(key)
+
+	if err := s.userService.SetMFAPendingSecret(ctx, login, secret); err != nil {
+		return server.MFASetup{}, err
+	}
+
+	uri := url.URL{
+		Scheme: "otpauth",
+		Host:   "totp",
+		Path:   "/" + issuer + ":" + login,
+		RawQuery: url.Values{
+			"secret": {secret},
+			"issuer": {issuer},
+		}.Encode(),
+	}
+
+	return server.MFASetup{Secret: secret, URI: uri.String()}, nil
+}
+
+func (s *MFAService) Enable(ctx context.Context, login string, code string) ([]string, error) {
+	user, err := s.userService.Get(ctx, login)
+	if err != nil {
+		return nil, err
+	}
+	if user.MFAEnabled {
+		return nil, server.ErrMFAAlreadyEnabled
+	}
+	if user.MFAPendingSecret == "" {
+		return nil, server.ErrMFANotSetUp
+	}
+	step, ok := s.validate(user.MFAPendingSecret, code, 0)
+	if !ok {
+		return nil, server.ErrInvalidMFACode
+	}
+
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([][]byte, 0, recoveryCodeCount)
+	for range recoveryCodeCount {
+		code, err := newRecoveryCode()
+		if err != nil {
+			return nil, err
+		}
+		codes = append(codes, code)
+		hashes = append(hashes, hashRecoveryCode(code))
+	}
+
+	if err := s.userService.EnableMFA(ctx, login, user.MFAPendingSecret, step, hashes); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+func (s *MFAService) Disable(ctx context.Context, login string, code string) error {
+	if err := s.Verify(ctx, login, code); err != nil {
+		return err
+	}
+	return s.userService.DisableMFA(ctx, login)
+}
+
+func (s *MFAService) Verify(ctx context.Context, login string, code string) error {
+	user, err := s.userService.Get(ctx, login)
+	if err != nil {
+		return err
+	}
+	if !user.MFAEnabled {
+		return server.ErrMFANotSetUp
+	}
+	if step, ok := s.validate(user.MFASecret, code, user.MFALastStep); ok {
+		return s.userService.UseMFAStep(ctx, login, step)
+	}
+
+	err = s.userService.UseRecoveryCode(ctx, login, hashRecoveryCode(code))
+	if errors.Is(err, server.ErrRecoveryCodeNotFound) {
+		return server.ErrInvalidMFACode
+	}
+	return err
+}
+
+// validate проверяет код TOTP в текущем и соседних периодах позже after
+// и возвращает период, которому код соответствует.
+func (s *MFAService) validate(secret string, code string, after int64) (int64, bool) {
+	key, err := encoding.DecodeString(secret)
+	if err != nil || len(code) != digits {
+		return 0, false
+	}
+
+	counter := s.now().Unix() / int64(period.Seconds())
+	for step := counter - skew; step <= counter+skew; step++ {
+		if step <= after {
+			continue
+		}
+		expected := generate(key, uint64(step))
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+// generate вычисляет код HOTP (RFC 4226) для счетчика counter.
+func generate(key []byte, counter uint64) string {
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], counter)
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	return fmt.Sprintf("%0*d", digits, value%1_000_000)
+}
+
+// newRecoveryCode возвращает код восстановления вида xxxxx-xxxxx.
+func newRecoveryCode() (string, error) {
+	b := make([]byte, 5)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("recovery code: %w", err)
+	}
+	code := hex.EncodeToString(b)
+	return code[:5] + "-" + code[5:], nil
+}
+
+// hashRecoveryCode возвращает хэш кода восстановления. Регистр и дефисы
+// не учитываются, чтобы код можно было ввести в любом виде.
+func hashRecoveryCode(code string) []byte {
+	code = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+	hash := sha256.Sum256([]byte(code))
+	return hash[:]
+}
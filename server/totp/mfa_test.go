@@ -0,0 +1,147 @@
+package totp
+
+import (
+	"bytes"
+	"context"
+	"github.com/mkolibaba/gophkeeper/server"
+	"github.com/mkolibaba/gophkeeper/server/mock"
+	"github.com/stretchr/testify/require"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGenerate(t *testing.T) {
+	// Тестовые векторы RFC 6238 для SHA1, последние 6 цифр.
+	key := []byte("12345678901234567890")
+	cases := map[int64]string{
+		59:         "287082",
+		1111111109: "081804",
+		1234567890: "005924",
+		2000000000: "279037",
+	}
+	for unix, code := range cases {
+		require.Equal(t, code, generate(key, uint64(unix/30)))
+	}
+}
+
+func TestMFA(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	user := &server.User{Login: "alice"}
+	var recoveryCodes [][]byte
+
+	userService := &mock.UserServiceMock{
+		GetFunc: func(ctx context.Context, login string) (*server.User, error) {
+			u := *user
+			return &u, nil
+		},
+		SetMFAPendingSecretFunc: func(ctx context.Context, login string, secret string) error {
+			user.MFAPendingSecret = secret
+			return nil
+		},
+		EnableMFAFunc: func(ctx context.Context, login string, secret string, step int64, codes [][]byte) error {
+			user.MFASecret = secret
+			user.MFAPendingSecret = ""
+			user.MFAEnabled = true
+			user.MFALastStep = step
+			recoveryCodes = codes
+			return nil
+		},
+		DisableMFAFunc: func(ctx context.Context, login string) error {
+			user.MFASecret = ""
+			user.MFAPendingSecret = ""
+			user.MFAEnabled = false
+			user.MFALastStep = 0
+			recoveryCodes = nil
+			return nil
+		},
+		UseMFAStepFunc: func(ctx context.Context, login string, step int64) error {
+			if step <= user.MFALastStep {
+				return server.ErrInvalidMFACode
+			}
+			user.MFALastStep = step
+			return nil
+		},
+		UseRecoveryCodeFunc: func(ctx context.Context, login string, code []byte) error {
+			i := slices.IndexFunc(recoveryCodes, func(c []byte) bool { return bytes.Equal(c, code) })
+			if i < 0 {
+				return server.ErrRecoveryCodeNotFound
+			}
+			recoveryCodes = slices.Delete(recoveryCodes, i, i+1)
+			return nil
+		},
+	}
+
+	srv := NewMFAService(userService)
+	srv.now = func() time.Time { return now }
+
+	// code возвращает код ожидающего подтверждения секрета, а после
+	// включения - действующего.
+	code := func(at time.Time) string {
+		secret := user.MFAPendingSecret
+		if secret == "" {
+			secret = user.MFASecret
+		}
+		key, err := encoding.DecodeString(secret)
+		require.NoError(t, err)
+		return generate(key, uint64(at.Unix()/30))
+	}
+
+	_, err := srv.Enable(t.Context(), "alice", "123456")
+	require.ErrorIs(t, err, server.ErrMFANotSetUp)
+
+	setup, err := srv.Setup(t.Context(), "alice")
+	require.NoError(t, err)
+	require.Equal(t, user.MFAPendingSecret, setup.Secret)
+	require.True(t, strings.HasPrefix(setup.URI, "otpauth://totp/GophKeeper:alice?"))
+	require.Contains(t, setup.URI, "secret="+setup.Secret)
+
+	// До подтверждения второй фактор не действует.
+	err = srv.Verify(t.Context(), "alice", code(now))
+	require.ErrorIs(t, err, server.ErrMFANotSetUp)
+
+	_, err = srv.Enable(t.Context(), "alice", "000000")
+	require.ErrorIs(t, err, server.ErrInvalidMFACode)
+
+	codes, err := srv.Enable(t.Context(), "alice", code(now))
+	require.NoError(t, err)
+	require.Len(t, codes, recoveryCodeCount)
+	require.True(t, user.MFAEnabled)
+
+	// Включенный второй фактор нельзя перенастроить без выключения.
+	secret := user.MFASecret
+	_, err = srv.Setup(t.Context(), "alice")
+	require.ErrorIs(t, err, server.ErrMFAAlreadyEnabled)
+	require.Equal(t, secret, user.MFASecret)
+
+	t.Run("totp", func(t *testing.T) {
+		// Код, подтвердивший включение, повторно не принимается.
+		err := srv.Verify(t.Context(), "alice", code(now))
+		require.ErrorIs(t, err, server.ErrInvalidMFACode)
+
+		now = now.Add(time.Minute)
+		require.NoError(t, srv.Verify(t.Context(), "alice", code(now.Add(-30*time.Second))))
+		require.NoError(t, srv.Verify(t.Context(), "alice", code(now)))
+
+		err = srv.Verify(t.Context(), "alice", code(now))
+		require.ErrorIs(t, err, server.ErrInvalidMFACode)
+		err = srv.Verify(t.Context(), "alice", code(now.Add(-30*time.Second)))
+		require.ErrorIs(t, err, server.ErrInvalidMFACode)
+		err = srv.Verify(t.Context(), "alice", code(now.Add(-2*time.Minute)))
+		require.ErrorIs(t, err, server.ErrInvalidMFACode)
+	})
+	t.Run("recovery_code", func(t *testing.T) {
+		require.NoError(t, srv.Verify(t.Context(), "alice", strings.ToUpper(codes[0])))
+
+		err := srv.Verify(t.Context(), "alice", codes[0])
+		require.ErrorIs(t, err, server.ErrInvalidMFACode)
+	})
+	t.Run("disable", func(t *testing.T) {
+		err := srv.Disable(t.Context(), "alice", "000000")
+		require.ErrorIs(t, err, server.ErrInvalidMFACode)
+
+		require.NoError(t, srv.Disable(t.Context(), "alice", codes[1]))
+		require.False(t, user.MFAEnabled)
+	})
+}
@@ -0,0 +1,50 @@
+package server
+
+import (
+	"context"
+	"time"
+)
+
+// Trash - настройки корзины.
+type Trash struct {
+	// Retention - срок хранения записей в корзине. Нулевой срок выключает
+	// фоновую очистку: записи хранятся, пока их не удалит пользователь.
+	Retention time.Duration
+	// PurgeInterval - период фоновой очистки корзины.
+	PurgeInterval time.Duration `mapstructure:"purge_interval"`
+}
+
+// TrashEntry - запись в корзине. Заполнено поле данных, которое
+// соответствует Kind.
+type TrashEntry struct {
+	Kind DataKind
+	ID   int64
+	// DeletedAt - время перемещения записи в корзину.
+	DeletedAt time.Time
+
+	Login  *LoginData
+	Note   *NoteData
+	Binary *BinaryData
+	Card   *CardData
+	OTP    *OTPData
+}
+
+// TrashService - сервис корзины. Remove сервисов данных перемещает запись
+// в корзину: для клиентов запись удалена, но ее можно восстановить, пока
+// она не удалена окончательно. Записи в корзине учитываются в квоте.
+// Работать с корзиной может только владелец записей.
+type TrashService interface {
+	// List возвращает записи в корзине, начиная с удаленных последними.
+	List(ctx context.Context) ([]TrashEntry, error)
+
+	// Restore возвращает запись из корзины с прежним id. Запись получает
+	// новую версию.
+	Restore(ctx context.Context, kind DataKind, id int64) error
+
+	// Purge окончательно удаляет запись из корзины вместе с ее историей.
+	Purge(ctx context.Context, kind DataKind, id int64) error
+
+	// PurgeExpired окончательно удаляет записи всех пользователей,
+	// перемещенные в корзину не позже before, и возвращает их число.
+	PurgeExpired(ctx context.Context, before time.Time) (int, error)
+}
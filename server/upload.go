@@ -0,0 +1,66 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+var (
+	ErrUploadNotFound   = errors.New("upload not found")
+	ErrUploadInProgress = errors.New("upload is in progress")
+	ErrUploadIncomplete = errors.New("upload is incomplete")
+	ErrUploadTooLarge   = errors.New("upload exceeds declared size")
+	ErrChecksumMismatch = errors.New("checksum mismatch")
+)
+
+// UploadOffsetError возвращается, если данные пришли не с того смещения,
+// на котором остановилась загрузка.
+type UploadOffsetError struct {
+	// Offset - сохраненное смещение загрузки.
+	Offset int64
+}
+
+func (e *UploadOffsetError) Error() string {
+	return fmt.Sprintf("unexpected offset, upload is committed up to %d", e.Offset)
+}
+
+// Upload - незавершенная загрузка бинарных данных. Содержимое сохраняется
+// по частям, а запись BinaryData создается только после проверки всего
+// содержимого.
+type Upload struct {
+	ID string
+	BinaryData
+	// ContentSize - объявленный размер содержимого в байтах.
+	ContentSize int64
+	// SHA256 - объявленный хэш содержимого.
+	SHA256 []byte
+	// ContentKey - ключ дедупликации исходного файла от клиента. nil, если
+	// клиент его не передал.
+	ContentKey []byte
+	// Offset - число байт содержимого, уже сохраненных на сервере.
+	Offset    int64
+	ExpiresAt time.Time
+}
+
+// UploadService - сервис загрузки бинарных данных по частям. Работать
+// с загрузкой может только ее владелец.
+type UploadService interface {
+	// Create начинает загрузку для текущего пользователя и возвращает ее
+	// с новым идентификатором.
+	Create(ctx context.Context, upload Upload) (*Upload, error)
+
+	// Get возвращает загрузку с сохраненным смещением.
+	Get(ctx context.Context, id string) (*Upload, error)
+
+	// Write дописывает содержимое r, начиная с offset, и возвращает новое
+	// смещение, в том числе при ошибке чтения r. Если offset не совпадает
+	// с сохраненным, возвращается *UploadOffsetError.
+	Write(ctx context.Context, id string, offset int64, r io.Reader) (int64, error)
+
+	// Complete проверяет размер и SHA-256 содержимого и сохраняет его
+	// как бинарные данные. Завершенная или поврежденная загрузка удаляется.
+	Complete(ctx context.Context, id string) error
+}
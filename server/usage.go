@@ -0,0 +1,36 @@
+package server
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	ErrQuotaExceeded = errors.New("storage quota exceeded")
+	ErrFileTooLarge  = errors.New("file is too large")
+)
+
+// Quota - ограничения данных одного пользователя. Нулевое ограничение не
+// действует.
+type Quota struct {
+	// Bytes - суммарный размер содержимого бинарных данных.
+	Bytes int64
+	// Items - число записей всех типов.
+	Items int64
+	// MaxFileSize - размер содержимого одного файла.
+	MaxFileSize int64 `mapstructure:"max_file_size"`
+}
+
+// Usage - данные, которые занимает пользователь.
+type Usage struct {
+	// Bytes - размер сохраненного содержимого бинарных данных. Одинаковое
+	// содержимое разных записей учитывается для каждой записи.
+	Bytes int64
+	Items int64
+	Quota Quota
+}
+
+type UsageService interface {
+	// Get возвращает данные, которые занимает пользователь из контекста.
+	Get(ctx context.Context) (*Usage, error)
+}
@@ -7,9 +7,55 @@ import (
 type User struct {
 	Login    string
 	Password string
+	// VaultKey - ключ хранилища пользователя, зашифрованный на клиенте
+	// мастер-паролем. Сервер хранит его как есть и не может расшифровать.
+	VaultKey []byte
+	// MFASecret - секрет TOTP второго фактора. Пустой, если он не создан.
+	MFASecret string
+	// MFAEnabled - второй фактор подтвержден и проверяется при входе.
+	MFAEnabled bool
+	// MFAPendingSecret - новый секрет TOTP, ожидающий подтверждения кодом.
+	// Пустой, если настройка не начата.
+	MFAPendingSecret string
+	// MFALastStep - номер последнего принятого периода TOTP. Коды этого
+	// и более ранних периодов повторно не принимаются.
+	MFALastStep int64
 }
 
 type UserService interface {
 	Get(ctx context.Context, login string) (*User, error)
 	Save(ctx context.Context, user User) error
+
+	// ChangePassword заменяет мастер-пароль пользователя и ключ хранилища,
+	// зашифрованный новым паролем.
+	ChangePassword(ctx context.Context, login string, password string, vaultKey []byte) error
+
+	// EnrollVaultKey задает пароль и ключ хранилища учетной записи, созданной
+	// до шифрования на клиенте. Если ключ хранилища уже задан, возвращает
+	// ErrVaultKeyExists.
+	EnrollVaultKey(ctx context.Context, login string, password string, vaultKey []byte) error
+
+	// Delete удаляет пользователя вместе со всеми его данными и сессиями.
+	Delete(ctx context.Context, login string) error
+
+	// SetMFAPendingSecret сохраняет новый секрет TOTP, ожидающий
+	// подтверждения. Действующий секрет не изменяется.
+	SetMFAPendingSecret(ctx context.Context, login string, secret string) error
+
+	// EnableMFA включает второй фактор с подтвержденным секретом secret,
+	// принятым в периоде step, и заменяет коды восстановления пользователя
+	// хэшами recoveryCodes. Если второй фактор уже включен, возвращает
+	// ErrMFAAlreadyEnabled.
+	EnableMFA(ctx context.Context, login string, secret string, step int64, recoveryCodes [][]byte) error
+
+	// DisableMFA выключает второй фактор и удаляет секрет и коды восстановления.
+	DisableMFA(ctx context.Context, login string) error
+
+	// UseMFAStep отмечает период TOTP step использованным. Если код этого
+	// периода уже принимался, возвращает ErrInvalidMFACode.
+	UseMFAStep(ctx context.Context, login string, step int64) error
+
+	// UseRecoveryCode удаляет код восстановления с хэшем code. Если такого
+	// кода нет, возвращает ErrRecoveryCodeNotFound.
+	UseRecoveryCode(ctx context.Context, login string, code []byte) error
 }
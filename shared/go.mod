@@ -4,11 +4,11 @@ go 1.25.3
 
 require (
 	github.com/fatih/color v1.18.0
+	github.com/magefile/mage v1.15.0
 	github.com/uwu-tools/magex v0.10.1
 )
 
 require (
-	github.com/magefile/mage v1.15.0 // indirect
 	github.com/mattn/go-colorable v0.1.13 // indirect
 	github.com/mattn/go-isatty v0.0.20 // indirect
 	golang.org/x/sys v0.25.0 // indirect
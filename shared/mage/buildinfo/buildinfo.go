@@ -0,0 +1,25 @@
+package buildinfo
+
+import (
+	"fmt"
+	"github.com/magefile/mage/sh"
+	"time"
+)
+
+// LDFlags возвращает флаги компоновщика, которые записывают в пакет main
+// версию, коммит и дату сборки. Версия берется из git describe.
+func LDFlags() string {
+	version := gitOutput("dev", "describe", "--tags", "--always", "--dirty")
+	commit := gitOutput("none", "rev-parse", "--short", "HEAD")
+	date := time.Now().UTC().Format(time.RFC3339)
+
+	return fmt.Sprintf("-s -w -X main.version=%s -X main.commit=%s -X main.date=%s", version, commit, date)
+}
+
+func gitOutput(fallback string, args ...string) string {
+	out, err := sh.Output("git", args...)
+	if err != nil || out == "" {
+		return fallback
+	}
+	return out
+}